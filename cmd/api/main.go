@@ -2,12 +2,15 @@ package main
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
 	"strconv"
+	"sync"
 	"syscall"
 	"time"
 
@@ -15,16 +18,28 @@ import (
 	"github.com/ventros/crm/infrastructure/cache"
 	"github.com/ventros/crm/infrastructure/channels/waha"
 	"github.com/ventros/crm/infrastructure/config"
+	infracrypto "github.com/ventros/crm/infrastructure/crypto"
 	"github.com/ventros/crm/infrastructure/database"
+	"github.com/ventros/crm/infrastructure/email"
 	"github.com/ventros/crm/infrastructure/health"
 	"github.com/ventros/crm/infrastructure/http/handlers"
 	"github.com/ventros/crm/infrastructure/http/middleware"
 	"github.com/ventros/crm/infrastructure/http/routes"
 	"github.com/ventros/crm/infrastructure/messaging"
+	"github.com/ventros/crm/infrastructure/oidc"
 	"github.com/ventros/crm/infrastructure/persistence"
+	"github.com/ventros/crm/infrastructure/storage"
+	"github.com/ventros/crm/infrastructure/stripe"
 	"github.com/ventros/crm/infrastructure/webhooks"
 	ws "github.com/ventros/crm/infrastructure/websocket"
 	"github.com/ventros/crm/infrastructure/workflow"
+	agentapp "github.com/ventros/crm/internal/application/agent"
+	apikeyapp "github.com/ventros/crm/internal/application/apikey"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	authapp "github.com/ventros/crm/internal/application/auth"
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	businesshoursapp "github.com/ventros/crm/internal/application/businesshours"
+	cannedresponseapp "github.com/ventros/crm/internal/application/cannedresponse"
 	channelapp "github.com/ventros/crm/internal/application/channel"
 	"github.com/ventros/crm/internal/application/channel/activation"
 	importpkg "github.com/ventros/crm/internal/application/channel/import"
@@ -37,10 +52,18 @@ import (
 	appconfig "github.com/ventros/crm/internal/application/config"
 	contactapp "github.com/ventros/crm/internal/application/contact"
 	contacteventapp "github.com/ventros/crm/internal/application/contact_event"
+	contactlistapp "github.com/ventros/crm/internal/application/contact_list"
 	messageapp "github.com/ventros/crm/internal/application/message"
+	noteapp "github.com/ventros/crm/internal/application/note"
 	pipelineapp "github.com/ventros/crm/internal/application/pipeline"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"github.com/ventros/crm/internal/application/queries"
+	routingapp "github.com/ventros/crm/internal/application/routing"
 	sessionapp "github.com/ventros/crm/internal/application/session"
 	"github.com/ventros/crm/internal/application/shared"
+	slaapp "github.com/ventros/crm/internal/application/sla"
+	taskapp "github.com/ventros/crm/internal/application/task"
+	teamapp "github.com/ventros/crm/internal/application/team"
 	trackingapp "github.com/ventros/crm/internal/application/tracking"
 	"github.com/ventros/crm/internal/application/user"
 	webhookapp "github.com/ventros/crm/internal/application/webhook"
@@ -49,10 +72,15 @@ import (
 	// contact_event "github.com/ventros/crm/internal/domain/contact/events" // Temporariamente comentado
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/ventros/crm/internal/domain/core/billing"
 	domainPipeline "github.com/ventros/crm/internal/domain/crm/pipeline"
+	domainstorage "github.com/ventros/crm/internal/domain/storage"
 	channelworkflow "github.com/ventros/crm/internal/workflows/channel"
+	dunningworkflow "github.com/ventros/crm/internal/workflows/dunning"
 	sagaworkflow "github.com/ventros/crm/internal/workflows/saga"
 	sessionworkflow "github.com/ventros/crm/internal/workflows/session"
+	slaworkflow "github.com/ventros/crm/internal/workflows/sla"
+	taskworkflow "github.com/ventros/crm/internal/workflows/task"
 	"go.temporal.io/sdk/worker"
 	"go.uber.org/zap"
 )
@@ -98,12 +126,33 @@ func (l *loggerAdapter) Debug(msg string, args ...interface{}) {
 	l.logger.Sugar().Debugf(msg, args...)
 }
 
-// loggingActionExecutor is a minimal MVP executor that only logs actions
+// loggingActionExecutor is a minimal MVP executor that only logs actions,
+// except for action types wired to a real executor through Handle
 type loggingActionExecutor struct {
 	logger *zap.Logger
+
+	mu        sync.RWMutex
+	executors map[domainPipeline.AutomationAction]pipelineapp.ActionExecutor
+}
+
+// Handle delegates an action type to a real executor
+func (e *loggingActionExecutor) Handle(actionType domainPipeline.AutomationAction, executor pipelineapp.ActionExecutor) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.executors == nil {
+		e.executors = make(map[domainPipeline.AutomationAction]pipelineapp.ActionExecutor)
+	}
+	e.executors[actionType] = executor
 }
 
 func (e *loggingActionExecutor) Execute(ctx context.Context, action domainPipeline.RuleAction, actionCtx pipelineapp.ActionContext) error {
+	e.mu.RLock()
+	executor, ok := e.executors[action.Type]
+	e.mu.RUnlock()
+	if ok {
+		return executor.Execute(ctx, action, actionCtx)
+	}
+
 	e.logger.Info("📋 Scheduled automation action (MVP - logging only)",
 		zap.String("action_type", string(action.Type)),
 		zap.String("rule_id", actionCtx.RuleID.String()),
@@ -275,8 +324,30 @@ func main() {
 	webhookRepo := persistence.NewWebhookRepositoryAdapter(gormDB)
 	webhookUseCase := webhookapp.NewManageSubscriptionUseCase(webhookRepo, logger)
 
-	// Initialize webhook notifier
-	webhookNotifier := webhooks.NewWebhookNotifier(logger, webhookRepo)
+	// Initialize webhook notifier: cada tentativa vai para o log de entregas (webhook_deliveries) e,
+	// após WEBHOOK_MAX_CONSECUTIVE_FAILURES falhas seguidas, a inscrição é desativada e o dono do projeto avisado por email
+	webhookDeliveryRepo := persistence.NewGormWebhookDeliveryRepository(gormDB)
+	var webhookEmail webhookapp.EmailSender
+	if smtpSender := email.NewSMTPSender(email.SMTPConfig(cfg.SMTP)); smtpSender != nil {
+		webhookEmail = smtpSender
+	}
+	webhookRecorder := webhookapp.NewDeliveryRecorder(webhookRepo, webhookDeliveryRepo, persistence.NewGormProjectOwnerDirectory(gormDB), webhookEmail, cfg.Webhook.MaxConsecutiveFailures, logger)
+	webhookNotifier := webhooks.NewWebhookNotifier(logger, webhookRepo, webhookRecorder)
+	webhookNotifier.SetLegacySignature(cfg.Webhook.LegacySignature)
+	if cfg.Webhook.LegacySignature {
+		logger.Warn("Webhooks still send the deprecated X-Webhook-Signature header; set WEBHOOK_LEGACY_SIGNATURE=false once receivers verify Ventros-Signature",
+			zap.String("sunset", webhooks.LegacySignatureSunset))
+	}
+	webhookDeliveriesUseCase := webhookapp.NewManageDeliveriesUseCase(webhookRepo, webhookDeliveryRepo, webhookNotifier, logger)
+	if temporalClient != nil {
+		// Entregas via Temporal (WebhookDeliveryWorkflow) registram as tentativas no mesmo log
+		webhookWorker := workflow.NewWebhookWorker(temporalClient, webhookRepo, webhookRecorder, logger)
+		if err := webhookWorker.Start(ctx); err != nil {
+			logger.Error("Failed to start webhook delivery worker", zap.Error(err))
+		} else {
+			defer webhookWorker.Stop()
+		}
+	}
 
 	// Initialize event bus with Outbox Pattern (SEM POLLING!)
 	eventBus := messaging.NewDomainEventBus(gormDB, outboxRepo, webhookNotifier, eventLogRepo, rabbitConn)
@@ -412,6 +483,7 @@ func main() {
 	changePipelineStatusUseCase := contactapp.NewChangePipelineStatusUseCase(contactRepo, pipelineRepo, contactEventBus, txManagerShared)
 	createSessionUseCase := sessionapp.NewCreateSessionUseCase(sessionRepo, sessionEventBus, txManagerShared)
 	closeSessionUseCase := sessionapp.NewCloseSessionUseCase(sessionRepo, sessionEventBus, txManagerShared)
+	setSessionPriorityUseCase := sessionapp.NewSetPriorityUseCase(sessionRepo, sessionEventBus, txManagerShared)
 
 	// Initialize Chat use cases (DDD: Application Service)
 	createChatUseCase := chatapp.NewCreateChatUseCase(chatRepo, chatEventBus)
@@ -557,6 +629,8 @@ func main() {
 	// Exemplo de inicialização (quando serviços estiverem prontos):
 	/*
 		enrichmentService := messageapp.NewMessageEnrichmentService(...)
+		// Limite de minutos de IA do plano (criar depois de quotaService/recordUsageUseCase)
+		enrichmentService.SetAIUsageLimits(quotaService, recordUsageUseCase)
 		aiAgentService := messageapp.NewAIAgentService(...)
 
 		messageGroupWorker := messageapp.NewMessageGroupWorker(
@@ -728,23 +802,280 @@ func main() {
 	authHandler := handlers.NewAuthHandler(logger, userService)
 	channelHandler := handlers.NewChannelHandler(logger, channelService, activateChannelHandler, importHistoryHandler, temporalClient)
 	wahaHandler := handlers.NewWAHAWebhookHandler(logger, wahaIntegration.RawEventBus, channelRepo)
-	webhookHandler := handlers.NewWebhookSubscriptionHandler(logger, webhookUseCase)
+	webhookHandler := handlers.NewWebhookSubscriptionHandler(logger, webhookUseCase, webhookDeliveriesUseCase)
 	queueHandler := handlers.NewQueueHandler(logger, rabbitConn)
-	sessionHandler := handlers.NewSessionHandler(logger, sessionRepo, closeSessionHandler)
-	contactHandler := handlers.NewContactHandler(logger, contactRepo, changePipelineStatusUseCase, createContactHandler, updateContactHandler, deleteContactHandler)
+	sessionAnalyticsRepo := persistence.NewGormSessionAnalyticsRepository(gormDB)
+	sessionAnalyticsQueryHandler := queries.NewSessionAnalyticsQueryHandler(sessionAnalyticsRepo, persistence.NewGormProjectRepository(gormDB), logger)
+	sessionHandler := handlers.NewSessionHandler(logger, sessionRepo, persistence.NewGormSessionHistoryRepository(gormDB), sessionAnalyticsQueryHandler, closeSessionHandler, setSessionPriorityUseCase)
+	contactStatsRepo := persistence.NewGormContactStatsRepository(gormDB)
+	contactHandler := handlers.NewContactHandler(logger, contactRepo, changePipelineStatusUseCase, createContactHandler, updateContactHandler, deleteContactHandler, contactStatsRepo)
 	chatHandler := handlers.NewChatHandler(logger, createChatUseCase, findChatUseCase, manageParticipantsUseCase, archiveChatUseCase, updateChatUseCase)
-	messageHandler := handlers.NewMessageHandler(logger, messageRepo, sendMessageHandler, confirmMessageDeliveryHandler)
+	messageHandler := handlers.NewMessageHandler(logger, messageRepo, persistence.NewGormMessageHistoryRepository(gormDB), sessionRepo, sendMessageHandler, confirmMessageDeliveryHandler)
 	trackingHandler := handlers.NewTrackingHandler(createTrackingUseCase, getTrackingUseCase, getContactTrackingsUseCase, logger)
-	agentHandler := handlers.NewAgentHandler(logger, agentRepo)
-	noteHandler := handlers.NewNoteHandler(logger, noteRepo)
+	agentPerformanceUseCase := agentapp.NewGetAgentPerformanceUseCase(agentRepo, persistence.NewGormAgentPerformanceRepository(gormDB))
+
+	// Contact lists: API + recálculo de listas dinâmicas (eventos list_joined / list_left)
+	contactListRepo := persistence.NewGormContactListRepository(gormDB)
+	recalculateContactListUseCase := contactlistapp.NewRecalculateContactListUseCase(contactListRepo, eventBus, txManagerShared)
+	contactListHandler := handlers.NewContactListHandler(
+		logger,
+		contactlistapp.NewCreateContactListUseCase(contactListRepo),
+		contactlistapp.NewUpdateContactListUseCase(contactListRepo),
+		contactlistapp.NewDeleteContactListUseCase(contactListRepo),
+		contactlistapp.NewGetContactListUseCase(contactListRepo),
+		contactlistapp.NewListContactListsUseCase(contactListRepo),
+		contactlistapp.NewGetContactsInListUseCase(contactListRepo),
+		contactlistapp.NewManageStaticListUseCase(contactListRepo, eventBus, txManagerShared),
+		recalculateContactListUseCase,
+		contactlistapp.NewPreviewContactListUseCase(contactListRepo),
+	)
+
+	contactListRecalculationConsumer := messaging.NewContactListRecalculationConsumer(rabbitConn, recalculateContactListUseCase, logger)
+	go func() {
+		if err := contactListRecalculationConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start contact list recalculation consumer", zap.Error(err))
+		}
+	}()
+
+	contactListSequenceTriggerConsumer := messaging.NewContactListSequenceTriggerConsumer(rabbitConn, sequenceRepo, enrollContactHandler, logger)
+	go func() {
+		if err := contactListSequenceTriggerConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start contact list sequence trigger consumer", zap.Error(err))
+		}
+	}()
+
+	contactListWorker := workflow.NewContactListRecalculationWorker(
+		contactListRepo,
+		recalculateContactListUseCase,
+		1*time.Minute,  // Poll every 1 minute
+		15*time.Minute, // Recalcula listas dinâmicas com mais de 15 minutos
+		logger,
+	)
+	go contactListWorker.Start(ctx)
+	defer contactListWorker.Stop()
+	logger.Info("✅ Contact list recalculation started (event consumers + periodic sweep)")
+
+	// Session analytics: rollup incremental (15 minutos) consumido por /crm/sessions/analytics
+	sessionAnalyticsWorker := workflow.NewSessionAnalyticsRollupWorker(sessionAnalyticsRepo, 1*time.Minute, logger)
+	go sessionAnalyticsWorker.Start(ctx)
+	defer sessionAnalyticsWorker.Stop()
+	logger.Info("✅ Session analytics rollup worker started")
+
+	// Webhook deliveries: retenção do log de entregas (WEBHOOK_DELIVERY_RETENTION_DAYS)
+	webhookRetentionWorker := workflow.NewWebhookDeliveryRetentionWorker(webhookDeliveryRepo, time.Duration(cfg.Webhook.DeliveryRetentionDays)*24*time.Hour, 1*time.Hour, logger)
+	go webhookRetentionWorker.Start(ctx)
+	defer webhookRetentionWorker.Stop()
+	logger.Info("✅ Webhook delivery retention worker started")
+
+	// Agent performance: participações em agent_sessions alimentadas por session.agent_assigned / session.ended
+	agentSessionRepo := persistence.NewGormAgentSessionRepository(gormDB)
+	trackAgentParticipationUseCase := agentapp.NewTrackAgentParticipationUseCase(agentSessionRepo, txManagerShared)
+	agentParticipationConsumer := messaging.NewAgentParticipationConsumer(rabbitConn, trackAgentParticipationUseCase, logger)
+	go func() {
+		if err := agentParticipationConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start agent participation consumer", zap.Error(err))
+		}
+	}()
+
+	// Roteamento automático: session.started atribui agente segundo a configuração do projeto;
+	// sessões sem agente elegível aguardam na fila drenada pelo worker
+	routingRepo := persistence.NewGormRoutingRepository(gormDB)
+	routingProjectRepo := persistence.NewGormProjectRepository(gormDB)
+	routeSessionUseCase := routingapp.NewRouteSessionUseCase(
+		sessionRepo,
+		contactRepo,
+		routingProjectRepo,
+		agentRepo,
+		routingRepo,
+		eventBus,
+		txManagerShared,
+	)
+	sessionRoutingConsumer := messaging.NewSessionRoutingConsumer(rabbitConn, routeSessionUseCase, logger)
+	go func() {
+		if err := sessionRoutingConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start session routing consumer", zap.Error(err))
+		}
+	}()
+	sessionRoutingWorker := workflow.NewSessionRoutingQueueWorker(routingRepo, routeSessionUseCase, 15*time.Second, logger)
+	go sessionRoutingWorker.Start(ctx)
+	defer sessionRoutingWorker.Stop()
+	logger.Info("✅ Session routing started (session.started consumer + queue worker)")
+
+	// Expediente do projeto: condição within_business_hours nas automações, fallback das políticas de SLA
+	// e resposta de ausência (message.created, fan-out out_of_office)
+	businessHoursCalendarRepo := persistence.NewGormBusinessHoursCalendarRepository(gormDB)
+	automationEngine.SetBusinessHoursChecker(businesshoursapp.NewChecker(pipelineRepo, businessHoursCalendarRepo))
+	outOfOfficeUseCase := businesshoursapp.NewOutOfOfficeUseCase(
+		messageRepo,
+		businessHoursCalendarRepo,
+		persistence.NewGormOutOfOfficeReplyRepository(gormDB),
+		wahaMessageSender,
+		txManagerShared,
+	)
+	outOfOfficeConsumer := messaging.NewOutOfOfficeConsumer(rabbitConn, outOfOfficeUseCase, logger)
+	go func() {
+		if err := outOfOfficeConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start out-of-office consumer", zap.Error(err))
+		}
+	}()
+	businessHoursHandler := handlers.NewBusinessHoursHandler(
+		logger,
+		businesshoursapp.NewManageCalendarUseCase(businessHoursCalendarRepo, routingProjectRepo),
+	)
+	logger.Info("✅ Business hours started (automation condition + out-of-office consumer)")
+
+	// SLA: session.* (fan-out session_sla) abre e para os relógios; timers Temporal disparam aviso e
+	// vencimento (sem Temporal, só a varredura periódica). Vencimento escala a sessão e dispara automações.
+	slaPolicyRepo := persistence.NewGormSLAPolicyRepository(gormDB)
+	slaClockRepo := persistence.NewGormSLAClockRepository(gormDB)
+	var slaTimers slaapp.Timers = slaworkflow.PollingTimers{}
+	if temporalClient != nil {
+		slaTimers = slaworkflow.NewTemporalTimers(temporalClient)
+	}
+	slaAutomation := pipelineapp.NewAutomationIntegration(automationEngine, sessionRepo, pipelineRepo, logAdapter)
+	slaClockService := slaapp.NewClockService(slaClockRepo, sessionRepo, eventBus, txManagerShared, slaAutomation)
+	trackSessionSLAUseCase := slaapp.NewTrackSessionUseCase(sessionRepo, contactRepo, slaPolicyRepo, slaClockRepo, businessHoursCalendarRepo, slaTimers, eventBus, txManagerShared)
+	sessionSLAConsumer := messaging.NewSessionSLAConsumer(rabbitConn, trackSessionSLAUseCase, logger)
+	go func() {
+		if err := sessionSLAConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start session SLA consumer", zap.Error(err))
+		}
+	}()
+	if temporalClient != nil {
+		slaWorker := workflow.NewSLAWorker(temporalClient, slaClockService, logger)
+		if err := slaWorker.Start(ctx); err != nil {
+			logger.Error("Failed to start SLA worker", zap.Error(err))
+		} else {
+			defer slaWorker.Stop()
+		}
+	}
+	slaClockWorker := workflow.NewSLAClockWorker(slaClockService, 1*time.Minute, logger)
+	go slaClockWorker.Start(ctx)
+	defer slaClockWorker.Stop()
+	slaHandler := handlers.NewSLAHandler(
+		logger,
+		slaapp.NewManagePoliciesUseCase(slaPolicyRepo, routingProjectRepo),
+		slaapp.NewComplianceReportUseCase(slaClockRepo, agentRepo),
+	)
+	logger.Info("✅ SLA tracking started (session_sla consumers + timers + due clock sweep)")
+
+	// Busca full-text unificada (tsvector + GIN da migration 000063)
+	searchHandler := handlers.NewSearchHandler(logger, persistence.NewGormSearchRepository(gormDB))
+
 	domainEventHandler := handlers.NewDomainEventHandler(eventLogRepo, logger)
 
+	// API keys por projeto (vtr_...): o último uso é acumulado no Redis (em memória, sem Redis)
+	// e gravado no banco em lote a cada minuto
+	apiKeyRepo := persistence.NewGormAPIKeyRepository(gormDB)
+	var apiKeyUsageBuffer apikeyapp.UsageBuffer = cache.NewMemoryAPIKeyUsageBuffer()
+	if redisClient != nil {
+		apiKeyUsageBuffer = cache.NewRedisAPIKeyUsageBuffer(redisClient)
+	}
+	apiKeyAuthenticator := apikeyapp.NewAuthenticator(apiKeyRepo, apiKeyUsageBuffer, logger)
+	apiKeyUsageFlushWorker := workflow.NewAPIKeyUsageFlushWorker(apiKeyAuthenticator, 1*time.Minute, logger)
+	go apiKeyUsageFlushWorker.Start(ctx)
+	defer apiKeyUsageFlushWorker.Stop()
+	apiKeyHandler := handlers.NewAPIKeyHandler(
+		logger,
+		apikeyapp.NewManageAPIKeysUseCase(apiKeyRepo, routingProjectRepo, eventBus, txManagerShared, logger),
+	)
+
+	// Sessões de login: access token JWT curto + refresh token rotacionado (auth_sessions)
+	jwtSecret := []byte(cfg.Auth.JWTSecret)
+	if len(jwtSecret) == 0 {
+		jwtSecret = make([]byte, 32)
+		if _, err := rand.Read(jwtSecret); err != nil {
+			log.Fatalf("Failed to generate JWT secret: %v", err)
+		}
+		logger.Warn("JWT_SECRET not set: using a random secret, access tokens will not survive restarts")
+	}
+	tokenIssuer, err := authapp.NewTokenIssuer(jwtSecret, cfg.Auth.TokenIssuer, time.Duration(cfg.Auth.AccessTokenTTLMinutes)*time.Minute)
+	if err != nil {
+		log.Fatalf("Failed to create token issuer: %v", err)
+	}
+
+	// 2FA TOTP: segredos cifrados com AES-256-GCM (TWO_FACTOR_ENCRYPTION_KEY)
+	var twoFactorEncryptor *infracrypto.AESEncryptor
+	if cfg.Auth.TwoFactorEncryptionKey != "" {
+		twoFactorEncryptor, err = infracrypto.NewAESEncryptorFromBase64(cfg.Auth.TwoFactorEncryptionKey)
+	} else {
+		derivedKey := sha256.Sum256(append([]byte("two-factor:"), jwtSecret...))
+		twoFactorEncryptor, err = infracrypto.NewAESEncryptor(derivedKey[:])
+		logger.Warn("TWO_FACTOR_ENCRYPTION_KEY not set: deriving the two-factor key from JWT_SECRET")
+	}
+	if err != nil {
+		log.Fatalf("Failed to create two-factor encryptor: %v", err)
+	}
+	twoFactorRepo := persistence.NewGormTwoFactorRepository(gormDB, twoFactorEncryptor)
+	authSessionRepo := persistence.NewGormAuthSessionRepository(gormDB)
+
+	authSessionsUseCase := authapp.NewManageSessionsUseCase(
+		authSessionRepo,
+		userService,
+		twoFactorRepo,
+		routingProjectRepo,
+		tokenIssuer,
+		txManagerShared,
+		time.Duration(cfg.Auth.RefreshTokenTTLHours)*time.Hour,
+		logger,
+	)
+
+	// Login OIDC (Google, Azure AD, Okta...): provedor que falha no discovery fica fora, sem derrubar a API
+	var identityProviders []authapp.IdentityProvider
+	for _, providerCfg := range cfg.Auth.OIDCProviders {
+		provider, err := oidc.NewProvider(ctx, oidc.Config{
+			Name:           providerCfg.Name,
+			Issuer:         providerCfg.Issuer,
+			ClientID:       providerCfg.ClientID,
+			ClientSecret:   providerCfg.ClientSecret,
+			RedirectURL:    providerCfg.RedirectURL,
+			Scopes:         providerCfg.Scopes,
+			Audience:       providerCfg.Audience,
+			AllowedDomains: providerCfg.AllowedDomains,
+			TrustEmail:     providerCfg.TrustEmail,
+		}, nil)
+		if err != nil {
+			logger.Error("OIDC provider disabled", zap.String("provider", providerCfg.Name), zap.Error(err))
+			continue
+		}
+		defer provider.Close()
+		identityProviders = append(identityProviders, provider)
+	}
+	var oidcStateStore authapp.LoginStateStore = cache.NewMemoryOIDCStateStore()
+	if redisClient != nil {
+		oidcStateStore = cache.NewRedisOIDCStateStore(redisClient)
+	}
+	oidcLoginUseCase := authapp.NewOIDCLoginUseCase(identityProviders, oidcStateStore, userService, authSessionsUseCase, cfg.Auth.OIDCAutoProvision, logger)
+	authSessionHandler := handlers.NewAuthSessionHandler(logger, authSessionsUseCase, oidcLoginUseCase)
+	// Trilha de auditoria (append-only, encadeada por hash por projeto)
+	auditLogRepo := persistence.NewGormAuditLogRepository(gormDB)
+	auditRecorder := auditapp.NewRecorder(auditLogRepo, logger)
+	auditLogHandler := handlers.NewAuditLogHandler(logger, auditapp.NewQueryAuditLogUseCase(auditLogRepo, logger))
+	twoFactorUseCase := authapp.NewTwoFactorUseCase(twoFactorRepo, authSessionRepo, userService, routingProjectRepo, txManagerShared, cfg.Auth.TwoFactorIssuer, logger)
+	twoFactorHandler := handlers.NewTwoFactorHandler(logger, twoFactorUseCase)
+	logger.Info("✅ Auth sessions ready", zap.Int("oidc_providers", len(identityProviders)))
+
 	// Create auth middleware
-	authMiddleware := middleware.NewAuthMiddleware(logger, cfg.Server.Env != "production", userService)
+	authMiddleware := middleware.NewAuthMiddleware(logger, cfg.Server.Env != "production", userService, apiKeyAuthenticator, authSessionsUseCase)
 
 	// Create RLS middleware (agora só precisa do logger)
 	rlsMiddleware := middleware.NewRLSMiddleware(logger)
 
+	// RBAC por projeto: o dono é admin implícito, os demais pelo papel (padrão ou custom) em
+	// project_members. O acesso fica em cache e cada mudança de papel invalida o projeto (em todas
+	// as réplicas via Redis Pub/Sub)
+	projectMemberRepo := persistence.NewGormProjectMemberRepository(gormDB)
+	projectRoleRepo := persistence.NewGormProjectRoleRepository(gormDB)
+	accessResolver := projectapp.NewAccessResolver(routingProjectRepo, projectMemberRepo, projectRoleRepo, projectapp.DefaultAccessCacheTTL)
+	var accessInvalidator projectapp.AccessInvalidator = accessResolver
+	if redisClient != nil {
+		redisAccessInvalidator := cache.NewRedisProjectAccessInvalidator(redisClient, accessResolver, logger)
+		redisAccessInvalidator.Listen(ctx)
+		accessInvalidator = redisAccessInvalidator
+	}
+	twoFactorUseCase.SetAccessInvalidator(accessInvalidator)
+	rbacMiddleware := middleware.NewRBACMiddleware(accessResolver, logger)
+
 	// TODO: Update handlers to use use cases instead of repositories directly
 	_ = createContactUseCase
 	_ = createSessionUseCase
@@ -754,9 +1085,35 @@ func main() {
 	// Initialize pipeline handler
 	pipelineHandler := handlers.NewPipelineHandler(logger, pipelineRepo, logrusLogger)
 
-	// Initialize project handler (placeholder - using mock for now)
-	projectRepo := persistence.NewMockProjectRepository()
-	projectHandler := handlers.NewProjectHandler(logger, projectRepo)
+	// Projetos, membros e convites por email (INVITATION_TTL_HOURS, INVITATION_ACCEPT_URL, SMTP_HOST)
+	var invitationEmail projectapp.EmailSender
+	if smtpSender := email.NewSMTPSender(email.SMTPConfig(cfg.SMTP)); smtpSender != nil {
+		invitationEmail = smtpSender
+	}
+	projectHandler := handlers.NewProjectHandler(logger, routingProjectRepo,
+		projectapp.NewManageProjectsUseCase(routingProjectRepo, projectMemberRepo, accessInvalidator, eventBus, txManagerShared, logger))
+	projectInvitationRepo := persistence.NewGormProjectInvitationRepository(gormDB)
+	projectMemberHandler := handlers.NewProjectMemberHandler(logger,
+		projectapp.NewManageMembersUseCase(routingProjectRepo, projectMemberRepo, projectRoleRepo, accessInvalidator, userService, eventBus, txManagerShared, logger),
+		projectapp.NewManageInvitationsUseCase(
+			projectInvitationRepo,
+			projectMemberRepo,
+			projectRoleRepo,
+			accessInvalidator,
+			routingProjectRepo,
+			userService,
+			invitationEmail,
+			projectapp.InvitationConfig{
+				TTL:       time.Duration(cfg.Auth.InvitationTTLHours) * time.Hour,
+				AcceptURL: cfg.Auth.InvitationAcceptURL,
+			},
+			eventBus,
+			txManagerShared,
+			logger,
+		),
+	)
+	projectRoleHandler := handlers.NewProjectRoleHandler(logger,
+		projectapp.NewManageRolesUseCase(projectRoleRepo, projectMemberRepo, projectInvitationRepo, routingProjectRepo, accessInvalidator, eventBus, txManagerShared, logger))
 
 	// Initialize TriggerRegistry for automation discovery
 	triggerRegistry := domainPipeline.NewTriggerRegistry()
@@ -791,6 +1148,239 @@ func main() {
 	// WebSocket Hub (Redis Pub/Sub para multi-server)
 	wsHub := ws.NewHub(redisClient, wsMessageHandler, logger)
 
+	// Presença dos agentes: heartbeats das conexões, status manual, ausência por inatividade e jornada
+	agentPresenceUseCase := agentapp.NewAgentPresenceUseCase(
+		agentRepo,
+		persistence.NewGormAgentPresenceRepository(gormDB),
+		eventBus,
+		ws.NewAgentPresenceNotifier(wsHub),
+	)
+	wsHub.SetPresenceTracker(agentPresenceUseCase)
+	agentHandler := handlers.NewAgentHandler(logger, agentRepo, agentPerformanceUseCase, agentPresenceUseCase)
+
+	// Times e filas: ação assign_to_queue das automações coloca a sessão na fila; filas automáticas
+	// distribuem entre os membros (worker), filas manuais esperam o agente puxar (API e websocket).
+	// session.ended / session.agent_assigned (fan-out session_queues) encerram a espera.
+	teamRepo := persistence.NewGormTeamRepository(gormDB)
+	queueRepo := persistence.NewGormQueueRepository(gormDB)
+	queueItemRepo := persistence.NewGormQueueItemRepository(gormDB)
+	queueSessionsUseCase := teamapp.NewQueueSessionsUseCase(
+		sessionRepo,
+		contactRepo,
+		routingProjectRepo,
+		agentRepo,
+		teamRepo,
+		queueRepo,
+		queueItemRepo,
+		routingRepo,
+		eventBus,
+		txManagerShared,
+		ws.NewQueueNotifier(wsHub),
+	)
+	loggingExecutor.Handle(domainPipeline.ActionAssignToQueue, pipelineapp.NewAuditingActionExecutor(
+		pipelineapp.NewDefaultActionExecutor(nil, nil, nil, queueSessionsUseCase, nil, nil, nil, nil, logAdapter),
+		auditRecorder, pipelineRepo, logAdapter))
+	wsHub.SetQueuePicker(queueSessionsUseCase)
+	sessionQueueConsumer := messaging.NewSessionQueueConsumer(rabbitConn, queueSessionsUseCase, logger)
+	go func() {
+		if err := sessionQueueConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start session queue consumer", zap.Error(err))
+		}
+	}()
+	sessionQueueWorker := workflow.NewSessionQueueDistributionWorker(queueSessionsUseCase, 15*time.Second, logger)
+	go sessionQueueWorker.Start(ctx)
+	defer sessionQueueWorker.Stop()
+	teamHandler := handlers.NewTeamHandler(
+		logger,
+		teamapp.NewManageTeamsUseCase(teamRepo, queueRepo, queueItemRepo, routingProjectRepo, agentRepo),
+		queueSessionsUseCase,
+		teamapp.NewQueueMonitorUseCase(teamRepo, queueRepo, queueItemRepo, agentRepo, routingProjectRepo, routingRepo),
+	)
+	logger.Info("✅ Teams and queues started (session_queues consumers + distribution worker)")
+
+	// Limites do plano: consumo contabilizado a partir do outbox (filas usage_metering), bloqueio
+	// com 402 nas rotas que consomem o plano e envio em lote aos Billing Meters do Stripe (STRIPE_API_KEY)
+	billingAccountRepo := persistence.NewBillingRepositoryAdapter(gormDB)
+	usageMeterRepo := persistence.NewUsageMeterRepositoryAdapter(gormDB)
+	usageRecordRepo := persistence.NewGormUsageRecordRepository(gormDB)
+	quotaService := billingapp.NewQuotaService(
+		routingProjectRepo,
+		persistence.NewSubscriptionRepositoryAdapter(gormDB),
+		persistence.NewGormBillingPlanRepository(gormDB),
+		usageMeterRepo,
+		persistence.NewGormUsageResourceCounter(gormDB),
+		billingapp.DefaultPlanCacheTTL,
+	)
+	var usageEmail billingapp.EmailSender
+	if smtpSender := email.NewSMTPSender(email.SMTPConfig(cfg.SMTP)); smtpSender != nil {
+		usageEmail = smtpSender
+	}
+	recordUsageUseCase := billingapp.NewRecordUsageUseCase(quotaService, billingAccountRepo, usageRecordRepo, usageMeterRepo, eventBus, txManagerShared, usageEmail, logger)
+	usageMeteringConsumer := messaging.NewUsageMeteringConsumer(rabbitConn, recordUsageUseCase, logger)
+	go func() {
+		if err := usageMeteringConsumer.Start(ctx); err != nil {
+			logger.Error("Failed to start usage metering consumer", zap.Error(err))
+		}
+	}()
+	quotaMiddleware := middleware.NewQuotaMiddleware(quotaService, logger)
+	if cfg.Stripe.APIKey != "" {
+		stripeService, err := stripe.NewService(cfg.Stripe.APIKey, logger)
+		if err != nil {
+			logger.Warn("Stripe unavailable, usage will not be reported", zap.Error(err))
+		} else {
+			usageReporter := billingapp.NewUsageReporter(usageRecordRepo, billingAccountRepo, stripe.NewUsageReporter(stripeService), billingapp.DefaultUsageReportBatchSize, logger)
+			usageReportWorker := workflow.NewUsageReportWorker(usageReporter, 5*time.Minute, logger)
+			go usageReportWorker.Start(ctx)
+			defer usageReportWorker.Stop()
+		}
+	}
+	logger.Info("✅ Plan limits started (usage_metering consumers + quota middleware)")
+
+	// Régua de cobrança: fatura vencida (invoice.payment_failed ou varredura) abre um caso com
+	// lembretes por email, carência, modo somente leitura (402 nas escritas) e suspensão com
+	// canais pausados; invoice.paid da última fatura em atraso reativa a conta.
+	dunningPolicy := billing.DunningPolicy{
+		ReminderDays:     cfg.Dunning.ReminderDays,
+		GracePeriodDays:  cfg.Dunning.GracePeriodDays,
+		SuspendAfterDays: cfg.Dunning.SuspendAfterDays,
+	}
+	if err := dunningPolicy.Validate(); err != nil {
+		logger.Warn("Invalid dunning policy, using default", zap.Error(err))
+		dunningPolicy = billing.DefaultDunningPolicy()
+	}
+	invoiceRepo := persistence.NewInvoiceRepositoryAdapter(gormDB)
+	dunningCaseRepo := persistence.NewGormDunningCaseRepository(gormDB)
+	billingEventRepo := persistence.NewGormBillingEventRepository(gormDB)
+	standingService := billingapp.NewStandingService(quotaService, billingAccountRepo, billingapp.DefaultStandingCacheTTL)
+	rbacMiddleware.SetStandingChecker(standingService)
+	// Mudança de situação vale em todas as réplicas via Redis Pub/Sub (sem Redis, só nesta)
+	var standingCache billingapp.StandingCache = standingService
+	if redisClient != nil {
+		redisStandingInvalidator := cache.NewRedisStandingInvalidator(redisClient, standingService, logger)
+		redisStandingInvalidator.Listen(ctx)
+		standingCache = redisStandingInvalidator
+	}
+	var dunningTimers billingapp.DunningTimers = dunningworkflow.PollingTimers{}
+	if temporalClient != nil {
+		dunningTimers = dunningworkflow.NewTemporalTimers(temporalClient)
+	}
+	dunningService := billingapp.NewDunningService(
+		billingAccountRepo,
+		invoiceRepo,
+		dunningCaseRepo,
+		billingEventRepo,
+		routingProjectRepo,
+		channelRepo,
+		standingCache,
+		dunningTimers,
+		eventBus,
+		txManagerShared,
+		usageEmail,
+		dunningPolicy,
+		logger,
+	)
+	if temporalClient != nil {
+		dunningWorker := workflow.NewDunningWorker(temporalClient, dunningService, logger)
+		if err := dunningWorker.Start(ctx); err != nil {
+			logger.Error("Failed to start dunning worker", zap.Error(err))
+		} else {
+			defer dunningWorker.Stop()
+		}
+	}
+	dunningSweepWorker := workflow.NewDunningSweepWorker(dunningService, 15*time.Minute, logger)
+	go dunningSweepWorker.Start(ctx)
+	defer dunningSweepWorker.Stop()
+	stripeWebhookHandler := handlers.NewStripeWebhookHandler(
+		logger,
+		cfg.Stripe.WebhookSecret,
+		billingAccountRepo,
+		persistence.NewSubscriptionRepositoryAdapter(gormDB),
+		invoiceRepo,
+		usageMeterRepo,
+		dunningService,
+	)
+	billingHandler := handlers.NewBillingHandler(logger,
+		billingapp.NewBillingStatusUseCase(quotaService, billingAccountRepo, invoiceRepo, dunningCaseRepo, billingEventRepo, dunningPolicy))
+	logger.Info("✅ Dunning started (Stripe webhooks + reminder timers + standing checks)")
+
+	// Notas: CRUD por contato/sessão, menções notificadas via websocket (note_mention) e,
+	// para agentes com notify_mentions_by_email, por email (SMTP_HOST). Anexos exigem GCS_BUCKET.
+	var mentionEmail noteapp.EmailSender
+	if smtpSender := email.NewSMTPSender(email.SMTPConfig(cfg.SMTP)); smtpSender != nil {
+		mentionEmail = smtpSender
+	}
+	mentionDispatcher := noteapp.NewMentionDispatcher(agentRepo, ws.NewNoteMentionNotifier(wsHub), mentionEmail, logger)
+	createNoteUseCase := noteapp.NewCreateNoteUseCase(noteRepo, eventBus, logger, txManagerShared)
+	createNoteUseCase.SetMentionDispatcher(mentionDispatcher)
+	var attachmentStorage domainstorage.Storage
+	if cfg.Storage.GCSBucket != "" {
+		gcsStorage, err := storage.NewGCSStorage(ctx, cfg.Storage.GCSBucket, cfg.Storage.GCSProjectID, logger)
+		if err != nil {
+			logger.Warn("GCS storage unavailable, attachments disabled", zap.Error(err))
+		} else {
+			attachmentStorage = gcsStorage
+		}
+	}
+	var uploadNoteAttachmentUseCase *noteapp.UploadAttachmentUseCase
+	if attachmentStorage != nil {
+		uploadNoteAttachmentUseCase = noteapp.NewUploadAttachmentUseCase(noteRepo, attachmentStorage, eventBus, logger, txManagerShared)
+	}
+	noteHandler := handlers.NewNoteHandler(
+		logger,
+		noteRepo,
+		noteapp.NewAuthorResolver(agentRepo),
+		noteapp.NewTargetResolver(contactRepo, sessionRepo),
+		createNoteUseCase,
+		noteapp.NewUpdateNoteUseCase(noteRepo, eventBus, logger, txManagerShared, mentionDispatcher),
+		noteapp.NewDeleteNoteUseCase(noteRepo, eventBus, logger, txManagerShared),
+		noteapp.NewPinNoteUseCase(noteRepo, eventBus, logger, txManagerShared),
+		uploadNoteAttachmentUseCase,
+	)
+
+	// Tarefas: timer Temporal por tarefa dispara no prazo (sem Temporal, só a varredura periódica);
+	// o vencimento registra task.overdue, lembra o responsável via websocket e dispara as automações.
+	taskRepo := persistence.NewGormTaskRepository(gormDB)
+	var taskTimers taskapp.Timers = taskworkflow.PollingTimers{}
+	if temporalClient != nil {
+		taskTimers = taskworkflow.NewTemporalTimers(temporalClient)
+	}
+	taskReminderService := taskapp.NewReminderService(
+		taskRepo,
+		agentRepo,
+		eventBus,
+		txManagerShared,
+		ws.NewTaskReminderNotifier(wsHub),
+		pipelineapp.NewAutomationIntegration(automationEngine, sessionRepo, pipelineRepo, logAdapter),
+		logger,
+	)
+	if temporalClient != nil {
+		taskWorker := workflow.NewTaskWorker(temporalClient, taskReminderService, logger)
+		if err := taskWorker.Start(ctx); err != nil {
+			logger.Error("Failed to start task reminder worker", zap.Error(err))
+		} else {
+			defer taskWorker.Stop()
+		}
+	}
+	taskReminderWorker := workflow.NewTaskReminderWorker(taskReminderService, 1*time.Minute, logger)
+	go taskReminderWorker.Start(ctx)
+	defer taskReminderWorker.Stop()
+	taskHandler := handlers.NewTaskHandler(
+		logger,
+		taskapp.NewManageTasksUseCase(taskRepo, agentRepo, contactRepo, sessionRepo, pipelineRepo, eventBus, txManagerShared, taskTimers, logger),
+	)
+	logger.Info("✅ Tasks started (reminder timers + overdue sweep)")
+
+	// Respostas prontas: biblioteca do projeto e privadas dos agentes. O compositor expande
+	// atalhos via websocket (canned_response_expand) ou POST /expand. Anexos exigem GCS_BUCKET.
+	cannedResponseRepo := persistence.NewGormCannedResponseRepository(gormDB)
+	useCannedResponseUseCase := cannedresponseapp.NewUseCannedResponseUseCase(cannedResponseRepo, agentRepo, contactRepo, sessionRepo, routingProjectRepo, logger)
+	wsHub.SetCannedResponses(useCannedResponseUseCase)
+	cannedResponseHandler := handlers.NewCannedResponseHandler(
+		logger,
+		cannedresponseapp.NewManageCannedResponsesUseCase(cannedResponseRepo, agentRepo, routingProjectRepo, eventBus, txManagerShared, attachmentStorage, logger),
+		useCannedResponseUseCase,
+	)
+
 	// Start Hub em goroutine (event loop)
 	go wsHub.Run()
 	logger.Info("✅ WebSocket Hub started (Redis Pub/Sub enabled)")
@@ -806,8 +1396,29 @@ func main() {
 	isProduction := cfg.Server.Env == "production"
 	websocketHandler := handlers.NewWebSocketMessageHandler(wsHub, isProduction, logger)
 
+	// Regras de reatribuição (inatividade / sem resposta / balanceamento) com aviso aos agentes via websocket
+	enforceReassignmentUseCase := routingapp.NewEnforceReassignmentRulesUseCase(
+		sessionRepo,
+		contactRepo,
+		routingProjectRepo,
+		agentRepo,
+		routingRepo,
+		eventBus,
+		txManagerShared,
+		ws.NewSessionReassignmentNotifier(wsHub),
+	)
+	sessionReassignmentWorker := workflow.NewSessionReassignmentWorker(enforceReassignmentUseCase, 1*time.Minute, logger)
+	go sessionReassignmentWorker.Start(ctx)
+	defer sessionReassignmentWorker.Stop()
+	logger.Info("✅ Session reassignment worker started")
+
+	agentPresenceWorker := workflow.NewAgentPresenceWorker(agentPresenceUseCase, 15*time.Second, logger)
+	go agentPresenceWorker.Start(ctx)
+	defer agentPresenceWorker.Stop()
+	logger.Info("✅ Agent presence worker started")
+
 	// WebSocket auth middleware
-	wsAuthMiddleware := middleware.NewWebSocketAuthMiddleware(authMiddleware, logger)
+	wsAuthMiddleware := middleware.NewWebSocketAuthMiddleware(authMiddleware, rbacMiddleware, logger)
 
 	// WebSocket rate limiter (max 5 connections per minute per IP)
 	wsRateLimiter := middleware.NewWebSocketRateLimiter(redisClient, logger)
@@ -821,7 +1432,7 @@ func main() {
 	router := gin.Default()
 
 	// Setup basic routes (health, queue, session, contact, webhooks, auth, automation, broadcasts, sequences, campaigns, channels, projects, pipelines, trackings, automation discovery, messages, chats, agents, notes, WebSocket)
-	routes.SetupRoutesBasicWithTest(router, logger, healthChecker, authHandler, automationHandler, broadcastHandler, sequenceHandler, campaignHandler, channelHandler, projectHandler, pipelineHandler, wahaHandler, webhookHandler, queueHandler, sessionHandler, contactHandler, trackingHandler, messageHandler, chatHandler, agentHandler, noteHandler, automationDiscoveryHandler, websocketHandler, wsRateLimiter, gormDB, authMiddleware, wsAuthMiddleware, rlsMiddleware)
+	routes.SetupRoutesBasicWithTest(router, logger, healthChecker, authHandler, apiKeyHandler, authSessionHandler, twoFactorHandler, auditLogHandler, auditRecorder, automationHandler, broadcastHandler, sequenceHandler, campaignHandler, channelHandler, projectHandler, projectMemberHandler, projectRoleHandler, pipelineHandler, wahaHandler, webhookHandler, queueHandler, sessionHandler, contactHandler, trackingHandler, messageHandler, chatHandler, agentHandler, slaHandler, businessHoursHandler, teamHandler, searchHandler, noteHandler, taskHandler, cannedResponseHandler, contactListHandler, automationDiscoveryHandler, billingHandler, stripeWebhookHandler, websocketHandler, wsRateLimiter, gormDB, authMiddleware, wsAuthMiddleware, rlsMiddleware, rbacMiddleware, quotaMiddleware)
 
 	// Start server with graceful shutdown
 	srv := &http.Server{
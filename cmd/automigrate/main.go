@@ -66,15 +66,37 @@ func main() {
 		&entities.ContactCustomFieldEntity{},
 		&entities.SessionEntity{},
 		&entities.MessageEntity{},
+		&entities.SessionAnalyticsHourlyEntity{},
+		&entities.AnalyticsRollupStateEntity{},
 		&entities.NoteEntity{},
 		&entities.AgentEntity{},
 		&entities.AgentSessionEntity{},
+		&entities.RoutingCursorEntity{},
+		&entities.SessionRoutingQueueEntity{},
+		&entities.AgentPresenceEntity{},
+		&entities.SLAPolicyEntity{},
+		&entities.SLAClockEntity{},
+		&entities.BusinessHoursCalendarEntity{},
+		&entities.OutOfOfficeReplyEntity{},
+		&entities.TeamEntity{},
+		&entities.QueueEntity{},
+		&entities.QueueItemEntity{},
+		&entities.TaskEntity{},
+		&entities.CannedResponseEntity{},
+		&entities.CannedResponseUsageEntity{},
 		&entities.AutomationEntity{},
 		&entities.WebhookSubscriptionEntity{},
+		&entities.WebhookDeliveryEntity{},
 		&entities.UserAPIKeyEntity{},
+		&entities.AuthSessionEntity{},
+		&entities.UserIdentityEntity{},
+		&entities.UserTwoFactorEntity{},
+		&entities.AuditLogEntity{},
 		&entities.CredentialEntity{},
 		&entities.ContactEventEntity{},
 		&entities.ContactListEntity{},
+		&entities.ContactListFilterRuleEntity{},
+		&entities.ContactListMemberEntity{},
 		&entities.TrackingEntity{},
 		&entities.TrackingEnrichmentEntity{},
 		&entities.OutboxEventEntity{},
@@ -96,8 +118,14 @@ func main() {
 		&entities.SubscriptionEntity{},
 		&entities.InvoiceEntity{},
 		&entities.UsageMeterEntity{},
+		&entities.BillingPlanEntity{},
+		&entities.UsageRecordEntity{},
+		&entities.DunningCaseEntity{},
+		&entities.BillingEventEntity{},
 		// Project members
 		&entities.ProjectMemberEntity{},
+		&entities.ProjectInvitationEntity{},
+		&entities.ProjectRoleEntity{},
 	); err != nil {
 		log.Fatal("❌ Failed to migrate dependent tables:", err)
 	}
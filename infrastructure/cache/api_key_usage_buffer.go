@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+)
+
+// apiKeyUsageKey hash chave→"unixnano|ip" com o último uso de cada API key
+const apiKeyUsageKey = "api_keys:last_used"
+
+// RedisAPIKeyUsageBuffer acumula o último uso das API keys no Redis, compartilhado entre as
+// réplicas da API; o worker de flush grava tudo no Postgres de tempos em tempos
+type RedisAPIKeyUsageBuffer struct {
+	client *redis.Client
+}
+
+func NewRedisAPIKeyUsageBuffer(client *redis.Client) *RedisAPIKeyUsageBuffer {
+	return &RedisAPIKeyUsageBuffer{client: client}
+}
+
+func (b *RedisAPIKeyUsageBuffer) Track(ctx context.Context, usage apikey.Usage) error {
+	return b.client.HSet(ctx, apiKeyUsageKey, usage.KeyID.String(), encodeAPIKeyUsage(usage)).Err()
+}
+
+// Drain lê e apaga o hash na mesma transação (MULTI/EXEC), sem perder usos registrados no meio
+func (b *RedisAPIKeyUsageBuffer) Drain(ctx context.Context) ([]apikey.Usage, error) {
+	var entries *redis.MapStringStringCmd
+	_, err := b.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		entries = pipe.HGetAll(ctx, apiKeyUsageKey)
+		pipe.Del(ctx, apiKeyUsageKey)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]apikey.Usage, 0, len(entries.Val()))
+	for field, value := range entries.Val() {
+		usage, err := decodeAPIKeyUsage(field, value)
+		if err != nil {
+			continue
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// MemoryAPIKeyUsageBuffer alternativa em memória quando o Redis não está disponível (uma réplica só)
+type MemoryAPIKeyUsageBuffer struct {
+	mu     sync.Mutex
+	usages map[uuid.UUID]apikey.Usage
+}
+
+func NewMemoryAPIKeyUsageBuffer() *MemoryAPIKeyUsageBuffer {
+	return &MemoryAPIKeyUsageBuffer{usages: make(map[uuid.UUID]apikey.Usage)}
+}
+
+func (b *MemoryAPIKeyUsageBuffer) Track(_ context.Context, usage apikey.Usage) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if current, ok := b.usages[usage.KeyID]; !ok || usage.At.After(current.At) {
+		b.usages[usage.KeyID] = usage
+	}
+	return nil
+}
+
+func (b *MemoryAPIKeyUsageBuffer) Drain(_ context.Context) ([]apikey.Usage, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	usages := make([]apikey.Usage, 0, len(b.usages))
+	for _, usage := range b.usages {
+		usages = append(usages, usage)
+	}
+	b.usages = make(map[uuid.UUID]apikey.Usage)
+	return usages, nil
+}
+
+func encodeAPIKeyUsage(usage apikey.Usage) string {
+	return strconv.FormatInt(usage.At.UnixNano(), 10) + "|" + usage.IP
+}
+
+func decodeAPIKeyUsage(field, value string) (apikey.Usage, error) {
+	keyID, err := uuid.Parse(field)
+	if err != nil {
+		return apikey.Usage{}, fmt.Errorf("invalid api key id %q: %w", field, err)
+	}
+	nanos, ip, ok := strings.Cut(value, "|")
+	if !ok {
+		return apikey.Usage{}, fmt.Errorf("invalid api key usage %q", value)
+	}
+	at, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return apikey.Usage{}, fmt.Errorf("invalid api key usage %q: %w", value, err)
+	}
+	return apikey.Usage{KeyID: keyID, At: time.Unix(0, at).UTC(), IP: ip}, nil
+}
@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	"go.uber.org/zap"
+)
+
+// billingStandingChannel canal com o ID da conta de cobrança cuja situação mudou
+const billingStandingChannel = "billing_standing:invalidate"
+
+// RedisStandingInvalidator invalida a situação em cache da conta nesta réplica e avisa as demais
+// pelo Pub/Sub, para o modo somente leitura (e a reativação) valer em todas na hora
+type RedisStandingInvalidator struct {
+	client *redis.Client
+	local  billingapp.StandingCache
+	logger *zap.Logger
+}
+
+func NewRedisStandingInvalidator(client *redis.Client, local billingapp.StandingCache, logger *zap.Logger) *RedisStandingInvalidator {
+	return &RedisStandingInvalidator{client: client, local: local, logger: logger}
+}
+
+func (i *RedisStandingInvalidator) Invalidate(ctx context.Context, billingAccountID uuid.UUID) {
+	i.local.Invalidate(ctx, billingAccountID)
+	if err := i.client.Publish(ctx, billingStandingChannel, billingAccountID.String()).Err(); err != nil {
+		// As outras réplicas ficam com a situação antiga até o TTL do cache
+		i.logger.Warn("Failed to publish billing standing invalidation",
+			zap.String("billing_account_id", billingAccountID.String()),
+			zap.Error(err))
+	}
+}
+
+// Listen recebe as invalidações das outras réplicas até o contexto acabar
+func (i *RedisStandingInvalidator) Listen(ctx context.Context) {
+	pubsub := i.client.Subscribe(ctx, billingStandingChannel)
+	go func() {
+		defer pubsub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-pubsub.Channel():
+				if !ok {
+					return
+				}
+				billingAccountID, err := uuid.Parse(msg.Payload)
+				if err != nil {
+					continue
+				}
+				i.local.Invalidate(ctx, billingAccountID)
+			}
+		}
+	}()
+}
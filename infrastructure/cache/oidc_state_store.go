@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/ventros/crm/internal/application/auth"
+)
+
+// oidcStateKeyPrefix chave oidc:state:<state> com o LoginState em JSON
+const oidcStateKeyPrefix = "oidc:state:"
+
+// RedisOIDCStateStore guarda o state do login OIDC no Redis, para o callback cair em qualquer réplica
+type RedisOIDCStateStore struct {
+	client *redis.Client
+}
+
+func NewRedisOIDCStateStore(client *redis.Client) *RedisOIDCStateStore {
+	return &RedisOIDCStateStore{client: client}
+}
+
+func (s *RedisOIDCStateStore) Save(ctx context.Context, state string, login auth.LoginState, ttl time.Duration) error {
+	payload, err := json.Marshal(login)
+	if err != nil {
+		return fmt.Errorf("failed to encode login state: %w", err)
+	}
+	return s.client.Set(ctx, oidcStateKeyPrefix+state, payload, ttl).Err()
+}
+
+// Consume GETDEL: o state só pode ser usado uma vez
+func (s *RedisOIDCStateStore) Consume(ctx context.Context, state string) (*auth.LoginState, error) {
+	payload, err := s.client.GetDel(ctx, oidcStateKeyPrefix+state).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, auth.ErrLoginStateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	var login auth.LoginState
+	if err := json.Unmarshal(payload, &login); err != nil {
+		return nil, fmt.Errorf("failed to decode login state: %w", err)
+	}
+	return &login, nil
+}
+
+type memoryOIDCState struct {
+	login     auth.LoginState
+	expiresAt time.Time
+}
+
+// MemoryOIDCStateStore alternativa em memória quando o Redis não está disponível (uma réplica só)
+type MemoryOIDCStateStore struct {
+	mu     sync.Mutex
+	states map[string]memoryOIDCState
+}
+
+func NewMemoryOIDCStateStore() *MemoryOIDCStateStore {
+	return &MemoryOIDCStateStore{states: make(map[string]memoryOIDCState)}
+}
+
+func (s *MemoryOIDCStateStore) Save(_ context.Context, state string, login auth.LoginState, ttl time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	// Remove os expirados aqui mesmo: logins abandonados não acumulam
+	for key, entry := range s.states {
+		if now.After(entry.expiresAt) {
+			delete(s.states, key)
+		}
+	}
+	s.states[state] = memoryOIDCState{login: login, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+func (s *MemoryOIDCStateStore) Consume(_ context.Context, state string) (*auth.LoginState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.states[state]
+	delete(s.states, state)
+	if !ok || time.Now().After(entry.expiresAt) {
+		return nil, auth.ErrLoginStateNotFound
+	}
+	return &entry.login, nil
+}
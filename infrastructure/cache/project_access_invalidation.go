@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"go.uber.org/zap"
+)
+
+// projectAccessChannel canal com o ID do projeto cujo acesso em cache mudou
+const projectAccessChannel = "project_access:invalidate"
+
+// RedisProjectAccessInvalidator invalida o acesso em cache do projeto nesta réplica e avisa as
+// demais pelo Pub/Sub, para a mudança de papel valer em todas na hora
+type RedisProjectAccessInvalidator struct {
+	client *redis.Client
+	local  projectapp.AccessInvalidator
+	logger *zap.Logger
+}
+
+func NewRedisProjectAccessInvalidator(client *redis.Client, local projectapp.AccessInvalidator, logger *zap.Logger) *RedisProjectAccessInvalidator {
+	return &RedisProjectAccessInvalidator{client: client, local: local, logger: logger}
+}
+
+func (i *RedisProjectAccessInvalidator) InvalidateProject(ctx context.Context, projectID uuid.UUID) {
+	i.local.InvalidateProject(ctx, projectID)
+	if err := i.client.Publish(ctx, projectAccessChannel, projectID.String()).Err(); err != nil {
+		// As outras réplicas ficam com o acesso antigo até o TTL do cache
+		i.logger.Warn("Failed to publish project access invalidation",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	}
+}
+
+// Listen recebe as invalidações das outras réplicas até o contexto acabar
+func (i *RedisProjectAccessInvalidator) Listen(ctx context.Context) {
+	pubsub := i.client.Subscribe(ctx, projectAccessChannel)
+	go func() {
+		defer pubsub.Close()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg, ok := <-pubsub.Channel():
+				if !ok {
+					return
+				}
+				projectID, err := uuid.Parse(msg.Payload)
+				if err != nil {
+					continue
+				}
+				i.local.InvalidateProject(ctx, projectID)
+			}
+		}
+	}()
+}
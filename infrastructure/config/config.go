@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/joho/godotenv"
 )
@@ -21,6 +22,11 @@ type Config struct {
 	WAHA                 WAHAConfig
 	AI                   AIConfig
 	Stripe               StripeConfig
+	SMTP                 SMTPConfig
+	Storage              StorageConfig
+	Webhook              WebhookConfig
+	Dunning              DunningConfig
+	Auth                 AuthConfig
 	UseSagaOrchestration bool // Feature flag: Saga Orchestration (Temporal workflows)
 }
 
@@ -129,6 +135,76 @@ type StripeConfig struct {
 	APIVersion     string // API version (default: 2025-06-30.basil)
 }
 
+// SMTPConfig holds outgoing email configuration (notificações por email)
+// Sem SMTP_HOST o envio de emails fica desabilitado.
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// StorageConfig holds file storage configuration (anexos de notas, mídia)
+// Sem GCS_BUCKET o upload de arquivos fica desabilitado.
+type StorageConfig struct {
+	GCSBucket    string
+	GCSProjectID string
+}
+
+// WebhookConfig holds outgoing webhook delivery configuration
+// MaxConsecutiveFailures = 0 desliga a desativação automática de inscrições.
+type WebhookConfig struct {
+	DeliveryRetentionDays  int
+	MaxConsecutiveFailures int
+	// LegacySignature envia também o X-Webhook-Signature (HMAC só do corpo) junto do
+	// Ventros-Signature, para receptores que ainda não migraram. Depreciado: sai em 2027-01-31.
+	LegacySignature bool
+}
+
+// DunningConfig holds the overdue invoice policy (régua de cobrança), em dias após o vencimento
+// Política inválida (ex: suspensão antes do fim da carência) cai na régua padrão.
+type DunningConfig struct {
+	ReminderDays     []int // Lembretes por email
+	GracePeriodDays  int   // Fim da carência: conta somente leitura
+	SuspendAfterDays int   // Suspensão: canais pausados e envios bloqueados
+}
+
+// AuthConfig holds native login configuration (access/refresh tokens and OIDC providers)
+// Sem JWT_SECRET um segredo aleatório é gerado a cada start: os tokens não sobrevivem a
+// um restart nem valem entre réplicas.
+type AuthConfig struct {
+	JWTSecret             string
+	TokenIssuer           string
+	AccessTokenTTLMinutes int
+	RefreshTokenTTLHours  int  // Validade absoluta da sessão (a rotação não estende)
+	OIDCAutoProvision     bool // Cria o usuário no primeiro login OIDC com email verificado
+	OIDCProviders         []OIDCProviderConfig
+	// TwoFactorIssuer nome exibido no app autenticador
+	TwoFactorIssuer string
+	// TwoFactorEncryptionKey chave AES-256 (base64, 32 bytes) dos segredos TOTP. Sem ela a chave é
+	// derivada do JWT_SECRET, que então não pode ser trocado sem perder as inscrições.
+	TwoFactorEncryptionKey string
+	// InvitationTTLHours validade dos convites de projeto enviados por email
+	InvitationTTLHours int
+	// InvitationAcceptURL página do frontend que recebe ?token= do convite (vazio = só o token no email)
+	InvitationAcceptURL string
+}
+
+// OIDCProviderConfig provedor OIDC (Google Workspace, Azure AD, Okta, Keycloak...)
+// Declarado em OIDC_PROVIDERS=google,azure e configurado por OIDC_<NOME>_*.
+type OIDCProviderConfig struct {
+	Name           string
+	Issuer         string
+	ClientID       string
+	ClientSecret   string
+	RedirectURL    string
+	Scopes         []string
+	Audience       string   // Default: ClientID
+	AllowedDomains []string // Domínios de email aceitos (vazio = todos)
+	TrustEmail     bool     // Trata o email como verificado (ex: Azure AD de um único tenant)
+}
+
 // Load loads configuration from environment variables
 // Automatically loads .env file if it exists (development)
 func Load() *Config {
@@ -211,6 +287,39 @@ func Load() *Config {
 			Environment:    getEnv("STRIPE_ENVIRONMENT", "test"),
 			APIVersion:     getEnv("STRIPE_API_VERSION", "2025-06-30.basil"),
 		},
+		SMTP: SMTPConfig{
+			Host:     getEnv("SMTP_HOST", ""),
+			Port:     getEnv("SMTP_PORT", "587"),
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+			From:     getEnv("SMTP_FROM", "no-reply@ventros.com"),
+		},
+		Storage: StorageConfig{
+			GCSBucket:    getEnv("GCS_BUCKET", ""),
+			GCSProjectID: getEnv("GCS_PROJECT_ID", ""),
+		},
+		Webhook: WebhookConfig{
+			DeliveryRetentionDays:  getEnvInt("WEBHOOK_DELIVERY_RETENTION_DAYS", 30),
+			MaxConsecutiveFailures: getEnvInt("WEBHOOK_MAX_CONSECUTIVE_FAILURES", 20),
+			LegacySignature:        getEnv("WEBHOOK_LEGACY_SIGNATURE", "true") == "true",
+		},
+		Dunning: DunningConfig{
+			ReminderDays:     getEnvIntList("DUNNING_REMINDER_DAYS", []int{1, 3, 7}),
+			GracePeriodDays:  getEnvInt("DUNNING_GRACE_PERIOD_DAYS", 7),
+			SuspendAfterDays: getEnvInt("DUNNING_SUSPEND_AFTER_DAYS", 14),
+		},
+		Auth: AuthConfig{
+			JWTSecret:              getEnv("JWT_SECRET", ""),
+			TokenIssuer:            getEnv("JWT_ISSUER", "ventros-crm"),
+			AccessTokenTTLMinutes:  getEnvInt("ACCESS_TOKEN_TTL_MINUTES", 15),
+			RefreshTokenTTLHours:   getEnvInt("REFRESH_TOKEN_TTL_HOURS", 720),
+			OIDCAutoProvision:      getEnv("OIDC_AUTO_PROVISION", "false") == "true",
+			OIDCProviders:          getOIDCProviders(),
+			TwoFactorIssuer:        getEnv("TWO_FACTOR_ISSUER", "Ventros CRM"),
+			TwoFactorEncryptionKey: getEnv("TWO_FACTOR_ENCRYPTION_KEY", ""),
+			InvitationTTLHours:     getEnvInt("INVITATION_TTL_HOURS", 168),
+			InvitationAcceptURL:    getEnv("INVITATION_ACCEPT_URL", ""),
+		},
 	}
 }
 
@@ -231,6 +340,23 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
+// getEnvIntList lê uma lista de inteiros separada por vírgula; item inválido devolve o padrão
+func getEnvIntList(key string, defaultValue []int) []int {
+	items := splitList(os.Getenv(key))
+	if len(items) == 0 {
+		return defaultValue
+	}
+	values := make([]int, 0, len(items))
+	for _, item := range items {
+		var intValue int
+		if _, err := fmt.Sscanf(item, "%d", &intValue); err != nil {
+			return defaultValue
+		}
+		values = append(values, intValue)
+	}
+	return values
+}
+
 // getRabbitMQURL constrói a URL do RabbitMQ usando variáveis de ambiente separadas
 // ou retorna RABBITMQ_URL se estiver definida (para compatibilidade)
 func getRabbitMQURL() string {
@@ -247,3 +373,38 @@ func getRabbitMQURL() string {
 
 	return fmt.Sprintf("amqp://%s:%s@%s:%s/", user, password, host, port)
 }
+
+// getOIDCProviders lê os provedores listados em OIDC_PROVIDERS; cada um usa as variáveis
+// OIDC_<NOME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET, _REDIRECT_URL, _SCOPES, _AUDIENCE,
+// _ALLOWED_DOMAINS e _TRUST_EMAIL (listas separadas por vírgula)
+func getOIDCProviders() []OIDCProviderConfig {
+	names := splitList(os.Getenv("OIDC_PROVIDERS"))
+	providers := make([]OIDCProviderConfig, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:           name,
+			Issuer:         getEnv(prefix+"ISSUER", ""),
+			ClientID:       getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret:   getEnv(prefix+"CLIENT_SECRET", ""),
+			RedirectURL:    getEnv(prefix+"REDIRECT_URL", ""),
+			Scopes:         splitList(os.Getenv(prefix + "SCOPES")),
+			Audience:       getEnv(prefix+"AUDIENCE", ""),
+			AllowedDomains: splitList(os.Getenv(prefix + "ALLOWED_DOMAINS")),
+			TrustEmail:     getEnv(prefix+"TRUST_EMAIL", "false") == "true",
+		})
+	}
+	return providers
+}
+
+// splitList separa por vírgula, ignorando itens vazios
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,7 @@
+-- ========================================
+-- Migration 000053 Rollback: Contact list filters and members
+-- ========================================
+
+DROP INDEX IF EXISTS idx_contact_lists_dynamic_last_calculated;
+DROP TABLE IF EXISTS contact_list_members;
+DROP TABLE IF EXISTS contact_list_filters;
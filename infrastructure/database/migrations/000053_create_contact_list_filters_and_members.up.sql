@@ -0,0 +1,37 @@
+-- ========================================
+-- Migration 000053: Contact list filters and materialized members
+-- ========================================
+-- contact_list_filters: regras de filtro das listas dinâmicas
+-- contact_list_members: membros de listas estáticas e snapshot das listas dinâmicas
+--   (o snapshot permite detectar quem entrou/saiu em cada recálculo → list_joined / list_left)
+-- ========================================
+
+CREATE TABLE IF NOT EXISTS contact_list_filters (
+    id uuid DEFAULT gen_random_uuid() NOT NULL PRIMARY KEY,
+    contact_list_id uuid NOT NULL REFERENCES contact_lists(id) ON DELETE CASCADE,
+    filter_type text NOT NULL,
+    operator text NOT NULL,
+    field_key text NOT NULL,
+    field_type text,
+    value text,
+    pipeline_id uuid,
+    created_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS idx_contact_list_filters_contact_list_id ON contact_list_filters(contact_list_id);
+CREATE INDEX IF NOT EXISTS idx_contact_list_filters_pipeline_id ON contact_list_filters(pipeline_id);
+
+CREATE TABLE IF NOT EXISTS contact_list_members (
+    id uuid DEFAULT gen_random_uuid() NOT NULL PRIMARY KEY,
+    contact_list_id uuid NOT NULL REFERENCES contact_lists(id) ON DELETE CASCADE,
+    contact_id uuid NOT NULL,
+    added_at timestamp with time zone
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_contact_list_member ON contact_list_members(contact_list_id, contact_id);
+CREATE INDEX IF NOT EXISTS idx_contact_list_members_contact_id ON contact_list_members(contact_id);
+
+-- Recálculo periódico busca listas dinâmicas desatualizadas
+CREATE INDEX IF NOT EXISTS idx_contact_lists_dynamic_last_calculated
+ON contact_lists(last_calculated_at)
+WHERE is_static = false AND deleted_at IS NULL;
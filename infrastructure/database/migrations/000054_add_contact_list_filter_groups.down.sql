@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_contact_events_contact_type_occurred;
+DROP INDEX IF EXISTS idx_messages_contact_timestamp;
+
+ALTER TABLE contact_list_filters DROP COLUMN IF EXISTS within_days;
+ALTER TABLE contact_lists DROP COLUMN IF EXISTS filter_group;
@@ -0,0 +1,17 @@
+-- ========================================
+-- Migration 000054: Nested filter groups for contact lists
+-- ========================================
+-- contact_lists.filter_group: árvore de filtros aninhados (AND/OR/NOT)
+-- contact_list_filters.within_days: janela de contagem para filtros de evento/interação
+-- ========================================
+
+ALTER TABLE contact_lists ADD COLUMN IF NOT EXISTS filter_group JSONB;
+
+COMMENT ON COLUMN contact_lists.filter_group IS 'Árvore de filtros aninhados; quando presente substitui contact_list_filters + logical_operator';
+
+-- Janela de contagem para filtros de evento/interação (ex.: 3+ mensagens nos últimos 7 dias)
+ALTER TABLE contact_list_filters ADD COLUMN IF NOT EXISTS within_days INTEGER;
+
+-- Índices para subqueries correlacionadas dos filtros de interação
+CREATE INDEX IF NOT EXISTS idx_messages_contact_timestamp ON messages (contact_id, timestamp) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_contact_events_contact_type_occurred ON contact_events (contact_id, event_type, occurred_at) WHERE deleted_at IS NULL;
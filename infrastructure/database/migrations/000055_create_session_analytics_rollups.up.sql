@@ -0,0 +1,42 @@
+-- ========================================
+-- Migration 000055: Session analytics rollups
+-- ========================================
+-- session_analytics_hourly: sessões pré-agregadas por (tenant, hora UTC, canal, agente, status)
+--   - séries por dia/semana/mês e hora do dia são derivadas no fuso do projeto na consulta
+--   - histogramas (bigint[]) permitem estimar percentis de resposta/espera
+--     limites em segundos: 10, 30, 60, 120, 300, 600, 1800, 3600, 14400, 86400, +inf
+-- analytics_rollup_state: marca d'água do refresh incremental
+-- ========================================
+
+CREATE TABLE IF NOT EXISTS session_analytics_hourly (
+    tenant_id text NOT NULL,
+    bucket_start timestamp with time zone NOT NULL,
+    channel_type_id integer NOT NULL DEFAULT 0,
+    agent_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000',
+    status text NOT NULL,
+    session_count bigint NOT NULL DEFAULT 0,
+    resolved_count bigint NOT NULL DEFAULT 0,
+    escalated_count bigint NOT NULL DEFAULT 0,
+    converted_count bigint NOT NULL DEFAULT 0,
+    message_count bigint NOT NULL DEFAULT 0,
+    duration_count bigint NOT NULL DEFAULT 0,
+    duration_sum bigint NOT NULL DEFAULT 0,
+    response_count bigint NOT NULL DEFAULT 0,
+    response_sum bigint NOT NULL DEFAULT 0,
+    response_histogram bigint[] NOT NULL DEFAULT '{}',
+    wait_count bigint NOT NULL DEFAULT 0,
+    wait_sum bigint NOT NULL DEFAULT 0,
+    wait_histogram bigint[] NOT NULL DEFAULT '{}',
+    refreshed_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (tenant_id, bucket_start, channel_type_id, agent_id, status)
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_analytics_hourly_tenant_bucket ON session_analytics_hourly(tenant_id, bucket_start);
+
+CREATE TABLE IF NOT EXISTS analytics_rollup_state (
+    name text NOT NULL PRIMARY KEY,
+    last_refreshed_at timestamp with time zone NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE session_analytics_hourly IS 'Rollup horário de sessões para dashboards; recalculado incrementalmente por SessionAnalyticsRollupWorker';
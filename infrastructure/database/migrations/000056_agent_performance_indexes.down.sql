@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_agent_sessions_active_unique;
+DELETE FROM agent_sessions WHERE metadata @> '{"backfilled": true}'::jsonb;
+DROP INDEX IF EXISTS idx_messages_agent_sent;
+DROP INDEX IF EXISTS idx_agent_sessions_agent_joined;
+DROP INDEX IF EXISTS idx_agent_sessions_session_joined;
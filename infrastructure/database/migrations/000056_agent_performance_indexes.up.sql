@@ -0,0 +1,55 @@
+-- ========================================
+-- Migration 000056: Agent performance
+-- ========================================
+-- Métricas de agentes são derivadas de agent_sessions (participações) e messages:
+--   - índices para janelas por sessão/agente e mensagens enviadas por agente
+--   - no máximo uma participação ativa por (sessão, agente)
+--   - backfill de agent_sessions a partir de sessions.agent_ids para sessões sem participações
+-- ========================================
+
+CREATE INDEX IF NOT EXISTS idx_agent_sessions_session_joined
+    ON agent_sessions (session_id, joined_at)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_agent_sessions_agent_joined
+    ON agent_sessions (agent_id, joined_at)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_messages_agent_sent
+    ON messages (agent_id, timestamp)
+    WHERE from_me AND agent_id IS NOT NULL AND deleted_at IS NULL;
+
+-- Backfill: cada agente em sessions.agent_ids vira uma participação, na ordem do array.
+-- joined_at usa started_at (+1ms por posição para preservar a ordem das transferências);
+-- left_at é a entrada do próximo agente ou ended_at; só o último agente de uma sessão ativa fica ativo.
+INSERT INTO agent_sessions (agent_id, session_id, role_in_session, joined_at, left_at, is_active, metadata, created_at, updated_at)
+SELECT
+    p.agent_id,
+    p.session_id,
+    'primary',
+    p.joined_at,
+    COALESCE(p.next_joined_at, p.ended_at),
+    p.next_joined_at IS NULL AND p.status = 'active',
+    '{"backfilled": true}'::jsonb,
+    now(),
+    now()
+FROM (
+    SELECT
+        a.agent_id_text::uuid AS agent_id,
+        s.id AS session_id,
+        s.status,
+        s.ended_at,
+        s.started_at + (a.ord - 1) * interval '1 millisecond' AS joined_at,
+        LEAD(s.started_at + (a.ord - 1) * interval '1 millisecond') OVER (PARTITION BY s.id ORDER BY a.ord) AS next_joined_at
+    FROM sessions s
+    CROSS JOIN LATERAL jsonb_array_elements_text(s.agent_ids) WITH ORDINALITY AS a(agent_id_text, ord)
+    WHERE s.deleted_at IS NULL
+        AND jsonb_typeof(s.agent_ids) = 'array'
+        AND a.agent_id_text ~* '^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$'
+        AND NOT EXISTS (SELECT 1 FROM agent_sessions x WHERE x.session_id = s.id)
+) AS p
+WHERE EXISTS (SELECT 1 FROM agents ag WHERE ag.id = p.agent_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_agent_sessions_active_unique
+    ON agent_sessions (session_id, agent_id)
+    WHERE is_active AND deleted_at IS NULL;
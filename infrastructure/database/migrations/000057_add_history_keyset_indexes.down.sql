@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_sessions_tenant_active;
+DROP INDEX IF EXISTS idx_sessions_contact_keyset;
+DROP INDEX IF EXISTS idx_messages_reply_to;
+DROP INDEX IF EXISTS idx_messages_contact_keyset;
+DROP INDEX IF EXISTS idx_messages_session_keyset;
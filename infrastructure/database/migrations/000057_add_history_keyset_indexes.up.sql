@@ -0,0 +1,29 @@
+-- ========================================
+-- Migration 000057: Keyset pagination indexes for conversation history
+-- ========================================
+-- Histórico de mensagens e sessões é paginado por (timestamp, id) em vez de OFFSET.
+-- Os índices compostos permitem que cada página seja um index range scan, independente
+-- da profundidade do scroll.
+-- ========================================
+
+CREATE INDEX IF NOT EXISTS idx_messages_session_keyset
+    ON messages (session_id, timestamp, id)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_messages_contact_keyset
+    ON messages (contact_id, timestamp, id)
+    WHERE deleted_at IS NULL;
+
+-- Reconstrução de threads de resposta (reply_to_id)
+CREATE INDEX IF NOT EXISTS idx_messages_reply_to
+    ON messages (reply_to_id)
+    WHERE reply_to_id IS NOT NULL AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_contact_keyset
+    ON sessions (contact_id, started_at, id)
+    WHERE deleted_at IS NULL;
+
+-- Fila de sessões ativas por tenant
+CREATE INDEX IF NOT EXISTS idx_sessions_tenant_active
+    ON sessions (tenant_id, last_activity_at)
+    WHERE status = 'active' AND deleted_at IS NULL;
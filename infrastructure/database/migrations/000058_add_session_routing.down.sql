@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_sessions_active_current_agent;
+DROP TABLE IF EXISTS session_routing_queue;
+DROP TABLE IF EXISTS routing_cursors;
+ALTER TABLE projects DROP COLUMN IF EXISTS agent_assignment;
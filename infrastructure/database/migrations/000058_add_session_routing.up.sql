@@ -0,0 +1,30 @@
+-- Configuração de atribuição automática de agentes (project.AgentAssignmentConfig)
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS agent_assignment JSONB;
+
+-- Cursor de round-robin por projeto: último agente que recebeu uma sessão
+CREATE TABLE IF NOT EXISTS routing_cursors (
+    project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    last_agent_id UUID NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Sessões aguardando um agente elegível (capacidade, skills, idioma ou disponibilidade)
+CREATE TABLE IF NOT EXISTS session_routing_queue (
+    session_id UUID PRIMARY KEY REFERENCES sessions(id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    contact_id UUID NOT NULL,
+    language TEXT NOT NULL DEFAULT '',
+    required_skills TEXT[] NOT NULL DEFAULT '{}',
+    enqueued_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    attempts INT NOT NULL DEFAULT 1,
+    last_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_session_routing_queue_enqueued ON session_routing_queue(enqueued_at);
+CREATE INDEX IF NOT EXISTS idx_session_routing_queue_project ON session_routing_queue(project_id, enqueued_at);
+
+-- Carga por agente: sessões ativas pelo agente atual (último de agent_ids)
+CREATE INDEX IF NOT EXISTS idx_sessions_active_current_agent
+    ON sessions ((agent_ids->>-1))
+    WHERE status = 'active' AND deleted_at IS NULL;
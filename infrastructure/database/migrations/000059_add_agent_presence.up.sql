@@ -0,0 +1,13 @@
+-- Presença dos agentes humanos, alimentada pelos heartbeats das conexões websocket
+CREATE TABLE IF NOT EXISTS agent_presence (
+    agent_id UUID PRIMARY KEY REFERENCES agents(id) ON DELETE CASCADE,
+    tenant_id TEXT NOT NULL,
+    last_seen_at TIMESTAMPTZ,
+    last_activity_at TIMESTAMPTZ,
+    manual_status TEXT,
+    manual_status_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_agent_presence_tenant ON agent_presence(tenant_id);
+CREATE INDEX IF NOT EXISTS idx_agent_presence_last_seen ON agent_presence(last_seen_at);
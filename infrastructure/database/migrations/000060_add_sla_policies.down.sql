@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS sla_clocks;
+DROP TABLE IF EXISTS sla_policies;
+DROP INDEX IF EXISTS idx_sessions_priority;
+ALTER TABLE sessions DROP COLUMN IF EXISTS priority;
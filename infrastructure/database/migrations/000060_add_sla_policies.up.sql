@@ -0,0 +1,48 @@
+-- Prioridade de atendimento da sessão (seleção de política de SLA e filas)
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS priority TEXT NOT NULL DEFAULT 'normal';
+CREATE INDEX IF NOT EXISTS idx_sessions_priority ON sessions(priority);
+
+-- Políticas de SLA por projeto; pipeline, tipo de canal e prioridade NULL = qualquer
+CREATE TABLE IF NOT EXISTS sla_policies (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    pipeline_id UUID REFERENCES pipelines(id) ON DELETE CASCADE,
+    channel_type_id INT,
+    priority TEXT,
+    first_response_seconds INT NOT NULL DEFAULT 0,
+    next_response_seconds INT NOT NULL DEFAULT 0,
+    resolution_seconds INT NOT NULL DEFAULT 0,
+    warning_percent INT NOT NULL DEFAULT 80,
+    business_hours JSONB,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sla_policies_project ON sla_policies(project_id);
+
+-- Relógios de SLA: um por métrica acompanhada em cada sessão (next_response: um por mensagem do contato)
+CREATE TABLE IF NOT EXISTS sla_clocks (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    policy_id UUID NOT NULL,
+    metric TEXT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'running',
+    started_at TIMESTAMPTZ NOT NULL,
+    warn_at TIMESTAMPTZ NOT NULL,
+    due_at TIMESTAMPTZ NOT NULL,
+    warned_at TIMESTAMPTZ,
+    breached_at TIMESTAMPTZ,
+    stopped_at TIMESTAMPTZ,
+    agent_id UUID,
+    elapsed_seconds INT,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sla_clocks_session ON sla_clocks(session_id);
+CREATE INDEX IF NOT EXISTS idx_sla_clocks_running ON sla_clocks(warn_at, due_at) WHERE status = 'running';
+CREATE INDEX IF NOT EXISTS idx_sla_clocks_compliance ON sla_clocks(tenant_id, started_at);
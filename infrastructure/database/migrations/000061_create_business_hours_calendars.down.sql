@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS out_of_office_replies;
+DROP TABLE IF EXISTS business_hours_calendars;
@@ -0,0 +1,27 @@
+-- Expediente por projeto: intervalos semanais, fuso IANA, feriados (YYYY-MM-DD ou MM-DD recorrente)
+CREATE TABLE IF NOT EXISTS business_hours_calendars (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL UNIQUE REFERENCES projects(id) ON DELETE CASCADE,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    weekly JSONB NOT NULL DEFAULT '{}',
+    holidays JSONB NOT NULL DEFAULT '[]',
+    out_of_office_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    out_of_office_message TEXT NOT NULL DEFAULT '',
+    out_of_office_cooldown_seconds INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Respostas de ausência enviadas: no máximo uma por sessão
+CREATE TABLE IF NOT EXISTS out_of_office_replies (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL,
+    contact_id UUID NOT NULL,
+    session_id UUID NOT NULL UNIQUE REFERENCES sessions(id) ON DELETE CASCADE,
+    message_id UUID NOT NULL,
+    sent_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_out_of_office_replies_contact ON out_of_office_replies(contact_id, sent_at DESC);
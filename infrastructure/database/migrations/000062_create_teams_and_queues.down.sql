@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS queue_items;
+DROP TABLE IF EXISTS queues;
+DROP TABLE IF EXISTS teams;
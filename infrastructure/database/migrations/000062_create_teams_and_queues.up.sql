@@ -0,0 +1,54 @@
+-- Times de agentes: membros (array de agent_id) e supervisor opcional
+CREATE TABLE IF NOT EXISTS teams (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    supervisor_id UUID,
+    member_ids JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_teams_project ON teams(project_id);
+CREATE INDEX IF NOT EXISTS idx_teams_member_ids ON teams USING GIN (member_ids);
+CREATE INDEX IF NOT EXISTS idx_teams_supervisor ON teams(supervisor_id) WHERE supervisor_id IS NOT NULL;
+
+-- Filas de atendimento de um time: estratégia de distribuição e prioridade entre filas
+CREATE TABLE IF NOT EXISTS queues (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    team_id UUID NOT NULL REFERENCES teams(id) ON DELETE RESTRICT,
+    name TEXT NOT NULL,
+    strategy TEXT NOT NULL DEFAULT 'manual',
+    priority INT NOT NULL DEFAULT 0,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    last_assigned_agent_id UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_queues_project ON queues(project_id);
+CREATE INDEX IF NOT EXISTS idx_queues_team ON queues(team_id);
+
+-- Passagens das sessões pelas filas (waiting / assigned / abandoned)
+CREATE TABLE IF NOT EXISTS queue_items (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL,
+    queue_id UUID NOT NULL REFERENCES queues(id) ON DELETE CASCADE,
+    session_id UUID NOT NULL REFERENCES sessions(id) ON DELETE CASCADE,
+    contact_id UUID NOT NULL,
+    priority TEXT NOT NULL DEFAULT 'normal',
+    status TEXT NOT NULL DEFAULT 'waiting',
+    agent_id UUID,
+    enqueued_at TIMESTAMPTZ NOT NULL,
+    resolved_at TIMESTAMPTZ
+);
+
+-- Uma sessão aguarda em no máximo uma fila
+CREATE UNIQUE INDEX IF NOT EXISTS idx_queue_items_waiting_session ON queue_items(session_id) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_queue_items_waiting ON queue_items(queue_id, enqueued_at) WHERE status = 'waiting';
+CREATE INDEX IF NOT EXISTS idx_queue_items_resolved ON queue_items(queue_id, resolved_at) WHERE status <> 'waiting';
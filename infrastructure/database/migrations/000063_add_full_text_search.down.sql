@@ -0,0 +1,25 @@
+DROP TRIGGER IF EXISTS trg_contacts_reindex_search ON contacts;
+DROP TRIGGER IF EXISTS trg_notes_search_config ON notes;
+DROP TRIGGER IF EXISTS trg_enrichments_search_config ON message_enrichments;
+DROP TRIGGER IF EXISTS trg_messages_search_config ON messages;
+
+DROP FUNCTION IF EXISTS contacts_reindex_search_language();
+DROP FUNCTION IF EXISTS notes_set_search_config();
+DROP FUNCTION IF EXISTS message_enrichments_set_search_config();
+DROP FUNCTION IF EXISTS messages_set_search_config();
+
+DROP INDEX IF EXISTS idx_notes_search_vector;
+DROP INDEX IF EXISTS idx_enrichments_search_vector;
+DROP INDEX IF EXISTS idx_messages_search_vector;
+DROP INDEX IF EXISTS idx_contacts_search_vector;
+
+ALTER TABLE notes DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE message_enrichments DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE messages DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE contacts DROP COLUMN IF EXISTS search_vector;
+
+ALTER TABLE notes DROP COLUMN IF EXISTS search_config;
+ALTER TABLE message_enrichments DROP COLUMN IF EXISTS search_config;
+ALTER TABLE messages DROP COLUMN IF EXISTS search_config;
+
+DROP FUNCTION IF EXISTS crm_search_config(TEXT);
@@ -0,0 +1,125 @@
+-- Busca full-text: tsvector gerados + índices GIN em contatos, mensagens, transcrições/OCR e notas.
+-- A configuração de text search segue o idioma do contato (pt/es/en com stemming, demais "simple");
+-- o mapeamento espelha search.ConfigForLanguage.
+
+CREATE OR REPLACE FUNCTION crm_search_config(lang TEXT) RETURNS regconfig
+LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$
+    SELECT CASE lower(split_part(replace(trim(coalesce(lang, '')), '_', '-'), '-', 1))
+        WHEN 'pt' THEN 'portuguese'::regconfig
+        WHEN 'portuguese' THEN 'portuguese'::regconfig
+        WHEN 'es' THEN 'spanish'::regconfig
+        WHEN 'spanish' THEN 'spanish'::regconfig
+        WHEN 'en' THEN 'english'::regconfig
+        WHEN 'english' THEN 'english'::regconfig
+        ELSE 'simple'::regconfig
+    END
+$$;
+
+-- Contatos: nome com stemming do idioma do contato (peso A); e-mail, telefone e external_id sem stemming (peso B)
+ALTER TABLE contacts ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector(crm_search_config(language), coalesce(name, '')), 'A') ||
+        setweight(to_tsvector('simple'::regconfig,
+            coalesce(email, '') || ' ' || coalesce(phone, '') || ' ' || coalesce(external_id, '')), 'B')
+    ) STORED;
+CREATE INDEX IF NOT EXISTS idx_contacts_search_vector ON contacts USING GIN (search_vector);
+
+-- Mensagens, transcrições/OCR e notas: search_config preenchido por trigger (idioma da mensagem ou do contato)
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_config regconfig NOT NULL DEFAULT 'simple';
+ALTER TABLE message_enrichments ADD COLUMN IF NOT EXISTS search_config regconfig NOT NULL DEFAULT 'simple';
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS search_config regconfig NOT NULL DEFAULT 'simple';
+
+UPDATE messages m
+SET search_config = crm_search_config(coalesce(m.language, c.language))
+FROM contacts c
+WHERE c.id = m.contact_id;
+
+UPDATE message_enrichments e
+SET search_config = m.search_config
+FROM messages m
+WHERE m.id = e.message_id;
+
+UPDATE notes n
+SET search_config = crm_search_config(c.language)
+FROM contacts c
+WHERE c.id = n.contact_id;
+
+-- Colunas geradas são calculadas depois dos triggers BEFORE, já com o search_config da linha
+ALTER TABLE messages ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(search_config, coalesce(text, ''))) STORED;
+ALTER TABLE message_enrichments ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(search_config, coalesce(extracted_text, ''))) STORED;
+ALTER TABLE notes ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (to_tsvector(search_config, coalesce(content, ''))) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_messages_search_vector ON messages USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_enrichments_search_vector ON message_enrichments USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_notes_search_vector ON notes USING GIN (search_vector);
+
+CREATE OR REPLACE FUNCTION messages_set_search_config() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_config := crm_search_config(coalesce(
+        NEW.language,
+        (SELECT language FROM contacts WHERE id = NEW.contact_id)
+    ));
+    RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION message_enrichments_set_search_config() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_config := coalesce(
+        (SELECT search_config FROM messages WHERE id = NEW.message_id),
+        'simple'::regconfig
+    );
+    RETURN NEW;
+END;
+$$;
+
+CREATE OR REPLACE FUNCTION notes_set_search_config() RETURNS trigger
+LANGUAGE plpgsql AS $$
+BEGIN
+    NEW.search_config := crm_search_config((SELECT language FROM contacts WHERE id = NEW.contact_id));
+    RETURN NEW;
+END;
+$$;
+
+-- Mudança de idioma do contato reindexa o histórico dele
+CREATE OR REPLACE FUNCTION contacts_reindex_search_language() RETURNS trigger
+LANGUAGE plpgsql AS $$
+DECLARE
+    cfg regconfig := crm_search_config(NEW.language);
+BEGIN
+    IF cfg IS DISTINCT FROM crm_search_config(OLD.language) THEN
+        UPDATE messages SET search_config = crm_search_config(coalesce(language, NEW.language))
+        WHERE contact_id = NEW.id;
+        UPDATE message_enrichments e SET search_config = m.search_config
+        FROM messages m
+        WHERE m.id = e.message_id AND m.contact_id = NEW.id;
+        UPDATE notes SET search_config = cfg WHERE contact_id = NEW.id;
+    END IF;
+    RETURN NEW;
+END;
+$$;
+
+DROP TRIGGER IF EXISTS trg_messages_search_config ON messages;
+CREATE TRIGGER trg_messages_search_config
+    BEFORE INSERT OR UPDATE OF language, contact_id ON messages
+    FOR EACH ROW EXECUTE FUNCTION messages_set_search_config();
+
+DROP TRIGGER IF EXISTS trg_enrichments_search_config ON message_enrichments;
+CREATE TRIGGER trg_enrichments_search_config
+    BEFORE INSERT OR UPDATE OF message_id ON message_enrichments
+    FOR EACH ROW EXECUTE FUNCTION message_enrichments_set_search_config();
+
+DROP TRIGGER IF EXISTS trg_notes_search_config ON notes;
+CREATE TRIGGER trg_notes_search_config
+    BEFORE INSERT OR UPDATE OF contact_id ON notes
+    FOR EACH ROW EXECUTE FUNCTION notes_set_search_config();
+
+DROP TRIGGER IF EXISTS trg_contacts_reindex_search ON contacts;
+CREATE TRIGGER trg_contacts_reindex_search
+    AFTER UPDATE OF language ON contacts
+    FOR EACH ROW EXECUTE FUNCTION contacts_reindex_search_language();
@@ -0,0 +1,31 @@
+-- Tarefas dos agentes: prazo absoluto (UTC) + fuso para exibição e recorrência.
+-- Relacionadas a um contato, a uma sessão e/ou a um negócio (contato em um pipeline).
+CREATE TABLE IF NOT EXISTS tasks (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    title TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    due_at TIMESTAMPTZ,
+    timezone TEXT NOT NULL DEFAULT 'UTC',
+    priority TEXT NOT NULL DEFAULT 'normal',
+    status TEXT NOT NULL DEFAULT 'open',
+    recurrence TEXT NOT NULL DEFAULT 'none',
+    assignee_id UUID,
+    contact_id UUID REFERENCES contacts(id) ON DELETE CASCADE,
+    session_id UUID REFERENCES sessions(id) ON DELETE SET NULL,
+    pipeline_id UUID REFERENCES pipelines(id) ON DELETE SET NULL,
+    created_by UUID NOT NULL,
+    overdue_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    completed_by UUID,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+-- "Minhas tarefas" e atrasadas por responsável
+CREATE INDEX IF NOT EXISTS idx_tasks_assignee ON tasks(tenant_id, assignee_id, status, due_at) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_contact ON tasks(contact_id) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tasks_session ON tasks(session_id) WHERE session_id IS NOT NULL AND deleted_at IS NULL;
+-- Varredura de prazos vencidos (lembrete + task.overdue)
+CREATE INDEX IF NOT EXISTS idx_tasks_due ON tasks(due_at) WHERE status = 'open' AND overdue_at IS NULL AND deleted_at IS NULL;
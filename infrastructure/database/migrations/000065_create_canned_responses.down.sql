@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS canned_response_usages;
+DROP TABLE IF EXISTS canned_responses;
@@ -0,0 +1,42 @@
+-- Respostas prontas: compartilhadas com o projeto (owner_agent_id NULL) ou privadas do agente.
+-- O atalho (ex: /preco) é único em cada escopo; respostas removidas liberam o atalho.
+CREATE TABLE IF NOT EXISTS canned_responses (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    owner_agent_id UUID REFERENCES agents(id) ON DELETE CASCADE,
+    shortcut TEXT NOT NULL,
+    title TEXT NOT NULL,
+    content TEXT NOT NULL,
+    folder TEXT NOT NULL DEFAULT '',
+    attachments JSONB NOT NULL DEFAULT '[]',
+    usage_count BIGINT NOT NULL DEFAULT 0,
+    last_used_at TIMESTAMPTZ,
+    created_by UUID NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_canned_responses_shared_shortcut
+    ON canned_responses(project_id, shortcut) WHERE owner_agent_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_canned_responses_private_shortcut
+    ON canned_responses(project_id, owner_agent_id, shortcut) WHERE owner_agent_id IS NOT NULL AND deleted_at IS NULL;
+-- Busca do compositor (prefixo do atalho + título/texto)
+CREATE INDEX IF NOT EXISTS idx_canned_responses_shortcut_prefix
+    ON canned_responses(project_id, shortcut text_pattern_ops) WHERE deleted_at IS NULL;
+
+-- Usos das respostas (análise das mais usadas por período/agente)
+CREATE TABLE IF NOT EXISTS canned_response_usages (
+    id UUID PRIMARY KEY,
+    tenant_id TEXT NOT NULL,
+    project_id UUID NOT NULL,
+    canned_response_id UUID NOT NULL REFERENCES canned_responses(id) ON DELETE CASCADE,
+    agent_id UUID,
+    user_id UUID NOT NULL,
+    session_id UUID,
+    used_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_canned_response_usages_project ON canned_response_usages(project_id, used_at);
+CREATE INDEX IF NOT EXISTS idx_canned_response_usages_response ON canned_response_usages(canned_response_id);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS webhook_deliveries;
+
+ALTER TABLE webhook_subscriptions
+    DROP COLUMN IF EXISTS disabled_reason,
+    DROP COLUMN IF EXISTS disabled_at,
+    DROP COLUMN IF EXISTS consecutive_failures;
@@ -0,0 +1,5 @@
+-- Rotação de segredo sem downtime: durante a janela o header Ventros-Signature
+-- traz uma assinatura v1 com o segredo novo e outra com o anterior
+ALTER TABLE webhook_subscriptions
+    ADD COLUMN IF NOT EXISTS previous_secret TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS previous_secret_expires_at TIMESTAMPTZ;
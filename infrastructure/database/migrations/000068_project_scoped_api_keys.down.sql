@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_user_api_keys_project;
+
+DELETE FROM user_api_keys WHERE project_id IS NOT NULL;
+
+ALTER TABLE user_api_keys
+    DROP COLUMN IF EXISTS revoked_at,
+    DROP COLUMN IF EXISTS last_used_ip,
+    DROP COLUMN IF EXISTS allowed_ips,
+    DROP COLUMN IF EXISTS permissions,
+    DROP COLUMN IF EXISTS prefix,
+    DROP COLUMN IF EXISTS project_id,
+    DROP COLUMN IF EXISTS tenant_id;
@@ -0,0 +1,13 @@
+-- API keys por projeto: token vtr_... guardado só como SHA-256 (key_hash), com prefixo para
+-- exibição, permissões (project_member.Permission), allow-list de IPs/CIDRs e revogação.
+-- Chaves legadas (login/criação de usuário) continuam com project_id NULL.
+ALTER TABLE user_api_keys
+    ADD COLUMN IF NOT EXISTS tenant_id TEXT,
+    ADD COLUMN IF NOT EXISTS project_id UUID REFERENCES projects(id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS prefix TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS permissions TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS allowed_ips TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS last_used_ip TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS revoked_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_user_api_keys_project ON user_api_keys(project_id, created_at DESC) WHERE project_id IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE projects DROP COLUMN IF EXISTS require_two_factor;
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS reauthenticated_at;
+ALTER TABLE auth_sessions DROP COLUMN IF EXISTS two_factor_verified_at;
+DROP TABLE IF EXISTS user_two_factor;
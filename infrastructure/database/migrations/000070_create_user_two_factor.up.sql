@@ -0,0 +1,25 @@
+-- 2FA TOTP por usuário: o segredo fica cifrado (AES-256-GCM, TWO_FACTOR_ENCRYPTION_KEY) e os
+-- códigos de recuperação só como SHA-256. last_used_step impede reusar um código já aceito.
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    id UUID PRIMARY KEY,
+    user_id UUID NOT NULL UNIQUE REFERENCES users(id) ON DELETE CASCADE,
+    secret_ciphertext TEXT NOT NULL,
+    secret_nonce TEXT NOT NULL,
+    status TEXT NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    recovery_code_hashes TEXT[] NOT NULL DEFAULT '{}',
+    confirmed_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Sessões: quando o segundo fator foi verificado e quando o usuário se autenticou por último
+-- (login ou step-up), para as ações sensíveis
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS two_factor_verified_at TIMESTAMPTZ;
+ALTER TABLE auth_sessions ADD COLUMN IF NOT EXISTS reauthenticated_at TIMESTAMPTZ;
+UPDATE auth_sessions SET reauthenticated_at = created_at WHERE reauthenticated_at IS NULL;
+ALTER TABLE auth_sessions ALTER COLUMN reauthenticated_at SET NOT NULL;
+
+-- Política do projeto: todos os membros precisam de 2FA
+ALTER TABLE projects ADD COLUMN IF NOT EXISTS require_two_factor BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,46 @@
+-- Trilha de auditoria append-only: quem fez o quê pela API (usuário, API key ou automação).
+-- Os registros de cada projeto formam uma cadeia de hashes (hash = SHA-256 do registro + prev_hash):
+-- alterar ou remover uma linha quebra a cadeia a partir dela. project_id 00000000-... reúne as
+-- ações fora de projeto.
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL,
+    tenant_id TEXT NOT NULL DEFAULT '',
+    sequence BIGINT NOT NULL,
+    actor_type TEXT NOT NULL,
+    actor_id TEXT NOT NULL DEFAULT '',
+    actor_label TEXT NOT NULL DEFAULT '',
+    action TEXT NOT NULL,
+    resource_type TEXT NOT NULL DEFAULT '',
+    resource_id TEXT NOT NULL DEFAULT '',
+    status INTEGER NOT NULL DEFAULT 0,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    correlation_id TEXT NOT NULL DEFAULT '',
+    changes JSONB,
+    metadata JSONB,
+    occurred_at TIMESTAMPTZ NOT NULL,
+    prev_hash TEXT NOT NULL DEFAULT '',
+    hash TEXT NOT NULL,
+    UNIQUE (project_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_logs_project_time ON audit_logs(project_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_actor ON audit_logs(project_id, actor_type, actor_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_resource ON audit_logs(project_id, resource_type, resource_id);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_action ON audit_logs(project_id, action);
+CREATE INDEX IF NOT EXISTS idx_audit_logs_correlation ON audit_logs(correlation_id) WHERE correlation_id <> '';
+
+-- Append-only também para quem tem acesso direto ao banco pela aplicação
+CREATE OR REPLACE FUNCTION audit_logs_append_only()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_logs is append-only (% not allowed)', TG_OP;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trigger_audit_logs_append_only ON audit_logs;
+CREATE TRIGGER trigger_audit_logs_append_only
+    BEFORE UPDATE OR DELETE ON audit_logs
+    FOR EACH ROW
+    EXECUTE FUNCTION audit_logs_append_only();
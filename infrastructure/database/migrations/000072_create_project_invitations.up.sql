@@ -0,0 +1,20 @@
+-- Convites para projetos: o token vti_... vai por email e só o SHA-256 fica no banco (token_hash).
+-- Um email tem no máximo um convite pendente por projeto; aceitar cria o project_members.
+CREATE TABLE IF NOT EXISTS project_invitations (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    email TEXT NOT NULL,
+    role VARCHAR(50) NOT NULL CHECK (role IN ('admin', 'supervisor', 'agent', 'viewer')),
+    token_hash TEXT NOT NULL UNIQUE,
+    invited_by VARCHAR(255) NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    expires_at TIMESTAMPTZ NOT NULL,
+    accepted_by VARCHAR(255) NOT NULL DEFAULT '',
+    responded_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_invitations_project ON project_invitations(project_id, created_at DESC);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_invitations_pending_email
+    ON project_invitations(project_id, email) WHERE status = 'pending';
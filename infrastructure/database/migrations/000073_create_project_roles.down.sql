@@ -0,0 +1,16 @@
+-- Quem tinha papel custom volta para viewer (somente leitura) antes de restaurar a restrição
+UPDATE project_members SET role = 'viewer' WHERE role LIKE 'custom:%';
+UPDATE project_invitations SET status = 'revoked', updated_at = NOW() WHERE role LIKE 'custom:%' AND status = 'pending';
+UPDATE project_invitations SET role = 'viewer' WHERE role LIKE 'custom:%';
+
+ALTER TABLE project_invitations DROP CONSTRAINT IF EXISTS project_invitations_role_check;
+ALTER TABLE project_invitations ADD CONSTRAINT project_invitations_role_check
+    CHECK (role IN ('admin', 'supervisor', 'agent', 'viewer'));
+
+ALTER TABLE project_members DROP CONSTRAINT IF EXISTS project_members_role_check;
+ALTER TABLE project_members ADD CONSTRAINT project_members_role_check
+    CHECK (role IN ('admin', 'supervisor', 'agent', 'viewer'));
+
+COMMENT ON COLUMN project_members.role IS 'Role: admin (full access), supervisor (management), agent (operations), viewer (read-only)';
+
+DROP TABLE IF EXISTS project_roles;
@@ -0,0 +1,28 @@
+-- Papéis definidos pelo projeto, compostos pelas permissões existentes. A chave custom:<slug> é o
+-- valor gravado em project_members.role e project_invitations.role.
+CREATE TABLE IF NOT EXISTS project_roles (
+    id UUID PRIMARY KEY,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    key VARCHAR(50) NOT NULL CHECK (key LIKE 'custom:%'),
+    name VARCHAR(100) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    permissions TEXT[] NOT NULL DEFAULT '{}',
+    cloned_from VARCHAR(50) NOT NULL DEFAULT '',
+    created_by VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_project_role_key UNIQUE (project_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_roles_project ON project_roles(project_id, name);
+
+-- Membros e convites passam a aceitar os papéis custom
+ALTER TABLE project_members DROP CONSTRAINT IF EXISTS project_members_role_check;
+ALTER TABLE project_members ADD CONSTRAINT project_members_role_check
+    CHECK (role IN ('admin', 'supervisor', 'agent', 'viewer') OR role LIKE 'custom:%');
+
+ALTER TABLE project_invitations DROP CONSTRAINT IF EXISTS project_invitations_role_check;
+ALTER TABLE project_invitations ADD CONSTRAINT project_invitations_role_check
+    CHECK (role IN ('admin', 'supervisor', 'agent', 'viewer') OR role LIKE 'custom:%');
+
+COMMENT ON COLUMN project_members.role IS 'Role: admin, supervisor, agent, viewer or a project role (custom:<slug>, see project_roles)';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_usage_meters_account_metric;
+ALTER TABLE usage_meters ALTER COLUMN stripe_meter_id DROP DEFAULT;
+ALTER TABLE usage_meters ALTER COLUMN stripe_customer_id DROP DEFAULT;
+
+DROP TABLE IF EXISTS usage_records;
+DROP TABLE IF EXISTS billing_plans;
@@ -0,0 +1,55 @@
+-- Planos comerciais: limites de uso vinculados ao price do Stripe da assinatura (-1 = ilimitado).
+-- O plano padrão vale para contas sem assinatura ativa ou com price sem plano cadastrado.
+CREATE TABLE IF NOT EXISTS billing_plans (
+    id UUID PRIMARY KEY,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    stripe_price_id VARCHAR(255) NOT NULL DEFAULT '',
+    max_channels BIGINT NOT NULL DEFAULT -1 CHECK (max_channels >= -1),
+    max_contacts BIGINT NOT NULL DEFAULT -1 CHECK (max_contacts >= -1),
+    max_agents BIGINT NOT NULL DEFAULT -1 CHECK (max_agents >= -1),
+    monthly_messages BIGINT NOT NULL DEFAULT -1 CHECK (monthly_messages >= -1),
+    ai_minutes BIGINT NOT NULL DEFAULT -1 CHECK (ai_minutes >= -1),
+    broadcasts_per_day BIGINT NOT NULL DEFAULT -1 CHECK (broadcasts_per_day >= -1),
+    soft_limit_percent INTEGER NOT NULL DEFAULT 80 CHECK (soft_limit_percent BETWEEN 1 AND 100),
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_billing_plan_key UNIQUE (key)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_billing_plans_stripe_price ON billing_plans(stripe_price_id) WHERE stripe_price_id <> '';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_billing_plans_default ON billing_plans(is_default) WHERE is_default;
+
+INSERT INTO billing_plans (id, key, name, max_channels, max_contacts, max_agents, monthly_messages, ai_minutes, broadcasts_per_day, is_default)
+VALUES (gen_random_uuid(), 'free', 'Free', 1, 1000, 2, 1000, 30, 1, TRUE)
+ON CONFLICT (key) DO NOTHING;
+
+-- Consumo de cada ação medida. (metric, source_id) é único: reentregas do mesmo evento do
+-- outbox não contam duas vezes. reported_at marca o envio em lote ao Stripe.
+CREATE TABLE IF NOT EXISTS usage_records (
+    id UUID PRIMARY KEY,
+    billing_account_id UUID NOT NULL REFERENCES billing_accounts(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL,
+    metric VARCHAR(50) NOT NULL,
+    source_id VARCHAR(255) NOT NULL,
+    quantity BIGINT NOT NULL CHECK (quantity > 0),
+    occurred_at TIMESTAMPTZ NOT NULL,
+    reported_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT unique_usage_record_source UNIQUE (metric, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_usage_records_account ON usage_records(billing_account_id, metric, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_usage_records_project ON usage_records(project_id);
+CREATE INDEX IF NOT EXISTS idx_usage_records_unreported ON usage_records(occurred_at) WHERE reported_at IS NULL;
+
+-- Medidores de contas ainda sem customer no Stripe também acumulam uso
+ALTER TABLE usage_meters ALTER COLUMN stripe_customer_id SET DEFAULT '';
+ALTER TABLE usage_meters ALTER COLUMN stripe_meter_id SET DEFAULT '';
+ALTER TABLE usage_meters ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE usage_meters ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_usage_meters_account_metric ON usage_meters(billing_account_id, metric_name) WHERE deleted_at IS NULL;
+
+COMMENT ON TABLE billing_plans IS 'Plan entitlements linked to the Stripe price of the subscription (-1 = unlimited)';
+COMMENT ON TABLE usage_records IS 'Idempotent usage ledger of metered actions, reported to Stripe Billing Meters in batches';
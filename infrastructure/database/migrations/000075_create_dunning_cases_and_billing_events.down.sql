@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS billing_events;
+DROP TABLE IF EXISTS dunning_cases;
+
+ALTER TABLE billing_accounts DROP COLUMN IF EXISTS read_only_at;
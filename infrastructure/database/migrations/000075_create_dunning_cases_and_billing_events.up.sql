@@ -0,0 +1,41 @@
+-- Régua de cobrança: conta somente leitura no fim da carência de uma fatura em atraso
+ALTER TABLE billing_accounts ADD COLUMN IF NOT EXISTS read_only_at TIMESTAMPTZ;
+
+-- Uma régua por fatura em atraso. Registra o que já foi feito (último lembrete, somente leitura,
+-- suspensão) para que cada passo rode uma única vez, pelo timer Temporal ou pela varredura.
+CREATE TABLE IF NOT EXISTS dunning_cases (
+    id UUID PRIMARY KEY,
+    version INTEGER NOT NULL DEFAULT 1,
+    billing_account_id UUID NOT NULL REFERENCES billing_accounts(id) ON DELETE CASCADE,
+    invoice_id UUID NOT NULL REFERENCES invoices(id) ON DELETE CASCADE,
+    due_date TIMESTAMPTZ NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'resolved')),
+    last_reminder_day INTEGER,
+    reminders_sent INTEGER NOT NULL DEFAULT 0,
+    read_only_at TIMESTAMPTZ,
+    suspended_at TIMESTAMPTZ,
+    resolved_at TIMESTAMPTZ,
+    resolution VARCHAR(50) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dunning_cases_open_invoice ON dunning_cases(invoice_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_dunning_cases_account ON dunning_cases(billing_account_id, status);
+CREATE INDEX IF NOT EXISTS idx_dunning_cases_open ON dunning_cases(created_at) WHERE status = 'open';
+
+-- Linha do tempo de cobrança exibida ao cliente (lembretes, bloqueios, pagamentos, reativação)
+CREATE TABLE IF NOT EXISTS billing_events (
+    id UUID PRIMARY KEY,
+    billing_account_id UUID NOT NULL REFERENCES billing_accounts(id) ON DELETE CASCADE,
+    invoice_id UUID,
+    event_type VARCHAR(100) NOT NULL,
+    message TEXT NOT NULL DEFAULT '',
+    data JSONB NOT NULL DEFAULT '{}',
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_billing_events_account ON billing_events(billing_account_id, occurred_at DESC);
+
+COMMENT ON TABLE dunning_cases IS 'Dunning progress of each overdue invoice: reminders, read-only, suspension and resolution';
+COMMENT ON TABLE billing_events IS 'Customer-visible billing timeline (dunning steps, payments, reactivation)';
@@ -0,0 +1,5 @@
+-- Volta ao rollup horário: o próximo refresh reconstrói todos os buckets
+DELETE FROM session_analytics_hourly;
+DELETE FROM analytics_rollup_state WHERE name = 'session_analytics_hourly';
+
+COMMENT ON TABLE session_analytics_hourly IS 'Rollup horário de sessões para dashboards; recalculado incrementalmente por SessionAnalyticsRollupWorker';
@@ -0,0 +1,8 @@
+-- Rollup de sessões em buckets de 15 minutos (antes: hora UTC). Fusos com deslocamento de
+-- meia hora ou 45 minutos (Asia/Kolkata, Australia/Adelaide, Asia/Kathmandu) caíam no meio de
+-- um bucket horário e recebiam limites de dia/semana/mês errados.
+-- Apaga o rollup e a marca d'água: o próximo refresh reconstrói todos os buckets.
+DELETE FROM session_analytics_hourly;
+DELETE FROM analytics_rollup_state WHERE name = 'session_analytics_hourly';
+
+COMMENT ON TABLE session_analytics_hourly IS 'Rollup de sessões por 15 minutos UTC para dashboards; recalculado incrementalmente por SessionAnalyticsRollupWorker';
@@ -0,0 +1,55 @@
+package email
+
+import (
+	"context"
+	"errors"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSMTPSender_DisabledWithoutHost(t *testing.T) {
+	assert.Nil(t, NewSMTPSender(SMTPConfig{}))
+}
+
+func TestSMTPSender_Send(t *testing.T) {
+	var gotAddr, gotFrom string
+	var gotTo []string
+	var gotMsg []byte
+
+	sender := NewSMTPSender(SMTPConfig{Host: "smtp.example.com", Username: "crm", Password: "secret", From: "crm@example.com"})
+	sender.send = func(addr string, a smtp.Auth, from string, to []string, msg []byte) error {
+		gotAddr, gotFrom, gotTo, gotMsg = addr, from, to, msg
+		assert.NotNil(t, a)
+		return nil
+	}
+
+	err := sender.Send(context.Background(), []string{"ana@example.com", "bruno@example.com"}, "Carla mencionou você", "linha 1\nlinha 2")
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", gotAddr)
+	assert.Equal(t, "crm@example.com", gotFrom)
+	assert.Equal(t, []string{"ana@example.com", "bruno@example.com"}, gotTo)
+
+	msg := string(gotMsg)
+	assert.Contains(t, msg, "Subject: =?UTF-8?q?")
+	assert.NotContains(t, msg, "ana@example.com", "recipients stay in the envelope only")
+	assert.True(t, strings.HasSuffix(msg, "linha 1\r\nlinha 2"))
+}
+
+func TestSMTPSender_SendError(t *testing.T) {
+	sender := NewSMTPSender(SMTPConfig{Host: "smtp.example.com", Port: "25", From: "crm@example.com"})
+	sender.send = func(string, smtp.Auth, string, []string, []byte) error { return errors.New("refused") }
+
+	err := sender.Send(context.Background(), []string{"ana@example.com"}, "Oi", "corpo")
+	assert.ErrorContains(t, err, "smtp.example.com:25")
+}
+
+func TestBuildMessage_ASCIISubjectIsNotEncoded(t *testing.T) {
+	msg := string(buildMessage("crm@example.com", "Hello\r\nBcc: x", "body", time.Unix(0, 0)))
+	assert.Contains(t, msg, "Subject: Hello  Bcc: x\r\n")
+}
@@ -110,6 +110,8 @@ func mapErrorTypeToHTTPStatus(errorType shared.ErrorType) int {
 		return http.StatusPreconditionFailed // 412
 	case shared.ErrorTypeInvariantViolation:
 		return http.StatusUnprocessableEntity // 422
+	case shared.ErrorTypePaymentRequired:
+		return http.StatusPaymentRequired // 402
 	case shared.ErrorTypeDatabase:
 		return http.StatusInternalServerError // 500
 	case shared.ErrorTypeCache:
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	apierrors "github.com/ventros/crm/infrastructure/http/errors"
 	"github.com/ventros/crm/infrastructure/http/middleware"
+	agentapp "github.com/ventros/crm/internal/application/agent"
 	"github.com/ventros/crm/internal/application/queries"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/agent"
@@ -20,14 +21,18 @@ type AgentHandler struct {
 	agentRepo                agent.Repository
 	listAgentsQueryHandler   *queries.ListAgentsQueryHandler
 	searchAgentsQueryHandler *queries.SearchAgentsQueryHandler
+	performanceUseCase       *agentapp.GetAgentPerformanceUseCase
+	presenceUseCase          *agentapp.AgentPresenceUseCase
 }
 
-func NewAgentHandler(logger *zap.Logger, agentRepo agent.Repository) *AgentHandler {
+func NewAgentHandler(logger *zap.Logger, agentRepo agent.Repository, performanceUseCase *agentapp.GetAgentPerformanceUseCase, presenceUseCase *agentapp.AgentPresenceUseCase) *AgentHandler {
 	return &AgentHandler{
 		logger:                   logger,
 		agentRepo:                agentRepo,
 		listAgentsQueryHandler:   queries.NewListAgentsQueryHandler(agentRepo, logger),
 		searchAgentsQueryHandler: queries.NewSearchAgentsQueryHandler(agentRepo, logger),
+		performanceUseCase:       performanceUseCase,
+		presenceUseCase:          presenceUseCase,
 	}
 }
 
@@ -72,6 +77,11 @@ type UpdateAgentRequest struct {
 	Active      *bool    `json:"active,omitempty"`
 }
 
+// SetAgentStatusRequest representa o payload para definir o status manual de um agente
+type SetAgentStatusRequest struct {
+	Status string `json:"status" binding:"required" example:"busy" enums:"available,busy,away,offline,auto"`
+}
+
 // ListAgents lists all agents with optional filters
 //
 //	@Summary		List agents
@@ -222,31 +232,143 @@ func (h *AgentHandler) DeleteAgent(c *gin.Context) {
 // GetAgentStats gets agent statistics
 //
 //	@Summary		Get agent statistics
-//	@Description	Obtém estatísticas de um agente
+//	@Description	Métricas de desempenho do agente no período, calculadas a partir das participações em sessões e do histórico de mensagens:
+//	@Description	sessões atendidas, mensagens enviadas, mediana e p90 do tempo de primeira resposta, taxa de resolução, transferências recebidas/realizadas, sentimento das sessões e horários de pico.
 //	@Tags			CRM - Agents
 //	@Produce		json
-//	@Param			id	path		string					true	"Agent ID (UUID)"
-//	@Success		200	{object}	map[string]interface{}	"Agent statistics"
-//	@Failure		400	{object}	map[string]interface{}	"Invalid agent ID"
-//	@Failure		404	{object}	map[string]interface{}	"Agent not found"
-//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Security		BearerAuth
+//	@Param			id			path		string							true	"Agent ID (UUID)"
+//	@Param			start_date	query		string							false	"Início do período (RFC3339 ou YYYY-MM-DD, default: 30 dias atrás)"
+//	@Param			end_date	query		string							false	"Fim do período, exclusivo (RFC3339 ou YYYY-MM-DD, default: agora)"
+//	@Param			timezone	query		string							false	"Fuso IANA para horários de pico"	default(UTC)
+//	@Success		200			{object}	agentapp.AgentPerformanceDTO	"Agent statistics"
+//	@Failure		400			{object}	map[string]interface{}			"Invalid agent ID or period"
+//	@Failure		404			{object}	map[string]interface{}			"Agent not found"
+//	@Failure		500			{object}	map[string]interface{}			"Internal server error"
 //	@Router			/api/v1/agents/{id}/stats [get]
 func (h *AgentHandler) GetAgentStats(c *gin.Context) {
-	idStr := c.Param("id")
-	agentID, err := uuid.Parse(idStr)
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	agentID, err := uuid.Parse(c.Param("id"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid agent ID format"})
+		apierrors.ValidationError(c, "id", "Invalid agent ID format (must be UUID)")
 		return
 	}
 
-	// TODO: Implement agent statistics
-	c.JSON(http.StatusOK, gin.H{
-		"message":         "Agent statistics not yet implemented",
-		"agent_id":        agentID,
-		"active_sessions": 0,
-		"total_sessions":  0,
-		"avg_rating":      0.0,
+	period, ok := parsePerformancePeriod(c)
+	if !ok {
+		return
+	}
+
+	stats, err := h.performanceUseCase.GetAgentStats(c.Request.Context(), agentapp.GetAgentStatsRequest{
+		AgentID:  agentID,
+		TenantID: authCtx.TenantID,
+		Period:   period,
 	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, stats)
+}
+
+// GetAgentLeaderboard ranks agents of a project
+//
+//	@Summary		Agent leaderboard
+//	@Description	Ranking dos agentes de um projeto no período, ordenado pela métrica escolhida.
+//	@Tags			CRM - Agents
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string							true	"Project ID (UUID)"
+//	@Param			sort_by		query		string							false	"Métrica de ordenação"	Enums(sessions_handled, messages_sent, resolution_rate, first_response_time)	default(sessions_handled)
+//	@Param			limit		query		int								false	"Número de agentes (max 100)"	default(10)
+//	@Param			start_date	query		string							false	"Início do período (RFC3339 ou YYYY-MM-DD, default: 30 dias atrás)"
+//	@Param			end_date	query		string							false	"Fim do período, exclusivo (RFC3339 ou YYYY-MM-DD, default: agora)"
+//	@Param			timezone	query		string							false	"Fuso IANA para horários de pico"	default(UTC)
+//	@Success		200			{object}	agentapp.LeaderboardResponse	"Agent leaderboard"
+//	@Failure		400			{object}	map[string]interface{}			"Invalid parameters"
+//	@Failure		500			{object}	map[string]interface{}			"Internal server error"
+//	@Router			/api/v1/crm/agents/leaderboard [get]
+func (h *AgentHandler) GetAgentLeaderboard(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, err := uuid.Parse(c.Query("project_id"))
+	if err != nil {
+		apierrors.ValidationError(c, "project_id", "project_id is required and must be a UUID")
+		return
+	}
+
+	period, ok := parsePerformancePeriod(c)
+	if !ok {
+		return
+	}
+
+	limit := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if limit, err = strconv.Atoi(limitStr); err != nil {
+			apierrors.ValidationError(c, "limit", "limit must be an integer")
+			return
+		}
+	}
+
+	response, err := h.performanceUseCase.GetLeaderboard(c.Request.Context(), agentapp.GetLeaderboardRequest{
+		TenantID:  authCtx.TenantID,
+		ProjectID: projectID,
+		Period:    period,
+		SortBy:    agent.LeaderboardSort(c.Query("sort_by")),
+		Limit:     limit,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parsePerformancePeriod lê start_date, end_date e timezone (default: últimos 30 dias, UTC)
+func parsePerformancePeriod(c *gin.Context) (agentapp.PerformancePeriod, bool) {
+	period := agentapp.PerformancePeriod{
+		EndDate:  time.Now(),
+		Location: time.UTC,
+	}
+	period.StartDate = period.EndDate.AddDate(0, 0, -30)
+
+	if value := c.Query("start_date"); value != "" {
+		start, err := parseAnalyticsDate(value)
+		if err != nil {
+			apierrors.ValidationError(c, "start_date", "start_date must be RFC3339 or YYYY-MM-DD")
+			return period, false
+		}
+		period.StartDate = start
+	}
+	if value := c.Query("end_date"); value != "" {
+		end, err := parseAnalyticsDate(value)
+		if err != nil {
+			apierrors.ValidationError(c, "end_date", "end_date must be RFC3339 or YYYY-MM-DD")
+			return period, false
+		}
+		period.EndDate = end
+	}
+	if value := c.Query("timezone"); value != "" {
+		location, err := time.LoadLocation(value)
+		if err != nil {
+			apierrors.ValidationError(c, "timezone", "Invalid IANA time zone")
+			return period, false
+		}
+		period.Location = location
+	}
+
+	return period, true
 }
 
 // ListAgentsAdvanced lists agents with advanced filters, pagination, and sorting
@@ -661,3 +783,156 @@ func (h *AgentHandler) EndVirtualAgentPeriod(c *gin.Context) {
 		"updated_at":             virtualAgent.UpdatedAt(),
 	})
 }
+
+// ListAgentPresence lists the presence of human agents
+//
+//	@Summary		Agent presence
+//	@Description	Presença atual dos agentes humanos do tenant: status efetivo, status manual, conexão e horário de trabalho.
+//	@Description	Mudanças são enviadas em tempo real aos supervisores pelo websocket (mensagem agent_presence).
+//	@Tags			CRM - Agents
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]interface{}	"Presence list"
+//	@Failure		401	{object}	map[string]interface{}	"Authentication required"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/crm/agents/presence [get]
+func (h *AgentHandler) ListAgentPresence(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	presence, err := h.presenceUseCase.ListPresence(c.Request.Context(), authCtx.TenantID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"agents": presence,
+		"count":  len(presence),
+	})
+}
+
+// SetAgentStatus sets a manual status override
+//
+//	@Summary		Set agent status
+//	@Description	Define o status manual do agente (busy, away, offline). "available" ou "auto" voltam à presença automática
+//	@Description	(conexões websocket + ausência após inatividade). Sem conexão ativa o agente fica offline.
+//	@Tags			CRM - Agents
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Agent ID (UUID)"
+//	@Param			request	body		SetAgentStatusRequest		true	"Status"
+//	@Success		200		{object}	agentapp.PresenceView		"Updated presence"
+//	@Failure		400		{object}	map[string]interface{}		"Invalid status"
+//	@Failure		404		{object}	map[string]interface{}		"Agent not found"
+//	@Failure		500		{object}	map[string]interface{}		"Internal server error"
+//	@Router			/api/v1/crm/agents/{id}/status [put]
+func (h *AgentHandler) SetAgentStatus(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	agentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid agent ID format (must be UUID)")
+		return
+	}
+
+	var req SetAgentStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.ValidationError(c, "status", err.Error())
+		return
+	}
+
+	view, err := h.presenceUseCase.SetManualStatus(c.Request.Context(), authCtx.TenantID, agentID, req.Status)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// GetAgentWorkingHours returns the agent weekly schedule
+//
+//	@Summary		Get agent working hours
+//	@Description	Jornada semanal do agente (fuso, intervalos por dia e feriados). Fora da jornada o agente não recebe sessões.
+//	@Tags			CRM - Agents
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Agent ID (UUID)"
+//	@Success		200	{object}	agent.WorkingHours		"Working hours"
+//	@Failure		404	{object}	map[string]interface{}	"Agent not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/crm/agents/{id}/working-hours [get]
+func (h *AgentHandler) GetAgentWorkingHours(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	agentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid agent ID format (must be UUID)")
+		return
+	}
+
+	hours, err := h.presenceUseCase.GetWorkingHours(c.Request.Context(), authCtx.TenantID, agentID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, hours)
+}
+
+// UpdateAgentWorkingHours replaces the agent weekly schedule
+//
+//	@Summary		Update agent working hours
+//	@Description	Substitui a jornada semanal do agente. Dias: monday...sunday; horários HH:MM (fim exclusivo, "24:00" permitido);
+//	@Description	feriados YYYY-MM-DD no fuso do agente. Enviar weekly vazio remove a restrição de horário.
+//	@Tags			CRM - Agents
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Agent ID (UUID)"
+//	@Param			request	body		agent.WorkingHours		true	"Working hours"
+//	@Success		200		{object}	agent.WorkingHours		"Updated working hours"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid working hours"
+//	@Failure		404		{object}	map[string]interface{}	"Agent not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/crm/agents/{id}/working-hours [put]
+func (h *AgentHandler) UpdateAgentWorkingHours(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	agentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid agent ID format (must be UUID)")
+		return
+	}
+
+	var hours agent.WorkingHours
+	if err := c.ShouldBindJSON(&hours); err != nil {
+		apierrors.ValidationError(c, "working_hours", err.Error())
+		return
+	}
+
+	saved, err := h.presenceUseCase.UpdateWorkingHours(c.Request.Context(), authCtx.TenantID, agentID, hours)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, saved)
+}
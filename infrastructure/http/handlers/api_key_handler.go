@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	apikeyapp "github.com/ventros/crm/internal/application/apikey"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// APIKeyHandler gerencia as API keys por projeto: criação (token exibido uma única vez),
+// listagem e revogação
+type APIKeyHandler struct {
+	logger *zap.Logger
+	manage *apikeyapp.ManageAPIKeysUseCase
+}
+
+func NewAPIKeyHandler(logger *zap.Logger, manage *apikeyapp.ManageAPIKeysUseCase) *APIKeyHandler {
+	return &APIKeyHandler{
+		logger: logger,
+		manage: manage,
+	}
+}
+
+// CreateAPIKeyRequest corpo de criação da chave
+type CreateAPIKeyRequest struct {
+	Name        string                      `json:"name" binding:"required" example:"Integração ERP"`
+	Permissions []project_member.Permission `json:"permissions" binding:"required" example:"contacts.view,contacts.manage"`
+	AllowedIPs  []string                    `json:"allowed_ips" example:"203.0.113.7,10.0.0.0/8"`
+	ExpiresAt   *time.Time                  `json:"expires_at" example:"2027-01-01T00:00:00Z"`
+}
+
+// CreateAPIKey creates a project API key
+//
+//	@Summary		Create API key
+//	@Description	Cria uma API key do projeto com as permissões informadas, allow-list de IPs/CIDRs e validade opcionais.
+//	@Description	O token (vtr_...) é retornado só nesta resposta; depois disso apenas o prefixo fica visível.
+//	@Description	A chave é do projeto da requisição e só recebe um subconjunto das permissões de quem cria (papel ou API key).
+//	@Tags			AUTH - API Keys
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Project-ID	header		string							false	"Project ID (default: project of the credential)"
+//	@Param			request			body		CreateAPIKeyRequest				true	"API key"
+//	@Success		201				{object}	apikeyapp.CreatedAPIKeyView		"API key created"
+//	@Failure		400				{object}	map[string]interface{}			"Invalid request"
+//	@Failure		403				{object}	map[string]interface{}			"Permission cannot be granted"
+//	@Router			/api/v1/api-keys [post]
+//	@Router			/api/v1/auth/api-key [post]
+func (h *APIKeyHandler) CreateAPIKey(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	var req CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	// A chave nunca vai além de quem a cria, seja usuário (permissões do papel) ou outra API key
+	grantable := access.Permissions
+	if grantable == nil {
+		grantable = []project_member.Permission{}
+	}
+	created, err := h.manage.Create(c.Request.Context(), apikeyapp.CreateAPIKeyCommand{
+		TenantID:             authCtx.TenantID,
+		UserID:               authCtx.UserID,
+		ProjectID:            access.ProjectID,
+		Name:                 req.Name,
+		Permissions:          req.Permissions,
+		AllowedIPs:           req.AllowedIPs,
+		ExpiresAt:            req.ExpiresAt,
+		GrantablePermissions: grantable,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+// ListAPIKeys lists the project API keys
+//
+//	@Summary		List API keys
+//	@Description	Lista as API keys do projeto com prefixo, permissões, status e último uso (gravado em lote, pode atrasar até um minuto).
+//	@Tags			AUTH - API Keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			X-Project-ID	header		string					false	"Project ID (default: project of the credential)"
+//	@Param			include_revoked	query		bool					false	"Include revoked keys"	default(false)
+//	@Success		200				{object}	map[string]interface{}	"API keys"
+//	@Failure		404				{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/api-keys [get]
+func (h *APIKeyHandler) ListAPIKeys(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	includeRevoked := false
+	if value := c.Query("include_revoked"); value != "" {
+		if b, err := strconv.ParseBool(value); err == nil {
+			includeRevoked = b
+		}
+	}
+
+	keys, err := h.manage.List(c.Request.Context(), authCtx.TenantID, access.ProjectID, includeRevoked)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"api_keys": keys,
+		"total":    len(keys),
+	})
+}
+
+// RevokeAPIKey revokes an API key
+//
+//	@Summary		Revoke API key
+//	@Description	Revoga a chave imediatamente. A chave continua listada com status revoked (include_revoked=true).
+//	@Tags			AUTH - API Keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"API key ID (UUID)"
+//	@Success		200	{object}	apikeyapp.APIKeyView	"API key revoked"
+//	@Failure		404	{object}	map[string]interface{}	"API key not found"
+//	@Failure		409	{object}	map[string]interface{}	"API key already revoked"
+//	@Router			/api/v1/api-keys/{id} [delete]
+func (h *APIKeyHandler) RevokeAPIKey(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	id, ok := pathUUID(c, "id", "api_key")
+	if !ok {
+		return
+	}
+
+	view, err := h.manage.Revoke(c.Request.Context(), apikeyapp.RevokeAPIKeyCommand{
+		TenantID:  authCtx.TenantID,
+		UserID:    authCtx.UserID,
+		APIKeyID:  id,
+		ProjectID: access.ProjectID,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// ListAPIKeyPermissions lists the permissions that can be granted to API keys
+//
+//	@Summary		List API key permissions
+//	@Tags			AUTH - API Keys
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]interface{}	"Permissions"
+//	@Router			/api/v1/api-keys/permissions [get]
+func (h *APIKeyHandler) ListAPIKeyPermissions(c *gin.Context) {
+	permissions := project_member.AllPermissions()
+	items := make([]gin.H, len(permissions))
+	for i, p := range permissions {
+		items[i] = gin.H{"permission": p, "description": project_member.PermissionDescription(p)}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"permissions": items})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	apikeyapp "github.com/ventros/crm/internal/application/apikey"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// fakeAPIKeyRepository chaves em memória; registra os projetos listados
+type fakeAPIKeyRepository struct {
+	apikey.Repository
+	keys           map[uuid.UUID]*apikey.APIKey
+	listedProjects []uuid.UUID
+}
+
+func (r *fakeAPIKeyRepository) Save(ctx context.Context, k *apikey.APIKey) error {
+	r.keys[k.ID()] = k
+	return nil
+}
+
+func (r *fakeAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*apikey.APIKey, error) {
+	k, ok := r.keys[id]
+	if !ok {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	return k, nil
+}
+
+func (r *fakeAPIKeyRepository) ListByProject(ctx context.Context, projectID uuid.UUID, includeRevoked bool) ([]*apikey.APIKey, error) {
+	r.listedProjects = append(r.listedProjects, projectID)
+	return nil, nil
+}
+
+type fakeProjectRepository struct {
+	project.Repository
+	projects map[uuid.UUID]*project.Project
+}
+
+func (r *fakeProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	p, ok := r.projects[id]
+	if !ok {
+		return nil, project.ErrProjectNotFound
+	}
+	return p, nil
+}
+
+type discardEventBus struct{}
+
+func (discardEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	return nil
+}
+
+type inlineTransactions struct{}
+
+func (inlineTransactions) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type apiKeyHandlerFixture struct {
+	router *gin.Engine
+	repo   *fakeAPIKeyRepository
+	// project projeto resolvido pelo RBAC; other outro projeto do mesmo tenant
+	project *project.Project
+	other   *project.Project
+}
+
+// newAPIKeyHandlerFixture handler com o acesso que o RBACMiddleware deixaria resolvido: um papel
+// custom que gerencia configurações e só vê contatos
+func newAPIKeyHandlerFixture(t *testing.T) *apiKeyHandlerFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ownerID := uuid.New()
+	p, err := project.NewProject(ownerID, uuid.New(), "tenant-a", "Main")
+	require.NoError(t, err)
+	other, err := project.NewProject(ownerID, uuid.New(), "tenant-a", "Other")
+	require.NoError(t, err)
+
+	f := &apiKeyHandlerFixture{
+		repo:    &fakeAPIKeyRepository{keys: map[uuid.UUID]*apikey.APIKey{}},
+		project: p,
+		other:   other,
+	}
+	projects := &fakeProjectRepository{projects: map[uuid.UUID]*project.Project{p.ID(): p, other.ID(): other}}
+	handler := NewAPIKeyHandler(zap.NewNop(),
+		apikeyapp.NewManageAPIKeysUseCase(f.repo, projects, discardEventBus{}, inlineTransactions{}, zap.NewNop()))
+
+	f.router = gin.New()
+	f.router.Use(func(c *gin.Context) {
+		c.Set("auth", &middleware.AuthContext{UserID: ownerID, TenantID: "tenant-a", ProjectID: p.ID()})
+		c.Set(middleware.ProjectAccessKey, &middleware.ProjectAccess{
+			ProjectID: p.ID(),
+			TenantID:  "tenant-a",
+			Role:      "custom:settings",
+			Permissions: []project_member.Permission{
+				project_member.PermissionViewSettings, project_member.PermissionManageSettings, project_member.PermissionViewContacts,
+			},
+		})
+	})
+	f.router.POST("/api-keys", handler.CreateAPIKey)
+	f.router.GET("/api-keys", handler.ListAPIKeys)
+	f.router.DELETE("/api-keys/:id", handler.RevokeAPIKey)
+	return f
+}
+
+func (f *apiKeyHandlerFixture) do(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAPIKeyHandler_GrantsOnlyCallerPermissions(t *testing.T) {
+	f := newAPIKeyHandlerFixture(t)
+
+	w := f.do(http.MethodPost, "/api-keys", `{"name":"ERP","permissions":["contacts.view","contacts.manage"]}`)
+	require.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	assert.Empty(t, f.repo.keys)
+
+	w = f.do(http.MethodPost, "/api-keys", `{"name":"ERP","permissions":["contacts.view"]}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Len(t, f.repo.keys, 1)
+}
+
+func TestAPIKeyHandler_UsesResolvedProject(t *testing.T) {
+	f := newAPIKeyHandlerFixture(t)
+
+	// project_id no corpo ou na query não escolhe outro projeto
+	w := f.do(http.MethodPost, "/api-keys", `{"name":"ERP","permissions":["contacts.view"],"project_id":"`+f.other.ID().String()+`"}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	var created apikeyapp.CreatedAPIKeyView
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &created))
+	assert.Equal(t, f.project.ID(), created.ProjectID)
+
+	w = f.do(http.MethodGet, "/api-keys?project_id="+f.other.ID().String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []uuid.UUID{f.project.ID()}, f.repo.listedProjects)
+}
+
+func TestAPIKeyHandler_RevokesOnlyKeysOfResolvedProject(t *testing.T) {
+	f := newAPIKeyHandlerFixture(t)
+	k, _, err := apikey.NewAPIKey("tenant-a", f.other.ID(), uuid.New(), apikey.Details{
+		Name:        "Other",
+		Permissions: []project_member.Permission{project_member.PermissionViewContacts},
+	})
+	require.NoError(t, err)
+	f.repo.keys[k.ID()] = k
+
+	w := f.do(http.MethodDelete, "/api-keys/"+k.ID().String(), "")
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+	assert.Nil(t, k.RevokedAt())
+}
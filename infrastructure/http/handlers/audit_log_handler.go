@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"go.uber.org/zap"
+)
+
+// AuditLogHandler consulta, exportação e verificação da trilha de auditoria do projeto
+type AuditLogHandler struct {
+	logger *zap.Logger
+	query  *auditapp.QueryAuditLogUseCase
+}
+
+func NewAuditLogHandler(logger *zap.Logger, query *auditapp.QueryAuditLogUseCase) *AuditLogHandler {
+	return &AuditLogHandler{
+		logger: logger,
+		query:  query,
+	}
+}
+
+// ListAuditLog lists the audit trail of the current project
+//
+//	@Summary		List audit log
+//	@Description	Trilha de auditoria do projeto (quem fez o quê pela API), mais recentes primeiro.
+//	@Description	Alterações trazem o diff antes/depois; campos sensíveis aparecem como [redacted].
+//	@Tags			AUDIT
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			actor_type		query		string					false	"user, api_key, automation ou system"
+//	@Param			actor_id		query		string					false	"User, API key or automation ID"
+//	@Param			action			query		string					false	"Ex.: channels.delete"
+//	@Param			resource_type	query		string					false	"Ex.: channels"
+//	@Param			resource_id		query		string					false	"Resource ID"
+//	@Param			correlation_id	query		string					false	"X-Correlation-ID da requisição"
+//	@Param			since			query		string					false	"Início (RFC3339 ou YYYY-MM-DD)"
+//	@Param			until			query		string					false	"Fim (RFC3339 ou YYYY-MM-DD)"
+//	@Param			limit			query		int						false	"Page size (default 50, max 500)"
+//	@Param			offset			query		int						false	"Offset"
+//	@Success		200				{object}	map[string]interface{}	"Audit entries"
+//	@Failure		400				{object}	map[string]interface{}	"Invalid filter"
+//	@Router			/api/v1/audit-logs [get]
+func (h *AuditLogHandler) ListAuditLog(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	filter, ok := auditFilterFromQuery(c, authCtx)
+	if !ok {
+		return
+	}
+	filter.Limit, filter.Offset = parsePagination(c)
+
+	entries, total, err := h.query.List(c.Request.Context(), filter)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"entries": entries,
+		"total":   total,
+		"limit":   filter.Limit,
+		"offset":  filter.Offset,
+	})
+}
+
+// ExportAuditLog exports the audit trail as CSV
+//
+//	@Summary		Export audit log (CSV)
+//	@Description	Exporta os registros do filtro em CSV (até 100.000 linhas, mais recentes primeiro), com os
+//	@Description	hashes da cadeia. Exige step-up recente; a exportação também entra na trilha.
+//	@Tags			AUDIT
+//	@Produce		text/csv
+//	@Security		BearerAuth
+//	@Param			actor_type		query		string	false	"user, api_key, automation ou system"
+//	@Param			actor_id		query		string	false	"User, API key or automation ID"
+//	@Param			action			query		string	false	"Ex.: channels.delete"
+//	@Param			resource_type	query		string	false	"Ex.: channels"
+//	@Param			resource_id		query		string	false	"Resource ID"
+//	@Param			since			query		string	false	"Início (RFC3339 ou YYYY-MM-DD)"
+//	@Param			until			query		string	false	"Fim (RFC3339 ou YYYY-MM-DD)"
+//	@Success		200				{file}		file	"CSV"
+//	@Failure		403				{object}	map[string]interface{}	"Step-up required"
+//	@Router			/api/v1/audit-logs/export [get]
+func (h *AuditLogHandler) ExportAuditLog(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	filter, ok := auditFilterFromQuery(c, authCtx)
+	if !ok {
+		return
+	}
+
+	filename := fmt.Sprintf("audit-log-%s-%s.csv", authCtx.ProjectID, time.Now().UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	rows, err := h.query.ExportCSV(c.Request.Context(), filter, c.Writer)
+	if err != nil {
+		// O corpo já começou a ser enviado: só resta registrar a exportação incompleta
+		h.logger.Error("Audit log export interrupted",
+			zap.String("project_id", authCtx.ProjectID.String()),
+			zap.Int("rows", rows),
+			zap.Error(err))
+	}
+}
+
+// VerifyAuditLog recomputes the hash chain of the project
+//
+//	@Summary		Verify audit log chain
+//	@Description	Recalcula a cadeia de hashes do projeto. valid=false aponta o primeiro registro alterado
+//	@Description	ou removido (broken_at_sequence).
+//	@Tags			AUDIT
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	audit.Verification	"Verification result"
+//	@Router			/api/v1/audit-logs/verify [get]
+func (h *AuditLogHandler) VerifyAuditLog(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	result, err := h.query.Verify(c.Request.Context(), authCtx.ProjectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+func auditFilterFromQuery(c *gin.Context, authCtx *middleware.AuthContext) (audit.Filter, bool) {
+	filter := audit.Filter{
+		ProjectID:     authCtx.ProjectID,
+		ActorType:     audit.ActorType(c.Query("actor_type")),
+		ActorID:       c.Query("actor_id"),
+		Action:        c.Query("action"),
+		ResourceType:  c.Query("resource_type"),
+		ResourceID:    c.Query("resource_id"),
+		CorrelationID: c.Query("correlation_id"),
+	}
+	if filter.ActorType != "" && !filter.ActorType.IsValid() {
+		apierrors.BadRequest(c, "Invalid actor_type (user, api_key, automation, system)")
+		return filter, false
+	}
+	since, err := optionalAnalyticsDate(c.Query("since"))
+	if err != nil {
+		apierrors.BadRequest(c, "Invalid since date")
+		return filter, false
+	}
+	if !since.IsZero() {
+		filter.Since = &since
+	}
+	until, err := optionalAnalyticsDate(c.Query("until"))
+	if err != nil {
+		apierrors.BadRequest(c, "Invalid until date")
+		return filter, false
+	}
+	if !until.IsZero() {
+		filter.Until = &until
+	}
+	return filter, true
+}
@@ -1,10 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/http/middleware"
 	"github.com/ventros/crm/internal/application/user"
 	"go.uber.org/zap"
@@ -36,11 +36,6 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required" example:"senha123"`
 }
 
-// GenerateAPIKeyRequest representa o payload para gerar API key
-type GenerateAPIKeyRequest struct {
-	Name string `json:"name" example:"Minha API Key"`
-}
-
 // CreateUser creates a new user
 //
 //	@Summary		Criar Usuário
@@ -115,6 +110,13 @@ func (h *AuthHandler) Login(c *gin.Context) {
 	}
 
 	response, err := h.userService.Login(serviceReq)
+	if errors.Is(err, user.ErrTwoFactorRequired) {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"error": "Two-factor authentication required",
+			"hint":  "Use POST /api/v1/auth/token and complete the login with POST /api/v1/auth/token/2fa",
+		})
+		return
+	}
 	if err != nil {
 		h.logger.Error("Login failed", zap.Error(err))
 		c.JSON(http.StatusUnauthorized, gin.H{
@@ -158,42 +160,6 @@ func (h *AuthHandler) GetProfile(c *gin.Context) {
 	})
 }
 
-// GenerateAPIKey generates a new API key for the user
-//
-//	@Summary		Gerar API Key
-//	@Description	Gera uma nova API key para o usuário
-//	@Tags			AUTH - Authentication
-//	@Accept			json
-//	@Produce		json
-//	@Security		ApiKeyAuth
-//	@Param			request	body		GenerateAPIKeyRequest	true	"API key request"
-//	@Success		200		{object}	map[string]interface{}	"API key generated"
-//	@Failure		401		{object}	map[string]interface{}	"Not authenticated"
-//	@Router			/api/v1/auth/api-key [post]
-func (h *AuthHandler) GenerateAPIKey(c *gin.Context) {
-	authCtx, exists := middleware.GetAuthContext(c)
-	if !exists {
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "Not authenticated"})
-		return
-	}
-
-	var req GenerateAPIKeyRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		req.Name = "Default API Key"
-	}
-
-	// TODO: Implementar geração real de API key
-	apiKey := uuid.New().String()
-
-	c.JSON(http.StatusOK, gin.H{
-		"message": "API key generated successfully",
-		"api_key": apiKey,
-		"name":    req.Name,
-		"user_id": authCtx.UserID,
-		"note":    "Save this API key - it won't be shown again",
-	})
-}
-
 // GetAuthInfo provides authentication information for development
 //
 //	@Summary		Informações de Autenticação
@@ -213,9 +179,25 @@ func (h *AuthHandler) GetAuthInfo(c *gin.Context) {
 				"X-Dev-Tenant-ID": "Tenant ID (optional, defaults to dev-tenant)",
 			},
 			"api_key": map[string]string{
-				"header":      "Authorization: Bearer <api_key>",
-				"dev_keys":    "dev-admin-key, dev-user-key",
-				"custom_keys": "Any UUID can be used as API key in dev mode",
+				"header":       "Authorization: Bearer <api_key>",
+				"dev_keys":     "dev-admin-key, dev-user-key",
+				"custom_keys":  "Any UUID can be used as API key in dev mode",
+				"project_keys": "POST /api/v1/api-keys creates a vtr_... key scoped to a project and a set of permissions",
+			},
+			"access_token": map[string]string{
+				"header":  "Authorization: Bearer <access_token>",
+				"login":   "POST /api/v1/auth/token with email and password returns access_token + refresh_token",
+				"refresh": "POST /api/v1/auth/token/refresh rotates the refresh token (each one is single use)",
+				"logout":  "POST /api/v1/auth/logout revokes the session",
+			},
+			"oidc": map[string]string{
+				"providers": "GET /api/v1/auth/oidc/providers",
+				"login":     "GET /api/v1/auth/oidc/{provider}/login redirects to the identity provider",
+			},
+			"two_factor": map[string]string{
+				"enroll":  "POST /api/v1/auth/2fa/enroll returns the TOTP secret, confirm with POST /api/v1/auth/2fa/confirm",
+				"login":   "with two-factor enabled, /auth/token returns a challenge_token; POST /api/v1/auth/token/2fa with the code",
+				"step_up": "POST /api/v1/auth/step-up before sensitive actions that answer 403 step_up_required",
 			},
 			"predefined_users": []map[string]string{
 				{
@@ -0,0 +1,339 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	authapp "github.com/ventros/crm/internal/application/auth"
+	"go.uber.org/zap"
+)
+
+// AuthSessionHandler login nativo: access token curto + refresh token rotacionado, login OIDC
+// (authorization code + PKCE) e gestão das sessões do usuário
+type AuthSessionHandler struct {
+	logger    *zap.Logger
+	sessions  *authapp.ManageSessionsUseCase
+	oidcLogin *authapp.OIDCLoginUseCase
+}
+
+func NewAuthSessionHandler(logger *zap.Logger, sessions *authapp.ManageSessionsUseCase, oidcLogin *authapp.OIDCLoginUseCase) *AuthSessionHandler {
+	return &AuthSessionHandler{
+		logger:    logger,
+		sessions:  sessions,
+		oidcLogin: oidcLogin,
+	}
+}
+
+// RefreshTokenRequest corpo do refresh e do logout
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required" example:"vrt_..."`
+}
+
+// IssueToken logs in with email and password and returns access and refresh tokens
+//
+//	@Summary		Login (tokens)
+//	@Description	Autentica com email e senha e abre uma sessão. O access token (JWT) vale poucos minutos;
+//	@Description	o refresh token é trocado por um novo par em /auth/token/refresh (cada refresh token vale uma vez).
+//	@Description	Com 2FA ativo, retorna two_factor_required e um challenge_token para /auth/token/2fa.
+//	@Tags			AUTH - Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			credentials	body		LoginRequest			true	"Login credentials"
+//	@Success		200			{object}	authapp.LoginResult		"Session tokens or two-factor challenge"
+//	@Failure		401			{object}	map[string]interface{}	"Invalid credentials"
+//	@Router			/api/v1/auth/token [post]
+func (h *AuthSessionHandler) IssueToken(c *gin.Context) {
+	var req LoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := h.sessions.LoginWithPassword(c.Request.Context(), req.Email, req.Password, clientInfo(c))
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CompleteTwoFactorLoginRequest segunda etapa do login com 2FA
+type CompleteTwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required" example:"123456"`
+}
+
+// CompleteTwoFactorLogin exchanges the two-factor challenge for session tokens
+//
+//	@Summary		Login second factor
+//	@Description	Troca o challenge_token do login (válido por 5 minutos) e o código do app autenticador
+//	@Description	(ou um código de recuperação) pelos tokens da sessão.
+//	@Tags			AUTH - Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		CompleteTwoFactorLoginRequest	true	"Challenge and code"
+//	@Success		200		{object}	authapp.TokenPair				"Session tokens"
+//	@Failure		401		{object}	map[string]interface{}			"Invalid challenge or code"
+//	@Router			/api/v1/auth/token/2fa [post]
+func (h *AuthSessionHandler) CompleteTwoFactorLogin(c *gin.Context) {
+	var req CompleteTwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	pair, err := h.sessions.CompleteTwoFactorLogin(c.Request.Context(), req.ChallengeToken, req.Code, clientInfo(c))
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pair)
+}
+
+// RefreshToken rotates the refresh token
+//
+//	@Summary		Refresh tokens
+//	@Description	Troca o refresh token por um novo par. Reapresentar um refresh token já trocado revoga a sessão inteira.
+//	@Tags			AUTH - Authentication
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		RefreshTokenRequest		true	"Refresh token"
+//	@Success		200		{object}	authapp.TokenPair		"New session tokens"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid refresh token"
+//	@Router			/api/v1/auth/token/refresh [post]
+func (h *AuthSessionHandler) RefreshToken(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	pair, err := h.sessions.Refresh(c.Request.Context(), req.RefreshToken, clientInfo(c))
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pair)
+}
+
+// Logout revokes the session of the refresh token
+//
+//	@Summary		Logout
+//	@Description	Revoga a sessão do refresh token; o access token dela deixa de valer na próxima requisição.
+//	@Tags			AUTH - Authentication
+//	@Accept			json
+//	@Param			request	body	RefreshTokenRequest	true	"Refresh token"
+//	@Success		204		"Session revoked"
+//	@Router			/api/v1/auth/logout [post]
+func (h *AuthSessionHandler) Logout(c *gin.Context) {
+	var req RefreshTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.sessions.Logout(c.Request.Context(), req.RefreshToken); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ListOIDCProviders lists the configured OIDC providers
+//
+//	@Summary		List OIDC providers
+//	@Tags			AUTH - Authentication
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Providers"
+//	@Router			/api/v1/auth/oidc/providers [get]
+func (h *AuthSessionHandler) ListOIDCProviders(c *gin.Context) {
+	providers := []string{}
+	if h.oidcLogin != nil {
+		providers = h.oidcLogin.Providers()
+	}
+	c.JSON(http.StatusOK, gin.H{"providers": providers})
+}
+
+// StartOIDCLogin redirects to the provider authorization endpoint
+//
+//	@Summary		Start OIDC login
+//	@Description	Redireciona (302) para o provedor com authorization code + PKCE. Com format=json devolve a URL
+//	@Description	em vez de redirecionar (SPA). O state vale 10 minutos e uma única vez.
+//	@Tags			AUTH - Authentication
+//	@Produce		json
+//	@Param			provider	path		string							true	"Provider name (OIDC_PROVIDERS)"
+//	@Param			format		query		string							false	"json para receber a URL"
+//	@Success		200			{object}	authapp.OIDCAuthorization		"Authorization URL"
+//	@Success		302			"Redirect to the provider"
+//	@Failure		404			{object}	map[string]interface{}			"Unknown provider"
+//	@Router			/api/v1/auth/oidc/{provider}/login [get]
+func (h *AuthSessionHandler) StartOIDCLogin(c *gin.Context) {
+	if h.oidcLogin == nil {
+		apierrors.NotFound(c, "oidc_provider", c.Param("provider"))
+		return
+	}
+
+	authorization, err := h.oidcLogin.Start(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	if c.Query("format") == "json" {
+		c.JSON(http.StatusOK, authorization)
+		return
+	}
+	c.Redirect(http.StatusFound, authorization.AuthorizationURL)
+}
+
+// OIDCCallback completes the OIDC login
+//
+//	@Summary		OIDC callback
+//	@Description	Recebe code e state do provedor (redirect_uri), valida o id_token e abre a sessão.
+//	@Description	Sem conta vinculada (e sem OIDC_AUTO_PROVISION) retorna 403.
+//	@Tags			AUTH - Authentication
+//	@Produce		json
+//	@Param			provider	path		string					true	"Provider name"
+//	@Param			code		query		string					true	"Authorization code"
+//	@Param			state		query		string					true	"State"
+//	@Success		200			{object}	authapp.LoginResult		"Session tokens or two-factor challenge"
+//	@Failure		401			{object}	map[string]interface{}	"Invalid state or token"
+//	@Failure		403			{object}	map[string]interface{}	"No account for this identity"
+//	@Router			/api/v1/auth/oidc/{provider}/callback [get]
+func (h *AuthSessionHandler) OIDCCallback(c *gin.Context) {
+	if h.oidcLogin == nil {
+		apierrors.NotFound(c, "oidc_provider", c.Param("provider"))
+		return
+	}
+	// Usuário cancelou ou o provedor recusou (RFC 6749 §4.1.2.1)
+	if providerErr := c.Query("error"); providerErr != "" {
+		apierrors.Unauthorized(c, "Identity provider returned "+providerErr+": "+c.Query("error_description"))
+		return
+	}
+
+	result, err := h.oidcLogin.Complete(c.Request.Context(), c.Param("provider"), c.Query("state"), c.Query("code"), clientInfo(c))
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ListSessions lists the active sessions of the current user
+//
+//	@Summary		List sessions
+//	@Description	Sessões ativas do usuário (dispositivo, IP, último refresh); current marca a da requisição.
+//	@Tags			AUTH - Sessions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]interface{}	"Sessions"
+//	@Router			/api/v1/auth/sessions [get]
+func (h *AuthSessionHandler) ListSessions(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessions.List(c.Request.Context(), authCtx.UserID, currentSessionID(authCtx))
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"sessions": sessions,
+		"total":    len(sessions),
+	})
+}
+
+// RevokeSession revokes one session of the current user
+//
+//	@Summary		Revoke session
+//	@Tags			AUTH - Sessions
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Session ID (UUID)"
+//	@Success		204	"Session revoked"
+//	@Failure		404	{object}	map[string]interface{}	"Session not found"
+//	@Failure		409	{object}	map[string]interface{}	"Session already revoked"
+//	@Router			/api/v1/auth/sessions/{id} [delete]
+func (h *AuthSessionHandler) RevokeSession(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+	id, ok := pathUUID(c, "id", "session")
+	if !ok {
+		return
+	}
+
+	if err := h.sessions.Revoke(c.Request.Context(), authCtx.UserID, id); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RevokeOtherSessions revokes every other session of the current user
+//
+//	@Summary		Revoke other sessions
+//	@Description	Encerra todas as sessões do usuário menos a atual; include_current=true encerra também a atual.
+//	@Tags			AUTH - Sessions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			include_current	query		bool					false	"Revoke the current session too"	default(false)
+//	@Success		200				{object}	map[string]interface{}	"Revoked count"
+//	@Router			/api/v1/auth/sessions [delete]
+func (h *AuthSessionHandler) RevokeOtherSessions(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+
+	keep := currentSessionID(authCtx)
+	if includeCurrent, err := strconv.ParseBool(c.Query("include_current")); err == nil && includeCurrent {
+		keep = uuid.Nil
+	}
+
+	revoked, err := h.sessions.RevokeOthers(c.Request.Context(), authCtx.UserID, keep)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"revoked": revoked})
+}
+
+// userAuthContext sessões pertencem a usuários: API keys não listam nem revogam logins
+func userAuthContext(c *gin.Context) (*middleware.AuthContext, bool) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	if authCtx.IsAPIKey() {
+		apierrors.Forbidden(c, "API keys cannot manage login sessions")
+		return nil, false
+	}
+	return authCtx, true
+}
+
+func currentSessionID(authCtx *middleware.AuthContext) uuid.UUID {
+	if authCtx.SessionID == nil {
+		return uuid.Nil
+	}
+	return *authCtx.SessionID
+}
+
+func clientInfo(c *gin.Context) authapp.ClientInfo {
+	return authapp.ClientInfo{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
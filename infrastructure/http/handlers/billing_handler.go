@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	"go.uber.org/zap"
+)
+
+// BillingHandler situação de cobrança e linha do tempo da conta do projeto
+type BillingHandler struct {
+	logger *zap.Logger
+	status *billingapp.BillingStatusUseCase
+}
+
+func NewBillingHandler(logger *zap.Logger, status *billingapp.BillingStatusUseCase) *BillingHandler {
+	return &BillingHandler{
+		logger: logger,
+		status: status,
+	}
+}
+
+// GetBillingStatus returns the billing standing of the current project
+//
+//	@Summary		Get billing status
+//	@Description	Situação da conta de cobrança do projeto: good, read_only (fim da carência de uma fatura
+//	@Description	vencida, escritas respondem 402) ou suspended (canais pausados e envios bloqueados), com as
+//	@Description	faturas em atraso e a régua em vigor.
+//	@Tags			BILLING
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	billingapp.BillingStatus	"Billing status"
+//	@Failure		401	{object}	map[string]interface{}		"Unauthorized"
+//	@Router			/api/v1/billing/status [get]
+func (h *BillingHandler) GetBillingStatus(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	status, err := h.status.Status(c.Request.Context(), authCtx.ProjectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, status)
+}
+
+// ListBillingEvents lists the billing timeline of the current project
+//
+//	@Summary		List billing events
+//	@Description	Linha do tempo de cobrança da conta, mais recentes primeiro: pagamento pendente, lembretes
+//	@Description	enviados, modo somente leitura, suspensão, pagamento confirmado e reativação.
+//	@Tags			BILLING
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			limit	query		int						false	"Page size (default 50, max 500)"
+//	@Param			offset	query		int						false	"Offset"
+//	@Success		200		{object}	map[string]interface{}	"Billing events"
+//	@Failure		401		{object}	map[string]interface{}	"Unauthorized"
+//	@Router			/api/v1/billing/events [get]
+func (h *BillingHandler) ListBillingEvents(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	limit, offset := parsePagination(c)
+
+	events, total, err := h.status.Timeline(c.Request.Context(), authCtx.ProjectID, limit, offset)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"events": events,
+		"total":  total,
+		"limit":  limit,
+		"offset": offset,
+	})
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	businesshoursapp "github.com/ventros/crm/internal/application/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"go.uber.org/zap"
+)
+
+// maxICalSize limite do arquivo iCal importado
+const maxICalSize = 1 << 20
+
+// BusinessHoursHandler expõe o expediente do projeto (feriados e resposta de ausência)
+type BusinessHoursHandler struct {
+	logger   *zap.Logger
+	calendar *businesshoursapp.ManageCalendarUseCase
+}
+
+func NewBusinessHoursHandler(logger *zap.Logger, calendar *businesshoursapp.ManageCalendarUseCase) *BusinessHoursHandler {
+	return &BusinessHoursHandler{
+		logger:   logger,
+		calendar: calendar,
+	}
+}
+
+// BusinessHoursRequest corpo do expediente do projeto.
+// Sem intervalos semanais o projeto é considerado sempre aberto.
+type BusinessHoursRequest struct {
+	Timezone    string                              `json:"timezone" example:"America/Sao_Paulo"`
+	Weekly      map[string][]businesshours.Interval `json:"weekly"`
+	Holidays    []string                            `json:"holidays" example:"12-25,2025-03-03"`
+	OutOfOffice struct {
+		Enabled         bool   `json:"enabled"`
+		Message         string `json:"message" example:"Nosso horário é de segunda a sexta, das 9h às 18h. Respondemos assim que possível!"`
+		CooldownMinutes int    `json:"cooldown_minutes" example:"240"`
+	} `json:"out_of_office"`
+}
+
+// GetBusinessHours returns the business hours calendar of a project
+//
+//	@Summary		Get business hours
+//	@Description	Retorna o expediente do projeto (default: projeto do token), com open_now.
+//	@Tags			CRM - Business Hours
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string							false	"Project ID (UUID)"
+//	@Success		200			{object}	businesshoursapp.CalendarView	"Business hours"
+//	@Failure		404			{object}	map[string]interface{}			"Project or calendar not found"
+//	@Router			/api/v1/crm/business-hours [get]
+func (h *BusinessHoursHandler) GetBusinessHours(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	calendar, err := h.calendar.Get(c.Request.Context(), authCtx.TenantID, projectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// UpdateBusinessHours creates or replaces the business hours calendar of a project
+//
+//	@Summary		Set business hours
+//	@Description	Cria ou substitui o expediente: intervalos semanais ("HH:MM"), fuso IANA e feriados
+//	@Description	(YYYY-MM-DD ou MM-DD para datas que se repetem todo ano). A resposta de ausência é enviada
+//	@Description	uma vez por sessão quando o contato escreve fora do expediente; cooldown evita repeti-la ao mesmo contato.
+//	@Tags			CRM - Business Hours
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string							false	"Project ID (UUID)"
+//	@Param			request		body		BusinessHoursRequest			true	"Business hours"
+//	@Success		200			{object}	businesshoursapp.CalendarView	"Business hours saved"
+//	@Failure		400			{object}	map[string]interface{}			"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}			"Project not found"
+//	@Router			/api/v1/crm/business-hours [put]
+func (h *BusinessHoursHandler) UpdateBusinessHours(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	var req BusinessHoursRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	calendar, err := h.calendar.Upsert(c.Request.Context(), authCtx.TenantID, projectID, businesshoursapp.CalendarInput{
+		Schedule: businesshours.Schedule{
+			Timezone: req.Timezone,
+			Weekly:   req.Weekly,
+			Holidays: req.Holidays,
+		},
+		OutOfOfficeEnabled:  req.OutOfOffice.Enabled,
+		OutOfOfficeMessage:  req.OutOfOffice.Message,
+		OutOfOfficeCooldown: req.OutOfOffice.CooldownMinutes,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, calendar)
+}
+
+// DeleteBusinessHours removes the business hours calendar of a project
+//
+//	@Summary		Delete business hours
+//	@Description	Remove o expediente: o projeto passa a ser considerado sempre aberto.
+//	@Tags			CRM - Business Hours
+//	@Security		BearerAuth
+//	@Param			project_id	query	string	false	"Project ID (UUID)"
+//	@Success		204	"Business hours deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Project or calendar not found"
+//	@Router			/api/v1/crm/business-hours [delete]
+func (h *BusinessHoursHandler) DeleteBusinessHours(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	if err := h.calendar.Delete(c.Request.Context(), authCtx.TenantID, projectID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ImportHolidays imports holidays from an iCalendar file
+//
+//	@Summary		Import holidays from iCal
+//	@Description	Acrescenta ao expediente os feriados de um arquivo .ics (multipart "file" ou corpo text/calendar).
+//	@Description	Eventos com RRULE FREQ=YEARLY viram datas recorrentes (MM-DD); eventos de vários dias são expandidos.
+//	@Tags			CRM - Business Hours
+//	@Accept			multipart/form-data
+//	@Accept			text/calendar
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string							false	"Project ID (UUID)"
+//	@Param			file		formData	file							false	"iCalendar file (.ics)"
+//	@Success		200			{object}	businesshoursapp.ImportResult	"Holidays imported"
+//	@Failure		400			{object}	map[string]interface{}			"Invalid file"
+//	@Failure		404			{object}	map[string]interface{}			"Project or calendar not found"
+//	@Router			/api/v1/crm/business-hours/holidays/import [post]
+func (h *BusinessHoursHandler) ImportHolidays(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxICalSize)
+
+	var ics io.Reader = c.Request.Body
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		file, _, err := c.Request.FormFile("file")
+		if err != nil {
+			apierrors.ValidationError(c, "file", "file is required")
+			return
+		}
+		defer file.Close()
+		ics = file
+	}
+
+	result, err := h.calendar.ImportICal(c.Request.Context(), authCtx.TenantID, projectID, ics)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
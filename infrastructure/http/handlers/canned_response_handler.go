@@ -0,0 +1,629 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	cannedresponseapp "github.com/ventros/crm/internal/application/cannedresponse"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"go.uber.org/zap"
+)
+
+// CannedResponseHandler expõe a biblioteca de respostas prontas: CRUD, pastas, anexos,
+// busca do compositor, expansão de atalhos e analytics de uso
+type CannedResponseHandler struct {
+	logger   *zap.Logger
+	manage   *cannedresponseapp.ManageCannedResponsesUseCase
+	useCases *cannedresponseapp.UseCannedResponseUseCase
+}
+
+func NewCannedResponseHandler(
+	logger *zap.Logger,
+	manage *cannedresponseapp.ManageCannedResponsesUseCase,
+	useCases *cannedresponseapp.UseCannedResponseUseCase,
+) *CannedResponseHandler {
+	return &CannedResponseHandler{
+		logger:   logger,
+		manage:   manage,
+		useCases: useCases,
+	}
+}
+
+// CannedResponseRequest corpo de criação/edição. private só vale na criação.
+type CannedResponseRequest struct {
+	ProjectID *uuid.UUID `json:"project_id"`
+	Shortcut  string     `json:"shortcut" binding:"required" example:"/preco"`
+	Title     string     `json:"title" binding:"required" example:"Tabela de preços"`
+	Content   string     `json:"content" binding:"required" example:"Oi {{contact.first_name}}, segue nossa tabela!"`
+	Folder    string     `json:"folder" example:"Vendas/Preços"`
+	Private   bool       `json:"private"`
+}
+
+func (r CannedResponseRequest) input() cannedresponseapp.CannedResponseInput {
+	return cannedresponseapp.CannedResponseInput{
+		Shortcut: r.Shortcut,
+		Title:    r.Title,
+		Content:  r.Content,
+		Folder:   r.Folder,
+		Private:  r.Private,
+	}
+}
+
+// UseCannedResponseRequest contexto usado para renderizar as variáveis
+type UseCannedResponseRequest struct {
+	SessionID *uuid.UUID `json:"session_id"`
+	ContactID *uuid.UUID `json:"contact_id"`
+}
+
+// ExpandCannedResponseRequest atalho digitado no compositor
+type ExpandCannedResponseRequest struct {
+	ProjectID *uuid.UUID `json:"project_id"`
+	Shortcut  string     `json:"shortcut" binding:"required" example:"/preco"`
+	SessionID *uuid.UUID `json:"session_id"`
+	ContactID *uuid.UUID `json:"contact_id"`
+}
+
+// ListCannedResponses lists canned responses
+//
+//	@Summary		List canned responses
+//	@Description	Lista as respostas prontas do projeto visíveis ao usuário: as compartilhadas e as privadas do seu agente.
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID, default: project of the token)"
+//	@Param			folder		query		string					false	"Folder (empty = root)"
+//	@Param			scope		query		string					false	"all, shared or private"	default(all)
+//	@Param			q			query		string					false	"Search by shortcut, title or content"
+//	@Param			limit		query		int						false	"Page size (max 500)"	default(50)
+//	@Param			offset		query		int						false	"Offset"				default(0)
+//	@Success		200			{object}	map[string]interface{}	"Canned responses"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/canned-responses [get]
+func (h *CannedResponseHandler) ListCannedResponses(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	filter, ok := parseCannedResponseFilter(c, authCtx)
+	if !ok {
+		return
+	}
+	filter.Limit, filter.Offset = parsePagination(c)
+
+	h.list(c, authCtx, filter)
+}
+
+// SearchCannedResponses searches canned responses for the composer
+//
+//	@Summary		Search canned responses
+//	@Description	Busca do compositor: atalhos que começam com o termo aparecem primeiro, depois as mais usadas.
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID, default: project of the token)"
+//	@Param			q			query		string					false	"Typed text (e.g. /pre)"
+//	@Param			limit		query		int						false	"Max results (max 50)"	default(10)
+//	@Success		200			{object}	map[string]interface{}	"Canned responses"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/canned-responses/search [get]
+func (h *CannedResponseHandler) SearchCannedResponses(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	limit := 10
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 50 {
+		limit = l
+	}
+
+	h.list(c, authCtx, cannedresponse.Filter{
+		TenantID:  authCtx.TenantID,
+		ProjectID: projectID,
+		Scope:     cannedresponse.ScopeAll,
+		Query:     c.Query("q"),
+		Limit:     limit,
+	})
+}
+
+// ListCannedResponseFolders lists folders with their response count
+//
+//	@Summary		List canned response folders
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID, default: project of the token)"
+//	@Param			scope		query		string					false	"all, shared or private"	default(all)
+//	@Success		200			{object}	map[string]interface{}	"Folders"
+//	@Router			/api/v1/crm/canned-responses/folders [get]
+func (h *CannedResponseHandler) ListCannedResponseFolders(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	filter, ok := parseCannedResponseFilter(c, authCtx)
+	if !ok {
+		return
+	}
+	filter.Folder = nil
+	filter.Query = ""
+
+	folders, err := h.manage.Folders(c.Request.Context(), authCtx.UserID, filter)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+	if folders == nil {
+		folders = []cannedresponse.FolderCount{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{"folders": folders})
+}
+
+// ListCannedResponseVariables lists the supported template variables
+//
+//	@Summary		List canned response variables
+//	@Description	Variáveis aceitas no conteúdo ({{contact.first_name}} etc.), renderizadas com o contato e a sessão atuais.
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]interface{}	"Variables"
+//	@Router			/api/v1/crm/canned-responses/variables [get]
+func (h *CannedResponseHandler) ListCannedResponseVariables(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"variables": cannedresponse.SupportedVariables})
+}
+
+// CannedResponseAnalytics returns the most used canned responses
+//
+//	@Summary		Canned response usage analytics
+//	@Description	Respostas mais usadas no período (padrão: últimos 30 dias), com agentes e sessões distintos.
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID, default: project of the token)"
+//	@Param			since		query		string					false	"Start (RFC3339 or YYYY-MM-DD)"
+//	@Param			until		query		string					false	"End (RFC3339 or YYYY-MM-DD)"
+//	@Param			agent_id	query		string					false	"Only uses by this agent (UUID)"
+//	@Param			limit		query		int						false	"Max responses (max 100)"	default(20)
+//	@Success		200			{object}	map[string]interface{}	"Usage stats"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/canned-responses/analytics [get]
+func (h *CannedResponseHandler) CannedResponseAnalytics(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+	agentID, ok := parseOptionalUUIDQuery(c, "agent_id")
+	if !ok {
+		return
+	}
+	since, err := optionalAnalyticsDate(c.Query("since"))
+	if err != nil {
+		apierrors.ValidationError(c, "since", "Invalid date (use RFC3339 or YYYY-MM-DD)")
+		return
+	}
+	until, err := optionalAnalyticsDate(c.Query("until"))
+	if err != nil {
+		apierrors.ValidationError(c, "until", "Invalid date (use RFC3339 or YYYY-MM-DD)")
+		return
+	}
+	limit := 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		limit = l
+	}
+
+	query := cannedresponse.UsageQuery{
+		TenantID:  authCtx.TenantID,
+		ProjectID: projectID,
+		Since:     since,
+		Until:     until,
+		AgentID:   agentID,
+		Limit:     limit,
+	}
+	stats, err := h.manage.Analytics(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"project_id": projectID, "responses": stats})
+}
+
+// CreateCannedResponse creates a canned response
+//
+//	@Summary		Create canned response
+//	@Description	Cria uma resposta compartilhada do projeto ou, com private=true, uma resposta privada do agente do usuário.
+//	@Tags			CRM - Canned Responses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		CannedResponseRequest						true	"Canned response"
+//	@Success		201		{object}	cannedresponseapp.CannedResponseView		"Canned response created"
+//	@Failure		400		{object}	map[string]interface{}						"Invalid request"
+//	@Failure		409		{object}	map[string]interface{}						"Shortcut already in use"
+//	@Router			/api/v1/crm/canned-responses [post]
+func (h *CannedResponseHandler) CreateCannedResponse(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req CannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	projectID := authCtx.ProjectID
+	if req.ProjectID != nil {
+		projectID = *req.ProjectID
+	}
+	if projectID == uuid.Nil {
+		apierrors.ValidationError(c, "project_id", "project_id is required")
+		return
+	}
+
+	view, err := h.manage.Create(c.Request.Context(), authCtx.TenantID, authCtx.UserID, projectID, req.input())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+// GetCannedResponse returns a canned response
+//
+//	@Summary		Get canned response
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string									true	"Canned response ID (UUID)"
+//	@Success		200	{object}	cannedresponseapp.CannedResponseView	"Canned response"
+//	@Failure		404	{object}	map[string]interface{}					"Canned response not found"
+//	@Router			/api/v1/crm/canned-responses/{id} [get]
+func (h *CannedResponseHandler) GetCannedResponse(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	id, ok := pathUUID(c, "id", "canned_response")
+	if !ok {
+		return
+	}
+
+	view, err := h.manage.Get(c.Request.Context(), authCtx.TenantID, authCtx.UserID, id)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// UpdateCannedResponse edits a canned response
+//
+//	@Summary		Update canned response
+//	@Description	Edita atalho, título, conteúdo e pasta. A visibilidade (privada/compartilhada) não muda.
+//	@Tags			CRM - Canned Responses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string									true	"Canned response ID (UUID)"
+//	@Param			request	body		CannedResponseRequest					true	"Canned response"
+//	@Success		200		{object}	cannedresponseapp.CannedResponseView	"Canned response updated"
+//	@Failure		400		{object}	map[string]interface{}					"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}					"Canned response not found"
+//	@Failure		409		{object}	map[string]interface{}					"Shortcut already in use"
+//	@Router			/api/v1/crm/canned-responses/{id} [put]
+func (h *CannedResponseHandler) UpdateCannedResponse(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	id, ok := pathUUID(c, "id", "canned_response")
+	if !ok {
+		return
+	}
+
+	var req CannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	view, err := h.manage.Update(c.Request.Context(), authCtx.TenantID, authCtx.UserID, id, req.input())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// DeleteCannedResponse removes a canned response
+//
+//	@Summary		Delete canned response
+//	@Tags			CRM - Canned Responses
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Canned response ID (UUID)"
+//	@Success		204	"Canned response deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Canned response not found"
+//	@Router			/api/v1/crm/canned-responses/{id} [delete]
+func (h *CannedResponseHandler) DeleteCannedResponse(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	id, ok := pathUUID(c, "id", "canned_response")
+	if !ok {
+		return
+	}
+
+	if err := h.manage.Delete(c.Request.Context(), authCtx.TenantID, authCtx.UserID, id); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// UseCannedResponse renders a canned response and records its usage
+//
+//	@Summary		Use canned response
+//	@Description	Renderiza as variáveis com a sessão (ou contato) informada e registra o uso. missing_variables lista as variáveis sem valor, que ficam vazias.
+//	@Tags			CRM - Canned Responses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Canned response ID (UUID)"
+//	@Param			request	body		UseCannedResponseRequest			false	"Render context"
+//	@Success		200		{object}	cannedresponseapp.RenderedResponse	"Rendered response"
+//	@Failure		404		{object}	map[string]interface{}				"Canned response, session or contact not found"
+//	@Router			/api/v1/crm/canned-responses/{id}/use [post]
+func (h *CannedResponseHandler) UseCannedResponse(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	id, ok := pathUUID(c, "id", "canned_response")
+	if !ok {
+		return
+	}
+
+	var req UseCannedResponseRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	rendered, err := h.useCases.Use(c.Request.Context(), cannedresponseapp.UseCommand{
+		TenantID:         authCtx.TenantID,
+		UserID:           authCtx.UserID,
+		CannedResponseID: id,
+		SessionID:        req.SessionID,
+		ContactID:        req.ContactID,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rendered)
+}
+
+// ExpandCannedResponse expands a shortcut typed in the composer
+//
+//	@Summary		Expand canned response shortcut
+//	@Description	Resolve o atalho (a resposta privada do agente tem precedência sobre a compartilhada), renderiza e registra o uso. Também disponível via websocket (canned_response_expand).
+//	@Tags			CRM - Canned Responses
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		ExpandCannedResponseRequest			true	"Shortcut and render context"
+//	@Success		200		{object}	cannedresponseapp.RenderedResponse	"Rendered response"
+//	@Failure		400		{object}	map[string]interface{}				"Invalid shortcut"
+//	@Failure		404		{object}	map[string]interface{}				"Shortcut not found"
+//	@Router			/api/v1/crm/canned-responses/expand [post]
+func (h *CannedResponseHandler) ExpandCannedResponse(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req ExpandCannedResponseRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	projectID := authCtx.ProjectID
+	if req.ProjectID != nil {
+		projectID = *req.ProjectID
+	}
+	if projectID == uuid.Nil && req.SessionID == nil && req.ContactID == nil {
+		apierrors.ValidationError(c, "project_id", "project_id, session_id or contact_id is required")
+		return
+	}
+
+	rendered, err := h.useCases.Expand(c.Request.Context(), cannedresponseapp.ExpandCommand{
+		TenantID:  authCtx.TenantID,
+		UserID:    authCtx.UserID,
+		ProjectID: projectID,
+		Shortcut:  req.Shortcut,
+		SessionID: req.SessionID,
+		ContactID: req.ContactID,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, rendered)
+}
+
+// UploadCannedResponseAttachment uploads a file and attaches it to a canned response
+//
+//	@Summary		Upload canned response attachment
+//	@Description	Envia o arquivo ao storage configurado e anexa à resposta (máx. 16MB, 10 anexos).
+//	@Tags			CRM - Canned Responses
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			file	formData	file									true	"File to attach (max 16MB)"
+//	@Param			id		path		string									true	"Canned response ID (UUID)"
+//	@Success		201		{object}	cannedresponseapp.CannedResponseView	"Attachment added"
+//	@Failure		400		{object}	map[string]interface{}					"Invalid file"
+//	@Failure		404		{object}	map[string]interface{}					"Canned response not found"
+//	@Failure		503		{object}	map[string]interface{}					"Storage not configured"
+//	@Router			/api/v1/crm/canned-responses/{id}/attachments [post]
+func (h *CannedResponseHandler) UploadCannedResponseAttachment(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	if !h.manage.AttachmentsEnabled() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Attachment storage is not configured"})
+		return
+	}
+
+	id, ok := pathUUID(c, "id", "canned_response")
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		apierrors.ValidationError(c, "file", "No file provided")
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	view, err := h.manage.AddAttachment(c.Request.Context(), cannedresponseapp.UploadAttachmentCommand{
+		TenantID:         authCtx.TenantID,
+		UserID:           authCtx.UserID,
+		CannedResponseID: id,
+		Filename:         header.Filename,
+		ContentType:      contentType,
+		Size:             header.Size,
+		File:             file,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
+}
+
+// DeleteCannedResponseAttachment removes an attachment from a canned response
+//
+//	@Summary		Delete canned response attachment
+//	@Tags			CRM - Canned Responses
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		string									true	"Canned response ID (UUID)"
+//	@Param			attachment_id	path		string									true	"Attachment ID (UUID)"
+//	@Success		200				{object}	cannedresponseapp.CannedResponseView	"Attachment removed"
+//	@Failure		404				{object}	map[string]interface{}					"Canned response or attachment not found"
+//	@Router			/api/v1/crm/canned-responses/{id}/attachments/{attachment_id} [delete]
+func (h *CannedResponseHandler) DeleteCannedResponseAttachment(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	id, ok := pathUUID(c, "id", "canned_response")
+	if !ok {
+		return
+	}
+	attachmentID, ok := pathUUID(c, "attachment_id", "attachment")
+	if !ok {
+		return
+	}
+
+	view, err := h.manage.RemoveAttachment(c.Request.Context(), authCtx.TenantID, authCtx.UserID, id, attachmentID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+func (h *CannedResponseHandler) list(c *gin.Context, authCtx *middleware.AuthContext, filter cannedresponse.Filter) {
+	views, total, err := h.manage.List(c.Request.Context(), authCtx.UserID, filter)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+	if views == nil {
+		views = []cannedresponseapp.CannedResponseView{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"canned_responses": views,
+		"total":            total,
+		"limit":            filter.Limit,
+		"offset":           filter.Offset,
+	})
+}
+
+// parseCannedResponseFilter projeto, escopo, pasta (presente e vazia = raiz) e busca
+func parseCannedResponseFilter(c *gin.Context, authCtx *middleware.AuthContext) (cannedresponse.Filter, bool) {
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return cannedresponse.Filter{}, false
+	}
+
+	filter := cannedresponse.Filter{
+		TenantID:  authCtx.TenantID,
+		ProjectID: projectID,
+		Scope:     cannedresponse.Scope(c.DefaultQuery("scope", string(cannedresponse.ScopeAll))),
+		Query:     c.Query("q"),
+	}
+	if folder, present := c.GetQuery("folder"); present {
+		filter.Folder = &folder
+	}
+	return filter, true
+}
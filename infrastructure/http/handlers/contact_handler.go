@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -28,6 +29,7 @@ type ContactHandler struct {
 	// Query handlers
 	listContactsQueryHandler   *queries.ListContactsQueryHandler
 	searchContactsQueryHandler *queries.SearchContactsQueryHandler
+	contactStatsQueryHandler   *queries.GetContactStatsQueryHandler
 }
 
 func NewContactHandler(
@@ -37,6 +39,7 @@ func NewContactHandler(
 	createContactHandler *contactcmd.CreateContactHandler,
 	updateContactHandler *contactcmd.UpdateContactHandler,
 	deleteContactHandler *contactcmd.DeleteContactHandler,
+	contactStatsRepo contact.StatsRepository,
 ) *ContactHandler {
 	return &ContactHandler{
 		logger:                      logger,
@@ -47,6 +50,7 @@ func NewContactHandler(
 		deleteContactHandler:        deleteContactHandler,
 		listContactsQueryHandler:    queries.NewListContactsQueryHandler(contactRepo, logger),
 		searchContactsQueryHandler:  queries.NewSearchContactsQueryHandler(contactRepo, logger),
+		contactStatsQueryHandler:    queries.NewGetContactStatsQueryHandler(contactRepo, contactStatsRepo, logger),
 	}
 }
 
@@ -261,6 +265,54 @@ func (h *ContactHandler) GetContact(c *gin.Context) {
 	c.JSON(http.StatusOK, response)
 }
 
+// GetContactStats gets aggregated activity for a contact
+//
+//	@Summary		Get contact statistics
+//	@Description	Retorna mensagens, sessões, conversões, trackings e status atuais em pipelines do contato
+//	@Tags			CRM - Contacts
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"Contact ID (UUID)"
+//	@Success		200	{object}	queries.GetContactStatsResponse	"Contact statistics"
+//	@Failure		400	{object}	map[string]interface{}			"Invalid contact ID"
+//	@Failure		404	{object}	map[string]interface{}			"Contact not found"
+//	@Failure		500	{object}	map[string]interface{}			"Internal server error"
+//	@Router			/api/v1/contacts/{id}/stats [get]
+func (h *ContactHandler) GetContactStats(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid contact ID format (must be UUID)")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	response, err := h.contactStatsQueryHandler.Handle(c.Request.Context(), queries.GetContactStatsQuery{
+		ContactID: contactID,
+		TenantID:  tenantID,
+	})
+	if err != nil {
+		if errors.Is(err, contact.ErrContactNotFound) {
+			apierrors.NotFound(c, "contact", contactID.String())
+			return
+		}
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
 // UpdateContact updates a contact
 //
 //	@Summary		Update contact
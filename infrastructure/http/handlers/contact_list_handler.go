@@ -0,0 +1,627 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	contactlistapp "github.com/ventros/crm/internal/application/contact_list"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+	"go.uber.org/zap"
+)
+
+type ContactListHandler struct {
+	logger                   *zap.Logger
+	createContactListUseCase *contactlistapp.CreateContactListUseCase
+	updateContactListUseCase *contactlistapp.UpdateContactListUseCase
+	deleteContactListUseCase *contactlistapp.DeleteContactListUseCase
+	getContactListUseCase    *contactlistapp.GetContactListUseCase
+	listContactListsUseCase  *contactlistapp.ListContactListsUseCase
+	getContactsInListUseCase *contactlistapp.GetContactsInListUseCase
+	manageStaticListUseCase  *contactlistapp.ManageStaticListUseCase
+	recalculateUseCase       *contactlistapp.RecalculateContactListUseCase
+	previewUseCase           *contactlistapp.PreviewContactListUseCase
+}
+
+func NewContactListHandler(
+	logger *zap.Logger,
+	createContactListUseCase *contactlistapp.CreateContactListUseCase,
+	updateContactListUseCase *contactlistapp.UpdateContactListUseCase,
+	deleteContactListUseCase *contactlistapp.DeleteContactListUseCase,
+	getContactListUseCase *contactlistapp.GetContactListUseCase,
+	listContactListsUseCase *contactlistapp.ListContactListsUseCase,
+	getContactsInListUseCase *contactlistapp.GetContactsInListUseCase,
+	manageStaticListUseCase *contactlistapp.ManageStaticListUseCase,
+	recalculateUseCase *contactlistapp.RecalculateContactListUseCase,
+	previewUseCase *contactlistapp.PreviewContactListUseCase,
+) *ContactListHandler {
+	return &ContactListHandler{
+		logger:                   logger,
+		createContactListUseCase: createContactListUseCase,
+		updateContactListUseCase: updateContactListUseCase,
+		deleteContactListUseCase: deleteContactListUseCase,
+		getContactListUseCase:    getContactListUseCase,
+		listContactListsUseCase:  listContactListsUseCase,
+		getContactsInListUseCase: getContactsInListUseCase,
+		manageStaticListUseCase:  manageStaticListUseCase,
+		recalculateUseCase:       recalculateUseCase,
+		previewUseCase:           previewUseCase,
+	}
+}
+
+// FilterRuleRequest represents a filter rule of a dynamic contact list
+type FilterRuleRequest struct {
+	FilterType string      `json:"filter_type" binding:"required" example:"tag"`
+	Operator   string      `json:"operator" binding:"required" example:"contains"`
+	FieldKey   string      `json:"field_key" example:"tags"`
+	FieldType  *string     `json:"field_type,omitempty" example:"text"`
+	Value      interface{} `json:"value"`
+	PipelineID *uuid.UUID  `json:"pipeline_id,omitempty"`
+	WithinDays *int        `json:"within_days,omitempty" example:"30"` // event/interaction: conta apenas os últimos N dias
+}
+
+// FilterGroupRequest represents a nested boolean group of filter rules (AND / OR / NOT)
+type FilterGroupRequest struct {
+	Logic  string               `json:"logic" binding:"required" example:"OR"`
+	Rules  []FilterRuleRequest  `json:"rules"`
+	Groups []FilterGroupRequest `json:"groups"`
+}
+
+// CreateContactListRequest represents the request to create a contact list
+type CreateContactListRequest struct {
+	Name            string              `json:"name" binding:"required" example:"VIP customers"`
+	Description     *string             `json:"description,omitempty"`
+	LogicalOperator string              `json:"logical_operator" example:"AND"`
+	IsStatic        bool                `json:"is_static"`
+	FilterRules     []FilterRuleRequest `json:"filter_rules"`
+	FilterGroup     *FilterGroupRequest `json:"filter_group,omitempty"`
+}
+
+// UpdateContactListRequest represents the request to update a contact list
+type UpdateContactListRequest struct {
+	Name            *string              `json:"name,omitempty"`
+	Description     *string              `json:"description,omitempty"`
+	LogicalOperator *string              `json:"logical_operator,omitempty" example:"OR"`
+	FilterRules     *[]FilterRuleRequest `json:"filter_rules,omitempty"`
+	FilterGroup     *FilterGroupRequest  `json:"filter_group,omitempty"` // grupo vazio remove os filtros aninhados
+}
+
+// PreviewContactListRequest represents unsaved filters to be evaluated
+type PreviewContactListRequest struct {
+	LogicalOperator string              `json:"logical_operator" example:"AND"`
+	FilterRules     []FilterRuleRequest `json:"filter_rules"`
+	FilterGroup     *FilterGroupRequest `json:"filter_group,omitempty"`
+}
+
+// AddContactToListRequest represents the request to add a contact to a static list
+type AddContactToListRequest struct {
+	ContactID uuid.UUID `json:"contact_id" binding:"required"`
+}
+
+// CreateContactList creates a new contact list
+//
+//	@Summary		Create contact list
+//	@Description	Create a static or dynamic (filter based) contact list. Dynamic lists are calculated right away.
+//	@Tags			CRM - Contact Lists
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			X-Project-ID	header		string						false	"Project ID (default: project of the credential)"
+//	@Param			list		body		CreateContactListRequest	true	"Contact list data"
+//	@Success		201			{object}	contactlistapp.ContactListDTO	"Contact list created"
+//	@Failure		400			{object}	map[string]interface{}		"Invalid request"
+//	@Failure		401			{object}	map[string]interface{}		"Authentication required"
+//	@Failure		500			{object}	map[string]interface{}		"Internal server error"
+//	@Router			/api/v1/crm/contact-lists [post]
+func (h *ContactListHandler) CreateContactList(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := resolvedProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	var req CreateContactListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	logicalOperator := contact_list.LogicalOperatorAND
+	if req.LogicalOperator != "" {
+		logicalOperator = contact_list.LogicalOperator(req.LogicalOperator)
+	}
+
+	result, err := h.createContactListUseCase.Execute(c.Request.Context(), contactlistapp.CreateContactListRequest{
+		ProjectID:       projectID,
+		TenantID:        authCtx.TenantID,
+		Name:            req.Name,
+		Description:     req.Description,
+		LogicalOperator: logicalOperator,
+		IsStatic:        req.IsStatic,
+		FilterRules:     toFilterRuleRequests(req.FilterRules),
+		FilterGroup:     toFilterGroupRequest(req.FilterGroup),
+	})
+	if err != nil {
+		h.logger.Error("Failed to create contact list", zap.Error(err))
+		respondContactListError(c, err)
+		return
+	}
+
+	h.recalculateDynamicList(c, result.ContactListID, req.IsStatic)
+
+	list, err := h.getContactListUseCase.Execute(c.Request.Context(), result.ContactListID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, list)
+}
+
+// ListContactLists lists the contact lists of a project
+//
+//	@Summary		List contact lists
+//	@Description	List contact lists of a project with pagination
+//	@Tags			CRM - Contact Lists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			X-Project-ID	header		string	false	"Project ID (default: project of the credential)"
+//	@Param			limit		query		int		false	"Limit"		default(50)
+//	@Param			offset		query		int		false	"Offset"	default(0)
+//	@Success		200			{object}	contactlistapp.ListContactListsResponse	"Contact lists"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Failure		401			{object}	map[string]interface{}	"Authentication required"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/crm/contact-lists [get]
+func (h *ContactListHandler) ListContactLists(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := resolvedProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	limit, offset := parsePagination(c)
+
+	result, err := h.listContactListsUseCase.Execute(c.Request.Context(), contactlistapp.ListContactListsRequest{
+		ProjectID: projectID,
+		Limit:     limit,
+		Offset:    offset,
+	})
+	if err != nil {
+		h.logger.Error("Failed to list contact lists", zap.Error(err))
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetContactList gets a contact list by ID
+//
+//	@Summary		Get contact list
+//	@Description	Get a contact list with its filter rules
+//	@Tags			CRM - Contact Lists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Contact list ID"
+//	@Success		200	{object}	contactlistapp.ContactListDTO	"Contact list"
+//	@Failure		400	{object}	map[string]interface{}	"Invalid ID"
+//	@Failure		401	{object}	map[string]interface{}	"Authentication required"
+//	@Failure		404	{object}	map[string]interface{}	"Contact list not found"
+//	@Router			/api/v1/crm/contact-lists/{id} [get]
+func (h *ContactListHandler) GetContactList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// UpdateContactList updates a contact list
+//
+//	@Summary		Update contact list
+//	@Description	Update name, description, logical operator or filter rules (replaces all rules). Dynamic lists are recalculated.
+//	@Tags			CRM - Contact Lists
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string						true	"Contact list ID"
+//	@Param			list	body		UpdateContactListRequest	true	"Fields to update"
+//	@Success		200		{object}	contactlistapp.ContactListDTO	"Contact list updated"
+//	@Failure		400		{object}	map[string]interface{}		"Invalid request"
+//	@Failure		401		{object}	map[string]interface{}		"Authentication required"
+//	@Failure		404		{object}	map[string]interface{}		"Contact list not found"
+//	@Router			/api/v1/crm/contact-lists/{id} [put]
+func (h *ContactListHandler) UpdateContactList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	var req UpdateContactListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	updateReq := contactlistapp.UpdateContactListRequest{
+		ContactListID: list.ID,
+		Name:          req.Name,
+		Description:   req.Description,
+	}
+	if req.LogicalOperator != nil {
+		op := contact_list.LogicalOperator(*req.LogicalOperator)
+		updateReq.LogicalOperator = &op
+	}
+	if req.FilterRules != nil {
+		rules := toFilterRuleRequests(*req.FilterRules)
+		updateReq.FilterRules = &rules
+	}
+	updateReq.FilterGroup = toFilterGroupRequest(req.FilterGroup)
+
+	if err := h.updateContactListUseCase.Execute(c.Request.Context(), updateReq); err != nil {
+		h.logger.Error("Failed to update contact list", zap.Error(err))
+		respondContactListError(c, err)
+		return
+	}
+
+	if req.LogicalOperator != nil || req.FilterRules != nil || req.FilterGroup != nil {
+		h.recalculateDynamicList(c, list.ID, list.IsStatic)
+	}
+
+	updated, err := h.getContactListUseCase.Execute(c.Request.Context(), list.ID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, updated)
+}
+
+// DeleteContactList deletes a contact list
+//
+//	@Summary		Delete contact list
+//	@Description	Soft delete a contact list
+//	@Tags			CRM - Contact Lists
+//	@Security		ApiKeyAuth
+//	@Param			id	path	string	true	"Contact list ID"
+//	@Success		204	"Contact list deleted"
+//	@Failure		401	{object}	map[string]interface{}	"Authentication required"
+//	@Failure		404	{object}	map[string]interface{}	"Contact list not found"
+//	@Router			/api/v1/crm/contact-lists/{id} [delete]
+func (h *ContactListHandler) DeleteContactList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	if err := h.deleteContactListUseCase.Execute(c.Request.Context(), contactlistapp.DeleteContactListRequest{
+		ContactListID: list.ID,
+	}); err != nil {
+		h.logger.Error("Failed to delete contact list", zap.Error(err))
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetContactsInList lists the contacts of a list
+//
+//	@Summary		List contacts in list
+//	@Description	List the contact IDs that belong to a contact list
+//	@Tags			CRM - Contact Lists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id		path		string	true	"Contact list ID"
+//	@Param			limit	query		int		false	"Limit"		default(50)
+//	@Param			offset	query		int		false	"Offset"	default(0)
+//	@Success		200		{object}	contactlistapp.GetContactsInListResponse	"Contacts in list"
+//	@Failure		401		{object}	map[string]interface{}	"Authentication required"
+//	@Failure		404		{object}	map[string]interface{}	"Contact list not found"
+//	@Router			/api/v1/crm/contact-lists/{id}/contacts [get]
+func (h *ContactListHandler) GetContactsInList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	limit, offset := parsePagination(c)
+
+	result, err := h.getContactsInListUseCase.Execute(c.Request.Context(), contactlistapp.GetContactsInListRequest{
+		ContactListID: list.ID,
+		Limit:         limit,
+		Offset:        offset,
+	})
+	if err != nil {
+		h.logger.Error("Failed to get contacts in list", zap.Error(err))
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// AddContactToList adds a contact to a static list
+//
+//	@Summary		Add contact to static list
+//	@Description	Add a contact to a static list (emits contact_list.list_joined)
+//	@Tags			CRM - Contact Lists
+//	@Accept			json
+//	@Security		ApiKeyAuth
+//	@Param			id		path	string					true	"Contact list ID"
+//	@Param			body	body	AddContactToListRequest	true	"Contact"
+//	@Success		204		"Contact added"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request or dynamic list"
+//	@Failure		401		{object}	map[string]interface{}	"Authentication required"
+//	@Failure		404		{object}	map[string]interface{}	"Contact list not found"
+//	@Router			/api/v1/crm/contact-lists/{id}/contacts [post]
+func (h *ContactListHandler) AddContactToList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	var req AddContactToListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.manageStaticListUseCase.AddContact(c.Request.Context(), contactlistapp.AddContactToListRequest{
+		ContactListID: list.ID,
+		ContactID:     req.ContactID,
+	}); err != nil {
+		respondContactListError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveContactFromList removes a contact from a static list
+//
+//	@Summary		Remove contact from static list
+//	@Description	Remove a contact from a static list (emits contact_list.list_left)
+//	@Tags			CRM - Contact Lists
+//	@Security		ApiKeyAuth
+//	@Param			id			path	string	true	"Contact list ID"
+//	@Param			contact_id	path	string	true	"Contact ID"
+//	@Success		204			"Contact removed"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request or dynamic list"
+//	@Failure		401			{object}	map[string]interface{}	"Authentication required"
+//	@Failure		404			{object}	map[string]interface{}	"Contact list not found"
+//	@Router			/api/v1/crm/contact-lists/{id}/contacts/{contact_id} [delete]
+func (h *ContactListHandler) RemoveContactFromList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Param("contact_id"))
+	if err != nil {
+		apierrors.ValidationError(c, "contact_id", "Invalid contact ID format (must be UUID)")
+		return
+	}
+
+	if err := h.manageStaticListUseCase.RemoveContact(c.Request.Context(), contactlistapp.RemoveContactFromListRequest{
+		ContactListID: list.ID,
+		ContactID:     contactID,
+	}); err != nil {
+		respondContactListError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RecalculateContactList recalculates a contact list on demand
+//
+//	@Summary		Recalculate contact list
+//	@Description	Re-evaluate the list filters, emitting list_joined / list_left for every membership change
+//	@Tags			CRM - Contact Lists
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			id	path		string	true	"Contact list ID"
+//	@Success		200	{object}	contactlistapp.RecalculateContactListResponse	"Recalculation result"
+//	@Failure		401	{object}	map[string]interface{}	"Authentication required"
+//	@Failure		404	{object}	map[string]interface{}	"Contact list not found"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/crm/contact-lists/{id}/recalculate [post]
+func (h *ContactListHandler) RecalculateContactList(c *gin.Context) {
+	list, ok := h.loadContactList(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.recalculateUseCase.Execute(c.Request.Context(), list.ID)
+	if err != nil {
+		h.logger.Error("Failed to recalculate contact list", zap.Error(err))
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// PreviewContactList evaluates filters before saving a list
+//
+//	@Summary		Preview contact list
+//	@Description	Evaluate filter rules / nested filter groups without saving, returning the number of matching contacts and a sample
+//	@Tags			CRM - Contact Lists
+//	@Accept			json
+//	@Produce		json
+//	@Security		ApiKeyAuth
+//	@Param			X-Project-ID	header		string						false	"Project ID (default: project of the credential)"
+//	@Param			sample_size	query		int							false	"Sample size (max 50)"	default(10)
+//	@Param			filters		body		PreviewContactListRequest	true	"Filters to evaluate"
+//	@Success		200			{object}	contactlistapp.PreviewContactListResponse	"Preview result"
+//	@Failure		400			{object}	map[string]interface{}		"Invalid filters"
+//	@Failure		401			{object}	map[string]interface{}		"Authentication required"
+//	@Router			/api/v1/crm/contact-lists/preview [post]
+func (h *ContactListHandler) PreviewContactList(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := resolvedProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	var req PreviewContactListRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	sampleSize, _ := strconv.Atoi(c.DefaultQuery("sample_size", "0"))
+
+	result, err := h.previewUseCase.Execute(c.Request.Context(), contactlistapp.PreviewContactListRequest{
+		ProjectID:       projectID,
+		TenantID:        authCtx.TenantID,
+		LogicalOperator: contact_list.LogicalOperator(req.LogicalOperator),
+		FilterRules:     toFilterRuleRequests(req.FilterRules),
+		FilterGroup:     toFilterGroupRequest(req.FilterGroup),
+		SampleSize:      sampleSize,
+	})
+	if err != nil {
+		h.logger.Warn("Failed to preview contact list", zap.Error(err))
+		respondContactListError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// loadContactList busca a lista do path e garante que pertence ao projeto resolvido pelo RBAC
+func (h *ContactListHandler) loadContactList(c *gin.Context) (*contactlistapp.ContactListDTO, bool) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+
+	idStr := c.Param("id")
+	listID, err := uuid.Parse(idStr)
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid contact list ID format (must be UUID)")
+		return nil, false
+	}
+
+	list, err := h.getContactListUseCase.Execute(c.Request.Context(), listID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return nil, false
+	}
+
+	if list.TenantID != authCtx.TenantID || list.ProjectID != authCtx.ProjectID {
+		apierrors.NotFound(c, "contact_list", idStr)
+		return nil, false
+	}
+
+	return list, true
+}
+
+// recalculateDynamicList calcula a lista logo após mudanças nos filtros.
+// Falhas não impedem a resposta: a varredura periódica recalcula a lista depois.
+func (h *ContactListHandler) recalculateDynamicList(c *gin.Context, listID uuid.UUID, isStatic bool) {
+	if isStatic || h.recalculateUseCase == nil {
+		return
+	}
+
+	if _, err := h.recalculateUseCase.Execute(c.Request.Context(), listID); err != nil {
+		h.logger.Warn("Failed to recalculate contact list",
+			zap.String("contact_list_id", listID.String()),
+			zap.Error(err))
+	}
+}
+
+func toFilterRuleRequests(rules []FilterRuleRequest) []contactlistapp.FilterRuleRequest {
+	result := make([]contactlistapp.FilterRuleRequest, len(rules))
+	for i, rule := range rules {
+		result[i] = contactlistapp.FilterRuleRequest{
+			FilterType: contact_list.FilterType(rule.FilterType),
+			Operator:   contact_list.FilterOperator(rule.Operator),
+			FieldKey:   rule.FieldKey,
+			FieldType:  rule.FieldType,
+			Value:      rule.Value,
+			PipelineID: rule.PipelineID,
+			WithinDays: rule.WithinDays,
+		}
+	}
+	return result
+}
+
+func toFilterGroupRequest(group *FilterGroupRequest) *contactlistapp.FilterGroupRequest {
+	if group == nil {
+		return nil
+	}
+
+	result := &contactlistapp.FilterGroupRequest{
+		Logic:  contact_list.LogicalOperator(group.Logic),
+		Rules:  toFilterRuleRequests(group.Rules),
+		Groups: make([]contactlistapp.FilterGroupRequest, 0, len(group.Groups)),
+	}
+	for i := range group.Groups {
+		result.Groups = append(result.Groups, *toFilterGroupRequest(&group.Groups[i]))
+	}
+	return result
+}
+
+// respondContactListError trata erros de validação dos use cases (errors.New) como 400
+func respondContactListError(c *gin.Context, err error) {
+	var domainErr *shared.DomainError
+	if shared.IsDomainError(err, &domainErr) {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+	apierrors.BadRequest(c, err.Error())
+}
+
+// resolvedProjectID projeto escolhido pelo RBACMiddleware (X-Project-ID ou o projeto da credencial).
+// O antigo ?project_id= só é aceito quando aponta para esse mesmo projeto: nunca escolhe o projeto.
+func resolvedProjectID(c *gin.Context, authCtx *middleware.AuthContext) (uuid.UUID, bool) {
+	if projectIDStr := c.Query("project_id"); projectIDStr != "" {
+		projectID, err := uuid.Parse(projectIDStr)
+		if err != nil {
+			apierrors.ValidationError(c, "project_id", "Invalid project_id format (must be UUID)")
+			return uuid.Nil, false
+		}
+		if projectID != authCtx.ProjectID {
+			apierrors.Forbidden(c, "project_id does not match the project of the request ("+middleware.ProjectHeader+" header)")
+			return uuid.Nil, false
+		}
+	}
+
+	return authCtx.ProjectID, true
+}
+
+func parsePagination(c *gin.Context) (int, int) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit <= 0 || limit > 500 {
+		limit = 50
+	}
+	offset, err := strconv.Atoi(c.DefaultQuery("offset", "0"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+	return limit, offset
+}
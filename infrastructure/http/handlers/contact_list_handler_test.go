@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	contactlistapp "github.com/ventros/crm/internal/application/contact_list"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+	"go.uber.org/zap"
+)
+
+// fakeContactListRepository listas em memória; registra os projetos consultados e criados
+type fakeContactListRepository struct {
+	contact_list.Repository
+	lists          map[uuid.UUID]*contact_list.ContactList
+	listedProjects []uuid.UUID
+	created        []*contact_list.ContactList
+	previewed      []*contact_list.ContactList
+}
+
+func (r *fakeContactListRepository) Create(ctx context.Context, list *contact_list.ContactList) error {
+	r.created = append(r.created, list)
+	r.lists[list.ID()] = list
+	return nil
+}
+
+func (r *fakeContactListRepository) FindByID(ctx context.Context, id uuid.UUID) (*contact_list.ContactList, error) {
+	list, ok := r.lists[id]
+	if !ok {
+		return nil, shared.NewNotFoundError("contact_list", id.String())
+	}
+	return list, nil
+}
+
+func (r *fakeContactListRepository) ListByProject(ctx context.Context, projectID uuid.UUID, limit, offset int) ([]*contact_list.ContactList, int, error) {
+	r.listedProjects = append(r.listedProjects, projectID)
+	return nil, 0, nil
+}
+
+func (r *fakeContactListRepository) Preview(ctx context.Context, list *contact_list.ContactList, sampleSize int) (*contact_list.PreviewResult, error) {
+	r.previewed = append(r.previewed, list)
+	return &contact_list.PreviewResult{Sample: []contact_list.ContactSample{}}, nil
+}
+
+type contactListHandlerFixture struct {
+	router    *gin.Engine
+	repo      *fakeContactListRepository
+	projectID uuid.UUID
+}
+
+// newContactListHandlerFixture handler com o AuthContext que o RBACMiddleware deixaria resolvido
+func newContactListHandlerFixture(t *testing.T) *contactListHandlerFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	f := &contactListHandlerFixture{
+		repo:      &fakeContactListRepository{lists: map[uuid.UUID]*contact_list.ContactList{}},
+		projectID: uuid.New(),
+	}
+	handler := NewContactListHandler(zap.NewNop(),
+		contactlistapp.NewCreateContactListUseCase(f.repo), nil, nil,
+		contactlistapp.NewGetContactListUseCase(f.repo),
+		contactlistapp.NewListContactListsUseCase(f.repo),
+		nil, nil, nil, contactlistapp.NewPreviewContactListUseCase(f.repo))
+
+	f.router = gin.New()
+	f.router.Use(func(c *gin.Context) {
+		c.Set("auth", &middleware.AuthContext{UserID: uuid.New(), TenantID: "tenant-a", ProjectID: f.projectID})
+	})
+	f.router.GET("/contact-lists", handler.ListContactLists)
+	f.router.POST("/contact-lists", handler.CreateContactList)
+	f.router.POST("/contact-lists/preview", handler.PreviewContactList)
+	f.router.GET("/contact-lists/:id", handler.GetContactList)
+	return f
+}
+
+func (f *contactListHandlerFixture) do(method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+func TestContactListHandler_RejectsForeignProjectID(t *testing.T) {
+	f := newContactListHandlerFixture(t)
+	foreign := uuid.NewString()
+
+	w := f.do(http.MethodGet, "/contact-lists?project_id="+foreign, "")
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = f.do(http.MethodPost, "/contact-lists?project_id="+foreign, `{"name":"VIP","is_static":true}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	w = f.do(http.MethodPost, "/contact-lists/preview?project_id="+foreign+"&sample_size=50", `{"logical_operator":"AND"}`)
+	assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+
+	assert.Empty(t, f.repo.listedProjects)
+	assert.Empty(t, f.repo.created)
+	assert.Empty(t, f.repo.previewed)
+}
+
+func TestContactListHandler_UsesResolvedProject(t *testing.T) {
+	f := newContactListHandlerFixture(t)
+
+	require.Equal(t, http.StatusOK, f.do(http.MethodGet, "/contact-lists", "").Code)
+	require.Equal(t, http.StatusOK, f.do(http.MethodGet, "/contact-lists?project_id="+f.projectID.String(), "").Code)
+	assert.Equal(t, []uuid.UUID{f.projectID, f.projectID}, f.repo.listedProjects)
+
+	w := f.do(http.MethodPost, "/contact-lists", `{"name":"VIP","is_static":true}`)
+	require.Equal(t, http.StatusCreated, w.Code, w.Body.String())
+	require.Len(t, f.repo.created, 1)
+	assert.Equal(t, f.projectID, f.repo.created[0].ProjectID())
+	assert.Equal(t, "tenant-a", f.repo.created[0].TenantID())
+
+	w = f.do(http.MethodPost, "/contact-lists/preview?sample_size=50", `{"logical_operator":"AND"}`)
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	require.Len(t, f.repo.previewed, 1)
+	assert.Equal(t, f.projectID, f.repo.previewed[0].ProjectID())
+	assert.Equal(t, "tenant-a", f.repo.previewed[0].TenantID())
+}
+
+func TestContactListHandler_HidesListsOfOtherProjects(t *testing.T) {
+	f := newContactListHandlerFixture(t)
+	// Mesmo tenant, outro projeto: a lista não existe para o projeto da requisição
+	other, err := contact_list.NewContactList(uuid.New(), "tenant-a", "Outro projeto", contact_list.LogicalOperatorAND, true)
+	require.NoError(t, err)
+	f.repo.lists[other.ID()] = other
+
+	w := f.do(http.MethodGet, "/contact-lists/"+other.ID().String(), "")
+	assert.Equal(t, http.StatusNotFound, w.Code, w.Body.String())
+
+	own, err := contact_list.NewContactList(f.projectID, "tenant-a", "VIP", contact_list.LogicalOperatorAND, true)
+	require.NoError(t, err)
+	f.repo.lists[own.ID()] = own
+
+	w = f.do(http.MethodGet, "/contact-lists/"+own.ID().String(), "")
+	require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	var body contactlistapp.ContactListDTO
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.Equal(t, own.ID(), body.ID)
+}
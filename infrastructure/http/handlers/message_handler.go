@@ -13,6 +13,7 @@ import (
 	"github.com/ventros/crm/internal/application/queries"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	domainMessage "github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/session"
 	"go.uber.org/zap"
 )
 
@@ -23,9 +24,11 @@ type MessageHandler struct {
 	confirmMessageDeliveryHandler *message.ConfirmMessageDeliveryHandler
 	listMessagesQueryHandler      *queries.ListMessagesQueryHandler
 	searchMessagesQueryHandler    *queries.SearchMessagesQueryHandler
+	messageHistoryQueryHandler    *queries.MessageHistoryQueryHandler
+	conversationQueryHandler      *queries.ConversationThreadQueryHandler
 }
 
-func NewMessageHandler(logger *zap.Logger, messageRepo domainMessage.Repository, sendMessageHandler *message.SendMessageHandler, confirmMessageDeliveryHandler *message.ConfirmMessageDeliveryHandler) *MessageHandler {
+func NewMessageHandler(logger *zap.Logger, messageRepo domainMessage.Repository, historyRepo domainMessage.HistoryRepository, sessionRepo session.Repository, sendMessageHandler *message.SendMessageHandler, confirmMessageDeliveryHandler *message.ConfirmMessageDeliveryHandler) *MessageHandler {
 	return &MessageHandler{
 		logger:                        logger,
 		messageRepo:                   messageRepo,
@@ -33,6 +36,8 @@ func NewMessageHandler(logger *zap.Logger, messageRepo domainMessage.Repository,
 		confirmMessageDeliveryHandler: confirmMessageDeliveryHandler,
 		listMessagesQueryHandler:      queries.NewListMessagesQueryHandler(messageRepo, logger),
 		searchMessagesQueryHandler:    queries.NewSearchMessagesQueryHandler(messageRepo, logger),
+		messageHistoryQueryHandler:    queries.NewMessageHistoryQueryHandler(historyRepo, sessionRepo, logger),
+		conversationQueryHandler:      queries.NewConversationThreadQueryHandler(historyRepo, sessionRepo, logger),
 	}
 }
 
@@ -313,29 +318,182 @@ func (h *MessageHandler) DeleteMessage(c *gin.Context) {
 // GetMessagesBySession gets messages for a specific session
 //
 //	@Summary		Get messages by session
-//	@Description	Get all messages from a specific session
+//	@Description	Histórico de mensagens da sessão com paginação por cursor (keyset por timestamp e id), para infinite scroll nos dois sentidos.
+//	@Description	Sem cursor, direction=older retorna as mensagens mais recentes. Use older_cursor para carregar mensagens anteriores e newer_cursor para buscar novas.
+//	@Description	Transcrições, OCR e parsing de documentos vêm inline em enrichments; mensagens citadas vêm em reply_to.
 //	@Tags			CRM - Messages
 //	@Produce		json
-//	@Param			session_id	path		string					true	"Session ID (UUID)"
-//	@Param			limit		query		int						false	"Limit results"			default(100)
-//	@Param			offset		query		int						false	"Offset for pagination"	default(0)
-//	@Success		200			{array}		map[string]interface{}	"List of messages"
-//	@Failure		400			{object}	map[string]interface{}	"Invalid session ID"
-//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
-//	@Router			/api/v1/sessions/{session_id}/messages [get]
+//	@Security		BearerAuth
+//	@Param			id			path		string							true	"Session ID (UUID)"
+//	@Param			cursor		query		string							false	"Cursor opaco (older_cursor ou newer_cursor)"
+//	@Param			direction	query		string							false	"Sentido da página"	Enums(older, newer)	default(older)
+//	@Param			limit		query		int								false	"Mensagens por página (max 200)"	default(50)
+//	@Success		200			{object}	queries.MessageHistoryResponse	"Message history"
+//	@Failure		400			{object}	map[string]interface{}			"Invalid parameters"
+//	@Failure		404			{object}	map[string]interface{}			"Session not found"
+//	@Failure		500			{object}	map[string]interface{}			"Internal server error"
+//	@Router			/api/v1/crm/sessions/{id}/messages [get]
 func (h *MessageHandler) GetMessagesBySession(c *gin.Context) {
-	sessionIDStr := c.Param("session_id")
-	sessionID, err := uuid.Parse(sessionIDStr)
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID format"})
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
-		"message":    "Session messages retrieval not yet implemented",
-		"session_id": sessionID,
-		"messages":   []interface{}{},
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid session ID format (must be UUID)")
+		return
+	}
+
+	limit, ok := parseHistoryLimit(c)
+	if !ok {
+		return
+	}
+
+	response, err := h.messageHistoryQueryHandler.Handle(c.Request.Context(), queries.MessageHistoryQuery{
+		SessionID: sessionID,
+		TenantID:  tenantID,
+		Cursor:    c.Query("cursor"),
+		Direction: c.Query("direction"),
+		Limit:     limit,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetConversationThread gets the conversation timeline of a contact across sessions
+//
+//	@Summary		Contact conversation thread
+//	@Description	Linha do tempo de mensagens do contato em todas as sessões, agrupada por sessão e paginada por cursor nos dois sentidos.
+//	@Tags			CRM - Messages
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			contact_id	query		string								true	"Contact ID (UUID)"
+//	@Param			channel_id	query		string								false	"Filtrar por canal (UUID)"
+//	@Param			cursor		query		string								false	"Cursor opaco (older_cursor ou newer_cursor)"
+//	@Param			direction	query		string								false	"Sentido da página"	Enums(older, newer)	default(older)
+//	@Param			limit		query		int									false	"Mensagens por página (max 200)"	default(50)
+//	@Success		200			{object}	queries.ConversationThreadResponse	"Conversation thread"
+//	@Failure		400			{object}	map[string]interface{}				"Invalid parameters"
+//	@Failure		500			{object}	map[string]interface{}				"Internal server error"
+//	@Router			/api/v1/crm/messages/conversation [get]
+func (h *MessageHandler) GetConversationThread(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Query("contact_id"))
+	if err != nil {
+		apierrors.ValidationError(c, "contact_id", "contact_id is required and must be a UUID")
+		return
+	}
+
+	query := queries.ConversationThreadQuery{
+		ContactID: contactID,
+		TenantID:  tenantID,
+		Cursor:    c.Query("cursor"),
+		Direction: c.Query("direction"),
+	}
+
+	if channelIDStr := c.Query("channel_id"); channelIDStr != "" {
+		channelID, err := uuid.Parse(channelIDStr)
+		if err != nil {
+			apierrors.ValidationError(c, "channel_id", "Invalid channel ID format (must be UUID)")
+			return
+		}
+		query.ChannelID = &channelID
+	}
+
+	var ok bool
+	if query.Limit, ok = parseHistoryLimit(c); !ok {
+		return
+	}
+
+	response, err := h.conversationQueryHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetMessageThread rebuilds the reply thread of a message
+//
+//	@Summary		Message reply thread
+//	@Description	Reconstrói a cadeia de respostas (reply_to_id) que contém a mensagem: da mensagem raiz até todas as respostas, em ordem cronológica.
+//	@Tags			CRM - Messages
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string							true	"Message ID (UUID)"
+//	@Success		200	{object}	queries.MessageThreadResponse	"Reply thread"
+//	@Failure		400	{object}	map[string]interface{}			"Invalid message ID"
+//	@Failure		404	{object}	map[string]interface{}			"Message not found"
+//	@Failure		500	{object}	map[string]interface{}			"Internal server error"
+//	@Router			/api/v1/crm/messages/{id}/thread [get]
+func (h *MessageHandler) GetMessageThread(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	messageID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid message ID format (must be UUID)")
+		return
+	}
+
+	response, err := h.conversationQueryHandler.HandleMessageThread(c.Request.Context(), queries.MessageThreadQuery{
+		MessageID: messageID,
+		TenantID:  tenantID,
 	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func parseHistoryLimit(c *gin.Context) (int, bool) {
+	limitStr := c.Query("limit")
+	if limitStr == "" {
+		return 0, true
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 0 {
+		apierrors.ValidationError(c, "limit", "limit must be a positive integer")
+		return 0, false
+	}
+
+	return limit, true
 }
 
 // ConfirmMessageDeliveryRequest represents the request to confirm delivery.
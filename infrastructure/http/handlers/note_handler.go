@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -8,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	apierrors "github.com/ventros/crm/infrastructure/http/errors"
 	"github.com/ventros/crm/infrastructure/http/middleware"
+	noteapp "github.com/ventros/crm/internal/application/note"
 	"github.com/ventros/crm/internal/application/queries"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/note"
@@ -19,14 +21,38 @@ type NoteHandler struct {
 	noteRepo                note.Repository
 	listNotesQueryHandler   *queries.ListNotesQueryHandler
 	searchNotesQueryHandler *queries.SearchNotesQueryHandler
+	authors                 *noteapp.AuthorResolver
+	targets                 *noteapp.TargetResolver
+	createNote              *noteapp.CreateNoteUseCase
+	updateNote              *noteapp.UpdateNoteUseCase
+	deleteNote              *noteapp.DeleteNoteUseCase
+	pinNote                 *noteapp.PinNoteUseCase
+	uploadAttachment        *noteapp.UploadAttachmentUseCase // nil quando não há storage configurado
 }
 
-func NewNoteHandler(logger *zap.Logger, noteRepo note.Repository) *NoteHandler {
+func NewNoteHandler(
+	logger *zap.Logger,
+	noteRepo note.Repository,
+	authors *noteapp.AuthorResolver,
+	targets *noteapp.TargetResolver,
+	createNote *noteapp.CreateNoteUseCase,
+	updateNote *noteapp.UpdateNoteUseCase,
+	deleteNote *noteapp.DeleteNoteUseCase,
+	pinNote *noteapp.PinNoteUseCase,
+	uploadAttachment *noteapp.UploadAttachmentUseCase,
+) *NoteHandler {
 	return &NoteHandler{
 		logger:                  logger,
 		noteRepo:                noteRepo,
 		listNotesQueryHandler:   queries.NewListNotesQueryHandler(noteRepo, logger),
 		searchNotesQueryHandler: queries.NewSearchNotesQueryHandler(noteRepo, logger),
+		authors:                 authors,
+		targets:                 targets,
+		createNote:              createNote,
+		updateNote:              updateNote,
+		deleteNote:              deleteNote,
+		pinNote:                 pinNote,
+		uploadAttachment:        uploadAttachment,
 	}
 }
 
@@ -294,3 +320,542 @@ func (h *NoteHandler) SearchNotes(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// NoteRequest corpo para criar nota em um contato ou sessão
+type NoteRequest struct {
+	Content         string   `json:"content" binding:"required" example:"Cliente pediu retorno amanhã às 10h"`
+	NoteType        string   `json:"note_type" example:"follow_up"`
+	Priority        string   `json:"priority" example:"normal"`
+	VisibleToClient bool     `json:"visible_to_client"`
+	Tags            []string `json:"tags"`
+	Mentions        []string `json:"mentions"` // IDs dos agentes mencionados (notificados)
+}
+
+// UpdateNoteRequest corpo para editar nota; campos omitidos não mudam
+type UpdateNoteRequest struct {
+	Content         *string   `json:"content"`
+	Priority        *string   `json:"priority"`
+	VisibleToClient *bool     `json:"visible_to_client"`
+	Tags            *[]string `json:"tags"`
+	Mentions        *[]string `json:"mentions"` // lista completa; só os novos são notificados
+}
+
+// ListContactNotes lists the notes of a contact
+//
+//	@Summary		List contact notes
+//	@Description	Lista as notas de um contato (mais recentes primeiro). Use pinned=true para só as fixadas.
+//	@Tags			CRM - Notes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Contact ID (UUID)"
+//	@Param			pinned	query		bool						false	"Only pinned notes"
+//	@Param			page	query		int							false	"Page number"		default(1)
+//	@Param			limit	query		int							false	"Items per page"	default(20)
+//	@Success		200		{object}	queries.ListNotesResponse	"Notes"
+//	@Failure		404		{object}	map[string]interface{}		"Contact not found"
+//	@Router			/api/v1/contacts/{id}/notes [get]
+func (h *NoteHandler) ListContactNotes(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	contactID, ok := pathUUID(c, "id", "contact")
+	if !ok {
+		return
+	}
+
+	if err := h.targets.Contact(c.Request.Context(), authCtx.TenantID, contactID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	h.listNotes(c, authCtx.TenantID, queries.ListNotesQuery{ContactID: &contactID})
+}
+
+// ListSessionNotes lists the notes of a session
+//
+//	@Summary		List session notes
+//	@Description	Lista as notas registradas durante uma sessão (conversa).
+//	@Tags			CRM - Notes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Session ID (UUID)"
+//	@Param			pinned	query		bool						false	"Only pinned notes"
+//	@Param			page	query		int							false	"Page number"		default(1)
+//	@Param			limit	query		int							false	"Items per page"	default(20)
+//	@Success		200		{object}	queries.ListNotesResponse	"Notes"
+//	@Failure		404		{object}	map[string]interface{}		"Session not found"
+//	@Router			/api/v1/crm/sessions/{id}/notes [get]
+func (h *NoteHandler) ListSessionNotes(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	sessionID, ok := pathUUID(c, "id", "session")
+	if !ok {
+		return
+	}
+
+	if _, err := h.targets.Session(c.Request.Context(), authCtx.TenantID, sessionID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	h.listNotes(c, authCtx.TenantID, queries.ListNotesQuery{SessionID: &sessionID})
+}
+
+// ListMyMentions lists the notes that mention the authenticated agent
+//
+//	@Summary		Mentions inbox
+//	@Description	Caixa de menções do agente autenticado: notas em que ele foi mencionado, mais recentes primeiro.
+//	@Tags			CRM - Notes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			page	query		int							false	"Page number"		default(1)
+//	@Param			limit	query		int							false	"Items per page"	default(20)
+//	@Success		200		{object}	queries.ListNotesResponse	"Notes mentioning the agent"
+//	@Failure		404		{object}	map[string]interface{}		"User has no agent in this tenant"
+//	@Router			/api/v1/crm/notes/mentions [get]
+func (h *NoteHandler) ListMyMentions(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	a, err := h.authors.AgentForUser(c.Request.Context(), authCtx.TenantID, authCtx.UserID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	agentID := a.ID()
+	h.listNotes(c, authCtx.TenantID, queries.ListNotesQuery{MentionedAgent: &agentID})
+}
+
+// CreateContactNote creates a note on a contact
+//
+//	@Summary		Create contact note
+//	@Description	Cria uma nota no contato. Agentes em `mentions` são notificados in-app (websocket `note_mention`) e, se habilitado no agente, por email.
+//	@Tags			CRM - Notes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Contact ID (UUID)"
+//	@Param			request	body		NoteRequest				true	"Note"
+//	@Success		201		{object}	queries.NoteDTO			"Note created"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Contact not found"
+//	@Router			/api/v1/contacts/{id}/notes [post]
+func (h *NoteHandler) CreateContactNote(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	contactID, ok := pathUUID(c, "id", "contact")
+	if !ok {
+		return
+	}
+
+	if err := h.targets.Contact(c.Request.Context(), authCtx.TenantID, contactID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	h.create(c, authCtx, contactID, nil)
+}
+
+// CreateSessionNote creates a note on a session
+//
+//	@Summary		Create session note
+//	@Description	Cria uma nota vinculada à sessão (e ao contato da sessão). Menções são notificadas como em notas de contato.
+//	@Tags			CRM - Notes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Session ID (UUID)"
+//	@Param			request	body		NoteRequest				true	"Note"
+//	@Success		201		{object}	queries.NoteDTO			"Note created"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Session not found"
+//	@Router			/api/v1/crm/sessions/{id}/notes [post]
+func (h *NoteHandler) CreateSessionNote(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	sessionID, ok := pathUUID(c, "id", "session")
+	if !ok {
+		return
+	}
+
+	contactID, err := h.targets.Session(c.Request.Context(), authCtx.TenantID, sessionID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	h.create(c, authCtx, contactID, &sessionID)
+}
+
+// GetNote returns a note
+//
+//	@Summary		Get note
+//	@Tags			CRM - Notes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Note ID (UUID)"
+//	@Success		200	{object}	queries.NoteDTO			"Note"
+//	@Failure		404	{object}	map[string]interface{}	"Note not found"
+//	@Router			/api/v1/crm/notes/{id} [get]
+func (h *NoteHandler) GetNote(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	noteID, ok := pathUUID(c, "id", "note")
+	if !ok {
+		return
+	}
+
+	n, err := h.noteRepo.FindByID(c.Request.Context(), noteID)
+	if err != nil {
+		if errors.Is(err, note.ErrNoteNotFound) {
+			apierrors.NotFound(c, "note", noteID.String())
+			return
+		}
+		apierrors.InternalError(c, "Failed to load note", err)
+		return
+	}
+	if n.TenantID() != authCtx.TenantID {
+		apierrors.NotFound(c, "note", noteID.String())
+		return
+	}
+
+	c.JSON(http.StatusOK, queries.NewNoteDTO(n))
+}
+
+// UpdateNote edits a note
+//
+//	@Summary		Update note
+//	@Description	Edita conteúdo, prioridade, visibilidade, tags e menções. `mentions` é a lista completa; apenas agentes recém-mencionados são notificados.
+//	@Tags			CRM - Notes
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Note ID (UUID)"
+//	@Param			request	body		UpdateNoteRequest		true	"Changes"
+//	@Success		200		{object}	queries.NoteDTO			"Note updated"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Note not found"
+//	@Router			/api/v1/crm/notes/{id} [put]
+func (h *NoteHandler) UpdateNote(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	noteID, ok := pathUUID(c, "id", "note")
+	if !ok {
+		return
+	}
+
+	var req UpdateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	cmd := noteapp.UpdateNoteCommand{
+		NoteID:          noteID,
+		TenantID:        authCtx.TenantID,
+		UpdatedBy:       h.authors.Resolve(c.Request.Context(), authCtx.TenantID, authCtx.UserID, authCtx.Email).ID,
+		Content:         req.Content,
+		VisibleToClient: req.VisibleToClient,
+		Tags:            req.Tags,
+	}
+	if req.Priority != nil {
+		priority := note.Priority(*req.Priority)
+		cmd.Priority = &priority
+	}
+	if req.Mentions != nil {
+		mentions, ok := parseMentions(c, *req.Mentions)
+		if !ok {
+			return
+		}
+		cmd.Mentions = &mentions
+	}
+
+	n, err := h.updateNote.Execute(c.Request.Context(), cmd)
+	if err != nil {
+		respondNoteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queries.NewNoteDTO(n))
+}
+
+// DeleteNote removes a note
+//
+//	@Summary		Delete note
+//	@Tags			CRM - Notes
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Note ID (UUID)"
+//	@Success		204	"Note deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Note not found"
+//	@Router			/api/v1/crm/notes/{id} [delete]
+func (h *NoteHandler) DeleteNote(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	noteID, ok := pathUUID(c, "id", "note")
+	if !ok {
+		return
+	}
+
+	deletedBy := h.authors.Resolve(c.Request.Context(), authCtx.TenantID, authCtx.UserID, authCtx.Email).ID
+	if err := h.deleteNote.Execute(c.Request.Context(), authCtx.TenantID, noteID, deletedBy); err != nil {
+		respondNoteError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PinNote pins a note to the top of the contact
+//
+//	@Summary		Pin note
+//	@Tags			CRM - Notes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Note ID (UUID)"
+//	@Success		200	{object}	queries.NoteDTO			"Note pinned"
+//	@Failure		404	{object}	map[string]interface{}	"Note not found"
+//	@Router			/api/v1/crm/notes/{id}/pin [post]
+func (h *NoteHandler) PinNote(c *gin.Context) {
+	h.setPinned(c, true)
+}
+
+// UnpinNote removes the pin of a note
+//
+//	@Summary		Unpin note
+//	@Tags			CRM - Notes
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Note ID (UUID)"
+//	@Success		200	{object}	queries.NoteDTO			"Note unpinned"
+//	@Failure		404	{object}	map[string]interface{}	"Note not found"
+//	@Router			/api/v1/crm/notes/{id}/pin [delete]
+func (h *NoteHandler) UnpinNote(c *gin.Context) {
+	h.setPinned(c, false)
+}
+
+// UploadNoteAttachment uploads a file and attaches it to a note
+//
+//	@Summary		Upload note attachment
+//	@Description	Envia o arquivo ao storage configurado e anexa a URL à nota (máx. 25MB).
+//	@Tags			CRM - Notes
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			file	formData	file					true	"File to attach (max 25MB)"
+//	@Param			id		path		string					true	"Note ID (UUID)"
+//	@Success		201		{object}	queries.NoteDTO			"Attachment added"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid file"
+//	@Failure		404		{object}	map[string]interface{}	"Note not found"
+//	@Failure		503		{object}	map[string]interface{}	"Storage not configured"
+//	@Router			/api/v1/crm/notes/{id}/attachments [post]
+func (h *NoteHandler) UploadNoteAttachment(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	if h.uploadAttachment == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Attachment storage is not configured"})
+		return
+	}
+
+	noteID, ok := pathUUID(c, "id", "note")
+	if !ok {
+		return
+	}
+
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		apierrors.ValidationError(c, "file", "No file provided")
+		return
+	}
+	defer file.Close()
+
+	contentType := header.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	n, _, err := h.uploadAttachment.Execute(c.Request.Context(), noteapp.UploadAttachmentCommand{
+		NoteID:      noteID,
+		TenantID:    authCtx.TenantID,
+		UploadedBy:  h.authors.Resolve(c.Request.Context(), authCtx.TenantID, authCtx.UserID, authCtx.Email).ID,
+		Filename:    header.Filename,
+		ContentType: contentType,
+		Size:        header.Size,
+		File:        file,
+	})
+	if err != nil {
+		respondNoteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, queries.NewNoteDTO(n))
+}
+
+func (h *NoteHandler) setPinned(c *gin.Context, pinned bool) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	noteID, ok := pathUUID(c, "id", "note")
+	if !ok {
+		return
+	}
+
+	userID := h.authors.Resolve(c.Request.Context(), authCtx.TenantID, authCtx.UserID, authCtx.Email).ID
+	n, err := h.pinNote.Execute(c.Request.Context(), authCtx.TenantID, noteID, userID, pinned)
+	if err != nil {
+		respondNoteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, queries.NewNoteDTO(n))
+}
+
+// create cria a nota com o usuário autenticado como autor
+func (h *NoteHandler) create(c *gin.Context, authCtx *middleware.AuthContext, contactID uuid.UUID, sessionID *uuid.UUID) {
+	var req NoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	priority := note.PriorityNormal
+	if req.Priority != "" {
+		priority = note.Priority(req.Priority)
+		if !priority.IsValid() {
+			apierrors.ValidationError(c, "priority", "Priority must be one of: low, normal, high, urgent")
+			return
+		}
+	}
+
+	noteType := note.NoteTypeGeneral
+	if req.NoteType != "" {
+		noteType = note.NoteType(req.NoteType)
+	}
+
+	mentions, ok := parseMentions(c, req.Mentions)
+	if !ok {
+		return
+	}
+
+	author := h.authors.Resolve(c.Request.Context(), authCtx.TenantID, authCtx.UserID, authCtx.Email)
+	n, err := h.createNote.Execute(c.Request.Context(), noteapp.CreateNoteCommand{
+		ContactID:       contactID,
+		SessionID:       sessionID,
+		TenantID:        authCtx.TenantID,
+		AuthorID:        author.ID,
+		AuthorType:      author.Type,
+		AuthorName:      author.Name,
+		Content:         req.Content,
+		NoteType:        noteType,
+		Priority:        priority,
+		VisibleToClient: req.VisibleToClient,
+		Tags:            req.Tags,
+		Mentions:        mentions,
+	})
+	if err != nil {
+		respondNoteError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, queries.NewNoteDTO(n))
+}
+
+// listNotes aplica paginação e o filtro pinned ao escopo informado
+func (h *NoteHandler) listNotes(c *gin.Context, tenantID string, query queries.ListNotesQuery) {
+	tid, err := shared.NewTenantID(tenantID)
+	if err != nil {
+		h.logger.Error("Invalid tenant ID", zap.Error(err))
+		apierrors.InternalError(c, "Invalid tenant configuration", err)
+		return
+	}
+
+	query.TenantID = tid
+	query.Page = 1
+	if p, err := strconv.Atoi(c.Query("page")); err == nil && p > 0 {
+		query.Page = p
+	}
+	query.Limit = 20
+	if l, err := strconv.Atoi(c.Query("limit")); err == nil && l > 0 && l <= 100 {
+		query.Limit = l
+	}
+	query.SortBy = "created_at"
+	query.SortDir = "desc"
+	if pinnedStr := c.Query("pinned"); pinnedStr != "" {
+		pinned := pinnedStr == "true"
+		query.Pinned = &pinned
+	}
+
+	response, err := h.listNotesQueryHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		h.logger.Error("Failed to list notes", zap.Error(err))
+		apierrors.InternalError(c, "Failed to list notes", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseMentions converte os IDs de agentes mencionados
+func parseMentions(c *gin.Context, values []string) ([]uuid.UUID, bool) {
+	mentions := make([]uuid.UUID, 0, len(values))
+	for _, value := range values {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			apierrors.ValidationError(c, "mentions", "Invalid agent ID format (must be UUID): "+value)
+			return nil, false
+		}
+		mentions = append(mentions, id)
+	}
+	return mentions, true
+}
+
+// respondNoteError traduz os erros de validação do agregado de nota
+func respondNoteError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, note.ErrEmptyContent):
+		apierrors.ValidationError(c, "content", err.Error())
+	case errors.Is(err, note.ErrInvalidPriority):
+		apierrors.ValidationError(c, "priority", err.Error())
+	case errors.Is(err, note.ErrInvalidContact):
+		apierrors.ValidationError(c, "contact_id", err.Error())
+	default:
+		apierrors.RespondWithError(c, err)
+	}
+}
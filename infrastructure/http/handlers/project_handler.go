@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	apierrors "github.com/ventros/crm/infrastructure/http/errors"
 	"github.com/ventros/crm/infrastructure/http/middleware"
+	projectapp "github.com/ventros/crm/internal/application/project"
 	"github.com/ventros/crm/internal/application/queries"
 	"github.com/ventros/crm/internal/domain/core/project"
 	"github.com/ventros/crm/internal/domain/core/shared"
@@ -17,58 +18,62 @@ import (
 type ProjectHandler struct {
 	logger                     *zap.Logger
 	projectRepo                project.Repository
+	manageProjects             *projectapp.ManageProjectsUseCase
 	listProjectsQueryHandler   *queries.ListProjectsQueryHandler
 	searchProjectsQueryHandler *queries.SearchProjectsQueryHandler
 }
 
-func NewProjectHandler(logger *zap.Logger, projectRepo project.Repository) *ProjectHandler {
+func NewProjectHandler(logger *zap.Logger, projectRepo project.Repository, manageProjects *projectapp.ManageProjectsUseCase) *ProjectHandler {
 	return &ProjectHandler{
 		logger:                     logger,
 		projectRepo:                projectRepo,
+		manageProjects:             manageProjects,
 		listProjectsQueryHandler:   queries.NewListProjectsQueryHandler(projectRepo, logger),
 		searchProjectsQueryHandler: queries.NewSearchProjectsQueryHandler(projectRepo, logger),
 	}
 }
 
-// Removed generic helpers - using direct uuid.Parse instead
-
 // CreateProjectRequest representa o payload para criar um projeto
 type CreateProjectRequest struct {
-	Name        string `json:"name" binding:"required" example:"Projeto Vendas"`
+	Name        string `json:"name" binding:"required,max=100" example:"Projeto Vendas"`
 	Description string `json:"description" example:"Projeto principal de vendas"`
-	TenantID    string `json:"tenant_id" binding:"required" example:"tenant_123"`
+	// TenantID opcional: sem ele um identificador novo é gerado
+	TenantID string `json:"tenant_id,omitempty" binding:"omitempty,min=3,max=50" example:"tenant_123"`
 }
 
 // UpdateProjectRequest representa o payload para atualizar um projeto
 type UpdateProjectRequest struct {
-	Name        *string `json:"name,omitempty"`
+	Name        *string `json:"name,omitempty" binding:"omitempty,max=100"`
 	Description *string `json:"description,omitempty"`
 	Active      *bool   `json:"active,omitempty"`
 }
 
-// ListProjects lists all projects with optional filters
+// ListProjects lists the projects of the authenticated user
 //
-//	@Summary		List projects
-//	@Description	Lista todos os projetos do usuário autenticado
+//	@Summary		List my projects
+//	@Description	Projetos do usuário autenticado: os que ele é dono (role admin, owner=true) e os que
+//	@Description	participa como membro, com o papel em cada um. Use o ID no header X-Project-ID para
+//	@Description	trabalhar em outro projeto.
 //	@Tags			CRM - Projects
 //	@Produce		json
-//	@Security		ApiKeyAuth
-//	@Param			tenant_id	query		string					false	"Filter by tenant ID"
-//	@Param			active		query		bool					false	"Filter by active status"
-//	@Param			limit		query		int						false	"Limit results"			default(50)
-//	@Param			offset		query		int						false	"Offset for pagination"	default(0)
-//	@Success		200			{array}		map[string]interface{}	"List of projects"
-//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
-//	@Failure		401			{object}	map[string]interface{}	"Not authenticated"
-//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
-//	@Router			/api/v1/projects [get]
+//	@Security		BearerAuth
+//	@Success		200	{object}	map[string]interface{}	"List of projects"
+//	@Failure		401	{object}	map[string]interface{}	"Not authenticated"
+//	@Failure		403	{object}	map[string]interface{}	"API keys are scoped to one project"
+//	@Router			/api/v1/crm/projects [get]
 func (h *ProjectHandler) ListProjects(c *gin.Context) {
-	// TODO: Implementar listagem real filtrada por user_id via RLS
-	// RLS middleware já filtra por user_id automaticamente
-	projects := []map[string]interface{}{}
+	authCtx, ok := projectUserContext(c)
+	if !ok {
+		return
+	}
+
+	projects, err := h.manageProjects.ListForUser(c.Request.Context(), authCtx.UserID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"message":  "Projects retrieved successfully",
 		"projects": projects,
 		"count":    len(projects),
 	})
@@ -77,121 +82,155 @@ func (h *ProjectHandler) ListProjects(c *gin.Context) {
 // CreateProject creates a new project
 //
 //	@Summary		Create project
-//	@Description	Cria um novo projeto
+//	@Description	Cria um projeto com o usuário logado como dono, na mesma conta de faturamento dos
+//	@Description	projetos que ele já possui.
 //	@Tags			CRM - Projects
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			project	body		CreateProjectRequest	true	"Project data"
-//	@Success		201		{object}	map[string]interface{}	"Project created successfully"
+//	@Success		201		{object}	projectapp.ProjectView	"Project created successfully"
 //	@Failure		400		{object}	map[string]interface{}	"Invalid request"
-//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
-//	@Router			/api/v1/projects [post]
+//	@Failure		409		{object}	map[string]interface{}	"Tenant ID already in use"
+//	@Failure		412		{object}	map[string]interface{}	"No billing account"
+//	@Router			/api/v1/crm/projects [post]
 func (h *ProjectHandler) CreateProject(c *gin.Context) {
+	authCtx, ok := projectUserContext(c)
+	if !ok {
+		return
+	}
+
 	var req CreateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("Failed to parse project request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
 		return
 	}
 
-	// TODO: Implement project creation
-	c.JSON(http.StatusCreated, gin.H{
-		"message":     "Project creation not yet implemented",
-		"name":        req.Name,
-		"description": req.Description,
-		"tenant_id":   req.TenantID,
+	view, err := h.manageProjects.Create(c.Request.Context(), projectapp.CreateProjectCommand{
+		UserID:      authCtx.UserID,
+		Name:        req.Name,
+		Description: req.Description,
+		TenantID:    req.TenantID,
 	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, view)
 }
 
 // GetProject gets a project by ID
 //
 //	@Summary		Get project by ID
-//	@Description	Obtém detalhes de um projeto específico
+//	@Description	Detalhes do projeto e o papel de quem consulta (exige ser membro).
 //	@Tags			CRM - Projects
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path		string					true	"Project ID (UUID)"
-//	@Success		200	{object}	map[string]interface{}	"Project details"
+//	@Success		200	{object}	projectapp.ProjectView	"Project details"
 //	@Failure		400	{object}	map[string]interface{}	"Invalid project ID"
-//	@Failure		404	{object}	map[string]interface{}	"Project not found"
-//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
-//	@Router			/api/v1/projects/{id} [get]
+//	@Failure		403	{object}	map[string]interface{}	"Not a project member"
+//	@Router			/api/v1/crm/projects/{id} [get]
 func (h *ProjectHandler) GetProject(c *gin.Context) {
-	idStr := c.Param("id")
-	projectID, err := uuid.Parse(idStr)
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	view, err := h.manageProjects.Get(c.Request.Context(), access.ProjectID, access.Role, access.Owner)
 	if err != nil {
-		h.logger.Error("Invalid project ID", zap.String("id", idStr), zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+		apierrors.RespondWithError(c, err)
 		return
 	}
 
-	// TODO: Implement project retrieval
-	c.JSON(http.StatusOK, gin.H{
-		"message":    "Project retrieval not yet implemented",
-		"project_id": projectID,
-	})
+	c.JSON(http.StatusOK, view)
 }
 
 // UpdateProject updates a project
 //
 //	@Summary		Update project
-//	@Description	Atualiza um projeto existente
+//	@Description	Renomeia, altera a descrição ou ativa/desativa o projeto. Exige settings.manage.
 //	@Tags			CRM - Projects
 //	@Accept			json
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id		path		string					true	"Project ID (UUID)"
 //	@Param			project	body		UpdateProjectRequest	true	"Project update data"
-//	@Success		200		{object}	map[string]interface{}	"Project updated successfully"
+//	@Success		200		{object}	projectapp.ProjectView	"Project updated successfully"
 //	@Failure		400		{object}	map[string]interface{}	"Invalid request"
-//	@Failure		404		{object}	map[string]interface{}	"Project not found"
-//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
-//	@Router			/api/v1/projects/{id} [put]
+//	@Failure		403		{object}	map[string]interface{}	"Insufficient permissions"
+//	@Router			/api/v1/crm/projects/{id} [put]
 func (h *ProjectHandler) UpdateProject(c *gin.Context) {
-	idStr := c.Param("id")
-	projectID, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+	access, ok := projectAccess(c)
+	if !ok {
 		return
 	}
 
 	var req UpdateProjectRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
-		h.logger.Error("Failed to parse update request", zap.Error(err))
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
 		return
 	}
 
-	// TODO: Implement project update
-	c.JSON(http.StatusOK, gin.H{
-		"message":    "Project update not yet implemented",
-		"project_id": projectID,
+	view, err := h.manageProjects.Update(c.Request.Context(), projectapp.UpdateProjectCommand{
+		ProjectID:   access.ProjectID,
+		Name:        req.Name,
+		Description: req.Description,
+		Active:      req.Active,
 	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+	view.Role, view.Owner = access.Role, access.Owner
+
+	c.JSON(http.StatusOK, view)
 }
 
 // DeleteProject deletes a project
 //
 //	@Summary		Delete project
-//	@Description	Remove um projeto (soft delete)
+//	@Description	Remove o projeto (soft delete). Só o dono, com step-up recente, e desde que ele
+//	@Description	continue com outro projeto ativo.
 //	@Tags			CRM - Projects
 //	@Produce		json
+//	@Security		BearerAuth
 //	@Param			id	path	string	true	"Project ID (UUID)"
 //	@Success		204	"Project deleted successfully"
-//	@Failure		400	{object}	map[string]interface{}	"Invalid project ID"
-//	@Failure		404	{object}	map[string]interface{}	"Project not found"
-//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
-//	@Router			/api/v1/projects/{id} [delete]
+//	@Failure		403	{object}	map[string]interface{}	"Not the owner"
+//	@Failure		412	{object}	map[string]interface{}	"Last active project"
+//	@Router			/api/v1/crm/projects/{id} [delete]
 func (h *ProjectHandler) DeleteProject(c *gin.Context) {
-	idStr := c.Param("id")
-	projectID, err := uuid.Parse(idStr)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid project ID format"})
+	authCtx, ok := projectUserContext(c)
+	if !ok {
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
 		return
 	}
 
-	// TODO: Implement project deletion
-	c.JSON(http.StatusOK, gin.H{
-		"message":    "Project deletion not yet implemented",
-		"project_id": projectID,
-	})
+	if err := h.manageProjects.Delete(c.Request.Context(), access.ProjectID, authCtx.UserID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// projectUserContext ações que valem para a conta do usuário, fora do projeto da credencial
+func projectUserContext(c *gin.Context) (*middleware.AuthContext, bool) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return nil, false
+	}
+	if authCtx.IsAPIKey() {
+		apierrors.Forbidden(c, "API keys are scoped to one project and cannot manage projects")
+		return nil, false
+	}
+	return authCtx, true
 }
 
 // ListProjectsAdvanced lists projects with advanced filters, pagination, and sorting
@@ -0,0 +1,419 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// ProjectMemberHandler membros do projeto, convites por email e transferência de dono
+type ProjectMemberHandler struct {
+	logger      *zap.Logger
+	members     *projectapp.ManageMembersUseCase
+	invitations *projectapp.ManageInvitationsUseCase
+}
+
+func NewProjectMemberHandler(logger *zap.Logger, members *projectapp.ManageMembersUseCase, invitations *projectapp.ManageInvitationsUseCase) *ProjectMemberHandler {
+	return &ProjectMemberHandler{
+		logger:      logger,
+		members:     members,
+		invitations: invitations,
+	}
+}
+
+// ChangeMemberRoleRequest novo papel do membro
+type ChangeMemberRoleRequest struct {
+	Role project_member.ProjectMemberRole `json:"role" binding:"required" example:"supervisor"`
+}
+
+// TransferOwnershipRequest membro que passa a ser o dono
+type TransferOwnershipRequest struct {
+	UserID uuid.UUID `json:"user_id" binding:"required" example:"550e8400-e29b-41d4-a716-446655440000"`
+}
+
+// InviteMemberRequest convite por email
+type InviteMemberRequest struct {
+	Email string                           `json:"email" binding:"required" example:"maria@example.com"`
+	Role  project_member.ProjectMemberRole `json:"role" binding:"required" example:"agent"`
+}
+
+// InvitationTokenRequest token recebido no email do convite
+type InvitationTokenRequest struct {
+	Token string `json:"token" binding:"required" example:"vti_..."`
+}
+
+// ListMembers lists the members of a project
+//
+//	@Summary		List project members
+//	@Description	Dono (sempre admin, owner=true) e membros do projeto, com papel, nome e email.
+//	@Tags			CRM - Project Members
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Project ID (UUID)"
+//	@Success		200	{object}	map[string]interface{}	"Members"
+//	@Failure		403	{object}	map[string]interface{}	"Insufficient permissions"
+//	@Router			/api/v1/crm/projects/{id}/members [get]
+func (h *ProjectMemberHandler) ListMembers(c *gin.Context) {
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	members, err := h.members.List(c.Request.Context(), access.ProjectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"members": members,
+		"count":   len(members),
+	})
+}
+
+// ChangeMemberRole changes the role of a member
+//
+//	@Summary		Change member role
+//	@Description	Troca o papel (admin, supervisor, agent, viewer ou custom:<slug> do projeto). Ninguém troca o próprio papel e o
+//	@Description	papel do dono é fixo (transfira o projeto antes).
+//	@Tags			CRM - Project Members
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Project ID (UUID)"
+//	@Param			user_id	path		string					true	"Member user ID"
+//	@Param			request	body		ChangeMemberRoleRequest	true	"New role"
+//	@Success		200		{object}	projectapp.MemberView	"Updated member"
+//	@Failure		403		{object}	map[string]interface{}	"Role with a permission the caller does not have"
+//	@Failure		404		{object}	map[string]interface{}	"Member not found"
+//	@Failure		412		{object}	map[string]interface{}	"Owner role cannot change"
+//	@Router			/api/v1/crm/projects/{id}/members/{user_id}/role [put]
+func (h *ProjectMemberHandler) ChangeMemberRole(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	userID, ok := pathUUID(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	var req ChangeMemberRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	member, err := h.members.ChangeRole(c.Request.Context(), projectapp.ChangeMemberRoleCommand{
+		ProjectID:            access.ProjectID,
+		UserID:               userID.String(),
+		Role:                 req.Role,
+		ChangedBy:            authCtx.UserID,
+		GrantablePermissions: grantablePermissions(access),
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, member)
+}
+
+// RemoveMember removes a member from the project
+//
+//	@Summary		Remove member
+//	@Description	Tira o membro do projeto. O dono não pode ser removido.
+//	@Tags			CRM - Project Members
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string	true	"Project ID (UUID)"
+//	@Param			user_id	path	string	true	"Member user ID"
+//	@Success		204		"Member removed"
+//	@Failure		404		{object}	map[string]interface{}	"Member not found"
+//	@Failure		412		{object}	map[string]interface{}	"Owner cannot be removed"
+//	@Router			/api/v1/crm/projects/{id}/members/{user_id} [delete]
+func (h *ProjectMemberHandler) RemoveMember(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	userID, ok := pathUUID(c, "user_id", "user")
+	if !ok {
+		return
+	}
+
+	if err := h.members.Remove(c.Request.Context(), access.ProjectID, userID.String(), authCtx.UserID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// TransferOwnership transfers the project to another member
+//
+//	@Summary		Transfer project ownership
+//	@Description	Passa o projeto para outro membro, que vira admin; o antigo dono continua como admin.
+//	@Description	Só o dono, com step-up recente. A conta de faturamento não muda.
+//	@Tags			CRM - Project Members
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Project ID (UUID)"
+//	@Param			request	body		TransferOwnershipRequest	true	"New owner"
+//	@Success		200		{object}	projectapp.MemberView		"New owner"
+//	@Failure		403		{object}	map[string]interface{}		"Not the owner or step-up required"
+//	@Failure		412		{object}	map[string]interface{}		"New owner is not a member"
+//	@Router			/api/v1/crm/projects/{id}/transfer-ownership [post]
+func (h *ProjectMemberHandler) TransferOwnership(c *gin.Context) {
+	authCtx, ok := projectUserContext(c)
+	if !ok {
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	var req TransferOwnershipRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	owner, err := h.members.TransferOwnership(c.Request.Context(), projectapp.TransferOwnershipCommand{
+		ProjectID:   access.ProjectID,
+		NewOwnerID:  req.UserID,
+		RequestedBy: authCtx.UserID,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, owner)
+}
+
+// ListInvitations lists the invitations of a project
+//
+//	@Summary		List project invitations
+//	@Description	Convites do projeto, mais recentes primeiro. Pendentes vencidos aparecem como expired.
+//	@Tags			CRM - Project Members
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Project ID (UUID)"
+//	@Param			status	query		string					false	"pending, expired, accepted, declined ou revoked"
+//	@Success		200		{object}	map[string]interface{}	"Invitations"
+//	@Router			/api/v1/crm/projects/{id}/invitations [get]
+func (h *ProjectMemberHandler) ListInvitations(c *gin.Context) {
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	status := c.Query("status")
+	switch status {
+	case "", projectapp.InvitationStatusExpired,
+		string(project_member.InvitationPending), string(project_member.InvitationAccepted),
+		string(project_member.InvitationDeclined), string(project_member.InvitationRevoked):
+	default:
+		apierrors.BadRequest(c, "Invalid status (pending, expired, accepted, declined, revoked)")
+		return
+	}
+
+	invitations, err := h.invitations.List(c.Request.Context(), access.ProjectID, status)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"invitations": invitations,
+		"count":       len(invitations),
+	})
+}
+
+// InviteMember invites someone to the project by email
+//
+//	@Summary		Invite member
+//	@Description	Envia por email um convite com token e validade (INVITATION_TTL_HOURS). Sem SMTP
+//	@Description	configurado, ou se o envio falhar, o token volta na resposta para ser repassado.
+//	@Tags			CRM - Project Members
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string								true	"Project ID (UUID)"
+//	@Param			request	body		InviteMemberRequest					true	"Invitation"
+//	@Success		201		{object}	projectapp.CreatedInvitationView	"Invitation created"
+//	@Failure		400		{object}	map[string]interface{}				"Invalid email or role"
+//	@Failure		403		{object}	map[string]interface{}				"Role with a permission the caller does not have"
+//	@Failure		409		{object}	map[string]interface{}				"Already a member or already invited"
+//	@Router			/api/v1/crm/projects/{id}/invitations [post]
+func (h *ProjectMemberHandler) InviteMember(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	var req InviteMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	invitation, err := h.invitations.Invite(c.Request.Context(), projectapp.InviteMemberCommand{
+		ProjectID:            access.ProjectID,
+		Email:                req.Email,
+		Role:                 req.Role,
+		InvitedBy:            authCtx.UserID,
+		InviterName:          authCtx.Email,
+		GrantablePermissions: grantablePermissions(access),
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, invitation)
+}
+
+// RevokeInvitation revokes a pending invitation
+//
+//	@Summary		Revoke invitation
+//	@Description	Cancela um convite pendente; o token deixa de valer.
+//	@Tags			CRM - Project Members
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		string						true	"Project ID (UUID)"
+//	@Param			invitation_id	path		string						true	"Invitation ID (UUID)"
+//	@Success		200				{object}	projectapp.InvitationView	"Revoked invitation"
+//	@Failure		404				{object}	map[string]interface{}		"Invitation not found"
+//	@Failure		412				{object}	map[string]interface{}		"Invitation is not pending"
+//	@Router			/api/v1/crm/projects/{id}/invitations/{invitation_id} [delete]
+func (h *ProjectMemberHandler) RevokeInvitation(c *gin.Context) {
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	invitationID, ok := pathUUID(c, "invitation_id", "invitation")
+	if !ok {
+		return
+	}
+
+	invitation, err := h.invitations.Revoke(c.Request.Context(), access.ProjectID, invitationID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// AcceptInvitation accepts an invitation with the emailed token
+//
+//	@Summary		Accept invitation
+//	@Description	O usuário logado entra no projeto com o papel do convite. O email da conta precisa
+//	@Description	ser o convidado; o projeto aparece em GET /api/v1/crm/projects.
+//	@Tags			CRM - Project Members
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		InvitationTokenRequest		true	"Invitation token"
+//	@Success		200		{object}	projectapp.InvitationView	"Accepted invitation"
+//	@Failure		403		{object}	map[string]interface{}		"Invitation sent to another email"
+//	@Failure		404		{object}	map[string]interface{}		"Invitation not found"
+//	@Failure		412		{object}	map[string]interface{}		"Invitation expired or already answered"
+//	@Router			/api/v1/invitations/accept [post]
+func (h *ProjectMemberHandler) AcceptInvitation(c *gin.Context) {
+	authCtx, ok := projectUserContext(c)
+	if !ok {
+		return
+	}
+
+	var req InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	invitation, err := h.invitations.Accept(c.Request.Context(), projectapp.AcceptInvitationCommand{
+		Token:  req.Token,
+		UserID: authCtx.UserID,
+		Email:  authCtx.Email,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+// DeclineInvitation declines an invitation with the emailed token
+//
+//	@Summary		Decline invitation
+//	@Description	Recusa o convite. Basta o token: quem foi convidado não precisa ter conta.
+//	@Tags			CRM - Project Members
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		InvitationTokenRequest		true	"Invitation token"
+//	@Success		200		{object}	projectapp.InvitationView	"Declined invitation"
+//	@Failure		404		{object}	map[string]interface{}		"Invitation not found"
+//	@Failure		412		{object}	map[string]interface{}		"Invitation expired or already answered"
+//	@Router			/api/v1/invitations/decline [post]
+func (h *ProjectMemberHandler) DeclineInvitation(c *gin.Context) {
+	var req InvitationTokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	invitation, err := h.invitations.Decline(c.Request.Context(), req.Token)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+func projectAccess(c *gin.Context) (*middleware.ProjectAccess, bool) {
+	access, ok := middleware.GetProjectAccess(c)
+	if !ok {
+		apierrors.Forbidden(c, "project membership required")
+		return nil, false
+	}
+	return access, true
+}
+
+// grantablePermissions limite do que o chamador pode conceder em papéis, trocas de papel e
+// convites: as próprias permissões, salvo para o dono e os admins (nil = sem limite)
+func grantablePermissions(access *middleware.ProjectAccess) []project_member.Permission {
+	if access.Owner || access.Role == project_member.RoleAdmin {
+		return nil
+	}
+	if access.Permissions == nil {
+		return []project_member.Permission{}
+	}
+	return access.Permissions
+}
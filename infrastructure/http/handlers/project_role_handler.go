@@ -0,0 +1,256 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// ProjectRoleHandler papéis custom do projeto (compostos pelas permissões existentes)
+type ProjectRoleHandler struct {
+	logger *zap.Logger
+	roles  *projectapp.ManageRolesUseCase
+}
+
+func NewProjectRoleHandler(logger *zap.Logger, roles *projectapp.ManageRolesUseCase) *ProjectRoleHandler {
+	return &ProjectRoleHandler{
+		logger: logger,
+		roles:  roles,
+	}
+}
+
+// CreateProjectRoleRequest novo papel custom; com clone_from as permissões partem do papel de origem
+type CreateProjectRoleRequest struct {
+	Name        string                           `json:"name" binding:"required" example:"Sales Agent"`
+	Description string                           `json:"description" example:"Agent that can also export contacts"`
+	Permissions []project_member.Permission      `json:"permissions" example:"sessions.view,messages.send"`
+	CloneFrom   project_member.ProjectMemberRole `json:"clone_from" example:"agent"`
+}
+
+// UpdateProjectRoleRequest alteração parcial do papel (campos ausentes ficam como estão)
+type UpdateProjectRoleRequest struct {
+	Name        *string                     `json:"name" example:"Senior Sales Agent"`
+	Description *string                     `json:"description"`
+	Permissions []project_member.Permission `json:"permissions"`
+}
+
+// ListRoles lists the roles of a project
+//
+//	@Summary		List project roles
+//	@Description	Papéis padrão (built_in=true, somente leitura) e os papéis custom do projeto, com as permissões.
+//	@Tags			CRM - Project Roles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Project ID (UUID)"
+//	@Success		200	{object}	map[string]interface{}	"Roles"
+//	@Failure		403	{object}	map[string]interface{}	"Insufficient permissions"
+//	@Router			/api/v1/crm/projects/{id}/roles [get]
+func (h *ProjectRoleHandler) ListRoles(c *gin.Context) {
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	roles, err := h.roles.List(c.Request.Context(), access.ProjectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"roles": roles,
+		"count": len(roles),
+	})
+}
+
+// ListPermissions lists the permissions available to custom roles
+//
+//	@Summary		List assignable permissions
+//	@Description	Permissões que podem compor um papel custom (billing fica restrito ao dono).
+//	@Tags			CRM - Project Roles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Project ID (UUID)"
+//	@Success		200	{object}	map[string]interface{}	"Permissions"
+//	@Router			/api/v1/crm/projects/{id}/roles/permissions [get]
+func (h *ProjectRoleHandler) ListPermissions(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"permissions": h.roles.Permissions(),
+	})
+}
+
+// GetRole returns a custom role
+//
+//	@Summary		Get project role
+//	@Tags			CRM - Project Roles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Project ID (UUID)"
+//	@Param			role_id	path		string					true	"Role ID (UUID)"
+//	@Success		200		{object}	projectapp.RoleView		"Role"
+//	@Failure		404		{object}	map[string]interface{}	"Role not found"
+//	@Router			/api/v1/crm/projects/{id}/roles/{role_id} [get]
+func (h *ProjectRoleHandler) GetRole(c *gin.Context) {
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	roleID, ok := pathUUID(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	role, err := h.roles.Get(c.Request.Context(), access.ProjectID, roleID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// CreateRole creates a custom role
+//
+//	@Summary		Create project role
+//	@Description	Cria um papel custom (chave custom:<slug do nome>) a partir das permissões informadas ou
+//	@Description	clonando um papel padrão ou custom (clone_from). A chave é usada ao trocar o papel de
+//	@Description	membros e nos convites.
+//	@Tags			CRM - Project Roles
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Project ID (UUID)"
+//	@Param			request	body		CreateProjectRoleRequest	true	"Role"
+//	@Success		201		{object}	projectapp.RoleView			"Created role"
+//	@Failure		400		{object}	map[string]interface{}		"Invalid name or permissions"
+//	@Failure		403		{object}	map[string]interface{}		"Permission the caller does not have"
+//	@Failure		409		{object}	map[string]interface{}		"Role already exists"
+//	@Router			/api/v1/crm/projects/{id}/roles [post]
+func (h *ProjectRoleHandler) CreateRole(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+
+	var req CreateProjectRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	role, err := h.roles.Create(c.Request.Context(), projectapp.CreateRoleCommand{
+		ProjectID:            access.ProjectID,
+		Name:                 req.Name,
+		Description:          req.Description,
+		Permissions:          req.Permissions,
+		CloneFrom:            req.CloneFrom,
+		CreatedBy:            authCtx.UserID,
+		GrantablePermissions: grantablePermissions(access),
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, role)
+}
+
+// UpdateRole updates a custom role
+//
+//	@Summary		Update project role
+//	@Description	Altera nome, descrição e permissões. As permissões novas valem na hora para todos os
+//	@Description	membros com o papel, inclusive conexões WebSocket abertas.
+//	@Tags			CRM - Project Roles
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Project ID (UUID)"
+//	@Param			role_id	path		string						true	"Role ID (UUID)"
+//	@Param			request	body		UpdateProjectRoleRequest	true	"Changes"
+//	@Success		200		{object}	projectapp.RoleView			"Updated role"
+//	@Failure		403		{object}	map[string]interface{}		"Permission the caller does not have"
+//	@Failure		404		{object}	map[string]interface{}		"Role not found"
+//	@Router			/api/v1/crm/projects/{id}/roles/{role_id} [put]
+func (h *ProjectRoleHandler) UpdateRole(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	roleID, ok := pathUUID(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	var req UpdateProjectRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request: "+err.Error())
+		return
+	}
+
+	role, err := h.roles.Update(c.Request.Context(), projectapp.UpdateRoleCommand{
+		ProjectID:            access.ProjectID,
+		RoleID:               roleID,
+		Name:                 req.Name,
+		Description:          req.Description,
+		Permissions:          req.Permissions,
+		ChangedBy:            authCtx.UserID,
+		GrantablePermissions: grantablePermissions(access),
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, role)
+}
+
+// DeleteRole deletes a custom role
+//
+//	@Summary		Delete project role
+//	@Description	Remove o papel. Membros e convites pendentes com o papel precisam ser movidos antes.
+//	@Tags			CRM - Project Roles
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path	string	true	"Project ID (UUID)"
+//	@Param			role_id	path	string	true	"Role ID (UUID)"
+//	@Success		204		"Role deleted"
+//	@Failure		404		{object}	map[string]interface{}	"Role not found"
+//	@Failure		412		{object}	map[string]interface{}	"Role in use"
+//	@Router			/api/v1/crm/projects/{id}/roles/{role_id} [delete]
+func (h *ProjectRoleHandler) DeleteRole(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+	access, ok := projectAccess(c)
+	if !ok {
+		return
+	}
+	roleID, ok := pathUUID(c, "role_id", "role")
+	if !ok {
+		return
+	}
+
+	if err := h.roles.Delete(c.Request.Context(), access.ProjectID, roleID, authCtx.UserID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
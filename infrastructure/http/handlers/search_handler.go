@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	"github.com/ventros/crm/internal/application/queries"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/search"
+	"go.uber.org/zap"
+)
+
+// SearchHandler expõe a busca full-text unificada
+type SearchHandler struct {
+	logger        *zap.Logger
+	unifiedSearch *queries.UnifiedSearchQueryHandler
+}
+
+func NewSearchHandler(logger *zap.Logger, searchRepo search.Repository) *SearchHandler {
+	return &SearchHandler{
+		logger:        logger,
+		unifiedSearch: queries.NewUnifiedSearchQueryHandler(searchRepo, logger),
+	}
+}
+
+// Search runs a full-text search across messages, notes and contacts
+//
+//	@Summary		Unified full-text search
+//	@Description	Busca em mensagens (texto, transcrições de áudio e OCR), notas e contatos, ordenada por relevância (ts_rank_cd).
+//	@Description	Aceita sintaxe de busca web: "frase exata", OR, -excluir. Snippets vêm com HTML escapado e termos em <mark>.
+//	@Description	channel_id restringe a mensagens; agent_id a mensagens (agente) e notas (autor).
+//	@Tags			CRM - Search
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			q			query		string							true	"Texto buscado (max 256)"
+//	@Param			type		query		[]string						false	"Origens (repetível, default: todas)"	collectionFormat(multi)	Enums(message, note, contact)
+//	@Param			language	query		string							false	"Fixa o idioma da busca (pt, es, en); default: todos os idiomas indexados"
+//	@Param			channel_id	query		string							false	"Channel ID (UUID)"
+//	@Param			agent_id	query		string							false	"Agent ID (UUID)"
+//	@Param			contact_id	query		string							false	"Contact ID (UUID)"
+//	@Param			start_date	query		string							false	"Início do período (RFC3339 ou YYYY-MM-DD)"
+//	@Param			end_date	query		string							false	"Fim do período, exclusivo (RFC3339 ou YYYY-MM-DD)"
+//	@Param			cursor		query		string							false	"next_cursor de uma resposta anterior"
+//	@Param			limit		query		int								false	"Resultados por página (max 100)"	default(20)
+//	@Success		200			{object}	queries.UnifiedSearchResponse	"Search results"
+//	@Failure		400			{object}	map[string]interface{}			"Invalid parameters"
+//	@Router			/api/v1/crm/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	query := queries.UnifiedSearchQuery{
+		TenantID: tenantID,
+		Query:    c.Query("q"),
+		Types:    c.QueryArray("type"),
+		Language: c.Query("language"),
+		Cursor:   c.Query("cursor"),
+	}
+
+	for field, target := range map[string]**uuid.UUID{
+		"channel_id": &query.ChannelID,
+		"agent_id":   &query.AgentID,
+		"contact_id": &query.ContactID,
+	} {
+		value := c.Query(field)
+		if value == "" {
+			continue
+		}
+		id, err := uuid.Parse(value)
+		if err != nil {
+			apierrors.ValidationError(c, field, field+" must be a UUID")
+			return
+		}
+		*target = &id
+	}
+
+	if value := c.Query("start_date"); value != "" {
+		from, err := parseAnalyticsDate(value)
+		if err != nil {
+			apierrors.ValidationError(c, "start_date", "start_date must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		query.From = &from
+	}
+	if value := c.Query("end_date"); value != "" {
+		to, err := parseAnalyticsDate(value)
+		if err != nil {
+			apierrors.ValidationError(c, "end_date", "end_date must be RFC3339 or YYYY-MM-DD")
+			return
+		}
+		query.To = &to
+	}
+
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 {
+			apierrors.ValidationError(c, "limit", "limit must be a positive integer")
+			return
+		}
+		query.Limit = limit
+	}
+
+	response, err := h.unifiedSearch.Handle(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -11,6 +12,7 @@ import (
 	"github.com/ventros/crm/infrastructure/http/middleware"
 	sessioncmd "github.com/ventros/crm/internal/application/commands/session"
 	"github.com/ventros/crm/internal/application/queries"
+	sessionapp "github.com/ventros/crm/internal/application/session"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/session"
 	"go.uber.org/zap"
@@ -21,16 +23,24 @@ type SessionHandler struct {
 	sessionRepo                session.Repository
 	listSessionsQueryHandler   *queries.ListSessionsQueryHandler
 	searchSessionsQueryHandler *queries.SearchSessionsQueryHandler
+	analyticsQueryHandler      *queries.SessionAnalyticsQueryHandler
+	sessionHistoryQueryHandler *queries.SessionHistoryQueryHandler
+	activeSessionsQueryHandler *queries.GetActiveSessionsQueryHandler
 	closeSessionHandler        *sessioncmd.CloseSessionHandler
+	setPriorityUseCase         *sessionapp.SetPriorityUseCase
 }
 
-func NewSessionHandler(logger *zap.Logger, sessionRepo session.Repository, closeSessionHandler *sessioncmd.CloseSessionHandler) *SessionHandler {
+func NewSessionHandler(logger *zap.Logger, sessionRepo session.Repository, historyRepo session.HistoryRepository, analyticsQueryHandler *queries.SessionAnalyticsQueryHandler, closeSessionHandler *sessioncmd.CloseSessionHandler, setPriorityUseCase *sessionapp.SetPriorityUseCase) *SessionHandler {
 	return &SessionHandler{
 		logger:                     logger,
 		sessionRepo:                sessionRepo,
 		listSessionsQueryHandler:   queries.NewListSessionsQueryHandler(sessionRepo, logger),
 		searchSessionsQueryHandler: queries.NewSearchSessionsQueryHandler(sessionRepo, logger),
+		analyticsQueryHandler:      analyticsQueryHandler,
+		sessionHistoryQueryHandler: queries.NewSessionHistoryQueryHandler(historyRepo, logger),
+		activeSessionsQueryHandler: queries.NewGetActiveSessionsQueryHandler(historyRepo, logger),
 		closeSessionHandler:        closeSessionHandler,
+		setPriorityUseCase:         setPriorityUseCase,
 	}
 }
 
@@ -179,6 +189,7 @@ func (h *SessionHandler) sessionToResponse(sess *session.Session) map[string]int
 		"started_at":            sess.StartedAt(),
 		"ended_at":              sess.EndedAt(),
 		"status":                sess.Status(),
+		"priority":              sess.Priority(),
 		"end_reason":            sess.EndReason(),
 		"timeout_duration":      sess.TimeoutDuration().String(),
 		"last_activity_at":      sess.LastActivityAt(),
@@ -263,6 +274,60 @@ func (h *SessionHandler) CloseSession(c *gin.Context) {
 	})
 }
 
+// SetSessionPriorityRequest representa a requisição para alterar a prioridade
+type SetSessionPriorityRequest struct {
+	Priority string `json:"priority" binding:"required" example:"high"` // low, normal, high, urgent
+}
+
+// SetSessionPriority altera a prioridade de uma sessão
+//
+//	@Summary		Set session priority
+//	@Description	Altera a prioridade da sessão. Os prazos de SLA em andamento passam a seguir a política da nova prioridade.
+//	@Tags			CRM - Sessions
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string						true	"Session ID (UUID)"
+//	@Param			request	body		SetSessionPriorityRequest	true	"Priority"
+//	@Success		200		{object}	map[string]interface{}		"Priority updated"
+//	@Failure		400		{object}	map[string]interface{}		"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}		"Session not found"
+//	@Router			/api/v1/crm/sessions/{id}/priority [put]
+func (h *SessionHandler) SetSessionPriority(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	sessionID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid session ID format (must be UUID)")
+		return
+	}
+
+	var req SetSessionPriorityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	sess, err := h.setPriorityUseCase.Execute(c.Request.Context(), sessionapp.SetPriorityCommand{
+		SessionID: sessionID,
+		TenantID:  authCtx.TenantID,
+		Priority:  req.Priority,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": sess.ID(),
+		"priority":   sess.Priority(),
+	})
+}
+
 // ListSessionsAdvanced lists sessions with advanced filters, pagination, and sorting
 //
 //	@Summary		List sessions with advanced filters and pagination
@@ -493,3 +558,235 @@ func (h *SessionHandler) SearchSessions(c *gin.Context) {
 
 	c.JSON(http.StatusOK, response)
 }
+
+// GetSessionAnalytics returns aggregated session analytics
+//
+//	@Summary		Get session analytics
+//	@Description	Retorna métricas agregadas de sessões (totais por status, canal, agente e hora do dia; tempos médios e percentis de primeira resposta e espera; série temporal por dia, semana ou mês no fuso do projeto).
+//	@Description	Servido a partir de rollups horários atualizados incrementalmente (veja refreshed_at).
+//	@Tags			CRM - Sessions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			start_date		query		string								true	"Início do período (RFC3339 ou YYYY-MM-DD)"
+//	@Param			end_date		query		string								true	"Fim do período, exclusivo (RFC3339 ou YYYY-MM-DD)"
+//	@Param			group_by		query		string								false	"Granularidade da série"	Enums(day, week, month)	default(day)
+//	@Param			channel_type_id	query		int									false	"Filtrar por tipo de canal"
+//	@Param			agent_id		query		string								false	"Filtrar por agente (UUID)"
+//	@Param			timezone		query		string								false	"Fuso IANA (default: fuso do projeto)"
+//	@Success		200				{object}	queries.SessionAnalyticsResponse	"Session analytics"
+//	@Failure		400				{object}	map[string]interface{}				"Invalid parameters"
+//	@Failure		401				{object}	map[string]interface{}				"Unauthorized"
+//	@Failure		500				{object}	map[string]interface{}				"Internal server error"
+//	@Router			/api/v1/crm/sessions/analytics [get]
+func (h *SessionHandler) GetSessionAnalytics(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	startDate, err := parseAnalyticsDate(c.Query("start_date"))
+	if err != nil {
+		apierrors.ValidationError(c, "start_date", "start_date must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+	endDate, err := parseAnalyticsDate(c.Query("end_date"))
+	if err != nil {
+		apierrors.ValidationError(c, "end_date", "end_date must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	query := queries.SessionAnalyticsQuery{
+		TenantID:  tenantID,
+		StartDate: startDate,
+		EndDate:   endDate,
+		GroupBy:   c.DefaultQuery("group_by", "day"),
+		Timezone:  c.Query("timezone"),
+	}
+
+	if channelTypeIDStr := c.Query("channel_type_id"); channelTypeIDStr != "" {
+		channelTypeID, err := strconv.Atoi(channelTypeIDStr)
+		if err != nil {
+			apierrors.ValidationError(c, "channel_type_id", "channel_type_id must be an integer")
+			return
+		}
+		query.ChannelTypeID = &channelTypeID
+	}
+
+	if agentIDStr := c.Query("agent_id"); agentIDStr != "" {
+		agentID, err := uuid.Parse(agentIDStr)
+		if err != nil {
+			apierrors.ValidationError(c, "agent_id", "Invalid agent ID format (must be UUID)")
+			return
+		}
+		query.AgentID = &agentID
+	}
+
+	response, err := h.analyticsQueryHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetActiveSessions lists the active sessions queue
+//
+//	@Summary		Active sessions queue
+//	@Description	Sessões ativas do tenant, ordenadas pelo tempo de espera (contato aguardando resposta há mais tempo primeiro).
+//	@Description	Canal e agente são derivados da última mensagem e da participação mais recente do agente.
+//	@Tags			CRM - Sessions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			channel_id	query		string								false	"Filtrar por canal (UUID)"
+//	@Param			agent_id	query		string								false	"Filtrar por agente (UUID)"
+//	@Param			unassigned	query		bool								false	"Apenas sessões sem agente"
+//	@Param			pipeline_id	query		string								false	"Filtrar por pipeline (UUID)"
+//	@Param			min_waiting	query		string								false	"Espera mínima (duração Go, ex: 5m, 1h)"
+//	@Param			limit		query		int									false	"Máximo de sessões (max 200)"	default(20)
+//	@Success		200			{object}	queries.GetActiveSessionsResponse	"Active sessions"
+//	@Failure		400			{object}	map[string]interface{}				"Invalid parameters"
+//	@Failure		401			{object}	map[string]interface{}				"Unauthorized"
+//	@Failure		500			{object}	map[string]interface{}				"Internal server error"
+//	@Router			/api/v1/crm/sessions/active [get]
+func (h *SessionHandler) GetActiveSessions(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	query := queries.GetActiveSessionsQuery{TenantID: tenantID}
+
+	var ok bool
+	if query.ChannelID, ok = parseOptionalUUIDQuery(c, "channel_id"); !ok {
+		return
+	}
+	if query.AgentID, ok = parseOptionalUUIDQuery(c, "agent_id"); !ok {
+		return
+	}
+	if query.PipelineID, ok = parseOptionalUUIDQuery(c, "pipeline_id"); !ok {
+		return
+	}
+
+	if value := c.Query("unassigned"); value != "" {
+		if query.Unassigned, err = strconv.ParseBool(value); err != nil {
+			apierrors.ValidationError(c, "unassigned", "unassigned must be a boolean")
+			return
+		}
+	}
+
+	if value := c.Query("min_waiting"); value != "" {
+		if query.MinWaiting, err = time.ParseDuration(value); err != nil {
+			apierrors.ValidationError(c, "min_waiting", "min_waiting must be a duration (e.g. 5m, 1h)")
+			return
+		}
+	}
+
+	if value := c.Query("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			apierrors.ValidationError(c, "limit", "limit must be an integer")
+			return
+		}
+	}
+
+	response, err := h.activeSessionsQueryHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetContactSessionHistory lists the sessions of a contact with cursor pagination
+//
+//	@Summary		Contact session history
+//	@Description	Histórico de sessões do contato, da mais recente para a mais antiga, paginado por cursor (use next_cursor da resposta anterior).
+//	@Tags			CRM - Sessions
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string							true	"Contact ID (UUID)"
+//	@Param			cursor	query		string							false	"Cursor opaco (next_cursor)"
+//	@Param			limit	query		int								false	"Sessões por página (max 100)"	default(20)
+//	@Success		200		{object}	queries.SessionHistoryResponse	"Session history"
+//	@Failure		400		{object}	map[string]interface{}			"Invalid parameters"
+//	@Failure		401		{object}	map[string]interface{}			"Unauthorized"
+//	@Failure		500		{object}	map[string]interface{}			"Internal server error"
+//	@Router			/api/v1/contacts/{id}/history [get]
+func (h *SessionHandler) GetContactSessionHistory(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	tenantID, err := shared.NewTenantID(authCtx.TenantID)
+	if err != nil {
+		apierrors.ValidationError(c, "tenant_id", "Invalid tenant ID")
+		return
+	}
+
+	contactID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid contact ID format (must be UUID)")
+		return
+	}
+
+	query := queries.SessionHistoryQuery{
+		ContactID: contactID,
+		TenantID:  tenantID,
+		Cursor:    c.Query("cursor"),
+	}
+	if value := c.Query("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			apierrors.ValidationError(c, "limit", "limit must be an integer")
+			return
+		}
+	}
+
+	response, err := h.sessionHistoryQueryHandler.Handle(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// parseOptionalUUIDQuery lê um filtro UUID opcional da query string
+func parseOptionalUUIDQuery(c *gin.Context, key string) (*uuid.UUID, bool) {
+	value := c.Query(key)
+	if value == "" {
+		return nil, true
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		apierrors.ValidationError(c, key, "Invalid "+key+" format (must be UUID)")
+		return nil, false
+	}
+
+	return &id, true
+}
+
+// parseAnalyticsDate aceita RFC3339 ou data simples (YYYY-MM-DD, meia-noite UTC)
+func parseAnalyticsDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
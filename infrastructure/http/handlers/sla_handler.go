@@ -0,0 +1,328 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	slaapp "github.com/ventros/crm/internal/application/sla"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"go.uber.org/zap"
+)
+
+// SLAHandler expõe as políticas de SLA e o relatório de cumprimento
+type SLAHandler struct {
+	logger           *zap.Logger
+	policiesUseCase  *slaapp.ManagePoliciesUseCase
+	complianceReport *slaapp.ComplianceReportUseCase
+}
+
+func NewSLAHandler(logger *zap.Logger, policiesUseCase *slaapp.ManagePoliciesUseCase, complianceReport *slaapp.ComplianceReportUseCase) *SLAHandler {
+	return &SLAHandler{
+		logger:           logger,
+		policiesUseCase:  policiesUseCase,
+		complianceReport: complianceReport,
+	}
+}
+
+// SLAPolicyRequest corpo de criação/atualização de uma política de SLA.
+// Metas em minutos úteis (0 = métrica não acompanhada); escopo vazio = vale para todas as sessões do projeto.
+type SLAPolicyRequest struct {
+	Name                 string                  `json:"name" binding:"required"`
+	PipelineID           *uuid.UUID              `json:"pipeline_id"`
+	ChannelTypeID        *int                    `json:"channel_type_id"`
+	Priority             *string                 `json:"priority" example:"high"`
+	FirstResponseMinutes int                     `json:"first_response_minutes" example:"15"`
+	NextResponseMinutes  int                     `json:"next_response_minutes" example:"30"`
+	ResolutionMinutes    int                     `json:"resolution_minutes" example:"480"`
+	WarningPercent       *int                    `json:"warning_percent" example:"80"`
+	BusinessHours        *businesshours.Schedule `json:"business_hours"`
+	Enabled              *bool                   `json:"enabled"`
+}
+
+func (r SLAPolicyRequest) toInput() slaapp.PolicyInput {
+	return slaapp.PolicyInput{
+		Name:                 r.Name,
+		PipelineID:           r.PipelineID,
+		ChannelTypeID:        r.ChannelTypeID,
+		Priority:             r.Priority,
+		FirstResponseMinutes: r.FirstResponseMinutes,
+		NextResponseMinutes:  r.NextResponseMinutes,
+		ResolutionMinutes:    r.ResolutionMinutes,
+		WarningPercent:       r.WarningPercent,
+		BusinessHours:        r.BusinessHours,
+		Enabled:              r.Enabled,
+	}
+}
+
+// ListPolicies lists the SLA policies of a project
+//
+//	@Summary		List SLA policies
+//	@Description	Lista as políticas de SLA do projeto (default: projeto do token).
+//	@Tags			CRM - SLA
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Success		200			{object}	map[string]interface{}	"SLA policies"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/sla/policies [get]
+func (h *SLAHandler) ListPolicies(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	policies, err := h.policiesUseCase.List(c.Request.Context(), authCtx.TenantID, projectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"policies": policies,
+		"total":    len(policies),
+	})
+}
+
+// CreatePolicy creates an SLA policy
+//
+//	@Summary		Create SLA policy
+//	@Description	Cria uma política de SLA. Prazos contam apenas dentro do expediente (business_hours) quando configurado.
+//	@Description	Com várias políticas no projeto, vale a mais específica para a sessão (pipeline, tipo de canal, prioridade).
+//	@Tags			CRM - SLA
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Param			request		body		SLAPolicyRequest		true	"SLA policy"
+//	@Success		201			{object}	slaapp.PolicyView		"SLA policy created"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/sla/policies [post]
+func (h *SLAHandler) CreatePolicy(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	var req SLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	policy, err := h.policiesUseCase.Create(c.Request.Context(), authCtx.TenantID, projectID, req.toInput())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, policy)
+}
+
+// GetPolicy returns an SLA policy
+//
+//	@Summary		Get SLA policy
+//	@Tags			CRM - SLA
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Policy ID (UUID)"
+//	@Success		200	{object}	slaapp.PolicyView		"SLA policy"
+//	@Failure		404	{object}	map[string]interface{}	"Policy not found"
+//	@Router			/api/v1/crm/sla/policies/{id} [get]
+func (h *SLAHandler) GetPolicy(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid policy ID format (must be UUID)")
+		return
+	}
+
+	policy, err := h.policiesUseCase.Get(c.Request.Context(), authCtx.TenantID, policyID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// UpdatePolicy replaces an SLA policy
+//
+//	@Summary		Update SLA policy
+//	@Description	Substitui metas, escopo e expediente. Vale para relógios iniciados depois da alteração.
+//	@Tags			CRM - SLA
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Policy ID (UUID)"
+//	@Param			request	body		SLAPolicyRequest		true	"SLA policy"
+//	@Success		200		{object}	slaapp.PolicyView		"SLA policy updated"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Policy not found"
+//	@Router			/api/v1/crm/sla/policies/{id} [put]
+func (h *SLAHandler) UpdatePolicy(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid policy ID format (must be UUID)")
+		return
+	}
+
+	var req SLAPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	policy, err := h.policiesUseCase.Update(c.Request.Context(), authCtx.TenantID, policyID, req.toInput())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, policy)
+}
+
+// DeletePolicy deletes an SLA policy
+//
+//	@Summary		Delete SLA policy
+//	@Tags			CRM - SLA
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Policy ID (UUID)"
+//	@Success		204	"SLA policy deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Policy not found"
+//	@Router			/api/v1/crm/sla/policies/{id} [delete]
+func (h *SLAHandler) DeletePolicy(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		apierrors.ValidationError(c, "id", "Invalid policy ID format (must be UUID)")
+		return
+	}
+
+	if err := h.policiesUseCase.Delete(c.Request.Context(), authCtx.TenantID, policyID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetCompliance returns the SLA compliance report
+//
+//	@Summary		SLA compliance report
+//	@Description	Percentual de prazos cumpridos por agente e da equipe (agentes filtrados) no período, por métrica.
+//	@Tags			CRM - SLA
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Param			agent_id	query		[]string				false	"Agentes da equipe (repetível)"	collectionFormat(multi)
+//	@Param			start_date	query		string					false	"Início do período (RFC3339 ou YYYY-MM-DD, default: 30 dias atrás)"
+//	@Param			end_date	query		string					false	"Fim do período, exclusivo (RFC3339 ou YYYY-MM-DD, default: agora)"
+//	@Success		200			{object}	slaapp.ComplianceReport	"Compliance report"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/sla/compliance [get]
+func (h *SLAHandler) GetCompliance(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	query := slaapp.ComplianceQuery{TenantID: authCtx.TenantID}
+
+	if value := c.Query("project_id"); value != "" {
+		projectID, err := uuid.Parse(value)
+		if err != nil {
+			apierrors.ValidationError(c, "project_id", "project_id must be a UUID")
+			return
+		}
+		query.ProjectID = &projectID
+	}
+
+	for _, value := range c.QueryArray("agent_id") {
+		agentID, err := uuid.Parse(value)
+		if err != nil {
+			apierrors.ValidationError(c, "agent_id", "agent_id must be a UUID")
+			return
+		}
+		query.AgentIDs = append(query.AgentIDs, agentID)
+	}
+
+	var err error
+	if query.From, err = optionalAnalyticsDate(c.Query("start_date")); err != nil {
+		apierrors.ValidationError(c, "start_date", "start_date must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+	if query.To, err = optionalAnalyticsDate(c.Query("end_date")); err != nil {
+		apierrors.ValidationError(c, "end_date", "end_date must be RFC3339 or YYYY-MM-DD")
+		return
+	}
+
+	report, err := h.complianceReport.Execute(c.Request.Context(), query)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
+
+// queryProjectID lê project_id da query; sem ele, usa o projeto do token
+func queryProjectID(c *gin.Context, authCtx *middleware.AuthContext) (uuid.UUID, bool) {
+	value := c.Query("project_id")
+	if value == "" {
+		if authCtx.ProjectID == uuid.Nil {
+			apierrors.ValidationError(c, "project_id", "project_id is required")
+			return uuid.Nil, false
+		}
+		return authCtx.ProjectID, true
+	}
+
+	projectID, err := uuid.Parse(value)
+	if err != nil {
+		apierrors.ValidationError(c, "project_id", "project_id must be a UUID")
+		return uuid.Nil, false
+	}
+	return projectID, true
+}
+
+// optionalAnalyticsDate vazio = zero (o caso de uso aplica o default)
+func optionalAnalyticsDate(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return parseAnalyticsDate(value)
+}
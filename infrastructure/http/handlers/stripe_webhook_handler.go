@@ -3,17 +3,25 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stripe/stripe-go/v81"
 	"github.com/stripe/stripe-go/v81/webhook"
 	"github.com/ventros/crm/internal/domain/core/billing"
 	"go.uber.org/zap"
 )
 
+// DunningTracker régua de cobrança alimentada pelos eventos de fatura do Stripe
+type DunningTracker interface {
+	Start(ctx context.Context, invoiceID uuid.UUID, now time.Time) error
+	InvoicePaid(ctx context.Context, invoiceID uuid.UUID, now time.Time) error
+}
+
 // StripeWebhookHandler handles Stripe webhook events
 type StripeWebhookHandler struct {
 	logger           *zap.Logger
@@ -22,9 +30,10 @@ type StripeWebhookHandler struct {
 	subscriptionRepo billing.SubscriptionRepository
 	invoiceRepo      billing.InvoiceRepository
 	usageMeterRepo   billing.UsageMeterRepository
+	dunning          DunningTracker
 }
 
-// NewStripeWebhookHandler creates a new Stripe webhook handler
+// NewStripeWebhookHandler creates a new Stripe webhook handler. dunning pode ser nil.
 func NewStripeWebhookHandler(
 	logger *zap.Logger,
 	webhookSecret string,
@@ -32,6 +41,7 @@ func NewStripeWebhookHandler(
 	subscriptionRepo billing.SubscriptionRepository,
 	invoiceRepo billing.InvoiceRepository,
 	usageMeterRepo billing.UsageMeterRepository,
+	dunning DunningTracker,
 ) *StripeWebhookHandler {
 	return &StripeWebhookHandler{
 		logger:           logger,
@@ -40,6 +50,7 @@ func NewStripeWebhookHandler(
 		subscriptionRepo: subscriptionRepo,
 		invoiceRepo:      invoiceRepo,
 		usageMeterRepo:   usageMeterRepo,
+		dunning:          dunning,
 	}
 }
 
@@ -133,24 +144,24 @@ func (h *StripeWebhookHandler) handleInvoicePaid(ctx context.Context, event stri
 		zap.String("invoice_id", stripeInvoice.ID),
 		zap.Int64("amount_paid", stripeInvoice.AmountPaid))
 
-	// Buscar invoice no banco
-	inv, err := h.invoiceRepo.FindByStripeInvoiceID(ctx, stripeInvoice.ID)
-	if err != nil {
-		// Se não existe, criar nova invoice
-		h.logger.Warn("Invoice not found, creating new one",
-			zap.String("stripe_invoice_id", stripeInvoice.ID))
-
-		// Buscar billing account pelo customer ID
-		// TODO: Implementar busca por stripe_customer_id
-		return nil
+	inv, err := h.findOrCreateInvoice(ctx, &stripeInvoice)
+	if err != nil || inv == nil {
+		return err
 	}
 
-	// Atualizar status da invoice
-	if err := inv.MarkAsPaid(stripeInvoice.AmountPaid); err != nil {
+	// Atualizar status da invoice (reentrega do evento: já paga)
+	if err := inv.MarkAsPaid(stripeInvoice.AmountPaid); err != nil && !errors.Is(err, billing.ErrInvoicePaid) {
+		return err
+	}
+	if err := h.invoiceRepo.Update(ctx, inv); err != nil {
 		return err
 	}
 
-	return h.invoiceRepo.Update(ctx, inv)
+	// Encerrar a régua de cobrança e reativar a conta
+	if h.dunning != nil {
+		return h.dunning.InvoicePaid(ctx, inv.ID(), time.Now())
+	}
+	return nil
 }
 
 // handleInvoicePaymentFailed processa falha no pagamento
@@ -164,13 +175,74 @@ func (h *StripeWebhookHandler) handleInvoicePaymentFailed(ctx context.Context, e
 		zap.String("invoice_id", stripeInvoice.ID),
 		zap.Int64("amount_due", stripeInvoice.AmountDue))
 
-	inv, err := h.invoiceRepo.FindByStripeInvoiceID(ctx, stripeInvoice.ID)
-	if err != nil {
+	inv, err := h.findOrCreateInvoice(ctx, &stripeInvoice)
+	if err != nil || inv == nil {
 		return err
 	}
 
 	inv.MarkAsFailedPayment()
-	return h.invoiceRepo.Update(ctx, inv)
+	if inv.DueDate() == nil {
+		// Cobrança automática não tem vencimento: a régua conta a partir da falha
+		inv.SetDueDate(time.Unix(event.Created, 0))
+	}
+	if err := h.invoiceRepo.Update(ctx, inv); err != nil {
+		return err
+	}
+
+	// Abrir a régua de cobrança (lembretes, somente leitura, suspensão)
+	if h.dunning != nil {
+		return h.dunning.Start(ctx, inv.ID(), time.Now())
+	}
+	return nil
+}
+
+// findOrCreateInvoice busca a invoice pelo ID do Stripe e, quando ainda não existe, cria a partir
+// do evento na billing account do customer. Customer sem billing account retorna nil (ignorado).
+func (h *StripeWebhookHandler) findOrCreateInvoice(ctx context.Context, stripeInvoice *stripe.Invoice) (*billing.Invoice, error) {
+	inv, err := h.invoiceRepo.FindByStripeInvoiceID(ctx, stripeInvoice.ID)
+	if err == nil {
+		return inv, nil
+	}
+	if !errors.Is(err, billing.ErrNotFound) {
+		return nil, err
+	}
+
+	if stripeInvoice.Customer == nil {
+		h.logger.Warn("Invoice without customer, ignoring",
+			zap.String("stripe_invoice_id", stripeInvoice.ID))
+		return nil, nil
+	}
+	account, err := h.billingRepo.FindByStripeCustomerID(ctx, stripeInvoice.Customer.ID)
+	if errors.Is(err, billing.ErrNotFound) {
+		h.logger.Warn("Billing account not found for Stripe customer, ignoring invoice",
+			zap.String("stripe_invoice_id", stripeInvoice.ID),
+			zap.String("stripe_customer_id", stripeInvoice.Customer.ID))
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	status := billing.InvoiceStatus(stripeInvoice.Status)
+	if status == "" {
+		status = billing.InvoiceStatusOpen
+	}
+	inv, err = billing.NewInvoice(account.ID(), stripeInvoice.ID, nil, stripeInvoice.AmountDue, string(stripeInvoice.Currency), status)
+	if err != nil {
+		return nil, err
+	}
+	if stripeInvoice.DueDate > 0 {
+		inv.SetDueDate(time.Unix(stripeInvoice.DueDate, 0))
+	}
+	inv.SetInvoiceURLs(stripeInvoice.HostedInvoiceURL, stripeInvoice.InvoicePDF)
+
+	h.logger.Info("Creating invoice from Stripe event",
+		zap.String("stripe_invoice_id", stripeInvoice.ID),
+		zap.String("billing_account_id", account.ID().String()))
+	if err := h.invoiceRepo.Create(ctx, inv); err != nil {
+		return nil, err
+	}
+	return inv, nil
 }
 
 // handleInvoicePaymentActionRequired processa ação necessária (3D Secure)
@@ -329,7 +401,7 @@ func (h *StripeWebhookHandler) GetWebhookInfo(c *gin.Context) {
 			"invoice_paid": map[string]interface{}{
 				"type":        "invoice.paid",
 				"description": "Triggered when an invoice is successfully paid",
-				"action":      "Updates invoice status to 'paid' in database",
+				"action":      "Updates invoice status to 'paid', closes dunning and reactivates the account",
 			},
 			"subscription_updated": map[string]interface{}{
 				"type":        "customer.subscription.updated",
@@ -0,0 +1,504 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	taskapp "github.com/ventros/crm/internal/application/task"
+	"github.com/ventros/crm/internal/domain/crm/task"
+	"go.uber.org/zap"
+)
+
+// TaskHandler expõe as tarefas dos agentes: CRUD, "minhas tarefas", atrasadas e conclusão
+type TaskHandler struct {
+	logger       *zap.Logger
+	tasksUseCase *taskapp.ManageTasksUseCase
+}
+
+func NewTaskHandler(logger *zap.Logger, tasksUseCase *taskapp.ManageTasksUseCase) *TaskHandler {
+	return &TaskHandler{
+		logger:       logger,
+		tasksUseCase: tasksUseCase,
+	}
+}
+
+// CreateTaskRequest corpo de criação de uma tarefa.
+// due_at aceita RFC3339 ou horário local (YYYY-MM-DDTHH:MM) no fuso timezone.
+type CreateTaskRequest struct {
+	Title       string          `json:"title" binding:"required"`
+	Description string          `json:"description"`
+	DueAt       string          `json:"due_at" example:"2026-03-10T14:30"`
+	Timezone    string          `json:"timezone" example:"America/Sao_Paulo"`
+	Priority    task.Priority   `json:"priority" example:"normal"`
+	Recurrence  task.Recurrence `json:"recurrence" example:"none"`
+	AssigneeID  *uuid.UUID      `json:"assignee_id"`
+	ContactID   *uuid.UUID      `json:"contact_id"`
+	SessionID   *uuid.UUID      `json:"session_id"`
+	PipelineID  *uuid.UUID      `json:"pipeline_id"`
+}
+
+func (r CreateTaskRequest) toDetails() (task.Details, error) {
+	details := task.Details{
+		Title:       r.Title,
+		Description: r.Description,
+		Timezone:    r.Timezone,
+		Priority:    r.Priority,
+		Recurrence:  r.Recurrence,
+		AssigneeID:  r.AssigneeID,
+		ContactID:   r.ContactID,
+		SessionID:   r.SessionID,
+		PipelineID:  r.PipelineID,
+	}
+	if r.DueAt != "" {
+		due, err := task.ParseDue(r.DueAt, r.Timezone)
+		if err != nil {
+			return task.Details{}, err
+		}
+		details.DueAt = &due
+	}
+	return details, nil
+}
+
+// UpdateTaskRequest atualização parcial: só os campos enviados mudam.
+// due_at vazio ("") remove o prazo; unassign remove o responsável.
+type UpdateTaskRequest struct {
+	Title       *string          `json:"title"`
+	Description *string          `json:"description"`
+	DueAt       *string          `json:"due_at" example:"2026-03-10T14:30"`
+	Timezone    *string          `json:"timezone" example:"America/Sao_Paulo"`
+	Priority    *task.Priority   `json:"priority"`
+	Recurrence  *task.Recurrence `json:"recurrence"`
+	AssigneeID  *uuid.UUID       `json:"assignee_id"`
+	Unassign    bool             `json:"unassign"`
+}
+
+// ListTasks lists tasks
+//
+//	@Summary		List tasks
+//	@Description	Lista as tarefas do tenant, ordenadas pelo prazo (sem prazo por último).
+//	@Tags			CRM - Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			assignee_id	query		string					false	"Assignee agent ID (UUID)"
+//	@Param			contact_id	query		string					false	"Contact ID (UUID)"
+//	@Param			session_id	query		string					false	"Session ID (UUID)"
+//	@Param			pipeline_id	query		string					false	"Pipeline ID (UUID)"
+//	@Param			status		query		string					false	"Statuses, comma separated (open, completed, cancelled)"
+//	@Param			due_before	query		string					false	"Due before (RFC3339 or YYYY-MM-DD)"
+//	@Param			due_after	query		string					false	"Due after (RFC3339 or YYYY-MM-DD)"
+//	@Param			limit		query		int						false	"Page size (max 100)"	default(50)
+//	@Param			offset		query		int						false	"Offset"				default(0)
+//	@Success		200			{object}	map[string]interface{}	"Tasks"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/tasks [get]
+func (h *TaskHandler) ListTasks(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	filter, ok := parseTaskFilter(c, authCtx.TenantID, true)
+	if !ok {
+		return
+	}
+
+	tasks, total, err := h.tasksUseCase.List(c.Request.Context(), filter)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	respondTasks(c, tasks, total, filter)
+}
+
+// MyTasks lists the tasks assigned to the caller
+//
+//	@Summary		My tasks
+//	@Description	Tarefas do agente do usuário autenticado (default: abertas).
+//	@Tags			CRM - Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			status		query		string					false	"Statuses, comma separated"	default(open)
+//	@Param			due_before	query		string					false	"Due before (RFC3339 or YYYY-MM-DD)"
+//	@Param			due_after	query		string					false	"Due after (RFC3339 or YYYY-MM-DD)"
+//	@Param			limit		query		int						false	"Page size (max 100)"	default(50)
+//	@Param			offset		query		int						false	"Offset"				default(0)
+//	@Success		200			{object}	map[string]interface{}	"Tasks"
+//	@Failure		404			{object}	map[string]interface{}	"User has no agent"
+//	@Router			/api/v1/crm/tasks/mine [get]
+func (h *TaskHandler) MyTasks(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	filter, ok := parseTaskFilter(c, authCtx.TenantID, false)
+	if !ok {
+		return
+	}
+	if c.Query("status") == "" {
+		filter.Statuses = []task.Status{task.StatusOpen}
+	}
+
+	tasks, total, err := h.tasksUseCase.Mine(c.Request.Context(), authCtx.TenantID, authCtx.UserID, filter)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	respondTasks(c, tasks, total, filter)
+}
+
+// OverdueTasks lists open tasks past their due date
+//
+//	@Summary		Overdue tasks
+//	@Description	Tarefas abertas com prazo vencido, filtráveis por responsável, contato, sessão ou pipeline.
+//	@Tags			CRM - Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			assignee_id	query		string					false	"Assignee agent ID (UUID)"
+//	@Param			contact_id	query		string					false	"Contact ID (UUID)"
+//	@Param			session_id	query		string					false	"Session ID (UUID)"
+//	@Param			pipeline_id	query		string					false	"Pipeline ID (UUID)"
+//	@Param			limit		query		int						false	"Page size (max 100)"	default(50)
+//	@Param			offset		query		int						false	"Offset"				default(0)
+//	@Success		200			{object}	map[string]interface{}	"Tasks"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/tasks/overdue [get]
+func (h *TaskHandler) OverdueTasks(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	filter, ok := parseTaskFilter(c, authCtx.TenantID, true)
+	if !ok {
+		return
+	}
+
+	tasks, total, err := h.tasksUseCase.Overdue(c.Request.Context(), filter)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	respondTasks(c, tasks, total, filter)
+}
+
+// CreateTask creates a task
+//
+//	@Summary		Create task
+//	@Description	Cria uma tarefa. Sem assignee_id, a tarefa fica com o agente de quem criou. Com prazo, o lembrete é entregue via websocket no vencimento.
+//	@Tags			CRM - Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		CreateTaskRequest		true	"Task"
+//	@Success		201		{object}	taskapp.TaskView		"Task created"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Contact, session or pipeline not found"
+//	@Router			/api/v1/crm/tasks [post]
+func (h *TaskHandler) CreateTask(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	var req CreateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+	details, err := req.toDetails()
+	if err != nil {
+		apierrors.ValidationError(c, "due_at", err.Error())
+		return
+	}
+
+	t, err := h.tasksUseCase.Create(c.Request.Context(), authCtx.TenantID, authCtx.UserID, details)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, t)
+}
+
+// GetTask gets a task
+//
+//	@Summary		Get task
+//	@Tags			CRM - Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Task ID (UUID)"
+//	@Success		200	{object}	taskapp.TaskView		"Task"
+//	@Failure		404	{object}	map[string]interface{}	"Task not found"
+//	@Router			/api/v1/crm/tasks/{id} [get]
+func (h *TaskHandler) GetTask(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	taskID, ok := pathUUID(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	t, err := h.tasksUseCase.Get(c.Request.Context(), authCtx.TenantID, taskID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// UpdateTask partially updates a task
+//
+//	@Summary		Update task
+//	@Description	Atualiza os campos enviados. Um novo prazo reagenda o lembrete; due_at "" remove o prazo (e a recorrência).
+//	@Tags			CRM - Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Task ID (UUID)"
+//	@Param			request	body		UpdateTaskRequest		true	"Changes"
+//	@Success		200		{object}	taskapp.TaskView		"Task updated"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		409		{object}	map[string]interface{}	"Task is closed"
+//	@Router			/api/v1/crm/tasks/{id} [patch]
+func (h *TaskHandler) UpdateTask(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	taskID, ok := pathUUID(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	var req UpdateTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	changes := task.Changes{
+		Title:       req.Title,
+		Description: req.Description,
+		Timezone:    req.Timezone,
+		Priority:    req.Priority,
+		Recurrence:  req.Recurrence,
+		AssigneeID:  req.AssigneeID,
+		Unassign:    req.Unassign,
+	}
+	if req.DueAt != nil {
+		if *req.DueAt == "" {
+			changes.ClearDueAt = true
+		} else {
+			// Horário local sem fuso explícito usa o fuso atual da tarefa
+			timezone := ""
+			if req.Timezone != nil {
+				timezone = *req.Timezone
+			} else {
+				current, err := h.tasksUseCase.Get(c.Request.Context(), authCtx.TenantID, taskID)
+				if err != nil {
+					apierrors.RespondWithError(c, err)
+					return
+				}
+				timezone = current.Timezone
+			}
+			due, err := task.ParseDue(*req.DueAt, timezone)
+			if err != nil {
+				apierrors.ValidationError(c, "due_at", err.Error())
+				return
+			}
+			changes.DueAt = &due
+		}
+	}
+
+	t, err := h.tasksUseCase.Update(c.Request.Context(), authCtx.TenantID, authCtx.UserID, taskID, changes)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// CompleteTask completes a task
+//
+//	@Summary		Complete task
+//	@Description	Conclui a tarefa. Tarefas recorrentes geram a próxima ocorrência, retornada em next.
+//	@Tags			CRM - Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Task ID (UUID)"
+//	@Success		200	{object}	taskapp.CompleteResult	"Task completed"
+//	@Failure		404	{object}	map[string]interface{}	"Task not found"
+//	@Failure		409	{object}	map[string]interface{}	"Task is closed"
+//	@Router			/api/v1/crm/tasks/{id}/complete [post]
+func (h *TaskHandler) CompleteTask(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	taskID, ok := pathUUID(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	result, err := h.tasksUseCase.Complete(c.Request.Context(), authCtx.TenantID, authCtx.UserID, taskID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// CancelTask cancels a task
+//
+//	@Summary		Cancel task
+//	@Tags			CRM - Tasks
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Task ID (UUID)"
+//	@Success		200	{object}	taskapp.TaskView		"Task cancelled"
+//	@Failure		404	{object}	map[string]interface{}	"Task not found"
+//	@Failure		409	{object}	map[string]interface{}	"Task is closed"
+//	@Router			/api/v1/crm/tasks/{id}/cancel [post]
+func (h *TaskHandler) CancelTask(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	taskID, ok := pathUUID(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	t, err := h.tasksUseCase.Cancel(c.Request.Context(), authCtx.TenantID, authCtx.UserID, taskID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// DeleteTask deletes a task
+//
+//	@Summary		Delete task
+//	@Tags			CRM - Tasks
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Task ID (UUID)"
+//	@Success		204	"Task deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Task not found"
+//	@Router			/api/v1/crm/tasks/{id} [delete]
+func (h *TaskHandler) DeleteTask(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	taskID, ok := pathUUID(c, "id", "task")
+	if !ok {
+		return
+	}
+
+	if err := h.tasksUseCase.Delete(c.Request.Context(), authCtx.TenantID, authCtx.UserID, taskID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// parseTaskFilter lê os filtros comuns das listagens; withRelations inclui os filtros por responsável/contato/sessão/pipeline
+func parseTaskFilter(c *gin.Context, tenantID string, withRelations bool) (task.Filter, bool) {
+	filter := task.Filter{TenantID: tenantID}
+	filter.Limit, filter.Offset = parsePagination(c)
+	if filter.Limit > 100 {
+		filter.Limit = 100
+	}
+
+	if withRelations {
+		targets := []struct {
+			key string
+			dst **uuid.UUID
+		}{
+			{"assignee_id", &filter.AssigneeID},
+			{"contact_id", &filter.ContactID},
+			{"session_id", &filter.SessionID},
+			{"pipeline_id", &filter.PipelineID},
+		}
+		for _, target := range targets {
+			id, ok := parseOptionalUUIDQuery(c, target.key)
+			if !ok {
+				return task.Filter{}, false
+			}
+			*target.dst = id
+		}
+	}
+
+	if value := c.Query("status"); value != "" {
+		for _, s := range strings.Split(value, ",") {
+			status := task.Status(strings.TrimSpace(s))
+			if status != task.StatusOpen && status != task.StatusCompleted && status != task.StatusCancelled {
+				apierrors.ValidationError(c, "status", "status must be open, completed or cancelled")
+				return task.Filter{}, false
+			}
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	for _, bound := range []struct {
+		key string
+		dst **time.Time
+	}{
+		{"due_before", &filter.DueBefore},
+		{"due_after", &filter.DueAfter},
+	} {
+		value := c.Query(bound.key)
+		if value == "" {
+			continue
+		}
+		at, err := parseAnalyticsDate(value)
+		if err != nil {
+			apierrors.ValidationError(c, bound.key, "Invalid "+bound.key+" (use RFC3339 or YYYY-MM-DD)")
+			return task.Filter{}, false
+		}
+		*bound.dst = &at
+	}
+
+	return filter, true
+}
+
+func respondTasks(c *gin.Context, tasks []taskapp.TaskView, total int64, filter task.Filter) {
+	c.JSON(http.StatusOK, gin.H{
+		"tasks":  tasks,
+		"total":  total,
+		"limit":  filter.Limit,
+		"offset": filter.Offset,
+	})
+}
@@ -0,0 +1,682 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	teamapp "github.com/ventros/crm/internal/application/team"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"go.uber.org/zap"
+)
+
+// TeamHandler expõe times, filas de atendimento, o "puxar a próxima" dos agentes e as métricas das filas
+type TeamHandler struct {
+	logger        *zap.Logger
+	teamsUseCase  *teamapp.ManageTeamsUseCase
+	queueSessions *teamapp.QueueSessionsUseCase
+	queueMonitor  *teamapp.QueueMonitorUseCase
+}
+
+func NewTeamHandler(
+	logger *zap.Logger,
+	teamsUseCase *teamapp.ManageTeamsUseCase,
+	queueSessions *teamapp.QueueSessionsUseCase,
+	queueMonitor *teamapp.QueueMonitorUseCase,
+) *TeamHandler {
+	return &TeamHandler{
+		logger:        logger,
+		teamsUseCase:  teamsUseCase,
+		queueSessions: queueSessions,
+		queueMonitor:  queueMonitor,
+	}
+}
+
+// TeamRequest corpo de criação/atualização de um time (member_ids substitui a lista inteira)
+type TeamRequest struct {
+	Name         string      `json:"name" binding:"required"`
+	Description  string      `json:"description"`
+	SupervisorID *uuid.UUID  `json:"supervisor_id"`
+	MemberIDs    []uuid.UUID `json:"member_ids"`
+}
+
+func (r TeamRequest) toInput() teamapp.TeamInput {
+	return teamapp.TeamInput{
+		Name:         r.Name,
+		Description:  r.Description,
+		SupervisorID: r.SupervisorID,
+		MemberIDs:    r.MemberIDs,
+	}
+}
+
+// TeamMemberRequest corpo da inclusão de um agente no time
+type TeamMemberRequest struct {
+	AgentID uuid.UUID `json:"agent_id" binding:"required"`
+}
+
+// QueueRequest corpo de criação/atualização de uma fila.
+// strategy manual = agentes puxam as sessões; as demais distribuem entre os membros do time.
+type QueueRequest struct {
+	TeamID   uuid.UUID                  `json:"team_id" binding:"required"`
+	Name     string                     `json:"name" binding:"required"`
+	Strategy project.AssignmentStrategy `json:"strategy" example:"round_robin"`
+	Priority int                        `json:"priority" example:"10"`
+	Enabled  *bool                      `json:"enabled"`
+}
+
+func (r QueueRequest) toInput() teamapp.QueueInput {
+	strategy := r.Strategy
+	if strategy == "" {
+		strategy = project.StrategyManual
+	}
+	return teamapp.QueueInput{
+		TeamID:   r.TeamID,
+		Name:     r.Name,
+		Strategy: strategy,
+		Priority: r.Priority,
+		Enabled:  r.Enabled == nil || *r.Enabled,
+	}
+}
+
+// ListTeams lists the teams of a project
+//
+//	@Summary		List teams
+//	@Description	Lista os times de atendimento do projeto (default: projeto do token).
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Success		200			{object}	map[string]interface{}	"Teams"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/teams [get]
+func (h *TeamHandler) ListTeams(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	teams, err := h.teamsUseCase.ListTeams(c.Request.Context(), authCtx.TenantID, projectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"teams": teams,
+		"total": len(teams),
+	})
+}
+
+// CreateTeam creates a team
+//
+//	@Summary		Create team
+//	@Description	Cria um time com membros (agentes) e supervisor opcional.
+//	@Tags			CRM - Teams & Queues
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Param			request		body		TeamRequest				true	"Team"
+//	@Success		201			{object}	teamapp.TeamView		"Team created"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/teams [post]
+func (h *TeamHandler) CreateTeam(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	t, err := h.teamsUseCase.CreateTeam(c.Request.Context(), authCtx.TenantID, projectID, req.toInput())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, t)
+}
+
+// GetTeam returns a team
+//
+//	@Summary		Get team
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Team ID (UUID)"
+//	@Success		200	{object}	teamapp.TeamView		"Team"
+//	@Failure		404	{object}	map[string]interface{}	"Team not found"
+//	@Router			/api/v1/crm/teams/{id} [get]
+func (h *TeamHandler) GetTeam(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	teamID, ok := pathUUID(c, "id", "team")
+	if !ok {
+		return
+	}
+
+	t, err := h.teamsUseCase.GetTeam(c.Request.Context(), authCtx.TenantID, teamID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// UpdateTeam replaces a team
+//
+//	@Summary		Update team
+//	@Description	Substitui nome, descrição, supervisor e membros do time.
+//	@Tags			CRM - Teams & Queues
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Team ID (UUID)"
+//	@Param			request	body		TeamRequest				true	"Team"
+//	@Success		200		{object}	teamapp.TeamView		"Team updated"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Team not found"
+//	@Router			/api/v1/crm/teams/{id} [put]
+func (h *TeamHandler) UpdateTeam(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	teamID, ok := pathUUID(c, "id", "team")
+	if !ok {
+		return
+	}
+
+	var req TeamRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	t, err := h.teamsUseCase.UpdateTeam(c.Request.Context(), authCtx.TenantID, teamID, req.toInput())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// DeleteTeam deletes a team
+//
+//	@Summary		Delete team
+//	@Description	Remove o time. Times com filas retornam 409: remova ou mova as filas antes.
+//	@Tags			CRM - Teams & Queues
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Team ID (UUID)"
+//	@Success		204	"Team deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Team not found"
+//	@Failure		409	{object}	map[string]interface{}	"Team has queues"
+//	@Router			/api/v1/crm/teams/{id} [delete]
+func (h *TeamHandler) DeleteTeam(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	teamID, ok := pathUUID(c, "id", "team")
+	if !ok {
+		return
+	}
+
+	if err := h.teamsUseCase.DeleteTeam(c.Request.Context(), authCtx.TenantID, teamID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// AddTeamMember adds an agent to a team
+//
+//	@Summary		Add team member
+//	@Tags			CRM - Teams & Queues
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Team ID (UUID)"
+//	@Param			request	body		TeamMemberRequest		true	"Agent"
+//	@Success		200		{object}	teamapp.TeamView		"Team updated"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Team not found"
+//	@Router			/api/v1/crm/teams/{id}/members [post]
+func (h *TeamHandler) AddTeamMember(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	teamID, ok := pathUUID(c, "id", "team")
+	if !ok {
+		return
+	}
+
+	var req TeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	t, err := h.teamsUseCase.AddMember(c.Request.Context(), authCtx.TenantID, teamID, req.AgentID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// RemoveTeamMember removes an agent from a team
+//
+//	@Summary		Remove team member
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id			path		string					true	"Team ID (UUID)"
+//	@Param			agent_id	path		string					true	"Agent ID (UUID)"
+//	@Success		200			{object}	teamapp.TeamView		"Team updated"
+//	@Failure		404			{object}	map[string]interface{}	"Team or member not found"
+//	@Router			/api/v1/crm/teams/{id}/members/{agent_id} [delete]
+func (h *TeamHandler) RemoveTeamMember(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	teamID, ok := pathUUID(c, "id", "team")
+	if !ok {
+		return
+	}
+	agentID, ok := pathUUID(c, "agent_id", "agent")
+	if !ok {
+		return
+	}
+
+	t, err := h.teamsUseCase.RemoveMember(c.Request.Context(), authCtx.TenantID, teamID, agentID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, t)
+}
+
+// ListQueues lists the queues of a project
+//
+//	@Summary		List queues
+//	@Description	Lista as filas de atendimento do projeto, maior prioridade primeiro.
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Success		200			{object}	map[string]interface{}	"Queues"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid parameters"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/queues [get]
+func (h *TeamHandler) ListQueues(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	queues, err := h.teamsUseCase.ListQueues(c.Request.Context(), authCtx.TenantID, projectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"queues": queues,
+		"total":  len(queues),
+	})
+}
+
+// CreateQueue creates a queue
+//
+//	@Summary		Create queue
+//	@Description	Cria uma fila atendida por um time. Sessões entram na fila pela ação assign_to_queue das automações.
+//	@Description	Filas com estratégia automática distribuem entre os membros do time; filas manual esperam o agente puxar.
+//	@Tags			CRM - Teams & Queues
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Param			request		body		QueueRequest			true	"Queue"
+//	@Success		201			{object}	teamapp.QueueView		"Queue created"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/queues [post]
+func (h *TeamHandler) CreateQueue(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	var req QueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	q, err := h.teamsUseCase.CreateQueue(c.Request.Context(), authCtx.TenantID, projectID, req.toInput())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, q)
+}
+
+// GetQueue returns a queue
+//
+//	@Summary		Get queue
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Queue ID (UUID)"
+//	@Success		200	{object}	teamapp.QueueView		"Queue"
+//	@Failure		404	{object}	map[string]interface{}	"Queue not found"
+//	@Router			/api/v1/crm/queues/{id} [get]
+func (h *TeamHandler) GetQueue(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	queueID, ok := pathUUID(c, "id", "queue")
+	if !ok {
+		return
+	}
+
+	q, err := h.teamsUseCase.GetQueue(c.Request.Context(), authCtx.TenantID, queueID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, q)
+}
+
+// UpdateQueue replaces a queue
+//
+//	@Summary		Update queue
+//	@Description	Substitui time, nome, estratégia, prioridade e estado da fila. Sessões aguardando permanecem na fila.
+//	@Tags			CRM - Teams & Queues
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Queue ID (UUID)"
+//	@Param			request	body		QueueRequest			true	"Queue"
+//	@Success		200		{object}	teamapp.QueueView		"Queue updated"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Queue not found"
+//	@Router			/api/v1/crm/queues/{id} [put]
+func (h *TeamHandler) UpdateQueue(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	queueID, ok := pathUUID(c, "id", "queue")
+	if !ok {
+		return
+	}
+
+	var req QueueRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	q, err := h.teamsUseCase.UpdateQueue(c.Request.Context(), authCtx.TenantID, queueID, req.toInput())
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, q)
+}
+
+// DeleteQueue deletes a queue
+//
+//	@Summary		Delete queue
+//	@Description	Remove a fila. Filas com sessões aguardando retornam 409.
+//	@Tags			CRM - Teams & Queues
+//	@Security		BearerAuth
+//	@Param			id	path	string	true	"Queue ID (UUID)"
+//	@Success		204	"Queue deleted"
+//	@Failure		404	{object}	map[string]interface{}	"Queue not found"
+//	@Failure		409	{object}	map[string]interface{}	"Queue has waiting sessions"
+//	@Router			/api/v1/crm/queues/{id} [delete]
+func (h *TeamHandler) DeleteQueue(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	queueID, ok := pathUUID(c, "id", "queue")
+	if !ok {
+		return
+	}
+
+	if err := h.teamsUseCase.DeleteQueue(c.Request.Context(), authCtx.TenantID, queueID); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// PickNext assigns the next waiting session to the caller
+//
+//	@Summary		Pick next session
+//	@Description	Atribui ao agente autenticado a próxima sessão das filas dos seus times:
+//	@Description	fila de maior prioridade primeiro, depois prioridade da sessão e tempo de espera.
+//	@Description	Sem sessões aguardando, retorna picked=false. Também disponível pelo websocket (queue_pick_next).
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	teamapp.PickResult		"Pick result"
+//	@Failure		403	{object}	map[string]interface{}	"Agent is not in any team"
+//	@Failure		404	{object}	map[string]interface{}	"Agent not found"
+//	@Router			/api/v1/crm/queues/pick-next [post]
+func (h *TeamHandler) PickNext(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	result, err := h.queueSessions.PickNext(c.Request.Context(), authCtx.TenantID, authCtx.UserID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetLiveView returns the supervisor live queue view
+//
+//	@Summary		Live queue view
+//	@Description	Filas dos times supervisionados (todas, para admin/supervisor): sessões aguardando, membros com status e carga, métricas das últimas 24h.
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id	query		string					false	"Project ID (UUID)"
+//	@Success		200			{object}	teamapp.LiveView		"Live view"
+//	@Failure		403			{object}	map[string]interface{}	"Not a supervisor"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Router			/api/v1/crm/queues/live [get]
+func (h *TeamHandler) GetLiveView(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+
+	view, err := h.queueMonitor.LiveView(c.Request.Context(), authCtx.TenantID, authCtx.UserID, projectID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, view)
+}
+
+// GetProjectQueueMetrics returns metrics for every queue of a project
+//
+//	@Summary		Queue metrics
+//	@Description	Aguardando, maior espera, atendidas, abandonadas, taxa de abandono (%) e espera média por fila.
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			project_id		query		string					false	"Project ID (UUID)"
+//	@Param			window_hours	query		int						false	"Janela das atendidas/abandonadas em horas (default: 24)"
+//	@Success		200				{object}	map[string]interface{}	"Queue metrics"
+//	@Failure		400				{object}	map[string]interface{}	"Invalid parameters"
+//	@Router			/api/v1/crm/queues/metrics [get]
+func (h *TeamHandler) GetProjectQueueMetrics(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	projectID, ok := queryProjectID(c, authCtx)
+	if !ok {
+		return
+	}
+	window, ok := queryMetricsWindow(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.queueMonitor.ProjectMetrics(c.Request.Context(), authCtx.TenantID, projectID, window)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"queues": metrics,
+		"total":  len(metrics),
+	})
+}
+
+// GetQueueMetrics returns the metrics of a queue
+//
+//	@Summary		Queue metrics by queue
+//	@Tags			CRM - Teams & Queues
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id				path		string						true	"Queue ID (UUID)"
+//	@Param			window_hours	query		int							false	"Janela das atendidas/abandonadas em horas (default: 24)"
+//	@Success		200				{object}	teamapp.QueueMetricsView	"Queue metrics"
+//	@Failure		404				{object}	map[string]interface{}		"Queue not found"
+//	@Router			/api/v1/crm/queues/{id}/metrics [get]
+func (h *TeamHandler) GetQueueMetrics(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		apierrors.Unauthorized(c, "Authentication required")
+		return
+	}
+
+	queueID, ok := pathUUID(c, "id", "queue")
+	if !ok {
+		return
+	}
+	window, ok := queryMetricsWindow(c)
+	if !ok {
+		return
+	}
+
+	metrics, err := h.queueMonitor.QueueMetrics(c.Request.Context(), authCtx.TenantID, queueID, window)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, metrics)
+}
+
+// pathUUID lê um UUID da rota
+func pathUUID(c *gin.Context, param, resource string) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param(param))
+	if err != nil {
+		apierrors.ValidationError(c, param, "Invalid "+resource+" ID format (must be UUID)")
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// queryMetricsWindow lê window_hours; zero = janela padrão do caso de uso
+func queryMetricsWindow(c *gin.Context) (time.Duration, bool) {
+	value := c.Query("window_hours")
+	if value == "" {
+		return 0, true
+	}
+	hours, err := strconv.Atoi(value)
+	if err != nil || hours <= 0 || hours > 24*90 {
+		apierrors.ValidationError(c, "window_hours", "window_hours must be between 1 and 2160")
+		return 0, false
+	}
+	return time.Duration(hours) * time.Hour, true
+}
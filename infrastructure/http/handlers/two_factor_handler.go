@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	authapp "github.com/ventros/crm/internal/application/auth"
+	"go.uber.org/zap"
+)
+
+// TwoFactorHandler 2FA TOTP do usuário (inscrição, códigos de recuperação), step-up para ações
+// sensíveis e a política de 2FA do projeto
+type TwoFactorHandler struct {
+	logger    *zap.Logger
+	twoFactor *authapp.TwoFactorUseCase
+}
+
+func NewTwoFactorHandler(logger *zap.Logger, twoFactor *authapp.TwoFactorUseCase) *TwoFactorHandler {
+	return &TwoFactorHandler{
+		logger:    logger,
+		twoFactor: twoFactor,
+	}
+}
+
+// TwoFactorCodeRequest código do app autenticador (6 dígitos) ou de recuperação (xxxxx-xxxxx)
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required" example:"123456"`
+}
+
+// StepUpRequest código 2FA (quem tem 2FA) ou senha (quem não tem)
+type StepUpRequest struct {
+	Code     string `json:"code,omitempty" example:"123456"`
+	Password string `json:"password,omitempty"`
+}
+
+// TwoFactorPolicyRequest política de 2FA do projeto
+type TwoFactorPolicyRequest struct {
+	RequireTwoFactor *bool `json:"require_two_factor" binding:"required"`
+}
+
+// GetStatus returns the two-factor status of the current user
+//
+//	@Summary		Two-factor status
+//	@Tags			AUTH - Two-Factor
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	authapp.TwoFactorStatus	"Status"
+//	@Router			/api/v1/auth/2fa [get]
+func (h *TwoFactorHandler) GetStatus(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+
+	status, err := h.twoFactor.Status(c.Request.Context(), authCtx.UserID)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, status)
+}
+
+// Enroll starts the two-factor enrollment
+//
+//	@Summary		Start two-factor enrollment
+//	@Description	Gera o segredo TOTP. Exiba provisioning_uri como QR code (ou o secret para digitação) e
+//	@Description	confirme com o primeiro código em /auth/2fa/confirm. Repetir substitui a inscrição pendente.
+//	@Tags			AUTH - Two-Factor
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	authapp.TwoFactorSetup	"Secret and provisioning URI"
+//	@Failure		409	{object}	map[string]interface{}	"Two-factor already enabled"
+//	@Router			/api/v1/auth/2fa/enroll [post]
+func (h *TwoFactorHandler) Enroll(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+
+	account := authCtx.Email
+	if account == "" {
+		account = authCtx.UserID.String()
+	}
+	setup, err := h.twoFactor.BeginEnrollment(c.Request.Context(), authCtx.UserID, account)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, setup)
+}
+
+// Confirm activates two-factor authentication
+//
+//	@Summary		Confirm two-factor enrollment
+//	@Description	Ativa o 2FA com o primeiro código do app e devolve os códigos de recuperação (exibidos uma única vez).
+//	@Tags			AUTH - Two-Factor
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		TwoFactorCodeRequest	true	"Code from the authenticator app"
+//	@Success		200		{object}	map[string]interface{}	"Recovery codes"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid code"
+//	@Router			/api/v1/auth/2fa/confirm [post]
+func (h *TwoFactorHandler) Confirm(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	codes, err := h.twoFactor.ConfirmEnrollment(c.Request.Context(), authCtx.UserID, currentSessionID(authCtx), req.Code)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes
+//
+//	@Summary		Regenerate recovery codes
+//	@Description	Invalida os códigos de recuperação atuais e devolve novos (exige um código válido).
+//	@Tags			AUTH - Two-Factor
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		TwoFactorCodeRequest	true	"Current two-factor code"
+//	@Success		200		{object}	map[string]interface{}	"Recovery codes"
+//	@Router			/api/v1/auth/2fa/recovery-codes [post]
+func (h *TwoFactorHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	codes, err := h.twoFactor.RegenerateRecoveryCodes(c.Request.Context(), authCtx.UserID, req.Code)
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"recovery_codes": codes})
+}
+
+// Disable removes two-factor authentication
+//
+//	@Summary		Disable two-factor
+//	@Description	Remove o 2FA (exige um código válido). Recusado enquanto o projeto exigir 2FA.
+//	@Tags			AUTH - Two-Factor
+//	@Accept			json
+//	@Security		BearerAuth
+//	@Param			request	body	TwoFactorCodeRequest	true	"Current two-factor code"
+//	@Success		204		"Two-factor disabled"
+//	@Failure		403		{object}	map[string]interface{}	"Required by project policy"
+//	@Router			/api/v1/auth/2fa/disable [post]
+func (h *TwoFactorHandler) Disable(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+	var req TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.twoFactor.Disable(c.Request.Context(), authCtx.UserID, authCtx.ProjectID, req.Code); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// StepUp re-authenticates the current session for sensitive actions
+//
+//	@Summary		Step-up authentication
+//	@Description	Confirma a identidade de novo (código 2FA; sem 2FA, a senha) e libera por 10 minutos as ações
+//	@Description	sensíveis que respondem 403 step_up_required (remover canal, ler credenciais, exportar dados).
+//	@Tags			AUTH - Two-Factor
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		StepUpRequest			true	"Code or password"
+//	@Success		200		{object}	authapp.StepUpResult	"Step-up window"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid code or password"
+//	@Router			/api/v1/auth/step-up [post]
+func (h *TwoFactorHandler) StepUp(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+	var req StepUpRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	result, err := h.twoFactor.StepUp(c.Request.Context(), authapp.StepUpRequest{
+		UserID:    authCtx.UserID,
+		SessionID: currentSessionID(authCtx),
+		Email:     authCtx.Email,
+		Code:      req.Code,
+		Password:  req.Password,
+	})
+	if err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// SetProjectPolicy requires two-factor authentication for every member of the project
+//
+//	@Summary		Set project two-factor policy
+//	@Description	Com require_two_factor=true, sessões sem 2FA só acessam o perfil e a inscrição no 2FA.
+//	@Description	Só o dono do projeto altera, e precisa ter o próprio 2FA ativo para ligar.
+//	@Tags			CRM - Projects
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Project ID (UUID)"
+//	@Param			request	body		TwoFactorPolicyRequest	true	"Policy"
+//	@Success		200		{object}	map[string]interface{}	"Policy updated"
+//	@Failure		403		{object}	map[string]interface{}	"Not the project owner"
+//	@Failure		412		{object}	map[string]interface{}	"Owner without two-factor"
+//	@Router			/api/v1/crm/projects/{id}/two-factor-policy [put]
+func (h *TwoFactorHandler) SetProjectPolicy(c *gin.Context) {
+	authCtx, ok := userAuthContext(c)
+	if !ok {
+		return
+	}
+	projectID, ok := pathUUID(c, "id", "project")
+	if !ok {
+		return
+	}
+	var req TwoFactorPolicyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apierrors.BadRequest(c, "Invalid request body: "+err.Error())
+		return
+	}
+
+	if err := h.twoFactor.SetProjectPolicy(c.Request.Context(), authCtx.UserID, projectID, *req.RequireTwoFactor); err != nil {
+		apierrors.RespondWithError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"project_id":         projectID,
+		"require_two_factor": *req.RequireTwoFactor,
+	})
+}
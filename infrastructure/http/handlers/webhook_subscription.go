@@ -1,7 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -12,14 +15,16 @@ import (
 )
 
 type WebhookSubscriptionHandler struct {
-	logger  *zap.Logger
-	useCase *webhookapp.ManageSubscriptionUseCase
+	logger     *zap.Logger
+	useCase    *webhookapp.ManageSubscriptionUseCase
+	deliveries *webhookapp.ManageDeliveriesUseCase
 }
 
-func NewWebhookSubscriptionHandler(logger *zap.Logger, useCase *webhookapp.ManageSubscriptionUseCase) *WebhookSubscriptionHandler {
+func NewWebhookSubscriptionHandler(logger *zap.Logger, useCase *webhookapp.ManageSubscriptionUseCase, deliveries *webhookapp.ManageDeliveriesUseCase) *WebhookSubscriptionHandler {
 	return &WebhookSubscriptionHandler{
-		logger:  logger,
-		useCase: useCase,
+		logger:     logger,
+		useCase:    useCase,
+		deliveries: deliveries,
 	}
 }
 
@@ -272,3 +277,287 @@ func (h *WebhookSubscriptionHandler) GetAvailableEvents(c *gin.Context) {
 		"queue_prefix": "waha.events",
 	})
 }
+
+type RotateSecretRequest struct {
+	Secret       string `json:"secret,omitempty" example:"whsec_my-new-secret-value"` // Vazio gera um segredo aleatório
+	OverlapHours *int   `json:"overlap_hours,omitempty" example:"24"`                 // Janela em que o segredo anterior ainda assina (padrão 24, máx 168)
+}
+
+// RotateSecret rotates the signing secret of a webhook subscription
+//
+//	@Summary		Rotate webhook signing secret
+//	@Description	Troca o segredo de assinatura. Durante overlap_hours o header Ventros-Signature traz uma assinatura v1 com cada segredo (novo e anterior), para o receptor trocar a configuração sem rejeitar entregas. overlap_hours=0 revoga o segredo anterior imediatamente. O novo segredo só é exibido nesta resposta.
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Webhook ID (UUID)"
+//	@Param			request	body		RotateSecretRequest			false	"Novo segredo e janela de rotação"
+//	@Success		200		{object}	webhookapp.SecretRotationDTO	"New secret"
+//	@Failure		400		{object}	map[string]interface{}		"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}		"Webhook not found"
+//	@Failure		500		{object}	map[string]interface{}		"Internal server error"
+//	@Router			/api/v1/webhook-subscriptions/{id}/rotate-secret [post]
+func (h *WebhookSubscriptionHandler) RotateSecret(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	var req RotateSecretRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+			return
+		}
+	}
+
+	overlap := webhook.DefaultSecretRotationOverlap
+	if req.OverlapHours != nil {
+		overlap = time.Duration(*req.OverlapHours) * time.Hour
+	}
+
+	result, err := h.useCase.RotateSecret(c.Request.Context(), authCtx.TenantID, id, req.Secret, overlap)
+	if err != nil {
+		switch {
+		case errors.Is(err, webhook.ErrNotFound):
+			c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+		case errors.Is(err, webhook.ErrInvalidSecret), errors.Is(err, webhook.ErrInvalidRotationOverlap):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		default:
+			h.logger.Error("Failed to rotate webhook secret", zap.Error(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rotate webhook secret"})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+type RedeliverFailedRequest struct {
+	Since time.Time `json:"since" binding:"required" example:"2024-01-01T00:00:00Z"`
+}
+
+// ListDeliveries lists delivery attempts of a webhook subscription
+//
+//	@Summary		List webhook deliveries
+//	@Description	Log de entregas da inscrição (uma linha por tentativa), mais recentes primeiro
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id			path		string					true	"Webhook ID (UUID)"
+//	@Param			event_type	query		string					false	"Filtra por tipo de evento"
+//	@Param			success		query		bool					false	"Filtra por sucesso"
+//	@Param			since		query		string					false	"Início (RFC3339 ou YYYY-MM-DD)"
+//	@Param			until		query		string					false	"Fim (RFC3339 ou YYYY-MM-DD)"
+//	@Param			limit		query		int						false	"Page size (default 50, max 500)"
+//	@Param			offset		query		int						false	"Offset"
+//	@Success		200			{object}	map[string]interface{}	"Deliveries"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}	"Webhook not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/webhook-subscriptions/{id}/deliveries [get]
+func (h *WebhookSubscriptionHandler) ListDeliveries(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	limit, offset := parsePagination(c)
+	filter := webhook.DeliveryFilter{
+		SubscriptionID: id,
+		EventType:      c.Query("event_type"),
+		Limit:          limit,
+		Offset:         offset,
+	}
+	if value := c.Query("success"); value != "" {
+		success, err := strconv.ParseBool(value)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid success filter"})
+			return
+		}
+		filter.Success = &success
+	}
+	since, err := optionalAnalyticsDate(c.Query("since"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid since date"})
+		return
+	}
+	if !since.IsZero() {
+		filter.Since = &since
+	}
+	until, err := optionalAnalyticsDate(c.Query("until"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid until date"})
+		return
+	}
+	if !until.IsZero() {
+		filter.Until = &until
+	}
+
+	deliveries, total, err := h.deliveries.ListDeliveries(c.Request.Context(), authCtx.TenantID, filter)
+	if err != nil {
+		h.respondDeliveryError(c, err, "Failed to list webhook deliveries")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"deliveries": deliveries,
+		"total":      total,
+		"limit":      limit,
+		"offset":     offset,
+	})
+}
+
+// GetDelivery returns one delivery attempt with request and response
+//
+//	@Summary		Get webhook delivery
+//	@Description	Detalhes de uma tentativa de entrega: request (headers sensíveis mascarados), status, resposta truncada, latência e erro
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id			path		string					true	"Webhook ID (UUID)"
+//	@Param			delivery_id	path		string					true	"Delivery ID (UUID)"
+//	@Success		200			{object}	webhookapp.DeliveryDTO	"Delivery"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}	"Delivery not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/webhook-subscriptions/{id}/deliveries/{delivery_id} [get]
+func (h *WebhookSubscriptionHandler) GetDelivery(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, deliveryID, ok := parseDeliveryPath(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.deliveries.GetDelivery(c.Request.Context(), authCtx.TenantID, id, deliveryID)
+	if err != nil {
+		h.respondDeliveryError(c, err, "Failed to get webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// ReplayDelivery re-sends a logged delivery
+//
+//	@Summary		Replay webhook delivery
+//	@Description	Reenvia manualmente o corpo de uma entrega (mesmo id de notificação) para o endpoint atual da inscrição. A nova tentativa entra no log.
+//	@Tags			webhooks
+//	@Produce		json
+//	@Param			id			path		string					true	"Webhook ID (UUID)"
+//	@Param			delivery_id	path		string					true	"Delivery ID (UUID)"
+//	@Success		200			{object}	webhookapp.DeliveryDTO	"New delivery attempt"
+//	@Failure		400			{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404			{object}	map[string]interface{}	"Delivery not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/webhook-subscriptions/{id}/deliveries/{delivery_id}/replay [post]
+func (h *WebhookSubscriptionHandler) ReplayDelivery(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, deliveryID, ok := parseDeliveryPath(c)
+	if !ok {
+		return
+	}
+
+	result, err := h.deliveries.Replay(c.Request.Context(), authCtx.TenantID, id, deliveryID)
+	if err != nil {
+		h.respondDeliveryError(c, err, "Failed to replay webhook delivery")
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// RedeliverFailed re-sends every notification that failed since a given time
+//
+//	@Summary		Redeliver failed webhook notifications
+//	@Description	Reenvia em segundo plano, em ordem cronológica, todas as notificações desde "since" que nunca foram entregues com sucesso (até 500 por chamada)
+//	@Tags			webhooks
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Webhook ID (UUID)"
+//	@Param			request	body		RedeliverFailedRequest	true	"Início da janela"
+//	@Success		202		{object}	map[string]interface{}	"Redelivery queued"
+//	@Failure		400		{object}	map[string]interface{}	"Invalid request"
+//	@Failure		404		{object}	map[string]interface{}	"Webhook not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Router			/api/v1/webhook-subscriptions/{id}/redeliver-failed [post]
+func (h *WebhookSubscriptionHandler) RedeliverFailed(c *gin.Context) {
+	authCtx, exists := middleware.GetAuthContext(c)
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Authentication required"})
+		return
+	}
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return
+	}
+
+	var req RedeliverFailedRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request: " + err.Error()})
+		return
+	}
+
+	queued, err := h.deliveries.RedeliverFailed(c.Request.Context(), authCtx.TenantID, id, req.Since)
+	if err != nil {
+		h.respondDeliveryError(c, err, "Failed to redeliver webhook notifications")
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"queued": queued,
+		"since":  req.Since,
+	})
+}
+
+func parseDeliveryPath(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid webhook ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	deliveryID, err := uuid.Parse(c.Param("delivery_id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return id, deliveryID, true
+}
+
+func (h *WebhookSubscriptionHandler) respondDeliveryError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, webhook.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Webhook not found"})
+	case errors.Is(err, webhook.ErrDeliveryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+	case errors.Is(err, webhookapp.ErrInvalidRedeliverSince):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	default:
+		h.logger.Error(message, zap.Error(err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": message})
+	}
+}
@@ -52,10 +52,11 @@ func NewWebSocketMessageHandler(hub *ws.Hub, production bool, logger *zap.Logger
 //	@Description	Establishes WebSocket connection for bi-directional real-time messaging
 //	@Tags			websocket
 //	@Security		BearerAuth
-//	@Param			token	query	string	false	"Authentication token (alternative to Bearer header)"
+//	@Param			token		query	string	false	"Authentication token (alternative to Bearer header)"
+//	@Param			project_id	query	string	false	"Project ID (alternative to X-Project-ID header)"
 //	@Success		101		"Switching Protocols - WebSocket connection established"
 //	@Failure		401		{object}	map[string]string	"Unauthorized - invalid or missing token"
-//	@Failure		403		{object}	map[string]string	"Forbidden - origin not allowed"
+//	@Failure		403		{object}	map[string]string	"Forbidden - origin not allowed or no sessions.view in the project"
 //	@Failure		500		{object}	map[string]string	"Internal server error"
 //	@Router			/api/v1/ws/messages [get]
 func (h *WebSocketMessageHandler) HandleWebSocket(c *gin.Context) {
@@ -69,10 +70,21 @@ func (h *WebSocketMessageHandler) HandleWebSocket(c *gin.Context) {
 		return
 	}
 
+	// SECURITY: permissões por ação, reavaliadas com o papel atual no projeto
+	authorize, ok := middleware.GetProjectAuthorizer(c)
+	if !ok {
+		c.JSON(http.StatusForbidden, gin.H{
+			"error": "forbidden",
+			"hint":  "Project access required",
+		})
+		return
+	}
+
 	// SECURITY: Log de conexão para auditoria
 	h.logger.Info("WebSocket connection attempt",
 		zap.String("user_id", authCtx.UserID.String()),
 		zap.String("tenant_id", authCtx.TenantID),
+		zap.String("project_id", authCtx.ProjectID.String()),
 		zap.String("remote_addr", c.ClientIP()),
 		zap.String("origin", c.GetHeader("Origin")),
 		zap.String("user_agent", c.GetHeader("User-Agent")))
@@ -88,6 +100,10 @@ func (h *WebSocketMessageHandler) HandleWebSocket(c *gin.Context) {
 
 	// Criar cliente WebSocket
 	client := ws.NewClient(h.hub, conn, authCtx.UserID, authCtx.TenantID, authCtx.ProjectID, h.logger)
+	client.SetAuthorizer(ws.Authorizer(authorize))
+	if checkStanding, ok := middleware.GetProjectStandingChecker(c); ok {
+		client.SetStandingChecker(ws.StandingChecker(checkStanding))
+	}
 
 	// Registrar no hub
 	h.hub.Register <- client
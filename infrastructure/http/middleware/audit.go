@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"go.uber.org/zap"
+)
+
+// AuditTrail grava na trilha de auditoria toda requisição autenticada que altera dados
+// (POST, PUT, PATCH, DELETE) e as leituras marcadas com AuditAccess. Um registro por requisição,
+// gravado depois do handler, com o status da resposta e o diff anotado pelos use cases.
+// Deve ser registrado antes das rotas (router.Use), depois do CorrelationIDMiddleware.
+func AuditTrail(recorder *auditapp.Recorder, logger *zap.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx, scope := auditapp.WithScope(c.Request.Context())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+
+		authCtx, exists := GetAuthContext(c)
+		route := c.FullPath()
+		if !exists || route == "" {
+			return
+		}
+		action := scope.Action()
+		if action == "" {
+			if !isMutation(c.Request.Method) {
+				return
+			}
+			action = routeAction(c.Request.Method, route)
+		}
+		resourceType, resourceID := scope.Resource()
+		if resourceType == "" {
+			resourceType, resourceID = routeResource(route), c.Param("id")
+		}
+
+		actor := audit.Actor{Type: audit.ActorUser, ID: authCtx.UserID.String(), Label: authCtx.Email}
+		if authCtx.IsAPIKey() {
+			actor = audit.Actor{Type: audit.ActorAPIKey, ID: authCtx.APIKeyID.String(), Label: authCtx.Email}
+		}
+		metadata := map[string]string{"method": c.Request.Method, "route": route}
+		for key, value := range scope.Metadata() {
+			metadata[key] = value
+		}
+
+		// A requisição já terminou: o registro não pode ser cancelado junto com ela
+		err := recorder.Record(context.WithoutCancel(c.Request.Context()), audit.Record{
+			ProjectID:     authCtx.ProjectID,
+			TenantID:      authCtx.TenantID,
+			Actor:         actor,
+			Action:        action,
+			ResourceType:  resourceType,
+			ResourceID:    resourceID,
+			Status:        c.Writer.Status(),
+			IP:            c.ClientIP(),
+			UserAgent:     c.Request.UserAgent(),
+			CorrelationID: GetCorrelationIDFromGin(c),
+			Changes:       scope.Changes(),
+			Metadata:      metadata,
+		})
+		if err != nil {
+			logger.Error("Audit entry lost", zap.String("action", action), zap.String("route", route), zap.Error(err))
+		}
+	}
+}
+
+// AuditAccess marca uma leitura sensível (exportação, detalhes com payload) para a trilha
+func AuditAccess(action string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		auditapp.SetAction(c.Request.Context(), action)
+		c.Next()
+	}
+}
+
+func isMutation(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// routeSegments segmentos fixos da rota, sem o prefixo /api/v1[/crm] e sem os parâmetros
+func routeSegments(route string) []string {
+	var segments []string
+	for i, segment := range strings.Split(strings.Trim(route, "/"), "/") {
+		if segment == "" || strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			continue
+		}
+		if (i == 0 && segment == "api") || (i == 1 && segment == "v1") || (i == 2 && segment == "crm") {
+			continue
+		}
+		segments = append(segments, segment)
+	}
+	return segments
+}
+
+// routeAction ação derivada da rota: DELETE /api/v1/channels/:id vira channels.delete e
+// POST /api/v1/webhook-subscriptions/:id/rotate-secret vira webhook-subscriptions.rotate-secret
+func routeAction(method, route string) string {
+	segments := routeSegments(route)
+	if len(segments) == 0 {
+		return strings.ToLower(method)
+	}
+	// POST em sub-rota é um comando (activate, replay, rotate-secret): o nome já é o verbo
+	if method == http.MethodPost && len(segments) > 1 {
+		return strings.Join(segments, ".")
+	}
+	verb := map[string]string{
+		http.MethodPost:   "create",
+		http.MethodPut:    "update",
+		http.MethodPatch:  "update",
+		http.MethodDelete: "delete",
+	}[method]
+	if verb == "" {
+		verb = strings.ToLower(method)
+	}
+	return strings.Join(segments, ".") + "." + verb
+}
+
+func routeResource(route string) string {
+	if segments := routeSegments(route); len(segments) > 0 {
+		return segments[0]
+	}
+	return ""
+}
@@ -1,15 +1,34 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	apikeyapp "github.com/ventros/crm/internal/application/apikey"
+	authapp "github.com/ventros/crm/internal/application/auth"
 	"github.com/ventros/crm/internal/application/user"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
 	"go.uber.org/zap"
 )
 
+// APIKeyPrincipalKey chave no gin.Context com o *apikeyapp.Principal das requisições autenticadas por API key
+const APIKeyPrincipalKey = "api_key"
+
+// APIKeyAuthenticator valida as API keys por projeto (tokens vtr_...)
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, token, clientIP string) (*apikeyapp.Principal, error)
+}
+
+// AccessTokenVerifier valida os access tokens (JWT) emitidos pelo login nativo (senha ou OIDC)
+type AccessTokenVerifier interface {
+	VerifyAccessToken(ctx context.Context, token string) (*authapp.AccessPrincipal, error)
+}
+
 // AuthContext representa o contexto de autenticação
 type AuthContext struct {
 	UserID    uuid.UUID `json:"user_id"`
@@ -17,6 +36,27 @@ type AuthContext struct {
 	Role      string    `json:"role"`
 	TenantID  string    `json:"tenant_id"`
 	ProjectID uuid.UUID `json:"project_id"`
+
+	// Preenchidos só quando a requisição usa uma API key do projeto
+	APIKeyID    *uuid.UUID                  `json:"api_key_id,omitempty"`
+	Permissions []project_member.Permission `json:"permissions,omitempty"`
+
+	// Preenchidos só quando a requisição usa um access token de sessão
+	SessionID         *uuid.UUID `json:"session_id,omitempty"`
+	ReauthenticatedAt *time.Time `json:"reauthenticated_at,omitempty"`
+	// TwoFactorPending o projeto exige 2FA e a sessão ainda não passou pelo segundo fator
+	TwoFactorPending bool `json:"two_factor_pending,omitempty"`
+	// TwoFactorVerified a sessão passou pelo segundo fator (vale para qualquer projeto acessado)
+	TwoFactorVerified bool `json:"two_factor_verified,omitempty"`
+
+	// DevBypass credencial de desenvolvimento (headers X-Dev-* ou dev keys): o RBAC não consulta
+	// o banco e usa o papel de X-Dev-Project-Role
+	DevBypass bool `json:"-"`
+}
+
+// IsAPIKey indica se a requisição foi autenticada por uma API key do projeto
+func (a *AuthContext) IsAPIKey() bool {
+	return a.APIKeyID != nil
 }
 
 // AuthMiddleware é um middleware flexível para desenvolvimento
@@ -24,18 +64,35 @@ type AuthMiddleware struct {
 	logger      *zap.Logger
 	devMode     bool
 	userService *user.UserService
+	apiKeys     APIKeyAuthenticator
+	sessions    AccessTokenVerifier
 }
 
-func NewAuthMiddleware(logger *zap.Logger, devMode bool, userService *user.UserService) *AuthMiddleware {
+// NewAuthMiddleware apiKeys nil desabilita as API keys por projeto (só as chaves legadas do usuário);
+// sessions nil desabilita os access tokens do login nativo
+func NewAuthMiddleware(logger *zap.Logger, devMode bool, userService *user.UserService, apiKeys APIKeyAuthenticator, sessions AccessTokenVerifier) *AuthMiddleware {
 	return &AuthMiddleware{
 		logger:      logger,
 		devMode:     devMode,
 		userService: userService,
+		apiKeys:     apiKeys,
+		sessions:    sessions,
 	}
 }
 
-// Authenticate middleware flexível para desenvolvimento
+// Authenticate middleware flexível para desenvolvimento. Sessões sem o 2FA exigido pelo projeto
+// são recusadas (403 two_factor_required).
 func (a *AuthMiddleware) Authenticate() gin.HandlerFunc {
+	return a.authenticate(false)
+}
+
+// AuthenticateAllowingTwoFactorSetup como Authenticate, mas deixa passar a sessão que ainda precisa
+// cadastrar o 2FA exigido pelo projeto (rotas de perfil, sessões e inscrição no 2FA)
+func (a *AuthMiddleware) AuthenticateAllowingTwoFactorSetup() gin.HandlerFunc {
+	return a.authenticate(true)
+}
+
+func (a *AuthMiddleware) authenticate(allowTwoFactorPending bool) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Em modo dev, permite bypass com headers especiais
 		if a.devMode {
@@ -48,6 +105,15 @@ func (a *AuthMiddleware) Authenticate() gin.HandlerFunc {
 
 		// Auth normal (API Key ou JWT)
 		if authCtx := a.handleAPIKeyAuth(c); authCtx != nil {
+			if authCtx.TwoFactorPending && !allowTwoFactorPending {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":   "two_factor_required",
+					"message": "This project requires two-factor authentication",
+					"hint":    "Enable it with POST /api/v1/auth/2fa/enroll and POST /api/v1/auth/2fa/confirm",
+				})
+				c.Abort()
+				return
+			}
 			c.Set("auth", authCtx)
 			c.Next()
 			return
@@ -56,7 +122,7 @@ func (a *AuthMiddleware) Authenticate() gin.HandlerFunc {
 		// Se chegou aqui, não autenticado
 		c.JSON(http.StatusUnauthorized, gin.H{
 			"error": "Authentication required",
-			"hint":  "Use X-Dev-User-ID header in dev mode or Authorization: Bearer <api_key>",
+			"hint":  "Use X-Dev-User-ID header in dev mode or Authorization: Bearer <access_token|api_key>",
 		})
 		c.Abort()
 	}
@@ -113,6 +179,7 @@ func (a *AuthMiddleware) handleDevAuth(c *gin.Context) *AuthContext {
 		Role:      role,
 		TenantID:  tenantID,
 		ProjectID: projectID,
+		DevBypass: true,
 	}
 }
 
@@ -126,19 +193,27 @@ func (a *AuthMiddleware) handleAPIKeyAuth(c *gin.Context) *AuthContext {
 	// Suporte para Bearer token
 	if strings.HasPrefix(authHeader, "Bearer ") {
 		apiKey := strings.TrimPrefix(authHeader, "Bearer ")
-		return a.validateAPIKey(apiKey)
+		return a.validateAPIKey(c, apiKey)
 	}
 
 	// Suporte para API Key direto
-	return a.validateAPIKey(authHeader)
+	return a.validateAPIKey(c, authHeader)
 }
 
-// validateAPIKey valida a API key usando o UserService
-func (a *AuthMiddleware) validateAPIKey(apiKey string) *AuthContext {
+// validateAPIKey valida a credencial: access token (JWT) pela sessão, vtr_... pelo pacote apikey,
+// as demais pelo UserService
+func (a *AuthMiddleware) validateAPIKey(c *gin.Context, apiKey string) *AuthContext {
 	if len(apiKey) < 10 {
 		return nil
 	}
 
+	if authapp.IsAccessToken(apiKey) {
+		return a.validateAccessToken(c, apiKey)
+	}
+	if apikey.IsToken(apiKey) {
+		return a.validateProjectAPIKey(c, apiKey)
+	}
+
 	// Em modo dev, mantém keys de desenvolvimento para facilitar testes
 	if a.devMode {
 		switch apiKey {
@@ -149,6 +224,7 @@ func (a *AuthMiddleware) validateAPIKey(apiKey string) *AuthContext {
 				Role:      "admin",
 				TenantID:  "dev-tenant",
 				ProjectID: uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				DevBypass: true,
 			}
 		case "dev-user-key":
 			return &AuthContext{
@@ -157,6 +233,7 @@ func (a *AuthMiddleware) validateAPIKey(apiKey string) *AuthContext {
 				Role:      "user",
 				TenantID:  "dev-tenant",
 				ProjectID: uuid.MustParse("11111111-1111-1111-1111-111111111111"),
+				DevBypass: true,
 			}
 		}
 	}
@@ -181,6 +258,92 @@ func (a *AuthMiddleware) validateAPIKey(apiKey string) *AuthContext {
 	return nil
 }
 
+// validateAccessToken autentica o access token do login nativo; a sessão revogada derruba o token
+// mesmo antes de ele expirar
+func (a *AuthMiddleware) validateAccessToken(c *gin.Context, token string) *AuthContext {
+	if a.sessions == nil {
+		return nil
+	}
+
+	principal, err := a.sessions.VerifyAccessToken(c.Request.Context(), token)
+	if err != nil {
+		a.logger.Debug("Access token validation failed", zap.Error(err))
+		return nil
+	}
+
+	sessionID := principal.SessionID
+	reauthenticatedAt := principal.ReauthenticatedAt
+	return &AuthContext{
+		UserID:            principal.UserID,
+		Email:             principal.Email,
+		Role:              principal.Role,
+		TenantID:          principal.TenantID,
+		ProjectID:         principal.ProjectID,
+		SessionID:         &sessionID,
+		ReauthenticatedAt: &reauthenticatedAt,
+		TwoFactorPending:  principal.TwoFactorPending,
+		TwoFactorVerified: principal.TwoFactorVerified,
+	}
+}
+
+// validateProjectAPIKey autentica uma API key do projeto. A requisição age em nome de quem
+// criou a chave, restrita ao projeto e às permissões da chave.
+func (a *AuthMiddleware) validateProjectAPIKey(c *gin.Context, token string) *AuthContext {
+	if a.apiKeys == nil {
+		return nil
+	}
+
+	principal, err := a.apiKeys.Authenticate(c.Request.Context(), token, c.ClientIP())
+	if err != nil {
+		a.logger.Debug("API key validation failed", zap.Error(err))
+		return nil
+	}
+	c.Set(APIKeyPrincipalKey, principal)
+
+	keyID := principal.KeyID
+	return &AuthContext{
+		UserID:      principal.UserID,
+		Role:        "api_key",
+		TenantID:    principal.TenantID,
+		ProjectID:   principal.ProjectID,
+		APIKeyID:    &keyID,
+		Permissions: principal.Permissions,
+	}
+}
+
+// GetAPIKeyPrincipal extrai a API key que autenticou a request (AuthMiddleware ou JWTAuthMiddleware)
+func GetAPIKeyPrincipal(c *gin.Context) (*apikeyapp.Principal, bool) {
+	value, exists := c.Get(APIKeyPrincipalKey)
+	if !exists {
+		return nil, false
+	}
+
+	principal, ok := value.(*apikeyapp.Principal)
+	return principal, ok
+}
+
+// RequireStepUp exige autenticação recente (login ou POST /api/v1/auth/step-up nos últimos
+// authapp.StepUpWindow) para ações sensíveis de sessões de login. API keys são credenciais de
+// máquina, sem segundo fator: continuam limitadas pelas próprias permissões.
+func RequireStepUp() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authCtx, ok := GetAuthContext(c)
+		if ok && authCtx.SessionID != nil {
+			if authCtx.ReauthenticatedAt == nil || time.Since(*authCtx.ReauthenticatedAt) > authapp.StepUpWindow {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":   "step_up_required",
+					"message": "This action requires recent authentication",
+					"hint":    "Confirm your identity with POST /api/v1/auth/step-up and retry",
+				})
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
 // GetAuthContext extrai o contexto de auth da request
 func GetAuthContext(c *gin.Context) (*AuthContext, bool) {
 	auth, exists := c.Get("auth")
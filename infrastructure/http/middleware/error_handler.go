@@ -142,8 +142,9 @@ func logError(c *gin.Context, logger *zap.Logger, err error) {
 			// Business logic errors - log as warning
 			logger.Warn("Business logic error", append(fields, zap.Error(err))...)
 
-		case shared.ErrorTypeRateLimit:
-			// Rate limit - log as info
+		case shared.ErrorTypeRateLimit,
+			shared.ErrorTypePaymentRequired:
+			// Rate limit / plan limit - log as info
 			logger.Info("Rate limit exceeded", append(fields, zap.Error(err))...)
 
 		case shared.ErrorTypeTimeout:
@@ -12,6 +12,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/sirupsen/logrus"
+	"github.com/ventros/crm/infrastructure/oidc"
+	"github.com/ventros/crm/internal/domain/core/apikey"
 )
 
 // AuthClaims representa os claims do JWT do Keycloak
@@ -51,12 +53,24 @@ type JWTConfig struct {
 	ClientID      string
 	RequiredRoles []string // Roles necessários (customer, agent)
 	Logger        *logrus.Logger
+
+	// Issuer de qualquer provedor OIDC (Google, Azure AD, Okta); o JWKS vem do discovery.
+	// Vazio = Keycloak em KeycloakURL/realms/Realm
+	Issuer string
+	// Audience aud exigido no token (default: ClientID)
+	Audience string
+
+	// APIKeys aceita também API keys do projeto (Bearer vtr_...) no lugar do JWT; nil = só JWT
+	APIKeys APIKeyAuthenticator
 }
 
 // JWTAuthMiddleware cria o middleware de autenticação JWT
 func JWTAuthMiddleware(config JWTConfig) gin.HandlerFunc {
 	// Initialize JWKS (JSON Web Key Set) client
-	jwksURL := fmt.Sprintf("%s/realms/%s/protocol/openid-connect/certs", config.KeycloakURL, config.Realm)
+	jwksURL, err := resolveJWKSURL(config)
+	if err != nil {
+		config.Logger.Fatalf("Failed to discover JWKS: %v", err)
+	}
 
 	// Keyfunc will automatically refresh keys every 5 minutes
 	jwks, err := keyfunc.Get(jwksURL, keyfunc.Options{
@@ -88,6 +102,12 @@ func JWTAuthMiddleware(config JWTConfig) gin.HandlerFunc {
 
 		tokenString := parts[1]
 
+		// API key do projeto: as permissões da chave substituem as roles do Keycloak
+		if config.APIKeys != nil && apikey.IsToken(tokenString) {
+			authenticateAPIKey(c, config, tokenString)
+			return
+		}
+
 		// Parse and validate JWT
 		token, err := jwt.ParseWithClaims(tokenString, &AuthClaims{}, jwks.Keyfunc)
 		if err != nil {
@@ -136,12 +156,7 @@ func JWTAuthMiddleware(config JWTConfig) gin.HandlerFunc {
 			Roles:             roles,
 		}
 
-		// Store in Gin context
-		c.Set(string(UserContextKey), userCtx)
-
-		// Store in request context (for use in application layer)
-		ctx := context.WithValue(c.Request.Context(), UserContextKey, userCtx)
-		c.Request = c.Request.WithContext(ctx)
+		setUserContext(c, userCtx)
 
 		// Log authentication (without sensitive data)
 		config.Logger.WithFields(logrus.Fields{
@@ -156,6 +171,63 @@ func JWTAuthMiddleware(config JWTConfig) gin.HandlerFunc {
 	}
 }
 
+// expectedIssuer issuer configurado ou o do realm Keycloak
+func expectedIssuer(config JWTConfig) string {
+	if config.Issuer != "" {
+		return strings.TrimSuffix(config.Issuer, "/")
+	}
+	return fmt.Sprintf("%s/realms/%s", config.KeycloakURL, config.Realm)
+}
+
+// resolveJWKSURL Keycloak tem URL fixa; os demais provedores publicam jwks_uri no discovery
+func resolveJWKSURL(config JWTConfig) (string, error) {
+	if config.Issuer == "" {
+		return fmt.Sprintf("%s/realms/%s/protocol/openid-connect/certs", config.KeycloakURL, config.Realm), nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	discovery, err := oidc.Discover(ctx, http.DefaultClient, config.Issuer)
+	if err != nil {
+		return "", err
+	}
+	return discovery.JWKSURI, nil
+}
+
+// authenticateAPIKey autentica a requisição por API key. O UserContext usa "api_key:<id>" como
+// Subject; RBACMiddleware reconhece a chave pelo principal e aplica o projeto e as permissões dela.
+func authenticateAPIKey(c *gin.Context, config JWTConfig, token string) {
+	principal, err := config.APIKeys.Authenticate(c.Request.Context(), token, c.ClientIP())
+	if err != nil {
+		config.Logger.Warnf("API key authentication error: %v", err)
+		respondUnauthorized(c, "invalid api key")
+		return
+	}
+
+	c.Set(APIKeyPrincipalKey, principal)
+	setUserContext(c, &UserContext{
+		Subject:  "api_key:" + principal.KeyID.String(),
+		Name:     principal.Name,
+		Roles:    []string{},
+		TenantID: principal.TenantID,
+	})
+
+	config.Logger.WithFields(logrus.Fields{
+		"api_key_id": principal.KeyID,
+		"project_id": principal.ProjectID,
+		"path":       c.Request.URL.Path,
+		"method":     c.Request.Method,
+	}).Debug("API key authenticated")
+
+	c.Next()
+}
+
+// setUserContext guarda o usuário no Gin context e no context.Context da request (camada de aplicação)
+func setUserContext(c *gin.Context, userCtx *UserContext) {
+	c.Set(string(UserContextKey), userCtx)
+	ctx := context.WithValue(c.Request.Context(), UserContextKey, userCtx)
+	c.Request = c.Request.WithContext(ctx)
+}
+
 // OptionalJWTAuthMiddleware middleware JWT opcional (permite requests sem auth)
 func OptionalJWTAuthMiddleware(config JWTConfig) gin.HandlerFunc {
 	requiredMiddleware := JWTAuthMiddleware(config)
@@ -182,17 +254,21 @@ func validateClaims(claims *AuthClaims, config JWTConfig) error {
 	}
 
 	// Validate issuer
-	expectedIssuer := fmt.Sprintf("%s/realms/%s", config.KeycloakURL, config.Realm)
-	if claims.Issuer != expectedIssuer {
-		return fmt.Errorf("invalid issuer: expected %s, got %s", expectedIssuer, claims.Issuer)
+	issuer := expectedIssuer(config)
+	if strings.TrimSuffix(claims.Issuer, "/") != issuer {
+		return fmt.Errorf("invalid issuer: expected %s, got %s", issuer, claims.Issuer)
 	}
 
-	// Validate audience (if client_id is configured)
-	if config.ClientID != "" {
+	// Validate audience (if audience or client_id is configured)
+	audience := config.Audience
+	if audience == "" {
+		audience = config.ClientID
+	}
+	if audience != "" {
 		if claims.Audience != nil {
 			found := false
 			for _, aud := range claims.Audience {
-				if aud == config.ClientID {
+				if aud == audience {
 					found = true
 					break
 				}
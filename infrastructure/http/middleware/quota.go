@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+)
+
+// QuotaChecker verifica o limite do plano antes de uma ação que consome a métrica
+type QuotaChecker interface {
+	Check(ctx context.Context, projectID uuid.UUID, metric billing.UsageMetric, quantity int64) error
+}
+
+// QuotaMiddleware bloqueia com 402 as ações que passariam do limite rígido do plano do projeto
+type QuotaMiddleware struct {
+	checker QuotaChecker
+	logger  *zap.Logger
+}
+
+func NewQuotaMiddleware(checker QuotaChecker, logger *zap.Logger) *QuotaMiddleware {
+	return &QuotaMiddleware{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+// RequireQuota exige espaço no plano para mais uma unidade da métrica. Deve vir depois de
+// RequireProjectMember. Falhas ao consultar o plano não bloqueiam a requisição (só são logadas):
+// o consumo é contabilizado de qualquer forma pelo outbox.
+func (m *QuotaMiddleware) RequireQuota(metric billing.UsageMetric) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if m == nil || m.checker == nil {
+			c.Next()
+			return
+		}
+
+		access, ok := GetProjectAccess(c)
+		if !ok {
+			c.Next()
+			return
+		}
+
+		err := m.checker.Check(c.Request.Context(), access.ProjectID, metric, 1)
+		switch {
+		case err == nil:
+			c.Next()
+		case shared.IsPaymentRequiredError(err):
+			apierrors.RespondWithError(c, err)
+			c.Abort()
+		default:
+			m.logger.Warn("Quota check failed, allowing request",
+				zap.String("project_id", access.ProjectID.String()),
+				zap.String("metric", string(metric)),
+				zap.Error(err))
+			c.Next()
+		}
+	}
+}
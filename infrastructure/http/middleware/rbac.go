@@ -2,96 +2,307 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
-	"github.com/sirupsen/logrus"
+	apierrors "github.com/ventros/crm/infrastructure/http/errors"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
 )
 
-// ProjectMemberRepository interface para buscar project members
-type ProjectMemberRepository interface {
-	FindByProjectAndAgent(ctx context.Context, projectID uuid.UUID, agentID string) (*project_member.ProjectMember, error)
+const (
+	// ProjectHeader escolhe o projeto da requisição entre os que o usuário participa (sem o
+	// header vale o projeto da sessão ou da API key)
+	ProjectHeader = "X-Project-ID"
+
+	// DevProjectRoleHeader papel usado no bypass de desenvolvimento (default admin)
+	DevProjectRoleHeader = "X-Dev-Project-Role"
+
+	// ProjectAccessKey chave no gin.Context com o *ProjectAccess resolvido pelo RBACMiddleware
+	ProjectAccessKey = "project_access"
+)
+
+// ProjectAccessResolver resolve papel e permissões do usuário no projeto (com cache invalidado a
+// cada mudança de papel, membro ou dono)
+type ProjectAccessResolver interface {
+	Resolve(ctx context.Context, projectID, userID uuid.UUID) (*projectapp.Access, error)
+	RolePermissions(ctx context.Context, projectID uuid.UUID, role project_member.ProjectMemberRole) ([]project_member.Permission, error)
+}
+
+// ErrAPIKeyWrongProject API key usada fora do projeto da chave
+var ErrAPIKeyWrongProject = errors.New("api key not valid for this project")
+
+// ErrTwoFactorRequired o projeto escolhido exige 2FA e a sessão não passou pelo segundo fator
+var ErrTwoFactorRequired = errors.New("project requires two-factor authentication")
+
+// ProjectAccess acesso de quem fez a requisição ao projeto
+type ProjectAccess struct {
+	ProjectID uuid.UUID
+	TenantID  string
+	Role      project_member.ProjectMemberRole
+	// Owner dono do projeto (customer_id): admin mesmo sem linha em project_members
+	Owner bool
+	// Permissions permissões efetivas: do papel (padrão ou custom) ou da API key
+	Permissions []project_member.Permission
+}
+
+// HasPermission indica se o acesso concede a permissão
+func (a *ProjectAccess) HasPermission(permission project_member.Permission) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// StandingChecker situação de cobrança do projeto: conta em modo somente leitura ou suspensa
+// responde PAYMENT_REQUIRED
+type StandingChecker interface {
+	CheckStanding(ctx context.Context, projectID uuid.UUID) error
 }
 
 // RBACMiddleware middleware de verificação de permissões RBAC
 type RBACMiddleware struct {
-	repo   ProjectMemberRepository
-	logger *logrus.Logger
+	resolver ProjectAccessResolver
+	standing StandingChecker
+	logger   *zap.Logger
 }
 
 // NewRBACMiddleware cria nova instância do middleware RBAC
-func NewRBACMiddleware(repo ProjectMemberRepository, logger *logrus.Logger) *RBACMiddleware {
+func NewRBACMiddleware(resolver ProjectAccessResolver, logger *zap.Logger) *RBACMiddleware {
 	return &RBACMiddleware{
-		repo:   repo,
-		logger: logger,
+		resolver: resolver,
+		logger:   logger,
 	}
 }
 
-// RequireProjectMember verifica se o usuário é membro do projeto
+// SetStandingChecker passa a bloquear com 402 as escritas (POST, PUT, PATCH, DELETE) nos projetos
+// cuja conta de cobrança está restrita por fatura em atraso, e também as escritas pelo WebSocket
+// (envio de mensagem, pegar sessão da fila). Leituras seguem liberadas.
+func (m *RBACMiddleware) SetStandingChecker(checker StandingChecker) {
+	m.standing = checker
+}
+
+// RequireProjectMember resolve o projeto ativo (header X-Project-ID ou o projeto da credencial) e
+// exige que quem chama participe dele. O AuthContext passa a apontar para esse projeto, então os
+// handlers e a trilha de auditoria usam o projeto escolhido.
 func (m *RBACMiddleware) RequireProjectMember() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Get user context
-		userCtx, err := GetUserContext(c)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "forbidden", "message": "authentication required",
-			})
+		authCtx, ok := GetAuthContext(c)
+		if !ok {
+			abortForbidden(c, "authentication required")
 			return
 		}
 
-		// Extract project_id
-		projectIDStr := c.Param("project_id")
-		if projectIDStr == "" {
-			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": "bad_request", "message": "project_id required",
-			})
+		projectID := authCtx.ProjectID
+		if header := c.GetHeader(ProjectHeader); header != "" {
+			parsed, err := uuid.Parse(header)
+			if err != nil {
+				c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+					"error": "bad_request", "message": "invalid " + ProjectHeader + " header",
+				})
+				return
+			}
+			projectID = parsed
+		}
+		m.resolve(c, authCtx, projectID)
+	}
+}
+
+// RequireProjectMemberParam igual a RequireProjectMember, com o projeto vindo do parâmetro da rota
+// (/crm/projects/:id/...)
+func (m *RBACMiddleware) RequireProjectMemberParam(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		authCtx, ok := GetAuthContext(c)
+		if !ok {
+			abortForbidden(c, "authentication required")
 			return
 		}
 
-		projectID, err := uuid.Parse(projectIDStr)
+		projectID, err := uuid.Parse(c.Param(param))
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
-				"error": "bad_request", "message": "invalid project_id",
+				"error": "bad_request", "message": "invalid project id",
 			})
 			return
 		}
+		m.resolve(c, authCtx, projectID)
+	}
+}
 
-		// Check membership
-		member, err := m.repo.FindByProjectAndAgent(c.Request.Context(), projectID, userCtx.Subject)
-		if err != nil {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "forbidden", "message": "access denied",
-			})
+func (m *RBACMiddleware) resolve(c *gin.Context, authCtx *AuthContext, projectID uuid.UUID) {
+	if projectID == uuid.Nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "bad_request", "message": "project required (" + ProjectHeader + " header)",
+		})
+		return
+	}
+
+	access, err := m.Access(c.Request.Context(), authCtx, projectID, project_member.ProjectMemberRole(c.GetHeader(DevProjectRoleHeader)))
+	switch {
+	case errors.Is(err, ErrAPIKeyWrongProject):
+		abortForbidden(c, err.Error())
+		return
+	case errors.Is(err, ErrTwoFactorRequired):
+		abortTwoFactorRequired(c)
+		return
+	case errors.Is(err, projectapp.ErrNoProjectAccess):
+		abortForbidden(c, "access denied")
+		return
+	case err != nil:
+		m.logger.Error("Failed to resolve project access for RBAC", zap.String("project_id", projectID.String()), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "internal_error", "message": "failed to check project access",
+		})
+		return
+	}
+
+	authCtx.ProjectID = access.ProjectID
+	authCtx.TenantID = access.TenantID
+	c.Set(ProjectAccessKey, access)
+	c.Set("project_id", access.ProjectID)
+
+	if isMutation(c.Request.Method) {
+		if err := m.CheckStanding(c.Request.Context(), access.ProjectID); err != nil {
+			apierrors.RespondWithError(c, err)
+			c.Abort()
 			return
 		}
+	}
+	c.Next()
+}
 
-		c.Set("project_member", member)
-		c.Set("project_id", projectID)
-		c.Next()
+// CheckStanding bloqueia escritas no projeto cuja conta de cobrança está restrita (erro
+// PAYMENT_REQUIRED). Falha ao consultar a situação não bloqueia: só é logada.
+func (m *RBACMiddleware) CheckStanding(ctx context.Context, projectID uuid.UUID) error {
+	if m.standing == nil {
+		return nil
+	}
+	err := m.standing.CheckStanding(ctx, projectID)
+	switch {
+	case shared.IsPaymentRequiredError(err):
+		return err
+	case err != nil:
+		m.logger.Warn("Billing standing check failed, allowing request",
+			zap.String("project_id", projectID.String()),
+			zap.Error(err))
+	}
+	return nil
+}
+
+// Access resolve o acesso da credencial ao projeto. API keys valem só para o projeto da chave, com
+// as permissões da chave; o bypass de desenvolvimento usa devRole (default admin) sem consultar o
+// banco; usuários passam pelo resolver (dono, papel padrão ou custom) e, se o projeto exige 2FA,
+// a sessão precisa ter passado pelo segundo fator (ErrTwoFactorRequired).
+func (m *RBACMiddleware) Access(ctx context.Context, authCtx *AuthContext, projectID uuid.UUID, devRole project_member.ProjectMemberRole) (*ProjectAccess, error) {
+	if authCtx.IsAPIKey() {
+		if authCtx.ProjectID != projectID {
+			return nil, ErrAPIKeyWrongProject
+		}
+		return &ProjectAccess{ProjectID: projectID, TenantID: authCtx.TenantID, Permissions: authCtx.Permissions}, nil
+	}
+
+	if authCtx.DevBypass {
+		if devRole == "" {
+			devRole = project_member.RoleAdmin
+		}
+		permissions, err := m.resolver.RolePermissions(ctx, projectID, devRole)
+		if err != nil {
+			return nil, err
+		}
+		return &ProjectAccess{ProjectID: projectID, TenantID: authCtx.TenantID, Role: devRole, Permissions: permissions}, nil
+	}
+
+	access, err := m.resolver.Resolve(ctx, projectID, authCtx.UserID)
+	if err != nil {
+		return nil, err
+	}
+	// O AuthMiddleware só olha o projeto da sessão; aqui vale o projeto de fato acessado
+	if access.RequiresTwoFactor && !authCtx.TwoFactorVerified {
+		return nil, ErrTwoFactorRequired
+	}
+	return &ProjectAccess{
+		ProjectID:   access.ProjectID,
+		TenantID:    access.TenantID,
+		Role:        access.Role,
+		Owner:       access.Owner,
+		Permissions: access.Permissions,
+	}, nil
+}
+
+// Authorize reavalia o acesso e exige a permissão. Usado fora do ciclo de uma requisição HTTP
+// (ações do WebSocket), onde o papel pode ter mudado desde a conexão.
+func (m *RBACMiddleware) Authorize(ctx context.Context, authCtx *AuthContext, projectID uuid.UUID, devRole project_member.ProjectMemberRole, permission project_member.Permission) error {
+	access, err := m.Access(ctx, authCtx, projectID, devRole)
+	if err != nil {
+		return err
+	}
+	if !access.HasPermission(permission) {
+		return &PermissionError{Permission: permission}
 	}
+	return nil
 }
 
-// RequirePermission verifica permissão específica
+// PermissionError o acesso ao projeto existe, mas sem a permissão exigida
+type PermissionError struct {
+	Permission project_member.Permission
+}
+
+func (e *PermissionError) Error() string {
+	return "insufficient permissions: " + string(e.Permission)
+}
+
+// RequirePermission verifica permissão específica nas permissões efetivas (da API key ou do papel
+// no projeto). Deve vir depois de RequireProjectMember(Param).
 func (m *RBACMiddleware) RequirePermission(permission project_member.Permission) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		memberInterface, exists := c.Get("project_member")
-		if !exists {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "forbidden", "message": "project membership required",
-			})
+		access, ok := GetProjectAccess(c)
+		if !ok {
+			abortForbidden(c, "project membership required")
 			return
 		}
-
-		member := memberInterface.(*project_member.ProjectMember)
-		if !member.HasPermission(permission) {
-			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
-				"error": "forbidden", "message": "insufficient permissions",
-			})
+		if !access.HasPermission(permission) {
+			abortMissingPermission(c, permission)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// GetProjectAccess extrai o acesso ao projeto resolvido pelo RBACMiddleware
+func GetProjectAccess(c *gin.Context) (*ProjectAccess, bool) {
+	value, exists := c.Get(ProjectAccessKey)
+	if !exists {
+		return nil, false
+	}
+
+	access, ok := value.(*ProjectAccess)
+	return access, ok
+}
+
+func abortForbidden(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": "forbidden", "message": message,
+	})
+}
+
+func abortTwoFactorRequired(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error":   "two_factor_required",
+		"message": "This project requires two-factor authentication",
+	})
+}
+
+func abortMissingPermission(c *gin.Context, permission project_member.Permission) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error":               "forbidden",
+		"message":             "insufficient permissions",
+		"required_permission": permission,
+	})
+}
@@ -1,26 +1,50 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
 	"go.uber.org/zap"
 )
 
+// ProjectAuthorizerKey chave no gin.Context com o ProjectAuthorizer da conexão WebSocket
+const ProjectAuthorizerKey = "project_authorizer"
+
+// ProjectAuthorizer reavalia, a cada ação, se a credencial da conexão ainda tem a permissão no
+// projeto (mudanças de papel valem para conexões já abertas)
+type ProjectAuthorizer func(ctx context.Context, permission project_member.Permission) error
+
+// ProjectStandingKey chave no gin.Context com o ProjectStandingChecker da conexão WebSocket
+const ProjectStandingKey = "project_standing"
+
+// ProjectStandingChecker reavalia, antes de cada escrita pela conexão, se a conta de cobrança do
+// projeto aceita escritas (mesma regra das rotas HTTP: somente leitura ou suspensa responde
+// PAYMENT_REQUIRED)
+type ProjectStandingChecker func(ctx context.Context) error
+
 // WebSocketAuthMiddleware autentica conexões WebSocket
 // Suporta autenticação via:
 // 1. Authorization header (Bearer token)
 // 2. Query parameter ?token=<token> (para compatibilidade com clientes que não suportam headers em WebSocket)
+//
+// O projeto vem do header X-Project-ID, do query parameter ?project_id= ou da credencial, e as
+// permissões são avaliadas pelo mesmo RBACMiddleware das rotas HTTP.
 type WebSocketAuthMiddleware struct {
 	authMiddleware *AuthMiddleware
+	rbac           *RBACMiddleware
 	logger         *zap.Logger
 }
 
 // NewWebSocketAuthMiddleware cria novo middleware
-func NewWebSocketAuthMiddleware(authMiddleware *AuthMiddleware, logger *zap.Logger) *WebSocketAuthMiddleware {
+func NewWebSocketAuthMiddleware(authMiddleware *AuthMiddleware, rbac *RBACMiddleware, logger *zap.Logger) *WebSocketAuthMiddleware {
 	return &WebSocketAuthMiddleware{
 		authMiddleware: authMiddleware,
+		rbac:           rbac,
 		logger:         logger,
 	}
 }
@@ -28,17 +52,23 @@ func NewWebSocketAuthMiddleware(authMiddleware *AuthMiddleware, logger *zap.Logg
 // Authenticate middleware para WebSocket
 func (m *WebSocketAuthMiddleware) Authenticate() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		// Tentar autenticação via header primeiro
-		if authCtx := m.tryHeaderAuth(c); authCtx != nil {
-			c.Set("auth", authCtx)
-			c.Next()
-			return
+		// Tentar autenticação via header primeiro; query parameter é o fallback para WebSocket
+		authCtx := m.tryHeaderAuth(c)
+		if authCtx == nil {
+			authCtx = m.tryQueryAuth(c)
 		}
-
-		// Tentar autenticação via query parameter (fallback para WebSocket)
-		if authCtx := m.tryQueryAuth(c); authCtx != nil {
+		if authCtx != nil {
+			// Mesma política do AuthMiddleware: projeto que exige 2FA não abre stream sem ele
+			if authCtx.TwoFactorPending {
+				c.JSON(http.StatusForbidden, gin.H{
+					"error":   "two_factor_required",
+					"message": "This project requires two-factor authentication",
+				})
+				c.Abort()
+				return
+			}
 			c.Set("auth", authCtx)
-			c.Next()
+			m.authorizeProject(c, authCtx)
 			return
 		}
 
@@ -83,19 +113,90 @@ func (m *WebSocketAuthMiddleware) tryQueryAuth(c *gin.Context) *AuthContext {
 	return authCtx
 }
 
-// ValidateWebSocketSession valida se usuário tem acesso a uma sessão
-// SECURITY: CRITICAL - previne acesso não autorizado a sessões
-func ValidateWebSocketSession(c *gin.Context, sessionID uuid.UUID) (*AuthContext, error) {
-	authCtx, exists := GetAuthContext(c)
+// authorizeProject resolve o projeto da conexão, exige sessions.view para abrir o stream e deixa no
+// contexto o ProjectAuthorizer usado nas ações seguintes
+func (m *WebSocketAuthMiddleware) authorizeProject(c *gin.Context, authCtx *AuthContext) {
+	projectID := authCtx.ProjectID
+	raw := c.GetHeader(ProjectHeader)
+	if raw == "" {
+		raw = c.Query("project_id")
+	}
+	if raw != "" {
+		parsed, err := uuid.Parse(raw)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": "bad_request", "message": "invalid project id",
+			})
+			return
+		}
+		projectID = parsed
+	}
+	if projectID == uuid.Nil {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+			"error": "bad_request", "message": "project required (" + ProjectHeader + " header or project_id query param)",
+		})
+		return
+	}
+
+	devRole := project_member.ProjectMemberRole(c.GetHeader(DevProjectRoleHeader))
+	access, err := m.rbac.Access(c.Request.Context(), authCtx, projectID, devRole)
+	switch {
+	case errors.Is(err, ErrAPIKeyWrongProject):
+		abortForbidden(c, err.Error())
+		return
+	case errors.Is(err, ErrTwoFactorRequired):
+		abortTwoFactorRequired(c)
+		return
+	case errors.Is(err, projectapp.ErrNoProjectAccess):
+		abortForbidden(c, "access denied")
+		return
+	case err != nil:
+		m.logger.Error("Failed to resolve project access for WebSocket",
+			zap.String("project_id", projectID.String()), zap.Error(err))
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{
+			"error": "internal_error", "message": "failed to check project access",
+		})
+		return
+	}
+	if !access.HasPermission(project_member.PermissionViewSessions) {
+		abortMissingPermission(c, project_member.PermissionViewSessions)
+		return
+	}
+
+	authCtx.ProjectID = access.ProjectID
+	authCtx.TenantID = access.TenantID
+	c.Set(ProjectAccessKey, access)
+	c.Set("project_id", access.ProjectID)
+	c.Set(ProjectAuthorizerKey, ProjectAuthorizer(func(ctx context.Context, permission project_member.Permission) error {
+		return m.rbac.Authorize(ctx, authCtx, projectID, devRole, permission)
+	}))
+	c.Set(ProjectStandingKey, ProjectStandingChecker(func(ctx context.Context) error {
+		return m.rbac.CheckStanding(ctx, access.ProjectID)
+	}))
+	c.Next()
+}
+
+// GetProjectAuthorizer extrai o ProjectAuthorizer da conexão WebSocket
+// SECURITY: CRITICAL - previne ações em sessões/projetos sem permissão
+func GetProjectAuthorizer(c *gin.Context) (ProjectAuthorizer, bool) {
+	value, exists := c.Get(ProjectAuthorizerKey)
 	if !exists {
-		return nil, ErrUnauthorized
+		return nil, false
 	}
 
-	// TODO: Implementar validação de permissão via repository
-	// Verificar se usuário tem acesso a essa sessão/projeto
-	// Por ora, assume que auth middleware já validou tenant/project
+	authorize, ok := value.(ProjectAuthorizer)
+	return authorize, ok
+}
+
+// GetProjectStandingChecker extrai o ProjectStandingChecker da conexão WebSocket
+func GetProjectStandingChecker(c *gin.Context) (ProjectStandingChecker, bool) {
+	value, exists := c.Get(ProjectStandingKey)
+	if !exists {
+		return nil, false
+	}
 
-	return authCtx, nil
+	check, ok := value.(ProjectStandingChecker)
+	return check, ok
 }
 
 var (
@@ -0,0 +1,648 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/http/handlers"
+	"github.com/ventros/crm/infrastructure/http/middleware"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	authapp "github.com/ventros/crm/internal/application/auth"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// tokenVerifier aceita access tokens "test.<user_id>.sig", todos com o projeto da sessão e
+// autenticação recente (step-up satisfeito); "test.<user_id>.2fa" é uma sessão verificada com o
+// segundo fator
+type tokenVerifier struct {
+	projectID uuid.UUID
+}
+
+func (v tokenVerifier) VerifyAccessToken(ctx context.Context, token string) (*authapp.AccessPrincipal, error) {
+	parts := strings.Split(token, ".")
+	userID, err := uuid.Parse(parts[1])
+	if err != nil {
+		return nil, err
+	}
+	return &authapp.AccessPrincipal{
+		SessionID:         uuid.New(),
+		UserID:            userID,
+		Email:             userID.String() + "@example.com",
+		Role:              "user",
+		ProjectID:         v.projectID,
+		ReauthenticatedAt: time.Now(),
+		TwoFactorVerified: parts[2] == "2fa",
+	}, nil
+}
+
+type memberLookup map[string]*project_member.ProjectMember
+
+func (m memberLookup) FindByProjectAndAgent(ctx context.Context, projectID uuid.UUID, agentID string) (*project_member.ProjectMember, error) {
+	member, ok := m[agentID]
+	if !ok || member.ProjectID() != projectID {
+		return nil, project_member.ErrMemberNotFound
+	}
+	return member, nil
+}
+
+type projectLookup struct {
+	project *project.Project
+}
+
+func (l projectLookup) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	if id != l.project.ID() {
+		return nil, project.ErrProjectNotFound
+	}
+	return l.project, nil
+}
+
+// roleLookup papéis custom do projeto pela chave (mutável nos testes de invalidação)
+type roleLookup map[project_member.ProjectMemberRole]*project_member.CustomRole
+
+func (l roleLookup) FindByKey(ctx context.Context, projectID uuid.UUID, key project_member.ProjectMemberRole) (*project_member.CustomRole, error) {
+	role, ok := l[key]
+	if !ok || role.ProjectID() != projectID {
+		return nil, project_member.ErrCustomRoleNotFound
+	}
+	return role, nil
+}
+
+// Repositórios do fixture para os casos de uso reais de papéis, membros e convites: leem e gravam
+// nos mesmos mapas que o AccessResolver usa
+type projectRepository struct {
+	project.Repository
+	lookup projectLookup
+}
+
+func (r projectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	return r.lookup.FindByID(ctx, id)
+}
+
+type memberRepository struct {
+	project_member.Repository
+	members memberLookup
+}
+
+func (r memberRepository) FindByProjectAndAgent(ctx context.Context, projectID uuid.UUID, agentID string) (*project_member.ProjectMember, error) {
+	return r.members.FindByProjectAndAgent(ctx, projectID, agentID)
+}
+
+func (r memberRepository) Save(ctx context.Context, member *project_member.ProjectMember) error {
+	r.members[member.AgentID()] = member
+	return nil
+}
+
+type roleRepository struct {
+	project_member.CustomRoleRepository
+	roles roleLookup
+}
+
+func (r roleRepository) FindByKey(ctx context.Context, projectID uuid.UUID, key project_member.ProjectMemberRole) (*project_member.CustomRole, error) {
+	return r.roles.FindByKey(ctx, projectID, key)
+}
+
+func (r roleRepository) FindByID(ctx context.Context, projectID, id uuid.UUID) (*project_member.CustomRole, error) {
+	for _, role := range r.roles {
+		if role.ID() == id && role.ProjectID() == projectID {
+			return role, nil
+		}
+	}
+	return nil, project_member.ErrCustomRoleNotFound
+}
+
+func (r roleRepository) Save(ctx context.Context, role *project_member.CustomRole) error {
+	r.roles[role.Key()] = role
+	return nil
+}
+
+type invitationRepository struct {
+	project_member.InvitationRepository
+}
+
+func (invitationRepository) FindPendingByEmail(ctx context.Context, projectID uuid.UUID, email string) (*project_member.Invitation, error) {
+	return nil, project_member.ErrInvitationNotFound
+}
+
+func (invitationRepository) Save(ctx context.Context, invitation *project_member.Invitation) error {
+	return nil
+}
+
+type discardEventBus struct{}
+
+func (discardEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	return nil
+}
+
+type inlineTransactions struct{}
+
+func (inlineTransactions) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type discardAuditRepository struct{}
+
+func (discardAuditRepository) Append(ctx context.Context, entry *audit.Entry) error {
+	return entry.Link(nil)
+}
+
+func (discardAuditRepository) List(ctx context.Context, filter audit.Filter) ([]*audit.Entry, int64, error) {
+	return nil, 0, nil
+}
+
+func (discardAuditRepository) Chain(ctx context.Context, projectID uuid.UUID, afterSequence int64, limit int) ([]*audit.Entry, error) {
+	return nil, errors.New("not implemented")
+}
+
+// quotaChecker limites de teste: métricas esgotadas (402) e métricas com erro na consulta
+type quotaChecker struct {
+	exceeded map[billing.UsageMetric]bool
+	failing  map[billing.UsageMetric]bool
+}
+
+func (q *quotaChecker) Check(ctx context.Context, projectID uuid.UUID, metric billing.UsageMetric, quantity int64) error {
+	if q.failing[metric] {
+		return errors.New("billing database unavailable")
+	}
+	if q.exceeded[metric] {
+		return shared.NewQuotaExceededError("Plan limit reached").WithDetail("metric", string(metric))
+	}
+	return nil
+}
+
+// standingChecker situação de cobrança de teste: conta restrita (402) ou erro na consulta
+type standingChecker struct {
+	standing billing.AccountStanding
+	failing  bool
+}
+
+func (s *standingChecker) CheckStanding(ctx context.Context, projectID uuid.UUID) error {
+	if s.failing {
+		return errors.New("billing database unavailable")
+	}
+	if s.standing == billing.StandingReadOnly || s.standing == billing.StandingSuspended {
+		return shared.NewAccountRestrictedError("Account restricted").WithDetail("standing", string(s.standing))
+	}
+	return nil
+}
+
+type rbacFixture struct {
+	router      *gin.Engine
+	quota       *middleware.QuotaMiddleware
+	limits      *quotaChecker
+	standing    *standingChecker
+	project     *project.Project
+	ownerID     uuid.UUID
+	roles       map[project_member.ProjectMemberRole]uuid.UUID
+	custom      *project_member.CustomRole
+	manager     *project_member.CustomRole
+	outsider    uuid.UUID
+	resolver    *projectapp.AccessResolver
+	members     memberLookup
+	customRoles roleLookup
+}
+
+// allowed permissão do papel: padrão pela tabela do domínio, custom pelo papel do projeto
+func (f *rbacFixture) allowed(role project_member.ProjectMemberRole, permission project_member.Permission) bool {
+	if custom, ok := f.customRoles[role]; ok {
+		return custom.HasPermission(permission)
+	}
+	return project_member.RoleHasPermission(role, permission)
+}
+
+// newRBACFixture monta o router real com handlers vazios: a requisição que passa pelo RBAC chega
+// ao handler (que pode falhar à vontade), a que não passa para no 403 do middleware
+func newRBACFixture(t *testing.T) *rbacFixture {
+	t.Helper()
+	return newRBACFixtureWith(t, func(f *rbacFixture) (*handlers.ProjectMemberHandler, *handlers.ProjectRoleHandler) {
+		return &handlers.ProjectMemberHandler{}, &handlers.ProjectRoleHandler{}
+	})
+}
+
+// newGrantFixture como o newRBACFixture, mas com os casos de uso reais de papéis, membros e
+// convites sobre os membros e papéis do fixture
+func newGrantFixture(t *testing.T) *rbacFixture {
+	t.Helper()
+	return newRBACFixtureWith(t, func(f *rbacFixture) (*handlers.ProjectMemberHandler, *handlers.ProjectRoleHandler) {
+		logger := zap.NewNop()
+		projects := projectRepository{lookup: projectLookup{project: f.project}}
+		members := memberRepository{members: f.members}
+		roles := roleRepository{roles: f.customRoles}
+		invitations := invitationRepository{}
+
+		manageMembers := projectapp.NewManageMembersUseCase(projects, members, roles, f.resolver, nil,
+			discardEventBus{}, inlineTransactions{}, logger)
+		manageInvitations := projectapp.NewManageInvitationsUseCase(invitations, members, roles, f.resolver, projects, nil, nil,
+			projectapp.InvitationConfig{}, discardEventBus{}, inlineTransactions{}, logger)
+		manageRoles := projectapp.NewManageRolesUseCase(roles, members, invitations, projects, f.resolver,
+			discardEventBus{}, inlineTransactions{}, logger)
+		return handlers.NewProjectMemberHandler(logger, manageMembers, manageInvitations), handlers.NewProjectRoleHandler(logger, manageRoles)
+	})
+}
+
+func newRBACFixtureWith(t *testing.T, projectHandlers func(f *rbacFixture) (*handlers.ProjectMemberHandler, *handlers.ProjectRoleHandler)) *rbacFixture {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	gin.DefaultErrorWriter = io.Discard
+
+	ownerID := uuid.New()
+	p, err := project.NewProject(ownerID, uuid.New(), "tenant-rbac", "RBAC")
+	require.NoError(t, err)
+
+	f := &rbacFixture{project: p, ownerID: ownerID, roles: map[project_member.ProjectMemberRole]uuid.UUID{}, outsider: uuid.New()}
+	members := memberLookup{}
+	for _, role := range project_member.ValidRoles() {
+		userID := uuid.New()
+		member, err := project_member.NewProjectMember(p.ID(), userID.String(), role, ownerID.String())
+		require.NoError(t, err)
+		members[userID.String()] = member
+		f.roles[role] = userID
+	}
+
+	// Papel custom: leitura de contatos e sessões + exportação, sem envio de mensagens
+	f.custom, err = project_member.NewCustomRole(p.ID(), "Contacts Exporter", "",
+		[]project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionExportContacts, project_member.PermissionViewSessions},
+		project_member.RoleViewer, ownerID.String())
+	require.NoError(t, err)
+	customUserID := uuid.New()
+	customMember, err := project_member.NewProjectMember(p.ID(), customUserID.String(), f.custom.Key(), ownerID.String())
+	require.NoError(t, err)
+	members[customUserID.String()] = customMember
+	f.roles[f.custom.Key()] = customUserID
+
+	// Papel custom que gerencia membros sem ser admin: só concede o que tem
+	f.manager, err = project_member.NewCustomRole(p.ID(), "Team Lead", "",
+		[]project_member.Permission{project_member.PermissionManageMembers, project_member.PermissionViewMembers, project_member.PermissionViewContacts},
+		"", ownerID.String())
+	require.NoError(t, err)
+	managerUserID := uuid.New()
+	managerMember, err := project_member.NewProjectMember(p.ID(), managerUserID.String(), f.manager.Key(), ownerID.String())
+	require.NoError(t, err)
+	members[managerUserID.String()] = managerMember
+	f.roles[f.manager.Key()] = managerUserID
+	f.members = members
+	f.customRoles = roleLookup{f.custom.Key(): f.custom, f.manager.Key(): f.manager}
+
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+
+	logger := zap.NewNop()
+	authMiddleware := middleware.NewAuthMiddleware(logger, false, nil, nil, tokenVerifier{projectID: p.ID()})
+	f.resolver = projectapp.NewAccessResolver(projectLookup{project: p}, members, f.customRoles, time.Hour)
+	rbac := middleware.NewRBACMiddleware(f.resolver, logger)
+	f.standing = &standingChecker{}
+	rbac.SetStandingChecker(f.standing)
+
+	f.limits = &quotaChecker{exceeded: map[billing.UsageMetric]bool{}, failing: map[billing.UsageMetric]bool{}}
+	f.quota = middleware.NewQuotaMiddleware(f.limits, logger)
+
+	memberHandler, roleHandler := projectHandlers(f)
+	f.router = gin.New()
+	SetupRoutesBasicWithTest(f.router, logger, nil,
+		&handlers.AuthHandler{}, &handlers.APIKeyHandler{}, &handlers.AuthSessionHandler{}, &handlers.TwoFactorHandler{},
+		&handlers.AuditLogHandler{}, auditapp.NewRecorder(discardAuditRepository{}, logger),
+		&handlers.AutomationHandler{}, &handlers.BroadcastHandler{}, &handlers.SequenceHandler{}, &handlers.CampaignHandler{},
+		&handlers.ChannelHandler{}, &handlers.ProjectHandler{}, memberHandler, roleHandler, &handlers.PipelineHandler{},
+		&handlers.WAHAWebhookHandler{}, &handlers.WebhookSubscriptionHandler{}, &handlers.QueueHandler{},
+		&handlers.SessionHandler{}, &handlers.ContactHandler{}, &handlers.TrackingHandler{}, &handlers.MessageHandler{},
+		&handlers.ChatHandler{}, &handlers.AgentHandler{}, &handlers.SLAHandler{}, &handlers.BusinessHoursHandler{},
+		&handlers.TeamHandler{}, &handlers.SearchHandler{}, &handlers.NoteHandler{}, &handlers.TaskHandler{},
+		&handlers.CannedResponseHandler{}, &handlers.ContactListHandler{}, &handlers.AutomationDiscoveryHandler{},
+		&handlers.BillingHandler{}, &handlers.StripeWebhookHandler{},
+		nil, nil, db, authMiddleware, nil, middleware.NewRLSMiddleware(logger), rbac, f.quota)
+	return f
+}
+
+func (f *rbacFixture) do(method, path string, userID uuid.UUID) *httptest.ResponseRecorder {
+	return f.doWithToken(method, path, "test."+userID.String()+".sig")
+}
+
+func (f *rbacFixture) doWithToken(method, path, token string) *httptest.ResponseRecorder {
+	return f.request(method, path, token, "{}")
+}
+
+func (f *rbacFixture) doJSON(method, path string, userID uuid.UUID, body string) *httptest.ResponseRecorder {
+	return f.request(method, path, "test."+userID.String()+".sig", body)
+}
+
+func (f *rbacFixture) request(method, path, token, body string) *httptest.ResponseRecorder {
+	path = strings.ReplaceAll(path, "{project}", f.project.ID().String())
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set(middleware.ProjectHeader, f.project.ID().String())
+	w := httptest.NewRecorder()
+	f.router.ServeHTTP(w, req)
+	return w
+}
+
+func requiredPermission(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	var body map[string]interface{}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body), w.Body.String())
+	permission, _ := body["required_permission"].(string)
+	return permission
+}
+
+// TestRBAC_RoleEndpointMatrix cada papel × endpoint representativo: quem não tem a permissão da rota
+// recebe 403 com required_permission, quem tem chega ao handler
+func TestRBAC_RoleEndpointMatrix(t *testing.T) {
+	f := newRBACFixture(t)
+	id := uuid.NewString()
+
+	endpoints := []struct {
+		method     string
+		path       string
+		permission project_member.Permission
+	}{
+		{http.MethodGet, "/api/v1/contacts", project_member.PermissionViewContacts},
+		{http.MethodPost, "/api/v1/contacts", project_member.PermissionManageContacts},
+		{http.MethodDelete, "/api/v1/contacts/" + id, project_member.PermissionManageContacts},
+		{http.MethodGet, "/api/v1/contacts/" + id + "/sessions", project_member.PermissionViewSessions},
+		{http.MethodGet, "/api/v1/sessions/" + id, project_member.PermissionViewSessions},
+		{http.MethodPost, "/api/v1/sessions/" + id + "/close", project_member.PermissionManageSessions},
+		{http.MethodGet, "/api/v1/crm/messages/" + id, project_member.PermissionViewMessages},
+		{http.MethodPost, "/api/v1/crm/messages/send", project_member.PermissionSendMessages},
+		{http.MethodGet, "/api/v1/crm/channels", project_member.PermissionViewChannels},
+		{http.MethodPost, "/api/v1/crm/channels", project_member.PermissionManageChannels},
+		{http.MethodGet, "/api/v1/crm/pipelines", project_member.PermissionViewPipelines},
+		{http.MethodPost, "/api/v1/crm/pipelines", project_member.PermissionManagePipelines},
+		{http.MethodGet, "/api/v1/automation", project_member.PermissionViewCampaigns},
+		{http.MethodPost, "/api/v1/automation", project_member.PermissionManageCampaigns},
+		{http.MethodGet, "/api/v1/crm/agents/leaderboard", project_member.PermissionViewAnalytics},
+		{http.MethodPost, "/api/v1/crm/teams", project_member.PermissionManageMembers},
+		{http.MethodPost, "/api/v1/crm/queues/pick-next", project_member.PermissionManageSessions},
+		{http.MethodGet, "/api/v1/crm/sla/policies", project_member.PermissionViewSettings},
+		{http.MethodPut, "/api/v1/crm/business-hours", project_member.PermissionManageSettings},
+		{http.MethodPost, "/api/v1/webhook-subscriptions", project_member.PermissionManageSettings},
+		{http.MethodGet, "/api/v1/api-keys", project_member.PermissionViewSettings},
+		{http.MethodPost, "/api/v1/api-keys", project_member.PermissionManageSettings},
+		{http.MethodGet, "/api/v1/audit-logs", project_member.PermissionViewAuditLog},
+		{http.MethodGet, "/api/v1/billing/status", project_member.PermissionViewSettings},
+		{http.MethodGet, "/api/v1/billing/events", project_member.PermissionViewSettings},
+		{http.MethodGet, "/api/v1/crm/projects/{project}", project_member.PermissionViewMembers},
+		{http.MethodPut, "/api/v1/crm/projects/{project}", project_member.PermissionManageSettings},
+		{http.MethodGet, "/api/v1/crm/projects/{project}/members", project_member.PermissionViewMembers},
+		{http.MethodPut, "/api/v1/crm/projects/{project}/members/" + id + "/role", project_member.PermissionManageMembers},
+		{http.MethodDelete, "/api/v1/crm/projects/{project}/members/" + id, project_member.PermissionManageMembers},
+		{http.MethodPost, "/api/v1/crm/projects/{project}/invitations", project_member.PermissionManageMembers},
+		{http.MethodPost, "/api/v1/crm/projects/{project}/transfer-ownership", project_member.PermissionManageMembers},
+		{http.MethodGet, "/api/v1/crm/projects/{project}/roles", project_member.PermissionViewMembers},
+		{http.MethodPost, "/api/v1/crm/projects/{project}/roles", project_member.PermissionManageMembers},
+		{http.MethodPut, "/api/v1/crm/projects/{project}/roles/" + id, project_member.PermissionManageMembers},
+		{http.MethodDelete, "/api/v1/crm/projects/{project}/roles/" + id, project_member.PermissionManageMembers},
+	}
+
+	for role, userID := range f.roles {
+		for _, e := range endpoints {
+			w := f.do(e.method, e.path, userID)
+			name := string(role) + " " + e.method + " " + e.path
+			if f.allowed(role, e.permission) {
+				assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}, w.Code, name)
+				continue
+			}
+			require.Equal(t, http.StatusForbidden, w.Code, name)
+			assert.Equal(t, string(e.permission), requiredPermission(t, w), name)
+		}
+	}
+
+	// O dono é admin implícito, sem linha em project_members
+	for _, e := range endpoints {
+		w := f.do(e.method, e.path, f.ownerID)
+		assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}, w.Code, "owner "+e.method+" "+e.path)
+	}
+}
+
+// TestRBAC_EveryProtectedRouteRequiresMembership toda rota de projeto recusa quem não é membro;
+// as exceções são as rotas públicas e as da conta do usuário
+func TestRBAC_EveryProtectedRouteRequiresMembership(t *testing.T) {
+	f := newRBACFixture(t)
+
+	public := []string{
+		"/health", "/ready", "/live", "/swagger/",
+		"/api/v1/webhooks/", "/api/v1/auth/", "/api/v1/invitations/", "/api/v1/crm/test/",
+	}
+	accountRoutes := map[string]bool{
+		"GET /api/v1/queues":                true,
+		"GET /api/v1/crm/projects":          true,
+		"POST /api/v1/crm/projects":         true,
+		"GET /api/v1/crm/projects/search":   true,
+		"GET /api/v1/crm/projects/advanced": true,
+	}
+
+	checked := 0
+	for _, route := range f.router.Routes() {
+		if accountRoutes[route.Method+" "+route.Path] || hasAnyPrefix(route.Path, public) {
+			continue
+		}
+
+		path := route.Path
+		for _, segment := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+				path = strings.Replace(path, segment, f.project.ID().String(), 1)
+			}
+		}
+
+		w := f.do(route.Method, path, f.outsider)
+		assert.Equal(t, http.StatusForbidden, w.Code, route.Method+" "+route.Path)
+		checked++
+	}
+	assert.Greater(t, checked, 100)
+}
+
+// TestRBAC_CustomRoleChangeTakesEffectOnInvalidation permissões novas do papel custom valem na
+// próxima requisição depois da invalidação do projeto (sem esperar o TTL do cache)
+func TestRBAC_CustomRoleChangeTakesEffectOnInvalidation(t *testing.T) {
+	f := newRBACFixture(t)
+	userID := f.roles[f.custom.Key()]
+	path := "/api/v1/crm/channels"
+
+	w := f.do(http.MethodGet, path, userID)
+	require.Equal(t, http.StatusForbidden, w.Code)
+	assert.Equal(t, string(project_member.PermissionViewChannels), requiredPermission(t, w))
+
+	require.NoError(t, f.custom.SetPermissions(append(f.custom.Permissions(), project_member.PermissionViewChannels), f.ownerID.String()))
+
+	// Ainda em cache: a mudança só vale depois da invalidação
+	assert.Equal(t, http.StatusForbidden, f.do(http.MethodGet, path, userID).Code)
+
+	f.resolver.InvalidateProject(context.Background(), f.project.ID())
+	w = f.do(http.MethodGet, path, userID)
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound}, w.Code)
+}
+
+// TestRBAC_GrantsLimitedToCallerPermissions quem gerencia membros por um papel custom só cria e
+// altera papéis, troca papéis e convida dentro das próprias permissões; dono e admin concedem tudo
+func TestRBAC_GrantsLimitedToCallerPermissions(t *testing.T) {
+	type grant struct {
+		method, path, body string
+		// exceeds concede permissão que o papel custom que gerencia membros não tem
+		exceeds bool
+	}
+	grants := func(f *rbacFixture, readers *project_member.CustomRole) []grant {
+		viewer := f.roles[project_member.RoleViewer].String()
+		return []grant{
+			{http.MethodPost, "/api/v1/crm/projects/{project}/roles", `{"name":"Settings","permissions":["settings.manage"]}`, true},
+			{http.MethodPost, "/api/v1/crm/projects/{project}/roles", `{"name":"Supervisor Copy","clone_from":"supervisor"}`, true},
+			{http.MethodPost, "/api/v1/crm/projects/{project}/roles", `{"name":"Readers Copy","permissions":["contacts.view"]}`, false},
+			{http.MethodPut, "/api/v1/crm/projects/{project}/roles/" + readers.ID().String(), `{"permissions":["contacts.view","contacts.export"]}`, true},
+			{http.MethodPut, "/api/v1/crm/projects/{project}/roles/" + readers.ID().String(), `{"name":"Contact Readers"}`, false},
+			{http.MethodPut, "/api/v1/crm/projects/{project}/members/" + viewer + "/role", `{"role":"` + string(f.custom.Key()) + `"}`, true},
+			{http.MethodPut, "/api/v1/crm/projects/{project}/members/" + viewer + "/role", `{"role":"admin"}`, true},
+			{http.MethodPut, "/api/v1/crm/projects/{project}/members/" + viewer + "/role", `{"role":"` + string(readers.Key()) + `"}`, false},
+			{http.MethodPost, "/api/v1/crm/projects/{project}/invitations", `{"email":"lead@example.com","role":"supervisor"}`, true},
+			{http.MethodPost, "/api/v1/crm/projects/{project}/invitations", `{"email":"reader@example.com","role":"` + string(readers.Key()) + `"}`, false},
+		}
+	}
+
+	callers := map[string]func(f *rbacFixture) uuid.UUID{
+		"owner":   func(f *rbacFixture) uuid.UUID { return f.ownerID },
+		"admin":   func(f *rbacFixture) uuid.UUID { return f.roles[project_member.RoleAdmin] },
+		"manager": func(f *rbacFixture) uuid.UUID { return f.roles[f.manager.Key()] },
+	}
+	for caller, userID := range callers {
+		f := newGrantFixture(t)
+		readers, err := project_member.NewCustomRole(f.project.ID(), "Readers", "",
+			[]project_member.Permission{project_member.PermissionViewContacts}, "", f.ownerID.String())
+		require.NoError(t, err)
+		f.customRoles[readers.Key()] = readers
+
+		for _, g := range grants(f, readers) {
+			w := f.doJSON(g.method, g.path, userID(f), g.body)
+			name := caller + " " + g.method + " " + g.path + " " + g.body
+			if caller == "manager" && g.exceeds {
+				require.Equal(t, http.StatusForbidden, w.Code, name)
+				assert.Empty(t, requiredPermission(t, w), name+": recusado pelo caso de uso, não pelo middleware")
+				continue
+			}
+			assert.Contains(t, []int{http.StatusOK, http.StatusCreated}, w.Code, name+": "+w.Body.String())
+		}
+	}
+}
+
+// TestRBAC_ProjectTwoFactorPolicyAppliesToSelectedProject o projeto escolhido por header ou pela
+// rota exige 2FA: sessão sem o segundo fator recebe 403 two_factor_required, mesmo que o projeto
+// da sessão não exija
+func TestRBAC_ProjectTwoFactorPolicyAppliesToSelectedProject(t *testing.T) {
+	f := newRBACFixture(t)
+	userID := f.roles[project_member.RoleAdmin]
+	paths := []string{"/api/v1/contacts", "/api/v1/crm/projects/{project}/members"}
+
+	for _, path := range paths {
+		assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, f.do(http.MethodGet, path, userID).Code, path)
+	}
+
+	f.project.SetRequireTwoFactor(true)
+	f.resolver.InvalidateProject(context.Background(), f.project.ID())
+
+	for _, path := range paths {
+		w := f.do(http.MethodGet, path, userID)
+		require.Equal(t, http.StatusForbidden, w.Code, path)
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "two_factor_required", body["error"], path)
+
+		w = f.doWithToken(http.MethodGet, path, "test."+userID.String()+".2fa")
+		assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, w.Code, path)
+	}
+}
+
+// TestQuota_HardLimitReturnsPaymentRequired a ação que passaria do limite rígido do plano para no
+// 402 com QUOTA_EXCEEDED; o RBAC vem antes e uma falha ao consultar o plano não bloqueia
+func TestQuota_HardLimitReturnsPaymentRequired(t *testing.T) {
+	f := newRBACFixture(t)
+	f.limits.exceeded[billing.MetricContacts] = true
+	f.limits.exceeded[billing.MetricMessages] = true
+	f.limits.failing[billing.MetricChannels] = true
+
+	for _, path := range []string{"/api/v1/contacts", "/api/v1/crm/messages/send"} {
+		w := f.do(http.MethodPost, path, f.ownerID)
+		require.Equal(t, http.StatusPaymentRequired, w.Code, path)
+
+		var body struct {
+			Error struct {
+				Code    string                 `json:"code"`
+				Details map[string]interface{} `json:"details"`
+			} `json:"error"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "QUOTA_EXCEEDED", body.Error.Code)
+		assert.NotEmpty(t, body.Error.Details["metric"])
+	}
+
+	// Sem permissão continua 403, mesmo com o limite esgotado
+	assert.Equal(t, http.StatusForbidden, f.do(http.MethodPost, "/api/v1/contacts", f.roles[project_member.RoleViewer]).Code)
+
+	// Leituras não consomem o plano
+	assert.NotEqual(t, http.StatusPaymentRequired, f.do(http.MethodGet, "/api/v1/contacts", f.ownerID).Code)
+
+	// Falha ao consultar o plano: a requisição segue
+	assert.NotContains(t, []int{http.StatusPaymentRequired, http.StatusForbidden}, f.do(http.MethodPost, "/api/v1/crm/channels", f.ownerID).Code)
+}
+
+// TestStanding_RestrictedAccountBlocksWrites conta em modo somente leitura ou suspensa por fatura
+// em atraso: escritas param no 402 com ACCOUNT_RESTRICTED, leituras e a situação de cobrança seguem
+func TestStanding_RestrictedAccountBlocksWrites(t *testing.T) {
+	f := newRBACFixture(t)
+
+	for _, standing := range []billing.AccountStanding{billing.StandingReadOnly, billing.StandingSuspended} {
+		f.standing.standing = standing
+		for _, e := range []struct{ method, path string }{
+			{http.MethodPost, "/api/v1/contacts"},
+			{http.MethodPost, "/api/v1/crm/messages/send"},
+			{http.MethodPut, "/api/v1/crm/business-hours"},
+			{http.MethodDelete, "/api/v1/contacts/" + uuid.NewString()},
+		} {
+			w := f.do(e.method, e.path, f.ownerID)
+			require.Equal(t, http.StatusPaymentRequired, w.Code, string(standing)+" "+e.method+" "+e.path)
+
+			var body struct {
+				Error struct {
+					Code    string                 `json:"code"`
+					Details map[string]interface{} `json:"details"`
+				} `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, "ACCOUNT_RESTRICTED", body.Error.Code)
+			assert.Equal(t, string(standing), body.Error.Details["standing"])
+		}
+
+		for _, path := range []string{"/api/v1/contacts", "/api/v1/billing/status", "/api/v1/billing/events"} {
+			assert.NotContains(t, []int{http.StatusPaymentRequired, http.StatusForbidden}, f.do(http.MethodGet, path, f.ownerID).Code, path)
+		}
+	}
+
+	// Quem não é membro continua no 403
+	assert.Equal(t, http.StatusForbidden, f.do(http.MethodPost, "/api/v1/contacts", f.outsider).Code)
+
+	// Falha ao consultar a situação: a requisição segue
+	f.standing.failing = true
+	assert.NotContains(t, []int{http.StatusPaymentRequired, http.StatusForbidden}, f.do(http.MethodPost, "/api/v1/crm/channels", f.ownerID).Code)
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) || path == strings.TrimSuffix(prefix, "/") {
+			return true
+		}
+	}
+	return false
+}
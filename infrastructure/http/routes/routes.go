@@ -9,6 +9,9 @@ import (
 	"github.com/ventros/crm/infrastructure/health"
 	"github.com/ventros/crm/infrastructure/http/handlers"
 	"github.com/ventros/crm/infrastructure/http/middleware"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
 	"go.uber.org/zap"
 	"gorm.io/gorm"
 )
@@ -201,7 +204,7 @@ func SetupRoutes(router *gin.Engine, logger *zap.Logger, healthChecker *health.H
 }
 
 // SetupRoutesBasic configura as rotas básicas sem pipeline handler (temporário)
-func SetupRoutesBasic(router *gin.Engine, logger *zap.Logger, healthChecker *health.HealthChecker, wahaHandler *handlers.WAHAWebhookHandler, webhookHandler *handlers.WebhookSubscriptionHandler, queueHandler *handlers.QueueHandler, sessionHandler *handlers.SessionHandler, contactHandler *handlers.ContactHandler, authMiddleware *middleware.AuthMiddleware, rlsMiddleware *middleware.RLSMiddleware) {
+func SetupRoutesBasic(router *gin.Engine, logger *zap.Logger, healthChecker *health.HealthChecker, wahaHandler *handlers.WAHAWebhookHandler, webhookHandler *handlers.WebhookSubscriptionHandler, queueHandler *handlers.QueueHandler, sessionHandler *handlers.SessionHandler, contactHandler *handlers.ContactHandler, authMiddleware *middleware.AuthMiddleware, rlsMiddleware *middleware.RLSMiddleware, rbac *middleware.RBACMiddleware, quota *middleware.QuotaMiddleware) {
 	// Middlewares
 	router.Use(gin.Recovery())
 	router.Use(LoggerMiddleware(logger))
@@ -246,46 +249,58 @@ func SetupRoutesBasic(router *gin.Engine, logger *zap.Logger, healthChecker *hea
 		webhookSubs := v1.Group("/webhook-subscriptions")
 		webhookSubs.Use(authMiddleware.Authenticate())
 		webhookSubs.Use(rlsMiddleware.SetUserContext())
+		webhookSubs.Use(rbac.RequireProjectMember())
 		{
-			webhookSubs.GET("/available-events", webhookHandler.GetAvailableEvents)
-			webhookSubs.POST("", webhookHandler.CreateWebhook)
-			webhookSubs.GET("", webhookHandler.ListWebhooks)
-			webhookSubs.GET("/:id", webhookHandler.GetWebhook)
-			webhookSubs.PUT("/:id", webhookHandler.UpdateWebhook)
-			webhookSubs.DELETE("/:id", webhookHandler.DeleteWebhook)
+			webhookSubs.GET("/available-events", rbac.RequirePermission(project_member.PermissionViewSettings), webhookHandler.GetAvailableEvents)
+			webhookSubs.POST("", rbac.RequirePermission(project_member.PermissionManageSettings), webhookHandler.CreateWebhook)
+			webhookSubs.GET("", rbac.RequirePermission(project_member.PermissionViewSettings), webhookHandler.ListWebhooks)
+			webhookSubs.GET("/:id", rbac.RequirePermission(project_member.PermissionViewSettings), webhookHandler.GetWebhook)
+			webhookSubs.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageSettings), webhookHandler.UpdateWebhook)
+			webhookSubs.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageSettings), webhookHandler.DeleteWebhook)
+			webhookSubs.POST("/:id/rotate-secret", rbac.RequirePermission(project_member.PermissionManageSettings), middleware.RequireStepUp(), webhookHandler.RotateSecret)
+
+			// Log de entregas, reenvio manual e reenvio em lote das falhas
+			webhookSubs.GET("/:id/deliveries", rbac.RequirePermission(project_member.PermissionViewSettings), webhookHandler.ListDeliveries)
+			webhookSubs.GET("/:id/deliveries/:delivery_id", rbac.RequirePermission(project_member.PermissionViewSettings), middleware.AuditAccess("webhook-subscriptions.deliveries.view"), webhookHandler.GetDelivery)
+			webhookSubs.POST("/:id/deliveries/:delivery_id/replay", rbac.RequirePermission(project_member.PermissionManageSettings), webhookHandler.ReplayDelivery)
+			webhookSubs.POST("/:id/redeliver-failed", rbac.RequirePermission(project_member.PermissionManageSettings), webhookHandler.RedeliverFailed)
 		}
 
 		// Contact routes
 		contacts := v1.Group("/contacts")
 		contacts.Use(authMiddleware.Authenticate())
 		contacts.Use(rlsMiddleware.SetUserContext())
+		contacts.Use(rbac.RequireProjectMember())
 		{
-			contacts.GET("/search", contactHandler.SearchContacts)         // Must be before /:id
-			contacts.GET("/advanced", contactHandler.ListContactsAdvanced) // Must be before /:id
-			contacts.GET("", contactHandler.ListContacts)
-			contacts.POST("", contactHandler.CreateContact)
-			contacts.GET("/:id", contactHandler.GetContact)
-			contacts.PUT("/:id", contactHandler.UpdateContact)
-			contacts.DELETE("/:id", contactHandler.DeleteContact)
+			contacts.GET("/search", rbac.RequirePermission(project_member.PermissionViewContacts), contactHandler.SearchContacts)         // Must be before /:id
+			contacts.GET("/advanced", rbac.RequirePermission(project_member.PermissionViewContacts), contactHandler.ListContactsAdvanced) // Must be before /:id
+			contacts.GET("", rbac.RequirePermission(project_member.PermissionViewContacts), contactHandler.ListContacts)
+			contacts.POST("", rbac.RequirePermission(project_member.PermissionManageContacts), quota.RequireQuota(billing.MetricContacts), contactHandler.CreateContact)
+			contacts.GET("/:id", rbac.RequirePermission(project_member.PermissionViewContacts), contactHandler.GetContact)
+			contacts.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), contactHandler.UpdateContact)
+			contacts.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), contactHandler.DeleteContact)
+			contacts.GET("/:id/stats", rbac.RequirePermission(project_member.PermissionViewContacts), contactHandler.GetContactStats)
+			contacts.GET("/:id/history", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.GetContactSessionHistory)
 
 			// Nested session routes under contact (using :id for contact)
-			contacts.GET("/:id/sessions", sessionHandler.ListSessions)
-			contacts.GET("/:id/sessions/:session_id", sessionHandler.GetSession)
+			contacts.GET("/:id/sessions", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.ListSessions)
+			contacts.GET("/:id/sessions/:session_id", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.GetSession)
 
 			// Pipeline status routes under contact
 			// PUT /api/v1/contacts/:id/pipelines/:pipeline_id/status
-			contacts.PUT("/:id/pipelines/:pipeline_id/status", contactHandler.ChangePipelineStatus)
+			contacts.PUT("/:id/pipelines/:pipeline_id/status", rbac.RequirePermission(project_member.PermissionManageContacts), contactHandler.ChangePipelineStatus)
 		}
 
 		// Session routes (protected) - global with required filters
 		sessions := v1.Group("/sessions")
 		sessions.Use(authMiddleware.Authenticate())
 		sessions.Use(rlsMiddleware.SetUserContext())
+		sessions.Use(rbac.RequireProjectMember())
 		{
-			sessions.GET("", sessionHandler.ListSessions) // Requires ?contact_id or ?channel_id
-			sessions.GET("/:id", sessionHandler.GetSession)
-			sessions.POST("/:id/close", sessionHandler.CloseSession) // Agente encerra sessão manualmente
-			sessions.GET("/stats", sessionHandler.GetSessionStats)
+			sessions.GET("", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.ListSessions) // Requires ?contact_id or ?channel_id
+			sessions.GET("/:id", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.GetSession)
+			sessions.POST("/:id/close", rbac.RequirePermission(project_member.PermissionManageSessions), sessionHandler.CloseSession) // Agente encerra sessão manualmente
+			sessions.GET("/stats", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.GetSessionStats)
 		}
 
 		// Message routes
@@ -297,15 +312,18 @@ func SetupRoutesBasic(router *gin.Engine, logger *zap.Logger, healthChecker *hea
 
 // SetupRoutesBasicWithTest configura as rotas básicas com endpoints de teste, auth, channels, projects, pipelines, messages, chats e WebSocket
 // LEGACY: Mantido para compatibilidade
-func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChecker *health.HealthChecker, authHandler *handlers.AuthHandler, automationHandler *handlers.AutomationHandler, broadcastHandler *handlers.BroadcastHandler, sequenceHandler *handlers.SequenceHandler, campaignHandler *handlers.CampaignHandler, channelHandler *handlers.ChannelHandler, projectHandler *handlers.ProjectHandler, pipelineHandler *handlers.PipelineHandler, wahaHandler *handlers.WAHAWebhookHandler, webhookHandler *handlers.WebhookSubscriptionHandler, queueHandler *handlers.QueueHandler, sessionHandler *handlers.SessionHandler, contactHandler *handlers.ContactHandler, trackingHandler *handlers.TrackingHandler, messageHandler *handlers.MessageHandler, chatHandler *handlers.ChatHandler, agentHandler *handlers.AgentHandler, noteHandler *handlers.NoteHandler, automationDiscoveryHandler *handlers.AutomationDiscoveryHandler, websocketHandler *handlers.WebSocketMessageHandler, wsRateLimiter *middleware.WebSocketRateLimiter, gormDB *gorm.DB, authMiddleware *middleware.AuthMiddleware, wsAuthMiddleware *middleware.WebSocketAuthMiddleware, rlsMiddleware *middleware.RLSMiddleware) {
+func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChecker *health.HealthChecker, authHandler *handlers.AuthHandler, apiKeyHandler *handlers.APIKeyHandler, authSessionHandler *handlers.AuthSessionHandler, twoFactorHandler *handlers.TwoFactorHandler, auditLogHandler *handlers.AuditLogHandler, auditRecorder *auditapp.Recorder, automationHandler *handlers.AutomationHandler, broadcastHandler *handlers.BroadcastHandler, sequenceHandler *handlers.SequenceHandler, campaignHandler *handlers.CampaignHandler, channelHandler *handlers.ChannelHandler, projectHandler *handlers.ProjectHandler, projectMemberHandler *handlers.ProjectMemberHandler, projectRoleHandler *handlers.ProjectRoleHandler, pipelineHandler *handlers.PipelineHandler, wahaHandler *handlers.WAHAWebhookHandler, webhookHandler *handlers.WebhookSubscriptionHandler, queueHandler *handlers.QueueHandler, sessionHandler *handlers.SessionHandler, contactHandler *handlers.ContactHandler, trackingHandler *handlers.TrackingHandler, messageHandler *handlers.MessageHandler, chatHandler *handlers.ChatHandler, agentHandler *handlers.AgentHandler, slaHandler *handlers.SLAHandler, businessHoursHandler *handlers.BusinessHoursHandler, teamHandler *handlers.TeamHandler, searchHandler *handlers.SearchHandler, noteHandler *handlers.NoteHandler, taskHandler *handlers.TaskHandler, cannedResponseHandler *handlers.CannedResponseHandler, contactListHandler *handlers.ContactListHandler, automationDiscoveryHandler *handlers.AutomationDiscoveryHandler, billingHandler *handlers.BillingHandler, stripeWebhookHandler *handlers.StripeWebhookHandler, websocketHandler *handlers.WebSocketMessageHandler, wsRateLimiter *middleware.WebSocketRateLimiter, gormDB *gorm.DB, authMiddleware *middleware.AuthMiddleware, wsAuthMiddleware *middleware.WebSocketAuthMiddleware, rlsMiddleware *middleware.RLSMiddleware, rbac *middleware.RBACMiddleware, quota *middleware.QuotaMiddleware) {
 	// Add GORM context middleware FIRST (before any other middleware)
 	router.Use(middleware.GORMContextMiddleware(gormDB))
 
 	// Add Correlation ID middleware for distributed tracing
 	router.Use(middleware.CorrelationIDMiddleware())
 
+	// Trilha de auditoria: antes de qualquer rota para cobrir todas
+	router.Use(middleware.AuditTrail(auditRecorder, logger))
+
 	// Use the basic setup first
-	SetupRoutesBasic(router, logger, healthChecker, wahaHandler, webhookHandler, queueHandler, sessionHandler, contactHandler, authMiddleware, rlsMiddleware)
+	SetupRoutesBasic(router, logger, healthChecker, wahaHandler, webhookHandler, queueHandler, sessionHandler, contactHandler, authMiddleware, rlsMiddleware, rbac, quota)
 
 	// Auth routes (INDEPENDENT - não faz parte do CRM)
 	// Moved from /api/v1/crm/auth to /api/v1/auth
@@ -317,13 +335,86 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 		authRoutes.POST("/login", authHandler.Login)
 		authRoutes.GET("/info", authHandler.GetAuthInfo)
 
-		// Protected auth routes
+		// Sessões: access token curto + refresh token rotacionado
+		authRoutes.POST("/token", authSessionHandler.IssueToken)
+		authRoutes.POST("/token/2fa", authSessionHandler.CompleteTwoFactorLogin)
+		authRoutes.POST("/token/refresh", authSessionHandler.RefreshToken)
+		authRoutes.POST("/logout", authSessionHandler.Logout)
+
+		// Login OIDC (authorization code + PKCE)
+		authRoutes.GET("/oidc/providers", authSessionHandler.ListOIDCProviders)
+		authRoutes.GET("/oidc/:provider/login", authSessionHandler.StartOIDCLogin)
+		authRoutes.GET("/oidc/:provider/callback", authSessionHandler.OIDCCallback)
+
+		// Protected auth routes (acessíveis enquanto o projeto aguarda a inscrição no 2FA)
 		authProtected := authRoutes.Group("")
-		authProtected.Use(authMiddleware.Authenticate())
+		authProtected.Use(authMiddleware.AuthenticateAllowingTwoFactorSetup())
 		{
 			authProtected.GET("/profile", authHandler.GetProfile)
-			authProtected.POST("/api-key", authHandler.GenerateAPIKey)
+			authProtected.GET("/sessions", authSessionHandler.ListSessions)
+			authProtected.DELETE("/sessions", authSessionHandler.RevokeOtherSessions)
+			authProtected.DELETE("/sessions/:id", authSessionHandler.RevokeSession)
+
+			// 2FA TOTP e step-up
+			authProtected.GET("/2fa", twoFactorHandler.GetStatus)
+			authProtected.POST("/2fa/enroll", twoFactorHandler.Enroll)
+			authProtected.POST("/2fa/confirm", twoFactorHandler.Confirm)
+			authProtected.POST("/2fa/recovery-codes", twoFactorHandler.RegenerateRecoveryCodes)
+			authProtected.POST("/2fa/disable", twoFactorHandler.Disable)
+			authProtected.POST("/step-up", twoFactorHandler.StepUp)
 		}
+
+		// Mantido por compatibilidade: mesmo que POST /api/v1/api-keys
+		authRoutes.POST("/api-key", authMiddleware.Authenticate(), rbac.RequireProjectMember(), rbac.RequirePermission(project_member.PermissionManageSettings), middleware.RequireStepUp(), apiKeyHandler.CreateAPIKey)
+	}
+
+	// Convites de projeto: aceite pelo usuário logado com o email convidado, recusa só com o token
+	invitations := router.Group("/api/v1/invitations")
+	invitations.Use(middleware.AuthRateLimitMiddleware())
+	{
+		invitations.POST("/accept", authMiddleware.Authenticate(), projectMemberHandler.AcceptInvitation)
+		invitations.POST("/decline", projectMemberHandler.DeclineInvitation)
+	}
+
+	// Trilha de auditoria do projeto
+	auditLogs := router.Group("/api/v1/audit-logs")
+	auditLogs.Use(authMiddleware.Authenticate())
+	auditLogs.Use(rbac.RequireProjectMember())
+	auditLogs.Use(rbac.RequirePermission(project_member.PermissionViewAuditLog))
+	{
+		auditLogs.GET("", middleware.AuditAccess("audit_log.view"), auditLogHandler.ListAuditLog)
+		auditLogs.GET("/export", middleware.AuditAccess("audit_log.export"), middleware.RequireStepUp(), auditLogHandler.ExportAuditLog)
+		auditLogs.GET("/verify", auditLogHandler.VerifyAuditLog)
+	}
+
+	// Webhook do Stripe (faturas e assinaturas): público, validado pelo header Stripe-Signature
+	stripeWebhooks := router.Group("/api/v1/webhooks/stripe")
+	{
+		stripeWebhooks.POST("", stripeWebhookHandler.HandleWebhook)
+		stripeWebhooks.GET("/info", stripeWebhookHandler.GetWebhookInfo)
+	}
+
+	// Cobrança do projeto: situação da conta (régua de faturas em atraso) e linha do tempo.
+	// billing.view é da conta do cliente e não entra em papéis de projeto; quem vê as
+	// configurações do projeto vê por que as escritas estão bloqueadas.
+	billingRoutes := router.Group("/api/v1/billing")
+	billingRoutes.Use(authMiddleware.Authenticate())
+	billingRoutes.Use(rbac.RequireProjectMember())
+	billingRoutes.Use(rbac.RequirePermission(project_member.PermissionViewSettings))
+	{
+		billingRoutes.GET("/status", billingHandler.GetBillingStatus)
+		billingRoutes.GET("/events", billingHandler.ListBillingEvents)
+	}
+
+	// API keys do projeto (tokens vtr_..., guardados só como hash)
+	apiKeys := router.Group("/api/v1/api-keys")
+	apiKeys.Use(authMiddleware.Authenticate())
+	apiKeys.Use(rbac.RequireProjectMember())
+	{
+		apiKeys.GET("/permissions", rbac.RequirePermission(project_member.PermissionViewSettings), apiKeyHandler.ListAPIKeyPermissions)
+		apiKeys.GET("", rbac.RequirePermission(project_member.PermissionViewSettings), apiKeyHandler.ListAPIKeys)
+		apiKeys.POST("", rbac.RequirePermission(project_member.PermissionManageSettings), middleware.RequireStepUp(), apiKeyHandler.CreateAPIKey)
+		apiKeys.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageSettings), apiKeyHandler.RevokeAPIKey)
 	}
 
 	// Add automation routes (cross-product AUTOMATION product - NOT CRM)
@@ -331,68 +422,69 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 	automation := router.Group("/api/v1/automation")
 	automation.Use(authMiddleware.Authenticate())
 	automation.Use(rlsMiddleware.SetUserContext())
+	automation.Use(rbac.RequireProjectMember())
 	automation.Use(middleware.UserBasedRateLimitMiddleware("1000-M")) // 1000 req/min per user
 	{
 		// Discovery endpoints (metadata)
-		automation.GET("/types", automationHandler.GetAutomationTypes)
-		automation.GET("/actions", automationHandler.GetAvailableActions)
-		automation.GET("/operators", automationHandler.GetAvailableOperators)
+		automation.GET("/types", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationHandler.GetAutomationTypes)
+		automation.GET("/actions", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationHandler.GetAvailableActions)
+		automation.GET("/operators", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationHandler.GetAvailableOperators)
 
 		// CRUD endpoints
-		automation.GET("", automationHandler.ListAutomations)
-		automation.POST("", automationHandler.CreateAutomation)
-		automation.GET("/:id", automationHandler.GetAutomation)
-		automation.PUT("/:id", automationHandler.UpdateAutomation)
-		automation.DELETE("/:id", automationHandler.DeleteAutomation)
+		automation.GET("", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationHandler.ListAutomations)
+		automation.POST("", rbac.RequirePermission(project_member.PermissionManageCampaigns), automationHandler.CreateAutomation)
+		automation.GET("/:id", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationHandler.GetAutomation)
+		automation.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageCampaigns), automationHandler.UpdateAutomation)
+		automation.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageCampaigns), automationHandler.DeleteAutomation)
 
 		// Broadcast endpoints
 		broadcasts := automation.Group("/broadcasts")
 		{
-			broadcasts.GET("", broadcastHandler.ListBroadcasts)
-			broadcasts.POST("", broadcastHandler.CreateBroadcast)
-			broadcasts.GET("/:id", broadcastHandler.GetBroadcast)
-			broadcasts.PUT("/:id", broadcastHandler.UpdateBroadcast)
-			broadcasts.DELETE("/:id", broadcastHandler.DeleteBroadcast)
-			broadcasts.POST("/:id/schedule", broadcastHandler.ScheduleBroadcast)
-			broadcasts.POST("/:id/execute", broadcastHandler.ExecuteBroadcast)
-			broadcasts.POST("/:id/cancel", broadcastHandler.CancelBroadcast)
-			broadcasts.GET("/:id/stats", broadcastHandler.GetBroadcastStats)
+			broadcasts.GET("", rbac.RequirePermission(project_member.PermissionViewCampaigns), broadcastHandler.ListBroadcasts)
+			broadcasts.POST("", rbac.RequirePermission(project_member.PermissionManageCampaigns), quota.RequireQuota(billing.MetricBroadcasts), broadcastHandler.CreateBroadcast)
+			broadcasts.GET("/:id", rbac.RequirePermission(project_member.PermissionViewCampaigns), broadcastHandler.GetBroadcast)
+			broadcasts.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageCampaigns), broadcastHandler.UpdateBroadcast)
+			broadcasts.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageCampaigns), broadcastHandler.DeleteBroadcast)
+			broadcasts.POST("/:id/schedule", rbac.RequirePermission(project_member.PermissionManageCampaigns), broadcastHandler.ScheduleBroadcast)
+			broadcasts.POST("/:id/execute", rbac.RequirePermission(project_member.PermissionManageCampaigns), quota.RequireQuota(billing.MetricBroadcasts), broadcastHandler.ExecuteBroadcast)
+			broadcasts.POST("/:id/cancel", rbac.RequirePermission(project_member.PermissionManageCampaigns), broadcastHandler.CancelBroadcast)
+			broadcasts.GET("/:id/stats", rbac.RequirePermission(project_member.PermissionViewCampaigns), broadcastHandler.GetBroadcastStats)
 		}
 
 		// Sequence endpoints
 		sequences := automation.Group("/sequences")
 		{
-			sequences.GET("", sequenceHandler.ListSequences)
-			sequences.POST("", sequenceHandler.CreateSequence)
-			sequences.GET("/:id", sequenceHandler.GetSequence)
-			sequences.PUT("/:id", sequenceHandler.UpdateSequence)
-			sequences.DELETE("/:id", sequenceHandler.DeleteSequence)
-			sequences.POST("/:id/activate", sequenceHandler.ActivateSequence)
-			sequences.POST("/:id/pause", sequenceHandler.PauseSequence)
-			sequences.POST("/:id/resume", sequenceHandler.ResumeSequence)
-			sequences.POST("/:id/archive", sequenceHandler.ArchiveSequence)
-			sequences.GET("/:id/stats", sequenceHandler.GetSequenceStats)
-			sequences.POST("/:id/enroll", sequenceHandler.EnrollContact)
-			sequences.GET("/:id/enrollments", sequenceHandler.ListEnrollments)
+			sequences.GET("", rbac.RequirePermission(project_member.PermissionViewSequences), sequenceHandler.ListSequences)
+			sequences.POST("", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.CreateSequence)
+			sequences.GET("/:id", rbac.RequirePermission(project_member.PermissionViewSequences), sequenceHandler.GetSequence)
+			sequences.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.UpdateSequence)
+			sequences.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.DeleteSequence)
+			sequences.POST("/:id/activate", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.ActivateSequence)
+			sequences.POST("/:id/pause", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.PauseSequence)
+			sequences.POST("/:id/resume", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.ResumeSequence)
+			sequences.POST("/:id/archive", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.ArchiveSequence)
+			sequences.GET("/:id/stats", rbac.RequirePermission(project_member.PermissionViewSequences), sequenceHandler.GetSequenceStats)
+			sequences.POST("/:id/enroll", rbac.RequirePermission(project_member.PermissionManageSequences), sequenceHandler.EnrollContact)
+			sequences.GET("/:id/enrollments", rbac.RequirePermission(project_member.PermissionViewSequences), sequenceHandler.ListEnrollments)
 		}
 
 		// Campaign endpoints
 		campaigns := automation.Group("/campaigns")
 		{
-			campaigns.GET("", campaignHandler.ListCampaigns)
-			campaigns.POST("", campaignHandler.CreateCampaign)
-			campaigns.GET("/:id", campaignHandler.GetCampaign)
-			campaigns.PUT("/:id", campaignHandler.UpdateCampaign)
-			campaigns.DELETE("/:id", campaignHandler.DeleteCampaign)
-			campaigns.POST("/:id/activate", campaignHandler.ActivateCampaign)
-			campaigns.POST("/:id/schedule", campaignHandler.ScheduleCampaign)
-			campaigns.POST("/:id/pause", campaignHandler.PauseCampaign)
-			campaigns.POST("/:id/resume", campaignHandler.ResumeCampaign)
-			campaigns.POST("/:id/complete", campaignHandler.CompleteCampaign)
-			campaigns.POST("/:id/archive", campaignHandler.ArchiveCampaign)
-			campaigns.GET("/:id/stats", campaignHandler.GetCampaignStats)
-			campaigns.POST("/:id/enroll", campaignHandler.EnrollContact)
-			campaigns.GET("/:id/enrollments", campaignHandler.ListEnrollments)
+			campaigns.GET("", rbac.RequirePermission(project_member.PermissionViewCampaigns), campaignHandler.ListCampaigns)
+			campaigns.POST("", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.CreateCampaign)
+			campaigns.GET("/:id", rbac.RequirePermission(project_member.PermissionViewCampaigns), campaignHandler.GetCampaign)
+			campaigns.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.UpdateCampaign)
+			campaigns.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.DeleteCampaign)
+			campaigns.POST("/:id/activate", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.ActivateCampaign)
+			campaigns.POST("/:id/schedule", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.ScheduleCampaign)
+			campaigns.POST("/:id/pause", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.PauseCampaign)
+			campaigns.POST("/:id/resume", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.ResumeCampaign)
+			campaigns.POST("/:id/complete", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.CompleteCampaign)
+			campaigns.POST("/:id/archive", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.ArchiveCampaign)
+			campaigns.GET("/:id/stats", rbac.RequirePermission(project_member.PermissionViewCampaigns), campaignHandler.GetCampaignStats)
+			campaigns.POST("/:id/enroll", rbac.RequirePermission(project_member.PermissionManageCampaigns), campaignHandler.EnrollContact)
+			campaigns.GET("/:id/enrollments", rbac.RequirePermission(project_member.PermissionViewCampaigns), campaignHandler.ListEnrollments)
 		}
 	}
 
@@ -401,33 +493,35 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 	channels := router.Group("/api/v1/crm/channels")
 	channels.Use(authMiddleware.Authenticate())
 	channels.Use(rlsMiddleware.SetUserContext())
+	channels.Use(rbac.RequireProjectMember())
 	channels.Use(middleware.UserBasedRateLimitMiddleware("1000-M")) // 1000 req/min per user
 	{
-		channels.GET("", channelHandler.ListChannels)
-		channels.POST("", channelHandler.CreateChannel)
-		channels.GET("/:id", channelHandler.GetChannel)
-		channels.PUT("/:id", channelHandler.UpdateChannel)
-		channels.PATCH("/:id", channelHandler.UpdateChannel)
-		channels.POST("/:id/activate", channelHandler.ActivateChannel)
-		channels.POST("/:id/deactivate", channelHandler.DeactivateChannel)
-		channels.DELETE("/:id", channelHandler.DeleteChannel)
+		channels.GET("", rbac.RequirePermission(project_member.PermissionViewChannels), channelHandler.ListChannels)
+		channels.POST("", rbac.RequirePermission(project_member.PermissionManageChannels), quota.RequireQuota(billing.MetricChannels), channelHandler.CreateChannel)
+		channels.GET("/:id", rbac.RequirePermission(project_member.PermissionViewChannels), channelHandler.GetChannel)
+		channels.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.UpdateChannel)
+		channels.PATCH("/:id", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.UpdateChannel)
+		channels.POST("/:id/activate", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.ActivateChannel)
+		channels.POST("/:id/deactivate", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.DeactivateChannel)
+		channels.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageChannels), middleware.RequireStepUp(), channelHandler.DeleteChannel)
 
 		// Webhook endpoints for channels
-		channels.GET("/:id/webhook-url", channelHandler.GetChannelWebhookURL)
-		channels.POST("/:id/configure-webhook", channelHandler.ConfigureChannelWebhook)
-		channels.GET("/:id/webhook-info", channelHandler.GetChannelWebhookInfo)
+		channels.GET("/:id/webhook-url", rbac.RequirePermission(project_member.PermissionViewChannels), channelHandler.GetChannelWebhookURL)
+		channels.POST("/:id/configure-webhook", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.ConfigureChannelWebhook)
+		channels.GET("/:id/webhook-info", rbac.RequirePermission(project_member.PermissionViewChannels), channelHandler.GetChannelWebhookInfo)
 
 		// WAHA-specific endpoints
-		channels.POST("/:id/activate-waha", channelHandler.ActivateWAHAChannel)
-		channels.POST("/:id/import-history", channelHandler.ImportWAHAHistory)
-		channels.GET("/:id/import-status", channelHandler.GetWAHAImportStatus)
+		channels.POST("/:id/activate-waha", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.ActivateWAHAChannel)
+		channels.POST("/:id/import-history", rbac.RequirePermission(project_member.PermissionManageChannels), channelHandler.ImportWAHAHistory)
+		channels.GET("/:id/import-status", rbac.RequirePermission(project_member.PermissionViewChannels), channelHandler.GetWAHAImportStatus)
 
 		// Nested session routes under channel (using :id for channel)
-		channels.GET("/:id/sessions", sessionHandler.ListSessions)
-		channels.GET("/:id/sessions/:session_id", sessionHandler.GetSession)
+		channels.GET("/:id/sessions", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.ListSessions)
+		channels.GET("/:id/sessions/:session_id", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.GetSession)
 	}
 
 	// Add project routes (all protected)
+	// Listagem e criação valem para a conta do usuário; as rotas /:id exigem ser membro do projeto
 	projects := router.Group("/api/v1/crm/projects")
 	projects.Use(authMiddleware.Authenticate())
 	projects.Use(rlsMiddleware.SetUserContext())
@@ -436,65 +530,98 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 		projects.GET("/advanced", projectHandler.ListProjectsAdvanced) // Must be before /:id
 		projects.GET("", projectHandler.ListProjects)
 		projects.POST("", projectHandler.CreateProject)
-		projects.GET("/:id", projectHandler.GetProject)
-		projects.PUT("/:id", projectHandler.UpdateProject)
-		projects.DELETE("/:id", projectHandler.DeleteProject)
+
+		project := projects.Group("/:id")
+		project.Use(rbac.RequireProjectMemberParam("id"))
+		{
+			project.GET("", rbac.RequirePermission(project_member.PermissionViewMembers), projectHandler.GetProject)
+			project.PUT("", rbac.RequirePermission(project_member.PermissionManageSettings), projectHandler.UpdateProject)
+			project.DELETE("", rbac.RequirePermission(project_member.PermissionManageSettings), middleware.RequireStepUp(), projectHandler.DeleteProject)
+			project.PUT("/two-factor-policy", rbac.RequirePermission(project_member.PermissionManageSettings), middleware.RequireStepUp(), twoFactorHandler.SetProjectPolicy)
+
+			// Membros, convites e transferência de dono
+			project.GET("/members", rbac.RequirePermission(project_member.PermissionViewMembers), projectMemberHandler.ListMembers)
+			project.PUT("/members/:user_id/role", rbac.RequirePermission(project_member.PermissionManageMembers), projectMemberHandler.ChangeMemberRole)
+			project.DELETE("/members/:user_id", rbac.RequirePermission(project_member.PermissionManageMembers), projectMemberHandler.RemoveMember)
+			project.POST("/transfer-ownership", rbac.RequirePermission(project_member.PermissionManageMembers), middleware.RequireStepUp(), projectMemberHandler.TransferOwnership)
+			project.GET("/invitations", rbac.RequirePermission(project_member.PermissionManageMembers), projectMemberHandler.ListInvitations)
+			project.POST("/invitations", rbac.RequirePermission(project_member.PermissionManageMembers), projectMemberHandler.InviteMember)
+			project.DELETE("/invitations/:invitation_id", rbac.RequirePermission(project_member.PermissionManageMembers), projectMemberHandler.RevokeInvitation)
+
+			// Papéis custom (padrão clonáveis); mudanças valem na hora via invalidação do cache de acesso
+			project.GET("/roles", rbac.RequirePermission(project_member.PermissionViewMembers), projectRoleHandler.ListRoles)
+			project.GET("/roles/permissions", rbac.RequirePermission(project_member.PermissionViewMembers), projectRoleHandler.ListPermissions)
+			project.GET("/roles/:role_id", rbac.RequirePermission(project_member.PermissionViewMembers), projectRoleHandler.GetRole)
+			project.POST("/roles", rbac.RequirePermission(project_member.PermissionManageMembers), projectRoleHandler.CreateRole)
+			project.PUT("/roles/:role_id", rbac.RequirePermission(project_member.PermissionManageMembers), projectRoleHandler.UpdateRole)
+			project.DELETE("/roles/:role_id", rbac.RequirePermission(project_member.PermissionManageMembers), projectRoleHandler.DeleteRole)
+		}
 	}
 
 	// Add pipeline routes (all protected)
 	pipelines := router.Group("/api/v1/crm/pipelines")
 	pipelines.Use(authMiddleware.Authenticate())
 	pipelines.Use(rlsMiddleware.SetUserContext())
+	pipelines.Use(rbac.RequireProjectMember())
 	{
-		pipelines.GET("/search", pipelineHandler.SearchPipelines)         // Must be before /:id
-		pipelines.GET("/advanced", pipelineHandler.ListPipelinesAdvanced) // Must be before /:id
-		pipelines.GET("", pipelineHandler.ListPipelines)
-		pipelines.POST("", pipelineHandler.CreatePipeline)
-		pipelines.GET("/:id", pipelineHandler.GetPipeline)
+		pipelines.GET("/search", rbac.RequirePermission(project_member.PermissionViewPipelines), pipelineHandler.SearchPipelines)         // Must be before /:id
+		pipelines.GET("/advanced", rbac.RequirePermission(project_member.PermissionViewPipelines), pipelineHandler.ListPipelinesAdvanced) // Must be before /:id
+		pipelines.GET("", rbac.RequirePermission(project_member.PermissionViewPipelines), pipelineHandler.ListPipelines)
+		pipelines.POST("", rbac.RequirePermission(project_member.PermissionManagePipelines), pipelineHandler.CreatePipeline)
+		pipelines.GET("/:id", rbac.RequirePermission(project_member.PermissionViewPipelines), pipelineHandler.GetPipeline)
 
 		// Status routes within pipelines
-		pipelines.POST("/:id/statuses", pipelineHandler.CreateStatus)
+		pipelines.POST("/:id/statuses", rbac.RequirePermission(project_member.PermissionManagePipelines), pipelineHandler.CreateStatus)
 
 		// Contact status routes (usando :id para pipeline)
-		pipelines.PUT("/:id/contacts/:contact_id/status", pipelineHandler.ChangeContactStatus)
-		pipelines.GET("/:id/contacts/:contact_id/status", pipelineHandler.GetContactStatus)
+		pipelines.PUT("/:id/contacts/:contact_id/status", rbac.RequirePermission(project_member.PermissionManageContacts), pipelineHandler.ChangeContactStatus)
+		pipelines.GET("/:id/contacts/:contact_id/status", rbac.RequirePermission(project_member.PermissionViewContacts), pipelineHandler.GetContactStatus)
 	}
 
 	// Add session routes (all protected) - advanced query endpoints
 	sessions := router.Group("/api/v1/crm/sessions")
 	sessions.Use(authMiddleware.Authenticate())
 	sessions.Use(rlsMiddleware.SetUserContext())
+	sessions.Use(rbac.RequireProjectMember())
 	{
-		sessions.GET("/search", sessionHandler.SearchSessions)         // Must be before /:id
-		sessions.GET("/advanced", sessionHandler.ListSessionsAdvanced) // Must be before /:id
+		sessions.GET("/search", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.SearchSessions)          // Must be before /:id
+		sessions.GET("/advanced", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.ListSessionsAdvanced)  // Must be before /:id
+		sessions.GET("/analytics", rbac.RequirePermission(project_member.PermissionViewAnalytics), sessionHandler.GetSessionAnalytics) // Must be before /:id
+		sessions.GET("/active", rbac.RequirePermission(project_member.PermissionViewSessions), sessionHandler.GetActiveSessions)       // Must be before /:id
+		sessions.GET("/:id/messages", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.GetMessagesBySession)
+		sessions.PUT("/:id/priority", rbac.RequirePermission(project_member.PermissionManageSessions), sessionHandler.SetSessionPriority)
 	}
 
 	// Add tracking routes (all protected)
 	trackings := router.Group("/api/v1/crm/trackings")
 	trackings.Use(authMiddleware.Authenticate())
 	trackings.Use(rlsMiddleware.SetUserContext())
+	trackings.Use(rbac.RequireProjectMember())
 	{
-		trackings.GET("/enums", trackingHandler.GetTrackingEnums) // Must be before /:id
-		trackings.POST("/encode", trackingHandler.EncodeTracking)
-		trackings.POST("/decode", trackingHandler.DecodeTracking)
-		trackings.POST("", trackingHandler.CreateTracking)
-		trackings.GET("/:id", trackingHandler.GetTracking)
+		trackings.GET("/enums", rbac.RequirePermission(project_member.PermissionViewContacts), trackingHandler.GetTrackingEnums) // Must be before /:id
+		trackings.POST("/encode", rbac.RequirePermission(project_member.PermissionViewContacts), trackingHandler.EncodeTracking)
+		trackings.POST("/decode", rbac.RequirePermission(project_member.PermissionViewContacts), trackingHandler.DecodeTracking)
+		trackings.POST("", rbac.RequirePermission(project_member.PermissionManageContacts), trackingHandler.CreateTracking)
+		trackings.GET("/:id", rbac.RequirePermission(project_member.PermissionViewContacts), trackingHandler.GetTracking)
 	}
 
 	// Add message routes (all protected)
 	messages := router.Group("/api/v1/crm/messages")
 	messages.Use(authMiddleware.Authenticate())
 	messages.Use(rlsMiddleware.SetUserContext())
+	messages.Use(rbac.RequireProjectMember())
 	{
-		messages.GET("/search", messageHandler.SearchMessages)         // Must be before /:id
-		messages.GET("/advanced", messageHandler.ListMessagesAdvanced) // Must be before /:id
-		messages.GET("", messageHandler.ListMessages)
-		messages.POST("", messageHandler.CreateMessage)
-		messages.POST("/send", messageHandler.SendMessage)
-		messages.POST("/confirm-delivery", messageHandler.ConfirmMessageDelivery)
-		messages.GET("/:id", messageHandler.GetMessage)
-		messages.PUT("/:id", messageHandler.UpdateMessage)
-		messages.DELETE("/:id", messageHandler.DeleteMessage)
+		messages.GET("/search", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.SearchMessages)              // Must be before /:id
+		messages.GET("/advanced", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.ListMessagesAdvanced)      // Must be before /:id
+		messages.GET("/conversation", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.GetConversationThread) // Must be before /:id
+		messages.GET("", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.ListMessages)
+		messages.POST("", rbac.RequirePermission(project_member.PermissionSendMessages), quota.RequireQuota(billing.MetricMessages), messageHandler.CreateMessage)
+		messages.POST("/send", rbac.RequirePermission(project_member.PermissionSendMessages), quota.RequireQuota(billing.MetricMessages), messageHandler.SendMessage)
+		messages.POST("/confirm-delivery", rbac.RequirePermission(project_member.PermissionSendMessages), messageHandler.ConfirmMessageDelivery)
+		messages.GET("/:id", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.GetMessage)
+		messages.PUT("/:id", rbac.RequirePermission(project_member.PermissionSendMessages), messageHandler.UpdateMessage)
+		messages.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageSessions), messageHandler.DeleteMessage)
+		messages.GET("/:id/thread", rbac.RequirePermission(project_member.PermissionViewMessages), messageHandler.GetMessageThread)
 	}
 
 	// Add chat routes (all protected) - NOTE: chatHandler will be nil initially, add when ready
@@ -502,16 +629,17 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 		chats := router.Group("/api/v1/crm/chats")
 		chats.Use(authMiddleware.Authenticate())
 		chats.Use(rlsMiddleware.SetUserContext())
+		chats.Use(rbac.RequireProjectMember())
 		{
-			chats.POST("", chatHandler.CreateChat)
-			chats.GET("", chatHandler.ListChats)
-			chats.GET("/:id", chatHandler.GetChat)
-			chats.POST("/:id/participants", chatHandler.AddParticipant)
-			chats.DELETE("/:id/participants/:participant_id", chatHandler.RemoveParticipant)
-			chats.POST("/:id/archive", chatHandler.ArchiveChat)
-			chats.POST("/:id/unarchive", chatHandler.UnarchiveChat)
-			chats.POST("/:id/close", chatHandler.CloseChat)
-			chats.PATCH("/:id/subject", chatHandler.UpdateChatSubject)
+			chats.POST("", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.CreateChat)
+			chats.GET("", rbac.RequirePermission(project_member.PermissionViewSessions), chatHandler.ListChats)
+			chats.GET("/:id", rbac.RequirePermission(project_member.PermissionViewSessions), chatHandler.GetChat)
+			chats.POST("/:id/participants", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.AddParticipant)
+			chats.DELETE("/:id/participants/:participant_id", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.RemoveParticipant)
+			chats.POST("/:id/archive", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.ArchiveChat)
+			chats.POST("/:id/unarchive", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.UnarchiveChat)
+			chats.POST("/:id/close", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.CloseChat)
+			chats.PATCH("/:id/subject", rbac.RequirePermission(project_member.PermissionManageSessions), chatHandler.UpdateChatSubject)
 		}
 	}
 
@@ -520,17 +648,97 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 		agents := router.Group("/api/v1/crm/agents")
 		agents.Use(authMiddleware.Authenticate())
 		agents.Use(rlsMiddleware.SetUserContext())
+		agents.Use(rbac.RequireProjectMember())
 		{
-			agents.GET("/search", agentHandler.SearchAgents)         // Must be before /:id
-			agents.GET("/advanced", agentHandler.ListAgentsAdvanced) // Must be before /:id
-			agents.POST("/virtual", agentHandler.CreateVirtualAgent) // Must be before /:id
-			agents.GET("", agentHandler.ListAgents)
-			agents.POST("", agentHandler.CreateAgent)
-			agents.GET("/:id", agentHandler.GetAgent)
-			agents.PUT("/:id", agentHandler.UpdateAgent)
-			agents.DELETE("/:id", agentHandler.DeleteAgent)
-			agents.GET("/:id/stats", agentHandler.GetAgentStats)
-			agents.PUT("/:id/virtual/end-period", agentHandler.EndVirtualAgentPeriod)
+			agents.GET("/search", rbac.RequirePermission(project_member.PermissionViewMembers), agentHandler.SearchAgents)               // Must be before /:id
+			agents.GET("/advanced", rbac.RequirePermission(project_member.PermissionViewMembers), agentHandler.ListAgentsAdvanced)       // Must be before /:id
+			agents.POST("/virtual", rbac.RequirePermission(project_member.PermissionManageMembers), agentHandler.CreateVirtualAgent)     // Must be before /:id
+			agents.GET("/leaderboard", rbac.RequirePermission(project_member.PermissionViewAnalytics), agentHandler.GetAgentLeaderboard) // Must be before /:id
+			agents.GET("/presence", rbac.RequirePermission(project_member.PermissionViewMembers), agentHandler.ListAgentPresence)        // Must be before /:id
+			agents.GET("", rbac.RequirePermission(project_member.PermissionViewMembers), agentHandler.ListAgents)
+			agents.POST("", rbac.RequirePermission(project_member.PermissionManageMembers), quota.RequireQuota(billing.MetricAgents), agentHandler.CreateAgent)
+			agents.GET("/:id", rbac.RequirePermission(project_member.PermissionViewMembers), agentHandler.GetAgent)
+			agents.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageMembers), agentHandler.UpdateAgent)
+			agents.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageMembers), agentHandler.DeleteAgent)
+			agents.GET("/:id/stats", rbac.RequirePermission(project_member.PermissionViewAnalytics), agentHandler.GetAgentStats)
+			agents.PUT("/:id/status", rbac.RequirePermission(project_member.PermissionManageSessions), agentHandler.SetAgentStatus)
+			agents.GET("/:id/working-hours", rbac.RequirePermission(project_member.PermissionViewMembers), agentHandler.GetAgentWorkingHours)
+			agents.PUT("/:id/working-hours", rbac.RequirePermission(project_member.PermissionManageMembers), agentHandler.UpdateAgentWorkingHours)
+			agents.PUT("/:id/virtual/end-period", rbac.RequirePermission(project_member.PermissionManageMembers), agentHandler.EndVirtualAgentPeriod)
+		}
+	}
+
+	// Add SLA routes (all protected)
+	if slaHandler != nil {
+		slaRoutes := router.Group("/api/v1/crm/sla")
+		slaRoutes.Use(authMiddleware.Authenticate())
+		slaRoutes.Use(rlsMiddleware.SetUserContext())
+		slaRoutes.Use(rbac.RequireProjectMember())
+		{
+			slaRoutes.GET("/compliance", rbac.RequirePermission(project_member.PermissionViewAnalytics), slaHandler.GetCompliance)
+			slaRoutes.GET("/policies", rbac.RequirePermission(project_member.PermissionViewSettings), slaHandler.ListPolicies)
+			slaRoutes.POST("/policies", rbac.RequirePermission(project_member.PermissionManageSettings), slaHandler.CreatePolicy)
+			slaRoutes.GET("/policies/:id", rbac.RequirePermission(project_member.PermissionViewSettings), slaHandler.GetPolicy)
+			slaRoutes.PUT("/policies/:id", rbac.RequirePermission(project_member.PermissionManageSettings), slaHandler.UpdatePolicy)
+			slaRoutes.DELETE("/policies/:id", rbac.RequirePermission(project_member.PermissionManageSettings), slaHandler.DeletePolicy)
+		}
+	}
+
+	// Add business hours routes (all protected)
+	if businessHoursHandler != nil {
+		businessHours := router.Group("/api/v1/crm/business-hours")
+		businessHours.Use(authMiddleware.Authenticate())
+		businessHours.Use(rlsMiddleware.SetUserContext())
+		businessHours.Use(rbac.RequireProjectMember())
+		{
+			businessHours.GET("", rbac.RequirePermission(project_member.PermissionViewSettings), businessHoursHandler.GetBusinessHours)
+			businessHours.PUT("", rbac.RequirePermission(project_member.PermissionManageSettings), businessHoursHandler.UpdateBusinessHours)
+			businessHours.DELETE("", rbac.RequirePermission(project_member.PermissionManageSettings), businessHoursHandler.DeleteBusinessHours)
+			businessHours.POST("/holidays/import", rbac.RequirePermission(project_member.PermissionManageSettings), businessHoursHandler.ImportHolidays)
+		}
+	}
+
+	// Add team and queue routes (all protected)
+	if teamHandler != nil {
+		teams := router.Group("/api/v1/crm/teams")
+		teams.Use(authMiddleware.Authenticate())
+		teams.Use(rlsMiddleware.SetUserContext())
+		teams.Use(rbac.RequireProjectMember())
+		{
+			teams.GET("", rbac.RequirePermission(project_member.PermissionViewMembers), teamHandler.ListTeams)
+			teams.POST("", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.CreateTeam)
+			teams.GET("/:id", rbac.RequirePermission(project_member.PermissionViewMembers), teamHandler.GetTeam)
+			teams.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.UpdateTeam)
+			teams.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.DeleteTeam)
+			teams.POST("/:id/members", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.AddTeamMember)
+			teams.DELETE("/:id/members/:agent_id", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.RemoveTeamMember)
+		}
+
+		queues := router.Group("/api/v1/crm/queues")
+		queues.Use(authMiddleware.Authenticate())
+		queues.Use(rlsMiddleware.SetUserContext())
+		queues.Use(rbac.RequireProjectMember())
+		{
+			queues.GET("", rbac.RequirePermission(project_member.PermissionViewMembers), teamHandler.ListQueues)
+			queues.POST("", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.CreateQueue)
+			queues.POST("/pick-next", rbac.RequirePermission(project_member.PermissionManageSessions), teamHandler.PickNext)           // Must be before /:id
+			queues.GET("/live", rbac.RequirePermission(project_member.PermissionViewAnalytics), teamHandler.GetLiveView)               // Must be before /:id
+			queues.GET("/metrics", rbac.RequirePermission(project_member.PermissionViewAnalytics), teamHandler.GetProjectQueueMetrics) // Must be before /:id
+			queues.GET("/:id", rbac.RequirePermission(project_member.PermissionViewMembers), teamHandler.GetQueue)
+			queues.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.UpdateQueue)
+			queues.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageMembers), teamHandler.DeleteQueue)
+			queues.GET("/:id/metrics", rbac.RequirePermission(project_member.PermissionViewAnalytics), teamHandler.GetQueueMetrics)
+		}
+	}
+
+	// Add unified search route (protected)
+	if searchHandler != nil {
+		searchRoutes := router.Group("/api/v1/crm/search")
+		searchRoutes.Use(authMiddleware.Authenticate())
+		searchRoutes.Use(rlsMiddleware.SetUserContext())
+		searchRoutes.Use(rbac.RequireProjectMember())
+		{
+			searchRoutes.GET("", rbac.RequirePermission(project_member.PermissionViewContacts), searchHandler.Search)
 		}
 	}
 
@@ -539,9 +747,100 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 		notes := router.Group("/api/v1/crm/notes")
 		notes.Use(authMiddleware.Authenticate())
 		notes.Use(rlsMiddleware.SetUserContext())
+		notes.Use(rbac.RequireProjectMember())
+		{
+			notes.GET("/search", rbac.RequirePermission(project_member.PermissionViewContacts), noteHandler.SearchNotes)         // Must be before /:id
+			notes.GET("/advanced", rbac.RequirePermission(project_member.PermissionViewContacts), noteHandler.ListNotesAdvanced) // Must be before /:id
+			notes.GET("/mentions", rbac.RequirePermission(project_member.PermissionViewContacts), noteHandler.ListMyMentions)    // Must be before /:id
+			notes.GET("/:id", rbac.RequirePermission(project_member.PermissionViewContacts), noteHandler.GetNote)
+			notes.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.UpdateNote)
+			notes.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.DeleteNote)
+			notes.POST("/:id/pin", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.PinNote)
+			notes.DELETE("/:id/pin", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.UnpinNote)
+			notes.POST("/:id/attachments", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.UploadNoteAttachment)
+		}
+
+		// Notas no escopo do contato e da sessão
+		contactNotes := router.Group("/api/v1/contacts/:id/notes")
+		contactNotes.Use(authMiddleware.Authenticate())
+		contactNotes.Use(rlsMiddleware.SetUserContext())
+		contactNotes.Use(rbac.RequireProjectMember())
+		{
+			contactNotes.GET("", rbac.RequirePermission(project_member.PermissionViewContacts), noteHandler.ListContactNotes)
+			contactNotes.POST("", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.CreateContactNote)
+		}
+
+		sessionNotes := router.Group("/api/v1/crm/sessions/:id/notes")
+		sessionNotes.Use(authMiddleware.Authenticate())
+		sessionNotes.Use(rlsMiddleware.SetUserContext())
+		sessionNotes.Use(rbac.RequireProjectMember())
+		{
+			sessionNotes.GET("", rbac.RequirePermission(project_member.PermissionViewContacts), noteHandler.ListSessionNotes)
+			sessionNotes.POST("", rbac.RequirePermission(project_member.PermissionManageContacts), noteHandler.CreateSessionNote)
+		}
+	}
+
+	// Add task routes (all protected)
+	if taskHandler != nil {
+		tasks := router.Group("/api/v1/crm/tasks")
+		tasks.Use(authMiddleware.Authenticate())
+		tasks.Use(rlsMiddleware.SetUserContext())
+		tasks.Use(rbac.RequireProjectMember())
+		{
+			tasks.GET("", rbac.RequirePermission(project_member.PermissionViewContacts), taskHandler.ListTasks)
+			tasks.POST("", rbac.RequirePermission(project_member.PermissionManageContacts), taskHandler.CreateTask)
+			tasks.GET("/mine", rbac.RequirePermission(project_member.PermissionViewContacts), taskHandler.MyTasks)         // Must be before /:id
+			tasks.GET("/overdue", rbac.RequirePermission(project_member.PermissionViewContacts), taskHandler.OverdueTasks) // Must be before /:id
+			tasks.GET("/:id", rbac.RequirePermission(project_member.PermissionViewContacts), taskHandler.GetTask)
+			tasks.PATCH("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), taskHandler.UpdateTask)
+			tasks.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), taskHandler.DeleteTask)
+			tasks.POST("/:id/complete", rbac.RequirePermission(project_member.PermissionManageContacts), taskHandler.CompleteTask)
+			tasks.POST("/:id/cancel", rbac.RequirePermission(project_member.PermissionManageContacts), taskHandler.CancelTask)
+		}
+	}
+
+	// Add canned response routes (all protected)
+	if cannedResponseHandler != nil {
+		cannedResponses := router.Group("/api/v1/crm/canned-responses")
+		cannedResponses.Use(authMiddleware.Authenticate())
+		cannedResponses.Use(rlsMiddleware.SetUserContext())
+		cannedResponses.Use(rbac.RequireProjectMember())
+		{
+			cannedResponses.GET("", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.ListCannedResponses)
+			cannedResponses.POST("", rbac.RequirePermission(project_member.PermissionSendMessages), cannedResponseHandler.CreateCannedResponse)
+			cannedResponses.GET("/search", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.SearchCannedResponses)          // Must be before /:id
+			cannedResponses.GET("/folders", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.ListCannedResponseFolders)     // Must be before /:id
+			cannedResponses.GET("/variables", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.ListCannedResponseVariables) // Must be before /:id
+			cannedResponses.GET("/analytics", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.CannedResponseAnalytics)     // Must be before /:id
+			cannedResponses.POST("/expand", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.ExpandCannedResponse)          // Must be before /:id
+			cannedResponses.GET("/:id", rbac.RequirePermission(project_member.PermissionViewMessages), cannedResponseHandler.GetCannedResponse)
+			cannedResponses.PUT("/:id", rbac.RequirePermission(project_member.PermissionSendMessages), cannedResponseHandler.UpdateCannedResponse)
+			cannedResponses.DELETE("/:id", rbac.RequirePermission(project_member.PermissionSendMessages), cannedResponseHandler.DeleteCannedResponse)
+			cannedResponses.POST("/:id/use", rbac.RequirePermission(project_member.PermissionSendMessages), cannedResponseHandler.UseCannedResponse)
+			cannedResponses.POST("/:id/attachments", rbac.RequirePermission(project_member.PermissionSendMessages), cannedResponseHandler.UploadCannedResponseAttachment)
+			cannedResponses.DELETE("/:id/attachments/:attachment_id", rbac.RequirePermission(project_member.PermissionSendMessages), cannedResponseHandler.DeleteCannedResponseAttachment)
+		}
+	}
+
+	// Add contact list routes (all protected)
+	if contactListHandler != nil {
+		contactLists := router.Group("/api/v1/crm/contact-lists")
+		contactLists.Use(authMiddleware.Authenticate())
+		contactLists.Use(rlsMiddleware.SetUserContext())
+		contactLists.Use(rbac.RequireProjectMember())
 		{
-			notes.GET("/search", noteHandler.SearchNotes)         // Must be before /:id
-			notes.GET("/advanced", noteHandler.ListNotesAdvanced) // Must be before /:id
+			contactLists.GET("", rbac.RequirePermission(project_member.PermissionViewContacts), contactListHandler.ListContactLists)
+			contactLists.POST("", rbac.RequirePermission(project_member.PermissionManageContacts), contactListHandler.CreateContactList)
+			contactLists.POST("/preview", rbac.RequirePermission(project_member.PermissionViewContacts), contactListHandler.PreviewContactList)
+			contactLists.GET("/:id", rbac.RequirePermission(project_member.PermissionViewContacts), contactListHandler.GetContactList)
+			contactLists.PUT("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), contactListHandler.UpdateContactList)
+			contactLists.DELETE("/:id", rbac.RequirePermission(project_member.PermissionManageContacts), contactListHandler.DeleteContactList)
+			contactLists.POST("/:id/recalculate", rbac.RequirePermission(project_member.PermissionManageContacts), contactListHandler.RecalculateContactList)
+
+			// Membros (adição/remoção manual apenas em listas estáticas)
+			contactLists.GET("/:id/contacts", rbac.RequirePermission(project_member.PermissionViewContacts), contactListHandler.GetContactsInList)
+			contactLists.POST("/:id/contacts", rbac.RequirePermission(project_member.PermissionManageContacts), contactListHandler.AddContactToList)
+			contactLists.DELETE("/:id/contacts/:contact_id", rbac.RequirePermission(project_member.PermissionManageContacts), contactListHandler.RemoveContactFromList)
 		}
 	}
 
@@ -561,19 +860,20 @@ func SetupRoutesBasicWithTest(router *gin.Engine, logger *zap.Logger, healthChec
 	if automationDiscoveryHandler != nil {
 		automation := router.Group("/api/v1/crm/automation")
 		automation.Use(authMiddleware.Authenticate())
+		automation.Use(rbac.RequireProjectMember())
 		{
 			// Discovery endpoints - read-only (no RLS needed)
-			automation.GET("/types", automationDiscoveryHandler.GetAutomationTypes)
-			automation.GET("/triggers", automationDiscoveryHandler.GetTriggers)
-			automation.GET("/triggers/:code", automationDiscoveryHandler.GetTriggerDetails)
-			automation.GET("/actions", automationDiscoveryHandler.GetActions)
-			automation.GET("/conditions/operators", automationDiscoveryHandler.GetConditionOperators)
-			automation.GET("/logic-operators", automationDiscoveryHandler.GetLogicOperators)
-			automation.GET("/discovery", automationDiscoveryHandler.GetFullDiscovery)
-
-			// Custom trigger management (admin only - add RBAC middleware if needed)
-			automation.POST("/triggers/custom", automationDiscoveryHandler.RegisterCustomTrigger)
-			automation.DELETE("/triggers/custom/:code", automationDiscoveryHandler.UnregisterCustomTrigger)
+			automation.GET("/types", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetAutomationTypes)
+			automation.GET("/triggers", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetTriggers)
+			automation.GET("/triggers/:code", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetTriggerDetails)
+			automation.GET("/actions", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetActions)
+			automation.GET("/conditions/operators", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetConditionOperators)
+			automation.GET("/logic-operators", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetLogicOperators)
+			automation.GET("/discovery", rbac.RequirePermission(project_member.PermissionViewCampaigns), automationDiscoveryHandler.GetFullDiscovery)
+
+			// Custom trigger management (settings.manage)
+			automation.POST("/triggers/custom", rbac.RequirePermission(project_member.PermissionManageSettings), automationDiscoveryHandler.RegisterCustomTrigger)
+			automation.DELETE("/triggers/custom/:code", rbac.RequirePermission(project_member.PermissionManageSettings), automationDiscoveryHandler.UnregisterCustomTrigger)
 		}
 	}
 
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	agentapp "github.com/ventros/crm/internal/application/agent"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+// AgentParticipationConsumer mantém agent_sessions a partir de session.agent_assigned e session.ended.
+// Consome as filas de fan-out domain.events.<tipo>.agent_sessions (ver domain_event_subscriptions.go).
+type AgentParticipationConsumer struct {
+	conn    *RabbitMQConnection
+	tracker *agentapp.TrackAgentParticipationUseCase
+	logger  *zap.Logger
+}
+
+func NewAgentParticipationConsumer(
+	conn *RabbitMQConnection,
+	tracker *agentapp.TrackAgentParticipationUseCase,
+	logger *zap.Logger,
+) *AgentParticipationConsumer {
+	return &AgentParticipationConsumer{
+		conn:    conn,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Start inicia um consumer por tipo de evento
+func (c *AgentParticipationConsumer) Start(ctx context.Context) error {
+	handlers := map[string]Consumer{
+		"session.agent_assigned": &agentAssignedParticipationHandler{c},
+		"session.ended":          &sessionEndedParticipationHandler{c},
+	}
+
+	for eventType, handler := range handlers {
+		queueName := SubscriberQueue(eventType, AgentSessionsSubscriber)
+		consumerTag := fmt.Sprintf("agent-participation-%s-%s", eventType, uuid.New().String()[:8])
+
+		if err := c.conn.StartConsumer(ctx, queueName, consumerTag, handler, 10); err != nil {
+			c.logger.Error("Failed to start consumer",
+				zap.String("queue", queueName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	c.logger.Info("Agent participation consumers started")
+	return nil
+}
+
+type agentAssignedParticipationHandler struct {
+	consumer *AgentParticipationConsumer
+}
+
+func (h *agentAssignedParticipationHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.AgentAssignedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal AgentAssignedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.tracker.HandleAgentAssigned(ctx, agentapp.AgentAssignedInput{
+		SessionID:  event.SessionID,
+		AgentID:    event.AgentID,
+		AssignedAt: event.AssignedAt,
+	}); err != nil {
+		h.consumer.logger.Error("Failed to record agent participation",
+			zap.String("session_id", event.SessionID.String()),
+			zap.String("agent_id", event.AgentID.String()),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+type sessionEndedParticipationHandler struct {
+	consumer *AgentParticipationConsumer
+}
+
+func (h *sessionEndedParticipationHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.SessionEndedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal SessionEndedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.tracker.HandleSessionEnded(ctx, event.SessionID, event.EndedAt); err != nil {
+		h.consumer.logger.Error("Failed to close agent participations",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
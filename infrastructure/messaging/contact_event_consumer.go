@@ -4,15 +4,16 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"time"
 
 	"github.com/google/uuid"
 	amqp "github.com/rabbitmq/amqp091-go"
 	contacteventapp "github.com/ventros/crm/internal/application/contact_event"
 	"github.com/ventros/crm/internal/domain/crm/contact"
 	"github.com/ventros/crm/internal/domain/crm/contact_event"
+	"github.com/ventros/crm/internal/domain/crm/note"
 	"github.com/ventros/crm/internal/domain/crm/pipeline"
 	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/task"
 	"go.uber.org/zap"
 )
 
@@ -63,6 +64,12 @@ func (c *ContactEventConsumer) Start(ctx context.Context) error {
 		{"domain.events.session.ended", &sessionEndedConsumer{c}},
 		{"domain.events.tracking.message.meta_ads", &trackingAdConversionConsumer{c}},
 		{"domain.events.note.added", &noteAddedConsumer{c}},
+		{"domain.events.note.updated", &noteUpdatedConsumer{c}},
+		{"domain.events.note.pinned", &notePinnedConsumer{c}},
+		{"domain.events.note.unpinned", &noteUnpinnedConsumer{c}},
+		{"domain.events.note.deleted", &noteDeletedConsumer{c}},
+		{"domain.events.task.created", &taskCreatedConsumer{c}},
+		{"domain.events.task.completed", &taskCompletedConsumer{c}},
 	}
 
 	for _, cfg := range consumers {
@@ -616,20 +623,7 @@ type noteAddedConsumer struct {
 }
 
 func (c *noteAddedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
-	var event struct {
-		NoteID     uuid.UUID  `json:"note_id"`
-		ContactID  uuid.UUID  `json:"contact_id"`
-		SessionID  *uuid.UUID `json:"session_id"`
-		TenantID   string     `json:"tenant_id"`
-		AuthorID   uuid.UUID  `json:"author_id"`
-		AuthorType string     `json:"author_type"`
-		AuthorName string     `json:"author_name"`
-		Content    string     `json:"content"`
-		NoteType   string     `json:"note_type"`
-		Priority   string     `json:"priority"`
-		AddedAt    time.Time  `json:"added_at"`
-	}
-
+	var event note.NoteAddedEvent
 	if err := json.Unmarshal(delivery.Body, &event); err != nil {
 		c.parent.logger.Error("Failed to unmarshal NoteAddedEvent", zap.Error(err))
 		return err
@@ -642,7 +636,7 @@ func (c *noteAddedConsumer) ProcessMessage(ctx context.Context, delivery amqp.De
 		ContactID:   event.ContactID,
 		SessionID:   event.SessionID,
 		TenantID:    event.TenantID,
-		EventType:   "note_added",
+		EventType:   contact_event.EventTypeNoteAdded,
 		Category:    contact_event.CategoryNote,
 		Priority:    contact_event.Priority(event.Priority),
 		Source:      contact_event.SourceSystem,
@@ -651,9 +645,9 @@ func (c *noteAddedConsumer) ProcessMessage(ctx context.Context, delivery amqp.De
 		Payload: map[string]interface{}{
 			"note_id":         event.NoteID.String(),
 			"author_id":       event.AuthorID.String(),
-			"author_type":     event.AuthorType,
+			"author_type":     string(event.AuthorType),
 			"author_name":     event.AuthorName,
-			"note_type":       event.NoteType,
+			"note_type":       string(event.NoteType),
 			"content_preview": truncateString(event.Content, 100),
 		},
 		IsRealtime:      false,
@@ -661,17 +655,230 @@ func (c *noteAddedConsumer) ProcessMessage(ctx context.Context, delivery amqp.De
 		VisibleToAgent:  true,
 	}
 
-	_, err := c.parent.createContactEventUseCase.Execute(ctx, cmd)
-	if err != nil {
-		c.parent.logger.Error("Failed to create contact event",
+	return c.parent.createNoteEvent(ctx, cmd, event.NoteID)
+}
+
+// noteUpdatedConsumer processa eventos de nota editada
+type noteUpdatedConsumer struct {
+	parent *ContactEventConsumer
+}
+
+func (c *noteUpdatedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event note.NoteUpdatedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.parent.logger.Error("Failed to unmarshal NoteUpdatedEvent", zap.Error(err))
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"note_id":    event.NoteID.String(),
+		"updated_by": event.UpdatedBy.String(),
+		"changes":    event.Changes,
+	}
+	if event.OldContent != event.NewContent {
+		payload["content_preview"] = truncateString(event.NewContent, 100)
+	}
+
+	return c.parent.createNoteEvent(ctx, noteChangeCommand(event.ContactID, event.TenantID,
+		contact_event.EventTypeNoteUpdated, "Nota editada", payload), event.NoteID)
+}
+
+// notePinnedConsumer registra a fixação de nota como edição na timeline
+type notePinnedConsumer struct {
+	parent *ContactEventConsumer
+}
+
+func (c *notePinnedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event note.NotePinnedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.parent.logger.Error("Failed to unmarshal NotePinnedEvent", zap.Error(err))
+		return err
+	}
+
+	return c.parent.createNoteEvent(ctx, noteChangeCommand(event.ContactID, event.TenantID,
+		contact_event.EventTypeNoteUpdated, "Nota fixada", map[string]interface{}{
+			"note_id":    event.NoteID.String(),
+			"updated_by": event.PinnedBy.String(),
+			"changes":    []string{"pinned"},
+			"pinned":     true,
+		}), event.NoteID)
+}
+
+// noteUnpinnedConsumer registra a remoção da fixação como edição na timeline
+type noteUnpinnedConsumer struct {
+	parent *ContactEventConsumer
+}
+
+func (c *noteUnpinnedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event note.NoteUnpinnedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.parent.logger.Error("Failed to unmarshal NoteUnpinnedEvent", zap.Error(err))
+		return err
+	}
+
+	return c.parent.createNoteEvent(ctx, noteChangeCommand(event.ContactID, event.TenantID,
+		contact_event.EventTypeNoteUpdated, "Nota desafixada", map[string]interface{}{
+			"note_id":    event.NoteID.String(),
+			"updated_by": event.UnpinnedBy.String(),
+			"changes":    []string{"pinned"},
+			"pinned":     false,
+		}), event.NoteID)
+}
+
+// noteDeletedConsumer processa eventos de nota removida
+type noteDeletedConsumer struct {
+	parent *ContactEventConsumer
+}
+
+func (c *noteDeletedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event note.NoteDeletedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.parent.logger.Error("Failed to unmarshal NoteDeletedEvent", zap.Error(err))
+		return err
+	}
+
+	return c.parent.createNoteEvent(ctx, noteChangeCommand(event.ContactID, event.TenantID,
+		contact_event.EventTypeNoteDeleted, "Nota removida", map[string]interface{}{
+			"note_id":    event.NoteID.String(),
+			"deleted_by": event.DeletedBy.String(),
+		}), event.NoteID)
+}
+
+// noteChangeCommand monta o contact event interno de uma alteração de nota
+func noteChangeCommand(contactID uuid.UUID, tenantID, eventType, title string, payload map[string]interface{}) contacteventapp.CreateContactEventCommand {
+	return contacteventapp.CreateContactEventCommand{
+		ContactID:       contactID,
+		TenantID:        tenantID,
+		EventType:       eventType,
+		Category:        contact_event.CategoryNote,
+		Priority:        contact_event.PriorityLow,
+		Source:          contact_event.SourceSystem,
+		Title:           &title,
+		Payload:         payload,
+		IsRealtime:      false,
+		VisibleToClient: false, // Notas são internas por padrão
+		VisibleToAgent:  true,
+	}
+}
+
+// createNoteEvent grava o contact event de uma nota
+func (c *ContactEventConsumer) createNoteEvent(ctx context.Context, cmd contacteventapp.CreateContactEventCommand, noteID uuid.UUID) error {
+	if _, err := c.createContactEventUseCase.Execute(ctx, cmd); err != nil {
+		c.logger.Error("Failed to create contact event",
 			zap.Error(err),
-			zap.String("contact_id", event.ContactID.String()))
+			zap.String("contact_id", cmd.ContactID.String()),
+			zap.String("note_id", noteID.String()))
 		return err
 	}
 
-	c.parent.logger.Debug("Contact event created for NoteAdded",
-		zap.String("contact_id", event.ContactID.String()),
-		zap.String("note_id", event.NoteID.String()))
+	c.logger.Debug("Contact event created for note",
+		zap.String("event_type", cmd.EventType),
+		zap.String("contact_id", cmd.ContactID.String()),
+		zap.String("note_id", noteID.String()))
+	return nil
+}
+
+// taskCreatedConsumer registra na timeline a criação de tarefa ligada ao contato
+type taskCreatedConsumer struct {
+	parent *ContactEventConsumer
+}
+
+func (c *taskCreatedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event task.TaskCreatedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.parent.logger.Error("Failed to unmarshal TaskCreatedEvent", zap.Error(err))
+		return err
+	}
+	if event.ContactID == nil {
+		return nil // Tarefa sem contato não entra na timeline
+	}
+
+	payload := map[string]interface{}{
+		"task_id":    event.TaskID.String(),
+		"title":      event.Title,
+		"priority":   string(event.Priority),
+		"recurrence": string(event.Recurrence),
+		"created_by": event.CreatedBy.String(),
+	}
+	if event.DueAt != nil {
+		payload["due_at"] = event.DueAt
+		payload["timezone"] = event.Timezone
+	}
+	if event.AssigneeID != nil {
+		payload["assignee_id"] = event.AssigneeID.String()
+	}
+
+	return c.parent.createTaskEvent(ctx, taskEventCommand(*event.ContactID, event.SessionID, event.TenantID,
+		contact_event.EventTypeTaskCreated, "Tarefa criada", event.Title, payload), event.TaskID)
+}
+
+// taskCompletedConsumer registra na timeline a conclusão de tarefa ligada ao contato
+type taskCompletedConsumer struct {
+	parent *ContactEventConsumer
+}
+
+func (c *taskCompletedConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event task.TaskCompletedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.parent.logger.Error("Failed to unmarshal TaskCompletedEvent", zap.Error(err))
+		return err
+	}
+	if event.ContactID == nil {
+		return nil
+	}
+
+	payload := map[string]interface{}{
+		"task_id":      event.TaskID.String(),
+		"title":        event.Title,
+		"completed_by": event.CompletedBy.String(),
+		"completed_at": event.CompletedAt,
+	}
+	if event.DueAt != nil {
+		payload["due_at"] = event.DueAt
+		payload["late"] = event.CompletedAt.After(*event.DueAt)
+	}
+	if event.NextTaskID != nil {
+		payload["next_task_id"] = event.NextTaskID.String()
+	}
+
+	return c.parent.createTaskEvent(ctx, taskEventCommand(*event.ContactID, event.SessionID, event.TenantID,
+		contact_event.EventTypeTaskCompleted, "Tarefa concluída", event.Title, payload), event.TaskID)
+}
+
+// taskEventCommand monta o contact event interno de uma tarefa
+func taskEventCommand(contactID uuid.UUID, sessionID *uuid.UUID, tenantID, eventType, title, description string, payload map[string]interface{}) contacteventapp.CreateContactEventCommand {
+	description = truncateString(description, 100)
+	return contacteventapp.CreateContactEventCommand{
+		ContactID:       contactID,
+		SessionID:       sessionID,
+		TenantID:        tenantID,
+		EventType:       eventType,
+		Category:        contact_event.CategoryTask,
+		Priority:        contact_event.PriorityNormal,
+		Source:          contact_event.SourceAgent,
+		Title:           &title,
+		Description:     &description,
+		Payload:         payload,
+		IsRealtime:      true,
+		VisibleToClient: false, // Tarefas são internas
+		VisibleToAgent:  true,
+	}
+}
+
+// createTaskEvent grava o contact event de uma tarefa
+func (c *ContactEventConsumer) createTaskEvent(ctx context.Context, cmd contacteventapp.CreateContactEventCommand, taskID uuid.UUID) error {
+	if _, err := c.createContactEventUseCase.Execute(ctx, cmd); err != nil {
+		c.logger.Error("Failed to create contact event",
+			zap.Error(err),
+			zap.String("contact_id", cmd.ContactID.String()),
+			zap.String("task_id", taskID.String()))
+		return err
+	}
+
+	c.logger.Debug("Contact event created for task",
+		zap.String("event_type", cmd.EventType),
+		zap.String("contact_id", cmd.ContactID.String()),
+		zap.String("task_id", taskID.String()))
 	return nil
 }
 
@@ -0,0 +1,176 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	sequencecmd "github.com/ventros/crm/internal/application/commands/sequence"
+	contactlistapp "github.com/ventros/crm/internal/application/contact_list"
+	"github.com/ventros/crm/internal/domain/automation/sequence"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+	"go.uber.org/zap"
+)
+
+// ContactListRecalculationConsumer reavalia listas dinâmicas quando um contato muda.
+// Consome as filas de fan-out domain.events.<tipo>.contact_lists (ver domain_event_subscriptions.go).
+type ContactListRecalculationConsumer struct {
+	conn               *RabbitMQConnection
+	recalculateUseCase *contactlistapp.RecalculateContactListUseCase
+	logger             *zap.Logger
+}
+
+func NewContactListRecalculationConsumer(
+	conn *RabbitMQConnection,
+	recalculateUseCase *contactlistapp.RecalculateContactListUseCase,
+	logger *zap.Logger,
+) *ContactListRecalculationConsumer {
+	return &ContactListRecalculationConsumer{
+		conn:               conn,
+		recalculateUseCase: recalculateUseCase,
+		logger:             logger,
+	}
+}
+
+// Start inicia um consumer por tipo de evento de contato
+func (c *ContactListRecalculationConsumer) Start(ctx context.Context) error {
+	for _, eventType := range contactListRecalculationEvents {
+		queueName := SubscriberQueue(eventType, ContactListsSubscriber)
+		consumerTag := fmt.Sprintf("contact-list-recalculation-%s-%s", eventType, uuid.New().String()[:8])
+
+		if err := c.conn.StartConsumer(ctx, queueName, consumerTag, c, 10); err != nil {
+			c.logger.Error("Failed to start consumer",
+				zap.String("queue", queueName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	c.logger.Info("Contact list recalculation consumers started",
+		zap.Int("queues", len(contactListRecalculationEvents)))
+
+	return nil
+}
+
+// contactReferenceEvent extrai os contatos afetados de qualquer evento de contato
+type contactReferenceEvent struct {
+	ContactID        uuid.UUID
+	PrimaryContactID uuid.UUID   // contact.merged
+	MergedContactIDs []uuid.UUID // contact.merged
+}
+
+func (e contactReferenceEvent) contactIDs() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, 1+len(e.MergedContactIDs))
+	for _, id := range append([]uuid.UUID{e.ContactID, e.PrimaryContactID}, e.MergedContactIDs...) {
+		if id != uuid.Nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func (c *ContactListRecalculationConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event contactReferenceEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.logger.Error("Failed to unmarshal contact event", zap.Error(err))
+		return err
+	}
+
+	for _, contactID := range event.contactIDs() {
+		if err := c.recalculateUseCase.RecalculateForContact(ctx, contactID); err != nil {
+			c.logger.Error("Failed to recalculate contact lists",
+				zap.Error(err),
+				zap.String("contact_id", contactID.String()))
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ContactListSequenceTriggerConsumer inscreve contatos em sequences com trigger "list_joined"
+type ContactListSequenceTriggerConsumer struct {
+	conn                 *RabbitMQConnection
+	sequenceRepo         sequence.Repository
+	enrollContactHandler *sequencecmd.EnrollContactHandler
+	logger               *zap.Logger
+}
+
+func NewContactListSequenceTriggerConsumer(
+	conn *RabbitMQConnection,
+	sequenceRepo sequence.Repository,
+	enrollContactHandler *sequencecmd.EnrollContactHandler,
+	logger *zap.Logger,
+) *ContactListSequenceTriggerConsumer {
+	return &ContactListSequenceTriggerConsumer{
+		conn:                 conn,
+		sequenceRepo:         sequenceRepo,
+		enrollContactHandler: enrollContactHandler,
+		logger:               logger,
+	}
+}
+
+// Start inicia o consumo de domain.events.contact_list.list_joined
+func (c *ContactListSequenceTriggerConsumer) Start(ctx context.Context) error {
+	queueName := "domain.events.contact_list.list_joined"
+	consumerTag := fmt.Sprintf("contact-list-sequence-trigger-%s", uuid.New().String()[:8])
+
+	if err := c.conn.StartConsumer(ctx, queueName, consumerTag, c, 10); err != nil {
+		c.logger.Error("Failed to start consumer",
+			zap.String("queue", queueName),
+			zap.Error(err))
+		return err
+	}
+
+	c.logger.Info("Consumer started",
+		zap.String("queue", queueName),
+		zap.String("consumer_tag", consumerTag))
+
+	return nil
+}
+
+func (c *ContactListSequenceTriggerConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event contact_list.ContactJoinedListEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.logger.Error("Failed to unmarshal ContactJoinedListEvent", zap.Error(err))
+		return err
+	}
+
+	sequences, err := c.sequenceRepo.FindActiveByTriggerType(sequence.TriggerTypeListJoined)
+	if err != nil {
+		return fmt.Errorf("failed to find list_joined sequences: %w", err)
+	}
+
+	for _, seq := range sequences {
+		if seq.TenantID() != event.TenantID || !seq.IsTriggeredByListJoin(event.ContactListID) {
+			continue
+		}
+
+		_, err := c.enrollContactHandler.Handle(ctx, sequencecmd.EnrollContactCommand{
+			SequenceID: seq.ID(),
+			ContactID:  event.ContactID,
+			TenantID:   event.TenantID,
+		})
+		if err != nil {
+			// Reentrada na lista não deve gerar nova inscrição nem reprocessar a mensagem
+			if errors.Is(err, sequencecmd.ErrContactAlreadyEnrolled) || errors.Is(err, sequencecmd.ErrSequenceHasNoSteps) {
+				continue
+			}
+			c.logger.Error("Failed to enroll contact in sequence",
+				zap.Error(err),
+				zap.String("sequence_id", seq.ID().String()),
+				zap.String("contact_id", event.ContactID.String()))
+			return err
+		}
+
+		c.logger.Info("Contact enrolled in sequence from list_joined trigger",
+			zap.String("sequence_id", seq.ID().String()),
+			zap.String("contact_list_id", event.ContactListID.String()),
+			zap.String("contact_id", event.ContactID.String()))
+	}
+
+	return nil
+}
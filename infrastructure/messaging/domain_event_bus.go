@@ -255,6 +255,14 @@ func (bus *DomainEventBus) mapDomainToBusinessEvents(domainEvent string) []strin
 		return []string{"session.summarized"}
 	case "session.abandoned":
 		return []string{"session.abandoned"}
+	case "session.priority_changed":
+		return []string{"session.priority_changed"}
+
+	// Eventos de SLA
+	case "sla.warning":
+		return []string{"sla.warning"}
+	case "sla.breached":
+		return []string{"sla.breached"}
 
 	// Eventos de mensagem
 	case "message.created":
@@ -307,6 +315,36 @@ func (bus *DomainEventBus) mapDomainToBusinessEvents(domainEvent string) []strin
 		return []string{"note.deleted"}
 	case "note.pinned":
 		return []string{"note.pinned"}
+	case "note.unpinned":
+		return []string{"note.unpinned"}
+
+	// Eventos de tarefa
+	case "task.created":
+		return []string{"task.created"}
+	case "task.updated":
+		return []string{"task.updated"}
+	case "task.completed":
+		return []string{"task.completed"}
+	case "task.cancelled":
+		return []string{"task.cancelled"}
+	case "task.overdue":
+		return []string{"task.overdue"}
+	case "task.deleted":
+		return []string{"task.deleted"}
+
+	// Eventos de resposta pronta
+	case "canned_response.created":
+		return []string{"canned_response.created"}
+	case "canned_response.updated":
+		return []string{"canned_response.updated"}
+	case "canned_response.deleted":
+		return []string{"canned_response.deleted"}
+
+	// Eventos de API key (nunca carregam o token)
+	case "api_key.created":
+		return []string{"api_key.created"}
+	case "api_key.revoked":
+		return []string{"api_key.revoked"}
 
 	// Eventos de pipeline
 	case "pipeline.created":
@@ -379,6 +417,8 @@ func (bus *DomainEventBus) mapDomainToBusinessEvents(domainEvent string) []strin
 		return []string{"agent.permission_granted"}
 	case "agent.permission_revoked":
 		return []string{"agent.permission_revoked"}
+	case "agent.status_changed":
+		return []string{"agent.status_changed"}
 
 	// Eventos de contact list
 	case "contact_list.created":
@@ -450,6 +490,12 @@ func (bus *DomainEventBus) mapDomainToBusinessEvents(domainEvent string) []strin
 	case "message.ai.process_voice_requested":
 		return []string{"ai.process_voice_requested"}
 
+	// Eventos de contact list
+	case "contact_list.list_joined":
+		return []string{"contact_list.list_joined"}
+	case "contact_list.list_left":
+		return []string{"contact_list.list_left"}
+
 	// Eventos internos que não notificam webhooks
 	case "session.message_recorded":
 		return []string{}
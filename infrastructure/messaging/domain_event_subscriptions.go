@@ -0,0 +1,134 @@
+package messaging
+
+import "fmt"
+
+// Cada tipo de evento é publicado na fila domain.events.<tipo>, consumida de forma competitiva.
+// Quando outro bounded context precisa do mesmo evento, ele se registra aqui e o outbox
+// publica uma cópia em domain.events.<tipo>.<subscriber> (fan-out).
+
+// ContactListsSubscriber recalcula listas dinâmicas quando dados do contato mudam
+const ContactListsSubscriber = "contact_lists"
+
+// contactListRecalculationEvents são os eventos que podem alterar a composição de listas dinâmicas
+var contactListRecalculationEvents = []string{
+	"contact.created",
+	"contact.updated",
+	"contact.deleted",
+	"contact.merged",
+	"contact.name_changed",
+	"contact.email_set",
+	"contact.phone_set",
+	"contact.external_id_set",
+	"contact.language_changed",
+	"contact.timezone_set",
+	"contact.source_channel_set",
+	"contact.tag_added",
+	"contact.tag_removed",
+	"contact.tags_cleared",
+	"contact.interaction_recorded",
+	"contact.pipeline_status_changed",
+	"contact.status_changed",
+	"contact.entered_pipeline",
+	"contact.exited_pipeline",
+}
+
+// AgentSessionsSubscriber registra participações de agentes em sessões (agent_sessions)
+const AgentSessionsSubscriber = "agent_sessions"
+
+// agentParticipationEvents abrem e encerram participações de agentes
+var agentParticipationEvents = []string{
+	"session.agent_assigned",
+	"session.ended",
+}
+
+// SessionRoutingSubscriber atribui agentes automaticamente a sessões novas
+const SessionRoutingSubscriber = "session_routing"
+
+// sessionRoutingEvents disparam o roteamento automático
+var sessionRoutingEvents = []string{
+	"session.started",
+}
+
+// SessionSLASubscriber abre e para os relógios de SLA das sessões
+const SessionSLASubscriber = "session_sla"
+
+// sessionSLAEvents movimentam os relógios de SLA
+var sessionSLAEvents = []string{
+	"session.started",
+	"session.message_recorded",
+	"session.ended",
+	"session.priority_changed",
+}
+
+// OutOfOfficeSubscriber responde mensagens recebidas fora do expediente do projeto
+const OutOfOfficeSubscriber = "out_of_office"
+
+// outOfOfficeEvents disparam a resposta de ausência
+var outOfOfficeEvents = []string{
+	"message.created",
+}
+
+// SessionQueuesSubscriber encerra a espera nas filas quando a sessão termina ou recebe agente por fora
+const SessionQueuesSubscriber = "session_queues"
+
+// sessionQueueEvents tiram a sessão da fila de atendimento
+var sessionQueueEvents = []string{
+	"session.agent_assigned",
+	"session.ended",
+}
+
+// UsageMeteringSubscriber contabiliza as ações medidas pelos limites do plano
+const UsageMeteringSubscriber = "usage_metering"
+
+// usageMeteringEvents ações que consomem o plano (mensagens enviadas, recursos criados, IA, broadcasts)
+var usageMeteringEvents = []string{
+	"message.created",
+	"contact.created",
+	"channel.created",
+	"agent.created",
+	"broadcast.started",
+	"message.ai.process_image_requested",
+	"message.ai.process_video_requested",
+	"message.ai.process_audio_requested",
+	"message.ai.process_voice_requested",
+}
+
+var domainEventSubscriptions = map[string][]string{
+	ContactListsSubscriber:   contactListRecalculationEvents,
+	AgentSessionsSubscriber:  agentParticipationEvents,
+	SessionRoutingSubscriber: sessionRoutingEvents,
+	SessionSLASubscriber:     sessionSLAEvents,
+	OutOfOfficeSubscriber:    outOfOfficeEvents,
+	SessionQueuesSubscriber:  sessionQueueEvents,
+	UsageMeteringSubscriber:  usageMeteringEvents,
+}
+
+// SubscriberQueue retorna a fila de fan-out de um subscriber para um tipo de evento
+func SubscriberQueue(eventType, subscriber string) string {
+	return fmt.Sprintf("domain.events.%s.%s", eventType, subscriber)
+}
+
+// DomainEventQueues retorna todas as filas que devem receber um tipo de evento
+func DomainEventQueues(eventType string) []string {
+	queues := []string{fmt.Sprintf("domain.events.%s", eventType)}
+	for subscriber, eventTypes := range domainEventSubscriptions {
+		for _, et := range eventTypes {
+			if et == eventType {
+				queues = append(queues, SubscriberQueue(eventType, subscriber))
+				break
+			}
+		}
+	}
+	return queues
+}
+
+// subscriberQueues lista todas as filas de fan-out (declaradas no setup do RabbitMQ)
+func subscriberQueues() []string {
+	var queues []string
+	for subscriber, eventTypes := range domainEventSubscriptions {
+		for _, et := range eventTypes {
+			queues = append(queues, SubscriberQueue(et, subscriber))
+		}
+	}
+	return queues
+}
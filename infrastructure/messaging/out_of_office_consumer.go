@@ -0,0 +1,75 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	businesshoursapp "github.com/ventros/crm/internal/application/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/message"
+	"go.uber.org/zap"
+)
+
+// OutOfOfficeConsumer envia a resposta de ausência para mensagens recebidas fora do expediente.
+// Consome a fila de fan-out domain.events.message.created.out_of_office.
+type OutOfOfficeConsumer struct {
+	conn    *RabbitMQConnection
+	useCase *businesshoursapp.OutOfOfficeUseCase
+	logger  *zap.Logger
+}
+
+func NewOutOfOfficeConsumer(
+	conn *RabbitMQConnection,
+	useCase *businesshoursapp.OutOfOfficeUseCase,
+	logger *zap.Logger,
+) *OutOfOfficeConsumer {
+	return &OutOfOfficeConsumer{
+		conn:    conn,
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+func (c *OutOfOfficeConsumer) Start(ctx context.Context) error {
+	for _, eventType := range outOfOfficeEvents {
+		queueName := SubscriberQueue(eventType, OutOfOfficeSubscriber)
+		consumerTag := fmt.Sprintf("out-of-office-%s-%s", eventType, uuid.New().String()[:8])
+
+		if err := c.conn.StartConsumer(ctx, queueName, consumerTag, c, 10); err != nil {
+			c.logger.Error("Failed to start consumer",
+				zap.String("queue", queueName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	c.logger.Info("Out-of-office consumer started")
+	return nil
+}
+
+func (c *OutOfOfficeConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event message.MessageCreatedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.logger.Error("Failed to unmarshal MessageCreatedEvent", zap.Error(err))
+		return err
+	}
+	if event.FromMe {
+		return nil
+	}
+
+	sent, err := c.useCase.HandleInboundMessage(ctx, event.MessageID)
+	if err != nil {
+		c.logger.Error("Failed to send out-of-office reply",
+			zap.String("message_id", event.MessageID.String()),
+			zap.Error(err))
+		return err
+	}
+	if sent {
+		c.logger.Info("Out-of-office reply sent",
+			zap.String("message_id", event.MessageID.String()),
+			zap.String("contact_id", event.ContactID.String()))
+	}
+	return nil
+}
@@ -188,12 +188,13 @@ func (p *PostgresNotifyOutboxProcessor) processEvent(ctx context.Context, event
 		return fmt.Errorf("failed to mark as processing: %w", err)
 	}
 
-	// Publica no RabbitMQ
-	queue := fmt.Sprintf("domain.events.%s", event.EventType)
-	if err := p.eventPublisher.PublishRaw(ctx, queue, event.EventData); err != nil {
-		// Marca como falho
-		p.outboxRepo.MarkAsFailed(ctx, event.EventID, err.Error())
-		return fmt.Errorf("failed to publish: %w", err)
+	// Publica no RabbitMQ (fila principal + filas de fan-out dos subscribers)
+	for _, queue := range DomainEventQueues(event.EventType) {
+		if err := p.eventPublisher.PublishRaw(ctx, queue, event.EventData); err != nil {
+			// Marca como falho
+			p.outboxRepo.MarkAsFailed(ctx, event.EventID, err.Error())
+			return fmt.Errorf("failed to publish to %s: %w", queue, err)
+		}
 	}
 
 	// Marca como processado
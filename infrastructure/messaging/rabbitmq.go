@@ -652,11 +652,23 @@ func (r *RabbitMQConnection) SetupAllQueues() error {
 
 		// Note events
 		"domain.events.note.added",
+		"domain.events.note.updated",
+		"domain.events.note.pinned",
+		"domain.events.note.unpinned",
+		"domain.events.note.deleted",
+
+		// Task events
+		"domain.events.task.created",
+		"domain.events.task.completed",
 
 		// Channel events (Event-Driven + Strategy Pattern)
 		"domain.events.channel.activation.requested",
 		"domain.events.channel.history_import.requested",
+
+		// Contact list events
+		"domain.events.contact_list.list_joined",
 	}
+	domainEventQueues = append(domainEventQueues, subscriberQueues()...)
 
 	for _, queue := range domainEventQueues {
 		if err := r.DeclareQueueWithDLQ(queue, 3); err != nil {
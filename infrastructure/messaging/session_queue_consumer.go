@@ -0,0 +1,99 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	teamapp "github.com/ventros/crm/internal/application/team"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+// SessionQueueConsumer tira das filas as sessões encerradas (abandono) ou atribuídas por fora da fila.
+// Consome as filas de fan-out domain.events.<tipo>.session_queues (ver domain_event_subscriptions.go).
+type SessionQueueConsumer struct {
+	conn    *RabbitMQConnection
+	useCase *teamapp.QueueSessionsUseCase
+	logger  *zap.Logger
+}
+
+func NewSessionQueueConsumer(
+	conn *RabbitMQConnection,
+	useCase *teamapp.QueueSessionsUseCase,
+	logger *zap.Logger,
+) *SessionQueueConsumer {
+	return &SessionQueueConsumer{
+		conn:    conn,
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Start inicia um consumer por tipo de evento
+func (c *SessionQueueConsumer) Start(ctx context.Context) error {
+	handlers := map[string]Consumer{
+		"session.agent_assigned": &agentAssignedQueueHandler{c},
+		"session.ended":          &sessionEndedQueueHandler{c},
+	}
+
+	for eventType, handler := range handlers {
+		queueName := SubscriberQueue(eventType, SessionQueuesSubscriber)
+		consumerTag := fmt.Sprintf("session-queues-%s-%s", eventType, uuid.New().String()[:8])
+
+		if err := c.conn.StartConsumer(ctx, queueName, consumerTag, handler, 10); err != nil {
+			c.logger.Error("Failed to start consumer",
+				zap.String("queue", queueName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	c.logger.Info("Session queue consumers started")
+	return nil
+}
+
+type agentAssignedQueueHandler struct {
+	consumer *SessionQueueConsumer
+}
+
+func (h *agentAssignedQueueHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.AgentAssignedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal AgentAssignedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.useCase.HandleAgentAssigned(ctx, event.SessionID, event.AgentID); err != nil {
+		h.consumer.logger.Error("Failed to close queue wait after assignment",
+			zap.String("session_id", event.SessionID.String()),
+			zap.String("agent_id", event.AgentID.String()),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+type sessionEndedQueueHandler struct {
+	consumer *SessionQueueConsumer
+}
+
+func (h *sessionEndedQueueHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.SessionEndedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal SessionEndedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.useCase.HandleSessionEnded(ctx, event.SessionID); err != nil {
+		h.consumer.logger.Error("Failed to mark queued session as abandoned",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	routingapp "github.com/ventros/crm/internal/application/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+// SessionRoutingConsumer roteia sessões novas para agentes a partir de session.started.
+// Consome a fila de fan-out domain.events.session.started.session_routing (ver domain_event_subscriptions.go).
+type SessionRoutingConsumer struct {
+	conn    *RabbitMQConnection
+	useCase *routingapp.RouteSessionUseCase
+	logger  *zap.Logger
+}
+
+func NewSessionRoutingConsumer(
+	conn *RabbitMQConnection,
+	useCase *routingapp.RouteSessionUseCase,
+	logger *zap.Logger,
+) *SessionRoutingConsumer {
+	return &SessionRoutingConsumer{
+		conn:    conn,
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// Start inicia o consumer
+func (c *SessionRoutingConsumer) Start(ctx context.Context) error {
+	queueName := SubscriberQueue("session.started", SessionRoutingSubscriber)
+	consumerTag := fmt.Sprintf("session-routing-%s", uuid.New().String()[:8])
+
+	if err := c.conn.StartConsumer(ctx, queueName, consumerTag, c, 10); err != nil {
+		c.logger.Error("Failed to start consumer",
+			zap.String("queue", queueName),
+			zap.Error(err))
+		return err
+	}
+
+	c.logger.Info("Session routing consumer started")
+	return nil
+}
+
+func (c *SessionRoutingConsumer) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.SessionStartedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		c.logger.Error("Failed to unmarshal SessionStartedEvent", zap.Error(err))
+		return err
+	}
+
+	result, err := c.useCase.Execute(ctx, event.SessionID)
+	if err != nil {
+		c.logger.Error("Failed to route session",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+
+	c.logger.Debug("Session routed",
+		zap.String("session_id", event.SessionID.String()),
+		zap.String("outcome", string(result.Outcome)),
+		zap.String("agent_id", result.AgentID.String()),
+		zap.String("strategy", string(result.Strategy)),
+		zap.String("reason", result.Reason))
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	slaapp "github.com/ventros/crm/internal/application/sla"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+// SessionSLAConsumer movimenta os relógios de SLA a partir dos eventos da sessão.
+// Consome as filas de fan-out domain.events.<tipo>.session_sla (ver domain_event_subscriptions.go).
+type SessionSLAConsumer struct {
+	conn    *RabbitMQConnection
+	tracker *slaapp.TrackSessionUseCase
+	logger  *zap.Logger
+}
+
+func NewSessionSLAConsumer(
+	conn *RabbitMQConnection,
+	tracker *slaapp.TrackSessionUseCase,
+	logger *zap.Logger,
+) *SessionSLAConsumer {
+	return &SessionSLAConsumer{
+		conn:    conn,
+		tracker: tracker,
+		logger:  logger,
+	}
+}
+
+// Start inicia um consumer por tipo de evento
+func (c *SessionSLAConsumer) Start(ctx context.Context) error {
+	handlers := map[string]Consumer{
+		"session.started":          &sessionStartedSLAHandler{c},
+		"session.message_recorded": &messageRecordedSLAHandler{c},
+		"session.ended":            &sessionEndedSLAHandler{c},
+		"session.priority_changed": &priorityChangedSLAHandler{c},
+	}
+
+	for eventType, handler := range handlers {
+		queueName := SubscriberQueue(eventType, SessionSLASubscriber)
+		consumerTag := fmt.Sprintf("session-sla-%s-%s", eventType, uuid.New().String()[:8])
+
+		if err := c.conn.StartConsumer(ctx, queueName, consumerTag, handler, 10); err != nil {
+			c.logger.Error("Failed to start consumer",
+				zap.String("queue", queueName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	c.logger.Info("Session SLA consumers started")
+	return nil
+}
+
+type sessionStartedSLAHandler struct {
+	consumer *SessionSLAConsumer
+}
+
+func (h *sessionStartedSLAHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.SessionStartedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal SessionStartedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.tracker.OnSessionStarted(ctx, event.SessionID); err != nil {
+		h.consumer.logger.Error("Failed to start SLA clocks",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+type messageRecordedSLAHandler struct {
+	consumer *SessionSLAConsumer
+}
+
+func (h *messageRecordedSLAHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.MessageRecordedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal MessageRecordedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.tracker.OnMessageRecorded(ctx, event.SessionID, event.FromContact, event.RecordedAt); err != nil {
+		h.consumer.logger.Error("Failed to update SLA clocks",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+type sessionEndedSLAHandler struct {
+	consumer *SessionSLAConsumer
+}
+
+func (h *sessionEndedSLAHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.SessionEndedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal SessionEndedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.tracker.OnSessionEnded(ctx, event.SessionID, event.EndedAt); err != nil {
+		h.consumer.logger.Error("Failed to stop SLA clocks",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+type priorityChangedSLAHandler struct {
+	consumer *SessionSLAConsumer
+}
+
+func (h *priorityChangedSLAHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	var event session.SessionPriorityChangedEvent
+	if err := json.Unmarshal(delivery.Body, &event); err != nil {
+		h.consumer.logger.Error("Failed to unmarshal SessionPriorityChangedEvent", zap.Error(err))
+		return err
+	}
+
+	if err := h.consumer.tracker.OnPriorityChanged(ctx, event.SessionID); err != nil {
+		h.consumer.logger.Error("Failed to reschedule SLA clocks",
+			zap.String("session_id", event.SessionID.String()),
+			zap.Error(err))
+		return err
+	}
+	return nil
+}
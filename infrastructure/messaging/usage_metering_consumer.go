@@ -0,0 +1,228 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	amqp "github.com/rabbitmq/amqp091-go"
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	"github.com/ventros/crm/internal/domain/automation/broadcast"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/channel"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/message"
+	"go.uber.org/zap"
+)
+
+// UsageMeteringConsumer contabiliza no plano as ações medidas. Consome as filas de fan-out
+// domain.events.<tipo>.usage_metering (ver domain_event_subscriptions.go); a origem de cada
+// ação (mensagem, contato, broadcast...) torna a contagem idempotente a reentregas do outbox.
+type UsageMeteringConsumer struct {
+	conn    *RabbitMQConnection
+	useCase *billingapp.RecordUsageUseCase
+	logger  *zap.Logger
+}
+
+func NewUsageMeteringConsumer(
+	conn *RabbitMQConnection,
+	useCase *billingapp.RecordUsageUseCase,
+	logger *zap.Logger,
+) *UsageMeteringConsumer {
+	return &UsageMeteringConsumer{
+		conn:    conn,
+		useCase: useCase,
+		logger:  logger,
+	}
+}
+
+// usageDecoder extrai do evento o consumo; nil quando o evento não consome o plano
+type usageDecoder func(body []byte) (*billingapp.RecordUsageCommand, error)
+
+// Start inicia um consumer por tipo de evento
+func (c *UsageMeteringConsumer) Start(ctx context.Context) error {
+	decoders := map[string]usageDecoder{
+		"message.created":                    decodeMessageSentUsage,
+		"contact.created":                    decodeContactUsage,
+		"channel.created":                    decodeChannelUsage,
+		"agent.created":                      decodeAgentUsage,
+		"broadcast.started":                  decodeBroadcastUsage,
+		"message.ai.process_image_requested": decodeAIImageUsage,
+		"message.ai.process_video_requested": decodeAIVideoUsage,
+		"message.ai.process_audio_requested": decodeAIAudioUsage,
+		"message.ai.process_voice_requested": decodeAIVoiceUsage,
+	}
+
+	for eventType, decode := range decoders {
+		queueName := SubscriberQueue(eventType, UsageMeteringSubscriber)
+		consumerTag := fmt.Sprintf("usage-metering-%s-%s", eventType, uuid.New().String()[:8])
+		handler := &usageMeteringHandler{consumer: c, eventType: eventType, decode: decode}
+
+		if err := c.conn.StartConsumer(ctx, queueName, consumerTag, handler, 10); err != nil {
+			c.logger.Error("Failed to start consumer",
+				zap.String("queue", queueName),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	c.logger.Info("Usage metering consumers started")
+	return nil
+}
+
+type usageMeteringHandler struct {
+	consumer  *UsageMeteringConsumer
+	eventType string
+	decode    usageDecoder
+}
+
+func (h *usageMeteringHandler) ProcessMessage(ctx context.Context, delivery amqp.Delivery) error {
+	cmd, err := h.decode(delivery.Body)
+	if err != nil {
+		h.consumer.logger.Error("Failed to unmarshal event for usage metering",
+			zap.String("event_type", h.eventType),
+			zap.Error(err))
+		return err
+	}
+	if cmd == nil {
+		return nil
+	}
+
+	if err := h.consumer.useCase.Execute(ctx, *cmd); err != nil {
+		h.consumer.logger.Error("Failed to record usage",
+			zap.String("event_type", h.eventType),
+			zap.String("metric", string(cmd.Metric)),
+			zap.String("source_id", cmd.SourceID),
+			zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Só mensagens enviadas pela conta consomem a franquia de mensagens
+func decodeMessageSentUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event message.MessageCreatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	if !event.FromMe {
+		return nil, nil
+	}
+	return &billingapp.RecordUsageCommand{
+		ProjectID:  event.ProjectID,
+		Metric:     billing.MetricMessages,
+		SourceID:   event.MessageID.String(),
+		Quantity:   1,
+		OccurredAt: event.CreatedAt,
+	}, nil
+}
+
+func decodeContactUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event contact.ContactCreatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &billingapp.RecordUsageCommand{
+		ProjectID:  event.ProjectID,
+		Metric:     billing.MetricContacts,
+		SourceID:   event.ContactID.String(),
+		Quantity:   1,
+		OccurredAt: event.CreatedAt,
+	}, nil
+}
+
+func decodeChannelUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event channel.ChannelCreatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &billingapp.RecordUsageCommand{
+		ProjectID:  event.ProjectID,
+		Metric:     billing.MetricChannels,
+		SourceID:   event.ChannelID.String(),
+		Quantity:   1,
+		OccurredAt: event.CreatedAt,
+	}, nil
+}
+
+// Só agentes humanos ocupam assento do plano
+func decodeAgentUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event agent.AgentCreatedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	if event.Type != agent.AgentTypeHuman {
+		return nil, nil
+	}
+	return &billingapp.RecordUsageCommand{
+		ProjectID: event.ProjectID,
+		Metric:    billing.MetricAgents,
+		SourceID:  event.AgentID.String(),
+		Quantity:  1,
+	}, nil
+}
+
+func decodeBroadcastUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event broadcast.BroadcastStartedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return &billingapp.RecordUsageCommand{
+		TenantID: event.TenantID,
+		Metric:   billing.MetricBroadcasts,
+		SourceID: event.BroadcastID.String(),
+		Quantity: 1,
+	}, nil
+}
+
+func decodeAIImageUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event message.AIProcessImageRequestedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return aiUsage("image", event.MessageID, event.ProjectID, 0, event.RequestedAt), nil
+}
+
+func decodeAIVideoUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event message.AIProcessVideoRequestedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return aiUsage("video", event.MessageID, event.ProjectID, event.Duration, event.RequestedAt), nil
+}
+
+func decodeAIAudioUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event message.AIProcessAudioRequestedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return aiUsage("audio", event.MessageID, event.ProjectID, event.Duration, event.RequestedAt), nil
+}
+
+func decodeAIVoiceUsage(body []byte) (*billingapp.RecordUsageCommand, error) {
+	var event message.AIProcessVoiceRequestedEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, err
+	}
+	return aiUsage("voice", event.MessageID, event.ProjectID, event.Duration, event.RequestedAt), nil
+}
+
+// aiUsage minutos de IA do processamento: a duração da mídia arredondada para cima, mínimo de um
+// minuto (imagens e mídias sem duração)
+func aiUsage(kind string, messageID, projectID uuid.UUID, durationSeconds int, requestedAt time.Time) *billingapp.RecordUsageCommand {
+	minutes := int64((durationSeconds + 59) / 60)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return &billingapp.RecordUsageCommand{
+		ProjectID:  projectID,
+		Metric:     billing.MetricAIMinutes,
+		SourceID:   kind + ":" + messageID.String(),
+		Quantity:   minutes,
+		OccurredAt: requestedAt,
+	}
+}
@@ -0,0 +1,77 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrIssuerMismatch o documento de discovery declara outro issuer (OIDC Discovery §4.3)
+var ErrIssuerMismatch = errors.New("oidc: discovery issuer does not match the configured issuer")
+
+// Discovery campos usados do documento /.well-known/openid-configuration
+type Discovery struct {
+	Issuer                        string   `json:"issuer"`
+	AuthorizationEndpoint         string   `json:"authorization_endpoint"`
+	TokenEndpoint                 string   `json:"token_endpoint"`
+	UserinfoEndpoint              string   `json:"userinfo_endpoint"`
+	JWKSURI                       string   `json:"jwks_uri"`
+	ScopesSupported               []string `json:"scopes_supported"`
+	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported"`
+}
+
+// Discover busca e valida o documento de discovery do issuer
+func Discover(ctx context.Context, client *http.Client, issuer string) (*Discovery, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	issuer = strings.TrimSuffix(issuer, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, issuer+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid issuer %q: %w", issuer, err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to fetch discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to read discovery document: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery returned status %d", resp.StatusCode)
+	}
+
+	var doc Discovery
+	if err := json.Unmarshal(body, &doc); err != nil {
+		return nil, fmt.Errorf("oidc: invalid discovery document: %w", err)
+	}
+	if strings.TrimSuffix(doc.Issuer, "/") != issuer {
+		return nil, fmt.Errorf("%w: expected %s, got %s", ErrIssuerMismatch, issuer, doc.Issuer)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery document is missing authorization_endpoint, token_endpoint or jwks_uri")
+	}
+	return &doc, nil
+}
+
+// SupportsPKCE S256 anunciado ou lista ausente (vários provedores suportam sem anunciar)
+func (d *Discovery) SupportsPKCE() bool {
+	if len(d.CodeChallengeMethodsSupported) == 0 {
+		return true
+	}
+	for _, method := range d.CodeChallengeMethodsSupported {
+		if method == "S256" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+// Package oidc implementa o lado relying party do OpenID Connect: discovery, authorization
+// code com PKCE (S256) e validação do id_token (assinatura via JWKS, issuer, audience, nonce).
+// Funciona com qualquer provedor compatível (Keycloak, Google Workspace, Azure AD, Okta).
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/oauth2"
+)
+
+var (
+	ErrMissingIDToken         = errors.New("oidc: token response has no id_token")
+	ErrInvalidIDToken         = errors.New("oidc: invalid id_token")
+	ErrNonceMismatch          = errors.New("oidc: id_token nonce does not match")
+	ErrEmailDomainNotAllowed  = errors.New("oidc: email domain not allowed for this provider")
+	ErrPKCENotSupported       = errors.New("oidc: provider does not support PKCE S256")
+	ErrMissingProviderSetting = errors.New("oidc: provider name, issuer, client_id and redirect_url are required")
+)
+
+// clockSkew tolerância para exp/iat/nbf entre o provedor e a API
+const clockSkew = time.Minute
+
+// signingMethods algoritmos aceitos no id_token (nunca HS* nem none)
+var signingMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// Config provedor OIDC configurado
+type Config struct {
+	// Name identificador usado nas rotas (/auth/oidc/{name}/login)
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes default: openid email profile
+	Scopes []string
+	// Audience aud esperado no id_token (default: ClientID)
+	Audience string
+	// AllowedDomains restringe o domínio do email (vazio = qualquer domínio)
+	AllowedDomains []string
+	// TrustEmail trata o email como verificado mesmo sem email_verified (ex: Azure AD de um tenant)
+	TrustEmail bool
+}
+
+// Identity usuário autenticado pelo provedor
+type Identity struct {
+	Provider      string
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// idTokenClaims claims lidos do id_token
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string      `json:"nonce"`
+	AuthorizedParty   string      `json:"azp"`
+	Email             string      `json:"email"`
+	EmailVerified     interface{} `json:"email_verified"` // bool, ou "true" em alguns provedores
+	Name              string      `json:"name"`
+	PreferredUsername string      `json:"preferred_username"`
+}
+
+// Provider relying party de um provedor OIDC
+type Provider struct {
+	config     Config
+	discovery  *Discovery
+	oauth2     oauth2.Config
+	jwks       *keyfunc.JWKS
+	httpClient *http.Client
+}
+
+// NewProvider faz o discovery e carrega o JWKS do provedor. httpClient nil usa http.DefaultClient.
+func NewProvider(ctx context.Context, config Config, httpClient *http.Client) (*Provider, error) {
+	if config.Name == "" || config.Issuer == "" || config.ClientID == "" || config.RedirectURL == "" {
+		return nil, ErrMissingProviderSetting
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"openid", "email", "profile"}
+	}
+	if config.Audience == "" {
+		config.Audience = config.ClientID
+	}
+	for i, domain := range config.AllowedDomains {
+		config.AllowedDomains[i] = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(domain), "@"))
+	}
+
+	discovery, err := Discover(ctx, httpClient, config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+	if !discovery.SupportsPKCE() {
+		return nil, ErrPKCENotSupported
+	}
+
+	// Keys are refreshed hourly and whenever an unknown kid shows up (key rotation)
+	jwks, err := keyfunc.Get(discovery.JWKSURI, keyfunc.Options{
+		Client:            httpClient,
+		Ctx:               context.Background(),
+		RefreshInterval:   time.Hour,
+		RefreshRateLimit:  time.Minute,
+		RefreshTimeout:    10 * time.Second,
+		RefreshUnknownKID: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oidc: failed to load JWKS for %s: %w", config.Name, err)
+	}
+
+	return &Provider{
+		config:    config,
+		discovery: discovery,
+		oauth2: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Scopes:       config.Scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  discovery.AuthorizationEndpoint,
+				TokenURL: discovery.TokenEndpoint,
+			},
+		},
+		jwks:       jwks,
+		httpClient: httpClient,
+	}, nil
+}
+
+// Name identificador do provedor
+func (p *Provider) Name() string { return p.config.Name }
+
+// Issuer issuer validado no discovery
+func (p *Provider) Issuer() string { return p.discovery.Issuer }
+
+// AuthCodeURL URL de autorização com state, nonce e o challenge S256 do verifier
+func (p *Provider) AuthCodeURL(state, nonce, verifier string) string {
+	return p.oauth2.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(verifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	)
+}
+
+// Exchange troca o code pelos tokens (enviando o verifier) e valida o id_token
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Identity, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	token, err := p.oauth2.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("oidc: code exchange failed: %w", err)
+	}
+	rawIDToken, _ := token.Extra("id_token").(string)
+	if rawIDToken == "" {
+		return nil, ErrMissingIDToken
+	}
+	return p.VerifyIDToken(rawIDToken, nonce)
+}
+
+// VerifyIDToken valida assinatura, issuer, audience, validade e nonce do id_token
+func (p *Provider) VerifyIDToken(rawIDToken, nonce string) (*Identity, error) {
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(rawIDToken, claims, p.jwks.Keyfunc,
+		jwt.WithValidMethods(signingMethods),
+		jwt.WithIssuer(p.discovery.Issuer),
+		jwt.WithAudience(p.config.Audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("%w: missing sub", ErrInvalidIDToken)
+	}
+	// Com várias audiences, azp precisa ser o nosso client (OIDC Core §3.1.3.7)
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != "" && claims.AuthorizedParty != p.config.ClientID {
+		return nil, fmt.Errorf("%w: unexpected azp %s", ErrInvalidIDToken, claims.AuthorizedParty)
+	}
+	if nonce == "" || claims.Nonce != nonce {
+		return nil, ErrNonceMismatch
+	}
+
+	identity := &Identity{
+		Provider:      p.config.Name,
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: p.config.TrustEmail || isTrue(claims.EmailVerified),
+		Name:          claims.Name,
+	}
+	if identity.Email == "" && strings.Contains(claims.PreferredUsername, "@") {
+		// Azure AD sem o claim email: o UPN só vale como email se o tenant for confiável
+		identity.Email = strings.ToLower(claims.PreferredUsername)
+		identity.EmailVerified = p.config.TrustEmail
+	}
+	if !p.allowsEmail(identity.Email) {
+		return nil, ErrEmailDomainNotAllowed
+	}
+	return identity, nil
+}
+
+// Close encerra o refresh do JWKS em background
+func (p *Provider) Close() {
+	p.jwks.EndBackground()
+}
+
+func (p *Provider) allowsEmail(email string) bool {
+	if len(p.config.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := email[at+1:]
+	for _, allowed := range p.config.AllowedDomains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func isTrue(value interface{}) bool {
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
@@ -0,0 +1,181 @@
+package oidc
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/oidc/oidctest"
+	"golang.org/x/oauth2"
+)
+
+const redirectURL = "https://crm.example.com/api/v1/auth/oidc/test/callback"
+
+func newTestProvider(t *testing.T, mock *oidctest.Provider, mutate func(*Config)) *Provider {
+	t.Helper()
+	config := Config{
+		Name:         "test",
+		Issuer:       mock.Issuer(),
+		ClientID:     mock.ClientID,
+		ClientSecret: mock.ClientSecret,
+		RedirectURL:  redirectURL,
+	}
+	if mutate != nil {
+		mutate(&config)
+	}
+	p, err := NewProvider(context.Background(), config, mock.Client())
+	require.NoError(t, err)
+	t.Cleanup(p.Close)
+	return p
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	mock := oidctest.NewProvider("crm", "s3cret")
+	defer mock.Close()
+	p := newTestProvider(t, mock, nil)
+	ctx := context.Background()
+
+	verifier := oauth2.GenerateVerifier()
+	authURL := p.AuthCodeURL("state-1", "nonce-1", verifier)
+
+	parsed, err := url.Parse(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+	assert.Equal(t, "nonce-1", parsed.Query().Get("nonce"))
+	assert.Equal(t, "openid email profile", parsed.Query().Get("scope"))
+	assert.NotContains(t, authURL, verifier, "only the challenge leaves the server")
+
+	code, state, err := mock.Authorize(authURL)
+	require.NoError(t, err)
+	assert.Equal(t, "state-1", state)
+
+	identity, err := p.Exchange(ctx, code, verifier, "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "test", identity.Provider)
+	assert.Equal(t, mock.Issuer(), identity.Issuer)
+	assert.Equal(t, "user-1", identity.Subject)
+	assert.Equal(t, "ana@example.com", identity.Email)
+	assert.True(t, identity.EmailVerified)
+
+	_, err = p.Exchange(ctx, code, verifier, "nonce-1")
+	assert.Error(t, err, "codes are single use")
+}
+
+func TestProvider_RejectsBadExchanges(t *testing.T) {
+	mock := oidctest.NewProvider("crm", "s3cret")
+	defer mock.Close()
+	p := newTestProvider(t, mock, nil)
+	ctx := context.Background()
+
+	login := func(t *testing.T) (string, string) {
+		verifier := oauth2.GenerateVerifier()
+		code, _, err := mock.Authorize(p.AuthCodeURL("state", "nonce", verifier))
+		require.NoError(t, err)
+		return code, verifier
+	}
+
+	t.Run("wrong PKCE verifier", func(t *testing.T) {
+		code, _ := login(t)
+		_, err := p.Exchange(ctx, code, oauth2.GenerateVerifier(), "nonce")
+		assert.Error(t, err)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		code, verifier := login(t)
+		_, err := p.Exchange(ctx, code, verifier, "other-nonce")
+		assert.ErrorIs(t, err, ErrNonceMismatch)
+	})
+
+	t.Run("token for another audience", func(t *testing.T) {
+		mock.TokenClaims = func(c jwt.MapClaims) { c["aud"] = "other-client" }
+		defer func() { mock.TokenClaims = nil }()
+		code, verifier := login(t)
+		_, err := p.Exchange(ctx, code, verifier, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		mock.TokenClaims = func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Hour).Unix() }
+		defer func() { mock.TokenClaims = nil }()
+		code, verifier := login(t)
+		_, err := p.Exchange(ctx, code, verifier, "nonce")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestProvider_VerifyIDToken(t *testing.T) {
+	mock := oidctest.NewProvider("crm", "s3cret")
+	defer mock.Close()
+	claims := func(overrides jwt.MapClaims) jwt.MapClaims {
+		c := jwt.MapClaims{
+			"iss":   mock.Issuer(),
+			"sub":   "00u1",
+			"aud":   "crm",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "n",
+			"email": "Bia@Empresa.com.br",
+		}
+		for k, v := range overrides {
+			c[k] = v
+		}
+		return c
+	}
+
+	t.Run("issuer is enforced", func(t *testing.T) {
+		p := newTestProvider(t, mock, nil)
+		_, err := p.VerifyIDToken(mock.SignIDToken(claims(jwt.MapClaims{"iss": "https://evil.example.com"})), "n")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("email_verified as string and trusted providers", func(t *testing.T) {
+		p := newTestProvider(t, mock, nil)
+		identity, err := p.VerifyIDToken(mock.SignIDToken(claims(jwt.MapClaims{"email_verified": "true"})), "n")
+		require.NoError(t, err)
+		assert.Equal(t, "bia@empresa.com.br", identity.Email)
+		assert.True(t, identity.EmailVerified)
+
+		identity, err = p.VerifyIDToken(mock.SignIDToken(claims(nil)), "n")
+		require.NoError(t, err)
+		assert.False(t, identity.EmailVerified)
+
+		trusted := newTestProvider(t, mock, func(c *Config) { c.TrustEmail = true })
+		identity, err = trusted.VerifyIDToken(mock.SignIDToken(claims(jwt.MapClaims{"email": "", "preferred_username": "bia@empresa.com.br"})), "n")
+		require.NoError(t, err)
+		assert.Equal(t, "bia@empresa.com.br", identity.Email)
+		assert.True(t, identity.EmailVerified)
+	})
+
+	t.Run("allowed domains", func(t *testing.T) {
+		p := newTestProvider(t, mock, func(c *Config) { c.AllowedDomains = []string{"@Empresa.com.br"} })
+		_, err := p.VerifyIDToken(mock.SignIDToken(claims(nil)), "n")
+		assert.NoError(t, err)
+		_, err = p.VerifyIDToken(mock.SignIDToken(claims(jwt.MapClaims{"email": "bia@gmail.com"})), "n")
+		assert.ErrorIs(t, err, ErrEmailDomainNotAllowed)
+	})
+
+	t.Run("custom audience and azp", func(t *testing.T) {
+		p := newTestProvider(t, mock, func(c *Config) { c.Audience = "api://crm" })
+		_, err := p.VerifyIDToken(mock.SignIDToken(claims(jwt.MapClaims{"aud": []string{"api://crm", "other"}, "azp": "crm"})), "n")
+		assert.NoError(t, err)
+		_, err = p.VerifyIDToken(mock.SignIDToken(claims(jwt.MapClaims{"aud": []string{"api://crm", "other"}, "azp": "other"})), "n")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestDiscover_IssuerMismatch(t *testing.T) {
+	mock := oidctest.NewProvider("crm", "s3cret")
+	defer mock.Close()
+
+	_, err := Discover(context.Background(), mock.Client(), mock.Issuer()+"/")
+	assert.NoError(t, err, "trailing slash is ignored")
+
+	_, err = NewProvider(context.Background(), Config{
+		Name: "test", Issuer: mock.Issuer() + "/realms/other", ClientID: "crm", RedirectURL: redirectURL,
+	}, mock.Client())
+	assert.Error(t, err)
+}
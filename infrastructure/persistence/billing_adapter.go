@@ -73,6 +73,21 @@ func (a *BillingRepositoryAdapter) FindActiveByUserID(ctx context.Context, userI
 	return a.entityToDomain(entity)
 }
 
+// FindByStripeCustomerID busca a conta vinculada ao customer do Stripe
+func (a *BillingRepositoryAdapter) FindByStripeCustomerID(ctx context.Context, stripeCustomerID string) (*billing.BillingAccount, error) {
+	if stripeCustomerID == "" {
+		return nil, billing.ErrNotFound
+	}
+	entity, err := a.gormRepo.FindByStripeCustomerID(ctx, stripeCustomerID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, billing.ErrNotFound
+		}
+		return nil, err
+	}
+	return a.entityToDomain(entity)
+}
+
 // Update atualiza uma conta existente
 func (a *BillingRepositoryAdapter) Update(ctx context.Context, account *billing.BillingAccount) error {
 	entity, err := a.domainToEntity(account)
@@ -111,6 +126,7 @@ func (a *BillingRepositoryAdapter) domainToEntity(account *billing.BillingAccoun
 		Suspended:        account.IsSuspended(),
 		SuspendedAt:      account.SuspendedAt(),
 		SuspensionReason: account.SuspensionReason(),
+		ReadOnlyAt:       account.ReadOnlyAt(),
 		CreatedAt:        account.CreatedAt(),
 		UpdatedAt:        account.UpdatedAt(),
 	}, nil
@@ -139,6 +155,7 @@ func (a *BillingRepositoryAdapter) entityToDomain(entity *entities.BillingAccoun
 		entity.Suspended,
 		entity.SuspendedAt,
 		entity.SuspensionReason,
+		entity.ReadOnlyAt,
 		entity.CreatedAt,
 		entity.UpdatedAt,
 	), nil
@@ -0,0 +1,430 @@
+package persistence
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+)
+
+// contactListFilterCompiler traduz a árvore de filtros de uma lista dinâmica em uma única
+// condição SQL sobre a tabela contacts. Filtros sobre outras tabelas viram subqueries
+// correlacionadas (EXISTS / COUNT), de modo que o Postgres avalia tudo em uma só query.
+type contactListFilterCompiler struct {
+	now time.Time // referência para operadores de data relativa
+}
+
+// compileContactListFilter retorna a condição (com placeholders "?") e seus argumentos
+func compileContactListFilter(group *contact_list.FilterGroup, now time.Time) (string, []interface{}, error) {
+	if group.IsEmpty() {
+		return "", nil, nil
+	}
+
+	c := &contactListFilterCompiler{now: now}
+	return c.compileGroup(group)
+}
+
+// contactAttributeColumns lista os atributos filtráveis e se são colunas de data
+var contactAttributeColumns = map[string]bool{
+	"name":                 false,
+	"email":                false,
+	"phone":                false,
+	"language":             false,
+	"timezone":             false,
+	"source_channel":       false,
+	"first_interaction_at": true,
+	"last_interaction_at":  true,
+	"created_at":           true,
+	"updated_at":           true,
+}
+
+// interactionSource descreve a tabela consultada por filtros de evento/interação
+type interactionSource struct {
+	table      string
+	timeColumn string
+	condition  string
+}
+
+var interactionSources = map[contact_list.InteractionSource]interactionSource{
+	contact_list.InteractionMessages:         {table: "messages", timeColumn: "timestamp"},
+	contact_list.InteractionMessagesInbound:  {table: "messages", timeColumn: "timestamp", condition: "from_me = false"},
+	contact_list.InteractionMessagesOutbound: {table: "messages", timeColumn: "timestamp", condition: "from_me = true"},
+	contact_list.InteractionSessions:         {table: "sessions", timeColumn: "started_at"},
+	contact_list.InteractionTrackings:        {table: "trackings", timeColumn: "created_at"},
+	contact_list.InteractionContactEvents:    {table: "contact_events", timeColumn: "occurred_at"},
+}
+
+var comparisonOperators = map[contact_list.FilterOperator]string{
+	contact_list.OperatorEquals:       "=",
+	contact_list.OperatorNotEquals:    "<>",
+	contact_list.OperatorGreaterThan:  ">",
+	contact_list.OperatorLessThan:     "<",
+	contact_list.OperatorGreaterEqual: ">=",
+	contact_list.OperatorLessEqual:    "<=",
+}
+
+func (c *contactListFilterCompiler) compileGroup(group *contact_list.FilterGroup) (string, []interface{}, error) {
+	parts := make([]string, 0, len(group.Rules)+len(group.Groups))
+	var args []interface{}
+
+	for _, rule := range group.Rules {
+		sql, ruleArgs, err := c.compileRule(rule)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, sql)
+		args = append(args, ruleArgs...)
+	}
+
+	for i := range group.Groups {
+		if group.Groups[i].IsEmpty() {
+			continue
+		}
+		sql, groupArgs, err := c.compileGroup(&group.Groups[i])
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, sql)
+		args = append(args, groupArgs...)
+	}
+
+	if len(parts) == 0 {
+		return "TRUE", nil, nil
+	}
+
+	switch group.Logic {
+	case contact_list.LogicalOperatorOR:
+		return "(" + strings.Join(parts, " OR ") + ")", args, nil
+	case contact_list.LogicalOperatorNOT:
+		return "NOT (" + strings.Join(parts, " AND ") + ")", args, nil
+	case contact_list.LogicalOperatorAND, "":
+		return "(" + strings.Join(parts, " AND ") + ")", args, nil
+	default:
+		return "", nil, fmt.Errorf("invalid group logic: %s", group.Logic)
+	}
+}
+
+func (c *contactListFilterCompiler) compileRule(rule *contact_list.FilterRule) (string, []interface{}, error) {
+	switch rule.FilterType() {
+	case contact_list.FilterTypeAttribute:
+		return c.compileAttribute(rule)
+	case contact_list.FilterTypeTag:
+		return c.compileTag(rule)
+	case contact_list.FilterTypeCustomField:
+		return c.compileCustomField(rule)
+	case contact_list.FilterTypePipelineStatus:
+		return c.compilePipelineStatus(rule)
+	case contact_list.FilterTypeInteraction:
+		source, ok := interactionSources[contact_list.InteractionSource(rule.FieldKey())]
+		if !ok {
+			return "", nil, fmt.Errorf("invalid interaction source: %s", rule.FieldKey())
+		}
+		return c.compileActivity(rule, source, nil)
+	case contact_list.FilterTypeEvent:
+		source := interactionSources[contact_list.InteractionContactEvents]
+		source.condition = "event_type = ?"
+		return c.compileActivity(rule, source, []interface{}{rule.FieldKey()})
+	default:
+		return "", nil, fmt.Errorf("unsupported filter type: %s", rule.FilterType())
+	}
+}
+
+// compileAttribute filtra colunas da própria tabela contacts
+func (c *contactListFilterCompiler) compileAttribute(rule *contact_list.FilterRule) (string, []interface{}, error) {
+	isDate, ok := contactAttributeColumns[rule.FieldKey()]
+	if !ok {
+		return "", nil, fmt.Errorf("invalid attribute name: %s", rule.FieldKey())
+	}
+
+	column := "contacts." + rule.FieldKey()
+	if isDate {
+		return c.compileDateColumn(column, rule.Operator(), rule.Value())
+	}
+	return c.compileValueExpression(column, rule.Operator(), rule.Value())
+}
+
+// compileTag filtra o array jsonb contacts.tags
+func (c *contactListFilterCompiler) compileTag(rule *contact_list.FilterRule) (string, []interface{}, error) {
+	containsAny := func(values []string) (string, []interface{}) {
+		parts := make([]string, len(values))
+		args := make([]interface{}, len(values))
+		for i, value := range values {
+			tagJSON, _ := json.Marshal([]string{value})
+			parts[i] = "contacts.tags @> ?::jsonb"
+			args[i] = string(tagJSON)
+		}
+		return "(" + strings.Join(parts, " OR ") + ")", args
+	}
+
+	switch rule.Operator() {
+	case contact_list.OperatorEquals, contact_list.OperatorContains:
+		sql, args := containsAny([]string{fmt.Sprintf("%v", rule.Value())})
+		return sql, args, nil
+	case contact_list.OperatorNotEquals, contact_list.OperatorNotContains:
+		sql, args := containsAny([]string{fmt.Sprintf("%v", rule.Value())})
+		return "NOT COALESCE(" + sql + ", false)", args, nil
+	case contact_list.OperatorIn, contact_list.OperatorNotIn:
+		values := toStringSlice(rule.Value())
+		if len(values) == 0 {
+			return "", nil, fmt.Errorf("tag filter with %s requires at least one tag", rule.Operator())
+		}
+		sql, args := containsAny(values)
+		if rule.Operator() == contact_list.OperatorNotIn {
+			return "NOT COALESCE(" + sql + ", false)", args, nil
+		}
+		return sql, args, nil
+	case contact_list.OperatorIsNull:
+		return "(contacts.tags IS NULL OR contacts.tags = '[]'::jsonb)", nil, nil
+	case contact_list.OperatorIsNotNull:
+		return "(contacts.tags IS NOT NULL AND contacts.tags <> '[]'::jsonb)", nil, nil
+	default:
+		return "", nil, fmt.Errorf("operator %s is not supported for tag filters", rule.Operator())
+	}
+}
+
+// compileCustomField filtra contact_custom_fields. Negações (ne, not_contains, not_in) incluem
+// contatos sem o campo, por isso são compiladas como NOT EXISTS da condição positiva.
+func (c *contactListFilterCompiler) compileCustomField(rule *contact_list.FilterRule) (string, []interface{}, error) {
+	const exists = "EXISTS (SELECT 1 FROM contact_custom_fields ccf WHERE ccf.contact_id = contacts.id AND ccf.deleted_at IS NULL AND ccf.field_key = ?"
+	args := []interface{}{rule.FieldKey()}
+
+	operator := rule.Operator()
+	switch operator {
+	case contact_list.OperatorIsNull:
+		return "NOT " + exists + " AND ccf.field_value IS NOT NULL AND ccf.field_value <> 'null'::jsonb)", args, nil
+	case contact_list.OperatorIsNotNull:
+		return exists + " AND ccf.field_value IS NOT NULL AND ccf.field_value <> 'null'::jsonb)", args, nil
+	}
+
+	negate := false
+	switch operator {
+	case contact_list.OperatorNotEquals:
+		operator, negate = contact_list.OperatorEquals, true
+	case contact_list.OperatorNotContains:
+		operator, negate = contact_list.OperatorContains, true
+	case contact_list.OperatorNotIn:
+		operator, negate = contact_list.OperatorIn, true
+	}
+
+	var fieldType shared.FieldType = shared.FieldTypeText
+	if rule.FieldType() != nil {
+		fieldType = *rule.FieldType()
+	}
+
+	var (
+		condition     string
+		conditionArgs []interface{}
+		err           error
+	)
+	switch {
+	case (fieldType == shared.FieldTypeMultiSelect || fieldType == shared.FieldTypeLabel) &&
+		(operator == contact_list.OperatorContains || operator == contact_list.OperatorEquals):
+		// Campos multivalorados são arrays jsonb
+		valueJSON, _ := json.Marshal([]string{fmt.Sprintf("%v", rule.Value())})
+		condition, conditionArgs = "ccf.field_value @> ?::jsonb", []interface{}{string(valueJSON)}
+	case fieldType == shared.FieldTypeDate:
+		condition, conditionArgs, err = c.compileDateColumn("(ccf.field_value #>> '{}')::timestamptz", operator, rule.Value())
+	case fieldType == shared.FieldTypeNumber:
+		condition, conditionArgs, err = c.compileValueExpression("(ccf.field_value #>> '{}')::numeric", operator, rule.Value())
+	case fieldType == shared.FieldTypeBoolean:
+		condition, conditionArgs, err = c.compileValueExpression("(ccf.field_value #>> '{}')::boolean", operator, rule.Value())
+	default:
+		condition, conditionArgs, err = c.compileValueExpression("ccf.field_value #>> '{}'", operator, rule.Value())
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	sql := exists + " AND " + condition + ")"
+	if negate {
+		sql = "NOT " + sql
+	}
+	return sql, append(args, conditionArgs...), nil
+}
+
+// compilePipelineStatus verifica o status atual do contato em um pipeline
+func (c *contactListFilterCompiler) compilePipelineStatus(rule *contact_list.FilterRule) (string, []interface{}, error) {
+	if rule.PipelineID() == nil {
+		return "", nil, fmt.Errorf("pipeline status filter requires a pipeline id")
+	}
+
+	const exists = "EXISTS (SELECT 1 FROM contact_pipeline_statuses cps JOIN pipeline_statuses ps ON ps.id = cps.status_id " +
+		"WHERE cps.contact_id = contacts.id AND cps.pipeline_id = ? AND cps.exited_at IS NULL"
+	args := []interface{}{*rule.PipelineID()}
+
+	switch rule.Operator() {
+	case contact_list.OperatorEquals:
+		return exists + " AND ps.name = ?)", append(args, rule.Value()), nil
+	case contact_list.OperatorNotEquals:
+		return "NOT " + exists + " AND ps.name = ?)", append(args, rule.Value()), nil
+	case contact_list.OperatorIn, contact_list.OperatorNotIn:
+		values := toStringSlice(rule.Value())
+		if len(values) == 0 {
+			return "", nil, fmt.Errorf("pipeline status filter with %s requires at least one status", rule.Operator())
+		}
+		sql := exists + " AND ps.name IN ?)"
+		if rule.Operator() == contact_list.OperatorNotIn {
+			sql = "NOT " + sql
+		}
+		return sql, append(args, values), nil
+	case contact_list.OperatorIsNotNull:
+		return exists + ")", args, nil
+	case contact_list.OperatorIsNull:
+		return "NOT " + exists + ")", args, nil
+	default:
+		return "", nil, fmt.Errorf("operator %s is not supported for pipeline status filters", rule.Operator())
+	}
+}
+
+// compileActivity compila filtros de evento/interação:
+//   - comparações (eq, gt, gte...) contam registros, opcionalmente nos últimos within_days dias;
+//   - within_last_days: houve atividade nos últimos N dias;
+//   - more_than_days_ago / before / after: comparam a atividade mais recente;
+//   - is_null / is_not_null: nunca houve / já houve atividade.
+func (c *contactListFilterCompiler) compileActivity(rule *contact_list.FilterRule, source interactionSource, sourceArgs []interface{}) (string, []interface{}, error) {
+	timeColumn := "t." + source.timeColumn
+	from := fmt.Sprintf("FROM %s t WHERE t.contact_id = contacts.id AND t.deleted_at IS NULL", source.table)
+	if source.condition != "" {
+		from += " AND t." + source.condition
+	}
+	args := append([]interface{}{}, sourceArgs...)
+
+	operator := rule.Operator()
+	if sqlOp, ok := comparisonOperators[operator]; ok {
+		count, err := contact_list.ParseCount(rule.Value())
+		if err != nil {
+			return "", nil, err
+		}
+		if days := rule.WithinDays(); days != nil {
+			from += fmt.Sprintf(" AND %s >= ?", timeColumn)
+			args = append(args, c.daysAgo(*days))
+		}
+		return fmt.Sprintf("(SELECT COUNT(*) %s) %s ?", from, sqlOp), append(args, count), nil
+	}
+
+	switch operator {
+	case contact_list.OperatorIsNotNull:
+		return "EXISTS (SELECT 1 " + from + ")", args, nil
+	case contact_list.OperatorIsNull:
+		return "NOT EXISTS (SELECT 1 " + from + ")", args, nil
+	case contact_list.OperatorWithinLastDays:
+		days, err := contact_list.ParseDays(rule.Value())
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("EXISTS (SELECT 1 %s AND %s >= ?)", from, timeColumn), append(args, c.daysAgo(days)), nil
+	case contact_list.OperatorMoreThanDaysAgo:
+		days, err := contact_list.ParseDays(rule.Value())
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("(SELECT MAX(%s) %s) < ?", timeColumn, from), append(args, c.daysAgo(days)), nil
+	case contact_list.OperatorBefore, contact_list.OperatorAfter:
+		date, err := contact_list.ParseDate(rule.Value())
+		if err != nil {
+			return "", nil, err
+		}
+		sqlOp := "<"
+		if operator == contact_list.OperatorAfter {
+			sqlOp = ">"
+		}
+		return fmt.Sprintf("(SELECT MAX(%s) %s) %s ?", timeColumn, from, sqlOp), append(args, date), nil
+	default:
+		return "", nil, fmt.Errorf("operator %s is not supported for %s filters", operator, rule.FilterType())
+	}
+}
+
+// compileDateColumn aplica operadores relativos/absolutos de data sobre uma expressão
+func (c *contactListFilterCompiler) compileDateColumn(column string, operator contact_list.FilterOperator, value interface{}) (string, []interface{}, error) {
+	switch operator {
+	case contact_list.OperatorWithinLastDays, contact_list.OperatorMoreThanDaysAgo:
+		days, err := contact_list.ParseDays(value)
+		if err != nil {
+			return "", nil, err
+		}
+		if operator == contact_list.OperatorWithinLastDays {
+			return column + " >= ?", []interface{}{c.daysAgo(days)}, nil
+		}
+		return column + " < ?", []interface{}{c.daysAgo(days)}, nil
+	case contact_list.OperatorBefore, contact_list.OperatorAfter,
+		contact_list.OperatorEquals, contact_list.OperatorNotEquals,
+		contact_list.OperatorGreaterThan, contact_list.OperatorLessThan,
+		contact_list.OperatorGreaterEqual, contact_list.OperatorLessEqual:
+		date, err := contact_list.ParseDate(value)
+		if err != nil {
+			return "", nil, err
+		}
+		sqlOp := comparisonOperators[operator]
+		switch operator {
+		case contact_list.OperatorBefore:
+			sqlOp = "<"
+		case contact_list.OperatorAfter:
+			sqlOp = ">"
+		}
+		return fmt.Sprintf("%s %s ?", column, sqlOp), []interface{}{date}, nil
+	case contact_list.OperatorIsNull:
+		return column + " IS NULL", nil, nil
+	case contact_list.OperatorIsNotNull:
+		return column + " IS NOT NULL", nil, nil
+	default:
+		return "", nil, fmt.Errorf("operator %s is not supported for date fields", operator)
+	}
+}
+
+// compileValueExpression aplica operadores de comparação/texto sobre uma expressão escalar
+func (c *contactListFilterCompiler) compileValueExpression(expr string, operator contact_list.FilterOperator, value interface{}) (string, []interface{}, error) {
+	if sqlOp, ok := comparisonOperators[operator]; ok {
+		return fmt.Sprintf("%s %s ?", expr, sqlOp), []interface{}{value}, nil
+	}
+
+	switch operator {
+	case contact_list.OperatorContains:
+		return expr + " ILIKE ?", []interface{}{fmt.Sprintf("%%%v%%", value)}, nil
+	case contact_list.OperatorNotContains:
+		return fmt.Sprintf("COALESCE(%s, '') NOT ILIKE ?", expr), []interface{}{fmt.Sprintf("%%%v%%", value)}, nil
+	case contact_list.OperatorStartsWith:
+		return expr + " ILIKE ?", []interface{}{fmt.Sprintf("%v%%", value)}, nil
+	case contact_list.OperatorEndsWith:
+		return expr + " ILIKE ?", []interface{}{fmt.Sprintf("%%%v", value)}, nil
+	case contact_list.OperatorIn, contact_list.OperatorNotIn:
+		values := toStringSlice(value)
+		if len(values) == 0 {
+			return "", nil, fmt.Errorf("%s requires at least one value", operator)
+		}
+		if operator == contact_list.OperatorNotIn {
+			return expr + " NOT IN ?", []interface{}{values}, nil
+		}
+		return expr + " IN ?", []interface{}{values}, nil
+	case contact_list.OperatorIsNull:
+		return expr + " IS NULL", nil, nil
+	case contact_list.OperatorIsNotNull:
+		return expr + " IS NOT NULL", nil, nil
+	default:
+		return "", nil, fmt.Errorf("operator %s is not supported for this field", operator)
+	}
+}
+
+func (c *contactListFilterCompiler) daysAgo(days int) time.Time {
+	return c.now.AddDate(0, 0, -days)
+}
+
+// toStringSlice aceita []string, []interface{} (JSON) ou um valor único
+func toStringSlice(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprintf("%v", item))
+		}
+		return values
+	default:
+		return []string{fmt.Sprintf("%v", v)}
+	}
+}
@@ -0,0 +1,182 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+var compilerNow = time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+
+func mustRule(t *testing.T) func(*contact_list.FilterRule, error) *contact_list.FilterRule {
+	return func(rule *contact_list.FilterRule, err error) *contact_list.FilterRule {
+		t.Helper()
+		require.NoError(t, err)
+		return rule
+	}
+}
+
+func TestCompileContactListFilter_EmptyGroup(t *testing.T) {
+	sql, args, err := compileContactListFilter(nil, compilerNow)
+
+	require.NoError(t, err)
+	assert.Empty(t, sql)
+	assert.Empty(t, args)
+}
+
+func TestCompileContactListFilter_NestedGroups(t *testing.T) {
+	must := mustRule(t)
+	group := &contact_list.FilterGroup{
+		Logic: contact_list.LogicalOperatorAND,
+		Rules: []*contact_list.FilterRule{
+			must(contact_list.NewAttributeFilterRule("email", contact_list.OperatorIsNotNull, nil)),
+		},
+		Groups: []contact_list.FilterGroup{
+			{
+				Logic: contact_list.LogicalOperatorOR,
+				Rules: []*contact_list.FilterRule{
+					must(contact_list.NewTagFilterRule(contact_list.OperatorContains, "vip")),
+					must(contact_list.NewTagFilterRule(contact_list.OperatorContains, "premium")),
+				},
+			},
+			{
+				Logic: contact_list.LogicalOperatorNOT,
+				Rules: []*contact_list.FilterRule{
+					must(contact_list.NewAttributeFilterRule("source_channel", contact_list.OperatorEquals, "instagram")),
+				},
+			},
+		},
+	}
+
+	sql, args, err := compileContactListFilter(group, compilerNow)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		"(contacts.email IS NOT NULL AND ((contacts.tags @> ?::jsonb) OR (contacts.tags @> ?::jsonb)) AND NOT (contacts.source_channel = ?))",
+		sql)
+	assert.Equal(t, []interface{}{`["vip"]`, `["premium"]`, "instagram"}, args)
+}
+
+func TestCompileContactListFilter_RelativeDateAttribute(t *testing.T) {
+	must := mustRule(t)
+	group := &contact_list.FilterGroup{
+		Logic: contact_list.LogicalOperatorAND,
+		Rules: []*contact_list.FilterRule{
+			must(contact_list.NewAttributeFilterRule("last_interaction_at", contact_list.OperatorWithinLastDays, 7)),
+			must(contact_list.NewAttributeFilterRule("created_at", contact_list.OperatorBefore, "2025-01-01")),
+		},
+	}
+
+	sql, args, err := compileContactListFilter(group, compilerNow)
+
+	require.NoError(t, err)
+	assert.Equal(t, "(contacts.last_interaction_at >= ? AND contacts.created_at < ?)", sql)
+	assert.Equal(t, compilerNow.AddDate(0, 0, -7), args[0])
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), args[1])
+}
+
+func TestCompileContactListFilter_InteractionCountWithinDays(t *testing.T) {
+	must := mustRule(t)
+	days := 30
+	group := &contact_list.FilterGroup{
+		Logic: contact_list.LogicalOperatorAND,
+		Rules: []*contact_list.FilterRule{
+			must(contact_list.NewInteractionFilterRule(contact_list.InteractionMessagesInbound, contact_list.OperatorGreaterEqual, 3, &days)),
+		},
+	}
+
+	sql, args, err := compileContactListFilter(group, compilerNow)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		"((SELECT COUNT(*) FROM messages t WHERE t.contact_id = contacts.id AND t.deleted_at IS NULL AND t.from_me = false AND t.timestamp >= ?) >= ?)",
+		sql)
+	assert.Equal(t, []interface{}{compilerNow.AddDate(0, 0, -30), 3}, args)
+}
+
+func TestCompileContactListFilter_EventFilters(t *testing.T) {
+	must := mustRule(t)
+	group := &contact_list.FilterGroup{
+		Logic: contact_list.LogicalOperatorOR,
+		Rules: []*contact_list.FilterRule{
+			must(contact_list.NewEventFilterRule("purchase.completed", contact_list.OperatorWithinLastDays, 14, nil)),
+			must(contact_list.NewEventFilterRule("form.submitted", contact_list.OperatorIsNull, nil, nil)),
+		},
+	}
+
+	sql, args, err := compileContactListFilter(group, compilerNow)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		"(EXISTS (SELECT 1 FROM contact_events t WHERE t.contact_id = contacts.id AND t.deleted_at IS NULL AND t.event_type = ? AND t.occurred_at >= ?)"+
+			" OR NOT EXISTS (SELECT 1 FROM contact_events t WHERE t.contact_id = contacts.id AND t.deleted_at IS NULL AND t.event_type = ?))",
+		sql)
+	assert.Equal(t, []interface{}{"purchase.completed", compilerNow.AddDate(0, 0, -14), "form.submitted"}, args)
+}
+
+func TestCompileContactListFilter_CustomFieldNegation(t *testing.T) {
+	must := mustRule(t)
+	group := &contact_list.FilterGroup{
+		Logic: contact_list.LogicalOperatorAND,
+		Rules: []*contact_list.FilterRule{
+			must(contact_list.NewCustomFieldFilterRule("plan", shared.FieldTypeText, contact_list.OperatorNotEquals, "free")),
+			must(contact_list.NewCustomFieldFilterRule("score", shared.FieldTypeNumber, contact_list.OperatorGreaterThan, 50)),
+		},
+	}
+
+	sql, args, err := compileContactListFilter(group, compilerNow)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		"(NOT EXISTS (SELECT 1 FROM contact_custom_fields ccf WHERE ccf.contact_id = contacts.id AND ccf.deleted_at IS NULL AND ccf.field_key = ? AND ccf.field_value #>> '{}' = ?)"+
+			" AND EXISTS (SELECT 1 FROM contact_custom_fields ccf WHERE ccf.contact_id = contacts.id AND ccf.deleted_at IS NULL AND ccf.field_key = ? AND (ccf.field_value #>> '{}')::numeric > ?))",
+		sql)
+	assert.Equal(t, []interface{}{"plan", "free", "score", 50}, args)
+}
+
+func TestCompileContactListFilter_PipelineStatusIn(t *testing.T) {
+	must := mustRule(t)
+	pipelineID := uuid.New()
+	rule := must(contact_list.NewPipelineStatusFilterRule(pipelineID, "Lead", contact_list.OperatorEquals))
+	group := &contact_list.FilterGroup{Logic: contact_list.LogicalOperatorAND, Rules: []*contact_list.FilterRule{rule}}
+
+	sql, args, err := compileContactListFilter(group, compilerNow)
+
+	require.NoError(t, err)
+	assert.Contains(t, sql, "cps.pipeline_id = ? AND cps.exited_at IS NULL AND ps.name = ?")
+	assert.Equal(t, []interface{}{pipelineID, "Lead"}, args)
+}
+
+func TestCompileContactListFilter_UnsupportedOperator(t *testing.T) {
+	rule := contact_list.ReconstructFilterRule(uuid.New(), contact_list.FilterTypeTag, contact_list.OperatorWithinLastDays,
+		"tag", nil, 7, nil, nil, compilerNow)
+	group := &contact_list.FilterGroup{Logic: contact_list.LogicalOperatorAND, Rules: []*contact_list.FilterRule{rule}}
+
+	_, _, err := compileContactListFilter(group, compilerNow)
+
+	assert.Error(t, err)
+}
+
+func TestContactListDynamicQuery_ScopesByTenantAndProject(t *testing.T) {
+	db, err := gorm.Open(postgres.Open("host=localhost"), &gorm.Config{DisableAutomaticPing: true})
+	require.NoError(t, err)
+	projectID := uuid.New()
+	list, err := contact_list.NewContactList(projectID, "tenant-a", "VIP", contact_list.LogicalOperatorAND, false)
+	require.NoError(t, err)
+
+	repo := &GormContactListRepository{db: db}
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		return repo.dynamicListQuery(tx, list).Find(&[]entities.ContactEntity{})
+	})
+
+	assert.Contains(t, sql, "contacts.tenant_id = 'tenant-a'")
+	assert.Contains(t, sql, "contacts.project_id = '"+projectID.String()+"'")
+}
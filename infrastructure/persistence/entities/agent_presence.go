@@ -0,0 +1,22 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AgentPresenceEntity sinais de presença de um agente humano (heartbeats do websocket e status manual)
+type AgentPresenceEntity struct {
+	AgentID        uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID       string     `gorm:"not null;index:idx_agent_presence_tenant"`
+	LastSeenAt     *time.Time `gorm:"index:idx_agent_presence_last_seen"`
+	LastActivityAt *time.Time
+	ManualStatus   *string
+	ManualStatusAt *time.Time
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+func (AgentPresenceEntity) TableName() string {
+	return "agent_presence"
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// AuditLogEntity registro da trilha de auditoria (append-only, encadeado por hash)
+type AuditLogEntity struct {
+	ID            uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	ProjectID     uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_audit_logs_project_sequence"`
+	TenantID      string         `gorm:"not null;default:''"`
+	Sequence      int64          `gorm:"not null;uniqueIndex:idx_audit_logs_project_sequence"`
+	ActorType     string         `gorm:"not null"`
+	ActorID       string         `gorm:"not null;default:''"`
+	ActorLabel    string         `gorm:"not null;default:''"`
+	Action        string         `gorm:"not null"`
+	ResourceType  string         `gorm:"not null;default:''"`
+	ResourceID    string         `gorm:"not null;default:''"`
+	Status        int            `gorm:"not null;default:0"`
+	IPAddress     string         `gorm:"column:ip_address;not null;default:''"`
+	UserAgent     string         `gorm:"not null;default:''"`
+	CorrelationID string         `gorm:"not null;default:''"`
+	Changes       datatypes.JSON `gorm:"type:jsonb"` // map[string]audit.Change
+	Metadata      datatypes.JSON `gorm:"type:jsonb"` // map[string]string
+	OccurredAt    time.Time      `gorm:"not null"`
+	PrevHash      string         `gorm:"not null;default:''"`
+	Hash          string         `gorm:"not null"`
+}
+
+func (AuditLogEntity) TableName() string {
+	return "audit_logs"
+}
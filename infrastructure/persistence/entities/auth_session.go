@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuthSessionEntity sessão de login (refresh token guardado só como hash)
+type AuthSessionEntity struct {
+	ID                       uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID                   uuid.UUID `gorm:"type:uuid;not null;index"`
+	Method                   string    `gorm:"not null"`
+	Provider                 string    `gorm:"not null;default:''"`
+	RefreshTokenHash         string    `gorm:"not null;uniqueIndex"`
+	PreviousRefreshTokenHash string    `gorm:"not null;default:''"`
+	UserAgent                string    `gorm:"not null;default:''"`
+	IPAddress                string    `gorm:"column:ip_address;not null;default:''"`
+	ExpiresAt                time.Time `gorm:"not null"`
+	LastRefreshedAt          *time.Time
+	RevokedAt                *time.Time
+	RevokeReason             string `gorm:"not null;default:''"`
+	TwoFactorVerifiedAt      *time.Time
+	ReauthenticatedAt        time.Time `gorm:"not null"`
+	CreatedAt                time.Time `gorm:"autoCreateTime"`
+	UpdatedAt                time.Time `gorm:"autoUpdateTime"`
+
+	// Relacionamentos
+	User UserEntity `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (AuthSessionEntity) TableName() string {
+	return "auth_sessions"
+}
+
+// UserIdentityEntity identidade OIDC vinculada a um usuário local
+type UserIdentityEntity struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	UserID      uuid.UUID `gorm:"type:uuid;not null;index"`
+	Provider    string    `gorm:"not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Issuer      string    `gorm:"not null"`
+	Subject     string    `gorm:"not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email       string    `gorm:"not null;default:''"`
+	LastLoginAt *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+
+	// Relacionamentos
+	User UserEntity `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (UserIdentityEntity) TableName() string {
+	return "user_identities"
+}
@@ -20,6 +20,7 @@ type BillingAccountEntity struct {
 	Suspended        bool           `gorm:"default:false;index"`
 	SuspendedAt      *time.Time     `gorm:""`
 	SuspensionReason string         `gorm:""`
+	ReadOnlyAt       *time.Time     `gorm:""` // Somente leitura por fatura em atraso
 	CreatedAt        time.Time      `gorm:"autoCreateTime"`
 	UpdatedAt        time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt        gorm.DeletedAt `gorm:"index"`
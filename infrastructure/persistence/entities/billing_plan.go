@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BillingPlanEntity plano comercial com os limites de uso, vinculado ao price do Stripe.
+// Limite -1 é ilimitado.
+type BillingPlanEntity struct {
+	ID               uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Key              string    `gorm:"type:varchar(50);not null;uniqueIndex:unique_billing_plan_key"`
+	Name             string    `gorm:"type:varchar(100);not null"`
+	StripePriceID    string    `gorm:"type:varchar(255);not null;default:'';index:idx_billing_plans_stripe_price"`
+	MaxChannels      int64     `gorm:"not null;default:-1"`
+	MaxContacts      int64     `gorm:"not null;default:-1"`
+	MaxAgents        int64     `gorm:"not null;default:-1"`
+	MonthlyMessages  int64     `gorm:"not null;default:-1"`
+	AIMinutes        int64     `gorm:"column:ai_minutes;not null;default:-1"`
+	BroadcastsPerDay int64     `gorm:"not null;default:-1"`
+	SoftLimitPercent int       `gorm:"not null;default:80"`
+	IsDefault        bool      `gorm:"not null;default:false"`
+	CreatedAt        time.Time `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time `gorm:"autoUpdateTime"`
+}
+
+func (BillingPlanEntity) TableName() string {
+	return "billing_plans"
+}
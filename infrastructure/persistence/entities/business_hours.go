@@ -0,0 +1,42 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// BusinessHoursCalendarEntity expediente de um projeto (um por projeto)
+type BusinessHoursCalendarEntity struct {
+	ID                         uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	TenantID                   string         `gorm:"not null"`
+	ProjectID                  uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
+	Timezone                   string         `gorm:"not null;default:'UTC'"`
+	Weekly                     datatypes.JSON `gorm:"type:jsonb"` // map[string][]businesshours.Interval
+	Holidays                   datatypes.JSON `gorm:"type:jsonb"` // []string
+	OutOfOfficeEnabled         bool           `gorm:"not null;default:false"`
+	OutOfOfficeMessage         string         `gorm:"not null;default:''"`
+	OutOfOfficeCooldownSeconds int            `gorm:"not null;default:0"`
+	CreatedAt                  time.Time      `gorm:"not null"`
+	UpdatedAt                  time.Time      `gorm:"not null"`
+}
+
+func (BusinessHoursCalendarEntity) TableName() string {
+	return "business_hours_calendars"
+}
+
+// OutOfOfficeReplyEntity resposta de ausência enviada (única por sessão)
+type OutOfOfficeReplyEntity struct {
+	ID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID  string    `gorm:"not null"`
+	ProjectID uuid.UUID `gorm:"type:uuid;not null"`
+	ContactID uuid.UUID `gorm:"type:uuid;not null;index:idx_out_of_office_replies_contact"`
+	SessionID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex"`
+	MessageID uuid.UUID `gorm:"type:uuid;not null"`
+	SentAt    time.Time `gorm:"not null"`
+}
+
+func (OutOfOfficeReplyEntity) TableName() string {
+	return "out_of_office_replies"
+}
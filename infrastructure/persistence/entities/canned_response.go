@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// CannedResponseEntity resposta pronta (owner_agent_id NULL = compartilhada com o projeto)
+type CannedResponseEntity struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	TenantID     string         `gorm:"not null"`
+	ProjectID    uuid.UUID      `gorm:"type:uuid;not null;index:idx_canned_responses_project"`
+	OwnerAgentID *uuid.UUID     `gorm:"type:uuid"`
+	Shortcut     string         `gorm:"not null"`
+	Title        string         `gorm:"not null"`
+	Content      string         `gorm:"not null"`
+	Folder       string         `gorm:"not null;default:''"`
+	Attachments  datatypes.JSON `gorm:"type:jsonb;not null;default:'[]'"` // []cannedresponse.Attachment
+	UsageCount   int64          `gorm:"not null;default:0"`
+	LastUsedAt   *time.Time
+	CreatedBy    uuid.UUID `gorm:"type:uuid;not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+	UpdatedAt    time.Time `gorm:"not null"`
+	DeletedAt    *time.Time
+}
+
+func (CannedResponseEntity) TableName() string {
+	return "canned_responses"
+}
+
+// CannedResponseUsageEntity uso de uma resposta pronta
+type CannedResponseUsageEntity struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID         string     `gorm:"not null"`
+	ProjectID        uuid.UUID  `gorm:"type:uuid;not null;index:idx_canned_response_usages_project,priority:1"`
+	CannedResponseID uuid.UUID  `gorm:"type:uuid;not null;index:idx_canned_response_usages_response"`
+	AgentID          *uuid.UUID `gorm:"type:uuid"`
+	UserID           uuid.UUID  `gorm:"type:uuid;not null"`
+	SessionID        *uuid.UUID `gorm:"type:uuid"`
+	UsedAt           time.Time  `gorm:"not null;index:idx_canned_response_usages_project,priority:2"`
+}
+
+func (CannedResponseUsageEntity) TableName() string {
+	return "canned_response_usages"
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -16,6 +17,7 @@ type ContactListEntity struct {
 	Name             string         `gorm:"not null"`
 	Description      string         `gorm:"type:text"`
 	LogicalOperator  string         `gorm:"not null;default:'AND'"` // AND ou OR
+	FilterGroup      datatypes.JSON `gorm:"type:jsonb"`             // contact_list.FilterGroup (filtros aninhados)
 	IsStatic         bool           `gorm:"not null;default:false"`
 	ContactCount     int            `gorm:"not null;default:0"`
 	LastCalculatedAt *time.Time     `gorm:""`
@@ -42,6 +44,7 @@ type ContactListFilterRuleEntity struct {
 	FieldType     string     `gorm:""`                // Tipo do campo (apenas para custom_field)
 	Value         string     `gorm:"type:text"`       // Valor serializado como JSON
 	PipelineID    *uuid.UUID `gorm:"type:uuid;index"` // Apenas para pipeline_status
+	WithinDays    *int       `gorm:""`                // Janela de contagem (apenas event/interaction)
 	CreatedAt     time.Time  `gorm:"autoCreateTime"`
 
 	// Relacionamento
@@ -55,8 +58,8 @@ func (ContactListFilterRuleEntity) TableName() string {
 // ContactListMemberEntity representa um contato em uma lista estática
 type ContactListMemberEntity struct {
 	ID            uuid.UUID `gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
-	ContactListID uuid.UUID `gorm:"type:uuid;not null;index:idx_contact_list_member"`
-	ContactID     uuid.UUID `gorm:"type:uuid;not null;index:idx_contact_list_member"`
+	ContactListID uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_contact_list_member"`
+	ContactID     uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_contact_list_member"`
 	AddedAt       time.Time `gorm:"autoCreateTime"`
 }
 
@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DunningCaseEntity régua de cobrança de uma fatura em atraso. Só uma régua aberta por fatura.
+type DunningCaseEntity struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	Version          int        `gorm:"default:1;not null"` // Optimistic locking
+	BillingAccountID uuid.UUID  `gorm:"type:uuid;not null;index:idx_dunning_cases_account"`
+	InvoiceID        uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_dunning_cases_open_invoice,where:status = 'open'"`
+	DueDate          time.Time  `gorm:"not null"`
+	Status           string     `gorm:"type:varchar(20);not null;default:'open';index:idx_dunning_cases_account"`
+	LastReminderDay  *int       `gorm:""`
+	RemindersSent    int        `gorm:"not null;default:0"`
+	ReadOnlyAt       *time.Time `gorm:""`
+	SuspendedAt      *time.Time `gorm:""`
+	ResolvedAt       *time.Time `gorm:""`
+	Resolution       string     `gorm:"type:varchar(50);not null;default:''"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt        time.Time  `gorm:"autoUpdateTime"`
+
+	// Relacionamentos
+	BillingAccount BillingAccountEntity `gorm:"foreignKey:BillingAccountID;constraint:OnDelete:CASCADE"`
+	Invoice        InvoiceEntity        `gorm:"foreignKey:InvoiceID;constraint:OnDelete:CASCADE"`
+}
+
+func (DunningCaseEntity) TableName() string {
+	return "dunning_cases"
+}
+
+// BillingEventEntity registro da linha do tempo de cobrança exibida ao cliente
+type BillingEventEntity struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	BillingAccountID uuid.UUID  `gorm:"type:uuid;not null;index:idx_billing_events_account"`
+	InvoiceID        *uuid.UUID `gorm:"type:uuid"`
+	EventType        string     `gorm:"type:varchar(100);not null"`
+	Message          string     `gorm:"type:text;not null;default:''"`
+	Data             []byte     `gorm:"type:jsonb;not null;default:'{}'"`
+	OccurredAt       time.Time  `gorm:"not null;index:idx_billing_events_account"`
+
+	// Relacionamentos
+	BillingAccount BillingAccountEntity `gorm:"foreignKey:BillingAccountID;constraint:OnDelete:CASCADE"`
+}
+
+func (BillingEventEntity) TableName() string {
+	return "billing_events"
+}
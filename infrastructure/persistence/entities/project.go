@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 )
 
@@ -19,6 +20,8 @@ type ProjectEntity struct {
 	Configuration         map[string]interface{} `gorm:"type:jsonb;index:idx_projects_config,type:gin"`
 	Active                bool                   `gorm:"default:true;index:idx_projects_active;index:idx_projects_tenant_active,priority:2"`
 	SessionTimeoutMinutes int                    `gorm:"default:30;not null;index:idx_projects_timeout"` // Timeout padrão para todas as sessões do projeto
+	AgentAssignment       datatypes.JSON         `gorm:"type:jsonb"`                                     // project.AgentAssignmentConfig
+	RequireTwoFactor      bool                   `gorm:"default:false;not null"`                         // Todos os membros precisam de 2FA
 	CreatedAt             time.Time              `gorm:"autoCreateTime;index:idx_projects_created"`
 	UpdatedAt             time.Time              `gorm:"autoUpdateTime;index:idx_projects_updated"`
 	DeletedAt             gorm.DeletedAt         `gorm:"index:idx_projects_deleted"`
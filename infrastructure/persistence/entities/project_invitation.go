@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProjectInvitationEntity convite para um projeto (só o hash do token é guardado)
+type ProjectInvitationEntity struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID `gorm:"type:uuid;not null;index:idx_project_invitations_project"`
+	Email       string    `gorm:"type:text;not null"`
+	Role        string    `gorm:"type:varchar(50);not null"`
+	TokenHash   string    `gorm:"type:text;not null;uniqueIndex"`
+	InvitedBy   string    `gorm:"type:varchar(255);not null"`
+	Status      string    `gorm:"type:text;not null;default:pending"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	AcceptedBy  string    `gorm:"type:varchar(255);not null;default:''"`
+	RespondedAt *time.Time
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+
+	// Relacionamentos
+	Project ProjectEntity `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+}
+
+func (ProjectInvitationEntity) TableName() string {
+	return "project_invitations"
+}
@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ProjectRoleEntity papel definido pelo projeto (chave custom:<slug>)
+type ProjectRoleEntity struct {
+	ID          uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	ProjectID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:unique_project_role_key;index:idx_project_roles_project"`
+	Key         string         `gorm:"type:varchar(50);not null;uniqueIndex:unique_project_role_key"`
+	Name        string         `gorm:"type:varchar(100);not null;index:idx_project_roles_project"`
+	Description string         `gorm:"type:text;not null;default:''"`
+	Permissions pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	ClonedFrom  string         `gorm:"type:varchar(50);not null;default:''"`
+	CreatedBy   string         `gorm:"type:varchar(255);not null"`
+	CreatedAt   time.Time      `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time      `gorm:"autoUpdateTime"`
+
+	// Relacionamentos
+	Project ProjectEntity `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
+}
+
+func (ProjectRoleEntity) TableName() string {
+	return "project_roles"
+}
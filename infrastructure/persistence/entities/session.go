@@ -52,6 +52,7 @@ type SessionEntity struct {
 	Escalated   bool     `gorm:"default:false;index:idx_sessions_escalated"`
 	Converted   bool     `gorm:"default:false;index:idx_sessions_converted"`
 	OutcomeTags []string `gorm:"type:jsonb;index:idx_sessions_outcome_tags,type:gin"`
+	Priority    string   `gorm:"default:'normal';index:idx_sessions_priority"`
 
 	CreatedAt time.Time      `gorm:"autoCreateTime;index:idx_sessions_created"`
 	UpdatedAt time.Time      `gorm:"autoUpdateTime;index:idx_sessions_updated"`
@@ -0,0 +1,47 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// SessionAnalyticsHourlyEntity é o rollup de sessões usado pelos dashboards, em buckets de 15
+// minutos (a tabela mantém o nome original). Linhas são recalculadas por bucket pelo refresh incremental.
+type SessionAnalyticsHourlyEntity struct {
+	TenantID          string        `gorm:"primaryKey;index:idx_session_analytics_hourly_tenant_bucket,priority:1"`
+	BucketStart       time.Time     `gorm:"primaryKey;index:idx_session_analytics_hourly_tenant_bucket,priority:2"` // Quarto de hora UTC de started_at
+	ChannelTypeID     int           `gorm:"primaryKey;default:0"`                                                   // 0 = sem canal
+	AgentID           uuid.UUID     `gorm:"type:uuid;primaryKey"`                                                   // Primeiro agente (uuid.Nil = sem agente)
+	Status            string        `gorm:"primaryKey"`
+	SessionCount      int64         `gorm:"not null;default:0"`
+	ResolvedCount     int64         `gorm:"not null;default:0"`
+	EscalatedCount    int64         `gorm:"not null;default:0"`
+	ConvertedCount    int64         `gorm:"not null;default:0"`
+	MessageCount      int64         `gorm:"not null;default:0"`
+	DurationCount     int64         `gorm:"not null;default:0"`
+	DurationSum       int64         `gorm:"not null;default:0"`
+	ResponseCount     int64         `gorm:"not null;default:0"`
+	ResponseSum       int64         `gorm:"not null;default:0"`
+	ResponseHistogram pq.Int64Array `gorm:"type:bigint[];not null;default:'{}'"`
+	WaitCount         int64         `gorm:"not null;default:0"`
+	WaitSum           int64         `gorm:"not null;default:0"`
+	WaitHistogram     pq.Int64Array `gorm:"type:bigint[];not null;default:'{}'"`
+	RefreshedAt       time.Time     `gorm:"not null"`
+}
+
+func (SessionAnalyticsHourlyEntity) TableName() string {
+	return "session_analytics_hourly"
+}
+
+// AnalyticsRollupStateEntity guarda a marca d'água de cada rollup incremental
+type AnalyticsRollupStateEntity struct {
+	Name            string    `gorm:"primaryKey"`
+	LastRefreshedAt time.Time `gorm:"not null"`
+	UpdatedAt       time.Time `gorm:"autoUpdateTime"`
+}
+
+func (AnalyticsRollupStateEntity) TableName() string {
+	return "analytics_rollup_state"
+}
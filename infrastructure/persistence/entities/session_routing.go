@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// RoutingCursorEntity guarda o último agente que recebeu sessão no round-robin do projeto
+type RoutingCursorEntity struct {
+	ProjectID   uuid.UUID `gorm:"type:uuid;primaryKey"`
+	LastAgentID uuid.UUID `gorm:"type:uuid;not null"`
+	UpdatedAt   time.Time `gorm:"not null"`
+}
+
+func (RoutingCursorEntity) TableName() string {
+	return "routing_cursors"
+}
+
+// SessionRoutingQueueEntity sessão aguardando um agente elegível
+type SessionRoutingQueueEntity struct {
+	SessionID      uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	TenantID       string         `gorm:"not null"`
+	ProjectID      uuid.UUID      `gorm:"type:uuid;not null;index:idx_session_routing_queue_project,priority:1"`
+	ContactID      uuid.UUID      `gorm:"type:uuid;not null"`
+	Language       string         `gorm:"not null;default:''"`
+	RequiredSkills pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	EnqueuedAt     time.Time      `gorm:"not null;index:idx_session_routing_queue_enqueued;index:idx_session_routing_queue_project,priority:2"`
+	Attempts       int            `gorm:"not null;default:1"`
+	LastAttemptAt  time.Time      `gorm:"not null"`
+}
+
+func (SessionRoutingQueueEntity) TableName() string {
+	return "session_routing_queue"
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// SLAPolicyEntity política de SLA de um projeto (metas em segundos úteis)
+type SLAPolicyEntity struct {
+	ID                   uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID             string     `gorm:"not null"`
+	ProjectID            uuid.UUID  `gorm:"type:uuid;not null;index:idx_sla_policies_project"`
+	Name                 string     `gorm:"not null"`
+	PipelineID           *uuid.UUID `gorm:"type:uuid"`
+	ChannelTypeID        *int
+	Priority             *string
+	FirstResponseSeconds int            `gorm:"not null;default:0"`
+	NextResponseSeconds  int            `gorm:"not null;default:0"`
+	ResolutionSeconds    int            `gorm:"not null;default:0"`
+	WarningPercent       int            `gorm:"not null;default:80"`
+	BusinessHours        datatypes.JSON `gorm:"type:jsonb"` // businesshours.Schedule
+	Enabled              bool           `gorm:"not null;default:true"`
+	CreatedAt            time.Time      `gorm:"not null"`
+	UpdatedAt            time.Time      `gorm:"not null"`
+}
+
+func (SLAPolicyEntity) TableName() string {
+	return "sla_policies"
+}
+
+// SLAClockEntity relógio de uma métrica de SLA em uma sessão
+type SLAClockEntity struct {
+	ID             uuid.UUID `gorm:"type:uuid;primaryKey"`
+	TenantID       string    `gorm:"not null;index:idx_sla_clocks_compliance,priority:1"`
+	ProjectID      uuid.UUID `gorm:"type:uuid;not null"`
+	SessionID      uuid.UUID `gorm:"type:uuid;not null;index:idx_sla_clocks_session"`
+	PolicyID       uuid.UUID `gorm:"type:uuid;not null"`
+	Metric         string    `gorm:"not null"`
+	Status         string    `gorm:"not null;default:'running'"`
+	StartedAt      time.Time `gorm:"not null;index:idx_sla_clocks_compliance,priority:2"`
+	WarnAt         time.Time `gorm:"not null"`
+	DueAt          time.Time `gorm:"not null"`
+	WarnedAt       *time.Time
+	BreachedAt     *time.Time
+	StoppedAt      *time.Time
+	AgentID        *uuid.UUID `gorm:"type:uuid"`
+	ElapsedSeconds *int
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+func (SLAClockEntity) TableName() string {
+	return "sla_clocks"
+}
@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskEntity tarefa de um agente
+type TaskEntity struct {
+	ID          uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID    string     `gorm:"not null;index:idx_tasks_assignee,priority:1"`
+	Title       string     `gorm:"not null"`
+	Description string     `gorm:"not null;default:''"`
+	DueAt       *time.Time `gorm:"index:idx_tasks_assignee,priority:4"`
+	Timezone    string     `gorm:"not null;default:'UTC'"`
+	Priority    string     `gorm:"not null;default:'normal'"`
+	Status      string     `gorm:"not null;default:'open';index:idx_tasks_assignee,priority:3"`
+	Recurrence  string     `gorm:"not null;default:'none'"`
+	AssigneeID  *uuid.UUID `gorm:"type:uuid;index:idx_tasks_assignee,priority:2"`
+	ContactID   *uuid.UUID `gorm:"type:uuid;index:idx_tasks_contact"`
+	SessionID   *uuid.UUID `gorm:"type:uuid"`
+	PipelineID  *uuid.UUID `gorm:"type:uuid"`
+	CreatedBy   uuid.UUID  `gorm:"type:uuid;not null"`
+	OverdueAt   *time.Time
+	CompletedAt *time.Time
+	CompletedBy *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt   time.Time  `gorm:"not null"`
+	UpdatedAt   time.Time  `gorm:"not null"`
+	DeletedAt   *time.Time
+}
+
+func (TaskEntity) TableName() string {
+	return "tasks"
+}
@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// TeamEntity time de agentes de um projeto
+type TeamEntity struct {
+	ID           uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	TenantID     string         `gorm:"not null"`
+	ProjectID    uuid.UUID      `gorm:"type:uuid;not null;index:idx_teams_project"`
+	Name         string         `gorm:"not null"`
+	Description  string         `gorm:"not null;default:''"`
+	SupervisorID *uuid.UUID     `gorm:"type:uuid"`
+	MemberIDs    datatypes.JSON `gorm:"type:jsonb"` // []uuid.UUID
+	CreatedAt    time.Time      `gorm:"not null"`
+	UpdatedAt    time.Time      `gorm:"not null"`
+}
+
+func (TeamEntity) TableName() string {
+	return "teams"
+}
+
+// QueueEntity fila de atendimento de um time
+type QueueEntity struct {
+	ID                  uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID            string     `gorm:"not null"`
+	ProjectID           uuid.UUID  `gorm:"type:uuid;not null;index:idx_queues_project"`
+	TeamID              uuid.UUID  `gorm:"type:uuid;not null;index:idx_queues_team"`
+	Name                string     `gorm:"not null"`
+	Strategy            string     `gorm:"not null;default:'manual'"`
+	Priority            int        `gorm:"not null;default:0"`
+	Enabled             bool       `gorm:"not null;default:true"`
+	LastAssignedAgentID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt           time.Time  `gorm:"not null"`
+	UpdatedAt           time.Time  `gorm:"not null"`
+}
+
+func (QueueEntity) TableName() string {
+	return "queues"
+}
+
+// QueueItemEntity passagem de uma sessão por uma fila
+type QueueItemEntity struct {
+	ID         uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	TenantID   string     `gorm:"not null"`
+	ProjectID  uuid.UUID  `gorm:"type:uuid;not null"`
+	QueueID    uuid.UUID  `gorm:"type:uuid;not null"`
+	SessionID  uuid.UUID  `gorm:"type:uuid;not null"`
+	ContactID  uuid.UUID  `gorm:"type:uuid;not null"`
+	Priority   string     `gorm:"not null;default:'normal'"`
+	Status     string     `gorm:"not null;default:'waiting'"`
+	AgentID    *uuid.UUID `gorm:"type:uuid"`
+	EnqueuedAt time.Time  `gorm:"not null"`
+	ResolvedAt *time.Time
+}
+
+func (QueueItemEntity) TableName() string {
+	return "queue_items"
+}
@@ -0,0 +1,28 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageRecordEntity consumo de uma ação medida. (metric, source_id) é único: a mesma ação
+// reentregue pelo outbox não conta duas vezes.
+type UsageRecordEntity struct {
+	ID               uuid.UUID  `gorm:"type:uuid;primaryKey"`
+	BillingAccountID uuid.UUID  `gorm:"type:uuid;not null;index:idx_usage_records_account"`
+	ProjectID        uuid.UUID  `gorm:"type:uuid;not null;index:idx_usage_records_project"`
+	Metric           string     `gorm:"type:varchar(50);not null;uniqueIndex:unique_usage_record_source"`
+	SourceID         string     `gorm:"type:varchar(255);not null;uniqueIndex:unique_usage_record_source"`
+	Quantity         int64      `gorm:"not null"`
+	OccurredAt       time.Time  `gorm:"not null"`
+	ReportedAt       *time.Time `gorm:"index:idx_usage_records_unreported"`
+	CreatedAt        time.Time  `gorm:"autoCreateTime"`
+
+	// Relacionamentos
+	BillingAccount BillingAccountEntity `gorm:"foreignKey:BillingAccountID;constraint:OnDelete:CASCADE"`
+}
+
+func (UsageRecordEntity) TableName() string {
+	return "usage_records"
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
 )
 
@@ -20,6 +21,15 @@ type UserAPIKeyEntity struct {
 	UpdatedAt time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt gorm.DeletedAt `gorm:"index"`
 
+	// Chaves por projeto (nil = chave legada do usuário, gerada no login)
+	TenantID    *string        `gorm:"index"`
+	ProjectID   *uuid.UUID     `gorm:"type:uuid;index"`
+	Prefix      string         `gorm:"not null;default:''"` // Início do token, para o usuário reconhecer a chave
+	Permissions pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	AllowedIPs  pq.StringArray `gorm:"column:allowed_ips;type:text[];not null;default:'{}'"`
+	LastUsedIP  string         `gorm:"column:last_used_ip;not null;default:''"`
+	RevokedAt   *time.Time
+
 	// Relacionamentos
 	User UserEntity `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 }
@@ -0,0 +1,30 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// UserTwoFactorEntity inscrição TOTP do usuário (segredo cifrado com AES-256-GCM)
+type UserTwoFactorEntity struct {
+	ID                 uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	UserID             uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex"`
+	SecretCiphertext   string         `gorm:"type:text;not null"`
+	SecretNonce        string         `gorm:"type:text;not null"`
+	Status             string         `gorm:"not null"`
+	LastUsedStep       int64          `gorm:"not null;default:0"`
+	RecoveryCodeHashes pq.StringArray `gorm:"type:text[];not null;default:'{}'"`
+	ConfirmedAt        *time.Time
+	LastUsedAt         *time.Time
+	CreatedAt          time.Time `gorm:"autoCreateTime"`
+	UpdatedAt          time.Time `gorm:"autoUpdateTime"`
+
+	// Relacionamentos
+	User UserEntity `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
+}
+
+func (UserTwoFactorEntity) TableName() string {
+	return "user_two_factor"
+}
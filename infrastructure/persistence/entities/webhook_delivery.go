@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+)
+
+// WebhookDeliveryEntity tentativa de entrega de webhook (log por tentativa, com retenção)
+type WebhookDeliveryEntity struct {
+	ID             uuid.UUID      `gorm:"type:uuid;primaryKey"`
+	SubscriptionID uuid.UUID      `gorm:"type:uuid;not null;index:idx_webhook_deliveries_subscription,priority:1"`
+	TenantID       string         `gorm:"not null"`
+	ProjectID      uuid.UUID      `gorm:"type:uuid;not null"`
+	EventID        uuid.UUID      `gorm:"type:uuid;not null"`
+	EventType      string         `gorm:"not null"`
+	Trigger        string         `gorm:"not null;default:'event'"`
+	ReplayOf       *uuid.UUID     `gorm:"type:uuid"`
+	Attempt        int            `gorm:"not null;default:1"`
+	RequestURL     string         `gorm:"not null"`
+	RequestHeaders datatypes.JSON `gorm:"type:jsonb;not null;default:'{}'"`
+	RequestBody    string         `gorm:"not null;default:''"`
+	StatusCode     int            `gorm:"not null;default:0"`
+	ResponseBody   string         `gorm:"not null;default:''"`
+	LatencyMs      int64          `gorm:"not null;default:0"`
+	Error          string         `gorm:"not null;default:''"`
+	Success        bool           `gorm:"not null;default:false"`
+	CreatedAt      time.Time      `gorm:"not null;index:idx_webhook_deliveries_subscription,priority:2"`
+}
+
+func (WebhookDeliveryEntity) TableName() string {
+	return "webhook_deliveries"
+}
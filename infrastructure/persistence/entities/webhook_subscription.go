@@ -37,6 +37,15 @@ type WebhookSubscriptionEntity struct {
 	UpdatedAt       time.Time      `gorm:"autoUpdateTime"`
 	DeletedAt       gorm.DeletedAt `gorm:"index"`
 
+	// Desativação automática após falhas seguidas
+	ConsecutiveFailures int        `gorm:"not null;default:0"`
+	DisabledAt          *time.Time `gorm:""`
+	DisabledReason      string     `gorm:"not null;default:''"`
+
+	// Rotação de segredo: o anterior continua assinando até PreviousSecretExpiresAt
+	PreviousSecret          string     `gorm:"not null;default:''"`
+	PreviousSecretExpiresAt *time.Time `gorm:""`
+
 	// Relacionamentos
 	User    UserEntity    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE"`
 	Project ProjectEntity `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE"`
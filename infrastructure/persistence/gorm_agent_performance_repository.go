@@ -0,0 +1,246 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"gorm.io/gorm"
+)
+
+// GormAgentPerformanceRepository calcula métricas de agentes a partir de agent_sessions e messages.
+// Nada é lido dos contadores do agregado Agent (sessions_handled, average_response_ms).
+type GormAgentPerformanceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAgentPerformanceRepository(db *gorm.DB) agent.PerformanceRepository {
+	return &GormAgentPerformanceRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormAgentPerformanceRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+type agentPerformanceRow struct {
+	AgentID                    uuid.UUID
+	AgentName                  string
+	SessionsHandled            int64
+	SessionsResolved           int64
+	MessagesSent               int64
+	FirstResponseSamples       int64
+	MedianFirstResponseSeconds *float64
+	P90FirstResponseSeconds    *float64
+	TransfersIn                int64
+	TransfersOut               int64
+	AverageSentimentScore      *float64
+}
+
+type agentBreakdownRow struct {
+	AgentID uuid.UUID
+	Key     string
+	Total   int64
+}
+
+type agentHourRow struct {
+	AgentID uuid.UUID
+	Hour    int
+	Total   int64
+}
+
+func (r *GormAgentPerformanceRepository) GetPerformance(ctx context.Context, filter agent.PerformanceFilter) ([]*agent.PerformanceStats, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctes, args := agentPerformanceCTEs(filter)
+	db := r.getDB(ctx)
+
+	var rows []agentPerformanceRow
+	if err := db.Raw(ctes+`
+		SELECT
+			a.id AS agent_id,
+			a.name AS agent_name,
+			COALESCE(h.sessions_handled, 0) AS sessions_handled,
+			COALESCE(h.sessions_resolved, 0) AS sessions_resolved,
+			COALESCE(sent.messages_sent, 0) AS messages_sent,
+			COALESCE(frt.samples, 0) AS first_response_samples,
+			frt.median_seconds AS median_first_response_seconds,
+			frt.p90_seconds AS p90_first_response_seconds,
+			COALESCE(t.transfers_in, 0) AS transfers_in,
+			COALESCE(t.transfers_out, 0) AS transfers_out,
+			h.average_sentiment_score
+		FROM agent_scope a
+		LEFT JOIN (
+			SELECT hs.agent_id,
+				COUNT(*) AS sessions_handled,
+				COUNT(*) FILTER (WHERE s.resolved) AS sessions_resolved,
+				AVG(s.sentiment_score) AS average_sentiment_score
+			FROM handled_sessions hs
+			JOIN sessions s ON s.id = hs.session_id
+			GROUP BY hs.agent_id
+		) h ON h.agent_id = a.id
+		LEFT JOIN (
+			SELECT agent_id,
+				COUNT(*) FILTER (WHERE previous_agent_id IS NOT NULL AND previous_agent_id <> agent_id) AS transfers_in,
+				COUNT(*) FILTER (WHERE next_agent_id IS NOT NULL AND next_agent_id <> agent_id) AS transfers_out
+			FROM scoped_participations
+			GROUP BY agent_id
+		) t ON t.agent_id = a.id
+		LEFT JOIN (
+			SELECT agent_id,
+				COUNT(*) AS samples,
+				percentile_cont(0.5) WITHIN GROUP (ORDER BY seconds) AS median_seconds,
+				percentile_cont(0.9) WITHIN GROUP (ORDER BY seconds) AS p90_seconds
+			FROM first_responses
+			GROUP BY agent_id
+		) frt ON frt.agent_id = a.id
+		LEFT JOIN sent ON sent.agent_id = a.id
+		ORDER BY a.name`, args...).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute agent performance: %w", err)
+	}
+
+	stats := make([]*agent.PerformanceStats, 0, len(rows))
+	byAgent := make(map[uuid.UUID]*agent.PerformanceStats, len(rows))
+	for _, row := range rows {
+		s := &agent.PerformanceStats{
+			AgentID:                    row.AgentID,
+			AgentName:                  row.AgentName,
+			SessionsHandled:            row.SessionsHandled,
+			SessionsResolved:           row.SessionsResolved,
+			MessagesSent:               row.MessagesSent,
+			FirstResponseSamples:       row.FirstResponseSamples,
+			MedianFirstResponseSeconds: row.MedianFirstResponseSeconds,
+			P90FirstResponseSeconds:    row.P90FirstResponseSeconds,
+			TransfersIn:                row.TransfersIn,
+			TransfersOut:               row.TransfersOut,
+			AverageSentimentScore:      row.AverageSentimentScore,
+			SentimentBreakdown:         make(map[string]int64),
+			BusyHours:                  make(map[int]int64),
+		}
+		stats = append(stats, s)
+		byAgent[s.AgentID] = s
+	}
+
+	if len(stats) == 0 {
+		return stats, nil
+	}
+
+	var sentiments []agentBreakdownRow
+	if err := db.Raw(ctes+`
+		SELECT hs.agent_id, s.sentiment AS key, COUNT(*) AS total
+		FROM handled_sessions hs
+		JOIN sessions s ON s.id = hs.session_id
+		WHERE s.sentiment IS NOT NULL
+		GROUP BY hs.agent_id, s.sentiment`, args...).
+		Scan(&sentiments).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute agent sentiment: %w", err)
+	}
+	for _, row := range sentiments {
+		if s, ok := byAgent[row.AgentID]; ok {
+			s.SentimentBreakdown[row.Key] = row.Total
+		}
+	}
+
+	var hours []agentHourRow
+	hourArgs := append(append([]interface{}{}, args...), filter.Location.String())
+	if err := db.Raw(ctes+`
+		SELECT m.agent_id, EXTRACT(HOUR FROM m.timestamp AT TIME ZONE ?)::int AS hour, COUNT(*) AS total
+		FROM sent_messages m
+		GROUP BY 1, 2`, hourArgs...).
+		Scan(&hours).Error; err != nil {
+		return nil, fmt.Errorf("failed to compute agent busy hours: %w", err)
+	}
+	for _, row := range hours {
+		if s, ok := byAgent[row.AgentID]; ok {
+			s.BusyHours[row.Hour] = row.Total
+		}
+	}
+
+	return stats, nil
+}
+
+// agentPerformanceCTEs monta as CTEs compartilhadas pelas consultas de desempenho:
+//   - agent_scope: agentes do filtro
+//   - participations: entradas em sessões com o agente anterior/seguinte (transferências)
+//   - scoped_participations: participações dos agentes do escopo iniciadas no período
+//   - handled_sessions: pares (agente, sessão) distintos
+//   - first_responses: segundos entre a entrada do agente (ou a primeira mensagem do contato,
+//     se posterior) e a primeira mensagem enviada pelo agente na sessão
+//   - sent_messages / sent: mensagens enviadas pelos agentes no período
+func agentPerformanceCTEs(filter agent.PerformanceFilter) (string, []interface{}) {
+	scope := []string{"a.tenant_id = ?", "a.deleted_at IS NULL"}
+	args := []interface{}{filter.TenantID}
+
+	if filter.ProjectID != nil {
+		scope = append(scope, "a.project_id = ?")
+		args = append(args, *filter.ProjectID)
+	}
+	if len(filter.AgentIDs) > 0 {
+		scope = append(scope, "a.id IN ?")
+		args = append(args, filter.AgentIDs)
+	}
+
+	start, end := filter.StartDate.UTC(), filter.EndDate.UTC()
+	args = append(args, start, end, start, end, start, end)
+
+	return `WITH agent_scope AS (
+			SELECT a.id, a.name FROM agents a WHERE ` + strings.Join(scope, " AND ") + `
+		),
+		participations AS (
+			SELECT ags.agent_id, ags.session_id, ags.joined_at,
+				LAG(ags.agent_id) OVER w AS previous_agent_id,
+				LEAD(ags.agent_id) OVER w AS next_agent_id
+			FROM agent_sessions ags
+			WHERE ags.deleted_at IS NULL
+				AND ags.session_id IN (
+					SELECT x.session_id FROM agent_sessions x
+					WHERE x.agent_id IN (SELECT id FROM agent_scope)
+						AND x.deleted_at IS NULL
+						AND x.joined_at >= ? AND x.joined_at < ?
+				)
+			WINDOW w AS (PARTITION BY ags.session_id ORDER BY ags.joined_at)
+		),
+		scoped_participations AS (
+			SELECT p.* FROM participations p
+			JOIN agent_scope a ON a.id = p.agent_id
+			WHERE p.joined_at >= ? AND p.joined_at < ?
+		),
+		handled_sessions AS (
+			SELECT DISTINCT sp.agent_id, sp.session_id
+			FROM scoped_participations sp
+			JOIN sessions s ON s.id = sp.session_id AND s.deleted_at IS NULL
+		),
+		first_responses AS (
+			SELECT sp.agent_id,
+				EXTRACT(EPOCH FROM (fr.first_reply - GREATEST(sp.joined_at, fi.first_inbound))) AS seconds
+			FROM scoped_participations sp
+			CROSS JOIN LATERAL (
+				SELECT MIN(m.timestamp) AS first_reply FROM messages m
+				WHERE m.session_id = sp.session_id AND m.agent_id = sp.agent_id
+					AND m.from_me AND m.deleted_at IS NULL AND m.timestamp >= sp.joined_at
+			) fr
+			CROSS JOIN LATERAL (
+				SELECT MIN(m.timestamp) AS first_inbound FROM messages m
+				WHERE m.session_id = sp.session_id AND NOT m.from_me AND m.deleted_at IS NULL
+			) fi
+			WHERE fr.first_reply IS NOT NULL AND fi.first_inbound IS NOT NULL AND fi.first_inbound <= fr.first_reply
+		),
+		sent_messages AS (
+			SELECT m.agent_id, m.timestamp FROM messages m
+			JOIN agent_scope a ON a.id = m.agent_id
+			WHERE m.from_me AND m.deleted_at IS NULL AND m.timestamp >= ? AND m.timestamp < ?
+		),
+		sent AS (
+			SELECT agent_id, COUNT(*) AS messages_sent FROM sent_messages GROUP BY agent_id
+		)
+	`, args
+}
@@ -0,0 +1,30 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+func TestAgentPerformanceCTEs_BindsArgsInOrder(t *testing.T) {
+	start := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	projectID := uuid.New()
+	agentIDs := []uuid.UUID{uuid.New()}
+
+	sql, args := agentPerformanceCTEs(agent.PerformanceFilter{
+		TenantID:  "tenant-1",
+		ProjectID: &projectID,
+		AgentIDs:  agentIDs,
+		StartDate: start,
+		EndDate:   end,
+	})
+
+	assert.Contains(t, sql, "WHERE a.tenant_id = ? AND a.deleted_at IS NULL AND a.project_id = ? AND a.id IN ?")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{"tenant-1", projectID, agentIDs, start, end, start, end, start, end}, args)
+}
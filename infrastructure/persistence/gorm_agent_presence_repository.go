@@ -0,0 +1,152 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"gorm.io/gorm"
+)
+
+// GormAgentPresenceRepository persiste os heartbeats e o status manual dos agentes.
+// Cada servidor grava os heartbeats dos seus clientes, então a presença vale para o cluster todo.
+type GormAgentPresenceRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAgentPresenceRepository(db *gorm.DB) agent.PresenceRepository {
+	return &GormAgentPresenceRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormAgentPresenceRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormAgentPresenceRepository) Touch(ctx context.Context, tenantID string, userID uuid.UUID, at time.Time, activity bool) error {
+	sql, args := touchPresenceQuery(tenantID, userID, at, activity)
+	if err := r.getDB(ctx).Exec(sql, args...).Error; err != nil {
+		return fmt.Errorf("failed to record agent heartbeat: %w", err)
+	}
+	return nil
+}
+
+// touchPresenceQuery faz upsert da presença de todos os agentes humanos do usuário no tenant.
+// GREATEST ignora NULL e impede que heartbeats atrasados de outro servidor voltem o relógio.
+func touchPresenceQuery(tenantID string, userID uuid.UUID, at time.Time, activity bool) (string, []interface{}) {
+	var activityAt *time.Time
+	if activity {
+		activityAt = &at
+	}
+
+	return `INSERT INTO agent_presence (agent_id, tenant_id, last_seen_at, last_activity_at, updated_at)
+		SELECT a.id, a.tenant_id, ?, ?::timestamptz, ? FROM agents a
+		WHERE a.user_id = ? AND a.tenant_id = ? AND a.type = 'human' AND a.deleted_at IS NULL
+		ON CONFLICT (agent_id) DO UPDATE SET
+			last_seen_at = GREATEST(agent_presence.last_seen_at, EXCLUDED.last_seen_at),
+			last_activity_at = GREATEST(agent_presence.last_activity_at, EXCLUDED.last_activity_at),
+			updated_at = EXCLUDED.updated_at`,
+		[]interface{}{at, activityAt, at, userID, tenantID}
+}
+
+func (r *GormAgentPresenceRepository) SetManualStatus(ctx context.Context, agentID uuid.UUID, tenantID string, status *agent.AgentStatus, at time.Time) error {
+	var value *string
+	var statusAt *time.Time
+	if status != nil {
+		s := string(*status)
+		value = &s
+		statusAt = &at
+	}
+
+	err := r.getDB(ctx).Exec(`
+		INSERT INTO agent_presence (agent_id, tenant_id, manual_status, manual_status_at, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (agent_id) DO UPDATE SET
+			manual_status = EXCLUDED.manual_status,
+			manual_status_at = EXCLUDED.manual_status_at,
+			updated_at = EXCLUDED.updated_at`,
+		agentID, tenantID, value, statusAt, at).Error
+	if err != nil {
+		return fmt.Errorf("failed to save manual status: %w", err)
+	}
+	return nil
+}
+
+func (r *GormAgentPresenceRepository) FindByAgent(ctx context.Context, agentID uuid.UUID) (*agent.Presence, error) {
+	var entity entities.AgentPresenceEntity
+	err := r.getDB(ctx).Where("agent_id = ?", agentID).First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &agent.Presence{AgentID: agentID}, nil
+		}
+		return nil, fmt.Errorf("failed to load agent presence: %w", err)
+	}
+	presence := presenceEntityToDomain(entity)
+	return &presence, nil
+}
+
+func (r *GormAgentPresenceRepository) FindByTenant(ctx context.Context, tenantID string) ([]agent.Presence, error) {
+	var rows []entities.AgentPresenceEntity
+	if err := r.getDB(ctx).Where("tenant_id = ?", tenantID).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load agent presence: %w", err)
+	}
+
+	presences := make([]agent.Presence, len(rows))
+	for i, row := range rows {
+		presences[i] = presenceEntityToDomain(row)
+	}
+	return presences, nil
+}
+
+func (r *GormAgentPresenceRepository) FindSweepCandidates(ctx context.Context, connectedSince time.Time, limit int) ([]agent.Presence, error) {
+	sql, args := presenceSweepQuery(connectedSince, limit)
+
+	var rows []entities.AgentPresenceEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load presence sweep candidates: %w", err)
+	}
+
+	presences := make([]agent.Presence, len(rows))
+	for i, row := range rows {
+		presences[i] = presenceEntityToDomain(row)
+	}
+	return presences, nil
+}
+
+// presenceSweepQuery seleciona agentes humanos que ainda aparecem como presentes
+// ou que enviaram heartbeat recentemente (incluindo os que nunca tiveram linha de presença)
+func presenceSweepQuery(connectedSince time.Time, limit int) (string, []interface{}) {
+	return `SELECT
+			a.id AS agent_id, a.tenant_id,
+			p.last_seen_at, p.last_activity_at, p.manual_status, p.manual_status_at,
+			COALESCE(p.updated_at, a.updated_at) AS updated_at
+		FROM agents a
+		LEFT JOIN agent_presence p ON p.agent_id = a.id
+		WHERE a.deleted_at IS NULL AND a.type = 'human' AND a.user_id IS NOT NULL
+			AND (a.status <> 'offline' OR p.last_seen_at >= ?)
+		ORDER BY a.id
+		LIMIT ?`, []interface{}{connectedSince, limit}
+}
+
+func presenceEntityToDomain(entity entities.AgentPresenceEntity) agent.Presence {
+	presence := agent.Presence{
+		AgentID:        entity.AgentID,
+		TenantID:       entity.TenantID,
+		LastSeenAt:     entity.LastSeenAt,
+		LastActivityAt: entity.LastActivityAt,
+		ManualStatusAt: entity.ManualStatusAt,
+	}
+	if entity.ManualStatus != nil {
+		status := agent.AgentStatus(*entity.ManualStatus)
+		presence.ManualStatus = &status
+	}
+	return presence
+}
@@ -0,0 +1,49 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+func TestTouchPresenceQuery(t *testing.T) {
+	userID := uuid.New()
+	at := time.Now()
+
+	sql, args := touchPresenceQuery("tenant-1", userID, at, false)
+
+	assert.Contains(t, sql, "a.user_id = ? AND a.tenant_id = ?")
+	assert.Contains(t, sql, "GREATEST(agent_presence.last_seen_at, EXCLUDED.last_seen_at)")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Nil(t, args[1], "heartbeat without activity keeps last_activity_at")
+
+	_, args = touchPresenceQuery("tenant-1", userID, at, true)
+	assert.Equal(t, &at, args[1])
+}
+
+func TestPresenceSweepQuery(t *testing.T) {
+	since := time.Now().Add(-90 * time.Second)
+
+	sql, args := presenceSweepQuery(since, 1000)
+
+	assert.Contains(t, sql, "LEFT JOIN agent_presence p")
+	assert.Contains(t, sql, "a.status <> 'offline' OR p.last_seen_at >= ?")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{since, 1000}, args)
+}
+
+func TestPresenceEntityToDomain(t *testing.T) {
+	busy := "busy"
+	entity := entities.AgentPresenceEntity{AgentID: uuid.New(), TenantID: "tenant-1", ManualStatus: &busy}
+
+	presence := presenceEntityToDomain(entity)
+
+	assert.Equal(t, entity.AgentID, presence.AgentID)
+	assert.Equal(t, agent.AgentStatusBusy, *presence.ManualStatus)
+	assert.Nil(t, presence.LastSeenAt)
+}
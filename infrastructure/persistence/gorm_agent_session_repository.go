@@ -0,0 +1,163 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	domainShared "github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent_session"
+	"gorm.io/gorm"
+)
+
+// GormAgentSessionRepository persiste participações de agentes em sessões (agent_sessions)
+type GormAgentSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAgentSessionRepository(db *gorm.DB) agent_session.Repository {
+	return &GormAgentSessionRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormAgentSessionRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormAgentSessionRepository) Create(ctx context.Context, as *agent_session.AgentSession) error {
+	return r.getDB(ctx).Create(r.domainToEntity(as)).Error
+}
+
+func (r *GormAgentSessionRepository) Update(ctx context.Context, as *agent_session.AgentSession) error {
+	entity := r.domainToEntity(as)
+	result := r.getDB(ctx).Model(&entities.AgentSessionEntity{}).
+		Where("id = ?", entity.ID).
+		Updates(map[string]interface{}{
+			"role_in_session": entity.RoleInSession,
+			"left_at":         entity.LeftAt,
+			"is_active":       entity.IsActive,
+			"metadata":        entity.Metadata,
+			"updated_at":      entity.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return domainShared.NewNotFoundError("agent_session", entity.ID.String())
+	}
+	return nil
+}
+
+func (r *GormAgentSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*agent_session.AgentSession, error) {
+	var entity entities.AgentSessionEntity
+	if err := r.getDB(ctx).First(&entity, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainShared.NewNotFoundError("agent_session", id.String())
+		}
+		return nil, err
+	}
+	return r.entityToDomain(&entity), nil
+}
+
+func (r *GormAgentSessionRepository) FindActiveBySessionID(ctx context.Context, sessionID uuid.UUID) ([]*agent_session.AgentSession, error) {
+	var rows []entities.AgentSessionEntity
+	if err := r.getDB(ctx).
+		Where("session_id = ? AND is_active = ?", sessionID, true).
+		Order("joined_at ASC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return r.entitiesToDomain(rows), nil
+}
+
+func (r *GormAgentSessionRepository) FindByAgentID(ctx context.Context, agentID uuid.UUID) ([]*agent_session.AgentSession, error) {
+	var rows []entities.AgentSessionEntity
+	if err := r.getDB(ctx).
+		Where("agent_id = ?", agentID).
+		Order("joined_at DESC").
+		Find(&rows).Error; err != nil {
+		return nil, err
+	}
+	return r.entitiesToDomain(rows), nil
+}
+
+func (r *GormAgentSessionRepository) FindByAgentAndSession(ctx context.Context, agentID, sessionID uuid.UUID) (*agent_session.AgentSession, error) {
+	var entity entities.AgentSessionEntity
+	if err := r.getDB(ctx).
+		Where("agent_id = ? AND session_id = ?", agentID, sessionID).
+		Order("joined_at DESC").
+		First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, domainShared.NewNotFoundError("agent_session", agentID.String()+"/"+sessionID.String())
+		}
+		return nil, err
+	}
+	return r.entityToDomain(&entity), nil
+}
+
+func (r *GormAgentSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return r.getDB(ctx).Delete(&entities.AgentSessionEntity{}, "id = ?", id).Error
+}
+
+func (r *GormAgentSessionRepository) domainToEntity(as *agent_session.AgentSession) *entities.AgentSessionEntity {
+	var role *string
+	if as.RoleInSession() != nil {
+		value := as.RoleInSession().String()
+		role = &value
+	}
+
+	metadataJSON, _ := json.Marshal(as.Metadata())
+
+	return &entities.AgentSessionEntity{
+		ID:            as.ID(),
+		AgentID:       as.AgentID(),
+		SessionID:     as.SessionID(),
+		RoleInSession: role,
+		JoinedAt:      as.JoinedAt(),
+		LeftAt:        as.LeftAt(),
+		IsActive:      as.IsActive(),
+		Metadata:      metadataJSON,
+		CreatedAt:     as.CreatedAt(),
+		UpdatedAt:     as.UpdatedAt(),
+	}
+}
+
+func (r *GormAgentSessionRepository) entityToDomain(entity *entities.AgentSessionEntity) *agent_session.AgentSession {
+	var role *agent_session.RoleInSession
+	if entity.RoleInSession != nil {
+		value := agent_session.RoleInSession(*entity.RoleInSession)
+		role = &value
+	}
+
+	var metadata map[string]interface{}
+	if len(entity.Metadata) > 0 {
+		_ = json.Unmarshal(entity.Metadata, &metadata)
+	}
+
+	return agent_session.ReconstructAgentSession(
+		entity.ID,
+		entity.AgentID,
+		entity.SessionID,
+		role,
+		entity.JoinedAt,
+		entity.LeftAt,
+		entity.IsActive,
+		metadata,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
+
+func (r *GormAgentSessionRepository) entitiesToDomain(rows []entities.AgentSessionEntity) []*agent_session.AgentSession {
+	result := make([]*agent_session.AgentSession, 0, len(rows))
+	for i := range rows {
+		result = append(result, r.entityToDomain(&rows[i]))
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormAPIKeyRepository persiste as API keys por projeto em user_api_keys (project_id NOT NULL)
+type GormAPIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAPIKeyRepository(db *gorm.DB) apikey.Repository {
+	return &GormAPIKeyRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormAPIKeyRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormAPIKeyRepository) Save(ctx context.Context, k *apikey.APIKey) error {
+	entity := apiKeyToEntity(k)
+	// Depois de criada, a chave só muda ao ser revogada; last_used* são mantidos por RecordUsage
+	err := r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"active", "revoked_at", "updated_at"}),
+	}).Create(entity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save api key: %w", err)
+	}
+	return nil
+}
+
+func (r *GormAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*apikey.APIKey, error) {
+	return r.findOne(ctx, "id = ? AND project_id IS NOT NULL", id)
+}
+
+func (r *GormAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	return r.findOne(ctx, "key_hash = ? AND project_id IS NOT NULL", keyHash)
+}
+
+func (r *GormAPIKeyRepository) findOne(ctx context.Context, where string, args ...interface{}) (*apikey.APIKey, error) {
+	var entity entities.UserAPIKeyEntity
+	if err := r.getDB(ctx).Where(where, args...).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apikey.ErrAPIKeyNotFound
+		}
+		return nil, fmt.Errorf("failed to load api key: %w", err)
+	}
+	return apiKeyToDomain(entity), nil
+}
+
+func (r *GormAPIKeyRepository) ListByProject(ctx context.Context, projectID uuid.UUID, includeRevoked bool) ([]*apikey.APIKey, error) {
+	query := r.getDB(ctx).Where("project_id = ?", projectID)
+	if !includeRevoked {
+		query = query.Where("revoked_at IS NULL")
+	}
+
+	var rows []entities.UserAPIKeyEntity
+	if err := query.Order("created_at DESC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+
+	keys := make([]*apikey.APIKey, len(rows))
+	for i, row := range rows {
+		keys[i] = apiKeyToDomain(row)
+	}
+	return keys, nil
+}
+
+func (r *GormAPIKeyRepository) RecordUsage(ctx context.Context, usages []apikey.Usage) error {
+	if len(usages) == 0 {
+		return nil
+	}
+	query, args := apiKeyUsageUpdate(usages)
+	if err := r.getDB(ctx).Exec(query, args...).Error; err != nil {
+		return fmt.Errorf("failed to record api key usage: %w", err)
+	}
+	return nil
+}
+
+// apiKeyUsageUpdate grava o último uso de várias chaves num único UPDATE. A condição em
+// last_used evita que um lote atrasado (ex: outra réplica) sobrescreva um uso mais recente.
+func apiKeyUsageUpdate(usages []apikey.Usage) (string, []interface{}) {
+	values := make([]string, len(usages))
+	args := make([]interface{}, 0, len(usages)*3)
+	for i, u := range usages {
+		values[i] = "(?::uuid, ?::timestamptz, ?)"
+		args = append(args, u.KeyID, u.At.UTC(), u.IP)
+	}
+
+	query := `UPDATE user_api_keys AS k
+SET last_used = u.used_at, last_used_ip = u.ip
+FROM (VALUES ` + strings.Join(values, ", ") + `) AS u(id, used_at, ip)
+WHERE k.id = u.id AND (k.last_used IS NULL OR k.last_used < u.used_at)`
+	return query, args
+}
+
+func apiKeyToEntity(k *apikey.APIKey) *entities.UserAPIKeyEntity {
+	tenantID := k.TenantID()
+	projectID := k.ProjectID()
+
+	permissions := make(pq.StringArray, 0, len(k.Permissions()))
+	for _, p := range k.Permissions() {
+		permissions = append(permissions, string(p))
+	}
+
+	return &entities.UserAPIKeyEntity{
+		ID:          k.ID(),
+		UserID:      k.UserID(),
+		Name:        k.Name(),
+		KeyHash:     k.KeyHash(),
+		Active:      k.RevokedAt() == nil,
+		LastUsed:    k.LastUsedAt(),
+		ExpiresAt:   k.ExpiresAt(),
+		CreatedAt:   k.CreatedAt(),
+		UpdatedAt:   k.UpdatedAt(),
+		TenantID:    &tenantID,
+		ProjectID:   &projectID,
+		Prefix:      k.Prefix(),
+		Permissions: permissions,
+		AllowedIPs:  pq.StringArray(k.AllowedIPs()),
+		LastUsedIP:  k.LastUsedIP(),
+		RevokedAt:   k.RevokedAt(),
+	}
+}
+
+func apiKeyToDomain(e entities.UserAPIKeyEntity) *apikey.APIKey {
+	var tenantID string
+	if e.TenantID != nil {
+		tenantID = *e.TenantID
+	}
+	var projectID uuid.UUID
+	if e.ProjectID != nil {
+		projectID = *e.ProjectID
+	}
+
+	permissions := make([]project_member.Permission, len(e.Permissions))
+	for i, p := range e.Permissions {
+		permissions[i] = project_member.Permission(p)
+	}
+
+	return apikey.ReconstructAPIKey(
+		e.ID,
+		tenantID,
+		projectID,
+		e.UserID,
+		e.Name,
+		e.Prefix,
+		e.KeyHash,
+		permissions,
+		[]string(e.AllowedIPs),
+		e.ExpiresAt,
+		e.LastUsed,
+		e.LastUsedIP,
+		e.RevokedAt,
+		e.CreatedAt,
+		e.UpdatedAt,
+	)
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+func TestAPIKeyUsageUpdate(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+	at := time.Date(2026, 5, 4, 12, 0, 0, 0, time.FixedZone("BRT", -3*3600))
+
+	query, args := apiKeyUsageUpdate([]apikey.Usage{
+		{KeyID: first, At: at, IP: "203.0.113.7"},
+		{KeyID: second, At: at.Add(time.Minute), IP: "10.0.0.1"},
+	})
+
+	assert.Contains(t, query, "FROM (VALUES (?::uuid, ?::timestamptz, ?), (?::uuid, ?::timestamptz, ?)) AS u(id, used_at, ip)")
+	assert.Contains(t, query, "k.last_used IS NULL OR k.last_used < u.used_at", "never moves last_used backwards")
+	assert.NotContains(t, query, "updated_at")
+	assert.Equal(t, strings.Count(query, "?"), len(args))
+	assert.Equal(t, []interface{}{first, at.UTC(), "203.0.113.7", second, at.Add(time.Minute).UTC(), "10.0.0.1"}, args)
+}
+
+func TestAPIKeyMapping(t *testing.T) {
+	expiresAt := time.Now().Add(24 * time.Hour).UTC()
+	k, token, err := apikey.NewAPIKey("tenant-1", uuid.New(), uuid.New(), apikey.Details{
+		Name:        "Zapier",
+		Permissions: []project_member.Permission{project_member.PermissionViewContacts},
+		AllowedIPs:  []string{"10.0.0.0/8"},
+		ExpiresAt:   &expiresAt,
+	})
+	require.NoError(t, err)
+
+	entity := apiKeyToEntity(k)
+	assert.True(t, entity.Active)
+	assert.Equal(t, apikey.HashToken(token), entity.KeyHash)
+	require.NotNil(t, entity.ProjectID)
+	assert.Equal(t, k.ProjectID(), *entity.ProjectID)
+
+	restored := apiKeyToDomain(*entity)
+	assert.Equal(t, k.ID(), restored.ID())
+	assert.Equal(t, "tenant-1", restored.TenantID())
+	assert.Equal(t, k.Prefix(), restored.Prefix())
+	assert.Equal(t, k.Permissions(), restored.Permissions())
+	assert.Equal(t, []string{"10.0.0.0/8"}, restored.AllowedIPs())
+	assert.Equal(t, k.ExpiresAt(), restored.ExpiresAt())
+
+	require.NoError(t, k.Revoke(uuid.New()))
+	assert.False(t, apiKeyToEntity(k).Active, "revoked keys are inactive for the legacy lookup too")
+}
@@ -0,0 +1,217 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GormAuditLogRepository trilha de auditoria em audit_logs (append-only; UPDATE e DELETE são
+// bloqueados por trigger)
+type GormAuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuditLogRepository(db *gorm.DB) audit.Repository {
+	return &GormAuditLogRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormAuditLogRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Append lê o último registro do projeto e grava o próximo sob um advisory lock do projeto:
+// gravações concorrentes do mesmo projeto entram na cadeia uma de cada vez
+func (r *GormAuditLogRepository) Append(ctx context.Context, entry *audit.Entry) error {
+	appendFn := func(db *gorm.DB) error {
+		if err := db.Exec("SELECT pg_advisory_xact_lock(hashtextextended(?, 0))", "audit_logs:"+entry.ProjectID().String()).Error; err != nil {
+			return fmt.Errorf("failed to lock audit chain: %w", err)
+		}
+
+		var prev *audit.Entry
+		var last entities.AuditLogEntity
+		err := db.Where("project_id = ?", entry.ProjectID()).Order("sequence DESC").Take(&last).Error
+		switch {
+		case err == nil:
+			prev = auditLogToDomain(last)
+		case !errors.Is(err, gorm.ErrRecordNotFound):
+			return fmt.Errorf("failed to load audit chain head: %w", err)
+		}
+
+		if err := entry.Link(prev); err != nil {
+			return err
+		}
+		entity, err := auditLogToEntity(entry)
+		if err != nil {
+			return err
+		}
+		if err := db.Create(entity).Error; err != nil {
+			return fmt.Errorf("failed to insert audit entry: %w", err)
+		}
+		return nil
+	}
+
+	// Dentro da transação do use case o lock vale até o commit dela
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return appendFn(tx.WithContext(ctx))
+	}
+	return r.db.WithContext(ctx).Transaction(appendFn)
+}
+
+func (r *GormAuditLogRepository) List(ctx context.Context, filter audit.Filter) ([]*audit.Entry, int64, error) {
+	query := auditLogFilterQuery(r.getDB(ctx).Model(&entities.AuditLogEntity{}), filter)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit entries: %w", err)
+	}
+
+	var rows []entities.AuditLogEntity
+	query = query.Order("sequence DESC")
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if filter.Offset > 0 {
+		query = query.Offset(filter.Offset)
+	}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list audit entries: %w", err)
+	}
+
+	result := make([]*audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, auditLogToDomain(row))
+	}
+	return result, total, nil
+}
+
+func (r *GormAuditLogRepository) Chain(ctx context.Context, projectID uuid.UUID, afterSequence int64, limit int) ([]*audit.Entry, error) {
+	var rows []entities.AuditLogEntity
+	err := r.getDB(ctx).
+		Where("project_id = ? AND sequence > ?", projectID, afterSequence).
+		Order("sequence ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load audit chain: %w", err)
+	}
+
+	result := make([]*audit.Entry, 0, len(rows))
+	for _, row := range rows {
+		result = append(result, auditLogToDomain(row))
+	}
+	return result, nil
+}
+
+func auditLogFilterQuery(query *gorm.DB, filter audit.Filter) *gorm.DB {
+	query = query.Where("project_id = ?", filter.ProjectID)
+	if filter.ActorType != "" {
+		query = query.Where("actor_type = ?", string(filter.ActorType))
+	}
+	if filter.ActorID != "" {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if filter.CorrelationID != "" {
+		query = query.Where("correlation_id = ?", filter.CorrelationID)
+	}
+	if filter.Since != nil {
+		query = query.Where("occurred_at >= ?", *filter.Since)
+	}
+	if filter.Until != nil {
+		query = query.Where("occurred_at < ?", *filter.Until)
+	}
+	if filter.BeforeSequence > 0 {
+		query = query.Where("sequence < ?", filter.BeforeSequence)
+	}
+	return query
+}
+
+func auditLogToEntity(e *audit.Entry) (*entities.AuditLogEntity, error) {
+	entity := &entities.AuditLogEntity{
+		ID:            e.ID(),
+		ProjectID:     e.ProjectID(),
+		TenantID:      e.TenantID(),
+		Sequence:      e.Sequence(),
+		ActorType:     string(e.Actor().Type),
+		ActorID:       e.Actor().ID,
+		ActorLabel:    e.Actor().Label,
+		Action:        e.Action(),
+		ResourceType:  e.ResourceType(),
+		ResourceID:    e.ResourceID(),
+		Status:        e.Status(),
+		IPAddress:     e.IP(),
+		UserAgent:     e.UserAgent(),
+		CorrelationID: e.CorrelationID(),
+		OccurredAt:    e.OccurredAt(),
+		PrevHash:      e.PrevHash(),
+		Hash:          e.Hash(),
+	}
+	if len(e.Changes()) > 0 {
+		raw, err := json.Marshal(e.Changes())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal audit changes: %w", err)
+		}
+		entity.Changes = datatypes.JSON(raw)
+	}
+	if len(e.Metadata()) > 0 {
+		raw, err := json.Marshal(e.Metadata())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal audit metadata: %w", err)
+		}
+		entity.Metadata = datatypes.JSON(raw)
+	}
+	return entity, nil
+}
+
+// auditLogToDomain não falha em JSON inválido: o registro volta sem o campo e a verificação da
+// cadeia acusa a divergência
+func auditLogToDomain(entity entities.AuditLogEntity) *audit.Entry {
+	var changes map[string]audit.Change
+	if len(entity.Changes) > 0 {
+		_ = json.Unmarshal(entity.Changes, &changes)
+	}
+	var metadata map[string]string
+	if len(entity.Metadata) > 0 {
+		_ = json.Unmarshal(entity.Metadata, &metadata)
+	}
+	return audit.ReconstructEntry(
+		entity.ID,
+		entity.ProjectID,
+		entity.TenantID,
+		entity.Sequence,
+		audit.Actor{Type: audit.ActorType(entity.ActorType), ID: entity.ActorID, Label: entity.ActorLabel},
+		entity.Action,
+		entity.ResourceType,
+		entity.ResourceID,
+		entity.Status,
+		entity.IPAddress,
+		entity.UserAgent,
+		entity.CorrelationID,
+		changes,
+		metadata,
+		entity.OccurredAt,
+		entity.PrevHash,
+		entity.Hash,
+	)
+}
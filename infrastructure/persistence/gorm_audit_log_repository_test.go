@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/audit"
+)
+
+func TestAuditLogMapping(t *testing.T) {
+	projectID := uuid.New()
+	first, err := audit.NewEntry(audit.Record{
+		ProjectID: projectID,
+		Actor:     audit.Actor{Type: audit.ActorUser, ID: uuid.NewString(), Label: "ana@example.com"},
+		Action:    "channels.create",
+	}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, first.Link(nil))
+
+	second, err := audit.NewEntry(audit.Record{
+		ProjectID:     projectID,
+		TenantID:      "tenant-1",
+		Actor:         audit.Actor{Type: audit.ActorAPIKey, ID: uuid.NewString(), Label: "vtr_abc123"},
+		Action:        "webhook-subscriptions.update",
+		ResourceType:  "webhook-subscriptions",
+		ResourceID:    uuid.NewString(),
+		Status:        200,
+		IP:            "203.0.113.9",
+		UserAgent:     "curl/8.0",
+		CorrelationID: "corr-1",
+		Changes: map[string]audit.Change{
+			"retry_count": {Before: 3, After: 5},
+			"events":      {Before: []string{"contact.created"}, After: []string{"contact.created", "message.received"}},
+		},
+		Metadata: map[string]string{"route": "/api/v1/webhook-subscriptions/:id"},
+	}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, second.Link(first))
+
+	firstEntity, err := auditLogToEntity(first)
+	require.NoError(t, err)
+	assert.Nil(t, firstEntity.Changes)
+	assert.Nil(t, firstEntity.Metadata)
+
+	entity, err := auditLogToEntity(second)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), entity.Sequence)
+	assert.Equal(t, first.Hash(), entity.PrevHash)
+
+	restored := auditLogToDomain(*entity)
+	assert.Equal(t, second.Hash(), restored.ComputeHash(), "the stored row must still match its hash")
+
+	verifier := audit.NewChainVerifier()
+	assert.True(t, verifier.Add([]*audit.Entry{auditLogToDomain(*firstEntity), restored}))
+	assert.Equal(t, int64(2), verifier.Result().Checked)
+}
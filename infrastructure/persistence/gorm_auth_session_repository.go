@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormAuthSessionRepository persiste as sessões de login em auth_sessions
+type GormAuthSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewGormAuthSessionRepository(db *gorm.DB) authsession.Repository {
+	return &GormAuthSessionRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormAuthSessionRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormAuthSessionRepository) Save(ctx context.Context, s *authsession.Session) error {
+	entity := authSessionToEntity(s)
+	err := r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"refresh_token_hash", "previous_refresh_token_hash", "ip_address",
+			"last_refreshed_at", "revoked_at", "revoke_reason", "two_factor_verified_at",
+			"reauthenticated_at", "updated_at",
+		}),
+	}).Create(entity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+func (r *GormAuthSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*authsession.Session, error) {
+	var entity entities.AuthSessionEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, authsession.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	return authSessionToDomain(entity), nil
+}
+
+// FindByRefreshHash trava a linha (FOR UPDATE) dentro de transação: dois refresh simultâneos com
+// o mesmo token são serializados e o segundo cai na detecção de reuso
+func (r *GormAuthSessionRepository) FindByRefreshHash(ctx context.Context, tokenHash string) (*authsession.Session, error) {
+	var entity entities.AuthSessionEntity
+	err := r.getDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("refresh_token_hash = ? OR previous_refresh_token_hash = ?", tokenHash, tokenHash).
+		First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, authsession.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	return authSessionToDomain(entity), nil
+}
+
+func (r *GormAuthSessionRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*authsession.Session, error) {
+	var rows []entities.AuthSessionEntity
+	err := r.getDB(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, time.Now().UTC()).
+		Order("created_at DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	sessions := make([]*authsession.Session, len(rows))
+	for i, row := range rows {
+		sessions[i] = authSessionToDomain(row)
+	}
+	return sessions, nil
+}
+
+func (r *GormAuthSessionRepository) RevokeAllByUser(ctx context.Context, userID, except uuid.UUID, reason string) (int64, error) {
+	query, args := revokeUserSessionsUpdate(userID, except, reason, time.Now().UTC())
+	result := r.getDB(ctx).Exec(query, args...)
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// revokeUserSessionsUpdate revoga de uma vez as sessões ativas do usuário, preservando except
+func revokeUserSessionsUpdate(userID, except uuid.UUID, reason string, now time.Time) (string, []interface{}) {
+	query := `UPDATE auth_sessions
+SET revoked_at = ?, revoke_reason = ?, updated_at = ?
+WHERE user_id = ? AND revoked_at IS NULL`
+	args := []interface{}{now, reason, now, userID}
+	if except != uuid.Nil {
+		query += " AND id <> ?"
+		args = append(args, except)
+	}
+	return query, args
+}
+
+func authSessionToEntity(s *authsession.Session) *entities.AuthSessionEntity {
+	return &entities.AuthSessionEntity{
+		ID:                       s.ID(),
+		UserID:                   s.UserID(),
+		Method:                   string(s.Method()),
+		Provider:                 s.Provider(),
+		RefreshTokenHash:         s.RefreshHash(),
+		PreviousRefreshTokenHash: s.PreviousRefreshHash(),
+		UserAgent:                s.UserAgent(),
+		IPAddress:                s.IP(),
+		ExpiresAt:                s.ExpiresAt(),
+		LastRefreshedAt:          s.LastRefreshedAt(),
+		RevokedAt:                s.RevokedAt(),
+		RevokeReason:             s.RevokeReason(),
+		TwoFactorVerifiedAt:      s.TwoFactorVerifiedAt(),
+		ReauthenticatedAt:        s.ReauthenticatedAt(),
+		CreatedAt:                s.CreatedAt(),
+		UpdatedAt:                s.UpdatedAt(),
+	}
+}
+
+func authSessionToDomain(e entities.AuthSessionEntity) *authsession.Session {
+	return authsession.ReconstructSession(
+		e.ID,
+		e.UserID,
+		authsession.Method(e.Method),
+		e.Provider,
+		e.RefreshTokenHash,
+		e.PreviousRefreshTokenHash,
+		e.UserAgent,
+		e.IPAddress,
+		e.ExpiresAt,
+		e.LastRefreshedAt,
+		e.RevokedAt,
+		e.RevokeReason,
+		e.TwoFactorVerifiedAt,
+		e.ReauthenticatedAt,
+		e.CreatedAt,
+		e.UpdatedAt,
+	)
+}
@@ -0,0 +1,51 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+)
+
+func TestRevokeUserSessionsUpdate(t *testing.T) {
+	userID, current := uuid.New(), uuid.New()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	query, args := revokeUserSessionsUpdate(userID, current, authsession.ReasonRevokeOthers, now)
+	assert.Contains(t, query, "WHERE user_id = ? AND revoked_at IS NULL AND id <> ?")
+	assert.Equal(t, strings.Count(query, "?"), len(args))
+	assert.Equal(t, []interface{}{now, authsession.ReasonRevokeOthers, now, userID, current}, args)
+
+	query, args = revokeUserSessionsUpdate(userID, uuid.Nil, authsession.ReasonRevoked, now)
+	assert.NotContains(t, query, "id <>", "uuid.Nil revokes every session")
+	assert.Equal(t, strings.Count(query, "?"), len(args))
+}
+
+func TestAuthSessionMapping(t *testing.T) {
+	s, token, err := authsession.NewSession(uuid.New(), authsession.Details{
+		Method: authsession.MethodOIDC, Provider: "google", UserAgent: "Chrome", IP: "203.0.113.9", TwoFactorVerified: true,
+	}, time.Hour)
+	require.NoError(t, err)
+	_, err = s.Refresh(authsession.HashToken(token), "198.51.100.4", time.Now())
+	require.NoError(t, err)
+
+	entity := authSessionToEntity(s)
+	assert.Equal(t, "oidc", entity.Method)
+	assert.Equal(t, authsession.HashToken(token), entity.PreviousRefreshTokenHash)
+	assert.Equal(t, "198.51.100.4", entity.IPAddress)
+
+	restored := authSessionToDomain(*entity)
+	assert.Equal(t, s.ID(), restored.ID())
+	assert.Equal(t, s.RefreshHash(), restored.RefreshHash())
+	assert.Equal(t, s.PreviousRefreshHash(), restored.PreviousRefreshHash())
+	assert.Equal(t, "google", restored.Provider())
+	assert.Equal(t, s.ExpiresAt(), restored.ExpiresAt())
+	assert.Equal(t, s.LastRefreshedAt(), restored.LastRefreshedAt())
+	assert.Equal(t, s.TwoFactorVerifiedAt(), restored.TwoFactorVerifiedAt())
+	assert.Equal(t, s.ReauthenticatedAt(), restored.ReauthenticatedAt())
+	assert.True(t, restored.IsActive(time.Now()))
+}
@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormBillingPlanRepository catálogo de planos em billing_plans
+type GormBillingPlanRepository struct {
+	db *gorm.DB
+}
+
+func NewGormBillingPlanRepository(db *gorm.DB) billing.PlanRepository {
+	return &GormBillingPlanRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormBillingPlanRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Save grava o plano; o plano padrão é único, então marcar um desmarca o anterior
+func (r *GormBillingPlanRepository) Save(ctx context.Context, plan *billing.Plan) error {
+	entity := billingPlanToEntity(plan)
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if entity.IsDefault {
+			if err := tx.Model(&entities.BillingPlanEntity{}).
+				Where("is_default AND id <> ?", entity.ID).
+				Update("is_default", false).Error; err != nil {
+				return fmt.Errorf("failed to unset default plan: %w", err)
+			}
+		}
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"name", "stripe_price_id", "max_channels", "max_contacts", "max_agents",
+				"monthly_messages", "ai_minutes", "broadcasts_per_day", "soft_limit_percent",
+				"is_default", "updated_at",
+			}),
+		}).Create(entity).Error
+		if err != nil {
+			return fmt.Errorf("failed to save billing plan: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GormBillingPlanRepository) FindByStripePriceID(ctx context.Context, stripePriceID string) (*billing.Plan, error) {
+	if stripePriceID == "" {
+		return nil, billing.ErrPlanNotFound
+	}
+	return r.findOne(ctx, "stripe_price_id = ?", stripePriceID)
+}
+
+func (r *GormBillingPlanRepository) FindDefault(ctx context.Context) (*billing.Plan, error) {
+	return r.findOne(ctx, "is_default")
+}
+
+func (r *GormBillingPlanRepository) findOne(ctx context.Context, where string, args ...interface{}) (*billing.Plan, error) {
+	var entity entities.BillingPlanEntity
+	if err := r.getDB(ctx).Where(where, args...).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billing.ErrPlanNotFound
+		}
+		return nil, fmt.Errorf("failed to load billing plan: %w", err)
+	}
+	return billingPlanToDomain(entity), nil
+}
+
+func (r *GormBillingPlanRepository) List(ctx context.Context) ([]*billing.Plan, error) {
+	var rows []entities.BillingPlanEntity
+	if err := r.getDB(ctx).Order("key ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list billing plans: %w", err)
+	}
+
+	plans := make([]*billing.Plan, len(rows))
+	for i, row := range rows {
+		plans[i] = billingPlanToDomain(row)
+	}
+	return plans, nil
+}
+
+func billingPlanToEntity(plan *billing.Plan) *entities.BillingPlanEntity {
+	e := plan.Entitlements()
+	return &entities.BillingPlanEntity{
+		ID:               plan.ID(),
+		Key:              plan.Key(),
+		Name:             plan.Name(),
+		StripePriceID:    plan.StripePriceID(),
+		MaxChannels:      e.MaxChannels,
+		MaxContacts:      e.MaxContacts,
+		MaxAgents:        e.MaxAgents,
+		MonthlyMessages:  e.MonthlyMessages,
+		AIMinutes:        e.AIMinutes,
+		BroadcastsPerDay: e.BroadcastsPerDay,
+		SoftLimitPercent: plan.SoftLimitPercent(),
+		IsDefault:        plan.IsDefault(),
+		CreatedAt:        plan.CreatedAt(),
+		UpdatedAt:        plan.UpdatedAt(),
+	}
+}
+
+func billingPlanToDomain(entity entities.BillingPlanEntity) *billing.Plan {
+	return billing.ReconstructPlan(
+		entity.ID,
+		entity.Key,
+		entity.Name,
+		entity.StripePriceID,
+		billing.Entitlements{
+			MaxChannels:      entity.MaxChannels,
+			MaxContacts:      entity.MaxContacts,
+			MaxAgents:        entity.MaxAgents,
+			MonthlyMessages:  entity.MonthlyMessages,
+			AIMinutes:        entity.AIMinutes,
+			BroadcastsPerDay: entity.BroadcastsPerDay,
+		},
+		entity.SoftLimitPercent,
+		entity.IsDefault,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
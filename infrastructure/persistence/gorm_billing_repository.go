@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appshared "github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"gorm.io/gorm"
 )
@@ -20,15 +21,23 @@ func NewGormBillingRepository(db *gorm.DB) *GormBillingRepository {
 	return &GormBillingRepository{db: db}
 }
 
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormBillingRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appshared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 // Create cria uma nova billing account
 func (r *GormBillingRepository) Create(ctx context.Context, account *entities.BillingAccountEntity) error {
-	return r.db.WithContext(ctx).Create(account).Error
+	return r.getDB(ctx).Create(account).Error
 }
 
 // FindByID busca uma billing account por ID
 func (r *GormBillingRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.BillingAccountEntity, error) {
 	var account entities.BillingAccountEntity
-	err := r.db.WithContext(ctx).First(&account, "id = ?", id).Error
+	err := r.getDB(ctx).First(&account, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -38,14 +47,14 @@ func (r *GormBillingRepository) FindByID(ctx context.Context, id uuid.UUID) (*en
 // FindByUserID busca todas as billing accounts de um usuário
 func (r *GormBillingRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*entities.BillingAccountEntity, error) {
 	var accounts []*entities.BillingAccountEntity
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&accounts).Error
+	err := r.getDB(ctx).Where("user_id = ?", userID).Find(&accounts).Error
 	return accounts, err
 }
 
 // FindActiveByUserID busca a primeira conta ativa de um usuário
 func (r *GormBillingRepository) FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*entities.BillingAccountEntity, error) {
 	var account entities.BillingAccountEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("user_id = ? AND payment_status = ? AND suspended = ?", userID, "active", false).
 		First(&account).Error
 	if err != nil {
@@ -54,22 +63,32 @@ func (r *GormBillingRepository) FindActiveByUserID(ctx context.Context, userID u
 	return &account, nil
 }
 
+// FindByStripeCustomerID busca a billing account vinculada ao customer do Stripe
+func (r *GormBillingRepository) FindByStripeCustomerID(ctx context.Context, stripeCustomerID string) (*entities.BillingAccountEntity, error) {
+	var account entities.BillingAccountEntity
+	err := r.getDB(ctx).Where("stripe_customer_id = ?", stripeCustomerID).First(&account).Error
+	if err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 // Update atualiza uma billing account
 func (r *GormBillingRepository) Update(ctx context.Context, account *entities.BillingAccountEntity) error {
 	// Check if exists
 	var existing entities.BillingAccountEntity
-	err := r.db.WithContext(ctx).Where("id = ?", account.ID).First(&existing).Error
+	err := r.getDB(ctx).Where("id = ?", account.ID).First(&existing).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Insert if not found
-			return r.db.WithContext(ctx).Create(account).Error
+			return r.getDB(ctx).Create(account).Error
 		}
 		return err
 	}
 
 	// Update with optimistic locking
-	result := r.db.WithContext(ctx).Model(&entities.BillingAccountEntity{}).
+	result := r.getDB(ctx).Model(&entities.BillingAccountEntity{}).
 		Where("id = ? AND version = ?", account.ID, existing.Version).
 		Updates(map[string]interface{}{
 			"version":            existing.Version + 1, // Increment version
@@ -82,6 +101,7 @@ func (r *GormBillingRepository) Update(ctx context.Context, account *entities.Bi
 			"suspended":          account.Suspended,
 			"suspended_at":       account.SuspendedAt,
 			"suspension_reason":  account.SuspensionReason,
+			"read_only_at":       account.ReadOnlyAt,
 			"updated_at":         account.UpdatedAt,
 		})
 
@@ -104,5 +124,5 @@ func (r *GormBillingRepository) Update(ctx context.Context, account *entities.Bi
 
 // Delete deleta uma billing account (soft delete)
 func (r *GormBillingRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.BillingAccountEntity{}, "id = ?", id).Error
+	return r.getDB(ctx).Delete(&entities.BillingAccountEntity{}, "id = ?", id).Error
 }
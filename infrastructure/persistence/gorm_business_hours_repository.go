@@ -0,0 +1,174 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormBusinessHoursCalendarRepository persiste o expediente dos projetos
+type GormBusinessHoursCalendarRepository struct {
+	db *gorm.DB
+}
+
+func NewGormBusinessHoursCalendarRepository(db *gorm.DB) businesshours.CalendarRepository {
+	return &GormBusinessHoursCalendarRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormBusinessHoursCalendarRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormBusinessHoursCalendarRepository) Save(ctx context.Context, calendar *businesshours.Calendar) error {
+	entity, err := businessHoursCalendarToEntity(calendar)
+	if err != nil {
+		return err
+	}
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to save business hours calendar: %w", err)
+	}
+	return nil
+}
+
+func (r *GormBusinessHoursCalendarRepository) FindByProject(ctx context.Context, projectID uuid.UUID) (*businesshours.Calendar, error) {
+	var entity entities.BusinessHoursCalendarEntity
+	if err := r.getDB(ctx).Where("project_id = ?", projectID).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, businesshours.ErrCalendarNotFound
+		}
+		return nil, fmt.Errorf("failed to load business hours calendar: %w", err)
+	}
+	return businessHoursCalendarToDomain(entity), nil
+}
+
+func (r *GormBusinessHoursCalendarRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.getDB(ctx).Where("id = ?", id).Delete(&entities.BusinessHoursCalendarEntity{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete business hours calendar: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return businesshours.ErrCalendarNotFound
+	}
+	return nil
+}
+
+func businessHoursCalendarToEntity(c *businesshours.Calendar) (*entities.BusinessHoursCalendarEntity, error) {
+	schedule := c.Schedule()
+	weekly, err := json.Marshal(schedule.Weekly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal weekly intervals: %w", err)
+	}
+	holidays := schedule.Holidays
+	if holidays == nil {
+		holidays = []string{}
+	}
+	holidaysJSON, err := json.Marshal(holidays)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal holidays: %w", err)
+	}
+
+	timezone := schedule.Timezone
+	if timezone == "" {
+		timezone = "UTC"
+	}
+	outOfOffice := c.OutOfOffice()
+	return &entities.BusinessHoursCalendarEntity{
+		ID:                         c.ID(),
+		TenantID:                   c.TenantID(),
+		ProjectID:                  c.ProjectID(),
+		Timezone:                   timezone,
+		Weekly:                     datatypes.JSON(weekly),
+		Holidays:                   datatypes.JSON(holidaysJSON),
+		OutOfOfficeEnabled:         outOfOffice.Enabled,
+		OutOfOfficeMessage:         outOfOffice.Message,
+		OutOfOfficeCooldownSeconds: int(outOfOffice.Cooldown.Seconds()),
+		CreatedAt:                  c.CreatedAt(),
+		UpdatedAt:                  c.UpdatedAt(),
+	}, nil
+}
+
+func businessHoursCalendarToDomain(entity entities.BusinessHoursCalendarEntity) *businesshours.Calendar {
+	schedule := businesshours.Schedule{Timezone: entity.Timezone}
+	if len(entity.Weekly) > 0 {
+		_ = json.Unmarshal(entity.Weekly, &schedule.Weekly)
+	}
+	if len(entity.Holidays) > 0 {
+		_ = json.Unmarshal(entity.Holidays, &schedule.Holidays)
+	}
+
+	return businesshours.ReconstructCalendar(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		schedule,
+		businesshours.OutOfOffice{
+			Enabled:  entity.OutOfOfficeEnabled,
+			Message:  entity.OutOfOfficeMessage,
+			Cooldown: time.Duration(entity.OutOfOfficeCooldownSeconds) * time.Second,
+		},
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
+
+// GormOutOfOfficeReplyRepository registra as respostas de ausência enviadas
+type GormOutOfOfficeReplyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormOutOfOfficeReplyRepository(db *gorm.DB) businesshours.AutoReplyRepository {
+	return &GormOutOfOfficeReplyRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormOutOfOfficeReplyRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormOutOfOfficeReplyRepository) TryRecord(ctx context.Context, reply businesshours.AutoReply) (bool, error) {
+	entity := entities.OutOfOfficeReplyEntity{
+		ID:        reply.ID,
+		TenantID:  reply.TenantID,
+		ProjectID: reply.ProjectID,
+		ContactID: reply.ContactID,
+		SessionID: reply.SessionID,
+		MessageID: reply.MessageID,
+		SentAt:    reply.SentAt,
+	}
+	result := r.getDB(ctx).
+		Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "session_id"}}, DoNothing: true}).
+		Create(&entity)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record out-of-office reply: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *GormOutOfOfficeReplyRepository) LastSentAt(ctx context.Context, contactID uuid.UUID) (*time.Time, error) {
+	var entity entities.OutOfOfficeReplyEntity
+	err := r.getDB(ctx).Where("contact_id = ?", contactID).Order("sent_at DESC").First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load last out-of-office reply: %w", err)
+	}
+	return &entity.SentAt, nil
+}
@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+)
+
+func TestBusinessHoursCalendarEntityRoundTrip(t *testing.T) {
+	calendar, err := businesshours.NewCalendar("tenant-1", uuid.New(), businesshours.Schedule{
+		Timezone: "America/Sao_Paulo",
+		Weekly:   map[string][]businesshours.Interval{"monday": {{Start: "09:00", End: "18:00"}}},
+		Holidays: []string{"12-25", "2025-01-01"},
+	})
+	require.NoError(t, err)
+	require.NoError(t, calendar.SetOutOfOffice(businesshours.OutOfOffice{Enabled: true, Message: "Fechado", Cooldown: 4 * time.Hour}))
+
+	entity, err := businessHoursCalendarToEntity(calendar)
+	require.NoError(t, err)
+	assert.Equal(t, 4*3600, entity.OutOfOfficeCooldownSeconds)
+	assert.JSONEq(t, `["12-25","2025-01-01"]`, string(entity.Holidays))
+
+	restored := businessHoursCalendarToDomain(*entity)
+	assert.Equal(t, calendar.ID(), restored.ID())
+	assert.Equal(t, calendar.ProjectID(), restored.ProjectID())
+	assert.Equal(t, calendar.Schedule(), restored.Schedule())
+	assert.Equal(t, calendar.OutOfOffice(), restored.OutOfOffice())
+}
+
+func TestBusinessHoursCalendarEntity_Defaults(t *testing.T) {
+	calendar, err := businesshours.NewCalendar("tenant-1", uuid.New(), businesshours.Schedule{})
+	require.NoError(t, err)
+
+	entity, err := businessHoursCalendarToEntity(calendar)
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", entity.Timezone)
+	assert.JSONEq(t, `[]`, string(entity.Holidays))
+
+	restored := businessHoursCalendarToDomain(entities.BusinessHoursCalendarEntity{ID: entity.ID, Timezone: "UTC"})
+	assert.True(t, restored.Schedule().IsAlwaysOpen())
+}
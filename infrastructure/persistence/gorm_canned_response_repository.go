@@ -0,0 +1,304 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormCannedResponseRepository persiste as respostas prontas e seus usos
+type GormCannedResponseRepository struct {
+	db *gorm.DB
+}
+
+func NewGormCannedResponseRepository(db *gorm.DB) cannedresponse.Repository {
+	return &GormCannedResponseRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormCannedResponseRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormCannedResponseRepository) Save(ctx context.Context, cr *cannedresponse.CannedResponse) error {
+	entity, err := cannedResponseToEntity(cr)
+	if err != nil {
+		return err
+	}
+	// usage_count/last_used_at são mantidos por RecordUsage
+	err = r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"shortcut", "title", "content", "folder", "attachments", "updated_at", "deleted_at",
+		}),
+	}).Create(entity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save canned response: %w", err)
+	}
+	return nil
+}
+
+func (r *GormCannedResponseRepository) FindByID(ctx context.Context, id uuid.UUID) (*cannedresponse.CannedResponse, error) {
+	var entity entities.CannedResponseEntity
+	if err := r.getDB(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cannedresponse.ErrCannedResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to load canned response: %w", err)
+	}
+	return cannedResponseToDomain(entity), nil
+}
+
+func (r *GormCannedResponseRepository) FindByShortcut(ctx context.Context, projectID uuid.UUID, ownerAgentID *uuid.UUID, shortcut string) (*cannedresponse.CannedResponse, error) {
+	query := r.getDB(ctx).Where("project_id = ? AND shortcut = ? AND deleted_at IS NULL", projectID, shortcut)
+	if ownerAgentID == nil {
+		query = query.Where("owner_agent_id IS NULL")
+	} else {
+		query = query.Where("owner_agent_id = ?", *ownerAgentID)
+	}
+
+	var entity entities.CannedResponseEntity
+	if err := query.First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, cannedresponse.ErrCannedResponseNotFound
+		}
+		return nil, fmt.Errorf("failed to load canned response: %w", err)
+	}
+	return cannedResponseToDomain(entity), nil
+}
+
+func (r *GormCannedResponseRepository) List(ctx context.Context, filter cannedresponse.Filter) ([]*cannedresponse.CannedResponse, int64, error) {
+	where, args := cannedResponseFilterConditions(filter)
+	query := r.getDB(ctx).Model(&entities.CannedResponseEntity{}).Where(where, args...)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count canned responses: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	orderSQL, orderArgs := cannedResponseOrder(filter)
+	var rows []entities.CannedResponseEntity
+	err := query.Clauses(clause.OrderBy{Expression: clause.Expr{SQL: orderSQL, Vars: orderArgs, WithoutParentheses: true}}).
+		Limit(limit).Offset(filter.Offset).Find(&rows).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list canned responses: %w", err)
+	}
+
+	responses := make([]*cannedresponse.CannedResponse, len(rows))
+	for i, row := range rows {
+		responses[i] = cannedResponseToDomain(row)
+	}
+	return responses, total, nil
+}
+
+func (r *GormCannedResponseRepository) Folders(ctx context.Context, filter cannedresponse.Filter) ([]cannedresponse.FolderCount, error) {
+	filter.Folder = nil
+	filter.Query = ""
+	where, args := cannedResponseFilterConditions(filter)
+
+	var folders []cannedresponse.FolderCount
+	err := r.getDB(ctx).Model(&entities.CannedResponseEntity{}).
+		Select("folder, COUNT(*) AS count").
+		Where(where, args...).
+		Group("folder").
+		Order("folder ASC").
+		Scan(&folders).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list canned response folders: %w", err)
+	}
+	return folders, nil
+}
+
+func (r *GormCannedResponseRepository) RecordUsage(ctx context.Context, usage cannedresponse.Usage) error {
+	if usage.ID == uuid.Nil {
+		usage.ID = uuid.New()
+	}
+	entity := &entities.CannedResponseUsageEntity{
+		ID:               usage.ID,
+		TenantID:         usage.TenantID,
+		ProjectID:        usage.ProjectID,
+		CannedResponseID: usage.CannedResponseID,
+		AgentID:          usage.AgentID,
+		UserID:           usage.UserID,
+		SessionID:        usage.SessionID,
+		UsedAt:           usage.UsedAt,
+	}
+
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(entity).Error; err != nil {
+			return fmt.Errorf("failed to record canned response usage: %w", err)
+		}
+		err := tx.Model(&entities.CannedResponseEntity{}).
+			Where("id = ?", usage.CannedResponseID).
+			Updates(map[string]interface{}{
+				"usage_count":  gorm.Expr("usage_count + 1"),
+				"last_used_at": gorm.Expr("GREATEST(COALESCE(last_used_at, ?), ?)", usage.UsedAt, usage.UsedAt),
+			}).Error
+		if err != nil {
+			return fmt.Errorf("failed to update canned response usage count: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *GormCannedResponseRepository) UsageStats(ctx context.Context, query cannedresponse.UsageQuery) ([]cannedresponse.UsageStat, error) {
+	sql, args := cannedResponseUsageStatsQuery(query)
+
+	var stats []cannedresponse.UsageStat
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to load canned response usage: %w", err)
+	}
+	return stats, nil
+}
+
+// cannedResponseFilterConditions cláusula WHERE das respostas visíveis ao agente do filtro
+func cannedResponseFilterConditions(filter cannedresponse.Filter) (string, []interface{}) {
+	conditions := []string{"tenant_id = ?", "project_id = ?", "deleted_at IS NULL"}
+	args := []interface{}{filter.TenantID, filter.ProjectID}
+
+	switch {
+	case filter.Scope == cannedresponse.ScopePrivate && filter.ViewerAgentID == nil:
+		conditions = append(conditions, "FALSE")
+	case filter.Scope == cannedresponse.ScopePrivate:
+		conditions = append(conditions, "owner_agent_id = ?")
+		args = append(args, *filter.ViewerAgentID)
+	case filter.Scope == cannedresponse.ScopeShared || filter.ViewerAgentID == nil:
+		conditions = append(conditions, "owner_agent_id IS NULL")
+	default:
+		conditions = append(conditions, "(owner_agent_id IS NULL OR owner_agent_id = ?)")
+		args = append(args, *filter.ViewerAgentID)
+	}
+
+	if filter.Folder != nil {
+		conditions = append(conditions, "folder = ?")
+		args = append(args, *filter.Folder)
+	}
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		pattern := "%" + escapeLikePattern(q) + "%"
+		conditions = append(conditions, "(shortcut LIKE ? OR title ILIKE ? OR content ILIKE ?)")
+		args = append(args, escapeLikePattern(shortcutQuery(q))+"%", pattern, pattern)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// cannedResponseOrder na busca, atalhos com o prefixo digitado vêm primeiro; depois as mais usadas
+func cannedResponseOrder(filter cannedresponse.Filter) (string, []interface{}) {
+	if q := strings.TrimSpace(filter.Query); q != "" {
+		return "CASE WHEN shortcut LIKE ? THEN 0 ELSE 1 END, usage_count DESC, shortcut ASC",
+			[]interface{}{escapeLikePattern(shortcutQuery(q)) + "%"}
+	}
+	return "folder ASC, shortcut ASC", nil
+}
+
+// cannedResponseUsageStatsQuery respostas mais usadas no período (opcionalmente por agente)
+func cannedResponseUsageStatsQuery(query cannedresponse.UsageQuery) (string, []interface{}) {
+	conditions := []string{"u.tenant_id = ?", "u.project_id = ?", "u.used_at >= ?", "u.used_at < ?"}
+	args := []interface{}{query.TenantID, query.ProjectID, query.Since, query.Until}
+	if query.AgentID != nil {
+		conditions = append(conditions, "u.agent_id = ?")
+		args = append(args, *query.AgentID)
+	}
+
+	limit := query.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	args = append(args, limit)
+
+	return `SELECT
+			cr.id AS canned_response_id,
+			cr.shortcut,
+			cr.title,
+			cr.folder,
+			cr.owner_agent_id IS NOT NULL AS private,
+			COUNT(*) AS uses,
+			COUNT(DISTINCT u.agent_id) AS agents,
+			COUNT(DISTINCT u.session_id) AS sessions,
+			MAX(u.used_at) AS last_used_at
+		FROM canned_response_usages u
+		JOIN canned_responses cr ON cr.id = u.canned_response_id
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		GROUP BY cr.id, cr.shortcut, cr.title, cr.folder, cr.owner_agent_id
+		ORDER BY uses DESC, last_used_at DESC
+		LIMIT ?`, args
+}
+
+// shortcutQuery o texto buscado como atalho ("pre" → "/pre")
+func shortcutQuery(q string) string {
+	q = strings.ToLower(q)
+	if !strings.HasPrefix(q, "/") {
+		q = "/" + q
+	}
+	return q
+}
+
+// escapeLikePattern escapa os curingas do LIKE (escape padrão do PostgreSQL: \)
+func escapeLikePattern(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
+
+func cannedResponseToEntity(cr *cannedresponse.CannedResponse) (*entities.CannedResponseEntity, error) {
+	attachments, err := json.Marshal(cr.Attachments())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal canned response attachments: %w", err)
+	}
+	return &entities.CannedResponseEntity{
+		ID:           cr.ID(),
+		TenantID:     cr.TenantID(),
+		ProjectID:    cr.ProjectID(),
+		OwnerAgentID: cr.OwnerAgentID(),
+		Shortcut:     cr.Shortcut(),
+		Title:        cr.Title(),
+		Content:      cr.Content(),
+		Folder:       cr.Folder(),
+		Attachments:  datatypes.JSON(attachments),
+		UsageCount:   cr.UsageCount(),
+		LastUsedAt:   cr.LastUsedAt(),
+		CreatedBy:    cr.CreatedBy(),
+		CreatedAt:    cr.CreatedAt(),
+		UpdatedAt:    cr.UpdatedAt(),
+		DeletedAt:    cr.DeletedAt(),
+	}, nil
+}
+
+func cannedResponseToDomain(entity entities.CannedResponseEntity) *cannedresponse.CannedResponse {
+	var attachments []cannedresponse.Attachment
+	if len(entity.Attachments) > 0 {
+		_ = json.Unmarshal(entity.Attachments, &attachments)
+	}
+	return cannedresponse.ReconstructCannedResponse(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		entity.OwnerAgentID,
+		entity.Shortcut,
+		entity.Title,
+		entity.Content,
+		entity.Folder,
+		attachments,
+		entity.UsageCount,
+		entity.LastUsedAt,
+		entity.CreatedBy,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+		entity.DeletedAt,
+	)
+}
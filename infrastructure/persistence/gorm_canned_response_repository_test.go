@@ -0,0 +1,96 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+)
+
+func TestCannedResponseFilterConditions(t *testing.T) {
+	projectID := uuid.New()
+	agentID := uuid.New()
+
+	t.Run("shared and own private", func(t *testing.T) {
+		where, args := cannedResponseFilterConditions(cannedresponse.Filter{
+			TenantID: "tenant-1", ProjectID: projectID, ViewerAgentID: &agentID, Scope: cannedresponse.ScopeAll,
+		})
+
+		assert.Equal(t, "tenant_id = ? AND project_id = ? AND deleted_at IS NULL AND (owner_agent_id IS NULL OR owner_agent_id = ?)", where)
+		assert.Equal(t, []interface{}{"tenant-1", projectID, agentID}, args)
+	})
+
+	t.Run("no agent sees only shared", func(t *testing.T) {
+		where, _ := cannedResponseFilterConditions(cannedresponse.Filter{TenantID: "tenant-1", ProjectID: projectID})
+		assert.Contains(t, where, "owner_agent_id IS NULL")
+
+		where, _ = cannedResponseFilterConditions(cannedresponse.Filter{TenantID: "tenant-1", ProjectID: projectID, Scope: cannedresponse.ScopePrivate})
+		assert.Contains(t, where, "FALSE")
+	})
+
+	t.Run("folder and escaped query", func(t *testing.T) {
+		folder := "Vendas"
+		where, args := cannedResponseFilterConditions(cannedresponse.Filter{
+			TenantID: "tenant-1", ProjectID: projectID, ViewerAgentID: &agentID, Folder: &folder, Query: "100%",
+		})
+
+		assert.Contains(t, where, "folder = ?")
+		assert.Contains(t, where, "(shortcut LIKE ? OR title ILIKE ? OR content ILIKE ?)")
+		assert.Equal(t, strings.Count(where, "?"), len(args))
+		assert.Equal(t, `/100\%%`, args[4])
+		assert.Equal(t, `%100\%%`, args[5])
+	})
+}
+
+func TestCannedResponseOrder(t *testing.T) {
+	sql, args := cannedResponseOrder(cannedresponse.Filter{Query: "Pre"})
+	assert.True(t, strings.HasPrefix(sql, "CASE WHEN shortcut LIKE ?"))
+	assert.Equal(t, []interface{}{"/pre%"}, args)
+
+	sql, args = cannedResponseOrder(cannedresponse.Filter{})
+	assert.Equal(t, "folder ASC, shortcut ASC", sql)
+	assert.Empty(t, args)
+}
+
+func TestCannedResponseUsageStatsQuery(t *testing.T) {
+	projectID := uuid.New()
+	agentID := uuid.New()
+	since := time.Now().Add(-24 * time.Hour)
+	until := time.Now()
+
+	sql, args := cannedResponseUsageStatsQuery(cannedresponse.UsageQuery{
+		TenantID: "tenant-1", ProjectID: projectID, Since: since, Until: until, AgentID: &agentID,
+	})
+
+	assert.Contains(t, sql, "u.agent_id = ?")
+	assert.Contains(t, sql, "ORDER BY uses DESC")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, 20, args[len(args)-1], "default limit")
+}
+
+func TestCannedResponseEntityRoundTrip(t *testing.T) {
+	owner := uuid.New()
+	cr, err := cannedresponse.NewCannedResponse("tenant-1", uuid.New(), &owner, uuid.New(), cannedresponse.Details{
+		Shortcut: "/preco",
+		Title:    "Preços",
+		Content:  "Oi {{contact.first_name}}",
+		Folder:   "Vendas",
+	})
+	require.NoError(t, err)
+	require.NoError(t, cr.AddAttachment(uuid.New(), cannedresponse.Attachment{URL: "https://cdn/tabela.pdf", Filename: "tabela.pdf", Size: 42}))
+
+	entity, err := cannedResponseToEntity(cr)
+	require.NoError(t, err)
+	restored := cannedResponseToDomain(*entity)
+
+	assert.Equal(t, cr.ID(), restored.ID())
+	assert.Equal(t, &owner, restored.OwnerAgentID())
+	assert.Equal(t, "/preco", restored.Shortcut())
+	assert.Equal(t, "Vendas", restored.Folder())
+	assert.Equal(t, cr.Attachments(), restored.Attachments())
+	assert.Empty(t, restored.DomainEvents())
+}
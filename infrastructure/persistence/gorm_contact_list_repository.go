@@ -9,9 +9,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appShared "github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/contact_list"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type GormContactListRepository struct {
@@ -22,11 +25,19 @@ func NewGormContactListRepository(db *gorm.DB) contact_list.Repository {
 	return &GormContactListRepository{db: db}
 }
 
+// getDB retorna a transação do contexto, se houver, ou a conexão padrão
+func (r *GormContactListRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appShared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 func (r *GormContactListRepository) Create(ctx context.Context, list *contact_list.ContactList) error {
 	entity := r.domainToEntity(list)
 
 	// Criar dentro de uma transação para incluir as regras de filtro
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(entity).Error; err != nil {
 			return err
 		}
@@ -46,7 +57,7 @@ func (r *GormContactListRepository) Create(ctx context.Context, list *contact_li
 func (r *GormContactListRepository) Update(ctx context.Context, list *contact_list.ContactList) error {
 	entity := r.domainToEntity(list)
 
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
 		// Get existing entity for version check
 		var existing entities.ContactListEntity
 		err := tx.Where("id = ?", entity.ID).First(&existing).Error
@@ -64,6 +75,7 @@ func (r *GormContactListRepository) Update(ctx context.Context, list *contact_li
 				"name":               entity.Name,
 				"description":        entity.Description,
 				"logical_operator":   entity.LogicalOperator,
+				"filter_group":       entity.FilterGroup,
 				"is_static":          entity.IsStatic,
 				"contact_count":      entity.ContactCount,
 				"last_calculated_at": entity.LastCalculatedAt,
@@ -102,18 +114,36 @@ func (r *GormContactListRepository) Update(ctx context.Context, list *contact_li
 }
 
 func (r *GormContactListRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.ContactListEntity{}, "id = ?", id).Error
+	return r.getDB(ctx).Delete(&entities.ContactListEntity{}, "id = ?", id).Error
 }
 
 func (r *GormContactListRepository) FindByID(ctx context.Context, id uuid.UUID) (*contact_list.ContactList, error) {
 	var entity entities.ContactListEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Preload("FilterRules").
 		First(&entity, "id = ?", id).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return nil, errors.New("contact list not found")
+			return nil, shared.NewNotFoundError("contact_list", id.String())
+		}
+		return nil, err
+	}
+
+	return r.entityToDomain(&entity)
+}
+
+// FindByIDForUpdate busca a lista com SELECT ... FOR UPDATE (só faz sentido dentro de transação)
+func (r *GormContactListRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*contact_list.ContactList, error) {
+	var entity entities.ContactListEntity
+	err := r.getDB(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Preload("FilterRules").
+		First(&entity, "id = ?", id).Error
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, shared.NewNotFoundError("contact_list", id.String())
 		}
 		return nil, err
 	}
@@ -123,7 +153,7 @@ func (r *GormContactListRepository) FindByID(ctx context.Context, id uuid.UUID)
 
 func (r *GormContactListRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*contact_list.ContactList, error) {
 	var listEntities []entities.ContactListEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Preload("FilterRules").
 		Where("project_id = ? AND deleted_at IS NULL", projectID).
 		Find(&listEntities).Error
@@ -146,7 +176,7 @@ func (r *GormContactListRepository) FindByProjectID(ctx context.Context, project
 
 func (r *GormContactListRepository) FindByTenantID(ctx context.Context, tenantID string) ([]*contact_list.ContactList, error) {
 	var listEntities []entities.ContactListEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Preload("FilterRules").
 		Where("tenant_id = ? AND deleted_at IS NULL", tenantID).
 		Find(&listEntities).Error
@@ -172,14 +202,14 @@ func (r *GormContactListRepository) ListByProject(ctx context.Context, projectID
 	var total int64
 
 	// Count total
-	if err := r.db.WithContext(ctx).Model(&entities.ContactListEntity{}).
+	if err := r.getDB(ctx).Model(&entities.ContactListEntity{}).
 		Where("project_id = ? AND deleted_at IS NULL", projectID).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get paginated results
-	query := r.db.WithContext(ctx).
+	query := r.getDB(ctx).
 		Preload("FilterRules").
 		Where("project_id = ? AND deleted_at IS NULL", projectID).
 		Order("created_at DESC")
@@ -228,14 +258,14 @@ func (r *GormContactListRepository) getStaticListContacts(ctx context.Context, l
 	var members []entities.ContactListMemberEntity
 
 	// Count
-	if err := r.db.WithContext(ctx).Model(&entities.ContactListMemberEntity{}).
+	if err := r.getDB(ctx).Model(&entities.ContactListMemberEntity{}).
 		Where("contact_list_id = ?", listID).
 		Count(&total).Error; err != nil {
 		return nil, 0, err
 	}
 
 	// Get members
-	query := r.db.WithContext(ctx).
+	query := r.getDB(ctx).
 		Where("contact_list_id = ?", listID).
 		Order("added_at DESC")
 
@@ -258,16 +288,29 @@ func (r *GormContactListRepository) getStaticListContacts(ctx context.Context, l
 	return contactIDs, int(total), nil
 }
 
-func (r *GormContactListRepository) getDynamicListContacts(ctx context.Context, list *contact_list.ContactList, limit, offset int) ([]uuid.UUID, int, error) {
-	// Construir query com base nas regras de filtro
-	query := r.db.WithContext(ctx).Model(&entities.ContactEntity{}).
-		Where("deleted_at IS NULL AND project_id = ?", list.ProjectID())
+// dynamicListQuery monta a query de contatos que atendem aos filtros da lista.
+// A árvore de filtros é compilada em uma única condição entre parênteses (ver contact_list_filter_sql.go),
+// para que OR/NOT não escapem do escopo do projeto. O tenant da lista também filtra os contatos:
+// uma lista com project_id de outro tenant não enxerga nada.
+func (r *GormContactListRepository) dynamicListQuery(db *gorm.DB, list *contact_list.ContactList) *gorm.DB {
+	query := db.Model(&entities.ContactEntity{}).
+		Where("contacts.deleted_at IS NULL AND contacts.tenant_id = ? AND contacts.project_id = ?", list.TenantID(), list.ProjectID())
 
-	// Aplicar filtros
-	for _, rule := range list.FilterRules() {
-		query = r.applyFilterRule(query, rule, list.LogicalOperator())
+	condition, args, err := compileContactListFilter(list.EffectiveFilterGroup(), time.Now())
+	if err != nil {
+		query.AddError(fmt.Errorf("invalid contact list filter: %w", err))
+		return query
+	}
+	if condition == "" {
+		return query
 	}
 
+	return query.Where(condition, args...)
+}
+
+func (r *GormContactListRepository) getDynamicListContacts(ctx context.Context, list *contact_list.ContactList, limit, offset int) ([]uuid.UUID, int, error) {
+	query := r.dynamicListQuery(r.getDB(ctx), list)
+
 	// Count total
 	var total int64
 	if err := query.Count(&total).Error; err != nil {
@@ -286,7 +329,7 @@ func (r *GormContactListRepository) getDynamicListContacts(ctx context.Context,
 		query = query.Offset(offset)
 	}
 
-	if err := query.Select("id").Find(&contacts).Error; err != nil {
+	if err := query.Select("contacts.id").Find(&contacts).Error; err != nil {
 		return nil, 0, err
 	}
 
@@ -298,134 +341,6 @@ func (r *GormContactListRepository) getDynamicListContacts(ctx context.Context,
 	return contactIDs, int(total), nil
 }
 
-func (r *GormContactListRepository) applyFilterRule(query *gorm.DB, rule *contact_list.FilterRule, logicalOp contact_list.LogicalOperator) *gorm.DB {
-	// Implementar lógica de filtro baseada no tipo e operador
-	switch rule.FilterType() {
-	case contact_list.FilterTypeAttribute:
-		return r.applyAttributeFilter(query, rule, logicalOp)
-	case contact_list.FilterTypeTag:
-		return r.applyTagFilter(query, rule, logicalOp)
-	case contact_list.FilterTypeCustomField:
-		return r.applyCustomFieldFilter(query, rule, logicalOp)
-	case contact_list.FilterTypePipelineStatus:
-		return r.applyPipelineStatusFilter(query, rule, logicalOp)
-	default:
-		return query
-	}
-}
-
-func (r *GormContactListRepository) applyAttributeFilter(query *gorm.DB, rule *contact_list.FilterRule, logicalOp contact_list.LogicalOperator) *gorm.DB {
-	fieldKey := rule.FieldKey()
-	operator := rule.Operator()
-	value := rule.Value()
-
-	condition := ""
-	args := []interface{}{}
-
-	switch operator {
-	case contact_list.OperatorEquals:
-		condition = fmt.Sprintf("%s = ?", fieldKey)
-		args = append(args, value)
-	case contact_list.OperatorNotEquals:
-		condition = fmt.Sprintf("%s != ?", fieldKey)
-		args = append(args, value)
-	case contact_list.OperatorGreaterThan:
-		condition = fmt.Sprintf("%s > ?", fieldKey)
-		args = append(args, value)
-	case contact_list.OperatorLessThan:
-		condition = fmt.Sprintf("%s < ?", fieldKey)
-		args = append(args, value)
-	case contact_list.OperatorContains:
-		condition = fmt.Sprintf("%s ILIKE ?", fieldKey)
-		args = append(args, fmt.Sprintf("%%%v%%", value))
-	case contact_list.OperatorStartsWith:
-		condition = fmt.Sprintf("%s ILIKE ?", fieldKey)
-		args = append(args, fmt.Sprintf("%v%%", value))
-	case contact_list.OperatorIsNull:
-		condition = fmt.Sprintf("%s IS NULL", fieldKey)
-	case contact_list.OperatorIsNotNull:
-		condition = fmt.Sprintf("%s IS NOT NULL", fieldKey)
-	}
-
-	if logicalOp == contact_list.LogicalOperatorAND {
-		return query.Where(condition, args...)
-	}
-	return query.Or(condition, args...)
-}
-
-func (r *GormContactListRepository) applyTagFilter(query *gorm.DB, rule *contact_list.FilterRule, logicalOp contact_list.LogicalOperator) *gorm.DB {
-	operator := rule.Operator()
-	value := rule.Value()
-
-	condition := ""
-	args := []interface{}{}
-
-	switch operator {
-	case contact_list.OperatorContains:
-		// tags é jsonb, usar operador @>
-		condition = "tags @> ?::jsonb"
-		tagJSON, _ := json.Marshal([]string{fmt.Sprintf("%v", value)})
-		args = append(args, string(tagJSON))
-	case contact_list.OperatorIn:
-		// value deve ser um array
-		if tags, ok := value.([]string); ok {
-			tagJSON, _ := json.Marshal(tags)
-			condition = "tags @> ?::jsonb"
-			args = append(args, string(tagJSON))
-		}
-	}
-
-	if condition != "" {
-		if logicalOp == contact_list.LogicalOperatorAND {
-			return query.Where(condition, args...)
-		}
-		return query.Or(condition, args...)
-	}
-
-	return query
-}
-
-func (r *GormContactListRepository) applyCustomFieldFilter(query *gorm.DB, rule *contact_list.FilterRule, logicalOp contact_list.LogicalOperator) *gorm.DB {
-	// Filtrar por custom fields usando subquery
-	fieldKey := rule.FieldKey()
-	operator := rule.Operator()
-	value := rule.Value()
-
-	subQuery := r.db.Table("contact_custom_fields").
-		Select("contact_id").
-		Where("field_key = ?", fieldKey)
-
-	switch operator {
-	case contact_list.OperatorEquals:
-		subQuery = subQuery.Where("field_value = ?::jsonb", fmt.Sprintf(`"%v"`, value))
-	case contact_list.OperatorContains:
-		subQuery = subQuery.Where("field_value::text ILIKE ?", fmt.Sprintf("%%%v%%", value))
-	}
-
-	if logicalOp == contact_list.LogicalOperatorAND {
-		return query.Where("id IN (?)", subQuery)
-	}
-	return query.Or("id IN (?)", subQuery)
-}
-
-func (r *GormContactListRepository) applyPipelineStatusFilter(query *gorm.DB, rule *contact_list.FilterRule, logicalOp contact_list.LogicalOperator) *gorm.DB {
-	if rule.PipelineID() == nil {
-		return query
-	}
-
-	statusName := rule.Value()
-	pipelineID := *rule.PipelineID()
-
-	subQuery := r.db.Table("contact_pipeline_statuses").
-		Select("contact_id").
-		Where("pipeline_id = ? AND current_status = ?", pipelineID, statusName)
-
-	if logicalOp == contact_list.LogicalOperatorAND {
-		return query.Where("id IN (?)", subQuery)
-	}
-	return query.Or("id IN (?)", subQuery)
-}
-
 func (r *GormContactListRepository) RecalculateContactCount(ctx context.Context, listID uuid.UUID) (int, error) {
 	contactIDs, total, err := r.GetContactsInList(ctx, listID, 0, 0)
 	if err != nil {
@@ -436,7 +351,7 @@ func (r *GormContactListRepository) RecalculateContactCount(ctx context.Context,
 
 	// Atualizar contador na lista
 	now := time.Now()
-	err = r.db.WithContext(ctx).Model(&entities.ContactListEntity{}).
+	err = r.getDB(ctx).Model(&entities.ContactListEntity{}).
 		Where("id = ?", listID).
 		Updates(map[string]interface{}{
 			"contact_count":      total,
@@ -454,11 +369,11 @@ func (r *GormContactListRepository) AddContactToStaticList(ctx context.Context,
 		AddedAt:       time.Now(),
 	}
 
-	return r.db.WithContext(ctx).Create(member).Error
+	return r.getDB(ctx).Create(member).Error
 }
 
 func (r *GormContactListRepository) RemoveContactFromStaticList(ctx context.Context, listID, contactID uuid.UUID) error {
-	return r.db.WithContext(ctx).
+	return r.getDB(ctx).
 		Where("contact_list_id = ? AND contact_id = ?", listID, contactID).
 		Delete(&entities.ContactListMemberEntity{}).Error
 }
@@ -471,7 +386,7 @@ func (r *GormContactListRepository) IsContactInList(ctx context.Context, listID,
 
 	if list.IsStatic() {
 		var count int64
-		err := r.db.WithContext(ctx).Model(&entities.ContactListMemberEntity{}).
+		err := r.getDB(ctx).Model(&entities.ContactListMemberEntity{}).
 			Where("contact_list_id = ? AND contact_id = ?", listID, contactID).
 			Count(&count).Error
 		return count > 0, err
@@ -492,7 +407,167 @@ func (r *GormContactListRepository) IsContactInList(ctx context.Context, listID,
 	return false, nil
 }
 
+func (r *GormContactListRepository) FindDynamicListsByContact(ctx context.Context, contactID uuid.UUID) ([]*contact_list.ContactList, error) {
+	var listEntities []entities.ContactListEntity
+	// Subquery sem filtro de deleted_at: contatos removidos também precisam sair das listas
+	err := r.getDB(ctx).
+		Preload("FilterRules").
+		Where("is_static = ? AND project_id = (SELECT project_id FROM contacts WHERE id = ?)", false, contactID).
+		Find(&listEntities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.entitiesToDomain(listEntities), nil
+}
+
+func (r *GormContactListRepository) FindStaleDynamicLists(ctx context.Context, calculatedBefore time.Time, limit int) ([]*contact_list.ContactList, error) {
+	var listEntities []entities.ContactListEntity
+	query := r.getDB(ctx).
+		Preload("FilterRules").
+		Where("is_static = ? AND (last_calculated_at IS NULL OR last_calculated_at < ?)", false, calculatedBefore).
+		Order("last_calculated_at ASC NULLS FIRST")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&listEntities).Error; err != nil {
+		return nil, err
+	}
+
+	return r.entitiesToDomain(listEntities), nil
+}
+
+func (r *GormContactListRepository) RefreshMembership(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) (*contact_list.MembershipDiff, error) {
+	list, err := r.FindByID(ctx, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &contact_list.MembershipDiff{}
+
+	// Membros de listas estáticas são gerenciados manualmente
+	if list.IsStatic() {
+		return diff, nil
+	}
+
+	err = r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		matchQuery := r.dynamicListQuery(tx, list)
+		memberQuery := tx.Model(&entities.ContactListMemberEntity{}).Where("contact_list_id = ?", listID)
+		if len(contactIDs) > 0 {
+			matchQuery = matchQuery.Where("contacts.id IN ?", contactIDs)
+			memberQuery = memberQuery.Where("contact_id IN ?", contactIDs)
+		}
+
+		var matching []uuid.UUID
+		if err := matchQuery.Pluck("contacts.id", &matching).Error; err != nil {
+			return err
+		}
+
+		var current []uuid.UUID
+		if err := memberQuery.Pluck("contact_id", &current).Error; err != nil {
+			return err
+		}
+
+		currentSet := make(map[uuid.UUID]struct{}, len(current))
+		for _, id := range current {
+			currentSet[id] = struct{}{}
+		}
+		matchingSet := make(map[uuid.UUID]struct{}, len(matching))
+		for _, id := range matching {
+			matchingSet[id] = struct{}{}
+			if _, ok := currentSet[id]; !ok {
+				diff.Joined = append(diff.Joined, id)
+			}
+		}
+		for _, id := range current {
+			if _, ok := matchingSet[id]; !ok {
+				diff.Left = append(diff.Left, id)
+			}
+		}
+
+		if len(diff.Joined) > 0 {
+			now := time.Now()
+			members := make([]entities.ContactListMemberEntity, len(diff.Joined))
+			for i, contactID := range diff.Joined {
+				members[i] = entities.ContactListMemberEntity{
+					ID:            uuid.New(),
+					ContactListID: listID,
+					ContactID:     contactID,
+					AddedAt:       now,
+				}
+			}
+			if err := tx.Clauses(OnConflictDoNothing("idx_contact_list_member")).
+				CreateInBatches(members, 500).Error; err != nil {
+				return err
+			}
+		}
+
+		if len(diff.Left) > 0 {
+			if err := tx.Where("contact_list_id = ? AND contact_id IN ?", listID, diff.Left).
+				Delete(&entities.ContactListMemberEntity{}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh contact list membership: %w", err)
+	}
+
+	return diff, nil
+}
+
+func (r *GormContactListRepository) Preview(ctx context.Context, list *contact_list.ContactList, sampleSize int) (*contact_list.PreviewResult, error) {
+	query := r.dynamicListQuery(r.getDB(ctx), list)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, err
+	}
+
+	result := &contact_list.PreviewResult{Total: int(total), Sample: []contact_list.ContactSample{}}
+	if sampleSize <= 0 || total == 0 {
+		return result, nil
+	}
+
+	var contacts []entities.ContactEntity
+	err := query.
+		Select("contacts.id, contacts.name, contacts.email, contacts.phone").
+		Order("contacts.created_at DESC").
+		Limit(sampleSize).
+		Find(&contacts).Error
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range contacts {
+		result.Sample = append(result.Sample, contact_list.ContactSample{
+			ID:    c.ID,
+			Name:  c.Name,
+			Email: c.Email,
+			Phone: c.Phone,
+		})
+	}
+
+	return result, nil
+}
+
 // Mappers
+func (r *GormContactListRepository) entitiesToDomain(listEntities []entities.ContactListEntity) []*contact_list.ContactList {
+	lists := make([]*contact_list.ContactList, 0, len(listEntities))
+	for _, entity := range listEntities {
+		list, err := r.entityToDomain(&entity)
+		if err != nil {
+			continue
+		}
+		lists = append(lists, list)
+	}
+	return lists
+}
+
 func (r *GormContactListRepository) domainToEntity(list *contact_list.ContactList) *entities.ContactListEntity {
 	entity := &entities.ContactListEntity{
 		ID:               list.ID(),
@@ -512,6 +587,11 @@ func (r *GormContactListRepository) domainToEntity(list *contact_list.ContactLis
 		entity.Description = *desc
 	}
 
+	if group := list.FilterGroup(); group != nil {
+		groupJSON, _ := json.Marshal(group)
+		entity.FilterGroup = datatypes.JSON(groupJSON)
+	}
+
 	if deletedAt := list.DeletedAt(); deletedAt != nil {
 		entity.DeletedAt = gorm.DeletedAt{Time: *deletedAt, Valid: true}
 	}
@@ -530,6 +610,7 @@ func (r *GormContactListRepository) filterRuleToEntity(rule *contact_list.Filter
 		FieldKey:      rule.FieldKey(),
 		Value:         string(valueJSON),
 		PipelineID:    rule.PipelineID(),
+		WithinDays:    rule.WithinDays(),
 		CreatedAt:     rule.CreatedAt(),
 	}
 
@@ -563,6 +644,14 @@ func (r *GormContactListRepository) entityToDomain(entity *entities.ContactListE
 
 	logicalOp := contact_list.LogicalOperator(entity.LogicalOperator)
 
+	var filterGroup *contact_list.FilterGroup
+	if len(entity.FilterGroup) > 0 && string(entity.FilterGroup) != "null" {
+		filterGroup = &contact_list.FilterGroup{}
+		if err := json.Unmarshal(entity.FilterGroup, filterGroup); err != nil {
+			return nil, fmt.Errorf("invalid filter group for contact list %s: %w", entity.ID, err)
+		}
+	}
+
 	return contact_list.ReconstructContactList(
 		entity.ID,
 		entity.Version,
@@ -572,6 +661,7 @@ func (r *GormContactListRepository) entityToDomain(entity *entities.ContactListE
 		description,
 		filterRules,
 		logicalOp,
+		filterGroup,
 		entity.IsStatic,
 		entity.ContactCount,
 		entity.LastCalculatedAt,
@@ -601,6 +691,7 @@ func (r *GormContactListRepository) filterRuleEntityToDomain(entity *entities.Co
 		fieldType,
 		value,
 		entity.PipelineID,
+		entity.WithinDays,
 		entity.CreatedAt,
 	), nil
 }
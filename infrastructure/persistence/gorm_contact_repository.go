@@ -10,6 +10,7 @@ import (
 	"github.com/ventros/crm/internal/application/shared"
 	domainShared "github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/search"
 	"gorm.io/gorm"
 )
 
@@ -373,7 +374,8 @@ func (r *GormContactRepository) FindByTenantWithFilters(
 	return contacts, total, nil
 }
 
-// SearchByText performs full-text search on contacts by name, phone, and email
+// SearchByText performs full-text search on contacts (search_vector: name, email, phone, external_id).
+// Digit-only queries also match partial phone numbers.
 func (r *GormContactRepository) SearchByText(
 	ctx context.Context,
 	tenantID string,
@@ -383,23 +385,14 @@ func (r *GormContactRepository) SearchByText(
 	query := r.db.WithContext(ctx).Model(&entities.ContactEntity{}).
 		Where("tenant_id = ? AND deleted_at IS NULL", tenantID)
 
-	// Full-text search using ILIKE (case-insensitive)
-	searchPattern := "%" + searchText + "%"
-	query = query.Where(
-		"LOWER(name) LIKE ? OR phone LIKE ? OR LOWER(email) LIKE ?",
-		searchPattern, searchPattern, searchPattern,
-	)
+	tsq, tsqArgs := searchTSQuery(search.Filter{Query: searchText})
+	if digits := onlyDigits(searchText); digits != "" {
+		query = query.Where("(search_vector @@ "+tsq+" OR phone LIKE ?)", append(tsqArgs, "%"+digits+"%")...)
+	} else {
+		query = query.Where("search_vector @@ "+tsq, tsqArgs...)
+	}
 
-	// Order by relevance (name match first, then phone, then email)
-	// Using CASE for simple relevance scoring
-	query = query.Order(gorm.Expr(`
-		CASE
-			WHEN LOWER(name) LIKE ? THEN 1
-			WHEN phone LIKE ? THEN 2
-			WHEN LOWER(email) LIKE ? THEN 3
-			ELSE 4
-		END, name ASC
-	`, searchPattern, searchPattern, searchPattern))
+	query = query.Order(gorm.Expr("ts_rank_cd(search_vector, "+tsq+") DESC, name ASC", tsqArgs...))
 
 	// Apply limit
 	query = query.Limit(limit)
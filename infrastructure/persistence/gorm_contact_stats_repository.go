@@ -0,0 +1,103 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"gorm.io/gorm"
+)
+
+// GormContactStatsRepository agrega a atividade de um contato diretamente das tabelas de origem.
+// As consultas usam os índices por contact_id, então o custo é proporcional ao histórico do contato.
+type GormContactStatsRepository struct {
+	db *gorm.DB
+}
+
+func NewGormContactStatsRepository(db *gorm.DB) contact.StatsRepository {
+	return &GormContactStatsRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormContactStatsRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormContactStatsRepository) GetStats(ctx context.Context, contactID uuid.UUID) (*contact.Stats, error) {
+	db := r.getDB(ctx)
+	stats := &contact.Stats{PipelineStatuses: []contact.PipelineStatusSummary{}}
+
+	var messages struct {
+		Total int64
+		First *time.Time
+		Last  *time.Time
+	}
+	if err := db.Raw(`
+		SELECT COUNT(*) AS total, MIN(timestamp) AS first, MAX(timestamp) AS last
+		FROM messages
+		WHERE contact_id = ? AND deleted_at IS NULL`, contactID).
+		Scan(&messages).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate contact messages: %w", err)
+	}
+	stats.TotalMessages = messages.Total
+	stats.FirstInteractionAt = messages.First
+	stats.LastInteractionAt = messages.Last
+
+	var sessions struct {
+		Total       int64
+		Active      int64
+		Converted   int64
+		AvgDuration float64
+	}
+	if err := db.Raw(`
+		SELECT
+			COUNT(*) AS total,
+			COUNT(*) FILTER (WHERE status = 'active') AS active,
+			COUNT(*) FILTER (WHERE converted) AS converted,
+			COALESCE(AVG(duration_seconds) FILTER (WHERE status <> 'active'), 0) AS avg_duration
+		FROM sessions
+		WHERE contact_id = ? AND deleted_at IS NULL`, contactID).
+		Scan(&sessions).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate contact sessions: %w", err)
+	}
+	stats.TotalSessions = sessions.Total
+	stats.ActiveSessions = sessions.Active
+	stats.ConvertedSessions = sessions.Converted
+	stats.AverageSessionDuration = time.Duration(sessions.AvgDuration * float64(time.Second))
+
+	if err := db.Raw(`SELECT COUNT(*) FROM trackings WHERE contact_id = ? AND deleted_at IS NULL`, contactID).
+		Scan(&stats.TrackingCount).Error; err != nil {
+		return nil, fmt.Errorf("failed to count contact trackings: %w", err)
+	}
+
+	var statuses []struct {
+		PipelineID   uuid.UUID
+		PipelineName string
+		StatusName   string
+	}
+	if err := db.Raw(`
+		SELECT cps.pipeline_id, p.name AS pipeline_name, ps.name AS status_name
+		FROM contact_pipeline_statuses cps
+		JOIN pipelines p ON p.id = cps.pipeline_id
+		JOIN pipeline_statuses ps ON ps.id = cps.status_id
+		WHERE cps.contact_id = ? AND cps.exited_at IS NULL AND cps.deleted_at IS NULL
+		ORDER BY p.name`, contactID).
+		Scan(&statuses).Error; err != nil {
+		return nil, fmt.Errorf("failed to load contact pipeline statuses: %w", err)
+	}
+	for _, status := range statuses {
+		stats.PipelineStatuses = append(stats.PipelineStatuses, contact.PipelineStatusSummary{
+			PipelineID:   status.PipelineID,
+			PipelineName: status.PipelineName,
+			StatusName:   status.StatusName,
+		})
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,271 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appshared "github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"gorm.io/gorm"
+)
+
+// GormDunningCaseRepository implementa billing.DunningCaseRepository usando GORM
+type GormDunningCaseRepository struct {
+	db *gorm.DB
+}
+
+// NewGormDunningCaseRepository cria uma nova instância do repositório
+func NewGormDunningCaseRepository(db *gorm.DB) billing.DunningCaseRepository {
+	return &GormDunningCaseRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormDunningCaseRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appshared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Save cria a régua ou a atualiza com optimistic locking. Duas réguas abertas para a mesma
+// fatura (webhook e varredura ao mesmo tempo) esbarram no índice único e viram
+// OptimisticLockError.
+func (r *GormDunningCaseRepository) Save(ctx context.Context, c *billing.DunningCase) error {
+	entity := dunningCaseToEntity(c)
+
+	var existing entities.DunningCaseEntity
+	err := r.getDB(ctx).Select("id", "version").Where("id = ?", entity.ID).First(&existing).Error
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+		if err := r.getDB(ctx).Omit("BillingAccount", "Invoice").Create(entity).Error; err != nil {
+			var pqErr *pq.Error
+			if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+				return shared.NewOptimisticLockError("DunningCase", entity.ID.String(), 0, entity.Version)
+			}
+			return err
+		}
+		return nil
+	}
+
+	result := r.getDB(ctx).Model(&entities.DunningCaseEntity{}).
+		Where("id = ? AND version = ?", entity.ID, entity.Version).
+		Updates(map[string]interface{}{
+			"version":           entity.Version + 1,
+			"status":            entity.Status,
+			"last_reminder_day": entity.LastReminderDay,
+			"reminders_sent":    entity.RemindersSent,
+			"read_only_at":      entity.ReadOnlyAt,
+			"suspended_at":      entity.SuspendedAt,
+			"resolved_at":       entity.ResolvedAt,
+			"resolution":        entity.Resolution,
+			"updated_at":        entity.UpdatedAt,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return shared.NewOptimisticLockError("DunningCase", entity.ID.String(), existing.Version, entity.Version)
+	}
+	return nil
+}
+
+// FindByID busca uma régua por ID
+func (r *GormDunningCaseRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.DunningCase, error) {
+	var entity entities.DunningCaseEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billing.ErrDunningCaseNotFound
+		}
+		return nil, err
+	}
+	return dunningCaseToDomain(&entity), nil
+}
+
+// FindOpenByInvoice busca a régua aberta da fatura
+func (r *GormDunningCaseRepository) FindOpenByInvoice(ctx context.Context, invoiceID uuid.UUID) (*billing.DunningCase, error) {
+	var entity entities.DunningCaseEntity
+	err := r.getDB(ctx).
+		Where("invoice_id = ? AND status = ?", invoiceID, string(billing.DunningStatusOpen)).
+		First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, billing.ErrDunningCaseNotFound
+		}
+		return nil, err
+	}
+	return dunningCaseToDomain(&entity), nil
+}
+
+// FindOpenByAccount busca as réguas abertas da conta
+func (r *GormDunningCaseRepository) FindOpenByAccount(ctx context.Context, billingAccountID uuid.UUID) ([]*billing.DunningCase, error) {
+	var list []entities.DunningCaseEntity
+	err := r.getDB(ctx).
+		Where("billing_account_id = ? AND status = ?", billingAccountID, string(billing.DunningStatusOpen)).
+		Order("due_date ASC").
+		Find(&list).Error
+	if err != nil {
+		return nil, err
+	}
+	return dunningCasesToDomain(list), nil
+}
+
+// FindOpen busca réguas abertas, das mais antigas para as mais novas
+func (r *GormDunningCaseRepository) FindOpen(ctx context.Context, limit int) ([]*billing.DunningCase, error) {
+	query := r.getDB(ctx).
+		Where("status = ?", string(billing.DunningStatusOpen)).
+		Order("due_date ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	var list []entities.DunningCaseEntity
+	if err := query.Find(&list).Error; err != nil {
+		return nil, err
+	}
+	return dunningCasesToDomain(list), nil
+}
+
+func dunningCasesToDomain(list []entities.DunningCaseEntity) []*billing.DunningCase {
+	cases := make([]*billing.DunningCase, 0, len(list))
+	for i := range list {
+		cases = append(cases, dunningCaseToDomain(&list[i]))
+	}
+	return cases
+}
+
+func dunningCaseToEntity(c *billing.DunningCase) *entities.DunningCaseEntity {
+	return &entities.DunningCaseEntity{
+		ID:               c.ID(),
+		Version:          c.Version(),
+		BillingAccountID: c.BillingAccountID(),
+		InvoiceID:        c.InvoiceID(),
+		DueDate:          c.DueDate(),
+		Status:           string(c.Status()),
+		LastReminderDay:  c.LastReminderDay(),
+		RemindersSent:    c.RemindersSent(),
+		ReadOnlyAt:       c.ReadOnlyAt(),
+		SuspendedAt:      c.SuspendedAt(),
+		ResolvedAt:       c.ResolvedAt(),
+		Resolution:       c.Resolution(),
+		CreatedAt:        c.CreatedAt(),
+		UpdatedAt:        c.UpdatedAt(),
+	}
+}
+
+func dunningCaseToDomain(e *entities.DunningCaseEntity) *billing.DunningCase {
+	return billing.ReconstructDunningCase(
+		e.ID,
+		e.Version,
+		e.BillingAccountID,
+		e.InvoiceID,
+		e.DueDate,
+		billing.DunningStatus(e.Status),
+		e.LastReminderDay,
+		e.RemindersSent,
+		e.ReadOnlyAt,
+		e.SuspendedAt,
+		e.ResolvedAt,
+		e.Resolution,
+		e.CreatedAt,
+		e.UpdatedAt,
+	)
+}
+
+// GormBillingEventRepository implementa billing.BillingEventRepository usando GORM
+type GormBillingEventRepository struct {
+	db *gorm.DB
+}
+
+// NewGormBillingEventRepository cria uma nova instância do repositório
+func NewGormBillingEventRepository(db *gorm.DB) billing.BillingEventRepository {
+	return &GormBillingEventRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormBillingEventRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appshared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Append grava um registro na linha do tempo (append-only)
+func (r *GormBillingEventRepository) Append(ctx context.Context, e *billing.BillingEvent) error {
+	entity, err := billingEventToEntity(e)
+	if err != nil {
+		return err
+	}
+	return r.getDB(ctx).Omit("BillingAccount").Create(entity).Error
+}
+
+// ListByAccount lista a linha do tempo da conta, do mais recente para o mais antigo
+func (r *GormBillingEventRepository) ListByAccount(ctx context.Context, billingAccountID uuid.UUID, limit, offset int) ([]*billing.BillingEvent, int64, error) {
+	var total int64
+	err := r.getDB(ctx).Model(&entities.BillingEventEntity{}).
+		Where("billing_account_id = ?", billingAccountID).
+		Count(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var list []entities.BillingEventEntity
+	err = r.getDB(ctx).Where("billing_account_id = ?", billingAccountID).Order("occurred_at DESC, id DESC").Limit(limit).Offset(offset).Find(&list).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	events := make([]*billing.BillingEvent, 0, len(list))
+	for i := range list {
+		event, err := billingEventToDomain(&list[i])
+		if err != nil {
+			return nil, 0, err
+		}
+		events = append(events, event)
+	}
+	return events, total, nil
+}
+
+func billingEventToEntity(e *billing.BillingEvent) (*entities.BillingEventEntity, error) {
+	data := e.Data()
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return &entities.BillingEventEntity{
+		ID:               e.ID(),
+		BillingAccountID: e.BillingAccountID(),
+		InvoiceID:        e.InvoiceID(),
+		EventType:        e.Type(),
+		Message:          e.Message(),
+		Data:             raw,
+		OccurredAt:       e.OccurredAt(),
+	}, nil
+}
+
+func billingEventToDomain(e *entities.BillingEventEntity) (*billing.BillingEvent, error) {
+	data := map[string]interface{}{}
+	if len(e.Data) > 0 {
+		if err := json.Unmarshal(e.Data, &data); err != nil {
+			return nil, err
+		}
+	}
+	return billing.ReconstructBillingEvent(
+		e.ID,
+		e.BillingAccountID,
+		e.InvoiceID,
+		e.EventType,
+		e.Message,
+		data,
+		e.OccurredAt,
+	), nil
+}
@@ -0,0 +1,60 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+)
+
+func TestDunningCaseMapping(t *testing.T) {
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c, err := billing.NewDunningCase(uuid.New(), uuid.New(), due)
+	require.NoError(t, err)
+	require.NoError(t, c.RecordReminder(3, due.AddDate(0, 0, 3)))
+	require.NoError(t, c.RecordReadOnly(due.AddDate(0, 0, 7)))
+
+	entity := dunningCaseToEntity(c)
+	assert.Equal(t, "open", entity.Status)
+	require.NotNil(t, entity.LastReminderDay)
+	assert.Equal(t, 3, *entity.LastReminderDay)
+	assert.Equal(t, 1, entity.RemindersSent)
+	assert.NotNil(t, entity.ReadOnlyAt)
+	assert.Nil(t, entity.SuspendedAt)
+
+	restored := dunningCaseToDomain(entity)
+	assert.Equal(t, c.ID(), restored.ID())
+	assert.Equal(t, c.BillingAccountID(), restored.BillingAccountID())
+	assert.Equal(t, c.InvoiceID(), restored.InvoiceID())
+	assert.Equal(t, due, restored.DueDate())
+	assert.True(t, restored.IsOpen())
+	assert.Empty(t, restored.DomainEvents())
+
+	// O passo já registrado não volta a ficar pendente depois da reconstrução
+	steps := restored.PendingSteps(billing.DefaultDunningPolicy(), due.AddDate(0, 0, 8))
+	require.Len(t, steps, 1)
+	assert.Equal(t, 7, steps[0].Day)
+	assert.Equal(t, billing.DunningActionReminder, steps[0].Action)
+}
+
+func TestBillingEventMapping(t *testing.T) {
+	invoiceID := uuid.New()
+	occurredAt := time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC)
+	event := billing.NewBillingEvent(uuid.New(), &invoiceID, "billing.dunning.reminder_sent", "Lembrete enviado", map[string]interface{}{"day": 3}, occurredAt)
+
+	entity, err := billingEventToEntity(event)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"day":3}`, string(entity.Data))
+
+	restored, err := billingEventToDomain(entity)
+	require.NoError(t, err)
+	assert.Equal(t, event.ID(), restored.ID())
+	assert.Equal(t, &invoiceID, restored.InvoiceID())
+	assert.Equal(t, "billing.dunning.reminder_sent", restored.Type())
+	assert.Equal(t, "Lembrete enviado", restored.Message())
+	assert.Equal(t, float64(3), restored.Data()["day"])
+	assert.Equal(t, occurredAt, restored.OccurredAt())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appshared "github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"gorm.io/gorm"
 )
@@ -21,15 +22,23 @@ func NewGormInvoiceRepository(db *gorm.DB) *GormInvoiceRepository {
 	return &GormInvoiceRepository{db: db}
 }
 
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormInvoiceRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appshared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 // Create cria uma nova invoice
 func (r *GormInvoiceRepository) Create(ctx context.Context, invoice *entities.InvoiceEntity) error {
-	return r.db.WithContext(ctx).Create(invoice).Error
+	return r.getDB(ctx).Create(invoice).Error
 }
 
 // FindByID busca uma invoice por ID
 func (r *GormInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.InvoiceEntity, error) {
 	var invoice entities.InvoiceEntity
-	err := r.db.WithContext(ctx).First(&invoice, "id = ?", id).Error
+	err := r.getDB(ctx).First(&invoice, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +48,7 @@ func (r *GormInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*en
 // FindByStripeInvoiceID busca uma invoice pelo Stripe Invoice ID
 func (r *GormInvoiceRepository) FindByStripeInvoiceID(ctx context.Context, stripeInvoiceID string) (*entities.InvoiceEntity, error) {
 	var invoice entities.InvoiceEntity
-	err := r.db.WithContext(ctx).Where("stripe_invoice_id = ?", stripeInvoiceID).First(&invoice).Error
+	err := r.getDB(ctx).Where("stripe_invoice_id = ?", stripeInvoiceID).First(&invoice).Error
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +58,7 @@ func (r *GormInvoiceRepository) FindByStripeInvoiceID(ctx context.Context, strip
 // FindByBillingAccountID busca todas as invoices de uma billing account
 func (r *GormInvoiceRepository) FindByBillingAccountID(ctx context.Context, billingAccountID uuid.UUID) ([]*entities.InvoiceEntity, error) {
 	var invoices []*entities.InvoiceEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("billing_account_id = ?", billingAccountID).
 		Order("created_at DESC").
 		Find(&invoices).Error
@@ -59,7 +68,7 @@ func (r *GormInvoiceRepository) FindByBillingAccountID(ctx context.Context, bill
 // FindBySubscriptionID busca todas as invoices de uma subscription
 func (r *GormInvoiceRepository) FindBySubscriptionID(ctx context.Context, subscriptionID uuid.UUID) ([]*entities.InvoiceEntity, error) {
 	var invoices []*entities.InvoiceEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("subscription_id = ?", subscriptionID).
 		Order("created_at DESC").
 		Find(&invoices).Error
@@ -70,7 +79,7 @@ func (r *GormInvoiceRepository) FindBySubscriptionID(ctx context.Context, subscr
 func (r *GormInvoiceRepository) FindOverdueInvoices(ctx context.Context) ([]*entities.InvoiceEntity, error) {
 	var invoices []*entities.InvoiceEntity
 	now := time.Now()
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("status IN (?) AND due_date < ?", []string{"open", "past_due"}, now).
 		Order("due_date ASC").
 		Find(&invoices).Error
@@ -80,7 +89,7 @@ func (r *GormInvoiceRepository) FindOverdueInvoices(ctx context.Context) ([]*ent
 // FindByStatus busca invoices por status
 func (r *GormInvoiceRepository) FindByStatus(ctx context.Context, status string, limit int) ([]*entities.InvoiceEntity, error) {
 	var invoices []*entities.InvoiceEntity
-	query := r.db.WithContext(ctx).
+	query := r.getDB(ctx).
 		Where("status = ?", status).
 		Order("created_at DESC")
 
@@ -96,18 +105,18 @@ func (r *GormInvoiceRepository) FindByStatus(ctx context.Context, status string,
 func (r *GormInvoiceRepository) Update(ctx context.Context, invoice *entities.InvoiceEntity) error {
 	// Check if exists
 	var existing entities.InvoiceEntity
-	err := r.db.WithContext(ctx).Where("id = ?", invoice.ID).First(&existing).Error
+	err := r.getDB(ctx).Where("id = ?", invoice.ID).First(&existing).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Insert if not found
-			return r.db.WithContext(ctx).Create(invoice).Error
+			return r.getDB(ctx).Create(invoice).Error
 		}
 		return err
 	}
 
 	// Update with optimistic locking
-	result := r.db.WithContext(ctx).Model(&entities.InvoiceEntity{}).
+	result := r.getDB(ctx).Model(&entities.InvoiceEntity{}).
 		Where("id = ? AND version = ?", invoice.ID, existing.Version).
 		Updates(map[string]interface{}{
 			"version":                existing.Version + 1, // Increment version
@@ -147,5 +156,5 @@ func (r *GormInvoiceRepository) Update(ctx context.Context, invoice *entities.In
 
 // Delete deleta uma invoice (soft delete)
 func (r *GormInvoiceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.InvoiceEntity{}, "id = ?", id).Error
+	return r.getDB(ctx).Delete(&entities.InvoiceEntity{}, "id = ?", id).Error
 }
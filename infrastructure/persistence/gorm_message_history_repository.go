@@ -0,0 +1,299 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/application/shared"
+	domainShared "github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/message"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GormMessageHistoryRepository é o read side do histórico de mensagens.
+// Pagina por keyset (timestamp, id) — nunca por OFFSET — usando os índices
+// (session_id|contact_id, timestamp, id) da migration 000057.
+type GormMessageHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormMessageHistoryRepository(db *gorm.DB) message.HistoryRepository {
+	return &GormMessageHistoryRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormMessageHistoryRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+const historyMessageColumns = `
+	m.id, m.session_id, m.contact_id, m.channel_id, ch.name AS channel_name,
+	m.from_me, m.content_type, m.text, m.media_url, m.media_mimetype, m.status,
+	m.agent_id, a.name AS agent_name, m.reply_to_id, m.metadata,
+	m.timestamp, m.delivered_at, m.read_at, m.created_at,
+	r.id AS reply_id, r.from_me AS reply_from_me, r.content_type AS reply_content_type,
+	r.text AS reply_text, r.timestamp AS reply_timestamp`
+
+const historyMessageJoins = `
+	LEFT JOIN channels ch ON ch.id = m.channel_id
+	LEFT JOIN agents a ON a.id = m.agent_id
+	LEFT JOIN messages r ON r.id = m.reply_to_id AND r.deleted_at IS NULL`
+
+type historyMessageRow struct {
+	ID               uuid.UUID
+	SessionID        *uuid.UUID
+	ContactID        uuid.UUID
+	ChannelID        uuid.UUID
+	ChannelName      *string
+	FromMe           bool
+	ContentType      string
+	Text             *string
+	MediaURL         *string
+	MediaMimetype    *string
+	Status           string
+	AgentID          *uuid.UUID
+	AgentName        *string
+	ReplyToID        *uuid.UUID
+	Metadata         datatypes.JSON
+	Timestamp        time.Time
+	DeliveredAt      *time.Time
+	ReadAt           *time.Time
+	CreatedAt        time.Time
+	ReplyID          *uuid.UUID
+	ReplyFromMe      *bool
+	ReplyContentType *string
+	ReplyText        *string
+	ReplyTimestamp   *time.Time
+}
+
+type historyEnrichmentRow struct {
+	MessageID     uuid.UUID
+	ContentType   string
+	Provider      string
+	Status        string
+	ExtractedText *string
+	ProcessedAt   *time.Time
+}
+
+func (r *GormMessageHistoryRepository) FindHistory(ctx context.Context, filter message.HistoryFilter) (*message.HistoryPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	db := r.getDB(ctx)
+	sql, args := messageHistoryQuery(filter)
+
+	var rows []historyMessageRow
+	if err := db.Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load message history: %w", err)
+	}
+
+	hasMore := len(rows) > filter.Limit
+	if hasMore {
+		rows = rows[:filter.Limit]
+	}
+
+	messages := make([]*message.HistoryMessage, len(rows))
+	for i := range rows {
+		messages[i] = historyRowToMessage(&rows[i])
+	}
+
+	page := &message.HistoryPage{}
+	if filter.Direction == domainShared.PageNewer {
+		page.HasNewer = hasMore
+		page.HasOlder = filter.Cursor != nil
+	} else {
+		// Consultado do mais recente para o mais antigo; a página é devolvida em ordem cronológica
+		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+			messages[i], messages[j] = messages[j], messages[i]
+		}
+		page.HasOlder = hasMore
+		page.HasNewer = filter.Cursor != nil
+	}
+	page.Messages = messages
+
+	if err := r.attachEnrichments(db, messages); err != nil {
+		return nil, err
+	}
+
+	return page, nil
+}
+
+func (r *GormMessageHistoryRepository) FindReplyThread(ctx context.Context, tenantID string, messageID uuid.UUID) ([]*message.HistoryMessage, error) {
+	db := r.getDB(ctx)
+
+	// Sobe até a raiz da cadeia de citações e desce por todas as respostas.
+	// A profundidade é limitada para tolerar ciclos em dados importados.
+	var rows []historyMessageRow
+	if err := db.Raw(`
+		WITH RECURSIVE ancestors AS (
+			SELECT id, reply_to_id, 0 AS depth FROM messages
+			WHERE id = ? AND tenant_id = ? AND deleted_at IS NULL
+			UNION ALL
+			SELECT p.id, p.reply_to_id, an.depth + 1 FROM messages p
+			JOIN ancestors an ON p.id = an.reply_to_id
+			WHERE p.tenant_id = ? AND p.deleted_at IS NULL AND an.depth < 50
+		),
+		root AS (
+			SELECT id FROM ancestors ORDER BY depth DESC LIMIT 1
+		),
+		thread AS (
+			SELECT id, 0 AS depth FROM root
+			UNION ALL
+			SELECT c.id, t.depth + 1 FROM messages c
+			JOIN thread t ON c.reply_to_id = t.id
+			WHERE c.tenant_id = ? AND c.deleted_at IS NULL AND t.depth < 50
+		)
+		SELECT `+historyMessageColumns+`
+		FROM messages m`+historyMessageJoins+`
+		WHERE m.id IN (SELECT id FROM thread)
+		ORDER BY m.timestamp, m.id
+		LIMIT ?`, messageID, tenantID, tenantID, tenantID, message.MaxReplyThreadSize).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load reply thread: %w", err)
+	}
+
+	if len(rows) == 0 {
+		return nil, message.ErrMessageNotFound
+	}
+
+	messages := make([]*message.HistoryMessage, len(rows))
+	for i := range rows {
+		messages[i] = historyRowToMessage(&rows[i])
+	}
+
+	if err := r.attachEnrichments(db, messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// attachEnrichments inclui transcrições, OCR e parsing de documentos em cada mensagem
+func (r *GormMessageHistoryRepository) attachEnrichments(db *gorm.DB, messages []*message.HistoryMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(messages))
+	byID := make(map[uuid.UUID]*message.HistoryMessage, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+		byID[m.ID] = m
+	}
+
+	var rows []historyEnrichmentRow
+	if err := db.Raw(`
+		SELECT message_id, content_type, provider, status, extracted_text, processed_at
+		FROM message_enrichments
+		WHERE message_id IN ?
+		ORDER BY created_at`, ids).
+		Scan(&rows).Error; err != nil {
+		return fmt.Errorf("failed to load message enrichments: %w", err)
+	}
+
+	for _, row := range rows {
+		if m, ok := byID[row.MessageID]; ok {
+			m.Enrichments = append(m.Enrichments, message.HistoryEnrichment{
+				ContentType:   row.ContentType,
+				Provider:      row.Provider,
+				Status:        row.Status,
+				ExtractedText: row.ExtractedText,
+				ProcessedAt:   row.ProcessedAt,
+			})
+		}
+	}
+
+	return nil
+}
+
+// messageHistoryQuery monta a consulta keyset. Busca Limit+1 linhas para saber se há mais páginas.
+func messageHistoryQuery(filter message.HistoryFilter) (string, []interface{}) {
+	where := []string{"m.tenant_id = ?", "m.deleted_at IS NULL"}
+	args := []interface{}{filter.TenantID}
+
+	if filter.SessionID != nil {
+		where = append(where, "m.session_id = ?")
+		args = append(args, *filter.SessionID)
+	}
+	if filter.ContactID != nil {
+		where = append(where, "m.contact_id = ?")
+		args = append(args, *filter.ContactID)
+	}
+	if filter.ChannelID != nil {
+		where = append(where, "m.channel_id = ?")
+		args = append(args, *filter.ChannelID)
+	}
+
+	order, op := "DESC", "<"
+	if filter.Direction == domainShared.PageNewer {
+		order, op = "ASC", ">"
+	}
+
+	if filter.Cursor != nil {
+		where = append(where, "(m.timestamp, m.id) "+op+" (?, ?)")
+		args = append(args, filter.Cursor.Timestamp, filter.Cursor.ID)
+	}
+
+	args = append(args, filter.Limit+1)
+
+	return `SELECT ` + historyMessageColumns + `
+		FROM messages m` + historyMessageJoins + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY m.timestamp ` + order + `, m.id ` + order + `
+		LIMIT ?`, args
+}
+
+func historyRowToMessage(row *historyMessageRow) *message.HistoryMessage {
+	m := &message.HistoryMessage{
+		ID:            row.ID,
+		SessionID:     row.SessionID,
+		ContactID:     row.ContactID,
+		ChannelID:     row.ChannelID,
+		ChannelName:   row.ChannelName,
+		FromMe:        row.FromMe,
+		ContentType:   row.ContentType,
+		Text:          row.Text,
+		MediaURL:      row.MediaURL,
+		MediaMimetype: row.MediaMimetype,
+		Status:        row.Status,
+		AgentID:       row.AgentID,
+		AgentName:     row.AgentName,
+		ReplyToID:     row.ReplyToID,
+		Timestamp:     row.Timestamp,
+		DeliveredAt:   row.DeliveredAt,
+		ReadAt:        row.ReadAt,
+		CreatedAt:     row.CreatedAt,
+	}
+
+	if len(row.Metadata) > 0 {
+		_ = json.Unmarshal(row.Metadata, &m.Metadata)
+	}
+
+	if row.ReplyID != nil {
+		reply := &message.ReplyPreview{
+			ID:   *row.ReplyID,
+			Text: row.ReplyText,
+		}
+		if row.ReplyFromMe != nil {
+			reply.FromMe = *row.ReplyFromMe
+		}
+		if row.ReplyContentType != nil {
+			reply.ContentType = *row.ReplyContentType
+		}
+		if row.ReplyTimestamp != nil {
+			reply.Timestamp = *row.ReplyTimestamp
+		}
+		m.ReplyTo = reply
+	}
+
+	return m
+}
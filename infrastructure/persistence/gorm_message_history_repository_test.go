@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	domainShared "github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/message"
+)
+
+func TestMessageHistoryQuery_OlderFromCursor(t *testing.T) {
+	sessionID := uuid.New()
+	cursor := domainShared.NewCursor(time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC), uuid.New())
+
+	sql, args := messageHistoryQuery(message.HistoryFilter{
+		TenantID:  "tenant-1",
+		SessionID: &sessionID,
+		Cursor:    &cursor,
+		Direction: domainShared.PageOlder,
+		Limit:     50,
+	})
+
+	assert.Contains(t, sql, "(m.timestamp, m.id) < (?, ?)")
+	assert.Contains(t, sql, "ORDER BY m.timestamp DESC, m.id DESC")
+	assert.NotContains(t, sql, "OFFSET")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{"tenant-1", sessionID, cursor.Timestamp, cursor.ID, 51}, args)
+}
+
+func TestMessageHistoryQuery_NewerByContactAndChannel(t *testing.T) {
+	contactID := uuid.New()
+	channelID := uuid.New()
+
+	sql, args := messageHistoryQuery(message.HistoryFilter{
+		TenantID:  "tenant-1",
+		ContactID: &contactID,
+		ChannelID: &channelID,
+		Direction: domainShared.PageNewer,
+		Limit:     20,
+	})
+
+	assert.Contains(t, sql, "m.contact_id = ? AND m.channel_id = ?")
+	assert.Contains(t, sql, "ORDER BY m.timestamp ASC, m.id ASC")
+	assert.NotContains(t, sql, "(m.timestamp, m.id)")
+	assert.Equal(t, []interface{}{"tenant-1", contactID, channelID, 21}, args)
+}
+
+func TestHistoryRowToMessage_ReplyPreview(t *testing.T) {
+	replyID := uuid.New()
+	fromMe := true
+	text := "original"
+
+	m := historyRowToMessage(&historyMessageRow{
+		ID:          uuid.New(),
+		ReplyToID:   &replyID,
+		ReplyID:     &replyID,
+		ReplyFromMe: &fromMe,
+		ReplyText:   &text,
+		Metadata:    []byte(`{"quoted": true}`),
+	})
+
+	assert.Equal(t, replyID, m.ReplyTo.ID)
+	assert.True(t, m.ReplyTo.FromMe)
+	assert.Equal(t, &text, m.ReplyTo.Text)
+	assert.Equal(t, true, m.Metadata["quoted"])
+
+	deleted := historyRowToMessage(&historyMessageRow{ID: uuid.New(), ReplyToID: &replyID})
+	assert.Nil(t, deleted.ReplyTo, "citação de mensagem removida não gera preview")
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/saga"
 	"github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/search"
 	"gorm.io/gorm"
 )
 
@@ -219,9 +220,9 @@ func (r *GormMessageRepository) SearchByText(ctx context.Context, tenantID strin
 	// Apply tenant filter
 	query = query.Where("tenant_id = ?", tenantID)
 
-	// Text search in message text content
-	searchPattern := "%" + searchText + "%"
-	query = query.Where("text ILIKE ?", searchPattern)
+	// Full-text search in message text content (search_vector)
+	tsq, tsqArgs := searchTSQuery(search.Filter{Query: searchText})
+	query = query.Where("search_vector @@ "+tsq, tsqArgs...)
 
 	// Count total results
 	var total int64
@@ -230,7 +231,7 @@ func (r *GormMessageRepository) SearchByText(ctx context.Context, tenantID strin
 	}
 
 	// Apply pagination and sorting
-	query = query.Order("timestamp DESC")
+	query = query.Order(gorm.Expr("ts_rank_cd(search_vector, "+tsq+") DESC, timestamp DESC", tsqArgs...))
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
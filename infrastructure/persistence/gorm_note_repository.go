@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
 	"github.com/ventros/crm/internal/domain/crm/note"
+	"github.com/ventros/crm/internal/domain/crm/search"
 	"gorm.io/gorm"
 )
 
@@ -256,6 +257,9 @@ func (r *GormNoteRepository) FindByTenantWithFilters(ctx context.Context, filter
 	if filters.Pinned != nil {
 		query = query.Where("pinned = ?", *filters.Pinned)
 	}
+	if filters.MentionedAgent != nil {
+		query = query.Where("mentions @> ?::jsonb", mentionFilterJSON(*filters.MentionedAgent))
+	}
 	if filters.CreatedAfter != nil {
 		query = query.Where("created_at >= ?", *filters.CreatedAfter)
 	}
@@ -313,12 +317,9 @@ func (r *GormNoteRepository) SearchByText(ctx context.Context, tenantID string,
 	// Apply tenant filter
 	query = query.Where("tenant_id = ?", tenantID)
 
-	// Text search in content and author_name
-	searchPattern := "%" + searchText + "%"
-	query = query.Where(
-		r.db.Where("content ILIKE ?", searchPattern).
-			Or("author_name ILIKE ?", searchPattern),
-	)
+	// Full-text search in content (search_vector); author_name by substring
+	tsq, tsqArgs := searchTSQuery(search.Filter{Query: searchText})
+	query = query.Where("(search_vector @@ "+tsq+" OR author_name ILIKE ?)", append(tsqArgs, "%"+searchText+"%")...)
 
 	// Count total results
 	var total int64
@@ -327,7 +328,7 @@ func (r *GormNoteRepository) SearchByText(ctx context.Context, tenantID string,
 	}
 
 	// Apply pagination and sorting
-	query = query.Order("created_at DESC")
+	query = query.Order(gorm.Expr("ts_rank_cd(search_vector, "+tsq+") DESC, created_at DESC", tsqArgs...))
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
@@ -353,3 +354,8 @@ func (r *GormNoteRepository) SearchByText(ctx context.Context, tenantID string,
 
 	return notes, total, nil
 }
+
+// mentionFilterJSON monta o array JSONB usado com @> (aproveita o índice GIN de mentions)
+func mentionFilterJSON(agentID uuid.UUID) string {
+	return `["` + agentID.String() + `"]`
+}
@@ -0,0 +1,27 @@
+package persistence
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/note"
+)
+
+func TestNoteMentionFilterJSON(t *testing.T) {
+	agentID := uuid.New()
+
+	// Deve ter o mesmo formato que domainToEntity grava em mentions
+	n, err := note.NewNote(uuid.New(), "tenant-1", uuid.New(), note.AuthorTypeAgent, "Carla", "Veja isso", note.NoteTypeGeneral)
+	require.NoError(t, err)
+	n.MentionAgent(agentID)
+	stored := (&GormNoteRepository{}).domainToEntity(n).Mentions
+
+	var storedIDs, filterIDs []string
+	require.NoError(t, json.Unmarshal(stored, &storedIDs))
+	require.NoError(t, json.Unmarshal([]byte(mentionFilterJSON(agentID)), &filterIDs))
+
+	assert.Equal(t, storedIDs, filterIDs)
+}
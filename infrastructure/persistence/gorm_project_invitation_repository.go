@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormProjectInvitationRepository convites para projetos em project_invitations
+type GormProjectInvitationRepository struct {
+	db *gorm.DB
+}
+
+func NewGormProjectInvitationRepository(db *gorm.DB) project_member.InvitationRepository {
+	return &GormProjectInvitationRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormProjectInvitationRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormProjectInvitationRepository) Save(ctx context.Context, invitation *project_member.Invitation) error {
+	entity := projectInvitationToEntity(invitation)
+	// Depois de criado, o convite só muda ao ser respondido ou revogado
+	err := r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "accepted_by", "responded_at", "updated_at"}),
+	}).Create(entity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save project invitation: %w", err)
+	}
+	return nil
+}
+
+func (r *GormProjectInvitationRepository) FindByID(ctx context.Context, projectID, id uuid.UUID) (*project_member.Invitation, error) {
+	return r.findOne(ctx, "project_id = ? AND id = ?", projectID, id)
+}
+
+func (r *GormProjectInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*project_member.Invitation, error) {
+	return r.findOne(ctx, "token_hash = ?", tokenHash)
+}
+
+func (r *GormProjectInvitationRepository) FindPendingByEmail(ctx context.Context, projectID uuid.UUID, email string) (*project_member.Invitation, error) {
+	return r.findOne(ctx, "project_id = ? AND email = ? AND status = ?", projectID, email, string(project_member.InvitationPending))
+}
+
+func (r *GormProjectInvitationRepository) findOne(ctx context.Context, where string, args ...interface{}) (*project_member.Invitation, error) {
+	var entity entities.ProjectInvitationEntity
+	if err := r.getDB(ctx).Where(where, args...).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, project_member.ErrInvitationNotFound
+		}
+		return nil, fmt.Errorf("failed to load project invitation: %w", err)
+	}
+	return projectInvitationToDomain(entity), nil
+}
+
+func (r *GormProjectInvitationRepository) FindByProject(ctx context.Context, projectID uuid.UUID, status project_member.InvitationStatus) ([]*project_member.Invitation, error) {
+	query := r.getDB(ctx).Where("project_id = ?", projectID)
+	if status != "" {
+		query = query.Where("status = ?", string(status))
+	}
+
+	var rows []entities.ProjectInvitationEntity
+	if err := query.Order("created_at DESC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list project invitations: %w", err)
+	}
+
+	invitations := make([]*project_member.Invitation, len(rows))
+	for i, row := range rows {
+		invitations[i] = projectInvitationToDomain(row)
+	}
+	return invitations, nil
+}
+
+func projectInvitationToEntity(i *project_member.Invitation) *entities.ProjectInvitationEntity {
+	return &entities.ProjectInvitationEntity{
+		ID:          i.ID(),
+		ProjectID:   i.ProjectID(),
+		Email:       i.Email(),
+		Role:        string(i.Role()),
+		TokenHash:   i.TokenHash(),
+		InvitedBy:   i.InvitedBy(),
+		Status:      string(i.Status()),
+		ExpiresAt:   i.ExpiresAt(),
+		AcceptedBy:  i.AcceptedBy(),
+		RespondedAt: i.RespondedAt(),
+		CreatedAt:   i.CreatedAt(),
+		UpdatedAt:   i.UpdatedAt(),
+	}
+}
+
+func projectInvitationToDomain(entity entities.ProjectInvitationEntity) *project_member.Invitation {
+	return project_member.ReconstructInvitation(
+		entity.ID,
+		entity.ProjectID,
+		entity.Email,
+		project_member.ProjectMemberRole(entity.Role),
+		entity.TokenHash,
+		entity.InvitedBy,
+		project_member.InvitationStatus(entity.Status),
+		entity.ExpiresAt,
+		entity.AcceptedBy,
+		entity.RespondedAt,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
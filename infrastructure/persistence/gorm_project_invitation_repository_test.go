@@ -0,0 +1,32 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+func TestProjectInvitationMapping(t *testing.T) {
+	inv, token, err := project_member.NewInvitation(uuid.New(), "maria@example.com", project_member.RoleSupervisor, uuid.NewString(), time.Hour)
+	require.NoError(t, err)
+	userID := uuid.NewString()
+	require.NoError(t, inv.Accept(userID, "maria@example.com", time.Now()))
+
+	entity := projectInvitationToEntity(inv)
+	assert.Equal(t, "accepted", entity.Status)
+	assert.Equal(t, "supervisor", entity.Role)
+	assert.NotEqual(t, token, entity.TokenHash, "only the token hash is stored")
+
+	restored := projectInvitationToDomain(*entity)
+	assert.Equal(t, inv.ID(), restored.ID())
+	assert.Equal(t, inv.ProjectID(), restored.ProjectID())
+	assert.Equal(t, project_member.HashInvitationToken(token), restored.TokenHash())
+	assert.Equal(t, project_member.InvitationAccepted, restored.Status())
+	assert.Equal(t, userID, restored.AcceptedBy())
+	require.NotNil(t, restored.RespondedAt())
+	assert.Empty(t, restored.DomainEvents())
+}
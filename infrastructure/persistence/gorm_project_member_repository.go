@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appShared "github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/project_member"
 	"gorm.io/gorm"
@@ -21,17 +22,25 @@ func NewGormProjectMemberRepository(db *gorm.DB) *GormProjectMemberRepository {
 	return &GormProjectMemberRepository{db: db}
 }
 
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormProjectMemberRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appShared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 // Save persiste um ProjectMember (create ou update)
 func (r *GormProjectMemberRepository) Save(ctx context.Context, member *project_member.ProjectMember) error {
 	entity := r.toEntity(member)
 
 	// Check if exists
 	var existing entities.ProjectMemberEntity
-	err := r.db.WithContext(ctx).Where("id = ?", entity.ID).First(&existing).Error
+	err := r.getDB(ctx).Where("id = ?", entity.ID).First(&existing).Error
 
 	if err == nil {
 		// UPDATE with optimistic locking
-		return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
 			result := tx.Model(&entities.ProjectMemberEntity{}).
 				Where("id = ? AND version = ?", entity.ID, existing.Version).
 				Updates(map[string]interface{}{
@@ -64,7 +73,7 @@ func (r *GormProjectMemberRepository) Save(ctx context.Context, member *project_
 
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		// INSERT
-		return r.db.WithContext(ctx).Create(entity).Error
+		return r.getDB(ctx).Create(entity).Error
 	}
 
 	return err
@@ -73,7 +82,7 @@ func (r *GormProjectMemberRepository) Save(ctx context.Context, member *project_
 // FindByID busca um ProjectMember por ID
 func (r *GormProjectMemberRepository) FindByID(ctx context.Context, id uuid.UUID) (*project_member.ProjectMember, error) {
 	var entity entities.ProjectMemberEntity
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&entity).Error
+	err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, project_member.ErrMemberNotFound
@@ -86,7 +95,7 @@ func (r *GormProjectMemberRepository) FindByID(ctx context.Context, id uuid.UUID
 // FindByProjectAndAgent busca um membro específico de um projeto
 func (r *GormProjectMemberRepository) FindByProjectAndAgent(ctx context.Context, projectID uuid.UUID, agentID string) (*project_member.ProjectMember, error) {
 	var entity entities.ProjectMemberEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("project_id = ? AND agent_id = ?", projectID, agentID).
 		First(&entity).Error
 	if err != nil {
@@ -101,7 +110,7 @@ func (r *GormProjectMemberRepository) FindByProjectAndAgent(ctx context.Context,
 // FindByProject busca todos os membros de um projeto
 func (r *GormProjectMemberRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*project_member.ProjectMember, error) {
 	var entities []entities.ProjectMemberEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("project_id = ?", projectID).
 		Order("role ASC, created_at ASC").
 		Find(&entities).Error
@@ -119,7 +128,7 @@ func (r *GormProjectMemberRepository) FindByProject(ctx context.Context, project
 // FindByAgent busca todos os projetos de um agent
 func (r *GormProjectMemberRepository) FindByAgent(ctx context.Context, agentID string) ([]*project_member.ProjectMember, error) {
 	var entities []entities.ProjectMemberEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("agent_id = ?", agentID).
 		Order("created_at DESC").
 		Find(&entities).Error
@@ -137,7 +146,7 @@ func (r *GormProjectMemberRepository) FindByAgent(ctx context.Context, agentID s
 // FindAdminsByProject busca todos os admins de um projeto
 func (r *GormProjectMemberRepository) FindAdminsByProject(ctx context.Context, projectID uuid.UUID) ([]*project_member.ProjectMember, error) {
 	var entities []entities.ProjectMemberEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("project_id = ? AND role = ?", projectID, string(project_member.RoleAdmin)).
 		Order("created_at ASC").
 		Find(&entities).Error
@@ -155,7 +164,7 @@ func (r *GormProjectMemberRepository) FindAdminsByProject(ctx context.Context, p
 // CountAdminsByProject conta quantos admins tem em um projeto
 func (r *GormProjectMemberRepository) CountAdminsByProject(ctx context.Context, projectID uuid.UUID) (int, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Model(&entities.ProjectMemberEntity{}).
 		Where("project_id = ? AND role = ?", projectID, string(project_member.RoleAdmin)).
 		Count(&count).Error
@@ -165,16 +174,17 @@ func (r *GormProjectMemberRepository) CountAdminsByProject(ctx context.Context,
 // ExistsInProject verifica se um agent já é membro de um projeto
 func (r *GormProjectMemberRepository) ExistsInProject(ctx context.Context, projectID uuid.UUID, agentID string) (bool, error) {
 	var count int64
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Model(&entities.ProjectMemberEntity{}).
 		Where("project_id = ? AND agent_id = ?", projectID, agentID).
 		Count(&count).Error
 	return count > 0, err
 }
 
-// Delete remove um ProjectMember (soft delete)
+// Delete remove um ProjectMember (a entidade não tem gorm.DeletedAt: a linha é apagada e o
+// usuário pode ser convidado de novo)
 func (r *GormProjectMemberRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.ProjectMemberEntity{}, "id = ?", id).Error
+	return r.getDB(ctx).Delete(&entities.ProjectMemberEntity{}, "id = ?", id).Error
 }
 
 // toEntity converte domain para entity
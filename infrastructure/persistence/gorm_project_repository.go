@@ -2,11 +2,13 @@ package persistence
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appShared "github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/project"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"gorm.io/gorm"
@@ -22,17 +24,28 @@ func NewGormProjectRepository(db *gorm.DB) *GormProjectRepository {
 	return &GormProjectRepository{db: db}
 }
 
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormProjectRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appShared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 // Save saves a project to the database
 func (r *GormProjectRepository) Save(ctx context.Context, proj *project.Project) error {
-	entity := r.domainToEntity(proj)
+	entity, err := r.domainToEntity(proj)
+	if err != nil {
+		return err
+	}
 
 	// Check if exists
 	var existing entities.ProjectEntity
-	err := r.db.WithContext(ctx).Where("id = ?", entity.ID).First(&existing).Error
+	err = r.getDB(ctx).Where("id = ?", entity.ID).First(&existing).Error
 
 	if err == nil {
 		// Update with optimistic locking
-		result := r.db.WithContext(ctx).Model(&entities.ProjectEntity{}).
+		result := r.getDB(ctx).Model(&entities.ProjectEntity{}).
 			Where("id = ? AND version = ?", entity.ID, existing.Version).
 			Updates(map[string]interface{}{
 				"version":                 existing.Version + 1, // Increment version
@@ -44,6 +57,8 @@ func (r *GormProjectRepository) Save(ctx context.Context, proj *project.Project)
 				"configuration":           entity.Configuration,
 				"active":                  entity.Active,
 				"session_timeout_minutes": entity.SessionTimeoutMinutes,
+				"agent_assignment":        entity.AgentAssignment,
+				"require_two_factor":      entity.RequireTwoFactor,
 				"updated_at":              entity.UpdatedAt,
 			})
 
@@ -64,7 +79,7 @@ func (r *GormProjectRepository) Save(ctx context.Context, proj *project.Project)
 		return nil
 	} else if errors.Is(err, gorm.ErrRecordNotFound) {
 		// Insert
-		if err := r.db.WithContext(ctx).Create(entity).Error; err != nil {
+		if err := r.getDB(ctx).Create(entity).Error; err != nil {
 			return fmt.Errorf("failed to create project: %w", err)
 		}
 		return nil
@@ -77,7 +92,7 @@ func (r *GormProjectRepository) Save(ctx context.Context, proj *project.Project)
 func (r *GormProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
 	var entity entities.ProjectEntity
 
-	err := r.db.WithContext(ctx).Where("id = ?", id).First(&entity).Error
+	err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, project.ErrProjectNotFound
@@ -92,7 +107,7 @@ func (r *GormProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*pr
 func (r *GormProjectRepository) FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error) {
 	var entity entities.ProjectEntity
 
-	err := r.db.WithContext(ctx).Where("tenant_id = ?", tenantID).First(&entity).Error
+	err := r.getDB(ctx).Where("tenant_id = ?", tenantID).First(&entity).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, project.ErrProjectNotFound
@@ -107,7 +122,7 @@ func (r *GormProjectRepository) FindByTenantID(ctx context.Context, tenantID str
 func (r *GormProjectRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*project.Project, error) {
 	var entities []entities.ProjectEntity
 
-	err := r.db.WithContext(ctx).Where("user_id = ?", customerID).Find(&entities).Error
+	err := r.getDB(ctx).Where("user_id = ?", customerID).Find(&entities).Error
 	if err != nil {
 		return nil, fmt.Errorf("failed to find projects by customer ID: %w", err)
 	}
@@ -124,11 +139,32 @@ func (r *GormProjectRepository) FindByCustomer(ctx context.Context, customerID u
 	return projects, nil
 }
 
+// FindByBillingAccount finds the projects billed to a billing account
+func (r *GormProjectRepository) FindByBillingAccount(ctx context.Context, billingAccountID uuid.UUID) ([]*project.Project, error) {
+	var entities []entities.ProjectEntity
+
+	err := r.getDB(ctx).Where("billing_account_id = ?", billingAccountID).Find(&entities).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to find projects by billing account: %w", err)
+	}
+
+	projects := make([]*project.Project, len(entities))
+	for i, entity := range entities {
+		proj, err := r.entityToDomain(&entity)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert entity to domain: %w", err)
+		}
+		projects[i] = proj
+	}
+
+	return projects, nil
+}
+
 // FindActiveProjects finds all active projects
 func (r *GormProjectRepository) FindActiveProjects(ctx context.Context, limit, offset int) ([]*project.Project, error) {
 	var entities []entities.ProjectEntity
 
-	query := r.db.WithContext(ctx).Where("active = ?", true)
+	query := r.getDB(ctx).Where("active = ?", true)
 
 	if limit > 0 {
 		query = query.Limit(limit)
@@ -156,7 +192,7 @@ func (r *GormProjectRepository) FindActiveProjects(ctx context.Context, limit, o
 
 // Delete deletes a project (soft delete)
 func (r *GormProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	result := r.db.WithContext(ctx).Delete(&entities.ProjectEntity{}, id)
+	result := r.getDB(ctx).Delete(&entities.ProjectEntity{}, id)
 	if result.Error != nil {
 		return fmt.Errorf("failed to delete project: %w", result.Error)
 	}
@@ -169,7 +205,12 @@ func (r *GormProjectRepository) Delete(ctx context.Context, id uuid.UUID) error
 }
 
 // domainToEntity converts domain project to entity
-func (r *GormProjectRepository) domainToEntity(proj *project.Project) *entities.ProjectEntity {
+func (r *GormProjectRepository) domainToEntity(proj *project.Project) (*entities.ProjectEntity, error) {
+	agentAssignment, err := json.Marshal(proj.GetAgentAssignment())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal agent assignment: %w", err)
+	}
+
 	return &entities.ProjectEntity{
 		ID:                    proj.ID(),
 		Version:               proj.Version(),
@@ -181,15 +222,24 @@ func (r *GormProjectRepository) domainToEntity(proj *project.Project) *entities.
 		Configuration:         proj.Configuration(),
 		Active:                proj.IsActive(),
 		SessionTimeoutMinutes: proj.SessionTimeoutMinutes(),
+		AgentAssignment:       agentAssignment,
+		RequireTwoFactor:      proj.RequiresTwoFactor(),
 		CreatedAt:             proj.CreatedAt(),
 		UpdatedAt:             proj.UpdatedAt(),
-	}
+	}, nil
 }
 
 // entityToDomain converts entity to domain project
 func (r *GormProjectRepository) entityToDomain(entity *entities.ProjectEntity) (*project.Project, error) {
-	// TODO: Store and retrieve agent_assignment from database (JSONB column)
-	// For now, initialize with default config
+	// Projetos anteriores à coluna agent_assignment ficam com a configuração padrão
+	var agentAssignment *project.AgentAssignmentConfig
+	if len(entity.AgentAssignment) > 0 && string(entity.AgentAssignment) != "null" {
+		agentAssignment = &project.AgentAssignmentConfig{}
+		if err := json.Unmarshal(entity.AgentAssignment, agentAssignment); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal agent assignment: %w", err)
+		}
+	}
+
 	return project.ReconstructProject(
 		entity.ID,
 		entity.Version,
@@ -201,14 +251,15 @@ func (r *GormProjectRepository) entityToDomain(entity *entities.ProjectEntity) (
 		entity.Configuration,
 		entity.Active,
 		entity.SessionTimeoutMinutes,
-		nil, // agentAssignment - will be initialized with default by ReconstructProject
+		agentAssignment,
+		entity.RequireTwoFactor,
 		entity.CreatedAt,
 		entity.UpdatedAt,
 	), nil
 }
 
 func (r *GormProjectRepository) FindByTenantWithFilters(ctx context.Context, filters project.ProjectFilters) ([]*project.Project, int64, error) {
-	query := r.db.WithContext(ctx).Model(&entities.ProjectEntity{})
+	query := r.getDB(ctx).Model(&entities.ProjectEntity{})
 
 	// Apply tenant filter (required)
 	query = query.Where("tenant_id = ?", filters.TenantID)
@@ -266,7 +317,7 @@ func (r *GormProjectRepository) FindByTenantWithFilters(ctx context.Context, fil
 }
 
 func (r *GormProjectRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*project.Project, int64, error) {
-	query := r.db.WithContext(ctx).Model(&entities.ProjectEntity{})
+	query := r.getDB(ctx).Model(&entities.ProjectEntity{})
 
 	// Apply tenant filter
 	query = query.Where("tenant_id = ?", tenantID)
@@ -0,0 +1,126 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormProjectRoleRepository papéis custom dos projetos em project_roles
+type GormProjectRoleRepository struct {
+	db *gorm.DB
+}
+
+func NewGormProjectRoleRepository(db *gorm.DB) project_member.CustomRoleRepository {
+	return &GormProjectRoleRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormProjectRoleRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormProjectRoleRepository) Save(ctx context.Context, role *project_member.CustomRole) error {
+	entity := projectRoleToEntity(role)
+	// A chave é fixa: só nome, descrição e permissões mudam
+	err := r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "description", "permissions", "updated_at"}),
+	}).Create(entity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save project role: %w", err)
+	}
+	return nil
+}
+
+func (r *GormProjectRoleRepository) FindByID(ctx context.Context, projectID, id uuid.UUID) (*project_member.CustomRole, error) {
+	return r.findOne(ctx, "project_id = ? AND id = ?", projectID, id)
+}
+
+func (r *GormProjectRoleRepository) FindByKey(ctx context.Context, projectID uuid.UUID, key project_member.ProjectMemberRole) (*project_member.CustomRole, error) {
+	return r.findOne(ctx, "project_id = ? AND key = ?", projectID, string(key))
+}
+
+func (r *GormProjectRoleRepository) findOne(ctx context.Context, where string, args ...interface{}) (*project_member.CustomRole, error) {
+	var entity entities.ProjectRoleEntity
+	if err := r.getDB(ctx).Where(where, args...).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, project_member.ErrCustomRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to load project role: %w", err)
+	}
+	return projectRoleToDomain(entity), nil
+}
+
+func (r *GormProjectRoleRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*project_member.CustomRole, error) {
+	var rows []entities.ProjectRoleEntity
+	if err := r.getDB(ctx).Where("project_id = ?", projectID).Order("name ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list project roles: %w", err)
+	}
+
+	roles := make([]*project_member.CustomRole, len(rows))
+	for i, row := range rows {
+		roles[i] = projectRoleToDomain(row)
+	}
+	return roles, nil
+}
+
+func (r *GormProjectRoleRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	result := r.getDB(ctx).Where("project_id = ? AND id = ?", projectID, id).Delete(&entities.ProjectRoleEntity{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete project role: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return project_member.ErrCustomRoleNotFound
+	}
+	return nil
+}
+
+func projectRoleToEntity(role *project_member.CustomRole) *entities.ProjectRoleEntity {
+	permissions := make(pq.StringArray, 0, len(role.Permissions()))
+	for _, p := range role.Permissions() {
+		permissions = append(permissions, string(p))
+	}
+	return &entities.ProjectRoleEntity{
+		ID:          role.ID(),
+		ProjectID:   role.ProjectID(),
+		Key:         string(role.Key()),
+		Name:        role.Name(),
+		Description: role.Description(),
+		Permissions: permissions,
+		ClonedFrom:  string(role.ClonedFrom()),
+		CreatedBy:   role.CreatedBy(),
+		CreatedAt:   role.CreatedAt(),
+		UpdatedAt:   role.UpdatedAt(),
+	}
+}
+
+func projectRoleToDomain(entity entities.ProjectRoleEntity) *project_member.CustomRole {
+	permissions := make([]project_member.Permission, len(entity.Permissions))
+	for i, p := range entity.Permissions {
+		permissions[i] = project_member.Permission(p)
+	}
+	return project_member.ReconstructCustomRole(
+		entity.ID,
+		entity.ProjectID,
+		project_member.ProjectMemberRole(entity.Key),
+		entity.Name,
+		entity.Description,
+		permissions,
+		project_member.ProjectMemberRole(entity.ClonedFrom),
+		entity.CreatedBy,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
@@ -0,0 +1,31 @@
+package persistence
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+func TestProjectRoleMapping(t *testing.T) {
+	role, err := project_member.NewCustomRole(uuid.New(), "Sales Agent", "Exports contacts",
+		[]project_member.Permission{project_member.PermissionExportContacts, project_member.PermissionViewContacts},
+		project_member.RoleAgent, uuid.NewString())
+	require.NoError(t, err)
+
+	entity := projectRoleToEntity(role)
+	assert.Equal(t, "custom:sales-agent", entity.Key)
+	assert.Equal(t, []string{"contacts.export", "contacts.view"}, []string(entity.Permissions))
+	assert.Equal(t, "agent", entity.ClonedFrom)
+
+	restored := projectRoleToDomain(*entity)
+	assert.Equal(t, role.ID(), restored.ID())
+	assert.Equal(t, role.ProjectID(), restored.ProjectID())
+	assert.Equal(t, role.Key(), restored.Key())
+	assert.Equal(t, "Exports contacts", restored.Description())
+	assert.Equal(t, role.Permissions(), restored.Permissions())
+	assert.Equal(t, project_member.RoleAgent, restored.ClonedFrom())
+	assert.Empty(t, restored.DomainEvents())
+}
@@ -0,0 +1,221 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"gorm.io/gorm"
+)
+
+// GormRoutingRepository persiste cursor de round-robin e fila de espera do roteamento de sessões.
+// A carga dos agentes é calculada on-the-fly a partir de sessions e agent_sessions.
+type GormRoutingRepository struct {
+	db *gorm.DB
+}
+
+func NewGormRoutingRepository(db *gorm.DB) routing.Repository {
+	return &GormRoutingRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormRoutingRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// LockProject usa advisory lock de transação; fora de transação o lock é liberado imediatamente
+func (r *GormRoutingRepository) LockProject(ctx context.Context, projectID uuid.UUID) error {
+	if err := r.getDB(ctx).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "routing:"+projectID.String()).Error; err != nil {
+		return fmt.Errorf("failed to lock project routing: %w", err)
+	}
+	return nil
+}
+
+type workloadRow struct {
+	AgentID        uuid.UUID
+	ActiveSessions int
+	LastAssignedAt *time.Time
+}
+
+func (r *GormRoutingRepository) GetWorkloads(ctx context.Context, tenantID string, agentIDs []uuid.UUID) (map[uuid.UUID]routing.Workload, error) {
+	workloads := make(map[uuid.UUID]routing.Workload, len(agentIDs))
+	if len(agentIDs) == 0 {
+		return workloads, nil
+	}
+
+	sql, args := workloadQuery(tenantID, agentIDs)
+
+	var rows []workloadRow
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load agent workloads: %w", err)
+	}
+
+	for _, id := range agentIDs {
+		workloads[id] = routing.Workload{}
+	}
+	for _, row := range rows {
+		workloads[row.AgentID] = routing.Workload{
+			ActiveSessions: row.ActiveSessions,
+			LastAssignedAt: row.LastAssignedAt,
+		}
+	}
+
+	return workloads, nil
+}
+
+// workloadQuery conta as sessões ativas cujo agente atual (último de agent_ids) é o agente
+// e a entrada mais recente dele em agent_sessions
+func workloadQuery(tenantID string, agentIDs []uuid.UUID) (string, []interface{}) {
+	ids := make([]string, len(agentIDs))
+	for i, id := range agentIDs {
+		ids[i] = id.String()
+	}
+
+	return `SELECT
+			a.id AS agent_id,
+			(SELECT COUNT(*) FROM sessions s
+				WHERE s.tenant_id = ? AND s.status = 'active' AND s.deleted_at IS NULL
+					AND s.agent_ids->>-1 = a.id::text) AS active_sessions,
+			(SELECT MAX(ags.joined_at) FROM agent_sessions ags
+				WHERE ags.agent_id = a.id AND ags.deleted_at IS NULL) AS last_assigned_at
+		FROM unnest(?::uuid[]) AS a(id)`, []interface{}{tenantID, pq.Array(ids)}
+}
+
+func (r *GormRoutingRepository) GetRoundRobinCursor(ctx context.Context, projectID uuid.UUID) (*uuid.UUID, error) {
+	var entity entities.RoutingCursorEntity
+	err := r.getDB(ctx).Where("project_id = ?", projectID).First(&entity).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to load routing cursor: %w", err)
+	}
+	return &entity.LastAgentID, nil
+}
+
+func (r *GormRoutingRepository) SaveRoundRobinCursor(ctx context.Context, projectID, agentID uuid.UUID) error {
+	err := r.getDB(ctx).Exec(`
+		INSERT INTO routing_cursors (project_id, last_agent_id, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (project_id) DO UPDATE SET last_agent_id = EXCLUDED.last_agent_id, updated_at = EXCLUDED.updated_at`,
+		projectID, agentID, time.Now().UTC()).Error
+	if err != nil {
+		return fmt.Errorf("failed to save routing cursor: %w", err)
+	}
+	return nil
+}
+
+func (r *GormRoutingRepository) Enqueue(ctx context.Context, entry routing.QueueEntry) error {
+	skills := entry.Requirements.Skills
+	if skills == nil {
+		skills = []string{}
+	}
+
+	err := r.getDB(ctx).Exec(`
+		INSERT INTO session_routing_queue
+			(session_id, tenant_id, project_id, contact_id, language, required_skills, enqueued_at, attempts, last_attempt_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, 1, ?)
+		ON CONFLICT (session_id) DO UPDATE SET
+			attempts = session_routing_queue.attempts + 1,
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			language = EXCLUDED.language,
+			required_skills = EXCLUDED.required_skills`,
+		entry.SessionID, entry.TenantID, entry.ProjectID, entry.ContactID,
+		entry.Requirements.Language, pq.StringArray(skills), entry.EnqueuedAt, entry.LastAttemptAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to enqueue session: %w", err)
+	}
+	return nil
+}
+
+func (r *GormRoutingRepository) Dequeue(ctx context.Context, sessionID uuid.UUID) error {
+	if err := r.getDB(ctx).Delete(&entities.SessionRoutingQueueEntity{}, "session_id = ?", sessionID).Error; err != nil {
+		return fmt.Errorf("failed to dequeue session: %w", err)
+	}
+	return nil
+}
+
+func (r *GormRoutingRepository) FindQueued(ctx context.Context, limit int) ([]routing.QueueEntry, error) {
+	var rows []entities.SessionRoutingQueueEntity
+	if err := r.getDB(ctx).Order("enqueued_at ASC").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load routing queue: %w", err)
+	}
+
+	entries := make([]routing.QueueEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = routing.QueueEntry{
+			SessionID: row.SessionID,
+			TenantID:  row.TenantID,
+			ProjectID: row.ProjectID,
+			ContactID: row.ContactID,
+			Requirements: routing.Requirements{
+				Language: row.Language,
+				Skills:   []string(row.RequiredSkills),
+			},
+			EnqueuedAt:    row.EnqueuedAt,
+			Attempts:      row.Attempts,
+			LastAttemptAt: row.LastAttemptAt,
+		}
+	}
+
+	return entries, nil
+}
+
+func (r *GormRoutingRepository) FindProjectsWithReassignmentRules(ctx context.Context) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.getDB(ctx).Raw(`
+		SELECT p.id FROM projects p
+		WHERE p.active AND p.deleted_at IS NULL
+			AND (p.agent_assignment->>'enabled')::boolean
+			AND EXISTS (
+				SELECT 1 FROM jsonb_array_elements(COALESCE(p.agent_assignment->'reassignment_rules', '[]'::jsonb)) rule
+				WHERE (rule->>'enabled')::boolean
+			)
+		ORDER BY p.id`).Scan(&ids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to load projects with reassignment rules: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *GormRoutingRepository) FindActiveAssignments(ctx context.Context, projectID uuid.UUID, limit int) ([]routing.ActiveAssignment, error) {
+	sql, args := activeAssignmentsQuery(projectID, limit)
+
+	var assignments []routing.ActiveAssignment
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&assignments).Error; err != nil {
+		return nil, fmt.Errorf("failed to load active assignments: %w", err)
+	}
+	return assignments, nil
+}
+
+// activeAssignmentsQuery lista as sessões ativas com agente do projeto e a espera do contato
+// (mensagens do contato posteriores à última resposta)
+func activeAssignmentsQuery(projectID uuid.UUID, limit int) (string, []interface{}) {
+	return `SELECT
+			s.id AS session_id, s.tenant_id, s.contact_id,
+			(s.agent_ids->>-1)::uuid AS agent_id,
+			s.last_activity_at, s.agent_transfers AS transfers,
+			w.waiting_since
+		FROM sessions s
+		JOIN contacts c ON c.id = s.contact_id
+		LEFT JOIN LATERAL (
+			SELECT MIN(m.timestamp) AS waiting_since FROM messages m
+			WHERE m.session_id = s.id AND m.deleted_at IS NULL AND NOT m.from_me
+				AND m.timestamp > COALESCE((
+					SELECT MAX(o.timestamp) FROM messages o
+					WHERE o.session_id = s.id AND o.from_me AND o.deleted_at IS NULL
+				), '-infinity')
+		) w ON true
+		WHERE c.project_id = ? AND s.status = 'active' AND s.deleted_at IS NULL
+			AND jsonb_array_length(COALESCE(s.agent_ids, '[]'::jsonb)) > 0
+		ORDER BY s.last_activity_at ASC, s.id
+		LIMIT ?`, []interface{}{projectID, limit}
+}
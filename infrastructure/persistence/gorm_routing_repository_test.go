@@ -0,0 +1,34 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadQuery(t *testing.T) {
+	a1, a2 := uuid.New(), uuid.New()
+
+	sql, args := workloadQuery("tenant-1", []uuid.UUID{a1, a2})
+
+	assert.Contains(t, sql, "s.agent_ids->>-1 = a.id::text")
+	assert.Contains(t, sql, "s.status = 'active'")
+	assert.Contains(t, sql, "MAX(ags.joined_at)")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{"tenant-1", pq.Array([]string{a1.String(), a2.String()})}, args)
+}
+
+func TestActiveAssignmentsQuery(t *testing.T) {
+	projectID := uuid.New()
+
+	sql, args := activeAssignmentsQuery(projectID, 200)
+
+	assert.Contains(t, sql, "(s.agent_ids->>-1)::uuid AS agent_id")
+	assert.Contains(t, sql, "c.project_id = ?")
+	assert.Contains(t, sql, "ORDER BY s.last_activity_at ASC")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{projectID, 200}, args)
+}
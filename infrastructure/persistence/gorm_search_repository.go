@@ -0,0 +1,265 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/search"
+	"gorm.io/gorm"
+)
+
+// GormSearchRepository busca full-text em mensagens (texto, transcrições e OCR), notas e contatos
+// usando as colunas search_vector e os índices GIN da migration 000063.
+// Ordena por ts_rank_cd e pagina por keyset (rank, occurred_at, id) — nunca por OFFSET.
+type GormSearchRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSearchRepository(db *gorm.DB) search.Repository {
+	return &GormSearchRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormSearchRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// searchHeadlineOptions trechos curtos com os termos entre os delimitadores do domínio
+var searchHeadlineOptions = fmt.Sprintf(
+	`StartSel=%s, StopSel=%s, MaxWords=30, MinWords=10, MaxFragments=2, FragmentDelimiter=" … "`,
+	search.HighlightStart, search.HighlightEnd,
+)
+
+type searchHitRow struct {
+	Kind        string
+	ID          uuid.UUID
+	Rank        float32
+	Field       string
+	Snippet     string
+	OccurredAt  time.Time
+	ContactID   *uuid.UUID
+	ContactName *string
+	SessionID   *uuid.UUID
+	ChannelID   *uuid.UUID
+	AgentID     *uuid.UUID
+}
+
+func (r *GormSearchRepository) Search(ctx context.Context, filter search.Filter) (*search.Page, error) {
+	sql, args := unifiedSearchQuery(filter)
+
+	var rows []searchHitRow
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	page := &search.Page{}
+	if len(rows) > filter.Limit {
+		page.HasMore = true
+		rows = rows[:filter.Limit]
+	}
+	page.Hits = make([]search.Hit, len(rows))
+	for i, row := range rows {
+		page.Hits[i] = search.Hit{
+			Type:        search.ResultType(row.Kind),
+			ID:          row.ID,
+			Rank:        row.Rank,
+			MatchField:  row.Field,
+			Snippet:     row.Snippet,
+			OccurredAt:  row.OccurredAt,
+			ContactID:   row.ContactID,
+			ContactName: row.ContactName,
+			SessionID:   row.SessionID,
+			ChannelID:   row.ChannelID,
+			AgentID:     row.AgentID,
+		}
+	}
+	return page, nil
+}
+
+// searchTSQuery expressão tsquery do filtro. Sem idioma fixo, combina (OR) a consulta em todas as
+// configurações indexadas — a expressão é constante, então o planner usa os índices GIN.
+func searchTSQuery(filter search.Filter) (string, []interface{}) {
+	if filter.Language != "" {
+		return "websearch_to_tsquery(?::regconfig, ?)", []interface{}{search.ConfigForLanguage(filter.Language), filter.Query}
+	}
+	parts := make([]string, len(search.Configs))
+	args := make([]interface{}, 0, len(search.Configs))
+	for i, config := range search.Configs {
+		parts[i] = fmt.Sprintf("websearch_to_tsquery('%s', ?)", config)
+		args = append(args, filter.Query)
+	}
+	return "(" + strings.Join(parts, " || ") + ")", args
+}
+
+// unifiedSearchQuery monta a busca: uma parte por origem (UNION ALL), melhor rank por resultado
+// (mensagem encontrada no texto e na transcrição aparece uma vez), keyset e só então ts_headline,
+// calculado apenas para as linhas da página.
+func unifiedSearchQuery(filter search.Filter) (string, []interface{}) {
+	tsq, tsqArgs := searchTSQuery(filter)
+
+	var (
+		parts []string
+		args  []interface{}
+	)
+	add := func(sql string, partArgs ...interface{}) {
+		parts = append(parts, sql)
+		args = append(args, partArgs...)
+	}
+
+	if filter.Includes(search.TypeMessage) {
+		where, whereArgs := searchMessageConditions(filter)
+
+		partArgs := append(append([]interface{}{}, tsqArgs...), filter.TenantID)
+		partArgs = append(append(partArgs, tsqArgs...), whereArgs...)
+		add(`
+	SELECT 'message' AS kind, m.id, ts_rank_cd(m.search_vector, `+tsq+`) AS rank, 'text' AS field,
+		m.search_config AS config, m.text AS body, m.timestamp AS occurred_at,
+		m.contact_id, m.session_id, m.channel_id, m.agent_id
+	FROM messages m
+	WHERE m.tenant_id = ? AND m.deleted_at IS NULL AND m.search_vector @@ `+tsq+where, partArgs...)
+
+		partArgs = append(append([]interface{}{}, tsqArgs...), filter.TenantID)
+		partArgs = append(append(partArgs, tsqArgs...), whereArgs...)
+		add(`
+	SELECT 'message' AS kind, m.id, ts_rank_cd(e.search_vector, `+tsq+`) AS rank,
+		CASE e.content_type WHEN 'audio' THEN 'transcript' WHEN 'voice' THEN 'transcript'
+			WHEN 'video' THEN 'transcript' WHEN 'image' THEN 'ocr' ELSE 'document' END AS field,
+		e.search_config AS config, e.extracted_text AS body, m.timestamp AS occurred_at,
+		m.contact_id, m.session_id, m.channel_id, m.agent_id
+	FROM message_enrichments e
+	JOIN messages m ON m.id = e.message_id
+	WHERE m.tenant_id = ? AND m.deleted_at IS NULL AND e.status = 'completed' AND e.search_vector @@ `+tsq+where, partArgs...)
+	}
+
+	if filter.Includes(search.TypeNote) {
+		where := ""
+		var whereArgs []interface{}
+		if filter.AgentID != nil {
+			where += " AND n.author_id = ?"
+			whereArgs = append(whereArgs, *filter.AgentID)
+		}
+		if filter.ContactID != nil {
+			where += " AND n.contact_id = ?"
+			whereArgs = append(whereArgs, *filter.ContactID)
+		}
+		w, wArgs := searchDateConditions("n.created_at", filter)
+		where += w
+		whereArgs = append(whereArgs, wArgs...)
+
+		partArgs := append(append([]interface{}{}, tsqArgs...), filter.TenantID)
+		partArgs = append(append(partArgs, tsqArgs...), whereArgs...)
+		add(`
+	SELECT 'note' AS kind, n.id, ts_rank_cd(n.search_vector, `+tsq+`) AS rank, 'content' AS field,
+		n.search_config AS config, n.content AS body, n.created_at AS occurred_at,
+		n.contact_id, n.session_id, NULL::uuid AS channel_id, n.author_id AS agent_id
+	FROM notes n
+	WHERE n.tenant_id = ? AND n.deleted_at IS NULL AND n.search_vector @@ `+tsq+where, partArgs...)
+	}
+
+	if filter.Includes(search.TypeContact) {
+		where := ""
+		var whereArgs []interface{}
+		if filter.ContactID != nil {
+			where += " AND c.id = ?"
+			whereArgs = append(whereArgs, *filter.ContactID)
+		}
+		w, wArgs := searchDateConditions("c.created_at", filter)
+		where += w
+		whereArgs = append(whereArgs, wArgs...)
+
+		partArgs := append(append([]interface{}{}, tsqArgs...), filter.TenantID)
+		partArgs = append(append(partArgs, tsqArgs...), whereArgs...)
+		add(`
+	SELECT 'contact' AS kind, c.id, ts_rank_cd(c.search_vector, `+tsq+`) AS rank, 'profile' AS field,
+		crm_search_config(c.language) AS config,
+		concat_ws(' ', c.name, c.email, c.phone) AS body, c.created_at AS occurred_at,
+		c.id AS contact_id, NULL::uuid AS session_id, NULL::uuid AS channel_id, NULL::uuid AS agent_id
+	FROM contacts c
+	WHERE c.tenant_id = ? AND c.deleted_at IS NULL AND c.search_vector @@ `+tsq+where, partArgs...)
+	}
+
+	keyset := ""
+	if filter.Cursor != nil {
+		keyset = "\n\tWHERE (rank, occurred_at, id) < (?::real, ?, ?)"
+		args = append(args, filter.Cursor.Rank, filter.Cursor.OccurredAt, filter.Cursor.ID)
+	}
+	args = append(args, filter.Limit+1)
+	args = append(args, tsqArgs...)
+
+	sql := `
+WITH hits AS (` + strings.Join(parts, "\n\tUNION ALL") + `
+), best AS (
+	SELECT DISTINCT ON (kind, id) *
+	FROM hits
+	ORDER BY kind, id, rank DESC
+), page AS (
+	SELECT * FROM best` + keyset + `
+	ORDER BY rank DESC, occurred_at DESC, id DESC
+	LIMIT ?
+)
+SELECT p.kind, p.id, p.rank, p.field, p.occurred_at, p.contact_id, ct.name AS contact_name,
+	p.session_id, p.channel_id, p.agent_id,
+	ts_headline(p.config, coalesce(p.body, ''), ` + tsq + `, '` + searchHeadlineOptions + `') AS snippet
+FROM page p
+LEFT JOIN contacts ct ON ct.id = p.contact_id
+ORDER BY p.rank DESC, p.occurred_at DESC, p.id DESC`
+
+	return sql, args
+}
+
+// searchMessageConditions filtros das partes de mensagens (texto e enriquecimentos)
+func searchMessageConditions(filter search.Filter) (string, []interface{}) {
+	where := ""
+	var args []interface{}
+	if filter.ChannelID != nil {
+		where += " AND m.channel_id = ?"
+		args = append(args, *filter.ChannelID)
+	}
+	if filter.AgentID != nil {
+		where += " AND m.agent_id = ?"
+		args = append(args, *filter.AgentID)
+	}
+	if filter.ContactID != nil {
+		where += " AND m.contact_id = ?"
+		args = append(args, *filter.ContactID)
+	}
+	w, wArgs := searchDateConditions("m.timestamp", filter)
+	return where + w, append(args, wArgs...)
+}
+
+// searchDateConditions período [from, to)
+func searchDateConditions(column string, filter search.Filter) (string, []interface{}) {
+	where := ""
+	var args []interface{}
+	if filter.From != nil {
+		where += " AND " + column + " >= ?"
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		where += " AND " + column + " < ?"
+		args = append(args, *filter.To)
+	}
+	return where, args
+}
+
+// onlyDigits retorna os dígitos da busca quando ela é um telefone (só dígitos e pontuação), senão ""
+func onlyDigits(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case strings.ContainsRune(" +-()./", r):
+		default:
+			return ""
+		}
+	}
+	return b.String()
+}
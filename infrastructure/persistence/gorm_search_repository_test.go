@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/search"
+)
+
+func TestUnifiedSearchQuery_AllTypes(t *testing.T) {
+	filter := search.Filter{TenantID: "tenant-1", Query: "boleto atrasado"}
+	require.NoError(t, filter.Validate())
+
+	sql, args := unifiedSearchQuery(filter)
+
+	assert.Equal(t, 4, strings.Count(sql, "UNION ALL")+1, "message text, enrichments, notes and contacts")
+	assert.Contains(t, sql, "m.search_vector @@ (websearch_to_tsquery('portuguese', ?) || websearch_to_tsquery('spanish', ?)")
+	assert.Contains(t, sql, "e.status = 'completed' AND e.search_vector @@")
+	assert.Contains(t, sql, "SELECT DISTINCT ON (kind, id) *")
+	assert.Contains(t, sql, "ORDER BY rank DESC, occurred_at DESC, id DESC")
+	assert.Contains(t, sql, "ts_headline(p.config")
+	assert.NotContains(t, sql, "OFFSET")
+	assert.NotContains(t, sql, "(rank, occurred_at, id) <")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, filter.Limit+1, args[len(args)-len(search.Configs)-1])
+}
+
+func TestUnifiedSearchQuery_LanguageAndCursor(t *testing.T) {
+	cursor := search.Cursor{Rank: 0.25, OccurredAt: time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC), ID: uuid.New()}
+	filter := search.Filter{
+		TenantID: "tenant-1",
+		Query:    "fatura",
+		Types:    []search.ResultType{search.TypeNote},
+		Language: "pt-BR",
+		Cursor:   &cursor,
+		Limit:    10,
+	}
+	require.NoError(t, filter.Validate())
+
+	sql, args := unifiedSearchQuery(filter)
+
+	assert.NotContains(t, sql, "FROM messages m")
+	assert.NotContains(t, sql, "FROM contacts c")
+	assert.Contains(t, sql, "n.search_vector @@ websearch_to_tsquery(?::regconfig, ?)")
+	assert.Contains(t, sql, "WHERE (rank, occurred_at, id) < (?::real, ?, ?)")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{
+		"portuguese", "fatura", "tenant-1", "portuguese", "fatura",
+		cursor.Rank, cursor.OccurredAt, cursor.ID, 11,
+		"portuguese", "fatura",
+	}, args)
+}
+
+func TestUnifiedSearchQuery_Filters(t *testing.T) {
+	channelID := uuid.New()
+	agentID := uuid.New()
+	contactID := uuid.New()
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+	filter := search.Filter{
+		TenantID:  "tenant-1",
+		Query:     "reembolso",
+		ChannelID: &channelID,
+		AgentID:   &agentID,
+		ContactID: &contactID,
+		From:      &from,
+		To:        &to,
+	}
+	require.NoError(t, filter.Validate())
+
+	sql, args := unifiedSearchQuery(filter)
+
+	// canal e agente restringem a busca às mensagens
+	assert.NotContains(t, sql, "FROM notes n")
+	assert.NotContains(t, sql, "FROM contacts c")
+	assert.Equal(t, 2, strings.Count(sql, "AND m.channel_id = ? AND m.agent_id = ? AND m.contact_id = ? AND m.timestamp >= ? AND m.timestamp < ?"))
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+}
+
+func TestUnifiedSearchQuery_ContactOnly(t *testing.T) {
+	filter := search.Filter{TenantID: "tenant-1", Query: "maria@example.com", Types: []search.ResultType{search.TypeContact}}
+	require.NoError(t, filter.Validate())
+
+	sql, args := unifiedSearchQuery(filter)
+
+	assert.Contains(t, sql, "c.search_vector @@")
+	assert.Contains(t, sql, "crm_search_config(c.language) AS config")
+	assert.NotContains(t, sql, "UNION ALL")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+}
+
+func TestOnlyDigits(t *testing.T) {
+	assert.Equal(t, "5511999998888", onlyDigits("+55 (11) 99999-8888"))
+	assert.Equal(t, "", onlyDigits("maria 99"))
+	assert.Equal(t, "", onlyDigits(""))
+}
@@ -0,0 +1,355 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"gorm.io/gorm"
+)
+
+const (
+	// sessionAnalyticsRollup é o nome do rollup em analytics_rollup_state
+	sessionAnalyticsRollup = "session_analytics_hourly"
+
+	// rollupRefreshOverlap cobre sessões commitadas com updated_at anterior à marca d'água
+	rollupRefreshOverlap = 5 * time.Minute
+)
+
+// sessionBucketSize é a granularidade do rollup. Todo fuso em uso tem deslocamento múltiplo de
+// 15 minutos (Asia/Kolkata +05:30, Asia/Kathmandu +05:45), então meia-noite e hora cheia locais
+// sempre coincidem com o início de um bucket.
+const sessionBucketSize = 15 * time.Minute
+
+// sessionBucketSQL é o quarto de hora UTC de início da sessão (chave temporal do rollup)
+const sessionBucketSQL = "date_bin('15 minutes', s.started_at, TIMESTAMPTZ '2000-01-01 00:00:00+00')"
+
+// GormSessionAnalyticsRepository serve analytics de sessões a partir de session_analytics_hourly
+// (apesar do nome, buckets de 15 minutos)
+type GormSessionAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSessionAnalyticsRepository(db *gorm.DB) session.AnalyticsRepository {
+	return &GormSessionAnalyticsRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormSessionAnalyticsRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// RefreshRollups recalcula os buckets (tenant, 15 minutos) que contêm sessões criadas, alteradas ou
+// removidas desde a última execução. Cada bucket afetado é apagado e reagregado a partir de
+// sessions, então o resultado é idempotente. Um advisory lock evita refreshes concorrentes.
+func (r *GormSessionAnalyticsRepository) RefreshRollups(ctx context.Context) (int, error) {
+	var refreshed int
+
+	err := r.getDB(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", sessionAnalyticsRollup).Error; err != nil {
+			return fmt.Errorf("failed to acquire rollup lock: %w", err)
+		}
+
+		now := time.Now().UTC()
+
+		var state entities.AnalyticsRollupStateEntity
+		err := tx.Where("name = ?", sessionAnalyticsRollup).First(&state).Error
+		if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to load rollup state: %w", err)
+		}
+
+		// Sem marca d'água: reconstrói todos os buckets
+		since := time.Time{}
+		if err == nil {
+			since = state.LastRefreshedAt.Add(-rollupRefreshOverlap)
+		}
+
+		if err := tx.Exec("DROP TABLE IF EXISTS tmp_session_analytics_buckets").Error; err != nil {
+			return err
+		}
+		createBuckets := tx.Exec(`
+			CREATE TEMP TABLE tmp_session_analytics_buckets ON COMMIT DROP AS
+			SELECT DISTINCT s.tenant_id, `+sessionBucketSQL+` AS bucket_start
+			FROM sessions s
+			WHERE s.updated_at >= ? OR s.created_at >= ? OR s.deleted_at >= ?`,
+			since, since, since)
+		if createBuckets.Error != nil {
+			return fmt.Errorf("failed to collect affected buckets: %w", createBuckets.Error)
+		}
+		refreshed = int(createBuckets.RowsAffected)
+
+		if refreshed > 0 {
+			if err := tx.Exec(`
+				DELETE FROM session_analytics_hourly h
+				USING tmp_session_analytics_buckets b
+				WHERE h.tenant_id = b.tenant_id AND h.bucket_start = b.bucket_start`).Error; err != nil {
+				return fmt.Errorf("failed to clear affected buckets: %w", err)
+			}
+
+			if err := tx.Exec(sessionRollupInsertSQL(), now).Error; err != nil {
+				return fmt.Errorf("failed to aggregate sessions: %w", err)
+			}
+		}
+
+		return tx.Exec(`
+			INSERT INTO analytics_rollup_state (name, last_refreshed_at, updated_at)
+			VALUES (?, ?, ?)
+			ON CONFLICT (name) DO UPDATE SET last_refreshed_at = EXCLUDED.last_refreshed_at, updated_at = EXCLUDED.updated_at`,
+			sessionAnalyticsRollup, now, now).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return refreshed, nil
+}
+
+// sessionRollupInsertSQL reagrega as sessões dos buckets afetados.
+// O agente do rollup é o primeiro de agent_ids (quem assumiu a sessão).
+func sessionRollupInsertSQL() string {
+	return `
+		INSERT INTO session_analytics_hourly (
+			tenant_id, bucket_start, channel_type_id, agent_id, status,
+			session_count, resolved_count, escalated_count, converted_count, message_count,
+			duration_count, duration_sum,
+			response_count, response_sum, response_histogram,
+			wait_count, wait_sum, wait_histogram,
+			refreshed_at
+		)
+		SELECT
+			s.tenant_id,
+			` + sessionBucketSQL + `,
+			COALESCE(s.channel_type_id, 0),
+			COALESCE((s.agent_ids->>0)::uuid, '00000000-0000-0000-0000-000000000000'::uuid),
+			s.status,
+			COUNT(*),
+			COUNT(*) FILTER (WHERE s.resolved),
+			COUNT(*) FILTER (WHERE s.escalated),
+			COUNT(*) FILTER (WHERE s.converted),
+			COALESCE(SUM(s.message_count), 0),
+			COUNT(*) FILTER (WHERE s.status <> 'active'),
+			COALESCE(SUM(s.duration_seconds) FILTER (WHERE s.status <> 'active'), 0),
+			COUNT(s.agent_response_time_seconds),
+			COALESCE(SUM(s.agent_response_time_seconds), 0),
+			` + histogramCountSQL("s.agent_response_time_seconds") + `,
+			COUNT(s.contact_wait_time_seconds),
+			COALESCE(SUM(s.contact_wait_time_seconds), 0),
+			` + histogramCountSQL("s.contact_wait_time_seconds") + `,
+			?
+		FROM sessions s
+		JOIN tmp_session_analytics_buckets b
+			ON b.tenant_id = s.tenant_id AND b.bucket_start = ` + sessionBucketSQL + `
+		WHERE s.deleted_at IS NULL
+		GROUP BY 1, 2, 3, 4, 5`
+}
+
+// histogramCountSQL conta valores de column por bucket de session.ResponseTimeBuckets
+func histogramCountSQL(column string) string {
+	parts := make([]string, 0, session.HistogramSize)
+	lower := int64(-1)
+	for _, upper := range session.ResponseTimeBuckets {
+		if lower < 0 {
+			parts = append(parts, fmt.Sprintf("COUNT(*) FILTER (WHERE %s < %d)", column, upper))
+		} else {
+			parts = append(parts, fmt.Sprintf("COUNT(*) FILTER (WHERE %s >= %d AND %s < %d)", column, lower, column, upper))
+		}
+		lower = upper
+	}
+	parts = append(parts, fmt.Sprintf("COUNT(*) FILTER (WHERE %s >= %d)", column, lower))
+	return "ARRAY[" + strings.Join(parts, ", ") + "]::bigint[]"
+}
+
+// histogramSumSQL soma elemento a elemento os histogramas armazenados em column
+func histogramSumSQL(column string) string {
+	parts := make([]string, 0, session.HistogramSize)
+	for i := 1; i <= session.HistogramSize; i++ {
+		parts = append(parts, fmt.Sprintf("COALESCE(SUM(%s[%d]), 0)", column, i))
+	}
+	return "ARRAY[" + strings.Join(parts, ", ") + "]::bigint[]"
+}
+
+// analyticsTotalsSelect agrega linhas do rollup em totais
+var analyticsTotalsSelect = `
+	COALESCE(SUM(session_count), 0) AS sessions,
+	COALESCE(SUM(session_count) FILTER (WHERE status = 'active'), 0) AS active,
+	COALESCE(SUM(session_count) FILTER (WHERE status <> 'active'), 0) AS closed,
+	COALESCE(SUM(resolved_count), 0) AS resolved,
+	COALESCE(SUM(escalated_count), 0) AS escalated,
+	COALESCE(SUM(converted_count), 0) AS converted,
+	COALESCE(SUM(message_count), 0) AS messages,
+	COALESCE(SUM(duration_count), 0) AS duration_count,
+	COALESCE(SUM(duration_sum), 0) AS duration_sum,
+	COALESCE(SUM(response_count), 0) AS response_count,
+	COALESCE(SUM(response_sum), 0) AS response_sum,
+	` + histogramSumSQL("response_histogram") + ` AS response_histogram,
+	COALESCE(SUM(wait_count), 0) AS wait_count,
+	COALESCE(SUM(wait_sum), 0) AS wait_sum,
+	` + histogramSumSQL("wait_histogram") + ` AS wait_histogram`
+
+type analyticsTotalsRow struct {
+	Sessions          int64
+	Active            int64
+	Closed            int64
+	Resolved          int64
+	Escalated         int64
+	Converted         int64
+	Messages          int64
+	DurationCount     int64
+	DurationSum       int64
+	ResponseCount     int64
+	ResponseSum       int64
+	ResponseHistogram pq.Int64Array
+	WaitCount         int64
+	WaitSum           int64
+	WaitHistogram     pq.Int64Array
+}
+
+func (row analyticsTotalsRow) toDomain() session.AnalyticsTotals {
+	return session.AnalyticsTotals{
+		Sessions:     row.Sessions,
+		Active:       row.Active,
+		Closed:       row.Closed,
+		Resolved:     row.Resolved,
+		Escalated:    row.Escalated,
+		Converted:    row.Converted,
+		Messages:     row.Messages,
+		Duration:     session.DurationStats{Count: row.DurationCount, Sum: row.DurationSum},
+		ResponseTime: session.DurationStats{Count: row.ResponseCount, Sum: row.ResponseSum, Histogram: []int64(row.ResponseHistogram)},
+		WaitTime:     session.DurationStats{Count: row.WaitCount, Sum: row.WaitSum, Histogram: []int64(row.WaitHistogram)},
+	}
+}
+
+type analyticsTimelineRow struct {
+	PeriodStart time.Time
+	analyticsTotalsRow
+}
+
+// GetAnalytics agrega o rollup no período do filtro. Os limites do período são efetivamente
+// arredondados para o quarto de hora.
+func (r *GormSessionAnalyticsRepository) GetAnalytics(ctx context.Context, filter session.AnalyticsFilter) (*session.AnalyticsReport, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	where, args := analyticsWhere(filter)
+	db := r.getDB(ctx)
+	tz := filter.Location.String()
+
+	report := &session.AnalyticsReport{
+		ByStatus:  make(map[string]int64),
+		ByChannel: make(map[int]int64),
+		ByAgent:   make(map[uuid.UUID]int64),
+		ByHour:    make(map[int]int64),
+		Timeline:  []session.AnalyticsTimelinePoint{},
+	}
+
+	var totals analyticsTotalsRow
+	if err := db.Raw("SELECT "+analyticsTotalsSelect+" FROM session_analytics_hourly WHERE "+where, args...).
+		Scan(&totals).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate session totals: %w", err)
+	}
+	report.Totals = totals.toDomain()
+
+	var byStatus []struct {
+		Status string
+		Total  int64
+	}
+	if err := db.Raw("SELECT status, SUM(session_count) AS total FROM session_analytics_hourly WHERE "+where+" GROUP BY status", args...).
+		Scan(&byStatus).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate sessions by status: %w", err)
+	}
+	for _, row := range byStatus {
+		report.ByStatus[row.Status] = row.Total
+	}
+
+	var byChannel []struct {
+		ChannelTypeID int
+		Total         int64
+	}
+	if err := db.Raw("SELECT channel_type_id, SUM(session_count) AS total FROM session_analytics_hourly WHERE "+where+" GROUP BY channel_type_id", args...).
+		Scan(&byChannel).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate sessions by channel: %w", err)
+	}
+	for _, row := range byChannel {
+		report.ByChannel[row.ChannelTypeID] = row.Total
+	}
+
+	var byAgent []struct {
+		AgentID uuid.UUID
+		Total   int64
+	}
+	if err := db.Raw("SELECT agent_id, SUM(session_count) AS total FROM session_analytics_hourly WHERE "+where+" GROUP BY agent_id", args...).
+		Scan(&byAgent).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate sessions by agent: %w", err)
+	}
+	for _, row := range byAgent {
+		report.ByAgent[row.AgentID] = row.Total
+	}
+
+	var byHour []struct {
+		Hour  int
+		Total int64
+	}
+	hourArgs := append([]interface{}{tz}, args...)
+	if err := db.Raw("SELECT EXTRACT(HOUR FROM bucket_start AT TIME ZONE ?)::int AS hour, SUM(session_count) AS total FROM session_analytics_hourly WHERE "+where+" GROUP BY 1", hourArgs...).
+		Scan(&byHour).Error; err != nil {
+		return nil, fmt.Errorf("failed to aggregate sessions by hour: %w", err)
+	}
+	for _, row := range byHour {
+		report.ByHour[row.Hour] = row.Total
+	}
+
+	var timeline []analyticsTimelineRow
+	timelineArgs := append([]interface{}{string(filter.GroupBy), tz}, args...)
+	if err := db.Raw("SELECT date_trunc(?, bucket_start AT TIME ZONE ?) AS period_start, "+analyticsTotalsSelect+
+		" FROM session_analytics_hourly WHERE "+where+" GROUP BY 1 ORDER BY 1", timelineArgs...).
+		Scan(&timeline).Error; err != nil {
+		return nil, fmt.Errorf("failed to build session timeline: %w", err)
+	}
+	for _, row := range timeline {
+		// period_start é horário local sem fuso: reinterpreta no fuso do filtro
+		local := row.PeriodStart
+		periodStart := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), local.Minute(), 0, 0, filter.Location)
+		report.Timeline = append(report.Timeline, session.AnalyticsTimelinePoint{
+			PeriodStart: periodStart,
+			Totals:      row.analyticsTotalsRow.toDomain(),
+		})
+	}
+
+	var state entities.AnalyticsRollupStateEntity
+	err := db.Where("name = ?", sessionAnalyticsRollup).First(&state).Error
+	if err == nil {
+		refreshedAt := state.LastRefreshedAt
+		report.RefreshedAt = &refreshedAt
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load rollup state: %w", err)
+	}
+
+	return report, nil
+}
+
+func analyticsWhere(filter session.AnalyticsFilter) (string, []interface{}) {
+	conditions := []string{"tenant_id = ?", "bucket_start >= ?", "bucket_start < ?"}
+	args := []interface{}{filter.TenantID, filter.StartDate.UTC(), filter.EndDate.UTC()}
+
+	if filter.ChannelTypeID != nil {
+		conditions = append(conditions, "channel_type_id = ?")
+		args = append(args, *filter.ChannelTypeID)
+	}
+	if filter.AgentID != nil {
+		conditions = append(conditions, "agent_id = ?")
+		args = append(args, *filter.AgentID)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
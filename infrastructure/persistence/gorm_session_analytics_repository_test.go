@@ -0,0 +1,66 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func TestHistogramCountSQL(t *testing.T) {
+	sql := histogramCountSQL("x")
+
+	assert.Contains(t, sql, "ARRAY[COUNT(*) FILTER (WHERE x < 10), COUNT(*) FILTER (WHERE x >= 10 AND x < 30),")
+	assert.Contains(t, sql, "COUNT(*) FILTER (WHERE x >= 86400)]::bigint[]")
+}
+
+func TestHistogramSumSQL(t *testing.T) {
+	sql := histogramSumSQL("h")
+
+	assert.Contains(t, sql, "ARRAY[COALESCE(SUM(h[1]), 0),")
+	assert.Contains(t, sql, "COALESCE(SUM(h[11]), 0)]::bigint[]")
+}
+
+func TestAnalyticsWhere(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	channelTypeID := 2
+	agentID := uuid.New()
+
+	where, args := analyticsWhere(session.AnalyticsFilter{
+		TenantID:      "tenant-1",
+		StartDate:     start,
+		EndDate:       start.AddDate(0, 0, 7),
+		ChannelTypeID: &channelTypeID,
+		AgentID:       &agentID,
+	})
+
+	assert.Equal(t, "tenant_id = ? AND bucket_start >= ? AND bucket_start < ? AND channel_type_id = ? AND agent_id = ?", where)
+	assert.Equal(t, []interface{}{"tenant-1", start, start.AddDate(0, 0, 7), 2, agentID}, args)
+}
+
+func TestSessionBucket_AlignsWithNonWholeHourTimeZones(t *testing.T) {
+	assert.Contains(t, sessionBucketSQL, "'15 minutes'")
+	assert.Equal(t, 15*time.Minute, sessionBucketSize)
+
+	// Meia-noite local (limite de dia/semana/mês) precisa cair no início de um bucket UTC
+	for _, name := range []string{"America/Sao_Paulo", "Asia/Kolkata", "Australia/Adelaide", "Asia/Kathmandu", "America/St_Johns"} {
+		loc, err := time.LoadLocation(name)
+		require.NoError(t, err)
+
+		for _, day := range []time.Time{
+			time.Date(2025, 1, 15, 0, 0, 0, 0, loc),
+			time.Date(2025, 7, 15, 0, 0, 0, 0, loc),
+		} {
+			utc := day.UTC()
+			assert.True(t, utc.Equal(utc.Truncate(sessionBucketSize)), "%s: %s", name, utc)
+		}
+	}
+
+	// O bucket horário antigo misturava dois dias locais em Asia/Kolkata
+	kolkata, _ := time.LoadLocation("Asia/Kolkata")
+	midnight := time.Date(2025, 1, 15, 0, 0, 0, 0, kolkata).UTC()
+	assert.False(t, midnight.Equal(midnight.Truncate(time.Hour)))
+}
@@ -0,0 +1,153 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"gorm.io/gorm"
+)
+
+// GormSessionHistoryRepository é o read side do histórico de sessões e da fila de sessões ativas.
+// Canal e agente são derivados da última mensagem e da participação mais recente (agent_sessions),
+// já que sessions não guarda o canal.
+type GormSessionHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSessionHistoryRepository(db *gorm.DB) session.HistoryRepository {
+	return &GormSessionHistoryRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormSessionHistoryRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+const sessionReadModelJoins = `
+	LEFT JOIN LATERAL (
+		SELECT m.channel_id, m.text, m.timestamp FROM messages m
+		WHERE m.session_id = s.id AND m.deleted_at IS NULL
+		ORDER BY m.timestamp DESC, m.id DESC
+		LIMIT 1
+	) last ON true
+	LEFT JOIN LATERAL (
+		SELECT ags.agent_id FROM agent_sessions ags
+		WHERE ags.session_id = s.id AND ags.deleted_at IS NULL
+		ORDER BY ags.is_active DESC, ags.joined_at DESC
+		LIMIT 1
+	) ag ON true
+	LEFT JOIN agents a ON a.id = ag.agent_id
+	LEFT JOIN LATERAL (
+		SELECT COUNT(*) AS unanswered_count, MIN(m.timestamp) AS waiting_since FROM messages m
+		WHERE m.session_id = s.id AND m.deleted_at IS NULL AND NOT m.from_me
+			AND m.timestamp > COALESCE((
+				SELECT MAX(o.timestamp) FROM messages o
+				WHERE o.session_id = s.id AND o.from_me AND o.deleted_at IS NULL
+			), '-infinity')
+	) w ON true`
+
+func (r *GormSessionHistoryRepository) FindContactHistory(ctx context.Context, filter session.HistoryFilter) (*session.HistoryPage, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	sql, args := sessionHistoryQuery(filter)
+
+	var entries []*session.HistoryEntry
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to load session history: %w", err)
+	}
+
+	page := &session.HistoryPage{Sessions: entries}
+	if len(entries) > filter.Limit {
+		page.Sessions = entries[:filter.Limit]
+		page.HasMore = true
+	}
+
+	return page, nil
+}
+
+func (r *GormSessionHistoryRepository) FindActive(ctx context.Context, filter session.ActiveFilter) ([]*session.ActiveEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+
+	sql, args := activeSessionsQuery(filter)
+
+	var entries []*session.ActiveEntry
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&entries).Error; err != nil {
+		return nil, fmt.Errorf("failed to load active sessions: %w", err)
+	}
+
+	return entries, nil
+}
+
+// sessionHistoryQuery monta a consulta keyset (started_at, id) do histórico do contato
+func sessionHistoryQuery(filter session.HistoryFilter) (string, []interface{}) {
+	where := []string{"s.tenant_id = ?", "s.contact_id = ?", "s.deleted_at IS NULL"}
+	args := []interface{}{filter.TenantID, filter.ContactID}
+
+	if filter.Cursor != nil {
+		where = append(where, "(s.started_at, s.id) < (?, ?)")
+		args = append(args, filter.Cursor.Timestamp, filter.Cursor.ID)
+	}
+
+	args = append(args, filter.Limit+1)
+
+	return `SELECT
+			s.id, s.status, s.started_at, s.ended_at, s.duration_seconds, s.message_count, s.summary,
+			last.channel_id, ch.name AS channel_name,
+			last.text AS last_message_text, last.timestamp AS last_message_at,
+			ag.agent_id, a.name AS agent_name,
+			COALESCE(w.unanswered_count, 0) AS unanswered_count
+		FROM sessions s` + sessionReadModelJoins + `
+		LEFT JOIN channels ch ON ch.id = last.channel_id
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY s.started_at DESC, s.id DESC
+		LIMIT ?`, args
+}
+
+// activeSessionsQuery monta a consulta da fila de sessões ativas, mais antigas na espera primeiro
+func activeSessionsQuery(filter session.ActiveFilter) (string, []interface{}) {
+	where := []string{"s.tenant_id = ?", "s.status = ?", "s.deleted_at IS NULL"}
+	args := []interface{}{filter.TenantID, string(session.StatusActive)}
+
+	if filter.ChannelID != nil {
+		where = append(where, "last.channel_id = ?")
+		args = append(args, *filter.ChannelID)
+	}
+	if filter.AgentID != nil {
+		where = append(where, "ag.agent_id = ?")
+		args = append(args, *filter.AgentID)
+	}
+	if filter.Unassigned {
+		where = append(where, "ag.agent_id IS NULL")
+	}
+	if filter.PipelineID != nil {
+		where = append(where, "s.pipeline_id = ?")
+		args = append(args, *filter.PipelineID)
+	}
+	if filter.MinWaiting > 0 {
+		where = append(where, "w.waiting_since <= ?")
+		args = append(args, filter.Now.Add(-filter.MinWaiting).UTC())
+	}
+
+	args = append(args, filter.Limit)
+
+	return `SELECT
+			s.id, s.contact_id, c.name AS contact_name, c.phone AS contact_phone,
+			last.channel_id, ag.agent_id, a.name AS agent_name, s.pipeline_id, s.status,
+			s.started_at, last.timestamp AS last_message_at, s.message_count,
+			COALESCE(w.unanswered_count, 0) AS unanswered_count, w.waiting_since
+		FROM sessions s
+		JOIN contacts c ON c.id = s.contact_id` + sessionReadModelJoins + `
+		WHERE ` + strings.Join(where, " AND ") + `
+		ORDER BY w.waiting_since ASC NULLS LAST, s.last_activity_at DESC, s.id
+		LIMIT ?`, args
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	domainShared "github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func TestSessionHistoryQuery_Keyset(t *testing.T) {
+	contactID := uuid.New()
+	cursor := domainShared.NewCursor(time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC), uuid.New())
+
+	sql, args := sessionHistoryQuery(session.HistoryFilter{
+		TenantID:  "tenant-1",
+		ContactID: contactID,
+		Cursor:    &cursor,
+		Limit:     20,
+	})
+
+	assert.Contains(t, sql, "(s.started_at, s.id) < (?, ?)")
+	assert.Contains(t, sql, "ORDER BY s.started_at DESC, s.id DESC")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{"tenant-1", contactID, cursor.Timestamp, cursor.ID, 21}, args)
+}
+
+func TestActiveSessionsQuery_Filters(t *testing.T) {
+	channelID := uuid.New()
+	pipelineID := uuid.New()
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	sql, args := activeSessionsQuery(session.ActiveFilter{
+		TenantID:   "tenant-1",
+		ChannelID:  &channelID,
+		Unassigned: true,
+		PipelineID: &pipelineID,
+		MinWaiting: 10 * time.Minute,
+		Limit:      50,
+		Now:        now,
+	})
+
+	assert.Contains(t, sql, "last.channel_id = ? AND ag.agent_id IS NULL AND s.pipeline_id = ? AND w.waiting_since <= ?")
+	assert.Contains(t, sql, "ORDER BY w.waiting_since ASC NULLS LAST")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{"tenant-1", "active", channelID, pipelineID, now.Add(-10 * time.Minute), 50}, args)
+}
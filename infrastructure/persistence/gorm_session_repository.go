@@ -77,6 +77,7 @@ func (r *GormSessionRepository) Save(ctx context.Context, s *session.Session) er
 				"escalated":                   entity.Escalated,
 				"converted":                   entity.Converted,
 				"outcome_tags":                entity.OutcomeTags,
+				"priority":                    entity.Priority,
 				"updated_at":                  entity.UpdatedAt,
 			})
 
@@ -459,6 +460,7 @@ func (r *GormSessionRepository) domainToEntity(s *session.Session) *entities.Ses
 		Escalated:           s.IsEscalated(),
 		Converted:           s.IsConverted(),
 		OutcomeTags:         s.OutcomeTags(),
+		Priority:            s.Priority().String(),
 		CreatedAt:           s.StartedAt(), // Usar StartedAt como CreatedAt
 		UpdatedAt:           time.Now(),
 	}
@@ -531,6 +533,7 @@ func (r *GormSessionRepository) entityToDomain(entity *entities.SessionEntity) *
 		entity.Escalated,
 		entity.Converted,
 		entity.OutcomeTags,
+		session.Priority(entity.Priority),
 	)
 }
 
@@ -0,0 +1,321 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormSLAPolicyRepository persiste as políticas de SLA dos projetos
+type GormSLAPolicyRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSLAPolicyRepository(db *gorm.DB) sla.PolicyRepository {
+	return &GormSLAPolicyRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormSLAPolicyRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormSLAPolicyRepository) Save(ctx context.Context, policy *sla.Policy) error {
+	entity, err := slaPolicyToEntity(policy)
+	if err != nil {
+		return err
+	}
+	if err := r.getDB(ctx).Save(entity).Error; err != nil {
+		return fmt.Errorf("failed to save sla policy: %w", err)
+	}
+	return nil
+}
+
+func (r *GormSLAPolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*sla.Policy, error) {
+	var entity entities.SLAPolicyEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, sla.ErrPolicyNotFound
+		}
+		return nil, fmt.Errorf("failed to load sla policy: %w", err)
+	}
+	return slaPolicyToDomain(entity), nil
+}
+
+func (r *GormSLAPolicyRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*sla.Policy, error) {
+	var rows []entities.SLAPolicyEntity
+	if err := r.getDB(ctx).Where("project_id = ?", projectID).Order("created_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sla policies: %w", err)
+	}
+
+	policies := make([]*sla.Policy, len(rows))
+	for i, row := range rows {
+		policies[i] = slaPolicyToDomain(row)
+	}
+	return policies, nil
+}
+
+func (r *GormSLAPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.getDB(ctx).Where("id = ?", id).Delete(&entities.SLAPolicyEntity{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete sla policy: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return sla.ErrPolicyNotFound
+	}
+	return nil
+}
+
+func slaPolicyToEntity(p *sla.Policy) (*entities.SLAPolicyEntity, error) {
+	entity := &entities.SLAPolicyEntity{
+		ID:                   p.ID(),
+		TenantID:             p.TenantID(),
+		ProjectID:            p.ProjectID(),
+		Name:                 p.Name(),
+		PipelineID:           p.PipelineID(),
+		ChannelTypeID:        p.ChannelTypeID(),
+		FirstResponseSeconds: int(p.Targets().FirstResponse.Seconds()),
+		NextResponseSeconds:  int(p.Targets().NextResponse.Seconds()),
+		ResolutionSeconds:    int(p.Targets().Resolution.Seconds()),
+		WarningPercent:       p.WarningPercent(),
+		Enabled:              p.IsEnabled(),
+		CreatedAt:            p.CreatedAt(),
+		UpdatedAt:            p.UpdatedAt(),
+	}
+	if p.Priority() != nil {
+		priority := p.Priority().String()
+		entity.Priority = &priority
+	}
+	if p.BusinessHours() != nil {
+		data, err := json.Marshal(p.BusinessHours())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal business hours: %w", err)
+		}
+		entity.BusinessHours = datatypes.JSON(data)
+	}
+	return entity, nil
+}
+
+func slaPolicyToDomain(entity entities.SLAPolicyEntity) *sla.Policy {
+	var priority *session.Priority
+	if entity.Priority != nil {
+		p := session.Priority(*entity.Priority)
+		priority = &p
+	}
+
+	var schedule *businesshours.Schedule
+	if len(entity.BusinessHours) > 0 && string(entity.BusinessHours) != "null" {
+		var s businesshours.Schedule
+		if err := json.Unmarshal(entity.BusinessHours, &s); err == nil {
+			schedule = &s
+		}
+	}
+
+	return sla.ReconstructPolicy(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		entity.Name,
+		entity.PipelineID,
+		entity.ChannelTypeID,
+		priority,
+		sla.Targets{
+			FirstResponse: time.Duration(entity.FirstResponseSeconds) * time.Second,
+			NextResponse:  time.Duration(entity.NextResponseSeconds) * time.Second,
+			Resolution:    time.Duration(entity.ResolutionSeconds) * time.Second,
+		},
+		entity.WarningPercent,
+		schedule,
+		entity.Enabled,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
+
+// GormSLAClockRepository persiste os relógios de SLA das sessões
+type GormSLAClockRepository struct {
+	db *gorm.DB
+}
+
+func NewGormSLAClockRepository(db *gorm.DB) sla.ClockRepository {
+	return &GormSLAClockRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormSLAClockRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormSLAClockRepository) Save(ctx context.Context, clock *sla.Clock) error {
+	entity := slaClockToEntity(clock)
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to save sla clock: %w", err)
+	}
+	return nil
+}
+
+func (r *GormSLAClockRepository) FindByID(ctx context.Context, id uuid.UUID) (*sla.Clock, error) {
+	var entity entities.SLAClockEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, sla.ErrClockNotFound
+		}
+		return nil, fmt.Errorf("failed to load sla clock: %w", err)
+	}
+	return slaClockToDomain(entity), nil
+}
+
+func (r *GormSLAClockRepository) FindBySession(ctx context.Context, sessionID uuid.UUID) ([]*sla.Clock, error) {
+	var rows []entities.SLAClockEntity
+	if err := r.getDB(ctx).Where("session_id = ?", sessionID).Order("started_at ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sla clocks: %w", err)
+	}
+	return slaClocksToDomain(rows), nil
+}
+
+func (r *GormSLAClockRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*sla.Clock, error) {
+	sql, args := slaDueClocksQuery(now, limit)
+
+	var rows []entities.SLAClockEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load due sla clocks: %w", err)
+	}
+	return slaClocksToDomain(rows), nil
+}
+
+// slaDueClocksQuery relógios em andamento com vencimento alcançado ou aviso pendente
+func slaDueClocksQuery(now time.Time, limit int) (string, []interface{}) {
+	return `SELECT * FROM sla_clocks
+		WHERE status = 'running'
+			AND (due_at <= ? OR (warned_at IS NULL AND warn_at <= ?))
+		ORDER BY due_at ASC
+		LIMIT ?`, []interface{}{now, now, limit}
+}
+
+type slaComplianceRow struct {
+	AgentID           *uuid.UUID
+	Metric            string
+	Met               int
+	Breached          int
+	AvgElapsedSeconds float64
+}
+
+func (r *GormSLAClockRepository) Compliance(ctx context.Context, filter sla.ComplianceFilter) ([]sla.ComplianceRow, error) {
+	sql, args := slaComplianceQuery(filter)
+
+	var rows []slaComplianceRow
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load sla compliance: %w", err)
+	}
+
+	result := make([]sla.ComplianceRow, len(rows))
+	for i, row := range rows {
+		result[i] = sla.ComplianceRow{
+			AgentID:           row.AgentID,
+			Metric:            sla.Metric(row.Metric),
+			Met:               row.Met,
+			Breached:          row.Breached,
+			AvgElapsedSeconds: row.AvgElapsedSeconds,
+		}
+	}
+	return result, nil
+}
+
+// slaComplianceQuery agrega por agente e métrica os relógios decididos (cumpridos ou vencidos)
+func slaComplianceQuery(filter sla.ComplianceFilter) (string, []interface{}) {
+	sql := `SELECT
+			agent_id,
+			metric,
+			COUNT(*) FILTER (WHERE status = 'met') AS met,
+			COUNT(*) FILTER (WHERE status = 'breached') AS breached,
+			COALESCE(AVG(elapsed_seconds), 0) AS avg_elapsed_seconds
+		FROM sla_clocks
+		WHERE tenant_id = ? AND status IN ('met', 'breached') AND started_at >= ? AND started_at < ?`
+	args := []interface{}{filter.TenantID, filter.From, filter.To}
+
+	if filter.ProjectID != nil {
+		sql += " AND project_id = ?"
+		args = append(args, *filter.ProjectID)
+	}
+	if len(filter.AgentIDs) > 0 {
+		ids := make([]string, len(filter.AgentIDs))
+		for i, id := range filter.AgentIDs {
+			ids[i] = id.String()
+		}
+		sql += " AND agent_id = ANY(?::uuid[])"
+		args = append(args, pq.Array(ids))
+	}
+
+	sql += `
+		GROUP BY agent_id, metric
+		ORDER BY agent_id NULLS LAST, metric`
+	return sql, args
+}
+
+func slaClockToEntity(c *sla.Clock) *entities.SLAClockEntity {
+	return &entities.SLAClockEntity{
+		ID:             c.ID(),
+		TenantID:       c.TenantID(),
+		ProjectID:      c.ProjectID(),
+		SessionID:      c.SessionID(),
+		PolicyID:       c.PolicyID(),
+		Metric:         string(c.Metric()),
+		Status:         string(c.Status()),
+		StartedAt:      c.StartedAt(),
+		WarnAt:         c.WarnAt(),
+		DueAt:          c.DueAt(),
+		WarnedAt:       c.WarnedAt(),
+		BreachedAt:     c.BreachedAt(),
+		StoppedAt:      c.StoppedAt(),
+		AgentID:        c.AgentID(),
+		ElapsedSeconds: c.ElapsedSeconds(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+func slaClockToDomain(entity entities.SLAClockEntity) *sla.Clock {
+	return sla.ReconstructClock(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		entity.SessionID,
+		entity.PolicyID,
+		sla.Metric(entity.Metric),
+		sla.ClockStatus(entity.Status),
+		entity.StartedAt,
+		entity.WarnAt,
+		entity.DueAt,
+		entity.WarnedAt,
+		entity.BreachedAt,
+		entity.StoppedAt,
+		entity.AgentID,
+		entity.ElapsedSeconds,
+	)
+}
+
+func slaClocksToDomain(rows []entities.SLAClockEntity) []*sla.Clock {
+	clocks := make([]*sla.Clock, len(rows))
+	for i, row := range rows {
+		clocks[i] = slaClockToDomain(row)
+	}
+	return clocks
+}
@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+func TestSLADueClocksQuery(t *testing.T) {
+	now := time.Now()
+
+	sql, args := slaDueClocksQuery(now, 500)
+
+	assert.Contains(t, sql, "status = 'running'")
+	assert.Contains(t, sql, "due_at <= ? OR (warned_at IS NULL AND warn_at <= ?)")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{now, now, 500}, args)
+}
+
+func TestSLAComplianceQuery(t *testing.T) {
+	from := time.Now().AddDate(0, 0, -7)
+	to := time.Now()
+
+	t.Run("tenant scope", func(t *testing.T) {
+		sql, args := slaComplianceQuery(sla.ComplianceFilter{TenantID: "tenant-1", From: from, To: to})
+
+		assert.Contains(t, sql, "status IN ('met', 'breached')")
+		assert.Contains(t, sql, "GROUP BY agent_id, metric")
+		assert.NotContains(t, sql, "project_id = ?")
+		assert.Equal(t, strings.Count(sql, "?"), len(args))
+	})
+
+	t.Run("project and agents", func(t *testing.T) {
+		projectID := uuid.New()
+		sql, args := slaComplianceQuery(sla.ComplianceFilter{
+			TenantID:  "tenant-1",
+			ProjectID: &projectID,
+			AgentIDs:  []uuid.UUID{uuid.New(), uuid.New()},
+			From:      from,
+			To:        to,
+		})
+
+		assert.Contains(t, sql, "project_id = ?")
+		assert.Contains(t, sql, "agent_id = ANY(?::uuid[])")
+		assert.Equal(t, strings.Count(sql, "?"), len(args))
+		assert.Equal(t, projectID, args[3])
+	})
+}
+
+func TestSLAPolicyEntityRoundTrip(t *testing.T) {
+	pipelineID := uuid.New()
+	high := session.PriorityHigh
+	policy, err := sla.NewPolicy("tenant-1", uuid.New(), "VIP", sla.Targets{
+		FirstResponse: 5 * time.Minute,
+		Resolution:    4 * time.Hour,
+	})
+	require.NoError(t, err)
+	require.NoError(t, policy.SetScope(&pipelineID, nil, &high))
+	require.NoError(t, policy.SetBusinessHours(&businesshours.Schedule{
+		Timezone: "America/Sao_Paulo",
+		Weekly:   map[string][]businesshours.Interval{"monday": {{Start: "09:00", End: "18:00"}}},
+	}))
+
+	entity, err := slaPolicyToEntity(policy)
+	require.NoError(t, err)
+	assert.Equal(t, 300, entity.FirstResponseSeconds)
+	assert.Equal(t, 0, entity.NextResponseSeconds)
+
+	restored := slaPolicyToDomain(*entity)
+	assert.Equal(t, policy.Targets(), restored.Targets())
+	assert.Equal(t, high, *restored.Priority())
+	assert.Equal(t, pipelineID, *restored.PipelineID())
+	assert.Equal(t, "America/Sao_Paulo", restored.BusinessHours().Timezone)
+}
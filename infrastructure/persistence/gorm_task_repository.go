@@ -0,0 +1,191 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/task"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormTaskRepository persiste as tarefas dos agentes
+type GormTaskRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTaskRepository(db *gorm.DB) task.Repository {
+	return &GormTaskRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormTaskRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormTaskRepository) Save(ctx context.Context, t *task.Task) error {
+	entity := taskToEntity(t)
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+	return nil
+}
+
+func (r *GormTaskRepository) FindByID(ctx context.Context, id uuid.UUID) (*task.Task, error) {
+	var entity entities.TaskEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, task.ErrTaskNotFound
+		}
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	return taskToDomain(entity), nil
+}
+
+func (r *GormTaskRepository) List(ctx context.Context, filter task.Filter) ([]*task.Task, int64, error) {
+	where, args := taskFilterConditions(filter)
+	query := r.getDB(ctx).Model(&entities.TaskEntity{}).Where(where, args...)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count tasks: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 50
+	}
+
+	var rows []entities.TaskEntity
+	err := query.Order("due_at ASC NULLS LAST").Order("created_at ASC").
+		Limit(limit).Offset(filter.Offset).Find(&rows).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	return tasksToDomain(rows), total, nil
+}
+
+func (r *GormTaskRepository) FindDue(ctx context.Context, now time.Time, limit int) ([]*task.Task, error) {
+	sql, args := taskDueQuery(now, limit)
+
+	var rows []entities.TaskEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load due tasks: %w", err)
+	}
+	return tasksToDomain(rows), nil
+}
+
+// taskFilterConditions cláusula WHERE das listagens (tarefas removidas ficam de fora)
+func taskFilterConditions(filter task.Filter) (string, []interface{}) {
+	conditions := []string{"tenant_id = ?", "deleted_at IS NULL"}
+	args := []interface{}{filter.TenantID}
+
+	if filter.AssigneeID != nil {
+		conditions = append(conditions, "assignee_id = ?")
+		args = append(args, *filter.AssigneeID)
+	}
+	if filter.ContactID != nil {
+		conditions = append(conditions, "contact_id = ?")
+		args = append(args, *filter.ContactID)
+	}
+	if filter.SessionID != nil {
+		conditions = append(conditions, "session_id = ?")
+		args = append(args, *filter.SessionID)
+	}
+	if filter.PipelineID != nil {
+		conditions = append(conditions, "pipeline_id = ?")
+		args = append(args, *filter.PipelineID)
+	}
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i, s := range filter.Statuses {
+			statuses[i] = string(s)
+		}
+		conditions = append(conditions, "status IN ?")
+		args = append(args, statuses)
+	}
+	if filter.DueBefore != nil {
+		conditions = append(conditions, "due_at < ?")
+		args = append(args, *filter.DueBefore)
+	}
+	if filter.DueAfter != nil {
+		conditions = append(conditions, "due_at >= ?")
+		args = append(args, *filter.DueAfter)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// taskDueQuery tarefas abertas com prazo alcançado e atraso ainda não registrado
+func taskDueQuery(now time.Time, limit int) (string, []interface{}) {
+	return `SELECT * FROM tasks
+		WHERE status = 'open' AND overdue_at IS NULL AND deleted_at IS NULL AND due_at <= ?
+		ORDER BY due_at ASC
+		LIMIT ?`, []interface{}{now, limit}
+}
+
+func taskToEntity(t *task.Task) *entities.TaskEntity {
+	return &entities.TaskEntity{
+		ID:          t.ID(),
+		TenantID:    t.TenantID(),
+		Title:       t.Title(),
+		Description: t.Description(),
+		DueAt:       t.DueAt(),
+		Timezone:    t.Timezone(),
+		Priority:    string(t.Priority()),
+		Status:      string(t.Status()),
+		Recurrence:  string(t.Recurrence()),
+		AssigneeID:  t.AssigneeID(),
+		ContactID:   t.ContactID(),
+		SessionID:   t.SessionID(),
+		PipelineID:  t.PipelineID(),
+		CreatedBy:   t.CreatedBy(),
+		OverdueAt:   t.OverdueAt(),
+		CompletedAt: t.CompletedAt(),
+		CompletedBy: t.CompletedBy(),
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+		DeletedAt:   t.DeletedAt(),
+	}
+}
+
+func taskToDomain(entity entities.TaskEntity) *task.Task {
+	return task.ReconstructTask(
+		entity.ID,
+		entity.TenantID,
+		entity.Title,
+		entity.Description,
+		entity.DueAt,
+		entity.Timezone,
+		task.Priority(entity.Priority),
+		task.Status(entity.Status),
+		task.Recurrence(entity.Recurrence),
+		entity.AssigneeID,
+		entity.ContactID,
+		entity.SessionID,
+		entity.PipelineID,
+		entity.CreatedBy,
+		entity.OverdueAt,
+		entity.CompletedAt,
+		entity.CompletedBy,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+		entity.DeletedAt,
+	)
+}
+
+func tasksToDomain(rows []entities.TaskEntity) []*task.Task {
+	tasks := make([]*task.Task, len(rows))
+	for i, row := range rows {
+		tasks[i] = taskToDomain(row)
+	}
+	return tasks
+}
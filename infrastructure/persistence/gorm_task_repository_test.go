@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/task"
+)
+
+func TestTaskFilterConditions(t *testing.T) {
+	t.Run("tenant only", func(t *testing.T) {
+		where, args := taskFilterConditions(task.Filter{TenantID: "tenant-1"})
+
+		assert.Equal(t, "tenant_id = ? AND deleted_at IS NULL", where)
+		assert.Equal(t, []interface{}{"tenant-1"}, args)
+	})
+
+	t.Run("overdue for assignee", func(t *testing.T) {
+		agentID := uuid.New()
+		now := time.Now()
+		where, args := taskFilterConditions(task.Filter{
+			TenantID:   "tenant-1",
+			AssigneeID: &agentID,
+			Statuses:   []task.Status{task.StatusOpen},
+			DueBefore:  &now,
+		})
+
+		assert.Contains(t, where, "assignee_id = ?")
+		assert.Contains(t, where, "status IN ?")
+		assert.Contains(t, where, "due_at < ?")
+		assert.Equal(t, strings.Count(where, "?"), len(args))
+		assert.Equal(t, []string{"open"}, args[2])
+	})
+}
+
+func TestTaskDueQuery(t *testing.T) {
+	now := time.Now()
+
+	sql, args := taskDueQuery(now, 200)
+
+	assert.Contains(t, sql, "status = 'open' AND overdue_at IS NULL AND deleted_at IS NULL")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{now, 200}, args)
+}
+
+func TestTaskEntityRoundTrip(t *testing.T) {
+	due := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+	contactID := uuid.New()
+	sessionID := uuid.New()
+	agentID := uuid.New()
+	tk, err := task.NewTask("tenant-1", uuid.New(), task.Details{
+		Title:      "Retornar ligação",
+		DueAt:      &due,
+		Timezone:   "America/Sao_Paulo",
+		Priority:   task.PriorityHigh,
+		Recurrence: task.RecurrenceWeekly,
+		AssigneeID: &agentID,
+		ContactID:  &contactID,
+		SessionID:  &sessionID,
+	})
+	require.NoError(t, err)
+
+	restored := taskToDomain(*taskToEntity(tk))
+
+	assert.Equal(t, tk.ID(), restored.ID())
+	assert.Equal(t, due, *restored.DueAt())
+	assert.Equal(t, "America/Sao_Paulo", restored.Timezone())
+	assert.Equal(t, task.PriorityHigh, restored.Priority())
+	assert.Equal(t, task.RecurrenceWeekly, restored.Recurrence())
+	assert.Equal(t, agentID, *restored.AssigneeID())
+	assert.Equal(t, sessionID, *restored.SessionID())
+	assert.True(t, restored.IsOpen())
+}
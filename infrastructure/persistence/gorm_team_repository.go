@@ -0,0 +1,457 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/team"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormTeamRepository persiste os times de agentes
+type GormTeamRepository struct {
+	db *gorm.DB
+}
+
+func NewGormTeamRepository(db *gorm.DB) team.TeamRepository {
+	return &GormTeamRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormTeamRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormTeamRepository) Save(ctx context.Context, t *team.Team) error {
+	entity, err := teamToEntity(t)
+	if err != nil {
+		return err
+	}
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to save team: %w", err)
+	}
+	return nil
+}
+
+func (r *GormTeamRepository) FindByID(ctx context.Context, id uuid.UUID) (*team.Team, error) {
+	var entity entities.TeamEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, team.ErrTeamNotFound
+		}
+		return nil, fmt.Errorf("failed to load team: %w", err)
+	}
+	return teamToDomain(entity), nil
+}
+
+func (r *GormTeamRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*team.Team, error) {
+	var rows []entities.TeamEntity
+	if err := r.getDB(ctx).Where("project_id = ?", projectID).Order("name ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load teams: %w", err)
+	}
+	return teamsToDomain(rows), nil
+}
+
+func (r *GormTeamRepository) FindByMember(ctx context.Context, tenantID string, agentID uuid.UUID) ([]*team.Team, error) {
+	var rows []entities.TeamEntity
+	if err := r.getDB(ctx).
+		Where("tenant_id = ? AND member_ids @> ?::jsonb", tenantID, teamMemberFilter(agentID)).
+		Order("name ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load agent teams: %w", err)
+	}
+	return teamsToDomain(rows), nil
+}
+
+func (r *GormTeamRepository) FindBySupervisor(ctx context.Context, tenantID string, agentID uuid.UUID) ([]*team.Team, error) {
+	var rows []entities.TeamEntity
+	if err := r.getDB(ctx).
+		Where("tenant_id = ? AND supervisor_id = ?", tenantID, agentID).
+		Order("name ASC").
+		Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load supervised teams: %w", err)
+	}
+	return teamsToDomain(rows), nil
+}
+
+func (r *GormTeamRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.getDB(ctx).Where("id = ?", id).Delete(&entities.TeamEntity{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete team: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return team.ErrTeamNotFound
+	}
+	return nil
+}
+
+// teamMemberFilter documento JSONB usado com @> para achar times que contêm o agente
+func teamMemberFilter(agentID uuid.UUID) string {
+	return fmt.Sprintf(`[%q]`, agentID.String())
+}
+
+func teamToEntity(t *team.Team) (*entities.TeamEntity, error) {
+	members, err := json.Marshal(t.MemberIDs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal team members: %w", err)
+	}
+	return &entities.TeamEntity{
+		ID:           t.ID(),
+		TenantID:     t.TenantID(),
+		ProjectID:    t.ProjectID(),
+		Name:         t.Name(),
+		Description:  t.Description(),
+		SupervisorID: t.SupervisorID(),
+		MemberIDs:    datatypes.JSON(members),
+		CreatedAt:    t.CreatedAt(),
+		UpdatedAt:    t.UpdatedAt(),
+	}, nil
+}
+
+func teamToDomain(entity entities.TeamEntity) *team.Team {
+	var members []uuid.UUID
+	if len(entity.MemberIDs) > 0 {
+		_ = json.Unmarshal(entity.MemberIDs, &members)
+	}
+	return team.ReconstructTeam(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		entity.Name,
+		entity.Description,
+		entity.SupervisorID,
+		members,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
+
+func teamsToDomain(rows []entities.TeamEntity) []*team.Team {
+	teams := make([]*team.Team, len(rows))
+	for i, row := range rows {
+		teams[i] = teamToDomain(row)
+	}
+	return teams
+}
+
+// GormQueueRepository persiste as filas de atendimento
+type GormQueueRepository struct {
+	db *gorm.DB
+}
+
+func NewGormQueueRepository(db *gorm.DB) team.QueueRepository {
+	return &GormQueueRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormQueueRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormQueueRepository) Save(ctx context.Context, q *team.Queue) error {
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(queueToEntity(q)).Error; err != nil {
+		return fmt.Errorf("failed to save queue: %w", err)
+	}
+	return nil
+}
+
+func (r *GormQueueRepository) FindByID(ctx context.Context, id uuid.UUID) (*team.Queue, error) {
+	var entity entities.QueueEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, team.ErrQueueNotFound
+		}
+		return nil, fmt.Errorf("failed to load queue: %w", err)
+	}
+	return queueToDomain(entity), nil
+}
+
+func (r *GormQueueRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*team.Queue, error) {
+	var rows []entities.QueueEntity
+	if err := r.getDB(ctx).Where("project_id = ?", projectID).Order("priority DESC, name ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load queues: %w", err)
+	}
+	return queuesToDomain(rows), nil
+}
+
+func (r *GormQueueRepository) FindByTeams(ctx context.Context, teamIDs []uuid.UUID) ([]*team.Queue, error) {
+	if len(teamIDs) == 0 {
+		return []*team.Queue{}, nil
+	}
+	var rows []entities.QueueEntity
+	if err := r.getDB(ctx).Where("team_id IN ?", teamIDs).Order("priority DESC, name ASC").Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load team queues: %w", err)
+	}
+	return queuesToDomain(rows), nil
+}
+
+func (r *GormQueueRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := r.getDB(ctx).Where("id = ?", id).Delete(&entities.QueueEntity{})
+	if result.Error != nil {
+		return fmt.Errorf("failed to delete queue: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return team.ErrQueueNotFound
+	}
+	return nil
+}
+
+func queueToEntity(q *team.Queue) *entities.QueueEntity {
+	return &entities.QueueEntity{
+		ID:                  q.ID(),
+		TenantID:            q.TenantID(),
+		ProjectID:           q.ProjectID(),
+		TeamID:              q.TeamID(),
+		Name:                q.Name(),
+		Strategy:            string(q.Strategy()),
+		Priority:            q.Priority(),
+		Enabled:             q.IsEnabled(),
+		LastAssignedAgentID: q.LastAssignedAgentID(),
+		CreatedAt:           q.CreatedAt(),
+		UpdatedAt:           q.UpdatedAt(),
+	}
+}
+
+func queueToDomain(entity entities.QueueEntity) *team.Queue {
+	return team.ReconstructQueue(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		entity.TeamID,
+		entity.Name,
+		project.AssignmentStrategy(entity.Strategy),
+		entity.Priority,
+		entity.Enabled,
+		entity.LastAssignedAgentID,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	)
+}
+
+func queuesToDomain(rows []entities.QueueEntity) []*team.Queue {
+	queues := make([]*team.Queue, len(rows))
+	for i, row := range rows {
+		queues[i] = queueToDomain(row)
+	}
+	return queues
+}
+
+// GormQueueItemRepository persiste as passagens das sessões pelas filas
+type GormQueueItemRepository struct {
+	db *gorm.DB
+}
+
+func NewGormQueueItemRepository(db *gorm.DB) team.QueueItemRepository {
+	return &GormQueueItemRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormQueueItemRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormQueueItemRepository) Save(ctx context.Context, item *team.QueueItem) error {
+	if err := r.getDB(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(queueItemToEntity(item)).Error; err != nil {
+		return fmt.Errorf("failed to save queue item: %w", err)
+	}
+	return nil
+}
+
+func (r *GormQueueItemRepository) FindWaitingBySession(ctx context.Context, sessionID uuid.UUID) (*team.QueueItem, error) {
+	var entity entities.QueueItemEntity
+	if err := r.getDB(ctx).Where("session_id = ? AND status = ?", sessionID, string(team.ItemWaiting)).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, team.ErrQueueItemNotFound
+		}
+		return nil, fmt.Errorf("failed to load queue item: %w", err)
+	}
+	return queueItemToDomain(entity), nil
+}
+
+func (r *GormQueueItemRepository) FindWaiting(ctx context.Context, queueIDs []uuid.UUID, limit int) ([]*team.QueueItem, error) {
+	if len(queueIDs) == 0 {
+		return []*team.QueueItem{}, nil
+	}
+	sql, args := waitingQueueItemsQuery(queueIDs, limit)
+
+	var rows []entities.QueueItemEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load waiting queue items: %w", err)
+	}
+	return queueItemsToDomain(rows), nil
+}
+
+func (r *GormQueueItemRepository) ClaimNext(ctx context.Context, queueIDs []uuid.UUID) (*team.QueueItem, error) {
+	if len(queueIDs) == 0 {
+		return nil, team.ErrQueueItemNotFound
+	}
+	sql, args := claimNextQueueItemQuery(queueIDs)
+
+	var rows []entities.QueueItemEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to claim queue item: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, team.ErrQueueItemNotFound
+	}
+	return queueItemToDomain(rows[0]), nil
+}
+
+func (r *GormQueueItemRepository) FindDistributable(ctx context.Context, limit int) ([]*team.QueueItem, error) {
+	sql, args := distributableQueueItemsQuery(limit)
+
+	var rows []entities.QueueItemEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load distributable queue items: %w", err)
+	}
+	return queueItemsToDomain(rows), nil
+}
+
+type queueMetricsRow struct {
+	QueueID            uuid.UUID
+	Waiting            int
+	LongestWaitSeconds float64
+	Assigned           int
+	Abandoned          int
+	AvgWaitSeconds     float64
+}
+
+func (r *GormQueueItemRepository) Metrics(ctx context.Context, queueIDs []uuid.UUID, since time.Time, now time.Time) (map[uuid.UUID]team.Metrics, error) {
+	result := make(map[uuid.UUID]team.Metrics, len(queueIDs))
+	if len(queueIDs) == 0 {
+		return result, nil
+	}
+	sql, args := queueMetricsQuery(queueIDs, since, now)
+
+	var rows []queueMetricsRow
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load queue metrics: %w", err)
+	}
+	for _, row := range rows {
+		result[row.QueueID] = team.Metrics{
+			QueueID:     row.QueueID,
+			Waiting:     row.Waiting,
+			LongestWait: secondsToDuration(row.LongestWaitSeconds),
+			Assigned:    row.Assigned,
+			Abandoned:   row.Abandoned,
+			AvgWait:     secondsToDuration(row.AvgWaitSeconds),
+		}
+	}
+	return result, nil
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second)).Round(time.Second)
+}
+
+// queueItemRankSQL ordem de atendimento dentro da fila: prioridade da sessão e, depois, o mais antigo
+const queueItemRankSQL = `CASE i.priority WHEN 'urgent' THEN 3 WHEN 'high' THEN 2 WHEN 'low' THEN 0 ELSE 1 END DESC, i.enqueued_at ASC`
+
+func uuidArray(ids []uuid.UUID) interface{} {
+	values := make([]string, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return pq.Array(values)
+}
+
+// waitingQueueItemsQuery itens em espera das filas, agrupados por fila na ordem de atendimento
+func waitingQueueItemsQuery(queueIDs []uuid.UUID, limit int) (string, []interface{}) {
+	return `SELECT i.* FROM queue_items i
+		WHERE i.status = 'waiting' AND i.queue_id = ANY(?::uuid[])
+		ORDER BY i.queue_id, ` + queueItemRankSQL + `
+		LIMIT ?`, []interface{}{uuidArray(queueIDs), limit}
+}
+
+// claimNextQueueItemQuery bloqueia o próximo item: fila de maior prioridade, prioridade da sessão, mais antigo
+func claimNextQueueItemQuery(queueIDs []uuid.UUID) (string, []interface{}) {
+	return `SELECT i.* FROM queue_items i
+		JOIN queues q ON q.id = i.queue_id
+		WHERE i.status = 'waiting' AND q.enabled AND i.queue_id = ANY(?::uuid[])
+		ORDER BY q.priority DESC, ` + queueItemRankSQL + `
+		LIMIT 1
+		FOR UPDATE OF i SKIP LOCKED`, []interface{}{uuidArray(queueIDs)}
+}
+
+// distributableQueueItemsQuery itens em espera de filas automáticas habilitadas
+func distributableQueueItemsQuery(limit int) (string, []interface{}) {
+	return `SELECT i.* FROM queue_items i
+		JOIN queues q ON q.id = i.queue_id
+		WHERE i.status = 'waiting' AND q.enabled AND q.strategy IN (?, ?)
+		ORDER BY q.priority DESC, ` + queueItemRankSQL + `
+		LIMIT ?`, []interface{}{string(project.StrategyRoundRobin), string(project.StrategyLeastSessions), limit}
+}
+
+// queueMetricsQuery espera atual (waiting, maior espera) e desfecho das esperas encerradas desde since
+func queueMetricsQuery(queueIDs []uuid.UUID, since, now time.Time) (string, []interface{}) {
+	return `SELECT
+			queue_id,
+			COUNT(*) FILTER (WHERE status = 'waiting') AS waiting,
+			COALESCE(MAX(EXTRACT(EPOCH FROM (?::timestamptz - enqueued_at))) FILTER (WHERE status = 'waiting'), 0) AS longest_wait_seconds,
+			COUNT(*) FILTER (WHERE status = 'assigned') AS assigned,
+			COUNT(*) FILTER (WHERE status = 'abandoned') AS abandoned,
+			COALESCE(AVG(EXTRACT(EPOCH FROM (resolved_at - enqueued_at))) FILTER (WHERE status = 'assigned'), 0) AS avg_wait_seconds
+		FROM queue_items
+		WHERE queue_id = ANY(?::uuid[]) AND (status = 'waiting' OR resolved_at >= ?)
+		GROUP BY queue_id`, []interface{}{now, uuidArray(queueIDs), since}
+}
+
+func queueItemToEntity(item *team.QueueItem) *entities.QueueItemEntity {
+	return &entities.QueueItemEntity{
+		ID:         item.ID(),
+		TenantID:   item.TenantID(),
+		ProjectID:  item.ProjectID(),
+		QueueID:    item.QueueID(),
+		SessionID:  item.SessionID(),
+		ContactID:  item.ContactID(),
+		Priority:   string(item.Priority()),
+		Status:     string(item.Status()),
+		AgentID:    item.AgentID(),
+		EnqueuedAt: item.EnqueuedAt(),
+		ResolvedAt: item.ResolvedAt(),
+	}
+}
+
+func queueItemToDomain(entity entities.QueueItemEntity) *team.QueueItem {
+	return team.ReconstructQueueItem(
+		entity.ID,
+		entity.TenantID,
+		entity.ProjectID,
+		entity.QueueID,
+		entity.SessionID,
+		entity.ContactID,
+		session.Priority(entity.Priority),
+		team.ItemStatus(entity.Status),
+		entity.AgentID,
+		entity.EnqueuedAt,
+		entity.ResolvedAt,
+	)
+}
+
+func queueItemsToDomain(rows []entities.QueueItemEntity) []*team.QueueItem {
+	items := make([]*team.QueueItem, len(rows))
+	for i, row := range rows {
+		items[i] = queueItemToDomain(row)
+	}
+	return items
+}
@@ -0,0 +1,113 @@
+package persistence
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+func TestTeamMemberFilter(t *testing.T) {
+	agentID := uuid.New()
+
+	var ids []uuid.UUID
+	require.NoError(t, json.Unmarshal([]byte(teamMemberFilter(agentID)), &ids))
+	assert.Equal(t, []uuid.UUID{agentID}, ids)
+}
+
+func TestTeamEntityRoundTrip(t *testing.T) {
+	tm, err := team.NewTeam("tenant-1", uuid.New(), "Suporte")
+	require.NoError(t, err)
+	supervisor := uuid.New()
+	members := []uuid.UUID{uuid.New(), uuid.New()}
+	require.NoError(t, tm.SetSupervisor(&supervisor))
+	require.NoError(t, tm.SetMembers(members))
+	tm.SetDescription("Atendimento nível 1")
+
+	entity, err := teamToEntity(tm)
+	require.NoError(t, err)
+	restored := teamToDomain(*entity)
+
+	assert.Equal(t, tm.ID(), restored.ID())
+	assert.Equal(t, "Atendimento nível 1", restored.Description())
+	assert.Equal(t, members, restored.MemberIDs())
+	assert.True(t, restored.IsSupervisor(supervisor))
+}
+
+func TestQueueEntityRoundTrip(t *testing.T) {
+	q, err := team.NewQueue("tenant-1", uuid.New(), uuid.New(), "Vendas", project.StrategyRoundRobin)
+	require.NoError(t, err)
+	q.SetPriority(10)
+	agentID := uuid.New()
+	q.RecordAssignment(agentID)
+	q.Disable()
+
+	restored := queueToDomain(*queueToEntity(q))
+
+	assert.Equal(t, project.StrategyRoundRobin, restored.Strategy())
+	assert.Equal(t, 10, restored.Priority())
+	assert.False(t, restored.IsEnabled())
+	assert.Equal(t, agentID, *restored.LastAssignedAgentID())
+}
+
+func TestQueueItemEntityRoundTrip(t *testing.T) {
+	resolvedAt := time.Now()
+	agentID := uuid.New()
+	item := team.ReconstructQueueItem(uuid.New(), "tenant-1", uuid.New(), uuid.New(), uuid.New(), uuid.New(),
+		session.PriorityUrgent, team.ItemAssigned, &agentID, resolvedAt.Add(-time.Minute), &resolvedAt)
+
+	restored := queueItemToDomain(*queueItemToEntity(item))
+
+	assert.Equal(t, session.PriorityUrgent, restored.Priority())
+	assert.Equal(t, team.ItemAssigned, restored.Status())
+	assert.Equal(t, agentID, *restored.AgentID())
+	assert.Equal(t, time.Minute, restored.WaitTime(time.Now()))
+}
+
+func TestQueueItemQueries(t *testing.T) {
+	queueIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("claim next", func(t *testing.T) {
+		sql, args := claimNextQueueItemQuery(queueIDs)
+
+		assert.Contains(t, sql, "q.enabled")
+		assert.Contains(t, sql, "ORDER BY q.priority DESC, CASE i.priority")
+		assert.Contains(t, sql, "FOR UPDATE OF i SKIP LOCKED")
+		assert.Equal(t, strings.Count(sql, "?"), len(args))
+	})
+
+	t.Run("waiting", func(t *testing.T) {
+		sql, args := waitingQueueItemsQuery(queueIDs, 50)
+
+		assert.Contains(t, sql, "i.status = 'waiting'")
+		assert.Equal(t, strings.Count(sql, "?"), len(args))
+		assert.Equal(t, 50, args[1])
+	})
+
+	t.Run("distributable", func(t *testing.T) {
+		sql, args := distributableQueueItemsQuery(100)
+
+		assert.Contains(t, sql, "q.strategy IN (?, ?)")
+		assert.Equal(t, strings.Count(sql, "?"), len(args))
+		assert.Equal(t, []interface{}{"round_robin", "least_sessions", 100}, args)
+	})
+
+	t.Run("metrics", func(t *testing.T) {
+		since := time.Now().Add(-24 * time.Hour)
+		now := time.Now()
+		sql, args := queueMetricsQuery(queueIDs, since, now)
+
+		assert.Contains(t, sql, "status = 'waiting' OR resolved_at >= ?")
+		assert.Contains(t, sql, "GROUP BY queue_id")
+		assert.Equal(t, strings.Count(sql, "?"), len(args))
+		assert.Equal(t, now, args[0])
+		assert.Equal(t, since, args[2])
+	})
+}
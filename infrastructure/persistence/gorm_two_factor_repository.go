@@ -0,0 +1,120 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
+	"github.com/ventros/crm/internal/domain/crm/credential"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormTwoFactorRepository persiste as inscrições 2FA em user_two_factor. O segredo TOTP é cifrado
+// com o mesmo AES-256-GCM das credenciais.
+type GormTwoFactorRepository struct {
+	db        *gorm.DB
+	encryptor credential.Encryptor
+}
+
+func NewGormTwoFactorRepository(db *gorm.DB, encryptor credential.Encryptor) twofactor.Repository {
+	return &GormTwoFactorRepository{db: db, encryptor: encryptor}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormTwoFactorRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Save grava pelo user_id: reiniciar a inscrição substitui a pendente anterior
+func (r *GormTwoFactorRepository) Save(ctx context.Context, e *twofactor.Enrollment) error {
+	entity, err := twoFactorToEntity(e, r.encryptor)
+	if err != nil {
+		return err
+	}
+	err = r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"id", "secret_ciphertext", "secret_nonce", "status", "last_used_step",
+			"recovery_code_hashes", "confirmed_at", "last_used_at", "created_at", "updated_at",
+		}),
+	}).Create(entity).Error
+	if err != nil {
+		return fmt.Errorf("failed to save two-factor enrollment: %w", err)
+	}
+	return nil
+}
+
+// FindByUserID trava a linha (FOR UPDATE) dentro de transação: duas verificações simultâneas do
+// mesmo código são serializadas e a segunda é recusada pelo last_used_step
+func (r *GormTwoFactorRepository) FindByUserID(ctx context.Context, userID uuid.UUID) (*twofactor.Enrollment, error) {
+	var entity entities.UserTwoFactorEntity
+	db := r.getDB(ctx)
+	if shared.TransactionFromContext(ctx) != nil {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	if err := db.Where("user_id = ?", userID).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, twofactor.ErrEnrollmentNotFound
+		}
+		return nil, fmt.Errorf("failed to load two-factor enrollment: %w", err)
+	}
+	return twoFactorToDomain(entity, r.encryptor)
+}
+
+func (r *GormTwoFactorRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	if err := r.getDB(ctx).Where("user_id = ?", userID).Delete(&entities.UserTwoFactorEntity{}).Error; err != nil {
+		return fmt.Errorf("failed to delete two-factor enrollment: %w", err)
+	}
+	return nil
+}
+
+func twoFactorToEntity(e *twofactor.Enrollment, encryptor credential.Encryptor) (*entities.UserTwoFactorEntity, error) {
+	secret, err := encryptor.Encrypt(twofactor.EncodeSecret(e.Secret()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt two-factor secret: %w", err)
+	}
+	return &entities.UserTwoFactorEntity{
+		ID:                 e.ID(),
+		UserID:             e.UserID(),
+		SecretCiphertext:   secret.Ciphertext(),
+		SecretNonce:        secret.Nonce(),
+		Status:             string(e.Status()),
+		LastUsedStep:       e.LastUsedStep(),
+		RecoveryCodeHashes: e.RecoveryCodeHashes(),
+		ConfirmedAt:        e.ConfirmedAt(),
+		LastUsedAt:         e.LastUsedAt(),
+		CreatedAt:          e.CreatedAt(),
+		UpdatedAt:          e.UpdatedAt(),
+	}, nil
+}
+
+func twoFactorToDomain(entity entities.UserTwoFactorEntity, encryptor credential.Encryptor) (*twofactor.Enrollment, error) {
+	encoded, err := encryptor.Decrypt(credential.NewEncryptedValue(entity.SecretCiphertext, entity.SecretNonce))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt two-factor secret: %w", err)
+	}
+	secret, err := twofactor.DecodeSecret(encoded)
+	if err != nil {
+		return nil, err
+	}
+	return twofactor.ReconstructEnrollment(
+		entity.ID,
+		entity.UserID,
+		secret,
+		twofactor.Status(entity.Status),
+		entity.LastUsedStep,
+		entity.RecoveryCodeHashes,
+		entity.ConfirmedAt,
+		entity.LastUsedAt,
+		entity.CreatedAt,
+		entity.UpdatedAt,
+	), nil
+}
@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/crypto"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
+)
+
+func TestTwoFactorMapping(t *testing.T) {
+	encryptor, err := crypto.NewAESEncryptor([]byte("0123456789abcdef0123456789abcdef"))
+	require.NoError(t, err)
+	e, err := twofactor.NewEnrollment(uuid.New())
+	require.NoError(t, err)
+	now := time.Now()
+	codes, err := e.Confirm(twofactor.Code(e.Secret(), twofactor.Step(now)), now)
+	require.NoError(t, err)
+
+	entity, err := twoFactorToEntity(e, encryptor)
+	require.NoError(t, err)
+	assert.Equal(t, "active", entity.Status)
+	assert.NotContains(t, entity.SecretCiphertext, twofactor.EncodeSecret(e.Secret()), "secret is encrypted")
+	assert.Len(t, entity.RecoveryCodeHashes, twofactor.RecoveryCodeCount)
+
+	restored, err := twoFactorToDomain(*entity, encryptor)
+	require.NoError(t, err)
+	assert.Equal(t, e.Secret(), restored.Secret())
+	assert.Equal(t, e.LastUsedStep(), restored.LastUsedStep())
+	assert.True(t, restored.IsActive())
+	usedRecovery, err := restored.Verify(codes[0], now)
+	require.NoError(t, err)
+	assert.True(t, usedRecovery)
+
+	otherKey, err := crypto.NewAESEncryptor([]byte("fedcba9876543210fedcba9876543210"))
+	require.NoError(t, err)
+	_, err = twoFactorToDomain(*entity, otherKey)
+	assert.Error(t, err)
+}
@@ -6,7 +6,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	appshared "github.com/ventros/crm/internal/application/shared"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"gorm.io/gorm"
 )
@@ -21,15 +23,23 @@ func NewGormUsageMeterRepository(db *gorm.DB) *GormUsageMeterRepository {
 	return &GormUsageMeterRepository{db: db}
 }
 
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormUsageMeterRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := appshared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
 // Create cria um novo usage meter
 func (r *GormUsageMeterRepository) Create(ctx context.Context, meter *entities.UsageMeterEntity) error {
-	return r.db.WithContext(ctx).Create(meter).Error
+	return r.getDB(ctx).Create(meter).Error
 }
 
 // FindByID busca um usage meter por ID
 func (r *GormUsageMeterRepository) FindByID(ctx context.Context, id uuid.UUID) (*entities.UsageMeterEntity, error) {
 	var meter entities.UsageMeterEntity
-	err := r.db.WithContext(ctx).First(&meter, "id = ?", id).Error
+	err := r.getDB(ctx).First(&meter, "id = ?", id).Error
 	if err != nil {
 		return nil, err
 	}
@@ -39,7 +49,7 @@ func (r *GormUsageMeterRepository) FindByID(ctx context.Context, id uuid.UUID) (
 // FindByBillingAccountAndMetric busca um usage meter por billing account e métrica
 func (r *GormUsageMeterRepository) FindByBillingAccountAndMetric(ctx context.Context, billingAccountID uuid.UUID, metricName string) (*entities.UsageMeterEntity, error) {
 	var meter entities.UsageMeterEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("billing_account_id = ? AND metric_name = ?", billingAccountID, metricName).
 		Order("created_at DESC").
 		First(&meter).Error
@@ -52,7 +62,7 @@ func (r *GormUsageMeterRepository) FindByBillingAccountAndMetric(ctx context.Con
 // FindByBillingAccountID busca todos os usage meters de uma billing account
 func (r *GormUsageMeterRepository) FindByBillingAccountID(ctx context.Context, billingAccountID uuid.UUID) ([]*entities.UsageMeterEntity, error) {
 	var meters []*entities.UsageMeterEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("billing_account_id = ?", billingAccountID).
 		Order("created_at DESC").
 		Find(&meters).Error
@@ -62,7 +72,7 @@ func (r *GormUsageMeterRepository) FindByBillingAccountID(ctx context.Context, b
 // FindByStripeCustomerID busca todos os usage meters de um Stripe customer
 func (r *GormUsageMeterRepository) FindByStripeCustomerID(ctx context.Context, stripeCustomerID string) ([]*entities.UsageMeterEntity, error) {
 	var meters []*entities.UsageMeterEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("stripe_customer_id = ?", stripeCustomerID).
 		Order("created_at DESC").
 		Find(&meters).Error
@@ -75,7 +85,7 @@ func (r *GormUsageMeterRepository) FindPendingReports(ctx context.Context, limit
 	var meters []*entities.UsageMeterEntity
 	oneHourAgo := time.Now().Add(-1 * time.Hour)
 
-	query := r.db.WithContext(ctx).
+	query := r.getDB(ctx).
 		Where("last_reported_at IS NULL OR last_reported_at < ?", oneHourAgo).
 		Where("quantity > 0").
 		Order("last_reported_at ASC NULLS FIRST")
@@ -91,32 +101,42 @@ func (r *GormUsageMeterRepository) FindPendingReports(ctx context.Context, limit
 // FindByPeriod busca usage meters por período
 func (r *GormUsageMeterRepository) FindByPeriod(ctx context.Context, periodStart, periodEnd time.Time) ([]*entities.UsageMeterEntity, error) {
 	var meters []*entities.UsageMeterEntity
-	err := r.db.WithContext(ctx).
+	err := r.getDB(ctx).
 		Where("period_start >= ? AND period_end <= ?", periodStart, periodEnd).
 		Order("created_at DESC").
 		Find(&meters).Error
 	return meters, err
 }
 
-// Update atualiza um usage meter
+// Update atualiza um usage meter, criando-o quando ainda não existe. A versão lida pelo
+// chamador é a esperada no banco: incrementos concorrentes do mesmo medidor resultam em
+// OptimisticLockError e devem ser refeitos.
 func (r *GormUsageMeterRepository) Update(ctx context.Context, meter *entities.UsageMeterEntity) error {
 	// Check if exists
 	var existing entities.UsageMeterEntity
-	err := r.db.WithContext(ctx).Where("id = ?", meter.ID).First(&existing).Error
+	err := r.getDB(ctx).Where("id = ?", meter.ID).First(&existing).Error
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			// Insert if not found
-			return r.db.WithContext(ctx).Create(meter).Error
+			if err := r.getDB(ctx).Create(meter).Error; err != nil {
+				// Outro processo criou o medidor da mesma métrica ao mesmo tempo
+				var pqErr *pq.Error
+				if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+					return shared.NewOptimisticLockError("UsageMeter", meter.ID.String(), 0, meter.Version)
+				}
+				return err
+			}
+			return nil
 		}
 		return err
 	}
 
 	// Update with optimistic locking
-	result := r.db.WithContext(ctx).Model(&entities.UsageMeterEntity{}).
-		Where("id = ? AND version = ?", meter.ID, existing.Version).
+	result := r.getDB(ctx).Model(&entities.UsageMeterEntity{}).
+		Where("id = ? AND version = ?", meter.ID, meter.Version).
 		Updates(map[string]interface{}{
-			"version":            existing.Version + 1, // Increment version
+			"version":            meter.Version + 1, // Increment version
 			"billing_account_id": meter.BillingAccountID,
 			"stripe_customer_id": meter.StripeCustomerID,
 			"stripe_meter_id":    meter.StripeMeterID,
@@ -149,5 +169,5 @@ func (r *GormUsageMeterRepository) Update(ctx context.Context, meter *entities.U
 
 // Delete deleta um usage meter (soft delete)
 func (r *GormUsageMeterRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Delete(&entities.UsageMeterEntity{}, "id = ?", id).Error
+	return r.getDB(ctx).Delete(&entities.UsageMeterEntity{}, "id = ?", id).Error
 }
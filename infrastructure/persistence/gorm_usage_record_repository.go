@@ -0,0 +1,157 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormUsageRecordRepository registros de consumo em usage_records
+type GormUsageRecordRepository struct {
+	db *gorm.DB
+}
+
+func NewGormUsageRecordRepository(db *gorm.DB) billing.UsageRecordRepository {
+	return &GormUsageRecordRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormUsageRecordRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+// Record insere ignorando a origem repetida: a unique (metric, source_id) decide quem conta
+func (r *GormUsageRecordRepository) Record(ctx context.Context, record *billing.UsageRecord) (bool, error) {
+	result := r.getDB(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "metric"}, {Name: "source_id"}},
+		DoNothing: true,
+	}).Create(usageRecordToEntity(record))
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to record usage: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *GormUsageRecordRepository) FindUnreported(ctx context.Context, limit int) ([]*billing.UsageRecord, error) {
+	metered := make([]string, 0, len(billing.MeteredMetrics()))
+	for _, metric := range billing.MeteredMetrics() {
+		metered = append(metered, string(metric))
+	}
+
+	var rows []entities.UsageRecordEntity
+	query := r.getDB(ctx).
+		Select("usage_records.*").
+		Joins("JOIN billing_accounts ON billing_accounts.id = usage_records.billing_account_id").
+		Where("usage_records.reported_at IS NULL").
+		Where("usage_records.metric IN ?", metered).
+		Where("billing_accounts.stripe_customer_id IS NOT NULL AND billing_accounts.stripe_customer_id <> ''").
+		Order("usage_records.occurred_at ASC")
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+	if err := query.Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to list unreported usage: %w", err)
+	}
+
+	records := make([]*billing.UsageRecord, len(rows))
+	for i, row := range rows {
+		records[i] = usageRecordToDomain(row)
+	}
+	return records, nil
+}
+
+func (r *GormUsageRecordRepository) MarkReported(ctx context.Context, ids []uuid.UUID, reportedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	err := r.getDB(ctx).Model(&entities.UsageRecordEntity{}).
+		Where("id IN ? AND reported_at IS NULL", ids).
+		Update("reported_at", reportedAt).Error
+	if err != nil {
+		return fmt.Errorf("failed to mark usage as reported: %w", err)
+	}
+	return nil
+}
+
+func usageRecordToEntity(record *billing.UsageRecord) *entities.UsageRecordEntity {
+	return &entities.UsageRecordEntity{
+		ID:               record.ID(),
+		BillingAccountID: record.BillingAccountID(),
+		ProjectID:        record.ProjectID(),
+		Metric:           string(record.Metric()),
+		SourceID:         record.SourceID(),
+		Quantity:         record.Quantity(),
+		OccurredAt:       record.OccurredAt(),
+		ReportedAt:       record.ReportedAt(),
+		CreatedAt:        record.CreatedAt(),
+	}
+}
+
+func usageRecordToDomain(entity entities.UsageRecordEntity) *billing.UsageRecord {
+	return billing.ReconstructUsageRecord(
+		entity.ID,
+		entity.BillingAccountID,
+		entity.ProjectID,
+		billing.UsageMetric(entity.Metric),
+		entity.SourceID,
+		entity.Quantity,
+		entity.OccurredAt,
+		entity.ReportedAt,
+		entity.CreatedAt,
+	)
+}
+
+// GormUsageResourceCounter conta os recursos de estoque (canais, contatos, agentes) de todos os
+// projetos ativos de uma conta de cobrança
+type GormUsageResourceCounter struct {
+	db *gorm.DB
+}
+
+func NewGormUsageResourceCounter(db *gorm.DB) *GormUsageResourceCounter {
+	return &GormUsageResourceCounter{db: db}
+}
+
+func (c *GormUsageResourceCounter) CountResources(ctx context.Context, billingAccountID uuid.UUID, metric billing.UsageMetric) (int64, error) {
+	query, ok := resourceCountSQL(metric)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s is not a resource count", billing.ErrInvalidUsageMetric, metric)
+	}
+
+	db := c.db.WithContext(ctx)
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		db = tx.WithContext(ctx)
+	}
+
+	var count int64
+	if err := db.Raw(query, billingAccountID).Scan(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count %s: %w", metric, err)
+	}
+	return count, nil
+}
+
+// resourceCountSQL consulta de contagem da métrica de estoque (só agentes humanos ativos contam)
+func resourceCountSQL(metric billing.UsageMetric) (string, bool) {
+	var table, filter string
+	switch metric {
+	case billing.MetricChannels:
+		table = "channels"
+	case billing.MetricContacts:
+		table = "contacts"
+	case billing.MetricAgents:
+		table, filter = "agents", " AND r.type = 'human' AND r.active"
+	default:
+		return "", false
+	}
+	return "SELECT COUNT(*) FROM " + table + " r JOIN projects p ON p.id = r.project_id" +
+		" WHERE p.billing_account_id = ? AND p.deleted_at IS NULL AND r.deleted_at IS NULL" + filter, true
+}
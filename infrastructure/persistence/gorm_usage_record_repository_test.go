@@ -0,0 +1,67 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+)
+
+func TestUsageRecordMapping(t *testing.T) {
+	occurredAt := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	record, err := billing.NewUsageRecord(uuid.New(), uuid.New(), billing.MetricMessages, " msg-1 ", 1, occurredAt)
+	require.NoError(t, err)
+
+	entity := usageRecordToEntity(record)
+	assert.Equal(t, "messages_sent", entity.Metric)
+	assert.Equal(t, "msg-1", entity.SourceID)
+	assert.Nil(t, entity.ReportedAt)
+
+	restored := usageRecordToDomain(*entity)
+	assert.Equal(t, record.ID(), restored.ID())
+	assert.Equal(t, record.BillingAccountID(), restored.BillingAccountID())
+	assert.Equal(t, record.ProjectID(), restored.ProjectID())
+	assert.Equal(t, billing.MetricMessages, restored.Metric())
+	assert.Equal(t, int64(1), restored.Quantity())
+	assert.Equal(t, occurredAt, restored.OccurredAt())
+}
+
+func TestBillingPlanMapping(t *testing.T) {
+	plan, err := billing.NewPlan("pro", "Pro", "price_pro", billing.Entitlements{
+		MaxChannels: 5, MaxContacts: 10000, MaxAgents: 10,
+		MonthlyMessages: 50000, AIMinutes: billing.Unlimited, BroadcastsPerDay: 20,
+	}, 90)
+	require.NoError(t, err)
+	plan.MarkAsDefault()
+
+	entity := billingPlanToEntity(plan)
+	assert.Equal(t, int64(-1), entity.AIMinutes)
+	assert.Equal(t, 90, entity.SoftLimitPercent)
+	assert.True(t, entity.IsDefault)
+
+	restored := billingPlanToDomain(*entity)
+	assert.Equal(t, plan.ID(), restored.ID())
+	assert.Equal(t, "pro", restored.Key())
+	assert.Equal(t, "price_pro", restored.StripePriceID())
+	assert.Equal(t, plan.Entitlements(), restored.Entitlements())
+	assert.Equal(t, int64(18), restored.SoftLimit(billing.MetricBroadcasts))
+	assert.True(t, restored.IsDefault())
+}
+
+func TestResourceCountSQL(t *testing.T) {
+	query, ok := resourceCountSQL(billing.MetricAgents)
+	require.True(t, ok)
+	assert.Contains(t, query, "FROM agents r JOIN projects p ON p.id = r.project_id")
+	assert.Contains(t, query, "r.type = 'human' AND r.active")
+	assert.Contains(t, query, "p.deleted_at IS NULL AND r.deleted_at IS NULL")
+
+	query, ok = resourceCountSQL(billing.MetricContacts)
+	require.True(t, ok)
+	assert.NotContains(t, query, "r.type")
+
+	_, ok = resourceCountSQL(billing.MetricMessages)
+	assert.False(t, ok)
+}
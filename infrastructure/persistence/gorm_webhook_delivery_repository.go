@@ -0,0 +1,199 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/shared"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+// GormWebhookDeliveryRepository persiste o log de entregas de webhook
+type GormWebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewGormWebhookDeliveryRepository(db *gorm.DB) webhook.DeliveryRepository {
+	return &GormWebhookDeliveryRepository{db: db}
+}
+
+// getDB extracts transaction from context if present, otherwise returns default connection
+func (r *GormWebhookDeliveryRepository) getDB(ctx context.Context) *gorm.DB {
+	if tx := shared.TransactionFromContext(ctx); tx != nil {
+		return tx.WithContext(ctx)
+	}
+	return r.db.WithContext(ctx)
+}
+
+func (r *GormWebhookDeliveryRepository) Save(ctx context.Context, delivery *webhook.Delivery) error {
+	entity, err := webhookDeliveryToEntity(delivery)
+	if err != nil {
+		return err
+	}
+	if err := r.getDB(ctx).Create(entity).Error; err != nil {
+		return fmt.Errorf("failed to save webhook delivery: %w", err)
+	}
+	return nil
+}
+
+func (r *GormWebhookDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	var entity entities.WebhookDeliveryEntity
+	if err := r.getDB(ctx).Where("id = ?", id).First(&entity).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, webhook.ErrDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to load webhook delivery: %w", err)
+	}
+	return webhookDeliveryToDomain(entity), nil
+}
+
+func (r *GormWebhookDeliveryRepository) List(ctx context.Context, filter webhook.DeliveryFilter) ([]*webhook.Delivery, int64, error) {
+	where, args := webhookDeliveryFilterConditions(filter)
+	query := r.getDB(ctx).Model(&entities.WebhookDeliveryEntity{}).Where(where, args...)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+
+	var rows []entities.WebhookDeliveryEntity
+	if err := query.Order("created_at DESC").Limit(filter.Limit).Offset(filter.Offset).Find(&rows).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = webhookDeliveryToDomain(row)
+	}
+	return deliveries, total, nil
+}
+
+func (r *GormWebhookDeliveryRepository) FindFailedSince(ctx context.Context, subscriptionID uuid.UUID, since time.Time, limit int) ([]*webhook.Delivery, error) {
+	sql, args := failedWebhookDeliveriesQuery(subscriptionID, since, limit)
+
+	var rows []entities.WebhookDeliveryEntity
+	if err := r.getDB(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to find failed webhook deliveries: %w", err)
+	}
+
+	deliveries := make([]*webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = webhookDeliveryToDomain(row)
+	}
+	return deliveries, nil
+}
+
+func (r *GormWebhookDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.getDB(ctx).Where("created_at < ?", before).Delete(&entities.WebhookDeliveryEntity{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete old webhook deliveries: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// webhookDeliveryFilterConditions WHERE da listagem do log de uma inscrição
+func webhookDeliveryFilterConditions(filter webhook.DeliveryFilter) (string, []interface{}) {
+	conditions := []string{"subscription_id = ?"}
+	args := []interface{}{filter.SubscriptionID}
+
+	if filter.EventType != "" {
+		conditions = append(conditions, "event_type = ?")
+		args = append(args, filter.EventType)
+	}
+	if filter.Success != nil {
+		conditions = append(conditions, "success = ?")
+		args = append(args, *filter.Success)
+	}
+	if filter.Since != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.Since)
+	}
+	if filter.Until != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.Until)
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+// failedWebhookDeliveriesQuery última tentativa de cada notificação desde since sem nenhuma entrega
+// bem-sucedida (nem anterior, nem por reenvio), das mais antigas para as mais novas
+func failedWebhookDeliveriesQuery(subscriptionID uuid.UUID, since time.Time, limit int) (string, []interface{}) {
+	sql := `SELECT * FROM (
+	SELECT DISTINCT ON (event_id) *
+	FROM webhook_deliveries
+	WHERE subscription_id = ? AND created_at >= ?
+	ORDER BY event_id, created_at DESC
+) latest
+WHERE NOT EXISTS (
+	SELECT 1 FROM webhook_deliveries delivered
+	WHERE delivered.subscription_id = latest.subscription_id
+		AND delivered.event_id = latest.event_id
+		AND delivered.success
+)
+ORDER BY created_at ASC
+LIMIT ?`
+	return sql, []interface{}{subscriptionID, since, limit}
+}
+
+func webhookDeliveryToEntity(d *webhook.Delivery) (*entities.WebhookDeliveryEntity, error) {
+	headers, err := json.Marshal(d.RequestHeaders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal webhook delivery headers: %w", err)
+	}
+	return &entities.WebhookDeliveryEntity{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		TenantID:       d.TenantID,
+		ProjectID:      d.ProjectID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Trigger:        string(d.Trigger),
+		ReplayOf:       d.ReplayOf,
+		Attempt:        d.Attempt,
+		RequestURL:     d.RequestURL,
+		RequestHeaders: datatypes.JSON(headers),
+		RequestBody:    d.RequestBody,
+		StatusCode:     d.StatusCode,
+		ResponseBody:   d.ResponseBody,
+		LatencyMs:      d.Latency.Milliseconds(),
+		Error:          d.Error,
+		Success:        d.Success,
+		CreatedAt:      d.CreatedAt,
+	}, nil
+}
+
+func webhookDeliveryToDomain(e entities.WebhookDeliveryEntity) *webhook.Delivery {
+	headers := make(map[string]string)
+	if len(e.RequestHeaders) > 0 {
+		_ = json.Unmarshal(e.RequestHeaders, &headers)
+	}
+	return &webhook.Delivery{
+		ID:             e.ID,
+		SubscriptionID: e.SubscriptionID,
+		TenantID:       e.TenantID,
+		ProjectID:      e.ProjectID,
+		EventID:        e.EventID,
+		EventType:      e.EventType,
+		Trigger:        webhook.DeliveryTrigger(e.Trigger),
+		ReplayOf:       e.ReplayOf,
+		Attempt:        e.Attempt,
+		RequestURL:     e.RequestURL,
+		RequestHeaders: headers,
+		RequestBody:    e.RequestBody,
+		StatusCode:     e.StatusCode,
+		ResponseBody:   e.ResponseBody,
+		Latency:        time.Duration(e.LatencyMs) * time.Millisecond,
+		Error:          e.Error,
+		Success:        e.Success,
+		CreatedAt:      e.CreatedAt,
+	}
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+)
+
+func TestWebhookDeliveryFilterConditions(t *testing.T) {
+	subID := uuid.New()
+
+	where, args := webhookDeliveryFilterConditions(webhook.DeliveryFilter{SubscriptionID: subID})
+	assert.Equal(t, "subscription_id = ?", where)
+	assert.Equal(t, []interface{}{subID}, args)
+
+	failed := false
+	since := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	where, args = webhookDeliveryFilterConditions(webhook.DeliveryFilter{
+		SubscriptionID: subID, EventType: "contact.created", Success: &failed, Since: &since, Until: &until,
+	})
+	assert.Equal(t, "subscription_id = ? AND event_type = ? AND success = ? AND created_at >= ? AND created_at < ?", where)
+	assert.Equal(t, []interface{}{subID, "contact.created", false, since, until}, args)
+}
+
+func TestWebhookFailedDeliveriesQuery(t *testing.T) {
+	subID := uuid.New()
+	since := time.Now().Add(-time.Hour)
+
+	sql, args := failedWebhookDeliveriesQuery(subID, since, 500)
+
+	assert.Contains(t, sql, "DISTINCT ON (event_id)")
+	assert.Contains(t, sql, "ORDER BY event_id, created_at DESC")
+	assert.Contains(t, sql, "NOT EXISTS")
+	assert.Contains(t, sql, "delivered.success")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, []interface{}{subID, since, 500}, args)
+}
+
+func TestWebhookTriggerUpdate(t *testing.T) {
+	id := uuid.New()
+	now := time.Now()
+
+	sql, args := webhookTriggerUpdate(id, true, now)
+	assert.Contains(t, sql, "success_count = success_count + 1")
+	assert.Contains(t, sql, "consecutive_failures = 0")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+
+	sql, args = webhookTriggerUpdate(id, false, now)
+	assert.Contains(t, sql, "failure_count = failure_count + 1")
+	assert.Contains(t, sql, "consecutive_failures = consecutive_failures + 1")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, id, args[len(args)-1])
+}
+
+func TestWebhookAutoDisableUpdate(t *testing.T) {
+	id := uuid.New()
+
+	sql, args := webhookAutoDisableUpdate(id, 20, webhook.AutoDisableReason(20), time.Now())
+
+	assert.Contains(t, sql, "SET active = false")
+	assert.Contains(t, sql, "WHERE id = ? AND active AND deleted_at IS NULL AND consecutive_failures >= ?")
+	assert.Equal(t, strings.Count(sql, "?"), len(args))
+	assert.Equal(t, "disabled after 20 consecutive failed deliveries", args[1])
+	assert.Equal(t, 20, args[len(args)-1])
+}
+
+func TestWebhookDeliveryMapping(t *testing.T) {
+	sub, err := webhook.NewWebhookSubscription(uuid.New(), uuid.New(), "tenant-1", "N8N", "https://n8n.example.com/hook", []string{"*"})
+	require.NoError(t, err)
+	original := webhook.NewDelivery(sub, uuid.New(), "contact.created", webhook.DeliveryTriggerEvent, 1,
+		http.Header{"Authorization": {"Bearer abc"}, "Content-Type": {"application/json"}}, []byte(`{"id":"1"}`))
+	replay := webhook.NewReplay(sub, original, http.Header{})
+	replay.RecordResponse(http.StatusServiceUnavailable, []byte("down"), 1500*time.Millisecond)
+
+	entity, err := webhookDeliveryToEntity(replay)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), entity.LatencyMs)
+	assert.Equal(t, "replay", entity.Trigger)
+
+	back := webhookDeliveryToDomain(*entity)
+	assert.Equal(t, replay, back)
+
+	entity, err = webhookDeliveryToEntity(original)
+	require.NoError(t, err)
+	assert.Equal(t, "[redacted]", webhookDeliveryToDomain(*entity).RequestHeaders["Authorization"])
+}
+
+func TestWebhookProjectOwnerQuery(t *testing.T) {
+	projectID := uuid.New()
+	sql, args := projectOwnerQuery(projectID)
+	assert.Contains(t, sql, "JOIN users u ON u.id = p.user_id")
+	assert.Equal(t, []interface{}{projectID}, args)
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
@@ -56,3 +57,38 @@ func (r *GormWebhookRepository) Update(ctx context.Context, webhook *entities.We
 func (r *GormWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return r.db.WithContext(ctx).Delete(&entities.WebhookSubscriptionEntity{}, "id = ?", id).Error
 }
+
+// RecordTrigger atualiza contadores de disparo atomicamente (entregas concorrentes da mesma inscrição)
+func (r *GormWebhookRepository) RecordTrigger(ctx context.Context, id uuid.UUID, success bool) error {
+	sql, args := webhookTriggerUpdate(id, success, time.Now())
+	return r.db.WithContext(ctx).Exec(sql, args...).Error
+}
+
+// AutoDisable desativa a inscrição se ainda ativa e com falhas seguidas >= maxConsecutiveFailures.
+// Retorna true apenas se esta chamada fez a desativação.
+func (r *GormWebhookRepository) AutoDisable(ctx context.Context, id uuid.UUID, maxConsecutiveFailures int, reason string) (bool, error) {
+	sql, args := webhookAutoDisableUpdate(id, maxConsecutiveFailures, reason, time.Now())
+	result := r.db.WithContext(ctx).Exec(sql, args...)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func webhookTriggerUpdate(id uuid.UUID, success bool, now time.Time) (string, []interface{}) {
+	if success {
+		return `UPDATE webhook_subscriptions
+SET last_triggered_at = ?, last_success_at = ?, success_count = success_count + 1, consecutive_failures = 0, updated_at = ?
+WHERE id = ? AND deleted_at IS NULL`, []interface{}{now, now, now, id}
+	}
+	return `UPDATE webhook_subscriptions
+SET last_triggered_at = ?, last_failure_at = ?, failure_count = failure_count + 1, consecutive_failures = consecutive_failures + 1, updated_at = ?
+WHERE id = ? AND deleted_at IS NULL`, []interface{}{now, now, now, id}
+}
+
+func webhookAutoDisableUpdate(id uuid.UUID, maxConsecutiveFailures int, reason string, now time.Time) (string, []interface{}) {
+	return `UPDATE webhook_subscriptions
+SET active = false, disabled_at = ?, disabled_reason = ?, updated_at = ?
+WHERE id = ? AND active AND deleted_at IS NULL AND consecutive_failures >= ?`,
+		[]interface{}{now, reason, now, id, maxConsecutiveFailures}
+}
@@ -0,0 +1,43 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	webhookapp "github.com/ventros/crm/internal/application/webhook"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"gorm.io/gorm"
+)
+
+// GormProjectOwnerDirectory resolve o usuário dono de um projeto (projects.user_id)
+type GormProjectOwnerDirectory struct {
+	db *gorm.DB
+}
+
+func NewGormProjectOwnerDirectory(db *gorm.DB) *GormProjectOwnerDirectory {
+	return &GormProjectOwnerDirectory{db: db}
+}
+
+func (d *GormProjectOwnerDirectory) ProjectOwner(ctx context.Context, projectID uuid.UUID) (*webhookapp.ProjectOwner, error) {
+	sql, args := projectOwnerQuery(projectID)
+
+	var rows []struct {
+		Name  string
+		Email string
+	}
+	if err := d.db.WithContext(ctx).Raw(sql, args...).Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load project owner: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, shared.NewNotFoundError("project", projectID.String())
+	}
+	return &webhookapp.ProjectOwner{Name: rows[0].Name, Email: rows[0].Email}, nil
+}
+
+func projectOwnerQuery(projectID uuid.UUID) (string, []interface{}) {
+	return `SELECT u.name, u.email
+FROM projects p
+JOIN users u ON u.id = p.user_id
+WHERE p.id = ? AND p.deleted_at IS NULL AND u.deleted_at IS NULL`, []interface{}{projectID}
+}
@@ -31,6 +31,10 @@ func (r *MockProjectRepository) FindByCustomer(ctx context.Context, customerID u
 	return nil, fmt.Errorf("not implemented")
 }
 
+func (r *MockProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return fmt.Errorf("not implemented")
+}
+
 func (r *MockProjectRepository) FindByTenantWithFilters(ctx context.Context, filters project.ProjectFilters) ([]*project.Project, int64, error) {
 	return nil, 0, fmt.Errorf("not implemented")
 }
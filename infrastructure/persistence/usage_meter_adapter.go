@@ -77,7 +77,7 @@ func (a *UsageMeterRepositoryAdapter) FindByMetricName(ctx context.Context, bill
 // FindByStripeMeterID busca usage meter pelo Stripe Meter ID
 func (a *UsageMeterRepositoryAdapter) FindByStripeMeterID(ctx context.Context, stripeMeterID string) (*billing.UsageMeter, error) {
 	var entity entities.UsageMeterEntity
-	err := a.gormRepo.db.WithContext(ctx).
+	err := a.gormRepo.getDB(ctx).
 		Where("stripe_meter_id = ?", stripeMeterID).
 		First(&entity).Error
 	if err != nil {
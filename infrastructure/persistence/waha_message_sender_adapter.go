@@ -60,6 +60,12 @@ func (a *WAHAMessageSenderAdapter) SendMessage(ctx context.Context, msg *message
 		return a.failResult(msg.ID, err), fmt.Errorf("failed to find channel: %w", err)
 	}
 
+	// Canal pausado (conta suspensa pela régua de cobrança) não envia nada
+	if ch.IsPaused() {
+		err := fmt.Errorf("channel %s is paused", ch.ID)
+		return a.failResult(msg.ID, err), err
+	}
+
 	if ch.Type != channel.TypeWAHA && ch.Type != channel.TypeWhatsAppBusiness {
 		err := fmt.Errorf("channel type %s is not supported for WAHA sending", ch.Type)
 		return a.failResult(msg.ID, err), err
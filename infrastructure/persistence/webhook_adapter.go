@@ -138,13 +138,15 @@ func (a *WebhookRepositoryAdapter) Delete(ctx context.Context, id uuid.UUID) err
 
 // RecordTrigger atualiza estatísticas de disparo do webhook
 func (a *WebhookRepositoryAdapter) RecordTrigger(ctx context.Context, id uuid.UUID, success bool) error {
-	w, err := a.FindByID(ctx, id)
-	if err != nil {
-		return err
-	}
+	return a.gormRepo.RecordTrigger(ctx, id, success)
+}
 
-	w.RecordTrigger(success)
-	return a.Update(ctx, w)
+// AutoDisable desativa o webhook após maxConsecutiveFailures falhas seguidas (limite <= 0 desliga a regra)
+func (a *WebhookRepositoryAdapter) AutoDisable(ctx context.Context, id uuid.UUID, maxConsecutiveFailures int) (bool, error) {
+	if maxConsecutiveFailures <= 0 {
+		return false, nil
+	}
+	return a.gormRepo.AutoDisable(ctx, id, maxConsecutiveFailures, webhook.AutoDisableReason(maxConsecutiveFailures))
 }
 
 // domainToEntity converte webhook.WebhookSubscription para entities.WebhookSubscriptionEntity
@@ -164,25 +166,31 @@ func (a *WebhookRepositoryAdapter) domainToEntity(w *webhook.WebhookSubscription
 	copy(events, w.Events)
 
 	return &entities.WebhookSubscriptionEntity{
-		ID:              w.ID,
-		UserID:          w.UserID,
-		ProjectID:       w.ProjectID,
-		TenantID:        w.TenantID,
-		Name:            w.Name,
-		URL:             w.URL,
-		Events:          events,
-		Active:          w.Active,
-		Secret:          w.Secret,
-		Headers:         headersBytes,
-		RetryCount:      w.RetryCount,
-		TimeoutSeconds:  w.TimeoutSeconds,
-		LastTriggeredAt: w.LastTriggeredAt,
-		LastSuccessAt:   w.LastSuccessAt,
-		LastFailureAt:   w.LastFailureAt,
-		SuccessCount:    w.SuccessCount,
-		FailureCount:    w.FailureCount,
-		CreatedAt:       w.CreatedAt,
-		UpdatedAt:       w.UpdatedAt,
+		ID:                  w.ID,
+		UserID:              w.UserID,
+		ProjectID:           w.ProjectID,
+		TenantID:            w.TenantID,
+		Name:                w.Name,
+		URL:                 w.URL,
+		Events:              events,
+		Active:              w.Active,
+		Secret:              w.Secret,
+		Headers:             headersBytes,
+		RetryCount:          w.RetryCount,
+		TimeoutSeconds:      w.TimeoutSeconds,
+		LastTriggeredAt:     w.LastTriggeredAt,
+		LastSuccessAt:       w.LastSuccessAt,
+		LastFailureAt:       w.LastFailureAt,
+		SuccessCount:        w.SuccessCount,
+		FailureCount:        w.FailureCount,
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		DisabledAt:          w.DisabledAt,
+		DisabledReason:      w.DisabledReason,
+		CreatedAt:           w.CreatedAt,
+		UpdatedAt:           w.UpdatedAt,
+
+		PreviousSecret:          w.PreviousSecret,
+		PreviousSecretExpiresAt: w.PreviousSecretExpiresAt,
 	}, nil
 }
 
@@ -202,24 +210,30 @@ func (a *WebhookRepositoryAdapter) entityToDomain(e *entities.WebhookSubscriptio
 	copy(events, e.Events)
 
 	return &webhook.WebhookSubscription{
-		ID:              e.ID,
-		UserID:          e.UserID,
-		ProjectID:       e.ProjectID,
-		TenantID:        e.TenantID,
-		Name:            e.Name,
-		URL:             e.URL,
-		Events:          events,
-		Active:          e.Active,
-		Secret:          e.Secret,
-		Headers:         headers,
-		RetryCount:      e.RetryCount,
-		TimeoutSeconds:  e.TimeoutSeconds,
-		LastTriggeredAt: e.LastTriggeredAt,
-		LastSuccessAt:   e.LastSuccessAt,
-		LastFailureAt:   e.LastFailureAt,
-		SuccessCount:    e.SuccessCount,
-		FailureCount:    e.FailureCount,
-		CreatedAt:       e.CreatedAt,
-		UpdatedAt:       e.UpdatedAt,
+		ID:                  e.ID,
+		UserID:              e.UserID,
+		ProjectID:           e.ProjectID,
+		TenantID:            e.TenantID,
+		Name:                e.Name,
+		URL:                 e.URL,
+		Events:              events,
+		Active:              e.Active,
+		Secret:              e.Secret,
+		Headers:             headers,
+		RetryCount:          e.RetryCount,
+		TimeoutSeconds:      e.TimeoutSeconds,
+		LastTriggeredAt:     e.LastTriggeredAt,
+		LastSuccessAt:       e.LastSuccessAt,
+		LastFailureAt:       e.LastFailureAt,
+		SuccessCount:        e.SuccessCount,
+		FailureCount:        e.FailureCount,
+		ConsecutiveFailures: e.ConsecutiveFailures,
+		DisabledAt:          e.DisabledAt,
+		DisabledReason:      e.DisabledReason,
+		CreatedAt:           e.CreatedAt,
+		UpdatedAt:           e.UpdatedAt,
+
+		PreviousSecret:          e.PreviousSecret,
+		PreviousSecretExpiresAt: e.PreviousSecretExpiresAt,
 	}, nil
 }
@@ -215,6 +215,7 @@ type MeterEventParams struct {
 	StripeCustomerID string                 // Customer ID
 	Value            int64                  // Quantidade de uso
 	Timestamp        *time.Time             // Timestamp (opcional, default: now)
+	Identifier       string                 // Chave de idempotência (opcional): o Stripe ignora repetições
 	Payload          map[string]interface{} // Payload adicional
 }
 
@@ -252,6 +253,10 @@ func (s *Service) ReportUsage(ctx context.Context, params MeterEventParams) erro
 		},
 	}
 
+	if params.Identifier != "" {
+		payload["identifier"] = params.Identifier
+	}
+
 	// Adiciona timestamp se fornecido
 	if params.Timestamp != nil {
 		payload["payload"].(map[string]interface{})["timestamp"] = params.Timestamp.Unix()
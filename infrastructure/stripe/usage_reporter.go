@@ -0,0 +1,27 @@
+package stripe
+
+import (
+	"context"
+
+	billingapp "github.com/ventros/crm/internal/application/billing"
+)
+
+// UsageReporter envia o consumo agregado aos Billing Meters do Stripe
+type UsageReporter struct {
+	service *Service
+}
+
+func NewUsageReporter(service *Service) *UsageReporter {
+	return &UsageReporter{service: service}
+}
+
+func (r *UsageReporter) ReportMeterEvent(ctx context.Context, event billingapp.MeterEvent) error {
+	timestamp := event.Timestamp
+	return r.service.ReportUsage(ctx, MeterEventParams{
+		EventName:        event.EventName,
+		StripeCustomerID: event.StripeCustomerID,
+		Value:            event.Value,
+		Timestamp:        &timestamp,
+		Identifier:       event.Identifier,
+	})
+}
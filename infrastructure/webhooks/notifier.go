@@ -12,27 +12,48 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+	webhookapp "github.com/ventros/crm/internal/application/webhook"
 	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"github.com/ventros/crm/pkg/webhooksig"
 	"go.uber.org/zap"
 )
 
+// LegacySignatureHeader assinatura antiga: HMAC-SHA256 hexadecimal só do corpo, com o segredo atual
+// (sem timestamp, não protege contra replay). Depreciada em favor do Ventros-Signature: só vai
+// com WEBHOOK_LEGACY_SIGNATURE ligado e sai de vez em LegacySignatureSunset.
+const LegacySignatureHeader = "X-Webhook-Signature"
+
+// LegacySignatureSunset data a partir da qual o X-Webhook-Signature deixa de ser enviado
+const LegacySignatureSunset = "2027-01-31"
+
 type WebhookNotifier struct {
-	logger     *zap.Logger
-	repo       webhook.Repository
-	httpClient *http.Client
+	logger          *zap.Logger
+	repo            webhook.Repository
+	recorder        *webhookapp.DeliveryRecorder
+	httpClient      *http.Client
+	legacySignature bool
 }
 
-func NewWebhookNotifier(logger *zap.Logger, repo webhook.Repository) *WebhookNotifier {
+// NewWebhookNotifier cria o notifier; cada tentativa é registrada pelo recorder no log de entregas
+func NewWebhookNotifier(logger *zap.Logger, repo webhook.Repository, recorder *webhookapp.DeliveryRecorder) *WebhookNotifier {
 	return &WebhookNotifier{
-		logger: logger,
-		repo:   repo,
+		logger:   logger,
+		repo:     repo,
+		recorder: recorder,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
 }
 
+// SetLegacySignature liga o envio do X-Webhook-Signature junto do Ventros-Signature
+func (n *WebhookNotifier) SetLegacySignature(enabled bool) {
+	n.legacySignature = enabled
+}
+
 type WebhookPayload struct {
+	ID        uuid.UUID   `json:"id"` // Mesmo valor em retries e reenvios (idempotência no receptor)
 	Event     string      `json:"event"`
 	Timestamp time.Time   `json:"timestamp"`
 	Data      interface{} `json:"data"`
@@ -59,6 +80,7 @@ func (n *WebhookNotifier) NotifyWebhooks(ctx context.Context, eventType string,
 
 	// Prepare payload
 	payload := WebhookPayload{
+		ID:        uuid.New(),
 		Event:     eventType,
 		Timestamp: time.Now().UTC(),
 		Data:      eventData,
@@ -70,6 +92,17 @@ func (n *WebhookNotifier) NotifyWebhooks(ctx context.Context, eventType string,
 	}
 }
 
+// Redeliver reenvia o corpo de uma entrega registrada (mesmo id de notificação) uma única vez,
+// sem retries. A tentativa entra no log e conta para as estatísticas da inscrição.
+func (n *WebhookNotifier) Redeliver(ctx context.Context, sub *webhook.WebhookSubscription, original *webhook.Delivery) *webhook.Delivery {
+	delivery := n.send(ctx, sub, original.EventID, []byte(original.RequestBody), func(headers http.Header) *webhook.Delivery {
+		return webhook.NewReplay(sub, original, headers)
+	})
+	n.recorder.Record(ctx, delivery)
+	n.recorder.RecordOutcome(ctx, sub, delivery.Success, delivery.Error)
+	return delivery
+}
+
 func (n *WebhookNotifier) notifyWebhook(sub *webhook.WebhookSubscription, payload WebhookPayload) {
 	ctx := context.Background()
 	start := time.Now()
@@ -85,7 +118,7 @@ func (n *WebhookNotifier) notifyWebhook(sub *webhook.WebhookSubscription, payloa
 	}
 
 	// Try up to retry_count times
-	var lastErr error
+	var lastErr string
 	for attempt := 0; attempt < sub.RetryCount; attempt++ {
 		if attempt > 0 {
 			// Exponential backoff
@@ -97,84 +130,95 @@ func (n *WebhookNotifier) notifyWebhook(sub *webhook.WebhookSubscription, payloa
 			)
 		}
 
-		err := n.sendWebhook(sub, payloadBytes)
-		if err == nil {
+		attemptNumber := attempt + 1
+		delivery := n.send(ctx, sub, payload.ID, payloadBytes, func(headers http.Header) *webhook.Delivery {
+			return webhook.NewDelivery(sub, payload.ID, payload.Event, webhook.DeliveryTriggerEvent, attemptNumber, headers, payloadBytes)
+		})
+		n.recorder.Record(ctx, delivery)
+
+		if delivery.Success {
 			// Success
 			duration := time.Since(start)
-			if err := n.repo.RecordTrigger(ctx, sub.ID, true); err != nil {
-				n.logger.Error("Failed to record success", zap.Error(err))
-			}
+			n.recorder.RecordOutcome(ctx, sub, true, "")
 			n.logger.Info("Webhook sent successfully",
 				zap.String("webhook_id", sub.ID.String()),
 				zap.String("webhook_name", sub.Name),
 				zap.String("event", payload.Event),
 				zap.Duration("duration", duration),
-				zap.Int("attempts", attempt+1),
+				zap.Int("attempts", attemptNumber),
 			)
 			return
 		}
 
-		lastErr = err
+		lastErr = delivery.Error
 	}
 
 	// All retries failed
 	duration := time.Since(start)
-	if err := n.repo.RecordTrigger(ctx, sub.ID, false); err != nil {
-		n.logger.Error("Failed to record failure", zap.Error(err))
-	}
+	n.recorder.RecordOutcome(ctx, sub, false, lastErr)
 	n.logger.Error("Webhook failed after retries",
 		zap.String("webhook_id", sub.ID.String()),
 		zap.String("webhook_name", sub.Name),
 		zap.String("event", payload.Event),
-		zap.Error(lastErr),
+		zap.String("error", lastErr),
 		zap.Duration("duration", duration),
 		zap.Int("attempts", sub.RetryCount),
 	)
 }
 
-func (n *WebhookNotifier) sendWebhook(sub *webhook.WebhookSubscription, payloadBytes []byte) error {
+// send faz uma tentativa de entrega; newDelivery recebe os headers efetivamente enviados
+func (n *WebhookNotifier) send(ctx context.Context, sub *webhook.WebhookSubscription, eventID uuid.UUID, payloadBytes []byte, newDelivery func(http.Header) *webhook.Delivery) *webhook.Delivery {
 	// Create request
 	req, err := http.NewRequest("POST", sub.URL, bytes.NewBuffer(payloadBytes))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		delivery := newDelivery(http.Header{})
+		delivery.RecordError(fmt.Errorf("failed to create request: %w", err), 0)
+		return delivery
 	}
 
 	// Set headers
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("User-Agent", "Ventros-CRM-Webhook/1.0")
+	req.Header.Set("X-Webhook-Event-ID", eventID.String())
 
 	// Add custom headers
 	for key, value := range sub.Headers {
 		req.Header.Set(key, value)
 	}
 
-	// Add HMAC signature if secret is provided
-	if sub.Secret != "" {
-		signature := n.generateHMAC(payloadBytes, sub.Secret)
-		req.Header.Set("X-Webhook-Signature", signature)
+	// Assinatura v2 (Ventros-Signature): HMAC de "t.corpo" com cada segredo ativo (dois durante a rotação).
+	// t é renovado a cada tentativa, então o receptor pode rejeitar requisições antigas (replay).
+	signedAt := time.Now()
+	if secrets := sub.SigningSecrets(signedAt); len(secrets) > 0 {
+		req.Header.Set(webhooksig.HeaderName, webhooksig.Sign(payloadBytes, signedAt, secrets...))
+
+		// Legado: HMAC apenas do corpo com o segredo atual, para receptores que ainda não migraram
+		if n.legacySignature {
+			req.Header.Set(LegacySignatureHeader, n.generateHMAC(payloadBytes, sub.Secret))
+		}
 	}
 
+	delivery := newDelivery(req.Header)
+
 	// Set timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(sub.TimeoutSeconds)*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, time.Duration(sub.TimeoutSeconds)*time.Second)
 	defer cancel()
 	req = req.WithContext(ctx)
 
 	// Send request
+	start := time.Now()
 	resp, err := n.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		delivery.RecordError(fmt.Errorf("failed to send request: %w", err), time.Since(start))
+		return delivery
 	}
 	defer resp.Body.Close()
 
-	// Read response body for logging
-	bodyBytes, _ := io.ReadAll(resp.Body)
-
-	// Check status code
-	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, string(bodyBytes))
-	}
+	// Read response body for logging (o log guarda no máximo MaxLoggedResponseBytes)
+	bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, webhook.MaxLoggedResponseBytes+1))
+	delivery.RecordResponse(resp.StatusCode, bodyBytes, time.Since(start))
 
-	return nil
+	return delivery
 }
 
 func (n *WebhookNotifier) generateHMAC(payload []byte, secret string) string {
@@ -0,0 +1,53 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"github.com/ventros/crm/pkg/webhooksig"
+	"go.uber.org/zap"
+)
+
+func TestWebhookNotifier_LegacySignature(t *testing.T) {
+	var received http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header.Clone()
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	sub := &webhook.WebhookSubscription{ID: uuid.New(), URL: server.URL, Secret: "whsec_legacy_secret_value", TimeoutSeconds: 5}
+	payload := []byte(`{"event":"contact.created"}`)
+	send := func(n *WebhookNotifier) {
+		delivery := n.send(context.Background(), sub, uuid.New(), payload, func(headers http.Header) *webhook.Delivery {
+			return webhook.NewDelivery(sub, uuid.New(), "contact.created", webhook.DeliveryTriggerEvent, 1, headers, payload)
+		})
+		require.True(t, delivery.Success, delivery.Error)
+		require.Equal(t, payload, body)
+		require.NoError(t, webhooksig.Verify(payload, received.Get(webhooksig.HeaderName), sub.Secret, webhooksig.DefaultTolerance))
+	}
+
+	n := NewWebhookNotifier(zap.NewNop(), nil, nil)
+	send(n)
+	assert.Empty(t, received.Get(LegacySignatureHeader))
+
+	n.SetLegacySignature(true)
+	send(n)
+	// HMAC-SHA256 do corpo com o segredo atual, como os receptores antigos verificam
+	mac := hmac.New(sha256.New, []byte(sub.Secret))
+	mac.Write(payload)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), received.Get(LegacySignatureHeader))
+	assert.NotEmpty(t, received.Get(webhooksig.HeaderName))
+}
@@ -2,11 +2,16 @@ package websocket
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/websocket"
+	cannedresponseapp "github.com/ventros/crm/internal/application/cannedresponse"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
 	"go.uber.org/zap"
 )
 
@@ -24,6 +29,18 @@ const (
 	maxMessageSize = 1024 * 1024
 )
 
+// ErrPermissionDenied o papel atual do usuário no projeto não permite a ação
+var ErrPermissionDenied = errors.New("permission denied")
+
+// ErrAccountRestricted a conta de cobrança do projeto está em modo somente leitura ou suspensa
+var ErrAccountRestricted = errors.New("account restricted")
+
+// Authorizer reavalia, antes de cada ação, se o cliente ainda tem a permissão no projeto
+type Authorizer func(ctx context.Context, permission project_member.Permission) error
+
+// StandingChecker reavalia, antes de cada escrita, se a conta de cobrança do projeto aceita escritas
+type StandingChecker func(ctx context.Context) error
+
 // Client representa um cliente WebSocket conectado
 type Client struct {
 	hub  *Hub
@@ -40,6 +57,14 @@ type Client struct {
 	sessions map[uuid.UUID]bool
 	mu       sync.RWMutex
 
+	// Último registro de atividade na presença (unix nano)
+	lastActivityReport atomic.Int64
+
+	// Checagem de permissão por ação (sem authorizer nenhuma ação é permitida)
+	authorizer Authorizer
+	// Situação de cobrança do projeto, exigida nas escritas (sem checker as escritas seguem)
+	standing StandingChecker
+
 	logger *zap.Logger
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -64,6 +89,53 @@ func NewClient(hub *Hub, conn *websocket.Conn, userID uuid.UUID, tenantID string
 	}
 }
 
+// SetAuthorizer define a checagem de permissão usada nas ações do cliente
+func (c *Client) SetAuthorizer(authorizer Authorizer) {
+	c.authorizer = authorizer
+}
+
+// SetStandingChecker define a checagem da situação de cobrança usada nas escritas do cliente
+func (c *Client) SetStandingChecker(standing StandingChecker) {
+	c.standing = standing
+}
+
+// checkStanding bloqueia escritas quando a conta do projeto está restrita por fatura em atraso
+func (c *Client) checkStanding() error {
+	if c.standing == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	if err := c.standing(ctx); err != nil {
+		c.logger.Warn("WebSocket write blocked by billing standing",
+			zap.String("client_id", c.id),
+			zap.Error(err))
+		return fmt.Errorf("%w: %v", ErrAccountRestricted, err)
+	}
+	return nil
+}
+
+// authorize exige a permissão no projeto da conexão
+func (c *Client) authorize(permission project_member.Permission) error {
+	if c.authorizer == nil {
+		return fmt.Errorf("%w: %s", ErrPermissionDenied, permission)
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, 5*time.Second)
+	defer cancel()
+
+	if err := c.authorizer(ctx, permission); err != nil {
+		c.logger.Warn("WebSocket action denied",
+			zap.String("client_id", c.id),
+			zap.String("permission", string(permission)),
+			zap.Error(err))
+		return fmt.Errorf("%w: %s", ErrPermissionDenied, permission)
+	}
+	return nil
+}
+
 // ReadPump bombeia mensagens do cliente WebSocket para o hub
 func (c *Client) ReadPump() {
 	defer func() {
@@ -96,7 +168,14 @@ func (c *Client) ReadPump() {
 				zap.Error(err))
 
 			// Enviar erro para o cliente
-			errorMsg := NewErrorMessage("message_error", err.Error())
+			code := "message_error"
+			switch {
+			case errors.Is(err, ErrPermissionDenied):
+				code = "forbidden"
+			case errors.Is(err, ErrAccountRestricted):
+				code = "account_restricted"
+			}
+			errorMsg := NewErrorMessage(code, err.Error())
 			c.SendMessage(errorMsg)
 		}
 	}
@@ -161,6 +240,9 @@ func (c *Client) handleMessage(data []byte) error {
 		zap.String("client_id", c.id),
 		zap.String("message_type", string(msg.Type)))
 
+	// Ping é só keep-alive; qualquer outra mensagem é interação do agente
+	c.hub.RecordActivity(c, msg.Type != MessageTypePing)
+
 	// Processar por tipo
 	switch msg.Type {
 	case MessageTypeJoinSession:
@@ -173,6 +255,10 @@ func (c *Client) handleMessage(data []byte) error {
 		return c.handleTyping(msg)
 	case MessageTypePing:
 		return c.handlePing()
+	case MessageTypeQueuePickNext:
+		return c.handleQueuePickNext()
+	case MessageTypeCannedResponseExpand:
+		return c.handleCannedResponseExpand(msg)
 	default:
 		c.logger.Warn("Unknown message type",
 			zap.String("type", string(msg.Type)))
@@ -187,6 +273,9 @@ func (c *Client) handleJoinSession(msg *WSMessage) error {
 	if err := msg.ParsePayload(&payload); err != nil {
 		return err
 	}
+	if err := c.authorize(project_member.PermissionViewSessions); err != nil {
+		return err
+	}
 
 	c.mu.Lock()
 	c.sessions[payload.SessionID] = true
@@ -227,8 +316,13 @@ func (c *Client) handleSendMessage(msg *WSMessage) error {
 	// Sanitizar texto (anti-XSS)
 	payload.Text = SanitizeText(payload.Text)
 
-	// SECURITY: Validar que usuário tem acesso a essa sessão
-	// TODO: Implementar validação de permissão
+	// SECURITY: papel atual no projeto precisa permitir envio
+	if err := c.authorize(project_member.PermissionSendMessages); err != nil {
+		return err
+	}
+	if err := c.checkStanding(); err != nil {
+		return err
+	}
 
 	// Publicar mensagem via hub
 	c.hub.HandleSendMessage(c, payload)
@@ -242,6 +336,9 @@ func (c *Client) handleTyping(msg *WSMessage) error {
 	if err := msg.ParsePayload(&payload); err != nil {
 		return err
 	}
+	if err := c.authorize(project_member.PermissionSendMessages); err != nil {
+		return err
+	}
 
 	// Broadcast para outros clientes na mesma sessão
 	c.hub.BroadcastToSession(payload.SessionID, NewWSMessage(MessageTypeUserTyping, payload), c.id)
@@ -249,6 +346,69 @@ func (c *Client) handleTyping(msg *WSMessage) error {
 	return nil
 }
 
+// handleQueuePickNext atribui ao agente a próxima sessão das suas filas e já o inscreve nela
+func (c *Client) handleQueuePickNext() error {
+	if c.hub.queues == nil {
+		return errors.New("queues are not available")
+	}
+	if err := c.authorize(project_member.PermissionManageSessions); err != nil {
+		return err
+	}
+	if err := c.checkStanding(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := c.hub.queues.PickNext(ctx, c.tenantID, c.userID)
+	if err != nil {
+		return err
+	}
+
+	if result.Picked {
+		c.mu.Lock()
+		c.sessions[result.SessionID] = true
+		c.mu.Unlock()
+	}
+
+	c.SendMessage(NewWSMessage(MessageTypeQueueSessionPicked, result))
+	return nil
+}
+
+// handleCannedResponseExpand expande o atalho digitado pelo agente com os dados da sessão/contato
+func (c *Client) handleCannedResponseExpand(msg *WSMessage) error {
+	if c.hub.cannedResponses == nil {
+		return errors.New("canned responses are not available")
+	}
+
+	var payload CannedResponseExpandPayload
+	if err := msg.ParsePayload(&payload); err != nil {
+		return err
+	}
+	if err := c.authorize(project_member.PermissionViewMessages); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(c.ctx, 10*time.Second)
+	defer cancel()
+
+	result, err := c.hub.cannedResponses.Expand(ctx, cannedresponseapp.ExpandCommand{
+		TenantID:  c.tenantID,
+		UserID:    c.userID,
+		ProjectID: c.projectID,
+		Shortcut:  payload.Shortcut,
+		SessionID: payload.SessionID,
+		ContactID: payload.ContactID,
+	})
+	if err != nil {
+		return err
+	}
+
+	c.SendMessage(NewWSMessage(MessageTypeCannedResponseExpanded, result))
+	return nil
+}
+
 // handlePing responde ao ping
 func (c *Client) handlePing() error {
 	c.SendMessage(NewWSMessage(MessageTypePong, nil))
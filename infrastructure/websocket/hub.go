@@ -8,6 +8,8 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
+	cannedresponseapp "github.com/ventros/crm/internal/application/cannedresponse"
+	teamapp "github.com/ventros/crm/internal/application/team"
 	"go.uber.org/zap"
 )
 
@@ -33,6 +35,15 @@ type Hub struct {
 	// Message handler (integração com domain)
 	messageHandler MessageHandler
 
+	// Presença dos agentes (opcional): heartbeats das conexões deste servidor
+	presence PresenceTracker
+
+	// Filas de atendimento (opcional): queue_pick_next dos agentes
+	queues QueuePicker
+
+	// Respostas prontas (opcional): canned_response_expand do compositor
+	cannedResponses CannedResponseExpander
+
 	logger *zap.Logger
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -51,6 +62,29 @@ type MessageHandler interface {
 	SendMessage(ctx context.Context, userID uuid.UUID, payload SendMessagePayload) (*NewMessagePayload, error)
 }
 
+// PresenceTracker registra que um usuário tem conexão viva; activity indica interação real
+type PresenceTracker interface {
+	Heartbeat(ctx context.Context, tenantID string, userID uuid.UUID, activity bool) error
+}
+
+// QueuePicker atribui ao usuário a próxima sessão aguardando nas filas dos seus times
+type QueuePicker interface {
+	PickNext(ctx context.Context, tenantID string, userID uuid.UUID) (*teamapp.PickResult, error)
+}
+
+// CannedResponseExpander resolve um atalho de resposta pronta e renderiza as variáveis
+type CannedResponseExpander interface {
+	Expand(ctx context.Context, cmd cannedresponseapp.ExpandCommand) (*cannedresponseapp.RenderedResponse, error)
+}
+
+const (
+	// presenceHeartbeatInterval intervalo dos heartbeats de todas as conexões abertas
+	presenceHeartbeatInterval = 30 * time.Second
+
+	// presenceActivityThrottle intervalo mínimo entre registros de atividade da mesma conexão
+	presenceActivityThrottle = 20 * time.Second
+)
+
 // NewHub cria novo Hub WebSocket
 func NewHub(redis *redis.Client, messageHandler MessageHandler, logger *zap.Logger) *Hub {
 	ctx, cancel := context.WithCancel(context.Background())
@@ -69,6 +103,21 @@ func NewHub(redis *redis.Client, messageHandler MessageHandler, logger *zap.Logg
 	}
 }
 
+// SetPresenceTracker liga as conexões à presença dos agentes. Deve ser chamado antes de Run.
+func (h *Hub) SetPresenceTracker(tracker PresenceTracker) {
+	h.presence = tracker
+}
+
+// SetQueuePicker habilita o queue_pick_next. Deve ser chamado antes de Run.
+func (h *Hub) SetQueuePicker(picker QueuePicker) {
+	h.queues = picker
+}
+
+// SetCannedResponses habilita o canned_response_expand. Deve ser chamado antes de Run.
+func (h *Hub) SetCannedResponses(expander CannedResponseExpander) {
+	h.cannedResponses = expander
+}
+
 // Run inicia o hub
 func (h *Hub) Run() {
 	// Iniciar Redis Pub/Sub
@@ -76,6 +125,10 @@ func (h *Hub) Run() {
 		h.startRedisPubSub()
 	}
 
+	if h.presence != nil {
+		go h.runPresenceHeartbeats()
+	}
+
 	for {
 		select {
 		case client := <-h.Register:
@@ -112,6 +165,9 @@ func (h *Hub) registerClient(client *Client) {
 		Timestamp: time.Now(),
 	})
 	client.SendMessage(welcomeMsg)
+
+	// Conectar conta como atividade: o agente acabou de abrir o app
+	h.RecordActivity(client, true)
 }
 
 // unregisterClient remove cliente
@@ -224,6 +280,11 @@ func (h *Hub) handleRedisMessage(payload string) {
 		return
 	}
 
+	if len(redisMsg.UserIDs) > 0 {
+		h.sendToLocalUsers(redisMsg.UserIDs, redisMsg.WSMessage)
+		return
+	}
+
 	// Broadcast para clientes locais
 	h.broadcastMessage(&BroadcastMessage{
 		SessionID: redisMsg.SessionID,
@@ -255,9 +316,10 @@ func (h *Hub) publishToRedis(sessionID uuid.UUID, msg *WSMessage) {
 
 // RedisMessage mensagem enviada via Redis Pub/Sub
 type RedisMessage struct {
-	SessionID uuid.UUID  `json:"session_id"`
-	WSMessage *WSMessage `json:"ws_message"`
-	Timestamp time.Time  `json:"timestamp"`
+	SessionID uuid.UUID   `json:"session_id"`
+	UserIDs   []uuid.UUID `json:"user_ids,omitempty"` // entrega direta a usuários, independente da sessão
+	WSMessage *WSMessage  `json:"ws_message"`
+	Timestamp time.Time   `json:"timestamp"`
 }
 
 // GetStats retorna estatísticas do hub
@@ -327,3 +389,114 @@ func (h *Hub) NotifyMessageRead(sessionID, messageID uuid.UUID) {
 		h.publishToRedis(sessionID, wsMsg)
 	}
 }
+
+// SendToUsers envia mensagem para todas as conexões dos usuários, estejam ou não observando a sessão.
+// Com Redis, a entrega acontece ao receber a publicação (inclusive neste servidor), evitando duplicatas.
+func (h *Hub) SendToUsers(userIDs []uuid.UUID, msg *WSMessage) {
+	if len(userIDs) == 0 {
+		return
+	}
+
+	if h.redis == nil {
+		h.sendToLocalUsers(userIDs, msg)
+		return
+	}
+
+	data, err := json.Marshal(RedisMessage{
+		UserIDs:   userIDs,
+		WSMessage: msg,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		h.logger.Error("Failed to marshal Redis message", zap.Error(err))
+		return
+	}
+
+	channel := "websocket:messages"
+	if err := h.redis.Publish(h.ctx, channel, data).Err(); err != nil {
+		h.logger.Error("Failed to publish to Redis",
+			zap.Error(err),
+			zap.String("channel", channel))
+	}
+}
+
+// sendToLocalUsers entrega a mensagem às conexões locais dos usuários
+func (h *Hub) sendToLocalUsers(userIDs []uuid.UUID, msg *WSMessage) {
+	targets := make(map[uuid.UUID]bool, len(userIDs))
+	for _, id := range userIDs {
+		targets[id] = true
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for client := range h.clients {
+		if targets[client.userID] {
+			client.SendMessage(msg)
+		}
+	}
+}
+
+// RecordActivity registra presença do cliente. Atividade real é limitada a um registro
+// por presenceActivityThrottle por conexão; heartbeats periódicos cobrem o resto.
+func (h *Hub) RecordActivity(client *Client, activity bool) {
+	if h.presence == nil || !activity {
+		return
+	}
+
+	now := time.Now()
+	last := client.lastActivityReport.Load()
+	if last != 0 && now.Sub(time.Unix(0, last)) < presenceActivityThrottle {
+		return
+	}
+	if !client.lastActivityReport.CompareAndSwap(last, now.UnixNano()) {
+		return
+	}
+
+	go h.reportPresence(client.tenantID, client.userID, true)
+}
+
+// runPresenceHeartbeats envia heartbeat de cada usuário conectado a este servidor.
+// Conexões encerradas simplesmente param de enviar e expiram pelo timeout da política de presença.
+func (h *Hub) runPresenceHeartbeats() {
+	ticker := time.NewTicker(presenceHeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			type key struct {
+				tenantID string
+				userID   uuid.UUID
+			}
+			users := make(map[key]bool)
+
+			h.mu.RLock()
+			for client := range h.clients {
+				users[key{client.tenantID, client.userID}] = true
+			}
+			h.mu.RUnlock()
+
+			for user := range users {
+				h.reportPresence(user.tenantID, user.userID, false)
+			}
+
+		case <-h.ctx.Done():
+			return
+		}
+	}
+}
+
+func (h *Hub) reportPresence(tenantID string, userID uuid.UUID, activity bool) {
+	if tenantID == "" || userID == uuid.Nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(h.ctx, 5*time.Second)
+	defer cancel()
+
+	if err := h.presence.Heartbeat(ctx, tenantID, userID, activity); err != nil {
+		h.logger.Warn("Failed to record presence heartbeat",
+			zap.String("user_id", userID.String()),
+			zap.Error(err))
+	}
+}
@@ -12,11 +12,13 @@ type MessageType string
 
 const (
 	// Client → Server
-	MessageTypeSendMessage  MessageType = "send_message"  // Enviar nova mensagem
-	MessageTypeTyping       MessageType = "typing"        // Usuário está digitando
-	MessageTypeJoinSession  MessageType = "join_session"  // Entrar em uma sessão
-	MessageTypeLeaveSession MessageType = "leave_session" // Sair de uma sessão
-	MessageTypePing         MessageType = "ping"          // Heartbeat
+	MessageTypeSendMessage          MessageType = "send_message"           // Enviar nova mensagem
+	MessageTypeTyping               MessageType = "typing"                 // Usuário está digitando
+	MessageTypeJoinSession          MessageType = "join_session"           // Entrar em uma sessão
+	MessageTypeLeaveSession         MessageType = "leave_session"          // Sair de uma sessão
+	MessageTypePing                 MessageType = "ping"                   // Heartbeat
+	MessageTypeQueuePickNext        MessageType = "queue_pick_next"        // Puxar a próxima sessão das filas do agente
+	MessageTypeCannedResponseExpand MessageType = "canned_response_expand" // Expandir um atalho de resposta pronta no compositor
 
 	// Server → Client
 	MessageTypeNewMessage  MessageType = "new_message"  // Nova mensagem recebida
@@ -26,6 +28,14 @@ const (
 	MessageTypeError       MessageType = "error"        // Erro
 	MessageTypePong        MessageType = "pong"         // Resposta ao ping
 	MessageTypeConnected   MessageType = "connected"    // Conexão estabelecida
+
+	MessageTypeSessionReassigned      MessageType = "session_reassigned"       // Sessão mudou de agente (regra de reatribuição)
+	MessageTypeAgentPresence          MessageType = "agent_presence"           // Status de um agente mudou (para supervisores)
+	MessageTypeQueueSessionPicked     MessageType = "queue_session_picked"     // Resultado do queue_pick_next
+	MessageTypeQueueUpdated           MessageType = "queue_updated"            // Sessão entrou/saiu de uma fila (membros e supervisores)
+	MessageTypeNoteMention            MessageType = "note_mention"             // Agente foi mencionado em uma nota
+	MessageTypeTaskDue                MessageType = "task_due"                 // Lembrete: prazo de uma tarefa do agente chegou
+	MessageTypeCannedResponseExpanded MessageType = "canned_response_expanded" // Resultado do canned_response_expand
 )
 
 // WSMessage representa uma mensagem WebSocket
@@ -73,6 +83,68 @@ type JoinSessionPayload struct {
 	SessionID uuid.UUID `json:"session_id"`
 }
 
+// SessionReassignedPayload avisa o agente anterior e o novo sobre uma reatribuição
+type SessionReassignedPayload struct {
+	SessionID       uuid.UUID `json:"session_id"`
+	ContactID       uuid.UUID `json:"contact_id"`
+	PreviousAgentID uuid.UUID `json:"previous_agent_id"`
+	NewAgentID      uuid.UUID `json:"new_agent_id"`
+	Trigger         string    `json:"trigger"`
+	Reason          string    `json:"reason"`
+	ReassignedAt    time.Time `json:"reassigned_at"`
+}
+
+// AgentPresencePayload avisa supervisores sobre mudança de status de um agente
+type AgentPresencePayload struct {
+	AgentID        uuid.UUID `json:"agent_id"`
+	Name           string    `json:"name"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	Manual         bool      `json:"manual"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+// QueueUpdatedPayload avisa membros e supervisores do time sobre mudança em uma fila
+type QueueUpdatedPayload struct {
+	QueueID            uuid.UUID  `json:"queue_id"`
+	QueueName          string     `json:"queue_name"`
+	Change             string     `json:"change"`
+	SessionID          uuid.UUID  `json:"session_id"`
+	AgentID            *uuid.UUID `json:"agent_id,omitempty"`
+	Waiting            int        `json:"waiting"`
+	LongestWaitSeconds int64      `json:"longest_wait_seconds"`
+	At                 time.Time  `json:"at"`
+}
+
+// NoteMentionPayload avisa o agente mencionado em uma nota
+type NoteMentionPayload struct {
+	NoteID     uuid.UUID  `json:"note_id"`
+	ContactID  uuid.UUID  `json:"contact_id"`
+	SessionID  *uuid.UUID `json:"session_id,omitempty"`
+	AuthorID   uuid.UUID  `json:"author_id"`
+	AuthorName string     `json:"author_name"`
+	Preview    string     `json:"preview"`
+	At         time.Time  `json:"at"`
+}
+
+// TaskDuePayload lembrete do prazo de uma tarefa ao responsável
+type TaskDuePayload struct {
+	TaskID    uuid.UUID  `json:"task_id"`
+	Title     string     `json:"title"`
+	Priority  string     `json:"priority"`
+	DueAt     time.Time  `json:"due_at"`
+	Timezone  string     `json:"timezone"`
+	ContactID *uuid.UUID `json:"contact_id,omitempty"`
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+}
+
+// CannedResponseExpandPayload atalho digitado no compositor. Sem sessão/contato, vale o projeto da conexão
+type CannedResponseExpandPayload struct {
+	Shortcut  string     `json:"shortcut"`
+	SessionID *uuid.UUID `json:"session_id,omitempty"`
+	ContactID *uuid.UUID `json:"contact_id,omitempty"`
+}
+
 // ErrorPayload para erros
 type ErrorPayload struct {
 	Code    string `json:"code"`
@@ -0,0 +1,29 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	noteapp "github.com/ventros/crm/internal/application/note"
+)
+
+// NoteMentionNotifier entrega por websocket as menções em notas aos usuários dos agentes mencionados
+type NoteMentionNotifier struct {
+	hub *Hub
+}
+
+func NewNoteMentionNotifier(hub *Hub) *NoteMentionNotifier {
+	return &NoteMentionNotifier{hub: hub}
+}
+
+func (n *NoteMentionNotifier) NotifyMention(ctx context.Context, userIDs []uuid.UUID, mention noteapp.Mention) {
+	n.hub.SendToUsers(userIDs, NewWSMessage(MessageTypeNoteMention, NoteMentionPayload{
+		NoteID:     mention.NoteID,
+		ContactID:  mention.ContactID,
+		SessionID:  mention.SessionID,
+		AuthorID:   mention.AuthorID,
+		AuthorName: mention.AuthorName,
+		Preview:    mention.Preview,
+		At:         mention.At,
+	}))
+}
@@ -0,0 +1,28 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	agentapp "github.com/ventros/crm/internal/application/agent"
+)
+
+// AgentPresenceNotifier envia por websocket as mudanças de status dos agentes aos supervisores do tenant
+type AgentPresenceNotifier struct {
+	hub *Hub
+}
+
+func NewAgentPresenceNotifier(hub *Hub) *AgentPresenceNotifier {
+	return &AgentPresenceNotifier{hub: hub}
+}
+
+func (n *AgentPresenceNotifier) NotifyPresenceChanged(ctx context.Context, supervisorUserIDs []uuid.UUID, change agentapp.PresenceChange) {
+	n.hub.SendToUsers(supervisorUserIDs, NewWSMessage(MessageTypeAgentPresence, AgentPresencePayload{
+		AgentID:        change.AgentID,
+		Name:           change.Name,
+		PreviousStatus: string(change.PreviousStatus),
+		Status:         string(change.Status),
+		Manual:         change.Manual,
+		ChangedAt:      change.ChangedAt,
+	}))
+}
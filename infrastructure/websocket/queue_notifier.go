@@ -0,0 +1,30 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	teamapp "github.com/ventros/crm/internal/application/team"
+)
+
+// QueueNotifier envia por websocket as mudanças das filas aos membros e supervisores do time
+type QueueNotifier struct {
+	hub *Hub
+}
+
+func NewQueueNotifier(hub *Hub) *QueueNotifier {
+	return &QueueNotifier{hub: hub}
+}
+
+func (n *QueueNotifier) NotifyQueueUpdated(ctx context.Context, userIDs []uuid.UUID, update teamapp.QueueUpdate) {
+	n.hub.SendToUsers(userIDs, NewWSMessage(MessageTypeQueueUpdated, QueueUpdatedPayload{
+		QueueID:            update.QueueID,
+		QueueName:          update.QueueName,
+		Change:             string(update.Change),
+		SessionID:          update.SessionID,
+		AgentID:            update.AgentID,
+		Waiting:            update.Waiting,
+		LongestWaitSeconds: int64(update.LongestWait.Seconds()),
+		At:                 update.At,
+	}))
+}
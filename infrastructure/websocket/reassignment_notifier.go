@@ -0,0 +1,36 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	routingapp "github.com/ventros/crm/internal/application/routing"
+)
+
+// SessionReassignmentNotifier avisa por websocket o agente que perdeu a sessão e o que a recebeu
+type SessionReassignmentNotifier struct {
+	hub *Hub
+}
+
+func NewSessionReassignmentNotifier(hub *Hub) *SessionReassignmentNotifier {
+	return &SessionReassignmentNotifier{hub: hub}
+}
+
+func (n *SessionReassignmentNotifier) NotifySessionReassigned(ctx context.Context, notice routingapp.ReassignmentNotice) {
+	var userIDs []uuid.UUID
+	for _, id := range []*uuid.UUID{notice.PreviousUserID, notice.NewUserID} {
+		if id != nil && *id != uuid.Nil {
+			userIDs = append(userIDs, *id)
+		}
+	}
+
+	n.hub.SendToUsers(userIDs, NewWSMessage(MessageTypeSessionReassigned, SessionReassignedPayload{
+		SessionID:       notice.SessionID,
+		ContactID:       notice.ContactID,
+		PreviousAgentID: notice.PreviousAgentID,
+		NewAgentID:      notice.NewAgentID,
+		Trigger:         string(notice.Trigger),
+		Reason:          notice.Reason,
+		ReassignedAt:    notice.ReassignedAt,
+	}))
+}
@@ -0,0 +1,29 @@
+package websocket
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	taskapp "github.com/ventros/crm/internal/application/task"
+)
+
+// TaskReminderNotifier entrega por websocket os lembretes de prazo de tarefas aos responsáveis
+type TaskReminderNotifier struct {
+	hub *Hub
+}
+
+func NewTaskReminderNotifier(hub *Hub) *TaskReminderNotifier {
+	return &TaskReminderNotifier{hub: hub}
+}
+
+func (n *TaskReminderNotifier) NotifyTaskDue(ctx context.Context, userIDs []uuid.UUID, reminder taskapp.Reminder) {
+	n.hub.SendToUsers(userIDs, NewWSMessage(MessageTypeTaskDue, TaskDuePayload{
+		TaskID:    reminder.TaskID,
+		Title:     reminder.Title,
+		Priority:  string(reminder.Priority),
+		DueAt:     reminder.DueAt,
+		Timezone:  reminder.Timezone,
+		ContactID: reminder.ContactID,
+		SessionID: reminder.SessionID,
+	}))
+}
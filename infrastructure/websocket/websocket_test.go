@@ -1,10 +1,16 @@
 package websocket
 
 import (
+	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
 )
 
 func TestNewWSMessage(t *testing.T) {
@@ -73,3 +79,85 @@ func TestWSMessage_ParsePayload(t *testing.T) {
 	assert.Equal(t, sendPayload.Text, parsed.Text)
 	assert.Equal(t, sendPayload.ContentType, parsed.ContentType)
 }
+
+type presenceCall struct {
+	tenantID string
+	userID   uuid.UUID
+	activity bool
+}
+
+type fakePresenceTracker struct {
+	calls chan presenceCall
+}
+
+func (f *fakePresenceTracker) Heartbeat(ctx context.Context, tenantID string, userID uuid.UUID, activity bool) error {
+	f.calls <- presenceCall{tenantID, userID, activity}
+	return nil
+}
+
+func TestHub_RecordActivity_Throttled(t *testing.T) {
+	tracker := &fakePresenceTracker{calls: make(chan presenceCall, 10)}
+	hub := NewHub(nil, nil, zap.NewNop())
+	hub.SetPresenceTracker(tracker)
+	client := NewClient(hub, nil, uuid.New(), "tenant-1", uuid.New(), zap.NewNop())
+
+	hub.RecordActivity(client, false)
+	hub.RecordActivity(client, true)
+	hub.RecordActivity(client, true)
+
+	select {
+	case call := <-tracker.calls:
+		assert.Equal(t, presenceCall{"tenant-1", client.UserID(), true}, call)
+	case <-time.After(time.Second):
+		require.Fail(t, "activity was not recorded")
+	}
+
+	select {
+	case call := <-tracker.calls:
+		assert.Failf(t, "unexpected heartbeat", "%+v", call)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestClient_ActionsRequirePermission(t *testing.T) {
+	hub := NewHub(nil, nil, zap.NewNop())
+	client := NewClient(hub, nil, uuid.New(), "tenant-1", uuid.New(), zap.NewNop())
+	sessionID := uuid.New()
+	join := NewWSMessage(MessageTypeJoinSession, JoinSessionPayload{SessionID: sessionID})
+
+	// Sem authorizer nenhuma ação passa
+	assert.ErrorIs(t, client.handleJoinSession(join), ErrPermissionDenied)
+	assert.False(t, client.IsWatchingSession(sessionID))
+
+	granted := map[project_member.Permission]bool{project_member.PermissionViewSessions: true}
+	var checked []project_member.Permission
+	client.SetAuthorizer(func(ctx context.Context, permission project_member.Permission) error {
+		checked = append(checked, permission)
+		if !granted[permission] {
+			return errors.New("insufficient permissions")
+		}
+		return nil
+	})
+
+	require.NoError(t, client.handleJoinSession(join))
+	assert.True(t, client.IsWatchingSession(sessionID))
+
+	typing := NewWSMessage(MessageTypeTyping, TypingPayload{SessionID: sessionID})
+	assert.ErrorIs(t, client.handleTyping(typing), ErrPermissionDenied)
+	assert.Equal(t, []project_member.Permission{project_member.PermissionViewSessions, project_member.PermissionSendMessages}, checked)
+}
+
+func TestClient_WritesRequireAccountStanding(t *testing.T) {
+	hub := NewHub(nil, nil, zap.NewNop())
+	client := NewClient(hub, nil, uuid.New(), "tenant-1", uuid.New(), zap.NewNop())
+	client.SetAuthorizer(func(ctx context.Context, permission project_member.Permission) error { return nil })
+	client.SetStandingChecker(func(ctx context.Context) error { return errors.New("account is read-only") })
+	sessionID := uuid.New()
+
+	send := NewWSMessage(MessageTypeSendMessage, SendMessagePayload{SessionID: sessionID, Text: "olá"})
+	assert.ErrorIs(t, client.handleSendMessage(send), ErrAccountRestricted)
+
+	// Leituras e presença seguem liberadas
+	require.NoError(t, client.handleJoinSession(NewWSMessage(MessageTypeJoinSession, JoinSessionPayload{SessionID: sessionID})))
+	require.NoError(t, client.handleTyping(NewWSMessage(MessageTypeTyping, TypingPayload{SessionID: sessionID})))
+}
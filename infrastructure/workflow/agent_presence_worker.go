@@ -0,0 +1,77 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	agentapp "github.com/ventros/crm/internal/application/agent"
+	"go.uber.org/zap"
+)
+
+// AgentPresenceWorker recalcula periodicamente o status dos agentes a partir dos heartbeats:
+// conexões novas ficam available, ociosas ficam away e as expiradas ficam offline.
+type AgentPresenceWorker struct {
+	useCase      *agentapp.AgentPresenceUseCase
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewAgentPresenceWorker cria novo worker
+func NewAgentPresenceWorker(
+	useCase *agentapp.AgentPresenceUseCase,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *AgentPresenceWorker {
+	if pollInterval == 0 {
+		pollInterval = 15 * time.Second // default: metade do intervalo de heartbeat do hub
+	}
+
+	return &AgentPresenceWorker{
+		useCase:      useCase,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *AgentPresenceWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting agent presence worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.refresh(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Agent presence worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Agent presence worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *AgentPresenceWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *AgentPresenceWorker) refresh(ctx context.Context) {
+	changed, err := w.useCase.RefreshStatuses(ctx, time.Now())
+	if err != nil {
+		// Agentes com falha são reavaliados no próximo ciclo
+		w.logger.Error("Failed to refresh agent presence", zap.Error(err))
+	}
+
+	if changed > 0 {
+		w.logger.Debug("Agent presence refreshed", zap.Int("changed", changed))
+	}
+}
@@ -0,0 +1,79 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// APIKeyUsageFlusher grava no banco os últimos usos acumulados das API keys
+type APIKeyUsageFlusher interface {
+	FlushUsage(ctx context.Context) (int, error)
+}
+
+// APIKeyUsageFlushWorker grava periodicamente o last_used das API keys acumulado no buffer,
+// trocando um UPDATE por requisição autenticada por um UPDATE em lote por ciclo
+type APIKeyUsageFlushWorker struct {
+	flusher      APIKeyUsageFlusher
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewAPIKeyUsageFlushWorker cria novo worker
+func NewAPIKeyUsageFlushWorker(flusher APIKeyUsageFlusher, pollInterval time.Duration, logger *zap.Logger) *APIKeyUsageFlushWorker {
+	if pollInterval == 0 {
+		pollInterval = time.Minute
+	}
+
+	return &APIKeyUsageFlushWorker{
+		flusher:      flusher,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *APIKeyUsageFlushWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting API key usage flush worker", zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.flush(ctx)
+
+		// No shutdown faz um último flush para não perder os usos do buffer em memória
+		case <-w.stopChan:
+			w.flush(context.Background())
+			w.logger.Info("API key usage flush worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.flush(context.Background())
+			w.logger.Info("API key usage flush worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *APIKeyUsageFlushWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *APIKeyUsageFlushWorker) flush(ctx context.Context) {
+	flushed, err := w.flusher.FlushUsage(ctx)
+	if err != nil {
+		w.logger.Error("Failed to flush API key usage", zap.Error(err))
+		return
+	}
+
+	if flushed > 0 {
+		w.logger.Debug("API key usage flushed", zap.Int("keys", flushed))
+	}
+}
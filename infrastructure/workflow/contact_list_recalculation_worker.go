@@ -0,0 +1,113 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	contactlistapp "github.com/ventros/crm/internal/application/contact_list"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+	"go.uber.org/zap"
+)
+
+// ContactListRecalculationWorker recalcula periodicamente listas dinâmicas desatualizadas.
+// Complementa o consumer de eventos de contato: cobre filtros que mudam sem evento
+// (custom fields, datas relativas) e corrige desvios na contagem incremental.
+type ContactListRecalculationWorker struct {
+	repo               contact_list.Repository
+	recalculateUseCase *contactlistapp.RecalculateContactListUseCase
+	pollInterval       time.Duration
+	maxAge             time.Duration
+	batchSize          int
+	logger             *zap.Logger
+	stopChan           chan struct{}
+}
+
+// NewContactListRecalculationWorker cria novo worker
+func NewContactListRecalculationWorker(
+	repo contact_list.Repository,
+	recalculateUseCase *contactlistapp.RecalculateContactListUseCase,
+	pollInterval time.Duration,
+	maxAge time.Duration,
+	logger *zap.Logger,
+) *ContactListRecalculationWorker {
+	if pollInterval == 0 {
+		pollInterval = 1 * time.Minute // default: 1 minuto
+	}
+	if maxAge == 0 {
+		maxAge = 15 * time.Minute // default: listas com mais de 15 minutos
+	}
+
+	return &ContactListRecalculationWorker{
+		repo:               repo,
+		recalculateUseCase: recalculateUseCase,
+		pollInterval:       pollInterval,
+		maxAge:             maxAge,
+		batchSize:          50,
+		logger:             logger,
+		stopChan:           make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *ContactListRecalculationWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting contact list recalculation worker",
+		zap.Duration("poll_interval", w.pollInterval),
+		zap.Duration("max_age", w.maxAge))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	// Primeira execução imediata
+	w.recalculateStaleLists(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.recalculateStaleLists(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Contact list recalculation worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Contact list recalculation worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *ContactListRecalculationWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *ContactListRecalculationWorker) recalculateStaleLists(ctx context.Context) {
+	lists, err := w.repo.FindStaleDynamicLists(ctx, time.Now().Add(-w.maxAge), w.batchSize)
+	if err != nil {
+		w.logger.Error("Failed to fetch stale contact lists", zap.Error(err))
+		return
+	}
+
+	if len(lists) == 0 {
+		return
+	}
+
+	for _, list := range lists {
+		result, err := w.recalculateUseCase.Execute(ctx, list.ID())
+		if err != nil {
+			w.logger.Error("Failed to recalculate contact list",
+				zap.String("contact_list_id", list.ID().String()),
+				zap.Error(err))
+			// Continua para próximas listas
+			continue
+		}
+
+		if result.Joined > 0 || result.Left > 0 {
+			w.logger.Info("Contact list recalculated",
+				zap.String("contact_list_id", list.ID().String()),
+				zap.Int("contact_count", result.ContactCount),
+				zap.Int("joined", result.Joined),
+				zap.Int("left", result.Left))
+		}
+	}
+}
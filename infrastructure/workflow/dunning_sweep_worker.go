@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	"go.uber.org/zap"
+)
+
+// DunningSweepWorker varre periodicamente as faturas vencidas e as réguas abertas. Rede de
+// segurança para webhooks e timers Temporal perdidos e único disparador quando o Temporal está desligado.
+type DunningSweepWorker struct {
+	service      *billingapp.DunningService
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewDunningSweepWorker cria novo worker
+func NewDunningSweepWorker(service *billingapp.DunningService, pollInterval time.Duration, logger *zap.Logger) *DunningSweepWorker {
+	if pollInterval == 0 {
+		pollInterval = 15 * time.Minute
+	}
+
+	return &DunningSweepWorker{
+		service:      service,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *DunningSweepWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting dunning sweep worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.processDue(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Dunning sweep worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Dunning sweep worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *DunningSweepWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *DunningSweepWorker) processDue(ctx context.Context) {
+	count, err := w.service.ProcessDue(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to process dunning cases", zap.Error(err))
+	}
+	if count > 0 {
+		w.logger.Info("Dunning cases processed", zap.Int("count", count))
+	}
+}
@@ -0,0 +1,49 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	dunningworkflow "github.com/ventros/crm/internal/workflows/dunning"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.uber.org/zap"
+)
+
+// DunningWorker gerencia o worker Temporal da régua de cobrança
+type DunningWorker struct {
+	worker  worker.Worker
+	handler dunningworkflow.AdvanceHandler
+	logger  *zap.Logger
+}
+
+// NewDunningWorker cria um novo worker para a régua de cobrança
+func NewDunningWorker(temporalClient client.Client, handler dunningworkflow.AdvanceHandler, logger *zap.Logger) *DunningWorker {
+	return &DunningWorker{
+		worker:  worker.New(temporalClient, dunningworkflow.TaskQueue, worker.Options{}),
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start inicia o worker Temporal
+func (w *DunningWorker) Start(ctx context.Context) error {
+	w.worker.RegisterWorkflow(dunningworkflow.DunningWorkflow)
+
+	activities := dunningworkflow.NewDunningActivities(w.handler)
+	w.worker.RegisterActivityWithOptions(activities.AdvanceActivity, activity.RegisterOptions{Name: "DunningAdvanceActivity"})
+
+	w.logger.Info("Starting dunning worker", zap.String("task_queue", dunningworkflow.TaskQueue))
+
+	if err := w.worker.Start(); err != nil {
+		return fmt.Errorf("failed to start dunning worker: %w", err)
+	}
+	return nil
+}
+
+// Stop para o worker
+func (w *DunningWorker) Stop() {
+	w.logger.Info("Stopping dunning worker")
+	w.worker.Stop()
+}
@@ -0,0 +1,85 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+// SessionAnalyticsRollupWorker atualiza periodicamente o rollup de sessões (buckets de 15 minutos)
+// consumido pelos dashboards de analytics. Cada execução recalcula apenas os buckets
+// com sessões alteradas desde a anterior.
+type SessionAnalyticsRollupWorker struct {
+	repo         session.AnalyticsRepository
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewSessionAnalyticsRollupWorker cria novo worker
+func NewSessionAnalyticsRollupWorker(
+	repo session.AnalyticsRepository,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *SessionAnalyticsRollupWorker {
+	if pollInterval == 0 {
+		pollInterval = 1 * time.Minute // default: 1 minuto
+	}
+
+	return &SessionAnalyticsRollupWorker{
+		repo:         repo,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *SessionAnalyticsRollupWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting session analytics rollup worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	// Primeira execução imediata
+	w.refresh(ctx)
+
+	for {
+		select {
+		case <-ticker.C:
+			w.refresh(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Session analytics rollup worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Session analytics rollup worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *SessionAnalyticsRollupWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *SessionAnalyticsRollupWorker) refresh(ctx context.Context) {
+	start := time.Now()
+
+	buckets, err := w.repo.RefreshRollups(ctx)
+	if err != nil {
+		w.logger.Error("Failed to refresh session analytics rollups", zap.Error(err))
+		return
+	}
+
+	if buckets > 0 {
+		w.logger.Debug("Session analytics rollups refreshed",
+			zap.Int("buckets", buckets),
+			zap.Duration("duration", time.Since(start)))
+	}
+}
@@ -0,0 +1,96 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	teamapp "github.com/ventros/crm/internal/application/team"
+	"go.uber.org/zap"
+)
+
+// SessionQueueDistributionWorker tenta periodicamente distribuir as sessões que aguardam
+// em filas automáticas, quando membros do time ficam disponíveis ou liberam capacidade.
+type SessionQueueDistributionWorker struct {
+	useCase      *teamapp.QueueSessionsUseCase
+	pollInterval time.Duration
+	batchSize    int
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewSessionQueueDistributionWorker cria novo worker
+func NewSessionQueueDistributionWorker(
+	useCase *teamapp.QueueSessionsUseCase,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *SessionQueueDistributionWorker {
+	if pollInterval == 0 {
+		pollInterval = 15 * time.Second // default: 15 segundos
+	}
+
+	return &SessionQueueDistributionWorker{
+		useCase:      useCase,
+		pollInterval: pollInterval,
+		batchSize:    100,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *SessionQueueDistributionWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting session queue distribution worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.distribute(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Session queue distribution worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Session queue distribution worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *SessionQueueDistributionWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *SessionQueueDistributionWorker) distribute(ctx context.Context) {
+	sessionIDs, err := w.useCase.FindDistributable(ctx, w.batchSize)
+	if err != nil {
+		w.logger.Error("Failed to fetch waiting queue items", zap.Error(err))
+		return
+	}
+
+	assigned := 0
+	for _, sessionID := range sessionIDs {
+		ok, err := w.useCase.DistributeWaiting(ctx, sessionID)
+		if err != nil {
+			w.logger.Error("Failed to distribute queued session",
+				zap.String("session_id", sessionID.String()),
+				zap.Error(err))
+			// Continua para próximas sessões
+			continue
+		}
+		if ok {
+			assigned++
+		}
+	}
+
+	if assigned > 0 {
+		w.logger.Info("Queued sessions distributed",
+			zap.Int("assigned", assigned),
+			zap.Int("waiting", len(sessionIDs)-assigned))
+	}
+}
@@ -0,0 +1,80 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	routingapp "github.com/ventros/crm/internal/application/routing"
+	"go.uber.org/zap"
+)
+
+// SessionReassignmentWorker aplica periodicamente as regras de reatribuição dos projetos
+// (inatividade, falta de resposta e balanceamento quando o agente fica indisponível).
+type SessionReassignmentWorker struct {
+	useCase      *routingapp.EnforceReassignmentRulesUseCase
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewSessionReassignmentWorker cria novo worker
+func NewSessionReassignmentWorker(
+	useCase *routingapp.EnforceReassignmentRulesUseCase,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *SessionReassignmentWorker {
+	if pollInterval == 0 {
+		pollInterval = 1 * time.Minute // default: 1 minuto (menor granularidade das regras)
+	}
+
+	return &SessionReassignmentWorker{
+		useCase:      useCase,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *SessionReassignmentWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting session reassignment worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.enforceRules(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Session reassignment worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Session reassignment worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *SessionReassignmentWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *SessionReassignmentWorker) enforceRules(ctx context.Context) {
+	summary, err := w.useCase.Execute(ctx, time.Now())
+	if err != nil {
+		// Projetos com falha são reavaliados no próximo ciclo; os demais já foram processados
+		w.logger.Error("Failed to enforce reassignment rules", zap.Error(err))
+	}
+
+	if summary != nil && (summary.Reassigned > 0 || summary.Unassignable > 0) {
+		w.logger.Info("Reassignment rules enforced",
+			zap.Int("evaluated", summary.Evaluated),
+			zap.Int("reassigned", summary.Reassigned),
+			zap.Int("unassignable", summary.Unassignable))
+	}
+}
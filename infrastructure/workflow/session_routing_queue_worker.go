@@ -0,0 +1,101 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	routingapp "github.com/ventros/crm/internal/application/routing"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"go.uber.org/zap"
+)
+
+// SessionRoutingQueueWorker tenta periodicamente rotear as sessões que aguardam agente.
+// Agentes ficam elegíveis ao voltar a "available", liberar capacidade ou ser adicionados ao projeto.
+type SessionRoutingQueueWorker struct {
+	repo         routing.Repository
+	routeUseCase *routingapp.RouteSessionUseCase
+	pollInterval time.Duration
+	batchSize    int
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewSessionRoutingQueueWorker cria novo worker
+func NewSessionRoutingQueueWorker(
+	repo routing.Repository,
+	routeUseCase *routingapp.RouteSessionUseCase,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *SessionRoutingQueueWorker {
+	if pollInterval == 0 {
+		pollInterval = 15 * time.Second // default: 15 segundos
+	}
+
+	return &SessionRoutingQueueWorker{
+		repo:         repo,
+		routeUseCase: routeUseCase,
+		pollInterval: pollInterval,
+		batchSize:    100,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *SessionRoutingQueueWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting session routing queue worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.drainQueue(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Session routing queue worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Session routing queue worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *SessionRoutingQueueWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *SessionRoutingQueueWorker) drainQueue(ctx context.Context) {
+	entries, err := w.repo.FindQueued(ctx, w.batchSize)
+	if err != nil {
+		w.logger.Error("Failed to fetch routing queue", zap.Error(err))
+		return
+	}
+
+	assigned := 0
+	for _, entry := range entries {
+		result, err := w.routeUseCase.Execute(ctx, entry.SessionID)
+		if err != nil {
+			w.logger.Error("Failed to route queued session",
+				zap.String("session_id", entry.SessionID.String()),
+				zap.Int("attempts", entry.Attempts),
+				zap.Error(err))
+			// Continua para próximas sessões
+			continue
+		}
+		if result.Outcome == routingapp.OutcomeAssigned {
+			assigned++
+		}
+	}
+
+	if assigned > 0 {
+		w.logger.Info("Queued sessions routed",
+			zap.Int("assigned", assigned),
+			zap.Int("queued", len(entries)-assigned))
+	}
+}
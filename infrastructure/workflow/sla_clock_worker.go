@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	slaapp "github.com/ventros/crm/internal/application/sla"
+	"go.uber.org/zap"
+)
+
+// SLAClockWorker varre periodicamente os relógios de SLA com aviso ou vencimento pendente.
+// Rede de segurança para timers Temporal perdidos e único disparador quando o Temporal está desligado.
+type SLAClockWorker struct {
+	service      *slaapp.ClockService
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewSLAClockWorker cria novo worker
+func NewSLAClockWorker(service *slaapp.ClockService, pollInterval time.Duration, logger *zap.Logger) *SLAClockWorker {
+	if pollInterval == 0 {
+		pollInterval = 1 * time.Minute
+	}
+
+	return &SLAClockWorker{
+		service:      service,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *SLAClockWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting SLA clock worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.processDue(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("SLA clock worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("SLA clock worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *SLAClockWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *SLAClockWorker) processDue(ctx context.Context) {
+	count, err := w.service.ProcessDue(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to process due SLA clocks", zap.Error(err))
+	}
+	if count > 0 {
+		w.logger.Info("Due SLA clocks processed", zap.Int("count", count))
+	}
+}
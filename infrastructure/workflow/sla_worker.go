@@ -0,0 +1,50 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	slaworkflow "github.com/ventros/crm/internal/workflows/sla"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.uber.org/zap"
+)
+
+// SLAWorker gerencia o worker Temporal dos timers de SLA
+type SLAWorker struct {
+	worker  worker.Worker
+	handler slaworkflow.ClockHandler
+	logger  *zap.Logger
+}
+
+// NewSLAWorker cria um novo worker para os timers de SLA
+func NewSLAWorker(temporalClient client.Client, handler slaworkflow.ClockHandler, logger *zap.Logger) *SLAWorker {
+	return &SLAWorker{
+		worker:  worker.New(temporalClient, slaworkflow.TaskQueue, worker.Options{}),
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start inicia o worker Temporal
+func (w *SLAWorker) Start(ctx context.Context) error {
+	w.worker.RegisterWorkflow(slaworkflow.SLAClockWorkflow)
+
+	activities := slaworkflow.NewSLAActivities(w.handler)
+	w.worker.RegisterActivityWithOptions(activities.WarnActivity, activity.RegisterOptions{Name: "SLAWarnActivity"})
+	w.worker.RegisterActivityWithOptions(activities.BreachActivity, activity.RegisterOptions{Name: "SLABreachActivity"})
+
+	w.logger.Info("Starting SLA worker", zap.String("task_queue", slaworkflow.TaskQueue))
+
+	if err := w.worker.Start(); err != nil {
+		return fmt.Errorf("failed to start sla worker: %w", err)
+	}
+	return nil
+}
+
+// Stop para o worker
+func (w *SLAWorker) Stop() {
+	w.logger.Info("Stopping SLA worker")
+	w.worker.Stop()
+}
@@ -0,0 +1,71 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	taskapp "github.com/ventros/crm/internal/application/task"
+	"go.uber.org/zap"
+)
+
+// TaskReminderWorker varre periodicamente as tarefas com prazo vencido ainda não registrado.
+// Rede de segurança para timers Temporal perdidos e único disparador quando o Temporal está desligado.
+type TaskReminderWorker struct {
+	service      *taskapp.ReminderService
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewTaskReminderWorker cria novo worker
+func NewTaskReminderWorker(service *taskapp.ReminderService, pollInterval time.Duration, logger *zap.Logger) *TaskReminderWorker {
+	if pollInterval == 0 {
+		pollInterval = 1 * time.Minute
+	}
+
+	return &TaskReminderWorker{
+		service:      service,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *TaskReminderWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting task reminder sweep worker",
+		zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.processDue(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Task reminder sweep worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Task reminder sweep worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *TaskReminderWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *TaskReminderWorker) processDue(ctx context.Context) {
+	count, err := w.service.ProcessDue(ctx, time.Now())
+	if err != nil {
+		w.logger.Error("Failed to process due tasks", zap.Error(err))
+	}
+	if count > 0 {
+		w.logger.Info("Due tasks processed", zap.Int("count", count))
+	}
+}
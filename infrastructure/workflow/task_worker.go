@@ -0,0 +1,49 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	taskworkflow "github.com/ventros/crm/internal/workflows/task"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.uber.org/zap"
+)
+
+// TaskWorker gerencia o worker Temporal dos lembretes de tarefas
+type TaskWorker struct {
+	worker  worker.Worker
+	handler taskworkflow.DueHandler
+	logger  *zap.Logger
+}
+
+// NewTaskWorker cria um novo worker para os lembretes de tarefas
+func NewTaskWorker(temporalClient client.Client, handler taskworkflow.DueHandler, logger *zap.Logger) *TaskWorker {
+	return &TaskWorker{
+		worker:  worker.New(temporalClient, taskworkflow.TaskQueue, worker.Options{}),
+		handler: handler,
+		logger:  logger,
+	}
+}
+
+// Start inicia o worker Temporal
+func (w *TaskWorker) Start(ctx context.Context) error {
+	w.worker.RegisterWorkflow(taskworkflow.TaskReminderWorkflow)
+
+	activities := taskworkflow.NewTaskActivities(w.handler)
+	w.worker.RegisterActivityWithOptions(activities.DueActivity, activity.RegisterOptions{Name: "TaskDueActivity"})
+
+	w.logger.Info("Starting task reminder worker", zap.String("task_queue", taskworkflow.TaskQueue))
+
+	if err := w.worker.Start(); err != nil {
+		return fmt.Errorf("failed to start task reminder worker: %w", err)
+	}
+	return nil
+}
+
+// Stop para o worker
+func (w *TaskWorker) Stop() {
+	w.logger.Info("Stopping task reminder worker")
+	w.worker.Stop()
+}
@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// PendingUsageReporter envia ao Stripe o consumo ainda não reportado
+type PendingUsageReporter interface {
+	ReportPending(ctx context.Context) (int, error)
+}
+
+// UsageReportWorker envia periodicamente, em lotes, o consumo medido aos Billing Meters do Stripe
+type UsageReportWorker struct {
+	reporter     PendingUsageReporter
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewUsageReportWorker cria novo worker
+func NewUsageReportWorker(reporter PendingUsageReporter, pollInterval time.Duration, logger *zap.Logger) *UsageReportWorker {
+	if pollInterval == 0 {
+		pollInterval = 5 * time.Minute
+	}
+
+	return &UsageReportWorker{
+		reporter:     reporter,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *UsageReportWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting usage report worker", zap.Duration("poll_interval", w.pollInterval))
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.report(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Usage report worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Usage report worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *UsageReportWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *UsageReportWorker) report(ctx context.Context) {
+	reported, err := w.reporter.ReportPending(ctx)
+	if err != nil {
+		w.logger.Error("Failed to report usage to Stripe", zap.Error(err), zap.Int("reported", reported))
+		return
+	}
+
+	if reported > 0 {
+		w.logger.Info("Usage reported to Stripe", zap.Int("records", reported))
+	}
+}
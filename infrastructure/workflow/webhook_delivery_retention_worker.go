@@ -0,0 +1,87 @@
+package workflow
+
+import (
+	"context"
+	"time"
+
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"go.uber.org/zap"
+)
+
+// WebhookDeliveryRetentionWorker remove periodicamente do log as entregas de webhook
+// mais antigas que o período de retenção
+type WebhookDeliveryRetentionWorker struct {
+	deliveries   webhook.DeliveryRepository
+	retention    time.Duration
+	pollInterval time.Duration
+	logger       *zap.Logger
+	stopChan     chan struct{}
+}
+
+// NewWebhookDeliveryRetentionWorker cria novo worker
+func NewWebhookDeliveryRetentionWorker(
+	deliveries webhook.DeliveryRepository,
+	retention time.Duration,
+	pollInterval time.Duration,
+	logger *zap.Logger,
+) *WebhookDeliveryRetentionWorker {
+	if retention == 0 {
+		retention = 30 * 24 * time.Hour
+	}
+	if pollInterval == 0 {
+		pollInterval = time.Hour
+	}
+
+	return &WebhookDeliveryRetentionWorker{
+		deliveries:   deliveries,
+		retention:    retention,
+		pollInterval: pollInterval,
+		logger:       logger,
+		stopChan:     make(chan struct{}),
+	}
+}
+
+// Start inicia o worker
+func (w *WebhookDeliveryRetentionWorker) Start(ctx context.Context) {
+	w.logger.Info("Starting webhook delivery retention worker",
+		zap.Duration("retention", w.retention),
+		zap.Duration("poll_interval", w.pollInterval))
+
+	// Limpa na subida para não esperar um ciclo inteiro após deploys
+	w.purge(ctx)
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.purge(ctx)
+
+		case <-w.stopChan:
+			w.logger.Info("Webhook delivery retention worker stopped")
+			return
+
+		case <-ctx.Done():
+			w.logger.Info("Webhook delivery retention worker context cancelled")
+			return
+		}
+	}
+}
+
+// Stop para o worker
+func (w *WebhookDeliveryRetentionWorker) Stop() {
+	close(w.stopChan)
+}
+
+func (w *WebhookDeliveryRetentionWorker) purge(ctx context.Context) {
+	deleted, err := w.deliveries.DeleteBefore(ctx, time.Now().Add(-w.retention))
+	if err != nil {
+		w.logger.Error("Failed to purge webhook deliveries", zap.Error(err))
+		return
+	}
+
+	if deleted > 0 {
+		w.logger.Info("Webhook deliveries purged", zap.Int64("deleted", deleted))
+	}
+}
@@ -0,0 +1,52 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	webhookworkflow "github.com/ventros/crm/internal/workflows/webhook"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/worker"
+	"go.uber.org/zap"
+)
+
+// WebhookWorker gerencia o worker Temporal das entregas de webhook
+type WebhookWorker struct {
+	worker   worker.Worker
+	repo     webhook.Repository
+	recorder webhookworkflow.DeliveryRecorder
+	logger   *zap.Logger
+}
+
+// NewWebhookWorker cria um novo worker para as entregas de webhook
+func NewWebhookWorker(temporalClient client.Client, repo webhook.Repository, recorder webhookworkflow.DeliveryRecorder, logger *zap.Logger) *WebhookWorker {
+	return &WebhookWorker{
+		worker:   worker.New(temporalClient, webhookworkflow.TaskQueue, worker.Options{}),
+		repo:     repo,
+		recorder: recorder,
+		logger:   logger,
+	}
+}
+
+// Start inicia o worker Temporal
+func (w *WebhookWorker) Start(ctx context.Context) error {
+	w.worker.RegisterWorkflow(webhookworkflow.WebhookDeliveryWorkflow)
+
+	// Registra DeliverWebhookActivity e WebhookStatusUpdateActivity pelo nome do método
+	w.worker.RegisterActivity(webhookworkflow.NewWebhookActivities(w.repo, w.recorder))
+	w.worker.RegisterActivity(webhookworkflow.CompensateWebhookActivity)
+
+	w.logger.Info("Starting webhook delivery worker", zap.String("task_queue", webhookworkflow.TaskQueue))
+
+	if err := w.worker.Start(); err != nil {
+		return fmt.Errorf("failed to start webhook delivery worker: %w", err)
+	}
+	return nil
+}
+
+// Stop para o worker
+func (w *WebhookWorker) Stop() {
+	w.logger.Info("Stopping webhook delivery worker")
+	w.worker.Stop()
+}
@@ -0,0 +1,234 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+const (
+	defaultLeaderboardLimit = 10
+	maxLeaderboardLimit     = 100
+)
+
+// GetAgentPerformanceUseCase calcula estatísticas de um agente e o ranking de um projeto
+type GetAgentPerformanceUseCase struct {
+	agentRepo       agent.Repository
+	performanceRepo agent.PerformanceRepository
+}
+
+// NewGetAgentPerformanceUseCase creates a new instance
+func NewGetAgentPerformanceUseCase(agentRepo agent.Repository, performanceRepo agent.PerformanceRepository) *GetAgentPerformanceUseCase {
+	return &GetAgentPerformanceUseCase{
+		agentRepo:       agentRepo,
+		performanceRepo: performanceRepo,
+	}
+}
+
+// PerformancePeriod período e fuso usados no cálculo
+type PerformancePeriod struct {
+	StartDate time.Time
+	EndDate   time.Time
+	Location  *time.Location
+}
+
+// AgentPerformanceDTO métricas de desempenho de um agente
+type AgentPerformanceDTO struct {
+	AgentID                    uuid.UUID        `json:"agent_id"`
+	AgentName                  string           `json:"agent_name"`
+	Rank                       int              `json:"rank,omitempty"`
+	SessionsHandled            int64            `json:"sessions_handled"`
+	SessionsResolved           int64            `json:"sessions_resolved"`
+	ResolutionRate             float64          `json:"resolution_rate"`
+	MessagesSent               int64            `json:"messages_sent"`
+	FirstResponseSamples       int64            `json:"first_response_samples"`
+	MedianFirstResponseSeconds *float64         `json:"median_first_response_seconds"`
+	P90FirstResponseSeconds    *float64         `json:"p90_first_response_seconds"`
+	TransfersIn                int64            `json:"transfers_in"`
+	TransfersOut               int64            `json:"transfers_out"`
+	AverageSentimentScore      *float64         `json:"average_sentiment_score"`
+	SentimentBreakdown         map[string]int64 `json:"sentiment_breakdown"`
+	BusyHours                  map[int]int64    `json:"busy_hours"`
+}
+
+// GetAgentStatsRequest estatísticas de um agente
+type GetAgentStatsRequest struct {
+	AgentID  uuid.UUID
+	TenantID string
+	Period   PerformancePeriod
+}
+
+// GetLeaderboardRequest ranking de agentes de um projeto
+type GetLeaderboardRequest struct {
+	TenantID  string
+	ProjectID uuid.UUID
+	Period    PerformancePeriod
+	SortBy    agent.LeaderboardSort
+	Limit     int
+}
+
+// LeaderboardResponse ranking ordenado
+type LeaderboardResponse struct {
+	ProjectID uuid.UUID              `json:"project_id"`
+	SortBy    agent.LeaderboardSort  `json:"sort_by"`
+	StartDate time.Time              `json:"start_date"`
+	EndDate   time.Time              `json:"end_date"`
+	Agents    []*AgentPerformanceDTO `json:"agents"`
+}
+
+// GetAgentStats retorna as métricas de um agente no período
+func (uc *GetAgentPerformanceUseCase) GetAgentStats(ctx context.Context, req GetAgentStatsRequest) (*AgentPerformanceDTO, error) {
+	foundAgent, err := uc.agentRepo.FindByID(ctx, req.AgentID)
+	if err != nil {
+		if errors.Is(err, agent.ErrAgentNotFound) {
+			return nil, shared.NewNotFoundError("agent", req.AgentID.String())
+		}
+		return nil, fmt.Errorf("failed to find agent: %w", err)
+	}
+	if foundAgent.TenantID() != req.TenantID {
+		return nil, shared.NewNotFoundError("agent", req.AgentID.String())
+	}
+
+	filter := agent.PerformanceFilter{
+		TenantID:  req.TenantID,
+		AgentIDs:  []uuid.UUID{req.AgentID},
+		StartDate: req.Period.StartDate,
+		EndDate:   req.Period.EndDate,
+		Location:  req.Period.Location,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "period")
+	}
+
+	stats, err := uc.performanceRepo.GetPerformance(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute agent performance: %w", err)
+	}
+
+	for _, s := range stats {
+		if s.AgentID == req.AgentID {
+			return toAgentPerformanceDTO(s), nil
+		}
+	}
+
+	// Agente sem nenhuma atividade registrada
+	return toAgentPerformanceDTO(&agent.PerformanceStats{
+		AgentID:            foundAgent.ID(),
+		AgentName:          foundAgent.Name(),
+		SentimentBreakdown: map[string]int64{},
+		BusyHours:          map[int]int64{},
+	}), nil
+}
+
+// GetLeaderboard retorna os agentes do projeto ordenados pela métrica escolhida
+func (uc *GetAgentPerformanceUseCase) GetLeaderboard(ctx context.Context, req GetLeaderboardRequest) (*LeaderboardResponse, error) {
+	if req.ProjectID == uuid.Nil {
+		return nil, shared.NewValidationError("project_id is required", "project_id")
+	}
+	if req.SortBy == "" {
+		req.SortBy = agent.SortBySessionsHandled
+	}
+	if !req.SortBy.IsValid() {
+		return nil, shared.NewValidationError("invalid sort_by", "sort_by")
+	}
+	if req.Limit <= 0 {
+		req.Limit = defaultLeaderboardLimit
+	}
+	if req.Limit > maxLeaderboardLimit {
+		req.Limit = maxLeaderboardLimit
+	}
+
+	projectID := req.ProjectID
+	filter := agent.PerformanceFilter{
+		TenantID:  req.TenantID,
+		ProjectID: &projectID,
+		StartDate: req.Period.StartDate,
+		EndDate:   req.Period.EndDate,
+		Location:  req.Period.Location,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "period")
+	}
+
+	stats, err := uc.performanceRepo.GetPerformance(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute agent performance: %w", err)
+	}
+
+	sortLeaderboard(stats, req.SortBy)
+
+	if len(stats) > req.Limit {
+		stats = stats[:req.Limit]
+	}
+
+	response := &LeaderboardResponse{
+		ProjectID: req.ProjectID,
+		SortBy:    req.SortBy,
+		StartDate: filter.StartDate,
+		EndDate:   filter.EndDate,
+		Agents:    make([]*AgentPerformanceDTO, 0, len(stats)),
+	}
+	for i, s := range stats {
+		dto := toAgentPerformanceDTO(s)
+		dto.Rank = i + 1
+		response.Agents = append(response.Agents, dto)
+	}
+
+	return response, nil
+}
+
+// sortLeaderboard ordena de forma estável; empates são desfeitos por sessões atendidas e nome.
+// Em first_response_time, menor é melhor e agentes sem amostras vão para o fim.
+func sortLeaderboard(stats []*agent.PerformanceStats, sortBy agent.LeaderboardSort) {
+	sort.SliceStable(stats, func(i, j int) bool {
+		a, b := stats[i], stats[j]
+		switch sortBy {
+		case agent.SortByMessagesSent:
+			if a.MessagesSent != b.MessagesSent {
+				return a.MessagesSent > b.MessagesSent
+			}
+		case agent.SortByResolutionRate:
+			if a.ResolutionRate() != b.ResolutionRate() {
+				return a.ResolutionRate() > b.ResolutionRate()
+			}
+		case agent.SortByFirstResponseTime:
+			switch {
+			case a.MedianFirstResponseSeconds == nil && b.MedianFirstResponseSeconds != nil:
+				return false
+			case a.MedianFirstResponseSeconds != nil && b.MedianFirstResponseSeconds == nil:
+				return true
+			case a.MedianFirstResponseSeconds != nil && *a.MedianFirstResponseSeconds != *b.MedianFirstResponseSeconds:
+				return *a.MedianFirstResponseSeconds < *b.MedianFirstResponseSeconds
+			}
+		}
+		if a.SessionsHandled != b.SessionsHandled {
+			return a.SessionsHandled > b.SessionsHandled
+		}
+		return a.AgentName < b.AgentName
+	})
+}
+
+func toAgentPerformanceDTO(s *agent.PerformanceStats) *AgentPerformanceDTO {
+	return &AgentPerformanceDTO{
+		AgentID:                    s.AgentID,
+		AgentName:                  s.AgentName,
+		SessionsHandled:            s.SessionsHandled,
+		SessionsResolved:           s.SessionsResolved,
+		ResolutionRate:             s.ResolutionRate(),
+		MessagesSent:               s.MessagesSent,
+		FirstResponseSamples:       s.FirstResponseSamples,
+		MedianFirstResponseSeconds: s.MedianFirstResponseSeconds,
+		P90FirstResponseSeconds:    s.P90FirstResponseSeconds,
+		TransfersIn:                s.TransfersIn,
+		TransfersOut:               s.TransfersOut,
+		AverageSentimentScore:      s.AverageSentimentScore,
+		SentimentBreakdown:         s.SentimentBreakdown,
+		BusyHours:                  s.BusyHours,
+	}
+}
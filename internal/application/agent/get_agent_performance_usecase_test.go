@@ -0,0 +1,114 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+func performancePeriod() PerformancePeriod {
+	start := time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC)
+	return PerformancePeriod{StartDate: start, EndDate: start.AddDate(0, 1, 0)}
+}
+
+func floatPtr(v float64) *float64 { return &v }
+
+func newTestAgent(t *testing.T, tenantID, name string) *agent.Agent {
+	userID := uuid.New()
+	a, err := agent.NewAgent(uuid.New(), tenantID, name, agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	return a
+}
+
+func TestGetAgentPerformanceUseCase_GetAgentStats(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	perfRepo := new(MockPerformanceRepository)
+	useCase := NewGetAgentPerformanceUseCase(agentRepo, perfRepo)
+
+	found := newTestAgent(t, "tenant-1", "Ana")
+
+	agentRepo.On("FindByID", ctx, found.ID()).Return(found, nil)
+	perfRepo.On("GetPerformance", ctx, mock.MatchedBy(func(f agent.PerformanceFilter) bool {
+		return f.TenantID == "tenant-1" && len(f.AgentIDs) == 1 && f.AgentIDs[0] == found.ID()
+	})).Return([]*agent.PerformanceStats{{
+		AgentID:                    found.ID(),
+		AgentName:                  "Ana",
+		SessionsHandled:            4,
+		SessionsResolved:           3,
+		MedianFirstResponseSeconds: floatPtr(42),
+	}}, nil)
+
+	dto, err := useCase.GetAgentStats(ctx, GetAgentStatsRequest{AgentID: found.ID(), TenantID: "tenant-1", Period: performancePeriod()})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), dto.SessionsHandled)
+	assert.Equal(t, 0.75, dto.ResolutionRate)
+	assert.Equal(t, 42.0, *dto.MedianFirstResponseSeconds)
+}
+
+func TestGetAgentPerformanceUseCase_GetAgentStats_OtherTenant(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	useCase := NewGetAgentPerformanceUseCase(agentRepo, new(MockPerformanceRepository))
+
+	found := newTestAgent(t, "tenant-1", "Ana")
+	agentRepo.On("FindByID", ctx, found.ID()).Return(found, nil)
+
+	_, err := useCase.GetAgentStats(ctx, GetAgentStatsRequest{AgentID: found.ID(), TenantID: "tenant-2", Period: performancePeriod()})
+
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+func TestGetAgentPerformanceUseCase_GetLeaderboard(t *testing.T) {
+	ctx := context.Background()
+	perfRepo := new(MockPerformanceRepository)
+	useCase := NewGetAgentPerformanceUseCase(new(MockAgentRepository), perfRepo)
+	projectID := uuid.New()
+
+	fast := &agent.PerformanceStats{AgentID: uuid.New(), AgentName: "Fast", SessionsHandled: 2, MedianFirstResponseSeconds: floatPtr(10)}
+	slow := &agent.PerformanceStats{AgentID: uuid.New(), AgentName: "Slow", SessionsHandled: 9, MedianFirstResponseSeconds: floatPtr(300)}
+	idle := &agent.PerformanceStats{AgentID: uuid.New(), AgentName: "Idle"}
+
+	perfRepo.On("GetPerformance", ctx, mock.MatchedBy(func(f agent.PerformanceFilter) bool {
+		return f.ProjectID != nil && *f.ProjectID == projectID
+	})).Return([]*agent.PerformanceStats{idle, slow, fast}, nil)
+
+	t.Run("by first response time", func(t *testing.T) {
+		resp, err := useCase.GetLeaderboard(ctx, GetLeaderboardRequest{
+			TenantID: "tenant-1", ProjectID: projectID, Period: performancePeriod(), SortBy: agent.SortByFirstResponseTime,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Agents, 3)
+		assert.Equal(t, "Fast", resp.Agents[0].AgentName)
+		assert.Equal(t, 1, resp.Agents[0].Rank)
+		assert.Equal(t, "Slow", resp.Agents[1].AgentName)
+		assert.Equal(t, "Idle", resp.Agents[2].AgentName)
+	})
+
+	t.Run("by sessions handled with limit", func(t *testing.T) {
+		resp, err := useCase.GetLeaderboard(ctx, GetLeaderboardRequest{
+			TenantID: "tenant-1", ProjectID: projectID, Period: performancePeriod(), Limit: 1,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, resp.Agents, 1)
+		assert.Equal(t, "Slow", resp.Agents[0].AgentName)
+	})
+
+	t.Run("invalid sort", func(t *testing.T) {
+		_, err := useCase.GetLeaderboard(ctx, GetLeaderboardRequest{
+			TenantID: "tenant-1", ProjectID: projectID, Period: performancePeriod(), SortBy: "vibes",
+		})
+
+		assert.Error(t, err)
+	})
+}
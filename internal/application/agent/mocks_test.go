@@ -2,11 +2,13 @@ package agent
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/agent_session"
 )
 
 // ========== Shared Mocks for agent package tests ==========
@@ -117,3 +119,112 @@ func (m *MockTransactionManagerWithRollback) ExecuteInTransaction(ctx context.Co
 	}
 	return err
 }
+
+type MockAgentSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockAgentSessionRepository) Create(ctx context.Context, as *agent_session.AgentSession) error {
+	args := m.Called(ctx, as)
+	return args.Error(0)
+}
+
+func (m *MockAgentSessionRepository) Update(ctx context.Context, as *agent_session.AgentSession) error {
+	args := m.Called(ctx, as)
+	return args.Error(0)
+}
+
+func (m *MockAgentSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*agent_session.AgentSession, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent_session.AgentSession), args.Error(1)
+}
+
+func (m *MockAgentSessionRepository) FindActiveBySessionID(ctx context.Context, sessionID uuid.UUID) ([]*agent_session.AgentSession, error) {
+	args := m.Called(ctx, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent_session.AgentSession), args.Error(1)
+}
+
+func (m *MockAgentSessionRepository) FindByAgentID(ctx context.Context, agentID uuid.UUID) ([]*agent_session.AgentSession, error) {
+	args := m.Called(ctx, agentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent_session.AgentSession), args.Error(1)
+}
+
+func (m *MockAgentSessionRepository) FindByAgentAndSession(ctx context.Context, agentID, sessionID uuid.UUID) (*agent_session.AgentSession, error) {
+	args := m.Called(ctx, agentID, sessionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent_session.AgentSession), args.Error(1)
+}
+
+func (m *MockAgentSessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockPerformanceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPerformanceRepository) GetPerformance(ctx context.Context, filter agent.PerformanceFilter) ([]*agent.PerformanceStats, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent.PerformanceStats), args.Error(1)
+}
+
+type MockPresenceRepository struct {
+	mock.Mock
+}
+
+func (m *MockPresenceRepository) Touch(ctx context.Context, tenantID string, userID uuid.UUID, at time.Time, activity bool) error {
+	args := m.Called(ctx, tenantID, userID, at, activity)
+	return args.Error(0)
+}
+
+func (m *MockPresenceRepository) SetManualStatus(ctx context.Context, agentID uuid.UUID, tenantID string, status *agent.AgentStatus, at time.Time) error {
+	args := m.Called(ctx, agentID, tenantID, status, at)
+	return args.Error(0)
+}
+
+func (m *MockPresenceRepository) FindByAgent(ctx context.Context, agentID uuid.UUID) (*agent.Presence, error) {
+	args := m.Called(ctx, agentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent.Presence), args.Error(1)
+}
+
+func (m *MockPresenceRepository) FindByTenant(ctx context.Context, tenantID string) ([]agent.Presence, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]agent.Presence), args.Error(1)
+}
+
+func (m *MockPresenceRepository) FindSweepCandidates(ctx context.Context, connectedSince time.Time, limit int) ([]agent.Presence, error) {
+	args := m.Called(ctx, connectedSince, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]agent.Presence), args.Error(1)
+}
+
+type MockPresenceNotifier struct {
+	mock.Mock
+}
+
+func (m *MockPresenceNotifier) NotifyPresenceChanged(ctx context.Context, supervisorUserIDs []uuid.UUID, change PresenceChange) {
+	m.Called(ctx, supervisorUserIDs, change)
+}
@@ -0,0 +1,270 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+// PresenceChange mudança de status de um agente, enviada aos supervisores
+type PresenceChange struct {
+	AgentID        uuid.UUID
+	TenantID       string
+	Name           string
+	PreviousStatus agent.AgentStatus
+	Status         agent.AgentStatus
+	Manual         bool
+	ChangedAt      time.Time
+}
+
+// PresenceNotifier entrega mudanças de presença em tempo real (ex: websocket)
+type PresenceNotifier interface {
+	NotifyPresenceChanged(ctx context.Context, supervisorUserIDs []uuid.UUID, change PresenceChange)
+}
+
+// PresenceView estado de presença de um agente exposto pela API
+type PresenceView struct {
+	AgentID            uuid.UUID          `json:"agent_id"`
+	Name               string             `json:"name"`
+	Status             agent.AgentStatus  `json:"status"`
+	ManualStatus       *agent.AgentStatus `json:"manual_status,omitempty"`
+	Connected          bool               `json:"connected"`
+	LastSeenAt         *time.Time         `json:"last_seen_at,omitempty"`
+	LastActivityAt     *time.Time         `json:"last_activity_at,omitempty"`
+	WithinWorkingHours bool               `json:"within_working_hours"`
+}
+
+// AgentPresenceUseCase mantém o status dos agentes humanos a partir dos heartbeats do websocket,
+// do status manual e do tempo ocioso. O status salvo no agente é o que o roteamento consulta.
+type AgentPresenceUseCase struct {
+	agentRepo    agent.Repository
+	presenceRepo agent.PresenceRepository
+	eventBus     EventBus
+	notifier     PresenceNotifier
+	policy       agent.PresencePolicy
+	batchSize    int
+}
+
+// NewAgentPresenceUseCase creates a new instance
+func NewAgentPresenceUseCase(agentRepo agent.Repository, presenceRepo agent.PresenceRepository, eventBus EventBus, notifier PresenceNotifier) *AgentPresenceUseCase {
+	return &AgentPresenceUseCase{
+		agentRepo:    agentRepo,
+		presenceRepo: presenceRepo,
+		eventBus:     eventBus,
+		notifier:     notifier,
+		policy:       agent.DefaultPresencePolicy(),
+		batchSize:    1000,
+	}
+}
+
+// Policy retorna a política de presença em uso
+func (uc *AgentPresenceUseCase) Policy() agent.PresencePolicy {
+	return uc.policy
+}
+
+// Heartbeat registra que o usuário tem conexão viva; activity indica interação real
+func (uc *AgentPresenceUseCase) Heartbeat(ctx context.Context, tenantID string, userID uuid.UUID, activity bool) error {
+	return uc.presenceRepo.Touch(ctx, tenantID, userID, time.Now(), activity)
+}
+
+// SetManualStatus grava o status escolhido pelo agente ("auto" ou "available" voltam ao automático)
+// e aplica o novo status na hora, sem esperar a próxima varredura.
+func (uc *AgentPresenceUseCase) SetManualStatus(ctx context.Context, tenantID string, agentID uuid.UUID, value string) (*PresenceView, error) {
+	status, err := agent.ParseManualStatus(value)
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "status")
+	}
+
+	a, err := uc.findAgent(ctx, tenantID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if !a.TracksPresence() {
+		return nil, shared.NewValidationError("only human agents with a user have presence", "agent_id")
+	}
+
+	now := time.Now()
+	if err := uc.presenceRepo.SetManualStatus(ctx, agentID, tenantID, status, now); err != nil {
+		return nil, err
+	}
+
+	presence, err := uc.presenceRepo.FindByAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.apply(ctx, a, *presence, now, status != nil); err != nil {
+		return nil, err
+	}
+
+	view := uc.view(a, *presence, now)
+	return &view, nil
+}
+
+// ListPresence retorna a presença dos agentes humanos do tenant
+func (uc *AgentPresenceUseCase) ListPresence(ctx context.Context, tenantID string) ([]PresenceView, error) {
+	agents, err := uc.agentRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agents: %w", err)
+	}
+	presences, err := uc.presenceRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	byAgent := make(map[uuid.UUID]agent.Presence, len(presences))
+	for _, p := range presences {
+		byAgent[p.AgentID] = p
+	}
+
+	now := time.Now()
+	views := make([]PresenceView, 0, len(agents))
+	for _, a := range agents {
+		if !a.TracksPresence() {
+			continue
+		}
+		presence, ok := byAgent[a.ID()]
+		if !ok {
+			presence = agent.Presence{AgentID: a.ID(), TenantID: tenantID}
+		}
+		views = append(views, uc.view(a, presence, now))
+	}
+	return views, nil
+}
+
+// RefreshStatuses recalcula o status dos agentes que podem ter mudado (conexão, ociosidade,
+// desconexão) e retorna quantos mudaram. Falhas em um agente não impedem os demais.
+func (uc *AgentPresenceUseCase) RefreshStatuses(ctx context.Context, now time.Time) (int, error) {
+	candidates, err := uc.presenceRepo.FindSweepCandidates(ctx, now.Add(-uc.policy.HeartbeatTimeout), uc.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	var errs []error
+	for _, presence := range candidates {
+		a, err := uc.agentRepo.FindByID(ctx, presence.AgentID)
+		if err != nil {
+			if !errors.Is(err, agent.ErrAgentNotFound) {
+				errs = append(errs, fmt.Errorf("agent %s: %w", presence.AgentID, err))
+			}
+			continue
+		}
+
+		before := a.Status()
+		if err := uc.apply(ctx, a, presence, now, false); err != nil {
+			errs = append(errs, fmt.Errorf("agent %s: %w", presence.AgentID, err))
+			continue
+		}
+		if a.Status() != before {
+			changed++
+		}
+	}
+
+	return changed, errors.Join(errs...)
+}
+
+// GetWorkingHours retorna o horário de trabalho do agente
+func (uc *AgentPresenceUseCase) GetWorkingHours(ctx context.Context, tenantID string, agentID uuid.UUID) (*agent.WorkingHours, error) {
+	a, err := uc.findAgent(ctx, tenantID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	hours := a.WorkingHours()
+	return &hours, nil
+}
+
+// UpdateWorkingHours substitui o horário de trabalho do agente
+func (uc *AgentPresenceUseCase) UpdateWorkingHours(ctx context.Context, tenantID string, agentID uuid.UUID, hours agent.WorkingHours) (*agent.WorkingHours, error) {
+	a, err := uc.findAgent(ctx, tenantID, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.SetWorkingHours(hours); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "working_hours")
+	}
+	if err := uc.agentRepo.Save(ctx, a); err != nil {
+		return nil, fmt.Errorf("failed to save agent: %w", err)
+	}
+
+	saved := a.WorkingHours()
+	return &saved, nil
+}
+
+// apply salva o status efetivo se ele mudou, publica agent.status_changed e avisa os supervisores
+func (uc *AgentPresenceUseCase) apply(ctx context.Context, a *agent.Agent, presence agent.Presence, now time.Time, manual bool) error {
+	previous := a.Status()
+	if !a.ApplyPresenceStatus(presence.EffectiveStatus(uc.policy, now), manual) {
+		return nil
+	}
+
+	if err := uc.agentRepo.Save(ctx, a); err != nil {
+		return fmt.Errorf("failed to save agent: %w", err)
+	}
+	for _, event := range a.DomainEvents() {
+		if err := uc.eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	a.ClearEvents()
+
+	if uc.notifier != nil {
+		uc.notifier.NotifyPresenceChanged(ctx, uc.supervisorUserIDs(ctx, a.TenantID()), PresenceChange{
+			AgentID:        a.ID(),
+			TenantID:       a.TenantID(),
+			Name:           a.Name(),
+			PreviousStatus: previous,
+			Status:         a.Status(),
+			Manual:         manual,
+			ChangedAt:      now,
+		})
+	}
+	return nil
+}
+
+// supervisorUserIDs retorna os usuários de supervisores e admins ativos do tenant
+func (uc *AgentPresenceUseCase) supervisorUserIDs(ctx context.Context, tenantID string) []uuid.UUID {
+	agents, err := uc.agentRepo.FindActiveByTenant(ctx, tenantID)
+	if err != nil {
+		return nil
+	}
+
+	var userIDs []uuid.UUID
+	for _, a := range agents {
+		if a.Role().CanManageAgents() && a.UserID() != nil {
+			userIDs = append(userIDs, *a.UserID())
+		}
+	}
+	return userIDs
+}
+
+func (uc *AgentPresenceUseCase) findAgent(ctx context.Context, tenantID string, agentID uuid.UUID) (*agent.Agent, error) {
+	a, err := uc.agentRepo.FindByID(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, agent.ErrAgentNotFound) {
+			return nil, shared.NewNotFoundError("agent", agentID.String())
+		}
+		return nil, fmt.Errorf("failed to load agent: %w", err)
+	}
+	if a.TenantID() != tenantID {
+		return nil, shared.NewNotFoundError("agent", agentID.String())
+	}
+	return a, nil
+}
+
+func (uc *AgentPresenceUseCase) view(a *agent.Agent, presence agent.Presence, now time.Time) PresenceView {
+	return PresenceView{
+		AgentID:            a.ID(),
+		Name:               a.Name(),
+		Status:             a.Status(),
+		ManualStatus:       presence.ManualStatus,
+		Connected:          presence.IsConnected(uc.policy, now),
+		LastSeenAt:         presence.LastSeenAt,
+		LastActivityAt:     presence.LastActivityAt,
+		WithinWorkingHours: a.WorkingHours().IsWorkingAt(now),
+	}
+}
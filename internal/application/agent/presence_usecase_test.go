@@ -0,0 +1,156 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+func newPresenceAgent(role agent.Role, status agent.AgentStatus) *agent.Agent {
+	userID := uuid.New()
+	now := time.Now()
+	return agent.ReconstructAgent(uuid.New(), 1, uuid.New(), &userID, "tenant-1", "Agent "+string(role), "",
+		agent.AgentTypeHuman, status, role, true, map[string]interface{}{}, map[string]bool{}, map[string]interface{}{},
+		0, 0, nil, nil, now, now, nil)
+}
+
+func TestAgentPresenceUseCase_RefreshStatuses(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	presenceRepo := new(MockPresenceRepository)
+	eventBus := new(MockEventBus)
+	notifier := new(MockPresenceNotifier)
+	useCase := NewAgentPresenceUseCase(agentRepo, presenceRepo, eventBus, notifier)
+
+	now := time.Now()
+	recent := now.Add(-10 * time.Second)
+	idle := now.Add(-30 * time.Minute)
+	stale := now.Add(-10 * time.Minute)
+
+	connecting := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusOffline)
+	idleAgent := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusAvailable)
+	disconnected := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusBusy)
+	unchanged := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusAvailable)
+	supervisor := newPresenceAgent(agent.RoleSupervisor, agent.AgentStatusAvailable)
+
+	presenceRepo.On("FindSweepCandidates", ctx, now.Add(-90*time.Second), 1000).Return([]agent.Presence{
+		{AgentID: connecting.ID(), LastSeenAt: &recent, LastActivityAt: &recent},
+		{AgentID: idleAgent.ID(), LastSeenAt: &recent, LastActivityAt: &idle},
+		{AgentID: disconnected.ID(), LastSeenAt: &stale, LastActivityAt: &stale},
+		{AgentID: unchanged.ID(), LastSeenAt: &recent, LastActivityAt: &recent},
+	}, nil)
+	for _, a := range []*agent.Agent{connecting, idleAgent, disconnected, unchanged} {
+		agentRepo.On("FindByID", ctx, a.ID()).Return(a, nil)
+	}
+	agentRepo.On("Save", ctx, mock.Anything).Return(nil)
+	agentRepo.On("FindActiveByTenant", ctx, "tenant-1").Return([]*agent.Agent{connecting, supervisor}, nil)
+	eventBus.On("Publish", ctx, mock.AnythingOfType("agent.AgentStatusChangedEvent")).Return(nil)
+	notifier.On("NotifyPresenceChanged", ctx, []uuid.UUID{*supervisor.UserID()}, mock.Anything).Return()
+
+	changed, err := useCase.RefreshStatuses(ctx, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, changed)
+	assert.Equal(t, agent.AgentStatusAvailable, connecting.Status())
+	assert.Equal(t, agent.AgentStatusAway, idleAgent.Status())
+	assert.Equal(t, agent.AgentStatusOffline, disconnected.Status())
+	agentRepo.AssertNumberOfCalls(t, "Save", 3)
+	eventBus.AssertNumberOfCalls(t, "Publish", 3)
+	notifier.AssertNumberOfCalls(t, "NotifyPresenceChanged", 3)
+}
+
+func TestAgentPresenceUseCase_RefreshStatuses_ContinuesAfterFailure(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	presenceRepo := new(MockPresenceRepository)
+	useCase := NewAgentPresenceUseCase(agentRepo, presenceRepo, new(MockEventBus), nil)
+
+	now := time.Now()
+	broken := uuid.New()
+	removed := uuid.New()
+	presenceRepo.On("FindSweepCandidates", ctx, mock.Anything, 1000).Return([]agent.Presence{
+		{AgentID: broken}, {AgentID: removed},
+	}, nil)
+	agentRepo.On("FindByID", ctx, broken).Return(nil, errors.New("db down"))
+	agentRepo.On("FindByID", ctx, removed).Return(nil, agent.ErrAgentNotFound)
+
+	changed, err := useCase.RefreshStatuses(ctx, now)
+
+	assert.Equal(t, 0, changed)
+	assert.ErrorContains(t, err, "db down")
+	agentRepo.AssertNumberOfCalls(t, "FindByID", 2)
+}
+
+func TestAgentPresenceUseCase_SetManualStatus(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	presenceRepo := new(MockPresenceRepository)
+	eventBus := new(MockEventBus)
+	useCase := NewAgentPresenceUseCase(agentRepo, presenceRepo, eventBus, nil)
+
+	a := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusAvailable)
+	seen := time.Now()
+	busy := agent.AgentStatusBusy
+
+	agentRepo.On("FindByID", ctx, a.ID()).Return(a, nil)
+	presenceRepo.On("SetManualStatus", ctx, a.ID(), "tenant-1", &busy, mock.Anything).Return(nil)
+	presenceRepo.On("FindByAgent", ctx, a.ID()).Return(&agent.Presence{AgentID: a.ID(), LastSeenAt: &seen, LastActivityAt: &seen, ManualStatus: &busy}, nil)
+	agentRepo.On("Save", ctx, a).Return(nil)
+	eventBus.On("Publish", ctx, mock.MatchedBy(func(e agent.AgentStatusChangedEvent) bool {
+		return e.Manual && e.Status == agent.AgentStatusBusy && e.PreviousStatus == agent.AgentStatusAvailable
+	})).Return(nil)
+
+	view, err := useCase.SetManualStatus(ctx, "tenant-1", a.ID(), "busy")
+	require.NoError(t, err)
+
+	assert.Equal(t, agent.AgentStatusBusy, view.Status)
+	assert.Equal(t, &busy, view.ManualStatus)
+	assert.True(t, view.Connected)
+	assert.False(t, a.IsAvailableForRouting())
+	eventBus.AssertExpectations(t)
+}
+
+func TestAgentPresenceUseCase_SetManualStatus_Validation(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	useCase := NewAgentPresenceUseCase(agentRepo, new(MockPresenceRepository), new(MockEventBus), nil)
+
+	_, err := useCase.SetManualStatus(ctx, "tenant-1", uuid.New(), "lunch")
+	assert.True(t, shared.IsValidationError(err))
+
+	other := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusAvailable)
+	agentRepo.On("FindByID", ctx, other.ID()).Return(other, nil)
+
+	_, err = useCase.SetManualStatus(ctx, "tenant-2", other.ID(), "busy")
+	assert.True(t, shared.IsNotFoundError(err), "agents from other tenants are not visible")
+}
+
+func TestAgentPresenceUseCase_UpdateWorkingHours(t *testing.T) {
+	ctx := context.Background()
+	agentRepo := new(MockAgentRepository)
+	useCase := NewAgentPresenceUseCase(agentRepo, new(MockPresenceRepository), new(MockEventBus), nil)
+
+	a := newPresenceAgent(agent.RoleHumanAgent, agent.AgentStatusAvailable)
+	agentRepo.On("FindByID", ctx, a.ID()).Return(a, nil)
+	agentRepo.On("Save", ctx, a).Return(nil)
+
+	hours := agent.WorkingHours{
+		Timezone: "America/Sao_Paulo",
+		Weekly:   map[string][]agent.TimeRange{"monday": {{Start: "09:00", End: "18:00"}}},
+	}
+	saved, err := useCase.UpdateWorkingHours(ctx, "tenant-1", a.ID(), hours)
+	require.NoError(t, err)
+	assert.Equal(t, hours, *saved)
+
+	_, err = useCase.UpdateWorkingHours(ctx, "tenant-1", a.ID(), agent.WorkingHours{Timezone: "nowhere"})
+	assert.True(t, shared.IsValidationError(err))
+	agentRepo.AssertNumberOfCalls(t, "Save", 1)
+}
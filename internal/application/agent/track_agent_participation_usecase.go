@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/agent_session"
+)
+
+// TrackAgentParticipationUseCase mantém agent_sessions a partir dos eventos de sessão.
+// É a fonte das métricas de desempenho: cada assignment abre uma participação e encerra
+// a do agente anterior (transferência); o fim da sessão encerra as participações ativas.
+type TrackAgentParticipationUseCase struct {
+	agentSessionRepo agent_session.Repository
+	txManager        TransactionManager
+}
+
+// NewTrackAgentParticipationUseCase creates a new instance
+func NewTrackAgentParticipationUseCase(agentSessionRepo agent_session.Repository, txManager TransactionManager) *TrackAgentParticipationUseCase {
+	return &TrackAgentParticipationUseCase{
+		agentSessionRepo: agentSessionRepo,
+		txManager:        txManager,
+	}
+}
+
+// AgentAssignedInput dados do evento session.agent_assigned
+type AgentAssignedInput struct {
+	SessionID  uuid.UUID
+	AgentID    uuid.UUID
+	AssignedAt time.Time
+}
+
+// HandleAgentAssigned registra a entrada do agente na sessão. Idempotente: reentregas do
+// mesmo evento não criam participações duplicadas.
+func (uc *TrackAgentParticipationUseCase) HandleAgentAssigned(ctx context.Context, input AgentAssignedInput) error {
+	if input.SessionID == uuid.Nil || input.AgentID == uuid.Nil {
+		return fmt.Errorf("session_id and agent_id are required")
+	}
+	if input.AssignedAt.IsZero() {
+		input.AssignedAt = time.Now()
+	}
+
+	return uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		active, err := uc.agentSessionRepo.FindActiveBySessionID(txCtx, input.SessionID)
+		if err != nil {
+			return fmt.Errorf("failed to load active participations: %w", err)
+		}
+
+		for _, participation := range active {
+			if participation.AgentID() == input.AgentID {
+				return nil
+			}
+		}
+
+		// Transferência: o agente anterior deixa a sessão quando o novo assume
+		for _, participation := range active {
+			if err := participation.LeaveAt(input.AssignedAt); err != nil {
+				return err
+			}
+			if err := uc.agentSessionRepo.Update(txCtx, participation); err != nil {
+				return fmt.Errorf("failed to close previous participation: %w", err)
+			}
+		}
+
+		role := agent_session.RolePrimary
+		participation, err := agent_session.NewAgentSessionAt(input.AgentID, input.SessionID, &role, input.AssignedAt)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.agentSessionRepo.Create(txCtx, participation); err != nil {
+			return fmt.Errorf("failed to create participation: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// HandleSessionEnded encerra as participações ativas da sessão
+func (uc *TrackAgentParticipationUseCase) HandleSessionEnded(ctx context.Context, sessionID uuid.UUID, endedAt time.Time) error {
+	if endedAt.IsZero() {
+		endedAt = time.Now()
+	}
+
+	return uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		active, err := uc.agentSessionRepo.FindActiveBySessionID(txCtx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to load active participations: %w", err)
+		}
+
+		for _, participation := range active {
+			if err := participation.LeaveAt(endedAt); err != nil {
+				return err
+			}
+			if err := uc.agentSessionRepo.Update(txCtx, participation); err != nil {
+				return fmt.Errorf("failed to close participation: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
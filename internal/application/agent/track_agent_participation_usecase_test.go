@@ -0,0 +1,97 @@
+package agent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/agent_session"
+)
+
+func TestTrackAgentParticipationUseCase_HandleAgentAssigned_FirstAgent(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAgentSessionRepository)
+	useCase := NewTrackAgentParticipationUseCase(repo, &SimpleTransactionManager{})
+
+	sessionID, agentID := uuid.New(), uuid.New()
+	assignedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+
+	repo.On("FindActiveBySessionID", mock.Anything, sessionID).Return([]*agent_session.AgentSession{}, nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(as *agent_session.AgentSession) bool {
+		return as.AgentID() == agentID && as.SessionID() == sessionID && as.JoinedAt().Equal(assignedAt) && as.IsActive()
+	})).Return(nil)
+
+	err := useCase.HandleAgentAssigned(ctx, AgentAssignedInput{SessionID: sessionID, AgentID: agentID, AssignedAt: assignedAt})
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestTrackAgentParticipationUseCase_HandleAgentAssigned_TransferClosesPrevious(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAgentSessionRepository)
+	useCase := NewTrackAgentParticipationUseCase(repo, &SimpleTransactionManager{})
+
+	sessionID, previousAgentID, newAgentID := uuid.New(), uuid.New(), uuid.New()
+	joinedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	assignedAt := joinedAt.Add(15 * time.Minute)
+
+	previous := agent_session.ReconstructAgentSession(uuid.New(), previousAgentID, sessionID, nil, joinedAt, nil, true, nil, joinedAt, joinedAt)
+
+	repo.On("FindActiveBySessionID", mock.Anything, sessionID).Return([]*agent_session.AgentSession{previous}, nil)
+	repo.On("Update", mock.Anything, previous).Return(nil)
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(as *agent_session.AgentSession) bool {
+		return as.AgentID() == newAgentID
+	})).Return(nil)
+
+	err := useCase.HandleAgentAssigned(ctx, AgentAssignedInput{SessionID: sessionID, AgentID: newAgentID, AssignedAt: assignedAt})
+
+	require.NoError(t, err)
+	assert.False(t, previous.IsActive())
+	require.NotNil(t, previous.LeftAt())
+	assert.Equal(t, assignedAt, *previous.LeftAt())
+	repo.AssertExpectations(t)
+}
+
+func TestTrackAgentParticipationUseCase_HandleAgentAssigned_Idempotent(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAgentSessionRepository)
+	useCase := NewTrackAgentParticipationUseCase(repo, &SimpleTransactionManager{})
+
+	sessionID, agentID := uuid.New(), uuid.New()
+	now := time.Now()
+	existing := agent_session.ReconstructAgentSession(uuid.New(), agentID, sessionID, nil, now, nil, true, nil, now, now)
+
+	repo.On("FindActiveBySessionID", mock.Anything, sessionID).Return([]*agent_session.AgentSession{existing}, nil)
+
+	err := useCase.HandleAgentAssigned(ctx, AgentAssignedInput{SessionID: sessionID, AgentID: agentID, AssignedAt: now})
+
+	require.NoError(t, err)
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestTrackAgentParticipationUseCase_HandleSessionEnded(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockAgentSessionRepository)
+	useCase := NewTrackAgentParticipationUseCase(repo, &SimpleTransactionManager{})
+
+	sessionID := uuid.New()
+	joinedAt := time.Date(2025, 5, 1, 10, 0, 0, 0, time.UTC)
+	endedAt := joinedAt.Add(time.Hour)
+	active := agent_session.ReconstructAgentSession(uuid.New(), uuid.New(), sessionID, nil, joinedAt, nil, true, nil, joinedAt, joinedAt)
+
+	repo.On("FindActiveBySessionID", mock.Anything, sessionID).Return([]*agent_session.AgentSession{active}, nil)
+	repo.On("Update", mock.Anything, active).Return(nil)
+
+	err := useCase.HandleSessionEnded(ctx, sessionID, endedAt)
+
+	require.NoError(t, err)
+	assert.False(t, active.IsActive())
+	assert.Equal(t, endedAt, *active.LeftAt())
+	repo.AssertExpectations(t)
+}
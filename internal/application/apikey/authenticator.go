@@ -0,0 +1,108 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidAPIKey resposta única para qualquer falha de autenticação por chave, para não
+// revelar se a chave existe, foi revogada ou expirou (o motivo real vai para o log)
+var ErrInvalidAPIKey = errors.New("invalid api key")
+
+// Principal identidade de uma requisição autenticada por API key
+type Principal struct {
+	KeyID       uuid.UUID
+	Name        string
+	TenantID    string
+	ProjectID   uuid.UUID
+	UserID      uuid.UUID
+	Permissions []project_member.Permission
+}
+
+// HasPermission indica se a chave recebeu a permissão
+func (p *Principal) HasPermission(permission project_member.Permission) bool {
+	return containsPermission(p.Permissions, permission)
+}
+
+// Authenticator valida os tokens vtr_... e registra o último uso no buffer
+type Authenticator struct {
+	repo   apikey.Repository
+	buffer UsageBuffer
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+func NewAuthenticator(repo apikey.Repository, buffer UsageBuffer, logger *zap.Logger) *Authenticator {
+	return &Authenticator{
+		repo:   repo,
+		buffer: buffer,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Authenticate valida o token contra o hash guardado, a validade, a revogação e a allow-list de IPs
+func (a *Authenticator) Authenticate(ctx context.Context, token, clientIP string) (*Principal, error) {
+	if !apikey.IsToken(token) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	k, err := a.repo.FindByHash(ctx, apikey.HashToken(token))
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, fmt.Errorf("failed to load api key: %w", err)
+	}
+
+	now := a.now()
+	if err := k.Validate(now, clientIP); err != nil {
+		a.logger.Info("API key rejected",
+			zap.String("api_key_id", k.ID().String()),
+			zap.String("prefix", k.Prefix()),
+			zap.String("ip", clientIP),
+			zap.String("reason", err.Error()))
+		return nil, ErrInvalidAPIKey
+	}
+
+	if a.buffer != nil {
+		if err := a.buffer.Track(ctx, apikey.Usage{KeyID: k.ID(), At: now, IP: clientIP}); err != nil {
+			a.logger.Warn("Failed to track API key usage", zap.Error(err), zap.String("api_key_id", k.ID().String()))
+		}
+	}
+
+	return &Principal{
+		KeyID:       k.ID(),
+		Name:        k.Name(),
+		TenantID:    k.TenantID(),
+		ProjectID:   k.ProjectID(),
+		UserID:      k.UserID(),
+		Permissions: k.Permissions(),
+	}, nil
+}
+
+// FlushUsage grava no banco, em um único UPDATE, os usos acumulados desde o último flush
+func (a *Authenticator) FlushUsage(ctx context.Context) (int, error) {
+	if a.buffer == nil {
+		return 0, nil
+	}
+	usages, err := a.buffer.Drain(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to drain api key usage: %w", err)
+	}
+	if len(usages) == 0 {
+		return 0, nil
+	}
+	if err := a.repo.RecordUsage(ctx, usages); err != nil {
+		// Perder um lote só atrasa o last_used; o próximo uso da chave o corrige
+		return 0, err
+	}
+	return len(usages), nil
+}
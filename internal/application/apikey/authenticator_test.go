@@ -0,0 +1,127 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+func newKey(t *testing.T, details apikey.Details) (*apikey.APIKey, string) {
+	t.Helper()
+	if details.Name == "" {
+		details.Name = "ERP"
+	}
+	if details.Permissions == nil {
+		details.Permissions = []project_member.Permission{project_member.PermissionViewContacts}
+	}
+	k, token, err := apikey.NewAPIKey("tenant-1", uuid.New(), uuid.New(), details)
+	require.NoError(t, err)
+	return k, token
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid key tracks usage", func(t *testing.T) {
+		k, token := newKey(t, apikey.Details{AllowedIPs: []string{"203.0.113.0/24"}})
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, apikey.HashToken(token)).Return(k, nil)
+		buffer := new(MockUsageBuffer)
+		buffer.On("Track", ctx, mock.MatchedBy(func(u apikey.Usage) bool {
+			return u.KeyID == k.ID() && u.IP == "203.0.113.9" && !u.At.IsZero()
+		})).Return(nil).Once()
+
+		principal, err := NewAuthenticator(repo, buffer, zap.NewNop()).Authenticate(ctx, token, "203.0.113.9")
+
+		require.NoError(t, err)
+		assert.Equal(t, k.ID(), principal.KeyID)
+		assert.Equal(t, k.ProjectID(), principal.ProjectID)
+		assert.Equal(t, "tenant-1", principal.TenantID)
+		assert.True(t, principal.HasPermission(project_member.PermissionViewContacts))
+		assert.False(t, principal.HasPermission(project_member.PermissionManageContacts))
+		buffer.AssertExpectations(t)
+	})
+
+	t.Run("tracking failure does not block the request", func(t *testing.T) {
+		k, token := newKey(t, apikey.Details{})
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, apikey.HashToken(token)).Return(k, nil)
+		buffer := new(MockUsageBuffer)
+		buffer.On("Track", ctx, mock.Anything).Return(errors.New("redis down"))
+
+		_, err := NewAuthenticator(repo, buffer, zap.NewNop()).Authenticate(ctx, token, "10.0.0.1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("rejections share one error", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		expiring, expiringToken := newKey(t, apikey.Details{ExpiresAt: &expiresAt})
+		restricted, restrictedToken := newKey(t, apikey.Details{AllowedIPs: []string{"203.0.113.7"}})
+		revoked, revokedToken := newKey(t, apikey.Details{})
+		require.NoError(t, revoked.Revoke(uuid.New()))
+
+		repo := new(MockAPIKeyRepository)
+		repo.On("FindByHash", ctx, apikey.HashToken(expiringToken)).Return(expiring, nil)
+		repo.On("FindByHash", ctx, apikey.HashToken(restrictedToken)).Return(restricted, nil)
+		repo.On("FindByHash", ctx, apikey.HashToken(revokedToken)).Return(revoked, nil)
+		repo.On("FindByHash", ctx, mock.Anything).Return(nil, apikey.ErrAPIKeyNotFound)
+		buffer := new(MockUsageBuffer)
+
+		auth := NewAuthenticator(repo, buffer, zap.NewNop())
+		auth.now = func() time.Time { return expiresAt.Add(time.Second) }
+		_, err := auth.Authenticate(ctx, expiringToken, "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, "expired")
+
+		auth.now = time.Now
+		_, err = auth.Authenticate(ctx, restrictedToken, "198.51.100.1")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, "ip not allowed")
+		_, err = auth.Authenticate(ctx, revokedToken, "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, "revoked")
+		_, err = auth.Authenticate(ctx, apikey.TokenPrefix+"unknown-token-value", "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, "unknown")
+		_, err = auth.Authenticate(ctx, "0123456789abcdef", "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, "legacy format is not looked up")
+
+		buffer.AssertNotCalled(t, "Track", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAuthenticator_FlushUsage(t *testing.T) {
+	ctx := context.Background()
+	usages := []apikey.Usage{{KeyID: uuid.New(), At: time.Now(), IP: "10.0.0.1"}}
+
+	t.Run("writes the drained batch", func(t *testing.T) {
+		buffer := new(MockUsageBuffer)
+		buffer.On("Drain", ctx).Return(usages, nil).Once()
+		repo := new(MockAPIKeyRepository)
+		repo.On("RecordUsage", ctx, usages).Return(nil).Once()
+
+		flushed, err := NewAuthenticator(repo, buffer, zap.NewNop()).FlushUsage(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, flushed)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("empty buffer skips the database", func(t *testing.T) {
+		buffer := new(MockUsageBuffer)
+		buffer.On("Drain", ctx).Return([]apikey.Usage{}, nil).Once()
+		repo := new(MockAPIKeyRepository)
+
+		flushed, err := NewAuthenticator(repo, buffer, zap.NewNop()).FlushUsage(ctx)
+
+		require.NoError(t, err)
+		assert.Zero(t, flushed)
+		repo.AssertNotCalled(t, "RecordUsage", mock.Anything, mock.Anything)
+	})
+}
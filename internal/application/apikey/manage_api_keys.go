@@ -0,0 +1,248 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// Status situação da chave exibida na listagem
+const (
+	StatusActive  = "active"
+	StatusExpired = "expired"
+	StatusRevoked = "revoked"
+)
+
+// APIKeyView chave exposta pela API (nunca inclui o token nem o hash)
+type APIKeyView struct {
+	ID          uuid.UUID                   `json:"id"`
+	ProjectID   uuid.UUID                   `json:"project_id"`
+	Name        string                      `json:"name"`
+	Prefix      string                      `json:"prefix"`
+	Permissions []project_member.Permission `json:"permissions"`
+	AllowedIPs  []string                    `json:"allowed_ips"`
+	Status      string                      `json:"status"`
+	ExpiresAt   *time.Time                  `json:"expires_at,omitempty"`
+	LastUsedAt  *time.Time                  `json:"last_used_at,omitempty"`
+	LastUsedIP  string                      `json:"last_used_ip,omitempty"`
+	RevokedAt   *time.Time                  `json:"revoked_at,omitempty"`
+	CreatedBy   uuid.UUID                   `json:"created_by"`
+	CreatedAt   time.Time                   `json:"created_at"`
+}
+
+// CreatedAPIKeyView chave recém-criada, com o token em claro (mostrado uma única vez)
+type CreatedAPIKeyView struct {
+	APIKeyView
+	Token string `json:"token"`
+}
+
+func newAPIKeyView(k *apikey.APIKey, now time.Time) APIKeyView {
+	status := StatusActive
+	switch {
+	case k.RevokedAt() != nil:
+		status = StatusRevoked
+	case k.IsExpired(now):
+		status = StatusExpired
+	}
+	return APIKeyView{
+		ID:          k.ID(),
+		ProjectID:   k.ProjectID(),
+		Name:        k.Name(),
+		Prefix:      k.Prefix(),
+		Permissions: k.Permissions(),
+		AllowedIPs:  k.AllowedIPs(),
+		Status:      status,
+		ExpiresAt:   k.ExpiresAt(),
+		LastUsedAt:  k.LastUsedAt(),
+		LastUsedIP:  k.LastUsedIP(),
+		RevokedAt:   k.RevokedAt(),
+		CreatedBy:   k.UserID(),
+		CreatedAt:   k.CreatedAt(),
+	}
+}
+
+// CreateAPIKeyCommand dados de criação da chave
+type CreateAPIKeyCommand struct {
+	TenantID    string
+	UserID      uuid.UUID
+	ProjectID   uuid.UUID
+	Name        string
+	Permissions []project_member.Permission
+	AllowedIPs  []string
+	ExpiresAt   *time.Time
+	// GrantablePermissions permissões de quem cria (do papel no projeto ou da API key): a chave não
+	// vai além delas (nil = sem limite)
+	GrantablePermissions []project_member.Permission
+}
+
+// RevokeAPIKeyCommand revogação de uma chave
+type RevokeAPIKeyCommand struct {
+	TenantID string
+	UserID   uuid.UUID
+	APIKeyID uuid.UUID
+	// ProjectID restringe a revogação às chaves do projeto (uuid.Nil = qualquer projeto do tenant)
+	ProjectID uuid.UUID
+}
+
+// ManageAPIKeysUseCase criação, listagem e revogação das API keys de um projeto
+type ManageAPIKeysUseCase struct {
+	repo        apikey.Repository
+	projectRepo project.Repository
+	eventBus    EventBus
+	txManager   TransactionManager
+	logger      *zap.Logger
+}
+
+func NewManageAPIKeysUseCase(
+	repo apikey.Repository,
+	projectRepo project.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	logger *zap.Logger,
+) *ManageAPIKeysUseCase {
+	return &ManageAPIKeysUseCase{
+		repo:        repo,
+		projectRepo: projectRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		logger:      logger,
+	}
+}
+
+// Create gera a chave e devolve o token em claro; depois disso só o prefixo fica visível
+func (uc *ManageAPIKeysUseCase) Create(ctx context.Context, cmd CreateAPIKeyCommand) (*CreatedAPIKeyView, error) {
+	if err := checkProject(ctx, uc.projectRepo, cmd.TenantID, cmd.ProjectID); err != nil {
+		return nil, err
+	}
+	if cmd.GrantablePermissions != nil {
+		for _, p := range cmd.Permissions {
+			if !containsPermission(cmd.GrantablePermissions, p) {
+				return nil, shared.NewForbiddenError(fmt.Sprintf("cannot grant permission %s: you do not have it", p))
+			}
+		}
+	}
+
+	k, token, err := apikey.NewAPIKey(cmd.TenantID, cmd.ProjectID, cmd.UserID, apikey.Details{
+		Name:        cmd.Name,
+		Permissions: cmd.Permissions,
+		AllowedIPs:  cmd.AllowedIPs,
+		ExpiresAt:   cmd.ExpiresAt,
+	})
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if err := uc.save(ctx, k); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("API key created",
+		zap.String("api_key_id", k.ID().String()),
+		zap.String("prefix", k.Prefix()),
+		zap.String("project_id", k.ProjectID().String()),
+		zap.String("user_id", k.UserID().String()))
+
+	return &CreatedAPIKeyView{APIKeyView: newAPIKeyView(k, time.Now()), Token: token}, nil
+}
+
+// List chaves do projeto; includeRevoked traz também as revogadas
+func (uc *ManageAPIKeysUseCase) List(ctx context.Context, tenantID string, projectID uuid.UUID, includeRevoked bool) ([]APIKeyView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	keys, err := uc.repo.ListByProject(ctx, projectID, includeRevoked)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	views := make([]APIKeyView, len(keys))
+	for i, k := range keys {
+		views[i] = newAPIKeyView(k, now)
+	}
+	return views, nil
+}
+
+// Revoke invalida a chave; requisições em andamento com ela falham a partir da próxima
+func (uc *ManageAPIKeysUseCase) Revoke(ctx context.Context, cmd RevokeAPIKeyCommand) (*APIKeyView, error) {
+	k, err := uc.repo.FindByID(ctx, cmd.APIKeyID)
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return nil, shared.NewNotFoundError("api_key", cmd.APIKeyID.String())
+		}
+		return nil, fmt.Errorf("failed to load api key: %w", err)
+	}
+	if k.TenantID() != cmd.TenantID || (cmd.ProjectID != uuid.Nil && k.ProjectID() != cmd.ProjectID) {
+		return nil, shared.NewNotFoundError("api_key", cmd.APIKeyID.String())
+	}
+	if err := k.Revoke(cmd.UserID); err != nil {
+		return nil, shared.NewConflictError(err.Error())
+	}
+	if err := uc.save(ctx, k); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Info("API key revoked",
+		zap.String("api_key_id", k.ID().String()),
+		zap.String("prefix", k.Prefix()),
+		zap.String("revoked_by", cmd.UserID.String()))
+
+	view := newAPIKeyView(k, time.Now())
+	return &view, nil
+}
+
+func (uc *ManageAPIKeysUseCase) save(ctx context.Context, k *apikey.APIKey) error {
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.repo.Save(txCtx, k); err != nil {
+			return fmt.Errorf("failed to save api key: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, k)
+	})
+	if err != nil {
+		return err
+	}
+	k.ClearEvents()
+	return nil
+}
+
+func checkProject(ctx context.Context, projectRepo project.Repository, tenantID string, projectID uuid.UUID) error {
+	if projectID == uuid.Nil {
+		return shared.NewValidationError("project_id is required", "project_id")
+	}
+	proj, err := projectRepo.FindByID(ctx, projectID)
+	if err != nil || proj == nil || proj.TenantID() != tenantID {
+		return shared.NewNotFoundError("project", projectID.String())
+	}
+	return nil
+}
+
+// validationError traduz os erros do domínio para erros de validação com o campo
+func validationError(err error) error {
+	field := ""
+	switch {
+	case errors.Is(err, apikey.ErrEmptyName), errors.Is(err, apikey.ErrNameTooLong):
+		field = "name"
+	case errors.Is(err, apikey.ErrNoPermissions), errors.Is(err, apikey.ErrInvalidPermission):
+		field = "permissions"
+	case errors.Is(err, apikey.ErrInvalidAllowedIP), errors.Is(err, apikey.ErrTooManyAllowedIPs):
+		field = "allowed_ips"
+	case errors.Is(err, apikey.ErrExpiryInPast):
+		field = "expires_at"
+	}
+	return shared.NewValidationError(err.Error(), field)
+}
+
+func containsPermission(permissions []project_member.Permission, permission project_member.Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
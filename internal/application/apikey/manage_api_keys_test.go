@@ -0,0 +1,120 @@
+package apikey
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+func newProject(t *testing.T, tenantID string) *project.Project {
+	t.Helper()
+	proj, err := project.NewProject(uuid.New(), uuid.New(), tenantID, "Loja Centro")
+	require.NoError(t, err)
+	return proj
+}
+
+func TestManageAPIKeys_Create(t *testing.T) {
+	ctx := context.Background()
+	proj := newProject(t, "tenant-1")
+	projects := new(MockProjectRepository)
+	projects.On("FindByID", ctx, proj.ID()).Return(proj, nil)
+
+	t.Run("returns the token once and stores only the hash", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		var saved *apikey.APIKey
+		repo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) { saved = args.Get(1).(*apikey.APIKey) }).Return(nil).Once()
+		eventBus := new(MockEventBus)
+		eventBus.On("Publish", ctx, mock.MatchedBy(func(e shared.DomainEvent) bool { return e.EventName() == "api_key.created" })).Return(nil).Once()
+
+		uc := NewManageAPIKeysUseCase(repo, projects, eventBus, &SimpleTransactionManager{}, zap.NewNop())
+		created, err := uc.Create(ctx, CreateAPIKeyCommand{
+			TenantID:    "tenant-1",
+			UserID:      uuid.New(),
+			ProjectID:   proj.ID(),
+			Name:        "ERP",
+			Permissions: []project_member.Permission{project_member.PermissionViewContacts},
+		})
+
+		require.NoError(t, err)
+		assert.True(t, apikey.IsToken(created.Token))
+		assert.Equal(t, StatusActive, created.Status)
+		assert.Equal(t, apikey.HashToken(created.Token), saved.KeyHash())
+		assert.Equal(t, created.Token[:apikey.DisplayPrefixLength], created.Prefix)
+		assert.Empty(t, saved.DomainEvents(), "events are cleared after publishing")
+		eventBus.AssertExpectations(t)
+	})
+
+	t.Run("api key cannot grant more than it has", func(t *testing.T) {
+		repo := new(MockAPIKeyRepository)
+		uc := NewManageAPIKeysUseCase(repo, projects, new(MockEventBus), &SimpleTransactionManager{}, zap.NewNop())
+
+		_, err := uc.Create(ctx, CreateAPIKeyCommand{
+			TenantID:             "tenant-1",
+			UserID:               uuid.New(),
+			ProjectID:            proj.ID(),
+			Name:                 "Escalada",
+			Permissions:          []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionExportContacts},
+			GrantablePermissions: []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionManageSettings},
+		})
+
+		assert.True(t, shared.IsForbiddenError(err))
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("validation and tenant isolation", func(t *testing.T) {
+		uc := NewManageAPIKeysUseCase(new(MockAPIKeyRepository), projects, new(MockEventBus), &SimpleTransactionManager{}, zap.NewNop())
+
+		_, err := uc.Create(ctx, CreateAPIKeyCommand{TenantID: "tenant-1", UserID: uuid.New(), ProjectID: proj.ID(), Name: "Sem escopo"})
+		assert.True(t, shared.IsValidationError(err))
+
+		_, err = uc.Create(ctx, CreateAPIKeyCommand{
+			TenantID: "tenant-2", UserID: uuid.New(), ProjectID: proj.ID(), Name: "Outro tenant",
+			Permissions: []project_member.Permission{project_member.PermissionViewContacts},
+		})
+		assert.True(t, shared.IsNotFoundError(err))
+	})
+}
+
+func TestManageAPIKeys_Revoke(t *testing.T) {
+	ctx := context.Background()
+	k, _, err := apikey.NewAPIKey("tenant-1", uuid.New(), uuid.New(), apikey.Details{
+		Name:        "ERP",
+		Permissions: []project_member.Permission{project_member.PermissionViewContacts},
+	})
+	require.NoError(t, err)
+	k.ClearEvents()
+
+	repo := new(MockAPIKeyRepository)
+	repo.On("FindByID", ctx, k.ID()).Return(k, nil)
+	repo.On("Save", ctx, k).Return(nil)
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.Anything).Return(nil)
+	uc := NewManageAPIKeysUseCase(repo, new(MockProjectRepository), eventBus, &SimpleTransactionManager{}, zap.NewNop())
+
+	_, err = uc.Revoke(ctx, RevokeAPIKeyCommand{TenantID: "tenant-2", UserID: uuid.New(), APIKeyID: k.ID()})
+	assert.True(t, shared.IsNotFoundError(err), "other tenant")
+
+	_, err = uc.Revoke(ctx, RevokeAPIKeyCommand{TenantID: "tenant-1", UserID: uuid.New(), APIKeyID: k.ID(), ProjectID: uuid.New()})
+	assert.True(t, shared.IsNotFoundError(err), "api key of another project")
+
+	view, err := uc.Revoke(ctx, RevokeAPIKeyCommand{TenantID: "tenant-1", UserID: uuid.New(), APIKeyID: k.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, StatusRevoked, view.Status)
+	assert.NotNil(t, view.RevokedAt)
+	assert.ErrorIs(t, k.Validate(time.Now(), ""), apikey.ErrAPIKeyRevoked)
+
+	_, err = uc.Revoke(ctx, RevokeAPIKeyCommand{TenantID: "tenant-1", UserID: uuid.New(), APIKeyID: k.ID()})
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, shared.ErrorTypeConflict, domainErr.Type)
+}
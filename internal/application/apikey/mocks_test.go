@@ -0,0 +1,138 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// ========== Shared Mocks for apikey package tests ==========
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) Save(ctx context.Context, k *apikey.APIKey) error {
+	args := m.Called(ctx, k)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) FindByID(ctx context.Context, id uuid.UUID) (*apikey.APIKey, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikey.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikey.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) ListByProject(ctx context.Context, projectID uuid.UUID, includeRevoked bool) ([]*apikey.APIKey, error) {
+	args := m.Called(ctx, projectID, includeRevoked)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apikey.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RecordUsage(ctx context.Context, usages []apikey.Usage) error {
+	args := m.Called(ctx, usages)
+	return args.Error(0)
+}
+
+type MockUsageBuffer struct {
+	mock.Mock
+}
+
+func (m *MockUsageBuffer) Track(ctx context.Context, usage apikey.Usage) error {
+	args := m.Called(ctx, usage)
+	return args.Error(0)
+}
+
+func (m *MockUsageBuffer) Drain(ctx context.Context) ([]apikey.Usage, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]apikey.Usage), args.Error(1)
+}
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) Save(ctx context.Context, p *project.Project) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*project.Project, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FindByTenantWithFilters(ctx context.Context, filters project.ProjectFilters) ([]*project.Project, int64, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*project.Project), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProjectRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*project.Project, int64, error) {
+	args := m.Called(ctx, tenantID, searchText, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*project.Project), args.Get(1).(int64), args.Error(2)
+}
+
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// SimpleTransactionManager is a test transaction manager that just executes the function
+type SimpleTransactionManager struct{}
+
+func (m *SimpleTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
@@ -0,0 +1,35 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ventros/crm/internal/domain/core/apikey"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// UsageBuffer acumula o último uso de cada chave entre um flush e outro, para que a
+// autenticação não faça um UPDATE por requisição. Track sobrescreve o uso anterior da chave.
+type UsageBuffer interface {
+	Track(ctx context.Context, usage apikey.Usage) error
+	// Drain devolve e remove atomicamente os usos acumulados
+	Drain(ctx context.Context) ([]apikey.Usage, error)
+}
+
+// publishEvents publica os eventos pendentes da chave (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, k *apikey.APIKey) error {
+	for _, event := range k.DomainEvents() {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+		}
+	}
+	return nil
+}
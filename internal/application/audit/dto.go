@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/audit"
+)
+
+// EntryDTO registro da trilha de auditoria
+type EntryDTO struct {
+	ID            uuid.UUID               `json:"id"`
+	ProjectID     uuid.UUID               `json:"project_id"`
+	Sequence      int64                   `json:"sequence" example:"42"`
+	ActorType     audit.ActorType         `json:"actor_type" example:"user"`
+	ActorID       string                  `json:"actor_id"`
+	ActorLabel    string                  `json:"actor_label,omitempty" example:"ana@example.com"`
+	Action        string                  `json:"action" example:"channels.delete"`
+	ResourceType  string                  `json:"resource_type,omitempty" example:"channels"`
+	ResourceID    string                  `json:"resource_id,omitempty"`
+	Status        int                     `json:"status,omitempty" example:"204"`
+	IP            string                  `json:"ip,omitempty" example:"203.0.113.9"`
+	UserAgent     string                  `json:"user_agent,omitempty"`
+	CorrelationID string                  `json:"correlation_id,omitempty"`
+	Changes       map[string]audit.Change `json:"changes,omitempty" swaggertype:"object"`
+	Metadata      map[string]string       `json:"metadata,omitempty" swaggertype:"object"`
+	OccurredAt    time.Time               `json:"occurred_at"`
+	PrevHash      string                  `json:"prev_hash,omitempty"`
+	Hash          string                  `json:"hash"`
+}
+
+func ToEntryDTO(e *audit.Entry) EntryDTO {
+	actor := e.Actor()
+	return EntryDTO{
+		ID:            e.ID(),
+		ProjectID:     e.ProjectID(),
+		Sequence:      e.Sequence(),
+		ActorType:     actor.Type,
+		ActorID:       actor.ID,
+		ActorLabel:    actor.Label,
+		Action:        e.Action(),
+		ResourceType:  e.ResourceType(),
+		ResourceID:    e.ResourceID(),
+		Status:        e.Status(),
+		IP:            e.IP(),
+		UserAgent:     e.UserAgent(),
+		CorrelationID: e.CorrelationID(),
+		Changes:       e.Changes(),
+		Metadata:      e.Metadata(),
+		OccurredAt:    e.OccurredAt(),
+		PrevHash:      e.PrevHash(),
+		Hash:          e.Hash(),
+	}
+}
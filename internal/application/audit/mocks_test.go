@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"context"
+	"sort"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/audit"
+)
+
+// fakeAuditRepository trilha em memória com o mesmo encadeamento por projeto do repositório GORM
+type fakeAuditRepository struct {
+	entries []*audit.Entry
+}
+
+func (r *fakeAuditRepository) Append(ctx context.Context, entry *audit.Entry) error {
+	var last *audit.Entry
+	for _, e := range r.entries {
+		if e.ProjectID() == entry.ProjectID() {
+			last = e
+		}
+	}
+	if err := entry.Link(last); err != nil {
+		return err
+	}
+	r.entries = append(r.entries, entry)
+	return nil
+}
+
+func (r *fakeAuditRepository) List(ctx context.Context, filter audit.Filter) ([]*audit.Entry, int64, error) {
+	var matched []*audit.Entry
+	for _, e := range r.entries {
+		if e.ProjectID() != filter.ProjectID {
+			continue
+		}
+		if filter.Action != "" && e.Action() != filter.Action {
+			continue
+		}
+		if filter.ActorID != "" && e.Actor().ID != filter.ActorID {
+			continue
+		}
+		if filter.BeforeSequence > 0 && e.Sequence() >= filter.BeforeSequence {
+			continue
+		}
+		matched = append(matched, e)
+	}
+	sort.Slice(matched, func(i, j int) bool { return matched[i].Sequence() > matched[j].Sequence() })
+
+	total := int64(len(matched))
+	if filter.Offset < len(matched) {
+		matched = matched[filter.Offset:]
+	} else {
+		matched = nil
+	}
+	if filter.Limit > 0 && len(matched) > filter.Limit {
+		matched = matched[:filter.Limit]
+	}
+	return matched, total, nil
+}
+
+func (r *fakeAuditRepository) Chain(ctx context.Context, projectID uuid.UUID, afterSequence int64, limit int) ([]*audit.Entry, error) {
+	var chain []*audit.Entry
+	for _, e := range r.entries {
+		if e.ProjectID() == projectID && e.Sequence() > afterSequence {
+			chain = append(chain, e)
+		}
+		if len(chain) == limit {
+			break
+		}
+	}
+	return chain, nil
+}
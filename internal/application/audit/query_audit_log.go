@@ -0,0 +1,168 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+)
+
+const (
+	// MaxExportRows teto de linhas por exportação; períodos maiores são exportados em partes
+	MaxExportRows = 100000
+	exportBatch   = 1000
+	verifyBatch   = 1000
+)
+
+var csvHeader = []string{
+	"sequence", "occurred_at", "actor_type", "actor_id", "actor_label", "action", "resource_type",
+	"resource_id", "status", "ip", "user_agent", "correlation_id", "changes", "metadata", "prev_hash", "hash",
+}
+
+// QueryAuditLogUseCase consulta, exporta e confere a trilha de auditoria de um projeto
+type QueryAuditLogUseCase struct {
+	repo   audit.Repository
+	logger *zap.Logger
+}
+
+func NewQueryAuditLogUseCase(repo audit.Repository, logger *zap.Logger) *QueryAuditLogUseCase {
+	return &QueryAuditLogUseCase{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// List registros do projeto, mais recentes primeiro
+func (uc *QueryAuditLogUseCase) List(ctx context.Context, filter audit.Filter) ([]EntryDTO, int64, error) {
+	if filter.ProjectID == uuid.Nil {
+		return nil, 0, shared.NewValidationError("project is required", "project_id")
+	}
+	entries, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	result := make([]EntryDTO, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, ToEntryDTO(e))
+	}
+	return result, total, nil
+}
+
+// ExportCSV escreve em w os registros do filtro (até MaxExportRows), mais recentes primeiro.
+// A própria exportação fica registrada como audit_log.export.
+func (uc *QueryAuditLogUseCase) ExportCSV(ctx context.Context, filter audit.Filter, w io.Writer) (int, error) {
+	if filter.ProjectID == uuid.Nil {
+		return 0, shared.NewValidationError("project is required", "project_id")
+	}
+	SetAction(ctx, "audit_log.export")
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return 0, err
+	}
+
+	// Cursor por sequência: novos registros gravados durante a exportação não deslocam as páginas
+	filter.Offset = 0
+	filter.BeforeSequence = 0
+	rows := 0
+	for rows < MaxExportRows {
+		filter.Limit = min(exportBatch, MaxExportRows-rows)
+		entries, _, err := uc.repo.List(ctx, filter)
+		if err != nil {
+			return rows, err
+		}
+		for _, e := range entries {
+			if err := writer.Write(csvRow(e)); err != nil {
+				return rows, err
+			}
+		}
+		rows += len(entries)
+		if len(entries) < filter.Limit {
+			break
+		}
+		filter.BeforeSequence = entries[len(entries)-1].Sequence()
+	}
+
+	writer.Flush()
+	AddMetadata(ctx, "rows", strconv.Itoa(rows))
+	return rows, writer.Error()
+}
+
+// Verify recalcula a cadeia inteira do projeto e aponta o primeiro registro alterado ou removido
+func (uc *QueryAuditLogUseCase) Verify(ctx context.Context, projectID uuid.UUID) (audit.Verification, error) {
+	verifier := audit.NewChainVerifier()
+	var after int64
+	for {
+		entries, err := uc.repo.Chain(ctx, projectID, after, verifyBatch)
+		if err != nil {
+			return audit.Verification{}, err
+		}
+		if !verifier.Add(entries) || len(entries) < verifyBatch {
+			break
+		}
+		after = entries[len(entries)-1].Sequence()
+	}
+
+	result := verifier.Result()
+	if !result.Valid {
+		uc.logger.Error("Audit log chain is broken",
+			zap.String("project_id", projectID.String()),
+			zap.Int64("sequence", result.BrokenAtSequence),
+			zap.String("reason", result.Reason))
+	}
+	return result, nil
+}
+
+func csvRow(e *audit.Entry) []string {
+	actor := e.Actor()
+	status := ""
+	if e.Status() != 0 {
+		status = strconv.Itoa(e.Status())
+	}
+	return []string{
+		strconv.FormatInt(e.Sequence(), 10),
+		e.OccurredAt().Format(time.RFC3339Nano),
+		string(actor.Type),
+		actor.ID,
+		csvSafe(actor.Label),
+		e.Action(),
+		e.ResourceType(),
+		csvSafe(e.ResourceID()),
+		status,
+		e.IP(),
+		csvSafe(e.UserAgent()),
+		csvSafe(e.CorrelationID()),
+		jsonCell(e.Changes()),
+		jsonCell(e.Metadata()),
+		e.PrevHash(),
+		e.Hash(),
+	}
+}
+
+func jsonCell[T any](value map[string]T) string {
+	if len(value) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(raw)
+}
+
+// csvSafe neutraliza fórmulas (=, +, -, @) em campos livres quando a planilha é aberta no Excel
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
@@ -0,0 +1,120 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"go.uber.org/zap"
+)
+
+func recordN(t *testing.T, recorder *Recorder, projectID uuid.UUID, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		require.NoError(t, recorder.Record(context.Background(), audit.Record{
+			ProjectID:    projectID,
+			Actor:        audit.Actor{Type: audit.ActorAPIKey, ID: "key-1", Label: "vtr_abc123"},
+			Action:       "contacts.create",
+			ResourceType: "contacts",
+			Status:       201,
+		}))
+	}
+}
+
+func TestRecorder_ChainsPerProject(t *testing.T) {
+	repo := &fakeAuditRepository{}
+	recorder := NewRecorder(repo, zap.NewNop())
+	projectA, projectB := uuid.New(), uuid.New()
+
+	recordN(t, recorder, projectA, 2)
+	recordN(t, recorder, projectB, 1)
+
+	assert.Equal(t, int64(2), repo.entries[1].Sequence())
+	assert.Equal(t, repo.entries[0].Hash(), repo.entries[1].PrevHash())
+	assert.Equal(t, int64(1), repo.entries[2].Sequence(), "each project has its own chain")
+
+	err := recorder.Record(context.Background(), audit.Record{ProjectID: projectA, Action: "contacts.create"})
+	assert.ErrorIs(t, err, audit.ErrInvalidActor)
+}
+
+func TestScope(t *testing.T) {
+	Annotate(context.Background(), "webhook-subscriptions", "1", nil, map[string]string{"url": "x"}) // sem escopo: ignorado
+
+	ctx, scope := WithScope(context.Background())
+	Annotate(ctx, "webhook-subscriptions", "wh-1",
+		map[string]interface{}{"url": "https://a.example.com", "active": true, "updated_at": "t1"},
+		map[string]interface{}{"url": "https://b.example.com", "active": true, "updated_at": "t2"})
+	SetAction(ctx, "webhook-subscriptions.update")
+	AddMetadata(ctx, "reason", "endpoint moved")
+
+	resourceType, resourceID := scope.Resource()
+	assert.Equal(t, "webhook-subscriptions", resourceType)
+	assert.Equal(t, "wh-1", resourceID)
+	assert.Equal(t, "webhook-subscriptions.update", scope.Action())
+	assert.Equal(t, map[string]audit.Change{"url": {Before: "https://a.example.com", After: "https://b.example.com"}}, scope.Changes())
+	assert.Equal(t, "endpoint moved", scope.Metadata()["reason"])
+}
+
+func TestQueryAuditLog_ExportCSV(t *testing.T) {
+	repo := &fakeAuditRepository{}
+	recorder := NewRecorder(repo, zap.NewNop())
+	projectID := uuid.New()
+	recordN(t, recorder, projectID, exportBatch+5)
+	require.NoError(t, recorder.Record(context.Background(), audit.Record{
+		ProjectID:  projectID,
+		Actor:      audit.Actor{Type: audit.ActorUser, ID: uuid.NewString(), Label: "=HYPERLINK(\"http://evil\")"},
+		Action:     "channels.update",
+		Changes:    map[string]audit.Change{"name": {Before: "A", After: "B"}},
+		UserAgent:  "Mozilla/5.0",
+		ResourceID: "ch-1",
+	}))
+	recordN(t, recorder, uuid.New(), 3)
+
+	ctx, scope := WithScope(context.Background())
+	var out bytes.Buffer
+	uc := NewQueryAuditLogUseCase(repo, zap.NewNop())
+	rows, err := uc.ExportCSV(ctx, audit.Filter{ProjectID: projectID, Limit: 10}, &out)
+	require.NoError(t, err)
+	assert.Equal(t, exportBatch+6, rows, "export pages through every row, ignoring the list limit")
+
+	records, err := csv.NewReader(&out).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, rows+1)
+	assert.Equal(t, csvHeader, records[0])
+	newest := records[1]
+	assert.Equal(t, "1006", newest[0])
+	assert.Equal(t, "'=HYPERLINK(\"http://evil\")", newest[4], "formulas are neutralized")
+	assert.Equal(t, `{"name":{"before":"A","after":"B"}}`, newest[12])
+	assert.Equal(t, "1", records[len(records)-1][0])
+
+	assert.Equal(t, "audit_log.export", scope.Action())
+	assert.Equal(t, "1006", scope.Metadata()["rows"])
+
+	_, err = uc.ExportCSV(ctx, audit.Filter{}, &out)
+	assert.Error(t, err)
+}
+
+func TestQueryAuditLog_Verify(t *testing.T) {
+	repo := &fakeAuditRepository{}
+	recorder := NewRecorder(repo, zap.NewNop())
+	projectID := uuid.New()
+	recordN(t, recorder, projectID, verifyBatch+2)
+	uc := NewQueryAuditLogUseCase(repo, zap.NewNop())
+
+	result, err := uc.Verify(context.Background(), projectID)
+	require.NoError(t, err)
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(verifyBatch+2), result.Checked)
+
+	// Linha removida direto no banco
+	repo.entries = append(repo.entries[:verifyBatch], repo.entries[verifyBatch+1:]...)
+	result, err = uc.Verify(context.Background(), projectID)
+	require.NoError(t, err)
+	assert.False(t, result.Valid)
+	assert.Equal(t, int64(verifyBatch+2), result.BrokenAtSequence)
+}
@@ -0,0 +1,42 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"go.uber.org/zap"
+)
+
+// Recorder grava registros na trilha de auditoria. Requisições HTTP são gravadas pelo middleware;
+// workers e automações chamam Record direto com o ator correspondente.
+type Recorder struct {
+	repo   audit.Repository
+	logger *zap.Logger
+	now    func() time.Time
+}
+
+func NewRecorder(repo audit.Repository, logger *zap.Logger) *Recorder {
+	return &Recorder{
+		repo:   repo,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Record encadeia e grava o registro
+func (r *Recorder) Record(ctx context.Context, record audit.Record) error {
+	entry, err := audit.NewEntry(record, r.now())
+	if err != nil {
+		return err
+	}
+	if err := r.repo.Append(ctx, entry); err != nil {
+		r.logger.Error("Failed to append audit entry",
+			zap.String("action", record.Action),
+			zap.String("project_id", record.ProjectID.String()),
+			zap.Error(err))
+		return fmt.Errorf("failed to append audit entry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package audit
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ventros/crm/internal/domain/core/audit"
+)
+
+type scopeKey struct{}
+
+// Scope anotações de uma requisição. O middleware de auditoria abre o escopo e grava um único
+// registro ao final; os use cases só acrescentam o que o HTTP não enxerga (ação, diff antes/depois).
+type Scope struct {
+	mu           sync.Mutex
+	action       string
+	resourceType string
+	resourceID   string
+	changes      map[string]audit.Change
+	metadata     map[string]string
+}
+
+// WithScope abre o escopo de auditoria da requisição
+func WithScope(ctx context.Context) (context.Context, *Scope) {
+	scope := &Scope{}
+	return context.WithValue(ctx, scopeKey{}, scope), scope
+}
+
+// ScopeFromContext escopo aberto pelo middleware (nil fora de requisições auditadas)
+func ScopeFromContext(ctx context.Context) *Scope {
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+// Annotate registra o recurso alterado e o diff entre before e after. Sem escopo (workers,
+// testes) não faz nada.
+func Annotate(ctx context.Context, resourceType, resourceID string, before, after interface{}) {
+	scope := ScopeFromContext(ctx)
+	if scope == nil {
+		return
+	}
+	changes, err := audit.Diff(before, after)
+	if err != nil {
+		changes = nil
+	}
+
+	scope.mu.Lock()
+	defer scope.mu.Unlock()
+	scope.resourceType = resourceType
+	scope.resourceID = resourceID
+	if scope.changes == nil {
+		scope.changes = make(map[string]audit.Change)
+	}
+	for key, change := range changes {
+		scope.changes[key] = change
+	}
+}
+
+// SetAction substitui a ação derivada da rota (ex.: audit_log.export em um GET)
+func SetAction(ctx context.Context, action string) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.mu.Lock()
+		scope.action = action
+		scope.mu.Unlock()
+	}
+}
+
+// AddMetadata acrescenta um detalhe ao registro (ex.: quantidade de linhas exportadas)
+func AddMetadata(ctx context.Context, key, value string) {
+	if scope := ScopeFromContext(ctx); scope != nil {
+		scope.mu.Lock()
+		if scope.metadata == nil {
+			scope.metadata = make(map[string]string)
+		}
+		scope.metadata[key] = value
+		scope.mu.Unlock()
+	}
+}
+
+// Action ação definida pelo use case ("" quando a rota decide)
+func (s *Scope) Action() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.action
+}
+
+// Resource recurso anotado pelo use case ("" quando a rota decide)
+func (s *Scope) Resource() (resourceType, resourceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.resourceType, s.resourceID
+}
+
+func (s *Scope) Changes() map[string]audit.Change {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.changes
+}
+
+func (s *Scope) Metadata() map[string]string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.metadata
+}
@@ -0,0 +1,448 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
+	"go.uber.org/zap"
+)
+
+// ErrInvalidRefreshToken resposta única para refresh token desconhecido, expirado, revogado ou reutilizado
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// TokenPair tokens devolvidos no login e no refresh
+type TokenPair struct {
+	AccessToken           string    `json:"access_token"`
+	TokenType             string    `json:"token_type"`
+	ExpiresIn             int       `json:"expires_in"`
+	AccessTokenExpiresAt  time.Time `json:"access_token_expires_at"`
+	RefreshToken          string    `json:"refresh_token"`
+	RefreshTokenExpiresAt time.Time `json:"refresh_token_expires_at"`
+	SessionID             uuid.UUID `json:"session_id"`
+	UserID                uuid.UUID `json:"user_id"`
+	Email                 string    `json:"email"`
+	Role                  string    `json:"role"`
+	ProjectID             uuid.UUID `json:"default_project_id"`
+}
+
+// TwoFactorChallenge devolvido no lugar dos tokens quando a conta tem 2FA: o login termina em
+// POST /auth/token/2fa com o challenge_token e o código do app (ou um código de recuperação)
+type TwoFactorChallenge struct {
+	ChallengeToken string    `json:"challenge_token"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
+
+// LoginResult resultado do primeiro fator: os tokens da sessão ou o desafio de 2FA
+type LoginResult struct {
+	*TokenPair
+	TwoFactorRequired bool                `json:"two_factor_required"`
+	Challenge         *TwoFactorChallenge `json:"two_factor,omitempty"`
+}
+
+// ClientInfo origem da requisição de login/refresh (exibida na lista de sessões)
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
+
+// SessionView sessão exposta pela API (nunca inclui o hash do refresh token)
+type SessionView struct {
+	ID              uuid.UUID          `json:"id"`
+	Method          authsession.Method `json:"method"`
+	Provider        string             `json:"provider,omitempty"`
+	UserAgent       string             `json:"user_agent"`
+	IP              string             `json:"ip"`
+	Current         bool               `json:"current"`
+	CreatedAt       time.Time          `json:"created_at"`
+	LastRefreshedAt *time.Time         `json:"last_refreshed_at,omitempty"`
+	ExpiresAt       time.Time          `json:"expires_at"`
+}
+
+// AccessPrincipal identidade de uma requisição autenticada por access token
+type AccessPrincipal struct {
+	SessionID uuid.UUID
+	UserID    uuid.UUID
+	Email     string
+	Role      string
+	TenantID  string
+	ProjectID uuid.UUID
+	// ReauthenticatedAt último login ou step-up da sessão (ações sensíveis exigem StepUpWindow)
+	ReauthenticatedAt time.Time
+	// TwoFactorPending o projeto exige 2FA e a sessão não passou pelo segundo fator: só as rotas de
+	// inscrição ficam liberadas
+	TwoFactorPending bool
+	// TwoFactorVerified sessão aberta ou confirmada com o segundo fator: o RBAC usa para aplicar a
+	// exigência de 2FA do projeto escolhido por header, rota ou WebSocket
+	TwoFactorVerified bool
+}
+
+// ManageSessionsUseCase login local, refresh com rotação, logout e revogação de sessões
+type ManageSessionsUseCase struct {
+	repo        authsession.Repository
+	accounts    AccountStore
+	enrollments twofactor.Repository
+	projects    project.Repository
+	tokens      *TokenIssuer
+	txManager   TransactionManager
+	refreshTTL  time.Duration
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+func NewManageSessionsUseCase(
+	repo authsession.Repository,
+	accounts AccountStore,
+	enrollments twofactor.Repository,
+	projects project.Repository,
+	tokens *TokenIssuer,
+	txManager TransactionManager,
+	refreshTTL time.Duration,
+	logger *zap.Logger,
+) *ManageSessionsUseCase {
+	return &ManageSessionsUseCase{
+		repo:        repo,
+		accounts:    accounts,
+		enrollments: enrollments,
+		projects:    projects,
+		tokens:      tokens,
+		txManager:   txManager,
+		refreshTTL:  refreshTTL,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// LoginWithPassword valida email e senha e abre uma sessão (ou devolve o desafio de 2FA)
+func (uc *ManageSessionsUseCase) LoginWithPassword(ctx context.Context, email, password string, client ClientInfo) (*LoginResult, error) {
+	account, err := uc.accounts.VerifyPassword(ctx, strings.TrimSpace(email), password)
+	if err != nil {
+		if errors.Is(err, ErrInvalidCredentials) {
+			return nil, shared.NewUnauthorizedError(ErrInvalidCredentials.Error())
+		}
+		return nil, fmt.Errorf("failed to verify credentials: %w", err)
+	}
+	return uc.StartSession(ctx, account, authsession.Details{Method: authsession.MethodPassword, UserAgent: client.UserAgent, IP: client.IP})
+}
+
+// StartSession abre a sessão da conta já autenticada (senha ou OIDC) e emite os tokens. Conta com
+// 2FA ativo recebe o desafio em vez dos tokens, qualquer que seja o primeiro fator.
+func (uc *ManageSessionsUseCase) StartSession(ctx context.Context, account *Account, details authsession.Details) (*LoginResult, error) {
+	if !details.TwoFactorVerified {
+		enabled, err := uc.twoFactorEnabled(ctx, account.UserID)
+		if err != nil {
+			return nil, err
+		}
+		if enabled {
+			challenge, expiresAt, err := uc.tokens.IssueChallenge(account.UserID, details)
+			if err != nil {
+				return nil, err
+			}
+			return &LoginResult{
+				TwoFactorRequired: true,
+				Challenge:         &TwoFactorChallenge{ChallengeToken: challenge, ExpiresAt: expiresAt},
+			}, nil
+		}
+	}
+
+	pair, err := uc.openSession(ctx, account, details)
+	if err != nil {
+		return nil, err
+	}
+	return &LoginResult{TokenPair: pair}, nil
+}
+
+// CompleteTwoFactorLogin conclui o login com o código TOTP (ou de recuperação) e abre a sessão
+func (uc *ManageSessionsUseCase) CompleteTwoFactorLogin(ctx context.Context, challenge, code string, client ClientInfo) (*TokenPair, error) {
+	userID, details, err := uc.tokens.ParseChallenge(challenge)
+	if err != nil {
+		return nil, shared.NewUnauthorizedError(err.Error())
+	}
+
+	usedRecoveryCode, err := verifyTwoFactorCode(ctx, uc.txManager, uc.enrollments, userID, code, uc.now())
+	if err != nil {
+		if isTwoFactorRejection(err) {
+			return nil, shared.NewUnauthorizedError(twofactor.ErrInvalidCode.Error())
+		}
+		return nil, err
+	}
+	if usedRecoveryCode {
+		uc.logger.Warn("Login completed with a recovery code", zap.String("user_id", userID.String()))
+	}
+
+	account, err := uc.accounts.FindAccount(ctx, userID)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, shared.NewUnauthorizedError(ErrInvalidChallenge.Error())
+		}
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+
+	details.UserAgent = client.UserAgent
+	details.IP = client.IP
+	details.TwoFactorVerified = true
+	return uc.openSession(ctx, account, details)
+}
+
+func (uc *ManageSessionsUseCase) openSession(ctx context.Context, account *Account, details authsession.Details) (*TokenPair, error) {
+	s, refreshToken, err := authsession.NewSession(account.UserID, details, uc.refreshTTL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+	if err := uc.repo.Save(ctx, s); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	uc.logger.Info("Session started",
+		zap.String("session_id", s.ID().String()),
+		zap.String("user_id", account.UserID.String()),
+		zap.String("method", string(s.Method())),
+		zap.String("provider", s.Provider()),
+		zap.Bool("two_factor", s.IsTwoFactorVerified()),
+		zap.String("ip", details.IP))
+
+	return uc.tokenPair(account, s, refreshToken)
+}
+
+// Refresh troca o refresh token por um novo par. Reutilizar um refresh token já trocado revoga a
+// sessão inteira (sinal de vazamento): o par roubado e o legítimo deixam de valer.
+func (uc *ManageSessionsUseCase) Refresh(ctx context.Context, refreshToken string, client ClientInfo) (*TokenPair, error) {
+	if !strings.HasPrefix(refreshToken, authsession.RefreshTokenPrefix) {
+		return nil, shared.NewUnauthorizedError(ErrInvalidRefreshToken.Error())
+	}
+	tokenHash := authsession.HashToken(refreshToken)
+
+	var (
+		s        *authsession.Session
+		newToken string
+		reused   bool
+	)
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		s, err = uc.repo.FindByRefreshHash(txCtx, tokenHash)
+		if err != nil {
+			return err
+		}
+		newToken, err = s.Refresh(tokenHash, client.IP, uc.now())
+		if errors.Is(err, authsession.ErrRefreshTokenReuse) {
+			// Grava a revogação e deixa a transação confirmar
+			reused = true
+			return uc.repo.Save(txCtx, s)
+		}
+		if err != nil {
+			return err
+		}
+		return uc.repo.Save(txCtx, s)
+	})
+	if reused {
+		uc.logger.Warn("Refresh token reuse detected, session revoked",
+			zap.String("session_id", s.ID().String()),
+			zap.String("user_id", s.UserID().String()),
+			zap.String("ip", client.IP))
+		return nil, shared.NewUnauthorizedError(ErrInvalidRefreshToken.Error())
+	}
+	if err != nil {
+		if isSessionRejection(err) {
+			return nil, shared.NewUnauthorizedError(ErrInvalidRefreshToken.Error())
+		}
+		return nil, fmt.Errorf("failed to refresh session: %w", err)
+	}
+
+	account, err := uc.accounts.FindAccount(ctx, s.UserID())
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			return nil, shared.NewUnauthorizedError(ErrInvalidRefreshToken.Error())
+		}
+		return nil, fmt.Errorf("failed to load account: %w", err)
+	}
+	return uc.tokenPair(account, s, newToken)
+}
+
+// Logout revoga a sessão do refresh token. Token desconhecido ou sessão já encerrada não é erro.
+func (uc *ManageSessionsUseCase) Logout(ctx context.Context, refreshToken string) error {
+	s, err := uc.repo.FindByRefreshHash(ctx, authsession.HashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, authsession.ErrSessionNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	// Só o token atual encerra a sessão; um token antigo pode ter vazado e não deve servir para logout
+	if s.RefreshHash() != authsession.HashToken(refreshToken) {
+		return nil
+	}
+	return uc.revoke(ctx, s, authsession.ReasonLogout)
+}
+
+// List sessões ativas do usuário; current marca a sessão da requisição
+func (uc *ManageSessionsUseCase) List(ctx context.Context, userID, currentSessionID uuid.UUID) ([]SessionView, error) {
+	sessions, err := uc.repo.ListActiveByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+	views := make([]SessionView, len(sessions))
+	for i, s := range sessions {
+		views[i] = SessionView{
+			ID:              s.ID(),
+			Method:          s.Method(),
+			Provider:        s.Provider(),
+			UserAgent:       s.UserAgent(),
+			IP:              s.IP(),
+			Current:         s.ID() == currentSessionID,
+			CreatedAt:       s.CreatedAt(),
+			LastRefreshedAt: s.LastRefreshedAt(),
+			ExpiresAt:       s.ExpiresAt(),
+		}
+	}
+	return views, nil
+}
+
+// Revoke encerra uma sessão do próprio usuário (ex: "sair deste dispositivo")
+func (uc *ManageSessionsUseCase) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	s, err := uc.repo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, authsession.ErrSessionNotFound) {
+			return shared.NewNotFoundError("session", sessionID.String())
+		}
+		return fmt.Errorf("failed to load session: %w", err)
+	}
+	if s.UserID() != userID {
+		return shared.NewNotFoundError("session", sessionID.String())
+	}
+	if err := uc.revoke(ctx, s, authsession.ReasonRevoked); err != nil {
+		if errors.Is(err, authsession.ErrAlreadyRevoked) {
+			return shared.NewConflictError(err.Error())
+		}
+		return err
+	}
+	return nil
+}
+
+// RevokeOthers encerra todas as sessões do usuário menos a atual (uuid.Nil = todas)
+func (uc *ManageSessionsUseCase) RevokeOthers(ctx context.Context, userID, currentSessionID uuid.UUID) (int64, error) {
+	revoked, err := uc.repo.RevokeAllByUser(ctx, userID, currentSessionID, authsession.ReasonRevokeOthers)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	uc.logger.Info("Sessions revoked",
+		zap.String("user_id", userID.String()),
+		zap.Int64("count", revoked))
+	return revoked, nil
+}
+
+// VerifyAccessToken valida o JWT e confere se a sessão continua ativa (revogação imediata)
+func (uc *ManageSessionsUseCase) VerifyAccessToken(ctx context.Context, token string) (*AccessPrincipal, error) {
+	claims, err := uc.tokens.Parse(token)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid sub", ErrInvalidAccessToken)
+	}
+
+	s, err := uc.repo.FindByID(ctx, claims.SessionID)
+	if err != nil {
+		if errors.Is(err, authsession.ErrSessionNotFound) {
+			return nil, ErrInvalidAccessToken
+		}
+		return nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if s.UserID() != userID || !s.IsActive(uc.now()) {
+		return nil, ErrInvalidAccessToken
+	}
+
+	principal := &AccessPrincipal{
+		SessionID:         s.ID(),
+		UserID:            userID,
+		Email:             claims.Email,
+		Role:              claims.Role,
+		TenantID:          claims.TenantID,
+		ProjectID:         claims.ProjectID,
+		ReauthenticatedAt: s.ReauthenticatedAt(),
+		TwoFactorVerified: s.IsTwoFactorVerified(),
+	}
+	if !principal.TwoFactorVerified {
+		// A política é lida a cada requisição: ligar a exigência no projeto vale na hora
+		principal.TwoFactorPending, err = uc.projectRequiresTwoFactor(ctx, claims.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return principal, nil
+}
+
+func (uc *ManageSessionsUseCase) twoFactorEnabled(ctx context.Context, userID uuid.UUID) (bool, error) {
+	enrollment, err := uc.enrollments.FindByUserID(ctx, userID)
+	if errors.Is(err, twofactor.ErrEnrollmentNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load two-factor enrollment: %w", err)
+	}
+	return enrollment.IsActive(), nil
+}
+
+func (uc *ManageSessionsUseCase) projectRequiresTwoFactor(ctx context.Context, projectID uuid.UUID) (bool, error) {
+	if projectID == uuid.Nil {
+		return false, nil
+	}
+	p, err := uc.projects.FindByID(ctx, projectID)
+	if errors.Is(err, project.ErrProjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load project: %w", err)
+	}
+	return p.RequiresTwoFactor(), nil
+}
+
+func (uc *ManageSessionsUseCase) revoke(ctx context.Context, s *authsession.Session, reason string) error {
+	if err := s.Revoke(reason); err != nil {
+		if errors.Is(err, authsession.ErrAlreadyRevoked) && reason == authsession.ReasonLogout {
+			return nil
+		}
+		return err
+	}
+	if err := uc.repo.Save(ctx, s); err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	uc.logger.Info("Session revoked",
+		zap.String("session_id", s.ID().String()),
+		zap.String("user_id", s.UserID().String()),
+		zap.String("reason", reason))
+	return nil
+}
+
+func (uc *ManageSessionsUseCase) tokenPair(account *Account, s *authsession.Session, refreshToken string) (*TokenPair, error) {
+	accessToken, expiresAt, err := uc.tokens.Issue(account, s.ID())
+	if err != nil {
+		return nil, err
+	}
+	return &TokenPair{
+		AccessToken:           accessToken,
+		TokenType:             "Bearer",
+		ExpiresIn:             int(uc.tokens.TTL().Seconds()),
+		AccessTokenExpiresAt:  expiresAt,
+		RefreshToken:          refreshToken,
+		RefreshTokenExpiresAt: s.ExpiresAt(),
+		SessionID:             s.ID(),
+		UserID:                account.UserID,
+		Email:                 account.Email,
+		Role:                  account.Role,
+		ProjectID:             account.ProjectID,
+	}, nil
+}
+
+func isSessionRejection(err error) bool {
+	return errors.Is(err, authsession.ErrSessionNotFound) ||
+		errors.Is(err, authsession.ErrSessionRevoked) ||
+		errors.Is(err, authsession.ErrSessionExpired)
+}
@@ -0,0 +1,184 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+)
+
+func newSessionsUseCase(t *testing.T, repo *MockSessionRepository, accounts *MockAccountStore) *ManageSessionsUseCase {
+	t.Helper()
+	return newSessionsUseCaseWith(t, repo, accounts, newFakeEnrollmentStore(), newFakeProjectStore())
+}
+
+func newSessionsUseCaseWith(t *testing.T, repo *MockSessionRepository, accounts *MockAccountStore, enrollments *fakeEnrollmentStore, projects *fakeProjectStore) *ManageSessionsUseCase {
+	t.Helper()
+	return NewManageSessionsUseCase(repo, accounts, enrollments, projects, newTestIssuer(t), &SimpleTransactionManager{}, 30*24*time.Hour, zap.NewNop())
+}
+
+func TestManageSessions_LoginWithPassword(t *testing.T) {
+	ctx := context.Background()
+	account := testAccount()
+	accounts := new(MockAccountStore)
+	accounts.On("VerifyPassword", ctx, "ana@example.com", "senha123").Return(account, nil)
+	accounts.On("VerifyPassword", ctx, "ana@example.com", mock.Anything).Return(nil, ErrInvalidCredentials)
+
+	repo := new(MockSessionRepository)
+	var saved *authsession.Session
+	repo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) { saved = args.Get(1).(*authsession.Session) }).Return(nil).Once()
+	uc := newSessionsUseCase(t, repo, accounts)
+
+	pair, err := uc.LoginWithPassword(ctx, " ana@example.com ", "senha123", ClientInfo{UserAgent: "curl/8", IP: "203.0.113.9"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer", pair.TokenType)
+	assert.Equal(t, 900, pair.ExpiresIn)
+	assert.Equal(t, saved.ID(), pair.SessionID)
+	assert.Equal(t, authsession.HashToken(pair.RefreshToken), saved.RefreshHash(), "only the hash is stored")
+	assert.Equal(t, authsession.MethodPassword, saved.Method())
+	assert.Equal(t, "203.0.113.9", saved.IP())
+
+	_, err = uc.LoginWithPassword(ctx, "ana@example.com", "errada", ClientInfo{})
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, shared.ErrorTypeUnauthorized, domainErr.Type)
+}
+
+func TestManageSessions_Refresh(t *testing.T) {
+	ctx := context.Background()
+	account := testAccount()
+	accounts := new(MockAccountStore)
+	accounts.On("FindAccount", ctx, account.UserID).Return(account, nil)
+
+	t.Run("rotates the refresh token", func(t *testing.T) {
+		s, refreshToken, err := authsession.NewSession(account.UserID, authsession.Details{Method: authsession.MethodPassword}, time.Hour)
+		require.NoError(t, err)
+		repo := new(MockSessionRepository)
+		repo.On("FindByRefreshHash", ctx, authsession.HashToken(refreshToken)).Return(s, nil)
+		repo.On("Save", ctx, s).Return(nil).Once()
+		uc := newSessionsUseCase(t, repo, accounts)
+
+		pair, err := uc.Refresh(ctx, refreshToken, ClientInfo{IP: "198.51.100.4"})
+
+		require.NoError(t, err)
+		assert.NotEqual(t, refreshToken, pair.RefreshToken)
+		assert.Equal(t, authsession.HashToken(pair.RefreshToken), s.RefreshHash())
+		assert.Equal(t, s.ExpiresAt(), pair.RefreshTokenExpiresAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("reused refresh token revokes the session", func(t *testing.T) {
+		s, first, err := authsession.NewSession(account.UserID, authsession.Details{Method: authsession.MethodPassword}, time.Hour)
+		require.NoError(t, err)
+		repo := new(MockSessionRepository)
+		repo.On("FindByRefreshHash", ctx, mock.Anything).Return(s, nil)
+		repo.On("Save", ctx, s).Return(nil)
+		uc := newSessionsUseCase(t, repo, accounts)
+
+		second, err := uc.Refresh(ctx, first, ClientInfo{})
+		require.NoError(t, err)
+		_, err = uc.Refresh(ctx, first, ClientInfo{})
+		assert.True(t, shared.IsDomainError(err, new(*shared.DomainError)))
+		assert.Equal(t, authsession.ReasonTokenReuse, s.RevokeReason())
+
+		_, err = uc.Refresh(ctx, second.RefreshToken, ClientInfo{})
+		assert.Error(t, err, "the rotated pair dies with the session")
+		repo.AssertNumberOfCalls(t, "Save", 2)
+	})
+
+	t.Run("unknown and malformed tokens", func(t *testing.T) {
+		repo := new(MockSessionRepository)
+		repo.On("FindByRefreshHash", ctx, mock.Anything).Return(nil, authsession.ErrSessionNotFound)
+		uc := newSessionsUseCase(t, repo, accounts)
+
+		_, err := uc.Refresh(ctx, authsession.RefreshTokenPrefix+"unknown", ClientInfo{})
+		assert.Error(t, err)
+		_, err = uc.Refresh(ctx, "not-a-refresh-token", ClientInfo{})
+		assert.Error(t, err)
+		repo.AssertNumberOfCalls(t, "FindByRefreshHash", 1)
+	})
+}
+
+func TestManageSessions_VerifyAccessToken(t *testing.T) {
+	ctx := context.Background()
+	account := testAccount()
+	s, _, err := authsession.NewSession(account.UserID, authsession.Details{Method: authsession.MethodPassword}, time.Hour)
+	require.NoError(t, err)
+	repo := new(MockSessionRepository)
+	repo.On("FindByID", ctx, s.ID()).Return(s, nil)
+	repo.On("Save", ctx, s).Return(nil)
+	uc := newSessionsUseCase(t, repo, new(MockAccountStore))
+
+	token, _, err := uc.tokens.Issue(account, s.ID())
+	require.NoError(t, err)
+
+	principal, err := uc.VerifyAccessToken(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, account.UserID, principal.UserID)
+	assert.Equal(t, account.ProjectID, principal.ProjectID)
+	assert.Equal(t, s.ID(), principal.SessionID)
+
+	require.NoError(t, uc.Revoke(ctx, account.UserID, s.ID()))
+	_, err = uc.VerifyAccessToken(ctx, token)
+	assert.ErrorIs(t, err, ErrInvalidAccessToken, "revocation applies before the access token expires")
+}
+
+func TestManageSessions_RevokeAndList(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	current, _, err := authsession.NewSession(userID, authsession.Details{Method: authsession.MethodPassword, UserAgent: "Firefox"}, time.Hour)
+	require.NoError(t, err)
+	other, _, err := authsession.NewSession(userID, authsession.Details{Method: authsession.MethodOIDC, Provider: "google"}, time.Hour)
+	require.NoError(t, err)
+
+	repo := new(MockSessionRepository)
+	repo.On("ListActiveByUser", ctx, userID).Return([]*authsession.Session{current, other}, nil)
+	repo.On("FindByID", ctx, other.ID()).Return(other, nil)
+	repo.On("Save", ctx, other).Return(nil)
+	repo.On("RevokeAllByUser", ctx, userID, current.ID(), authsession.ReasonRevokeOthers).Return(int64(1), nil)
+	uc := newSessionsUseCase(t, repo, new(MockAccountStore))
+
+	views, err := uc.List(ctx, userID, current.ID())
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+	assert.True(t, views[0].Current)
+	assert.Equal(t, "google", views[1].Provider)
+
+	err = uc.Revoke(ctx, uuid.New(), other.ID())
+	assert.True(t, shared.IsNotFoundError(err), "sessions of other users are invisible")
+
+	require.NoError(t, uc.Revoke(ctx, userID, other.ID()))
+	err = uc.Revoke(ctx, userID, other.ID())
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, shared.ErrorTypeConflict, domainErr.Type)
+
+	revoked, err := uc.RevokeOthers(ctx, userID, current.ID())
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), revoked)
+}
+
+func TestManageSessions_Logout(t *testing.T) {
+	ctx := context.Background()
+	s, refreshToken, err := authsession.NewSession(uuid.New(), authsession.Details{Method: authsession.MethodPassword}, time.Hour)
+	require.NoError(t, err)
+	repo := new(MockSessionRepository)
+	repo.On("FindByRefreshHash", ctx, authsession.HashToken(refreshToken)).Return(s, nil)
+	repo.On("FindByRefreshHash", ctx, mock.Anything).Return(nil, authsession.ErrSessionNotFound)
+	repo.On("Save", ctx, s).Return(nil).Once()
+	uc := newSessionsUseCase(t, repo, new(MockAccountStore))
+
+	require.NoError(t, uc.Logout(ctx, refreshToken))
+	assert.Equal(t, authsession.ReasonLogout, s.RevokeReason())
+	assert.NoError(t, uc.Logout(ctx, refreshToken), "idempotent")
+	assert.NoError(t, uc.Logout(ctx, "vrt_unknown"))
+	repo.AssertExpectations(t)
+}
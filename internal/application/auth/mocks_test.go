@@ -0,0 +1,202 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/infrastructure/oidc"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
+)
+
+// ========== Shared Mocks for auth package tests ==========
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Save(ctx context.Context, s *authsession.Session) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*authsession.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authsession.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindByRefreshHash(ctx context.Context, tokenHash string) (*authsession.Session, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*authsession.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*authsession.Session, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*authsession.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) RevokeAllByUser(ctx context.Context, userID, except uuid.UUID, reason string) (int64, error) {
+	args := m.Called(ctx, userID, except, reason)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockAccountStore struct {
+	mock.Mock
+}
+
+func (m *MockAccountStore) VerifyPassword(ctx context.Context, email, password string) (*Account, error) {
+	args := m.Called(ctx, email, password)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Account), args.Error(1)
+}
+
+func (m *MockAccountStore) FindAccount(ctx context.Context, userID uuid.UUID) (*Account, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Account), args.Error(1)
+}
+
+func (m *MockAccountStore) ResolveIdentity(ctx context.Context, identity *oidc.Identity, autoProvision bool) (*Account, error) {
+	args := m.Called(ctx, identity, autoProvision)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Account), args.Error(1)
+}
+
+// fakeStateStore LoginStateStore em memória com consumo de uso único
+type fakeStateStore struct {
+	mu     sync.Mutex
+	states map[string]LoginState
+}
+
+func newFakeStateStore() *fakeStateStore {
+	return &fakeStateStore{states: make(map[string]LoginState)}
+}
+
+func (s *fakeStateStore) Save(_ context.Context, state string, login LoginState, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[state] = login
+	return nil
+}
+
+func (s *fakeStateStore) Consume(_ context.Context, state string) (*LoginState, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	login, ok := s.states[state]
+	if !ok {
+		return nil, ErrLoginStateNotFound
+	}
+	delete(s.states, state)
+	return &login, nil
+}
+
+// SimpleTransactionManager is a test transaction manager that just executes the function
+type SimpleTransactionManager struct{}
+
+func (m *SimpleTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeEnrollmentStore twofactor.Repository em memória
+type fakeEnrollmentStore struct {
+	mu          sync.Mutex
+	enrollments map[uuid.UUID]*twofactor.Enrollment
+}
+
+func newFakeEnrollmentStore() *fakeEnrollmentStore {
+	return &fakeEnrollmentStore{enrollments: make(map[uuid.UUID]*twofactor.Enrollment)}
+}
+
+func (s *fakeEnrollmentStore) Save(_ context.Context, e *twofactor.Enrollment) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.enrollments[e.UserID()] = e
+	return nil
+}
+
+func (s *fakeEnrollmentStore) FindByUserID(_ context.Context, userID uuid.UUID) (*twofactor.Enrollment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	e, ok := s.enrollments[userID]
+	if !ok {
+		return nil, twofactor.ErrEnrollmentNotFound
+	}
+	return e, nil
+}
+
+func (s *fakeEnrollmentStore) Delete(_ context.Context, userID uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.enrollments, userID)
+	return nil
+}
+
+// fakeProjectStore project.Repository em memória (só FindByID e Save são usados aqui)
+type fakeProjectStore struct {
+	mu       sync.Mutex
+	projects map[uuid.UUID]*project.Project
+}
+
+func newFakeProjectStore(projects ...*project.Project) *fakeProjectStore {
+	store := &fakeProjectStore{projects: make(map[uuid.UUID]*project.Project)}
+	for _, p := range projects {
+		store.projects[p.ID()] = p
+	}
+	return store
+}
+
+func (s *fakeProjectStore) Save(_ context.Context, p *project.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.projects[p.ID()] = p
+	return nil
+}
+
+func (s *fakeProjectStore) FindByID(_ context.Context, id uuid.UUID) (*project.Project, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p, ok := s.projects[id]
+	if !ok {
+		return nil, project.ErrProjectNotFound
+	}
+	return p, nil
+}
+
+func (s *fakeProjectStore) FindByTenantID(context.Context, string) (*project.Project, error) {
+	return nil, project.ErrProjectNotFound
+}
+
+func (s *fakeProjectStore) FindByCustomer(context.Context, uuid.UUID) ([]*project.Project, error) {
+	return nil, nil
+}
+
+func (s *fakeProjectStore) Delete(context.Context, uuid.UUID) error {
+	return nil
+}
+
+func (s *fakeProjectStore) FindByTenantWithFilters(context.Context, project.ProjectFilters) ([]*project.Project, int64, error) {
+	return nil, 0, nil
+}
+
+func (s *fakeProjectStore) SearchByText(context.Context, string, string, int, int) ([]*project.Project, int64, error) {
+	return nil, 0, nil
+}
@@ -0,0 +1,158 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/ventros/crm/infrastructure/oidc"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2"
+)
+
+// LoginStateTTL tempo que o usuário tem para concluir o login no provedor
+const LoginStateTTL = 10 * time.Minute
+
+// OIDCAuthorization início do login: o navegador deve ser enviado para AuthorizationURL
+type OIDCAuthorization struct {
+	Provider         string    `json:"provider"`
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// OIDCLoginUseCase login por provedores OIDC (authorization code + PKCE). O state, o nonce e o
+// code_verifier ficam no servidor; o navegador só carrega o state.
+type OIDCLoginUseCase struct {
+	providers     map[string]IdentityProvider
+	states        LoginStateStore
+	accounts      AccountStore
+	sessions      *ManageSessionsUseCase
+	autoProvision bool
+	logger        *zap.Logger
+}
+
+func NewOIDCLoginUseCase(
+	providers []IdentityProvider,
+	states LoginStateStore,
+	accounts AccountStore,
+	sessions *ManageSessionsUseCase,
+	autoProvision bool,
+	logger *zap.Logger,
+) *OIDCLoginUseCase {
+	byName := make(map[string]IdentityProvider, len(providers))
+	for _, p := range providers {
+		byName[p.Name()] = p
+	}
+	return &OIDCLoginUseCase{
+		providers:     byName,
+		states:        states,
+		accounts:      accounts,
+		sessions:      sessions,
+		autoProvision: autoProvision,
+		logger:        logger,
+	}
+}
+
+// Providers nomes dos provedores configurados, em ordem alfabética
+func (uc *OIDCLoginUseCase) Providers() []string {
+	names := make([]string, 0, len(uc.providers))
+	for name := range uc.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Start gera state, nonce e code_verifier e monta a URL de autorização do provedor
+func (uc *OIDCLoginUseCase) Start(ctx context.Context, providerName string) (*OIDCAuthorization, error) {
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		return nil, shared.NewNotFoundError("oidc_provider", providerName)
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	login := LoginState{Provider: providerName, Nonce: nonce, Verifier: oauth2.GenerateVerifier()}
+	if err := uc.states.Save(ctx, state, login, LoginStateTTL); err != nil {
+		return nil, fmt.Errorf("failed to save login state: %w", err)
+	}
+
+	return &OIDCAuthorization{
+		Provider:         providerName,
+		AuthorizationURL: provider.AuthCodeURL(state, nonce, login.Verifier),
+		State:            state,
+		ExpiresAt:        time.Now().Add(LoginStateTTL),
+	}, nil
+}
+
+// Complete conclui o callback: consome o state, troca o code, resolve a conta local e abre a sessão
+// (ou devolve o desafio de 2FA)
+func (uc *OIDCLoginUseCase) Complete(ctx context.Context, providerName, state, code string, client ClientInfo) (*LoginResult, error) {
+	provider, ok := uc.providers[providerName]
+	if !ok {
+		return nil, shared.NewNotFoundError("oidc_provider", providerName)
+	}
+	if state == "" || code == "" {
+		return nil, shared.NewValidationError("state and code are required", "code")
+	}
+
+	login, err := uc.states.Consume(ctx, state)
+	if err != nil {
+		if errors.Is(err, ErrLoginStateNotFound) {
+			return nil, shared.NewUnauthorizedError(ErrLoginStateNotFound.Error())
+		}
+		return nil, fmt.Errorf("failed to load login state: %w", err)
+	}
+	if login.Provider != providerName {
+		return nil, shared.NewUnauthorizedError(ErrLoginStateNotFound.Error())
+	}
+
+	identity, err := provider.Exchange(ctx, code, login.Verifier, login.Nonce)
+	if err != nil {
+		uc.logger.Warn("OIDC login rejected", zap.String("provider", providerName), zap.Error(err))
+		if errors.Is(err, oidc.ErrEmailDomainNotAllowed) {
+			return nil, shared.NewForbiddenError(err.Error())
+		}
+		return nil, shared.NewUnauthorizedError("identity provider login failed")
+	}
+
+	account, err := uc.accounts.ResolveIdentity(ctx, identity, uc.autoProvision)
+	if err != nil {
+		if errors.Is(err, ErrAccountNotFound) {
+			uc.logger.Info("OIDC identity without account",
+				zap.String("provider", providerName),
+				zap.String("subject", identity.Subject),
+				zap.Bool("email_verified", identity.EmailVerified))
+			return nil, shared.NewForbiddenError(ErrAccountNotFound.Error())
+		}
+		return nil, fmt.Errorf("failed to resolve account: %w", err)
+	}
+
+	return uc.sessions.StartSession(ctx, account, authsession.Details{
+		Method:    authsession.MethodOIDC,
+		Provider:  providerName,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+	})
+}
+
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate random token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
@@ -0,0 +1,103 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/infrastructure/oidc"
+	"github.com/ventros/crm/infrastructure/oidc/oidctest"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+)
+
+func newOIDCLogin(t *testing.T, accounts *MockAccountStore, repo *MockSessionRepository) (*OIDCLoginUseCase, *oidctest.Provider) {
+	t.Helper()
+	mockIdP := oidctest.NewProvider("crm", "s3cret")
+	t.Cleanup(mockIdP.Close)
+
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		Name:         "okta",
+		Issuer:       mockIdP.Issuer(),
+		ClientID:     "crm",
+		ClientSecret: "s3cret",
+		RedirectURL:  "https://crm.example.com/api/v1/auth/oidc/okta/callback",
+	}, mockIdP.Client())
+	require.NoError(t, err)
+	t.Cleanup(provider.Close)
+
+	sessions := newSessionsUseCase(t, repo, accounts)
+	uc := NewOIDCLoginUseCase([]IdentityProvider{provider}, newFakeStateStore(), accounts, sessions, false, zap.NewNop())
+	return uc, mockIdP
+}
+
+func TestOIDCLogin_CompleteFlow(t *testing.T) {
+	ctx := context.Background()
+	account := testAccount()
+	accounts := new(MockAccountStore)
+	accounts.On("ResolveIdentity", ctx, mock.MatchedBy(func(i *oidc.Identity) bool {
+		return i.Provider == "okta" && i.Subject == "user-1" && i.Email == "ana@example.com" && i.EmailVerified
+	}), false).Return(account, nil).Once()
+	repo := new(MockSessionRepository)
+	var saved *authsession.Session
+	repo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) { saved = args.Get(1).(*authsession.Session) }).Return(nil)
+	uc, mockIdP := newOIDCLogin(t, accounts, repo)
+
+	assert.Equal(t, []string{"okta"}, uc.Providers())
+
+	authorization, err := uc.Start(ctx, "okta")
+	require.NoError(t, err)
+	code, state, err := mockIdP.Authorize(authorization.AuthorizationURL)
+	require.NoError(t, err)
+	assert.Equal(t, authorization.State, state)
+
+	pair, err := uc.Complete(ctx, "okta", state, code, ClientInfo{UserAgent: "Chrome", IP: "203.0.113.9"})
+
+	require.NoError(t, err)
+	assert.Equal(t, account.UserID, pair.UserID)
+	assert.Equal(t, authsession.MethodOIDC, saved.Method())
+	assert.Equal(t, "okta", saved.Provider())
+
+	_, err = uc.Complete(ctx, "okta", state, code, ClientInfo{})
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr), "state is single use")
+	assert.Equal(t, shared.ErrorTypeUnauthorized, domainErr.Type)
+	accounts.AssertExpectations(t)
+}
+
+func TestOIDCLogin_Rejections(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("identity without account", func(t *testing.T) {
+		accounts := new(MockAccountStore)
+		accounts.On("ResolveIdentity", ctx, mock.Anything, false).Return(nil, ErrAccountNotFound)
+		repo := new(MockSessionRepository)
+		uc, mockIdP := newOIDCLogin(t, accounts, repo)
+
+		authorization, err := uc.Start(ctx, "okta")
+		require.NoError(t, err)
+		code, state, err := mockIdP.Authorize(authorization.AuthorizationURL)
+		require.NoError(t, err)
+
+		_, err = uc.Complete(ctx, "okta", state, code, ClientInfo{})
+		var domainErr *shared.DomainError
+		require.True(t, shared.IsDomainError(err, &domainErr))
+		assert.Equal(t, shared.ErrorTypeForbidden, domainErr.Type)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("forged state and unknown provider", func(t *testing.T) {
+		uc, _ := newOIDCLogin(t, new(MockAccountStore), new(MockSessionRepository))
+
+		_, err := uc.Complete(ctx, "okta", "forged", "code", ClientInfo{})
+		var domainErr *shared.DomainError
+		require.True(t, shared.IsDomainError(err, &domainErr))
+		assert.Equal(t, shared.ErrorTypeUnauthorized, domainErr.Type)
+
+		_, err = uc.Start(ctx, "github")
+		assert.True(t, shared.IsNotFoundError(err))
+	})
+}
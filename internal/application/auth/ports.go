@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/oidc"
+)
+
+var (
+	// ErrInvalidCredentials email/senha inválidos ou usuário inativo (sem distinguir os casos)
+	ErrInvalidCredentials = errors.New("invalid credentials")
+	// ErrAccountNotFound identidade externa sem conta vinculada e sem provisionamento automático
+	ErrAccountNotFound = errors.New("no account linked to this identity")
+	// ErrLoginStateNotFound state OIDC desconhecido, expirado ou já usado
+	ErrLoginStateNotFound = errors.New("login state not found or expired")
+)
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Account conta local que recebe a sessão. Tenant e projeto vêm do projeto default do usuário
+// e vão no access token, como nas API keys legadas.
+type Account struct {
+	UserID    uuid.UUID
+	Email     string
+	Name      string
+	Role      string
+	TenantID  string
+	ProjectID uuid.UUID
+}
+
+// AccountStore acesso às contas locais (implementado por user.UserService)
+type AccountStore interface {
+	// VerifyPassword devolve ErrInvalidCredentials para email desconhecido, senha errada ou conta inativa
+	VerifyPassword(ctx context.Context, email, password string) (*Account, error)
+	// FindAccount conta ativa pelo ID (ErrAccountNotFound se removida ou inativa)
+	FindAccount(ctx context.Context, userID uuid.UUID) (*Account, error)
+	// ResolveIdentity conta vinculada a (provider, subject). Sem vínculo, vincula pelo email verificado
+	// ou, com autoProvision, cria a conta; caso contrário ErrAccountNotFound.
+	ResolveIdentity(ctx context.Context, identity *oidc.Identity, autoProvision bool) (*Account, error)
+}
+
+// IdentityProvider provedor OIDC configurado (implementado por oidc.Provider)
+type IdentityProvider interface {
+	Name() string
+	AuthCodeURL(state, nonce, verifier string) string
+	Exchange(ctx context.Context, code, verifier, nonce string) (*oidc.Identity, error)
+}
+
+// LoginState dados guardados entre o redirect para o provedor e o callback
+type LoginState struct {
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+// LoginStateStore guarda o state do login OIDC; Consume é de uso único (ErrLoginStateNotFound)
+type LoginStateStore interface {
+	Save(ctx context.Context, state string, login LoginState, ttl time.Duration) error
+	Consume(ctx context.Context, state string) (*LoginState, error)
+}
+
+// ProjectAccessInvalidator descarta o acesso em cache do projeto (RBAC), em todas as réplicas
+// quando houver Redis
+type ProjectAccessInvalidator interface {
+	InvalidateProject(ctx context.Context, projectID uuid.UUID)
+}
@@ -0,0 +1,169 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+)
+
+var (
+	// ErrInvalidAccessToken assinatura, validade, issuer ou sessão inválidos
+	ErrInvalidAccessToken = errors.New("invalid access token")
+	// ErrInvalidChallenge desafio de 2FA inválido ou expirado
+	ErrInvalidChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+const (
+	// minSecretLength HS256 exige ao menos 256 bits de segredo
+	minSecretLength = 32
+	// ChallengeTTL tempo para digitar o código do segundo fator depois da senha/OIDC
+	ChallengeTTL = 5 * time.Minute
+	// challengeAudienceSuffix audience própria: o desafio não serve como access token e vice-versa
+	challengeAudienceSuffix = "#2fa"
+)
+
+// AccessClaims claims do access token emitido para contas locais
+type AccessClaims struct {
+	jwt.RegisteredClaims
+	SessionID uuid.UUID `json:"sid"`
+	Email     string    `json:"email"`
+	Role      string    `json:"role"`
+	TenantID  string    `json:"tid"`
+	ProjectID uuid.UUID `json:"pid"`
+}
+
+// ChallengeClaims desafio emitido quando o primeiro fator passou e falta o TOTP. Carrega a origem
+// do login para a sessão ser aberta igual depois do código.
+type ChallengeClaims struct {
+	jwt.RegisteredClaims
+	Method   authsession.Method `json:"method"`
+	Provider string             `json:"provider,omitempty"`
+}
+
+// TokenIssuer assina e valida os access tokens (JWT HS256 de vida curta). O refresh token é opaco
+// e fica na sessão; o JWT só referencia a sessão pelo claim sid.
+type TokenIssuer struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+func NewTokenIssuer(secret []byte, issuer string, ttl time.Duration) (*TokenIssuer, error) {
+	if len(secret) < minSecretLength {
+		return nil, fmt.Errorf("jwt secret must have at least %d bytes", minSecretLength)
+	}
+	if ttl <= 0 {
+		return nil, errors.New("access token ttl must be positive")
+	}
+	return &TokenIssuer{
+		secret: secret,
+		issuer: issuer,
+		ttl:    ttl,
+		now:    time.Now,
+	}, nil
+}
+
+// TTL validade dos access tokens
+func (i *TokenIssuer) TTL() time.Duration { return i.ttl }
+
+// Issue assina o access token da conta para a sessão informada
+func (i *TokenIssuer) Issue(account *Account, sessionID uuid.UUID) (string, time.Time, error) {
+	now := i.now()
+	expiresAt := now.Add(i.ttl)
+	claims := AccessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   account.UserID.String(),
+			Audience:  jwt.ClaimStrings{i.issuer},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+		},
+		SessionID: sessionID,
+		Email:     account.Email,
+		Role:      account.Role,
+		TenantID:  account.TenantID,
+		ProjectID: account.ProjectID,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign access token: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// Parse valida assinatura, algoritmo, issuer, audience e validade do access token
+func (i *TokenIssuer) Parse(token string) (*AccessClaims, error) {
+	claims := &AccessClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return i.secret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithAudience(i.issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAccessToken, err)
+	}
+	if claims.SessionID == uuid.Nil {
+		return nil, fmt.Errorf("%w: missing sid", ErrInvalidAccessToken)
+	}
+	return claims, nil
+}
+
+// IssueChallenge assina o desafio de 2FA do usuário (vale ChallengeTTL)
+func (i *TokenIssuer) IssueChallenge(userID uuid.UUID, details authsession.Details) (string, time.Time, error) {
+	now := i.now()
+	expiresAt := now.Add(ChallengeTTL)
+	claims := ChallengeClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    i.issuer,
+			Subject:   userID.String(),
+			Audience:  jwt.ClaimStrings{i.issuer + challengeAudienceSuffix},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			ID:        uuid.NewString(),
+		},
+		Method:   details.Method,
+		Provider: details.Provider,
+	}
+
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(i.secret)
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("failed to sign challenge: %w", err)
+	}
+	return signed, expiresAt, nil
+}
+
+// ParseChallenge valida o desafio de 2FA e devolve o usuário e a origem do login
+func (i *TokenIssuer) ParseChallenge(token string) (uuid.UUID, authsession.Details, error) {
+	claims := &ChallengeClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (interface{}, error) { return i.secret, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(i.issuer),
+		jwt.WithAudience(i.issuer+challengeAudienceSuffix),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(i.now),
+	)
+	if err != nil {
+		return uuid.Nil, authsession.Details{}, ErrInvalidChallenge
+	}
+	userID, err := uuid.Parse(claims.Subject)
+	if err != nil {
+		return uuid.Nil, authsession.Details{}, ErrInvalidChallenge
+	}
+	return userID, authsession.Details{Method: claims.Method, Provider: claims.Provider}, nil
+}
+
+// IsAccessToken indica se o valor tem formato de JWT (as API keys não têm pontos)
+func IsAccessToken(token string) bool {
+	return strings.Count(token, ".") == 2
+}
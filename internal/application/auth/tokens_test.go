@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSecret = []byte(strings.Repeat("k", 32))
+
+func newTestIssuer(t *testing.T) *TokenIssuer {
+	t.Helper()
+	issuer, err := NewTokenIssuer(testSecret, "ventros-crm", 15*time.Minute)
+	require.NoError(t, err)
+	return issuer
+}
+
+func testAccount() *Account {
+	return &Account{UserID: uuid.New(), Email: "ana@example.com", Role: "admin", TenantID: "user-1234", ProjectID: uuid.New()}
+}
+
+func TestTokenIssuer(t *testing.T) {
+	account := testAccount()
+	sessionID := uuid.New()
+
+	t.Run("round trip", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		token, expiresAt, err := issuer.Issue(account, sessionID)
+		require.NoError(t, err)
+		assert.True(t, IsAccessToken(token))
+		assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Second)
+
+		claims, err := issuer.Parse(token)
+		require.NoError(t, err)
+		assert.Equal(t, account.UserID.String(), claims.Subject)
+		assert.Equal(t, sessionID, claims.SessionID)
+		assert.Equal(t, account.TenantID, claims.TenantID)
+		assert.Equal(t, account.ProjectID, claims.ProjectID)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		issuer := newTestIssuer(t)
+		token, _, err := issuer.Issue(account, sessionID)
+		require.NoError(t, err)
+		issuer.now = func() time.Time { return time.Now().Add(16 * time.Minute) }
+
+		_, err = issuer.Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+	})
+
+	t.Run("other secret, other issuer and alg none", func(t *testing.T) {
+		token, _, err := newTestIssuer(t).Issue(account, sessionID)
+		require.NoError(t, err)
+
+		other, err := NewTokenIssuer([]byte(strings.Repeat("x", 32)), "ventros-crm", time.Minute)
+		require.NoError(t, err)
+		_, err = other.Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+
+		otherIssuer, err := NewTokenIssuer(testSecret, "another-app", time.Minute)
+		require.NoError(t, err)
+		_, err = otherIssuer.Parse(token)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+
+		unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+			"iss": "ventros-crm", "aud": "ventros-crm", "sub": account.UserID.String(),
+			"sid": sessionID.String(), "exp": time.Now().Add(time.Minute).Unix(),
+		}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+		_, err = newTestIssuer(t).Parse(unsigned)
+		assert.ErrorIs(t, err, ErrInvalidAccessToken)
+	})
+
+	t.Run("short secret is rejected", func(t *testing.T) {
+		_, err := NewTokenIssuer([]byte("secret"), "ventros-crm", time.Minute)
+		assert.Error(t, err)
+	})
+
+	assert.False(t, IsAccessToken("vtr_abc"))
+	assert.False(t, IsAccessToken("0123456789abcdef"))
+}
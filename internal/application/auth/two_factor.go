@@ -0,0 +1,375 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
+	"go.uber.org/zap"
+)
+
+// StepUpWindow ações sensíveis (remover canal, ler credenciais, exportar dados) exigem login ou
+// step-up mais recente que isto
+const StepUpWindow = 10 * time.Minute
+
+// TwoFactorStatus situação do 2FA do usuário
+type TwoFactorStatus struct {
+	Enabled                bool       `json:"enabled"`
+	Pending                bool       `json:"pending"`
+	ConfirmedAt            *time.Time `json:"confirmed_at,omitempty"`
+	LastUsedAt             *time.Time `json:"last_used_at,omitempty"`
+	RecoveryCodesRemaining int        `json:"recovery_codes_remaining"`
+}
+
+// TwoFactorSetup dados para cadastrar a conta no app autenticador (QR code ou digitação)
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+	Issuer          string `json:"issuer"`
+	Account         string `json:"account"`
+}
+
+// StepUpRequest segundo fator quando o usuário tem 2FA; senão a senha
+type StepUpRequest struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+	Email     string
+	Code      string
+	Password  string
+}
+
+// StepUpResult até quando as ações sensíveis ficam liberadas na sessão
+type StepUpResult struct {
+	ReauthenticatedAt time.Time `json:"reauthenticated_at"`
+	ValidUntil        time.Time `json:"valid_until"`
+	TwoFactor         bool      `json:"two_factor"`
+}
+
+// TwoFactorUseCase inscrição TOTP, códigos de recuperação, step-up e a política de 2FA do projeto
+type TwoFactorUseCase struct {
+	enrollments twofactor.Repository
+	sessions    authsession.Repository
+	accounts    AccountStore
+	projects    project.Repository
+	txManager   TransactionManager
+	issuer      string
+	access      ProjectAccessInvalidator
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+// SetAccessInvalidator invalida o acesso em cache do projeto quando a exigência de 2FA muda, para
+// que o RBAC aplique a nova política na hora
+func (uc *TwoFactorUseCase) SetAccessInvalidator(access ProjectAccessInvalidator) {
+	uc.access = access
+}
+
+// NewTwoFactorUseCase issuer é o nome exibido no app autenticador (ex: "Ventros CRM")
+func NewTwoFactorUseCase(
+	enrollments twofactor.Repository,
+	sessions authsession.Repository,
+	accounts AccountStore,
+	projects project.Repository,
+	txManager TransactionManager,
+	issuer string,
+	logger *zap.Logger,
+) *TwoFactorUseCase {
+	return &TwoFactorUseCase{
+		enrollments: enrollments,
+		sessions:    sessions,
+		accounts:    accounts,
+		projects:    projects,
+		txManager:   txManager,
+		issuer:      issuer,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Status situação do 2FA do usuário
+func (uc *TwoFactorUseCase) Status(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error) {
+	e, err := uc.enrollments.FindByUserID(ctx, userID)
+	if errors.Is(err, twofactor.ErrEnrollmentNotFound) {
+		return &TwoFactorStatus{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load two-factor enrollment: %w", err)
+	}
+	return &TwoFactorStatus{
+		Enabled:                e.IsActive(),
+		Pending:                !e.IsActive(),
+		ConfirmedAt:            e.ConfirmedAt(),
+		LastUsedAt:             e.LastUsedAt(),
+		RecoveryCodesRemaining: e.RemainingRecoveryCodes(),
+	}, nil
+}
+
+// BeginEnrollment gera um segredo novo (substitui uma inscrição pendente). O 2FA só passa a valer
+// em ConfirmEnrollment.
+func (uc *TwoFactorUseCase) BeginEnrollment(ctx context.Context, userID uuid.UUID, account string) (*TwoFactorSetup, error) {
+	existing, err := uc.enrollments.FindByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, twofactor.ErrEnrollmentNotFound) {
+		return nil, fmt.Errorf("failed to load two-factor enrollment: %w", err)
+	}
+	if existing != nil && existing.IsActive() {
+		return nil, shared.NewConflictError(twofactor.ErrAlreadyActive.Error())
+	}
+
+	e, err := twofactor.NewEnrollment(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create two-factor enrollment: %w", err)
+	}
+	if err := uc.enrollments.Save(ctx, e); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorSetup{
+		Secret:          twofactor.EncodeSecret(e.Secret()),
+		ProvisioningURI: e.ProvisioningURI(uc.issuer, account),
+		Issuer:          uc.issuer,
+		Account:         account,
+	}, nil
+}
+
+// ConfirmEnrollment ativa o 2FA com o primeiro código do app e devolve os códigos de recuperação
+// (exibidos uma única vez). A sessão atual passa a contar como verificada pelo segundo fator.
+func (uc *TwoFactorUseCase) ConfirmEnrollment(ctx context.Context, userID, sessionID uuid.UUID, code string) ([]string, error) {
+	var codes []string
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		e, err := uc.enrollments.FindByUserID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if codes, err = e.Confirm(code, uc.now()); err != nil {
+			return err
+		}
+		if err := uc.enrollments.Save(txCtx, e); err != nil {
+			return err
+		}
+		return uc.reauthenticateSession(txCtx, userID, sessionID, true)
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, twofactor.ErrEnrollmentNotFound):
+			return nil, shared.NewNotFoundError("two_factor_enrollment", userID.String())
+		case errors.Is(err, twofactor.ErrAlreadyActive):
+			return nil, shared.NewConflictError(err.Error())
+		case errors.Is(err, twofactor.ErrInvalidCode):
+			return nil, shared.NewValidationError(err.Error(), "code")
+		}
+		return nil, fmt.Errorf("failed to confirm two-factor enrollment: %w", err)
+	}
+
+	uc.logger.Info("Two-factor authentication enabled", zap.String("user_id", userID.String()))
+	return codes, nil
+}
+
+// RegenerateRecoveryCodes troca todos os códigos de recuperação (exige um código válido)
+func (uc *TwoFactorUseCase) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	var codes []string
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		e, err := uc.enrollments.FindByUserID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if _, err := e.Verify(code, uc.now()); err != nil {
+			return err
+		}
+		if codes, err = e.RegenerateRecoveryCodes(); err != nil {
+			return err
+		}
+		return uc.enrollments.Save(txCtx, e)
+	})
+	if err != nil {
+		return nil, codeError(err)
+	}
+	uc.logger.Info("Two-factor recovery codes regenerated", zap.String("user_id", userID.String()))
+	return codes, nil
+}
+
+// Disable remove o 2FA (exige um código válido). Não é permitido enquanto o projeto exigir 2FA.
+func (uc *TwoFactorUseCase) Disable(ctx context.Context, userID, projectID uuid.UUID, code string) error {
+	required, err := uc.projectRequiresTwoFactor(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	if required {
+		return shared.NewForbiddenError("project policy requires two-factor authentication")
+	}
+
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		e, err := uc.enrollments.FindByUserID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if _, err := e.Verify(code, uc.now()); err != nil {
+			return err
+		}
+		return uc.enrollments.Delete(txCtx, userID)
+	})
+	if err != nil {
+		return codeError(err)
+	}
+	uc.logger.Info("Two-factor authentication disabled", zap.String("user_id", userID.String()))
+	return nil
+}
+
+// StepUp confirma a identidade de novo para liberar ações sensíveis por StepUpWindow. Quem tem 2FA
+// usa o código; quem não tem, a senha (conta só OIDC faz login de novo).
+func (uc *TwoFactorUseCase) StepUp(ctx context.Context, req StepUpRequest) (*StepUpResult, error) {
+	if req.SessionID == uuid.Nil {
+		return nil, shared.NewForbiddenError("step-up requires a login session (API keys are not supported)")
+	}
+
+	e, err := uc.enrollments.FindByUserID(ctx, req.UserID)
+	if err != nil && !errors.Is(err, twofactor.ErrEnrollmentNotFound) {
+		return nil, fmt.Errorf("failed to load two-factor enrollment: %w", err)
+	}
+	twoFactor := e != nil && e.IsActive()
+
+	if twoFactor {
+		if req.Code == "" {
+			return nil, shared.NewValidationError("two-factor code is required", "code")
+		}
+		if _, err := verifyTwoFactorCode(ctx, uc.txManager, uc.enrollments, req.UserID, req.Code, uc.now()); err != nil {
+			return nil, codeError(err)
+		}
+	} else {
+		if req.Password == "" {
+			return nil, shared.NewValidationError("password is required", "password")
+		}
+		account, err := uc.accounts.VerifyPassword(ctx, req.Email, req.Password)
+		if errors.Is(err, ErrInvalidCredentials) || (err == nil && account.UserID != req.UserID) {
+			return nil, shared.NewValidationError("invalid password", "password")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify password: %w", err)
+		}
+	}
+
+	if err := uc.reauthenticateSession(ctx, req.UserID, req.SessionID, twoFactor); err != nil {
+		if isSessionRejection(err) {
+			return nil, shared.NewUnauthorizedError(ErrInvalidAccessToken.Error())
+		}
+		return nil, err
+	}
+
+	now := uc.now().UTC()
+	return &StepUpResult{
+		ReauthenticatedAt: now,
+		ValidUntil:        now.Add(StepUpWindow),
+		TwoFactor:         twoFactor,
+	}, nil
+}
+
+// SetProjectPolicy liga/desliga a exigência de 2FA no projeto. Só o dono altera, e para ligar
+// precisa ter o próprio 2FA ativo (não se tranca fora do projeto).
+func (uc *TwoFactorUseCase) SetProjectPolicy(ctx context.Context, userID, projectID uuid.UUID, required bool) error {
+	p, err := uc.projects.FindByID(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, project.ErrProjectNotFound) {
+			return shared.NewNotFoundError("project", projectID.String())
+		}
+		return fmt.Errorf("failed to load project: %w", err)
+	}
+	if p.CustomerID() != userID {
+		return shared.NewForbiddenError("only the project owner can change the two-factor policy")
+	}
+
+	if required {
+		status, err := uc.Status(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if !status.Enabled {
+			return shared.NewPreconditionError("enable two-factor authentication on your account before requiring it")
+		}
+	}
+
+	before := map[string]bool{"require_two_factor": p.RequiresTwoFactor()}
+	p.SetRequireTwoFactor(required)
+	if err := uc.projects.Save(ctx, p); err != nil {
+		return fmt.Errorf("failed to save project: %w", err)
+	}
+	if uc.access != nil {
+		uc.access.InvalidateProject(ctx, projectID)
+	}
+	auditapp.Annotate(ctx, "projects", projectID.String(), before, map[string]bool{"require_two_factor": required})
+	uc.logger.Info("Project two-factor policy changed",
+		zap.String("project_id", projectID.String()),
+		zap.String("user_id", userID.String()),
+		zap.Bool("required", required))
+	return nil
+}
+
+func (uc *TwoFactorUseCase) reauthenticateSession(ctx context.Context, userID, sessionID uuid.UUID, twoFactor bool) error {
+	if sessionID == uuid.Nil {
+		return nil
+	}
+	s, err := uc.sessions.FindByID(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+	if s.UserID() != userID {
+		return authsession.ErrSessionNotFound
+	}
+	if err := s.Reauthenticate(twoFactor, uc.now()); err != nil {
+		return err
+	}
+	return uc.sessions.Save(ctx, s)
+}
+
+func (uc *TwoFactorUseCase) projectRequiresTwoFactor(ctx context.Context, projectID uuid.UUID) (bool, error) {
+	if projectID == uuid.Nil {
+		return false, nil
+	}
+	p, err := uc.projects.FindByID(ctx, projectID)
+	if errors.Is(err, project.ErrProjectNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to load project: %w", err)
+	}
+	return p.RequiresTwoFactor(), nil
+}
+
+// verifyTwoFactorCode confere e grava o uso do código numa transação (o passo TOTP aceito e o
+// código de recuperação consumido não valem de novo)
+func verifyTwoFactorCode(ctx context.Context, txManager TransactionManager, enrollments twofactor.Repository, userID uuid.UUID, code string, now time.Time) (bool, error) {
+	var usedRecoveryCode bool
+	err := txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		e, err := enrollments.FindByUserID(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if usedRecoveryCode, err = e.Verify(code, now); err != nil {
+			return err
+		}
+		return enrollments.Save(txCtx, e)
+	})
+	return usedRecoveryCode, err
+}
+
+func isTwoFactorRejection(err error) bool {
+	return errors.Is(err, twofactor.ErrInvalidCode) ||
+		errors.Is(err, twofactor.ErrNotActive) ||
+		errors.Is(err, twofactor.ErrEnrollmentNotFound)
+}
+
+// codeError erros de código para quem já está autenticado: 400 no campo code, não 401 (que derrubaria a sessão no cliente)
+func codeError(err error) error {
+	switch {
+	case errors.Is(err, twofactor.ErrEnrollmentNotFound), errors.Is(err, twofactor.ErrNotActive):
+		return shared.NewPreconditionError(twofactor.ErrNotActive.Error())
+	case errors.Is(err, twofactor.ErrInvalidCode):
+		return shared.NewValidationError(err.Error(), "code")
+	}
+	return err
+}
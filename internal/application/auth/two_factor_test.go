@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"github.com/ventros/crm/internal/domain/core/authsession"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
+	"go.uber.org/zap"
+)
+
+// enrolled inscrição ativa confirmada em now; o próximo código aceito é o do passo seguinte
+func enrolled(t *testing.T, store *fakeEnrollmentStore, userID uuid.UUID, now time.Time) (*twofactor.Enrollment, []string) {
+	t.Helper()
+	e, err := twofactor.NewEnrollment(userID)
+	require.NoError(t, err)
+	codes, err := e.Confirm(twofactor.Code(e.Secret(), twofactor.Step(now)), now)
+	require.NoError(t, err)
+	require.NoError(t, store.Save(context.Background(), e))
+	return e, codes
+}
+
+func errorType(t *testing.T, err error) shared.ErrorType {
+	t.Helper()
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr), "expected a domain error, got %v", err)
+	return domainErr.Type
+}
+
+func TestManageSessions_TwoFactorLogin(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	later := now.Add(twofactor.Period)
+	account := testAccount()
+	accounts := new(MockAccountStore)
+	accounts.On("VerifyPassword", ctx, "ana@example.com", "senha123").Return(account, nil)
+	accounts.On("FindAccount", ctx, account.UserID).Return(account, nil)
+	enrollments := newFakeEnrollmentStore()
+	e, recoveryCodes := enrolled(t, enrollments, account.UserID, now)
+
+	repo := new(MockSessionRepository)
+	var saved *authsession.Session
+	repo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) { saved = args.Get(1).(*authsession.Session) }).Return(nil)
+	uc := newSessionsUseCaseWith(t, repo, accounts, enrollments, newFakeProjectStore())
+	uc.now = func() time.Time { return later }
+
+	result, err := uc.LoginWithPassword(ctx, "ana@example.com", "senha123", ClientInfo{UserAgent: "Chrome", IP: "203.0.113.9"})
+	require.NoError(t, err)
+	assert.True(t, result.TwoFactorRequired)
+	assert.Nil(t, result.TokenPair, "no session before the second factor")
+	repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+
+	_, err = uc.VerifyAccessToken(ctx, result.Challenge.ChallengeToken)
+	assert.ErrorIs(t, err, ErrInvalidAccessToken, "the challenge is not an access token")
+
+	code := twofactor.Code(e.Secret(), twofactor.Step(later))
+	pair, err := uc.CompleteTwoFactorLogin(ctx, result.Challenge.ChallengeToken, code, ClientInfo{UserAgent: "Chrome", IP: "203.0.113.9"})
+	require.NoError(t, err)
+	assert.Equal(t, saved.ID(), pair.SessionID)
+	assert.True(t, saved.IsTwoFactorVerified())
+	assert.Equal(t, authsession.MethodPassword, saved.Method())
+
+	_, err = uc.CompleteTwoFactorLogin(ctx, result.Challenge.ChallengeToken, code, ClientInfo{})
+	assert.Equal(t, shared.ErrorTypeUnauthorized, errorType(t, err), "a TOTP code is accepted once")
+
+	_, err = uc.CompleteTwoFactorLogin(ctx, result.Challenge.ChallengeToken, recoveryCodes[0], ClientInfo{})
+	require.NoError(t, err)
+	assert.Equal(t, twofactor.RecoveryCodeCount-1, e.RemainingRecoveryCodes())
+
+	_, err = uc.CompleteTwoFactorLogin(ctx, "forged", code, ClientInfo{})
+	assert.Equal(t, shared.ErrorTypeUnauthorized, errorType(t, err))
+}
+
+func TestManageSessions_ProjectTwoFactorPolicy(t *testing.T) {
+	ctx := context.Background()
+	account := testAccount()
+	p, err := project.NewProject(account.UserID, uuid.New(), account.TenantID, "Atendimento")
+	require.NoError(t, err)
+	p.SetRequireTwoFactor(true)
+	account.ProjectID = p.ID()
+
+	s, _, err := authsession.NewSession(account.UserID, authsession.Details{Method: authsession.MethodPassword}, time.Hour)
+	require.NoError(t, err)
+	repo := new(MockSessionRepository)
+	repo.On("FindByID", ctx, s.ID()).Return(s, nil)
+	repo.On("Save", ctx, s).Return(nil)
+	enrollments := newFakeEnrollmentStore()
+	projects := newFakeProjectStore(p)
+	uc := newSessionsUseCaseWith(t, repo, new(MockAccountStore), enrollments, projects)
+	token, _, err := uc.tokens.Issue(account, s.ID())
+	require.NoError(t, err)
+
+	principal, err := uc.VerifyAccessToken(ctx, token)
+	require.NoError(t, err)
+	assert.True(t, principal.TwoFactorPending)
+
+	// Inscrever-se na própria sessão libera o acesso sem novo login
+	twoFactor := NewTwoFactorUseCase(enrollments, repo, new(MockAccountStore), projects, &SimpleTransactionManager{}, "Ventros CRM", zap.NewNop())
+	setup, err := twoFactor.BeginEnrollment(ctx, account.UserID, account.Email)
+	require.NoError(t, err)
+	assert.Contains(t, setup.ProvisioningURI, "otpauth://totp/Ventros%20CRM:ana@example.com")
+	secret, err := twofactor.DecodeSecret(setup.Secret)
+	require.NoError(t, err)
+	codes, err := twoFactor.ConfirmEnrollment(ctx, account.UserID, s.ID(), twofactor.Code(secret, twofactor.Step(time.Now())))
+	require.NoError(t, err)
+	assert.Len(t, codes, twofactor.RecoveryCodeCount)
+
+	principal, err = uc.VerifyAccessToken(ctx, token)
+	require.NoError(t, err)
+	assert.False(t, principal.TwoFactorPending)
+
+	err = twoFactor.Disable(ctx, account.UserID, p.ID(), codes[0])
+	assert.Equal(t, shared.ErrorTypeForbidden, errorType(t, err), "cannot disable while the project requires it")
+}
+
+func TestTwoFactor_StepUp(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	userID := uuid.New()
+	s, _, err := authsession.NewSession(userID, authsession.Details{Method: authsession.MethodPassword}, time.Hour)
+	require.NoError(t, err)
+	repo := new(MockSessionRepository)
+	repo.On("FindByID", ctx, s.ID()).Return(s, nil)
+	repo.On("Save", ctx, s).Return(nil)
+	accounts := new(MockAccountStore)
+	accounts.On("VerifyPassword", ctx, "ana@example.com", "senha123").Return(&Account{UserID: userID}, nil)
+	accounts.On("VerifyPassword", ctx, "ana@example.com", mock.Anything).Return(nil, ErrInvalidCredentials)
+	enrollments := newFakeEnrollmentStore()
+	uc := NewTwoFactorUseCase(enrollments, repo, accounts, newFakeProjectStore(), &SimpleTransactionManager{}, "Ventros CRM", zap.NewNop())
+	request := StepUpRequest{UserID: userID, SessionID: s.ID(), Email: "ana@example.com"}
+
+	t.Run("password without two-factor", func(t *testing.T) {
+		request := request
+		request.Password = "errada"
+		_, err := uc.StepUp(ctx, request)
+		assert.Equal(t, shared.ErrorTypeValidation, errorType(t, err))
+
+		request.Password = "senha123"
+		result, err := uc.StepUp(ctx, request)
+		require.NoError(t, err)
+		assert.False(t, result.TwoFactor)
+		assert.False(t, s.IsTwoFactorVerified(), "a password step-up is not a second factor")
+	})
+
+	t.Run("code with two-factor", func(t *testing.T) {
+		e, _ := enrolled(t, enrollments, userID, now)
+		later := now.Add(twofactor.Period)
+		uc.now = func() time.Time { return later }
+
+		request := request
+		request.Password = "senha123"
+		_, err := uc.StepUp(ctx, request)
+		assert.Equal(t, shared.ErrorTypeValidation, errorType(t, err), "password alone is not enough once enrolled")
+
+		request.Code = twofactor.Code(e.Secret(), twofactor.Step(later))
+		result, err := uc.StepUp(ctx, request)
+		require.NoError(t, err)
+		assert.True(t, result.TwoFactor)
+		assert.Equal(t, later.UTC().Add(StepUpWindow), result.ValidUntil)
+		assert.True(t, s.IsTwoFactorVerified())
+	})
+
+	t.Run("api keys cannot step up", func(t *testing.T) {
+		_, err := uc.StepUp(ctx, StepUpRequest{UserID: userID})
+		assert.Equal(t, shared.ErrorTypeForbidden, errorType(t, err))
+	})
+}
+
+type recordingAccessInvalidator struct{ projects []uuid.UUID }
+
+func (r *recordingAccessInvalidator) InvalidateProject(ctx context.Context, projectID uuid.UUID) {
+	r.projects = append(r.projects, projectID)
+}
+
+func TestTwoFactor_SetProjectPolicy(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	p, err := project.NewProject(ownerID, uuid.New(), "tenant", "Atendimento")
+	require.NoError(t, err)
+	enrollments := newFakeEnrollmentStore()
+	uc := NewTwoFactorUseCase(enrollments, new(MockSessionRepository), new(MockAccountStore), newFakeProjectStore(p), &SimpleTransactionManager{}, "Ventros CRM", zap.NewNop())
+	invalidated := &recordingAccessInvalidator{}
+	uc.SetAccessInvalidator(invalidated)
+
+	err = uc.SetProjectPolicy(ctx, uuid.New(), p.ID(), true)
+	assert.Equal(t, shared.ErrorTypeForbidden, errorType(t, err))
+
+	err = uc.SetProjectPolicy(ctx, ownerID, p.ID(), true)
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(t, err), "owner must have 2FA before requiring it")
+	assert.False(t, p.RequiresTwoFactor())
+
+	enrolled(t, enrollments, ownerID, time.Now())
+	auditCtx, scope := auditapp.WithScope(ctx)
+	require.NoError(t, uc.SetProjectPolicy(auditCtx, ownerID, p.ID(), true))
+	assert.True(t, p.RequiresTwoFactor())
+	assert.Equal(t, map[string]audit.Change{"require_two_factor": {Before: false, After: true}}, scope.Changes(), "policy changes go to the audit log")
+	assert.Equal(t, []uuid.UUID{p.ID()}, invalidated.projects, "RBAC must drop the cached policy")
+
+	err = uc.SetProjectPolicy(ctx, ownerID, uuid.New(), false)
+	assert.True(t, shared.IsNotFoundError(err))
+}
@@ -0,0 +1,153 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+)
+
+// OverdueInvoice fatura com régua de cobrança aberta
+type OverdueInvoice struct {
+	InvoiceID        uuid.UUID  `json:"invoice_id"`
+	AmountDue        int64      `json:"amount_due"`
+	Currency         string     `json:"currency"`
+	DueDate          time.Time  `json:"due_date"`
+	DaysOverdue      int        `json:"days_overdue"`
+	RemindersSent    int        `json:"reminders_sent"`
+	ReadOnlyAt       *time.Time `json:"read_only_at,omitempty"`
+	SuspendedAt      *time.Time `json:"suspended_at,omitempty"`
+	HostedInvoiceURL string     `json:"hosted_invoice_url,omitempty"`
+}
+
+// BillingStatus situação de cobrança da conta do projeto
+type BillingStatus struct {
+	BillingAccountID uuid.UUID               `json:"billing_account_id"`
+	Standing         billing.AccountStanding `json:"standing"`
+	PaymentStatus    billing.PaymentStatus   `json:"payment_status"`
+	ReadOnlyAt       *time.Time              `json:"read_only_at,omitempty"`
+	SuspendedAt      *time.Time              `json:"suspended_at,omitempty"`
+	OverdueInvoices  []OverdueInvoice        `json:"overdue_invoices"`
+	Policy           BillingStatusPolicy     `json:"policy"`
+}
+
+// BillingStatusPolicy régua em vigor, para o cliente saber o que acontece e quando
+type BillingStatusPolicy struct {
+	ReminderDays     []int `json:"reminder_days"`
+	GracePeriodDays  int   `json:"grace_period_days"`
+	SuspendAfterDays int   `json:"suspend_after_days"`
+}
+
+// TimelineEntry registro da linha do tempo de cobrança
+type TimelineEntry struct {
+	ID         uuid.UUID              `json:"id"`
+	Type       string                 `json:"type"`
+	Message    string                 `json:"message"`
+	InvoiceID  *uuid.UUID             `json:"invoice_id,omitempty"`
+	Data       map[string]interface{} `json:"data"`
+	OccurredAt time.Time              `json:"occurred_at"`
+}
+
+// BillingStatusUseCase consultas de cobrança do projeto: situação da conta e linha do tempo
+type BillingStatusUseCase struct {
+	resolver projectAccountResolver
+	accounts accountLookup
+	invoices invoiceLookup
+	cases    billing.DunningCaseRepository
+	timeline billing.BillingEventRepository
+	policy   billing.DunningPolicy
+}
+
+func NewBillingStatusUseCase(
+	resolver projectAccountResolver,
+	accounts accountLookup,
+	invoices invoiceLookup,
+	cases billing.DunningCaseRepository,
+	timeline billing.BillingEventRepository,
+	policy billing.DunningPolicy,
+) *BillingStatusUseCase {
+	return &BillingStatusUseCase{
+		resolver: resolver,
+		accounts: accounts,
+		invoices: invoices,
+		cases:    cases,
+		timeline: timeline,
+		policy:   policy,
+	}
+}
+
+// Status situação da conta de cobrança do projeto e as faturas em atraso
+func (uc *BillingStatusUseCase) Status(ctx context.Context, projectID uuid.UUID) (*BillingStatus, error) {
+	accountID, err := uc.resolver.AccountFor(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	account, err := uc.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load billing account: %w", err)
+	}
+	cases, err := uc.cases.FindOpenByAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	overdue := make([]OverdueInvoice, 0, len(cases))
+	for _, c := range cases {
+		item := OverdueInvoice{
+			InvoiceID:     c.InvoiceID(),
+			DueDate:       c.DueDate(),
+			DaysOverdue:   c.DaysOverdue(now),
+			RemindersSent: c.RemindersSent(),
+			ReadOnlyAt:    c.ReadOnlyAt(),
+			SuspendedAt:   c.SuspendedAt(),
+		}
+		if inv, err := uc.invoices.FindByID(ctx, c.InvoiceID()); err == nil {
+			item.AmountDue = inv.AmountDue()
+			item.Currency = inv.Currency()
+			item.HostedInvoiceURL = inv.HostedInvoiceURL()
+		}
+		overdue = append(overdue, item)
+	}
+
+	return &BillingStatus{
+		BillingAccountID: accountID,
+		Standing:         account.Standing(),
+		PaymentStatus:    account.PaymentStatus(),
+		ReadOnlyAt:       account.ReadOnlyAt(),
+		SuspendedAt:      account.SuspendedAt(),
+		OverdueInvoices:  overdue,
+		Policy: BillingStatusPolicy{
+			ReminderDays:     uc.policy.ReminderDays,
+			GracePeriodDays:  uc.policy.GracePeriodDays,
+			SuspendAfterDays: uc.policy.SuspendAfterDays,
+		},
+	}, nil
+}
+
+// Timeline linha do tempo de cobrança da conta do projeto, mais recentes primeiro
+func (uc *BillingStatusUseCase) Timeline(ctx context.Context, projectID uuid.UUID, limit, offset int) ([]TimelineEntry, int64, error) {
+	accountID, err := uc.resolver.AccountFor(ctx, projectID)
+	if err != nil {
+		return nil, 0, err
+	}
+	events, total, err := uc.timeline.ListByAccount(ctx, accountID, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]TimelineEntry, 0, len(events))
+	for _, e := range events {
+		entries = append(entries, TimelineEntry{
+			ID:         e.ID(),
+			Type:       e.Type(),
+			Message:    e.Message(),
+			InvoiceID:  e.InvoiceID(),
+			Data:       e.Data(),
+			OccurredAt: e.OccurredAt(),
+		})
+	}
+	return entries, total, nil
+}
@@ -0,0 +1,523 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/channel"
+	"go.uber.org/zap"
+)
+
+// DunningTimers agenda os passos da régua de cada fatura (ex: workflow do Temporal). O disparo
+// chama DunningService.Advance, que é idempotente.
+type DunningTimers interface {
+	// Schedule agenda os passos; um agendamento anterior da mesma régua é substituído
+	Schedule(ctx context.Context, caseID uuid.UUID, steps []billing.DunningStep) error
+	Cancel(ctx context.Context, caseID uuid.UUID) error
+}
+
+// StandingCache cache da situação das contas, descartado a cada mudança feita pela régua (em
+// todas as réplicas, quando houver Redis)
+type StandingCache interface {
+	Invalidate(ctx context.Context, billingAccountID uuid.UUID)
+}
+
+type accountStore interface {
+	accountLookup
+	Update(ctx context.Context, account *billing.BillingAccount) error
+}
+
+type invoiceLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*billing.Invoice, error)
+	FindOverdue(ctx context.Context) ([]*billing.Invoice, error)
+}
+
+type accountProjects interface {
+	FindByBillingAccount(ctx context.Context, billingAccountID uuid.UUID) ([]*project.Project, error)
+}
+
+type channelStore interface {
+	GetByProjectID(projectID uuid.UUID) ([]*channel.Channel, error)
+	Update(channel *channel.Channel) error
+}
+
+// pauseReason motivo publicado em channel.paused quando a régua suspende a conta
+const pauseReason = "billing_suspended"
+
+// DunningService conduz a régua de cobrança das faturas vencidas: lembretes por email nos dias
+// configurados, modo somente leitura no fim da carência e, por fim, suspensão da conta com os
+// canais pausados. O pagamento (invoice.paid) encerra a régua e, sem outras faturas em atraso,
+// reativa a conta e os canais. Cada passo fica na linha do tempo de cobrança do cliente.
+type DunningService struct {
+	accounts  accountStore
+	invoices  invoiceLookup
+	cases     billing.DunningCaseRepository
+	timeline  billing.BillingEventRepository
+	projects  accountProjects
+	channels  channelStore
+	standing  StandingCache
+	timers    DunningTimers
+	eventBus  EventBus
+	txManager TransactionManager
+	email     EmailSender
+	policy    billing.DunningPolicy
+	logger    *zap.Logger
+	batchSize int
+}
+
+// NewDunningService creates a new instance. standing e email podem ser nil.
+func NewDunningService(
+	accounts accountStore,
+	invoices invoiceLookup,
+	cases billing.DunningCaseRepository,
+	timeline billing.BillingEventRepository,
+	projects accountProjects,
+	channels channelStore,
+	standing StandingCache,
+	timers DunningTimers,
+	eventBus EventBus,
+	txManager TransactionManager,
+	email EmailSender,
+	policy billing.DunningPolicy,
+	logger *zap.Logger,
+) *DunningService {
+	return &DunningService{
+		accounts:  accounts,
+		invoices:  invoices,
+		cases:     cases,
+		timeline:  timeline,
+		projects:  projects,
+		channels:  channels,
+		standing:  standing,
+		timers:    timers,
+		eventBus:  eventBus,
+		txManager: txManager,
+		email:     email,
+		policy:    policy,
+		logger:    logger,
+		batchSize: 200,
+	}
+}
+
+// Start abre a régua da fatura (falha de pagamento ou vencimento encontrado pela varredura).
+// Fatura paga, cancelada ou com régua aberta não muda nada.
+func (s *DunningService) Start(ctx context.Context, invoiceID uuid.UUID, now time.Time) error {
+	var opened *billing.DunningCase
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		inv, err := s.invoices.FindByID(txCtx, invoiceID)
+		if err != nil {
+			return fmt.Errorf("failed to load invoice: %w", err)
+		}
+		if inv.IsPaid() || inv.Status() == billing.InvoiceStatusVoid {
+			return nil
+		}
+		if _, err := s.cases.FindOpenByInvoice(txCtx, invoiceID); err == nil {
+			return nil
+		} else if !errors.Is(err, billing.ErrDunningCaseNotFound) {
+			return err
+		}
+
+		dueDate := now
+		if inv.DueDate() != nil {
+			dueDate = *inv.DueDate()
+		}
+		c, err := billing.NewDunningCase(inv.BillingAccountID(), inv.ID(), dueDate)
+		if err != nil {
+			return err
+		}
+		if err := s.cases.Save(txCtx, c); err != nil {
+			return err
+		}
+		if err := s.record(txCtx, c, "billing.dunning.started",
+			fmt.Sprintf("Fatura de %s com pagamento pendente (vencimento em %s).",
+				formatAmount(inv.AmountDue(), inv.Currency()), dueDate.Format("02/01/2006")),
+			map[string]interface{}{"due_date": dueDate, "amount_due": inv.AmountDue(), "currency": inv.Currency()}, now); err != nil {
+			return err
+		}
+		if err := publishEvents(txCtx, s.eventBus, c.DomainEvents()); err != nil {
+			return err
+		}
+		c.ClearEvents()
+		opened = c
+		return nil
+	})
+	if shared.IsOptimisticLockError(err) {
+		// A varredura e o webhook abriram a régua ao mesmo tempo: a outra venceu
+		return nil
+	}
+	if err != nil || opened == nil {
+		return err
+	}
+
+	if err := s.timers.Schedule(ctx, opened.ID(), s.policy.Steps(opened.DueDate())); err != nil {
+		s.logger.Error("Failed to schedule dunning steps",
+			zap.Error(err),
+			zap.String("dunning_case_id", opened.ID().String()))
+	}
+	return nil
+}
+
+// dunningReminder lembrete a enviar depois do commit
+type dunningReminder struct {
+	account     *billing.BillingAccount
+	invoice     *billing.Invoice
+	daysOverdue int
+	final       bool
+}
+
+// Advance executa os passos vencidos da régua em now. Fatura já paga ou cancelada encerra a régua.
+// Retorna true quando a régua está encerrada (o timer pode parar).
+func (s *DunningService) Advance(ctx context.Context, caseID uuid.UUID, now time.Time) (bool, error) {
+	var closed bool
+	var reminder *dunningReminder
+	var err error
+	for attempt := 1; ; attempt++ {
+		closed, reminder = false, nil
+		err = s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			closed, reminder, err = s.advance(txCtx, caseID, now)
+			return err
+		})
+		if err == nil || !shared.IsOptimisticLockError(err) || attempt == maxRecordAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if reminder != nil {
+		s.sendReminder(ctx, reminder)
+	}
+	return closed, nil
+}
+
+func (s *DunningService) advance(ctx context.Context, caseID uuid.UUID, now time.Time) (bool, *dunningReminder, error) {
+	c, err := s.cases.FindByID(ctx, caseID)
+	if errors.Is(err, billing.ErrDunningCaseNotFound) {
+		return true, nil, nil
+	}
+	if err != nil {
+		return false, nil, err
+	}
+	if !c.IsOpen() {
+		return true, nil, nil
+	}
+
+	inv, err := s.invoices.FindByID(ctx, c.InvoiceID())
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to load invoice: %w", err)
+	}
+	switch {
+	case inv.IsPaid():
+		// invoice.paid perdido ou atrasado: a varredura encerra a régua
+		return true, nil, s.settle(ctx, c, billing.DunningResolutionPaid, now)
+	case inv.Status() == billing.InvoiceStatusVoid:
+		return true, nil, s.settle(ctx, c, billing.DunningResolutionVoided, now)
+	}
+
+	steps := c.PendingSteps(s.policy, now)
+	if len(steps) == 0 {
+		return false, nil, nil
+	}
+
+	account, err := s.accounts.FindByID(ctx, c.BillingAccountID())
+	if err != nil {
+		return false, nil, fmt.Errorf("failed to load billing account: %w", err)
+	}
+
+	var reminder *dunningReminder
+	accountChanged := false
+	for _, step := range steps {
+		switch step.Action {
+		case billing.DunningActionReminder:
+			if err := c.RecordReminder(step.Day, now); err != nil {
+				return false, nil, err
+			}
+			reminder = &dunningReminder{
+				account:     account,
+				invoice:     inv,
+				daysOverdue: c.DaysOverdue(now),
+				final:       step.Day >= s.policy.GracePeriodDays,
+			}
+			if err := s.record(ctx, c, "billing.dunning.reminder_sent",
+				fmt.Sprintf("Lembrete de pagamento enviado para %s (%d dias de atraso).", account.BillingEmail(), c.DaysOverdue(now)),
+				map[string]interface{}{"day": step.Day, "days_overdue": c.DaysOverdue(now)}, now); err != nil {
+				return false, nil, err
+			}
+
+		case billing.DunningActionReadOnly:
+			if err := c.RecordReadOnly(now); err != nil {
+				return false, nil, err
+			}
+			if account.Standing() == billing.StandingGood {
+				account.EnterReadOnly(fmt.Sprintf("invoice %s overdue", inv.ID()))
+				accountChanged = true
+			}
+			if err := s.record(ctx, c, "billing.account.read_only",
+				"Carência encerrada: a conta está em modo somente leitura até o pagamento da fatura.",
+				map[string]interface{}{"day": step.Day}, now); err != nil {
+				return false, nil, err
+			}
+
+		case billing.DunningActionSuspend:
+			if err := c.RecordSuspension(now); err != nil {
+				return false, nil, err
+			}
+			if !account.IsSuspended() {
+				account.Suspend(fmt.Sprintf("invoice %s overdue", inv.ID()))
+				accountChanged = true
+			}
+			paused, err := s.pauseChannels(ctx, account.ID())
+			if err != nil {
+				return false, nil, err
+			}
+			if err := s.record(ctx, c, "billing.account.suspended",
+				"Conta suspensa por falta de pagamento: canais pausados e envios bloqueados até o pagamento da fatura.",
+				map[string]interface{}{"day": step.Day, "paused_channels": paused}, now); err != nil {
+				return false, nil, err
+			}
+		}
+	}
+
+	if err := s.cases.Save(ctx, c); err != nil {
+		return false, nil, err
+	}
+	if accountChanged {
+		if err := s.accounts.Update(ctx, account); err != nil {
+			return false, nil, fmt.Errorf("failed to update billing account: %w", err)
+		}
+		s.invalidate(ctx, account.ID())
+	}
+
+	events := append(c.DomainEvents(), account.DomainEvents()...)
+	if err := publishEvents(ctx, s.eventBus, events); err != nil {
+		return false, nil, err
+	}
+	c.ClearEvents()
+	account.ClearEvents()
+	return false, reminder, nil
+}
+
+// InvoicePaid encerra a régua aberta da fatura paga e, sem outras faturas em atraso, reativa
+// a conta e os canais pausados pela suspensão
+func (s *DunningService) InvoicePaid(ctx context.Context, invoiceID uuid.UUID, now time.Time) error {
+	var settled *billing.DunningCase
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		c, err := s.cases.FindOpenByInvoice(txCtx, invoiceID)
+		if errors.Is(err, billing.ErrDunningCaseNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.settle(txCtx, c, billing.DunningResolutionPaid, now); err != nil {
+			return err
+		}
+		settled = c
+		return nil
+	})
+	if err != nil || settled == nil {
+		return err
+	}
+
+	if err := s.timers.Cancel(ctx, settled.ID()); err != nil {
+		s.logger.Warn("Failed to cancel dunning timer",
+			zap.Error(err),
+			zap.String("dunning_case_id", settled.ID().String()))
+	}
+	return nil
+}
+
+// settle encerra a régua e, quando era a última em aberto da conta, tira as restrições
+func (s *DunningService) settle(ctx context.Context, c *billing.DunningCase, resolution string, now time.Time) error {
+	if err := c.Resolve(resolution, now); err != nil {
+		return err
+	}
+	if err := s.cases.Save(ctx, c); err != nil {
+		return err
+	}
+
+	message := "Pagamento da fatura confirmado."
+	if resolution == billing.DunningResolutionVoided {
+		message = "Fatura cancelada: cobrança encerrada."
+	}
+	if err := s.record(ctx, c, "billing.dunning.resolved", message,
+		map[string]interface{}{"resolution": resolution}, now); err != nil {
+		return err
+	}
+	events := c.DomainEvents()
+	c.ClearEvents()
+
+	open, err := s.cases.FindOpenByAccount(ctx, c.BillingAccountID())
+	if err != nil {
+		return err
+	}
+	if len(open) == 0 {
+		account, err := s.accounts.FindByID(ctx, c.BillingAccountID())
+		if err != nil {
+			return fmt.Errorf("failed to load billing account: %w", err)
+		}
+		if account.RestoreAfterPayment() {
+			if err := s.accounts.Update(ctx, account); err != nil {
+				return fmt.Errorf("failed to update billing account: %w", err)
+			}
+			resumed, err := s.resumeChannels(ctx, account.ID())
+			if err != nil {
+				return err
+			}
+			if err := s.record(ctx, c, "billing.account.reactivated",
+				"Conta reativada: acesso completo e canais retomados.",
+				map[string]interface{}{"resumed_channels": resumed}, now); err != nil {
+				return err
+			}
+			events = append(events, account.DomainEvents()...)
+			account.ClearEvents()
+			s.invalidate(ctx, account.ID())
+		}
+	}
+
+	return publishEvents(ctx, s.eventBus, events)
+}
+
+// ProcessDue abre a régua das faturas vencidas que ainda não têm uma e avança as réguas abertas.
+// Rede de segurança para webhooks e timers perdidos (ex: Temporal indisponível) e único
+// disparador sem Temporal. Falhas não param o lote.
+func (s *DunningService) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	var errs []error
+
+	overdue, err := s.invoices.FindOverdue(ctx)
+	if err != nil {
+		return 0, err
+	}
+	for _, inv := range overdue {
+		if err := s.Start(ctx, inv.ID(), now); err != nil {
+			errs = append(errs, fmt.Errorf("invoice %s: %w", inv.ID(), err))
+		}
+	}
+
+	cases, err := s.cases.FindOpen(ctx, s.batchSize)
+	if err != nil {
+		return 0, errors.Join(append(errs, err)...)
+	}
+	for _, c := range cases {
+		if _, err := s.Advance(ctx, c.ID(), now); err != nil {
+			errs = append(errs, fmt.Errorf("dunning case %s: %w", c.ID(), err))
+		}
+	}
+	return len(cases), errors.Join(errs...)
+}
+
+// pauseChannels pausa os canais ativos dos projetos da conta e retorna quantos pausou
+func (s *DunningService) pauseChannels(ctx context.Context, billingAccountID uuid.UUID) (int, error) {
+	return s.eachChannel(ctx, billingAccountID, func(ch *channel.Channel) bool {
+		return ch.Pause(pauseReason)
+	})
+}
+
+// resumeChannels retoma os canais pausados dos projetos da conta e retorna quantos retomou
+func (s *DunningService) resumeChannels(ctx context.Context, billingAccountID uuid.UUID) (int, error) {
+	return s.eachChannel(ctx, billingAccountID, func(ch *channel.Channel) bool {
+		return ch.Resume()
+	})
+}
+
+func (s *DunningService) eachChannel(ctx context.Context, billingAccountID uuid.UUID, apply func(ch *channel.Channel) bool) (int, error) {
+	projects, err := s.projects.FindByBillingAccount(ctx, billingAccountID)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	for _, p := range projects {
+		channels, err := s.channels.GetByProjectID(p.ID())
+		if err != nil {
+			return changed, fmt.Errorf("failed to load channels of project %s: %w", p.ID(), err)
+		}
+		for _, ch := range channels {
+			if !apply(ch) {
+				continue
+			}
+			if err := s.channels.Update(ch); err != nil {
+				return changed, fmt.Errorf("failed to update channel %s: %w", ch.ID, err)
+			}
+			if err := publishEvents(ctx, s.eventBus, ch.DomainEvents()); err != nil {
+				return changed, err
+			}
+			ch.ClearEvents()
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// record grava um registro da linha do tempo de cobrança ligado à fatura da régua
+func (s *DunningService) record(ctx context.Context, c *billing.DunningCase, eventType, message string, data map[string]interface{}, now time.Time) error {
+	invoiceID := c.InvoiceID()
+	data["dunning_case_id"] = c.ID().String()
+	if err := s.timeline.Append(ctx, billing.NewBillingEvent(c.BillingAccountID(), &invoiceID, eventType, message, data, now)); err != nil {
+		return fmt.Errorf("failed to record billing event: %w", err)
+	}
+	return nil
+}
+
+func (s *DunningService) invalidate(ctx context.Context, billingAccountID uuid.UUID) {
+	if s.standing != nil {
+		s.standing.Invalidate(ctx, billingAccountID)
+	}
+}
+
+// sendReminder avisa o email de cobrança da conta; falhas são só logadas
+func (s *DunningService) sendReminder(ctx context.Context, r *dunningReminder) {
+	if s.email == nil || r.account.BillingEmail() == "" {
+		return
+	}
+
+	subject := fmt.Sprintf("Fatura em atraso há %d dias", r.daysOverdue)
+	if r.daysOverdue <= 1 {
+		subject = "Fatura em atraso"
+	}
+	if err := s.email.Send(ctx, []string{r.account.BillingEmail()}, subject, s.reminderBody(r)); err != nil {
+		s.logger.Error("Failed to send dunning reminder email",
+			zap.Error(err),
+			zap.String("billing_account_id", r.account.ID().String()),
+			zap.String("invoice_id", r.invoice.ID().String()))
+	}
+}
+
+func (s *DunningService) reminderBody(r *dunningReminder) string {
+	var b strings.Builder
+	if r.account.Name() != "" {
+		fmt.Fprintf(&b, "Olá %s,\n\n", r.account.Name())
+	}
+	fmt.Fprintf(&b, "Não identificamos o pagamento da fatura de %s", formatAmount(r.invoice.AmountDue(), r.invoice.Currency()))
+	if r.invoice.DueDate() != nil {
+		fmt.Fprintf(&b, " com vencimento em %s", r.invoice.DueDate().Format("02/01/2006"))
+	}
+	b.WriteString(".\n\n")
+	if r.invoice.HostedInvoiceURL() != "" {
+		fmt.Fprintf(&b, "Pague pelo link: %s\n\n", r.invoice.HostedInvoiceURL())
+	}
+	if r.final {
+		fmt.Fprintf(&b, "A conta passa ao modo somente leitura e, %d dias após o vencimento, será suspensa, ",
+			s.policy.SuspendAfterDays)
+		b.WriteString("com os canais pausados e os envios bloqueados.\n")
+	} else {
+		fmt.Fprintf(&b, "Sem o pagamento, a conta passa ao modo somente leitura %d dias após o vencimento ",
+			s.policy.GracePeriodDays)
+		fmt.Fprintf(&b, "e será suspensa após %d dias.\n", s.policy.SuspendAfterDays)
+	}
+	return b.String()
+}
+
+// formatAmount valor em centavos na moeda da fatura (ex: BRL 149.90)
+func formatAmount(cents int64, currency string) string {
+	return fmt.Sprintf("%s %d.%02d", strings.ToUpper(currency), cents/100, cents%100)
+}
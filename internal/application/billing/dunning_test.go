@@ -0,0 +1,289 @@
+package billing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/channel"
+	"go.uber.org/zap"
+)
+
+type dunningFixture struct {
+	service  *DunningService
+	standing *StandingService
+	account  *billing.BillingAccount
+	project  *project.Project
+	channel  *channel.Channel
+	invoices *fakeInvoiceRepository
+	cases    *fakeDunningCaseRepository
+	timeline *fakeBillingEventRepository
+	timers   *recordingDunningTimers
+	email    *recordingEmailSender
+	eventBus *recordingEventBus
+}
+
+func newDunningFixture(t *testing.T) *dunningFixture {
+	t.Helper()
+	account, err := billing.NewBillingAccount(uuid.New(), "Acme", "billing@acme.com")
+	require.NoError(t, err)
+	account.ClearEvents()
+	p, err := project.NewProject(uuid.New(), account.ID(), "tenant-acme", "Acme")
+	require.NoError(t, err)
+	ch, err := channel.NewChannel(uuid.New(), p.ID(), "tenant-acme", "WhatsApp", channel.TypeWAHA)
+	require.NoError(t, err)
+	ch.Activate()
+	ch.ClearEvents()
+
+	projects := newFakeProjectRepository(p)
+	accounts := &fakeAccountRepository{accounts: map[uuid.UUID]*billing.BillingAccount{account.ID(): account}}
+	f := &dunningFixture{
+		account:  account,
+		project:  p,
+		channel:  ch,
+		invoices: &fakeInvoiceRepository{invoices: map[uuid.UUID]*billing.Invoice{}},
+		cases:    newFakeDunningCaseRepository(),
+		timeline: &fakeBillingEventRepository{},
+		timers:   newRecordingDunningTimers(),
+		email:    &recordingEmailSender{},
+		eventBus: &recordingEventBus{},
+	}
+	quota := NewQuotaService(projects, nil, nil, nil, nil, time.Minute)
+	f.standing = NewStandingService(quota, accounts, time.Minute)
+	f.service = NewDunningService(
+		accounts,
+		f.invoices,
+		f.cases,
+		f.timeline,
+		projects,
+		&fakeChannelRepository{channels: []*channel.Channel{ch}},
+		f.standing,
+		f.timers,
+		f.eventBus,
+		&SimpleTransactionManager{},
+		f.email,
+		billing.DefaultDunningPolicy(),
+		zap.NewNop(),
+	)
+	return f
+}
+
+func (f *dunningFixture) addInvoice(t *testing.T, dueDate time.Time) *billing.Invoice {
+	t.Helper()
+	inv, err := billing.NewInvoice(f.account.ID(), "in_"+uuid.NewString(), nil, 14990, "brl", billing.InvoiceStatusOpen)
+	require.NoError(t, err)
+	inv.SetDueDate(dueDate)
+	inv.SetInvoiceURLs("https://invoice.stripe.com/i/acme", "")
+	f.invoices.invoices[inv.ID()] = inv
+	return inv
+}
+
+func (f *dunningFixture) openCase(t *testing.T, invoiceID uuid.UUID) *billing.DunningCase {
+	t.Helper()
+	c, err := f.cases.FindOpenByInvoice(context.Background(), invoiceID)
+	require.NoError(t, err)
+	return c
+}
+
+func TestDunningService_FullCycle(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	inv := f.addInvoice(t, due)
+
+	require.NoError(t, f.service.Start(ctx, inv.ID(), due))
+	c := f.openCase(t, inv.ID())
+	assert.Len(t, f.timers.scheduled[c.ID()], 5)
+
+	// Dia 1: primeiro lembrete, conta segue normal
+	closed, err := f.service.Advance(ctx, c.ID(), due.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.False(t, closed)
+	require.Len(t, f.email.sent, 1)
+	assert.Equal(t, []string{"billing@acme.com"}, f.email.sent[0].to)
+	assert.Contains(t, f.email.sent[0].body, "https://invoice.stripe.com/i/acme")
+	assert.Contains(t, f.email.sent[0].body, "BRL 149.90")
+	assert.NoError(t, f.standing.CheckStanding(ctx, f.project.ID()))
+
+	// Timer repetido no mesmo dia não reenvia
+	_, err = f.service.Advance(ctx, c.ID(), due.AddDate(0, 0, 1).Add(time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, f.email.sent, 1)
+
+	// Dia 7: fim da carência, somente leitura
+	_, err = f.service.Advance(ctx, c.ID(), due.AddDate(0, 0, 7))
+	require.NoError(t, err)
+	assert.Len(t, f.email.sent, 2)
+	assert.Equal(t, billing.StandingReadOnly, f.account.Standing())
+	err = f.standing.CheckStanding(ctx, f.project.ID())
+	require.Error(t, err)
+	assert.True(t, shared.IsPaymentRequiredError(err))
+	assert.True(t, f.channel.IsActive())
+
+	// Dia 14: suspensão com canais pausados
+	_, err = f.service.Advance(ctx, c.ID(), due.AddDate(0, 0, 14))
+	require.NoError(t, err)
+	assert.Equal(t, billing.StandingSuspended, f.account.Standing())
+	assert.True(t, f.channel.IsPaused())
+
+	// invoice.paid: régua encerrada, conta e canais reativados
+	require.NoError(t, inv.MarkAsPaid(14990))
+	require.NoError(t, f.service.InvoicePaid(ctx, inv.ID(), due.AddDate(0, 0, 15)))
+	assert.Equal(t, billing.StandingGood, f.account.Standing())
+	assert.True(t, f.channel.IsActive())
+	assert.NoError(t, f.standing.CheckStanding(ctx, f.project.ID()))
+	assert.Equal(t, []uuid.UUID{c.ID()}, f.timers.canceled)
+
+	stored, err := f.cases.FindByID(ctx, c.ID())
+	require.NoError(t, err)
+	assert.False(t, stored.IsOpen())
+	assert.Equal(t, billing.DunningResolutionPaid, stored.Resolution())
+
+	assert.Equal(t, []string{
+		"billing.dunning.started",
+		"billing.dunning.reminder_sent",
+		"billing.dunning.reminder_sent",
+		"billing.account.read_only",
+		"billing.account.suspended",
+		"billing.dunning.resolved",
+		"billing.account.reactivated",
+	}, f.timeline.types())
+	assert.Subset(t, f.eventBus.eventTypes(), []string{
+		"billing.dunning.started",
+		"billing.account.read_only",
+		"billing.account.suspended",
+		"channel.paused",
+		"channel.resumed",
+		"billing.account.reactivated",
+	})
+}
+
+func TestDunningService_StartIsIdempotent(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	inv := f.addInvoice(t, due)
+
+	require.NoError(t, f.service.Start(ctx, inv.ID(), due))
+	require.NoError(t, f.service.Start(ctx, inv.ID(), due.Add(time.Hour)))
+	assert.Len(t, f.cases.cases, 1)
+	assert.Len(t, f.timers.scheduled, 1)
+
+	paid := f.addInvoice(t, due)
+	require.NoError(t, paid.MarkAsPaid(14990))
+	require.NoError(t, f.service.Start(ctx, paid.ID(), due))
+	assert.Len(t, f.cases.cases, 1)
+}
+
+func TestDunningService_AdvanceClosesPaidInvoice(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	inv := f.addInvoice(t, due)
+	require.NoError(t, f.service.Start(ctx, inv.ID(), due))
+	c := f.openCase(t, inv.ID())
+	_, err := f.service.Advance(ctx, c.ID(), due.AddDate(0, 0, 8))
+	require.NoError(t, err)
+	require.True(t, f.account.IsReadOnly())
+
+	// invoice.paid perdido: o próximo disparo encontra a fatura paga e encerra a régua
+	require.NoError(t, inv.MarkAsPaid(14990))
+	closed, err := f.service.Advance(ctx, c.ID(), due.AddDate(0, 0, 9))
+	require.NoError(t, err)
+	assert.True(t, closed)
+	assert.Equal(t, billing.StandingGood, f.account.Standing())
+	assert.Len(t, f.email.sent, 1)
+}
+
+func TestDunningService_ReactivatesOnlyAfterLastOverdueInvoice(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	first := f.addInvoice(t, due)
+	second := f.addInvoice(t, due.AddDate(0, 0, 2))
+	require.NoError(t, f.service.Start(ctx, first.ID(), due))
+	require.NoError(t, f.service.Start(ctx, second.ID(), due))
+	for _, inv := range []*billing.Invoice{first, second} {
+		_, err := f.service.Advance(ctx, f.openCase(t, inv.ID()).ID(), due.AddDate(0, 0, 20))
+		require.NoError(t, err)
+	}
+	require.True(t, f.account.IsSuspended())
+
+	require.NoError(t, first.MarkAsPaid(14990))
+	require.NoError(t, f.service.InvoicePaid(ctx, first.ID(), due.AddDate(0, 0, 21)))
+	assert.True(t, f.account.IsSuspended())
+	assert.True(t, f.channel.IsPaused())
+
+	require.NoError(t, second.MarkAsPaid(14990))
+	require.NoError(t, f.service.InvoicePaid(ctx, second.ID(), due.AddDate(0, 0, 22)))
+	assert.Equal(t, billing.StandingGood, f.account.Standing())
+	assert.True(t, f.channel.IsActive())
+}
+
+func TestDunningService_ProcessDueOpensMissedInvoices(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+	now := time.Now()
+	inv := f.addInvoice(t, now.AddDate(0, 0, -8))
+
+	count, err := f.service.ProcessDue(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Descoberta tarde: um lembrete só (o do dia 7) e o fim da carência
+	c := f.openCase(t, inv.ID())
+	require.NotNil(t, c.LastReminderDay())
+	assert.Equal(t, 7, *c.LastReminderDay())
+	assert.Equal(t, 1, c.RemindersSent())
+	assert.Len(t, f.email.sent, 1)
+	assert.True(t, f.account.IsReadOnly())
+}
+
+func TestStandingService_CachesUntilInvalidated(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+
+	assert.NoError(t, f.standing.CheckStanding(ctx, f.project.ID()))
+	f.account.EnterReadOnly("manual")
+	assert.NoError(t, f.standing.CheckStanding(ctx, f.project.ID()))
+
+	f.standing.Invalidate(ctx, f.account.ID())
+	err := f.standing.CheckStanding(ctx, f.project.ID())
+	var domainErr *shared.DomainError
+	require.ErrorAs(t, err, &domainErr)
+	assert.Equal(t, "ACCOUNT_RESTRICTED", domainErr.Code)
+	assert.Equal(t, "read_only", domainErr.Details["standing"])
+}
+
+func TestBillingStatusUseCase(t *testing.T) {
+	ctx := context.Background()
+	f := newDunningFixture(t)
+	due := time.Now().AddDate(0, 0, -10)
+	inv := f.addInvoice(t, due)
+	_, err := f.service.ProcessDue(ctx, time.Now())
+	require.NoError(t, err)
+
+	quota := NewQuotaService(newFakeProjectRepository(f.project), nil, nil, nil, nil, time.Minute)
+	accounts := &fakeAccountRepository{accounts: map[uuid.UUID]*billing.BillingAccount{f.account.ID(): f.account}}
+	uc := NewBillingStatusUseCase(quota, accounts, f.invoices, f.cases, f.timeline, billing.DefaultDunningPolicy())
+
+	status, err := uc.Status(ctx, f.project.ID())
+	require.NoError(t, err)
+	assert.Equal(t, billing.StandingReadOnly, status.Standing)
+	require.Len(t, status.OverdueInvoices, 1)
+	assert.Equal(t, inv.ID(), status.OverdueInvoices[0].InvoiceID)
+	assert.Equal(t, 10, status.OverdueInvoices[0].DaysOverdue)
+	assert.Equal(t, "https://invoice.stripe.com/i/acme", status.OverdueInvoices[0].HostedInvoiceURL)
+
+	entries, total, err := uc.Timeline(ctx, f.project.ID(), 2, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "billing.account.read_only", entries[0].Type)
+}
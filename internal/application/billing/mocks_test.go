@@ -0,0 +1,414 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/channel"
+)
+
+type fakeProjectRepository struct {
+	projects map[uuid.UUID]*project.Project
+	lookups  int
+}
+
+func newFakeProjectRepository(projects ...*project.Project) *fakeProjectRepository {
+	r := &fakeProjectRepository{projects: make(map[uuid.UUID]*project.Project)}
+	for _, p := range projects {
+		r.projects[p.ID()] = p
+	}
+	return r
+}
+
+func (r *fakeProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	r.lookups++
+	p, ok := r.projects[id]
+	if !ok {
+		return nil, project.ErrProjectNotFound
+	}
+	return p, nil
+}
+
+func (r *fakeProjectRepository) FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error) {
+	for _, p := range r.projects {
+		if p.TenantID() == tenantID {
+			return p, nil
+		}
+	}
+	return nil, project.ErrProjectNotFound
+}
+
+func (r *fakeProjectRepository) FindByBillingAccount(ctx context.Context, billingAccountID uuid.UUID) ([]*project.Project, error) {
+	var projects []*project.Project
+	for _, p := range r.projects {
+		if p.BillingAccountID() == billingAccountID {
+			projects = append(projects, p)
+		}
+	}
+	return projects, nil
+}
+
+type fakeSubscriptionRepository struct {
+	active map[uuid.UUID]*billing.Subscription
+}
+
+func (r *fakeSubscriptionRepository) FindActiveByBillingAccount(ctx context.Context, billingAccountID uuid.UUID) (*billing.Subscription, error) {
+	sub, ok := r.active[billingAccountID]
+	if !ok {
+		return nil, billing.ErrNotFound
+	}
+	return sub, nil
+}
+
+type fakePlanRepository struct {
+	plans []*billing.Plan
+}
+
+func (r *fakePlanRepository) FindByStripePriceID(ctx context.Context, stripePriceID string) (*billing.Plan, error) {
+	for _, p := range r.plans {
+		if p.StripePriceID() != "" && p.StripePriceID() == stripePriceID {
+			return p, nil
+		}
+	}
+	return nil, billing.ErrPlanNotFound
+}
+
+func (r *fakePlanRepository) FindDefault(ctx context.Context) (*billing.Plan, error) {
+	for _, p := range r.plans {
+		if p.IsDefault() {
+			return p, nil
+		}
+	}
+	return nil, billing.ErrPlanNotFound
+}
+
+// fakeMeterRepository guarda cópias dos medidores, como o banco: um incremento descartado por
+// conflito não vaza para a próxima tentativa
+type fakeMeterRepository struct {
+	mu        sync.Mutex
+	meters    map[string]*billing.UsageMeter
+	conflicts int
+}
+
+func newFakeMeterRepository() *fakeMeterRepository {
+	return &fakeMeterRepository{meters: make(map[string]*billing.UsageMeter)}
+}
+
+func copyMeter(m *billing.UsageMeter, version int) *billing.UsageMeter {
+	return billing.ReconstructUsageMeter(m.ID(), version, m.BillingAccountID(), m.StripeCustomerID(), m.StripeMeterID(),
+		m.MetricName(), m.EventName(), m.Quantity(), m.PeriodStart(), m.PeriodEnd(), m.LastReportedAt(), m.Metadata(),
+		m.CreatedAt(), m.UpdatedAt())
+}
+
+func (r *fakeMeterRepository) FindByMetricName(ctx context.Context, billingAccountID uuid.UUID, metricName string) (*billing.UsageMeter, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	m, ok := r.meters[billingAccountID.String()+metricName]
+	if !ok {
+		return nil, billing.ErrNotFound
+	}
+	return copyMeter(m, m.Version()), nil
+}
+
+func (r *fakeMeterRepository) Update(ctx context.Context, meter *billing.UsageMeter) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.conflicts > 0 {
+		r.conflicts--
+		return shared.NewOptimisticLockError("UsageMeter", meter.ID().String(), meter.Version(), meter.Version()+1)
+	}
+	r.meters[meter.BillingAccountID().String()+meter.MetricName()] = copyMeter(meter, meter.Version()+1)
+	return nil
+}
+
+func (r *fakeMeterRepository) quantity(billingAccountID uuid.UUID, metric billing.UsageMetric) int64 {
+	m, err := r.FindByMetricName(context.Background(), billingAccountID, string(metric))
+	if err != nil {
+		return 0
+	}
+	return m.Quantity()
+}
+
+type fakeResourceCounter struct {
+	counts map[billing.UsageMetric]int64
+}
+
+func (c *fakeResourceCounter) CountResources(ctx context.Context, billingAccountID uuid.UUID, metric billing.UsageMetric) (int64, error) {
+	return c.counts[metric], nil
+}
+
+type fakeAccountRepository struct {
+	accounts map[uuid.UUID]*billing.BillingAccount
+}
+
+func (r *fakeAccountRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.BillingAccount, error) {
+	a, ok := r.accounts[id]
+	if !ok {
+		return nil, billing.ErrNotFound
+	}
+	return a, nil
+}
+
+func (r *fakeAccountRepository) Update(ctx context.Context, account *billing.BillingAccount) error {
+	r.accounts[account.ID()] = account
+	return nil
+}
+
+type fakeUsageRecordRepository struct {
+	records  []*billing.UsageRecord
+	reported map[uuid.UUID]time.Time
+}
+
+func newFakeUsageRecordRepository() *fakeUsageRecordRepository {
+	return &fakeUsageRecordRepository{reported: make(map[uuid.UUID]time.Time)}
+}
+
+func (r *fakeUsageRecordRepository) Record(ctx context.Context, record *billing.UsageRecord) (bool, error) {
+	for _, existing := range r.records {
+		if existing.Metric() == record.Metric() && existing.SourceID() == record.SourceID() {
+			return false, nil
+		}
+	}
+	r.records = append(r.records, record)
+	return true, nil
+}
+
+func (r *fakeUsageRecordRepository) FindUnreported(ctx context.Context, limit int) ([]*billing.UsageRecord, error) {
+	var pending []*billing.UsageRecord
+	for _, record := range r.records {
+		if _, done := r.reported[record.ID()]; !done && record.Metric().IsMetered() {
+			pending = append(pending, record)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].OccurredAt().Before(pending[j].OccurredAt()) })
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+	return pending, nil
+}
+
+func (r *fakeUsageRecordRepository) MarkReported(ctx context.Context, ids []uuid.UUID, reportedAt time.Time) error {
+	for _, id := range ids {
+		r.reported[id] = reportedAt
+	}
+	return nil
+}
+
+type recordingMeterReporter struct {
+	events []MeterEvent
+	fail   map[string]bool
+}
+
+func (r *recordingMeterReporter) ReportMeterEvent(ctx context.Context, event MeterEvent) error {
+	if r.fail[event.StripeCustomerID] {
+		return errors.New("stripe unavailable")
+	}
+	r.events = append(r.events, event)
+	return nil
+}
+
+type sentEmail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+type recordingEmailSender struct {
+	sent []sentEmail
+}
+
+func (s *recordingEmailSender) Send(ctx context.Context, to []string, subject, body string) error {
+	s.sent = append(s.sent, sentEmail{to: to, subject: subject, body: body})
+	return nil
+}
+
+type recordingEventBus struct {
+	events []shared.DomainEvent
+}
+
+func (b *recordingEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *recordingEventBus) eventTypes() []string {
+	types := make([]string, 0, len(b.events))
+	for _, e := range b.events {
+		types = append(types, e.EventName())
+	}
+	return types
+}
+
+// SimpleTransactionManager is a test transaction manager that just executes the function
+type SimpleTransactionManager struct{}
+
+func (m *SimpleTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type fakeInvoiceRepository struct {
+	invoices map[uuid.UUID]*billing.Invoice
+}
+
+func (r *fakeInvoiceRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.Invoice, error) {
+	inv, ok := r.invoices[id]
+	if !ok {
+		return nil, billing.ErrNotFound
+	}
+	return inv, nil
+}
+
+func (r *fakeInvoiceRepository) FindOverdue(ctx context.Context) ([]*billing.Invoice, error) {
+	var overdue []*billing.Invoice
+	for _, inv := range r.invoices {
+		if inv.IsOverdue() {
+			overdue = append(overdue, inv)
+		}
+	}
+	return overdue, nil
+}
+
+// fakeDunningCaseRepository guarda cópias das réguas, como o banco
+type fakeDunningCaseRepository struct {
+	cases map[uuid.UUID]*billing.DunningCase
+}
+
+func newFakeDunningCaseRepository() *fakeDunningCaseRepository {
+	return &fakeDunningCaseRepository{cases: make(map[uuid.UUID]*billing.DunningCase)}
+}
+
+func copyDunningCase(c *billing.DunningCase, version int) *billing.DunningCase {
+	return billing.ReconstructDunningCase(c.ID(), version, c.BillingAccountID(), c.InvoiceID(), c.DueDate(), c.Status(),
+		c.LastReminderDay(), c.RemindersSent(), c.ReadOnlyAt(), c.SuspendedAt(), c.ResolvedAt(), c.Resolution(),
+		c.CreatedAt(), c.UpdatedAt())
+}
+
+func (r *fakeDunningCaseRepository) Save(ctx context.Context, c *billing.DunningCase) error {
+	if existing, ok := r.cases[c.ID()]; ok && existing.Version() != c.Version() {
+		return shared.NewOptimisticLockError("DunningCase", c.ID().String(), existing.Version(), c.Version())
+	}
+	version := c.Version()
+	if _, ok := r.cases[c.ID()]; ok {
+		version++
+	}
+	r.cases[c.ID()] = copyDunningCase(c, version)
+	return nil
+}
+
+func (r *fakeDunningCaseRepository) FindByID(ctx context.Context, id uuid.UUID) (*billing.DunningCase, error) {
+	c, ok := r.cases[id]
+	if !ok {
+		return nil, billing.ErrDunningCaseNotFound
+	}
+	return copyDunningCase(c, c.Version()), nil
+}
+
+func (r *fakeDunningCaseRepository) FindOpenByInvoice(ctx context.Context, invoiceID uuid.UUID) (*billing.DunningCase, error) {
+	for _, c := range r.cases {
+		if c.InvoiceID() == invoiceID && c.IsOpen() {
+			return copyDunningCase(c, c.Version()), nil
+		}
+	}
+	return nil, billing.ErrDunningCaseNotFound
+}
+
+func (r *fakeDunningCaseRepository) FindOpenByAccount(ctx context.Context, billingAccountID uuid.UUID) ([]*billing.DunningCase, error) {
+	var open []*billing.DunningCase
+	for _, c := range r.cases {
+		if c.BillingAccountID() == billingAccountID && c.IsOpen() {
+			open = append(open, copyDunningCase(c, c.Version()))
+		}
+	}
+	return open, nil
+}
+
+func (r *fakeDunningCaseRepository) FindOpen(ctx context.Context, limit int) ([]*billing.DunningCase, error) {
+	var open []*billing.DunningCase
+	for _, c := range r.cases {
+		if c.IsOpen() {
+			open = append(open, copyDunningCase(c, c.Version()))
+		}
+	}
+	return open, nil
+}
+
+type fakeBillingEventRepository struct {
+	events []*billing.BillingEvent
+}
+
+func (r *fakeBillingEventRepository) Append(ctx context.Context, event *billing.BillingEvent) error {
+	r.events = append(r.events, event)
+	return nil
+}
+
+func (r *fakeBillingEventRepository) ListByAccount(ctx context.Context, billingAccountID uuid.UUID, limit, offset int) ([]*billing.BillingEvent, int64, error) {
+	var list []*billing.BillingEvent
+	for i := len(r.events) - 1; i >= 0; i-- {
+		if r.events[i].BillingAccountID() == billingAccountID {
+			list = append(list, r.events[i])
+		}
+	}
+	total := int64(len(list))
+	if offset >= len(list) {
+		return nil, total, nil
+	}
+	list = list[offset:]
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+	return list, total, nil
+}
+
+func (r *fakeBillingEventRepository) types() []string {
+	types := make([]string, 0, len(r.events))
+	for _, e := range r.events {
+		types = append(types, e.Type())
+	}
+	return types
+}
+
+type fakeChannelRepository struct {
+	channels []*channel.Channel
+	updates  int
+}
+
+func (r *fakeChannelRepository) GetByProjectID(projectID uuid.UUID) ([]*channel.Channel, error) {
+	var list []*channel.Channel
+	for _, ch := range r.channels {
+		if ch.ProjectID == projectID {
+			list = append(list, ch)
+		}
+	}
+	return list, nil
+}
+
+func (r *fakeChannelRepository) Update(ch *channel.Channel) error {
+	r.updates++
+	return nil
+}
+
+type recordingDunningTimers struct {
+	scheduled map[uuid.UUID][]billing.DunningStep
+	canceled  []uuid.UUID
+}
+
+func newRecordingDunningTimers() *recordingDunningTimers {
+	return &recordingDunningTimers{scheduled: make(map[uuid.UUID][]billing.DunningStep)}
+}
+
+func (t *recordingDunningTimers) Schedule(ctx context.Context, caseID uuid.UUID, steps []billing.DunningStep) error {
+	t.scheduled[caseID] = steps
+	return nil
+}
+
+func (t *recordingDunningTimers) Cancel(ctx context.Context, caseID uuid.UUID) error {
+	t.canceled = append(t.canceled, caseID)
+	return nil
+}
@@ -0,0 +1,39 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// EmailSender envia emails de texto simples
+type EmailSender interface {
+	Send(ctx context.Context, to []string, subject, body string) error
+}
+
+// ResourceCounter conta os recursos de estoque (canais, contatos, agentes) de todos os projetos
+// de uma conta de cobrança
+type ResourceCounter interface {
+	CountResources(ctx context.Context, billingAccountID uuid.UUID, metric billing.UsageMetric) (int64, error)
+}
+
+// publishEvents publica os eventos pendentes (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, events []shared.DomainEvent) error {
+	for _, event := range events {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,244 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// DefaultPlanCacheTTL prazo do cache de plano por conta: mudanças de assinatura valem em até um minuto
+const DefaultPlanCacheTTL = time.Minute
+
+type projectLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error)
+	FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error)
+}
+
+type subscriptionLookup interface {
+	FindActiveByBillingAccount(ctx context.Context, billingAccountID uuid.UUID) (*billing.Subscription, error)
+}
+
+type planLookup interface {
+	FindByStripePriceID(ctx context.Context, stripePriceID string) (*billing.Plan, error)
+	FindDefault(ctx context.Context) (*billing.Plan, error)
+}
+
+type meterLookup interface {
+	FindByMetricName(ctx context.Context, billingAccountID uuid.UUID, metricName string) (*billing.UsageMeter, error)
+}
+
+// unlimitedPlan vale quando nem o plano padrão está cadastrado: sem catálogo não há o que bloquear
+var unlimitedPlan = billing.ReconstructPlan(uuid.Nil, "unlimited", "Unlimited", "", billing.Entitlements{
+	MaxChannels:      billing.Unlimited,
+	MaxContacts:      billing.Unlimited,
+	MaxAgents:        billing.Unlimited,
+	MonthlyMessages:  billing.Unlimited,
+	AIMinutes:        billing.Unlimited,
+	BroadcastsPerDay: billing.Unlimited,
+}, 0, true, time.Time{}, time.Time{})
+
+// AccountPlan plano em vigor de uma conta e o período da assinatura (zero sem assinatura ativa)
+type AccountPlan struct {
+	BillingAccountID uuid.UUID
+	Plan             *billing.Plan
+	PeriodStart      time.Time
+	PeriodEnd        time.Time
+}
+
+// Window janela de consumo da métrica que contém o instante: o dia (UTC) para métricas diárias e
+// o período da assinatura — ou o mês civil, sem assinatura — para as mensais
+func (a *AccountPlan) Window(metric billing.UsageMetric, at time.Time) (time.Time, time.Time) {
+	if metric.Window() == billing.WindowDay {
+		return billing.DayWindow(at)
+	}
+	if !a.PeriodStart.IsZero() && !at.Before(a.PeriodStart) && at.Before(a.PeriodEnd) {
+		return a.PeriodStart, a.PeriodEnd
+	}
+	return billing.MonthWindow(at)
+}
+
+type cachedAccount struct {
+	billingAccountID uuid.UUID
+	loadedAt         time.Time
+}
+
+type cachedPlan struct {
+	plan     *AccountPlan
+	loadedAt time.Time
+}
+
+// QuotaService resolve o plano de cada conta e compara o consumo atual com os limites. Projeto →
+// conta e conta → plano ficam em cache; o consumo é sempre lido na hora.
+type QuotaService struct {
+	projects      projectLookup
+	subscriptions subscriptionLookup
+	plans         planLookup
+	meters        meterLookup
+	counter       ResourceCounter
+	ttl           time.Duration
+	now           func() time.Time
+
+	mu       sync.Mutex
+	accounts map[uuid.UUID]cachedAccount
+	cache    map[uuid.UUID]cachedPlan
+}
+
+func NewQuotaService(
+	projects projectLookup,
+	subscriptions subscriptionLookup,
+	plans planLookup,
+	meters meterLookup,
+	counter ResourceCounter,
+	ttl time.Duration,
+) *QuotaService {
+	if ttl <= 0 {
+		ttl = DefaultPlanCacheTTL
+	}
+	return &QuotaService{
+		projects:      projects,
+		subscriptions: subscriptions,
+		plans:         plans,
+		meters:        meters,
+		counter:       counter,
+		ttl:           ttl,
+		now:           time.Now,
+		accounts:      make(map[uuid.UUID]cachedAccount),
+		cache:         make(map[uuid.UUID]cachedPlan),
+	}
+}
+
+// AccountFor conta de cobrança do projeto
+func (s *QuotaService) AccountFor(ctx context.Context, projectID uuid.UUID) (uuid.UUID, error) {
+	now := s.now()
+	s.mu.Lock()
+	entry, ok := s.accounts[projectID]
+	s.mu.Unlock()
+	if ok && now.Sub(entry.loadedAt) < s.ttl {
+		return entry.billingAccountID, nil
+	}
+
+	p, err := s.projects.FindByID(ctx, projectID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to load project %s: %w", projectID, err)
+	}
+
+	s.mu.Lock()
+	s.accounts[projectID] = cachedAccount{billingAccountID: p.BillingAccountID(), loadedAt: now}
+	s.mu.Unlock()
+	return p.BillingAccountID(), nil
+}
+
+// ProjectForTenant projeto do tenant, para eventos que só carregam o tenant (ex: broadcasts)
+func (s *QuotaService) ProjectForTenant(ctx context.Context, tenantID string) (uuid.UUID, error) {
+	p, err := s.projects.FindByTenantID(ctx, tenantID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to load project of tenant %s: %w", tenantID, err)
+	}
+	return p.ID(), nil
+}
+
+// AccountPlan plano em vigor: o da assinatura ativa (pelo price do Stripe) ou o plano padrão
+func (s *QuotaService) AccountPlan(ctx context.Context, billingAccountID uuid.UUID) (*AccountPlan, error) {
+	now := s.now()
+	s.mu.Lock()
+	entry, ok := s.cache[billingAccountID]
+	s.mu.Unlock()
+	if ok && now.Sub(entry.loadedAt) < s.ttl {
+		return entry.plan, nil
+	}
+
+	ap := &AccountPlan{BillingAccountID: billingAccountID}
+	sub, err := s.subscriptions.FindActiveByBillingAccount(ctx, billingAccountID)
+	switch {
+	case err == nil:
+		ap.PeriodStart, ap.PeriodEnd = sub.CurrentPeriodStart(), sub.CurrentPeriodEnd()
+		ap.Plan, err = s.plans.FindByStripePriceID(ctx, sub.StripePriceID())
+		if err != nil && !errors.Is(err, billing.ErrPlanNotFound) {
+			return nil, fmt.Errorf("failed to load plan: %w", err)
+		}
+	case !errors.Is(err, billing.ErrNotFound):
+		return nil, fmt.Errorf("failed to load subscription: %w", err)
+	}
+
+	if ap.Plan == nil {
+		ap.Plan, err = s.plans.FindDefault(ctx)
+		switch {
+		case errors.Is(err, billing.ErrPlanNotFound):
+			ap.Plan = unlimitedPlan
+		case err != nil:
+			return nil, fmt.Errorf("failed to load default plan: %w", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.cache[billingAccountID] = cachedPlan{plan: ap, loadedAt: now}
+	s.mu.Unlock()
+	return ap, nil
+}
+
+// InvalidateAccount descarta o plano em cache da conta (ex: depois de mudar a assinatura)
+func (s *QuotaService) InvalidateAccount(billingAccountID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.cache, billingAccountID)
+	s.mu.Unlock()
+}
+
+// CurrentUsage consumo da métrica em at: o medidor para métricas acumuladas (zero quando ainda
+// não existe ou a janela dele já terminou) e a contagem dos recursos para as demais
+func (s *QuotaService) CurrentUsage(ctx context.Context, ap *AccountPlan, metric billing.UsageMetric, at time.Time) (int64, error) {
+	if !metric.IsMetered() {
+		return s.counter.CountResources(ctx, ap.BillingAccountID, metric)
+	}
+
+	meter, err := s.meters.FindByMetricName(ctx, ap.BillingAccountID, string(metric))
+	if errors.Is(err, billing.ErrNotFound) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to load usage meter: %w", err)
+	}
+	if !at.Before(meter.PeriodEnd()) {
+		return 0, nil
+	}
+	return meter.Quantity(), nil
+}
+
+// Check verifica se o projeto ainda pode consumir quantity unidades da métrica. Limite rígido
+// atingido é um erro PAYMENT_REQUIRED (402) com métrica, limite, uso e plano nos detalhes.
+func (s *QuotaService) Check(ctx context.Context, projectID uuid.UUID, metric billing.UsageMetric, quantity int64) error {
+	accountID, err := s.AccountFor(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	ap, err := s.AccountPlan(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if ap.Plan.HardLimit(metric) == billing.Unlimited {
+		return nil
+	}
+
+	usage, err := s.CurrentUsage(ctx, ap, metric, s.now())
+	if err != nil {
+		return err
+	}
+
+	var exceeded *billing.QuotaExceededError
+	if err := ap.Plan.CheckQuota(metric, usage, quantity); errors.As(err, &exceeded) {
+		return shared.NewQuotaExceededError(
+			fmt.Sprintf("Plan limit reached: your %s plan allows %d %s. Upgrade your plan to continue.",
+				ap.Plan.Name(), exceeded.Limit, metric)).
+			WithDetail("metric", string(metric)).
+			WithDetail("limit", exceeded.Limit).
+			WithDetail("usage", exceeded.Current).
+			WithDetail("plan", ap.Plan.Key())
+	}
+	return nil
+}
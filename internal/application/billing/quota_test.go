@@ -0,0 +1,175 @@
+package billing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+type quotaFixture struct {
+	quota         *QuotaService
+	project       *project.Project
+	account       *billing.BillingAccount
+	projects      *fakeProjectRepository
+	subscriptions *fakeSubscriptionRepository
+	plans         *fakePlanRepository
+	meters        *fakeMeterRepository
+	counter       *fakeResourceCounter
+	free          *billing.Plan
+	pro           *billing.Plan
+	now           time.Time
+}
+
+func newQuotaFixture(t *testing.T) *quotaFixture {
+	t.Helper()
+	account, err := billing.NewBillingAccount(uuid.New(), "Acme", "billing@acme.com")
+	require.NoError(t, err)
+	p, err := project.NewProject(uuid.New(), account.ID(), "tenant-acme", "Acme")
+	require.NoError(t, err)
+
+	free, err := billing.NewPlan("free", "Free", "", billing.Entitlements{
+		MaxChannels: 1, MaxContacts: 10, MaxAgents: 2, MonthlyMessages: 10, AIMinutes: 5, BroadcastsPerDay: 1,
+	}, 80)
+	require.NoError(t, err)
+	free.MarkAsDefault()
+	pro, err := billing.NewPlan("pro", "Pro", "price_pro", billing.Entitlements{
+		MaxChannels: 5, MaxContacts: billing.Unlimited, MaxAgents: 10, MonthlyMessages: 1000, AIMinutes: 60, BroadcastsPerDay: 10,
+	}, 80)
+	require.NoError(t, err)
+
+	f := &quotaFixture{
+		project:       p,
+		account:       account,
+		projects:      newFakeProjectRepository(p),
+		subscriptions: &fakeSubscriptionRepository{active: map[uuid.UUID]*billing.Subscription{}},
+		plans:         &fakePlanRepository{plans: []*billing.Plan{free, pro}},
+		meters:        newFakeMeterRepository(),
+		counter:       &fakeResourceCounter{counts: map[billing.UsageMetric]int64{}},
+		free:          free,
+		pro:           pro,
+		now:           time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+	}
+	f.quota = NewQuotaService(f.projects, f.subscriptions, f.plans, f.meters, f.counter, time.Minute)
+	f.quota.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *quotaFixture) subscribe(t *testing.T, priceID string, start, end time.Time) {
+	t.Helper()
+	sub, err := billing.NewSubscription(f.account.ID(), "sub_123", priceID, billing.SubscriptionStatusActive, start, end)
+	require.NoError(t, err)
+	f.subscriptions.active[f.account.ID()] = sub
+	f.quota.InvalidateAccount(f.account.ID())
+}
+
+func TestQuotaService_AccountPlan(t *testing.T) {
+	ctx := context.Background()
+	f := newQuotaFixture(t)
+
+	ap, err := f.quota.AccountPlan(ctx, f.account.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "free", ap.Plan.Key(), "sem assinatura vale o plano padrão")
+	assert.True(t, ap.PeriodStart.IsZero())
+
+	start := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	f.subscribe(t, "price_pro", start, start.AddDate(0, 1, 0))
+	ap, err = f.quota.AccountPlan(ctx, f.account.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "pro", ap.Plan.Key())
+	assert.Equal(t, start, ap.PeriodStart)
+
+	// Price sem plano cadastrado cai no padrão
+	f.subscribe(t, "price_unknown", start, start.AddDate(0, 1, 0))
+	ap, err = f.quota.AccountPlan(ctx, f.account.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "free", ap.Plan.Key())
+
+	// Sem catálogo nenhum, nada é bloqueado
+	f.plans.plans = nil
+	f.quota.InvalidateAccount(f.account.ID())
+	ap, err = f.quota.AccountPlan(ctx, f.account.ID())
+	require.NoError(t, err)
+	assert.Equal(t, billing.Unlimited, ap.Plan.HardLimit(billing.MetricMessages))
+}
+
+func TestQuotaService_CachesPlanUntilTTL(t *testing.T) {
+	ctx := context.Background()
+	f := newQuotaFixture(t)
+
+	_, err := f.quota.AccountFor(ctx, f.project.ID())
+	require.NoError(t, err)
+	_, err = f.quota.AccountFor(ctx, f.project.ID())
+	require.NoError(t, err)
+	assert.Equal(t, 1, f.projects.lookups)
+
+	ap, err := f.quota.AccountPlan(ctx, f.account.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "free", ap.Plan.Key())
+
+	sub, err := billing.NewSubscription(f.account.ID(), "sub_123", "price_pro", billing.SubscriptionStatusActive, f.now, f.now.AddDate(0, 1, 0))
+	require.NoError(t, err)
+	f.subscriptions.active[f.account.ID()] = sub
+
+	ap, _ = f.quota.AccountPlan(ctx, f.account.ID())
+	assert.Equal(t, "free", ap.Plan.Key(), "em cache")
+
+	f.now = f.now.Add(2 * time.Minute)
+	ap, _ = f.quota.AccountPlan(ctx, f.account.ID())
+	assert.Equal(t, "pro", ap.Plan.Key())
+}
+
+func TestQuotaService_Check(t *testing.T) {
+	ctx := context.Background()
+	f := newQuotaFixture(t)
+
+	f.counter.counts[billing.MetricContacts] = 9
+	assert.NoError(t, f.quota.Check(ctx, f.project.ID(), billing.MetricContacts, 1))
+
+	f.counter.counts[billing.MetricContacts] = 10
+	err := f.quota.Check(ctx, f.project.ID(), billing.MetricContacts, 1)
+	require.Error(t, err)
+	assert.True(t, shared.IsPaymentRequiredError(err))
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, "QUOTA_EXCEEDED", domainErr.Code)
+	assert.Equal(t, "contacts", domainErr.Details["metric"])
+	assert.Equal(t, int64(10), domainErr.Details["limit"])
+	assert.Equal(t, "free", domainErr.Details["plan"])
+	assert.Contains(t, domainErr.Message, "Free plan allows 10 contacts")
+
+	// Métricas acumuladas leem o medidor da janela atual
+	meter, err := billing.NewUsageMeter(f.account.ID(), "", "", string(billing.MetricMessages), "ventros_messages_sent",
+		time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	require.NoError(t, meter.IncrementUsage(10))
+	require.NoError(t, f.meters.Update(ctx, meter))
+	assert.True(t, shared.IsPaymentRequiredError(f.quota.Check(ctx, f.project.ID(), billing.MetricMessages, 1)))
+
+	// Janela encerrada: o medidor antigo não conta
+	f.now = time.Date(2026, 4, 2, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, f.quota.Check(ctx, f.project.ID(), billing.MetricMessages, 1))
+}
+
+func TestAccountPlan_Window(t *testing.T) {
+	start := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	ap := &AccountPlan{PeriodStart: start, PeriodEnd: start.AddDate(0, 1, 0)}
+
+	s, e := ap.Window(billing.MetricMessages, start.Add(48*time.Hour))
+	assert.Equal(t, start, s)
+	assert.Equal(t, start.AddDate(0, 1, 0), e)
+
+	// Fora do período da assinatura (renovação ainda não sincronizada) vale o mês civil
+	s, _ = ap.Window(billing.MetricMessages, start.AddDate(0, 2, 0))
+	assert.Equal(t, time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC), s)
+
+	s, e = ap.Window(billing.MetricBroadcasts, start.Add(30*time.Hour))
+	assert.Equal(t, time.Date(2026, 3, 11, 0, 0, 0, 0, time.UTC), s)
+	assert.Equal(t, 24*time.Hour, e.Sub(s))
+}
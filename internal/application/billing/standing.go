@@ -0,0 +1,102 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// DefaultStandingCacheTTL prazo do cache da situação por conta; a régua descarta a entrada a cada mudança
+const DefaultStandingCacheTTL = 30 * time.Second
+
+type projectAccountResolver interface {
+	AccountFor(ctx context.Context, projectID uuid.UUID) (uuid.UUID, error)
+}
+
+type cachedStanding struct {
+	standing billing.AccountStanding
+	loadedAt time.Time
+}
+
+// StandingService informa se a conta de cobrança do projeto aceita escritas. Conta em modo
+// somente leitura ou suspensa por fatura em atraso responde PAYMENT_REQUIRED (402).
+type StandingService struct {
+	resolver projectAccountResolver
+	accounts accountLookup
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]cachedStanding
+}
+
+func NewStandingService(resolver projectAccountResolver, accounts accountLookup, ttl time.Duration) *StandingService {
+	if ttl <= 0 {
+		ttl = DefaultStandingCacheTTL
+	}
+	return &StandingService{
+		resolver: resolver,
+		accounts: accounts,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    make(map[uuid.UUID]cachedStanding),
+	}
+}
+
+// Standing situação atual da conta de cobrança do projeto
+func (s *StandingService) Standing(ctx context.Context, projectID uuid.UUID) (billing.AccountStanding, error) {
+	accountID, err := s.resolver.AccountFor(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	now := s.now()
+	s.mu.Lock()
+	entry, ok := s.cache[accountID]
+	s.mu.Unlock()
+	if ok && now.Sub(entry.loadedAt) < s.ttl {
+		return entry.standing, nil
+	}
+
+	account, err := s.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return "", fmt.Errorf("failed to load billing account: %w", err)
+	}
+
+	s.mu.Lock()
+	s.cache[accountID] = cachedStanding{standing: account.Standing(), loadedAt: now}
+	s.mu.Unlock()
+	return account.Standing(), nil
+}
+
+// CheckStanding bloqueia escritas de contas restritas pela régua de cobrança
+func (s *StandingService) CheckStanding(ctx context.Context, projectID uuid.UUID) error {
+	standing, err := s.Standing(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	switch standing {
+	case billing.StandingReadOnly:
+		return shared.NewAccountRestrictedError(
+			"Your account is read-only because an invoice is overdue. Pay the invoice to restore full access.").
+			WithDetail("standing", string(standing))
+	case billing.StandingSuspended:
+		return shared.NewAccountRestrictedError(
+			"Your account is suspended because an invoice is overdue. Pay the invoice to reactivate it.").
+			WithDetail("standing", string(standing))
+	}
+	return nil
+}
+
+// Invalidate descarta a situação em cache da conta nesta réplica
+func (s *StandingService) Invalidate(ctx context.Context, billingAccountID uuid.UUID) {
+	s.mu.Lock()
+	delete(s.cache, billingAccountID)
+	s.mu.Unlock()
+}
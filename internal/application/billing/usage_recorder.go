@@ -0,0 +1,260 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+)
+
+// maxRecordAttempts tentativas quando outro consumer incrementa o mesmo medidor ao mesmo tempo
+const maxRecordAttempts = 3
+
+type accountLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*billing.BillingAccount, error)
+}
+
+type meterStore interface {
+	meterLookup
+	Update(ctx context.Context, meter *billing.UsageMeter) error
+}
+
+type usageRecorder interface {
+	Record(ctx context.Context, record *billing.UsageRecord) (bool, error)
+}
+
+// RecordUsageCommand consumo de uma ação medida. SourceID identifica a ação (ex: ID da mensagem)
+// e torna o registro idempotente. Eventos que só carregam o tenant informam TenantID no lugar
+// de ProjectID.
+type RecordUsageCommand struct {
+	ProjectID  uuid.UUID
+	TenantID   string
+	Metric     billing.UsageMetric
+	SourceID   string
+	Quantity   int64
+	OccurredAt time.Time
+}
+
+// limitCrossing limite atravessado pelo registro, avisado por email depois do commit
+type limitCrossing struct {
+	metric billing.UsageMetric
+	plan   *billing.Plan
+	usage  int64
+	hard   bool
+}
+
+// RecordUsageUseCase registra o consumo vindo dos eventos do outbox: grava o registro idempotente,
+// incrementa o medidor da janela atual e avisa (evento + email) quando o consumo atravessa o
+// limite de aviso ou o limite rígido do plano
+type RecordUsageUseCase struct {
+	quota     *QuotaService
+	accounts  accountLookup
+	records   usageRecorder
+	meters    meterStore
+	eventBus  EventBus
+	txManager TransactionManager
+	email     EmailSender
+	logger    *zap.Logger
+}
+
+func NewRecordUsageUseCase(
+	quota *QuotaService,
+	accounts accountLookup,
+	records usageRecorder,
+	meters meterStore,
+	eventBus EventBus,
+	txManager TransactionManager,
+	email EmailSender,
+	logger *zap.Logger,
+) *RecordUsageUseCase {
+	return &RecordUsageUseCase{
+		quota:     quota,
+		accounts:  accounts,
+		records:   records,
+		meters:    meters,
+		eventBus:  eventBus,
+		txManager: txManager,
+		email:     email,
+		logger:    logger,
+	}
+}
+
+func (uc *RecordUsageUseCase) Execute(ctx context.Context, cmd RecordUsageCommand) error {
+	if !cmd.Metric.IsValid() {
+		return shared.NewValidationError(billing.ErrInvalidUsageMetric.Error(), "metric")
+	}
+	if cmd.OccurredAt.IsZero() {
+		cmd.OccurredAt = time.Now()
+	}
+
+	projectID := cmd.ProjectID
+	if projectID == uuid.Nil && cmd.TenantID != "" {
+		var err error
+		if projectID, err = uc.quota.ProjectForTenant(ctx, cmd.TenantID); err != nil {
+			return err
+		}
+	}
+	accountID, err := uc.quota.AccountFor(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	account, err := uc.accounts.FindByID(ctx, accountID)
+	if err != nil {
+		return fmt.Errorf("failed to load billing account: %w", err)
+	}
+	ap, err := uc.quota.AccountPlan(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	var crossing *limitCrossing
+	for attempt := 1; ; attempt++ {
+		crossing = nil
+		err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			crossing, err = uc.record(txCtx, cmd, projectID, account, ap)
+			return err
+		})
+		if err == nil || !shared.IsOptimisticLockError(err) || attempt == maxRecordAttempts {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if crossing != nil {
+		uc.notify(ctx, account, crossing)
+	}
+	return nil
+}
+
+func (uc *RecordUsageUseCase) record(ctx context.Context, cmd RecordUsageCommand, projectID uuid.UUID, account *billing.BillingAccount, ap *AccountPlan) (*limitCrossing, error) {
+	record, err := billing.NewUsageRecord(account.ID(), projectID, cmd.Metric, cmd.SourceID, cmd.Quantity, cmd.OccurredAt)
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "usage")
+	}
+	recorded, err := uc.records.Record(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	if !recorded {
+		// Reentrega do mesmo evento: já contado
+		return nil, nil
+	}
+
+	var events []shared.DomainEvent
+	var after int64
+	if cmd.Metric.IsMetered() {
+		meter, err := uc.incrementMeter(ctx, account, ap, record)
+		if err != nil {
+			return nil, err
+		}
+		if meter == nil {
+			// Ação de uma janela já encerrada: vale só para o Stripe
+			return nil, nil
+		}
+		events = append(events, meter.DomainEvents()...)
+		meter.ClearEvents()
+		after = meter.Quantity()
+	} else if after, err = uc.quota.CurrentUsage(ctx, ap, cmd.Metric, cmd.OccurredAt); err != nil {
+		return nil, err
+	}
+
+	before := after - record.Quantity()
+	if before < 0 {
+		before = 0
+	}
+
+	var crossing *limitCrossing
+	plan := ap.Plan
+	soft, hard := plan.CrossedLimits(cmd.Metric, before, after)
+	if soft {
+		events = append(events, billing.NewUsageSoftLimitReachedEvent(account.ID(), projectID, plan.Key(), cmd.Metric,
+			after, plan.SoftLimit(cmd.Metric), plan.HardLimit(cmd.Metric)))
+		crossing = &limitCrossing{metric: cmd.Metric, plan: plan, usage: after}
+	}
+	if hard {
+		events = append(events, billing.NewUsageLimitReachedEvent(account.ID(), projectID, plan.Key(), cmd.Metric,
+			after, plan.HardLimit(cmd.Metric)))
+		crossing = &limitCrossing{metric: cmd.Metric, plan: plan, usage: after, hard: true}
+	}
+
+	if err := publishEvents(ctx, uc.eventBus, events); err != nil {
+		return nil, err
+	}
+	return crossing, nil
+}
+
+// incrementMeter soma o registro ao medidor da métrica, abrindo uma janela nova quando a anterior
+// terminou. Devolve nil quando a ação é anterior à janela atual do medidor.
+func (uc *RecordUsageUseCase) incrementMeter(ctx context.Context, account *billing.BillingAccount, ap *AccountPlan, record *billing.UsageRecord) (*billing.UsageMeter, error) {
+	metric := record.Metric()
+	at := record.OccurredAt()
+	start, end := ap.Window(metric, at)
+
+	meter, err := uc.meters.FindByMetricName(ctx, account.ID(), string(metric))
+	switch {
+	case errors.Is(err, billing.ErrNotFound):
+		meter, err = billing.NewUsageMeter(account.ID(), account.StripeCustomerID(), "", string(metric), metric.StripeEventName(), start, end)
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to load usage meter: %w", err)
+	case at.Before(meter.PeriodStart()):
+		return nil, nil
+	case !at.Before(meter.PeriodEnd()):
+		meter.ResetForNewPeriod(start, end)
+	}
+
+	if err := meter.IncrementUsage(record.Quantity()); err != nil {
+		return nil, err
+	}
+	if err := uc.meters.Update(ctx, meter); err != nil {
+		return nil, err
+	}
+	return meter, nil
+}
+
+// notify avisa o email de cobrança da conta; falhas são só logadas
+func (uc *RecordUsageUseCase) notify(ctx context.Context, account *billing.BillingAccount, crossing *limitCrossing) {
+	if uc.email == nil || account.BillingEmail() == "" {
+		return
+	}
+
+	subject := fmt.Sprintf("Você atingiu %d%% do limite de %s do plano %s", crossing.plan.SoftLimitPercent(), crossing.metric, crossing.plan.Name())
+	if crossing.hard {
+		subject = fmt.Sprintf("Limite de %s do plano %s atingido", crossing.metric, crossing.plan.Name())
+	}
+	if err := uc.email.Send(ctx, []string{account.BillingEmail()}, subject, limitEmailBody(account, crossing)); err != nil {
+		uc.logger.Error("Failed to send usage limit email",
+			zap.Error(err),
+			zap.String("billing_account_id", account.ID().String()),
+			zap.String("metric", string(crossing.metric)))
+	}
+}
+
+func limitEmailBody(account *billing.BillingAccount, crossing *limitCrossing) string {
+	var b strings.Builder
+	if account.Name() != "" {
+		fmt.Fprintf(&b, "Olá %s,\n\n", account.Name())
+	}
+	limit := crossing.plan.HardLimit(crossing.metric)
+	fmt.Fprintf(&b, "O consumo de %s da sua conta chegou a %d de %d permitidos pelo plano %s.\n\n",
+		crossing.metric, crossing.usage, limit, crossing.plan.Name())
+	if crossing.hard {
+		b.WriteString("Novas ações que dependem deste recurso estão bloqueadas até a próxima janela de consumo ")
+		b.WriteString("ou até a troca de plano.\n")
+	} else {
+		b.WriteString("Ao atingir o limite, novas ações que dependem deste recurso serão bloqueadas. ")
+		b.WriteString("Considere trocar de plano para evitar interrupções.\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,188 @@
+package billing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"go.uber.org/zap"
+)
+
+type recorderFixture struct {
+	*quotaFixture
+	uc       *RecordUsageUseCase
+	records  *fakeUsageRecordRepository
+	eventBus *recordingEventBus
+	email    *recordingEmailSender
+}
+
+func newRecorderFixture(t *testing.T) *recorderFixture {
+	t.Helper()
+	f := &recorderFixture{
+		quotaFixture: newQuotaFixture(t),
+		records:      newFakeUsageRecordRepository(),
+		eventBus:     &recordingEventBus{},
+		email:        &recordingEmailSender{},
+	}
+	accounts := &fakeAccountRepository{accounts: map[uuid.UUID]*billing.BillingAccount{f.account.ID(): f.account}}
+	f.uc = NewRecordUsageUseCase(f.quota, accounts, f.records, f.meters, f.eventBus, &SimpleTransactionManager{}, f.email, zap.NewNop())
+	return f
+}
+
+func (f *recorderFixture) send(t *testing.T, metric billing.UsageMetric, sourceID string, quantity int64) {
+	t.Helper()
+	require.NoError(t, f.uc.Execute(context.Background(), RecordUsageCommand{
+		ProjectID:  f.project.ID(),
+		Metric:     metric,
+		SourceID:   sourceID,
+		Quantity:   quantity,
+		OccurredAt: f.now,
+	}))
+}
+
+func TestRecordUsage_IncrementsMeterIdempotently(t *testing.T) {
+	f := newRecorderFixture(t)
+
+	f.send(t, billing.MetricMessages, "msg-1", 1)
+	f.send(t, billing.MetricMessages, "msg-1", 1) // reentrega do outbox
+	f.send(t, billing.MetricMessages, "msg-2", 1)
+
+	assert.Equal(t, int64(2), f.meters.quantity(f.account.ID(), billing.MetricMessages))
+	assert.Len(t, f.records.records, 2)
+
+	meter, err := f.meters.FindByMetricName(context.Background(), f.account.ID(), string(billing.MetricMessages))
+	require.NoError(t, err)
+	assert.Equal(t, "ventros_messages_sent", meter.EventName())
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), meter.PeriodStart(), "sem assinatura: mês civil")
+	assert.Contains(t, f.eventBus.eventTypes(), "billing.usage_meter.created")
+}
+
+func TestRecordUsage_ResetsMeterOnNewWindow(t *testing.T) {
+	f := newRecorderFixture(t)
+
+	f.send(t, billing.MetricBroadcasts, "broadcast-1", 1)
+	f.now = f.now.Add(24 * time.Hour)
+	f.send(t, billing.MetricBroadcasts, "broadcast-2", 1)
+
+	meter, err := f.meters.FindByMetricName(context.Background(), f.account.ID(), string(billing.MetricBroadcasts))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), meter.Quantity())
+	assert.Equal(t, time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), meter.PeriodStart())
+
+	// Ação atrasada de uma janela já encerrada não conta para a atual
+	require.NoError(t, f.uc.Execute(context.Background(), RecordUsageCommand{
+		ProjectID: f.project.ID(), Metric: billing.MetricBroadcasts, SourceID: "broadcast-0", Quantity: 1,
+		OccurredAt: f.now.Add(-48 * time.Hour),
+	}))
+	assert.Equal(t, int64(1), f.meters.quantity(f.account.ID(), billing.MetricBroadcasts))
+	assert.Len(t, f.records.records, 3)
+}
+
+func TestRecordUsage_WarnsOnSoftAndHardLimits(t *testing.T) {
+	f := newRecorderFixture(t)
+
+	// Free: 10 mensagens, aviso em 8
+	for i := 1; i <= 7; i++ {
+		f.send(t, billing.MetricMessages, uuid.NewString(), 1)
+	}
+	assert.Empty(t, f.email.sent)
+
+	f.send(t, billing.MetricMessages, uuid.NewString(), 1)
+	assert.Contains(t, f.eventBus.eventTypes(), "billing.usage.soft_limit_reached")
+	require.Len(t, f.email.sent, 1)
+	assert.Equal(t, []string{"billing@acme.com"}, f.email.sent[0].to)
+	assert.Contains(t, f.email.sent[0].subject, "80%")
+	assert.Contains(t, f.email.sent[0].body, "8 de 10")
+
+	f.send(t, billing.MetricMessages, uuid.NewString(), 1)
+	assert.Len(t, f.email.sent, 1, "só avisa na travessia")
+
+	f.send(t, billing.MetricMessages, uuid.NewString(), 1)
+	assert.Contains(t, f.eventBus.eventTypes(), "billing.usage.limit_reached")
+	require.Len(t, f.email.sent, 2)
+	assert.Contains(t, f.email.sent[1].subject, "atingido")
+
+	var event billing.UsageLimitReachedEvent
+	for _, e := range f.eventBus.events {
+		if limit, ok := e.(billing.UsageLimitReachedEvent); ok {
+			event = limit
+		}
+	}
+	assert.Equal(t, f.project.ID(), event.ProjectID)
+	assert.Equal(t, int64(10), event.Usage)
+	assert.Equal(t, "free", event.PlanKey)
+}
+
+func TestRecordUsage_StockMetricsUseResourceCount(t *testing.T) {
+	f := newRecorderFixture(t)
+
+	// Free: 10 contatos. O contato novo já está na contagem quando o evento chega.
+	f.counter.counts[billing.MetricContacts] = 8
+	f.send(t, billing.MetricContacts, "contact-8", 1)
+	assert.Contains(t, f.eventBus.eventTypes(), "billing.usage.soft_limit_reached")
+	assert.Equal(t, int64(0), f.meters.quantity(f.account.ID(), billing.MetricContacts), "estoque não usa medidor")
+}
+
+func TestRecordUsage_ResolvesProjectByTenant(t *testing.T) {
+	f := newRecorderFixture(t)
+
+	require.NoError(t, f.uc.Execute(context.Background(), RecordUsageCommand{
+		TenantID: f.project.TenantID(), Metric: billing.MetricBroadcasts, SourceID: "broadcast-1", Quantity: 1, OccurredAt: f.now,
+	}))
+	require.Len(t, f.records.records, 1)
+	assert.Equal(t, f.project.ID(), f.records.records[0].ProjectID())
+}
+
+func TestRecordUsage_RetriesConcurrentIncrements(t *testing.T) {
+	f := newRecorderFixture(t)
+	f.records = newFakeUsageRecordRepository()
+	f.uc.records = &forgetfulRecords{f.records}
+
+	f.meters.conflicts = 2
+	f.send(t, billing.MetricMessages, "msg-1", 1)
+	assert.Equal(t, int64(1), f.meters.quantity(f.account.ID(), billing.MetricMessages))
+
+	f.meters.conflicts = maxRecordAttempts
+	err := f.uc.Execute(context.Background(), RecordUsageCommand{
+		ProjectID: f.project.ID(), Metric: billing.MetricMessages, SourceID: "msg-2", Quantity: 1, OccurredAt: f.now,
+	})
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), f.meters.quantity(f.account.ID(), billing.MetricMessages))
+}
+
+// forgetfulRecords simula o rollback da transação: o registro de uma tentativa com conflito não
+// fica gravado para a próxima
+type forgetfulRecords struct {
+	*fakeUsageRecordRepository
+}
+
+func (r *forgetfulRecords) Record(ctx context.Context, record *billing.UsageRecord) (bool, error) {
+	for i, existing := range r.records {
+		if existing.Metric() == record.Metric() && existing.SourceID() == record.SourceID() {
+			r.records = append(r.records[:i], r.records[i+1:]...)
+			break
+		}
+	}
+	return r.fakeUsageRecordRepository.Record(ctx, record)
+}
+
+func TestRecordUsage_RejectsInvalidUsage(t *testing.T) {
+	f := newRecorderFixture(t)
+
+	err := f.uc.Execute(context.Background(), RecordUsageCommand{ProjectID: f.project.ID(), Metric: "unknown", SourceID: "x", Quantity: 1})
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, "metric", domainErr.Field)
+	assert.Equal(t, billing.ErrInvalidUsageMetric.Error(), domainErr.Message)
+
+	err = f.uc.Execute(context.Background(), RecordUsageCommand{ProjectID: f.project.ID(), Metric: billing.MetricMessages, SourceID: "", Quantity: 1})
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, "usage", domainErr.Field)
+	assert.Equal(t, billing.ErrInvalidUsageSource.Error(), domainErr.Message)
+	assert.Empty(t, f.records.records)
+}
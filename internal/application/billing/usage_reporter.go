@@ -0,0 +1,166 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"go.uber.org/zap"
+)
+
+// DefaultUsageReportBatchSize registros lidos por rodada do envio ao Stripe
+const DefaultUsageReportBatchSize = 500
+
+// maxMeterEventAge o Stripe recusa eventos de medidor com mais de 35 dias
+const maxMeterEventAge = 35 * 24 * time.Hour
+
+// MeterEvent consumo agregado enviado a um Billing Meter do Stripe. Identifier é determinístico
+// para o mesmo conjunto de registros: reenvios depois de uma falha não contam duas vezes.
+type MeterEvent struct {
+	EventName        string
+	StripeCustomerID string
+	Value            int64
+	Timestamp        time.Time
+	Identifier       string
+}
+
+// MeterEventReporter envia eventos de medidor ao Stripe
+type MeterEventReporter interface {
+	ReportMeterEvent(ctx context.Context, event MeterEvent) error
+}
+
+type unreportedUsage interface {
+	FindUnreported(ctx context.Context, limit int) ([]*billing.UsageRecord, error)
+	MarkReported(ctx context.Context, ids []uuid.UUID, reportedAt time.Time) error
+}
+
+// usageBatch registros de uma conta, métrica e dia somados em um único evento
+type usageBatch struct {
+	billingAccountID uuid.UUID
+	metric           billing.UsageMetric
+	day              time.Time
+	records          []*billing.UsageRecord
+}
+
+// UsageReporter envia ao Stripe, em lotes, o consumo das métricas medidas ainda não reportado
+type UsageReporter struct {
+	records   unreportedUsage
+	accounts  accountLookup
+	reporter  MeterEventReporter
+	batchSize int
+	now       func() time.Time
+	logger    *zap.Logger
+}
+
+func NewUsageReporter(records unreportedUsage, accounts accountLookup, reporter MeterEventReporter, batchSize int, logger *zap.Logger) *UsageReporter {
+	if batchSize <= 0 {
+		batchSize = DefaultUsageReportBatchSize
+	}
+	return &UsageReporter{
+		records:   records,
+		accounts:  accounts,
+		reporter:  reporter,
+		batchSize: batchSize,
+		now:       time.Now,
+		logger:    logger,
+	}
+}
+
+// ReportPending agrupa os registros pendentes por conta, métrica e dia, envia um evento por grupo
+// e marca os registros como reportados. Retorna quantos registros foram enviados; grupos com
+// falha ficam para a próxima rodada.
+func (r *UsageReporter) ReportPending(ctx context.Context) (int, error) {
+	records, err := r.records.FindUnreported(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+	if len(records) == 0 {
+		return 0, nil
+	}
+
+	now := r.now()
+	var expired []uuid.UUID
+	var batches []*usageBatch
+	index := make(map[string]*usageBatch)
+	for _, record := range records {
+		if now.Sub(record.OccurredAt()) > maxMeterEventAge {
+			expired = append(expired, record.ID())
+			continue
+		}
+		day, _ := billing.DayWindow(record.OccurredAt())
+		key := fmt.Sprintf("%s|%s|%s", record.BillingAccountID(), record.Metric(), day.Format("2006-01-02"))
+		batch, ok := index[key]
+		if !ok {
+			batch = &usageBatch{billingAccountID: record.BillingAccountID(), metric: record.Metric(), day: day}
+			index[key] = batch
+			batches = append(batches, batch)
+		}
+		batch.records = append(batch.records, record)
+	}
+
+	var errs []error
+	if len(expired) > 0 {
+		r.logger.Warn("Skipping usage too old for Stripe meter events", zap.Int("records", len(expired)))
+		if err := r.records.MarkReported(ctx, expired, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	reported := 0
+	customers := make(map[uuid.UUID]string)
+	for _, batch := range batches {
+		customerID, ok := customers[batch.billingAccountID]
+		if !ok {
+			account, err := r.accounts.FindByID(ctx, batch.billingAccountID)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("failed to load billing account %s: %w", batch.billingAccountID, err))
+				continue
+			}
+			customerID = account.StripeCustomerID()
+			customers[batch.billingAccountID] = customerID
+		}
+		if customerID == "" {
+			continue
+		}
+
+		event, ids := batch.meterEvent(customerID)
+		if err := r.reporter.ReportMeterEvent(ctx, event); err != nil {
+			r.logger.Error("Failed to report usage to Stripe",
+				zap.Error(err),
+				zap.String("billing_account_id", batch.billingAccountID.String()),
+				zap.String("metric", string(batch.metric)),
+				zap.Int64("value", event.Value))
+			errs = append(errs, err)
+			continue
+		}
+		if err := r.records.MarkReported(ctx, ids, now); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		reported += len(ids)
+	}
+
+	return reported, errors.Join(errs...)
+}
+
+func (b *usageBatch) meterEvent(stripeCustomerID string) (MeterEvent, []uuid.UUID) {
+	ids := make([]uuid.UUID, len(b.records))
+	keys := make([]string, len(b.records))
+	event := MeterEvent{EventName: b.metric.StripeEventName(), StripeCustomerID: stripeCustomerID}
+	for i, record := range b.records {
+		ids[i] = record.ID()
+		keys[i] = record.ID().String()
+		event.Value += record.Quantity()
+		if record.OccurredAt().After(event.Timestamp) {
+			event.Timestamp = record.OccurredAt()
+		}
+	}
+	sort.Strings(keys)
+	event.Identifier = uuid.NewSHA1(uuid.NameSpaceOID, []byte(strings.Join(keys, ","))).String()
+	return event, ids
+}
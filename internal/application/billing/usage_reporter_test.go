@@ -0,0 +1,128 @@
+package billing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"go.uber.org/zap"
+)
+
+type reporterFixture struct {
+	reporter *UsageReporter
+	records  *fakeUsageRecordRepository
+	stripe   *recordingMeterReporter
+	accounts *fakeAccountRepository
+	now      time.Time
+}
+
+func newReporterFixture(t *testing.T, batchSize int) *reporterFixture {
+	t.Helper()
+	f := &reporterFixture{
+		records:  newFakeUsageRecordRepository(),
+		stripe:   &recordingMeterReporter{fail: map[string]bool{}},
+		accounts: &fakeAccountRepository{accounts: map[uuid.UUID]*billing.BillingAccount{}},
+		now:      time.Date(2026, 3, 15, 12, 0, 0, 0, time.UTC),
+	}
+	f.reporter = NewUsageReporter(f.records, f.accounts, f.stripe, batchSize, zap.NewNop())
+	f.reporter.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *reporterFixture) addAccount(t *testing.T, customerID string) uuid.UUID {
+	t.Helper()
+	account, err := billing.NewBillingAccount(uuid.New(), "Acme", "billing@acme.com")
+	require.NoError(t, err)
+	require.NoError(t, account.SetStripeCustomerID(customerID))
+	f.accounts.accounts[account.ID()] = account
+	return account.ID()
+}
+
+func (f *reporterFixture) add(t *testing.T, accountID uuid.UUID, metric billing.UsageMetric, quantity int64, at time.Time) {
+	t.Helper()
+	record, err := billing.NewUsageRecord(accountID, uuid.New(), metric, uuid.NewString(), quantity, at)
+	require.NoError(t, err)
+	_, err = f.records.Record(context.Background(), record)
+	require.NoError(t, err)
+}
+
+func TestUsageReporter_ReportsOneEventPerAccountMetricAndDay(t *testing.T) {
+	ctx := context.Background()
+	f := newReporterFixture(t, 100)
+	acme := f.addAccount(t, "cus_acme")
+	globex := f.addAccount(t, "cus_globex")
+
+	f.add(t, acme, billing.MetricMessages, 1, f.now.Add(-2*time.Hour))
+	f.add(t, acme, billing.MetricMessages, 1, f.now.Add(-1*time.Hour))
+	f.add(t, acme, billing.MetricAIMinutes, 3, f.now.Add(-1*time.Hour))
+	f.add(t, acme, billing.MetricMessages, 1, f.now.Add(-24*time.Hour))
+	f.add(t, globex, billing.MetricMessages, 5, f.now.Add(-1*time.Hour))
+	f.add(t, acme, billing.MetricContacts, 1, f.now) // estoque: não vai ao Stripe
+
+	reported, err := f.reporter.ReportPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 5, reported)
+	require.Len(t, f.stripe.events, 4)
+
+	var acmeToday *MeterEvent
+	for i, e := range f.stripe.events {
+		if e.StripeCustomerID == "cus_acme" && e.EventName == "ventros_messages_sent" && e.Timestamp.Day() == 15 {
+			acmeToday = &f.stripe.events[i]
+		}
+	}
+	require.NotNil(t, acmeToday)
+	assert.Equal(t, int64(2), acmeToday.Value)
+	assert.Equal(t, f.now.Add(-1*time.Hour), acmeToday.Timestamp)
+	assert.NotEmpty(t, acmeToday.Identifier)
+
+	// Nada pendente na próxima rodada
+	reported, err = f.reporter.ReportPending(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, reported)
+	assert.Len(t, f.stripe.events, 4)
+}
+
+func TestUsageReporter_RetriesFailedGroupsWithSameIdentifier(t *testing.T) {
+	ctx := context.Background()
+	f := newReporterFixture(t, 100)
+	acme := f.addAccount(t, "cus_acme")
+	globex := f.addAccount(t, "cus_globex")
+	f.add(t, acme, billing.MetricMessages, 1, f.now.Add(-time.Hour))
+	f.add(t, globex, billing.MetricMessages, 1, f.now.Add(-time.Hour))
+
+	f.stripe.fail["cus_acme"] = true
+	reported, err := f.reporter.ReportPending(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, reported)
+
+	// O lote com falha fica pendente e é reenviado com a mesma chave de idempotência
+	records, _ := f.records.FindUnreported(ctx, 0)
+	require.Len(t, records, 1)
+	batch := &usageBatch{records: records, metric: billing.MetricMessages}
+	expected, _ := batch.meterEvent("cus_acme")
+
+	delete(f.stripe.fail, "cus_acme")
+	reported, err = f.reporter.ReportPending(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, reported)
+	assert.Equal(t, expected.Identifier, f.stripe.events[len(f.stripe.events)-1].Identifier)
+}
+
+func TestUsageReporter_SkipsUsageTooOldForStripe(t *testing.T) {
+	ctx := context.Background()
+	f := newReporterFixture(t, 100)
+	acme := f.addAccount(t, "cus_acme")
+	f.add(t, acme, billing.MetricMessages, 1, f.now.AddDate(0, 0, -40))
+
+	reported, err := f.reporter.ReportPending(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, reported)
+	assert.Empty(t, f.stripe.events)
+
+	records, _ := f.records.FindUnreported(ctx, 0)
+	assert.Empty(t, records)
+}
@@ -0,0 +1,45 @@
+package businesshours
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+)
+
+// Checker responde se um instante está dentro do expediente do projeto de um pipeline.
+// Implementa pipeline.BusinessHoursChecker (condição within_business_hours das automações).
+type Checker struct {
+	pipelineRepo PipelineRepository
+	calendarRepo businesshours.CalendarRepository
+}
+
+func NewChecker(pipelineRepo PipelineRepository, calendarRepo businesshours.CalendarRepository) *Checker {
+	return &Checker{
+		pipelineRepo: pipelineRepo,
+		calendarRepo: calendarRepo,
+	}
+}
+
+// WithinBusinessHours retorna true se o projeto não tem expediente configurado
+func (c *Checker) WithinBusinessHours(ctx context.Context, pipelineID uuid.UUID, at time.Time) (bool, error) {
+	p, err := c.pipelineRepo.FindPipelineByID(ctx, pipelineID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load pipeline: %w", err)
+	}
+	if p == nil {
+		return false, fmt.Errorf("pipeline %s not found", pipelineID)
+	}
+
+	calendar, err := c.calendarRepo.FindByProject(ctx, p.ProjectID())
+	if err != nil {
+		if errors.Is(err, businesshours.ErrCalendarNotFound) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to load business hours calendar: %w", err)
+	}
+	return calendar.IsOpenAt(at), nil
+}
@@ -0,0 +1,53 @@
+package businesshours
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/pipeline"
+)
+
+func TestChecker_WithinBusinessHours(t *testing.T) {
+	pipelineRepo := new(MockPipelineRepository)
+	calendarRepo := new(MockCalendarRepository)
+	checker := NewChecker(pipelineRepo, calendarRepo)
+
+	p, err := pipeline.NewPipeline(uuid.New(), "tenant-123", "Vendas")
+	require.NoError(t, err)
+	pipelineRepo.On("FindPipelineByID", mock.Anything, p.ID()).Return(p, nil)
+
+	calendar, err := businesshours.NewCalendar("tenant-123", p.ProjectID(), weekdaySchedule())
+	require.NoError(t, err)
+	calendarRepo.On("FindByProject", mock.Anything, p.ProjectID()).Return(calendar, nil)
+
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	within, err := checker.WithinBusinessHours(context.Background(), p.ID(), time.Date(2025, 1, 6, 10, 0, 0, 0, loc))
+	require.NoError(t, err)
+	assert.True(t, within)
+
+	within, err = checker.WithinBusinessHours(context.Background(), p.ID(), time.Date(2025, 1, 5, 10, 0, 0, 0, loc))
+	require.NoError(t, err)
+	assert.False(t, within)
+}
+
+func TestChecker_NoCalendarIsAlwaysOpen(t *testing.T) {
+	pipelineRepo := new(MockPipelineRepository)
+	calendarRepo := new(MockCalendarRepository)
+	checker := NewChecker(pipelineRepo, calendarRepo)
+
+	p, err := pipeline.NewPipeline(uuid.New(), "tenant-123", "Vendas")
+	require.NoError(t, err)
+	pipelineRepo.On("FindPipelineByID", mock.Anything, p.ID()).Return(p, nil)
+	calendarRepo.On("FindByProject", mock.Anything, p.ProjectID()).Return(nil, businesshours.ErrCalendarNotFound)
+
+	within, err := checker.WithinBusinessHours(context.Background(), p.ID(), time.Now())
+
+	require.NoError(t, err)
+	assert.True(t, within)
+}
@@ -0,0 +1,176 @@
+package businesshours
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+)
+
+// CalendarInput dados do expediente do projeto (cooldown em minutos; 0 = só o limite de uma por sessão)
+type CalendarInput struct {
+	Schedule            businesshours.Schedule
+	OutOfOfficeEnabled  bool
+	OutOfOfficeMessage  string
+	OutOfOfficeCooldown int
+}
+
+// CalendarView expediente exposto pela API
+type CalendarView struct {
+	ID                         uuid.UUID              `json:"id"`
+	ProjectID                  uuid.UUID              `json:"project_id"`
+	Schedule                   businesshours.Schedule `json:"schedule"`
+	OutOfOfficeEnabled         bool                   `json:"out_of_office_enabled"`
+	OutOfOfficeMessage         string                 `json:"out_of_office_message"`
+	OutOfOfficeCooldownMinutes int                    `json:"out_of_office_cooldown_minutes"`
+	OpenNow                    bool                   `json:"open_now"`
+	CreatedAt                  time.Time              `json:"created_at"`
+	UpdatedAt                  time.Time              `json:"updated_at"`
+}
+
+func newCalendarView(c *businesshours.Calendar, now time.Time) CalendarView {
+	outOfOffice := c.OutOfOffice()
+	return CalendarView{
+		ID:                         c.ID(),
+		ProjectID:                  c.ProjectID(),
+		Schedule:                   *c.Schedule(),
+		OutOfOfficeEnabled:         outOfOffice.Enabled,
+		OutOfOfficeMessage:         outOfOffice.Message,
+		OutOfOfficeCooldownMinutes: int(outOfOffice.Cooldown.Minutes()),
+		OpenNow:                    c.IsOpenAt(now),
+		CreatedAt:                  c.CreatedAt(),
+		UpdatedAt:                  c.UpdatedAt(),
+	}
+}
+
+// ImportResult resultado da importação de feriados
+type ImportResult struct {
+	Parsed   int          `json:"parsed"`
+	Added    int          `json:"added"`
+	Calendar CalendarView `json:"calendar"`
+}
+
+// ManageCalendarUseCase leitura e edição do expediente de um projeto
+type ManageCalendarUseCase struct {
+	calendarRepo businesshours.CalendarRepository
+	projectRepo  project.Repository
+}
+
+func NewManageCalendarUseCase(calendarRepo businesshours.CalendarRepository, projectRepo project.Repository) *ManageCalendarUseCase {
+	return &ManageCalendarUseCase{
+		calendarRepo: calendarRepo,
+		projectRepo:  projectRepo,
+	}
+}
+
+func (uc *ManageCalendarUseCase) Get(ctx context.Context, tenantID string, projectID uuid.UUID) (*CalendarView, error) {
+	calendar, err := uc.find(ctx, tenantID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	view := newCalendarView(calendar, time.Now())
+	return &view, nil
+}
+
+// Upsert cria ou substitui o expediente do projeto
+func (uc *ManageCalendarUseCase) Upsert(ctx context.Context, tenantID string, projectID uuid.UUID, input CalendarInput) (*CalendarView, error) {
+	if err := uc.checkProject(ctx, tenantID, projectID); err != nil {
+		return nil, err
+	}
+
+	calendar, err := uc.calendarRepo.FindByProject(ctx, projectID)
+	switch {
+	case errors.Is(err, businesshours.ErrCalendarNotFound):
+		calendar, err = businesshours.NewCalendar(tenantID, projectID, input.Schedule)
+		if err != nil {
+			return nil, shared.NewValidationError(err.Error(), "schedule")
+		}
+	case err != nil:
+		return nil, fmt.Errorf("failed to load business hours calendar: %w", err)
+	default:
+		if err := calendar.SetSchedule(input.Schedule); err != nil {
+			return nil, shared.NewValidationError(err.Error(), "schedule")
+		}
+	}
+
+	if err := calendar.SetOutOfOffice(businesshours.OutOfOffice{
+		Enabled:  input.OutOfOfficeEnabled,
+		Message:  input.OutOfOfficeMessage,
+		Cooldown: time.Duration(input.OutOfOfficeCooldown) * time.Minute,
+	}); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "out_of_office")
+	}
+
+	if err := uc.calendarRepo.Save(ctx, calendar); err != nil {
+		return nil, err
+	}
+	view := newCalendarView(calendar, time.Now())
+	return &view, nil
+}
+
+func (uc *ManageCalendarUseCase) Delete(ctx context.Context, tenantID string, projectID uuid.UUID) error {
+	calendar, err := uc.find(ctx, tenantID, projectID)
+	if err != nil {
+		return err
+	}
+	return uc.calendarRepo.Delete(ctx, calendar.ID())
+}
+
+// ImportICal acrescenta ao expediente os feriados de um arquivo iCalendar
+func (uc *ManageCalendarUseCase) ImportICal(ctx context.Context, tenantID string, projectID uuid.UUID, ics io.Reader) (*ImportResult, error) {
+	calendar, err := uc.find(ctx, tenantID, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	holidays, err := businesshours.ParseICalHolidays(ics)
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "file")
+	}
+	added, err := calendar.ImportHolidays(holidays)
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "file")
+	}
+
+	if added > 0 {
+		if err := uc.calendarRepo.Save(ctx, calendar); err != nil {
+			return nil, err
+		}
+	}
+	return &ImportResult{
+		Parsed:   len(holidays),
+		Added:    added,
+		Calendar: newCalendarView(calendar, time.Now()),
+	}, nil
+}
+
+func (uc *ManageCalendarUseCase) checkProject(ctx context.Context, tenantID string, projectID uuid.UUID) error {
+	if projectID == uuid.Nil {
+		return shared.NewValidationError("project_id is required", "project_id")
+	}
+	proj, err := uc.projectRepo.FindByID(ctx, projectID)
+	if err != nil || proj == nil || proj.TenantID() != tenantID {
+		return shared.NewNotFoundError("project", projectID.String())
+	}
+	return nil
+}
+
+func (uc *ManageCalendarUseCase) find(ctx context.Context, tenantID string, projectID uuid.UUID) (*businesshours.Calendar, error) {
+	if err := uc.checkProject(ctx, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	calendar, err := uc.calendarRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		if errors.Is(err, businesshours.ErrCalendarNotFound) {
+			return nil, shared.NewNotFoundError("business_hours_calendar", projectID.String())
+		}
+		return nil, fmt.Errorf("failed to load business hours calendar: %w", err)
+	}
+	return calendar, nil
+}
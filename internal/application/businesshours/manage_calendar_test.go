@@ -0,0 +1,107 @@
+package businesshours
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+)
+
+func newCalendarFixture(t *testing.T) (*ManageCalendarUseCase, *MockCalendarRepository, *project.Project) {
+	t.Helper()
+	calendarRepo := new(MockCalendarRepository)
+	projectRepo := new(MockProjectRepository)
+
+	proj, err := project.NewProject(uuid.New(), uuid.New(), "tenant-123", "Project")
+	require.NoError(t, err)
+	projectRepo.On("FindByID", mock.Anything, proj.ID()).Return(proj, nil)
+
+	return NewManageCalendarUseCase(calendarRepo, projectRepo), calendarRepo, proj
+}
+
+func weekdaySchedule() businesshours.Schedule {
+	weekday := []businesshours.Interval{{Start: "09:00", End: "18:00"}}
+	return businesshours.Schedule{
+		Timezone: "America/Sao_Paulo",
+		Weekly: map[string][]businesshours.Interval{
+			"monday": weekday, "tuesday": weekday, "wednesday": weekday, "thursday": weekday, "friday": weekday,
+		},
+	}
+}
+
+func TestManageCalendar_UpsertCreatesAndUpdates(t *testing.T) {
+	useCase, calendarRepo, proj := newCalendarFixture(t)
+	calendarRepo.On("FindByProject", mock.Anything, proj.ID()).Return(nil, businesshours.ErrCalendarNotFound).Once()
+	calendarRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	view, err := useCase.Upsert(context.Background(), "tenant-123", proj.ID(), CalendarInput{
+		Schedule:            weekdaySchedule(),
+		OutOfOfficeEnabled:  true,
+		OutOfOfficeMessage:  "Atendemos de segunda a sexta, das 9h às 18h.",
+		OutOfOfficeCooldown: 120,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, proj.ID(), view.ProjectID)
+	assert.Equal(t, 120, view.OutOfOfficeCooldownMinutes)
+
+	existing, err := businesshours.NewCalendar("tenant-123", proj.ID(), weekdaySchedule())
+	require.NoError(t, err)
+	calendarRepo.On("FindByProject", mock.Anything, proj.ID()).Return(existing, nil)
+
+	view, err = useCase.Upsert(context.Background(), "tenant-123", proj.ID(), CalendarInput{Schedule: businesshours.Schedule{Timezone: "UTC"}})
+	require.NoError(t, err)
+	assert.Equal(t, existing.ID(), view.ID)
+	assert.True(t, view.OpenNow)
+	assert.False(t, view.OutOfOfficeEnabled)
+}
+
+func TestManageCalendar_UpsertValidation(t *testing.T) {
+	useCase, calendarRepo, proj := newCalendarFixture(t)
+	calendarRepo.On("FindByProject", mock.Anything, proj.ID()).Return(nil, businesshours.ErrCalendarNotFound)
+
+	_, err := useCase.Upsert(context.Background(), "tenant-123", proj.ID(), CalendarInput{Schedule: businesshours.Schedule{Timezone: "Mars/Olympus"}})
+	assert.True(t, shared.IsValidationError(err))
+
+	_, err = useCase.Upsert(context.Background(), "tenant-123", proj.ID(), CalendarInput{Schedule: weekdaySchedule(), OutOfOfficeEnabled: true})
+	assert.True(t, shared.IsValidationError(err))
+	calendarRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestManageCalendar_TenantIsolation(t *testing.T) {
+	useCase, calendarRepo, proj := newCalendarFixture(t)
+
+	_, err := useCase.Get(context.Background(), "other-tenant", proj.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+
+	err = useCase.Delete(context.Background(), "other-tenant", proj.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+	calendarRepo.AssertNotCalled(t, "FindByProject", mock.Anything, mock.Anything)
+}
+
+func TestManageCalendar_ImportICal(t *testing.T) {
+	useCase, calendarRepo, proj := newCalendarFixture(t)
+	calendar, err := businesshours.NewCalendar("tenant-123", proj.ID(), weekdaySchedule())
+	require.NoError(t, err)
+	calendarRepo.On("FindByProject", mock.Anything, proj.ID()).Return(calendar, nil)
+	calendarRepo.On("Save", mock.Anything, calendar).Return(nil)
+
+	ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20241225\nRRULE:FREQ=YEARLY\nEND:VEVENT\n" +
+		"BEGIN:VEVENT\nDTSTART;VALUE=DATE:20250303\nDTEND;VALUE=DATE:20250305\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	result, err := useCase.ImportICal(context.Background(), "tenant-123", proj.ID(), strings.NewReader(ics))
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, result.Parsed)
+	assert.Equal(t, 3, result.Added)
+	assert.Equal(t, []string{"12-25", "2025-03-03", "2025-03-04"}, result.Calendar.Schedule.Holidays)
+
+	_, err = useCase.ImportICal(context.Background(), "tenant-123", proj.ID(), strings.NewReader("not a calendar"))
+	assert.True(t, shared.IsValidationError(err))
+}
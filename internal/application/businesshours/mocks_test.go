@@ -0,0 +1,171 @@
+package businesshours
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/application/message"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	domainMessage "github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/pipeline"
+)
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) Save(ctx context.Context, p *project.Project) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*project.Project, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FindByTenantWithFilters(ctx context.Context, filters project.ProjectFilters) ([]*project.Project, int64, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*project.Project), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProjectRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*project.Project, int64, error) {
+	args := m.Called(ctx, tenantID, searchText, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*project.Project), args.Get(1).(int64), args.Error(2)
+}
+
+type MockCalendarRepository struct {
+	mock.Mock
+}
+
+func (m *MockCalendarRepository) Save(ctx context.Context, c *businesshours.Calendar) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockCalendarRepository) FindByProject(ctx context.Context, projectID uuid.UUID) (*businesshours.Calendar, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*businesshours.Calendar), args.Error(1)
+}
+
+func (m *MockCalendarRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type MockAutoReplyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAutoReplyRepository) TryRecord(ctx context.Context, reply businesshours.AutoReply) (bool, error) {
+	args := m.Called(ctx, reply)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockAutoReplyRepository) LastSentAt(ctx context.Context, contactID uuid.UUID) (*time.Time, error) {
+	args := m.Called(ctx, contactID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*time.Time), args.Error(1)
+}
+
+type MockMessageRepository struct {
+	mock.Mock
+}
+
+func (m *MockMessageRepository) FindByID(ctx context.Context, id uuid.UUID) (*domainMessage.Message, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domainMessage.Message), args.Error(1)
+}
+
+func (m *MockMessageRepository) Save(ctx context.Context, msg *domainMessage.Message) error {
+	args := m.Called(ctx, msg)
+	return args.Error(0)
+}
+
+type MockMessageSender struct {
+	mock.Mock
+}
+
+func (m *MockMessageSender) SendMessage(ctx context.Context, msg *message.OutboundMessage) (*message.SendResult, error) {
+	args := m.Called(ctx, msg)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*message.SendResult), args.Error(1)
+}
+
+func (m *MockMessageSender) SendBulkMessages(ctx context.Context, msgs []*message.OutboundMessage) ([]*message.SendResult, error) {
+	args := m.Called(ctx, msgs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*message.SendResult), args.Error(1)
+}
+
+func (m *MockMessageSender) GetSupportedTypes() []message.MessageType {
+	return []message.MessageType{message.MessageTypeText}
+}
+
+func (m *MockMessageSender) ValidateMessage(msg *message.OutboundMessage) error {
+	return nil
+}
+
+type MockPipelineRepository struct {
+	mock.Mock
+}
+
+func (m *MockPipelineRepository) FindPipelineByID(ctx context.Context, id uuid.UUID) (*pipeline.Pipeline, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*pipeline.Pipeline), args.Error(1)
+}
+
+// SimpleTransactionManager is a test transaction manager that just executes the function
+type SimpleTransactionManager struct{}
+
+func (m *SimpleTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
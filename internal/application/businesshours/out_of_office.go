@@ -0,0 +1,157 @@
+package businesshours
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/application/message"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	domainMessage "github.com/ventros/crm/internal/domain/crm/message"
+)
+
+// OutOfOfficeUseCase envia a resposta de ausência quando o contato escreve fora do expediente.
+// No máximo uma por sessão (garantido por AutoReplyRepository.TryRecord), respeitando o cooldown do contato.
+type OutOfOfficeUseCase struct {
+	messageRepo   MessageRepository
+	calendarRepo  businesshours.CalendarRepository
+	replyRepo     businesshours.AutoReplyRepository
+	messageSender message.MessageSender
+	txManager     TransactionManager
+}
+
+func NewOutOfOfficeUseCase(
+	messageRepo MessageRepository,
+	calendarRepo businesshours.CalendarRepository,
+	replyRepo businesshours.AutoReplyRepository,
+	messageSender message.MessageSender,
+	txManager TransactionManager,
+) *OutOfOfficeUseCase {
+	return &OutOfOfficeUseCase{
+		messageRepo:   messageRepo,
+		calendarRepo:  calendarRepo,
+		replyRepo:     replyRepo,
+		messageSender: messageSender,
+		txManager:     txManager,
+	}
+}
+
+// HandleInboundMessage avalia a mensagem recebida e responde se o projeto estiver fechado.
+// Retorna true se a resposta foi enviada.
+func (uc *OutOfOfficeUseCase) HandleInboundMessage(ctx context.Context, messageID uuid.UUID) (bool, error) {
+	inbound, err := uc.messageRepo.FindByID(ctx, messageID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load message: %w", err)
+	}
+	if inbound == nil || inbound.FromMe() || inbound.SessionID() == nil || inbound.ChannelID() == uuid.Nil {
+		return false, nil
+	}
+
+	calendar, err := uc.calendarRepo.FindByProject(ctx, inbound.ProjectID())
+	if err != nil {
+		if errors.Is(err, businesshours.ErrCalendarNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to load business hours calendar: %w", err)
+	}
+
+	lastSentAt, err := uc.replyRepo.LastSentAt(ctx, inbound.ContactID())
+	if err != nil {
+		return false, err
+	}
+	if !calendar.ShouldAutoReply(inbound.Timestamp(), lastSentAt) {
+		return false, nil
+	}
+
+	// Reserva a sessão e grava a resposta (pending) atomicamente
+	var reply *domainMessage.Message
+	claimed := false
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		reply, err = newAutoReply(inbound, calendar.OutOfOffice().Message)
+		if err != nil {
+			return err
+		}
+
+		claimed, err = uc.replyRepo.TryRecord(txCtx, businesshours.AutoReply{
+			ID:        uuid.New(),
+			TenantID:  calendar.TenantID(),
+			ProjectID: calendar.ProjectID(),
+			ContactID: inbound.ContactID(),
+			SessionID: *inbound.SessionID(),
+			MessageID: reply.ID(),
+			SentAt:    time.Now(),
+		})
+		if err != nil || !claimed {
+			return err
+		}
+		return uc.messageRepo.Save(txCtx, reply)
+	})
+	if err != nil {
+		return false, err
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	// Envio fora da transação (pode demorar)
+	result, sendErr := uc.messageSender.SendMessage(ctx, &message.OutboundMessage{
+		ID:        reply.ID(),
+		ChannelID: reply.ChannelID(),
+		ContactID: reply.ContactID(),
+		SessionID: reply.SessionID(),
+		AgentID:   agent.SystemAgentDefault,
+		Source:    domainMessage.SourceSystem,
+		Type:      message.MessageTypeText,
+		Content:   calendar.OutOfOffice().Message,
+		Priority:  message.PriorityNormal,
+		CreatedAt: time.Now(),
+	})
+
+	if sendErr != nil {
+		// Não reenvia: a sessão já foi reservada, a falha fica registrada na mensagem
+		reply.MarkAsFailed()
+	} else {
+		if result != nil && result.ExternalID != nil {
+			reply.SetChannelMessageID(*result.ExternalID)
+		}
+		reply.MarkAsDelivered()
+	}
+	if err := uc.messageRepo.Save(ctx, reply); err != nil {
+		return false, fmt.Errorf("failed to update out-of-office reply status: %w", err)
+	}
+
+	if sendErr != nil {
+		return false, fmt.Errorf("failed to send out-of-office reply: %w", sendErr)
+	}
+	return true, nil
+}
+
+// newAutoReply monta a mensagem de saída na mesma sessão e canal da mensagem recebida
+func newAutoReply(inbound *domainMessage.Message, text string) (*domainMessage.Message, error) {
+	reply, err := domainMessage.NewMessage(
+		inbound.ContactID(),
+		inbound.ProjectID(),
+		inbound.CustomerID(),
+		domainMessage.ContentTypeText,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	reply.AssignToChannel(inbound.ChannelID(), inbound.ChannelTypeID())
+	reply.AssignToSession(*inbound.SessionID())
+	if err := reply.AssignAgent(agent.SystemAgentDefault); err != nil {
+		return nil, err
+	}
+	if err := reply.SetSource(domainMessage.SourceSystem); err != nil {
+		return nil, err
+	}
+	if err := reply.SetText(text); err != nil {
+		return nil, err
+	}
+	return reply, nil
+}
@@ -0,0 +1,152 @@
+package businesshours
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/application/message"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	domainMessage "github.com/ventros/crm/internal/domain/crm/message"
+)
+
+type outOfOfficeFixture struct {
+	messageRepo  *MockMessageRepository
+	calendarRepo *MockCalendarRepository
+	replyRepo    *MockAutoReplyRepository
+	sender       *MockMessageSender
+	useCase      *OutOfOfficeUseCase
+
+	inbound  *domainMessage.Message
+	calendar *businesshours.Calendar
+}
+
+// newOutOfOfficeFixture monta uma mensagem recebida agora; closed=true fecha o dia (feriado)
+func newOutOfOfficeFixture(t *testing.T, closed bool) *outOfOfficeFixture {
+	t.Helper()
+	f := &outOfOfficeFixture{
+		messageRepo:  new(MockMessageRepository),
+		calendarRepo: new(MockCalendarRepository),
+		replyRepo:    new(MockAutoReplyRepository),
+		sender:       new(MockMessageSender),
+	}
+	f.useCase = NewOutOfOfficeUseCase(f.messageRepo, f.calendarRepo, f.replyRepo, f.sender, &SimpleTransactionManager{})
+
+	var err error
+	f.inbound, err = domainMessage.NewMessage(uuid.New(), uuid.New(), uuid.New(), domainMessage.ContentTypeText, false)
+	require.NoError(t, err)
+	f.inbound.AssignToChannel(uuid.New(), nil)
+	f.inbound.AssignToSession(uuid.New())
+	require.NoError(t, f.inbound.SetText("Oi, vocês estão abertos?"))
+
+	allDay := []businesshours.Interval{{Start: "00:00", End: "24:00"}}
+	schedule := businesshours.Schedule{Timezone: "UTC", Weekly: map[string][]businesshours.Interval{}}
+	for _, day := range []string{"sunday", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday"} {
+		schedule.Weekly[day] = allDay
+	}
+	if closed {
+		day := f.inbound.Timestamp().UTC()
+		schedule.Holidays = []string{day.Format("2006-01-02"), day.AddDate(0, 0, 1).Format("2006-01-02")}
+	}
+	f.calendar, err = businesshours.NewCalendar("tenant-123", f.inbound.ProjectID(), schedule)
+	require.NoError(t, err)
+	require.NoError(t, f.calendar.SetOutOfOffice(businesshours.OutOfOffice{Enabled: true, Message: "Voltamos amanhã às 9h", Cooldown: time.Hour}))
+
+	f.messageRepo.On("FindByID", mock.Anything, f.inbound.ID()).Return(f.inbound, nil)
+	f.calendarRepo.On("FindByProject", mock.Anything, f.inbound.ProjectID()).Return(f.calendar, nil)
+	return f
+}
+
+func TestOutOfOffice_RepliesWhenClosed(t *testing.T) {
+	f := newOutOfOfficeFixture(t, true)
+	f.replyRepo.On("LastSentAt", mock.Anything, f.inbound.ContactID()).Return(nil, nil)
+	f.replyRepo.On("TryRecord", mock.Anything, mock.MatchedBy(func(r businesshours.AutoReply) bool {
+		return r.SessionID == *f.inbound.SessionID() && r.ContactID == f.inbound.ContactID() && r.TenantID == "tenant-123"
+	})).Return(true, nil)
+	f.messageRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	externalID := "wamid.123"
+	f.sender.On("SendMessage", mock.Anything, mock.MatchedBy(func(out *message.OutboundMessage) bool {
+		return out.Content == "Voltamos amanhã às 9h" &&
+			out.ChannelID == f.inbound.ChannelID() &&
+			out.AgentID == agent.SystemAgentDefault &&
+			out.Source == domainMessage.SourceSystem
+	})).Return(&message.SendResult{ExternalID: &externalID, Status: "sent"}, nil)
+
+	sent, err := f.useCase.HandleInboundMessage(context.Background(), f.inbound.ID())
+
+	require.NoError(t, err)
+	assert.True(t, sent)
+	f.messageRepo.AssertNumberOfCalls(t, "Save", 2)
+	reply := f.messageRepo.Calls[len(f.messageRepo.Calls)-1].Arguments.Get(1).(*domainMessage.Message)
+	assert.True(t, reply.FromMe())
+	assert.Equal(t, domainMessage.StatusDelivered, reply.Status())
+	assert.Equal(t, f.inbound.SessionID(), reply.SessionID())
+}
+
+func TestOutOfOffice_OncePerSession(t *testing.T) {
+	f := newOutOfOfficeFixture(t, true)
+	f.replyRepo.On("LastSentAt", mock.Anything, f.inbound.ContactID()).Return(nil, nil)
+	f.replyRepo.On("TryRecord", mock.Anything, mock.Anything).Return(false, nil)
+
+	sent, err := f.useCase.HandleInboundMessage(context.Background(), f.inbound.ID())
+
+	require.NoError(t, err)
+	assert.False(t, sent)
+	f.messageRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	f.sender.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestOutOfOffice_RespectsCooldown(t *testing.T) {
+	f := newOutOfOfficeFixture(t, true)
+	recent := f.inbound.Timestamp().Add(-10 * time.Minute)
+	f.replyRepo.On("LastSentAt", mock.Anything, f.inbound.ContactID()).Return(&recent, nil)
+
+	sent, err := f.useCase.HandleInboundMessage(context.Background(), f.inbound.ID())
+
+	require.NoError(t, err)
+	assert.False(t, sent)
+	f.replyRepo.AssertNotCalled(t, "TryRecord", mock.Anything, mock.Anything)
+}
+
+func TestOutOfOffice_SkipsWhenOpen(t *testing.T) {
+	f := newOutOfOfficeFixture(t, false)
+	f.replyRepo.On("LastSentAt", mock.Anything, f.inbound.ContactID()).Return(nil, nil)
+
+	sent, err := f.useCase.HandleInboundMessage(context.Background(), f.inbound.ID())
+
+	require.NoError(t, err)
+	assert.False(t, sent)
+	f.sender.AssertNotCalled(t, "SendMessage", mock.Anything, mock.Anything)
+}
+
+func TestOutOfOffice_SkipsWithoutCalendar(t *testing.T) {
+	f := newOutOfOfficeFixture(t, true)
+	f.calendarRepo.ExpectedCalls = nil
+	f.calendarRepo.On("FindByProject", mock.Anything, f.inbound.ProjectID()).Return(nil, businesshours.ErrCalendarNotFound)
+
+	sent, err := f.useCase.HandleInboundMessage(context.Background(), f.inbound.ID())
+
+	require.NoError(t, err)
+	assert.False(t, sent)
+}
+
+func TestOutOfOffice_SendFailureMarksReplyFailed(t *testing.T) {
+	f := newOutOfOfficeFixture(t, true)
+	f.replyRepo.On("LastSentAt", mock.Anything, f.inbound.ContactID()).Return(nil, nil)
+	f.replyRepo.On("TryRecord", mock.Anything, mock.Anything).Return(true, nil)
+	f.messageRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	f.sender.On("SendMessage", mock.Anything, mock.Anything).Return(nil, errors.New("channel offline"))
+
+	sent, err := f.useCase.HandleInboundMessage(context.Background(), f.inbound.ID())
+
+	assert.Error(t, err)
+	assert.False(t, sent)
+	reply := f.messageRepo.Calls[len(f.messageRepo.Calls)-1].Arguments.Get(1).(*domainMessage.Message)
+	assert.Equal(t, domainMessage.StatusFailed, reply.Status())
+}
@@ -0,0 +1,24 @@
+package businesshours
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	domainMessage "github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/pipeline"
+)
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// MessageRepository subconjunto do repositório de mensagens usado pela resposta de ausência
+type MessageRepository interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*domainMessage.Message, error)
+	Save(ctx context.Context, msg *domainMessage.Message) error
+}
+
+// PipelineRepository resolve o projeto do pipeline (condição within_business_hours)
+type PipelineRepository interface {
+	FindPipelineByID(ctx context.Context, id uuid.UUID) (*pipeline.Pipeline, error)
+}
@@ -0,0 +1,493 @@
+package cannedresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"github.com/ventros/crm/internal/domain/storage"
+	"go.uber.org/zap"
+)
+
+// MaxAttachmentSize tamanho máximo de um anexo de resposta pronta (16MB, limite de mídia do WhatsApp)
+const MaxAttachmentSize int64 = 16 * 1024 * 1024
+
+// CannedResponseView resposta pronta exposta pela API
+type CannedResponseView struct {
+	ID           uuid.UUID                   `json:"id"`
+	ProjectID    uuid.UUID                   `json:"project_id"`
+	Shortcut     string                      `json:"shortcut"`
+	Title        string                      `json:"title"`
+	Content      string                      `json:"content"`
+	Folder       string                      `json:"folder"`
+	Private      bool                        `json:"private"`
+	OwnerAgentID *uuid.UUID                  `json:"owner_agent_id,omitempty"`
+	Variables    []string                    `json:"variables"`
+	Attachments  []cannedresponse.Attachment `json:"attachments"`
+	UsageCount   int64                       `json:"usage_count"`
+	LastUsedAt   *time.Time                  `json:"last_used_at,omitempty"`
+	CreatedBy    uuid.UUID                   `json:"created_by"`
+	CreatedAt    time.Time                   `json:"created_at"`
+	UpdatedAt    time.Time                   `json:"updated_at"`
+}
+
+func newCannedResponseView(r *cannedresponse.CannedResponse) CannedResponseView {
+	variables := cannedresponse.Variables(r.Content())
+	if variables == nil {
+		variables = []string{}
+	}
+	return CannedResponseView{
+		ID:           r.ID(),
+		ProjectID:    r.ProjectID(),
+		Shortcut:     r.Shortcut(),
+		Title:        r.Title(),
+		Content:      r.Content(),
+		Folder:       r.Folder(),
+		Private:      r.IsPrivate(),
+		OwnerAgentID: r.OwnerAgentID(),
+		Variables:    variables,
+		Attachments:  r.Attachments(),
+		UsageCount:   r.UsageCount(),
+		LastUsedAt:   r.LastUsedAt(),
+		CreatedBy:    r.CreatedBy(),
+		CreatedAt:    r.CreatedAt(),
+		UpdatedAt:    r.UpdatedAt(),
+	}
+}
+
+// CannedResponseInput dados de criação/edição. Private só vale na criação (resposta do agente de quem cria)
+type CannedResponseInput struct {
+	Shortcut string
+	Title    string
+	Content  string
+	Folder   string
+	Private  bool
+}
+
+func (i CannedResponseInput) details() cannedresponse.Details {
+	return cannedresponse.Details{Shortcut: i.Shortcut, Title: i.Title, Content: i.Content, Folder: i.Folder}
+}
+
+// UploadAttachmentCommand arquivo a anexar na resposta
+type UploadAttachmentCommand struct {
+	TenantID         string
+	UserID           uuid.UUID
+	CannedResponseID uuid.UUID
+	Filename         string
+	ContentType      string
+	Size             int64
+	File             io.Reader
+}
+
+// ManageCannedResponsesUseCase biblioteca de respostas prontas do projeto (compartilhadas)
+// e dos agentes (privadas), com pastas e anexos.
+type ManageCannedResponsesUseCase struct {
+	repo        cannedresponse.Repository
+	agentRepo   agent.Repository
+	projectRepo project.Repository
+	eventBus    EventBus
+	txManager   TransactionManager
+	storage     storage.Storage
+	logger      *zap.Logger
+}
+
+// NewManageCannedResponsesUseCase creates a new instance. storage nil desabilita anexos.
+func NewManageCannedResponsesUseCase(
+	repo cannedresponse.Repository,
+	agentRepo agent.Repository,
+	projectRepo project.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	storage storage.Storage,
+	logger *zap.Logger,
+) *ManageCannedResponsesUseCase {
+	return &ManageCannedResponsesUseCase{
+		repo:        repo,
+		agentRepo:   agentRepo,
+		projectRepo: projectRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		storage:     storage,
+		logger:      logger,
+	}
+}
+
+// AttachmentsEnabled indica se há storage configurado
+func (uc *ManageCannedResponsesUseCase) AttachmentsEnabled() bool {
+	return uc.storage != nil
+}
+
+func (uc *ManageCannedResponsesUseCase) Create(ctx context.Context, tenantID string, userID, projectID uuid.UUID, input CannedResponseInput) (*CannedResponseView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+
+	var owner *uuid.UUID
+	if input.Private {
+		a, err := agentForUser(ctx, uc.agentRepo, tenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if a == nil {
+			return nil, shared.NewValidationError("private canned responses need an agent for the user", "private")
+		}
+		agentID := a.ID()
+		owner = &agentID
+	}
+
+	r, err := cannedresponse.NewCannedResponse(tenantID, projectID, owner, userID, input.details())
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if err := uc.checkShortcut(ctx, r); err != nil {
+		return nil, err
+	}
+	if err := uc.save(ctx, r); err != nil {
+		return nil, err
+	}
+
+	view := newCannedResponseView(r)
+	return &view, nil
+}
+
+func (uc *ManageCannedResponsesUseCase) Get(ctx context.Context, tenantID string, userID, id uuid.UUID) (*CannedResponseView, error) {
+	r, err := uc.find(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	view := newCannedResponseView(r)
+	return &view, nil
+}
+
+// List respostas visíveis ao usuário: as do projeto e as privadas do seu agente
+func (uc *ManageCannedResponsesUseCase) List(ctx context.Context, userID uuid.UUID, filter cannedresponse.Filter) ([]CannedResponseView, int64, error) {
+	if err := uc.viewerFilter(ctx, userID, &filter); err != nil {
+		return nil, 0, err
+	}
+	responses, total, err := uc.repo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	views := make([]CannedResponseView, len(responses))
+	for i, r := range responses {
+		views[i] = newCannedResponseView(r)
+	}
+	return views, total, nil
+}
+
+// Folders pastas com respostas visíveis ao usuário
+func (uc *ManageCannedResponsesUseCase) Folders(ctx context.Context, userID uuid.UUID, filter cannedresponse.Filter) ([]cannedresponse.FolderCount, error) {
+	if err := uc.viewerFilter(ctx, userID, &filter); err != nil {
+		return nil, err
+	}
+	return uc.repo.Folders(ctx, filter)
+}
+
+func (uc *ManageCannedResponsesUseCase) Update(ctx context.Context, tenantID string, userID, id uuid.UUID, input CannedResponseInput) (*CannedResponseView, error) {
+	r, err := uc.find(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := r.Update(userID, input.details())
+	if err != nil {
+		return nil, validationError(err)
+	}
+	if len(changed) > 0 {
+		if contains(changed, "shortcut") {
+			if err := uc.checkShortcut(ctx, r); err != nil {
+				return nil, err
+			}
+		}
+		if err := uc.save(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	view := newCannedResponseView(r)
+	return &view, nil
+}
+
+// Delete remove a resposta; os arquivos anexados ficam no storage (usos passados podem referenciá-los)
+func (uc *ManageCannedResponsesUseCase) Delete(ctx context.Context, tenantID string, userID, id uuid.UUID) error {
+	r, err := uc.find(ctx, tenantID, userID, id)
+	if err != nil {
+		return err
+	}
+	r.Delete(userID)
+	return uc.save(ctx, r)
+}
+
+// AddAttachment envia o arquivo ao storage e o anexa à resposta. Se a resposta não puder ser salva, o arquivo é removido.
+func (uc *ManageCannedResponsesUseCase) AddAttachment(ctx context.Context, cmd UploadAttachmentCommand) (*CannedResponseView, error) {
+	if uc.storage == nil {
+		return nil, shared.NewValidationError("attachments are not available: storage is not configured", "file")
+	}
+	if cmd.Size > MaxAttachmentSize {
+		return nil, shared.NewValidationError(fmt.Sprintf("attachment exceeds %d bytes", MaxAttachmentSize), "file")
+	}
+
+	r, err := uc.find(ctx, cmd.TenantID, cmd.UserID, cmd.CannedResponseID)
+	if err != nil {
+		return nil, err
+	}
+	if len(r.Attachments()) >= cannedresponse.MaxAttachments {
+		return nil, validationError(cannedresponse.ErrTooManyAttachments)
+	}
+
+	path := attachmentPath(r, cmd.Filename)
+	url, err := uc.storage.Upload(ctx, cmd.File, path, storage.UploadOptions{
+		ContentType: cmd.ContentType,
+		Public:      true,
+		MaxSize:     MaxAttachmentSize,
+		Metadata: map[string]string{
+			"original_filename":  cmd.Filename,
+			"canned_response_id": r.ID().String(),
+			"uploaded_by":        cmd.UserID.String(),
+		},
+	})
+	if err != nil {
+		uc.logger.Error("Failed to upload canned response attachment",
+			zap.Error(err),
+			zap.String("canned_response_id", r.ID().String()),
+			zap.String("filename", cmd.Filename))
+		return nil, fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	err = r.AddAttachment(cmd.UserID, cannedresponse.Attachment{
+		URL:         url,
+		Path:        path,
+		Filename:    cmd.Filename,
+		ContentType: cmd.ContentType,
+		Size:        cmd.Size,
+	})
+	if err == nil {
+		err = uc.save(ctx, r)
+	} else {
+		err = validationError(err)
+	}
+	if err != nil {
+		uc.deleteFile(ctx, path)
+		return nil, err
+	}
+
+	view := newCannedResponseView(r)
+	return &view, nil
+}
+
+// RemoveAttachment desanexa o arquivo e o apaga do storage (best effort)
+func (uc *ManageCannedResponsesUseCase) RemoveAttachment(ctx context.Context, tenantID string, userID, id, attachmentID uuid.UUID) (*CannedResponseView, error) {
+	r, err := uc.find(ctx, tenantID, userID, id)
+	if err != nil {
+		return nil, err
+	}
+	removed, err := r.RemoveAttachment(userID, attachmentID)
+	if err != nil {
+		return nil, shared.NewNotFoundError("attachment", attachmentID.String())
+	}
+	if err := uc.save(ctx, r); err != nil {
+		return nil, err
+	}
+	uc.deleteFile(ctx, removed.Path)
+
+	view := newCannedResponseView(r)
+	return &view, nil
+}
+
+// Analytics respostas mais usadas no período (padrão: últimos 30 dias)
+func (uc *ManageCannedResponsesUseCase) Analytics(ctx context.Context, query cannedresponse.UsageQuery) ([]cannedresponse.UsageStat, error) {
+	if err := checkProject(ctx, uc.projectRepo, query.TenantID, query.ProjectID); err != nil {
+		return nil, err
+	}
+	if query.Until.IsZero() {
+		query.Until = time.Now()
+	}
+	if query.Since.IsZero() {
+		query.Since = query.Until.AddDate(0, 0, -30)
+	}
+	if !query.Since.Before(query.Until) {
+		return nil, shared.NewValidationError("since must be before until", "since")
+	}
+
+	stats, err := uc.repo.UsageStats(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		stats = []cannedresponse.UsageStat{}
+	}
+	return stats, nil
+}
+
+// find carrega a resposta do tenant; privadas de outros agentes não existem para o usuário
+func (uc *ManageCannedResponsesUseCase) find(ctx context.Context, tenantID string, userID, id uuid.UUID) (*cannedresponse.CannedResponse, error) {
+	r, err := findCannedResponse(ctx, uc.repo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if r.IsPrivate() {
+		a, err := agentForUser(ctx, uc.agentRepo, tenantID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !r.VisibleTo(agentID(a)) {
+			return nil, shared.NewNotFoundError("canned_response", id.String())
+		}
+	}
+	return r, nil
+}
+
+// viewerFilter completa o filtro com o agente do usuário e valida o projeto e o escopo
+func (uc *ManageCannedResponsesUseCase) viewerFilter(ctx context.Context, userID uuid.UUID, filter *cannedresponse.Filter) error {
+	if err := checkProject(ctx, uc.projectRepo, filter.TenantID, filter.ProjectID); err != nil {
+		return err
+	}
+	if filter.Scope == "" {
+		filter.Scope = cannedresponse.ScopeAll
+	}
+	if !filter.Scope.IsValid() {
+		return shared.NewValidationError("scope must be all, shared or private", "scope")
+	}
+	if filter.Folder != nil {
+		folder, err := cannedresponse.NormalizeFolder(*filter.Folder)
+		if err != nil {
+			return validationError(err)
+		}
+		filter.Folder = &folder
+	}
+	a, err := agentForUser(ctx, uc.agentRepo, filter.TenantID, userID)
+	if err != nil {
+		return err
+	}
+	filter.ViewerAgentID = agentID(a)
+	return nil
+}
+
+// checkShortcut garante o atalho único no escopo da resposta (projeto ou agente dono)
+func (uc *ManageCannedResponsesUseCase) checkShortcut(ctx context.Context, r *cannedresponse.CannedResponse) error {
+	existing, err := uc.repo.FindByShortcut(ctx, r.ProjectID(), r.OwnerAgentID(), r.Shortcut())
+	if err != nil {
+		if errors.Is(err, cannedresponse.ErrCannedResponseNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to check shortcut: %w", err)
+	}
+	if existing.ID() != r.ID() {
+		return shared.NewConflictError(fmt.Sprintf("shortcut %s is already in use", r.Shortcut()))
+	}
+	return nil
+}
+
+func (uc *ManageCannedResponsesUseCase) save(ctx context.Context, r *cannedresponse.CannedResponse) error {
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.repo.Save(txCtx, r); err != nil {
+			return fmt.Errorf("failed to save canned response: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, r)
+	})
+	if err != nil {
+		return err
+	}
+	r.ClearEvents()
+	return nil
+}
+
+func (uc *ManageCannedResponsesUseCase) deleteFile(ctx context.Context, path string) {
+	if path == "" {
+		return
+	}
+	if err := uc.storage.Delete(ctx, path); err != nil {
+		uc.logger.Warn("Failed to remove canned response attachment",
+			zap.Error(err),
+			zap.String("path", path))
+	}
+}
+
+// attachmentPath organiza os anexos por tenant e resposta, preservando a extensão original
+func attachmentPath(r *cannedresponse.CannedResponse, filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return fmt.Sprintf("canned-responses/%s/%s/%s%s", r.TenantID(), r.ID(), uuid.New(), ext)
+}
+
+func checkProject(ctx context.Context, projectRepo project.Repository, tenantID string, projectID uuid.UUID) error {
+	if projectID == uuid.Nil {
+		return shared.NewValidationError("project_id is required", "project_id")
+	}
+	proj, err := projectRepo.FindByID(ctx, projectID)
+	if err != nil || proj == nil || proj.TenantID() != tenantID {
+		return shared.NewNotFoundError("project", projectID.String())
+	}
+	return nil
+}
+
+func findCannedResponse(ctx context.Context, repo cannedresponse.Repository, tenantID string, id uuid.UUID) (*cannedresponse.CannedResponse, error) {
+	r, err := repo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, cannedresponse.ErrCannedResponseNotFound) {
+			return nil, shared.NewNotFoundError("canned_response", id.String())
+		}
+		return nil, fmt.Errorf("failed to load canned response: %w", err)
+	}
+	if r.TenantID() != tenantID || r.IsDeleted() {
+		return nil, shared.NewNotFoundError("canned_response", id.String())
+	}
+	return r, nil
+}
+
+// agentForUser agente ativo do usuário no tenant (nil se o usuário não for agente)
+func agentForUser(ctx context.Context, agentRepo agent.Repository, tenantID string, userID uuid.UUID) (*agent.Agent, error) {
+	agents, err := agentRepo.FindActiveByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agents: %w", err)
+	}
+	for _, a := range agents {
+		if a.UserID() != nil && *a.UserID() == userID {
+			return a, nil
+		}
+	}
+	return nil, nil
+}
+
+func agentID(a *agent.Agent) *uuid.UUID {
+	if a == nil {
+		return nil
+	}
+	id := a.ID()
+	return &id
+}
+
+// validationError traduz os erros do domínio para erros de validação com o campo
+func validationError(err error) error {
+	field := ""
+	var unknown *cannedresponse.UnknownVariableError
+	switch {
+	case errors.Is(err, cannedresponse.ErrInvalidShortcut):
+		field = "shortcut"
+	case errors.Is(err, cannedresponse.ErrEmptyTitle):
+		field = "title"
+	case errors.Is(err, cannedresponse.ErrEmptyContent), errors.Is(err, cannedresponse.ErrContentTooLong), errors.As(err, &unknown):
+		field = "content"
+	case errors.Is(err, cannedresponse.ErrInvalidFolder):
+		field = "folder"
+	case errors.Is(err, cannedresponse.ErrTooManyAttachments):
+		field = "file"
+	}
+	return shared.NewValidationError(err.Error(), field)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
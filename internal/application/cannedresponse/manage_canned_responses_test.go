@@ -0,0 +1,223 @@
+package cannedresponse
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"github.com/ventros/crm/internal/domain/storage"
+	"go.uber.org/zap"
+)
+
+type fixture struct {
+	project     *project.Project
+	agent       *agent.Agent
+	repo        *MockCannedResponseRepository
+	agentRepo   *MockAgentRepository
+	projectRepo *MockProjectRepository
+	eventBus    *MockEventBus
+}
+
+func newFixture(t *testing.T) *fixture {
+	t.Helper()
+	proj, err := project.NewProject(uuid.New(), uuid.New(), "tenant-1", "Loja Centro")
+	require.NoError(t, err)
+	userID := uuid.New()
+	a, err := agent.NewAgent(proj.ID(), "tenant-1", "João Souza", agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+
+	f := &fixture{
+		project:     proj,
+		agent:       a,
+		repo:        new(MockCannedResponseRepository),
+		agentRepo:   new(MockAgentRepository),
+		projectRepo: new(MockProjectRepository),
+		eventBus:    new(MockEventBus),
+	}
+	f.projectRepo.On("FindByID", mock.Anything, proj.ID()).Return(proj, nil)
+	f.agentRepo.On("FindActiveByTenant", mock.Anything, "tenant-1").Return([]*agent.Agent{a}, nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	return f
+}
+
+func (f *fixture) userID() uuid.UUID { return *f.agent.UserID() }
+
+func (f *fixture) manage(store storage.Storage) *ManageCannedResponsesUseCase {
+	return NewManageCannedResponsesUseCase(f.repo, f.agentRepo, f.projectRepo, f.eventBus, &SimpleTransactionManager{}, store, zap.NewNop())
+}
+
+func (f *fixture) newResponse(t *testing.T, owner *uuid.UUID, shortcut, content string) *cannedresponse.CannedResponse {
+	t.Helper()
+	r, err := cannedresponse.NewCannedResponse("tenant-1", f.project.ID(), owner, uuid.New(), cannedresponse.Details{
+		Shortcut: shortcut, Title: "Resposta", Content: content,
+	})
+	require.NoError(t, err)
+	r.ClearEvents()
+	return r
+}
+
+func TestManageCannedResponses_Create(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	agentID := f.agent.ID()
+
+	f.repo.On("FindByShortcut", ctx, f.project.ID(), &agentID, "/preco").Return(nil, cannedresponse.ErrCannedResponseNotFound)
+	f.repo.On("Save", ctx, mock.Anything).Return(nil).Once()
+
+	view, err := f.manage(nil).Create(ctx, "tenant-1", f.userID(), f.project.ID(), CannedResponseInput{
+		Shortcut: "Preco",
+		Title:    "Preços",
+		Content:  "Oi {{contact.first_name}}, segue a tabela",
+		Folder:   "Vendas",
+		Private:  true,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "/preco", view.Shortcut)
+	assert.True(t, view.Private)
+	assert.Equal(t, &agentID, view.OwnerAgentID)
+	assert.Equal(t, []string{"contact.first_name"}, view.Variables)
+	f.eventBus.AssertCalled(t, "Publish", ctx, mock.MatchedBy(func(e shared.DomainEvent) bool {
+		return e.EventName() == "canned_response.created"
+	}))
+}
+
+func TestManageCannedResponses_Create_Errors(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	existing := f.newResponse(t, nil, "/preco", "Tabela")
+	f.repo.On("FindByShortcut", ctx, f.project.ID(), (*uuid.UUID)(nil), "/preco").Return(existing, nil)
+
+	uc := f.manage(nil)
+
+	t.Run("duplicate shortcut", func(t *testing.T) {
+		_, err := uc.Create(ctx, "tenant-1", f.userID(), f.project.ID(), CannedResponseInput{Shortcut: "/preco", Title: "x", Content: "x"})
+		var domainErr *shared.DomainError
+		require.True(t, shared.IsDomainError(err, &domainErr))
+		assert.Equal(t, shared.ErrorTypeConflict, domainErr.Type)
+	})
+
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := uc.Create(ctx, "tenant-1", f.userID(), f.project.ID(), CannedResponseInput{Shortcut: "/oi", Title: "x", Content: "{{contact.cpf}}"})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	t.Run("project of other tenant", func(t *testing.T) {
+		_, err := uc.Create(ctx, "tenant-2", f.userID(), f.project.ID(), CannedResponseInput{Shortcut: "/oi", Title: "x", Content: "x"})
+		assert.True(t, shared.IsNotFoundError(err))
+	})
+
+	f.repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestManageCannedResponses_PrivateIsHiddenFromOthers(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	otherAgent := uuid.New()
+	private := f.newResponse(t, &otherAgent, "/minha", "Resposta de outro agente")
+	f.repo.On("FindByID", ctx, private.ID()).Return(private, nil)
+
+	_, err := f.manage(nil).Get(ctx, "tenant-1", f.userID(), private.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+
+	err = f.manage(nil).Delete(ctx, "tenant-1", f.userID(), private.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+	f.repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestManageCannedResponses_List_UsesViewerAgent(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	agentID := f.agent.ID()
+	folder := " Vendas / "
+
+	f.repo.On("List", ctx, mock.MatchedBy(func(filter cannedresponse.Filter) bool {
+		return filter.ViewerAgentID != nil && *filter.ViewerAgentID == agentID &&
+			filter.Scope == cannedresponse.ScopeAll && *filter.Folder == "Vendas"
+	})).Return([]*cannedresponse.CannedResponse{f.newResponse(t, nil, "/oi", "Oi")}, int64(1), nil)
+
+	views, total, err := f.manage(nil).List(ctx, f.userID(), cannedresponse.Filter{
+		TenantID: "tenant-1", ProjectID: f.project.ID(), Folder: &folder,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, views, 1)
+
+	_, _, err = f.manage(nil).List(ctx, f.userID(), cannedresponse.Filter{TenantID: "tenant-1", ProjectID: f.project.ID(), Scope: "mine"})
+	assert.True(t, shared.IsValidationError(err))
+}
+
+func TestManageCannedResponses_Attachments(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	r := f.newResponse(t, nil, "/catalogo", "Segue o catálogo")
+	f.repo.On("FindByID", ctx, r.ID()).Return(r, nil)
+
+	t.Run("storage not configured", func(t *testing.T) {
+		_, err := f.manage(nil).AddAttachment(ctx, UploadAttachmentCommand{TenantID: "tenant-1", UserID: f.userID(), CannedResponseID: r.ID()})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	t.Run("upload, then removed on save failure", func(t *testing.T) {
+		store := new(MockStorage)
+		store.On("Upload", ctx, mock.Anything, mock.AnythingOfType("string"), mock.Anything).Return("https://cdn/catalogo.pdf", nil)
+		store.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil).Once()
+		f.repo.On("Save", ctx, r).Return(errors.New("db down")).Once()
+
+		_, err := f.manage(store).AddAttachment(ctx, UploadAttachmentCommand{
+			TenantID: "tenant-1", UserID: f.userID(), CannedResponseID: r.ID(), Filename: "Catalogo.PDF", ContentType: "application/pdf", Size: 10,
+		})
+
+		require.Error(t, err)
+		store.AssertExpectations(t)
+	})
+
+	t.Run("upload and remove", func(t *testing.T) {
+		r := f.newResponse(t, nil, "/tabela", "Segue a tabela")
+		f.repo.On("FindByID", ctx, r.ID()).Return(r, nil)
+		store := new(MockStorage)
+		store.On("Upload", ctx, mock.Anything, mock.MatchedBy(func(path string) bool {
+			return len(path) > 0 && path[len(path)-4:] == ".pdf"
+		}), mock.Anything).Return("https://cdn/catalogo.pdf", nil)
+		f.repo.On("Save", ctx, r).Return(nil)
+
+		view, err := f.manage(store).AddAttachment(ctx, UploadAttachmentCommand{
+			TenantID: "tenant-1", UserID: f.userID(), CannedResponseID: r.ID(), Filename: "Catalogo.PDF", ContentType: "application/pdf", Size: 10,
+		})
+		require.NoError(t, err)
+		require.Len(t, view.Attachments, 1)
+		attachment := view.Attachments[0]
+
+		store.On("Delete", ctx, attachment.Path).Return(nil).Once()
+		view, err = f.manage(store).RemoveAttachment(ctx, "tenant-1", f.userID(), r.ID(), attachment.ID)
+		require.NoError(t, err)
+		assert.Empty(t, view.Attachments)
+
+		_, err = f.manage(store).RemoveAttachment(ctx, "tenant-1", f.userID(), r.ID(), attachment.ID)
+		assert.True(t, shared.IsNotFoundError(err))
+		store.AssertExpectations(t)
+	})
+}
+
+func TestManageCannedResponses_Analytics_DefaultWindow(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.repo.On("UsageStats", ctx, mock.MatchedBy(func(q cannedresponse.UsageQuery) bool {
+		return q.Until.Sub(q.Since).Hours() == 24*30
+	})).Return(nil, nil)
+
+	stats, err := f.manage(nil).Analytics(ctx, cannedresponse.UsageQuery{TenantID: "tenant-1", ProjectID: f.project.ID()})
+
+	require.NoError(t, err)
+	assert.NotNil(t, stats)
+	f.repo.AssertExpectations(t)
+}
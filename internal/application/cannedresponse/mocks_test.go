@@ -0,0 +1,432 @@
+package cannedresponse
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/storage"
+)
+
+// ========== Shared Mocks for cannedresponse package tests ==========
+
+type MockCannedResponseRepository struct {
+	mock.Mock
+}
+
+func (m *MockCannedResponseRepository) Save(ctx context.Context, r *cannedresponse.CannedResponse) error {
+	args := m.Called(ctx, r)
+	return args.Error(0)
+}
+
+func (m *MockCannedResponseRepository) FindByID(ctx context.Context, id uuid.UUID) (*cannedresponse.CannedResponse, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cannedresponse.CannedResponse), args.Error(1)
+}
+
+func (m *MockCannedResponseRepository) FindByShortcut(ctx context.Context, projectID uuid.UUID, ownerAgentID *uuid.UUID, shortcut string) (*cannedresponse.CannedResponse, error) {
+	args := m.Called(ctx, projectID, ownerAgentID, shortcut)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*cannedresponse.CannedResponse), args.Error(1)
+}
+
+func (m *MockCannedResponseRepository) List(ctx context.Context, filter cannedresponse.Filter) ([]*cannedresponse.CannedResponse, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*cannedresponse.CannedResponse), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCannedResponseRepository) Folders(ctx context.Context, filter cannedresponse.Filter) ([]cannedresponse.FolderCount, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]cannedresponse.FolderCount), args.Error(1)
+}
+
+func (m *MockCannedResponseRepository) RecordUsage(ctx context.Context, usage cannedresponse.Usage) error {
+	args := m.Called(ctx, usage)
+	return args.Error(0)
+}
+
+func (m *MockCannedResponseRepository) UsageStats(ctx context.Context, query cannedresponse.UsageQuery) ([]cannedresponse.UsageStat, error) {
+	args := m.Called(ctx, query)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]cannedresponse.UsageStat), args.Error(1)
+}
+
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+func (m *MockSessionRepository) Save(ctx context.Context, s *session.Session) error {
+	args := m.Called(ctx, s)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) FindByID(ctx context.Context, id uuid.UUID) (*session.Session, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindActiveByContact(ctx context.Context, contactID uuid.UUID, channelTypeID *int) (*session.Session, error) {
+	args := m.Called(ctx, contactID, channelTypeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindByChannelAndContacts(ctx context.Context, channelID uuid.UUID, contactIDs []uuid.UUID) ([]*session.Session, error) {
+	args := m.Called(ctx, channelID, contactIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindInactiveSessions(ctx context.Context, tenantID string) ([]*session.Session, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindSessionsRequiringSummary(ctx context.Context, tenantID string, limit int) ([]*session.Session, error) {
+	args := m.Called(ctx, tenantID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) CountActiveByTenant(ctx context.Context, tenantID string) (int, error) {
+	args := m.Called(ctx, tenantID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindActiveBeforeTime(ctx context.Context, cutoffTime time.Time) ([]*session.Session, error) {
+	args := m.Called(ctx, cutoffTime)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) FindByTenantWithFilters(ctx context.Context, filters session.SessionFilters) ([]*session.Session, int64, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*session.Session), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSessionRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*session.Session, int64, error) {
+	args := m.Called(ctx, tenantID, searchText, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*session.Session), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSessionRepository) FindByChannelPaginated(ctx context.Context, channelID uuid.UUID, limit int, offset int) ([]*session.Session, error) {
+	args := m.Called(ctx, channelID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), args.Error(1)
+}
+
+func (m *MockSessionRepository) CountByChannel(ctx context.Context, channelID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, channelID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockSessionRepository) DeleteBatch(ctx context.Context, sessionIDs []uuid.UUID) error {
+	args := m.Called(ctx, sessionIDs)
+	return args.Error(0)
+}
+
+func (m *MockSessionRepository) GetContactIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, channelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
+type MockContactRepository struct {
+	mock.Mock
+}
+
+func (m *MockContactRepository) Save(ctx context.Context, c *contact.Contact) error {
+	args := m.Called(ctx, c)
+	return args.Error(0)
+}
+
+func (m *MockContactRepository) FindByID(ctx context.Context, id uuid.UUID) (*contact.Contact, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) FindByPhone(ctx context.Context, projectID uuid.UUID, phone string) (*contact.Contact, error) {
+	args := m.Called(ctx, projectID, phone)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) FindByPhones(ctx context.Context, projectID uuid.UUID, phones []string) (map[string]*contact.Contact, error) {
+	args := m.Called(ctx, projectID, phones)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) FindByEmail(ctx context.Context, projectID uuid.UUID, email string) (*contact.Contact, error) {
+	args := m.Called(ctx, projectID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) FindByExternalID(ctx context.Context, projectID uuid.UUID, externalID string) (*contact.Contact, error) {
+	args := m.Called(ctx, projectID, externalID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) FindByProject(ctx context.Context, projectID uuid.UUID, limit, offset int) ([]*contact.Contact, error) {
+	args := m.Called(ctx, projectID, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) CountByProject(ctx context.Context, projectID uuid.UUID) (int, error) {
+	args := m.Called(ctx, projectID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockContactRepository) FindByTenantWithFilters(ctx context.Context, tenantID string, filters contact.ContactFilters, page, limit int, sortBy, sortDir string) ([]*contact.Contact, int64, error) {
+	args := m.Called(ctx, tenantID, filters, page, limit, sortBy, sortDir)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*contact.Contact), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockContactRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int) ([]*contact.Contact, error) {
+	args := m.Called(ctx, tenantID, searchText, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) SaveCustomFields(ctx context.Context, contactID uuid.UUID, fields map[string]string) error {
+	args := m.Called(ctx, contactID, fields)
+	return args.Error(0)
+}
+
+func (m *MockContactRepository) FindByCustomField(ctx context.Context, tenantID, key, value string) (*contact.Contact, error) {
+	args := m.Called(ctx, tenantID, key, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact.Contact), args.Error(1)
+}
+
+func (m *MockContactRepository) GetCustomFields(ctx context.Context, contactID uuid.UUID) (map[string]string, error) {
+	args := m.Called(ctx, contactID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]string), args.Error(1)
+}
+
+type MockAgentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAgentRepository) Save(ctx context.Context, a *agent.Agent) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAgentRepository) FindByID(ctx context.Context, id uuid.UUID) (*agent.Agent, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) FindByEmail(ctx context.Context, tenantID, email string) (*agent.Agent, error) {
+	args := m.Called(ctx, tenantID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) FindByTenant(ctx context.Context, tenantID string) ([]*agent.Agent, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) FindActiveByTenant(ctx context.Context, tenantID string) ([]*agent.Agent, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAgentRepository) FindByTenantWithFilters(ctx context.Context, filters agent.AgentFilters) ([]*agent.Agent, int64, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*agent.Agent), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAgentRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*agent.Agent, int64, error) {
+	args := m.Called(ctx, tenantID, searchText, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*agent.Agent), args.Get(1).(int64), args.Error(2)
+}
+
+type MockProjectRepository struct {
+	mock.Mock
+}
+
+func (m *MockProjectRepository) Save(ctx context.Context, p *project.Project) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*project.Project, error) {
+	args := m.Called(ctx, customerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*project.Project), args.Error(1)
+}
+
+func (m *MockProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockProjectRepository) FindByTenantWithFilters(ctx context.Context, filters project.ProjectFilters) ([]*project.Project, int64, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*project.Project), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockProjectRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*project.Project, int64, error) {
+	args := m.Called(ctx, tenantID, searchText, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*project.Project), args.Get(1).(int64), args.Error(2)
+}
+
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// SimpleTransactionManager is a test transaction manager that just executes the function
+type SimpleTransactionManager struct{}
+
+func (m *SimpleTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Upload(ctx context.Context, file io.Reader, path string, opts storage.UploadOptions) (string, error) {
+	args := m.Called(ctx, file, path, opts)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorage) GetSignedURL(ctx context.Context, path string, expiry time.Duration) (string, error) {
+	args := m.Called(ctx, path, expiry)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorage) Delete(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Exists(ctx context.Context, path string) (bool, error) {
+	args := m.Called(ctx, path)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,27 @@
+package cannedresponse
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// publishEvents publica os eventos pendentes da resposta (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, r *cannedresponse.CannedResponse) error {
+	for _, event := range r.DomainEvents() {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+		}
+	}
+	return nil
+}
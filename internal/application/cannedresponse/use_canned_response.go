@@ -0,0 +1,258 @@
+package cannedresponse
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+// RenderedResponse resposta com as variáveis aplicadas, pronta para o compositor
+type RenderedResponse struct {
+	ID               uuid.UUID                   `json:"id"`
+	Shortcut         string                      `json:"shortcut"`
+	Title            string                      `json:"title"`
+	Content          string                      `json:"content"`
+	Attachments      []cannedresponse.Attachment `json:"attachments"`
+	MissingVariables []string                    `json:"missing_variables"`
+}
+
+// UseCommand uso de uma resposta pelo id. SessionID (ou ContactID) fornece as variáveis
+type UseCommand struct {
+	TenantID         string
+	UserID           uuid.UUID
+	CannedResponseID uuid.UUID
+	SessionID        *uuid.UUID
+	ContactID        *uuid.UUID
+}
+
+// ExpandCommand expansão de um atalho digitado no compositor. Sem sessão/contato, ProjectID define o projeto
+type ExpandCommand struct {
+	TenantID  string
+	UserID    uuid.UUID
+	ProjectID uuid.UUID
+	Shortcut  string
+	SessionID *uuid.UUID
+	ContactID *uuid.UUID
+}
+
+// UseCannedResponseUseCase renderiza respostas contra o contato/sessão atuais e registra o uso
+type UseCannedResponseUseCase struct {
+	repo        cannedresponse.Repository
+	agentRepo   agent.Repository
+	contactRepo contact.Repository
+	sessionRepo session.Repository
+	projectRepo project.Repository
+	logger      *zap.Logger
+}
+
+func NewUseCannedResponseUseCase(
+	repo cannedresponse.Repository,
+	agentRepo agent.Repository,
+	contactRepo contact.Repository,
+	sessionRepo session.Repository,
+	projectRepo project.Repository,
+	logger *zap.Logger,
+) *UseCannedResponseUseCase {
+	return &UseCannedResponseUseCase{
+		repo:        repo,
+		agentRepo:   agentRepo,
+		contactRepo: contactRepo,
+		sessionRepo: sessionRepo,
+		projectRepo: projectRepo,
+		logger:      logger,
+	}
+}
+
+// renderContext dados disponíveis às variáveis
+type renderContext struct {
+	agent   *agent.Agent
+	contact *contact.Contact
+	session *session.Session
+}
+
+// Use renderiza a resposta escolhida e registra o uso
+func (uc *UseCannedResponseUseCase) Use(ctx context.Context, cmd UseCommand) (*RenderedResponse, error) {
+	rc, err := uc.loadContext(ctx, cmd.TenantID, cmd.UserID, cmd.SessionID, cmd.ContactID)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := findCannedResponse(ctx, uc.repo, cmd.TenantID, cmd.CannedResponseID)
+	if err != nil {
+		return nil, err
+	}
+	if !r.VisibleTo(agentID(rc.agent)) || (rc.contact != nil && rc.contact.ProjectID() != r.ProjectID()) {
+		return nil, shared.NewNotFoundError("canned_response", cmd.CannedResponseID.String())
+	}
+
+	return uc.render(ctx, cmd.TenantID, cmd.UserID, r, rc), nil
+}
+
+// Expand resolve o atalho (a resposta privada do agente tem precedência sobre a do projeto),
+// renderiza e registra o uso
+func (uc *UseCannedResponseUseCase) Expand(ctx context.Context, cmd ExpandCommand) (*RenderedResponse, error) {
+	shortcut, err := cannedresponse.NormalizeShortcut(cmd.Shortcut)
+	if err != nil {
+		return nil, validationError(err)
+	}
+	rc, err := uc.loadContext(ctx, cmd.TenantID, cmd.UserID, cmd.SessionID, cmd.ContactID)
+	if err != nil {
+		return nil, err
+	}
+
+	projectID := cmd.ProjectID
+	if rc.contact != nil {
+		projectID = rc.contact.ProjectID()
+	}
+	if err := checkProject(ctx, uc.projectRepo, cmd.TenantID, projectID); err != nil {
+		return nil, err
+	}
+
+	r, err := uc.findShortcut(ctx, projectID, agentID(rc.agent), shortcut)
+	if err != nil {
+		return nil, err
+	}
+	if r.TenantID() != cmd.TenantID {
+		return nil, shared.NewNotFoundError("canned_response", shortcut)
+	}
+
+	return uc.render(ctx, cmd.TenantID, cmd.UserID, r, rc), nil
+}
+
+func (uc *UseCannedResponseUseCase) findShortcut(ctx context.Context, projectID uuid.UUID, ownerAgentID *uuid.UUID, shortcut string) (*cannedresponse.CannedResponse, error) {
+	owners := []*uuid.UUID{nil}
+	if ownerAgentID != nil {
+		owners = []*uuid.UUID{ownerAgentID, nil}
+	}
+	for _, owner := range owners {
+		r, err := uc.repo.FindByShortcut(ctx, projectID, owner, shortcut)
+		if err == nil {
+			return r, nil
+		}
+		if !errors.Is(err, cannedresponse.ErrCannedResponseNotFound) {
+			return nil, fmt.Errorf("failed to load canned response: %w", err)
+		}
+	}
+	return nil, shared.NewNotFoundError("canned_response", shortcut)
+}
+
+// render aplica as variáveis e registra o uso (best effort: falha não bloqueia o compositor)
+func (uc *UseCannedResponseUseCase) render(ctx context.Context, tenantID string, userID uuid.UUID, r *cannedresponse.CannedResponse, rc renderContext) *RenderedResponse {
+	content, missing := r.Render(uc.variables(ctx, r.ProjectID(), rc))
+	if missing == nil {
+		missing = []string{}
+	}
+
+	usage := cannedresponse.Usage{
+		ID:               uuid.New(),
+		TenantID:         tenantID,
+		ProjectID:        r.ProjectID(),
+		CannedResponseID: r.ID(),
+		AgentID:          agentID(rc.agent),
+		UserID:           userID,
+		UsedAt:           time.Now().UTC(),
+	}
+	if rc.session != nil {
+		sessionID := rc.session.ID()
+		usage.SessionID = &sessionID
+	}
+	if err := uc.repo.RecordUsage(ctx, usage); err != nil {
+		uc.logger.Warn("Failed to record canned response usage",
+			zap.Error(err),
+			zap.String("canned_response_id", r.ID().String()))
+	}
+
+	return &RenderedResponse{
+		ID:               r.ID(),
+		Shortcut:         r.Shortcut(),
+		Title:            r.Title(),
+		Content:          content,
+		Attachments:      r.Attachments(),
+		MissingVariables: missing,
+	}
+}
+
+// variables valores das variáveis suportadas para o contexto
+func (uc *UseCannedResponseUseCase) variables(ctx context.Context, projectID uuid.UUID, rc renderContext) map[string]string {
+	values := make(map[string]string)
+
+	if c := rc.contact; c != nil {
+		values[cannedresponse.VarContactName] = c.Name()
+		values[cannedresponse.VarContactFirstName] = cannedresponse.FirstName(c.Name())
+		if c.Email() != nil {
+			values[cannedresponse.VarContactEmail] = c.Email().String()
+		}
+		if c.Phone() != nil {
+			values[cannedresponse.VarContactPhone] = c.Phone().String()
+		}
+	}
+	if a := rc.agent; a != nil {
+		values[cannedresponse.VarAgentName] = a.Name()
+		values[cannedresponse.VarAgentFirstName] = cannedresponse.FirstName(a.Name())
+	}
+	if s := rc.session; s != nil {
+		loc := time.UTC
+		if rc.contact != nil && rc.contact.Timezone() != nil {
+			if l, err := time.LoadLocation(*rc.contact.Timezone()); err == nil {
+				loc = l
+			}
+		}
+		values[cannedresponse.VarSessionStartedAt] = s.StartedAt().In(loc).Format("02/01/2006 15:04")
+	}
+	if proj, err := uc.projectRepo.FindByID(ctx, projectID); err == nil && proj != nil {
+		values[cannedresponse.VarProjectName] = proj.Name()
+	}
+	return values
+}
+
+// loadContext carrega o agente do usuário e a sessão/contato informados (a sessão define o contato)
+func (uc *UseCannedResponseUseCase) loadContext(ctx context.Context, tenantID string, userID uuid.UUID, sessionID, contactID *uuid.UUID) (renderContext, error) {
+	var rc renderContext
+
+	a, err := agentForUser(ctx, uc.agentRepo, tenantID, userID)
+	if err != nil {
+		return rc, err
+	}
+	rc.agent = a
+
+	if sessionID != nil {
+		s, err := uc.sessionRepo.FindByID(ctx, *sessionID)
+		if err != nil {
+			if errors.Is(err, session.ErrSessionNotFound) {
+				return rc, shared.NewNotFoundError("session", sessionID.String())
+			}
+			return rc, fmt.Errorf("failed to load session: %w", err)
+		}
+		if s.TenantID() != tenantID {
+			return rc, shared.NewNotFoundError("session", sessionID.String())
+		}
+		rc.session = s
+		id := s.ContactID()
+		contactID = &id
+	}
+
+	if contactID != nil {
+		c, err := uc.contactRepo.FindByID(ctx, *contactID)
+		if err != nil {
+			if shared.IsNotFoundError(err) || errors.Is(err, contact.ErrContactNotFound) {
+				return rc, shared.NewNotFoundError("contact", contactID.String())
+			}
+			return rc, fmt.Errorf("failed to load contact: %w", err)
+		}
+		if c.TenantID() != tenantID || c.IsDeleted() {
+			return rc, shared.NewNotFoundError("contact", contactID.String())
+		}
+		rc.contact = c
+	}
+	return rc, nil
+}
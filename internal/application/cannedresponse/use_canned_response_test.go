@@ -0,0 +1,113 @@
+package cannedresponse
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/cannedresponse"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"go.uber.org/zap"
+)
+
+func (f *fixture) use(contactRepo *MockContactRepository, sessionRepo *MockSessionRepository) *UseCannedResponseUseCase {
+	return NewUseCannedResponseUseCase(f.repo, f.agentRepo, contactRepo, sessionRepo, f.projectRepo, zap.NewNop())
+}
+
+func TestUseCannedResponse_Expand_PrivateOverridesShared(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	agentID := f.agent.ID()
+
+	c, err := contact.NewContact(f.project.ID(), "tenant-1", "Maria Silva")
+	require.NoError(t, err)
+	s, err := session.NewSession(c.ID(), "tenant-1", nil, 30*time.Minute)
+	require.NoError(t, err)
+
+	contactRepo := new(MockContactRepository)
+	contactRepo.On("FindByID", ctx, c.ID()).Return(c, nil)
+	sessionRepo := new(MockSessionRepository)
+	sessionRepo.On("FindByID", ctx, s.ID()).Return(s, nil)
+
+	private := f.newResponse(t, &agentID, "/oi", "Oi {{contact.first_name}}! Aqui é {{agent.first_name}}, da {{project.name}}. {{contact.email}}")
+	f.repo.On("FindByShortcut", ctx, f.project.ID(), &agentID, "/oi").Return(private, nil)
+	f.repo.On("RecordUsage", ctx, mock.MatchedBy(func(u cannedresponse.Usage) bool {
+		return u.CannedResponseID == private.ID() && *u.AgentID == agentID && *u.SessionID == s.ID()
+	})).Return(nil).Once()
+
+	sessionID := s.ID()
+	rendered, err := f.use(contactRepo, sessionRepo).Expand(ctx, ExpandCommand{
+		TenantID:  "tenant-1",
+		UserID:    f.userID(),
+		Shortcut:  "OI",
+		SessionID: &sessionID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "Oi Maria! Aqui é João, da Loja Centro. ", rendered.Content)
+	assert.Equal(t, []string{cannedresponse.VarContactEmail}, rendered.MissingVariables)
+	f.repo.AssertExpectations(t)
+	f.repo.AssertNotCalled(t, "FindByShortcut", ctx, f.project.ID(), (*uuid.UUID)(nil), "/oi")
+}
+
+func TestUseCannedResponse_Expand_FallsBackToShared(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	agentID := f.agent.ID()
+
+	shared := f.newResponse(t, nil, "/preco", "Tabela de preços")
+	f.repo.On("FindByShortcut", ctx, f.project.ID(), &agentID, "/preco").Return(nil, cannedresponse.ErrCannedResponseNotFound)
+	f.repo.On("FindByShortcut", ctx, f.project.ID(), (*uuid.UUID)(nil), "/preco").Return(shared, nil)
+	// Falha ao registrar o uso não impede a expansão
+	f.repo.On("RecordUsage", ctx, mock.Anything).Return(errors.New("db down")).Once()
+
+	rendered, err := f.use(nil, nil).Expand(ctx, ExpandCommand{
+		TenantID: "tenant-1", UserID: f.userID(), ProjectID: f.project.ID(), Shortcut: "/preco",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, shared.ID(), rendered.ID)
+	assert.Equal(t, "Tabela de preços", rendered.Content)
+	assert.Empty(t, rendered.MissingVariables)
+}
+
+func TestUseCannedResponse_Expand_NotFound(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+	f.repo.On("FindByShortcut", ctx, f.project.ID(), mock.Anything, "/nada").Return(nil, cannedresponse.ErrCannedResponseNotFound)
+
+	_, err := f.use(nil, nil).Expand(ctx, ExpandCommand{
+		TenantID: "tenant-1", UserID: f.userID(), ProjectID: f.project.ID(), Shortcut: "/nada",
+	})
+
+	assert.True(t, shared.IsNotFoundError(err))
+	f.repo.AssertNotCalled(t, "RecordUsage", mock.Anything, mock.Anything)
+}
+
+func TestUseCannedResponse_Use_RejectsOtherProjectContact(t *testing.T) {
+	ctx := context.Background()
+	f := newFixture(t)
+
+	c, err := contact.NewContact(uuid.New(), "tenant-1", "Maria")
+	require.NoError(t, err)
+	contactRepo := new(MockContactRepository)
+	contactRepo.On("FindByID", ctx, c.ID()).Return(c, nil)
+
+	r := f.newResponse(t, nil, "/oi", "Oi")
+	f.repo.On("FindByID", ctx, r.ID()).Return(r, nil)
+
+	contactID := c.ID()
+	_, err = f.use(contactRepo, nil).Use(ctx, UseCommand{
+		TenantID: "tenant-1", UserID: f.userID(), CannedResponseID: r.ID(), ContactID: &contactID,
+	})
+
+	assert.True(t, shared.IsNotFoundError(err))
+	f.repo.AssertNotCalled(t, "RecordUsage", mock.Anything, mock.Anything)
+}
@@ -36,6 +36,14 @@ func (m *MockContactRepository) FindByPhone(ctx context.Context, projectID uuid.
 	return args.Get(0).(*contact.Contact), args.Error(1)
 }
 
+func (m *MockContactRepository) FindByPhones(ctx context.Context, projectID uuid.UUID, phones []string) (map[string]*contact.Contact, error) {
+	args := m.Called(ctx, projectID, phones)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[string]*contact.Contact), args.Error(1)
+}
+
 func (m *MockContactRepository) FindByEmail(ctx context.Context, projectID uuid.UUID, email string) (*contact.Contact, error) {
 	args := m.Called(ctx, projectID, email)
 	if args.Get(0) == nil {
@@ -16,6 +16,7 @@ type CreateContactListRequest struct {
 	LogicalOperator contact_list.LogicalOperator
 	IsStatic        bool
 	FilterRules     []FilterRuleRequest
+	FilterGroup     *FilterGroupRequest // Filtros aninhados (substitui FilterRules + LogicalOperator)
 }
 
 type FilterRuleRequest struct {
@@ -25,6 +26,14 @@ type FilterRuleRequest struct {
 	FieldType  *string // Apenas para custom fields
 	Value      interface{}
 	PipelineID *uuid.UUID // Apenas para pipeline_status
+	WithinDays *int       // Apenas para event/interaction com operadores de contagem
+}
+
+// FilterGroupRequest representa um grupo booleano (AND/OR/NOT) de regras e subgrupos
+type FilterGroupRequest struct {
+	Logic  contact_list.LogicalOperator
+	Rules  []FilterRuleRequest
+	Groups []FilterGroupRequest
 }
 
 type CreateContactListResponse struct {
@@ -70,43 +79,24 @@ func (uc *CreateContactListUseCase) Execute(ctx context.Context, req CreateConta
 
 	// Adicionar regras de filtro
 	for _, ruleReq := range req.FilterRules {
-		var rule *contact_list.FilterRule
-		var err error
-
-		switch ruleReq.FilterType {
-		case contact_list.FilterTypeCustomField:
-			if ruleReq.FieldType == nil {
-				return nil, errors.New("field_type is required for custom_field filters")
-			}
-			// Converter string para FieldType
-			rule, err = contact_list.NewCustomFieldFilterRule(
-				ruleReq.FieldKey,
-				parseFieldType(*ruleReq.FieldType),
-				ruleReq.Operator,
-				ruleReq.Value,
-			)
-		case contact_list.FilterTypePipelineStatus:
-			if ruleReq.PipelineID == nil {
-				return nil, errors.New("pipeline_id is required for pipeline_status filters")
-			}
-			rule, err = contact_list.NewPipelineStatusFilterRule(
-				*ruleReq.PipelineID,
-				ruleReq.FieldKey,
-				ruleReq.Operator,
-			)
-		case contact_list.FilterTypeTag:
-			rule, err = contact_list.NewTagFilterRule(ruleReq.Operator, ruleReq.Value)
-		case contact_list.FilterTypeAttribute:
-			rule, err = contact_list.NewAttributeFilterRule(ruleReq.FieldKey, ruleReq.Operator, ruleReq.Value)
-		default:
-			rule, err = contact_list.NewFilterRule(ruleReq.FilterType, ruleReq.Operator, ruleReq.FieldKey, ruleReq.Value)
+		rule, err := buildFilterRule(ruleReq)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := list.AddFilterRule(rule); err != nil {
+			return nil, err
 		}
+	}
 
+	// Adicionar filtros aninhados
+	if req.FilterGroup != nil {
+		group, err := buildFilterGroup(req.FilterGroup)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := list.AddFilterRule(rule); err != nil {
+		if err := list.SetFilterGroup(group); err != nil {
 			return nil, err
 		}
 	}
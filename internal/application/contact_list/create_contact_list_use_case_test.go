@@ -490,9 +490,9 @@ func TestCreateContactListUseCase_Execute_WithEventFilter(t *testing.T) {
 		FilterRules: []FilterRuleRequest{
 			{
 				FilterType: contact_list.FilterTypeEvent,
-				Operator:   contact_list.OperatorEquals,
+				Operator:   contact_list.OperatorWithinLastDays,
 				FieldKey:   "page_view",
-				Value:      "homepage",
+				Value:      7,
 			},
 		},
 	}
@@ -527,7 +527,7 @@ func TestCreateContactListUseCase_Execute_WithInteractionFilter(t *testing.T) {
 			{
 				FilterType: contact_list.FilterTypeInteraction,
 				Operator:   contact_list.OperatorGreaterThan,
-				FieldKey:   "message_count",
+				FieldKey:   string(contact_list.InteractionMessages),
 				Value:      10,
 			},
 		},
@@ -544,6 +544,73 @@ func TestCreateContactListUseCase_Execute_WithInteractionFilter(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestCreateContactListUseCase_Execute_InvalidInteractionSource(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockContactListRepository)
+	useCase := NewCreateContactListUseCase(mockRepo)
+
+	req := CreateContactListRequest{
+		ProjectID:       uuid.New(),
+		TenantID:        "tenant-123",
+		Name:            "Interaction List",
+		LogicalOperator: contact_list.LogicalOperatorAND,
+		FilterRules: []FilterRuleRequest{
+			{
+				FilterType: contact_list.FilterTypeInteraction,
+				Operator:   contact_list.OperatorGreaterThan,
+				FieldKey:   "emails",
+				Value:      10,
+			},
+		},
+	}
+
+	// Act
+	resp, err := useCase.Execute(context.Background(), req)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestCreateContactListUseCase_Execute_WithFilterGroup(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockContactListRepository)
+	useCase := NewCreateContactListUseCase(mockRepo)
+	ctx := context.Background()
+
+	req := CreateContactListRequest{
+		ProjectID:       uuid.New(),
+		TenantID:        "tenant-123",
+		Name:            "Engaged VIPs",
+		LogicalOperator: contact_list.LogicalOperatorAND,
+		FilterGroup: &FilterGroupRequest{
+			Logic: contact_list.LogicalOperatorOR,
+			Rules: []FilterRuleRequest{
+				{FilterType: contact_list.FilterTypeTag, Operator: contact_list.OperatorContains, FieldKey: "tag", Value: "vip"},
+				{FilterType: contact_list.FilterTypeAttribute, Operator: contact_list.OperatorWithinLastDays, FieldKey: "last_interaction_at", Value: 7},
+			},
+		},
+	}
+
+	var created *contact_list.ContactList
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*contact_list.ContactList")).
+		Run(func(args mock.Arguments) { created = args.Get(1).(*contact_list.ContactList) }).
+		Return(nil)
+
+	// Act
+	resp, err := useCase.Execute(ctx, req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotNil(t, resp)
+	if assert.NotNil(t, created) && assert.NotNil(t, created.FilterGroup()) {
+		assert.Equal(t, contact_list.LogicalOperatorOR, created.FilterGroup().Logic)
+		assert.Len(t, created.FilterGroup().Rules, 2)
+	}
+	mockRepo.AssertExpectations(t)
+}
+
 func TestCreateContactListUseCase_NewUseCase(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
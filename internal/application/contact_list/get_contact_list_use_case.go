@@ -0,0 +1,30 @@
+package contact_list
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+)
+
+type GetContactListUseCase struct {
+	repo contact_list.Repository
+}
+
+func NewGetContactListUseCase(repo contact_list.Repository) *GetContactListUseCase {
+	return &GetContactListUseCase{repo: repo}
+}
+
+func (uc *GetContactListUseCase) Execute(ctx context.Context, contactListID uuid.UUID) (*ContactListDTO, error) {
+	list, err := uc.repo.FindByID(ctx, contactListID)
+	if err != nil {
+		return nil, err
+	}
+
+	if list.IsDeleted() {
+		return nil, shared.NewNotFoundError("contact_list", contactListID.String())
+	}
+
+	return toContactListDTO(list), nil
+}
@@ -0,0 +1,47 @@
+package contact_list
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+func TestGetContactListUseCase_Execute_Success(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockContactListRepository)
+	useCase := NewGetContactListUseCase(mockRepo)
+	ctx := context.Background()
+	list := newDynamicList(t)
+
+	mockRepo.On("FindByID", ctx, list.ID()).Return(list, nil)
+
+	// Act
+	dto, err := useCase.Execute(ctx, list.ID())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, list.ID(), dto.ID)
+	assert.Equal(t, "Dynamic List", dto.Name)
+	assert.False(t, dto.IsStatic)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetContactListUseCase_Execute_Deleted(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockContactListRepository)
+	useCase := NewGetContactListUseCase(mockRepo)
+	ctx := context.Background()
+	list := newDynamicList(t)
+	list.Delete()
+
+	mockRepo.On("FindByID", ctx, list.ID()).Return(list, nil)
+
+	// Act
+	dto, err := useCase.Execute(ctx, list.ID())
+
+	// Assert
+	assert.Nil(t, dto)
+	assert.True(t, shared.IsNotFoundError(err))
+}
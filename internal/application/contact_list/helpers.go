@@ -1,9 +1,84 @@
 package contact_list
 
 import (
+	"errors"
+
 	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
 )
 
 func parseFieldType(fieldTypeStr string) shared.FieldType {
 	return shared.FieldType(fieldTypeStr)
 }
+
+// buildFilterRule converte a requisição na regra de domínio correspondente ao tipo de filtro
+func buildFilterRule(req FilterRuleRequest) (*contact_list.FilterRule, error) {
+	switch req.FilterType {
+	case contact_list.FilterTypeCustomField:
+		if req.FieldType == nil {
+			return nil, errors.New("field_type is required for custom_field filters")
+		}
+		// Converter string para FieldType
+		return contact_list.NewCustomFieldFilterRule(
+			req.FieldKey,
+			parseFieldType(*req.FieldType),
+			req.Operator,
+			req.Value,
+		)
+	case contact_list.FilterTypePipelineStatus:
+		if req.PipelineID == nil {
+			return nil, errors.New("pipeline_id is required for pipeline_status filters")
+		}
+		return contact_list.NewPipelineStatusFilterRule(
+			*req.PipelineID,
+			req.FieldKey,
+			req.Operator,
+		)
+	case contact_list.FilterTypeTag:
+		return contact_list.NewTagFilterRule(req.Operator, req.Value)
+	case contact_list.FilterTypeAttribute:
+		return contact_list.NewAttributeFilterRule(req.FieldKey, req.Operator, req.Value)
+	case contact_list.FilterTypeEvent:
+		return contact_list.NewEventFilterRule(req.FieldKey, req.Operator, req.Value, req.WithinDays)
+	case contact_list.FilterTypeInteraction:
+		return contact_list.NewInteractionFilterRule(
+			contact_list.InteractionSource(req.FieldKey),
+			req.Operator,
+			req.Value,
+			req.WithinDays,
+		)
+	default:
+		return contact_list.NewFilterRule(req.FilterType, req.Operator, req.FieldKey, req.Value)
+	}
+}
+
+// buildFilterGroup converte recursivamente a árvore de filtros da requisição
+func buildFilterGroup(req *FilterGroupRequest) (*contact_list.FilterGroup, error) {
+	if req == nil {
+		return nil, nil
+	}
+
+	group := &contact_list.FilterGroup{
+		Logic:  req.Logic,
+		Rules:  make([]*contact_list.FilterRule, 0, len(req.Rules)),
+		Groups: make([]contact_list.FilterGroup, 0, len(req.Groups)),
+	}
+
+	for _, ruleReq := range req.Rules {
+		rule, err := buildFilterRule(ruleReq)
+		if err != nil {
+			return nil, err
+		}
+		group.Rules = append(group.Rules, rule)
+	}
+
+	for i := range req.Groups {
+		subgroup, err := buildFilterGroup(&req.Groups[i])
+		if err != nil {
+			return nil, err
+		}
+		group.Groups = append(group.Groups, *subgroup)
+	}
+
+	return group, nil
+}
@@ -24,6 +24,7 @@ type ContactListDTO struct {
 	ContactCount     int             `json:"contact_count"`
 	LastCalculatedAt *string         `json:"last_calculated_at,omitempty"`
 	FilterRules      []FilterRuleDTO `json:"filter_rules"`
+	FilterGroup      *FilterGroupDTO `json:"filter_group,omitempty"`
 	CreatedAt        string          `json:"created_at"`
 	UpdatedAt        string          `json:"updated_at"`
 }
@@ -36,6 +37,13 @@ type FilterRuleDTO struct {
 	FieldType  *string     `json:"field_type,omitempty"`
 	Value      interface{} `json:"value"`
 	PipelineID *uuid.UUID  `json:"pipeline_id,omitempty"`
+	WithinDays *int        `json:"within_days,omitempty"`
+}
+
+type FilterGroupDTO struct {
+	Logic  string           `json:"logic"`
+	Rules  []FilterRuleDTO  `json:"rules"`
+	Groups []FilterGroupDTO `json:"groups"`
 }
 
 type ListContactListsResponse struct {
@@ -69,6 +77,10 @@ func (uc *ListContactListsUseCase) Execute(ctx context.Context, req ListContactL
 }
 
 func (uc *ListContactListsUseCase) toDTO(list *contact_list.ContactList) *ContactListDTO {
+	return toContactListDTO(list)
+}
+
+func toContactListDTO(list *contact_list.ContactList) *ContactListDTO {
 	dto := &ContactListDTO{
 		ID:              list.ID(),
 		ProjectID:       list.ProjectID(),
@@ -89,22 +101,49 @@ func (uc *ListContactListsUseCase) toDTO(list *contact_list.ContactList) *Contac
 	}
 
 	for _, rule := range list.FilterRules() {
-		ruleDTO := FilterRuleDTO{
-			ID:         rule.ID(),
-			FilterType: string(rule.FilterType()),
-			Operator:   string(rule.Operator()),
-			FieldKey:   rule.FieldKey(),
-			Value:      rule.Value(),
-			PipelineID: rule.PipelineID(),
-		}
-
-		if rule.FieldType() != nil {
-			fieldTypeStr := string(*rule.FieldType())
-			ruleDTO.FieldType = &fieldTypeStr
-		}
-
-		dto.FilterRules = append(dto.FilterRules, ruleDTO)
+		dto.FilterRules = append(dto.FilterRules, toFilterRuleDTO(rule))
+	}
+
+	if group := list.FilterGroup(); group != nil {
+		groupDTO := toFilterGroupDTO(group)
+		dto.FilterGroup = &groupDTO
 	}
 
 	return dto
 }
+
+func toFilterRuleDTO(rule *contact_list.FilterRule) FilterRuleDTO {
+	ruleDTO := FilterRuleDTO{
+		ID:         rule.ID(),
+		FilterType: string(rule.FilterType()),
+		Operator:   string(rule.Operator()),
+		FieldKey:   rule.FieldKey(),
+		Value:      rule.Value(),
+		PipelineID: rule.PipelineID(),
+		WithinDays: rule.WithinDays(),
+	}
+
+	if rule.FieldType() != nil {
+		fieldTypeStr := string(*rule.FieldType())
+		ruleDTO.FieldType = &fieldTypeStr
+	}
+
+	return ruleDTO
+}
+
+func toFilterGroupDTO(group *contact_list.FilterGroup) FilterGroupDTO {
+	groupDTO := FilterGroupDTO{
+		Logic:  string(group.Logic),
+		Rules:  make([]FilterRuleDTO, 0, len(group.Rules)),
+		Groups: make([]FilterGroupDTO, 0, len(group.Groups)),
+	}
+
+	for _, rule := range group.Rules {
+		groupDTO.Rules = append(groupDTO.Rules, toFilterRuleDTO(rule))
+	}
+	for i := range group.Groups {
+		groupDTO.Groups = append(groupDTO.Groups, toFilterGroupDTO(&group.Groups[i]))
+	}
+
+	return groupDTO
+}
@@ -19,11 +19,17 @@ type RemoveContactFromListRequest struct {
 }
 
 type ManageStaticListUseCase struct {
-	repo contact_list.Repository
+	repo      contact_list.Repository
+	eventBus  EventBus
+	txManager TransactionManager
 }
 
-func NewManageStaticListUseCase(repo contact_list.Repository) *ManageStaticListUseCase {
-	return &ManageStaticListUseCase{repo: repo}
+func NewManageStaticListUseCase(repo contact_list.Repository, eventBus EventBus, txManager TransactionManager) *ManageStaticListUseCase {
+	return &ManageStaticListUseCase{
+		repo:      repo,
+		eventBus:  eventBus,
+		txManager: txManager,
+	}
 }
 
 func (uc *ManageStaticListUseCase) AddContact(ctx context.Context, req AddContactToListRequest) error {
@@ -47,8 +53,21 @@ func (uc *ManageStaticListUseCase) AddContact(ctx context.Context, req AddContac
 		return errors.New("contact already in list")
 	}
 
-	// Adicionar contato
-	return uc.repo.AddContactToStaticList(ctx, req.ContactListID, req.ContactID)
+	// Adicionar contato + publicar list_joined na mesma transação
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.repo.AddContactToStaticList(txCtx, req.ContactListID, req.ContactID); err != nil {
+			return err
+		}
+
+		list.RecordContactJoined(req.ContactID)
+		return publishEvents(txCtx, uc.eventBus, list)
+	})
+	if err != nil {
+		return err
+	}
+
+	list.ClearEvents()
+	return nil
 }
 
 func (uc *ManageStaticListUseCase) RemoveContact(ctx context.Context, req RemoveContactFromListRequest) error {
@@ -72,6 +91,19 @@ func (uc *ManageStaticListUseCase) RemoveContact(ctx context.Context, req Remove
 		return errors.New("contact not in list")
 	}
 
-	// Remover contato
-	return uc.repo.RemoveContactFromStaticList(ctx, req.ContactListID, req.ContactID)
+	// Remover contato + publicar list_left na mesma transação
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.repo.RemoveContactFromStaticList(txCtx, req.ContactListID, req.ContactID); err != nil {
+			return err
+		}
+
+		list.RecordContactLeft(req.ContactID)
+		return publishEvents(txCtx, uc.eventBus, list)
+	})
+	if err != nil {
+		return err
+	}
+
+	list.ClearEvents()
+	return nil
 }
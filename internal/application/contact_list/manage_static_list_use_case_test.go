@@ -7,13 +7,17 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/ventros/crm/internal/domain/crm/contact_list"
 )
 
 func TestManageStaticListUseCase_AddContact_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -28,6 +32,7 @@ func TestManageStaticListUseCase_AddContact_Success(t *testing.T) {
 		contact_list.LogicalOperatorAND,
 		true, // Static list
 	)
+	staticList.ClearEvents() // lista carregada do repositório não tem eventos pendentes
 
 	req := AddContactToListRequest{
 		ContactListID: contactListID,
@@ -37,6 +42,7 @@ func TestManageStaticListUseCase_AddContact_Success(t *testing.T) {
 	mockRepo.On("FindByID", ctx, contactListID).Return(staticList, nil)
 	mockRepo.On("IsContactInList", ctx, contactListID, contactID).Return(false, nil)
 	mockRepo.On("AddContactToStaticList", ctx, contactListID, contactID).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.AnythingOfType("contact_list.ContactJoinedListEvent")).Return(nil)
 
 	// Act
 	err := useCase.AddContact(ctx, req)
@@ -44,12 +50,16 @@ func TestManageStaticListUseCase_AddContact_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockEventBus.AssertExpectations(t)
 }
 
 func TestManageStaticListUseCase_AddContact_ListNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -75,7 +85,10 @@ func TestManageStaticListUseCase_AddContact_ListNotFound(t *testing.T) {
 func TestManageStaticListUseCase_AddContact_DynamicListError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -110,7 +123,10 @@ func TestManageStaticListUseCase_AddContact_DynamicListError(t *testing.T) {
 func TestManageStaticListUseCase_AddContact_AlreadyExists(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -146,7 +162,10 @@ func TestManageStaticListUseCase_AddContact_AlreadyExists(t *testing.T) {
 func TestManageStaticListUseCase_AddContact_IsContactInListError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -183,7 +202,10 @@ func TestManageStaticListUseCase_AddContact_IsContactInListError(t *testing.T) {
 func TestManageStaticListUseCase_AddContact_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -221,7 +243,10 @@ func TestManageStaticListUseCase_AddContact_RepositoryError(t *testing.T) {
 func TestManageStaticListUseCase_RemoveContact_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -236,6 +261,7 @@ func TestManageStaticListUseCase_RemoveContact_Success(t *testing.T) {
 		contact_list.LogicalOperatorAND,
 		true,
 	)
+	staticList.ClearEvents() // lista carregada do repositório não tem eventos pendentes
 
 	req := RemoveContactFromListRequest{
 		ContactListID: contactListID,
@@ -245,6 +271,7 @@ func TestManageStaticListUseCase_RemoveContact_Success(t *testing.T) {
 	mockRepo.On("FindByID", ctx, contactListID).Return(staticList, nil)
 	mockRepo.On("IsContactInList", ctx, contactListID, contactID).Return(true, nil)
 	mockRepo.On("RemoveContactFromStaticList", ctx, contactListID, contactID).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.AnythingOfType("contact_list.ContactLeftListEvent")).Return(nil)
 
 	// Act
 	err := useCase.RemoveContact(ctx, req)
@@ -252,12 +279,16 @@ func TestManageStaticListUseCase_RemoveContact_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockEventBus.AssertExpectations(t)
 }
 
 func TestManageStaticListUseCase_RemoveContact_ListNotFound(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -283,7 +314,10 @@ func TestManageStaticListUseCase_RemoveContact_ListNotFound(t *testing.T) {
 func TestManageStaticListUseCase_RemoveContact_DynamicListError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -318,7 +352,10 @@ func TestManageStaticListUseCase_RemoveContact_DynamicListError(t *testing.T) {
 func TestManageStaticListUseCase_RemoveContact_NotInList(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -354,7 +391,10 @@ func TestManageStaticListUseCase_RemoveContact_NotInList(t *testing.T) {
 func TestManageStaticListUseCase_RemoveContact_IsContactInListError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -391,7 +431,10 @@ func TestManageStaticListUseCase_RemoveContact_IsContactInListError(t *testing.T
 func TestManageStaticListUseCase_RemoveContact_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -429,7 +472,10 @@ func TestManageStaticListUseCase_RemoveContact_RepositoryError(t *testing.T) {
 func TestManageStaticListUseCase_AddContact_NilContactListID(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactID := uuid.New()
@@ -453,7 +499,10 @@ func TestManageStaticListUseCase_AddContact_NilContactListID(t *testing.T) {
 func TestManageStaticListUseCase_AddContact_NilContactID(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -467,6 +516,7 @@ func TestManageStaticListUseCase_AddContact_NilContactID(t *testing.T) {
 		contact_list.LogicalOperatorAND,
 		true,
 	)
+	staticList.ClearEvents() // lista carregada do repositório não tem eventos pendentes
 
 	req := AddContactToListRequest{
 		ContactListID: contactListID,
@@ -476,6 +526,7 @@ func TestManageStaticListUseCase_AddContact_NilContactID(t *testing.T) {
 	mockRepo.On("FindByID", ctx, contactListID).Return(staticList, nil)
 	mockRepo.On("IsContactInList", ctx, contactListID, uuid.Nil).Return(false, nil)
 	mockRepo.On("AddContactToStaticList", ctx, contactListID, uuid.Nil).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.AnythingOfType("contact_list.ContactJoinedListEvent")).Return(nil)
 
 	// Act
 	err := useCase.AddContact(ctx, req)
@@ -483,12 +534,16 @@ func TestManageStaticListUseCase_AddContact_NilContactID(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockEventBus.AssertExpectations(t)
 }
 
 func TestManageStaticListUseCase_RemoveContact_NilContactListID(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactID := uuid.New()
@@ -512,7 +567,10 @@ func TestManageStaticListUseCase_RemoveContact_NilContactListID(t *testing.T) {
 func TestManageStaticListUseCase_RemoveContact_NilContactID(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
-	useCase := NewManageStaticListUseCase(mockRepo)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 	ctx := context.Background()
 
 	contactListID := uuid.New()
@@ -526,6 +584,7 @@ func TestManageStaticListUseCase_RemoveContact_NilContactID(t *testing.T) {
 		contact_list.LogicalOperatorAND,
 		true,
 	)
+	staticList.ClearEvents() // lista carregada do repositório não tem eventos pendentes
 
 	req := RemoveContactFromListRequest{
 		ContactListID: contactListID,
@@ -535,6 +594,7 @@ func TestManageStaticListUseCase_RemoveContact_NilContactID(t *testing.T) {
 	mockRepo.On("FindByID", ctx, contactListID).Return(staticList, nil)
 	mockRepo.On("IsContactInList", ctx, contactListID, uuid.Nil).Return(true, nil)
 	mockRepo.On("RemoveContactFromStaticList", ctx, contactListID, uuid.Nil).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.AnythingOfType("contact_list.ContactLeftListEvent")).Return(nil)
 
 	// Act
 	err := useCase.RemoveContact(ctx, req)
@@ -542,16 +602,21 @@ func TestManageStaticListUseCase_RemoveContact_NilContactID(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	mockRepo.AssertExpectations(t)
+	mockEventBus.AssertExpectations(t)
 }
 
 func TestManageStaticListUseCase_NewUseCase(t *testing.T) {
 	// Arrange
 	mockRepo := new(MockContactListRepository)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
 
 	// Act
-	useCase := NewManageStaticListUseCase(mockRepo)
+	useCase := NewManageStaticListUseCase(mockRepo, mockEventBus, mockTxManager)
 
 	// Assert
 	assert.NotNil(t, useCase)
 	assert.Equal(t, mockRepo, useCase.repo)
+	assert.Equal(t, mockEventBus, useCase.eventBus)
+	assert.Equal(t, mockTxManager, useCase.txManager)
 }
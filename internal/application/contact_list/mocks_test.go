@@ -2,9 +2,11 @@ package contact_list
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/contact_list"
 )
 
@@ -36,6 +38,14 @@ func (m *MockContactListRepository) FindByID(ctx context.Context, id uuid.UUID)
 	return args.Get(0).(*contact_list.ContactList), args.Error(1)
 }
 
+func (m *MockContactListRepository) FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*contact_list.ContactList, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact_list.ContactList), args.Error(1)
+}
+
 func (m *MockContactListRepository) FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*contact_list.ContactList, error) {
 	args := m.Called(ctx, projectID)
 	if args.Get(0) == nil {
@@ -87,3 +97,58 @@ func (m *MockContactListRepository) IsContactInList(ctx context.Context, listID,
 	args := m.Called(ctx, listID, contactID)
 	return args.Bool(0), args.Error(1)
 }
+
+func (m *MockContactListRepository) FindDynamicListsByContact(ctx context.Context, contactID uuid.UUID) ([]*contact_list.ContactList, error) {
+	args := m.Called(ctx, contactID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contact_list.ContactList), args.Error(1)
+}
+
+func (m *MockContactListRepository) FindStaleDynamicLists(ctx context.Context, calculatedBefore time.Time, limit int) ([]*contact_list.ContactList, error) {
+	args := m.Called(ctx, calculatedBefore, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*contact_list.ContactList), args.Error(1)
+}
+
+func (m *MockContactListRepository) RefreshMembership(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) (*contact_list.MembershipDiff, error) {
+	args := m.Called(ctx, listID, contactIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact_list.MembershipDiff), args.Error(1)
+}
+
+func (m *MockContactListRepository) Preview(ctx context.Context, list *contact_list.ContactList, sampleSize int) (*contact_list.PreviewResult, error) {
+	args := m.Called(ctx, list, sampleSize)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*contact_list.PreviewResult), args.Error(1)
+}
+
+// MockEventBus is a mock implementation of EventBus
+type MockEventBus struct {
+	mock.Mock
+}
+
+func (m *MockEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+// MockTransactionManager executa a função diretamente, a menos que configurado para retornar erro
+type MockTransactionManager struct {
+	mock.Mock
+}
+
+func (m *MockTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	args := m.Called(ctx, fn)
+	if err := args.Error(0); err != nil {
+		return err
+	}
+	return fn(ctx)
+}
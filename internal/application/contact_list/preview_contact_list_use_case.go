@@ -0,0 +1,111 @@
+package contact_list
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+)
+
+const (
+	defaultPreviewSampleSize = 10
+	maxPreviewSampleSize     = 50
+)
+
+// PreviewContactListRequest descreve filtros ainda não salvos
+type PreviewContactListRequest struct {
+	ProjectID       uuid.UUID
+	TenantID        string
+	LogicalOperator contact_list.LogicalOperator
+	FilterRules     []FilterRuleRequest
+	FilterGroup     *FilterGroupRequest
+	SampleSize      int
+}
+
+type ContactSampleDTO struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	Email string    `json:"email,omitempty"`
+	Phone string    `json:"phone,omitempty"`
+}
+
+type PreviewContactListResponse struct {
+	Total  int                `json:"total"`
+	Sample []ContactSampleDTO `json:"sample"`
+}
+
+// PreviewContactListUseCase avalia filtros de lista dinâmica sem persistir a lista
+type PreviewContactListUseCase struct {
+	repo contact_list.Repository
+}
+
+func NewPreviewContactListUseCase(repo contact_list.Repository) *PreviewContactListUseCase {
+	return &PreviewContactListUseCase{repo: repo}
+}
+
+func (uc *PreviewContactListUseCase) Execute(ctx context.Context, req PreviewContactListRequest) (*PreviewContactListResponse, error) {
+	if req.ProjectID == uuid.Nil {
+		return nil, errors.New("project_id is required")
+	}
+	if req.TenantID == "" {
+		return nil, errors.New("tenant_id is required")
+	}
+	if req.LogicalOperator == "" {
+		req.LogicalOperator = contact_list.LogicalOperatorAND
+	}
+
+	sampleSize := req.SampleSize
+	if sampleSize <= 0 {
+		sampleSize = defaultPreviewSampleSize
+	}
+	if sampleSize > maxPreviewSampleSize {
+		sampleSize = maxPreviewSampleSize
+	}
+
+	// Lista transitória: apenas carrega os filtros para avaliação
+	list, err := contact_list.NewContactList(req.ProjectID, req.TenantID, "preview", req.LogicalOperator, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ruleReq := range req.FilterRules {
+		rule, err := buildFilterRule(ruleReq)
+		if err != nil {
+			return nil, err
+		}
+		if err := list.AddFilterRule(rule); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.FilterGroup != nil {
+		group, err := buildFilterGroup(req.FilterGroup)
+		if err != nil {
+			return nil, err
+		}
+		if err := list.SetFilterGroup(group); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := uc.repo.Preview(ctx, list, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PreviewContactListResponse{
+		Total:  result.Total,
+		Sample: make([]ContactSampleDTO, 0, len(result.Sample)),
+	}
+	for _, sample := range result.Sample {
+		response.Sample = append(response.Sample, ContactSampleDTO{
+			ID:    sample.ID,
+			Name:  sample.Name,
+			Email: sample.Email,
+			Phone: sample.Phone,
+		})
+	}
+
+	return response, nil
+}
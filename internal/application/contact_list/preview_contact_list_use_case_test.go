@@ -0,0 +1,116 @@
+package contact_list
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+)
+
+func TestPreviewContactListUseCase_Execute_NestedGroup(t *testing.T) {
+	// Arrange
+	mockRepo := new(MockContactListRepository)
+	useCase := NewPreviewContactListUseCase(mockRepo)
+	ctx := context.Background()
+
+	withinDays := 30
+	req := PreviewContactListRequest{
+		ProjectID: uuid.New(),
+		TenantID:  "tenant-123",
+		FilterGroup: &FilterGroupRequest{
+			Logic: contact_list.LogicalOperatorAND,
+			Rules: []FilterRuleRequest{
+				{FilterType: contact_list.FilterTypeTag, Operator: contact_list.OperatorContains, FieldKey: "tag", Value: "vip"},
+			},
+			Groups: []FilterGroupRequest{
+				{
+					Logic: contact_list.LogicalOperatorNOT,
+					Rules: []FilterRuleRequest{
+						{
+							FilterType: contact_list.FilterTypeInteraction,
+							Operator:   contact_list.OperatorGreaterEqual,
+							FieldKey:   string(contact_list.InteractionMessagesInbound),
+							Value:      float64(1), // JSON numbers chegam como float64
+							WithinDays: &withinDays,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	contactID := uuid.New()
+	mockRepo.On("Preview", ctx, mock.MatchedBy(func(list *contact_list.ContactList) bool {
+		group := list.EffectiveFilterGroup()
+		return group != nil && len(group.AllRules()) == 2 && group.Groups[0].Logic == contact_list.LogicalOperatorNOT
+	}), defaultPreviewSampleSize).Return(&contact_list.PreviewResult{
+		Total:  42,
+		Sample: []contact_list.ContactSample{{ID: contactID, Name: "Maria"}},
+	}, nil)
+
+	// Act
+	resp, err := useCase.Execute(ctx, req)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 42, resp.Total)
+	require.Len(t, resp.Sample, 1)
+	assert.Equal(t, contactID, resp.Sample[0].ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPreviewContactListUseCase_Execute_CapsSampleSize(t *testing.T) {
+	mockRepo := new(MockContactListRepository)
+	useCase := NewPreviewContactListUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("Preview", ctx, mock.Anything, maxPreviewSampleSize).
+		Return(&contact_list.PreviewResult{Total: 0}, nil)
+
+	resp, err := useCase.Execute(ctx, PreviewContactListRequest{
+		ProjectID:  uuid.New(),
+		TenantID:   "tenant-123",
+		SampleSize: 1000,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, resp.Total)
+	assert.Empty(t, resp.Sample)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPreviewContactListUseCase_Execute_InvalidFilter(t *testing.T) {
+	mockRepo := new(MockContactListRepository)
+	useCase := NewPreviewContactListUseCase(mockRepo)
+
+	_, err := useCase.Execute(context.Background(), PreviewContactListRequest{
+		ProjectID: uuid.New(),
+		TenantID:  "tenant-123",
+		FilterGroup: &FilterGroupRequest{
+			Logic: "XOR",
+			Rules: []FilterRuleRequest{
+				{FilterType: contact_list.FilterTypeTag, Operator: contact_list.OperatorContains, FieldKey: "tag", Value: "vip"},
+			},
+		},
+	})
+
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "Preview", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPreviewContactListUseCase_Execute_RepositoryError(t *testing.T) {
+	mockRepo := new(MockContactListRepository)
+	useCase := NewPreviewContactListUseCase(mockRepo)
+	ctx := context.Background()
+
+	mockRepo.On("Preview", ctx, mock.Anything, defaultPreviewSampleSize).Return(nil, errors.New("db error"))
+
+	_, err := useCase.Execute(ctx, PreviewContactListRequest{ProjectID: uuid.New(), TenantID: "tenant-123"})
+
+	assert.EqualError(t, err, "db error")
+}
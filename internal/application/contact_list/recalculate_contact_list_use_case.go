@@ -0,0 +1,130 @@
+package contact_list
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+)
+
+type RecalculateContactListResponse struct {
+	ContactListID uuid.UUID `json:"contact_list_id"`
+	ContactCount  int       `json:"contact_count"`
+	Joined        int       `json:"joined"`
+	Left          int       `json:"left"`
+}
+
+// RecalculateContactListUseCase reavalia a composição de listas e emite list_joined / list_left
+// para cada contato que entrou ou saiu desde o último cálculo.
+type RecalculateContactListUseCase struct {
+	repo      contact_list.Repository
+	eventBus  EventBus
+	txManager TransactionManager
+}
+
+func NewRecalculateContactListUseCase(repo contact_list.Repository, eventBus EventBus, txManager TransactionManager) *RecalculateContactListUseCase {
+	return &RecalculateContactListUseCase{
+		repo:      repo,
+		eventBus:  eventBus,
+		txManager: txManager,
+	}
+}
+
+// Execute recalcula a lista inteira
+func (uc *RecalculateContactListUseCase) Execute(ctx context.Context, contactListID uuid.UUID) (*RecalculateContactListResponse, error) {
+	list, err := uc.repo.FindByID(ctx, contactListID)
+	if err != nil {
+		return nil, err
+	}
+	if list.IsDeleted() {
+		return nil, shared.NewNotFoundError("contact_list", contactListID.String())
+	}
+
+	var diff *contact_list.MembershipDiff
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		diff, err = uc.repo.RefreshMembership(txCtx, list.ID(), nil)
+		if err != nil {
+			return err
+		}
+
+		count, err := uc.repo.RecalculateContactCount(txCtx, list.ID())
+		if err != nil {
+			return fmt.Errorf("failed to count contacts: %w", err)
+		}
+
+		list.RecordMembershipChanges(diff)
+		list.UpdateContactCount(count)
+
+		if err := uc.repo.Update(txCtx, list); err != nil {
+			return fmt.Errorf("failed to update contact list: %w", err)
+		}
+
+		return publishEvents(txCtx, uc.eventBus, list)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	list.ClearEvents()
+
+	return &RecalculateContactListResponse{
+		ContactListID: list.ID(),
+		ContactCount:  list.ContactCount(),
+		Joined:        len(diff.Joined),
+		Left:          len(diff.Left),
+	}, nil
+}
+
+// RecalculateForContact reavalia apenas um contato em todas as listas dinâmicas do seu projeto.
+// Usado pelos consumers de eventos de contato, onde recalcular a lista inteira seria caro.
+func (uc *RecalculateContactListUseCase) RecalculateForContact(ctx context.Context, contactID uuid.UUID) error {
+	lists, err := uc.repo.FindDynamicListsByContact(ctx, contactID)
+	if err != nil {
+		return fmt.Errorf("failed to find dynamic lists: %w", err)
+	}
+
+	for _, candidate := range lists {
+		var list *contact_list.ContactList
+		err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+			// Bloqueia a lista: consumers processando a mesma lista em paralelo ajustam o
+			// contador um depois do outro, sempre a partir do valor gravado
+			locked, err := uc.repo.FindByIDForUpdate(txCtx, candidate.ID())
+			if err != nil {
+				return fmt.Errorf("failed to lock contact list: %w", err)
+			}
+			list = locked
+
+			diff, err := uc.repo.RefreshMembership(txCtx, list.ID(), []uuid.UUID{contactID})
+			if err != nil {
+				return err
+			}
+			if !diff.HasChanges() {
+				return nil
+			}
+
+			// Ajuste incremental: a varredura periódica corrige eventuais desvios
+			count := list.ContactCount() + len(diff.Joined) - len(diff.Left)
+			if count < 0 {
+				count = 0
+			}
+
+			list.RecordMembershipChanges(diff)
+			list.UpdateContactCount(count)
+
+			if err := uc.repo.Update(txCtx, list); err != nil {
+				return fmt.Errorf("failed to update contact list: %w", err)
+			}
+
+			return publishEvents(txCtx, uc.eventBus, list)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to recalculate list %s for contact %s: %w", candidate.ID(), contactID, err)
+		}
+
+		list.ClearEvents()
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package contact_list
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/domain/crm/contact_list"
+)
+
+func newDynamicList(t *testing.T) *contact_list.ContactList {
+	t.Helper()
+	list, err := contact_list.NewContactList(uuid.New(), "tenant-123", "Dynamic List", contact_list.LogicalOperatorAND, false)
+	assert.NoError(t, err)
+	list.ClearEvents()
+	return list
+}
+
+func newRecalculateUseCase() (*RecalculateContactListUseCase, *MockContactListRepository, *MockEventBus) {
+	mockRepo := new(MockContactListRepository)
+	mockEventBus := new(MockEventBus)
+	mockTxManager := new(MockTransactionManager)
+	mockTxManager.On("ExecuteInTransaction", mock.Anything, mock.Anything).Return(nil)
+	return NewRecalculateContactListUseCase(mockRepo, mockEventBus, mockTxManager), mockRepo, mockEventBus
+}
+
+func TestRecalculateContactListUseCase_Execute_EmitsJoinedAndLeft(t *testing.T) {
+	// Arrange
+	useCase, mockRepo, mockEventBus := newRecalculateUseCase()
+	ctx := context.Background()
+	list := newDynamicList(t)
+
+	joined := uuid.New()
+	left := uuid.New()
+	diff := &contact_list.MembershipDiff{Joined: []uuid.UUID{joined}, Left: []uuid.UUID{left}}
+
+	mockRepo.On("FindByID", ctx, list.ID()).Return(list, nil)
+	mockRepo.On("RefreshMembership", ctx, list.ID(), []uuid.UUID(nil)).Return(diff, nil)
+	mockRepo.On("RecalculateContactCount", ctx, list.ID()).Return(10, nil)
+	mockRepo.On("Update", ctx, list).Return(nil)
+
+	var published []string
+	mockEventBus.On("Publish", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		switch e := args.Get(1).(type) {
+		case contact_list.ContactJoinedListEvent:
+			assert.Equal(t, joined, e.ContactID)
+		case contact_list.ContactLeftListEvent:
+			assert.Equal(t, left, e.ContactID)
+		}
+		published = append(published, args.Get(1).(interface{ EventName() string }).EventName())
+	}).Return(nil)
+
+	// Act
+	resp, err := useCase.Execute(ctx, list.ID())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10, resp.ContactCount)
+	assert.Equal(t, 1, resp.Joined)
+	assert.Equal(t, 1, resp.Left)
+	assert.Equal(t, []string{"contact_list.list_joined", "contact_list.list_left", "contact_list.recalculated"}, published)
+	assert.Empty(t, list.DomainEvents())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecalculateContactListUseCase_Execute_ListNotFound(t *testing.T) {
+	// Arrange
+	useCase, mockRepo, _ := newRecalculateUseCase()
+	ctx := context.Background()
+	listID := uuid.New()
+	expectedErr := errors.New("contact list not found")
+
+	mockRepo.On("FindByID", ctx, listID).Return(nil, expectedErr)
+
+	// Act
+	resp, err := useCase.Execute(ctx, listID)
+
+	// Assert
+	assert.Nil(t, resp)
+	assert.Equal(t, expectedErr, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestRecalculateContactListUseCase_Execute_RefreshError(t *testing.T) {
+	// Arrange
+	useCase, mockRepo, mockEventBus := newRecalculateUseCase()
+	ctx := context.Background()
+	list := newDynamicList(t)
+
+	mockRepo.On("FindByID", ctx, list.ID()).Return(list, nil)
+	mockRepo.On("RefreshMembership", ctx, list.ID(), []uuid.UUID(nil)).Return(nil, errors.New("database error"))
+
+	// Act
+	resp, err := useCase.Execute(ctx, list.ID())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Nil(t, resp)
+	mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	mockEventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestRecalculateContactListUseCase_RecalculateForContact(t *testing.T) {
+	// Arrange
+	useCase, mockRepo, mockEventBus := newRecalculateUseCase()
+	ctx := context.Background()
+	contactID := uuid.New()
+
+	changed := newDynamicList(t)
+	unchanged := newDynamicList(t)
+
+	mockRepo.On("FindDynamicListsByContact", ctx, contactID).Return([]*contact_list.ContactList{changed, unchanged}, nil)
+	mockRepo.On("FindByIDForUpdate", ctx, changed.ID()).Return(changed, nil)
+	mockRepo.On("FindByIDForUpdate", ctx, unchanged.ID()).Return(unchanged, nil)
+	mockRepo.On("RefreshMembership", ctx, changed.ID(), []uuid.UUID{contactID}).
+		Return(&contact_list.MembershipDiff{Joined: []uuid.UUID{contactID}}, nil)
+	mockRepo.On("RefreshMembership", ctx, unchanged.ID(), []uuid.UUID{contactID}).
+		Return(&contact_list.MembershipDiff{}, nil)
+	mockRepo.On("Update", ctx, changed).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.AnythingOfType("contact_list.ContactJoinedListEvent")).Return(nil).Once()
+	mockEventBus.On("Publish", ctx, mock.AnythingOfType("contact_list.ContactListRecalculatedEvent")).Return(nil).Once()
+
+	// Act
+	err := useCase.RecalculateForContact(ctx, contactID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 1, changed.ContactCount())
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "Update", ctx, unchanged)
+	mockEventBus.AssertExpectations(t)
+}
+
+func TestRecalculateContactListUseCase_RecalculateForContact_CountsFromLockedRow(t *testing.T) {
+	// Arrange: a lista carregada fora da transação está defasada; outro consumer já gravou 5
+	useCase, mockRepo, mockEventBus := newRecalculateUseCase()
+	ctx := context.Background()
+	contactID := uuid.New()
+	stale := newDynamicList(t)
+	locked := newDynamicList(t)
+	locked.UpdateContactCount(5)
+	locked.ClearEvents()
+
+	mockRepo.On("FindDynamicListsByContact", ctx, contactID).Return([]*contact_list.ContactList{stale}, nil)
+	mockRepo.On("FindByIDForUpdate", ctx, stale.ID()).Return(locked, nil)
+	mockRepo.On("RefreshMembership", ctx, locked.ID(), []uuid.UUID{contactID}).
+		Return(&contact_list.MembershipDiff{Joined: []uuid.UUID{contactID}}, nil)
+	mockRepo.On("Update", ctx, locked).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.Anything).Return(nil)
+
+	// Act
+	err := useCase.RecalculateForContact(ctx, contactID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 6, locked.ContactCount())
+	mockRepo.AssertNotCalled(t, "Update", ctx, stale)
+}
+
+func TestRecalculateContactListUseCase_RecalculateForContact_PublishError(t *testing.T) {
+	// Arrange
+	useCase, mockRepo, mockEventBus := newRecalculateUseCase()
+	ctx := context.Background()
+	contactID := uuid.New()
+	list := newDynamicList(t)
+
+	mockRepo.On("FindDynamicListsByContact", ctx, contactID).Return([]*contact_list.ContactList{list}, nil)
+	mockRepo.On("FindByIDForUpdate", ctx, list.ID()).Return(list, nil)
+	mockRepo.On("RefreshMembership", ctx, list.ID(), []uuid.UUID{contactID}).
+		Return(&contact_list.MembershipDiff{Left: []uuid.UUID{contactID}}, nil)
+	mockRepo.On("Update", ctx, list).Return(nil)
+	mockEventBus.On("Publish", ctx, mock.Anything).Return(errors.New("outbox error"))
+
+	// Act
+	err := useCase.RecalculateForContact(ctx, contactID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "outbox error")
+}
@@ -2,7 +2,6 @@ package contact_list
 
 import (
 	"context"
-	"errors"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/crm/contact_list"
@@ -14,6 +13,7 @@ type UpdateContactListRequest struct {
 	Description     *string
 	LogicalOperator *contact_list.LogicalOperator
 	FilterRules     *[]FilterRuleRequest
+	FilterGroup     *FilterGroupRequest // Grupo vazio remove os filtros aninhados
 }
 
 type UpdateContactListUseCase struct {
@@ -54,37 +54,7 @@ func (uc *UpdateContactListUseCase) Execute(ctx context.Context, req UpdateConta
 
 		// Adicionar novas regras
 		for _, ruleReq := range *req.FilterRules {
-			var rule *contact_list.FilterRule
-			var err error
-
-			switch ruleReq.FilterType {
-			case contact_list.FilterTypeCustomField:
-				if ruleReq.FieldType == nil {
-					return errors.New("field_type is required for custom_field filters")
-				}
-				rule, err = contact_list.NewCustomFieldFilterRule(
-					ruleReq.FieldKey,
-					parseFieldType(*ruleReq.FieldType),
-					ruleReq.Operator,
-					ruleReq.Value,
-				)
-			case contact_list.FilterTypePipelineStatus:
-				if ruleReq.PipelineID == nil {
-					return errors.New("pipeline_id is required for pipeline_status filters")
-				}
-				rule, err = contact_list.NewPipelineStatusFilterRule(
-					*ruleReq.PipelineID,
-					ruleReq.FieldKey,
-					ruleReq.Operator,
-				)
-			case contact_list.FilterTypeTag:
-				rule, err = contact_list.NewTagFilterRule(ruleReq.Operator, ruleReq.Value)
-			case contact_list.FilterTypeAttribute:
-				rule, err = contact_list.NewAttributeFilterRule(ruleReq.FieldKey, ruleReq.Operator, ruleReq.Value)
-			default:
-				rule, err = contact_list.NewFilterRule(ruleReq.FilterType, ruleReq.Operator, ruleReq.FieldKey, ruleReq.Value)
-			}
-
+			rule, err := buildFilterRule(ruleReq)
 			if err != nil {
 				return err
 			}
@@ -95,6 +65,17 @@ func (uc *UpdateContactListUseCase) Execute(ctx context.Context, req UpdateConta
 		}
 	}
 
+	if req.FilterGroup != nil {
+		group, err := buildFilterGroup(req.FilterGroup)
+		if err != nil {
+			return err
+		}
+
+		if err := list.SetFilterGroup(group); err != nil {
+			return err
+		}
+	}
+
 	// Persistir
 	return uc.repo.Update(ctx, list)
 }
@@ -737,9 +737,9 @@ func TestUpdateContactListUseCase_Execute_FilterRulesWithEvent(t *testing.T) {
 	newFilterRules := []FilterRuleRequest{
 		{
 			FilterType: contact_list.FilterTypeEvent,
-			Operator:   contact_list.OperatorEquals,
+			Operator:   contact_list.OperatorWithinLastDays,
 			FieldKey:   "page_view",
-			Value:      "homepage",
+			Value:      7,
 		},
 	}
 
@@ -782,7 +782,7 @@ func TestUpdateContactListUseCase_Execute_FilterRulesWithInteraction(t *testing.
 		{
 			FilterType: contact_list.FilterTypeInteraction,
 			Operator:   contact_list.OperatorGreaterThan,
-			FieldKey:   "message_count",
+			FieldKey:   string(contact_list.InteractionMessages),
 			Value:      10,
 		},
 	}
@@ -9,6 +9,9 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/ventros/crm/infrastructure/ai"
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/channel"
 	"github.com/ventros/crm/internal/domain/crm/message"
 	"github.com/ventros/crm/internal/domain/crm/message_enrichment"
@@ -23,6 +26,19 @@ type MessageEnrichmentService struct {
 	channelRepo    channel.Repository
 	mimetypeRouter *ai.MimetypeRouter
 	audioSplitter  *ai.AudioSplitter
+	quota          AIQuotaChecker
+	usage          AIUsageRecorder
+}
+
+// AIQuotaChecker limite de minutos de IA do plano (billing.QuotaService)
+type AIQuotaChecker interface {
+	Check(ctx context.Context, projectID uuid.UUID, metric billing.UsageMetric, quantity int64) error
+}
+
+// AIUsageRecorder registra os minutos de IA consumidos e avisa quando o consumo atravessa o limite
+// de aviso ou o limite rígido do plano (billing.RecordUsageUseCase)
+type AIUsageRecorder interface {
+	Execute(ctx context.Context, cmd billingapp.RecordUsageCommand) error
 }
 
 // NewMessageEnrichmentService cria um novo serviço de enriquecimento
@@ -42,6 +58,14 @@ func NewMessageEnrichmentService(
 	}
 }
 
+// SetAIUsageLimits aplica o limite de minutos de IA do plano: mensagens de projetos que já
+// atingiram o limite rígido não são enriquecidas, e cada enriquecimento criado é registrado no
+// consumo (o registro dispara o aviso de limite de aviso/rígido quando atravessa o limite)
+func (s *MessageEnrichmentService) SetAIUsageLimits(quota AIQuotaChecker, usage AIUsageRecorder) {
+	s.quota = quota
+	s.usage = usage
+}
+
 // ProcessGroupEnrichments processa enriquecimentos para um grupo de mensagens
 func (s *MessageEnrichmentService) ProcessGroupEnrichments(
 	ctx context.Context,
@@ -107,7 +131,26 @@ func (s *MessageEnrichmentService) processMessageEnrichment(
 		return nil
 	}
 
-	// 5. Determinar contexto de processamento (para providers como Vision)
+	// 5. Limite de minutos de IA do plano
+	metered := isAIMetered(enrichmentContentType)
+	if metered && s.quota != nil {
+		err := s.quota.Check(ctx, msg.ProjectID(), billing.MetricAIMinutes, aiMinimumMinutes)
+		switch {
+		case shared.IsPaymentRequiredError(err):
+			s.logger.Warn("AI minutes limit reached, skipping enrichment",
+				zap.String("message_id", messageID.String()),
+				zap.String("project_id", msg.ProjectID().String()),
+				zap.Error(err))
+			return nil
+		case err != nil:
+			// Falha ao consultar o plano não bloqueia o atendimento (mesma política das rotas)
+			s.logger.Warn("AI minutes quota check failed, allowing enrichment",
+				zap.String("message_id", messageID.String()),
+				zap.Error(err))
+		}
+	}
+
+	// 6. Determinar contexto de processamento (para providers como Vision)
 	// Default: chat_message para imagens em conversa
 	var context *string
 	if enrichmentContentType == message_enrichment.EnrichmentTypeImage {
@@ -115,7 +158,7 @@ func (s *MessageEnrichmentService) processMessageEnrichment(
 		context = &ctx
 	}
 
-	// 6. Criar registro de enriquecimento usando domain aggregate
+	// 7. Criar registro de enriquecimento usando domain aggregate
 	enrichment, err := message_enrichment.NewMessageEnrichment(
 		messageID,
 		groupID,
@@ -128,11 +171,27 @@ func (s *MessageEnrichmentService) processMessageEnrichment(
 		return fmt.Errorf("failed to create enrichment aggregate: %w", err)
 	}
 
-	// 7. Persistir
+	// 8. Persistir
 	if err := s.enrichmentRepo.Save(ctx, enrichment); err != nil {
 		return fmt.Errorf("failed to save enrichment: %w", err)
 	}
 
+	// 9. Registrar o consumo: mesmo SourceID do consumer de usage_metering, então um evento
+	// message.ai.*_requested da mesma mensagem não conta de novo
+	if metered && s.usage != nil {
+		if err := s.usage.Execute(ctx, billingapp.RecordUsageCommand{
+			ProjectID:  msg.ProjectID(),
+			Metric:     billing.MetricAIMinutes,
+			SourceID:   string(enrichmentContentType) + ":" + messageID.String(),
+			Quantity:   aiMinimumMinutes,
+			OccurredAt: time.Now(),
+		}); err != nil {
+			s.logger.Warn("Failed to record AI minutes usage",
+				zap.String("message_id", messageID.String()),
+				zap.Error(err))
+		}
+	}
+
 	s.logger.Info("Created enrichment record",
 		zap.String("enrichment_id", enrichment.ID().String()),
 		zap.String("message_id", messageID.String()),
@@ -144,6 +203,21 @@ func (s *MessageEnrichmentService) processMessageEnrichment(
 	return nil
 }
 
+// aiMinimumMinutes minutos de IA cobrados por mídia: a duração não chega com a mensagem, então vale
+// o mínimo que o consumer de usage_metering aplica a mídias sem duração
+const aiMinimumMinutes = 1
+
+// isAIMetered tipos de enriquecimento que consomem minutos de IA (os mesmos dos eventos
+// message.ai.*_requested medidos pelo consumer de usage_metering)
+func isAIMetered(contentType message_enrichment.EnrichmentContentType) bool {
+	switch contentType {
+	case message_enrichment.EnrichmentTypeVoice, message_enrichment.EnrichmentTypeAudio,
+		message_enrichment.EnrichmentTypeImage, message_enrichment.EnrichmentTypeVideo:
+		return true
+	}
+	return false
+}
+
 // determineEnrichmentType determina o tipo de enriquecimento e provider baseado na mensagem
 func (s *MessageEnrichmentService) determineEnrichmentType(msg *message.Message) (message_enrichment.EnrichmentContentType, message_enrichment.EnrichmentProvider) {
 	// Map message content type to enrichment content type
@@ -0,0 +1,92 @@
+package message
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	billingapp "github.com/ventros/crm/internal/application/billing"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/message_enrichment"
+	"go.uber.org/zap"
+)
+
+type fakeEnrichmentMessageRepository struct {
+	message.Repository
+	msg *message.Message
+}
+
+func (r fakeEnrichmentMessageRepository) FindByID(ctx context.Context, id uuid.UUID) (*message.Message, error) {
+	return r.msg, nil
+}
+
+type fakeEnrichmentRepository struct {
+	message_enrichment.Repository
+	saved []*message_enrichment.MessageEnrichment
+}
+
+func (r *fakeEnrichmentRepository) Save(ctx context.Context, enrichment *message_enrichment.MessageEnrichment) error {
+	r.saved = append(r.saved, enrichment)
+	return nil
+}
+
+type stubAIQuota struct{ err error }
+
+func (q stubAIQuota) Check(ctx context.Context, projectID uuid.UUID, metric billing.UsageMetric, quantity int64) error {
+	return q.err
+}
+
+type recordingAIUsage struct {
+	commands []billingapp.RecordUsageCommand
+}
+
+func (u *recordingAIUsage) Execute(ctx context.Context, cmd billingapp.RecordUsageCommand) error {
+	u.commands = append(u.commands, cmd)
+	return nil
+}
+
+func newVoiceMessage(t *testing.T) *message.Message {
+	t.Helper()
+	msg, err := message.NewMessage(uuid.New(), uuid.New(), uuid.New(), message.ContentTypeVoice, false)
+	require.NoError(t, err)
+	require.NoError(t, msg.SetMediaContent("https://example.com/voice.ogg", "audio/ogg"))
+	return msg
+}
+
+func TestMessageEnrichmentService_AIMinutesLimit(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("records usage for each enrichment", func(t *testing.T) {
+		msg := newVoiceMessage(t)
+		enrichments := &fakeEnrichmentRepository{}
+		usage := &recordingAIUsage{}
+		s := NewMessageEnrichmentService(zap.NewNop(), enrichments, fakeEnrichmentMessageRepository{msg: msg}, nil)
+		s.SetAIUsageLimits(stubAIQuota{}, usage)
+
+		require.NoError(t, s.processMessageEnrichment(ctx, msg.ID(), uuid.New(), nil))
+
+		assert.Len(t, enrichments.saved, 1)
+		require.Len(t, usage.commands, 1)
+		assert.Equal(t, msg.ProjectID(), usage.commands[0].ProjectID)
+		assert.Equal(t, billing.MetricAIMinutes, usage.commands[0].Metric)
+		assert.Equal(t, "voice:"+msg.ID().String(), usage.commands[0].SourceID)
+		assert.Equal(t, int64(1), usage.commands[0].Quantity)
+	})
+
+	t.Run("skips enrichment past the hard limit", func(t *testing.T) {
+		msg := newVoiceMessage(t)
+		enrichments := &fakeEnrichmentRepository{}
+		usage := &recordingAIUsage{}
+		s := NewMessageEnrichmentService(zap.NewNop(), enrichments, fakeEnrichmentMessageRepository{msg: msg}, nil)
+		s.SetAIUsageLimits(stubAIQuota{err: shared.NewQuotaExceededError("Plan limit reached")}, usage)
+
+		require.NoError(t, s.processMessageEnrichment(ctx, msg.ID(), uuid.New(), nil))
+
+		assert.Empty(t, enrichments.saved)
+		assert.Empty(t, usage.commands)
+	})
+}
@@ -0,0 +1,50 @@
+package note
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/note"
+)
+
+// Author quem escreve a nota pela API
+type Author struct {
+	ID   uuid.UUID
+	Type note.AuthorType
+	Name string
+}
+
+// AuthorResolver traduz o usuário autenticado no autor da nota: o agente do
+// usuário no tenant quando existir, senão o próprio usuário.
+type AuthorResolver struct {
+	agentRepo agent.Repository
+}
+
+func NewAuthorResolver(agentRepo agent.Repository) *AuthorResolver {
+	return &AuthorResolver{agentRepo: agentRepo}
+}
+
+// Resolve retorna o autor para o usuário; fallbackName é usado quando não há agente
+func (r *AuthorResolver) Resolve(ctx context.Context, tenantID string, userID uuid.UUID, fallbackName string) Author {
+	if a, err := r.AgentForUser(ctx, tenantID, userID); err == nil {
+		return Author{ID: a.ID(), Type: note.AuthorTypeAgent, Name: a.Name()}
+	}
+	return Author{ID: userID, Type: note.AuthorTypeUser, Name: fallbackName}
+}
+
+// AgentForUser retorna o agente ativo do usuário no tenant
+func (r *AuthorResolver) AgentForUser(ctx context.Context, tenantID string, userID uuid.UUID) (*agent.Agent, error) {
+	agents, err := r.agentRepo.FindActiveByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agents: %w", err)
+	}
+	for _, a := range agents {
+		if a.UserID() != nil && *a.UserID() == userID {
+			return a, nil
+		}
+	}
+	return nil, shared.NewNotFoundError("agent", userID.String())
+}
@@ -21,6 +21,7 @@ type CreateNoteUseCase struct {
 	eventBus  *messaging.DomainEventBus
 	logger    *zap.Logger
 	txManager TransactionManager
+	mentions  *MentionDispatcher
 }
 
 // NewCreateNoteUseCase cria uma nova instância do use case
@@ -38,6 +39,11 @@ func NewCreateNoteUseCase(
 	}
 }
 
+// SetMentionDispatcher habilita a notificação dos agentes mencionados na nota
+func (uc *CreateNoteUseCase) SetMentionDispatcher(mentions *MentionDispatcher) {
+	uc.mentions = mentions
+}
+
 // CreateNoteCommand comando para criar nota
 type CreateNoteCommand struct {
 	ContactID       uuid.UUID
@@ -128,6 +134,9 @@ func (uc *CreateNoteUseCase) Execute(ctx context.Context, cmd CreateNoteCommand)
 
 	n.ClearEvents()
 
+	// 5. Notificar menções (após o commit; falhas de entrega não desfazem a nota)
+	uc.mentions.Dispatch(ctx, n, n.Mentions())
+
 	uc.logger.Info("Note created successfully",
 		zap.String("note_id", n.ID().String()),
 		zap.String("contact_id", cmd.ContactID.String()))
@@ -0,0 +1,53 @@
+package note
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/messaging"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"go.uber.org/zap"
+)
+
+// DeleteNoteUseCase remove (soft delete) uma nota
+type DeleteNoteUseCase struct {
+	noteRepo  note.Repository
+	eventBus  *messaging.DomainEventBus
+	logger    *zap.Logger
+	txManager TransactionManager
+}
+
+// NewDeleteNoteUseCase cria uma nova instância do use case
+func NewDeleteNoteUseCase(
+	noteRepo note.Repository,
+	eventBus *messaging.DomainEventBus,
+	logger *zap.Logger,
+	txManager TransactionManager,
+) *DeleteNoteUseCase {
+	return &DeleteNoteUseCase{
+		noteRepo:  noteRepo,
+		eventBus:  eventBus,
+		logger:    logger,
+		txManager: txManager,
+	}
+}
+
+// Execute executa o use case
+func (uc *DeleteNoteUseCase) Execute(ctx context.Context, tenantID string, noteID, deletedBy uuid.UUID) error {
+	n, err := findTenantNote(ctx, uc.noteRepo, tenantID, noteID)
+	if err != nil {
+		return err
+	}
+
+	n.Delete(deletedBy)
+
+	if err := saveNote(ctx, uc.txManager, uc.noteRepo, uc.eventBus, uc.logger, n); err != nil {
+		return err
+	}
+
+	uc.logger.Info("Note deleted",
+		zap.String("note_id", noteID.String()),
+		zap.String("deleted_by", deletedBy.String()))
+
+	return nil
+}
@@ -0,0 +1,147 @@
+package note
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"go.uber.org/zap"
+)
+
+// EmailMentionsSetting chave em agent.Settings() que habilita o aviso de menções por email
+const EmailMentionsSetting = "notify_mentions_by_email"
+
+// mentionPreviewLength tamanho do trecho da nota enviado na notificação
+const mentionPreviewLength = 140
+
+// Mention aviso de que agentes foram mencionados em uma nota
+type Mention struct {
+	NoteID     uuid.UUID
+	ContactID  uuid.UUID
+	SessionID  *uuid.UUID
+	TenantID   string
+	AuthorID   uuid.UUID
+	AuthorName string
+	Preview    string
+	At         time.Time
+}
+
+// InAppMentionNotifier entrega a menção em tempo real (websocket) aos usuários
+type InAppMentionNotifier interface {
+	NotifyMention(ctx context.Context, userIDs []uuid.UUID, mention Mention)
+}
+
+// EmailSender envia emails de texto simples
+type EmailSender interface {
+	Send(ctx context.Context, to []string, subject, body string) error
+}
+
+// MentionDispatcher notifica os agentes mencionados: sempre in-app e, para quem
+// habilitou EmailMentionsSetting, também por email. Falhas de entrega são apenas
+// logadas — a nota já foi salva e continua na caixa de menções do agente.
+type MentionDispatcher struct {
+	agentRepo agent.Repository
+	inApp     InAppMentionNotifier
+	email     EmailSender
+	logger    *zap.Logger
+}
+
+// NewMentionDispatcher cria o dispatcher; inApp e email são opcionais
+func NewMentionDispatcher(agentRepo agent.Repository, inApp InAppMentionNotifier, email EmailSender, logger *zap.Logger) *MentionDispatcher {
+	return &MentionDispatcher{
+		agentRepo: agentRepo,
+		inApp:     inApp,
+		email:     email,
+		logger:    logger,
+	}
+}
+
+// Dispatch notifica os agentes informados sobre a nota. Agentes de outro tenant,
+// inativos ou o próprio autor são ignorados.
+func (d *MentionDispatcher) Dispatch(ctx context.Context, n *note.Note, agentIDs []uuid.UUID) {
+	if d == nil || len(agentIDs) == 0 {
+		return
+	}
+
+	mention := Mention{
+		NoteID:     n.ID(),
+		ContactID:  n.ContactID(),
+		SessionID:  n.SessionID(),
+		TenantID:   n.TenantID(),
+		AuthorID:   n.AuthorID(),
+		AuthorName: n.AuthorName(),
+		Preview:    previewOf(n.Content()),
+		At:         time.Now(),
+	}
+
+	var userIDs []uuid.UUID
+	var emails []string
+	for _, agentID := range agentIDs {
+		if agentID == n.AuthorID() {
+			continue
+		}
+		a, err := d.agentRepo.FindByID(ctx, agentID)
+		if err != nil {
+			d.logger.Warn("Mentioned agent not found",
+				zap.Error(err),
+				zap.String("note_id", n.ID().String()),
+				zap.String("agent_id", agentID.String()))
+			continue
+		}
+		if a.TenantID() != n.TenantID() || !a.IsActive() {
+			continue
+		}
+		if a.UserID() != nil && *a.UserID() != n.AuthorID() {
+			userIDs = append(userIDs, *a.UserID())
+		}
+		if wantsEmail(a) {
+			emails = append(emails, a.Email())
+		}
+	}
+
+	if d.inApp != nil && len(userIDs) > 0 {
+		d.inApp.NotifyMention(ctx, userIDs, mention)
+	}
+
+	if d.email != nil && len(emails) > 0 {
+		subject := fmt.Sprintf("%s mencionou você em uma nota", mention.AuthorName)
+		if err := d.email.Send(ctx, emails, subject, mentionEmailBody(mention)); err != nil {
+			d.logger.Error("Failed to send mention email",
+				zap.Error(err),
+				zap.String("note_id", n.ID().String()),
+				zap.Int("recipients", len(emails)))
+		}
+	}
+}
+
+func wantsEmail(a *agent.Agent) bool {
+	if a.Email() == "" {
+		return false
+	}
+	enabled, _ := a.Settings()[EmailMentionsSetting].(bool)
+	return enabled
+}
+
+func previewOf(content string) string {
+	runes := []rune(strings.TrimSpace(content))
+	if len(runes) <= mentionPreviewLength {
+		return string(runes)
+	}
+	return string(runes[:mentionPreviewLength]) + "…"
+}
+
+func mentionEmailBody(m Mention) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s mencionou você em uma nota:\n\n", m.AuthorName)
+	fmt.Fprintf(&b, "%s\n\n", m.Preview)
+	fmt.Fprintf(&b, "Contato: %s\n", m.ContactID)
+	if m.SessionID != nil {
+		fmt.Fprintf(&b, "Sessão: %s\n", *m.SessionID)
+	}
+	fmt.Fprintf(&b, "Nota: %s\n", m.NoteID)
+	return b.String()
+}
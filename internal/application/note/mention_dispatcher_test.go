@@ -0,0 +1,93 @@
+package note
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"go.uber.org/zap"
+)
+
+func newMentionedAgent(t *testing.T, tenantID, email string, emailOptIn bool) *agent.Agent {
+	t.Helper()
+	userID := uuid.New()
+	a, err := agent.NewAgent(uuid.New(), tenantID, "Agent "+email, agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	if email != "" {
+		require.NoError(t, a.UpdateProfile(a.Name(), email))
+	}
+	if emailOptIn {
+		a.UpdateSettings(map[string]interface{}{EmailMentionsSetting: true})
+	}
+	return a
+}
+
+func TestMentionDispatcher_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	tenantID := "tenant-1"
+
+	optedIn := newMentionedAgent(t, tenantID, "ana@example.com", true)
+	inAppOnly := newMentionedAgent(t, tenantID, "bruno@example.com", false)
+	foreign := newMentionedAgent(t, "other-tenant", "eve@example.com", true)
+	missing := uuid.New()
+
+	n, err := note.NewNote(uuid.New(), tenantID, uuid.New(), note.AuthorTypeAgent, "Carla", strings.Repeat("a", 200), note.NoteTypeGeneral)
+	require.NoError(t, err)
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindByID", ctx, optedIn.ID()).Return(optedIn, nil)
+	agentRepo.On("FindByID", ctx, inAppOnly.ID()).Return(inAppOnly, nil)
+	agentRepo.On("FindByID", ctx, foreign.ID()).Return(foreign, nil)
+	agentRepo.On("FindByID", ctx, missing).Return(nil, errors.New("not found"))
+
+	inApp := &recordingInAppNotifier{}
+	email := &recordingEmailSender{}
+	dispatcher := NewMentionDispatcher(agentRepo, inApp, email, zap.NewNop())
+
+	dispatcher.Dispatch(ctx, n, []uuid.UUID{optedIn.ID(), inAppOnly.ID(), foreign.ID(), missing, n.AuthorID()})
+
+	assert.ElementsMatch(t, []uuid.UUID{*optedIn.UserID(), *inAppOnly.UserID()}, inApp.userIDs)
+	require.Len(t, inApp.mentions, 1)
+	assert.Equal(t, n.ID(), inApp.mentions[0].NoteID)
+	assert.Equal(t, "Carla", inApp.mentions[0].AuthorName)
+	assert.Len(t, []rune(inApp.mentions[0].Preview), mentionPreviewLength+1) // trecho + reticências
+
+	assert.Equal(t, []string{"ana@example.com"}, email.to)
+	assert.Contains(t, email.subject, "Carla")
+	assert.Contains(t, email.body, n.ContactID().String())
+}
+
+func TestMentionDispatcher_EmailFailureIsNotFatal(t *testing.T) {
+	ctx := context.Background()
+	a := newMentionedAgent(t, "tenant-1", "ana@example.com", true)
+
+	n, err := note.NewNote(uuid.New(), "tenant-1", uuid.New(), note.AuthorTypeAgent, "Carla", "Olha isso", note.NoteTypeGeneral)
+	require.NoError(t, err)
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindByID", ctx, a.ID()).Return(a, nil)
+
+	inApp := &recordingInAppNotifier{}
+	email := &recordingEmailSender{err: errors.New("smtp down")}
+
+	NewMentionDispatcher(agentRepo, inApp, email, zap.NewNop()).Dispatch(ctx, n, []uuid.UUID{a.ID()})
+
+	assert.Equal(t, []uuid.UUID{*a.UserID()}, inApp.userIDs)
+	assert.Equal(t, []string{"ana@example.com"}, email.to)
+}
+
+func TestMentionDispatcher_NilIsNoop(t *testing.T) {
+	n, err := note.NewNote(uuid.New(), "tenant-1", uuid.New(), note.AuthorTypeAgent, "Carla", "Olha isso", note.NoteTypeGeneral)
+	require.NoError(t, err)
+
+	var dispatcher *MentionDispatcher
+	assert.NotPanics(t, func() {
+		dispatcher.Dispatch(context.Background(), n, []uuid.UUID{uuid.New()})
+	})
+}
@@ -0,0 +1,124 @@
+package note
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/storage"
+)
+
+type MockAgentRepository struct {
+	mock.Mock
+}
+
+func (m *MockAgentRepository) Save(ctx context.Context, a *agent.Agent) error {
+	args := m.Called(ctx, a)
+	return args.Error(0)
+}
+
+func (m *MockAgentRepository) FindByID(ctx context.Context, id uuid.UUID) (*agent.Agent, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) FindByEmail(ctx context.Context, tenantID, email string) (*agent.Agent, error) {
+	args := m.Called(ctx, tenantID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) FindByTenant(ctx context.Context, tenantID string) ([]*agent.Agent, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) FindActiveByTenant(ctx context.Context, tenantID string) ([]*agent.Agent, error) {
+	args := m.Called(ctx, tenantID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*agent.Agent), args.Error(1)
+}
+
+func (m *MockAgentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockAgentRepository) FindByTenantWithFilters(ctx context.Context, filters agent.AgentFilters) ([]*agent.Agent, int64, error) {
+	args := m.Called(ctx, filters)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*agent.Agent), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockAgentRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*agent.Agent, int64, error) {
+	args := m.Called(ctx, tenantID, searchText, limit, offset)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]*agent.Agent), args.Get(1).(int64), args.Error(2)
+}
+
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) Upload(ctx context.Context, file io.Reader, path string, opts storage.UploadOptions) (string, error) {
+	args := m.Called(ctx, file, path, opts)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorage) GetSignedURL(ctx context.Context, path string, expiry time.Duration) (string, error) {
+	args := m.Called(ctx, path, expiry)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockStorage) Delete(ctx context.Context, path string) error {
+	args := m.Called(ctx, path)
+	return args.Error(0)
+}
+
+func (m *MockStorage) Exists(ctx context.Context, path string) (bool, error) {
+	args := m.Called(ctx, path)
+	return args.Bool(0), args.Error(1)
+}
+
+// recordingInAppNotifier guarda as menções entregues in-app
+type recordingInAppNotifier struct {
+	userIDs  []uuid.UUID
+	mentions []Mention
+}
+
+func (n *recordingInAppNotifier) NotifyMention(ctx context.Context, userIDs []uuid.UUID, mention Mention) {
+	n.userIDs = append(n.userIDs, userIDs...)
+	n.mentions = append(n.mentions, mention)
+}
+
+// recordingEmailSender guarda os emails enviados
+type recordingEmailSender struct {
+	to      []string
+	subject string
+	body    string
+	err     error
+}
+
+func (s *recordingEmailSender) Send(ctx context.Context, to []string, subject, body string) error {
+	s.to = append(s.to, to...)
+	s.subject = subject
+	s.body = body
+	return s.err
+}
@@ -0,0 +1,57 @@
+package note
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/messaging"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"go.uber.org/zap"
+)
+
+// PinNoteUseCase fixa ou desafixa uma nota no topo do contato
+type PinNoteUseCase struct {
+	noteRepo  note.Repository
+	eventBus  *messaging.DomainEventBus
+	logger    *zap.Logger
+	txManager TransactionManager
+}
+
+// NewPinNoteUseCase cria uma nova instância do use case
+func NewPinNoteUseCase(
+	noteRepo note.Repository,
+	eventBus *messaging.DomainEventBus,
+	logger *zap.Logger,
+	txManager TransactionManager,
+) *PinNoteUseCase {
+	return &PinNoteUseCase{
+		noteRepo:  noteRepo,
+		eventBus:  eventBus,
+		logger:    logger,
+		txManager: txManager,
+	}
+}
+
+// Execute fixa (pinned=true) ou desafixa a nota. Repetir o estado atual não gera evento.
+func (uc *PinNoteUseCase) Execute(ctx context.Context, tenantID string, noteID, userID uuid.UUID, pinned bool) (*note.Note, error) {
+	n, err := findTenantNote(ctx, uc.noteRepo, tenantID, noteID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pinned {
+		n.Pin(userID)
+	} else {
+		n.Unpin(userID)
+	}
+
+	if len(n.DomainEvents()) == 0 {
+		return n, nil
+	}
+
+	if err := saveNote(ctx, uc.txManager, uc.noteRepo, uc.eventBus, uc.logger, n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
@@ -0,0 +1,53 @@
+package note
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+// TargetResolver valida onde a nota será criada: o contato (ou a sessão e seu
+// contato) precisa existir e pertencer ao tenant.
+type TargetResolver struct {
+	contactRepo contact.Repository
+	sessionRepo session.Repository
+}
+
+func NewTargetResolver(contactRepo contact.Repository, sessionRepo session.Repository) *TargetResolver {
+	return &TargetResolver{contactRepo: contactRepo, sessionRepo: sessionRepo}
+}
+
+// Contact garante que o contato existe no tenant
+func (r *TargetResolver) Contact(ctx context.Context, tenantID string, contactID uuid.UUID) error {
+	c, err := r.contactRepo.FindByID(ctx, contactID)
+	if err != nil {
+		if shared.IsNotFoundError(err) {
+			return err
+		}
+		return fmt.Errorf("failed to load contact: %w", err)
+	}
+	if c.TenantID() != tenantID || c.IsDeleted() {
+		return shared.NewNotFoundError("contact", contactID.String())
+	}
+	return nil
+}
+
+// Session garante que a sessão existe no tenant e retorna o contato dela
+func (r *TargetResolver) Session(ctx context.Context, tenantID string, sessionID uuid.UUID) (uuid.UUID, error) {
+	s, err := r.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return uuid.Nil, shared.NewNotFoundError("session", sessionID.String())
+		}
+		return uuid.Nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if s.TenantID() != tenantID {
+		return uuid.Nil, shared.NewNotFoundError("session", sessionID.String())
+	}
+	return s.ContactID(), nil
+}
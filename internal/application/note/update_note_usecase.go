@@ -0,0 +1,147 @@
+package note
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/messaging"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"go.uber.org/zap"
+)
+
+// UpdateNoteUseCase edita conteúdo, prioridade, visibilidade, tags e menções de uma nota
+type UpdateNoteUseCase struct {
+	noteRepo  note.Repository
+	eventBus  *messaging.DomainEventBus
+	logger    *zap.Logger
+	txManager TransactionManager
+	mentions  *MentionDispatcher
+}
+
+// NewUpdateNoteUseCase cria uma nova instância do use case; mentions é opcional
+func NewUpdateNoteUseCase(
+	noteRepo note.Repository,
+	eventBus *messaging.DomainEventBus,
+	logger *zap.Logger,
+	txManager TransactionManager,
+	mentions *MentionDispatcher,
+) *UpdateNoteUseCase {
+	return &UpdateNoteUseCase{
+		noteRepo:  noteRepo,
+		eventBus:  eventBus,
+		logger:    logger,
+		txManager: txManager,
+		mentions:  mentions,
+	}
+}
+
+// UpdateNoteCommand comando para editar nota; campos nil não são alterados
+type UpdateNoteCommand struct {
+	NoteID          uuid.UUID
+	TenantID        string
+	UpdatedBy       uuid.UUID
+	Content         *string
+	Priority        *note.Priority
+	VisibleToClient *bool
+	Tags            *[]string
+	Mentions        *[]uuid.UUID
+}
+
+// Execute executa o use case. Apenas agentes mencionados pela primeira vez são notificados.
+func (uc *UpdateNoteUseCase) Execute(ctx context.Context, cmd UpdateNoteCommand) (*note.Note, error) {
+	n, err := findTenantNote(ctx, uc.noteRepo, cmd.TenantID, cmd.NoteID)
+	if err != nil {
+		return nil, err
+	}
+
+	newMentions, err := n.Update(cmd.UpdatedBy, note.NoteChanges{
+		Content:         cmd.Content,
+		Priority:        cmd.Priority,
+		VisibleToClient: cmd.VisibleToClient,
+		Tags:            cmd.Tags,
+		Mentions:        cmd.Mentions,
+	})
+	if err != nil {
+		return nil, noteValidationError(err)
+	}
+
+	if len(n.DomainEvents()) == 0 {
+		return n, nil
+	}
+
+	if err := saveNote(ctx, uc.txManager, uc.noteRepo, uc.eventBus, uc.logger, n); err != nil {
+		return nil, err
+	}
+
+	uc.mentions.Dispatch(ctx, n, newMentions)
+
+	uc.logger.Info("Note updated",
+		zap.String("note_id", n.ID().String()),
+		zap.String("updated_by", cmd.UpdatedBy.String()))
+
+	return n, nil
+}
+
+// findTenantNote carrega a nota garantindo que pertence ao tenant
+func findTenantNote(ctx context.Context, noteRepo note.Repository, tenantID string, noteID uuid.UUID) (*note.Note, error) {
+	n, err := noteRepo.FindByID(ctx, noteID)
+	if err != nil {
+		if errors.Is(err, note.ErrNoteNotFound) {
+			return nil, shared.NewNotFoundError("note", noteID.String())
+		}
+		return nil, fmt.Errorf("failed to load note: %w", err)
+	}
+	if n.TenantID() != tenantID || n.IsDeleted() {
+		return nil, shared.NewNotFoundError("note", noteID.String())
+	}
+	return n, nil
+}
+
+// saveNote salva a nota e publica seus eventos na mesma transação
+func saveNote(ctx context.Context, txManager TransactionManager, noteRepo note.Repository, eventBus *messaging.DomainEventBus, logger *zap.Logger, n *note.Note) error {
+	err := txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := noteRepo.Save(txCtx, n); err != nil {
+			logger.Error("Failed to save note",
+				zap.Error(err),
+				zap.String("note_id", n.ID().String()))
+			return fmt.Errorf("failed to save note: %w", err)
+		}
+
+		if eventBus != nil {
+			for _, event := range n.DomainEvents() {
+				if err := eventBus.Publish(txCtx, event); err != nil {
+					logger.Error("Failed to publish domain event",
+						zap.Error(err),
+						zap.String("note_id", n.ID().String()))
+					return fmt.Errorf("failed to publish event: %w", err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	n.ClearEvents()
+	return nil
+}
+
+// noteValidationError converte erros de validação do agregado em erros de domínio
+func noteValidationError(err error) error {
+	switch {
+	case errors.Is(err, note.ErrEmptyContent):
+		return shared.NewValidationError(err.Error(), "content")
+	case errors.Is(err, note.ErrInvalidPriority):
+		return shared.NewValidationError(err.Error(), "priority")
+	case errors.Is(err, note.ErrInvalidContact):
+		return shared.NewValidationError(err.Error(), "contact_id")
+	case errors.Is(err, note.ErrInvalidAuthor):
+		return shared.NewValidationError(err.Error(), "author_id")
+	}
+	return err
+}
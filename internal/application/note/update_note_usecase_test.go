@@ -0,0 +1,149 @@
+package note
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"go.uber.org/zap"
+)
+
+func newStoredNote(t *testing.T, tenantID string) *note.Note {
+	t.Helper()
+	n, err := note.NewNote(uuid.New(), tenantID, uuid.New(), note.AuthorTypeAgent, "Carla", "Cliente pediu retorno", note.NoteTypeFollowUp)
+	require.NoError(t, err)
+	n.ClearEvents()
+	return n
+}
+
+func TestUpdateNoteUseCase_Execute_NotifiesOnlyNewMentions(t *testing.T) {
+	ctx := context.Background()
+	existing := newMentionedAgent(t, "tenant-1", "", false)
+	added := newMentionedAgent(t, "tenant-1", "", false)
+
+	n := newStoredNote(t, "tenant-1")
+	n.MentionAgent(existing.ID())
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+	noteRepo.On("Save", ctx, n).Return(nil)
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindByID", ctx, added.ID()).Return(added, nil)
+
+	inApp := &recordingInAppNotifier{}
+	uc := NewUpdateNoteUseCase(noteRepo, nil, zap.NewNop(), &SimpleTransactionManager{},
+		NewMentionDispatcher(agentRepo, inApp, nil, zap.NewNop()))
+
+	content := "Cliente pediu retorno amanhã"
+	result, err := uc.Execute(ctx, UpdateNoteCommand{
+		NoteID:    n.ID(),
+		TenantID:  "tenant-1",
+		UpdatedBy: uuid.New(),
+		Content:   &content,
+		Mentions:  &[]uuid.UUID{existing.ID(), added.ID()},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, content, result.Content())
+	assert.Empty(t, result.DomainEvents(), "events are cleared after publishing")
+	assert.Equal(t, []uuid.UUID{*added.UserID()}, inApp.userIDs)
+	noteRepo.AssertExpectations(t)
+	agentRepo.AssertNotCalled(t, "FindByID", ctx, existing.ID())
+}
+
+func TestUpdateNoteUseCase_Execute_NoChangesSkipsSave(t *testing.T) {
+	ctx := context.Background()
+	n := newStoredNote(t, "tenant-1")
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+
+	uc := NewUpdateNoteUseCase(noteRepo, nil, zap.NewNop(), &SimpleTransactionManager{}, nil)
+
+	content := n.Content()
+	_, err := uc.Execute(ctx, UpdateNoteCommand{NoteID: n.ID(), TenantID: "tenant-1", Content: &content})
+
+	require.NoError(t, err)
+	noteRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestUpdateNoteUseCase_Execute_Errors(t *testing.T) {
+	ctx := context.Background()
+	n := newStoredNote(t, "tenant-1")
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+	noteRepo.On("FindByID", ctx, mock.Anything).Return(nil, note.ErrNoteNotFound)
+
+	uc := NewUpdateNoteUseCase(noteRepo, nil, zap.NewNop(), &SimpleTransactionManager{}, nil)
+
+	t.Run("other tenant", func(t *testing.T) {
+		_, err := uc.Execute(ctx, UpdateNoteCommand{NoteID: n.ID(), TenantID: "tenant-2"})
+		assert.True(t, shared.IsNotFoundError(err))
+	})
+
+	t.Run("missing note", func(t *testing.T) {
+		_, err := uc.Execute(ctx, UpdateNoteCommand{NoteID: uuid.New(), TenantID: "tenant-1"})
+		assert.True(t, shared.IsNotFoundError(err))
+	})
+
+	t.Run("invalid priority", func(t *testing.T) {
+		priority := note.Priority("critical")
+		_, err := uc.Execute(ctx, UpdateNoteCommand{NoteID: n.ID(), TenantID: "tenant-1", Priority: &priority})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	noteRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestPinNoteUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	n := newStoredNote(t, "tenant-1")
+	userID := uuid.New()
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+	noteRepo.On("Save", ctx, n).Return(nil).Twice()
+
+	uc := NewPinNoteUseCase(noteRepo, nil, zap.NewNop(), &SimpleTransactionManager{})
+
+	result, err := uc.Execute(ctx, "tenant-1", n.ID(), userID, true)
+	require.NoError(t, err)
+	assert.True(t, result.Pinned())
+
+	// Fixar de novo não muda nada nem salva
+	_, err = uc.Execute(ctx, "tenant-1", n.ID(), userID, true)
+	require.NoError(t, err)
+
+	result, err = uc.Execute(ctx, "tenant-1", n.ID(), userID, false)
+	require.NoError(t, err)
+	assert.False(t, result.Pinned())
+
+	noteRepo.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestDeleteNoteUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	n := newStoredNote(t, "tenant-1")
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+	noteRepo.On("Save", ctx, n).Return(nil).Once()
+
+	uc := NewDeleteNoteUseCase(noteRepo, nil, zap.NewNop(), &SimpleTransactionManager{})
+
+	require.NoError(t, uc.Execute(ctx, "tenant-1", n.ID(), uuid.New()))
+	assert.True(t, n.IsDeleted())
+
+	// Nota já removida se comporta como inexistente
+	err := uc.Execute(ctx, "tenant-1", n.ID(), uuid.New())
+	assert.True(t, shared.IsNotFoundError(err))
+
+	noteRepo.AssertExpectations(t)
+}
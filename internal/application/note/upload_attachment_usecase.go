@@ -0,0 +1,112 @@
+package note
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/messaging"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/note"
+	"github.com/ventros/crm/internal/domain/storage"
+	"go.uber.org/zap"
+)
+
+// MaxAttachmentSize tamanho máximo de um anexo de nota (25MB)
+const MaxAttachmentSize int64 = 25 * 1024 * 1024
+
+// UploadAttachmentUseCase envia um arquivo ao storage e o anexa à nota
+type UploadAttachmentUseCase struct {
+	noteRepo  note.Repository
+	storage   storage.Storage
+	eventBus  *messaging.DomainEventBus
+	logger    *zap.Logger
+	txManager TransactionManager
+}
+
+// NewUploadAttachmentUseCase cria uma nova instância do use case
+func NewUploadAttachmentUseCase(
+	noteRepo note.Repository,
+	storage storage.Storage,
+	eventBus *messaging.DomainEventBus,
+	logger *zap.Logger,
+	txManager TransactionManager,
+) *UploadAttachmentUseCase {
+	return &UploadAttachmentUseCase{
+		noteRepo:  noteRepo,
+		storage:   storage,
+		eventBus:  eventBus,
+		logger:    logger,
+		txManager: txManager,
+	}
+}
+
+// UploadAttachmentCommand comando para anexar arquivo
+type UploadAttachmentCommand struct {
+	NoteID      uuid.UUID
+	TenantID    string
+	UploadedBy  uuid.UUID
+	Filename    string
+	ContentType string
+	Size        int64
+	File        io.Reader
+}
+
+// Execute executa o use case. Se a nota não puder ser salva, o arquivo enviado é removido.
+func (uc *UploadAttachmentUseCase) Execute(ctx context.Context, cmd UploadAttachmentCommand) (*note.Note, string, error) {
+	if cmd.Size > MaxAttachmentSize {
+		return nil, "", shared.NewValidationError(
+			fmt.Sprintf("attachment exceeds %d bytes", MaxAttachmentSize), "file")
+	}
+
+	n, err := findTenantNote(ctx, uc.noteRepo, cmd.TenantID, cmd.NoteID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	path := attachmentPath(cmd.TenantID, n.ID(), cmd.Filename)
+	url, err := uc.storage.Upload(ctx, cmd.File, path, storage.UploadOptions{
+		ContentType: cmd.ContentType,
+		Public:      true,
+		MaxSize:     MaxAttachmentSize,
+		Metadata: map[string]string{
+			"original_filename": cmd.Filename,
+			"note_id":           n.ID().String(),
+			"uploaded_by":       cmd.UploadedBy.String(),
+		},
+	})
+	if err != nil {
+		uc.logger.Error("Failed to upload note attachment",
+			zap.Error(err),
+			zap.String("note_id", n.ID().String()),
+			zap.String("filename", cmd.Filename))
+		return nil, "", fmt.Errorf("failed to upload attachment: %w", err)
+	}
+
+	n.Attach(url, cmd.UploadedBy)
+
+	if err := saveNote(ctx, uc.txManager, uc.noteRepo, uc.eventBus, uc.logger, n); err != nil {
+		if delErr := uc.storage.Delete(ctx, path); delErr != nil {
+			uc.logger.Warn("Failed to remove orphan note attachment",
+				zap.Error(delErr),
+				zap.String("path", path))
+		}
+		return nil, "", err
+	}
+
+	uc.logger.Info("Note attachment uploaded",
+		zap.String("note_id", n.ID().String()),
+		zap.String("path", path),
+		zap.Int64("size", cmd.Size))
+
+	return n, url, nil
+}
+
+// attachmentPath organiza os anexos por tenant e nota, preservando a extensão original
+func attachmentPath(tenantID string, noteID uuid.UUID, filename string) string {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return fmt.Sprintf("notes/%s/%s/%s%s", tenantID, noteID, uuid.New(), ext)
+}
@@ -0,0 +1,80 @@
+package note
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/storage"
+	"go.uber.org/zap"
+)
+
+func TestUploadAttachmentUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	n := newStoredNote(t, "tenant-1")
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+	noteRepo.On("Save", ctx, n).Return(nil)
+
+	store := new(MockStorage)
+	store.On("Upload", ctx, mock.Anything, mock.MatchedBy(func(path string) bool {
+		return strings.HasPrefix(path, "notes/tenant-1/"+n.ID().String()+"/") && strings.HasSuffix(path, ".pdf")
+	}), mock.MatchedBy(func(opts storage.UploadOptions) bool {
+		return opts.ContentType == "application/pdf" && opts.Metadata["original_filename"] == "Contrato.PDF"
+	})).Return("https://cdn.example.com/contract.pdf", nil)
+
+	uc := NewUploadAttachmentUseCase(noteRepo, store, nil, zap.NewNop(), &SimpleTransactionManager{})
+
+	result, url, err := uc.Execute(ctx, UploadAttachmentCommand{
+		NoteID:      n.ID(),
+		TenantID:    "tenant-1",
+		Filename:    "Contrato.PDF",
+		ContentType: "application/pdf",
+		Size:        1024,
+		File:        strings.NewReader("%PDF"),
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "https://cdn.example.com/contract.pdf", url)
+	assert.Equal(t, []string{url}, result.Attachments())
+	store.AssertExpectations(t)
+}
+
+func TestUploadAttachmentUseCase_Execute_RemovesFileWhenSaveFails(t *testing.T) {
+	ctx := context.Background()
+	n := newStoredNote(t, "tenant-1")
+
+	noteRepo := new(MockNoteRepository)
+	noteRepo.On("FindByID", ctx, n.ID()).Return(n, nil)
+	noteRepo.On("Save", ctx, n).Return(errors.New("db down"))
+
+	var uploadedPath string
+	store := new(MockStorage)
+	store.On("Upload", ctx, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { uploadedPath = args.String(2) }).
+		Return("https://cdn.example.com/x.png", nil)
+	store.On("Delete", ctx, mock.Anything).Return(nil)
+
+	uc := NewUploadAttachmentUseCase(noteRepo, store, nil, zap.NewNop(), &SimpleTransactionManager{})
+
+	_, _, err := uc.Execute(ctx, UploadAttachmentCommand{
+		NoteID: n.ID(), TenantID: "tenant-1", Filename: "x.png", Size: 10, File: strings.NewReader("png"),
+	})
+
+	require.Error(t, err)
+	store.AssertCalled(t, "Delete", ctx, uploadedPath)
+}
+
+func TestUploadAttachmentUseCase_Execute_TooLarge(t *testing.T) {
+	uc := NewUploadAttachmentUseCase(new(MockNoteRepository), new(MockStorage), nil, zap.NewNop(), &SimpleTransactionManager{})
+
+	_, _, err := uc.Execute(context.Background(), UploadAttachmentCommand{Size: MaxAttachmentSize + 1})
+
+	assert.True(t, shared.IsValidationError(err))
+}
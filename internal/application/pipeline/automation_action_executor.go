@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/audit"
 	"github.com/ventros/crm/internal/domain/crm/pipeline"
 )
 
@@ -354,3 +355,79 @@ func (e *DefaultActionExecutor) executeTriggerWorkflow(ctx context.Context, acti
 
 	return e.workflowTrigger.TriggerWorkflow(ctx, workflowName, input)
 }
+
+// AuditRecorder grava registros na trilha de auditoria (auditapp.Recorder)
+type AuditRecorder interface {
+	Record(ctx context.Context, record audit.Record) error
+}
+
+// PipelineLookup resolve o projeto do pipeline da regra
+type PipelineLookup interface {
+	FindPipelineByID(ctx context.Context, id uuid.UUID) (*pipeline.Pipeline, error)
+}
+
+// AuditingActionExecutor registra na trilha de auditoria, com ator automation, cada ação executada
+// pelo executor decorado (sucesso ou falha). Falha no registro não interrompe a automação.
+type AuditingActionExecutor struct {
+	next      ActionExecutor
+	recorder  AuditRecorder
+	pipelines PipelineLookup
+	logger    Logger
+}
+
+// NewAuditingActionExecutor decora um executor com o registro na trilha de auditoria
+func NewAuditingActionExecutor(next ActionExecutor, recorder AuditRecorder, pipelines PipelineLookup, logger Logger) *AuditingActionExecutor {
+	return &AuditingActionExecutor{
+		next:      next,
+		recorder:  recorder,
+		pipelines: pipelines,
+		logger:    logger,
+	}
+}
+
+// Execute executa a ação e registra o resultado
+func (e *AuditingActionExecutor) Execute(ctx context.Context, action pipeline.RuleAction, actionCtx ActionContext) error {
+	err := e.next.Execute(ctx, action, actionCtx)
+	e.record(ctx, action, actionCtx, err)
+	return err
+}
+
+func (e *AuditingActionExecutor) record(ctx context.Context, action pipeline.RuleAction, actionCtx ActionContext, execErr error) {
+	record := audit.Record{
+		TenantID: actionCtx.TenantID,
+		Actor: audit.Actor{
+			Type:  audit.ActorAutomation,
+			ID:    actionCtx.RuleID.String(),
+			Label: actionCtx.RuleName,
+		},
+		Action: "automations." + string(action.Type),
+		Metadata: map[string]string{
+			"trigger": string(actionCtx.Trigger),
+			"result":  "success",
+		},
+	}
+	switch {
+	case actionCtx.SessionID != nil:
+		record.ResourceType, record.ResourceID = "session", actionCtx.SessionID.String()
+	case actionCtx.ContactID != nil:
+		record.ResourceType, record.ResourceID = "contact", actionCtx.ContactID.String()
+	}
+	if execErr != nil {
+		record.Metadata["result"] = "failed"
+		record.Metadata["error"] = execErr.Error()
+	}
+
+	if actionCtx.PipelineID != nil {
+		record.Metadata["pipeline_id"] = actionCtx.PipelineID.String()
+		p, err := e.pipelines.FindPipelineByID(ctx, *actionCtx.PipelineID)
+		if err != nil {
+			e.logger.Error("failed to resolve pipeline project for audit", "pipelineID", *actionCtx.PipelineID, "error", err)
+		} else {
+			record.ProjectID = p.ProjectID()
+		}
+	}
+
+	if err := e.recorder.Record(ctx, record); err != nil {
+		e.logger.Error("audit entry lost", "ruleID", actionCtx.RuleID, "actionType", action.Type, "error", err)
+	}
+}
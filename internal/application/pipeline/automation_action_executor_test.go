@@ -0,0 +1,78 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/audit"
+	"github.com/ventros/crm/internal/domain/crm/pipeline"
+)
+
+type stubActionExecutor struct{ err error }
+
+func (s stubActionExecutor) Execute(ctx context.Context, action pipeline.RuleAction, actionCtx ActionContext) error {
+	return s.err
+}
+
+type recordingAuditRecorder struct{ records []audit.Record }
+
+func (r *recordingAuditRecorder) Record(ctx context.Context, record audit.Record) error {
+	r.records = append(r.records, record)
+	return nil
+}
+
+type stubPipelineLookup struct{ p *pipeline.Pipeline }
+
+func (s stubPipelineLookup) FindPipelineByID(ctx context.Context, id uuid.UUID) (*pipeline.Pipeline, error) {
+	return s.p, nil
+}
+
+func TestAuditingActionExecutor_RecordsAutomationActor(t *testing.T) {
+	projectID := uuid.New()
+	p, err := pipeline.NewPipeline(projectID, "tenant-1", "Vendas")
+	require.NoError(t, err)
+
+	recorder := &recordingAuditRecorder{}
+	ruleID, sessionID, pipelineID := uuid.New(), uuid.New(), p.ID()
+	executor := NewAuditingActionExecutor(stubActionExecutor{}, recorder, stubPipelineLookup{p: p}, &defaultLogger{})
+
+	err = executor.Execute(context.Background(), pipeline.RuleAction{Type: pipeline.ActionAssignToQueue}, ActionContext{
+		SessionID:  &sessionID,
+		PipelineID: &pipelineID,
+		TenantID:   "tenant-1",
+		RuleID:     ruleID,
+		RuleName:   "Distribuir leads",
+		Trigger:    pipeline.TriggerSessionEscalated,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, recorder.records, 1)
+	record := recorder.records[0]
+	assert.Equal(t, audit.Actor{Type: audit.ActorAutomation, ID: ruleID.String(), Label: "Distribuir leads"}, record.Actor)
+	assert.Equal(t, "automations.assign_to_queue", record.Action)
+	assert.Equal(t, projectID, record.ProjectID)
+	assert.Equal(t, "session", record.ResourceType)
+	assert.Equal(t, sessionID.String(), record.ResourceID)
+	assert.Equal(t, "success", record.Metadata["result"])
+}
+
+func TestAuditingActionExecutor_RecordsFailures(t *testing.T) {
+	recorder := &recordingAuditRecorder{}
+	contactID := uuid.New()
+	executor := NewAuditingActionExecutor(stubActionExecutor{err: errors.New("queue not found")}, recorder, stubPipelineLookup{}, &defaultLogger{})
+
+	err := executor.Execute(context.Background(), pipeline.RuleAction{Type: pipeline.ActionAssignToQueue}, ActionContext{
+		ContactID: &contactID,
+		RuleID:    uuid.New(),
+	})
+
+	assert.EqualError(t, err, "queue not found")
+	require.Len(t, recorder.records, 1)
+	assert.Equal(t, "contact", recorder.records[0].ResourceType)
+	assert.Equal(t, "failed", recorder.records[0].Metadata["result"])
+	assert.Equal(t, "queue not found", recorder.records[0].Metadata["error"])
+}
@@ -14,9 +14,15 @@ import (
 type AutomationEngine struct {
 	ruleRepo       pipeline.AutomationRepository
 	actionExecutor ActionExecutor
+	businessHours  BusinessHoursChecker
 	logger         Logger
 }
 
+// BusinessHoursChecker resolve a condição embutida within_business_hours pelo expediente do projeto do pipeline
+type BusinessHoursChecker interface {
+	WithinBusinessHours(ctx context.Context, pipelineID uuid.UUID, at time.Time) (bool, error)
+}
+
 // Logger interface para logging
 type Logger interface {
 	Info(msg string, args ...interface{})
@@ -37,6 +43,7 @@ type ActionContext struct {
 	PipelineID *uuid.UUID // ponteiro pois pode ser opcional
 	TenantID   string
 	RuleID     uuid.UUID
+	RuleName   string
 	Trigger    pipeline.AutomationTrigger
 	Metadata   map[string]interface{}
 }
@@ -58,6 +65,11 @@ func NewAutomationEngine(
 	}
 }
 
+// SetBusinessHoursChecker habilita a condição within_business_hours (sem checker o campo fica ausente)
+func (e *AutomationEngine) SetBusinessHoursChecker(checker BusinessHoursChecker) {
+	e.businessHours = checker
+}
+
 // EvaluateAndExecute avalia e executa regras para um evento específico
 func (e *AutomationEngine) EvaluateAndExecute(
 	ctx context.Context,
@@ -88,6 +100,9 @@ func (e *AutomationEngine) EvaluateAndExecute(
 
 	e.logger.Info("evaluating follow-up rules", "pipelineID", pipelineID, "trigger", trigger, "count", len(activeRules))
 
+	// Expediente só é consultado se alguma regra usa a condição
+	e.resolveBusinessHours(ctx, pipelineID, activeRules, evalContext)
+
 	// Avalia e executa cada regra (ordenadas por priority)
 	executedCount := 0
 	for _, rule := range activeRules {
@@ -108,6 +123,44 @@ func (e *AutomationEngine) EvaluateAndExecute(
 	return nil
 }
 
+// resolveBusinessHours preenche within_business_hours no contexto de avaliação
+func (e *AutomationEngine) resolveBusinessHours(
+	ctx context.Context,
+	pipelineID uuid.UUID,
+	rules []*pipeline.Automation,
+	evalContext map[string]interface{},
+) {
+	if e.businessHours == nil {
+		return
+	}
+	if _, ok := evalContext[pipeline.ConditionWithinBusinessHours]; ok {
+		return
+	}
+
+	needed := false
+	for _, rule := range rules {
+		if rule.UsesField(pipeline.ConditionWithinBusinessHours) {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return
+	}
+
+	at, ok := evalContext["occurred_at"].(time.Time)
+	if !ok {
+		at = time.Now()
+	}
+	within, err := e.businessHours.WithinBusinessHours(ctx, pipelineID, at)
+	if err != nil {
+		// Sem o campo, condições que o usam não passam
+		e.logger.Error("failed to check business hours", "pipelineID", pipelineID, "error", err)
+		return
+	}
+	evalContext[pipeline.ConditionWithinBusinessHours] = within
+}
+
 // evaluateAndExecuteRule avalia e executa uma regra individual
 func (e *AutomationEngine) evaluateAndExecuteRule(
 	ctx context.Context,
@@ -125,6 +178,7 @@ func (e *AutomationEngine) evaluateAndExecuteRule(
 
 	// 2. Preenche contexto da ação
 	actionCtx.RuleID = rule.ID()
+	actionCtx.RuleName = rule.Name()
 	actionCtx.PipelineID = rule.PipelineID()
 	actionCtx.TenantID = rule.TenantID()
 
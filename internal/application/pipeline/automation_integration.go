@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/crm/pipeline"
 	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/task"
 )
 
 // AutomationIntegration integra Follow-up Rules com eventos do sistema
@@ -213,6 +214,100 @@ func (i *AutomationIntegration) OnMessageReceived(
 	)
 }
 
+// OnSLAWarning processa aviso de prazo de SLA próximo do vencimento
+func (i *AutomationIntegration) OnSLAWarning(ctx context.Context, sessionID uuid.UUID, metric string, dueAt time.Time) error {
+	return i.onSLAEvent(ctx, pipeline.TriggerSLAWarning, sessionID, metric, dueAt)
+}
+
+// OnSLABreached processa vencimento de prazo de SLA
+func (i *AutomationIntegration) OnSLABreached(ctx context.Context, sessionID uuid.UUID, metric string, dueAt time.Time) error {
+	return i.onSLAEvent(ctx, pipeline.TriggerSLABreached, sessionID, metric, dueAt)
+}
+
+func (i *AutomationIntegration) onSLAEvent(ctx context.Context, trigger pipeline.AutomationTrigger, sessionID uuid.UUID, metric string, dueAt time.Time) error {
+	sess, err := i.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("failed to get session: %w", err)
+	}
+
+	if sess.PipelineID() == nil {
+		return nil
+	}
+
+	metadata := map[string]interface{}{
+		"metric":        metric,
+		"due_at":        dueAt,
+		"priority":      sess.Priority().String(),
+		"escalated":     sess.IsEscalated(),
+		"message_count": sess.MessageCount(),
+	}
+	if agentID := sess.GetCurrentAgent(); agentID != nil {
+		metadata["agent_id"] = agentID.String()
+	}
+
+	return i.engine.ProcessSessionEvent(
+		ctx,
+		*sess.PipelineID(),
+		trigger,
+		sessionID,
+		sess.ContactID(),
+		uuid.Nil, // channelID não disponível no domain
+		sess.TenantID(),
+		metadata,
+	)
+}
+
+// OnTaskOverdue processa o atraso de uma tarefa. O pipeline é o do negócio da tarefa
+// ou, na falta dele, o da sessão; tarefas sem nenhum dos dois não disparam automações.
+func (i *AutomationIntegration) OnTaskOverdue(ctx context.Context, t *task.Task) error {
+	metadata := map[string]interface{}{
+		"task_id":  t.ID().String(),
+		"title":    t.Title(),
+		"priority": string(t.Priority()),
+		"due_at":   *t.DueAt(),
+	}
+	if t.AssigneeID() != nil {
+		metadata["assignee_id"] = t.AssigneeID().String()
+	}
+
+	if t.PipelineID() == nil && t.SessionID() != nil {
+		sess, err := i.sessionRepo.FindByID(ctx, *t.SessionID())
+		if err != nil {
+			return fmt.Errorf("failed to get session: %w", err)
+		}
+		if sess.PipelineID() == nil {
+			return nil
+		}
+		metadata["session_id"] = sess.ID().String()
+		return i.engine.ProcessSessionEvent(
+			ctx,
+			*sess.PipelineID(),
+			pipeline.TriggerTaskOverdue,
+			sess.ID(),
+			sess.ContactID(),
+			uuid.Nil, // channelID não disponível no domain
+			sess.TenantID(),
+			metadata,
+		)
+	}
+
+	if t.PipelineID() == nil || t.ContactID() == nil {
+		i.logger.Debug("task has no pipeline, skipping task.overdue automations", "taskID", t.ID())
+		return nil
+	}
+	if t.SessionID() != nil {
+		metadata["session_id"] = t.SessionID().String()
+	}
+	return i.engine.ProcessContactEvent(
+		ctx,
+		*t.PipelineID(),
+		pipeline.TriggerTaskOverdue,
+		*t.ContactID(),
+		t.TenantID(),
+		metadata,
+	)
+}
+
 // OnStatusChanged processa trigger de mudança de status
 func (i *AutomationIntegration) OnStatusChanged(
 	ctx context.Context,
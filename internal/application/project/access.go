@@ -0,0 +1,236 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+// DefaultAccessCacheTTL prazo máximo de uma entrada do cache de acesso. As alterações de papel
+// invalidam o projeto na hora; o prazo só cobre uma invalidação perdida.
+const DefaultAccessCacheTTL = time.Minute
+
+// ErrNoProjectAccess o usuário não é dono nem membro do projeto (ou o projeto não existe)
+var ErrNoProjectAccess = errors.New("no access to project")
+
+// Access acesso efetivo de um usuário a um projeto
+type Access struct {
+	ProjectID uuid.UUID
+	TenantID  string
+	Role      project_member.ProjectMemberRole
+	// Owner dono do projeto (customer_id): admin mesmo sem linha em project_members
+	Owner       bool
+	Permissions []project_member.Permission
+	// RequiresTwoFactor o projeto exige sessão verificada com o segundo fator
+	RequiresTwoFactor bool
+}
+
+// HasPermission indica se o papel concede a permissão
+func (a *Access) HasPermission(permission project_member.Permission) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// AccessInvalidator descarta o acesso em cache de um projeto depois de mudanças de papel,
+// membros, dono ou da exigência de 2FA (em todas as réplicas, quando houver Redis)
+type AccessInvalidator interface {
+	InvalidateProject(ctx context.Context, projectID uuid.UUID)
+}
+
+type projectLookup interface {
+	FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error)
+}
+
+type memberLookup interface {
+	FindByProjectAndAgent(ctx context.Context, projectID uuid.UUID, agentID string) (*project_member.ProjectMember, error)
+}
+
+type roleLookup interface {
+	FindByKey(ctx context.Context, projectID uuid.UUID, key project_member.ProjectMemberRole) (*project_member.CustomRole, error)
+}
+
+// projectAccessEntry o que já foi resolvido de um projeto: dono, papel de cada usuário consultado
+// (vazio = não é membro) e permissões dos papéis custom
+type projectAccessEntry struct {
+	tenantID         string
+	ownerID          uuid.UUID
+	requireTwoFactor bool
+	members          map[uuid.UUID]project_member.ProjectMemberRole
+	roles            map[project_member.ProjectMemberRole][]project_member.Permission
+	loadedAt         time.Time
+}
+
+// AccessResolver resolve papel e permissões de um usuário no projeto. É o ponto único usado pelo
+// RBAC das rotas HTTP e pelo WebSocket, com cache por projeto.
+type AccessResolver struct {
+	projects projectLookup
+	members  memberLookup
+	roles    roleLookup
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu    sync.Mutex
+	cache map[uuid.UUID]*projectAccessEntry
+}
+
+func NewAccessResolver(projects projectLookup, members memberLookup, roles roleLookup, ttl time.Duration) *AccessResolver {
+	if ttl <= 0 {
+		ttl = DefaultAccessCacheTTL
+	}
+	return &AccessResolver{
+		projects: projects,
+		members:  members,
+		roles:    roles,
+		ttl:      ttl,
+		now:      time.Now,
+		cache:    make(map[uuid.UUID]*projectAccessEntry),
+	}
+}
+
+// Resolve acesso do usuário ao projeto; ErrNoProjectAccess se ele não participa
+func (r *AccessResolver) Resolve(ctx context.Context, projectID, userID uuid.UUID) (*Access, error) {
+	entry, err := r.project(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	if entry.ownerID == userID {
+		return &Access{
+			ProjectID:         projectID,
+			TenantID:          entry.tenantID,
+			Role:              project_member.RoleAdmin,
+			Owner:             true,
+			Permissions:       project_member.GetRolePermissions(project_member.RoleAdmin),
+			RequiresTwoFactor: entry.requireTwoFactor,
+		}, nil
+	}
+
+	role, err := r.memberRole(ctx, entry, projectID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if role == "" {
+		return nil, ErrNoProjectAccess
+	}
+	permissions, err := r.RolePermissions(ctx, projectID, role)
+	if err != nil {
+		return nil, err
+	}
+	return &Access{
+		ProjectID:         projectID,
+		TenantID:          entry.tenantID,
+		Role:              role,
+		Permissions:       permissions,
+		RequiresTwoFactor: entry.requireTwoFactor,
+	}, nil
+}
+
+// RolePermissions permissões de um papel padrão ou custom do projeto (papel custom removido não
+// concede nada)
+func (r *AccessResolver) RolePermissions(ctx context.Context, projectID uuid.UUID, role project_member.ProjectMemberRole) ([]project_member.Permission, error) {
+	if !project_member.IsCustomRole(role) {
+		return project_member.GetRolePermissions(role), nil
+	}
+
+	r.mu.Lock()
+	if entry, ok := r.cache[projectID]; ok && r.fresh(entry) {
+		if permissions, ok := entry.roles[role]; ok {
+			r.mu.Unlock()
+			return permissions, nil
+		}
+	}
+	r.mu.Unlock()
+
+	var permissions []project_member.Permission
+	custom, err := r.roles.FindByKey(ctx, projectID, role)
+	switch {
+	case errors.Is(err, project_member.ErrCustomRoleNotFound):
+		permissions = []project_member.Permission{}
+	case err != nil:
+		return nil, fmt.Errorf("failed to load project role: %w", err)
+	default:
+		permissions = custom.Permissions()
+	}
+
+	r.mu.Lock()
+	if entry, ok := r.cache[projectID]; ok && r.fresh(entry) {
+		entry.roles[role] = permissions
+	}
+	r.mu.Unlock()
+	return permissions, nil
+}
+
+// InvalidateProject descarta o que está em cache do projeto nesta réplica
+func (r *AccessResolver) InvalidateProject(ctx context.Context, projectID uuid.UUID) {
+	r.mu.Lock()
+	delete(r.cache, projectID)
+	r.mu.Unlock()
+}
+
+func (r *AccessResolver) project(ctx context.Context, projectID uuid.UUID) (*projectAccessEntry, error) {
+	r.mu.Lock()
+	entry, ok := r.cache[projectID]
+	if ok && r.fresh(entry) {
+		r.mu.Unlock()
+		return entry, nil
+	}
+	r.mu.Unlock()
+
+	p, err := r.projects.FindByID(ctx, projectID)
+	if errors.Is(err, project.ErrProjectNotFound) {
+		return nil, ErrNoProjectAccess
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
+	}
+
+	entry = &projectAccessEntry{
+		tenantID:         p.TenantID(),
+		ownerID:          p.CustomerID(),
+		requireTwoFactor: p.RequiresTwoFactor(),
+		members:          make(map[uuid.UUID]project_member.ProjectMemberRole),
+		roles:            make(map[project_member.ProjectMemberRole][]project_member.Permission),
+		loadedAt:         r.now(),
+	}
+	r.mu.Lock()
+	r.cache[projectID] = entry
+	r.mu.Unlock()
+	return entry, nil
+}
+
+func (r *AccessResolver) memberRole(ctx context.Context, entry *projectAccessEntry, projectID, userID uuid.UUID) (project_member.ProjectMemberRole, error) {
+	r.mu.Lock()
+	role, ok := entry.members[userID]
+	r.mu.Unlock()
+	if ok {
+		return role, nil
+	}
+
+	member, err := r.members.FindByProjectAndAgent(ctx, projectID, userID.String())
+	switch {
+	case errors.Is(err, project_member.ErrMemberNotFound):
+		role = ""
+	case err != nil:
+		return "", fmt.Errorf("failed to load project member: %w", err)
+	default:
+		role = member.Role()
+	}
+
+	r.mu.Lock()
+	entry.members[userID] = role
+	r.mu.Unlock()
+	return role, nil
+}
+
+func (r *AccessResolver) fresh(entry *projectAccessEntry) bool {
+	return r.now().Sub(entry.loadedAt) < r.ttl
+}
@@ -0,0 +1,159 @@
+package project
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+type accessFixture struct {
+	resolver *AccessResolver
+	projects *fakeProjectRepository
+	members  *fakeMemberRepository
+	roles    *fakeRoleRepository
+	project  uuid.UUID
+	ownerID  uuid.UUID
+	now      time.Time
+}
+
+func newAccessFixture(t *testing.T) *accessFixture {
+	t.Helper()
+	ownerID := uuid.New()
+	p := newTestProject(t, ownerID, "Access")
+
+	f := &accessFixture{
+		projects: newFakeProjectRepository(p),
+		members:  newFakeMemberRepository(),
+		roles:    newFakeRoleRepository(),
+		project:  p.ID(),
+		ownerID:  ownerID,
+		now:      time.Now(),
+	}
+	f.resolver = NewAccessResolver(f.projects, f.members, f.roles, time.Minute)
+	f.resolver.now = func() time.Time { return f.now }
+	return f
+}
+
+func (f *accessFixture) addMember(t *testing.T, role project_member.ProjectMemberRole) uuid.UUID {
+	t.Helper()
+	userID := uuid.New()
+	member, err := project_member.NewProjectMember(f.project, userID.String(), role, f.ownerID.String())
+	require.NoError(t, err)
+	require.NoError(t, f.members.Save(context.Background(), member))
+	return userID
+}
+
+func TestAccessResolver_Resolve(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	projectID := f.project
+
+	owner, err := f.resolver.Resolve(ctx, projectID, f.ownerID)
+	require.NoError(t, err)
+	assert.True(t, owner.Owner)
+	assert.Equal(t, project_member.RoleAdmin, owner.Role)
+	assert.True(t, owner.HasPermission(project_member.PermissionManageMembers))
+	assert.Equal(t, "tenant-access", owner.TenantID)
+
+	agentID := f.addMember(t, project_member.RoleAgent)
+	agent, err := f.resolver.Resolve(ctx, projectID, agentID)
+	require.NoError(t, err)
+	assert.False(t, agent.Owner)
+	assert.True(t, agent.HasPermission(project_member.PermissionSendMessages))
+	assert.False(t, agent.HasPermission(project_member.PermissionManageMembers))
+
+	_, err = f.resolver.Resolve(ctx, projectID, uuid.New())
+	assert.ErrorIs(t, err, ErrNoProjectAccess)
+	_, err = f.resolver.Resolve(ctx, uuid.New(), f.ownerID)
+	assert.ErrorIs(t, err, ErrNoProjectAccess)
+}
+
+func TestAccessResolver_CustomRole(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	projectID := f.project
+
+	exporter, err := project_member.NewCustomRole(projectID, "Exporter", "",
+		[]project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionExportContacts}, "", f.ownerID.String())
+	require.NoError(t, err)
+	require.NoError(t, f.roles.Save(ctx, exporter))
+	userID := f.addMember(t, exporter.Key())
+
+	access, err := f.resolver.Resolve(ctx, projectID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, exporter.Key(), access.Role)
+	assert.True(t, access.HasPermission(project_member.PermissionExportContacts))
+	assert.False(t, access.HasPermission(project_member.PermissionViewSessions))
+
+	// Em cache: nem o papel é buscado de novo
+	lookups := f.roles.keyLookups
+	_, err = f.resolver.Resolve(ctx, projectID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, lookups, f.roles.keyLookups)
+
+	// Papel removido não concede nada
+	require.NoError(t, f.roles.Delete(ctx, projectID, exporter.ID()))
+	f.resolver.InvalidateProject(ctx, projectID)
+	access, err = f.resolver.Resolve(ctx, projectID, userID)
+	require.NoError(t, err)
+	assert.Empty(t, access.Permissions)
+}
+
+func TestAccessResolver_Invalidation(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	projectID := f.project
+	userID := uuid.New()
+
+	// Quem não é membro também fica em cache
+	_, err := f.resolver.Resolve(ctx, projectID, userID)
+	require.ErrorIs(t, err, ErrNoProjectAccess)
+
+	member, err := project_member.NewProjectMember(projectID, userID.String(), project_member.RoleViewer, f.ownerID.String())
+	require.NoError(t, err)
+	require.NoError(t, f.members.Save(ctx, member))
+	_, err = f.resolver.Resolve(ctx, projectID, userID)
+	assert.ErrorIs(t, err, ErrNoProjectAccess)
+
+	f.resolver.InvalidateProject(ctx, projectID)
+	access, err := f.resolver.Resolve(ctx, projectID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, project_member.RoleViewer, access.Role)
+
+	// Troca de papel sem invalidação vale depois do TTL
+	require.NoError(t, member.ChangeRole(project_member.RoleSupervisor, f.ownerID.String()))
+	access, err = f.resolver.Resolve(ctx, projectID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, project_member.RoleViewer, access.Role)
+
+	f.now = f.now.Add(2 * time.Minute)
+	access, err = f.resolver.Resolve(ctx, projectID, userID)
+	require.NoError(t, err)
+	assert.Equal(t, project_member.RoleSupervisor, access.Role)
+}
+
+func TestAccessResolver_RequiresTwoFactor(t *testing.T) {
+	ctx := context.Background()
+	f := newAccessFixture(t)
+	agentID := f.addMember(t, project_member.RoleAgent)
+
+	access, err := f.resolver.Resolve(ctx, f.project, agentID)
+	require.NoError(t, err)
+	assert.False(t, access.RequiresTwoFactor)
+
+	p, err := f.projects.FindByID(ctx, f.project)
+	require.NoError(t, err)
+	p.SetRequireTwoFactor(true)
+	f.resolver.InvalidateProject(ctx, f.project)
+
+	for _, userID := range []uuid.UUID{f.ownerID, agentID} {
+		access, err = f.resolver.Resolve(ctx, f.project, userID)
+		require.NoError(t, err)
+		assert.True(t, access.RequiresTwoFactor)
+	}
+}
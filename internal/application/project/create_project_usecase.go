@@ -64,7 +64,7 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, req CreateProjectRe
 	// Check if project with tenant ID already exists
 	existingProject, err := uc.projectRepo.FindByTenantID(ctx, req.TenantID)
 	if err == nil && existingProject != nil {
-		return nil, fmt.Errorf("project with tenant ID %s already exists", req.TenantID)
+		return nil, shared.NewConflictError(fmt.Sprintf("project with tenant ID %s already exists", req.TenantID))
 	}
 	if err != nil && err != project.ErrProjectNotFound {
 		return nil, fmt.Errorf("failed to check existing project: %w", err)
@@ -78,7 +78,7 @@ func (uc *CreateProjectUseCase) Execute(ctx context.Context, req CreateProjectRe
 		req.Name,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create project: %w", err)
+		return nil, shared.NewValidationError(err.Error(), "")
 	}
 
 	// Set optional fields
@@ -0,0 +1,438 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// EmailSender envio de emails transacionais (nil desabilita o envio dos convites)
+type EmailSender interface {
+	Send(ctx context.Context, to []string, subject, body string) error
+}
+
+// InvitationConfig validade dos convites e página do frontend que recebe o token
+type InvitationConfig struct {
+	TTL time.Duration
+	// AcceptURL recebe ?token=...; vazio manda no email só o token e a rota da API
+	AcceptURL string
+}
+
+// InvitationStatusExpired convite pendente cujo prazo passou (só existe na listagem)
+const InvitationStatusExpired = "expired"
+
+// InvitationView convite exposto pela API (nunca inclui o token nem o hash)
+type InvitationView struct {
+	ID          uuid.UUID                        `json:"id"`
+	ProjectID   uuid.UUID                        `json:"project_id"`
+	Email       string                           `json:"email"`
+	Role        project_member.ProjectMemberRole `json:"role"`
+	Status      string                           `json:"status"`
+	InvitedBy   string                           `json:"invited_by"`
+	ExpiresAt   time.Time                        `json:"expires_at"`
+	AcceptedBy  string                           `json:"accepted_by,omitempty"`
+	RespondedAt *time.Time                       `json:"responded_at,omitempty"`
+	CreatedAt   time.Time                        `json:"created_at"`
+}
+
+// CreatedInvitationView convite recém-criado. O token só volta na resposta quando o email não
+// pôde ser enviado, para quem convidou repassar por outro meio.
+type CreatedInvitationView struct {
+	InvitationView
+	EmailSent bool   `json:"email_sent"`
+	Token     string `json:"token,omitempty"`
+}
+
+func newInvitationView(inv *project_member.Invitation, now time.Time) InvitationView {
+	status := string(inv.Status())
+	if inv.Status() == project_member.InvitationPending && inv.IsExpired(now) {
+		status = InvitationStatusExpired
+	}
+	return InvitationView{
+		ID:          inv.ID(),
+		ProjectID:   inv.ProjectID(),
+		Email:       inv.Email(),
+		Role:        inv.Role(),
+		Status:      status,
+		InvitedBy:   inv.InvitedBy(),
+		ExpiresAt:   inv.ExpiresAt(),
+		AcceptedBy:  inv.AcceptedBy(),
+		RespondedAt: inv.RespondedAt(),
+		CreatedAt:   inv.CreatedAt(),
+	}
+}
+
+// InviteMemberCommand convite de um email para o projeto
+type InviteMemberCommand struct {
+	ProjectID   uuid.UUID
+	Email       string
+	Role        project_member.ProjectMemberRole
+	InvitedBy   uuid.UUID
+	InviterName string
+	// GrantablePermissions permissões de quem convida: o papel do convite não pode ir além delas
+	// (nil = sem limite, dono ou admin)
+	GrantablePermissions []project_member.Permission
+}
+
+// AcceptInvitationCommand aceite do convite pelo usuário logado
+type AcceptInvitationCommand struct {
+	Token  string
+	UserID uuid.UUID
+	Email  string
+}
+
+// ManageInvitationsUseCase convites por email com token e validade: criação, listagem,
+// revogação, aceite e recusa
+type ManageInvitationsUseCase struct {
+	invitations project_member.InvitationRepository
+	members     project_member.Repository
+	roles       project_member.CustomRoleRepository
+	access      AccessInvalidator
+	projects    project.Repository
+	users       UserDirectory
+	email       EmailSender
+	config      InvitationConfig
+	eventBus    EventBus
+	txManager   TransactionManager
+	logger      *zap.Logger
+	now         func() time.Time
+}
+
+func NewManageInvitationsUseCase(
+	invitations project_member.InvitationRepository,
+	members project_member.Repository,
+	roles project_member.CustomRoleRepository,
+	access AccessInvalidator,
+	projects project.Repository,
+	users UserDirectory,
+	email EmailSender,
+	config InvitationConfig,
+	eventBus EventBus,
+	txManager TransactionManager,
+	logger *zap.Logger,
+) *ManageInvitationsUseCase {
+	if config.TTL <= 0 {
+		config.TTL = project_member.DefaultInvitationTTL
+	}
+	return &ManageInvitationsUseCase{
+		invitations: invitations,
+		members:     members,
+		roles:       roles,
+		access:      access,
+		projects:    projects,
+		users:       users,
+		email:       email,
+		config:      config,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// Invite cria o convite e envia o token por email. Um convite pendente já vencido para o mesmo
+// email é revogado e substituído; um ainda válido impede o novo (revogue antes de reenviar).
+func (uc *ManageInvitationsUseCase) Invite(ctx context.Context, cmd InviteMemberCommand) (*CreatedInvitationView, error) {
+	p, err := loadProject(ctx, uc.projects, cmd.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	email, err := project_member.NormalizeInvitationEmail(cmd.Email)
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "email")
+	}
+	permissions, err := rolePermissions(ctx, uc.roles, cmd.ProjectID, cmd.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureGrantable(cmd.GrantablePermissions, permissions); err != nil {
+		return nil, err
+	}
+	if err := uc.ensureNotMember(ctx, p, email); err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	var replaced *project_member.Invitation
+	existing, err := uc.invitations.FindPendingByEmail(ctx, cmd.ProjectID, email)
+	switch {
+	case errors.Is(err, project_member.ErrInvitationNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to load pending invitation: %w", err)
+	case existing.IsOpen(now):
+		return nil, shared.NewConflictError(project_member.ErrInvitationPending.Error())
+	default:
+		if err := existing.Revoke(now); err != nil {
+			return nil, invitationError(err)
+		}
+		replaced = existing
+	}
+
+	inv, token, err := project_member.NewInvitation(cmd.ProjectID, email, cmd.Role, cmd.InvitedBy.String(), uc.config.TTL)
+	if err != nil {
+		return nil, invitationError(err)
+	}
+
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if replaced != nil {
+			if err := uc.invitations.Save(txCtx, replaced); err != nil {
+				return fmt.Errorf("failed to revoke expired invitation: %w", err)
+			}
+		}
+		if err := uc.invitations.Save(txCtx, inv); err != nil {
+			return fmt.Errorf("failed to save invitation: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, inv.DomainEvents())
+	})
+	if err != nil {
+		return nil, err
+	}
+	inv.ClearEvents()
+
+	view := &CreatedInvitationView{InvitationView: newInvitationView(inv, now)}
+	view.EmailSent = uc.sendInvitation(ctx, p, inv, token, cmd.InviterName)
+	if !view.EmailSent {
+		view.Token = token
+	}
+
+	uc.logger.Info("Project invitation created",
+		zap.String("project_id", cmd.ProjectID.String()),
+		zap.String("invitation_id", inv.ID().String()),
+		zap.String("role", string(inv.Role())),
+		zap.Bool("email_sent", view.EmailSent))
+	return view, nil
+}
+
+// List convites do projeto (status vazio = todos; "expired" = pendentes vencidos)
+func (uc *ManageInvitationsUseCase) List(ctx context.Context, projectID uuid.UUID, status string) ([]InvitationView, error) {
+	filter := project_member.InvitationStatus(status)
+	if status == InvitationStatusExpired {
+		filter = project_member.InvitationPending
+	}
+	invitations, err := uc.invitations.FindByProject(ctx, projectID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	views := make([]InvitationView, 0, len(invitations))
+	for _, inv := range invitations {
+		view := newInvitationView(inv, now)
+		if status == InvitationStatusExpired && view.Status != InvitationStatusExpired {
+			continue
+		}
+		if status == string(project_member.InvitationPending) && view.Status == InvitationStatusExpired {
+			continue
+		}
+		views = append(views, view)
+	}
+	return views, nil
+}
+
+// Revoke cancela um convite pendente; o token deixa de valer na hora
+func (uc *ManageInvitationsUseCase) Revoke(ctx context.Context, projectID, invitationID uuid.UUID) (*InvitationView, error) {
+	inv, err := uc.invitations.FindByID(ctx, projectID, invitationID)
+	if errors.Is(err, project_member.ErrInvitationNotFound) {
+		return nil, shared.NewNotFoundError("invitation", invitationID.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invitation: %w", err)
+	}
+
+	now := uc.now()
+	if err := inv.Revoke(now); err != nil {
+		return nil, invitationError(err)
+	}
+	if err := uc.invitations.Save(ctx, inv); err != nil {
+		return nil, fmt.Errorf("failed to save invitation: %w", err)
+	}
+	view := newInvitationView(inv, now)
+	return &view, nil
+}
+
+// Accept aceita o convite: o usuário logado entra no projeto com o papel convidado. O email da
+// conta precisa ser o mesmo do convite.
+func (uc *ManageInvitationsUseCase) Accept(ctx context.Context, cmd AcceptInvitationCommand) (*InvitationView, error) {
+	inv, err := uc.findByToken(ctx, cmd.Token)
+	if err != nil {
+		return nil, err
+	}
+	p, err := loadProject(ctx, uc.projects, inv.ProjectID())
+	if err != nil {
+		return nil, err
+	}
+	if p.CustomerID() == cmd.UserID {
+		return nil, shared.NewConflictError(project_member.ErrMemberAlreadyExists.Error())
+	}
+	exists, err := uc.members.ExistsInProject(ctx, inv.ProjectID(), cmd.UserID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to check membership: %w", err)
+	}
+	if exists {
+		return nil, shared.NewConflictError(project_member.ErrMemberAlreadyExists.Error())
+	}
+
+	now := uc.now()
+	if err := inv.Accept(cmd.UserID.String(), cmd.Email, now); err != nil {
+		return nil, invitationError(err)
+	}
+	member, err := project_member.NewProjectMember(inv.ProjectID(), cmd.UserID.String(), inv.Role(), inv.InvitedBy())
+	if err != nil {
+		return nil, memberError(err)
+	}
+
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.invitations.Save(txCtx, inv); err != nil {
+			return fmt.Errorf("failed to save invitation: %w", err)
+		}
+		if err := uc.members.Save(txCtx, member); err != nil {
+			return fmt.Errorf("failed to save member: %w", err)
+		}
+		if err := publishEvents(txCtx, uc.eventBus, inv.DomainEvents()); err != nil {
+			return err
+		}
+		return publishEvents(txCtx, uc.eventBus, member.DomainEvents())
+	})
+	if err != nil {
+		return nil, err
+	}
+	inv.ClearEvents()
+	member.ClearEvents()
+	invalidateAccess(ctx, uc.access, inv.ProjectID())
+
+	uc.logger.Info("Project invitation accepted",
+		zap.String("project_id", inv.ProjectID().String()),
+		zap.String("invitation_id", inv.ID().String()),
+		zap.String("user_id", cmd.UserID.String()))
+
+	view := newInvitationView(inv, now)
+	return &view, nil
+}
+
+// Decline recusa o convite; basta o token, sem conta na plataforma
+func (uc *ManageInvitationsUseCase) Decline(ctx context.Context, token string) (*InvitationView, error) {
+	inv, err := uc.findByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	now := uc.now()
+	if err := inv.Decline(now); err != nil {
+		return nil, invitationError(err)
+	}
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.invitations.Save(txCtx, inv); err != nil {
+			return fmt.Errorf("failed to save invitation: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, inv.DomainEvents())
+	})
+	if err != nil {
+		return nil, err
+	}
+	inv.ClearEvents()
+
+	view := newInvitationView(inv, now)
+	return &view, nil
+}
+
+func (uc *ManageInvitationsUseCase) findByToken(ctx context.Context, token string) (*project_member.Invitation, error) {
+	token = strings.TrimSpace(token)
+	if !strings.HasPrefix(token, project_member.InvitationTokenPrefix) {
+		return nil, shared.NewValidationError("invalid invitation token", "token")
+	}
+	inv, err := uc.invitations.FindByTokenHash(ctx, project_member.HashInvitationToken(token))
+	if errors.Is(err, project_member.ErrInvitationNotFound) {
+		// Mesma resposta para token inexistente e de outro projeto: não revela convites
+		return nil, shared.NewNotFoundError("invitation", "token")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load invitation: %w", err)
+	}
+	return inv, nil
+}
+
+// ensureNotMember recusa convite para quem já está no projeto (dono ou membro)
+func (uc *ManageInvitationsUseCase) ensureNotMember(ctx context.Context, p *project.Project, email string) error {
+	if uc.users == nil {
+		return nil
+	}
+	u, err := uc.users.FindUserByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to look up user: %w", err)
+	}
+	if u == nil {
+		return nil
+	}
+	if u.ID == p.CustomerID() {
+		return shared.NewConflictError(project_member.ErrMemberAlreadyExists.Error())
+	}
+	exists, err := uc.members.ExistsInProject(ctx, p.ID(), u.ID.String())
+	if err != nil {
+		return fmt.Errorf("failed to check membership: %w", err)
+	}
+	if exists {
+		return shared.NewConflictError(project_member.ErrMemberAlreadyExists.Error())
+	}
+	return nil
+}
+
+// sendInvitation envia o email com o token; falha no envio não desfaz o convite
+func (uc *ManageInvitationsUseCase) sendInvitation(ctx context.Context, p *project.Project, inv *project_member.Invitation, token, inviterName string) bool {
+	if uc.email == nil {
+		return false
+	}
+
+	inviter := inviterName
+	if inviter == "" {
+		inviter = "A team member"
+	}
+	subject := fmt.Sprintf("You have been invited to %s", p.Name())
+
+	var body strings.Builder
+	fmt.Fprintf(&body, "%s invited you to join the project %q as %s.\n\n", inviter, p.Name(), inv.Role())
+	if uc.config.AcceptURL != "" {
+		link := uc.config.AcceptURL
+		if strings.Contains(link, "?") {
+			link += "&"
+		} else {
+			link += "?"
+		}
+		fmt.Fprintf(&body, "Accept or decline the invitation: %stoken=%s\n\n", link, url.QueryEscape(token))
+	} else {
+		fmt.Fprintf(&body, "Invitation token: %s\n", token)
+		fmt.Fprintf(&body, "Accept it with POST /api/v1/invitations/accept (signed in with this email) or decline it with POST /api/v1/invitations/decline.\n\n")
+	}
+	fmt.Fprintf(&body, "The invitation expires on %s.\n", inv.ExpiresAt().UTC().Format(time.RFC1123))
+
+	if err := uc.email.Send(ctx, []string{inv.Email()}, subject, body.String()); err != nil {
+		uc.logger.Warn("Failed to send project invitation",
+			zap.String("invitation_id", inv.ID().String()),
+			zap.Error(err))
+		return false
+	}
+	return true
+}
+
+// invitationError traduz os erros do domínio dos convites para erros da aplicação
+func invitationError(err error) error {
+	switch {
+	case errors.Is(err, project_member.ErrInvalidInvitationEmail):
+		return shared.NewValidationError(err.Error(), "email")
+	case errors.Is(err, project_member.ErrInvalidRole):
+		return shared.NewValidationError(err.Error(), "role")
+	case errors.Is(err, project_member.ErrInvitationEmailMatch):
+		return shared.NewForbiddenError(err.Error())
+	case errors.Is(err, project_member.ErrInvitationNotPending), errors.Is(err, project_member.ErrInvitationExpired):
+		return shared.NewPreconditionError(err.Error())
+	}
+	return shared.NewValidationError(err.Error(), "")
+}
@@ -0,0 +1,256 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+var invitationTokenPattern = regexp.MustCompile(`vti_[A-Za-z0-9_-]+`)
+
+type invitationsFixture struct {
+	uc          *ManageInvitationsUseCase
+	project     *project.Project
+	owner       UserSummary
+	users       *fakeUserDirectory
+	members     *fakeMemberRepository
+	invitations *fakeInvitationRepository
+	roles       *fakeRoleRepository
+	access      *recordingInvalidator
+	email       *fakeEmailSender
+	eventBus    *recordingEventBus
+}
+
+func newInvitationsFixture(t *testing.T, config InvitationConfig) *invitationsFixture {
+	t.Helper()
+	users := &fakeUserDirectory{}
+	owner := users.add("Owner", "owner@example.com")
+	p := newTestProject(t, owner.ID, "Support")
+
+	f := &invitationsFixture{
+		project:     p,
+		owner:       owner,
+		users:       users,
+		members:     newFakeMemberRepository(),
+		invitations: &fakeInvitationRepository{},
+		roles:       newFakeRoleRepository(),
+		access:      &recordingInvalidator{},
+		email:       &fakeEmailSender{},
+		eventBus:    &recordingEventBus{},
+	}
+	f.uc = NewManageInvitationsUseCase(f.invitations, f.members, f.roles, f.access, newFakeProjectRepository(p), users, f.email,
+		config, f.eventBus, &SimpleTransactionManager{}, zap.NewNop())
+	return f
+}
+
+func (f *invitationsFixture) invite(t *testing.T, email string) *CreatedInvitationView {
+	t.Helper()
+	view, err := f.uc.Invite(context.Background(), InviteMemberCommand{
+		ProjectID: f.project.ID(), Email: email, Role: project_member.RoleAgent, InvitedBy: f.owner.ID, InviterName: "Owner",
+	})
+	require.NoError(t, err)
+	return view
+}
+
+// tokenFromEmail extrai o token do último email enviado (o token não é guardado em claro)
+func (f *invitationsFixture) tokenFromEmail(t *testing.T) string {
+	t.Helper()
+	require.NotEmpty(t, f.email.sent)
+	token := invitationTokenPattern.FindString(f.email.sent[len(f.email.sent)-1].body)
+	require.NotEmpty(t, token)
+	return token
+}
+
+func TestManageInvitations_Invite(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sends the token by email", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{AcceptURL: "https://app.example.com/invite"})
+		view := f.invite(t, "Maria@Example.com")
+
+		assert.True(t, view.EmailSent)
+		assert.Empty(t, view.Token)
+		assert.Equal(t, "maria@example.com", view.Email)
+		assert.Equal(t, string(project_member.InvitationPending), view.Status)
+
+		require.Len(t, f.email.sent, 1)
+		assert.Equal(t, []string{"maria@example.com"}, f.email.sent[0].to)
+		assert.Contains(t, f.email.sent[0].body, "https://app.example.com/invite?token=vti_")
+		assert.Equal(t, []string{"project_invitation.created"}, f.eventBus.eventTypes())
+	})
+
+	t.Run("returns the token when the email fails", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{})
+		f.email.err = errors.New("smtp down")
+		view := f.invite(t, "maria@example.com")
+
+		assert.False(t, view.EmailSent)
+		require.NotEmpty(t, view.Token)
+		assert.Equal(t, project_member.HashInvitationToken(view.Token), f.invitations.invitations[0].TokenHash())
+	})
+
+	t.Run("rejects members and duplicate invitations", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{})
+		agent := f.users.add("Agent", "agent@example.com")
+		member, err := project_member.NewProjectMember(f.project.ID(), agent.ID.String(), project_member.RoleAgent, f.owner.ID.String())
+		require.NoError(t, err)
+		require.NoError(t, f.members.Save(ctx, member))
+
+		for _, email := range []string{"owner@example.com", "AGENT@example.com"} {
+			_, err := f.uc.Invite(ctx, InviteMemberCommand{ProjectID: f.project.ID(), Email: email, Role: project_member.RoleAgent, InvitedBy: f.owner.ID})
+			assert.Equal(t, shared.ErrorTypeConflict, errorType(err), email)
+		}
+
+		f.invite(t, "maria@example.com")
+		_, err = f.uc.Invite(ctx, InviteMemberCommand{ProjectID: f.project.ID(), Email: "maria@example.com", Role: project_member.RoleAgent, InvitedBy: f.owner.ID})
+		assert.Equal(t, shared.ErrorTypeConflict, errorType(err))
+
+		_, err = f.uc.Invite(ctx, InviteMemberCommand{ProjectID: f.project.ID(), Email: "nope", Role: project_member.RoleAgent, InvitedBy: f.owner.ID})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	t.Run("accepts only custom roles of the project", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{})
+		_, err := f.uc.Invite(ctx, InviteMemberCommand{ProjectID: f.project.ID(), Email: "maria@example.com", Role: "custom:sales", InvitedBy: f.owner.ID})
+		assert.True(t, shared.IsValidationError(err))
+
+		sales, err := project_member.NewCustomRole(f.project.ID(), "Sales", "", []project_member.Permission{project_member.PermissionViewContacts}, "", f.owner.ID.String())
+		require.NoError(t, err)
+		require.NoError(t, f.roles.Save(ctx, sales))
+
+		view, err := f.uc.Invite(ctx, InviteMemberCommand{ProjectID: f.project.ID(), Email: "maria@example.com", Role: sales.Key(), InvitedBy: f.owner.ID})
+		require.NoError(t, err)
+		assert.Equal(t, sales.Key(), view.Role)
+	})
+
+	t.Run("limits the role to the permissions of the inviter", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{})
+		grantable := project_member.GetRolePermissions(project_member.RoleSupervisor)
+
+		_, err := f.uc.Invite(ctx, InviteMemberCommand{
+			ProjectID: f.project.ID(), Email: "maria@example.com", Role: project_member.RoleAdmin, InvitedBy: f.owner.ID, GrantablePermissions: grantable,
+		})
+		assert.Equal(t, shared.ErrorTypeForbidden, errorType(err))
+		assert.Empty(t, f.invitations.invitations)
+
+		view, err := f.uc.Invite(ctx, InviteMemberCommand{
+			ProjectID: f.project.ID(), Email: "maria@example.com", Role: project_member.RoleAgent, InvitedBy: f.owner.ID, GrantablePermissions: grantable,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, project_member.RoleAgent, view.Role)
+	})
+
+	t.Run("replaces an expired invitation", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{TTL: time.Hour})
+		first := f.invite(t, "maria@example.com")
+
+		f.uc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		second := f.invite(t, "maria@example.com")
+		assert.NotEqual(t, first.ID, second.ID)
+
+		revoked, err := f.invitations.FindByID(ctx, f.project.ID(), first.ID)
+		require.NoError(t, err)
+		assert.Equal(t, project_member.InvitationRevoked, revoked.Status())
+	})
+}
+
+func TestManageInvitations_List(t *testing.T) {
+	ctx := context.Background()
+	f := newInvitationsFixture(t, InvitationConfig{})
+	f.invite(t, "open@example.com")
+	createdAt := time.Now().Add(-48 * time.Hour)
+	expiredInv := project_member.ReconstructInvitation(uuid.New(), f.project.ID(), "expired@example.com", project_member.RoleViewer,
+		"hash", f.owner.ID.String(), project_member.InvitationPending, createdAt.Add(24*time.Hour), "", nil, createdAt, createdAt)
+	require.NoError(t, f.invitations.Save(ctx, expiredInv))
+
+	all, err := f.uc.List(ctx, f.project.ID(), "")
+	require.NoError(t, err)
+	assert.Len(t, all, 2)
+
+	pending, err := f.uc.List(ctx, f.project.ID(), "pending")
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "open@example.com", pending[0].Email)
+
+	expired, err := f.uc.List(ctx, f.project.ID(), InvitationStatusExpired)
+	require.NoError(t, err)
+	require.Len(t, expired, 1)
+	assert.Equal(t, "expired@example.com", expired[0].Email)
+}
+
+func TestManageInvitations_Accept(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("creates the member with the invited role", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{})
+		f.invite(t, "maria@example.com")
+		token := f.tokenFromEmail(t)
+		userID := uuid.New()
+
+		view, err := f.uc.Accept(ctx, AcceptInvitationCommand{Token: token, UserID: userID, Email: "maria@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, string(project_member.InvitationAccepted), view.Status)
+
+		member, err := f.members.FindByProjectAndAgent(ctx, f.project.ID(), userID.String())
+		require.NoError(t, err)
+		assert.Equal(t, project_member.RoleAgent, member.Role())
+		assert.Contains(t, f.eventBus.eventTypes(), "project_invitation.accepted")
+		assert.Equal(t, []uuid.UUID{f.project.ID()}, f.access.projects)
+
+		// O token não serve duas vezes
+		_, err = f.uc.Accept(ctx, AcceptInvitationCommand{Token: token, UserID: uuid.New(), Email: "maria@example.com"})
+		assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+	})
+
+	t.Run("rejects another account, expired and unknown tokens", func(t *testing.T) {
+		f := newInvitationsFixture(t, InvitationConfig{TTL: time.Hour})
+		f.invite(t, "maria@example.com")
+		token := f.tokenFromEmail(t)
+
+		_, err := f.uc.Accept(ctx, AcceptInvitationCommand{Token: token, UserID: uuid.New(), Email: "joao@example.com"})
+		assert.True(t, shared.IsForbiddenError(err))
+
+		_, err = f.uc.Accept(ctx, AcceptInvitationCommand{Token: "vti_unknown", UserID: uuid.New(), Email: "maria@example.com"})
+		assert.True(t, shared.IsNotFoundError(err))
+
+		_, err = f.uc.Accept(ctx, AcceptInvitationCommand{Token: "garbage", UserID: uuid.New(), Email: "maria@example.com"})
+		assert.True(t, shared.IsValidationError(err))
+
+		f.uc.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+		_, err = f.uc.Accept(ctx, AcceptInvitationCommand{Token: token, UserID: uuid.New(), Email: "maria@example.com"})
+		assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+		assert.Empty(t, f.members.members)
+	})
+}
+
+func TestManageInvitations_DeclineAndRevoke(t *testing.T) {
+	ctx := context.Background()
+	f := newInvitationsFixture(t, InvitationConfig{})
+
+	f.invite(t, "maria@example.com")
+	view, err := f.uc.Decline(ctx, f.tokenFromEmail(t))
+	require.NoError(t, err)
+	assert.Equal(t, string(project_member.InvitationDeclined), view.Status)
+
+	created := f.invite(t, "joao@example.com")
+	token := f.tokenFromEmail(t)
+	revoked, err := f.uc.Revoke(ctx, f.project.ID(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, string(project_member.InvitationRevoked), revoked.Status)
+
+	_, err = f.uc.Accept(ctx, AcceptInvitationCommand{Token: token, UserID: uuid.New(), Email: "joao@example.com"})
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+
+	_, err = f.uc.Revoke(ctx, uuid.New(), created.ID)
+	assert.True(t, shared.IsNotFoundError(err))
+}
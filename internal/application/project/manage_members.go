@@ -0,0 +1,366 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// UserSummary dados do usuário exibidos na lista de membros
+type UserSummary struct {
+	ID    uuid.UUID
+	Name  string
+	Email string
+}
+
+// UserDirectory consulta os usuários da plataforma (implementado pelo user.UserService)
+type UserDirectory interface {
+	// FindUsers usuários pelos IDs; os que não existem ficam fora do mapa
+	FindUsers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]UserSummary, error)
+	// FindUserByEmail usuário ativo com o email (sem diferenciar maiúsculas); nil se não existe
+	FindUserByEmail(ctx context.Context, email string) (*UserSummary, error)
+}
+
+// MemberView membro do projeto. O dono aparece sempre, como admin, mesmo sem linha em project_members.
+type MemberView struct {
+	UserID    string                           `json:"user_id"`
+	Name      string                           `json:"name,omitempty"`
+	Email     string                           `json:"email,omitempty"`
+	Role      project_member.ProjectMemberRole `json:"role"`
+	Owner     bool                             `json:"owner"`
+	InvitedBy string                           `json:"invited_by,omitempty"`
+	JoinedAt  time.Time                        `json:"joined_at"`
+}
+
+// ChangeMemberRoleCommand troca do papel de um membro
+type ChangeMemberRoleCommand struct {
+	ProjectID uuid.UUID
+	UserID    string
+	Role      project_member.ProjectMemberRole
+	ChangedBy uuid.UUID
+	// GrantablePermissions permissões de quem troca: o novo papel não pode ir além delas
+	// (nil = sem limite, dono ou admin)
+	GrantablePermissions []project_member.Permission
+}
+
+// TransferOwnershipCommand passagem do projeto para outro membro
+type TransferOwnershipCommand struct {
+	ProjectID   uuid.UUID
+	NewOwnerID  uuid.UUID
+	RequestedBy uuid.UUID
+}
+
+// ManageMembersUseCase listagem, troca de papel, remoção de membros e transferência de dono
+type ManageMembersUseCase struct {
+	projects  project.Repository
+	members   project_member.Repository
+	roles     project_member.CustomRoleRepository
+	access    AccessInvalidator
+	users     UserDirectory
+	eventBus  EventBus
+	txManager TransactionManager
+	logger    *zap.Logger
+}
+
+func NewManageMembersUseCase(
+	projects project.Repository,
+	members project_member.Repository,
+	roles project_member.CustomRoleRepository,
+	access AccessInvalidator,
+	users UserDirectory,
+	eventBus EventBus,
+	txManager TransactionManager,
+	logger *zap.Logger,
+) *ManageMembersUseCase {
+	return &ManageMembersUseCase{
+		projects:  projects,
+		members:   members,
+		roles:     roles,
+		access:    access,
+		users:     users,
+		eventBus:  eventBus,
+		txManager: txManager,
+		logger:    logger,
+	}
+}
+
+// List dono e membros do projeto, com nome e email de cada um
+func (uc *ManageMembersUseCase) List(ctx context.Context, projectID uuid.UUID) ([]MemberView, error) {
+	p, err := loadProject(ctx, uc.projects, projectID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := uc.members.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+
+	owner := p.CustomerID().String()
+	views := []MemberView{{UserID: owner, Role: project_member.RoleAdmin, Owner: true, JoinedAt: p.CreatedAt()}}
+	ids := []uuid.UUID{p.CustomerID()}
+	for _, m := range members {
+		if m.AgentID() == owner {
+			continue
+		}
+		views = append(views, MemberView{
+			UserID:    m.AgentID(),
+			Role:      m.Role(),
+			InvitedBy: m.InvitedBy(),
+			JoinedAt:  m.CreatedAt(),
+		})
+		if id, err := uuid.Parse(m.AgentID()); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	if uc.users == nil {
+		return views, nil
+	}
+	users, err := uc.users.FindUsers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load member users: %w", err)
+	}
+	for i := range views {
+		id, err := uuid.Parse(views[i].UserID)
+		if err != nil {
+			continue
+		}
+		if u, ok := users[id]; ok {
+			views[i].Name = u.Name
+			views[i].Email = u.Email
+		}
+	}
+	return views, nil
+}
+
+// ChangeRole troca o papel de um membro (padrão ou custom do projeto). O dono é sempre admin e
+// não pode ter o papel trocado.
+func (uc *ManageMembersUseCase) ChangeRole(ctx context.Context, cmd ChangeMemberRoleCommand) (*MemberView, error) {
+	p, err := loadProject(ctx, uc.projects, cmd.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	if p.CustomerID().String() == cmd.UserID {
+		return nil, shared.NewPreconditionError("the project owner is always an admin; transfer ownership first")
+	}
+	member, err := uc.findMember(ctx, cmd.ProjectID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err := rolePermissions(ctx, uc.roles, cmd.ProjectID, cmd.Role)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureGrantable(cmd.GrantablePermissions, permissions); err != nil {
+		return nil, err
+	}
+
+	before := member.Role()
+	if err := member.ChangeRole(cmd.Role, cmd.ChangedBy.String()); err != nil {
+		return nil, memberError(err)
+	}
+	if err := uc.saveMembers(ctx, nil, member); err != nil {
+		return nil, err
+	}
+	auditapp.Annotate(ctx, "project_members", cmd.UserID,
+		map[string]interface{}{"role": before}, map[string]interface{}{"role": member.Role()})
+
+	uc.logger.Info("Project member role changed",
+		zap.String("project_id", cmd.ProjectID.String()),
+		zap.String("user_id", cmd.UserID),
+		zap.String("role", string(member.Role())))
+
+	view := MemberView{UserID: member.AgentID(), Role: member.Role(), InvitedBy: member.InvitedBy(), JoinedAt: member.CreatedAt()}
+	return &view, nil
+}
+
+// Remove tira o membro do projeto. O dono não sai: precisa transferir o projeto antes, o que
+// também garante que sempre sobra um admin.
+func (uc *ManageMembersUseCase) Remove(ctx context.Context, projectID uuid.UUID, userID string, removedBy uuid.UUID) error {
+	p, err := loadProject(ctx, uc.projects, projectID)
+	if err != nil {
+		return err
+	}
+	if p.CustomerID().String() == userID {
+		return shared.NewPreconditionError("the project owner cannot be removed; transfer ownership first")
+	}
+	member, err := uc.findMember(ctx, projectID, userID)
+	if err != nil {
+		return err
+	}
+	if err := member.Remove(removedBy.String(), false); err != nil {
+		return memberError(err)
+	}
+
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.members.Delete(txCtx, member.ID()); err != nil {
+			return fmt.Errorf("failed to remove member: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, member.DomainEvents())
+	})
+	if err != nil {
+		return err
+	}
+	member.ClearEvents()
+	invalidateAccess(ctx, uc.access, projectID)
+	auditapp.Annotate(ctx, "project_members", userID, map[string]interface{}{"role": member.Role()}, nil)
+
+	uc.logger.Info("Project member removed",
+		zap.String("project_id", projectID.String()),
+		zap.String("user_id", userID),
+		zap.String("removed_by", removedBy.String()))
+	return nil
+}
+
+// TransferOwnership passa o projeto para outro membro, que vira admin. O antigo dono continua
+// no projeto como admin.
+func (uc *ManageMembersUseCase) TransferOwnership(ctx context.Context, cmd TransferOwnershipCommand) (*MemberView, error) {
+	p, err := loadProject(ctx, uc.projects, cmd.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	previousOwner := p.CustomerID()
+	if previousOwner != cmd.RequestedBy {
+		return nil, shared.NewForbiddenError("only the project owner can transfer ownership")
+	}
+	if cmd.NewOwnerID == previousOwner {
+		return nil, shared.NewValidationError(project.ErrAlreadyOwner.Error(), "user_id")
+	}
+
+	newOwner, err := uc.members.FindByProjectAndAgent(ctx, cmd.ProjectID, cmd.NewOwnerID.String())
+	if errors.Is(err, project_member.ErrMemberNotFound) {
+		return nil, shared.NewPreconditionError("the new owner must already be a project member")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load member: %w", err)
+	}
+
+	if err := p.TransferOwnership(cmd.NewOwnerID, cmd.RequestedBy); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "user_id")
+	}
+	changed := []*project_member.ProjectMember{}
+	if newOwner.Role() != project_member.RoleAdmin {
+		if err := newOwner.ChangeRole(project_member.RoleAdmin, cmd.RequestedBy.String()); err != nil {
+			return nil, memberError(err)
+		}
+		changed = append(changed, newOwner)
+	}
+
+	// O antigo dono passa a ter uma linha de membro admin (antes o papel era implícito)
+	formerOwner, err := uc.members.FindByProjectAndAgent(ctx, cmd.ProjectID, previousOwner.String())
+	switch {
+	case errors.Is(err, project_member.ErrMemberNotFound):
+		formerOwner, err = project_member.NewProjectMember(cmd.ProjectID, previousOwner.String(), project_member.RoleAdmin, cmd.RequestedBy.String())
+		if err != nil {
+			return nil, memberError(err)
+		}
+		changed = append(changed, formerOwner)
+	case err != nil:
+		return nil, fmt.Errorf("failed to load member: %w", err)
+	case formerOwner.Role() != project_member.RoleAdmin:
+		// ChangeRole recusa a troca do próprio papel: quem assina a promoção é o novo dono
+		if err := formerOwner.ChangeRole(project_member.RoleAdmin, cmd.NewOwnerID.String()); err != nil {
+			return nil, memberError(err)
+		}
+		changed = append(changed, formerOwner)
+	}
+
+	if err := uc.saveMembers(ctx, p, changed...); err != nil {
+		return nil, err
+	}
+	auditapp.Annotate(ctx, "projects", cmd.ProjectID.String(),
+		map[string]interface{}{"owner_id": previousOwner}, map[string]interface{}{"owner_id": cmd.NewOwnerID})
+
+	uc.logger.Info("Project ownership transferred",
+		zap.String("project_id", cmd.ProjectID.String()),
+		zap.String("previous_owner_id", previousOwner.String()),
+		zap.String("new_owner_id", cmd.NewOwnerID.String()))
+
+	view := MemberView{
+		UserID:    newOwner.AgentID(),
+		Role:      project_member.RoleAdmin,
+		Owner:     true,
+		InvitedBy: newOwner.InvitedBy(),
+		JoinedAt:  newOwner.CreatedAt(),
+	}
+	return &view, nil
+}
+
+func (uc *ManageMembersUseCase) findMember(ctx context.Context, projectID uuid.UUID, userID string) (*project_member.ProjectMember, error) {
+	member, err := uc.members.FindByProjectAndAgent(ctx, projectID, userID)
+	if errors.Is(err, project_member.ErrMemberNotFound) {
+		return nil, shared.NewNotFoundError("project member", userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load member: %w", err)
+	}
+	return member, nil
+}
+
+// saveMembers grava o projeto (se houver) e os membros numa transação, com os eventos de todos
+func (uc *ManageMembersUseCase) saveMembers(ctx context.Context, p *project.Project, members ...*project_member.ProjectMember) error {
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if p != nil {
+			if err := uc.projects.Save(txCtx, p); err != nil {
+				return fmt.Errorf("failed to save project: %w", err)
+			}
+			if err := publishEvents(txCtx, uc.eventBus, p.DomainEvents()); err != nil {
+				return err
+			}
+		}
+		for _, m := range members {
+			if err := uc.members.Save(txCtx, m); err != nil {
+				return fmt.Errorf("failed to save member: %w", err)
+			}
+			if err := publishEvents(txCtx, uc.eventBus, m.DomainEvents()); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Papel ou dono mudou: o acesso em cache do projeto deixa de valer
+	if p != nil {
+		p.ClearEvents()
+		invalidateAccess(ctx, uc.access, p.ID())
+	}
+	for _, m := range members {
+		m.ClearEvents()
+		invalidateAccess(ctx, uc.access, m.ProjectID())
+	}
+	return nil
+}
+
+func loadProject(ctx context.Context, projects project.Repository, projectID uuid.UUID) (*project.Project, error) {
+	p, err := projects.FindByID(ctx, projectID)
+	if errors.Is(err, project.ErrProjectNotFound) {
+		return nil, shared.NewNotFoundError("project", projectID.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
+	}
+	return p, nil
+}
+
+// memberError traduz os erros do domínio de membros para erros da aplicação
+func memberError(err error) error {
+	switch {
+	case errors.Is(err, project_member.ErrInvalidRole):
+		return shared.NewValidationError(err.Error(), "role")
+	case errors.Is(err, project_member.ErrCannotChangeSelfRole):
+		return shared.NewForbiddenError(err.Error())
+	case errors.Is(err, project_member.ErrCannotRemoveLastAdmin):
+		return shared.NewPreconditionError(err.Error())
+	}
+	return shared.NewValidationError(err.Error(), "")
+}
@@ -0,0 +1,209 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+type membersFixture struct {
+	uc       *ManageMembersUseCase
+	project  *project.Project
+	owner    UserSummary
+	agent    UserSummary
+	members  *fakeMemberRepository
+	roles    *fakeRoleRepository
+	access   *recordingInvalidator
+	eventBus *recordingEventBus
+}
+
+func newMembersFixture(t *testing.T) *membersFixture {
+	t.Helper()
+	users := &fakeUserDirectory{}
+	owner := users.add("Owner", "owner@example.com")
+	agent := users.add("Agent", "agent@example.com")
+
+	p := newTestProject(t, owner.ID, "Support")
+	member, err := project_member.NewProjectMember(p.ID(), agent.ID.String(), project_member.RoleAgent, owner.ID.String())
+	require.NoError(t, err)
+	member.ClearEvents()
+
+	members := newFakeMemberRepository(member)
+	roles := newFakeRoleRepository()
+	access := &recordingInvalidator{}
+	eventBus := &recordingEventBus{}
+	return &membersFixture{
+		uc:       NewManageMembersUseCase(newFakeProjectRepository(p), members, roles, access, users, eventBus, &SimpleTransactionManager{}, zap.NewNop()),
+		project:  p,
+		owner:    owner,
+		agent:    agent,
+		members:  members,
+		roles:    roles,
+		access:   access,
+		eventBus: eventBus,
+	}
+}
+
+func TestManageMembers_List(t *testing.T) {
+	f := newMembersFixture(t)
+
+	views, err := f.uc.List(context.Background(), f.project.ID())
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+
+	assert.Equal(t, f.owner.ID.String(), views[0].UserID)
+	assert.True(t, views[0].Owner)
+	assert.Equal(t, project_member.RoleAdmin, views[0].Role)
+	assert.Equal(t, "owner@example.com", views[0].Email)
+
+	assert.Equal(t, f.agent.ID.String(), views[1].UserID)
+	assert.Equal(t, project_member.RoleAgent, views[1].Role)
+	assert.Equal(t, "Agent", views[1].Name)
+}
+
+func TestManageMembers_ChangeRole(t *testing.T) {
+	ctx := context.Background()
+	f := newMembersFixture(t)
+
+	view, err := f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: project_member.RoleSupervisor, ChangedBy: f.owner.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, project_member.RoleSupervisor, view.Role)
+	assert.Equal(t, []string{"project_member.role_changed"}, f.eventBus.eventTypes())
+	assert.Equal(t, []uuid.UUID{f.project.ID()}, f.access.projects)
+
+	_, err = f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: "owner", ChangedBy: f.owner.ID,
+	})
+	assert.True(t, shared.IsValidationError(err))
+
+	_, err = f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.owner.ID.String(), Role: project_member.RoleViewer, ChangedBy: f.agent.ID,
+	})
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+
+	_, err = f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: uuid.NewString(), Role: project_member.RoleViewer, ChangedBy: f.owner.ID,
+	})
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+func TestManageMembers_ChangeRoleToCustomRole(t *testing.T) {
+	ctx := context.Background()
+	f := newMembersFixture(t)
+
+	_, err := f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: "custom:sales", ChangedBy: f.owner.ID,
+	})
+	assert.True(t, shared.IsValidationError(err))
+	assert.Empty(t, f.access.projects)
+
+	sales, err := project_member.NewCustomRole(f.project.ID(), "Sales", "", []project_member.Permission{project_member.PermissionViewContacts}, "", f.owner.ID.String())
+	require.NoError(t, err)
+	f.roles.roles[sales.ID()] = sales
+
+	view, err := f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: sales.Key(), ChangedBy: f.owner.ID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, sales.Key(), view.Role)
+	assert.Equal(t, []uuid.UUID{f.project.ID()}, f.access.projects)
+}
+
+// TestManageMembers_ChangeRoleLimitedToCallerPermissions sem ser dono ou admin, o novo papel não
+// pode ter permissão que quem troca não tem
+func TestManageMembers_ChangeRoleLimitedToCallerPermissions(t *testing.T) {
+	ctx := context.Background()
+	f := newMembersFixture(t)
+	grantable := project_member.GetRolePermissions(project_member.RoleSupervisor)
+
+	_, err := f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: project_member.RoleAdmin, ChangedBy: f.owner.ID, GrantablePermissions: grantable,
+	})
+	assert.Equal(t, shared.ErrorTypeForbidden, errorType(err))
+
+	settings, err := project_member.NewCustomRole(f.project.ID(), "Settings", "", []project_member.Permission{project_member.PermissionManageSettings}, "", f.owner.ID.String())
+	require.NoError(t, err)
+	f.roles.roles[settings.ID()] = settings
+	_, err = f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: settings.Key(), ChangedBy: f.owner.ID, GrantablePermissions: grantable,
+	})
+	assert.Equal(t, shared.ErrorTypeForbidden, errorType(err))
+	assert.Empty(t, f.access.projects)
+
+	view, err := f.uc.ChangeRole(ctx, ChangeMemberRoleCommand{
+		ProjectID: f.project.ID(), UserID: f.agent.ID.String(), Role: project_member.RoleViewer, ChangedBy: f.owner.ID, GrantablePermissions: grantable,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, project_member.RoleViewer, view.Role)
+}
+
+func TestManageMembers_Remove(t *testing.T) {
+	ctx := context.Background()
+	f := newMembersFixture(t)
+
+	err := f.uc.Remove(ctx, f.project.ID(), f.owner.ID.String(), f.agent.ID)
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+
+	require.NoError(t, f.uc.Remove(ctx, f.project.ID(), f.agent.ID.String(), f.owner.ID))
+	assert.Empty(t, f.members.members)
+	assert.Equal(t, []uuid.UUID{f.project.ID()}, f.access.projects)
+	assert.Equal(t, []string{"project_member.removed"}, f.eventBus.eventTypes())
+
+	err = f.uc.Remove(ctx, f.project.ID(), f.agent.ID.String(), f.owner.ID)
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+func TestManageMembers_TransferOwnership(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("only the owner transfers", func(t *testing.T) {
+		f := newMembersFixture(t)
+		_, err := f.uc.TransferOwnership(ctx, TransferOwnershipCommand{
+			ProjectID: f.project.ID(), NewOwnerID: f.agent.ID, RequestedBy: f.agent.ID,
+		})
+		assert.True(t, shared.IsForbiddenError(err))
+	})
+
+	t.Run("new owner must be a member", func(t *testing.T) {
+		f := newMembersFixture(t)
+		_, err := f.uc.TransferOwnership(ctx, TransferOwnershipCommand{
+			ProjectID: f.project.ID(), NewOwnerID: uuid.New(), RequestedBy: f.owner.ID,
+		})
+		assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+
+		_, err = f.uc.TransferOwnership(ctx, TransferOwnershipCommand{
+			ProjectID: f.project.ID(), NewOwnerID: f.owner.ID, RequestedBy: f.owner.ID,
+		})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	t.Run("former owner stays as admin", func(t *testing.T) {
+		f := newMembersFixture(t)
+		view, err := f.uc.TransferOwnership(ctx, TransferOwnershipCommand{
+			ProjectID: f.project.ID(), NewOwnerID: f.agent.ID, RequestedBy: f.owner.ID,
+		})
+		require.NoError(t, err)
+		assert.True(t, view.Owner)
+		assert.Equal(t, project_member.RoleAdmin, view.Role)
+		assert.Equal(t, f.agent.ID, f.project.CustomerID())
+
+		newOwner, err := f.members.FindByProjectAndAgent(ctx, f.project.ID(), f.agent.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, project_member.RoleAdmin, newOwner.Role())
+
+		formerOwner, err := f.members.FindByProjectAndAgent(ctx, f.project.ID(), f.owner.ID.String())
+		require.NoError(t, err)
+		assert.Equal(t, project_member.RoleAdmin, formerOwner.Role())
+
+		assert.Contains(t, f.eventBus.eventTypes(), "project.ownership_transferred")
+	})
+}
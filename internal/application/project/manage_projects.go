@@ -0,0 +1,297 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// ProjectView projeto exposto pela API, com o papel de quem consulta
+type ProjectView struct {
+	ID               uuid.UUID                        `json:"id"`
+	OwnerID          uuid.UUID                        `json:"owner_id"`
+	BillingAccountID uuid.UUID                        `json:"billing_account_id"`
+	TenantID         string                           `json:"tenant_id"`
+	Name             string                           `json:"name"`
+	Description      string                           `json:"description"`
+	Active           bool                             `json:"active"`
+	RequireTwoFactor bool                             `json:"require_two_factor"`
+	SessionTimeout   int                              `json:"session_timeout_minutes"`
+	Role             project_member.ProjectMemberRole `json:"role,omitempty"`
+	Owner            bool                             `json:"owner"`
+	CreatedAt        time.Time                        `json:"created_at"`
+	UpdatedAt        time.Time                        `json:"updated_at"`
+}
+
+func newProjectView(p *project.Project, role project_member.ProjectMemberRole, owner bool) ProjectView {
+	return ProjectView{
+		ID:               p.ID(),
+		OwnerID:          p.CustomerID(),
+		BillingAccountID: p.BillingAccountID(),
+		TenantID:         p.TenantID(),
+		Name:             p.Name(),
+		Description:      p.Description(),
+		Active:           p.IsActive(),
+		RequireTwoFactor: p.RequiresTwoFactor(),
+		SessionTimeout:   p.SessionTimeoutMinutes(),
+		Role:             role,
+		Owner:            owner,
+		CreatedAt:        p.CreatedAt(),
+		UpdatedAt:        p.UpdatedAt(),
+	}
+}
+
+// projectAuditState campos do projeto registrados no diff da trilha de auditoria
+type projectAuditState struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Active      bool   `json:"active"`
+}
+
+func auditStateOf(p *project.Project) projectAuditState {
+	return projectAuditState{Name: p.Name(), Description: p.Description(), Active: p.IsActive()}
+}
+
+// CreateProjectCommand criação de um projeto pelo usuário logado
+type CreateProjectCommand struct {
+	UserID      uuid.UUID
+	Name        string
+	Description string
+	// TenantID opcional: vazio gera um identificador novo
+	TenantID string
+}
+
+// UpdateProjectCommand alteração parcial (campos nil ficam como estão)
+type UpdateProjectCommand struct {
+	ProjectID   uuid.UUID
+	Name        *string
+	Description *string
+	Active      *bool
+}
+
+// ManageProjectsUseCase ciclo de vida dos projetos: listagem, criação, alteração e remoção.
+// Quem cria é o dono (customer_id) e tem papel admin implícito, sem linha em project_members.
+type ManageProjectsUseCase struct {
+	projects  project.Repository
+	members   project_member.Repository
+	access    AccessInvalidator
+	create    *CreateProjectUseCase
+	eventBus  EventBus
+	txManager TransactionManager
+	logger    *zap.Logger
+}
+
+func NewManageProjectsUseCase(
+	projects project.Repository,
+	members project_member.Repository,
+	access AccessInvalidator,
+	eventBus EventBus,
+	txManager TransactionManager,
+	logger *zap.Logger,
+) *ManageProjectsUseCase {
+	return &ManageProjectsUseCase{
+		projects:  projects,
+		members:   members,
+		access:    access,
+		create:    NewCreateProjectUseCase(projects, eventBus, txManager),
+		eventBus:  eventBus,
+		txManager: txManager,
+		logger:    logger,
+	}
+}
+
+// ListForUser projetos do usuário: os que ele é dono e os que participa como membro
+func (uc *ManageProjectsUseCase) ListForUser(ctx context.Context, userID uuid.UUID) ([]ProjectView, error) {
+	owned, err := uc.projects.FindByCustomer(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned projects: %w", err)
+	}
+
+	views := make([]ProjectView, 0, len(owned))
+	seen := make(map[uuid.UUID]bool, len(owned))
+	for _, p := range owned {
+		views = append(views, newProjectView(p, project_member.RoleAdmin, true))
+		seen[p.ID()] = true
+	}
+
+	memberships, err := uc.members.FindByAgent(ctx, userID.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list memberships: %w", err)
+	}
+	for _, m := range memberships {
+		if seen[m.ProjectID()] {
+			continue
+		}
+		p, err := uc.projects.FindByID(ctx, m.ProjectID())
+		if errors.Is(err, project.ErrProjectNotFound) {
+			// Projeto removido: a linha de membro fica órfã até a limpeza
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project %s: %w", m.ProjectID(), err)
+		}
+		views = append(views, newProjectView(p, m.Role(), false))
+		seen[p.ID()] = true
+	}
+	return views, nil
+}
+
+// Create cria o projeto na conta de faturamento dos projetos que o usuário já possui
+func (uc *ManageProjectsUseCase) Create(ctx context.Context, cmd CreateProjectCommand) (*ProjectView, error) {
+	owned, err := uc.projects.FindByCustomer(ctx, cmd.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list owned projects: %w", err)
+	}
+	billingAccountID := uuid.Nil
+	for _, p := range owned {
+		if p.BillingAccountID() != uuid.Nil {
+			billingAccountID = p.BillingAccountID()
+			break
+		}
+	}
+	if billingAccountID == uuid.Nil {
+		return nil, shared.NewPreconditionError("no billing account found: create projects from an account that owns one")
+	}
+
+	tenantID := cmd.TenantID
+	if tenantID == "" {
+		tenantID = fmt.Sprintf("project-%s", uuid.New().String()[:8])
+	}
+
+	created, err := uc.create.Execute(ctx, CreateProjectRequest{
+		CustomerID:       cmd.UserID,
+		BillingAccountID: billingAccountID,
+		TenantID:         tenantID,
+		Name:             cmd.Name,
+		Description:      cmd.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p, err := uc.projects.FindByID(ctx, created.ProjectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load created project: %w", err)
+	}
+	uc.logger.Info("Project created",
+		zap.String("project_id", p.ID().String()),
+		zap.String("owner_id", cmd.UserID.String()))
+
+	view := newProjectView(p, project_member.RoleAdmin, true)
+	return &view, nil
+}
+
+// Get detalhes do projeto; o papel vem do RBAC de quem consulta
+func (uc *ManageProjectsUseCase) Get(ctx context.Context, projectID uuid.UUID, role project_member.ProjectMemberRole, owner bool) (*ProjectView, error) {
+	p, err := loadProject(ctx, uc.projects, projectID)
+	if err != nil {
+		return nil, err
+	}
+	view := newProjectView(p, role, owner)
+	return &view, nil
+}
+
+// Update renomeia, altera a descrição ou ativa/desativa o projeto
+func (uc *ManageProjectsUseCase) Update(ctx context.Context, cmd UpdateProjectCommand) (*ProjectView, error) {
+	p, err := loadProject(ctx, uc.projects, cmd.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	before := auditStateOf(p)
+
+	if cmd.Name != nil {
+		if err := p.Rename(*cmd.Name); err != nil {
+			return nil, shared.NewValidationError(err.Error(), "name")
+		}
+	}
+	if cmd.Description != nil {
+		p.UpdateDescription(*cmd.Description)
+	}
+	if cmd.Active != nil {
+		if *cmd.Active {
+			p.Activate()
+		} else {
+			p.Deactivate()
+		}
+	}
+
+	if err := uc.save(ctx, p); err != nil {
+		return nil, err
+	}
+	auditapp.Annotate(ctx, "projects", p.ID().String(), before, auditStateOf(p))
+
+	// O papel de quem alterou fica a cargo do chamador (vem do RBAC)
+	view := newProjectView(p, "", false)
+	return &view, nil
+}
+
+// Delete remove o projeto (soft delete). Só o dono remove, e ele precisa continuar com outro
+// projeto: o login sempre abre um projeto do próprio usuário.
+func (uc *ManageProjectsUseCase) Delete(ctx context.Context, projectID, userID uuid.UUID) error {
+	p, err := loadProject(ctx, uc.projects, projectID)
+	if err != nil {
+		return err
+	}
+	if p.CustomerID() != userID {
+		return shared.NewForbiddenError("only the project owner can delete the project")
+	}
+
+	owned, err := uc.projects.FindByCustomer(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to list owned projects: %w", err)
+	}
+	remaining := 0
+	for _, other := range owned {
+		if other.ID() != projectID && other.IsActive() {
+			remaining++
+		}
+	}
+	if remaining == 0 {
+		return shared.NewPreconditionError("cannot delete your only active project")
+	}
+
+	if err := uc.projects.Delete(ctx, projectID); err != nil {
+		if errors.Is(err, project.ErrProjectNotFound) {
+			return shared.NewNotFoundError("project", projectID.String())
+		}
+		return fmt.Errorf("failed to delete project: %w", err)
+	}
+	invalidateAccess(ctx, uc.access, projectID)
+	auditapp.Annotate(ctx, "projects", projectID.String(), auditStateOf(p), nil)
+
+	uc.logger.Info("Project deleted",
+		zap.String("project_id", projectID.String()),
+		zap.String("deleted_by", userID.String()))
+	return nil
+}
+
+func (uc *ManageProjectsUseCase) save(ctx context.Context, p *project.Project) error {
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.projects.Save(txCtx, p); err != nil {
+			return fmt.Errorf("failed to save project: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, p.DomainEvents())
+	})
+	if err != nil {
+		return err
+	}
+	p.ClearEvents()
+	return nil
+}
+
+func publishEvents(ctx context.Context, eventBus EventBus, events []shared.DomainEvent) error {
+	for _, event := range events {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event: %w", err)
+		}
+	}
+	return nil
+}
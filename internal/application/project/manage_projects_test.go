@@ -0,0 +1,125 @@
+package project
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+func newTestProject(t *testing.T, ownerID uuid.UUID, name string) *project.Project {
+	t.Helper()
+	p, err := project.NewProject(ownerID, uuid.New(), "tenant-"+strings.ToLower(name), name)
+	require.NoError(t, err)
+	p.ClearEvents()
+	return p
+}
+
+func newManageProjects(projects *fakeProjectRepository, members *fakeMemberRepository) *ManageProjectsUseCase {
+	return NewManageProjectsUseCase(projects, members, &recordingInvalidator{}, &recordingEventBus{}, &SimpleTransactionManager{}, zap.NewNop())
+}
+
+func TestManageProjects_ListForUser(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	owned := newTestProject(t, userID, "Owned")
+	memberOf := newTestProject(t, uuid.New(), "Shared")
+	other := newTestProject(t, uuid.New(), "Other")
+
+	membership, err := project_member.NewProjectMember(memberOf.ID(), userID.String(), project_member.RoleAgent, uuid.NewString())
+	require.NoError(t, err)
+	uc := newManageProjects(newFakeProjectRepository(owned, memberOf, other), newFakeMemberRepository(membership))
+
+	views, err := uc.ListForUser(ctx, userID)
+	require.NoError(t, err)
+	require.Len(t, views, 2)
+
+	assert.Equal(t, owned.ID(), views[0].ID)
+	assert.True(t, views[0].Owner)
+	assert.Equal(t, project_member.RoleAdmin, views[0].Role)
+
+	assert.Equal(t, memberOf.ID(), views[1].ID)
+	assert.False(t, views[1].Owner)
+	assert.Equal(t, project_member.RoleAgent, views[1].Role)
+}
+
+func TestManageProjects_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("uses the billing account of an owned project", func(t *testing.T) {
+		userID := uuid.New()
+		existing := newTestProject(t, userID, "First")
+		projects := newFakeProjectRepository(existing)
+		uc := newManageProjects(projects, newFakeMemberRepository())
+
+		view, err := uc.Create(ctx, CreateProjectCommand{UserID: userID, Name: "Second"})
+		require.NoError(t, err)
+		assert.Equal(t, existing.BillingAccountID(), view.BillingAccountID)
+		assert.Equal(t, userID, view.OwnerID)
+		assert.True(t, view.Owner)
+		assert.True(t, strings.HasPrefix(view.TenantID, "project-"))
+		assert.Len(t, projects.projects, 2)
+	})
+
+	t.Run("duplicate tenant is a conflict", func(t *testing.T) {
+		userID := uuid.New()
+		existing := newTestProject(t, userID, "First")
+		uc := newManageProjects(newFakeProjectRepository(existing), newFakeMemberRepository())
+
+		_, err := uc.Create(ctx, CreateProjectCommand{UserID: userID, Name: "Second", TenantID: existing.TenantID()})
+		assert.Equal(t, shared.ErrorTypeConflict, errorType(err))
+	})
+
+	t.Run("requires a billing account", func(t *testing.T) {
+		uc := newManageProjects(newFakeProjectRepository(), newFakeMemberRepository())
+
+		_, err := uc.Create(ctx, CreateProjectCommand{UserID: uuid.New(), Name: "First"})
+		assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+	})
+}
+
+func TestManageProjects_Update(t *testing.T) {
+	ctx := context.Background()
+	p := newTestProject(t, uuid.New(), "Support")
+	uc := newManageProjects(newFakeProjectRepository(p), newFakeMemberRepository())
+
+	name, active := "Sales", false
+	view, err := uc.Update(ctx, UpdateProjectCommand{ProjectID: p.ID(), Name: &name, Active: &active})
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", view.Name)
+	assert.False(t, view.Active)
+
+	empty := ""
+	_, err = uc.Update(ctx, UpdateProjectCommand{ProjectID: p.ID(), Name: &empty})
+	assert.True(t, shared.IsValidationError(err))
+
+	_, err = uc.Update(ctx, UpdateProjectCommand{ProjectID: uuid.New(), Name: &name})
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+func TestManageProjects_Delete(t *testing.T) {
+	ctx := context.Background()
+	ownerID := uuid.New()
+	first := newTestProject(t, ownerID, "First")
+	second := newTestProject(t, ownerID, "Second")
+	projects := newFakeProjectRepository(first, second)
+	uc := newManageProjects(projects, newFakeMemberRepository())
+
+	err := uc.Delete(ctx, first.ID(), uuid.New())
+	assert.True(t, shared.IsForbiddenError(err))
+
+	require.NoError(t, uc.Delete(ctx, first.ID(), ownerID))
+	_, stillThere := projects.projects[first.ID()]
+	assert.False(t, stillThere)
+
+	// O último projeto ativo do dono não pode ser removido
+	err = uc.Delete(ctx, second.ID(), ownerID)
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+}
@@ -0,0 +1,361 @@
+package project
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+// fakeProjectRepository projetos em memória (Delete remove do mapa, como o soft delete esconde)
+type fakeProjectRepository struct {
+	projects map[uuid.UUID]*project.Project
+}
+
+func newFakeProjectRepository(projects ...*project.Project) *fakeProjectRepository {
+	r := &fakeProjectRepository{projects: map[uuid.UUID]*project.Project{}}
+	for _, p := range projects {
+		r.projects[p.ID()] = p
+	}
+	return r
+}
+
+func (r *fakeProjectRepository) Save(ctx context.Context, p *project.Project) error {
+	r.projects[p.ID()] = p
+	return nil
+}
+
+func (r *fakeProjectRepository) FindByID(ctx context.Context, id uuid.UUID) (*project.Project, error) {
+	p, ok := r.projects[id]
+	if !ok {
+		return nil, project.ErrProjectNotFound
+	}
+	return p, nil
+}
+
+func (r *fakeProjectRepository) FindByTenantID(ctx context.Context, tenantID string) (*project.Project, error) {
+	for _, p := range r.projects {
+		if p.TenantID() == tenantID {
+			return p, nil
+		}
+	}
+	return nil, project.ErrProjectNotFound
+}
+
+func (r *fakeProjectRepository) FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*project.Project, error) {
+	var owned []*project.Project
+	for _, p := range r.projects {
+		if p.CustomerID() == customerID {
+			owned = append(owned, p)
+		}
+	}
+	sort.Slice(owned, func(i, j int) bool { return owned[i].Name() < owned[j].Name() })
+	return owned, nil
+}
+
+func (r *fakeProjectRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	if _, ok := r.projects[id]; !ok {
+		return project.ErrProjectNotFound
+	}
+	delete(r.projects, id)
+	return nil
+}
+
+func (r *fakeProjectRepository) FindByTenantWithFilters(ctx context.Context, filters project.ProjectFilters) ([]*project.Project, int64, error) {
+	return nil, 0, nil
+}
+
+func (r *fakeProjectRepository) SearchByText(ctx context.Context, tenantID string, searchText string, limit int, offset int) ([]*project.Project, int64, error) {
+	return nil, 0, nil
+}
+
+// fakeMemberRepository membros em memória
+type fakeMemberRepository struct {
+	members map[uuid.UUID]*project_member.ProjectMember
+}
+
+func newFakeMemberRepository(members ...*project_member.ProjectMember) *fakeMemberRepository {
+	r := &fakeMemberRepository{members: map[uuid.UUID]*project_member.ProjectMember{}}
+	for _, m := range members {
+		r.members[m.ID()] = m
+	}
+	return r
+}
+
+func (r *fakeMemberRepository) Save(ctx context.Context, m *project_member.ProjectMember) error {
+	r.members[m.ID()] = m
+	return nil
+}
+
+func (r *fakeMemberRepository) FindByID(ctx context.Context, id uuid.UUID) (*project_member.ProjectMember, error) {
+	m, ok := r.members[id]
+	if !ok {
+		return nil, project_member.ErrMemberNotFound
+	}
+	return m, nil
+}
+
+func (r *fakeMemberRepository) FindByProjectAndAgent(ctx context.Context, projectID uuid.UUID, agentID string) (*project_member.ProjectMember, error) {
+	for _, m := range r.members {
+		if m.ProjectID() == projectID && m.AgentID() == agentID {
+			return m, nil
+		}
+	}
+	return nil, project_member.ErrMemberNotFound
+}
+
+func (r *fakeMemberRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*project_member.ProjectMember, error) {
+	var found []*project_member.ProjectMember
+	for _, m := range r.members {
+		if m.ProjectID() == projectID {
+			found = append(found, m)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].AgentID() < found[j].AgentID() })
+	return found, nil
+}
+
+func (r *fakeMemberRepository) FindByAgent(ctx context.Context, agentID string) ([]*project_member.ProjectMember, error) {
+	var found []*project_member.ProjectMember
+	for _, m := range r.members {
+		if m.AgentID() == agentID {
+			found = append(found, m)
+		}
+	}
+	return found, nil
+}
+
+func (r *fakeMemberRepository) FindAdminsByProject(ctx context.Context, projectID uuid.UUID) ([]*project_member.ProjectMember, error) {
+	var admins []*project_member.ProjectMember
+	for _, m := range r.members {
+		if m.ProjectID() == projectID && m.IsAdmin() {
+			admins = append(admins, m)
+		}
+	}
+	return admins, nil
+}
+
+func (r *fakeMemberRepository) CountAdminsByProject(ctx context.Context, projectID uuid.UUID) (int, error) {
+	admins, _ := r.FindAdminsByProject(ctx, projectID)
+	return len(admins), nil
+}
+
+func (r *fakeMemberRepository) ExistsInProject(ctx context.Context, projectID uuid.UUID, agentID string) (bool, error) {
+	_, err := r.FindByProjectAndAgent(ctx, projectID, agentID)
+	return err == nil, nil
+}
+
+func (r *fakeMemberRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(r.members, id)
+	return nil
+}
+
+// fakeInvitationRepository convites em memória
+type fakeInvitationRepository struct {
+	invitations []*project_member.Invitation
+}
+
+func (r *fakeInvitationRepository) Save(ctx context.Context, inv *project_member.Invitation) error {
+	for i, existing := range r.invitations {
+		if existing.ID() == inv.ID() {
+			r.invitations[i] = inv
+			return nil
+		}
+	}
+	r.invitations = append(r.invitations, inv)
+	return nil
+}
+
+func (r *fakeInvitationRepository) FindByID(ctx context.Context, projectID, id uuid.UUID) (*project_member.Invitation, error) {
+	for _, inv := range r.invitations {
+		if inv.ProjectID() == projectID && inv.ID() == id {
+			return inv, nil
+		}
+	}
+	return nil, project_member.ErrInvitationNotFound
+}
+
+func (r *fakeInvitationRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*project_member.Invitation, error) {
+	for _, inv := range r.invitations {
+		if inv.TokenHash() == tokenHash {
+			return inv, nil
+		}
+	}
+	return nil, project_member.ErrInvitationNotFound
+}
+
+func (r *fakeInvitationRepository) FindPendingByEmail(ctx context.Context, projectID uuid.UUID, email string) (*project_member.Invitation, error) {
+	for _, inv := range r.invitations {
+		if inv.ProjectID() == projectID && inv.Email() == email && inv.Status() == project_member.InvitationPending {
+			return inv, nil
+		}
+	}
+	return nil, project_member.ErrInvitationNotFound
+}
+
+func (r *fakeInvitationRepository) FindByProject(ctx context.Context, projectID uuid.UUID, status project_member.InvitationStatus) ([]*project_member.Invitation, error) {
+	var found []*project_member.Invitation
+	for _, inv := range r.invitations {
+		if inv.ProjectID() == projectID && (status == "" || inv.Status() == status) {
+			found = append(found, inv)
+		}
+	}
+	return found, nil
+}
+
+// fakeRoleRepository papéis custom em memória (conta as buscas por chave para testar o cache)
+type fakeRoleRepository struct {
+	roles      map[uuid.UUID]*project_member.CustomRole
+	keyLookups int
+}
+
+func newFakeRoleRepository(roles ...*project_member.CustomRole) *fakeRoleRepository {
+	r := &fakeRoleRepository{roles: map[uuid.UUID]*project_member.CustomRole{}}
+	for _, role := range roles {
+		r.roles[role.ID()] = role
+	}
+	return r
+}
+
+func (r *fakeRoleRepository) Save(ctx context.Context, role *project_member.CustomRole) error {
+	r.roles[role.ID()] = role
+	return nil
+}
+
+func (r *fakeRoleRepository) FindByID(ctx context.Context, projectID, id uuid.UUID) (*project_member.CustomRole, error) {
+	role, ok := r.roles[id]
+	if !ok || role.ProjectID() != projectID {
+		return nil, project_member.ErrCustomRoleNotFound
+	}
+	return role, nil
+}
+
+func (r *fakeRoleRepository) FindByKey(ctx context.Context, projectID uuid.UUID, key project_member.ProjectMemberRole) (*project_member.CustomRole, error) {
+	r.keyLookups++
+	for _, role := range r.roles {
+		if role.ProjectID() == projectID && role.Key() == key {
+			return role, nil
+		}
+	}
+	return nil, project_member.ErrCustomRoleNotFound
+}
+
+func (r *fakeRoleRepository) FindByProject(ctx context.Context, projectID uuid.UUID) ([]*project_member.CustomRole, error) {
+	var found []*project_member.CustomRole
+	for _, role := range r.roles {
+		if role.ProjectID() == projectID {
+			found = append(found, role)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].Name() < found[j].Name() })
+	return found, nil
+}
+
+func (r *fakeRoleRepository) Delete(ctx context.Context, projectID, id uuid.UUID) error {
+	role, ok := r.roles[id]
+	if !ok || role.ProjectID() != projectID {
+		return project_member.ErrCustomRoleNotFound
+	}
+	delete(r.roles, id)
+	return nil
+}
+
+// recordingInvalidator projetos com acesso invalidado, na ordem
+type recordingInvalidator struct {
+	projects []uuid.UUID
+}
+
+func (i *recordingInvalidator) InvalidateProject(ctx context.Context, projectID uuid.UUID) {
+	i.projects = append(i.projects, projectID)
+}
+
+// fakeUserDirectory usuários da plataforma em memória
+type fakeUserDirectory struct {
+	users []UserSummary
+}
+
+func (d *fakeUserDirectory) add(name, email string) UserSummary {
+	u := UserSummary{ID: uuid.New(), Name: name, Email: email}
+	d.users = append(d.users, u)
+	return u
+}
+
+func (d *fakeUserDirectory) FindUsers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]UserSummary, error) {
+	found := map[uuid.UUID]UserSummary{}
+	for _, id := range ids {
+		for _, u := range d.users {
+			if u.ID == id {
+				found[id] = u
+			}
+		}
+	}
+	return found, nil
+}
+
+func (d *fakeUserDirectory) FindUserByEmail(ctx context.Context, email string) (*UserSummary, error) {
+	for _, u := range d.users {
+		if strings.EqualFold(u.Email, email) {
+			u := u
+			return &u, nil
+		}
+	}
+	return nil, nil
+}
+
+// fakeEmailSender guarda os emails enviados; err simula falha do SMTP
+type fakeEmailSender struct {
+	sent []sentEmail
+	err  error
+}
+
+type sentEmail struct {
+	to      []string
+	subject string
+	body    string
+}
+
+func (s *fakeEmailSender) Send(ctx context.Context, to []string, subject, body string) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.sent = append(s.sent, sentEmail{to: to, subject: subject, body: body})
+	return nil
+}
+
+// recordingEventBus guarda os eventos publicados
+type recordingEventBus struct {
+	events []shared.DomainEvent
+}
+
+func (b *recordingEventBus) Publish(ctx context.Context, event shared.DomainEvent) error {
+	b.events = append(b.events, event)
+	return nil
+}
+
+func (b *recordingEventBus) eventTypes() []string {
+	types := make([]string, 0, len(b.events))
+	for _, e := range b.events {
+		types = append(types, e.EventName())
+	}
+	return types
+}
+
+// SimpleTransactionManager is a test transaction manager that just executes the function
+type SimpleTransactionManager struct{}
+
+func (m *SimpleTransactionManager) ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// errorType tipo do erro da aplicação (vazio para erros que não são DomainError)
+func errorType(err error) shared.ErrorType {
+	var domainErr *shared.DomainError
+	if shared.IsDomainError(err, &domainErr) {
+		return domainErr.Type
+	}
+	return ""
+}
@@ -0,0 +1,417 @@
+package project
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	auditapp "github.com/ventros/crm/internal/application/audit"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+// RoleView papel do projeto: os padrão (BuiltIn, somente leitura) e os custom do tenant
+type RoleView struct {
+	ID          string                           `json:"id,omitempty"`
+	Key         project_member.ProjectMemberRole `json:"key"`
+	Name        string                           `json:"name"`
+	Description string                           `json:"description,omitempty"`
+	BuiltIn     bool                             `json:"built_in"`
+	Permissions []project_member.Permission      `json:"permissions"`
+	ClonedFrom  project_member.ProjectMemberRole `json:"cloned_from,omitempty"`
+	CreatedAt   *time.Time                       `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time                       `json:"updated_at,omitempty"`
+}
+
+func newRoleView(r *project_member.CustomRole) RoleView {
+	createdAt, updatedAt := r.CreatedAt(), r.UpdatedAt()
+	return RoleView{
+		ID:          r.ID().String(),
+		Key:         r.Key(),
+		Name:        r.Name(),
+		Description: r.Description(),
+		Permissions: r.Permissions(),
+		ClonedFrom:  r.ClonedFrom(),
+		CreatedAt:   &createdAt,
+		UpdatedAt:   &updatedAt,
+	}
+}
+
+var builtInRoleNames = map[project_member.ProjectMemberRole]string{
+	project_member.RoleAdmin:      "Admin",
+	project_member.RoleSupervisor: "Supervisor",
+	project_member.RoleAgent:      "Agent",
+	project_member.RoleViewer:     "Viewer",
+}
+
+func builtInRoleViews() []RoleView {
+	views := make([]RoleView, 0, len(builtInRoleNames))
+	for _, role := range project_member.ValidRoles() {
+		views = append(views, RoleView{
+			Key:         role,
+			Name:        builtInRoleNames[role],
+			BuiltIn:     true,
+			Permissions: project_member.GetRolePermissions(role),
+		})
+	}
+	return views
+}
+
+// CreateRoleCommand criação de papel custom. Com CloneFrom (papel padrão ou custom do projeto) as
+// permissões partem do papel de origem; Permissions, se informado, substitui a cópia.
+type CreateRoleCommand struct {
+	ProjectID   uuid.UUID
+	Name        string
+	Description string
+	Permissions []project_member.Permission
+	CloneFrom   project_member.ProjectMemberRole
+	CreatedBy   uuid.UUID
+	// GrantablePermissions permissões de quem cria: o papel não pode ir além delas
+	// (nil = sem limite, dono ou admin)
+	GrantablePermissions []project_member.Permission
+}
+
+// UpdateRoleCommand alteração parcial de papel custom (nil = mantém)
+type UpdateRoleCommand struct {
+	ProjectID   uuid.UUID
+	RoleID      uuid.UUID
+	Name        *string
+	Description *string
+	Permissions []project_member.Permission
+	ChangedBy   uuid.UUID
+	// GrantablePermissions permissões de quem altera: as novas permissões não podem ir além delas
+	// (nil = sem limite, dono ou admin)
+	GrantablePermissions []project_member.Permission
+}
+
+// ManageRolesUseCase papéis custom do projeto, compostos pelas permissões existentes. Toda mudança
+// invalida o acesso em cache do projeto, então vale na próxima requisição ou ação do WebSocket.
+type ManageRolesUseCase struct {
+	roles       project_member.CustomRoleRepository
+	members     project_member.Repository
+	invitations project_member.InvitationRepository
+	projects    project.Repository
+	access      AccessInvalidator
+	eventBus    EventBus
+	txManager   TransactionManager
+	logger      *zap.Logger
+}
+
+func NewManageRolesUseCase(
+	roles project_member.CustomRoleRepository,
+	members project_member.Repository,
+	invitations project_member.InvitationRepository,
+	projects project.Repository,
+	access AccessInvalidator,
+	eventBus EventBus,
+	txManager TransactionManager,
+	logger *zap.Logger,
+) *ManageRolesUseCase {
+	return &ManageRolesUseCase{
+		roles:       roles,
+		members:     members,
+		invitations: invitations,
+		projects:    projects,
+		access:      access,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		logger:      logger,
+	}
+}
+
+// Permissions permissões que podem compor um papel custom
+func (uc *ManageRolesUseCase) Permissions() []project_member.Permission {
+	return project_member.AssignablePermissions()
+}
+
+// List papéis padrão seguidos dos custom do projeto
+func (uc *ManageRolesUseCase) List(ctx context.Context, projectID uuid.UUID) ([]RoleView, error) {
+	if _, err := loadProject(ctx, uc.projects, projectID); err != nil {
+		return nil, err
+	}
+	custom, err := uc.roles.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list project roles: %w", err)
+	}
+
+	views := builtInRoleViews()
+	for _, r := range custom {
+		views = append(views, newRoleView(r))
+	}
+	return views, nil
+}
+
+// Get papel custom do projeto
+func (uc *ManageRolesUseCase) Get(ctx context.Context, projectID, roleID uuid.UUID) (*RoleView, error) {
+	r, err := uc.find(ctx, projectID, roleID)
+	if err != nil {
+		return nil, err
+	}
+	view := newRoleView(r)
+	return &view, nil
+}
+
+// Create cria o papel custom, opcionalmente clonando um papel padrão ou custom
+func (uc *ManageRolesUseCase) Create(ctx context.Context, cmd CreateRoleCommand) (*RoleView, error) {
+	if _, err := loadProject(ctx, uc.projects, cmd.ProjectID); err != nil {
+		return nil, err
+	}
+
+	permissions := cmd.Permissions
+	if cmd.CloneFrom != "" {
+		source, err := uc.sourcePermissions(ctx, cmd.ProjectID, cmd.CloneFrom)
+		if err != nil {
+			return nil, err
+		}
+		if len(permissions) == 0 {
+			permissions = source
+		}
+	}
+	if err := ensureGrantable(cmd.GrantablePermissions, permissions); err != nil {
+		return nil, err
+	}
+
+	r, err := project_member.NewCustomRole(cmd.ProjectID, cmd.Name, cmd.Description, permissions, cmd.CloneFrom, cmd.CreatedBy.String())
+	if err != nil {
+		return nil, roleError(err)
+	}
+	_, err = uc.roles.FindByKey(ctx, cmd.ProjectID, r.Key())
+	switch {
+	case errors.Is(err, project_member.ErrCustomRoleNotFound):
+	case err != nil:
+		return nil, fmt.Errorf("failed to check project role: %w", err)
+	default:
+		return nil, shared.NewConflictError(project_member.ErrCustomRoleExists.Error())
+	}
+
+	if err := uc.save(ctx, r); err != nil {
+		return nil, err
+	}
+	auditapp.Annotate(ctx, "project_roles", r.ID().String(), nil, roleAuditState(r))
+
+	uc.logger.Info("Project role created",
+		zap.String("project_id", cmd.ProjectID.String()),
+		zap.String("role", string(r.Key())),
+		zap.String("cloned_from", string(cmd.CloneFrom)))
+
+	view := newRoleView(r)
+	return &view, nil
+}
+
+// Update altera nome, descrição e permissões do papel; as permissões novas valem na hora para
+// todos os membros com o papel
+func (uc *ManageRolesUseCase) Update(ctx context.Context, cmd UpdateRoleCommand) (*RoleView, error) {
+	r, err := uc.find(ctx, cmd.ProjectID, cmd.RoleID)
+	if err != nil {
+		return nil, err
+	}
+	before := roleAuditState(r)
+
+	if cmd.Name != nil {
+		if err := r.Rename(*cmd.Name); err != nil {
+			return nil, roleError(err)
+		}
+	}
+	if cmd.Description != nil {
+		r.UpdateDescription(*cmd.Description)
+	}
+	if cmd.Permissions != nil {
+		if err := ensureGrantable(cmd.GrantablePermissions, cmd.Permissions); err != nil {
+			return nil, err
+		}
+		if err := r.SetPermissions(cmd.Permissions, cmd.ChangedBy.String()); err != nil {
+			return nil, roleError(err)
+		}
+	}
+
+	if err := uc.save(ctx, r); err != nil {
+		return nil, err
+	}
+	auditapp.Annotate(ctx, "project_roles", r.ID().String(), before, roleAuditState(r))
+
+	uc.logger.Info("Project role updated",
+		zap.String("project_id", cmd.ProjectID.String()),
+		zap.String("role", string(r.Key())))
+
+	view := newRoleView(r)
+	return &view, nil
+}
+
+// Delete remove o papel. Membros e convites pendentes com o papel precisam ser movidos antes.
+func (uc *ManageRolesUseCase) Delete(ctx context.Context, projectID, roleID, deletedBy uuid.UUID) error {
+	r, err := uc.find(ctx, projectID, roleID)
+	if err != nil {
+		return err
+	}
+	inUse, err := uc.inUse(ctx, projectID, r.Key())
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return shared.NewPreconditionError(project_member.ErrCustomRoleInUse.Error())
+	}
+
+	r.MarkDeleted(deletedBy.String())
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.roles.Delete(txCtx, projectID, roleID); err != nil {
+			return fmt.Errorf("failed to delete project role: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, r.DomainEvents())
+	})
+	if err != nil {
+		return err
+	}
+	r.ClearEvents()
+	invalidateAccess(ctx, uc.access, projectID)
+	auditapp.Annotate(ctx, "project_roles", roleID.String(), roleAuditState(r), nil)
+
+	uc.logger.Info("Project role deleted",
+		zap.String("project_id", projectID.String()),
+		zap.String("role", string(r.Key())),
+		zap.String("deleted_by", deletedBy.String()))
+	return nil
+}
+
+func (uc *ManageRolesUseCase) find(ctx context.Context, projectID, roleID uuid.UUID) (*project_member.CustomRole, error) {
+	r, err := uc.roles.FindByID(ctx, projectID, roleID)
+	if errors.Is(err, project_member.ErrCustomRoleNotFound) {
+		return nil, shared.NewNotFoundError("project role", roleID.String())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project role: %w", err)
+	}
+	return r, nil
+}
+
+// sourcePermissions permissões do papel clonado (padrão ou custom do mesmo projeto)
+func (uc *ManageRolesUseCase) sourcePermissions(ctx context.Context, projectID uuid.UUID, role project_member.ProjectMemberRole) ([]project_member.Permission, error) {
+	if project_member.IsBuiltInRole(role) {
+		return project_member.GetRolePermissions(role), nil
+	}
+	if !project_member.IsCustomRole(role) {
+		return nil, shared.NewValidationError(project_member.ErrInvalidRole.Error(), "clone_from")
+	}
+	source, err := uc.roles.FindByKey(ctx, projectID, role)
+	if errors.Is(err, project_member.ErrCustomRoleNotFound) {
+		return nil, shared.NewValidationError(err.Error(), "clone_from")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project role: %w", err)
+	}
+	return source.Permissions(), nil
+}
+
+// inUse indica se algum membro ou convite pendente usa o papel
+func (uc *ManageRolesUseCase) inUse(ctx context.Context, projectID uuid.UUID, key project_member.ProjectMemberRole) (bool, error) {
+	members, err := uc.members.FindByProject(ctx, projectID)
+	if err != nil {
+		return false, fmt.Errorf("failed to list members: %w", err)
+	}
+	for _, m := range members {
+		if m.Role() == key {
+			return true, nil
+		}
+	}
+
+	pending, err := uc.invitations.FindByProject(ctx, projectID, project_member.InvitationPending)
+	if err != nil {
+		return false, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	for _, inv := range pending {
+		if inv.Role() == key {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (uc *ManageRolesUseCase) save(ctx context.Context, r *project_member.CustomRole) error {
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.roles.Save(txCtx, r); err != nil {
+			return fmt.Errorf("failed to save project role: %w", err)
+		}
+		return publishEvents(txCtx, uc.eventBus, r.DomainEvents())
+	})
+	if err != nil {
+		return err
+	}
+	r.ClearEvents()
+	invalidateAccess(ctx, uc.access, r.ProjectID())
+	return nil
+}
+
+func roleAuditState(r *project_member.CustomRole) map[string]interface{} {
+	return map[string]interface{}{
+		"key":         r.Key(),
+		"name":        r.Name(),
+		"description": r.Description(),
+		"permissions": r.Permissions(),
+	}
+}
+
+// rolePermissions permissões do papel a conceder: padrão pela tabela do domínio, custom pelo papel
+// existente no projeto. Papel inválido volta sem permissões e é recusado pelo domínio.
+func rolePermissions(ctx context.Context, roles project_member.CustomRoleRepository, projectID uuid.UUID, role project_member.ProjectMemberRole) ([]project_member.Permission, error) {
+	if !project_member.IsCustomRole(role) {
+		return project_member.GetRolePermissions(role), nil
+	}
+	if roles == nil {
+		return nil, shared.NewValidationError(project_member.ErrCustomRoleNotFound.Error(), "role")
+	}
+	r, err := roles.FindByKey(ctx, projectID, role)
+	if errors.Is(err, project_member.ErrCustomRoleNotFound) {
+		return nil, shared.NewValidationError(err.Error(), "role")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project role: %w", err)
+	}
+	return r.Permissions(), nil
+}
+
+// ensureGrantable impede que quem gerencia membros sem ser dono ou admin conceda (por papel, convite
+// ou troca de papel) permissão que não tem. grantable nil = sem limite.
+func ensureGrantable(grantable, permissions []project_member.Permission) error {
+	if grantable == nil {
+		return nil
+	}
+	for _, p := range permissions {
+		if !containsPermission(grantable, p) {
+			return shared.NewForbiddenError(fmt.Sprintf("cannot grant permission %s: you do not have it", p)).
+				WithDetail("permission", string(p))
+		}
+	}
+	return nil
+}
+
+func containsPermission(permissions []project_member.Permission, permission project_member.Permission) bool {
+	for _, p := range permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateAccess descarta o acesso em cache do projeto depois de uma mudança já gravada
+func invalidateAccess(ctx context.Context, access AccessInvalidator, projectID uuid.UUID) {
+	if access != nil {
+		access.InvalidateProject(ctx, projectID)
+	}
+}
+
+// roleError traduz os erros do domínio dos papéis custom para erros da aplicação
+func roleError(err error) error {
+	switch {
+	case errors.Is(err, project_member.ErrCustomRoleNameEmpty), errors.Is(err, project_member.ErrCustomRoleNameTooLong),
+		errors.Is(err, project_member.ErrInvalidRole):
+		return shared.NewValidationError(err.Error(), "name")
+	case errors.Is(err, project_member.ErrCustomRoleNoPermissions), errors.Is(err, project_member.ErrInvalidPermission),
+		errors.Is(err, project_member.ErrPermissionNotAssignable):
+		return shared.NewValidationError(err.Error(), "permissions")
+	}
+	return shared.NewValidationError(err.Error(), "")
+}
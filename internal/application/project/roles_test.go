@@ -0,0 +1,222 @@
+package project
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+	"go.uber.org/zap"
+)
+
+type rolesFixture struct {
+	uc          *ManageRolesUseCase
+	project     *project.Project
+	ownerID     uuid.UUID
+	roles       *fakeRoleRepository
+	members     *fakeMemberRepository
+	invitations *fakeInvitationRepository
+	access      *recordingInvalidator
+	eventBus    *recordingEventBus
+}
+
+func newRolesFixture(t *testing.T) *rolesFixture {
+	t.Helper()
+	ownerID := uuid.New()
+	p := newTestProject(t, ownerID, "Roles")
+
+	f := &rolesFixture{
+		project:     p,
+		ownerID:     ownerID,
+		roles:       newFakeRoleRepository(),
+		members:     newFakeMemberRepository(),
+		invitations: &fakeInvitationRepository{},
+		access:      &recordingInvalidator{},
+		eventBus:    &recordingEventBus{},
+	}
+	f.uc = NewManageRolesUseCase(f.roles, f.members, f.invitations, newFakeProjectRepository(p), f.access,
+		f.eventBus, &SimpleTransactionManager{}, zap.NewNop())
+	return f
+}
+
+func (f *rolesFixture) create(t *testing.T, cmd CreateRoleCommand) *RoleView {
+	t.Helper()
+	cmd.ProjectID = f.project.ID()
+	cmd.CreatedBy = f.ownerID
+	view, err := f.uc.Create(context.Background(), cmd)
+	require.NoError(t, err)
+	return view
+}
+
+func TestManageRoles_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("composes the given permissions", func(t *testing.T) {
+		f := newRolesFixture(t)
+		view := f.create(t, CreateRoleCommand{
+			Name:        "Sales Agent",
+			Permissions: []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionExportContacts},
+		})
+
+		assert.Equal(t, project_member.ProjectMemberRole("custom:sales-agent"), view.Key)
+		assert.False(t, view.BuiltIn)
+		assert.ElementsMatch(t, []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionExportContacts}, view.Permissions)
+		assert.Equal(t, []string{"project_role.created"}, f.eventBus.eventTypes())
+		assert.Equal(t, []uuid.UUID{f.project.ID()}, f.access.projects)
+	})
+
+	t.Run("clones built-in and custom roles", func(t *testing.T) {
+		f := newRolesFixture(t)
+		agent := f.create(t, CreateRoleCommand{Name: "Agent Copy", CloneFrom: project_member.RoleAgent})
+		assert.ElementsMatch(t, project_member.GetRolePermissions(project_member.RoleAgent), agent.Permissions)
+		assert.Equal(t, project_member.RoleAgent, agent.ClonedFrom)
+
+		clone := f.create(t, CreateRoleCommand{Name: "Agent Copy 2", CloneFrom: agent.Key})
+		assert.Equal(t, agent.Permissions, clone.Permissions)
+
+		// Permissões informadas substituem as do papel clonado
+		custom := f.create(t, CreateRoleCommand{
+			Name: "Viewer Lite", CloneFrom: project_member.RoleViewer,
+			Permissions: []project_member.Permission{project_member.PermissionViewSessions},
+		})
+		assert.Equal(t, []project_member.Permission{project_member.PermissionViewSessions}, custom.Permissions)
+
+		_, err := f.uc.Create(ctx, CreateRoleCommand{ProjectID: f.project.ID(), Name: "Ghost", CloneFrom: "custom:ghost"})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	t.Run("rejects invalid roles", func(t *testing.T) {
+		f := newRolesFixture(t)
+		f.create(t, CreateRoleCommand{Name: "Sales", Permissions: []project_member.Permission{project_member.PermissionViewContacts}})
+
+		_, err := f.uc.Create(ctx, CreateRoleCommand{ProjectID: f.project.ID(), Name: "SALES", Permissions: []project_member.Permission{project_member.PermissionViewSessions}})
+		assert.Equal(t, shared.ErrorTypeConflict, errorType(err))
+
+		for _, cmd := range []CreateRoleCommand{
+			{Name: "", Permissions: []project_member.Permission{project_member.PermissionViewContacts}},
+			{Name: "Empty"},
+			{Name: "Unknown", Permissions: []project_member.Permission{"contacts.delete"}},
+			{Name: "Billing", Permissions: []project_member.Permission{project_member.PermissionManageBilling}},
+		} {
+			cmd.ProjectID = f.project.ID()
+			_, err := f.uc.Create(ctx, cmd)
+			assert.True(t, shared.IsValidationError(err), cmd.Name)
+		}
+	})
+}
+
+func TestManageRoles_List(t *testing.T) {
+	f := newRolesFixture(t)
+	f.create(t, CreateRoleCommand{Name: "Sales", CloneFrom: project_member.RoleAgent})
+
+	roles, err := f.uc.List(context.Background(), f.project.ID())
+	require.NoError(t, err)
+	require.Len(t, roles, len(project_member.ValidRoles())+1)
+	assert.True(t, roles[0].BuiltIn)
+	assert.Equal(t, project_member.RoleAdmin, roles[0].Key)
+	assert.Equal(t, project_member.ProjectMemberRole("custom:sales"), roles[len(roles)-1].Key)
+}
+
+func TestManageRoles_Update(t *testing.T) {
+	ctx := context.Background()
+	f := newRolesFixture(t)
+	created := f.create(t, CreateRoleCommand{Name: "Sales", CloneFrom: project_member.RoleViewer})
+	roleID := uuid.MustParse(created.ID)
+
+	name := "Inside Sales"
+	view, err := f.uc.Update(ctx, UpdateRoleCommand{
+		ProjectID:   f.project.ID(),
+		RoleID:      roleID,
+		Name:        &name,
+		Permissions: []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionSendMessages},
+		ChangedBy:   f.ownerID,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Inside Sales", view.Name)
+	assert.Equal(t, created.Key, view.Key, "a chave não muda com o nome")
+	assert.Equal(t, []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionSendMessages}, view.Permissions)
+	assert.Contains(t, f.eventBus.eventTypes(), "project_role.permissions_changed")
+	assert.Len(t, f.access.projects, 2)
+
+	_, err = f.uc.Update(ctx, UpdateRoleCommand{ProjectID: f.project.ID(), RoleID: roleID, Permissions: []project_member.Permission{}})
+	assert.True(t, shared.IsValidationError(err))
+
+	_, err = f.uc.Update(ctx, UpdateRoleCommand{ProjectID: uuid.New(), RoleID: roleID, Name: &name})
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+// TestManageRoles_GrantLimitedToCallerPermissions quem gerencia membros por papel custom não cria
+// nem altera papel com permissão que não tem
+func TestManageRoles_GrantLimitedToCallerPermissions(t *testing.T) {
+	ctx := context.Background()
+	f := newRolesFixture(t)
+	grantable := []project_member.Permission{project_member.PermissionManageMembers, project_member.PermissionViewContacts, project_member.PermissionViewSessions}
+
+	for _, cmd := range []CreateRoleCommand{
+		{Name: "Settings", Permissions: []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionManageSettings}},
+		{Name: "Admin Copy", CloneFrom: project_member.RoleAdmin},
+	} {
+		cmd.ProjectID = f.project.ID()
+		cmd.GrantablePermissions = grantable
+		_, err := f.uc.Create(ctx, cmd)
+		assert.Equal(t, shared.ErrorTypeForbidden, errorType(err), cmd.Name)
+	}
+	assert.Empty(t, f.roles.roles)
+
+	created := f.create(t, CreateRoleCommand{
+		Name:                 "Readers",
+		Permissions:          []project_member.Permission{project_member.PermissionViewContacts},
+		GrantablePermissions: grantable,
+	})
+	roleID := uuid.MustParse(created.ID)
+
+	_, err := f.uc.Update(ctx, UpdateRoleCommand{
+		ProjectID: f.project.ID(), RoleID: roleID, ChangedBy: f.ownerID,
+		Permissions:          []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionExportContacts},
+		GrantablePermissions: grantable,
+	})
+	assert.Equal(t, shared.ErrorTypeForbidden, errorType(err))
+
+	view, err := f.uc.Update(ctx, UpdateRoleCommand{
+		ProjectID: f.project.ID(), RoleID: roleID, ChangedBy: f.ownerID,
+		Permissions:          []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionViewSessions},
+		GrantablePermissions: grantable,
+	})
+	require.NoError(t, err)
+	assert.Len(t, view.Permissions, 2)
+}
+
+func TestManageRoles_Delete(t *testing.T) {
+	ctx := context.Background()
+	f := newRolesFixture(t)
+	created := f.create(t, CreateRoleCommand{Name: "Sales", CloneFrom: project_member.RoleAgent})
+	roleID := uuid.MustParse(created.ID)
+
+	member, err := project_member.NewProjectMember(f.project.ID(), uuid.NewString(), created.Key, f.ownerID.String())
+	require.NoError(t, err)
+	require.NoError(t, f.members.Save(ctx, member))
+
+	err = f.uc.Delete(ctx, f.project.ID(), roleID, f.ownerID)
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+
+	require.NoError(t, member.ChangeRole(project_member.RoleAgent, f.ownerID.String()))
+	inv, _, err := project_member.NewInvitation(f.project.ID(), "maria@example.com", created.Key, f.ownerID.String(), 0)
+	require.NoError(t, err)
+	require.NoError(t, f.invitations.Save(ctx, inv))
+
+	err = f.uc.Delete(ctx, f.project.ID(), roleID, f.ownerID)
+	assert.Equal(t, shared.ErrorTypePrecondition, errorType(err))
+
+	require.NoError(t, inv.Revoke(inv.CreatedAt()))
+	require.NoError(t, f.uc.Delete(ctx, f.project.ID(), roleID, f.ownerID))
+	assert.Empty(t, f.roles.roles)
+	assert.Contains(t, f.eventBus.eventTypes(), "project_role.deleted")
+	assert.Len(t, f.access.projects, 2)
+
+	err = f.uc.Delete(ctx, f.project.ID(), roleID, f.ownerID)
+	assert.True(t, shared.IsNotFoundError(err))
+}
@@ -2,89 +2,184 @@ package queries
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/session"
 	"go.uber.org/zap"
 )
 
-// ConversationThreadQuery query to get full conversation thread for a contact
+// ConversationThreadQuery query to get full conversation thread for a contact.
+// A linha do tempo atravessa todas as sessões do contato e é paginada por cursor.
 type ConversationThreadQuery struct {
 	ContactID uuid.UUID
 	TenantID  shared.TenantID
-	ChannelID string // optional filter by channel
-	Limit     int    // limit messages per session
+	ChannelID *uuid.UUID // optional filter by channel
+	Cursor    string
+	Direction string // "older" (default) or "newer"
+	Limit     int    // messages per page
 }
 
 // ConversationThreadResponse response for conversation thread
 type ConversationThreadResponse struct {
 	Sessions []ConversationSessionDTO `json:"sessions"`
-	Count    int                      `json:"count"`
+	Count    int                      `json:"count"` // mensagens na página
+	HistoryPageInfo
 }
 
 // ConversationSessionDTO session with messages in conversation thread
 type ConversationSessionDTO struct {
-	SessionID   string                 `json:"session_id"`
-	ChannelID   string                 `json:"channel_id"`
-	ChannelName string                 `json:"channel_name,omitempty"`
-	AgentID     *string                `json:"agent_id,omitempty"`
-	AgentName   *string                `json:"agent_name,omitempty"`
-	Status      string                 `json:"status"`
-	StartedAt   string                 `json:"started_at"`
-	ClosedAt    *string                `json:"closed_at,omitempty"`
-	Messages    []ConversationMessage  `json:"messages"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	SessionID   string       `json:"session_id,omitempty"` // vazio para mensagens fora de sessão
+	ChannelID   string       `json:"channel_id"`
+	ChannelName *string      `json:"channel_name,omitempty"`
+	AgentID     *string      `json:"agent_id,omitempty"`
+	Status      string       `json:"status,omitempty"`
+	StartedAt   *string      `json:"started_at,omitempty"`
+	ClosedAt    *string      `json:"closed_at,omitempty"`
+	Messages    []MessageDTO `json:"messages"`
 }
 
-// ConversationMessage message in conversation thread
-type ConversationMessage struct {
-	ID          string                 `json:"id"`
-	Direction   string                 `json:"direction"`
-	Content     string                 `json:"content"`
-	ContentType string                 `json:"content_type"`
-	MediaURL    *string                `json:"media_url,omitempty"`
-	Status      string                 `json:"status"`
-	SenderName  string                 `json:"sender_name,omitempty"`
-	SentAt      string                 `json:"sent_at"`
-	ReadAt      *string                `json:"read_at,omitempty"`
-	TrackingID  *string                `json:"tracking_id,omitempty"`
-	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+// MessageThreadQuery query to rebuild the reply chain (reply_to_id) containing a message
+type MessageThreadQuery struct {
+	MessageID uuid.UUID
+	TenantID  shared.TenantID
+}
+
+// MessageThreadResponse response for message thread
+type MessageThreadResponse struct {
+	RootID   string       `json:"root_id"`
+	Messages []MessageDTO `json:"messages"`
+	Count    int          `json:"count"`
 }
 
-// ConversationThreadQueryHandler handles ConversationThreadQuery
+// ConversationThreadQueryHandler handles ConversationThreadQuery and MessageThreadQuery
 type ConversationThreadQueryHandler struct {
-	messageRepo message.Repository
+	historyRepo message.HistoryRepository
+	sessionRepo session.Repository
 	logger      *zap.Logger
 }
 
 // NewConversationThreadQueryHandler creates a new ConversationThreadQueryHandler
-func NewConversationThreadQueryHandler(messageRepo message.Repository, logger *zap.Logger) *ConversationThreadQueryHandler {
+func NewConversationThreadQueryHandler(historyRepo message.HistoryRepository, sessionRepo session.Repository, logger *zap.Logger) *ConversationThreadQueryHandler {
 	return &ConversationThreadQueryHandler{
-		messageRepo: messageRepo,
+		historyRepo: historyRepo,
+		sessionRepo: sessionRepo,
 		logger:      logger,
 	}
 }
 
 // Handle executes the ConversationThreadQuery
 func (h *ConversationThreadQueryHandler) Handle(ctx context.Context, query ConversationThreadQuery) (*ConversationThreadResponse, error) {
-	// TODO: Implement repository method to get conversation thread
-	// This should:
-	// 1. Get all sessions for contact (optionally filtered by channel)
-	// 2. Get messages for each session (limited)
-	// 3. Join with agent/channel information
-	// 4. Order by session start time DESC
-
-	h.logger.Info("Getting conversation thread",
-		zap.String("contact_id", query.ContactID.String()),
-		zap.String("channel_id", query.ChannelID),
-		zap.Int("limit", query.Limit))
-
-	// Placeholder - needs proper repository implementation with joins
+	filter := message.HistoryFilter{
+		TenantID:  query.TenantID.String(),
+		ContactID: &query.ContactID,
+		ChannelID: query.ChannelID,
+		Direction: shared.PageDirection(query.Direction),
+		Limit:     query.Limit,
+	}
+	if err := applyHistoryCursor(&filter, query.Cursor); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "filter")
+	}
+
+	page, err := h.historyRepo.FindHistory(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to get conversation thread",
+			zap.String("contact_id", query.ContactID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	// Agrupa mensagens consecutivas da mesma sessão, preservando a ordem cronológica
 	sessions := []ConversationSessionDTO{}
+	for _, m := range page.Messages {
+		dto := toMessageDTO(m)
+		sessionKey := ""
+		if m.SessionID != nil {
+			sessionKey = m.SessionID.String()
+		}
+
+		if n := len(sessions); n > 0 && sessions[n-1].SessionID == sessionKey {
+			sessions[n-1].Messages = append(sessions[n-1].Messages, dto)
+			continue
+		}
+
+		group := ConversationSessionDTO{
+			SessionID:   sessionKey,
+			ChannelID:   m.ChannelID.String(),
+			ChannelName: m.ChannelName,
+			Messages:    []MessageDTO{dto},
+		}
+		if m.SessionID != nil {
+			h.describeSession(ctx, *m.SessionID, &group)
+		}
+		sessions = append(sessions, group)
+	}
 
 	return &ConversationThreadResponse{
-		Sessions: sessions,
-		Count:    len(sessions),
+		Sessions:        sessions,
+		Count:           len(page.Messages),
+		HistoryPageInfo: toHistoryPageInfo(page, filter.Limit),
 	}, nil
 }
+
+// HandleMessageThread executes the MessageThreadQuery
+func (h *ConversationThreadQueryHandler) HandleMessageThread(ctx context.Context, query MessageThreadQuery) (*MessageThreadResponse, error) {
+	messages, err := h.historyRepo.FindReplyThread(ctx, query.TenantID.String(), query.MessageID)
+	if err != nil {
+		if errors.Is(err, message.ErrMessageNotFound) {
+			return nil, shared.NewNotFoundError("message", query.MessageID.String())
+		}
+		h.logger.Error("Failed to get message thread",
+			zap.String("message_id", query.MessageID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	response := &MessageThreadResponse{
+		Messages: make([]MessageDTO, len(messages)),
+		Count:    len(messages),
+	}
+	for i, m := range messages {
+		response.Messages[i] = toMessageDTO(m)
+	}
+
+	// A raiz é a única mensagem da thread que não cita outra mensagem da thread
+	inThread := make(map[uuid.UUID]bool, len(messages))
+	for _, m := range messages {
+		inThread[m.ID] = true
+	}
+	for _, m := range messages {
+		if m.ReplyToID == nil || !inThread[*m.ReplyToID] {
+			response.RootID = m.ID.String()
+			break
+		}
+	}
+
+	return response, nil
+}
+
+// describeSession completa o grupo com status, período e agente da sessão
+func (h *ConversationThreadQueryHandler) describeSession(ctx context.Context, sessionID uuid.UUID, group *ConversationSessionDTO) {
+	sess, err := h.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		h.logger.Warn("Session not found for conversation thread",
+			zap.String("session_id", sessionID.String()),
+			zap.Error(err))
+		return
+	}
+
+	group.Status = string(sess.Status())
+	startedAt := sess.StartedAt().Format(time.RFC3339)
+	group.StartedAt = &startedAt
+	group.ClosedAt = timePtrString(sess.EndedAt())
+
+	if agentIDs := sess.AgentIDs(); len(agentIDs) > 0 {
+		group.AgentID = uuidPtrString(&agentIDs[len(agentIDs)-1])
+	}
+}
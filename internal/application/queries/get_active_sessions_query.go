@@ -2,7 +2,9 @@ package queries
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/session"
 	"go.uber.org/zap"
@@ -10,10 +12,13 @@ import (
 
 // GetActiveSessionsQuery query to get active sessions
 type GetActiveSessionsQuery struct {
-	TenantID  shared.TenantID
-	ChannelID string
-	AgentID   string
-	Limit     int
+	TenantID   shared.TenantID
+	ChannelID  *uuid.UUID
+	AgentID    *uuid.UUID
+	Unassigned bool
+	PipelineID *uuid.UUID
+	MinWaiting time.Duration
+	Limit      int
 }
 
 // GetActiveSessionsResponse response for active sessions query
@@ -28,44 +33,88 @@ type ActiveSessionDTO struct {
 	ContactID       string  `json:"contact_id"`
 	ContactName     string  `json:"contact_name"`
 	ContactPhone    string  `json:"contact_phone"`
-	ChannelID       string  `json:"channel_id"`
+	ChannelID       *string `json:"channel_id,omitempty"`
 	AgentID         *string `json:"agent_id,omitempty"`
 	AgentName       *string `json:"agent_name,omitempty"`
+	PipelineID      *string `json:"pipeline_id,omitempty"`
 	Status          string  `json:"status"`
 	StartedAt       string  `json:"started_at"`
 	LastMessageAt   *string `json:"last_message_at,omitempty"`
 	MessageCount    int     `json:"message_count"`
-	UnreadCount     int     `json:"unread_count"`
+	UnansweredCount int     `json:"unanswered_count"`
+	WaitingSince    *string `json:"waiting_since,omitempty"`
+	WaitingSeconds  int64   `json:"waiting_seconds"`
 	WaitingTime     string  `json:"waiting_time"`
 	SessionDuration string  `json:"session_duration"`
 }
 
 // GetActiveSessionsQueryHandler handles GetActiveSessionsQuery
 type GetActiveSessionsQueryHandler struct {
-	sessionRepo session.Repository
+	historyRepo session.HistoryRepository
 	logger      *zap.Logger
 }
 
 // NewGetActiveSessionsQueryHandler creates a new GetActiveSessionsQueryHandler
-func NewGetActiveSessionsQueryHandler(sessionRepo session.Repository, logger *zap.Logger) *GetActiveSessionsQueryHandler {
+func NewGetActiveSessionsQueryHandler(historyRepo session.HistoryRepository, logger *zap.Logger) *GetActiveSessionsQueryHandler {
 	return &GetActiveSessionsQueryHandler{
-		sessionRepo: sessionRepo,
+		historyRepo: historyRepo,
 		logger:      logger,
 	}
 }
 
 // Handle executes the GetActiveSessionsQuery
 func (h *GetActiveSessionsQueryHandler) Handle(ctx context.Context, query GetActiveSessionsQuery) (*GetActiveSessionsResponse, error) {
-	// TODO: Implement repository method FindActiveByTenant with filters
-	// For now, using basic FindAll (needs implementation)
+	now := time.Now()
+	filter := session.ActiveFilter{
+		TenantID:   query.TenantID.String(),
+		ChannelID:  query.ChannelID,
+		AgentID:    query.AgentID,
+		Unassigned: query.Unassigned,
+		PipelineID: query.PipelineID,
+		MinWaiting: query.MinWaiting,
+		Limit:      query.Limit,
+		Now:        now,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "filter")
+	}
 
-	h.logger.Info("Getting active sessions",
-		zap.String("tenant_id", query.TenantID.String()),
-		zap.String("channel_id", query.ChannelID),
-		zap.String("agent_id", query.AgentID))
+	entries, err := h.historyRepo.FindActive(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to get active sessions",
+			zap.String("tenant_id", query.TenantID.String()),
+			zap.Error(err))
+		return nil, err
+	}
 
-	// Placeholder - needs proper repository implementation
-	sessions := []ActiveSessionDTO{}
+	sessions := make([]ActiveSessionDTO, len(entries))
+	for i, e := range entries {
+		waiting := e.WaitingTime(now)
+		dto := ActiveSessionDTO{
+			ID:              e.ID.String(),
+			ContactID:       e.ContactID.String(),
+			ChannelID:       uuidPtrString(e.ChannelID),
+			AgentID:         uuidPtrString(e.AgentID),
+			AgentName:       e.AgentName,
+			PipelineID:      uuidPtrString(e.PipelineID),
+			Status:          e.Status,
+			StartedAt:       e.StartedAt.Format(time.RFC3339),
+			LastMessageAt:   timePtrString(e.LastMessageAt),
+			MessageCount:    e.MessageCount,
+			UnansweredCount: e.UnansweredCount,
+			WaitingSince:    timePtrString(e.WaitingSince),
+			WaitingSeconds:  int64(waiting / time.Second),
+			WaitingTime:     waiting.Round(time.Second).String(),
+			SessionDuration: now.Sub(e.StartedAt).Round(time.Second).String(),
+		}
+		if e.ContactName != nil {
+			dto.ContactName = *e.ContactName
+		}
+		if e.ContactPhone != nil {
+			dto.ContactPhone = *e.ContactPhone
+		}
+		sessions[i] = dto
+	}
 
 	return &GetActiveSessionsResponse{
 		Sessions: sessions,
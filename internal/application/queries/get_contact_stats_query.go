@@ -2,6 +2,7 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/core/shared"
@@ -33,13 +34,15 @@ type GetContactStatsResponse struct {
 // GetContactStatsQueryHandler handles GetContactStatsQuery
 type GetContactStatsQueryHandler struct {
 	contactRepo contact.Repository
+	statsRepo   contact.StatsRepository
 	logger      *zap.Logger
 }
 
 // NewGetContactStatsQueryHandler creates a new GetContactStatsQueryHandler
-func NewGetContactStatsQueryHandler(contactRepo contact.Repository, logger *zap.Logger) *GetContactStatsQueryHandler {
+func NewGetContactStatsQueryHandler(contactRepo contact.Repository, statsRepo contact.StatsRepository, logger *zap.Logger) *GetContactStatsQueryHandler {
 	return &GetContactStatsQueryHandler{
 		contactRepo: contactRepo,
+		statsRepo:   statsRepo,
 		logger:      logger,
 	}
 }
@@ -61,17 +64,39 @@ func (h *GetContactStatsQueryHandler) Handle(ctx context.Context, query GetConta
 		return nil, contact.ErrContactNotFound
 	}
 
-	// TODO: Implement aggregated queries in repository
-	// For now, return basic stats from contact entity
+	stats, err := h.statsRepo.GetStats(ctx, query.ContactID)
+	if err != nil {
+		h.logger.Error("Failed to aggregate contact stats",
+			zap.String("contact_id", query.ContactID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	// Sem mensagens, usa as datas do próprio contato
+	firstContactAt := c.CreatedAt()
+	if stats.FirstInteractionAt != nil {
+		firstContactAt = *stats.FirstInteractionAt
+	}
+	lastContactAt := c.UpdatedAt()
+	if stats.LastInteractionAt != nil {
+		lastContactAt = *stats.LastInteractionAt
+	}
+
+	pipelineStatuses := make(map[string]string, len(stats.PipelineStatuses))
+	for _, status := range stats.PipelineStatuses {
+		pipelineStatuses[status.PipelineName] = status.StatusName
+	}
+
 	return &GetContactStatsResponse{
-		ContactID:      c.ID().String(),
-		TotalMessages:  0, // TODO: Query from message repository
-		TotalSessions:  0, // TODO: Query from session repository
-		ActiveSessions: 0, // TODO: Query from session repository
-		FirstContactAt: c.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		LastContactAt:  c.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		// PipelineStatuses: c.PipelineStatuses, // TODO: Map pipeline statuses
-		ConversionEvents: 0, // TODO: Query from tracking repository
-		TrackingCount:    0, // TODO: Query from tracking repository
+		ContactID:              c.ID().String(),
+		TotalMessages:          stats.TotalMessages,
+		TotalSessions:          stats.TotalSessions,
+		ActiveSessions:         stats.ActiveSessions,
+		AverageSessionDuration: stats.AverageSessionDuration.Round(time.Second).String(),
+		FirstContactAt:         firstContactAt.Format(time.RFC3339),
+		LastContactAt:          lastContactAt.Format(time.RFC3339),
+		PipelineStatuses:       pipelineStatuses,
+		ConversionEvents:       stats.ConvertedSessions,
+		TrackingCount:          stats.TrackingCount,
 	}, nil
 }
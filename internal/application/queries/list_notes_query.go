@@ -21,6 +21,7 @@ type ListNotesQuery struct {
 	Priority        *string
 	VisibleToClient *bool
 	Pinned          *bool
+	MentionedAgent  *uuid.UUID
 	CreatedAfter    *time.Time
 	CreatedBefore   *time.Time
 	Page            int
@@ -52,10 +53,43 @@ type NoteDTO struct {
 	VisibleToClient bool     `json:"visible_to_client"`
 	Pinned          bool     `json:"pinned"`
 	Tags            []string `json:"tags,omitempty"`
+	Mentions        []string `json:"mentions,omitempty"`
+	Attachments     []string `json:"attachments,omitempty"`
 	CreatedAt       string   `json:"created_at"`
 	UpdatedAt       string   `json:"updated_at"`
 }
 
+// NewNoteDTO converts a note aggregate to its DTO
+func NewNoteDTO(n *note.Note) NoteDTO {
+	dto := NoteDTO{
+		ID:              n.ID().String(),
+		ContactID:       n.ContactID().String(),
+		AuthorID:        n.AuthorID().String(),
+		AuthorType:      string(n.AuthorType()),
+		AuthorName:      n.AuthorName(),
+		Content:         n.Content(),
+		NoteType:        string(n.NoteType()),
+		Priority:        string(n.Priority()),
+		VisibleToClient: n.VisibleToClient(),
+		Pinned:          n.Pinned(),
+		Tags:            n.Tags(),
+		Attachments:     n.Attachments(),
+		CreatedAt:       n.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+		UpdatedAt:       n.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	if sessionID := n.SessionID(); sessionID != nil {
+		sessionStr := sessionID.String()
+		dto.SessionID = &sessionStr
+	}
+
+	for _, agentID := range n.Mentions() {
+		dto.Mentions = append(dto.Mentions, agentID.String())
+	}
+
+	return dto
+}
+
 // ListNotesQueryHandler handles ListNotesQuery
 type ListNotesQueryHandler struct {
 	noteRepo note.Repository
@@ -88,6 +122,7 @@ func (h *ListNotesQueryHandler) Handle(ctx context.Context, query ListNotesQuery
 		Priority:        query.Priority,
 		VisibleToClient: query.VisibleToClient,
 		Pinned:          query.Pinned,
+		MentionedAgent:  query.MentionedAgent,
 		CreatedAfter:    query.CreatedAfter,
 		CreatedBefore:   query.CreatedBefore,
 		Limit:           query.Limit,
@@ -106,28 +141,7 @@ func (h *ListNotesQueryHandler) Handle(ctx context.Context, query ListNotesQuery
 	// Convert domain entities to DTOs
 	noteDTOs := make([]NoteDTO, len(notes))
 	for i, n := range notes {
-		dto := NoteDTO{
-			ID:              n.ID().String(),
-			ContactID:       n.ContactID().String(),
-			AuthorID:        n.AuthorID().String(),
-			AuthorType:      string(n.AuthorType()),
-			AuthorName:      n.AuthorName(),
-			Content:         n.Content(),
-			NoteType:        string(n.NoteType()),
-			Priority:        string(n.Priority()),
-			VisibleToClient: n.VisibleToClient(),
-			Pinned:          n.Pinned(),
-			Tags:            n.Tags(),
-			CreatedAt:       n.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-			UpdatedAt:       n.UpdatedAt().Format("2006-01-02T15:04:05Z07:00"),
-		}
-
-		if sessionID := n.SessionID(); sessionID != nil {
-			sessionStr := sessionID.String()
-			dto.SessionID = &sessionStr
-		}
-
-		noteDTOs[i] = dto
+		noteDTOs[i] = NewNoteDTO(n)
 	}
 
 	// Calculate pagination
@@ -2,81 +2,234 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/message"
+	"github.com/ventros/crm/internal/domain/crm/session"
 	"go.uber.org/zap"
 )
 
-// MessageHistoryQuery query to get message history for a session
+// MessageHistoryQuery query to get message history for a session (keyset pagination)
 type MessageHistoryQuery struct {
 	SessionID uuid.UUID
 	TenantID  shared.TenantID
-	Page      int
+	Cursor    string // token opaco de uma resposta anterior; vazio = extremidade da conversa
+	Direction string // "older" (default) or "newer"
 	Limit     int
-	Direction string // "asc" or "desc"
 }
 
 // MessageHistoryResponse response for message history
 type MessageHistoryResponse struct {
-	Messages   []MessageDTO `json:"messages"`
-	TotalCount int64        `json:"total_count"`
-	Page       int          `json:"page"`
-	Limit      int          `json:"limit"`
+	Messages []MessageDTO `json:"messages"`
+	HistoryPageInfo
+}
+
+// HistoryPageInfo cursores para infinite scroll nos dois sentidos
+type HistoryPageInfo struct {
+	OlderCursor *string `json:"older_cursor,omitempty"`
+	NewerCursor *string `json:"newer_cursor,omitempty"`
+	HasOlder    bool    `json:"has_older"`
+	HasNewer    bool    `json:"has_newer"`
+	Limit       int     `json:"limit"`
 }
 
 // MessageDTO data transfer object for message
 type MessageDTO struct {
-	ID           string                 `json:"id"`
-	SessionID    string                 `json:"session_id"`
-	ContactID    string                 `json:"contact_id"`
-	Direction    string                 `json:"direction"` // "inbound" or "outbound"
-	Content      string                 `json:"content"`
-	ContentType  string                 `json:"content_type"`
-	MediaURL     *string                `json:"media_url,omitempty"`
-	Status       string                 `json:"status"`
-	SentAt       *string                `json:"sent_at,omitempty"`
-	DeliveredAt  *string                `json:"delivered_at,omitempty"`
-	ReadAt       *string                `json:"read_at,omitempty"`
-	FailedAt     *string                `json:"failed_at,omitempty"`
-	ErrorMessage *string                `json:"error_message,omitempty"`
-	Metadata     map[string]interface{} `json:"metadata,omitempty"`
-	TrackingID   *string                `json:"tracking_id,omitempty"`
-	CreatedAt    string                 `json:"created_at"`
+	ID            string                 `json:"id"`
+	SessionID     *string                `json:"session_id,omitempty"`
+	ContactID     string                 `json:"contact_id"`
+	ChannelID     string                 `json:"channel_id"`
+	ChannelName   *string                `json:"channel_name,omitempty"`
+	Direction     string                 `json:"direction"` // "inbound" or "outbound"
+	Content       string                 `json:"content"`
+	ContentType   string                 `json:"content_type"`
+	MediaURL      *string                `json:"media_url,omitempty"`
+	MediaMimetype *string                `json:"media_mimetype,omitempty"`
+	Status        string                 `json:"status"`
+	AgentID       *string                `json:"agent_id,omitempty"`
+	AgentName     *string                `json:"agent_name,omitempty"`
+	ReplyToID     *string                `json:"reply_to_id,omitempty"`
+	ReplyTo       *ReplyPreviewDTO       `json:"reply_to,omitempty"`
+	Enrichments   []EnrichmentDTO        `json:"enrichments,omitempty"`
+	SentAt        string                 `json:"sent_at"`
+	DeliveredAt   *string                `json:"delivered_at,omitempty"`
+	ReadAt        *string                `json:"read_at,omitempty"`
+	Metadata      map[string]interface{} `json:"metadata,omitempty"`
+	CreatedAt     string                 `json:"created_at"`
+}
+
+// ReplyPreviewDTO mensagem citada por uma resposta
+type ReplyPreviewDTO struct {
+	ID          string  `json:"id"`
+	Direction   string  `json:"direction"`
+	Content     *string `json:"content,omitempty"`
+	ContentType string  `json:"content_type"`
+	SentAt      string  `json:"sent_at"`
+}
+
+// EnrichmentDTO resultado de processamento de mídia (transcrição, OCR, parsing)
+type EnrichmentDTO struct {
+	ContentType   string  `json:"content_type"`
+	Provider      string  `json:"provider"`
+	Status        string  `json:"status"`
+	ExtractedText *string `json:"extracted_text,omitempty"`
+	ProcessedAt   *string `json:"processed_at,omitempty"`
 }
 
 // MessageHistoryQueryHandler handles MessageHistoryQuery
 type MessageHistoryQueryHandler struct {
-	messageRepo message.Repository
+	historyRepo message.HistoryRepository
+	sessionRepo session.Repository
 	logger      *zap.Logger
 }
 
 // NewMessageHistoryQueryHandler creates a new MessageHistoryQueryHandler
-func NewMessageHistoryQueryHandler(messageRepo message.Repository, logger *zap.Logger) *MessageHistoryQueryHandler {
+func NewMessageHistoryQueryHandler(historyRepo message.HistoryRepository, sessionRepo session.Repository, logger *zap.Logger) *MessageHistoryQueryHandler {
 	return &MessageHistoryQueryHandler{
-		messageRepo: messageRepo,
+		historyRepo: historyRepo,
+		sessionRepo: sessionRepo,
 		logger:      logger,
 	}
 }
 
 // Handle executes the MessageHistoryQuery
 func (h *MessageHistoryQueryHandler) Handle(ctx context.Context, query MessageHistoryQuery) (*MessageHistoryResponse, error) {
-	// TODO: Implement repository method FindBySessionWithPagination
-	// For now, using basic FindBySession if it exists
+	sess, err := h.sessionRepo.FindByID(ctx, query.SessionID)
+	if err != nil || sess.TenantID() != query.TenantID.String() {
+		return nil, shared.NewNotFoundError("session", query.SessionID.String())
+	}
 
-	h.logger.Info("Getting message history",
-		zap.String("session_id", query.SessionID.String()),
-		zap.Int("page", query.Page),
-		zap.Int("limit", query.Limit))
+	filter := message.HistoryFilter{
+		TenantID:  query.TenantID.String(),
+		SessionID: &query.SessionID,
+		Direction: shared.PageDirection(query.Direction),
+		Limit:     query.Limit,
+	}
+	if err := applyHistoryCursor(&filter, query.Cursor); err != nil {
+		return nil, err
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "filter")
+	}
 
-	// Placeholder - needs proper repository implementation
-	messages := []MessageDTO{}
+	page, err := h.historyRepo.FindHistory(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to get message history",
+			zap.String("session_id", query.SessionID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	messages := make([]MessageDTO, len(page.Messages))
+	for i, m := range page.Messages {
+		messages[i] = toMessageDTO(m)
+	}
 
 	return &MessageHistoryResponse{
-		Messages:   messages,
-		TotalCount: 0,
-		Page:       query.Page,
-		Limit:      query.Limit,
+		Messages:        messages,
+		HistoryPageInfo: toHistoryPageInfo(page, filter.Limit),
 	}, nil
 }
+
+func applyHistoryCursor(filter *message.HistoryFilter, token string) error {
+	if token == "" {
+		return nil
+	}
+	cursor, err := shared.DecodeCursor(token)
+	if err != nil {
+		return shared.NewValidationError(err.Error(), "cursor")
+	}
+	filter.Cursor = &cursor
+	return nil
+}
+
+func toHistoryPageInfo(page *message.HistoryPage, limit int) HistoryPageInfo {
+	info := HistoryPageInfo{
+		HasOlder: page.HasOlder,
+		HasNewer: page.HasNewer,
+		Limit:    limit,
+	}
+	if cursor := page.OlderCursor(); cursor != nil {
+		token := cursor.Encode()
+		info.OlderCursor = &token
+	}
+	if cursor := page.NewerCursor(); cursor != nil {
+		token := cursor.Encode()
+		info.NewerCursor = &token
+	}
+	return info
+}
+
+func toMessageDTO(m *message.HistoryMessage) MessageDTO {
+	dto := MessageDTO{
+		ID:            m.ID.String(),
+		SessionID:     uuidPtrString(m.SessionID),
+		ContactID:     m.ContactID.String(),
+		ChannelID:     m.ChannelID.String(),
+		ChannelName:   m.ChannelName,
+		Direction:     messageDirection(m.FromMe),
+		ContentType:   m.ContentType,
+		MediaURL:      m.MediaURL,
+		MediaMimetype: m.MediaMimetype,
+		Status:        m.Status,
+		AgentID:       uuidPtrString(m.AgentID),
+		AgentName:     m.AgentName,
+		ReplyToID:     uuidPtrString(m.ReplyToID),
+		SentAt:        m.Timestamp.Format(time.RFC3339Nano),
+		DeliveredAt:   timePtrString(m.DeliveredAt),
+		ReadAt:        timePtrString(m.ReadAt),
+		Metadata:      m.Metadata,
+		CreatedAt:     m.CreatedAt.Format(time.RFC3339),
+	}
+
+	if m.Text != nil {
+		dto.Content = *m.Text
+	}
+
+	if m.ReplyTo != nil {
+		dto.ReplyTo = &ReplyPreviewDTO{
+			ID:          m.ReplyTo.ID.String(),
+			Direction:   messageDirection(m.ReplyTo.FromMe),
+			Content:     m.ReplyTo.Text,
+			ContentType: m.ReplyTo.ContentType,
+			SentAt:      m.ReplyTo.Timestamp.Format(time.RFC3339Nano),
+		}
+	}
+
+	for _, e := range m.Enrichments {
+		dto.Enrichments = append(dto.Enrichments, EnrichmentDTO{
+			ContentType:   e.ContentType,
+			Provider:      e.Provider,
+			Status:        e.Status,
+			ExtractedText: e.ExtractedText,
+			ProcessedAt:   timePtrString(e.ProcessedAt),
+		})
+	}
+
+	return dto
+}
+
+func messageDirection(fromMe bool) string {
+	if fromMe {
+		return "outbound"
+	}
+	return "inbound"
+}
+
+func uuidPtrString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+	s := id.String()
+	return &s
+}
+
+func timePtrString(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := t.Format(time.RFC3339)
+	return &s
+}
@@ -2,8 +2,11 @@ package queries
 
 import (
 	"context"
+	"strconv"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
 	"github.com/ventros/crm/internal/domain/core/shared"
 	"github.com/ventros/crm/internal/domain/crm/session"
 	"go.uber.org/zap"
@@ -11,12 +14,13 @@ import (
 
 // SessionAnalyticsQuery query to get session analytics
 type SessionAnalyticsQuery struct {
-	TenantID  shared.TenantID
-	StartDate time.Time
-	EndDate   time.Time
-	ChannelID string
-	AgentID   string
-	GroupBy   string // day, week, month
+	TenantID      shared.TenantID
+	StartDate     time.Time
+	EndDate       time.Time
+	ChannelTypeID *int
+	AgentID       *uuid.UUID
+	GroupBy       string // day, week, month
+	Timezone      string // IANA; vazio = fuso do projeto do tenant
 }
 
 // SessionAnalyticsResponse response for session analytics
@@ -24,60 +28,185 @@ type SessionAnalyticsResponse struct {
 	TotalSessions       int64               `json:"total_sessions"`
 	ActiveSessions      int64               `json:"active_sessions"`
 	ClosedSessions      int64               `json:"closed_sessions"`
+	ResolvedSessions    int64               `json:"resolved_sessions"`
+	EscalatedSessions   int64               `json:"escalated_sessions"`
+	ConvertedSessions   int64               `json:"converted_sessions"`
 	AverageDuration     string              `json:"average_duration"`
 	AverageWaitTime     string              `json:"average_wait_time"`
 	AverageResponseTime string              `json:"average_response_time"`
+	ResponseTime        DurationSummary     `json:"response_time"`
+	WaitTime            DurationSummary     `json:"wait_time"`
 	MessagesPerSession  float64             `json:"messages_per_session"`
 	SessionsByStatus    map[string]int64    `json:"sessions_by_status"`
 	SessionsByChannel   map[string]int64    `json:"sessions_by_channel"`
 	SessionsByAgent     map[string]int64    `json:"sessions_by_agent"`
 	SessionsByHour      map[int]int64       `json:"sessions_by_hour"`
 	Timeline            []TimelineDataPoint `json:"timeline"`
+	Timezone            string              `json:"timezone"`
+	GroupBy             string              `json:"group_by"`
+	RefreshedAt         *time.Time          `json:"refreshed_at,omitempty"`
+}
+
+// DurationSummary resume uma métrica de tempo em segundos (percentis estimados por histograma)
+type DurationSummary struct {
+	Samples        int64   `json:"samples"`
+	AverageSeconds float64 `json:"average_seconds"`
+	P50Seconds     float64 `json:"p50_seconds"`
+	P90Seconds     float64 `json:"p90_seconds"`
+	P95Seconds     float64 `json:"p95_seconds"`
 }
 
 // TimelineDataPoint represents a data point in time series
 type TimelineDataPoint struct {
-	Timestamp string `json:"timestamp"`
-	Count     int64  `json:"count"`
+	Timestamp                  string  `json:"timestamp"`
+	Count                      int64   `json:"count"`
+	Closed                     int64   `json:"closed"`
+	Resolved                   int64   `json:"resolved"`
+	AverageResponseTimeSeconds float64 `json:"average_response_time_seconds"`
+	P90ResponseTimeSeconds     float64 `json:"p90_response_time_seconds"`
 }
 
+const (
+	unassignedAgentKey = "unassigned"
+	unknownChannelKey  = "unknown"
+)
+
 // SessionAnalyticsQueryHandler handles SessionAnalyticsQuery
 type SessionAnalyticsQueryHandler struct {
-	sessionRepo session.Repository
-	logger      *zap.Logger
+	analyticsRepo session.AnalyticsRepository
+	projectRepo   project.Repository
+	logger        *zap.Logger
 }
 
 // NewSessionAnalyticsQueryHandler creates a new SessionAnalyticsQueryHandler
-func NewSessionAnalyticsQueryHandler(sessionRepo session.Repository, logger *zap.Logger) *SessionAnalyticsQueryHandler {
+func NewSessionAnalyticsQueryHandler(analyticsRepo session.AnalyticsRepository, projectRepo project.Repository, logger *zap.Logger) *SessionAnalyticsQueryHandler {
 	return &SessionAnalyticsQueryHandler{
-		sessionRepo: sessionRepo,
-		logger:      logger,
+		analyticsRepo: analyticsRepo,
+		projectRepo:   projectRepo,
+		logger:        logger,
 	}
 }
 
 // Handle executes the SessionAnalyticsQuery
 func (h *SessionAnalyticsQueryHandler) Handle(ctx context.Context, query SessionAnalyticsQuery) (*SessionAnalyticsResponse, error) {
-	// TODO: Implement analytics queries in repository
-	// This should use materialized views or pre-aggregated data for performance
-
-	h.logger.Info("Getting session analytics",
-		zap.String("tenant_id", query.TenantID.String()),
-		zap.Time("start_date", query.StartDate),
-		zap.Time("end_date", query.EndDate))
-
-	// Placeholder - needs proper repository implementation with aggregations
-	return &SessionAnalyticsResponse{
-		TotalSessions:       0,
-		ActiveSessions:      0,
-		ClosedSessions:      0,
-		AverageDuration:     "0s",
-		AverageWaitTime:     "0s",
-		AverageResponseTime: "0s",
-		MessagesPerSession:  0.0,
-		SessionsByStatus:    make(map[string]int64),
-		SessionsByChannel:   make(map[string]int64),
-		SessionsByAgent:     make(map[string]int64),
-		SessionsByHour:      make(map[int]int64),
-		Timeline:            []TimelineDataPoint{},
-	}, nil
+	location, err := h.resolveLocation(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := session.AnalyticsFilter{
+		TenantID:      query.TenantID.String(),
+		StartDate:     query.StartDate,
+		EndDate:       query.EndDate,
+		ChannelTypeID: query.ChannelTypeID,
+		AgentID:       query.AgentID,
+		GroupBy:       session.AnalyticsGroupBy(query.GroupBy),
+		Location:      location,
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "filter")
+	}
+
+	report, err := h.analyticsRepo.GetAnalytics(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to get session analytics",
+			zap.String("tenant_id", query.TenantID.String()),
+			zap.Error(err))
+		return nil, err
+	}
+
+	totals := report.Totals
+	response := &SessionAnalyticsResponse{
+		TotalSessions:       totals.Sessions,
+		ActiveSessions:      totals.Active,
+		ClosedSessions:      totals.Closed,
+		ResolvedSessions:    totals.Resolved,
+		EscalatedSessions:   totals.Escalated,
+		ConvertedSessions:   totals.Converted,
+		AverageDuration:     formatSeconds(totals.Duration.Average()),
+		AverageWaitTime:     formatSeconds(totals.WaitTime.Average()),
+		AverageResponseTime: formatSeconds(totals.ResponseTime.Average()),
+		ResponseTime:        summarizeDuration(totals.ResponseTime),
+		WaitTime:            summarizeDuration(totals.WaitTime),
+		SessionsByStatus:    report.ByStatus,
+		SessionsByChannel:   make(map[string]int64, len(report.ByChannel)),
+		SessionsByAgent:     make(map[string]int64, len(report.ByAgent)),
+		SessionsByHour:      report.ByHour,
+		Timeline:            make([]TimelineDataPoint, 0, len(report.Timeline)),
+		Timezone:            location.String(),
+		GroupBy:             string(filter.GroupBy),
+		RefreshedAt:         report.RefreshedAt,
+	}
+
+	if totals.Sessions > 0 {
+		response.MessagesPerSession = float64(totals.Messages) / float64(totals.Sessions)
+	}
+
+	for channelTypeID, count := range report.ByChannel {
+		key := unknownChannelKey
+		if channelTypeID != 0 {
+			key = strconv.Itoa(channelTypeID)
+		}
+		response.SessionsByChannel[key] += count
+	}
+
+	for agentID, count := range report.ByAgent {
+		key := unassignedAgentKey
+		if agentID != uuid.Nil {
+			key = agentID.String()
+		}
+		response.SessionsByAgent[key] += count
+	}
+
+	for _, point := range report.Timeline {
+		response.Timeline = append(response.Timeline, TimelineDataPoint{
+			Timestamp:                  point.PeriodStart.Format(time.RFC3339),
+			Count:                      point.Totals.Sessions,
+			Closed:                     point.Totals.Closed,
+			Resolved:                   point.Totals.Resolved,
+			AverageResponseTimeSeconds: point.Totals.ResponseTime.Average(),
+			P90ResponseTimeSeconds:     point.Totals.ResponseTime.Percentile(90),
+		})
+	}
+
+	return response, nil
+}
+
+// resolveLocation usa o fuso da query ou, se ausente, o configurado no projeto do tenant
+func (h *SessionAnalyticsQueryHandler) resolveLocation(ctx context.Context, query SessionAnalyticsQuery) (*time.Location, error) {
+	if query.Timezone != "" {
+		location, err := time.LoadLocation(query.Timezone)
+		if err != nil {
+			return nil, shared.NewValidationError("invalid IANA time zone", "timezone")
+		}
+		return location, nil
+	}
+
+	if h.projectRepo == nil {
+		return time.UTC, nil
+	}
+
+	proj, err := h.projectRepo.FindByTenantID(ctx, query.TenantID.String())
+	if err != nil {
+		h.logger.Warn("Project not found for analytics, using UTC",
+			zap.String("tenant_id", query.TenantID.String()),
+			zap.Error(err))
+		return time.UTC, nil
+	}
+
+	return proj.Location(), nil
+}
+
+func summarizeDuration(stats session.DurationStats) DurationSummary {
+	return DurationSummary{
+		Samples:        stats.Count,
+		AverageSeconds: stats.Average(),
+		P50Seconds:     stats.Percentile(50),
+		P90Seconds:     stats.Percentile(90),
+		P95Seconds:     stats.Percentile(95),
+	}
+}
+
+func formatSeconds(seconds float64) string {
+	return (time.Duration(seconds * float64(time.Second))).Round(time.Second).String()
 }
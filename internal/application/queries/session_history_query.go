@@ -2,6 +2,7 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/internal/domain/core/shared"
@@ -9,72 +10,108 @@ import (
 	"go.uber.org/zap"
 )
 
-// SessionHistoryQuery query to get session history for a contact
+// SessionHistoryQuery query to get session history for a contact (newest first, keyset pagination)
 type SessionHistoryQuery struct {
 	ContactID uuid.UUID
 	TenantID  shared.TenantID
-	Page      int
+	Cursor    string // next_cursor de uma resposta anterior
 	Limit     int
 }
 
 // SessionHistoryResponse response for session history
 type SessionHistoryResponse struct {
 	Sessions   []SessionHistoryDTO `json:"sessions"`
-	TotalCount int64               `json:"total_count"`
-	Page       int                 `json:"page"`
+	NextCursor *string             `json:"next_cursor,omitempty"`
+	HasMore    bool                `json:"has_more"`
 	Limit      int                 `json:"limit"`
 }
 
 // SessionHistoryDTO data transfer object for session history
 type SessionHistoryDTO struct {
-	ID              string                 `json:"id"`
-	Status          string                 `json:"status"`
-	ChannelID       string                 `json:"channel_id"`
-	ChannelName     string                 `json:"channel_name,omitempty"`
-	AgentID         *string                `json:"agent_id,omitempty"`
-	AgentName       *string                `json:"agent_name,omitempty"`
-	StartedAt       string                 `json:"started_at"`
-	ClosedAt        *string                `json:"closed_at,omitempty"`
-	Duration        string                 `json:"duration"`
-	MessageCount    int                    `json:"message_count"`
-	UnreadCount     int                    `json:"unread_count"`
-	LastMessageText *string                `json:"last_message_text,omitempty"`
-	LastMessageAt   *string                `json:"last_message_at,omitempty"`
-	CustomFields    map[string]interface{} `json:"custom_fields,omitempty"`
-	Notes           []string               `json:"notes,omitempty"`
+	ID              string  `json:"id"`
+	Status          string  `json:"status"`
+	ChannelID       *string `json:"channel_id,omitempty"`
+	ChannelName     *string `json:"channel_name,omitempty"`
+	AgentID         *string `json:"agent_id,omitempty"`
+	AgentName       *string `json:"agent_name,omitempty"`
+	StartedAt       string  `json:"started_at"`
+	ClosedAt        *string `json:"closed_at,omitempty"`
+	Duration        string  `json:"duration"`
+	MessageCount    int     `json:"message_count"`
+	UnansweredCount int     `json:"unanswered_count"`
+	LastMessageText *string `json:"last_message_text,omitempty"`
+	LastMessageAt   *string `json:"last_message_at,omitempty"`
+	Summary         *string `json:"summary,omitempty"`
 }
 
 // SessionHistoryQueryHandler handles SessionHistoryQuery
 type SessionHistoryQueryHandler struct {
-	sessionRepo session.Repository
+	historyRepo session.HistoryRepository
 	logger      *zap.Logger
 }
 
 // NewSessionHistoryQueryHandler creates a new SessionHistoryQueryHandler
-func NewSessionHistoryQueryHandler(sessionRepo session.Repository, logger *zap.Logger) *SessionHistoryQueryHandler {
+func NewSessionHistoryQueryHandler(historyRepo session.HistoryRepository, logger *zap.Logger) *SessionHistoryQueryHandler {
 	return &SessionHistoryQueryHandler{
-		sessionRepo: sessionRepo,
+		historyRepo: historyRepo,
 		logger:      logger,
 	}
 }
 
 // Handle executes the SessionHistoryQuery
 func (h *SessionHistoryQueryHandler) Handle(ctx context.Context, query SessionHistoryQuery) (*SessionHistoryResponse, error) {
-	// TODO: Implement repository method FindByContactWithPagination
-	// For now, using basic FindByContact if it exists
+	filter := session.HistoryFilter{
+		TenantID:  query.TenantID.String(),
+		ContactID: query.ContactID,
+		Limit:     query.Limit,
+	}
+	if query.Cursor != "" {
+		cursor, err := shared.DecodeCursor(query.Cursor)
+		if err != nil {
+			return nil, shared.NewValidationError(err.Error(), "cursor")
+		}
+		filter.Cursor = &cursor
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "filter")
+	}
+
+	page, err := h.historyRepo.FindContactHistory(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to get session history",
+			zap.String("contact_id", query.ContactID.String()),
+			zap.Error(err))
+		return nil, err
+	}
 
-	h.logger.Info("Getting session history",
-		zap.String("contact_id", query.ContactID.String()),
-		zap.Int("page", query.Page),
-		zap.Int("limit", query.Limit))
+	response := &SessionHistoryResponse{
+		Sessions: make([]SessionHistoryDTO, len(page.Sessions)),
+		HasMore:  page.HasMore,
+		Limit:    filter.Limit,
+	}
+	if cursor := page.NextCursor(); cursor != nil {
+		token := cursor.Encode()
+		response.NextCursor = &token
+	}
 
-	// Placeholder - needs proper repository implementation
-	sessions := []SessionHistoryDTO{}
+	for i, s := range page.Sessions {
+		response.Sessions[i] = SessionHistoryDTO{
+			ID:              s.ID.String(),
+			Status:          s.Status,
+			ChannelID:       uuidPtrString(s.ChannelID),
+			ChannelName:     s.ChannelName,
+			AgentID:         uuidPtrString(s.AgentID),
+			AgentName:       s.AgentName,
+			StartedAt:       s.StartedAt.Format(time.RFC3339),
+			ClosedAt:        timePtrString(s.EndedAt),
+			Duration:        (time.Duration(s.DurationSeconds) * time.Second).String(),
+			MessageCount:    s.MessageCount,
+			UnansweredCount: s.UnansweredCount,
+			LastMessageText: s.LastMessageText,
+			LastMessageAt:   timePtrString(s.LastMessageAt),
+			Summary:         s.Summary,
+		}
+	}
 
-	return &SessionHistoryResponse{
-		Sessions:   sessions,
-		TotalCount: 0,
-		Page:       query.Page,
-		Limit:      query.Limit,
-	}, nil
+	return response, nil
 }
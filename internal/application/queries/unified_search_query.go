@@ -0,0 +1,127 @@
+package queries
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/search"
+	"go.uber.org/zap"
+)
+
+// UnifiedSearchQuery busca full-text em mensagens (texto, transcrições, OCR), notas e contatos
+type UnifiedSearchQuery struct {
+	TenantID  shared.TenantID
+	Query     string
+	Types     []string
+	Language  string
+	ChannelID *uuid.UUID
+	AgentID   *uuid.UUID
+	ContactID *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+	Cursor    string // token opaco de uma resposta anterior
+	Limit     int
+}
+
+// UnifiedSearchResponse resultados mais relevantes primeiro
+type UnifiedSearchResponse struct {
+	Results    []SearchHitDTO `json:"results"`
+	NextCursor *string        `json:"next_cursor,omitempty"`
+	HasMore    bool           `json:"has_more"`
+	Limit      int            `json:"limit"`
+}
+
+// SearchHitDTO resultado da busca; snippet é HTML escapado com os termos em <mark>
+type SearchHitDTO struct {
+	Type        string  `json:"type"` // message, note, contact
+	ID          string  `json:"id"`
+	Rank        float32 `json:"rank"`
+	MatchField  string  `json:"match_field"` // text, transcript, ocr, document, content, profile
+	Snippet     string  `json:"snippet"`
+	OccurredAt  string  `json:"occurred_at"`
+	ContactID   *string `json:"contact_id,omitempty"`
+	ContactName *string `json:"contact_name,omitempty"`
+	SessionID   *string `json:"session_id,omitempty"`
+	ChannelID   *string `json:"channel_id,omitempty"`
+	AgentID     *string `json:"agent_id,omitempty"`
+}
+
+// UnifiedSearchQueryHandler handles UnifiedSearchQuery
+type UnifiedSearchQueryHandler struct {
+	searchRepo search.Repository
+	logger     *zap.Logger
+}
+
+// NewUnifiedSearchQueryHandler creates a new UnifiedSearchQueryHandler
+func NewUnifiedSearchQueryHandler(searchRepo search.Repository, logger *zap.Logger) *UnifiedSearchQueryHandler {
+	return &UnifiedSearchQueryHandler{
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+// Handle executes the UnifiedSearchQuery
+func (h *UnifiedSearchQueryHandler) Handle(ctx context.Context, query UnifiedSearchQuery) (*UnifiedSearchResponse, error) {
+	filter := search.Filter{
+		TenantID:  query.TenantID.String(),
+		Query:     query.Query,
+		Language:  query.Language,
+		ChannelID: query.ChannelID,
+		AgentID:   query.AgentID,
+		ContactID: query.ContactID,
+		From:      query.From,
+		To:        query.To,
+		Limit:     query.Limit,
+	}
+	for _, t := range query.Types {
+		filter.Types = append(filter.Types, search.ResultType(t))
+	}
+	if query.Cursor != "" {
+		cursor, err := search.DecodeCursor(query.Cursor)
+		if err != nil {
+			return nil, shared.NewValidationError(err.Error(), "cursor")
+		}
+		filter.Cursor = &cursor
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "filter")
+	}
+
+	page, err := h.searchRepo.Search(ctx, filter)
+	if err != nil {
+		h.logger.Error("Failed to search",
+			zap.String("tenant_id", filter.TenantID),
+			zap.Error(err))
+		return nil, err
+	}
+
+	results := make([]SearchHitDTO, len(page.Hits))
+	for i, hit := range page.Hits {
+		results[i] = SearchHitDTO{
+			Type:        string(hit.Type),
+			ID:          hit.ID.String(),
+			Rank:        hit.Rank,
+			MatchField:  hit.MatchField,
+			Snippet:     search.HighlightHTML(hit.Snippet),
+			OccurredAt:  hit.OccurredAt.Format(time.RFC3339Nano),
+			ContactID:   uuidPtrString(hit.ContactID),
+			ContactName: hit.ContactName,
+			SessionID:   uuidPtrString(hit.SessionID),
+			ChannelID:   uuidPtrString(hit.ChannelID),
+			AgentID:     uuidPtrString(hit.AgentID),
+		}
+	}
+
+	response := &UnifiedSearchResponse{
+		Results: results,
+		HasMore: page.HasMore,
+		Limit:   filter.Limit,
+	}
+	if cursor := page.NextCursor(); cursor != nil {
+		token := cursor.Encode()
+		response.NextCursor = &token
+	}
+	return response, nil
+}
@@ -0,0 +1,289 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+// ReassignmentNotice aviso aos agentes envolvidos em uma reatribuição
+type ReassignmentNotice struct {
+	SessionID       uuid.UUID
+	ContactID       uuid.UUID
+	PreviousAgentID uuid.UUID
+	PreviousUserID  *uuid.UUID // nil para agentes sem usuário (IA, bot) ou removidos
+	NewAgentID      uuid.UUID
+	NewUserID       *uuid.UUID
+	Trigger         project.ReassignmentTrigger
+	Reason          string
+	ReassignedAt    time.Time
+}
+
+// AgentNotifier avisa os agentes sobre reatribuições (ex: websocket)
+type AgentNotifier interface {
+	NotifySessionReassigned(ctx context.Context, notice ReassignmentNotice)
+}
+
+// ReassignmentSummary resultado de uma varredura
+type ReassignmentSummary struct {
+	Evaluated    int // sessões avaliadas
+	Reassigned   int // sessões movidas para outro agente
+	Unassignable int // regra disparou, mas nenhum outro agente era elegível
+}
+
+func (s *ReassignmentSummary) add(other *ReassignmentSummary) {
+	s.Evaluated += other.Evaluated
+	s.Reassigned += other.Reassigned
+	s.Unassignable += other.Unassignable
+}
+
+// EnforceReassignmentRulesUseCase aplica as regras de reatribuição dos projetos às sessões
+// ativas. O novo agente é escolhido pela estratégia do projeto, excluindo quem já atendeu
+// a sessão. As participações (agent_sessions) são registradas pelo consumer de
+// session.agent_assigned, como em qualquer atribuição.
+type EnforceReassignmentRulesUseCase struct {
+	sessionRepo session.Repository
+	contactRepo contact.Repository
+	projectRepo project.Repository
+	agentRepo   agent.Repository
+	routingRepo routing.Repository
+	eventBus    EventBus
+	txManager   TransactionManager
+	notifier    AgentNotifier
+	batchSize   int
+}
+
+// NewEnforceReassignmentRulesUseCase creates a new instance
+func NewEnforceReassignmentRulesUseCase(
+	sessionRepo session.Repository,
+	contactRepo contact.Repository,
+	projectRepo project.Repository,
+	agentRepo agent.Repository,
+	routingRepo routing.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	notifier AgentNotifier,
+) *EnforceReassignmentRulesUseCase {
+	return &EnforceReassignmentRulesUseCase{
+		sessionRepo: sessionRepo,
+		contactRepo: contactRepo,
+		projectRepo: projectRepo,
+		agentRepo:   agentRepo,
+		routingRepo: routingRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		notifier:    notifier,
+		batchSize:   500,
+	}
+}
+
+// Execute varre todos os projetos com regras ativas. Falhas em um projeto não impedem os demais.
+func (uc *EnforceReassignmentRulesUseCase) Execute(ctx context.Context, now time.Time) (*ReassignmentSummary, error) {
+	projectIDs, err := uc.routingRepo.FindProjectsWithReassignmentRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	total := &ReassignmentSummary{}
+	var errs []error
+	for _, projectID := range projectIDs {
+		summary, err := uc.EnforceProject(ctx, projectID, now)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("project %s: %w", projectID, err))
+			continue
+		}
+		total.add(summary)
+	}
+
+	return total, errors.Join(errs...)
+}
+
+// EnforceProject aplica as regras de um projeto em uma única transação, com o lock de roteamento do projeto
+func (uc *EnforceReassignmentRulesUseCase) EnforceProject(ctx context.Context, projectID uuid.UUID, now time.Time) (*ReassignmentSummary, error) {
+	summary := &ReassignmentSummary{}
+	var (
+		reassigned []*session.Session
+		notices    []ReassignmentNotice
+	)
+
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		proj, err := uc.projectRepo.FindByID(txCtx, projectID)
+		if err != nil {
+			return fmt.Errorf("failed to load project: %w", err)
+		}
+
+		config := proj.GetAgentAssignment()
+		if !config.ShouldAutoAssign() || len(config.GetActiveReassignmentRules()) == 0 {
+			return nil
+		}
+
+		if err := uc.routingRepo.LockProject(txCtx, projectID); err != nil {
+			return err
+		}
+
+		assignments, err := uc.routingRepo.FindActiveAssignments(txCtx, projectID, uc.batchSize)
+		if err != nil || len(assignments) == 0 {
+			return err
+		}
+
+		candidates, err := loadCandidates(txCtx, uc.agentRepo, uc.routingRepo, proj.TenantID(), config.AgentIDs)
+		if err != nil {
+			return err
+		}
+		index := make(map[uuid.UUID]int, len(candidates))
+		for i, c := range candidates {
+			index[c.Agent.ID()] = i
+		}
+
+		var lastAssigned *uuid.UUID
+		if config.Strategy == project.StrategyRoundRobin {
+			if lastAssigned, err = uc.routingRepo.GetRoundRobinCursor(txCtx, projectID); err != nil {
+				return err
+			}
+		}
+
+		for _, assignment := range assignments {
+			summary.Evaluated++
+
+			var current *routing.Candidate
+			if i, ok := index[assignment.AgentID]; ok {
+				current = &candidates[i]
+			}
+
+			rule := routing.MatchReassignmentRule(config, assignment, current, now)
+			if rule == nil {
+				continue
+			}
+
+			sess, err := uc.sessionRepo.FindByID(txCtx, assignment.SessionID)
+			if err != nil {
+				return fmt.Errorf("failed to load session %s: %w", assignment.SessionID, err)
+			}
+			// A sessão pode ter mudado entre a varredura e o lock
+			if currentAgent := sess.GetCurrentAgent(); !sess.IsActive() || currentAgent == nil || *currentAgent != assignment.AgentID {
+				continue
+			}
+
+			c, err := uc.contactRepo.FindByID(txCtx, sess.ContactID())
+			if err != nil {
+				return fmt.Errorf("failed to load contact: %w", err)
+			}
+			req := routing.RequirementsFor(config, c.Language(), c.Tags())
+
+			agentID, err := routing.SelectAgent(config, excludeAgents(candidates, sess.AgentIDs()), req, lastAssigned)
+			if errors.Is(err, routing.ErrNoEligibleAgent) {
+				summary.Unassignable++
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			if err := reassign(sess, rule, agentID, config.Strategy); err != nil {
+				return err
+			}
+			if err := uc.sessionRepo.Save(txCtx, sess); err != nil {
+				return fmt.Errorf("failed to save session: %w", err)
+			}
+			if err := publishEvents(txCtx, uc.eventBus, sess); err != nil {
+				return err
+			}
+
+			// Mantém a carga em memória coerente para as próximas decisões da varredura
+			newAgent := &candidates[index[agentID]]
+			newAgent.Workload.ActiveSessions++
+			assignedAt := now
+			newAgent.Workload.LastAssignedAt = &assignedAt
+			if current != nil && current.Workload.ActiveSessions > 0 {
+				current.Workload.ActiveSessions--
+			}
+
+			if config.Strategy == project.StrategyRoundRobin {
+				if err := uc.routingRepo.SaveRoundRobinCursor(txCtx, projectID, agentID); err != nil {
+					return err
+				}
+				lastAssigned = &agentID
+			}
+
+			summary.Reassigned++
+			reassigned = append(reassigned, sess)
+			notices = append(notices, ReassignmentNotice{
+				SessionID:       sess.ID(),
+				ContactID:       sess.ContactID(),
+				PreviousAgentID: assignment.AgentID,
+				PreviousUserID:  uc.userIDOf(txCtx, current, assignment.AgentID),
+				NewAgentID:      agentID,
+				NewUserID:       newAgent.Agent.UserID(),
+				Trigger:         rule.Trigger,
+				Reason:          rule.Name(),
+				ReassignedAt:    now,
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sess := range reassigned {
+		sess.ClearEvents()
+	}
+	// Notificações só depois do commit: agentes não devem ver reatribuições desfeitas
+	if uc.notifier != nil {
+		for _, notice := range notices {
+			uc.notifier.NotifySessionReassigned(ctx, notice)
+		}
+	}
+
+	return summary, nil
+}
+
+// userIDOf resolve o usuário do agente anterior, que pode já ter saído do projeto
+func (uc *EnforceReassignmentRulesUseCase) userIDOf(ctx context.Context, current *routing.Candidate, agentID uuid.UUID) *uuid.UUID {
+	if current != nil {
+		return current.Agent.UserID()
+	}
+	a, err := uc.agentRepo.FindByID(ctx, agentID)
+	if err != nil {
+		return nil
+	}
+	return a.UserID()
+}
+
+// excludeAgents remove os agentes que já atenderam a sessão: reatribuir a eles não teria efeito
+func excludeAgents(candidates []routing.Candidate, agentIDs []uuid.UUID) []routing.Candidate {
+	excluded := make(map[uuid.UUID]bool, len(agentIDs))
+	for _, id := range agentIDs {
+		excluded[id] = true
+	}
+
+	pool := make([]routing.Candidate, 0, len(candidates))
+	for _, c := range candidates {
+		if !excluded[c.Agent.ID()] {
+			pool = append(pool, c)
+		}
+	}
+	return pool
+}
+
+func reassign(sess *session.Session, rule *project.ReassignmentRule, agentID uuid.UUID, strategy project.AssignmentStrategy) error {
+	switch rule.Trigger {
+	case project.TriggerInactivity:
+		return sess.ReassignAgentByInactivity(agentID, string(strategy), rule.Name())
+	case project.TriggerNoResponse:
+		return sess.ReassignAgentByNoResponse(agentID, string(strategy), rule.Name())
+	case project.TriggerWorkloadBalance:
+		return sess.ReassignAgentByWorkload(agentID, string(strategy), rule.Name())
+	default:
+		return fmt.Errorf("reassignment trigger %s is not automatic", rule.Trigger)
+	}
+}
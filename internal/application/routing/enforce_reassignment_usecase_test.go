@@ -0,0 +1,160 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func newEnforceFixture(t *testing.T, strategy project.AssignmentStrategy, rule *project.ReassignmentRule) (*routeFixture, *MockAgentNotifier, *EnforceReassignmentRulesUseCase) {
+	t.Helper()
+	f := newRouteFixture(t, strategy, 3)
+	require.NoError(t, f.project.AddReassignmentRule(rule))
+
+	// Sessão atendida pelo primeiro agente
+	require.NoError(t, f.session.AssignAgentAutomatic(f.agents[0].ID(), string(strategy)))
+	f.session.ClearEvents()
+
+	notifier := new(MockAgentNotifier)
+	useCase := NewEnforceReassignmentRulesUseCase(f.sessionRepo, f.contactRepo, f.projectRepo, f.agentRepo, f.routingRepo, f.eventBus, &SimpleTransactionManager{}, notifier)
+	return f, notifier, useCase
+}
+
+func TestEnforceReassignmentRules_NoResponse(t *testing.T) {
+	rule := project.NewReassignmentRule(project.TriggerNoResponse, 10)
+	f, notifier, useCase := newEnforceFixture(t, project.StrategyLeastSessions, rule)
+	now := time.Now()
+	waiting := now.Add(-20 * time.Minute)
+
+	f.routingRepo.On("FindActiveAssignments", mock.Anything, f.project.ID(), 500).Return([]routing.ActiveAssignment{{
+		SessionID:      f.session.ID(),
+		TenantID:       "tenant-123",
+		ContactID:      f.contact.ID(),
+		AgentID:        f.agents[0].ID(),
+		LastActivityAt: waiting,
+		WaitingSince:   &waiting,
+	}}, nil)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{
+		f.agents[0].ID(): {ActiveSessions: 1},
+		f.agents[1].ID(): {ActiveSessions: 5},
+		f.agents[2].ID(): {ActiveSessions: 2},
+	}, nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(e session.AgentAssignedEvent) bool {
+		return e.AgentID == f.agents[2].ID() && e.Source == session.AssignmentSourceReassignmentNoResponse &&
+			e.PreviousAgentID != nil && *e.PreviousAgentID == f.agents[0].ID() &&
+			e.ReassignmentReason != nil && *e.ReassignmentReason == "no_response_10min"
+	})).Return(nil)
+	notifier.On("NotifySessionReassigned", mock.Anything, mock.MatchedBy(func(n ReassignmentNotice) bool {
+		return n.PreviousAgentID == f.agents[0].ID() && n.NewAgentID == f.agents[2].ID() &&
+			n.PreviousUserID != nil && n.NewUserID != nil && n.Trigger == project.TriggerNoResponse
+	})).Return()
+
+	summary, err := useCase.EnforceProject(context.Background(), f.project.ID(), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, &ReassignmentSummary{Evaluated: 1, Reassigned: 1}, summary)
+	assert.Equal(t, f.agents[2].ID(), *f.session.GetCurrentAgent())
+	assert.Equal(t, 1, f.session.GetReassignmentCount())
+	f.eventBus.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+}
+
+func TestEnforceReassignmentRules_WorkloadBalanceWhenAgentGoesOffline(t *testing.T) {
+	rule := project.NewReassignmentRule(project.TriggerWorkloadBalance, 0)
+	f, notifier, useCase := newEnforceFixture(t, project.StrategyRoundRobin, rule)
+	f.agents[0].SetStatus(agent.AgentStatusOffline)
+	now := time.Now()
+
+	last := f.agents[0].ID()
+	f.routingRepo.On("FindActiveAssignments", mock.Anything, f.project.ID(), 500).Return([]routing.ActiveAssignment{{
+		SessionID:      f.session.ID(),
+		ContactID:      f.contact.ID(),
+		AgentID:        f.agents[0].ID(),
+		LastActivityAt: now,
+	}}, nil)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+	f.routingRepo.On("GetRoundRobinCursor", mock.Anything, f.project.ID()).Return(&last, nil)
+	f.routingRepo.On("SaveRoundRobinCursor", mock.Anything, f.project.ID(), f.agents[1].ID()).Return(nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	notifier.On("NotifySessionReassigned", mock.Anything, mock.Anything).Return()
+
+	summary, err := useCase.EnforceProject(context.Background(), f.project.ID(), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, summary.Reassigned)
+	assert.Equal(t, f.agents[1].ID(), *f.session.GetCurrentAgent())
+	f.routingRepo.AssertExpectations(t)
+}
+
+func TestEnforceReassignmentRules_RespectsMaxReassignments(t *testing.T) {
+	rule := project.NewReassignmentRule(project.TriggerInactivity, 5)
+	rule.MaxReassignments = 1
+	f, notifier, useCase := newEnforceFixture(t, project.StrategyLeastSessions, rule)
+	now := time.Now()
+
+	f.routingRepo.On("FindActiveAssignments", mock.Anything, f.project.ID(), 500).Return([]routing.ActiveAssignment{{
+		SessionID:      f.session.ID(),
+		AgentID:        f.agents[0].ID(),
+		LastActivityAt: now.Add(-time.Hour),
+		Transfers:      1,
+	}}, nil)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+
+	summary, err := useCase.EnforceProject(context.Background(), f.project.ID(), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, &ReassignmentSummary{Evaluated: 1}, summary)
+	f.sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	notifier.AssertNotCalled(t, "NotifySessionReassigned", mock.Anything, mock.Anything)
+}
+
+func TestEnforceReassignmentRules_NoOtherAgentAvailable(t *testing.T) {
+	rule := project.NewReassignmentRule(project.TriggerInactivity, 5)
+	f, notifier, useCase := newEnforceFixture(t, project.StrategyLeastSessions, rule)
+	f.agents[1].SetStatus(agent.AgentStatusAway)
+	f.agents[2].SetStatus(agent.AgentStatusOffline)
+	now := time.Now()
+
+	f.routingRepo.On("FindActiveAssignments", mock.Anything, f.project.ID(), 500).Return([]routing.ActiveAssignment{{
+		SessionID:      f.session.ID(),
+		ContactID:      f.contact.ID(),
+		AgentID:        f.agents[0].ID(),
+		LastActivityAt: now.Add(-time.Hour),
+	}}, nil)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+
+	summary, err := useCase.EnforceProject(context.Background(), f.project.ID(), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, &ReassignmentSummary{Evaluated: 1, Unassignable: 1}, summary)
+	assert.Equal(t, f.agents[0].ID(), *f.session.GetCurrentAgent(), "current agent is never re-picked")
+	notifier.AssertNotCalled(t, "NotifySessionReassigned", mock.Anything, mock.Anything)
+}
+
+func TestEnforceReassignmentRules_ExecuteContinuesAfterProjectFailure(t *testing.T) {
+	rule := project.NewReassignmentRule(project.TriggerInactivity, 5)
+	f, _, useCase := newEnforceFixture(t, project.StrategyLeastSessions, rule)
+	broken := uuid.New()
+
+	f.routingRepo.On("FindProjectsWithReassignmentRules", mock.Anything).Return([]uuid.UUID{broken, f.project.ID()}, nil)
+	f.projectRepo.On("FindByID", mock.Anything, broken).Return(nil, errors.New("boom"))
+	f.routingRepo.On("FindActiveAssignments", mock.Anything, f.project.ID(), 500).Return([]routing.ActiveAssignment{}, nil)
+
+	summary, err := useCase.Execute(context.Background(), time.Now())
+
+	assert.Error(t, err)
+	assert.NotNil(t, summary)
+	f.routingRepo.AssertCalled(t, "FindActiveAssignments", mock.Anything, f.project.ID(), 500)
+}
@@ -0,0 +1,27 @@
+package routing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// publishEvents publica os eventos pendentes da sessão (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, sess *session.Session) error {
+	for _, event := range sess.DomainEvents() {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+		}
+	}
+	return nil
+}
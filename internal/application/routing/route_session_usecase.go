@@ -0,0 +1,215 @@
+package routing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+// RouteOutcome resultado de uma tentativa de roteamento
+type RouteOutcome string
+
+const (
+	OutcomeAssigned RouteOutcome = "assigned" // sessão atribuída a um agente
+	OutcomeQueued   RouteOutcome = "queued"   // nenhum agente elegível agora; sessão aguarda na fila
+	OutcomeSkipped  RouteOutcome = "skipped"  // nada a fazer (sessão encerrada, já atribuída ou projeto manual)
+)
+
+// RouteResult descreve o que aconteceu com a sessão
+type RouteResult struct {
+	Outcome  RouteOutcome
+	AgentID  uuid.UUID
+	Strategy project.AssignmentStrategy
+	Reason   string
+}
+
+// RouteSessionUseCase atribui automaticamente um agente a uma sessão nova segundo a
+// configuração de atribuição do projeto do contato. Sessões que nenhum agente pode
+// assumir ficam na fila de roteamento até que alguém fique disponível.
+type RouteSessionUseCase struct {
+	sessionRepo session.Repository
+	contactRepo contact.Repository
+	projectRepo project.Repository
+	agentRepo   agent.Repository
+	routingRepo routing.Repository
+	eventBus    EventBus
+	txManager   TransactionManager
+}
+
+// NewRouteSessionUseCase creates a new instance
+func NewRouteSessionUseCase(
+	sessionRepo session.Repository,
+	contactRepo contact.Repository,
+	projectRepo project.Repository,
+	agentRepo agent.Repository,
+	routingRepo routing.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+) *RouteSessionUseCase {
+	return &RouteSessionUseCase{
+		sessionRepo: sessionRepo,
+		contactRepo: contactRepo,
+		projectRepo: projectRepo,
+		agentRepo:   agentRepo,
+		routingRepo: routingRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+	}
+}
+
+// Execute roteia a sessão. É idempotente: sessões já atribuídas ou encerradas saem da fila e são ignoradas.
+func (uc *RouteSessionUseCase) Execute(ctx context.Context, sessionID uuid.UUID) (*RouteResult, error) {
+	if sessionID == uuid.Nil {
+		return nil, errors.New("sessionID is required")
+	}
+
+	var (
+		result *RouteResult
+		sess   *session.Session
+	)
+
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		sess, err = uc.sessionRepo.FindByID(txCtx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to load session: %w", err)
+		}
+
+		result, err = uc.route(txCtx, sess)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if sess != nil {
+		sess.ClearEvents()
+	}
+
+	return result, nil
+}
+
+func (uc *RouteSessionUseCase) route(ctx context.Context, sess *session.Session) (*RouteResult, error) {
+	if !sess.IsActive() {
+		return uc.skip(ctx, sess.ID(), "session is not active")
+	}
+	if sess.HasAssignedAgents() {
+		return uc.skip(ctx, sess.ID(), "session already has an agent")
+	}
+
+	c, err := uc.contactRepo.FindByID(ctx, sess.ContactID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contact: %w", err)
+	}
+
+	proj, err := uc.projectRepo.FindByID(ctx, c.ProjectID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project: %w", err)
+	}
+
+	config := proj.GetAgentAssignment()
+	if !config.ShouldAutoAssign() {
+		return uc.skip(ctx, sess.ID(), "auto-assignment is not enabled")
+	}
+
+	// Decisões do mesmo projeto são serializadas: cursor e carga lidos aqui não mudam até o commit
+	if err := uc.routingRepo.LockProject(ctx, proj.ID()); err != nil {
+		return nil, err
+	}
+
+	candidates, err := loadCandidates(ctx, uc.agentRepo, uc.routingRepo, sess.TenantID(), config.AgentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	var lastAssigned *uuid.UUID
+	if config.Strategy == project.StrategyRoundRobin {
+		if lastAssigned, err = uc.routingRepo.GetRoundRobinCursor(ctx, proj.ID()); err != nil {
+			return nil, err
+		}
+	}
+
+	req := routing.RequirementsFor(config, c.Language(), c.Tags())
+
+	agentID, err := routing.SelectAgent(config, candidates, req, lastAssigned)
+	if errors.Is(err, routing.ErrNoEligibleAgent) {
+		now := time.Now()
+		if err := uc.routingRepo.Enqueue(ctx, routing.QueueEntry{
+			SessionID:     sess.ID(),
+			TenantID:      sess.TenantID(),
+			ProjectID:     proj.ID(),
+			ContactID:     c.ID(),
+			Requirements:  req,
+			EnqueuedAt:    now,
+			LastAttemptAt: now,
+		}); err != nil {
+			return nil, err
+		}
+		return &RouteResult{Outcome: OutcomeQueued, Strategy: config.Strategy, Reason: err.Error()}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := sess.AssignAgentAutomatic(agentID, string(config.Strategy)); err != nil {
+		return nil, err
+	}
+	if err := uc.sessionRepo.Save(ctx, sess); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+	if err := publishEvents(ctx, uc.eventBus, sess); err != nil {
+		return nil, err
+	}
+
+	if config.Strategy == project.StrategyRoundRobin {
+		if err := uc.routingRepo.SaveRoundRobinCursor(ctx, proj.ID(), agentID); err != nil {
+			return nil, err
+		}
+	}
+	if err := uc.routingRepo.Dequeue(ctx, sess.ID()); err != nil {
+		return nil, err
+	}
+
+	return &RouteResult{Outcome: OutcomeAssigned, AgentID: agentID, Strategy: config.Strategy}, nil
+}
+
+// loadCandidates carrega os agentes configurados com sua carga atual.
+// Agentes removidos depois de configurados no projeto são ignorados.
+func loadCandidates(ctx context.Context, agentRepo agent.Repository, routingRepo routing.Repository, tenantID string, agentIDs []uuid.UUID) ([]routing.Candidate, error) {
+	workloads, err := routingRepo.GetWorkloads(ctx, tenantID, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]routing.Candidate, 0, len(agentIDs))
+	for _, id := range agentIDs {
+		a, err := agentRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, agent.ErrAgentNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load agent %s: %w", id, err)
+		}
+		if a.TenantID() != tenantID {
+			continue
+		}
+		candidates = append(candidates, routing.Candidate{Agent: a, Workload: workloads[id]})
+	}
+
+	return candidates, nil
+}
+
+func (uc *RouteSessionUseCase) skip(ctx context.Context, sessionID uuid.UUID, reason string) (*RouteResult, error) {
+	if err := uc.routingRepo.Dequeue(ctx, sessionID); err != nil {
+		return nil, err
+	}
+	return &RouteResult{Outcome: OutcomeSkipped, Reason: reason}, nil
+}
@@ -0,0 +1,167 @@
+package routing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+type routeFixture struct {
+	sessionRepo *MockSessionRepository
+	contactRepo *MockContactRepository
+	projectRepo *MockProjectRepository
+	agentRepo   *MockAgentRepository
+	routingRepo *MockRoutingRepository
+	eventBus    *MockEventBus
+	useCase     *RouteSessionUseCase
+
+	session *session.Session
+	contact *contact.Contact
+	project *project.Project
+	agents  []*agent.Agent
+}
+
+func newRouteFixture(t *testing.T, strategy project.AssignmentStrategy, agentCount int) *routeFixture {
+	t.Helper()
+	f := &routeFixture{
+		sessionRepo: new(MockSessionRepository),
+		contactRepo: new(MockContactRepository),
+		projectRepo: new(MockProjectRepository),
+		agentRepo:   new(MockAgentRepository),
+		routingRepo: new(MockRoutingRepository),
+		eventBus:    new(MockEventBus),
+	}
+	f.useCase = NewRouteSessionUseCase(f.sessionRepo, f.contactRepo, f.projectRepo, f.agentRepo, f.routingRepo, f.eventBus, &SimpleTransactionManager{})
+
+	var err error
+	f.project, err = project.NewProject(uuid.New(), uuid.New(), "tenant-123", "Project")
+	require.NoError(t, err)
+
+	f.contact, err = contact.NewContact(f.project.ID(), "tenant-123", "Maria")
+	require.NoError(t, err)
+
+	f.session, err = session.NewSession(f.contact.ID(), "tenant-123", nil, 30*time.Minute)
+	require.NoError(t, err)
+	f.session.ClearEvents()
+
+	config := project.NewAgentAssignmentConfig()
+	config.Enabled = true
+	config.Strategy = strategy
+	for i := 0; i < agentCount; i++ {
+		userID := uuid.New()
+		a, err := agent.NewAgent(f.project.ID(), "tenant-123", "Agent", agent.AgentTypeHuman, &userID)
+		require.NoError(t, err)
+		a.SetStatus(agent.AgentStatusAvailable)
+		f.agents = append(f.agents, a)
+		config.AgentIDs = append(config.AgentIDs, a.ID())
+		f.agentRepo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	}
+	require.NoError(t, f.project.SetAgentAssignmentConfig(config))
+
+	f.sessionRepo.On("FindByID", mock.Anything, f.session.ID()).Return(f.session, nil)
+	f.contactRepo.On("FindByID", mock.Anything, f.contact.ID()).Return(f.contact, nil)
+	f.projectRepo.On("FindByID", mock.Anything, f.project.ID()).Return(f.project, nil)
+	f.routingRepo.On("LockProject", mock.Anything, f.project.ID()).Return(nil)
+
+	return f
+}
+
+func TestRouteSessionUseCase_RoundRobinAdvancesCursor(t *testing.T) {
+	f := newRouteFixture(t, project.StrategyRoundRobin, 2)
+	last := f.agents[0].ID()
+
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+	f.routingRepo.On("GetRoundRobinCursor", mock.Anything, f.project.ID()).Return(&last, nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(e session.AgentAssignedEvent) bool {
+		return e.AgentID == f.agents[1].ID() && e.Source == session.AssignmentSourceAutomatic &&
+			e.AssignmentStrategy != nil && *e.AssignmentStrategy == string(project.StrategyRoundRobin)
+	})).Return(nil)
+	f.routingRepo.On("SaveRoundRobinCursor", mock.Anything, f.project.ID(), f.agents[1].ID()).Return(nil)
+	f.routingRepo.On("Dequeue", mock.Anything, f.session.ID()).Return(nil)
+
+	result, err := f.useCase.Execute(context.Background(), f.session.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, OutcomeAssigned, result.Outcome)
+	assert.Equal(t, f.agents[1].ID(), result.AgentID)
+	assert.Equal(t, []uuid.UUID{f.agents[1].ID()}, f.session.AgentIDs())
+	assert.Empty(t, f.session.DomainEvents())
+	f.eventBus.AssertExpectations(t)
+	f.routingRepo.AssertExpectations(t)
+}
+
+func TestRouteSessionUseCase_LeastSessions(t *testing.T) {
+	f := newRouteFixture(t, project.StrategyLeastSessions, 3)
+
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{
+		f.agents[0].ID(): {ActiveSessions: 4},
+		f.agents[1].ID(): {ActiveSessions: 1},
+		f.agents[2].ID(): {ActiveSessions: 2},
+	}, nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	f.routingRepo.On("Dequeue", mock.Anything, f.session.ID()).Return(nil)
+
+	result, err := f.useCase.Execute(context.Background(), f.session.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, OutcomeAssigned, result.Outcome)
+	assert.Equal(t, f.agents[1].ID(), result.AgentID)
+	f.routingRepo.AssertNotCalled(t, "GetRoundRobinCursor", mock.Anything, mock.Anything)
+	f.routingRepo.AssertNotCalled(t, "SaveRoundRobinCursor", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRouteSessionUseCase_QueuesWhenNoAgentEligible(t *testing.T) {
+	f := newRouteFixture(t, project.StrategyLeastSessions, 1)
+	config := f.project.GetAgentAssignment()
+	config.SkillTags = []string{"billing"}
+	f.contact.AddTag("billing")
+
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+	f.routingRepo.On("Enqueue", mock.Anything, mock.MatchedBy(func(e routing.QueueEntry) bool {
+		return e.SessionID == f.session.ID() && e.ProjectID == f.project.ID() &&
+			assert.ObjectsAreEqual([]string{"billing"}, e.Requirements.Skills)
+	})).Return(nil)
+
+	result, err := f.useCase.Execute(context.Background(), f.session.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, OutcomeQueued, result.Outcome)
+	assert.False(t, f.session.HasAssignedAgents())
+	f.sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	f.routingRepo.AssertExpectations(t)
+}
+
+func TestRouteSessionUseCase_Skips(t *testing.T) {
+	t.Run("manual strategy", func(t *testing.T) {
+		f := newRouteFixture(t, project.StrategyManual, 1)
+		f.routingRepo.On("Dequeue", mock.Anything, f.session.ID()).Return(nil)
+
+		result, err := f.useCase.Execute(context.Background(), f.session.ID())
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeSkipped, result.Outcome)
+		f.routingRepo.AssertNotCalled(t, "LockProject", mock.Anything, mock.Anything)
+	})
+
+	t.Run("session already assigned", func(t *testing.T) {
+		f := newRouteFixture(t, project.StrategyRoundRobin, 1)
+		require.NoError(t, f.session.AssignAgent(f.agents[0].ID()))
+		f.routingRepo.On("Dequeue", mock.Anything, f.session.ID()).Return(nil)
+
+		result, err := f.useCase.Execute(context.Background(), f.session.ID())
+		require.NoError(t, err)
+		assert.Equal(t, OutcomeSkipped, result.Outcome)
+		f.contactRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+}
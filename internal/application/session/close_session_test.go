@@ -40,6 +40,14 @@ func (m *MockSessionRepository) FindActiveByContact(ctx context.Context, contact
 	return args.Get(0).(*session.Session), args.Error(1)
 }
 
+func (m *MockSessionRepository) FindByChannelAndContacts(ctx context.Context, channelID uuid.UUID, contactIDs []uuid.UUID) ([]*session.Session, error) {
+	args := m.Called(ctx, channelID, contactIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*session.Session), args.Error(1)
+}
+
 func (m *MockSessionRepository) FindInactiveSessions(ctx context.Context, tenantID string) ([]*session.Session, error) {
 	args := m.Called(ctx, tenantID)
 	if args.Get(0) == nil {
@@ -103,6 +111,14 @@ func (m *MockSessionRepository) DeleteBatch(ctx context.Context, sessionIDs []uu
 	return args.Error(0)
 }
 
+func (m *MockSessionRepository) GetContactIDsByChannel(ctx context.Context, channelID uuid.UUID) ([]uuid.UUID, error) {
+	args := m.Called(ctx, channelID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]uuid.UUID), args.Error(1)
+}
+
 type MockEventBus struct {
 	mock.Mock
 }
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/application/shared"
+	domainShared "github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+// SetPriorityCommand contém os dados para alterar a prioridade de uma sessão.
+type SetPriorityCommand struct {
+	SessionID uuid.UUID
+	TenantID  string
+	Priority  string
+}
+
+// SetPriorityUseCase altera a prioridade da sessão e publica session.priority_changed
+// (o SLA recalcula os prazos da sessão a partir desse evento).
+type SetPriorityUseCase struct {
+	sessionRepo session.Repository
+	eventBus    EventBus
+	txManager   shared.TransactionManager
+}
+
+// NewSetPriorityUseCase cria uma nova instância.
+func NewSetPriorityUseCase(
+	sessionRepo session.Repository,
+	eventBus EventBus,
+	txManager shared.TransactionManager,
+) *SetPriorityUseCase {
+	return &SetPriorityUseCase{
+		sessionRepo: sessionRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+	}
+}
+
+// Execute executa o caso de uso.
+func (uc *SetPriorityUseCase) Execute(ctx context.Context, cmd SetPriorityCommand) (*session.Session, error) {
+	priority, err := session.ParsePriority(cmd.Priority)
+	if err != nil {
+		return nil, domainShared.NewValidationError(err.Error(), "priority")
+	}
+
+	sess, err := uc.sessionRepo.FindByID(ctx, cmd.SessionID)
+	if err != nil || sess == nil || sess.TenantID() != cmd.TenantID {
+		return nil, domainShared.NewNotFoundError("session", cmd.SessionID.String())
+	}
+
+	if err := sess.SetPriority(priority); err != nil {
+		return nil, domainShared.NewValidationError(err.Error(), "priority")
+	}
+	if len(sess.DomainEvents()) == 0 {
+		return sess, nil
+	}
+
+	err = uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		if err := uc.sessionRepo.Save(txCtx, sess); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+		for _, event := range sess.DomainEvents() {
+			if err := uc.eventBus.Publish(txCtx, event); err != nil {
+				return fmt.Errorf("failed to publish event: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sess.ClearEvents()
+	return sess, nil
+}
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	domainShared "github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func TestSetPriorityUseCase_Execute_Success(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := new(MockSessionRepository)
+	eventBus := new(MockEventBus)
+	useCase := NewSetPriorityUseCase(sessionRepo, eventBus, &SimpleTransactionManager{})
+
+	sess, _ := session.NewSession(uuid.New(), "tenant-1", nil, 30*time.Minute)
+	sess.ClearEvents()
+
+	sessionRepo.On("FindByID", ctx, sess.ID()).Return(sess, nil)
+	sessionRepo.On("Save", ctx, sess).Return(nil)
+	eventBus.On("Publish", ctx, mock.MatchedBy(func(e session.DomainEvent) bool {
+		return e.EventName() == "session.priority_changed"
+	})).Return(nil)
+
+	result, err := useCase.Execute(ctx, SetPriorityCommand{SessionID: sess.ID(), TenantID: "tenant-1", Priority: "urgent"})
+
+	require.NoError(t, err)
+	assert.Equal(t, session.PriorityUrgent, result.Priority())
+	assert.Empty(t, result.DomainEvents())
+	sessionRepo.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+}
+
+func TestSetPriorityUseCase_Execute_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := new(MockSessionRepository)
+	eventBus := new(MockEventBus)
+	useCase := NewSetPriorityUseCase(sessionRepo, eventBus, &SimpleTransactionManager{})
+
+	sess, _ := session.NewSession(uuid.New(), "tenant-1", nil, 30*time.Minute)
+	sess.ClearEvents()
+	sessionRepo.On("FindByID", ctx, sess.ID()).Return(sess, nil)
+
+	_, err := useCase.Execute(ctx, SetPriorityCommand{SessionID: sess.ID(), TenantID: "tenant-1", Priority: "normal"})
+
+	require.NoError(t, err)
+	sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestSetPriorityUseCase_Execute_Errors(t *testing.T) {
+	ctx := context.Background()
+	sessionRepo := new(MockSessionRepository)
+	useCase := NewSetPriorityUseCase(sessionRepo, new(MockEventBus), &SimpleTransactionManager{})
+
+	_, err := useCase.Execute(ctx, SetPriorityCommand{SessionID: uuid.New(), TenantID: "tenant-1", Priority: "critical"})
+	assert.True(t, domainShared.IsValidationError(err))
+
+	sess, _ := session.NewSession(uuid.New(), "tenant-1", nil, 30*time.Minute)
+	sessionRepo.On("FindByID", ctx, sess.ID()).Return(sess, nil)
+
+	_, err = useCase.Execute(ctx, SetPriorityCommand{SessionID: sess.ID(), TenantID: "other-tenant", Priority: "high"})
+	assert.True(t, domainShared.IsNotFoundError(err))
+}
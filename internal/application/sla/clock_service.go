@@ -0,0 +1,159 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+// ClockService trata os disparos dos timers de SLA: emite sla.warning, marca sla.breached
+// e escala a sessão. Todos os métodos são idempotentes (timers podem repetir ou atrasar).
+type ClockService struct {
+	clockRepo   sla.ClockRepository
+	sessionRepo session.Repository
+	eventBus    EventBus
+	txManager   TransactionManager
+	automation  AutomationTrigger
+	batchSize   int
+}
+
+// NewClockService creates a new instance. automation pode ser nil.
+func NewClockService(
+	clockRepo sla.ClockRepository,
+	sessionRepo session.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	automation AutomationTrigger,
+) *ClockService {
+	return &ClockService{
+		clockRepo:   clockRepo,
+		sessionRepo: sessionRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		automation:  automation,
+		batchSize:   500,
+	}
+}
+
+// Warn emite sla.warning se o relógio ainda corre e o ponto de aviso chegou
+func (s *ClockService) Warn(ctx context.Context, clockID uuid.UUID, now time.Time) error {
+	var warned *sla.Clock
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		clock, sess, err := s.load(txCtx, clockID)
+		if err != nil || clock == nil {
+			return err
+		}
+		if !clock.Warn(now, sess.GetCurrentAgent()) {
+			return nil
+		}
+		if err := s.save(txCtx, clock); err != nil {
+			return err
+		}
+		warned = clock
+		return nil
+	})
+	if err != nil || warned == nil {
+		return err
+	}
+
+	if s.automation != nil {
+		return s.automation.OnSLAWarning(ctx, warned.SessionID(), string(warned.Metric()), warned.DueAt())
+	}
+	return nil
+}
+
+// Breach marca o vencimento, emite sla.breached e escala a sessão ainda ativa
+func (s *ClockService) Breach(ctx context.Context, clockID uuid.UUID, now time.Time) error {
+	var breached *sla.Clock
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		clock, sess, err := s.load(txCtx, clockID)
+		if err != nil || clock == nil {
+			return err
+		}
+		if !clock.Breach(now, sess.GetCurrentAgent()) {
+			return nil
+		}
+		if err := s.save(txCtx, clock); err != nil {
+			return err
+		}
+		breached = clock
+
+		if !sess.IsActive() || sess.IsEscalated() {
+			return nil
+		}
+		if err := sess.Escalate(); err != nil {
+			return err
+		}
+		if err := s.sessionRepo.Save(txCtx, sess); err != nil {
+			return fmt.Errorf("failed to save session: %w", err)
+		}
+		if err := publishEvents(txCtx, s.eventBus, sess.DomainEvents()); err != nil {
+			return err
+		}
+		sess.ClearEvents()
+		return nil
+	})
+	if err != nil || breached == nil {
+		return err
+	}
+
+	if s.automation != nil {
+		return s.automation.OnSLABreached(ctx, breached.SessionID(), string(breached.Metric()), breached.DueAt())
+	}
+	return nil
+}
+
+// ProcessDue trata os relógios cujo aviso ou vencimento já passou e retorna quantos processou.
+// Rede de segurança para timers perdidos (ex: Temporal indisponível). Falhas não param o lote.
+func (s *ClockService) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	clocks, err := s.clockRepo.FindDue(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, clock := range clocks {
+		var err error
+		if !now.Before(clock.DueAt()) {
+			err = s.Breach(ctx, clock.ID(), now)
+		} else {
+			err = s.Warn(ctx, clock.ID(), now)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("clock %s: %w", clock.ID(), err))
+		}
+	}
+	return len(clocks), errors.Join(errs...)
+}
+
+// load retorna o relógio e a sessão; relógio inexistente é ignorado (nil, nil, nil)
+func (s *ClockService) load(ctx context.Context, clockID uuid.UUID) (*sla.Clock, *session.Session, error) {
+	clock, err := s.clockRepo.FindByID(ctx, clockID)
+	if err != nil {
+		if errors.Is(err, sla.ErrClockNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+	sess, err := s.sessionRepo.FindByID(ctx, clock.SessionID())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	return clock, sess, nil
+}
+
+func (s *ClockService) save(ctx context.Context, clock *sla.Clock) error {
+	if err := s.clockRepo.Save(ctx, clock); err != nil {
+		return err
+	}
+	if err := publishEvents(ctx, s.eventBus, clock.DomainEvents()); err != nil {
+		return err
+	}
+	clock.ClearEvents()
+	return nil
+}
@@ -0,0 +1,126 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+type clockFixture struct {
+	clockRepo   *MockClockRepository
+	sessionRepo *MockSessionRepository
+	eventBus    *MockEventBus
+	automation  *MockAutomationTrigger
+	service     *ClockService
+
+	session *session.Session
+	clock   *sla.Clock
+}
+
+func newClockFixture(t *testing.T) *clockFixture {
+	t.Helper()
+	f := &clockFixture{
+		clockRepo:   new(MockClockRepository),
+		sessionRepo: new(MockSessionRepository),
+		eventBus:    new(MockEventBus),
+		automation:  new(MockAutomationTrigger),
+	}
+	f.service = NewClockService(f.clockRepo, f.sessionRepo, f.eventBus, &SimpleTransactionManager{}, f.automation)
+
+	var err error
+	f.session, err = session.NewSession(uuid.New(), "tenant-123", nil, 30*time.Minute)
+	require.NoError(t, err)
+	f.session.ClearEvents()
+	agentID := uuid.New()
+	require.NoError(t, f.session.AssignAgent(agentID))
+	f.session.ClearEvents()
+
+	policy, err := sla.NewPolicy("tenant-123", uuid.New(), "Default", sla.Targets{FirstResponse: 10 * time.Minute})
+	require.NoError(t, err)
+	f.clock, err = policy.StartClock(f.session.ID(), sla.MetricFirstResponse, f.session.StartedAt())
+	require.NoError(t, err)
+
+	f.clockRepo.On("FindByID", mock.Anything, f.clock.ID()).Return(f.clock, nil)
+	f.sessionRepo.On("FindByID", mock.Anything, f.session.ID()).Return(f.session, nil)
+
+	return f
+}
+
+func TestClockService_Warn(t *testing.T) {
+	f := newClockFixture(t)
+	f.clockRepo.On("Save", mock.Anything, f.clock).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.MatchedBy(func(e sla.WarningEvent) bool {
+		return e.AgentID != nil && *e.AgentID == *f.session.GetCurrentAgent()
+	})).Return(nil)
+	f.automation.On("OnSLAWarning", mock.Anything, f.session.ID(), "first_response", f.clock.DueAt()).Return(nil)
+
+	err := f.service.Warn(context.Background(), f.clock.ID(), f.clock.WarnAt())
+	require.NoError(t, err)
+
+	// Segundo disparo não repete o aviso
+	err = f.service.Warn(context.Background(), f.clock.ID(), f.clock.WarnAt().Add(time.Second))
+	require.NoError(t, err)
+
+	assert.NotNil(t, f.clock.WarnedAt())
+	f.eventBus.AssertNumberOfCalls(t, "Publish", 1)
+	f.automation.AssertNumberOfCalls(t, "OnSLAWarning", 1)
+}
+
+func TestClockService_BreachEscalatesSession(t *testing.T) {
+	f := newClockFixture(t)
+	f.clockRepo.On("Save", mock.Anything, f.clock).Return(nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.AnythingOfType("sla.BreachedEvent")).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.AnythingOfType("session.SessionEscalatedEvent")).Return(nil)
+	f.automation.On("OnSLABreached", mock.Anything, f.session.ID(), "first_response", f.clock.DueAt()).Return(nil)
+
+	err := f.service.Breach(context.Background(), f.clock.ID(), f.clock.DueAt())
+
+	require.NoError(t, err)
+	assert.Equal(t, sla.ClockBreached, f.clock.Status())
+	assert.True(t, f.session.IsEscalated())
+	f.eventBus.AssertExpectations(t)
+	f.automation.AssertExpectations(t)
+}
+
+func TestClockService_BreachStoppedClockIsNoop(t *testing.T) {
+	f := newClockFixture(t)
+	f.clock.Stop(f.clock.StartedAt().Add(time.Minute), nil, time.Minute)
+
+	err := f.service.Breach(context.Background(), f.clock.ID(), f.clock.DueAt())
+
+	require.NoError(t, err)
+	f.clockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	f.automation.AssertNotCalled(t, "OnSLABreached", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestClockService_MissingClockIsIgnored(t *testing.T) {
+	f := newClockFixture(t)
+	missing := uuid.New()
+	f.clockRepo.On("FindByID", mock.Anything, missing).Return(nil, sla.ErrClockNotFound)
+
+	assert.NoError(t, f.service.Breach(context.Background(), missing, time.Now()))
+}
+
+func TestClockService_ProcessDue(t *testing.T) {
+	f := newClockFixture(t)
+	now := f.clock.WarnAt().Add(time.Second)
+	f.clockRepo.On("FindDue", mock.Anything, now, 500).Return([]*sla.Clock{f.clock}, nil)
+	f.clockRepo.On("Save", mock.Anything, f.clock).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	f.automation.On("OnSLAWarning", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	count, err := f.service.ProcessDue(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.NotNil(t, f.clock.WarnedAt())
+	assert.True(t, f.clock.IsRunning())
+}
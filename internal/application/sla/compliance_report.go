@@ -0,0 +1,184 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+// ComplianceQuery escopo do relatório. AgentIDs restringe a um grupo de agentes (a equipe);
+// vazio = todos os agentes do tenant/projeto.
+type ComplianceQuery struct {
+	TenantID  string
+	ProjectID *uuid.UUID
+	AgentIDs  []uuid.UUID
+	From      time.Time
+	To        time.Time
+}
+
+// MetricCompliance cumprimento de uma métrica
+type MetricCompliance struct {
+	Metric            sla.Metric `json:"metric"`
+	Met               int        `json:"met"`
+	Breached          int        `json:"breached"`
+	ComplianceRate    float64    `json:"compliance_rate"`
+	AvgElapsedSeconds float64    `json:"avg_elapsed_seconds"`
+}
+
+// ComplianceSummary totais de um agente ou da equipe
+type ComplianceSummary struct {
+	Met            int                `json:"met"`
+	Breached       int                `json:"breached"`
+	ComplianceRate float64            `json:"compliance_rate"`
+	Metrics        []MetricCompliance `json:"metrics"`
+}
+
+// AgentCompliance cumprimento de SLA de um agente. AgentID nil = sessões que venceram sem agente
+type AgentCompliance struct {
+	AgentID *uuid.UUID `json:"agent_id"`
+	Name    string     `json:"name"`
+	ComplianceSummary
+}
+
+// ComplianceReport cumprimento de SLA por agente e da equipe no período
+type ComplianceReport struct {
+	From   time.Time         `json:"from"`
+	To     time.Time         `json:"to"`
+	Team   ComplianceSummary `json:"team"`
+	Agents []AgentCompliance `json:"agents"`
+}
+
+// ComplianceReportUseCase monta o relatório de cumprimento de SLA a partir dos relógios decididos
+type ComplianceReportUseCase struct {
+	clockRepo sla.ClockRepository
+	agentRepo agent.Repository
+}
+
+// NewComplianceReportUseCase creates a new instance
+func NewComplianceReportUseCase(clockRepo sla.ClockRepository, agentRepo agent.Repository) *ComplianceReportUseCase {
+	return &ComplianceReportUseCase{
+		clockRepo: clockRepo,
+		agentRepo: agentRepo,
+	}
+}
+
+// Execute gera o relatório (padrão: últimos 30 dias)
+func (uc *ComplianceReportUseCase) Execute(ctx context.Context, query ComplianceQuery) (*ComplianceReport, error) {
+	if query.To.IsZero() {
+		query.To = time.Now()
+	}
+	if query.From.IsZero() {
+		query.From = query.To.AddDate(0, 0, -30)
+	}
+	if !query.From.Before(query.To) {
+		return nil, shared.NewValidationError("from must be before to", "from")
+	}
+
+	rows, err := uc.clockRepo.Compliance(ctx, sla.ComplianceFilter{
+		TenantID:  query.TenantID,
+		ProjectID: query.ProjectID,
+		AgentIDs:  query.AgentIDs,
+		From:      query.From,
+		To:        query.To,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names, err := uc.agentNames(ctx, query.TenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	byAgent := map[uuid.UUID][]sla.ComplianceRow{}
+	var unassigned []sla.ComplianceRow
+	for _, row := range rows {
+		if row.AgentID == nil {
+			unassigned = append(unassigned, row)
+			continue
+		}
+		byAgent[*row.AgentID] = append(byAgent[*row.AgentID], row)
+	}
+
+	report := &ComplianceReport{
+		From:   query.From,
+		To:     query.To,
+		Team:   summarize(rows),
+		Agents: make([]AgentCompliance, 0, len(byAgent)+1),
+	}
+	for agentID, agentRows := range byAgent {
+		id := agentID
+		report.Agents = append(report.Agents, AgentCompliance{
+			AgentID:           &id,
+			Name:              names[agentID],
+			ComplianceSummary: summarize(agentRows),
+		})
+	}
+	sort.Slice(report.Agents, func(i, j int) bool {
+		return report.Agents[i].ComplianceRate < report.Agents[j].ComplianceRate
+	})
+	if len(unassigned) > 0 {
+		report.Agents = append(report.Agents, AgentCompliance{Name: "unassigned", ComplianceSummary: summarize(unassigned)})
+	}
+
+	return report, nil
+}
+
+func (uc *ComplianceReportUseCase) agentNames(ctx context.Context, tenantID string) (map[uuid.UUID]string, error) {
+	agents, err := uc.agentRepo.FindByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agents: %w", err)
+	}
+	names := make(map[uuid.UUID]string, len(agents))
+	for _, a := range agents {
+		names[a.ID()] = a.Name()
+	}
+	return names, nil
+}
+
+// summarize soma as linhas por métrica; a média de tempo é ponderada pelo número de relógios
+func summarize(rows []sla.ComplianceRow) ComplianceSummary {
+	totals := map[sla.Metric]*sla.ComplianceRow{}
+	var summary ComplianceSummary
+
+	for _, row := range rows {
+		total, ok := totals[row.Metric]
+		if !ok {
+			total = &sla.ComplianceRow{Metric: row.Metric}
+			totals[row.Metric] = total
+		}
+		count := float64(row.Met + row.Breached)
+		prev := float64(total.Met + total.Breached)
+		if count+prev > 0 {
+			total.AvgElapsedSeconds = (total.AvgElapsedSeconds*prev + row.AvgElapsedSeconds*count) / (count + prev)
+		}
+		total.Met += row.Met
+		total.Breached += row.Breached
+
+		summary.Met += row.Met
+		summary.Breached += row.Breached
+	}
+
+	summary.ComplianceRate = sla.ComplianceRow{Met: summary.Met, Breached: summary.Breached}.Rate()
+	summary.Metrics = make([]MetricCompliance, 0, len(totals))
+	for _, metric := range []sla.Metric{sla.MetricFirstResponse, sla.MetricNextResponse, sla.MetricResolution} {
+		total, ok := totals[metric]
+		if !ok {
+			continue
+		}
+		summary.Metrics = append(summary.Metrics, MetricCompliance{
+			Metric:            metric,
+			Met:               total.Met,
+			Breached:          total.Breached,
+			ComplianceRate:    total.Rate(),
+			AvgElapsedSeconds: total.AvgElapsedSeconds,
+		})
+	}
+	return summary
+}
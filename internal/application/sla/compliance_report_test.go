@@ -0,0 +1,67 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+func TestComplianceReport_Execute(t *testing.T) {
+	clockRepo := new(MockClockRepository)
+	agentRepo := new(MockAgentRepository)
+	useCase := NewComplianceReportUseCase(clockRepo, agentRepo)
+
+	userID := uuid.New()
+	ana, err := agent.NewAgent(uuid.New(), "tenant-123", "Ana", agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	bruno, err := agent.NewAgent(uuid.New(), "tenant-123", "Bruno", agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	anaID, brunoID := ana.ID(), bruno.ID()
+
+	to := time.Now()
+	from := to.AddDate(0, 0, -7)
+	clockRepo.On("Compliance", mock.Anything, mock.MatchedBy(func(f sla.ComplianceFilter) bool {
+		return f.TenantID == "tenant-123" && f.From.Equal(from) && f.To.Equal(to)
+	})).Return([]sla.ComplianceRow{
+		{AgentID: &anaID, Metric: sla.MetricFirstResponse, Met: 9, Breached: 1, AvgElapsedSeconds: 100},
+		{AgentID: &anaID, Metric: sla.MetricResolution, Met: 5, Breached: 0, AvgElapsedSeconds: 3600},
+		{AgentID: &brunoID, Metric: sla.MetricFirstResponse, Met: 1, Breached: 1, AvgElapsedSeconds: 700},
+		{Metric: sla.MetricFirstResponse, Met: 0, Breached: 2, AvgElapsedSeconds: 900},
+	}, nil)
+	agentRepo.On("FindByTenant", mock.Anything, "tenant-123").Return([]*agent.Agent{ana, bruno}, nil)
+
+	report, err := useCase.Execute(context.Background(), ComplianceQuery{TenantID: "tenant-123", From: from, To: to})
+	require.NoError(t, err)
+
+	assert.Equal(t, 15, report.Team.Met)
+	assert.Equal(t, 4, report.Team.Breached)
+	require.Len(t, report.Team.Metrics, 2)
+	firstResponse := report.Team.Metrics[0]
+	assert.Equal(t, sla.MetricFirstResponse, firstResponse.Metric)
+	assert.Equal(t, 10, firstResponse.Met)
+	assert.Equal(t, 4, firstResponse.Breached)
+	assert.InDelta(t, (100.0*10+700*2+900*2)/14, firstResponse.AvgElapsedSeconds, 0.001)
+
+	require.Len(t, report.Agents, 3)
+	assert.Equal(t, "Bruno", report.Agents[0].Name)
+	assert.Equal(t, 50.0, report.Agents[0].ComplianceRate)
+	assert.Equal(t, "Ana", report.Agents[1].Name)
+	assert.Nil(t, report.Agents[2].AgentID)
+	assert.Equal(t, 0.0, report.Agents[2].ComplianceRate)
+}
+
+func TestComplianceReport_InvalidRange(t *testing.T) {
+	useCase := NewComplianceReportUseCase(new(MockClockRepository), new(MockAgentRepository))
+	now := time.Now()
+
+	_, err := useCase.Execute(context.Background(), ComplianceQuery{TenantID: "tenant-123", From: now, To: now.Add(-time.Hour)})
+
+	assert.Error(t, err)
+}
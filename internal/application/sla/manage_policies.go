@@ -0,0 +1,223 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+// PolicyInput dados de criação/atualização de uma política (metas em minutos úteis; 0 = não acompanhar)
+type PolicyInput struct {
+	Name                 string
+	PipelineID           *uuid.UUID
+	ChannelTypeID        *int
+	Priority             *string
+	FirstResponseMinutes int
+	NextResponseMinutes  int
+	ResolutionMinutes    int
+	WarningPercent       *int
+	BusinessHours        *businesshours.Schedule
+	Enabled              *bool
+}
+
+func (in PolicyInput) targets() sla.Targets {
+	return sla.Targets{
+		FirstResponse: time.Duration(in.FirstResponseMinutes) * time.Minute,
+		NextResponse:  time.Duration(in.NextResponseMinutes) * time.Minute,
+		Resolution:    time.Duration(in.ResolutionMinutes) * time.Minute,
+	}
+}
+
+// PolicyView política exposta pela API
+type PolicyView struct {
+	ID                   uuid.UUID               `json:"id"`
+	ProjectID            uuid.UUID               `json:"project_id"`
+	Name                 string                  `json:"name"`
+	PipelineID           *uuid.UUID              `json:"pipeline_id,omitempty"`
+	ChannelTypeID        *int                    `json:"channel_type_id,omitempty"`
+	Priority             *session.Priority       `json:"priority,omitempty"`
+	FirstResponseMinutes int                     `json:"first_response_minutes"`
+	NextResponseMinutes  int                     `json:"next_response_minutes"`
+	ResolutionMinutes    int                     `json:"resolution_minutes"`
+	WarningPercent       int                     `json:"warning_percent"`
+	BusinessHours        *businesshours.Schedule `json:"business_hours,omitempty"`
+	Enabled              bool                    `json:"enabled"`
+	CreatedAt            time.Time               `json:"created_at"`
+	UpdatedAt            time.Time               `json:"updated_at"`
+}
+
+func newPolicyView(p *sla.Policy) PolicyView {
+	return PolicyView{
+		ID:                   p.ID(),
+		ProjectID:            p.ProjectID(),
+		Name:                 p.Name(),
+		PipelineID:           p.PipelineID(),
+		ChannelTypeID:        p.ChannelTypeID(),
+		Priority:             p.Priority(),
+		FirstResponseMinutes: int(p.Targets().FirstResponse.Minutes()),
+		NextResponseMinutes:  int(p.Targets().NextResponse.Minutes()),
+		ResolutionMinutes:    int(p.Targets().Resolution.Minutes()),
+		WarningPercent:       p.WarningPercent(),
+		BusinessHours:        p.BusinessHours(),
+		Enabled:              p.IsEnabled(),
+		CreatedAt:            p.CreatedAt(),
+		UpdatedAt:            p.UpdatedAt(),
+	}
+}
+
+// ManagePoliciesUseCase CRUD das políticas de SLA de um projeto.
+// Mudanças valem para relógios iniciados depois delas (e para sessões que mudarem de prioridade).
+type ManagePoliciesUseCase struct {
+	policyRepo  sla.PolicyRepository
+	projectRepo project.Repository
+}
+
+// NewManagePoliciesUseCase creates a new instance
+func NewManagePoliciesUseCase(policyRepo sla.PolicyRepository, projectRepo project.Repository) *ManagePoliciesUseCase {
+	return &ManagePoliciesUseCase{
+		policyRepo:  policyRepo,
+		projectRepo: projectRepo,
+	}
+}
+
+func (uc *ManagePoliciesUseCase) List(ctx context.Context, tenantID string, projectID uuid.UUID) ([]PolicyView, error) {
+	if err := uc.checkProject(ctx, tenantID, projectID); err != nil {
+		return nil, err
+	}
+
+	policies, err := uc.policyRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]PolicyView, len(policies))
+	for i, p := range policies {
+		views[i] = newPolicyView(p)
+	}
+	return views, nil
+}
+
+func (uc *ManagePoliciesUseCase) Get(ctx context.Context, tenantID string, policyID uuid.UUID) (*PolicyView, error) {
+	p, err := uc.find(ctx, tenantID, policyID)
+	if err != nil {
+		return nil, err
+	}
+	view := newPolicyView(p)
+	return &view, nil
+}
+
+func (uc *ManagePoliciesUseCase) Create(ctx context.Context, tenantID string, projectID uuid.UUID, input PolicyInput) (*PolicyView, error) {
+	if err := uc.checkProject(ctx, tenantID, projectID); err != nil {
+		return nil, err
+	}
+
+	p, err := sla.NewPolicy(tenantID, projectID, input.Name, input.targets())
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "policy")
+	}
+	if err := apply(p, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.policyRepo.Save(ctx, p); err != nil {
+		return nil, err
+	}
+	view := newPolicyView(p)
+	return &view, nil
+}
+
+func (uc *ManagePoliciesUseCase) Update(ctx context.Context, tenantID string, policyID uuid.UUID, input PolicyInput) (*PolicyView, error) {
+	p, err := uc.find(ctx, tenantID, policyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.Rename(input.Name); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "name")
+	}
+	if err := p.SetTargets(input.targets()); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "targets")
+	}
+	if err := apply(p, input); err != nil {
+		return nil, err
+	}
+
+	if err := uc.policyRepo.Save(ctx, p); err != nil {
+		return nil, err
+	}
+	view := newPolicyView(p)
+	return &view, nil
+}
+
+func (uc *ManagePoliciesUseCase) Delete(ctx context.Context, tenantID string, policyID uuid.UUID) error {
+	if _, err := uc.find(ctx, tenantID, policyID); err != nil {
+		return err
+	}
+	return uc.policyRepo.Delete(ctx, policyID)
+}
+
+// apply grava escopo, aviso, expediente e habilitação
+func apply(p *sla.Policy, input PolicyInput) error {
+	var priority *session.Priority
+	if input.Priority != nil && *input.Priority != "" {
+		parsed, err := session.ParsePriority(*input.Priority)
+		if err != nil {
+			return shared.NewValidationError(err.Error(), "priority")
+		}
+		priority = &parsed
+	}
+	if err := p.SetScope(input.PipelineID, input.ChannelTypeID, priority); err != nil {
+		return shared.NewValidationError(err.Error(), "scope")
+	}
+
+	if input.WarningPercent != nil {
+		if err := p.SetWarningPercent(*input.WarningPercent); err != nil {
+			return shared.NewValidationError(err.Error(), "warning_percent")
+		}
+	}
+	if err := p.SetBusinessHours(input.BusinessHours); err != nil {
+		return shared.NewValidationError(err.Error(), "business_hours")
+	}
+
+	if input.Enabled != nil {
+		if *input.Enabled {
+			p.Enable()
+		} else {
+			p.Disable()
+		}
+	}
+	return nil
+}
+
+func (uc *ManagePoliciesUseCase) checkProject(ctx context.Context, tenantID string, projectID uuid.UUID) error {
+	if projectID == uuid.Nil {
+		return shared.NewValidationError("project_id is required", "project_id")
+	}
+	proj, err := uc.projectRepo.FindByID(ctx, projectID)
+	if err != nil || proj == nil || proj.TenantID() != tenantID {
+		return shared.NewNotFoundError("project", projectID.String())
+	}
+	return nil
+}
+
+func (uc *ManagePoliciesUseCase) find(ctx context.Context, tenantID string, policyID uuid.UUID) (*sla.Policy, error) {
+	p, err := uc.policyRepo.FindByID(ctx, policyID)
+	if err != nil {
+		if errors.Is(err, sla.ErrPolicyNotFound) {
+			return nil, shared.NewNotFoundError("sla_policy", policyID.String())
+		}
+		return nil, fmt.Errorf("failed to load sla policy: %w", err)
+	}
+	if p.TenantID() != tenantID {
+		return nil, shared.NewNotFoundError("sla_policy", policyID.String())
+	}
+	return p, nil
+}
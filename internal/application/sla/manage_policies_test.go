@@ -0,0 +1,78 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+func newPolicyFixture(t *testing.T) (*ManagePoliciesUseCase, *MockPolicyRepository, *project.Project) {
+	t.Helper()
+	policyRepo := new(MockPolicyRepository)
+	projectRepo := new(MockProjectRepository)
+
+	proj, err := project.NewProject(uuid.New(), uuid.New(), "tenant-123", "Project")
+	require.NoError(t, err)
+	projectRepo.On("FindByID", mock.Anything, proj.ID()).Return(proj, nil)
+
+	return NewManagePoliciesUseCase(policyRepo, projectRepo), policyRepo, proj
+}
+
+func TestManagePolicies_Create(t *testing.T) {
+	useCase, policyRepo, proj := newPolicyFixture(t)
+	policyRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	high := "high"
+	warning := 70
+	view, err := useCase.Create(context.Background(), "tenant-123", proj.ID(), PolicyInput{
+		Name:                 "VIP",
+		Priority:             &high,
+		FirstResponseMinutes: 5,
+		ResolutionMinutes:    240,
+		WarningPercent:       &warning,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "VIP", view.Name)
+	assert.Equal(t, "high", view.Priority.String())
+	assert.Equal(t, 5, view.FirstResponseMinutes)
+	assert.Equal(t, 0, view.NextResponseMinutes)
+	assert.Equal(t, 70, view.WarningPercent)
+	assert.True(t, view.Enabled)
+}
+
+func TestManagePolicies_CreateValidation(t *testing.T) {
+	useCase, _, proj := newPolicyFixture(t)
+	invalid := "critical"
+
+	_, err := useCase.Create(context.Background(), "tenant-123", proj.ID(), PolicyInput{Name: "VIP"})
+	assert.True(t, shared.IsValidationError(err))
+
+	_, err = useCase.Create(context.Background(), "tenant-123", proj.ID(), PolicyInput{Name: "VIP", FirstResponseMinutes: 5, Priority: &invalid})
+	assert.True(t, shared.IsValidationError(err))
+}
+
+func TestManagePolicies_TenantIsolation(t *testing.T) {
+	useCase, policyRepo, proj := newPolicyFixture(t)
+	policy, err := sla.NewPolicy("other-tenant", proj.ID(), "Default", sla.Targets{Resolution: time.Hour})
+	require.NoError(t, err)
+	policyRepo.On("FindByID", mock.Anything, policy.ID()).Return(policy, nil)
+
+	_, err = useCase.Get(context.Background(), "tenant-123", policy.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+
+	_, err = useCase.List(context.Background(), "other-tenant", proj.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+
+	err = useCase.Delete(context.Background(), "tenant-123", policy.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+	policyRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
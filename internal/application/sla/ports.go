@@ -0,0 +1,42 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Timers agenda os disparos de aviso e vencimento de cada relógio (ex: timers do Temporal).
+// Os disparos chamam ClockService.Warn/Breach, que são idempotentes.
+type Timers interface {
+	Schedule(ctx context.Context, clock *sla.Clock) error
+	Cancel(ctx context.Context, clockID uuid.UUID) error
+}
+
+// AutomationTrigger dispara as automações sla.warning / sla.breached do pipeline da sessão
+type AutomationTrigger interface {
+	OnSLAWarning(ctx context.Context, sessionID uuid.UUID, metric string, dueAt time.Time) error
+	OnSLABreached(ctx context.Context, sessionID uuid.UUID, metric string, dueAt time.Time) error
+}
+
+// publishEvents publica os eventos pendentes (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, events []shared.DomainEvent) error {
+	for _, event := range events {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+		}
+	}
+	return nil
+}
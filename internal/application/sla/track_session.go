@@ -0,0 +1,341 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+// TrackSessionUseCase abre e para os relógios de SLA a partir dos eventos da sessão:
+//
+//   - session.started: first_response e resolution, pela política mais específica do projeto
+//   - mensagem do contato após a primeira resposta: abre next_response (se não houver um aberto)
+//   - mensagem do agente: para first_response / next_response
+//   - session.ended: para resolution e cancela os relógios de resposta
+//   - session.priority_changed: aplica os prazos da política da nova prioridade
+//
+// Políticas sem expediente próprio contam o tempo pelo expediente do projeto (se houver).
+// Os timers só são agendados/cancelados depois do commit.
+type TrackSessionUseCase struct {
+	sessionRepo  session.Repository
+	contactRepo  contact.Repository
+	policyRepo   sla.PolicyRepository
+	clockRepo    sla.ClockRepository
+	calendarRepo businesshours.CalendarRepository
+	timers       Timers
+	eventBus     EventBus
+	txManager    TransactionManager
+}
+
+// NewTrackSessionUseCase creates a new instance
+func NewTrackSessionUseCase(
+	sessionRepo session.Repository,
+	contactRepo contact.Repository,
+	policyRepo sla.PolicyRepository,
+	clockRepo sla.ClockRepository,
+	calendarRepo businesshours.CalendarRepository,
+	timers Timers,
+	eventBus EventBus,
+	txManager TransactionManager,
+) *TrackSessionUseCase {
+	return &TrackSessionUseCase{
+		sessionRepo:  sessionRepo,
+		contactRepo:  contactRepo,
+		policyRepo:   policyRepo,
+		clockRepo:    clockRepo,
+		calendarRepo: calendarRepo,
+		timers:       timers,
+		eventBus:     eventBus,
+		txManager:    txManager,
+	}
+}
+
+// timerChanges timers a agendar e cancelar após o commit
+type timerChanges struct {
+	schedule []*sla.Clock
+	cancel   []uuid.UUID
+}
+
+// OnSessionStarted abre os relógios da sessão. Idempotente: sessões com relógios são ignoradas.
+func (uc *TrackSessionUseCase) OnSessionStarted(ctx context.Context, sessionID uuid.UUID) error {
+	return uc.run(ctx, func(txCtx context.Context, changes *timerChanges) error {
+		sess, clocks, err := uc.load(txCtx, sessionID)
+		if err != nil || !sess.IsActive() || len(clocks) > 0 {
+			return err
+		}
+
+		policy, err := uc.selectPolicy(txCtx, sess)
+		if err != nil || policy == nil {
+			return err
+		}
+
+		for _, metric := range []sla.Metric{sla.MetricFirstResponse, sla.MetricResolution} {
+			if !policy.Tracks(metric) {
+				continue
+			}
+			// Sessões iniciadas pelo agente não têm primeira resposta a esperar
+			if metric == sla.MetricFirstResponse && sess.FirstAgentResponseAt() != nil {
+				continue
+			}
+			if err := uc.start(txCtx, policy, sess.ID(), metric, sess.StartedAt(), changes); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// OnMessageRecorded para os relógios de resposta (mensagem do agente) ou abre next_response (mensagem do contato)
+func (uc *TrackSessionUseCase) OnMessageRecorded(ctx context.Context, sessionID uuid.UUID, fromContact bool, at time.Time) error {
+	return uc.run(ctx, func(txCtx context.Context, changes *timerChanges) error {
+		sess, clocks, err := uc.load(txCtx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		if !fromContact {
+			return uc.stopClocks(txCtx, sess, clocks, at, changes, sla.MetricFirstResponse, sla.MetricNextResponse)
+		}
+
+		if !sess.IsActive() || sess.FirstAgentResponseAt() == nil || hasRunning(clocks, sla.MetricFirstResponse, sla.MetricNextResponse) {
+			return nil
+		}
+
+		policy, err := uc.policyFor(txCtx, sess, clocks)
+		if err != nil || policy == nil || !policy.Tracks(sla.MetricNextResponse) {
+			return err
+		}
+		return uc.start(txCtx, policy, sess.ID(), sla.MetricNextResponse, at, changes)
+	})
+}
+
+// OnSessionEnded para o relógio de resolução e cancela os de resposta
+func (uc *TrackSessionUseCase) OnSessionEnded(ctx context.Context, sessionID uuid.UUID, at time.Time) error {
+	return uc.run(ctx, func(txCtx context.Context, changes *timerChanges) error {
+		sess, clocks, err := uc.load(txCtx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		if err := uc.stopClocks(txCtx, sess, clocks, at, changes, sla.MetricResolution); err != nil {
+			return err
+		}
+		for _, clock := range clocks {
+			if clock.Cancel(at) {
+				if err := uc.clockRepo.Save(txCtx, clock); err != nil {
+					return err
+				}
+				changes.cancel = append(changes.cancel, clock.ID())
+			}
+		}
+		return nil
+	})
+}
+
+// OnPriorityChanged reaplica os prazos dos relógios abertos segundo a política da nova prioridade.
+// Sessões sem relógios (nenhuma política se aplicava antes) são tratadas como recém-iniciadas.
+func (uc *TrackSessionUseCase) OnPriorityChanged(ctx context.Context, sessionID uuid.UUID) error {
+	sess, clocks, err := uc.load(ctx, sessionID)
+	if err != nil || !sess.IsActive() {
+		return err
+	}
+	if len(clocks) == 0 {
+		return uc.OnSessionStarted(ctx, sessionID)
+	}
+
+	return uc.run(ctx, func(txCtx context.Context, changes *timerChanges) error {
+		sess, clocks, err := uc.load(txCtx, sessionID)
+		if err != nil {
+			return err
+		}
+
+		policy, err := uc.selectPolicy(txCtx, sess)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		for _, clock := range clocks {
+			if !clock.IsRunning() || (policy != nil && clock.PolicyID() == policy.ID()) {
+				continue
+			}
+
+			if policy == nil || clock.Reschedule(policy) != nil {
+				// A nova política não acompanha a métrica
+				clock.Cancel(now)
+				changes.cancel = append(changes.cancel, clock.ID())
+			} else {
+				changes.cancel = append(changes.cancel, clock.ID())
+				changes.schedule = append(changes.schedule, clock)
+			}
+			if err := uc.clockRepo.Save(txCtx, clock); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// run executa a mudança em transação e só então ajusta os timers
+func (uc *TrackSessionUseCase) run(ctx context.Context, fn func(ctx context.Context, changes *timerChanges) error) error {
+	changes := &timerChanges{}
+	if err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		return fn(txCtx, changes)
+	}); err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, clockID := range changes.cancel {
+		if err := uc.timers.Cancel(ctx, clockID); err != nil {
+			errs = append(errs, fmt.Errorf("cancel timer %s: %w", clockID, err))
+		}
+	}
+	for _, clock := range changes.schedule {
+		if err := uc.timers.Schedule(ctx, clock); err != nil {
+			errs = append(errs, fmt.Errorf("schedule timer %s: %w", clock.ID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (uc *TrackSessionUseCase) load(ctx context.Context, sessionID uuid.UUID) (*session.Session, []*sla.Clock, error) {
+	sess, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	clocks, err := uc.clockRepo.FindBySession(ctx, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sess, clocks, nil
+}
+
+func (uc *TrackSessionUseCase) start(ctx context.Context, policy *sla.Policy, sessionID uuid.UUID, metric sla.Metric, at time.Time, changes *timerChanges) error {
+	clock, err := policy.StartClock(sessionID, metric, at)
+	if err != nil {
+		return err
+	}
+	if err := uc.clockRepo.Save(ctx, clock); err != nil {
+		return err
+	}
+	changes.schedule = append(changes.schedule, clock)
+	return nil
+}
+
+// stopClocks para os relógios abertos das métricas. Vencimentos detectados aqui (timer atrasado) são publicados.
+func (uc *TrackSessionUseCase) stopClocks(ctx context.Context, sess *session.Session, clocks []*sla.Clock, at time.Time, changes *timerChanges, metrics ...sla.Metric) error {
+	policies := map[uuid.UUID]*sla.Policy{}
+	for _, clock := range clocks {
+		if !clock.IsRunning() || !isMetric(clock.Metric(), metrics) {
+			continue
+		}
+
+		policy, ok := policies[clock.PolicyID()]
+		if !ok {
+			var err error
+			if policy, err = uc.policyRepo.FindByID(ctx, clock.PolicyID()); err != nil && !errors.Is(err, sla.ErrPolicyNotFound) {
+				return err
+			}
+			if err := uc.applyProjectHours(ctx, policy); err != nil {
+				return err
+			}
+			policies[clock.PolicyID()] = policy
+		}
+
+		elapsed := at.Sub(clock.StartedAt())
+		if policy != nil {
+			elapsed = policy.Elapsed(clock.StartedAt(), at)
+		}
+
+		clock.Stop(at, sess.GetCurrentAgent(), elapsed)
+		if err := uc.clockRepo.Save(ctx, clock); err != nil {
+			return err
+		}
+		if err := publishEvents(ctx, uc.eventBus, clock.DomainEvents()); err != nil {
+			return err
+		}
+		clock.ClearEvents()
+		changes.cancel = append(changes.cancel, clock.ID())
+	}
+	return nil
+}
+
+// selectPolicy escolhe a política do projeto do contato para a sessão
+func (uc *TrackSessionUseCase) selectPolicy(ctx context.Context, sess *session.Session) (*sla.Policy, error) {
+	c, err := uc.contactRepo.FindByID(ctx, sess.ContactID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load contact: %w", err)
+	}
+	policies, err := uc.policyRepo.FindByProject(ctx, c.ProjectID())
+	if err != nil {
+		return nil, err
+	}
+	policy := sla.SelectPolicy(policies, sla.TargetFor(sess))
+	if err := uc.applyProjectHours(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// policyFor reaproveita a política dos relógios da sessão; sem relógios, seleciona de novo
+func (uc *TrackSessionUseCase) policyFor(ctx context.Context, sess *session.Session, clocks []*sla.Clock) (*sla.Policy, error) {
+	if len(clocks) == 0 {
+		return uc.selectPolicy(ctx, sess)
+	}
+	policy, err := uc.policyRepo.FindByID(ctx, clocks[len(clocks)-1].PolicyID())
+	if errors.Is(err, sla.ErrPolicyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !policy.IsEnabled() {
+		return nil, nil
+	}
+	if err := uc.applyProjectHours(ctx, policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// applyProjectHours usa o expediente do projeto quando a política não define o seu
+func (uc *TrackSessionUseCase) applyProjectHours(ctx context.Context, policy *sla.Policy) error {
+	if policy == nil || policy.BusinessHours() != nil || uc.calendarRepo == nil {
+		return nil
+	}
+	calendar, err := uc.calendarRepo.FindByProject(ctx, policy.ProjectID())
+	if errors.Is(err, businesshours.ErrCalendarNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load business hours calendar: %w", err)
+	}
+	policy.UseProjectHours(calendar.Schedule())
+	return nil
+}
+
+func hasRunning(clocks []*sla.Clock, metrics ...sla.Metric) bool {
+	for _, clock := range clocks {
+		if clock.IsRunning() && isMetric(clock.Metric(), metrics) {
+			return true
+		}
+	}
+	return false
+}
+
+func isMetric(metric sla.Metric, metrics []sla.Metric) bool {
+	for _, m := range metrics {
+		if m == metric {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,251 @@
+package sla
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+)
+
+type trackFixture struct {
+	sessionRepo  *MockSessionRepository
+	contactRepo  *MockContactRepository
+	policyRepo   *MockPolicyRepository
+	clockRepo    *MockClockRepository
+	calendarRepo *MockCalendarRepository
+	timers       *MockTimers
+	eventBus     *MockEventBus
+	useCase      *TrackSessionUseCase
+
+	session *session.Session
+	contact *contact.Contact
+	policy  *sla.Policy
+}
+
+func newTrackFixture(t *testing.T) *trackFixture {
+	t.Helper()
+	f := &trackFixture{
+		sessionRepo:  new(MockSessionRepository),
+		contactRepo:  new(MockContactRepository),
+		policyRepo:   new(MockPolicyRepository),
+		clockRepo:    new(MockClockRepository),
+		calendarRepo: new(MockCalendarRepository),
+		timers:       new(MockTimers),
+		eventBus:     new(MockEventBus),
+	}
+	f.useCase = NewTrackSessionUseCase(f.sessionRepo, f.contactRepo, f.policyRepo, f.clockRepo, f.calendarRepo, f.timers, f.eventBus, &SimpleTransactionManager{})
+
+	var err error
+	f.contact, err = contact.NewContact(uuid.New(), "tenant-123", "Maria")
+	require.NoError(t, err)
+
+	f.session, err = session.NewSession(f.contact.ID(), "tenant-123", nil, 30*time.Minute)
+	require.NoError(t, err)
+	f.session.ClearEvents()
+
+	f.policy, err = sla.NewPolicy("tenant-123", f.contact.ProjectID(), "Default", sla.Targets{
+		FirstResponse: 10 * time.Minute,
+		NextResponse:  15 * time.Minute,
+		Resolution:    8 * time.Hour,
+	})
+	require.NoError(t, err)
+
+	f.sessionRepo.On("FindByID", mock.Anything, f.session.ID()).Return(f.session, nil)
+	f.contactRepo.On("FindByID", mock.Anything, f.contact.ID()).Return(f.contact, nil)
+	f.policyRepo.On("FindByProject", mock.Anything, f.contact.ProjectID()).Return([]*sla.Policy{f.policy}, nil)
+	f.policyRepo.On("FindByID", mock.Anything, f.policy.ID()).Return(f.policy, nil)
+	f.calendarRepo.On("FindByProject", mock.Anything, f.contact.ProjectID()).Return(nil, businesshours.ErrCalendarNotFound)
+
+	return f
+}
+
+func (f *trackFixture) clock(t *testing.T, metric sla.Metric, startedAt time.Time) *sla.Clock {
+	t.Helper()
+	clock, err := f.policy.StartClock(f.session.ID(), metric, startedAt)
+	require.NoError(t, err)
+	return clock
+}
+
+func TestTrackSession_OnSessionStarted_OpensClocks(t *testing.T) {
+	f := newTrackFixture(t)
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{}, nil)
+
+	var saved []*sla.Clock
+	f.clockRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(*sla.Clock))
+	}).Return(nil)
+	f.timers.On("Schedule", mock.Anything, mock.Anything).Return(nil)
+
+	err := f.useCase.OnSessionStarted(context.Background(), f.session.ID())
+
+	require.NoError(t, err)
+	require.Len(t, saved, 2)
+	assert.Equal(t, sla.MetricFirstResponse, saved[0].Metric())
+	assert.Equal(t, sla.MetricResolution, saved[1].Metric())
+	assert.Equal(t, f.session.StartedAt().Add(10*time.Minute), saved[0].DueAt())
+	f.timers.AssertNumberOfCalls(t, "Schedule", 2)
+}
+
+func TestTrackSession_OnSessionStarted_UsesProjectHours(t *testing.T) {
+	f := newTrackFixture(t)
+	// Projeto fechado o tempo todo exceto segunda 09:00-10:00 UTC
+	calendar, err := businesshours.NewCalendar("tenant-123", f.contact.ProjectID(), businesshours.Schedule{
+		Timezone: "UTC",
+		Weekly:   map[string][]businesshours.Interval{"monday": {{Start: "09:00", End: "10:00"}}},
+	})
+	require.NoError(t, err)
+	f.calendarRepo.ExpectedCalls = nil
+	f.calendarRepo.On("FindByProject", mock.Anything, f.contact.ProjectID()).Return(calendar, nil)
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{}, nil)
+
+	var saved []*sla.Clock
+	f.clockRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(*sla.Clock))
+	}).Return(nil)
+	f.timers.On("Schedule", mock.Anything, mock.Anything).Return(nil)
+
+	err = f.useCase.OnSessionStarted(context.Background(), f.session.ID())
+
+	require.NoError(t, err)
+	require.NotEmpty(t, saved)
+	due := saved[0].DueAt().UTC()
+	assert.Equal(t, time.Monday, due.Weekday())
+	assert.True(t, due.Hour() == 9 || (due.Hour() == 10 && due.Minute() == 0))
+}
+
+func TestTrackSession_OnSessionStarted_IgnoresTrackedSessions(t *testing.T) {
+	f := newTrackFixture(t)
+	existing := f.clock(t, sla.MetricResolution, f.session.StartedAt())
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{existing}, nil)
+
+	err := f.useCase.OnSessionStarted(context.Background(), f.session.ID())
+
+	require.NoError(t, err)
+	f.clockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	f.timers.AssertNotCalled(t, "Schedule", mock.Anything, mock.Anything)
+}
+
+func TestTrackSession_OnSessionStarted_NoPolicy(t *testing.T) {
+	f := newTrackFixture(t)
+	f.policy.Disable()
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{}, nil)
+
+	err := f.useCase.OnSessionStarted(context.Background(), f.session.ID())
+
+	require.NoError(t, err)
+	f.clockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestTrackSession_OnMessageRecorded_AgentReplyStopsFirstResponse(t *testing.T) {
+	f := newTrackFixture(t)
+	first := f.clock(t, sla.MetricFirstResponse, f.session.StartedAt())
+	resolution := f.clock(t, sla.MetricResolution, f.session.StartedAt())
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{first, resolution}, nil)
+	f.clockRepo.On("Save", mock.Anything, first).Return(nil)
+	f.timers.On("Cancel", mock.Anything, first.ID()).Return(nil)
+
+	err := f.useCase.OnMessageRecorded(context.Background(), f.session.ID(), false, f.session.StartedAt().Add(3*time.Minute))
+
+	require.NoError(t, err)
+	assert.Equal(t, sla.ClockMet, first.Status())
+	assert.Equal(t, 180, *first.ElapsedSeconds())
+	assert.True(t, resolution.IsRunning())
+	f.eventBus.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything)
+}
+
+func TestTrackSession_OnMessageRecorded_LateReplyPublishesBreach(t *testing.T) {
+	f := newTrackFixture(t)
+	first := f.clock(t, sla.MetricFirstResponse, f.session.StartedAt())
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{first}, nil)
+	f.clockRepo.On("Save", mock.Anything, first).Return(nil)
+	f.timers.On("Cancel", mock.Anything, first.ID()).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.AnythingOfType("sla.BreachedEvent")).Return(nil)
+
+	err := f.useCase.OnMessageRecorded(context.Background(), f.session.ID(), false, f.session.StartedAt().Add(20*time.Minute))
+
+	require.NoError(t, err)
+	assert.Equal(t, sla.ClockBreached, first.Status())
+	f.eventBus.AssertExpectations(t)
+}
+
+func TestTrackSession_OnMessageRecorded_ContactMessageOpensNextResponse(t *testing.T) {
+	f := newTrackFixture(t)
+	require.NoError(t, f.session.RecordMessage(false, f.session.StartedAt().Add(time.Minute)))
+	resolution := f.clock(t, sla.MetricResolution, f.session.StartedAt())
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{resolution}, nil)
+
+	var saved *sla.Clock
+	f.clockRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*sla.Clock)
+	}).Return(nil)
+	f.timers.On("Schedule", mock.Anything, mock.Anything).Return(nil)
+
+	at := f.session.StartedAt().Add(5 * time.Minute)
+	err := f.useCase.OnMessageRecorded(context.Background(), f.session.ID(), true, at)
+
+	require.NoError(t, err)
+	require.NotNil(t, saved)
+	assert.Equal(t, sla.MetricNextResponse, saved.Metric())
+	assert.Equal(t, at.Add(15*time.Minute), saved.DueAt())
+}
+
+func TestTrackSession_OnSessionEnded(t *testing.T) {
+	f := newTrackFixture(t)
+	next := f.clock(t, sla.MetricNextResponse, f.session.StartedAt())
+	resolution := f.clock(t, sla.MetricResolution, f.session.StartedAt())
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{next, resolution}, nil)
+	f.clockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	f.timers.On("Cancel", mock.Anything, mock.Anything).Return(nil)
+
+	err := f.useCase.OnSessionEnded(context.Background(), f.session.ID(), f.session.StartedAt().Add(time.Hour))
+
+	require.NoError(t, err)
+	assert.Equal(t, sla.ClockMet, resolution.Status())
+	assert.Equal(t, sla.ClockCancelled, next.Status())
+	f.timers.AssertNumberOfCalls(t, "Cancel", 2)
+}
+
+func TestTrackSession_OnPriorityChanged_ReschedulesWithNewPolicy(t *testing.T) {
+	f := newTrackFixture(t)
+	first := f.clock(t, sla.MetricFirstResponse, f.session.StartedAt())
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{first}, nil)
+
+	urgent := session.PriorityUrgent
+	urgentPolicy, err := sla.NewPolicy("tenant-123", f.contact.ProjectID(), "Urgent", sla.Targets{FirstResponse: 2 * time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, urgentPolicy.SetScope(nil, nil, &urgent))
+	f.policyRepo.ExpectedCalls = nil
+	f.policyRepo.On("FindByProject", mock.Anything, f.contact.ProjectID()).Return([]*sla.Policy{f.policy, urgentPolicy}, nil)
+
+	require.NoError(t, f.session.SetPriority(session.PriorityUrgent))
+	f.clockRepo.On("Save", mock.Anything, first).Return(nil)
+	f.timers.On("Cancel", mock.Anything, first.ID()).Return(nil)
+	f.timers.On("Schedule", mock.Anything, first).Return(nil)
+
+	err = f.useCase.OnPriorityChanged(context.Background(), f.session.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, urgentPolicy.ID(), first.PolicyID())
+	assert.Equal(t, f.session.StartedAt().Add(2*time.Minute), first.DueAt())
+	f.timers.AssertExpectations(t)
+}
+
+func TestTrackSession_TimerFailureDoesNotRollBack(t *testing.T) {
+	f := newTrackFixture(t)
+	f.clockRepo.On("FindBySession", mock.Anything, f.session.ID()).Return([]*sla.Clock{}, nil)
+	f.clockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+	f.timers.On("Schedule", mock.Anything, mock.Anything).Return(assert.AnError)
+
+	err := f.useCase.OnSessionStarted(context.Background(), f.session.ID())
+
+	require.Error(t, err)
+	f.clockRepo.AssertNumberOfCalls(t, "Save", 2)
+}
@@ -0,0 +1,430 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/pipeline"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/task"
+	"go.uber.org/zap"
+)
+
+// TaskView tarefa exposta pela API. DueAtLocal é o prazo no fuso da tarefa
+type TaskView struct {
+	ID          uuid.UUID       `json:"id"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	DueAt       *time.Time      `json:"due_at,omitempty"`
+	DueAtLocal  string          `json:"due_at_local,omitempty"`
+	Timezone    string          `json:"timezone"`
+	Priority    task.Priority   `json:"priority"`
+	Status      task.Status     `json:"status"`
+	Recurrence  task.Recurrence `json:"recurrence"`
+	Overdue     bool            `json:"overdue"`
+	AssigneeID  *uuid.UUID      `json:"assignee_id,omitempty"`
+	ContactID   *uuid.UUID      `json:"contact_id,omitempty"`
+	SessionID   *uuid.UUID      `json:"session_id,omitempty"`
+	PipelineID  *uuid.UUID      `json:"pipeline_id,omitempty"`
+	CreatedBy   uuid.UUID       `json:"created_by"`
+	CompletedAt *time.Time      `json:"completed_at,omitempty"`
+	CompletedBy *uuid.UUID      `json:"completed_by,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+	UpdatedAt   time.Time       `json:"updated_at"`
+}
+
+func newTaskView(t *task.Task, now time.Time) TaskView {
+	view := TaskView{
+		ID:          t.ID(),
+		Title:       t.Title(),
+		Description: t.Description(),
+		DueAt:       t.DueAt(),
+		Timezone:    t.Timezone(),
+		Priority:    t.Priority(),
+		Status:      t.Status(),
+		Recurrence:  t.Recurrence(),
+		Overdue:     t.IsOverdue(now),
+		AssigneeID:  t.AssigneeID(),
+		ContactID:   t.ContactID(),
+		SessionID:   t.SessionID(),
+		PipelineID:  t.PipelineID(),
+		CreatedBy:   t.CreatedBy(),
+		CompletedAt: t.CompletedAt(),
+		CompletedBy: t.CompletedBy(),
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+	}
+	if due := t.DueAt(); due != nil {
+		view.DueAtLocal = due.In(t.Location()).Format(time.RFC3339)
+	}
+	return view
+}
+
+func newTaskViews(tasks []*task.Task, now time.Time) []TaskView {
+	views := make([]TaskView, len(tasks))
+	for i, t := range tasks {
+		views[i] = newTaskView(t, now)
+	}
+	return views
+}
+
+// CompleteResult tarefa concluída e a próxima ocorrência, se recorrente
+type CompleteResult struct {
+	Task TaskView  `json:"task"`
+	Next *TaskView `json:"next,omitempty"`
+}
+
+// ManageTasksUseCase cadastro e ciclo de vida das tarefas dos agentes.
+// Cada tarefa com prazo tem um timer que dispara o lembrete e o atraso (ReminderService).
+type ManageTasksUseCase struct {
+	taskRepo     task.Repository
+	agentRepo    agent.Repository
+	contactRepo  contact.Repository
+	sessionRepo  session.Repository
+	pipelineRepo pipeline.Repository
+	eventBus     EventBus
+	txManager    TransactionManager
+	timers       Timers
+	logger       *zap.Logger
+}
+
+func NewManageTasksUseCase(
+	taskRepo task.Repository,
+	agentRepo agent.Repository,
+	contactRepo contact.Repository,
+	sessionRepo session.Repository,
+	pipelineRepo pipeline.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	timers Timers,
+	logger *zap.Logger,
+) *ManageTasksUseCase {
+	return &ManageTasksUseCase{
+		taskRepo:     taskRepo,
+		agentRepo:    agentRepo,
+		contactRepo:  contactRepo,
+		sessionRepo:  sessionRepo,
+		pipelineRepo: pipelineRepo,
+		eventBus:     eventBus,
+		txManager:    txManager,
+		timers:       timers,
+		logger:       logger,
+	}
+}
+
+// Create cria a tarefa. Sem responsável, ela fica com o agente do usuário que a criou (se houver).
+// Numa tarefa de sessão o contato é o da sessão.
+func (uc *ManageTasksUseCase) Create(ctx context.Context, tenantID string, userID uuid.UUID, details task.Details) (*TaskView, error) {
+	if details.SessionID != nil {
+		contactID, err := uc.checkSession(ctx, tenantID, *details.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if details.ContactID != nil && *details.ContactID != contactID {
+			return nil, shared.NewValidationError("contact_id does not match the session contact", "contact_id")
+		}
+		details.ContactID = &contactID
+	} else if details.ContactID != nil {
+		if err := uc.checkContact(ctx, tenantID, *details.ContactID); err != nil {
+			return nil, err
+		}
+	}
+	if details.PipelineID != nil {
+		if err := uc.checkPipeline(ctx, tenantID, *details.PipelineID); err != nil {
+			return nil, err
+		}
+	}
+	if details.AssigneeID != nil {
+		if err := uc.checkAgent(ctx, tenantID, *details.AssigneeID); err != nil {
+			return nil, err
+		}
+	} else if a, err := agentForUser(ctx, uc.agentRepo, tenantID, userID); err == nil {
+		assignee := a.ID()
+		details.AssigneeID = &assignee
+	}
+
+	t, err := task.NewTask(tenantID, userID, details)
+	if err != nil {
+		return nil, taskValidationError(err)
+	}
+	if err := uc.save(ctx, t); err != nil {
+		return nil, err
+	}
+	uc.schedule(ctx, t)
+
+	view := newTaskView(t, time.Now())
+	return &view, nil
+}
+
+func (uc *ManageTasksUseCase) Get(ctx context.Context, tenantID string, id uuid.UUID) (*TaskView, error) {
+	t, err := findTask(ctx, uc.taskRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	view := newTaskView(t, time.Now())
+	return &view, nil
+}
+
+// List tarefas do filtro (TenantID é sempre o do chamador)
+func (uc *ManageTasksUseCase) List(ctx context.Context, filter task.Filter) ([]TaskView, int64, error) {
+	tasks, total, err := uc.taskRepo.List(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+	return newTaskViews(tasks, time.Now()), total, nil
+}
+
+// Mine tarefas do agente do usuário
+func (uc *ManageTasksUseCase) Mine(ctx context.Context, tenantID string, userID uuid.UUID, filter task.Filter) ([]TaskView, int64, error) {
+	a, err := agentForUser(ctx, uc.agentRepo, tenantID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	agentID := a.ID()
+	filter.TenantID = tenantID
+	filter.AssigneeID = &agentID
+	return uc.List(ctx, filter)
+}
+
+// Overdue tarefas abertas com prazo vencido (do filtro; ex: de um responsável)
+func (uc *ManageTasksUseCase) Overdue(ctx context.Context, filter task.Filter) ([]TaskView, int64, error) {
+	now := time.Now()
+	filter.Statuses = []task.Status{task.StatusOpen}
+	filter.DueBefore = &now
+	filter.DueAfter = nil
+	return uc.List(ctx, filter)
+}
+
+func (uc *ManageTasksUseCase) Update(ctx context.Context, tenantID string, userID, id uuid.UUID, changes task.Changes) (*TaskView, error) {
+	if changes.AssigneeID != nil && !changes.Unassign {
+		if err := uc.checkAgent(ctx, tenantID, *changes.AssigneeID); err != nil {
+			return nil, err
+		}
+	}
+
+	t, err := findTask(ctx, uc.taskRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	changed, err := t.Update(userID, changes)
+	if err != nil {
+		return nil, taskValidationError(err)
+	}
+	if len(changed) > 0 {
+		if err := uc.save(ctx, t); err != nil {
+			return nil, err
+		}
+		if contains(changed, "due_at") {
+			uc.schedule(ctx, t)
+		}
+	}
+
+	view := newTaskView(t, time.Now())
+	return &view, nil
+}
+
+// Complete conclui a tarefa; a próxima ocorrência de uma recorrente é criada na mesma transação
+func (uc *ManageTasksUseCase) Complete(ctx context.Context, tenantID string, userID, id uuid.UUID) (*CompleteResult, error) {
+	t, err := findTask(ctx, uc.taskRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	next, err := t.Complete(userID, now)
+	if err != nil {
+		return nil, taskValidationError(err)
+	}
+
+	tasks := []*task.Task{t}
+	if next != nil {
+		tasks = append(tasks, next)
+	}
+	if err := uc.save(ctx, tasks...); err != nil {
+		return nil, err
+	}
+
+	uc.cancel(ctx, t.ID())
+	result := &CompleteResult{Task: newTaskView(t, now)}
+	if next != nil {
+		uc.schedule(ctx, next)
+		nextView := newTaskView(next, now)
+		result.Next = &nextView
+	}
+	return result, nil
+}
+
+func (uc *ManageTasksUseCase) Cancel(ctx context.Context, tenantID string, userID, id uuid.UUID) (*TaskView, error) {
+	t, err := findTask(ctx, uc.taskRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Cancel(userID); err != nil {
+		return nil, taskValidationError(err)
+	}
+	if err := uc.save(ctx, t); err != nil {
+		return nil, err
+	}
+	uc.cancel(ctx, t.ID())
+
+	view := newTaskView(t, time.Now())
+	return &view, nil
+}
+
+func (uc *ManageTasksUseCase) Delete(ctx context.Context, tenantID string, userID, id uuid.UUID) error {
+	t, err := findTask(ctx, uc.taskRepo, tenantID, id)
+	if err != nil {
+		return err
+	}
+	t.Delete(userID)
+	if err := uc.save(ctx, t); err != nil {
+		return err
+	}
+	uc.cancel(ctx, t.ID())
+	return nil
+}
+
+func (uc *ManageTasksUseCase) save(ctx context.Context, tasks ...*task.Task) error {
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		for _, t := range tasks {
+			if err := uc.taskRepo.Save(txCtx, t); err != nil {
+				return fmt.Errorf("failed to save task: %w", err)
+			}
+		}
+		return publishEvents(txCtx, uc.eventBus, tasks...)
+	})
+	if err != nil {
+		return err
+	}
+	for _, t := range tasks {
+		t.ClearEvents()
+	}
+	return nil
+}
+
+// schedule (re)agenda o timer do prazo. Falhas não desfazem a operação:
+// a varredura periódica (ReminderService.ProcessDue) cobre timers perdidos.
+func (uc *ManageTasksUseCase) schedule(ctx context.Context, t *task.Task) {
+	if t.DueAt() == nil {
+		uc.cancel(ctx, t.ID())
+		return
+	}
+	if err := uc.timers.Schedule(ctx, t.ID(), *t.DueAt()); err != nil {
+		uc.logger.Warn("Failed to schedule task reminder", zap.String("task_id", t.ID().String()), zap.Error(err))
+	}
+}
+
+func (uc *ManageTasksUseCase) cancel(ctx context.Context, taskID uuid.UUID) {
+	if err := uc.timers.Cancel(ctx, taskID); err != nil {
+		uc.logger.Warn("Failed to cancel task reminder", zap.String("task_id", taskID.String()), zap.Error(err))
+	}
+}
+
+func (uc *ManageTasksUseCase) checkContact(ctx context.Context, tenantID string, contactID uuid.UUID) error {
+	c, err := uc.contactRepo.FindByID(ctx, contactID)
+	if err != nil {
+		if shared.IsNotFoundError(err) || errors.Is(err, contact.ErrContactNotFound) {
+			return shared.NewNotFoundError("contact", contactID.String())
+		}
+		return fmt.Errorf("failed to load contact: %w", err)
+	}
+	if c.TenantID() != tenantID || c.IsDeleted() {
+		return shared.NewNotFoundError("contact", contactID.String())
+	}
+	return nil
+}
+
+// checkSession garante que a sessão existe no tenant e retorna o contato dela
+func (uc *ManageTasksUseCase) checkSession(ctx context.Context, tenantID string, sessionID uuid.UUID) (uuid.UUID, error) {
+	s, err := uc.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, session.ErrSessionNotFound) {
+			return uuid.Nil, shared.NewNotFoundError("session", sessionID.String())
+		}
+		return uuid.Nil, fmt.Errorf("failed to load session: %w", err)
+	}
+	if s.TenantID() != tenantID {
+		return uuid.Nil, shared.NewNotFoundError("session", sessionID.String())
+	}
+	return s.ContactID(), nil
+}
+
+func (uc *ManageTasksUseCase) checkPipeline(ctx context.Context, tenantID string, pipelineID uuid.UUID) error {
+	p, err := uc.pipelineRepo.FindPipelineByID(ctx, pipelineID)
+	if err != nil || p == nil || p.TenantID() != tenantID {
+		return shared.NewNotFoundError("pipeline", pipelineID.String())
+	}
+	return nil
+}
+
+func (uc *ManageTasksUseCase) checkAgent(ctx context.Context, tenantID string, agentID uuid.UUID) error {
+	a, err := uc.agentRepo.FindByID(ctx, agentID)
+	if err != nil || a == nil || a.TenantID() != tenantID {
+		return shared.NewValidationError(fmt.Sprintf("agent %s not found", agentID), "assignee_id")
+	}
+	return nil
+}
+
+func findTask(ctx context.Context, taskRepo task.Repository, tenantID string, id uuid.UUID) (*task.Task, error) {
+	t, err := taskRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, task.ErrTaskNotFound) {
+			return nil, shared.NewNotFoundError("task", id.String())
+		}
+		return nil, fmt.Errorf("failed to load task: %w", err)
+	}
+	if t.TenantID() != tenantID || t.IsDeleted() {
+		return nil, shared.NewNotFoundError("task", id.String())
+	}
+	return t, nil
+}
+
+// agentForUser agente ativo do usuário autenticado no tenant
+func agentForUser(ctx context.Context, agentRepo agent.Repository, tenantID string, userID uuid.UUID) (*agent.Agent, error) {
+	agents, err := agentRepo.FindActiveByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agents: %w", err)
+	}
+	for _, a := range agents {
+		if a.UserID() != nil && *a.UserID() == userID {
+			return a, nil
+		}
+	}
+	return nil, shared.NewNotFoundError("agent", userID.String())
+}
+
+// taskValidationError traduz os erros do domínio para erros de validação com o campo
+// (tarefa já encerrada vira conflito)
+func taskValidationError(err error) error {
+	if errors.Is(err, task.ErrTaskClosed) {
+		return shared.NewConflictError(err.Error())
+	}
+	field := ""
+	switch {
+	case errors.Is(err, task.ErrEmptyTitle):
+		field = "title"
+	case errors.Is(err, task.ErrInvalidPriority):
+		field = "priority"
+	case errors.Is(err, task.ErrInvalidTimezone):
+		field = "timezone"
+	case errors.Is(err, task.ErrInvalidRecurrence), errors.Is(err, task.ErrRecurrenceWithoutDue):
+		field = "recurrence"
+	case errors.Is(err, task.ErrSessionWithoutContact):
+		field = "session_id"
+	}
+	return shared.NewValidationError(err.Error(), field)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
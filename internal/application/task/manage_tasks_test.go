@@ -0,0 +1,252 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/task"
+	"go.uber.org/zap"
+)
+
+func newTestAgent(t *testing.T, tenantID string) *agent.Agent {
+	t.Helper()
+	userID := uuid.New()
+	a, err := agent.NewAgent(uuid.New(), tenantID, "Ana", agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	return a
+}
+
+func newStoredTask(t *testing.T, tenantID string, details task.Details) *task.Task {
+	t.Helper()
+	if details.Title == "" {
+		details.Title = "Retornar ligação"
+	}
+	tk, err := task.NewTask(tenantID, uuid.New(), details)
+	require.NoError(t, err)
+	tk.ClearEvents()
+	return tk
+}
+
+func newManageTasks(taskRepo *MockTaskRepository, agentRepo *MockAgentRepository, eventBus *MockEventBus, timers *MockTimers) *ManageTasksUseCase {
+	return NewManageTasksUseCase(taskRepo, agentRepo, nil, nil, nil, eventBus, &SimpleTransactionManager{}, timers, zap.NewNop())
+}
+
+func TestManageTasksUseCase_Create_AssignsCreatorAndSchedules(t *testing.T) {
+	ctx := context.Background()
+	creator := newTestAgent(t, "tenant-1")
+	due := time.Now().Add(2 * time.Hour)
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindActiveByTenant", ctx, "tenant-1").Return([]*agent.Agent{creator}, nil)
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.MatchedBy(func(e shared.DomainEvent) bool {
+		return e.EventName() == "task.created"
+	})).Return(nil).Once()
+
+	timers := new(MockTimers)
+	timers.On("Schedule", ctx, mock.Anything, due.UTC()).Return(nil).Once()
+
+	uc := newManageTasks(taskRepo, agentRepo, eventBus, timers)
+
+	view, err := uc.Create(ctx, "tenant-1", *creator.UserID(), task.Details{
+		Title:    "Enviar proposta",
+		DueAt:    &due,
+		Timezone: "America/Sao_Paulo",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, creator.ID(), *view.AssigneeID)
+	assert.Equal(t, *creator.UserID(), view.CreatedBy)
+	assert.Equal(t, due.In(mustLoad(t, "America/Sao_Paulo")).Format(time.RFC3339), view.DueAtLocal)
+	assert.False(t, view.Overdue)
+	taskRepo.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+	timers.AssertExpectations(t)
+}
+
+func TestManageTasksUseCase_Create_Validation(t *testing.T) {
+	ctx := context.Background()
+	foreign := newTestAgent(t, "tenant-2")
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindByID", ctx, foreign.ID()).Return(foreign, nil)
+	agentRepo.On("FindActiveByTenant", ctx, "tenant-1").Return([]*agent.Agent{}, nil)
+
+	taskRepo := new(MockTaskRepository)
+	uc := newManageTasks(taskRepo, agentRepo, new(MockEventBus), new(MockTimers))
+
+	t.Run("assignee from other tenant", func(t *testing.T) {
+		assignee := foreign.ID()
+		_, err := uc.Create(ctx, "tenant-1", uuid.New(), task.Details{Title: "x", AssigneeID: &assignee})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	t.Run("recurrence without due date", func(t *testing.T) {
+		_, err := uc.Create(ctx, "tenant-1", uuid.New(), task.Details{Title: "x", Recurrence: task.RecurrenceWeekly})
+		assert.True(t, shared.IsValidationError(err))
+	})
+
+	taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestManageTasksUseCase_Update_ReschedulesOnlyWhenDueChanges(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().Add(time.Hour)
+	tk := newStoredTask(t, "tenant-1", task.Details{DueAt: &due})
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindByID", ctx, tk.ID()).Return(tk, nil)
+	taskRepo.On("Save", ctx, tk).Return(nil)
+
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.Anything).Return(nil)
+
+	newDue := due.Add(24 * time.Hour)
+	timers := new(MockTimers)
+	timers.On("Schedule", ctx, tk.ID(), newDue.UTC()).Return(nil).Once()
+
+	uc := newManageTasks(taskRepo, new(MockAgentRepository), eventBus, timers)
+
+	high := task.PriorityHigh
+	view, err := uc.Update(ctx, "tenant-1", uuid.New(), tk.ID(), task.Changes{Priority: &high})
+	require.NoError(t, err)
+	assert.Equal(t, task.PriorityHigh, view.Priority)
+
+	_, err = uc.Update(ctx, "tenant-1", uuid.New(), tk.ID(), task.Changes{DueAt: &newDue})
+	require.NoError(t, err)
+
+	timers.AssertExpectations(t)
+	taskRepo.AssertNumberOfCalls(t, "Save", 2)
+}
+
+func TestManageTasksUseCase_Complete_Recurring(t *testing.T) {
+	ctx := context.Background()
+	due := time.Now().Add(time.Hour)
+	tk := newStoredTask(t, "tenant-1", task.Details{DueAt: &due, Recurrence: task.RecurrenceDaily})
+
+	var saved []*task.Task
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindByID", ctx, tk.ID()).Return(tk, nil)
+	taskRepo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		saved = append(saved, args.Get(1).(*task.Task))
+	}).Return(nil)
+
+	var published []string
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(1).(shared.DomainEvent).EventName())
+	}).Return(nil)
+
+	timers := new(MockTimers)
+	timers.On("Cancel", ctx, tk.ID()).Return(nil).Once()
+	timers.On("Schedule", ctx, mock.Anything, due.AddDate(0, 0, 1).UTC()).Return(nil).Once()
+
+	uc := newManageTasks(taskRepo, new(MockAgentRepository), eventBus, timers)
+
+	result, err := uc.Complete(ctx, "tenant-1", uuid.New(), tk.ID())
+
+	require.NoError(t, err)
+	assert.Equal(t, task.StatusCompleted, result.Task.Status)
+	require.NotNil(t, result.Next)
+	assert.Equal(t, task.StatusOpen, result.Next.Status)
+	assert.Len(t, saved, 2)
+	assert.Equal(t, []string{"task.completed", "task.created"}, published)
+	timers.AssertExpectations(t)
+
+	// Concluir de novo é conflito
+	_, err = uc.Complete(ctx, "tenant-1", uuid.New(), tk.ID())
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, shared.ErrorTypeConflict, domainErr.Type)
+}
+
+func TestManageTasksUseCase_Delete_CancelsTimer(t *testing.T) {
+	ctx := context.Background()
+	tk := newStoredTask(t, "tenant-1", task.Details{})
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindByID", ctx, tk.ID()).Return(tk, nil)
+	taskRepo.On("Save", ctx, tk).Return(nil).Once()
+
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.Anything).Return(nil)
+
+	timers := new(MockTimers)
+	timers.On("Cancel", ctx, tk.ID()).Return(errors.New("temporal down")).Once()
+
+	uc := newManageTasks(taskRepo, new(MockAgentRepository), eventBus, timers)
+
+	// Falha no timer não desfaz a remoção (a varredura ignora tarefas removidas)
+	require.NoError(t, uc.Delete(ctx, "tenant-1", uuid.New(), tk.ID()))
+	assert.True(t, tk.IsDeleted())
+
+	_, err := uc.Get(ctx, "tenant-1", tk.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+	timers.AssertExpectations(t)
+}
+
+func TestManageTasksUseCase_TenantIsolation(t *testing.T) {
+	ctx := context.Background()
+	tk := newStoredTask(t, "tenant-1", task.Details{})
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindByID", ctx, tk.ID()).Return(tk, nil)
+	taskRepo.On("FindByID", ctx, mock.Anything).Return(nil, task.ErrTaskNotFound)
+
+	uc := newManageTasks(taskRepo, new(MockAgentRepository), new(MockEventBus), new(MockTimers))
+
+	_, err := uc.Get(ctx, "tenant-2", tk.ID())
+	assert.True(t, shared.IsNotFoundError(err))
+
+	_, err = uc.Cancel(ctx, "tenant-1", uuid.New(), uuid.New())
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+func TestManageTasksUseCase_MineAndOverdue(t *testing.T) {
+	ctx := context.Background()
+	me := newTestAgent(t, "tenant-1")
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindActiveByTenant", ctx, "tenant-1").Return([]*agent.Agent{me}, nil)
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("List", ctx, mock.MatchedBy(func(f task.Filter) bool {
+		return f.TenantID == "tenant-1" && f.AssigneeID != nil && *f.AssigneeID == me.ID()
+	})).Return([]*task.Task{}, int64(0), nil).Once()
+	taskRepo.On("List", ctx, mock.MatchedBy(func(f task.Filter) bool {
+		return len(f.Statuses) == 1 && f.Statuses[0] == task.StatusOpen && f.DueBefore != nil
+	})).Return([]*task.Task{}, int64(0), nil).Once()
+
+	uc := newManageTasks(taskRepo, agentRepo, new(MockEventBus), new(MockTimers))
+
+	_, _, err := uc.Mine(ctx, "tenant-1", *me.UserID(), task.Filter{})
+	require.NoError(t, err)
+
+	_, _, err = uc.Overdue(ctx, task.Filter{TenantID: "tenant-1"})
+	require.NoError(t, err)
+
+	// Usuário sem agente não tem "minhas tarefas"
+	_, _, err = uc.Mine(ctx, "tenant-1", uuid.New(), task.Filter{})
+	assert.True(t, shared.IsNotFoundError(err))
+
+	taskRepo.AssertExpectations(t)
+}
+
+func mustLoad(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
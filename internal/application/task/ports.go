@@ -0,0 +1,60 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/task"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Timers agenda o disparo do prazo de cada tarefa (ex: timer do Temporal).
+// O disparo chama ReminderService.Due, que é idempotente.
+type Timers interface {
+	// Schedule (re)agenda o disparo; um agendamento anterior da mesma tarefa é substituído
+	Schedule(ctx context.Context, taskID uuid.UUID, dueAt time.Time) error
+	Cancel(ctx context.Context, taskID uuid.UUID) error
+}
+
+// Reminder lembrete de prazo entregue ao responsável
+type Reminder struct {
+	TaskID    uuid.UUID
+	Title     string
+	Priority  task.Priority
+	DueAt     time.Time
+	Timezone  string
+	ContactID *uuid.UUID
+	SessionID *uuid.UUID
+}
+
+// ReminderNotifier entrega os lembretes em tempo real (ex: websocket) aos usuários
+type ReminderNotifier interface {
+	NotifyTaskDue(ctx context.Context, userIDs []uuid.UUID, reminder Reminder)
+}
+
+// AutomationTrigger dispara as automações task.overdue do pipeline da tarefa
+type AutomationTrigger interface {
+	OnTaskOverdue(ctx context.Context, t *task.Task) error
+}
+
+// publishEvents publica os eventos pendentes das tarefas (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, tasks ...*task.Task) error {
+	for _, t := range tasks {
+		for _, event := range t.DomainEvents() {
+			if err := eventBus.Publish(ctx, event); err != nil {
+				return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/task"
+	"go.uber.org/zap"
+)
+
+// ReminderService trata o prazo das tarefas: registra o atraso (task.overdue), entrega o
+// lembrete ao responsável e dispara as automações. Idempotente (timers podem repetir ou atrasar).
+type ReminderService struct {
+	taskRepo   task.Repository
+	agentRepo  agent.Repository
+	eventBus   EventBus
+	txManager  TransactionManager
+	notifier   ReminderNotifier
+	automation AutomationTrigger
+	logger     *zap.Logger
+	batchSize  int
+}
+
+// NewReminderService creates a new instance. notifier e automation podem ser nil.
+func NewReminderService(
+	taskRepo task.Repository,
+	agentRepo agent.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	notifier ReminderNotifier,
+	automation AutomationTrigger,
+	logger *zap.Logger,
+) *ReminderService {
+	return &ReminderService{
+		taskRepo:   taskRepo,
+		agentRepo:  agentRepo,
+		eventBus:   eventBus,
+		txManager:  txManager,
+		notifier:   notifier,
+		automation: automation,
+		logger:     logger,
+		batchSize:  200,
+	}
+}
+
+// Due marca a tarefa como atrasada se o prazo passou e ela segue aberta; só então
+// o lembrete é entregue e as automações task.overdue disparam.
+func (s *ReminderService) Due(ctx context.Context, taskID uuid.UUID, now time.Time) error {
+	var due *task.Task
+	err := s.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		t, err := s.taskRepo.FindByID(txCtx, taskID)
+		if err != nil {
+			if errors.Is(err, task.ErrTaskNotFound) {
+				return nil
+			}
+			return err
+		}
+		if !t.MarkOverdue(now) {
+			return nil
+		}
+		if err := s.taskRepo.Save(txCtx, t); err != nil {
+			return fmt.Errorf("failed to save task: %w", err)
+		}
+		if err := publishEvents(txCtx, s.eventBus, t); err != nil {
+			return err
+		}
+		t.ClearEvents()
+		due = t
+		return nil
+	})
+	if err != nil || due == nil {
+		return err
+	}
+
+	s.remind(ctx, due)
+	if s.automation != nil {
+		return s.automation.OnTaskOverdue(ctx, due)
+	}
+	return nil
+}
+
+// ProcessDue trata as tarefas com prazo vencido ainda não registrado e retorna quantas processou.
+// Rede de segurança para timers perdidos (ex: Temporal indisponível). Falhas não param o lote.
+func (s *ReminderService) ProcessDue(ctx context.Context, now time.Time) (int, error) {
+	tasks, err := s.taskRepo.FindDue(ctx, now, s.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	for _, t := range tasks {
+		if err := s.Due(ctx, t.ID(), now); err != nil {
+			errs = append(errs, fmt.Errorf("task %s: %w", t.ID(), err))
+		}
+	}
+	return len(tasks), errors.Join(errs...)
+}
+
+// remind entrega o lembrete ao usuário do agente responsável (best effort)
+func (s *ReminderService) remind(ctx context.Context, t *task.Task) {
+	if s.notifier == nil || t.AssigneeID() == nil {
+		return
+	}
+	a, err := s.agentRepo.FindByID(ctx, *t.AssigneeID())
+	if err != nil || a == nil || a.UserID() == nil {
+		s.logger.Debug("Task assignee has no user to remind",
+			zap.String("task_id", t.ID().String()),
+			zap.String("agent_id", t.AssigneeID().String()))
+		return
+	}
+
+	s.notifier.NotifyTaskDue(ctx, []uuid.UUID{*a.UserID()}, Reminder{
+		TaskID:    t.ID(),
+		Title:     t.Title(),
+		Priority:  t.Priority(),
+		DueAt:     *t.DueAt(),
+		Timezone:  t.Timezone(),
+		ContactID: t.ContactID(),
+		SessionID: t.SessionID(),
+	})
+}
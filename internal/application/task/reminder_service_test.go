@@ -0,0 +1,107 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/task"
+	"go.uber.org/zap"
+)
+
+func TestReminderService_Due(t *testing.T) {
+	ctx := context.Background()
+	assignee := newTestAgent(t, "tenant-1")
+	agentID := assignee.ID()
+	due := time.Now().Add(-time.Minute)
+	tk := newStoredTask(t, "tenant-1", task.Details{DueAt: &due, AssigneeID: &agentID})
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindByID", ctx, tk.ID()).Return(tk, nil)
+	taskRepo.On("Save", ctx, tk).Return(nil).Once()
+
+	agentRepo := new(MockAgentRepository)
+	agentRepo.On("FindByID", ctx, agentID).Return(assignee, nil)
+
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.MatchedBy(func(e shared.DomainEvent) bool {
+		return e.EventName() == "task.overdue"
+	})).Return(nil).Once()
+
+	notifier := new(MockReminderNotifier)
+	notifier.On("NotifyTaskDue", ctx, []uuid.UUID{*assignee.UserID()}, mock.MatchedBy(func(r Reminder) bool {
+		return r.TaskID == tk.ID() && r.DueAt.Equal(due)
+	})).Once()
+
+	automation := new(MockAutomationTrigger)
+	automation.On("OnTaskOverdue", ctx, tk).Return(nil).Once()
+
+	service := NewReminderService(taskRepo, agentRepo, eventBus, &SimpleTransactionManager{}, notifier, automation, zap.NewNop())
+
+	now := time.Now()
+	require.NoError(t, service.Due(ctx, tk.ID(), now))
+	// Disparo repetido não lembra nem dispara de novo
+	require.NoError(t, service.Due(ctx, tk.ID(), now.Add(time.Minute)))
+
+	assert.NotNil(t, tk.OverdueAt())
+	taskRepo.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+	notifier.AssertExpectations(t)
+	automation.AssertExpectations(t)
+}
+
+func TestReminderService_Due_Noop(t *testing.T) {
+	ctx := context.Background()
+	future := time.Now().Add(time.Hour)
+	notYetDue := newStoredTask(t, "tenant-1", task.Details{DueAt: &future})
+	past := time.Now().Add(-time.Hour)
+	completed := newStoredTask(t, "tenant-1", task.Details{DueAt: &past})
+	_, err := completed.Complete(uuid.New(), time.Now())
+	require.NoError(t, err)
+	missing := uuid.New()
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindByID", ctx, notYetDue.ID()).Return(notYetDue, nil)
+	taskRepo.On("FindByID", ctx, completed.ID()).Return(completed, nil)
+	taskRepo.On("FindByID", ctx, missing).Return(nil, task.ErrTaskNotFound)
+
+	service := NewReminderService(taskRepo, new(MockAgentRepository), new(MockEventBus), &SimpleTransactionManager{}, nil, nil, zap.NewNop())
+
+	for _, id := range []uuid.UUID{notYetDue.ID(), completed.ID(), missing} {
+		require.NoError(t, service.Due(ctx, id, time.Now()))
+	}
+	taskRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestReminderService_ProcessDue(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	due := now.Add(-time.Minute)
+	ok := newStoredTask(t, "tenant-1", task.Details{DueAt: &due})
+	failing := newStoredTask(t, "tenant-1", task.Details{DueAt: &due})
+
+	taskRepo := new(MockTaskRepository)
+	taskRepo.On("FindDue", ctx, now, 200).Return([]*task.Task{failing, ok}, nil)
+	taskRepo.On("FindByID", ctx, ok.ID()).Return(ok, nil)
+	taskRepo.On("FindByID", ctx, failing.ID()).Return(failing, nil)
+	taskRepo.On("Save", ctx, ok).Return(nil)
+	taskRepo.On("Save", ctx, failing).Return(errors.New("db down"))
+
+	eventBus := new(MockEventBus)
+	eventBus.On("Publish", ctx, mock.Anything).Return(nil)
+
+	service := NewReminderService(taskRepo, new(MockAgentRepository), eventBus, &SimpleTransactionManager{}, nil, nil, zap.NewNop())
+
+	count, err := service.ProcessDue(ctx, now)
+
+	assert.Equal(t, 2, count)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), failing.ID().String())
+	assert.NotNil(t, ok.OverdueAt(), "a failure does not stop the batch")
+}
@@ -0,0 +1,415 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+// TeamInput dados de criação/atualização de um time (membros substituem os atuais)
+type TeamInput struct {
+	Name         string
+	Description  string
+	SupervisorID *uuid.UUID
+	MemberIDs    []uuid.UUID
+}
+
+// QueueInput dados de criação/atualização de uma fila
+type QueueInput struct {
+	TeamID   uuid.UUID
+	Name     string
+	Strategy project.AssignmentStrategy
+	Priority int
+	Enabled  bool
+}
+
+// TeamView time exposto pela API
+type TeamView struct {
+	ID           uuid.UUID   `json:"id"`
+	ProjectID    uuid.UUID   `json:"project_id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	SupervisorID *uuid.UUID  `json:"supervisor_id,omitempty"`
+	MemberIDs    []uuid.UUID `json:"member_ids"`
+	CreatedAt    time.Time   `json:"created_at"`
+	UpdatedAt    time.Time   `json:"updated_at"`
+}
+
+func newTeamView(t *team.Team) TeamView {
+	return TeamView{
+		ID:           t.ID(),
+		ProjectID:    t.ProjectID(),
+		Name:         t.Name(),
+		Description:  t.Description(),
+		SupervisorID: t.SupervisorID(),
+		MemberIDs:    t.MemberIDs(),
+		CreatedAt:    t.CreatedAt(),
+		UpdatedAt:    t.UpdatedAt(),
+	}
+}
+
+// QueueView fila exposta pela API
+type QueueView struct {
+	ID        uuid.UUID                  `json:"id"`
+	ProjectID uuid.UUID                  `json:"project_id"`
+	TeamID    uuid.UUID                  `json:"team_id"`
+	Name      string                     `json:"name"`
+	Strategy  project.AssignmentStrategy `json:"strategy"`
+	Priority  int                        `json:"priority"`
+	Enabled   bool                       `json:"enabled"`
+	CreatedAt time.Time                  `json:"created_at"`
+	UpdatedAt time.Time                  `json:"updated_at"`
+}
+
+func newQueueView(q *team.Queue) QueueView {
+	return QueueView{
+		ID:        q.ID(),
+		ProjectID: q.ProjectID(),
+		TeamID:    q.TeamID(),
+		Name:      q.Name(),
+		Strategy:  q.Strategy(),
+		Priority:  q.Priority(),
+		Enabled:   q.IsEnabled(),
+		CreatedAt: q.CreatedAt(),
+		UpdatedAt: q.UpdatedAt(),
+	}
+}
+
+// ManageTeamsUseCase cadastro de times e filas de um projeto
+type ManageTeamsUseCase struct {
+	teamRepo    team.TeamRepository
+	queueRepo   team.QueueRepository
+	itemRepo    team.QueueItemRepository
+	projectRepo project.Repository
+	agentRepo   agent.Repository
+}
+
+func NewManageTeamsUseCase(
+	teamRepo team.TeamRepository,
+	queueRepo team.QueueRepository,
+	itemRepo team.QueueItemRepository,
+	projectRepo project.Repository,
+	agentRepo agent.Repository,
+) *ManageTeamsUseCase {
+	return &ManageTeamsUseCase{
+		teamRepo:    teamRepo,
+		queueRepo:   queueRepo,
+		itemRepo:    itemRepo,
+		projectRepo: projectRepo,
+		agentRepo:   agentRepo,
+	}
+}
+
+func (uc *ManageTeamsUseCase) ListTeams(ctx context.Context, tenantID string, projectID uuid.UUID) ([]TeamView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	teams, err := uc.teamRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]TeamView, len(teams))
+	for i, t := range teams {
+		views[i] = newTeamView(t)
+	}
+	return views, nil
+}
+
+func (uc *ManageTeamsUseCase) GetTeam(ctx context.Context, tenantID string, id uuid.UUID) (*TeamView, error) {
+	t, err := findTeam(ctx, uc.teamRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	view := newTeamView(t)
+	return &view, nil
+}
+
+func (uc *ManageTeamsUseCase) CreateTeam(ctx context.Context, tenantID string, projectID uuid.UUID, input TeamInput) (*TeamView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	t, err := team.NewTeam(tenantID, projectID, input.Name)
+	if err != nil {
+		return nil, shared.NewValidationError(err.Error(), "name")
+	}
+	if err := uc.apply(ctx, t, input); err != nil {
+		return nil, err
+	}
+	if err := uc.teamRepo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	view := newTeamView(t)
+	return &view, nil
+}
+
+func (uc *ManageTeamsUseCase) UpdateTeam(ctx context.Context, tenantID string, id uuid.UUID, input TeamInput) (*TeamView, error) {
+	t, err := findTeam(ctx, uc.teamRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.Rename(input.Name); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "name")
+	}
+	if err := uc.apply(ctx, t, input); err != nil {
+		return nil, err
+	}
+	if err := uc.teamRepo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	view := newTeamView(t)
+	return &view, nil
+}
+
+// AddMember inclui um agente no time
+func (uc *ManageTeamsUseCase) AddMember(ctx context.Context, tenantID string, teamID, agentID uuid.UUID) (*TeamView, error) {
+	t, err := findTeam(ctx, uc.teamRepo, tenantID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkAgents(ctx, tenantID, "agent_id", agentID); err != nil {
+		return nil, err
+	}
+	if err := t.AddMember(agentID); err != nil {
+		return nil, shared.NewValidationError(err.Error(), "agent_id")
+	}
+	if err := uc.teamRepo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	view := newTeamView(t)
+	return &view, nil
+}
+
+// RemoveMember tira um agente do time
+func (uc *ManageTeamsUseCase) RemoveMember(ctx context.Context, tenantID string, teamID, agentID uuid.UUID) (*TeamView, error) {
+	t, err := findTeam(ctx, uc.teamRepo, tenantID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	if !t.HasMember(agentID) {
+		return nil, shared.NewNotFoundError("team_member", agentID.String())
+	}
+	t.RemoveMember(agentID)
+	if err := uc.teamRepo.Save(ctx, t); err != nil {
+		return nil, err
+	}
+	view := newTeamView(t)
+	return &view, nil
+}
+
+// DeleteTeam remove o time. Times com filas não podem ser removidos.
+func (uc *ManageTeamsUseCase) DeleteTeam(ctx context.Context, tenantID string, id uuid.UUID) error {
+	t, err := findTeam(ctx, uc.teamRepo, tenantID, id)
+	if err != nil {
+		return err
+	}
+	queues, err := uc.queueRepo.FindByTeams(ctx, []uuid.UUID{t.ID()})
+	if err != nil {
+		return err
+	}
+	if len(queues) > 0 {
+		return shared.NewConflictError("team has queues: delete or move them first")
+	}
+	return uc.teamRepo.Delete(ctx, t.ID())
+}
+
+func (uc *ManageTeamsUseCase) apply(ctx context.Context, t *team.Team, input TeamInput) error {
+	if err := uc.checkAgents(ctx, t.TenantID(), "member_ids", input.MemberIDs...); err != nil {
+		return err
+	}
+	if input.SupervisorID != nil {
+		if err := uc.checkAgents(ctx, t.TenantID(), "supervisor_id", *input.SupervisorID); err != nil {
+			return err
+		}
+	}
+
+	t.SetDescription(input.Description)
+	if err := t.SetMembers(input.MemberIDs); err != nil {
+		return shared.NewValidationError(err.Error(), "member_ids")
+	}
+	if err := t.SetSupervisor(input.SupervisorID); err != nil {
+		return shared.NewValidationError(err.Error(), "supervisor_id")
+	}
+	return nil
+}
+
+// checkAgents garante que os agentes existem e são do tenant
+func (uc *ManageTeamsUseCase) checkAgents(ctx context.Context, tenantID, field string, agentIDs ...uuid.UUID) error {
+	for _, id := range agentIDs {
+		a, err := uc.agentRepo.FindByID(ctx, id)
+		if err != nil && !errors.Is(err, agent.ErrAgentNotFound) {
+			return fmt.Errorf("failed to load agent %s: %w", id, err)
+		}
+		if a == nil || a.TenantID() != tenantID {
+			return shared.NewValidationError(fmt.Sprintf("agent %s not found", id), field)
+		}
+	}
+	return nil
+}
+
+func (uc *ManageTeamsUseCase) ListQueues(ctx context.Context, tenantID string, projectID uuid.UUID) ([]QueueView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	queues, err := uc.queueRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	views := make([]QueueView, len(queues))
+	for i, q := range queues {
+		views[i] = newQueueView(q)
+	}
+	return views, nil
+}
+
+func (uc *ManageTeamsUseCase) GetQueue(ctx context.Context, tenantID string, id uuid.UUID) (*QueueView, error) {
+	q, err := findQueue(ctx, uc.queueRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	view := newQueueView(q)
+	return &view, nil
+}
+
+func (uc *ManageTeamsUseCase) CreateQueue(ctx context.Context, tenantID string, projectID uuid.UUID, input QueueInput) (*QueueView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	if err := uc.checkQueueTeam(ctx, tenantID, projectID, input.TeamID); err != nil {
+		return nil, err
+	}
+
+	q, err := team.NewQueue(tenantID, projectID, input.TeamID, input.Name, input.Strategy)
+	if err != nil {
+		return nil, queueValidationError(err)
+	}
+	q.SetPriority(input.Priority)
+	if !input.Enabled {
+		q.Disable()
+	}
+
+	if err := uc.queueRepo.Save(ctx, q); err != nil {
+		return nil, err
+	}
+	view := newQueueView(q)
+	return &view, nil
+}
+
+func (uc *ManageTeamsUseCase) UpdateQueue(ctx context.Context, tenantID string, id uuid.UUID, input QueueInput) (*QueueView, error) {
+	q, err := findQueue(ctx, uc.queueRepo, tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.checkQueueTeam(ctx, tenantID, q.ProjectID(), input.TeamID); err != nil {
+		return nil, err
+	}
+
+	if err := q.Rename(input.Name); err != nil {
+		return nil, queueValidationError(err)
+	}
+	if err := q.MoveToTeam(input.TeamID); err != nil {
+		return nil, queueValidationError(err)
+	}
+	if err := q.SetStrategy(input.Strategy); err != nil {
+		return nil, queueValidationError(err)
+	}
+	q.SetPriority(input.Priority)
+	if input.Enabled {
+		q.Enable()
+	} else {
+		q.Disable()
+	}
+
+	if err := uc.queueRepo.Save(ctx, q); err != nil {
+		return nil, err
+	}
+	view := newQueueView(q)
+	return &view, nil
+}
+
+// DeleteQueue remove a fila. Filas com sessões aguardando não podem ser removidas.
+func (uc *ManageTeamsUseCase) DeleteQueue(ctx context.Context, tenantID string, id uuid.UUID) error {
+	q, err := findQueue(ctx, uc.queueRepo, tenantID, id)
+	if err != nil {
+		return err
+	}
+	waiting, err := uc.itemRepo.FindWaiting(ctx, []uuid.UUID{q.ID()}, 1)
+	if err != nil {
+		return err
+	}
+	if len(waiting) > 0 {
+		return shared.NewConflictError("queue has waiting sessions")
+	}
+	return uc.queueRepo.Delete(ctx, q.ID())
+}
+
+// checkQueueTeam o time da fila deve ser do mesmo projeto
+func (uc *ManageTeamsUseCase) checkQueueTeam(ctx context.Context, tenantID string, projectID, teamID uuid.UUID) error {
+	if teamID == uuid.Nil {
+		return shared.NewValidationError("team_id is required", "team_id")
+	}
+	t, err := uc.teamRepo.FindByID(ctx, teamID)
+	if err != nil && !errors.Is(err, team.ErrTeamNotFound) {
+		return fmt.Errorf("failed to load team: %w", err)
+	}
+	if t == nil || t.TenantID() != tenantID || t.ProjectID() != projectID {
+		return shared.NewValidationError("team not found in this project", "team_id")
+	}
+	return nil
+}
+
+func queueValidationError(err error) error {
+	switch {
+	case errors.Is(err, team.ErrInvalidStrategy):
+		return shared.NewValidationError(err.Error(), "strategy")
+	case errors.Is(err, team.ErrInvalidTeam):
+		return shared.NewValidationError(err.Error(), "team_id")
+	default:
+		return shared.NewValidationError(err.Error(), "name")
+	}
+}
+
+func checkProject(ctx context.Context, projectRepo project.Repository, tenantID string, projectID uuid.UUID) error {
+	if projectID == uuid.Nil {
+		return shared.NewValidationError("project_id is required", "project_id")
+	}
+	proj, err := projectRepo.FindByID(ctx, projectID)
+	if err != nil || proj == nil || proj.TenantID() != tenantID {
+		return shared.NewNotFoundError("project", projectID.String())
+	}
+	return nil
+}
+
+func findTeam(ctx context.Context, teamRepo team.TeamRepository, tenantID string, id uuid.UUID) (*team.Team, error) {
+	t, err := teamRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, team.ErrTeamNotFound) {
+			return nil, shared.NewNotFoundError("team", id.String())
+		}
+		return nil, fmt.Errorf("failed to load team: %w", err)
+	}
+	if t.TenantID() != tenantID {
+		return nil, shared.NewNotFoundError("team", id.String())
+	}
+	return t, nil
+}
+
+func findQueue(ctx context.Context, queueRepo team.QueueRepository, tenantID string, id uuid.UUID) (*team.Queue, error) {
+	q, err := queueRepo.FindByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, team.ErrQueueNotFound) {
+			return nil, shared.NewNotFoundError("queue", id.String())
+		}
+		return nil, fmt.Errorf("failed to load queue: %w", err)
+	}
+	if q.TenantID() != tenantID {
+		return nil, shared.NewNotFoundError("queue", id.String())
+	}
+	return q, nil
+}
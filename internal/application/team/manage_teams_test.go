@@ -0,0 +1,105 @@
+package team
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+func newManageFixture(t *testing.T) (*ManageTeamsUseCase, *queueFixture) {
+	t.Helper()
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	uc := NewManageTeamsUseCase(f.teamRepo, f.queueRepo, f.itemRepo, f.projectRepo, f.agentRepo)
+	return uc, f
+}
+
+func TestManageTeamsUseCase_CreateTeamRejectsForeignAgent(t *testing.T) {
+	uc, f := newManageFixture(t)
+
+	userID := uuid.New()
+	foreign, err := agent.NewAgent(uuid.New(), "other-tenant", "Intruso", agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	f.agentRepo.On("FindByID", mock.Anything, foreign.ID()).Return(foreign, nil)
+
+	_, err = uc.CreateTeam(context.Background(), "tenant-123", f.project.ID(), TeamInput{
+		Name:      "Vendas",
+		MemberIDs: []uuid.UUID{f.agents[0].ID(), foreign.ID()},
+	})
+	assert.True(t, shared.IsValidationError(err))
+	f.teamRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestManageTeamsUseCase_CreateTeam(t *testing.T) {
+	uc, f := newManageFixture(t)
+	f.teamRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	supervisor := f.agents[0].ID()
+	view, err := uc.CreateTeam(context.Background(), "tenant-123", f.project.ID(), TeamInput{
+		Name:         "Vendas",
+		SupervisorID: &supervisor,
+		MemberIDs:    []uuid.UUID{supervisor, supervisor},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Vendas", view.Name)
+	assert.Equal(t, []uuid.UUID{supervisor}, view.MemberIDs)
+	assert.Equal(t, &supervisor, view.SupervisorID)
+}
+
+func TestManageTeamsUseCase_DeleteTeamWithQueuesConflicts(t *testing.T) {
+	uc, f := newManageFixture(t)
+	f.queueRepo.On("FindByTeams", mock.Anything, []uuid.UUID{f.team.ID()}).Return([]*team.Queue{f.queue}, nil)
+
+	err := uc.DeleteTeam(context.Background(), "tenant-123", f.team.ID())
+	assertConflict(t, err)
+	f.teamRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestManageTeamsUseCase_CreateQueueRequiresTeamFromProject(t *testing.T) {
+	uc, f := newManageFixture(t)
+
+	other, err := team.NewTeam("tenant-123", uuid.New(), "Outro projeto")
+	require.NoError(t, err)
+	f.teamRepo.On("FindByID", mock.Anything, other.ID()).Return(other, nil)
+
+	_, err = uc.CreateQueue(context.Background(), "tenant-123", f.project.ID(), QueueInput{
+		TeamID:   other.ID(),
+		Name:     "Nível 2",
+		Strategy: project.StrategyRoundRobin,
+		Enabled:  true,
+	})
+	assert.True(t, shared.IsValidationError(err))
+
+	_, err = uc.CreateQueue(context.Background(), "tenant-123", f.project.ID(), QueueInput{
+		TeamID:   f.team.ID(),
+		Name:     "Nível 2",
+		Strategy: project.AssignmentStrategy("random"),
+		Enabled:  true,
+	})
+	assert.True(t, shared.IsValidationError(err))
+	f.queueRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestManageTeamsUseCase_DeleteQueueWithWaitingSessionsConflicts(t *testing.T) {
+	uc, f := newManageFixture(t)
+	item := team.NewQueueItem(f.queue, f.session, f.session.StartedAt())
+	f.itemRepo.On("FindWaiting", mock.Anything, []uuid.UUID{f.queue.ID()}, 1).Return([]*team.QueueItem{item}, nil)
+
+	err := uc.DeleteQueue(context.Background(), "tenant-123", f.queue.ID())
+	assertConflict(t, err)
+}
+
+func assertConflict(t *testing.T, err error) {
+	t.Helper()
+	var domainErr *shared.DomainError
+	require.True(t, shared.IsDomainError(err, &domainErr))
+	assert.Equal(t, shared.ErrorTypeConflict, domainErr.Type)
+}
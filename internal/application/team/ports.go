@@ -0,0 +1,57 @@
+package team
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+type EventBus interface {
+	Publish(ctx context.Context, event shared.DomainEvent) error
+}
+
+type TransactionManager interface {
+	ExecuteInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// QueueChange o que aconteceu com a fila
+type QueueChange string
+
+const (
+	QueueChangeEnqueued  QueueChange = "enqueued"  // sessão entrou na fila
+	QueueChangeAssigned  QueueChange = "assigned"  // sessão saiu da fila com um agente
+	QueueChangeAbandoned QueueChange = "abandoned" // sessão encerrada enquanto aguardava
+)
+
+// QueueUpdate mudança em uma fila, com o estado da espera logo depois dela
+type QueueUpdate struct {
+	QueueID     uuid.UUID
+	TenantID    string
+	ProjectID   uuid.UUID
+	QueueName   string
+	Change      QueueChange
+	SessionID   uuid.UUID
+	AgentID     *uuid.UUID
+	Waiting     int
+	LongestWait time.Duration
+	At          time.Time
+}
+
+// QueueNotifier entrega mudanças das filas em tempo real (ex: websocket) aos membros e supervisores
+type QueueNotifier interface {
+	NotifyQueueUpdated(ctx context.Context, userIDs []uuid.UUID, update QueueUpdate)
+}
+
+// publishEvents publica os eventos pendentes da sessão (no outbox, se ctx carregar transação)
+func publishEvents(ctx context.Context, eventBus EventBus, sess *session.Session) error {
+	for _, event := range sess.DomainEvents() {
+		if err := eventBus.Publish(ctx, event); err != nil {
+			return fmt.Errorf("failed to publish event %s: %w", event.EventName(), err)
+		}
+	}
+	return nil
+}
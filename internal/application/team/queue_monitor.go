@@ -0,0 +1,270 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+const (
+	// DefaultMetricsWindow janela padrão dos atendimentos/abandonos nas métricas
+	DefaultMetricsWindow = 24 * time.Hour
+
+	// maxLiveSessions sessões em espera listadas por fila na visão ao vivo
+	maxLiveSessions = 50
+)
+
+// QueueMetricsView indicadores de uma fila
+type QueueMetricsView struct {
+	QueueID            uuid.UUID `json:"queue_id"`
+	QueueName          string    `json:"queue_name"`
+	TeamID             uuid.UUID `json:"team_id"`
+	Waiting            int       `json:"waiting"`
+	LongestWaitSeconds int64     `json:"longest_wait_seconds"`
+	Assigned           int       `json:"assigned"`
+	Abandoned          int       `json:"abandoned"`
+	AbandonmentRate    float64   `json:"abandonment_rate"`
+	AvgWaitSeconds     int64     `json:"avg_wait_seconds"`
+	Since              time.Time `json:"since"`
+}
+
+func newQueueMetricsView(q *team.Queue, m team.Metrics, since time.Time) QueueMetricsView {
+	return QueueMetricsView{
+		QueueID:            q.ID(),
+		QueueName:          q.Name(),
+		TeamID:             q.TeamID(),
+		Waiting:            m.Waiting,
+		LongestWaitSeconds: int64(m.LongestWait.Seconds()),
+		Assigned:           m.Assigned,
+		Abandoned:          m.Abandoned,
+		AbandonmentRate:    m.AbandonmentRate(),
+		AvgWaitSeconds:     int64(m.AvgWait.Seconds()),
+		Since:              since,
+	}
+}
+
+// WaitingSessionView sessão aguardando na fila
+type WaitingSessionView struct {
+	SessionID   uuid.UUID        `json:"session_id"`
+	ContactID   uuid.UUID        `json:"contact_id"`
+	Priority    session.Priority `json:"priority"`
+	EnqueuedAt  time.Time        `json:"enqueued_at"`
+	WaitSeconds int64            `json:"wait_seconds"`
+}
+
+// TeamAgentView membro do time com status e carga atual
+type TeamAgentView struct {
+	AgentID        uuid.UUID         `json:"agent_id"`
+	Name           string            `json:"name"`
+	Status         agent.AgentStatus `json:"status"`
+	ActiveSessions int               `json:"active_sessions"`
+}
+
+// LiveQueueView estado atual de uma fila para o supervisor
+type LiveQueueView struct {
+	QueueMetricsView
+	TeamName string                     `json:"team_name"`
+	Strategy project.AssignmentStrategy `json:"strategy"`
+	Priority int                        `json:"priority"`
+	Enabled  bool                       `json:"enabled"`
+	Sessions []WaitingSessionView       `json:"sessions"`
+	Agents   []TeamAgentView            `json:"agents"`
+}
+
+// LiveView filas acompanhadas pelo supervisor, maior prioridade primeiro
+type LiveView struct {
+	ProjectID   uuid.UUID       `json:"project_id"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Queues      []LiveQueueView `json:"queues"`
+}
+
+// QueueMonitorUseCase métricas das filas e visão ao vivo para supervisores
+type QueueMonitorUseCase struct {
+	teamRepo    team.TeamRepository
+	queueRepo   team.QueueRepository
+	itemRepo    team.QueueItemRepository
+	agentRepo   agent.Repository
+	projectRepo project.Repository
+	routingRepo routing.Repository
+}
+
+func NewQueueMonitorUseCase(
+	teamRepo team.TeamRepository,
+	queueRepo team.QueueRepository,
+	itemRepo team.QueueItemRepository,
+	agentRepo agent.Repository,
+	projectRepo project.Repository,
+	routingRepo routing.Repository,
+) *QueueMonitorUseCase {
+	return &QueueMonitorUseCase{
+		teamRepo:    teamRepo,
+		queueRepo:   queueRepo,
+		itemRepo:    itemRepo,
+		agentRepo:   agentRepo,
+		projectRepo: projectRepo,
+		routingRepo: routingRepo,
+	}
+}
+
+// QueueMetrics indicadores de uma fila; atendimentos e abandonos contam a partir de now-window
+func (uc *QueueMonitorUseCase) QueueMetrics(ctx context.Context, tenantID string, queueID uuid.UUID, window time.Duration) (*QueueMetricsView, error) {
+	q, err := findQueue(ctx, uc.queueRepo, tenantID, queueID)
+	if err != nil {
+		return nil, err
+	}
+	views, err := uc.metrics(ctx, []*team.Queue{q}, window)
+	if err != nil {
+		return nil, err
+	}
+	return &views[0], nil
+}
+
+// ProjectMetrics indicadores de todas as filas do projeto
+func (uc *QueueMonitorUseCase) ProjectMetrics(ctx context.Context, tenantID string, projectID uuid.UUID, window time.Duration) ([]QueueMetricsView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	queues, err := uc.queueRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	return uc.metrics(ctx, queues, window)
+}
+
+func (uc *QueueMonitorUseCase) metrics(ctx context.Context, queues []*team.Queue, window time.Duration) ([]QueueMetricsView, error) {
+	if window <= 0 {
+		window = DefaultMetricsWindow
+	}
+	now := time.Now()
+	since := now.Add(-window)
+
+	ids := make([]uuid.UUID, len(queues))
+	for i, q := range queues {
+		ids[i] = q.ID()
+	}
+	metrics, err := uc.itemRepo.Metrics(ctx, ids, since, now)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]QueueMetricsView, len(queues))
+	for i, q := range queues {
+		views[i] = newQueueMetricsView(q, metrics[q.ID()], since)
+	}
+	return views, nil
+}
+
+// LiveView filas que o usuário supervisiona no projeto: todas para gestores (admin/supervisor),
+// senão as dos times em que ele é o supervisor.
+func (uc *QueueMonitorUseCase) LiveView(ctx context.Context, tenantID string, userID, projectID uuid.UUID) (*LiveView, error) {
+	if err := checkProject(ctx, uc.projectRepo, tenantID, projectID); err != nil {
+		return nil, err
+	}
+	viewer, err := agentForUser(ctx, uc.agentRepo, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := uc.teamRepo.FindByProject(ctx, projectID)
+	if err != nil {
+		return nil, err
+	}
+	supervised := make(map[uuid.UUID]*team.Team, len(teams))
+	teamIDs := make([]uuid.UUID, 0, len(teams))
+	for _, t := range teams {
+		if viewer.Role().CanManageAgents() || t.IsSupervisor(viewer.ID()) {
+			supervised[t.ID()] = t
+			teamIDs = append(teamIDs, t.ID())
+		}
+	}
+	if len(teamIDs) == 0 && !viewer.Role().CanManageAgents() {
+		return nil, shared.NewForbiddenError("only supervisors can see the live queue view")
+	}
+
+	queues, err := uc.queueRepo.FindByTeams(ctx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := uc.metrics(ctx, queues, DefaultMetricsWindow)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	agentsByTeam := make(map[uuid.UUID][]TeamAgentView, len(supervised))
+	live := &LiveView{ProjectID: projectID, GeneratedAt: now, Queues: make([]LiveQueueView, 0, len(queues))}
+	for i, q := range queues {
+		t := supervised[q.TeamID()]
+
+		agents, ok := agentsByTeam[t.ID()]
+		if !ok {
+			if agents, err = uc.teamAgents(ctx, t); err != nil {
+				return nil, err
+			}
+			agentsByTeam[t.ID()] = agents
+		}
+
+		items, err := uc.itemRepo.FindWaiting(ctx, []uuid.UUID{q.ID()}, maxLiveSessions)
+		if err != nil {
+			return nil, err
+		}
+		team.SortWaiting(items)
+		sessions := make([]WaitingSessionView, len(items))
+		for j, item := range items {
+			sessions[j] = WaitingSessionView{
+				SessionID:   item.SessionID(),
+				ContactID:   item.ContactID(),
+				Priority:    item.Priority(),
+				EnqueuedAt:  item.EnqueuedAt(),
+				WaitSeconds: int64(item.WaitTime(now).Seconds()),
+			}
+		}
+
+		live.Queues = append(live.Queues, LiveQueueView{
+			QueueMetricsView: metrics[i],
+			TeamName:         t.Name(),
+			Strategy:         q.Strategy(),
+			Priority:         q.Priority(),
+			Enabled:          q.IsEnabled(),
+			Sessions:         sessions,
+			Agents:           agents,
+		})
+	}
+	return live, nil
+}
+
+// teamAgents membros do time com status e sessões ativas
+func (uc *QueueMonitorUseCase) teamAgents(ctx context.Context, t *team.Team) ([]TeamAgentView, error) {
+	memberIDs := t.MemberIDs()
+	workloads, err := uc.routingRepo.GetWorkloads(ctx, t.TenantID(), memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	views := make([]TeamAgentView, 0, len(memberIDs))
+	for _, id := range memberIDs {
+		a, err := uc.agentRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, agent.ErrAgentNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load agent %s: %w", id, err)
+		}
+		views = append(views, TeamAgentView{
+			AgentID:        a.ID(),
+			Name:           a.Name(),
+			Status:         a.Status(),
+			ActiveSessions: workloads[id].ActiveSessions,
+		})
+	}
+	return views, nil
+}
@@ -0,0 +1,62 @@
+package team
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+func TestQueueMonitorUseCase_LiveViewRequiresSupervisor(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	uc := NewQueueMonitorUseCase(f.teamRepo, f.queueRepo, f.itemRepo, f.agentRepo, f.projectRepo, f.routingRepo)
+	f.teamRepo.On("FindByProject", mock.Anything, f.project.ID()).Return([]*team.Team{f.team}, nil)
+
+	_, err := uc.LiveView(context.Background(), "tenant-123", *f.agents[0].UserID(), f.project.ID())
+	assert.True(t, shared.IsForbiddenError(err))
+}
+
+func TestQueueMonitorUseCase_LiveViewForSupervisor(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 2)
+	uc := NewQueueMonitorUseCase(f.teamRepo, f.queueRepo, f.itemRepo, f.agentRepo, f.projectRepo, f.routingRepo)
+
+	supervisor := f.agents[0]
+	require.NoError(t, f.team.SetSupervisor(ptr(supervisor.ID())))
+	unsupervised, err := team.NewTeam("tenant-123", f.project.ID(), "Financeiro")
+	require.NoError(t, err)
+
+	item := team.NewQueueItem(f.queue, f.session, time.Now().Add(-5*time.Minute))
+	f.teamRepo.On("FindByProject", mock.Anything, f.project.ID()).Return([]*team.Team{f.team, unsupervised}, nil)
+	f.queueRepo.On("FindByTeams", mock.Anything, []uuid.UUID{f.team.ID()}).Return([]*team.Queue{f.queue}, nil)
+	f.itemRepo.ExpectedCalls = nil
+	f.itemRepo.On("Metrics", mock.Anything, []uuid.UUID{f.queue.ID()}, mock.Anything, mock.Anything).
+		Return(map[uuid.UUID]team.Metrics{f.queue.ID(): {Waiting: 1, LongestWait: 5 * time.Minute, Assigned: 3, Abandoned: 1}}, nil)
+	f.itemRepo.On("FindWaiting", mock.Anything, []uuid.UUID{f.queue.ID()}, maxLiveSessions).Return([]*team.QueueItem{item}, nil)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", f.team.MemberIDs()).Return(map[uuid.UUID]routing.Workload{
+		f.agents[1].ID(): {ActiveSessions: 2},
+	}, nil)
+
+	live, err := uc.LiveView(context.Background(), "tenant-123", *supervisor.UserID(), f.project.ID())
+	require.NoError(t, err)
+
+	require.Len(t, live.Queues, 1)
+	q := live.Queues[0]
+	assert.Equal(t, "Suporte", q.TeamName)
+	assert.Equal(t, 1, q.Waiting)
+	assert.Equal(t, int64(300), q.LongestWaitSeconds)
+	assert.InDelta(t, 25.0, q.AbandonmentRate, 0.001)
+	require.Len(t, q.Sessions, 1)
+	assert.Equal(t, f.session.ID(), q.Sessions[0].SessionID)
+	require.Len(t, q.Agents, 2)
+	assert.Equal(t, 2, q.Agents[1].ActiveSessions)
+}
+
+func ptr[T any](v T) *T { return &v }
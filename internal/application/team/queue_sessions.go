@@ -0,0 +1,530 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+// PickStrategy estratégia registrada na atribuição quando o agente puxa a sessão da fila
+const PickStrategy = "queue_pick"
+
+// maxPickAttempts itens obsoletos (sessão encerrada ou já atendida) pulados por chamada de PickNext
+const maxPickAttempts = 5
+
+// PickResult sessão entregue ao agente por PickNext. Picked=false quando as filas estão vazias.
+type PickResult struct {
+	Picked      bool             `json:"picked"`
+	SessionID   uuid.UUID        `json:"session_id,omitempty"`
+	ContactID   uuid.UUID        `json:"contact_id,omitempty"`
+	QueueID     uuid.UUID        `json:"queue_id,omitempty"`
+	QueueName   string           `json:"queue_name,omitempty"`
+	Priority    session.Priority `json:"priority,omitempty"`
+	WaitSeconds int64            `json:"wait_seconds,omitempty"`
+}
+
+// QueueSessionsUseCase movimenta as sessões pelas filas: entrada (ação assign_to_queue das
+// automações), distribuição automática, "puxar a próxima" pelos agentes e encerramento das
+// esperas quando a sessão termina ou é atribuída por fora da fila.
+type QueueSessionsUseCase struct {
+	sessionRepo session.Repository
+	contactRepo contact.Repository
+	projectRepo project.Repository
+	agentRepo   agent.Repository
+	teamRepo    team.TeamRepository
+	queueRepo   team.QueueRepository
+	itemRepo    team.QueueItemRepository
+	routingRepo routing.Repository
+	eventBus    EventBus
+	txManager   TransactionManager
+	notifier    QueueNotifier
+}
+
+// NewQueueSessionsUseCase creates a new instance
+func NewQueueSessionsUseCase(
+	sessionRepo session.Repository,
+	contactRepo contact.Repository,
+	projectRepo project.Repository,
+	agentRepo agent.Repository,
+	teamRepo team.TeamRepository,
+	queueRepo team.QueueRepository,
+	itemRepo team.QueueItemRepository,
+	routingRepo routing.Repository,
+	eventBus EventBus,
+	txManager TransactionManager,
+	notifier QueueNotifier,
+) *QueueSessionsUseCase {
+	return &QueueSessionsUseCase{
+		sessionRepo: sessionRepo,
+		contactRepo: contactRepo,
+		projectRepo: projectRepo,
+		agentRepo:   agentRepo,
+		teamRepo:    teamRepo,
+		queueRepo:   queueRepo,
+		itemRepo:    itemRepo,
+		routingRepo: routingRepo,
+		eventBus:    eventBus,
+		txManager:   txManager,
+		notifier:    notifier,
+	}
+}
+
+// AssignToQueue coloca a sessão na fila (implementa pipeline.QueueAssigner). Se a sessão já
+// aguarda em outra fila, ela é transferida mantendo o tempo de espera. Em filas automáticas a
+// sessão é distribuída na hora quando algum membro está elegível. Sessões que já têm agente
+// não entram na fila.
+func (uc *QueueSessionsUseCase) AssignToQueue(ctx context.Context, sessionID, queueID uuid.UUID) error {
+	var (
+		q       *team.Queue
+		item    *team.QueueItem
+		sess    *session.Session
+		changes []QueueChange
+	)
+
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		sess, err = uc.sessionRepo.FindByID(txCtx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to load session: %w", err)
+		}
+		if !sess.IsActive() || sess.HasAssignedAgents() {
+			return nil
+		}
+
+		q, err = findQueue(txCtx, uc.queueRepo, sess.TenantID(), queueID)
+		if err != nil {
+			return err
+		}
+		if !q.IsEnabled() {
+			return shared.NewValidationError("queue is disabled", "queue_id")
+		}
+
+		now := time.Now()
+		item, err = uc.itemRepo.FindWaitingBySession(txCtx, sessionID)
+		switch {
+		case errors.Is(err, team.ErrQueueItemNotFound):
+			item = team.NewQueueItem(q, sess, now)
+		case err != nil:
+			return err
+		case item.QueueID() == q.ID():
+			return nil
+		default:
+			if err := item.MoveTo(q, sess.Priority()); err != nil {
+				return err
+			}
+		}
+		changes = append(changes, QueueChangeEnqueued)
+
+		if q.IsAutomatic() {
+			assigned, err := uc.distribute(txCtx, q, item, sess, now)
+			if err != nil {
+				return err
+			}
+			if assigned {
+				changes = append(changes, QueueChangeAssigned)
+			}
+		}
+		return uc.itemRepo.Save(txCtx, item)
+	})
+	if err != nil {
+		return err
+	}
+	if sess != nil {
+		sess.ClearEvents()
+	}
+
+	for _, change := range changes {
+		uc.notify(ctx, q, item, change)
+	}
+	return nil
+}
+
+// DistributeWaiting tenta atribuir a um membro elegível a sessão que aguarda em fila automática.
+// Retorna true se a sessão saiu da fila (atribuída ou abandonada).
+func (uc *QueueSessionsUseCase) DistributeWaiting(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	var (
+		q      *team.Queue
+		item   *team.QueueItem
+		change QueueChange
+	)
+
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		item, err = uc.itemRepo.FindWaitingBySession(txCtx, sessionID)
+		if errors.Is(err, team.ErrQueueItemNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		q, err = uc.queueRepo.FindByID(txCtx, item.QueueID())
+		if err != nil {
+			return fmt.Errorf("failed to load queue: %w", err)
+		}
+		if !q.IsEnabled() || !q.IsAutomatic() {
+			return nil
+		}
+
+		sess, err := uc.sessionRepo.FindByID(txCtx, sessionID)
+		if err != nil {
+			return fmt.Errorf("failed to load session: %w", err)
+		}
+
+		now := time.Now()
+		if change, err = uc.resolveStale(txCtx, item, sess, now); err != nil || change != "" {
+			return err
+		}
+
+		assigned, err := uc.distribute(txCtx, q, item, sess, now)
+		if err != nil || !assigned {
+			return err
+		}
+		change = QueueChangeAssigned
+		sess.ClearEvents()
+		return uc.itemRepo.Save(txCtx, item)
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if change != "" {
+		uc.notify(ctx, q, item, change)
+		return true, nil
+	}
+	return false, nil
+}
+
+// FindDistributable retorna as sessões que aguardam em filas automáticas, na ordem de atendimento
+func (uc *QueueSessionsUseCase) FindDistributable(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	items, err := uc.itemRepo.FindDistributable(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.SessionID()
+	}
+	return ids, nil
+}
+
+// PickNext atribui ao agente do usuário a próxima sessão das filas dos seus times:
+// fila de maior prioridade, depois prioridade da sessão, depois quem espera há mais tempo.
+func (uc *QueueSessionsUseCase) PickNext(ctx context.Context, tenantID string, userID uuid.UUID) (*PickResult, error) {
+	a, err := agentForUser(ctx, uc.agentRepo, tenantID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	teams, err := uc.teamRepo.FindByMember(ctx, tenantID, a.ID())
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) == 0 {
+		return nil, shared.NewForbiddenError("agent is not a member of any team")
+	}
+	teamIDs := make([]uuid.UUID, len(teams))
+	for i, t := range teams {
+		teamIDs[i] = t.ID()
+	}
+	queues, err := uc.queueRepo.FindByTeams(ctx, teamIDs)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*team.Queue, len(queues))
+	queueIDs := make([]uuid.UUID, 0, len(queues))
+	for _, q := range queues {
+		if q.IsEnabled() {
+			byID[q.ID()] = q
+			queueIDs = append(queueIDs, q.ID())
+		}
+	}
+
+	for attempt := 0; attempt < maxPickAttempts; attempt++ {
+		var (
+			item   *team.QueueItem
+			change QueueChange
+		)
+
+		err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+			var err error
+			item, err = uc.itemRepo.ClaimNext(txCtx, queueIDs)
+			if err != nil {
+				return err
+			}
+
+			sess, err := uc.sessionRepo.FindByID(txCtx, item.SessionID())
+			if err != nil {
+				return fmt.Errorf("failed to load session: %w", err)
+			}
+
+			now := time.Now()
+			if change, err = uc.resolveStale(txCtx, item, sess, now); err != nil || change != "" {
+				return err
+			}
+
+			agentID := a.ID()
+			strategy := PickStrategy
+			if err := sess.AssignAgentWithSource(agentID, session.AssignmentSourceManual, &agentID, &strategy, nil); err != nil {
+				return err
+			}
+			if err := uc.sessionRepo.Save(txCtx, sess); err != nil {
+				return fmt.Errorf("failed to save session: %w", err)
+			}
+			if err := publishEvents(txCtx, uc.eventBus, sess); err != nil {
+				return err
+			}
+			sess.ClearEvents()
+
+			if err := item.Assign(agentID, now); err != nil {
+				return err
+			}
+			change = QueueChangeAssigned
+			return uc.itemRepo.Save(txCtx, item)
+		})
+		if errors.Is(err, team.ErrQueueItemNotFound) {
+			return &PickResult{Picked: false}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		q := byID[item.QueueID()]
+		uc.notify(ctx, q, item, change)
+		if item.AgentID() == nil || *item.AgentID() != a.ID() {
+			// Item obsoleto: encerrado e descartado, tenta o seguinte
+			continue
+		}
+
+		return &PickResult{
+			Picked:      true,
+			SessionID:   item.SessionID(),
+			ContactID:   item.ContactID(),
+			QueueID:     q.ID(),
+			QueueName:   q.Name(),
+			Priority:    item.Priority(),
+			WaitSeconds: int64(item.WaitTime(time.Now()).Seconds()),
+		}, nil
+	}
+
+	return &PickResult{Picked: false}, nil
+}
+
+// HandleSessionEnded encerra como abandonada a espera da sessão que terminou sem atendimento
+func (uc *QueueSessionsUseCase) HandleSessionEnded(ctx context.Context, sessionID uuid.UUID) error {
+	return uc.closeWaiting(ctx, sessionID, func(item *team.QueueItem, now time.Time) (QueueChange, error) {
+		return QueueChangeAbandoned, item.Abandon(now)
+	})
+}
+
+// HandleAgentAssigned encerra a espera da sessão atribuída por fora da fila (roteamento, atribuição manual)
+func (uc *QueueSessionsUseCase) HandleAgentAssigned(ctx context.Context, sessionID, agentID uuid.UUID) error {
+	return uc.closeWaiting(ctx, sessionID, func(item *team.QueueItem, now time.Time) (QueueChange, error) {
+		return QueueChangeAssigned, item.Assign(agentID, now)
+	})
+}
+
+func (uc *QueueSessionsUseCase) closeWaiting(ctx context.Context, sessionID uuid.UUID, resolve func(*team.QueueItem, time.Time) (QueueChange, error)) error {
+	var (
+		item   *team.QueueItem
+		change QueueChange
+	)
+	err := uc.txManager.ExecuteInTransaction(ctx, func(txCtx context.Context) error {
+		var err error
+		item, err = uc.itemRepo.FindWaitingBySession(txCtx, sessionID)
+		if errors.Is(err, team.ErrQueueItemNotFound) {
+			item = nil
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if change, err = resolve(item, time.Now()); err != nil {
+			return err
+		}
+		return uc.itemRepo.Save(txCtx, item)
+	})
+	if err != nil || item == nil {
+		return err
+	}
+
+	q, err := uc.queueRepo.FindByID(ctx, item.QueueID())
+	if err != nil {
+		return nil
+	}
+	uc.notify(ctx, q, item, change)
+	return nil
+}
+
+// resolveStale encerra o item cuja sessão terminou ou já tem agente. Retorna a mudança aplicada ("" = item válido).
+func (uc *QueueSessionsUseCase) resolveStale(ctx context.Context, item *team.QueueItem, sess *session.Session, now time.Time) (QueueChange, error) {
+	switch {
+	case !sess.IsActive():
+		if err := item.Abandon(now); err != nil {
+			return "", err
+		}
+		return QueueChangeAbandoned, uc.itemRepo.Save(ctx, item)
+	case sess.HasAssignedAgents():
+		if err := item.Assign(*sess.GetCurrentAgent(), now); err != nil {
+			return "", err
+		}
+		return QueueChangeAssigned, uc.itemRepo.Save(ctx, item)
+	default:
+		return "", nil
+	}
+}
+
+// distribute escolhe um membro do time da fila segundo a estratégia da fila e atribui a sessão.
+// Retorna false se nenhum membro está elegível (a sessão continua aguardando).
+func (uc *QueueSessionsUseCase) distribute(ctx context.Context, q *team.Queue, item *team.QueueItem, sess *session.Session, now time.Time) (bool, error) {
+	t, err := uc.teamRepo.FindByID(ctx, q.TeamID())
+	if err != nil {
+		return false, fmt.Errorf("failed to load team: %w", err)
+	}
+	proj, err := uc.projectRepo.FindByID(ctx, q.ProjectID())
+	if err != nil {
+		return false, fmt.Errorf("failed to load project: %w", err)
+	}
+	c, err := uc.contactRepo.FindByID(ctx, sess.ContactID())
+	if err != nil {
+		return false, fmt.Errorf("failed to load contact: %w", err)
+	}
+
+	config := q.AssignmentConfig(t, proj.GetAgentAssignment())
+	if !config.ShouldAutoAssign() {
+		return false, nil
+	}
+
+	// Mesma trava do roteamento do projeto: a carga lida aqui não muda até o commit
+	if err := uc.routingRepo.LockProject(ctx, proj.ID()); err != nil {
+		return false, err
+	}
+	candidates, err := uc.loadCandidates(ctx, sess.TenantID(), config.AgentIDs)
+	if err != nil {
+		return false, err
+	}
+
+	req := routing.RequirementsFor(config, c.Language(), c.Tags())
+	agentID, err := routing.SelectAgent(config, candidates, req, q.LastAssignedAgentID())
+	if errors.Is(err, routing.ErrNoEligibleAgent) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if err := sess.AssignAgentAutomatic(agentID, string(q.Strategy())); err != nil {
+		return false, err
+	}
+	if err := uc.sessionRepo.Save(ctx, sess); err != nil {
+		return false, fmt.Errorf("failed to save session: %w", err)
+	}
+	if err := publishEvents(ctx, uc.eventBus, sess); err != nil {
+		return false, err
+	}
+
+	q.RecordAssignment(agentID)
+	if err := uc.queueRepo.Save(ctx, q); err != nil {
+		return false, err
+	}
+	return true, item.Assign(agentID, now)
+}
+
+// loadCandidates carrega os membros com sua carga atual (agentes removidos ou de outro tenant são ignorados)
+func (uc *QueueSessionsUseCase) loadCandidates(ctx context.Context, tenantID string, agentIDs []uuid.UUID) ([]routing.Candidate, error) {
+	workloads, err := uc.routingRepo.GetWorkloads(ctx, tenantID, agentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]routing.Candidate, 0, len(agentIDs))
+	for _, id := range agentIDs {
+		a, err := uc.agentRepo.FindByID(ctx, id)
+		if err != nil {
+			if errors.Is(err, agent.ErrAgentNotFound) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to load agent %s: %w", id, err)
+		}
+		if a.TenantID() != tenantID {
+			continue
+		}
+		candidates = append(candidates, routing.Candidate{Agent: a, Workload: workloads[id]})
+	}
+	return candidates, nil
+}
+
+// notify avisa membros e supervisores do time, e gestores do tenant, sobre a mudança na fila
+func (uc *QueueSessionsUseCase) notify(ctx context.Context, q *team.Queue, item *team.QueueItem, change QueueChange) {
+	if uc.notifier == nil || q == nil || item == nil || change == "" {
+		return
+	}
+
+	now := time.Now()
+	update := QueueUpdate{
+		QueueID:   q.ID(),
+		TenantID:  q.TenantID(),
+		ProjectID: q.ProjectID(),
+		QueueName: q.Name(),
+		Change:    change,
+		SessionID: item.SessionID(),
+		AgentID:   item.AgentID(),
+		At:        now,
+	}
+	if metrics, err := uc.itemRepo.Metrics(ctx, []uuid.UUID{q.ID()}, now, now); err == nil {
+		update.Waiting = metrics[q.ID()].Waiting
+		update.LongestWait = metrics[q.ID()].LongestWait
+	}
+
+	t, err := uc.teamRepo.FindByID(ctx, q.TeamID())
+	if err != nil {
+		return
+	}
+	userIDs := queueAudience(ctx, uc.agentRepo, t)
+	if len(userIDs) > 0 {
+		uc.notifier.NotifyQueueUpdated(ctx, userIDs, update)
+	}
+}
+
+// queueAudience usuários que acompanham a fila: membros e supervisor do time e gestores ativos do tenant
+func queueAudience(ctx context.Context, agentRepo agent.Repository, t *team.Team) []uuid.UUID {
+	agents, err := agentRepo.FindActiveByTenant(ctx, t.TenantID())
+	if err != nil {
+		return nil
+	}
+
+	var userIDs []uuid.UUID
+	for _, a := range agents {
+		if a.UserID() == nil {
+			continue
+		}
+		if t.HasMember(a.ID()) || t.IsSupervisor(a.ID()) || a.Role().CanManageAgents() {
+			userIDs = append(userIDs, *a.UserID())
+		}
+	}
+	return userIDs
+}
+
+// agentForUser retorna o agente ativo do usuário no tenant
+func agentForUser(ctx context.Context, agentRepo agent.Repository, tenantID string, userID uuid.UUID) (*agent.Agent, error) {
+	agents, err := agentRepo.FindActiveByTenant(ctx, tenantID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agents: %w", err)
+	}
+	for _, a := range agents {
+		if a.UserID() != nil && *a.UserID() == userID {
+			return a, nil
+		}
+	}
+	return nil, shared.NewNotFoundError("agent", userID.String())
+}
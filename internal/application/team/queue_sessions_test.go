@@ -0,0 +1,287 @@
+package team
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+	"github.com/ventros/crm/internal/domain/crm/contact"
+	"github.com/ventros/crm/internal/domain/crm/routing"
+	"github.com/ventros/crm/internal/domain/crm/session"
+	"github.com/ventros/crm/internal/domain/crm/team"
+)
+
+type queueFixture struct {
+	sessionRepo *MockSessionRepository
+	contactRepo *MockContactRepository
+	projectRepo *MockProjectRepository
+	agentRepo   *MockAgentRepository
+	teamRepo    *MockTeamRepository
+	queueRepo   *MockQueueRepository
+	itemRepo    *MockQueueItemRepository
+	routingRepo *MockRoutingRepository
+	eventBus    *MockEventBus
+	notifier    *MockQueueNotifier
+	useCase     *QueueSessionsUseCase
+
+	project *project.Project
+	contact *contact.Contact
+	session *session.Session
+	team    *team.Team
+	queue   *team.Queue
+	agents  []*agent.Agent
+}
+
+func newQueueFixture(t *testing.T, strategy project.AssignmentStrategy, agentCount int) *queueFixture {
+	t.Helper()
+	f := &queueFixture{
+		sessionRepo: new(MockSessionRepository),
+		contactRepo: new(MockContactRepository),
+		projectRepo: new(MockProjectRepository),
+		agentRepo:   new(MockAgentRepository),
+		teamRepo:    new(MockTeamRepository),
+		queueRepo:   new(MockQueueRepository),
+		itemRepo:    new(MockQueueItemRepository),
+		routingRepo: new(MockRoutingRepository),
+		eventBus:    new(MockEventBus),
+		notifier:    new(MockQueueNotifier),
+	}
+	f.useCase = NewQueueSessionsUseCase(f.sessionRepo, f.contactRepo, f.projectRepo, f.agentRepo, f.teamRepo,
+		f.queueRepo, f.itemRepo, f.routingRepo, f.eventBus, &SimpleTransactionManager{}, f.notifier)
+
+	var err error
+	f.project, err = project.NewProject(uuid.New(), uuid.New(), "tenant-123", "Project")
+	require.NoError(t, err)
+	f.contact, err = contact.NewContact(f.project.ID(), "tenant-123", "Maria")
+	require.NoError(t, err)
+	f.session, err = session.NewSession(f.contact.ID(), "tenant-123", nil, 30*time.Minute)
+	require.NoError(t, err)
+	f.session.ClearEvents()
+
+	f.team, err = team.NewTeam("tenant-123", f.project.ID(), "Suporte")
+	require.NoError(t, err)
+	for i := 0; i < agentCount; i++ {
+		userID := uuid.New()
+		a, err := agent.NewAgent(f.project.ID(), "tenant-123", "Agent", agent.AgentTypeHuman, &userID)
+		require.NoError(t, err)
+		a.SetStatus(agent.AgentStatusAvailable)
+		f.agents = append(f.agents, a)
+		require.NoError(t, f.team.AddMember(a.ID()))
+		f.agentRepo.On("FindByID", mock.Anything, a.ID()).Return(a, nil)
+	}
+	f.queue, err = team.NewQueue("tenant-123", f.project.ID(), f.team.ID(), "Nível 1", strategy)
+	require.NoError(t, err)
+
+	f.sessionRepo.On("FindByID", mock.Anything, f.session.ID()).Return(f.session, nil)
+	f.contactRepo.On("FindByID", mock.Anything, f.contact.ID()).Return(f.contact, nil)
+	f.projectRepo.On("FindByID", mock.Anything, f.project.ID()).Return(f.project, nil)
+	f.teamRepo.On("FindByID", mock.Anything, f.team.ID()).Return(f.team, nil)
+	f.queueRepo.On("FindByID", mock.Anything, f.queue.ID()).Return(f.queue, nil)
+	f.routingRepo.On("LockProject", mock.Anything, f.project.ID()).Return(nil)
+	f.agentRepo.On("FindActiveByTenant", mock.Anything, "tenant-123").Return(f.agents, nil)
+	f.itemRepo.On("Metrics", mock.Anything, []uuid.UUID{f.queue.ID()}, mock.Anything, mock.Anything).
+		Return(map[uuid.UUID]team.Metrics{}, nil)
+	f.notifier.On("NotifyQueueUpdated", mock.Anything, mock.Anything, mock.Anything)
+
+	return f
+}
+
+func (f *queueFixture) changes() []QueueChange {
+	var changes []QueueChange
+	for _, call := range f.notifier.Calls {
+		changes = append(changes, call.Arguments.Get(2).(QueueUpdate).Change)
+	}
+	return changes
+}
+
+func TestQueueSessionsUseCase_AssignToManualQueueWaits(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 2)
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, f.session.ID()).Return(nil, team.ErrQueueItemNotFound)
+
+	var saved *team.QueueItem
+	f.itemRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*team.QueueItem)
+	}).Return(nil)
+
+	require.NoError(t, f.useCase.AssignToQueue(context.Background(), f.session.ID(), f.queue.ID()))
+
+	require.NotNil(t, saved)
+	assert.True(t, saved.IsWaiting())
+	assert.Equal(t, f.queue.ID(), saved.QueueID())
+	assert.False(t, f.session.HasAssignedAgents())
+	assert.Equal(t, []QueueChange{QueueChangeEnqueued}, f.changes())
+	f.sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
+func TestQueueSessionsUseCase_AssignToAutomaticQueueDistributes(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyLeastSessions, 2)
+	busy, idle := f.agents[0], f.agents[1]
+
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, f.session.ID()).Return(nil, team.ErrQueueItemNotFound)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{
+		busy.ID(): {ActiveSessions: 3},
+	}, nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	f.queueRepo.On("Save", mock.Anything, f.queue).Return(nil)
+
+	var saved *team.QueueItem
+	f.itemRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		saved = args.Get(1).(*team.QueueItem)
+	}).Return(nil)
+
+	require.NoError(t, f.useCase.AssignToQueue(context.Background(), f.session.ID(), f.queue.ID()))
+
+	assert.Equal(t, idle.ID(), *f.session.GetCurrentAgent())
+	assert.Equal(t, team.ItemAssigned, saved.Status())
+	assert.Equal(t, idle.ID(), *f.queue.LastAssignedAgentID())
+	assert.Equal(t, []QueueChange{QueueChangeEnqueued, QueueChangeAssigned}, f.changes())
+}
+
+func TestQueueSessionsUseCase_AssignToAutomaticQueueWithoutEligibleAgentWaits(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyRoundRobin, 1)
+	f.agents[0].SetStatus(agent.AgentStatusOffline)
+
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, f.session.ID()).Return(nil, team.ErrQueueItemNotFound)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+	f.itemRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, f.useCase.AssignToQueue(context.Background(), f.session.ID(), f.queue.ID()))
+
+	assert.False(t, f.session.HasAssignedAgents())
+	assert.Equal(t, []QueueChange{QueueChangeEnqueued}, f.changes())
+}
+
+func TestQueueSessionsUseCase_AssignToQueueMovesWaitingSession(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	other, err := team.NewQueue("tenant-123", f.project.ID(), f.team.ID(), "Triagem", project.StrategyManual)
+	require.NoError(t, err)
+	enqueuedAt := time.Now().Add(-10 * time.Minute)
+	item := team.NewQueueItem(other, f.session, enqueuedAt)
+
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, f.session.ID()).Return(item, nil)
+	f.itemRepo.On("Save", mock.Anything, item).Return(nil)
+
+	require.NoError(t, f.useCase.AssignToQueue(context.Background(), f.session.ID(), f.queue.ID()))
+
+	assert.Equal(t, f.queue.ID(), item.QueueID())
+	assert.Equal(t, enqueuedAt, item.EnqueuedAt())
+}
+
+func TestQueueSessionsUseCase_AssignToQueueIgnoresAssignedSession(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	require.NoError(t, f.session.AssignAgent(f.agents[0].ID()))
+
+	require.NoError(t, f.useCase.AssignToQueue(context.Background(), f.session.ID(), f.queue.ID()))
+
+	f.itemRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	assert.Empty(t, f.changes())
+}
+
+func TestQueueSessionsUseCase_AssignToDisabledQueueFails(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	f.queue.Disable()
+
+	err := f.useCase.AssignToQueue(context.Background(), f.session.ID(), f.queue.ID())
+	assert.True(t, shared.IsValidationError(err))
+}
+
+func TestQueueSessionsUseCase_PickNext(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 2)
+	picker := f.agents[1]
+	item := team.NewQueueItem(f.queue, f.session, time.Now().Add(-3*time.Minute))
+
+	f.teamRepo.On("FindByMember", mock.Anything, "tenant-123", picker.ID()).Return([]*team.Team{f.team}, nil)
+	f.queueRepo.On("FindByTeams", mock.Anything, []uuid.UUID{f.team.ID()}).Return([]*team.Queue{f.queue}, nil)
+	f.itemRepo.On("ClaimNext", mock.Anything, []uuid.UUID{f.queue.ID()}).Return(item, nil).Once()
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	f.itemRepo.On("Save", mock.Anything, item).Return(nil)
+
+	result, err := f.useCase.PickNext(context.Background(), "tenant-123", *picker.UserID())
+	require.NoError(t, err)
+
+	assert.True(t, result.Picked)
+	assert.Equal(t, f.session.ID(), result.SessionID)
+	assert.Equal(t, "Nível 1", result.QueueName)
+	assert.GreaterOrEqual(t, result.WaitSeconds, int64(180))
+	assert.Equal(t, picker.ID(), *f.session.GetCurrentAgent())
+	assert.Equal(t, team.ItemAssigned, item.Status())
+	assert.Equal(t, []QueueChange{QueueChangeAssigned}, f.changes())
+	f.eventBus.AssertCalled(t, "Publish", mock.Anything, mock.AnythingOfType("session.AgentAssignedEvent"))
+}
+
+func TestQueueSessionsUseCase_PickNextSkipsEndedSession(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	picker := f.agents[0]
+	stale := team.NewQueueItem(f.queue, f.session, time.Now())
+	require.NoError(t, f.session.End(session.ReasonContactRequest))
+
+	f.teamRepo.On("FindByMember", mock.Anything, "tenant-123", picker.ID()).Return([]*team.Team{f.team}, nil)
+	f.queueRepo.On("FindByTeams", mock.Anything, []uuid.UUID{f.team.ID()}).Return([]*team.Queue{f.queue}, nil)
+	f.itemRepo.On("ClaimNext", mock.Anything, []uuid.UUID{f.queue.ID()}).Return(stale, nil).Once()
+	f.itemRepo.On("ClaimNext", mock.Anything, []uuid.UUID{f.queue.ID()}).Return(nil, team.ErrQueueItemNotFound)
+	f.itemRepo.On("Save", mock.Anything, stale).Return(nil)
+
+	result, err := f.useCase.PickNext(context.Background(), "tenant-123", *picker.UserID())
+	require.NoError(t, err)
+
+	assert.False(t, result.Picked)
+	assert.Equal(t, team.ItemAbandoned, stale.Status())
+	assert.Equal(t, []QueueChange{QueueChangeAbandoned}, f.changes())
+}
+
+func TestQueueSessionsUseCase_PickNextRequiresTeam(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	f.teamRepo.On("FindByMember", mock.Anything, "tenant-123", f.agents[0].ID()).Return([]*team.Team{}, nil)
+
+	_, err := f.useCase.PickNext(context.Background(), "tenant-123", *f.agents[0].UserID())
+	assert.True(t, shared.IsForbiddenError(err))
+
+	_, err = f.useCase.PickNext(context.Background(), "tenant-123", uuid.New())
+	assert.True(t, shared.IsNotFoundError(err))
+}
+
+func TestQueueSessionsUseCase_HandleSessionEndedAbandons(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyManual, 1)
+	item := team.NewQueueItem(f.queue, f.session, time.Now())
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, f.session.ID()).Return(item, nil)
+	f.itemRepo.On("Save", mock.Anything, item).Return(nil)
+
+	require.NoError(t, f.useCase.HandleSessionEnded(context.Background(), f.session.ID()))
+
+	assert.Equal(t, team.ItemAbandoned, item.Status())
+	assert.Equal(t, []QueueChange{QueueChangeAbandoned}, f.changes())
+
+	// Sessões fora de fila são ignoradas
+	other := uuid.New()
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, other).Return(nil, team.ErrQueueItemNotFound)
+	require.NoError(t, f.useCase.HandleAgentAssigned(context.Background(), other, f.agents[0].ID()))
+	assert.Len(t, f.changes(), 1)
+}
+
+func TestQueueSessionsUseCase_DistributeWaiting(t *testing.T) {
+	f := newQueueFixture(t, project.StrategyRoundRobin, 2)
+	item := team.NewQueueItem(f.queue, f.session, time.Now().Add(-time.Minute))
+	f.queue.RecordAssignment(f.agents[0].ID())
+
+	f.itemRepo.On("FindWaitingBySession", mock.Anything, f.session.ID()).Return(item, nil)
+	f.routingRepo.On("GetWorkloads", mock.Anything, "tenant-123", mock.Anything).Return(map[uuid.UUID]routing.Workload{}, nil)
+	f.sessionRepo.On("Save", mock.Anything, f.session).Return(nil)
+	f.eventBus.On("Publish", mock.Anything, mock.Anything).Return(nil)
+	f.queueRepo.On("Save", mock.Anything, f.queue).Return(nil)
+	f.itemRepo.On("Save", mock.Anything, item).Return(nil)
+
+	done, err := f.useCase.DistributeWaiting(context.Background(), f.session.ID())
+	require.NoError(t, err)
+
+	assert.True(t, done)
+	assert.Equal(t, f.agents[1].ID(), *item.AgentID(), "round-robin continues after the cursor")
+}
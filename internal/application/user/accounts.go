@@ -0,0 +1,160 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/oidc"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/application/auth"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// dummyPasswordHash comparado quando o email não existe, para o tempo de resposta não revelar
+// quais emails têm conta
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy-password"), bcrypt.DefaultCost)
+
+// VerifyPassword valida email e senha (auth.AccountStore)
+func (s *UserService) VerifyPassword(ctx context.Context, email, password string) (*auth.Account, error) {
+	var user entities.UserEntity
+	err := s.db.WithContext(ctx).Where("email = ? AND status = 'active'", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		_ = bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return nil, auth.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, auth.ErrInvalidCredentials
+	}
+	return s.account(ctx, &user)
+}
+
+// FindAccount conta ativa pelo ID (auth.AccountStore)
+func (s *UserService) FindAccount(ctx context.Context, userID uuid.UUID) (*auth.Account, error) {
+	var user entities.UserEntity
+	err := s.db.WithContext(ctx).Where("id = ? AND status = 'active'", userID).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, auth.ErrAccountNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return s.account(ctx, &user)
+}
+
+// ResolveIdentity conta vinculada à identidade OIDC (auth.AccountStore). Ordem: vínculo existente
+// (provider, subject) → usuário com o mesmo email verificado → novo usuário, se autoProvision.
+// Email não verificado nunca vincula nem cria conta, para um provedor não assumir a conta de outro.
+func (s *UserService) ResolveIdentity(ctx context.Context, identity *oidc.Identity, autoProvision bool) (*auth.Account, error) {
+	db := s.db.WithContext(ctx)
+
+	var link entities.UserIdentityEntity
+	err := db.Where("provider = ? AND subject = ?", identity.Provider, identity.Subject).First(&link).Error
+	if err == nil {
+		now := time.Now().UTC()
+		db.Model(&link).Updates(map[string]interface{}{"email": identity.Email, "last_login_at": now})
+		return s.FindAccount(ctx, link.UserID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to load identity: %w", err)
+	}
+
+	if !identity.EmailVerified || identity.Email == "" {
+		return nil, auth.ErrAccountNotFound
+	}
+
+	var user entities.UserEntity
+	err = db.Where("LOWER(email) = ? AND status = 'active'", strings.ToLower(identity.Email)).First(&user).Error
+	switch {
+	case err == nil:
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		if !autoProvision {
+			return nil, auth.ErrAccountNotFound
+		}
+		created, err := s.provisionUser(identity)
+		if err != nil {
+			return nil, err
+		}
+		user.ID = created
+	default:
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+
+	if err := s.linkIdentity(ctx, user.ID, identity); err != nil {
+		return nil, err
+	}
+	return s.FindAccount(ctx, user.ID)
+}
+
+// provisionUser cria o usuário (com projeto e pipeline default) para a identidade externa. A senha
+// é aleatória e descartada: a conta só entra pelo provedor até alguém definir uma senha.
+func (s *UserService) provisionUser(identity *oidc.Identity) (uuid.UUID, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to generate password: %w", err)
+	}
+	name := identity.Name
+	if name == "" {
+		name = identity.Email
+	}
+
+	created, err := s.CreateUser(CreateUserRequest{
+		Name:     name,
+		Email:    identity.Email,
+		Password: hex.EncodeToString(secret),
+		Role:     "user",
+	})
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to provision user: %w", err)
+	}
+	return created.UserID, nil
+}
+
+func (s *UserService) linkIdentity(ctx context.Context, userID uuid.UUID, identity *oidc.Identity) error {
+	now := time.Now().UTC()
+	link := entities.UserIdentityEntity{
+		ID:          uuid.New(),
+		UserID:      userID,
+		Provider:    identity.Provider,
+		Issuer:      identity.Issuer,
+		Subject:     identity.Subject,
+		Email:       identity.Email,
+		LastLoginAt: &now,
+	}
+	// Dois callbacks simultâneos da mesma identidade: o segundo mantém o vínculo do primeiro
+	err := s.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "provider"}, {Name: "subject"}},
+		DoNothing: true,
+	}).Create(&link).Error
+	if err != nil {
+		return fmt.Errorf("failed to link identity: %w", err)
+	}
+	return nil
+}
+
+// account monta a conta com o projeto default do usuário (mesma regra do Login legado)
+func (s *UserService) account(ctx context.Context, user *entities.UserEntity) (*auth.Account, error) {
+	var project entities.ProjectEntity
+	if err := s.db.WithContext(ctx).Where("user_id = ? AND active = true", user.ID).Order("created_at").First(&project).Error; err != nil {
+		return nil, fmt.Errorf("no active project found for user %s", user.ID)
+	}
+	return &auth.Account{
+		UserID:    user.ID,
+		Email:     user.Email,
+		Name:      user.Name,
+		Role:      user.Role,
+		TenantID:  project.TenantID,
+		ProjectID: project.ID,
+	}, nil
+}
@@ -0,0 +1,46 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/infrastructure/persistence/entities"
+	projectapp "github.com/ventros/crm/internal/application/project"
+	"gorm.io/gorm"
+)
+
+// FindUsers nome e email dos usuários para a lista de membros (projectapp.UserDirectory)
+func (s *UserService) FindUsers(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]projectapp.UserSummary, error) {
+	users := make(map[uuid.UUID]projectapp.UserSummary, len(ids))
+	if len(ids) == 0 {
+		return users, nil
+	}
+
+	var rows []entities.UserEntity
+	if err := s.db.WithContext(ctx).Select("id", "name", "email").Where("id IN ?", ids).Find(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to load users: %w", err)
+	}
+	for _, row := range rows {
+		users[row.ID] = projectapp.UserSummary{ID: row.ID, Name: row.Name, Email: row.Email}
+	}
+	return users, nil
+}
+
+// FindUserByEmail usuário ativo com o email, para checar convites de quem já é membro
+// (projectapp.UserDirectory)
+func (s *UserService) FindUserByEmail(ctx context.Context, email string) (*projectapp.UserSummary, error) {
+	var row entities.UserEntity
+	err := s.db.WithContext(ctx).Select("id", "name", "email").
+		Where("LOWER(email) = ? AND status = 'active'", strings.ToLower(strings.TrimSpace(email))).
+		First(&row).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load user: %w", err)
+	}
+	return &projectapp.UserSummary{ID: row.ID, Name: row.Name, Email: row.Email}, nil
+}
@@ -4,16 +4,21 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/ventros/crm/infrastructure/persistence/entities"
+	"github.com/ventros/crm/internal/domain/core/twofactor"
 	"github.com/ventros/crm/internal/domain/core/user"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
+// ErrTwoFactorRequired conta com 2FA não usa o login legado por API key (POST /api/v1/auth/token)
+var ErrTwoFactorRequired = errors.New("two-factor authentication enabled: use POST /api/v1/auth/token")
+
 type UserService struct {
 	db *gorm.DB
 }
@@ -251,9 +256,18 @@ func (s *UserService) Login(req LoginRequest) (*LoginResponse, error) {
 		return nil, fmt.Errorf("invalid credentials")
 	}
 
+	// Com 2FA ativo a API key devolvida aqui contornaria o segundo fator
+	var twoFactor int64
+	if err := s.db.Model(&entities.UserTwoFactorEntity{}).Where("user_id = ? AND status = ?", user.ID, twofactor.StatusActive).Count(&twoFactor).Error; err != nil {
+		return nil, fmt.Errorf("failed to check two-factor: %w", err)
+	}
+	if twoFactor > 0 {
+		return nil, ErrTwoFactorRequired
+	}
+
 	// Busca API key ativa ou cria uma nova
 	var apiKeyEntity entities.UserAPIKeyEntity
-	err := s.db.Where("user_id = ? AND active = true", user.ID).First(&apiKeyEntity).Error
+	err := s.db.Where("user_id = ? AND active = true AND project_id IS NULL", user.ID).First(&apiKeyEntity).Error
 
 	var apiKey string
 	if err == gorm.ErrRecordNotFound {
@@ -296,7 +310,8 @@ func (s *UserService) ValidateAPIKey(apiKey string) (*entities.UserEntity, *enti
 	keyHash := hex.EncodeToString(hasher.Sum(nil))
 
 	var apiKeyEntity entities.UserAPIKeyEntity
-	if err := s.db.Where("key_hash = ? AND active = true", keyHash).First(&apiKeyEntity).Error; err != nil {
+	// Chaves por projeto (vtr_...) são validadas pelo pacote apikey, com permissões e allow-list
+	if err := s.db.Where("key_hash = ? AND active = true AND project_id IS NULL", keyHash).First(&apiKeyEntity).Error; err != nil {
 		return nil, nil, fmt.Errorf("invalid API key")
 	}
 
@@ -320,8 +335,8 @@ func (s *UserService) ValidateAPIKey(apiKey string) (*entities.UserEntity, *enti
 
 // generateAPIKey gera uma nova API key para o usuário
 func (s *UserService) generateAPIKey(tx *gorm.DB, userID uuid.UUID, name string) (string, error) {
-	// Desativa API keys existentes (apenas 1 ativa por usuário)
-	if err := tx.Model(&entities.UserAPIKeyEntity{}).Where("user_id = ?", userID).Update("active", false).Error; err != nil {
+	// Desativa API keys existentes (apenas 1 ativa por usuário; as chaves por projeto não são afetadas)
+	if err := tx.Model(&entities.UserAPIKeyEntity{}).Where("user_id = ? AND project_id IS NULL", userID).Update("active", false).Error; err != nil {
 		return "", fmt.Errorf("failed to deactivate existing keys: %w", err)
 	}
 
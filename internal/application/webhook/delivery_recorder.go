@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"go.uber.org/zap"
+)
+
+// ProjectOwner dono do projeto, avisado quando um webhook é desativado automaticamente
+type ProjectOwner struct {
+	Name  string
+	Email string
+}
+
+// ProjectOwnerDirectory resolve o dono de um projeto
+type ProjectOwnerDirectory interface {
+	ProjectOwner(ctx context.Context, projectID uuid.UUID) (*ProjectOwner, error)
+}
+
+// EmailSender envia emails de texto simples
+type EmailSender interface {
+	Send(ctx context.Context, to []string, subject, body string) error
+}
+
+// DeliveryRecorder registra cada tentativa de entrega no log e o resultado final de cada
+// notificação na inscrição, desativando-a após maxConsecutiveFailures falhas seguidas.
+// Falhas ao registrar são apenas logadas — nunca interrompem a entrega.
+type DeliveryRecorder struct {
+	repo                   webhook.Repository
+	deliveries             webhook.DeliveryRepository
+	owners                 ProjectOwnerDirectory
+	email                  EmailSender
+	maxConsecutiveFailures int
+	logger                 *zap.Logger
+}
+
+// NewDeliveryRecorder cria o recorder; owners e email são opcionais (sem eles a desativação
+// acontece, mas ninguém é avisado). maxConsecutiveFailures <= 0 desliga a desativação automática.
+func NewDeliveryRecorder(repo webhook.Repository, deliveries webhook.DeliveryRepository, owners ProjectOwnerDirectory, email EmailSender, maxConsecutiveFailures int, logger *zap.Logger) *DeliveryRecorder {
+	return &DeliveryRecorder{
+		repo:                   repo,
+		deliveries:             deliveries,
+		owners:                 owners,
+		email:                  email,
+		maxConsecutiveFailures: maxConsecutiveFailures,
+		logger:                 logger,
+	}
+}
+
+// Record grava uma tentativa no log de entregas
+func (r *DeliveryRecorder) Record(ctx context.Context, d *webhook.Delivery) {
+	if r == nil || d == nil {
+		return
+	}
+	if err := r.deliveries.Save(ctx, d); err != nil {
+		r.logger.Error("Failed to save webhook delivery",
+			zap.Error(err),
+			zap.String("subscription_id", d.SubscriptionID.String()),
+			zap.String("event_id", d.EventID.String()),
+			zap.Int("attempt", d.Attempt))
+	}
+}
+
+// RecordOutcome atualiza as estatísticas da inscrição com o resultado final da notificação
+// (após todos os retries) e, se for o caso, desativa a inscrição e avisa o dono do projeto
+func (r *DeliveryRecorder) RecordOutcome(ctx context.Context, sub *webhook.WebhookSubscription, success bool, lastError string) {
+	if r == nil {
+		return
+	}
+	if err := r.repo.RecordTrigger(ctx, sub.ID, success); err != nil {
+		r.logger.Error("Failed to record webhook trigger",
+			zap.Error(err),
+			zap.String("subscription_id", sub.ID.String()))
+		return
+	}
+	if success || r.maxConsecutiveFailures <= 0 {
+		return
+	}
+
+	disabled, err := r.repo.AutoDisable(ctx, sub.ID, r.maxConsecutiveFailures)
+	if err != nil {
+		r.logger.Error("Failed to auto-disable webhook subscription",
+			zap.Error(err),
+			zap.String("subscription_id", sub.ID.String()))
+		return
+	}
+	if !disabled {
+		return
+	}
+
+	r.logger.Warn("Webhook subscription disabled after consecutive failures",
+		zap.String("subscription_id", sub.ID.String()),
+		zap.String("url", sub.URL),
+		zap.Int("max_consecutive_failures", r.maxConsecutiveFailures))
+	r.notifyOwner(ctx, sub, lastError)
+}
+
+func (r *DeliveryRecorder) notifyOwner(ctx context.Context, sub *webhook.WebhookSubscription, lastError string) {
+	if r.owners == nil || r.email == nil {
+		return
+	}
+	owner, err := r.owners.ProjectOwner(ctx, sub.ProjectID)
+	if err != nil {
+		r.logger.Error("Failed to resolve project owner for webhook notification",
+			zap.Error(err),
+			zap.String("project_id", sub.ProjectID.String()))
+		return
+	}
+	if owner.Email == "" {
+		return
+	}
+
+	subject := fmt.Sprintf("Webhook \"%s\" foi desativado", sub.Name)
+	if err := r.email.Send(ctx, []string{owner.Email}, subject, disabledEmailBody(owner, sub, r.maxConsecutiveFailures, lastError)); err != nil {
+		r.logger.Error("Failed to send webhook disabled email",
+			zap.Error(err),
+			zap.String("subscription_id", sub.ID.String()))
+	}
+}
+
+func disabledEmailBody(owner *ProjectOwner, sub *webhook.WebhookSubscription, maxConsecutiveFailures int, lastError string) string {
+	var b strings.Builder
+	if owner.Name != "" {
+		fmt.Fprintf(&b, "Olá %s,\n\n", owner.Name)
+	}
+	fmt.Fprintf(&b, "O webhook \"%s\" (%s) foi desativado após %d entregas seguidas com falha.\n\n", sub.Name, sub.URL, maxConsecutiveFailures)
+	if lastError != "" {
+		fmt.Fprintf(&b, "Último erro: %s\n\n", lastError)
+	}
+	fmt.Fprintf(&b, "Inscrição: %s\n", sub.ID)
+	b.WriteString("Consulte o log de entregas, corrija o endpoint e reative o webhook. ")
+	b.WriteString("As notificações com falha podem ser reenviadas em lote.\n")
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"go.uber.org/zap"
+)
+
+func newSubscription(t *testing.T, tenantID string) *webhook.WebhookSubscription {
+	t.Helper()
+	sub, err := webhook.NewWebhookSubscription(uuid.New(), uuid.New(), tenantID, "N8N", "https://n8n.example.com/hook", []string{"contact.*"})
+	require.NoError(t, err)
+	return sub
+}
+
+func TestDeliveryRecorder_Record_IgnoresSaveError(t *testing.T) {
+	ctx := context.Background()
+	sub := newSubscription(t, "tenant-1")
+	deliveries := new(MockDeliveryRepository)
+	d := webhook.NewDelivery(sub, uuid.New(), "contact.created", webhook.DeliveryTriggerEvent, 1, http.Header{}, []byte(`{}`))
+	deliveries.On("Save", ctx, d).Return(errors.New("db down")).Once()
+
+	recorder := NewDeliveryRecorder(new(MockWebhookRepository), deliveries, nil, nil, 20, zap.NewNop())
+
+	assert.NotPanics(t, func() { recorder.Record(ctx, d) })
+	deliveries.AssertExpectations(t)
+}
+
+func TestDeliveryRecorder_RecordOutcome(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("success never disables", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("RecordTrigger", ctx, sub.ID, true).Return(nil).Once()
+
+		NewDeliveryRecorder(repo, new(MockDeliveryRepository), nil, nil, 3, zap.NewNop()).RecordOutcome(ctx, sub, true, "")
+
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "AutoDisable", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failure below threshold", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("RecordTrigger", ctx, sub.ID, false).Return(nil).Once()
+		repo.On("AutoDisable", ctx, sub.ID, 3).Return(false, nil).Once()
+		emailSender := new(MockEmailSender)
+
+		NewDeliveryRecorder(repo, new(MockDeliveryRepository), new(MockOwnerDirectory), emailSender, 3, zap.NewNop()).RecordOutcome(ctx, sub, false, "timeout")
+
+		repo.AssertExpectations(t)
+		emailSender.AssertNotCalled(t, "Send", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("disabled notifies project owner", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("RecordTrigger", ctx, sub.ID, false).Return(nil).Once()
+		repo.On("AutoDisable", ctx, sub.ID, 3).Return(true, nil).Once()
+		owners := new(MockOwnerDirectory)
+		owners.On("ProjectOwner", ctx, sub.ProjectID).Return(&ProjectOwner{Name: "Ana", Email: "ana@loja.com"}, nil)
+		emailSender := new(MockEmailSender)
+		emailSender.On("Send", ctx, []string{"ana@loja.com"}, `Webhook "N8N" foi desativado`, mock.MatchedBy(func(body string) bool {
+			return strings.Contains(body, "Olá Ana") &&
+				strings.Contains(body, "3 entregas seguidas") &&
+				strings.Contains(body, "endpoint returned status 502")
+		})).Return(nil).Once()
+
+		NewDeliveryRecorder(repo, new(MockDeliveryRepository), owners, emailSender, 3, zap.NewNop()).RecordOutcome(ctx, sub, false, "endpoint returned status 502")
+
+		repo.AssertExpectations(t)
+		emailSender.AssertExpectations(t)
+	})
+
+	t.Run("threshold zero disables the rule", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("RecordTrigger", ctx, sub.ID, false).Return(nil).Once()
+
+		NewDeliveryRecorder(repo, new(MockDeliveryRepository), nil, nil, 0, zap.NewNop()).RecordOutcome(ctx, sub, false, "timeout")
+
+		repo.AssertNotCalled(t, "AutoDisable", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -53,6 +53,18 @@ type WebhookDTO struct {
 	FailureCount    int               `json:"failure_count" example:"2"`
 	CreatedAt       time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
 	UpdatedAt       time.Time         `json:"updated_at" example:"2024-01-01T00:00:00Z"`
+
+	ConsecutiveFailures int        `json:"consecutive_failures" example:"0"`
+	DisabledAt          *time.Time `json:"disabled_at,omitempty" example:"2024-01-01T00:00:00Z"`
+	DisabledReason      string     `json:"disabled_reason,omitempty" example:"disabled after 20 consecutive failed deliveries"`
+
+	SecretRotationEndsAt *time.Time `json:"secret_rotation_ends_at,omitempty" example:"2024-01-02T00:00:00Z"`
+}
+
+// SecretRotationDTO resultado da rotação de segredo (o segredo só é exibido aqui)
+type SecretRotationDTO struct {
+	Secret                  string     `json:"secret" example:"whsec_3f9a..."`
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty" example:"2024-01-02T00:00:00Z"`
 }
 
 // ToDTO converte entidade de domínio para DTO
@@ -76,7 +88,21 @@ func ToDTO(w *webhook.WebhookSubscription) WebhookDTO {
 		FailureCount:    w.FailureCount,
 		CreatedAt:       w.CreatedAt,
 		UpdatedAt:       w.UpdatedAt,
+
+		ConsecutiveFailures: w.ConsecutiveFailures,
+		DisabledAt:          w.DisabledAt,
+		DisabledReason:      w.DisabledReason,
+
+		SecretRotationEndsAt: secretRotationEndsAt(w),
+	}
+}
+
+// secretRotationEndsAt fim da janela de rotação em andamento (nil quando não há rotação ativa)
+func secretRotationEndsAt(w *webhook.WebhookSubscription) *time.Time {
+	if len(w.SigningSecrets(time.Now())) < 2 {
+		return nil
 	}
+	return w.PreviousSecretExpiresAt
 }
 
 // ToDTOList converte lista de entidades para DTOs
@@ -87,3 +113,54 @@ func ToDTOList(webhooks []*webhook.WebhookSubscription) []WebhookDTO {
 	}
 	return dtos
 }
+
+// DeliveryDTO representa uma tentativa de entrega de webhook
+type DeliveryDTO struct {
+	ID             uuid.UUID         `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	SubscriptionID uuid.UUID         `json:"subscription_id" example:"550e8400-e29b-41d4-a716-446655440001"`
+	EventID        uuid.UUID         `json:"event_id" example:"550e8400-e29b-41d4-a716-446655440002"`
+	EventType      string            `json:"event_type" example:"contact.created"`
+	Trigger        string            `json:"trigger" example:"event"`
+	ReplayOf       *uuid.UUID        `json:"replay_of,omitempty"`
+	Attempt        int               `json:"attempt" example:"1"`
+	RequestURL     string            `json:"request_url" example:"https://n8n.example.com/webhook/waha-events"`
+	RequestHeaders map[string]string `json:"request_headers" swaggertype:"object"`
+	RequestBody    string            `json:"request_body"`
+	StatusCode     int               `json:"status_code" example:"500"`
+	ResponseBody   string            `json:"response_body"`
+	LatencyMs      int64             `json:"latency_ms" example:"230"`
+	Error          string            `json:"error,omitempty" example:"endpoint returned status 500"`
+	Success        bool              `json:"success" example:"false"`
+	CreatedAt      time.Time         `json:"created_at" example:"2024-01-01T00:00:00Z"`
+}
+
+// ToDeliveryDTO converte uma entrega para DTO
+func ToDeliveryDTO(d *webhook.Delivery) DeliveryDTO {
+	return DeliveryDTO{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Trigger:        string(d.Trigger),
+		ReplayOf:       d.ReplayOf,
+		Attempt:        d.Attempt,
+		RequestURL:     d.RequestURL,
+		RequestHeaders: d.RequestHeaders,
+		RequestBody:    d.RequestBody,
+		StatusCode:     d.StatusCode,
+		ResponseBody:   d.ResponseBody,
+		LatencyMs:      d.Latency.Milliseconds(),
+		Error:          d.Error,
+		Success:        d.Success,
+		CreatedAt:      d.CreatedAt,
+	}
+}
+
+// ToDeliveryDTOList converte lista de entregas para DTOs
+func ToDeliveryDTOList(deliveries []*webhook.Delivery) []DeliveryDTO {
+	dtos := make([]DeliveryDTO, len(deliveries))
+	for i, d := range deliveries {
+		dtos[i] = ToDeliveryDTO(d)
+	}
+	return dtos
+}
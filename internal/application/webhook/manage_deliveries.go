@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"go.uber.org/zap"
+)
+
+// MaxBulkRedeliveries limite de notificações reenviadas por chamada de RedeliverFailed
+const MaxBulkRedeliveries = 500
+
+var ErrInvalidRedeliverSince = errors.New("since must be in the past")
+
+// Redeliverer reenvia uma entrega registrada para o endpoint atual da inscrição,
+// registrando a nova tentativa no log
+type Redeliverer interface {
+	Redeliver(ctx context.Context, sub *webhook.WebhookSubscription, original *webhook.Delivery) *webhook.Delivery
+}
+
+// ManageDeliveriesUseCase consulta o log de entregas e reenvia notificações
+type ManageDeliveriesUseCase struct {
+	repo        webhook.Repository
+	deliveries  webhook.DeliveryRepository
+	redeliverer Redeliverer
+	logger      *zap.Logger
+}
+
+// NewManageDeliveriesUseCase cria um novo use case
+func NewManageDeliveriesUseCase(repo webhook.Repository, deliveries webhook.DeliveryRepository, redeliverer Redeliverer, logger *zap.Logger) *ManageDeliveriesUseCase {
+	return &ManageDeliveriesUseCase{
+		repo:        repo,
+		deliveries:  deliveries,
+		redeliverer: redeliverer,
+		logger:      logger,
+	}
+}
+
+// ListDeliveries lista as tentativas de entrega da inscrição, mais recentes primeiro
+func (uc *ManageDeliveriesUseCase) ListDeliveries(ctx context.Context, tenantID string, filter webhook.DeliveryFilter) ([]DeliveryDTO, int64, error) {
+	if _, err := uc.subscription(ctx, tenantID, filter.SubscriptionID); err != nil {
+		return nil, 0, err
+	}
+
+	deliveries, total, err := uc.deliveries.List(ctx, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	return ToDeliveryDTOList(deliveries), total, nil
+}
+
+// GetDelivery detalha uma tentativa de entrega (request e response)
+func (uc *ManageDeliveriesUseCase) GetDelivery(ctx context.Context, tenantID string, subscriptionID, deliveryID uuid.UUID) (*DeliveryDTO, error) {
+	_, delivery, err := uc.load(ctx, tenantID, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	result := ToDeliveryDTO(delivery)
+	return &result, nil
+}
+
+// Replay reenvia manualmente uma entrega e retorna a nova tentativa
+func (uc *ManageDeliveriesUseCase) Replay(ctx context.Context, tenantID string, subscriptionID, deliveryID uuid.UUID) (*DeliveryDTO, error) {
+	sub, original, err := uc.load(ctx, tenantID, subscriptionID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	replay := uc.redeliverer.Redeliver(ctx, sub, original)
+	uc.logger.Info("Webhook delivery replayed",
+		zap.String("subscription_id", sub.ID.String()),
+		zap.String("delivery_id", original.ID.String()),
+		zap.String("event_id", original.EventID.String()),
+		zap.Bool("success", replay.Success))
+
+	result := ToDeliveryDTO(replay)
+	return &result, nil
+}
+
+// RedeliverFailed reenvia, em segundo plano e em ordem cronológica, as notificações desde since
+// que nunca foram entregues com sucesso. Retorna quantas foram enfileiradas (até MaxBulkRedeliveries).
+func (uc *ManageDeliveriesUseCase) RedeliverFailed(ctx context.Context, tenantID string, subscriptionID uuid.UUID, since time.Time) (int, error) {
+	if !since.Before(time.Now()) {
+		return 0, ErrInvalidRedeliverSince
+	}
+	sub, err := uc.subscription(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return 0, err
+	}
+
+	failed, err := uc.deliveries.FindFailedSince(ctx, sub.ID, since, MaxBulkRedeliveries)
+	if err != nil {
+		return 0, fmt.Errorf("failed to find failed webhook deliveries: %w", err)
+	}
+	if len(failed) == 0 {
+		return 0, nil
+	}
+
+	go uc.redeliverAll(sub, failed)
+
+	uc.logger.Info("Webhook bulk redelivery started",
+		zap.String("subscription_id", sub.ID.String()),
+		zap.Time("since", since),
+		zap.Int("deliveries", len(failed)))
+	return len(failed), nil
+}
+
+func (uc *ManageDeliveriesUseCase) redeliverAll(sub *webhook.WebhookSubscription, failed []*webhook.Delivery) {
+	ctx := context.Background()
+	succeeded := 0
+	for _, original := range failed {
+		if uc.redeliverer.Redeliver(ctx, sub, original).Success {
+			succeeded++
+		}
+	}
+	uc.logger.Info("Webhook bulk redelivery finished",
+		zap.String("subscription_id", sub.ID.String()),
+		zap.Int("deliveries", len(failed)),
+		zap.Int("succeeded", succeeded))
+}
+
+// subscription carrega a inscrição garantindo que pertence ao tenant
+func (uc *ManageDeliveriesUseCase) subscription(ctx context.Context, tenantID string, id uuid.UUID) (*webhook.WebhookSubscription, error) {
+	sub, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.TenantID != tenantID {
+		return nil, webhook.ErrNotFound
+	}
+	return sub, nil
+}
+
+func (uc *ManageDeliveriesUseCase) load(ctx context.Context, tenantID string, subscriptionID, deliveryID uuid.UUID) (*webhook.WebhookSubscription, *webhook.Delivery, error) {
+	sub, err := uc.subscription(ctx, tenantID, subscriptionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	delivery, err := uc.deliveries.FindByID(ctx, deliveryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if delivery.SubscriptionID != sub.ID {
+		return nil, nil, webhook.ErrDeliveryNotFound
+	}
+	return sub, delivery, nil
+}
@@ -0,0 +1,110 @@
+package webhook
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"go.uber.org/zap"
+)
+
+func failedDelivery(sub *webhook.WebhookSubscription) *webhook.Delivery {
+	d := webhook.NewDelivery(sub, uuid.New(), "contact.created", webhook.DeliveryTriggerEvent, 3, http.Header{}, []byte(`{"event":"contact.created"}`))
+	d.RecordResponse(http.StatusBadGateway, []byte("bad gateway"), 120*time.Millisecond)
+	return d
+}
+
+func TestManageDeliveries_EnforcesTenantAndSubscription(t *testing.T) {
+	ctx := context.Background()
+	sub := newSubscription(t, "tenant-1")
+	other := newSubscription(t, "tenant-1")
+	repo := new(MockWebhookRepository)
+	repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+	deliveries := new(MockDeliveryRepository)
+	foreign := failedDelivery(other)
+	deliveries.On("FindByID", ctx, foreign.ID).Return(foreign, nil)
+	redeliverer := new(MockRedeliverer)
+
+	uc := NewManageDeliveriesUseCase(repo, deliveries, redeliverer, zap.NewNop())
+
+	_, _, err := uc.ListDeliveries(ctx, "tenant-2", webhook.DeliveryFilter{SubscriptionID: sub.ID})
+	assert.ErrorIs(t, err, webhook.ErrNotFound)
+
+	_, err = uc.GetDelivery(ctx, "tenant-1", sub.ID, foreign.ID)
+	assert.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+
+	_, err = uc.Replay(ctx, "tenant-1", sub.ID, foreign.ID)
+	assert.ErrorIs(t, err, webhook.ErrDeliveryNotFound)
+
+	deliveries.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	redeliverer.AssertNotCalled(t, "Redeliver", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestManageDeliveries_Replay(t *testing.T) {
+	ctx := context.Background()
+	sub := newSubscription(t, "tenant-1")
+	original := failedDelivery(sub)
+	replay := webhook.NewReplay(sub, original, http.Header{})
+	replay.RecordResponse(http.StatusOK, []byte("ok"), 80*time.Millisecond)
+
+	repo := new(MockWebhookRepository)
+	repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+	deliveries := new(MockDeliveryRepository)
+	deliveries.On("FindByID", ctx, original.ID).Return(original, nil)
+	redeliverer := new(MockRedeliverer)
+	redeliverer.On("Redeliver", ctx, sub, original).Return(replay).Once()
+
+	result, err := NewManageDeliveriesUseCase(repo, deliveries, redeliverer, zap.NewNop()).Replay(ctx, "tenant-1", sub.ID, original.ID)
+
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+	assert.Equal(t, "replay", result.Trigger)
+	assert.Equal(t, &original.ID, result.ReplayOf)
+	assert.Equal(t, original.EventID, result.EventID)
+	redeliverer.AssertExpectations(t)
+}
+
+func TestManageDeliveries_RedeliverFailed(t *testing.T) {
+	ctx := context.Background()
+	sub := newSubscription(t, "tenant-1")
+	repo := new(MockWebhookRepository)
+	repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+
+	t.Run("since in the future", func(t *testing.T) {
+		uc := NewManageDeliveriesUseCase(repo, new(MockDeliveryRepository), new(MockRedeliverer), zap.NewNop())
+		_, err := uc.RedeliverFailed(ctx, "tenant-1", sub.ID, time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidRedeliverSince)
+	})
+
+	t.Run("redelivers in background", func(t *testing.T) {
+		since := time.Now().Add(-24 * time.Hour)
+		failed := []*webhook.Delivery{failedDelivery(sub), failedDelivery(sub)}
+		deliveries := new(MockDeliveryRepository)
+		deliveries.On("FindFailedSince", ctx, sub.ID, since, MaxBulkRedeliveries).Return(failed, nil)
+
+		done := make(chan struct{}, len(failed))
+		redeliverer := new(MockRedeliverer)
+		redeliverer.On("Redeliver", mock.Anything, sub, mock.Anything).
+			Return(webhook.NewReplay(sub, failed[0], http.Header{})).
+			Run(func(mock.Arguments) { done <- struct{}{} })
+
+		queued, err := NewManageDeliveriesUseCase(repo, deliveries, redeliverer, zap.NewNop()).RedeliverFailed(ctx, "tenant-1", sub.ID, since)
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, queued)
+		for range failed {
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("redelivery did not run")
+			}
+		}
+		redeliverer.AssertNumberOfCalls(t, "Redeliver", 2)
+	})
+}
@@ -3,8 +3,10 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
+	auditapp "github.com/ventros/crm/internal/application/audit"
 	"github.com/ventros/crm/internal/domain/crm/webhook"
 	"go.uber.org/zap"
 )
@@ -98,6 +100,7 @@ func (uc *ManageSubscriptionUseCase) UpdateWebhook(ctx context.Context, id uuid.
 	if err != nil {
 		return nil, err
 	}
+	before := ToDTO(sub)
 
 	// Aplica updates
 	if dto.Name != nil {
@@ -147,6 +150,7 @@ func (uc *ManageSubscriptionUseCase) UpdateWebhook(ctx context.Context, id uuid.
 	)
 
 	result := ToDTO(sub)
+	auditapp.Annotate(ctx, "webhook-subscriptions", id.String(), before, result)
 	return &result, nil
 }
 
@@ -170,6 +174,46 @@ func (uc *ManageSubscriptionUseCase) DeleteWebhook(ctx context.Context, id uuid.
 	return nil
 }
 
+// RotateSecret troca o segredo de assinatura da inscrição. Sem newSecret um segredo aleatório é gerado.
+// O segredo anterior continua assinando as entregas por overlap (janela de rotação).
+// O novo segredo só é devolvido nesta resposta.
+func (uc *ManageSubscriptionUseCase) RotateSecret(ctx context.Context, tenantID string, id uuid.UUID, newSecret string, overlap time.Duration) (*SecretRotationDTO, error) {
+	sub, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if sub.TenantID != tenantID {
+		return nil, webhook.ErrNotFound
+	}
+
+	if newSecret == "" {
+		if newSecret, err = webhook.GenerateSecret(); err != nil {
+			return nil, err
+		}
+	}
+	if err := sub.RotateSecret(newSecret, overlap); err != nil {
+		return nil, err
+	}
+
+	if err := uc.repo.Update(ctx, sub); err != nil {
+		uc.logger.Error("Failed to rotate webhook secret",
+			zap.Error(err),
+			zap.String("id", id.String()),
+		)
+		return nil, fmt.Errorf("failed to rotate webhook secret: %w", err)
+	}
+
+	uc.logger.Info("Webhook secret rotated",
+		zap.String("id", sub.ID.String()),
+		zap.Duration("overlap", overlap),
+	)
+
+	return &SecretRotationDTO{
+		Secret:                  sub.Secret,
+		PreviousSecretExpiresAt: sub.PreviousSecretExpiresAt,
+	}, nil
+}
+
 // GetAvailableEvents retorna eventos disponíveis (WAHA + Domínio/Aplicação)
 func (uc *ManageSubscriptionUseCase) GetAvailableEvents() map[string]interface{} {
 	return map[string]interface{}{
@@ -188,22 +232,57 @@ func (uc *ManageSubscriptionUseCase) GetAvailableEvents() map[string]interface{}
 		"domain_sessions": map[string]interface{}{
 			"wildcard": "session.*", // Subscreve todos os eventos de sessão
 			"events": []string{
-				"session.created",        // Nova sessão criada (alias: session.started)
-				"session.closed",         // Sessão encerrada (alias: session.ended)
-				"session.agent_assigned", // Agente atribuído
-				"session.resolved",       // Sessão resolvida
-				"session.escalated",      // Sessão escalada
-				"session.summarized",     // Resumo gerado por IA
-				"session.abandoned",      // Sessão abandonada
+				"session.created",          // Nova sessão criada (alias: session.started)
+				"session.closed",           // Sessão encerrada (alias: session.ended)
+				"session.agent_assigned",   // Agente atribuído
+				"session.resolved",         // Sessão resolvida
+				"session.escalated",        // Sessão escalada
+				"session.summarized",       // Resumo gerado por IA
+				"session.abandoned",        // Sessão abandonada
+				"session.priority_changed", // Prioridade alterada
+			},
+		},
+		"domain_sla": map[string]interface{}{
+			"wildcard": "sla.*", // Subscreve todos os eventos de SLA
+			"events": []string{
+				"sla.warning",  // Prazo de SLA próximo do vencimento
+				"sla.breached", // Prazo de SLA vencido
 			},
 		},
 		"domain_notes": map[string]interface{}{
 			"wildcard": "note.*", // Subscreve todos os eventos de nota
 			"events": []string{
-				"note.added",   // Nota adicionada ao contato
-				"note.updated", // Nota atualizada
-				"note.deleted", // Nota deletada
-				"note.pinned",  // Nota fixada
+				"note.added",    // Nota adicionada ao contato
+				"note.updated",  // Nota atualizada
+				"note.deleted",  // Nota deletada
+				"note.pinned",   // Nota fixada
+				"note.unpinned", // Nota desafixada
+			},
+		},
+		"domain_tasks": map[string]interface{}{
+			"wildcard": "task.*", // Subscreve todos os eventos de tarefa
+			"events": []string{
+				"task.created",   // Tarefa criada (inclusive próxima ocorrência de recorrente)
+				"task.updated",   // Tarefa editada
+				"task.completed", // Tarefa concluída
+				"task.cancelled", // Tarefa cancelada
+				"task.overdue",   // Prazo da tarefa venceu sem conclusão
+				"task.deleted",   // Tarefa removida
+			},
+		},
+		"domain_canned_responses": map[string]interface{}{
+			"wildcard": "canned_response.*", // Subscreve todos os eventos de resposta pronta
+			"events": []string{
+				"canned_response.created", // Resposta pronta criada
+				"canned_response.updated", // Resposta pronta editada (inclusive anexos)
+				"canned_response.deleted", // Resposta pronta removida
+			},
+		},
+		"domain_api_keys": map[string]interface{}{
+			"wildcard": "api_key.*", // Subscreve todos os eventos de API key
+			"events": []string{
+				"api_key.created", // API key criada (sem o token)
+				"api_key.revoked", // API key revogada
 			},
 		},
 		"domain_tracking": map[string]interface{}{
@@ -228,13 +307,25 @@ func (uc *ManageSubscriptionUseCase) GetAvailableEvents() map[string]interface{}
 				"contact.exited_pipeline",  // Contato saiu do pipeline
 			},
 		},
+		"domain_contact_lists": map[string]interface{}{
+			"wildcard": "contact_list.*", // Subscreve todos os eventos de listas de contatos
+			"events": []string{
+				"contact_list.created",      // Lista criada
+				"contact_list.updated",      // Lista atualizada
+				"contact_list.deleted",      // Lista deletada
+				"contact_list.recalculated", // Contagem da lista recalculada
+				"contact_list.list_joined",  // Contato entrou na lista
+				"contact_list.list_left",    // Contato saiu da lista
+			},
+		},
 		"domain_agents": map[string]interface{}{
 			"wildcard": "agent.*", // Subscreve todos os eventos de agente
 			"events": []string{
-				"agent.created",     // Agente criado
-				"agent.updated",     // Agente atualizado
-				"agent.activated",   // Agente ativado
-				"agent.deactivated", // Agente desativado
+				"agent.created",        // Agente criado
+				"agent.updated",        // Agente atualizado
+				"agent.activated",      // Agente ativado
+				"agent.deactivated",    // Agente desativado
+				"agent.status_changed", // Presença do agente mudou (available, busy, away, offline)
 			},
 		},
 		"domain_channels": map[string]interface{}{
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+	"go.uber.org/zap"
+)
+
+func TestManageSubscription_RotateSecret(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("generates secret and keeps the old one during the window", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		sub.SetSecret("whsec_original_secret")
+		repo := new(MockWebhookRepository)
+		repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+		repo.On("Update", ctx, sub).Return(nil).Once()
+
+		result, err := NewManageSubscriptionUseCase(repo, zap.NewNop()).RotateSecret(ctx, "tenant-1", sub.ID, "", 24*time.Hour)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(result.Secret, "whsec_"))
+		require.NotNil(t, result.PreviousSecretExpiresAt)
+		assert.Equal(t, []string{result.Secret, "whsec_original_secret"}, sub.SigningSecrets(time.Now()))
+		assert.Equal(t, result.PreviousSecretExpiresAt, ToDTO(sub).SecretRotationEndsAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("other tenant", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+
+		_, err := NewManageSubscriptionUseCase(repo, zap.NewNop()).RotateSecret(ctx, "tenant-2", sub.ID, "", time.Hour)
+
+		assert.ErrorIs(t, err, webhook.ErrNotFound)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid secret or overlap", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+		uc := NewManageSubscriptionUseCase(repo, zap.NewNop())
+
+		_, err := uc.RotateSecret(ctx, "tenant-1", sub.ID, "curto", time.Hour)
+		assert.ErrorIs(t, err, webhook.ErrInvalidSecret)
+
+		_, err = uc.RotateSecret(ctx, "tenant-1", sub.ID, "", 30*24*time.Hour)
+		assert.ErrorIs(t, err, webhook.ErrInvalidRotationOverlap)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("save failure", func(t *testing.T) {
+		sub := newSubscription(t, "tenant-1")
+		repo := new(MockWebhookRepository)
+		repo.On("FindByID", ctx, sub.ID).Return(sub, nil)
+		repo.On("Update", ctx, sub).Return(errors.New("db down"))
+
+		_, err := NewManageSubscriptionUseCase(repo, zap.NewNop()).RotateSecret(ctx, "tenant-1", sub.ID, "whsec_explicit_secret", 0)
+
+		assert.Error(t, err)
+	})
+}
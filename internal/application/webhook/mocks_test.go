@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/ventros/crm/internal/domain/crm/webhook"
+)
+
+type MockWebhookRepository struct {
+	mock.Mock
+}
+
+func (m *MockWebhookRepository) Create(ctx context.Context, w *webhook.WebhookSubscription) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) FindByID(ctx context.Context, id uuid.UUID) (*webhook.WebhookSubscription, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindAll(ctx context.Context) ([]*webhook.WebhookSubscription, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindActiveByEvent(ctx context.Context, eventType string) ([]*webhook.WebhookSubscription, error) {
+	args := m.Called(ctx, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) FindByActive(ctx context.Context, active bool) ([]*webhook.WebhookSubscription, error) {
+	args := m.Called(ctx, active)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.WebhookSubscription), args.Error(1)
+}
+
+func (m *MockWebhookRepository) Update(ctx context.Context, w *webhook.WebhookSubscription) error {
+	args := m.Called(ctx, w)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) RecordTrigger(ctx context.Context, id uuid.UUID, success bool) error {
+	args := m.Called(ctx, id, success)
+	return args.Error(0)
+}
+
+func (m *MockWebhookRepository) AutoDisable(ctx context.Context, id uuid.UUID, maxConsecutiveFailures int) (bool, error) {
+	args := m.Called(ctx, id, maxConsecutiveFailures)
+	return args.Bool(0), args.Error(1)
+}
+
+type MockDeliveryRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeliveryRepository) Save(ctx context.Context, d *webhook.Delivery) error {
+	args := m.Called(ctx, d)
+	return args.Error(0)
+}
+
+func (m *MockDeliveryRepository) FindByID(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Delivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) List(ctx context.Context, filter webhook.DeliveryFilter) ([]*webhook.Delivery, int64, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*webhook.Delivery), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockDeliveryRepository) FindFailedSince(ctx context.Context, subscriptionID uuid.UUID, since time.Time, limit int) ([]*webhook.Delivery, error) {
+	args := m.Called(ctx, subscriptionID, since, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.Delivery), args.Error(1)
+}
+
+func (m *MockDeliveryRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+type MockOwnerDirectory struct {
+	mock.Mock
+}
+
+func (m *MockOwnerDirectory) ProjectOwner(ctx context.Context, projectID uuid.UUID) (*ProjectOwner, error) {
+	args := m.Called(ctx, projectID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*ProjectOwner), args.Error(1)
+}
+
+type MockEmailSender struct {
+	mock.Mock
+}
+
+func (m *MockEmailSender) Send(ctx context.Context, to []string, subject, body string) error {
+	args := m.Called(ctx, to, subject, body)
+	return args.Error(0)
+}
+
+type MockRedeliverer struct {
+	mock.Mock
+}
+
+func (m *MockRedeliverer) Redeliver(ctx context.Context, sub *webhook.WebhookSubscription, original *webhook.Delivery) *webhook.Delivery {
+	args := m.Called(ctx, sub, original)
+	return args.Get(0).(*webhook.Delivery)
+}
@@ -174,7 +174,7 @@ func (b *Broadcast) Start() error {
 	b.startedAt = &now
 	b.updatedAt = now
 
-	b.addEvent(NewBroadcastStartedEvent(b.id))
+	b.addEvent(NewBroadcastStartedEvent(b.id, b.tenantID))
 
 	return nil
 }
@@ -45,12 +45,14 @@ func NewBroadcastScheduledEvent(broadcastID uuid.UUID, scheduledFor time.Time) B
 type BroadcastStartedEvent struct {
 	shared.BaseEvent
 	BroadcastID uuid.UUID
+	TenantID    string
 }
 
-func NewBroadcastStartedEvent(broadcastID uuid.UUID) BroadcastStartedEvent {
+func NewBroadcastStartedEvent(broadcastID uuid.UUID, tenantID string) BroadcastStartedEvent {
 	return BroadcastStartedEvent{
 		BaseEvent:   shared.NewBaseEvent("broadcast.started", time.Now()),
 		BroadcastID: broadcastID,
+		TenantID:    tenantID,
 	}
 }
 
@@ -320,6 +320,18 @@ type SequenceStats struct {
 	CompletionRate float64 `json:"completion_rate"`
 }
 
+// TriggerDataContactListID is the trigger data key holding the list watched by list_joined sequences
+const TriggerDataContactListID = "contact_list_id"
+
+// IsTriggeredByListJoin reports whether joining the given contact list should enroll contacts
+func (s *Sequence) IsTriggeredByListJoin(contactListID uuid.UUID) bool {
+	if s.triggerType != TriggerTypeListJoined || s.status != SequenceStatusActive {
+		return false
+	}
+	listID, ok := s.triggerData[TriggerDataContactListID].(string)
+	return ok && listID == contactListID.String()
+}
+
 // GetStepByOrder returns a step by its order
 func (s *Sequence) GetStepByOrder(order int) (*SequenceStep, error) {
 	for _, step := range s.steps {
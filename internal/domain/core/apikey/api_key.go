@@ -0,0 +1,358 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+var (
+	ErrAPIKeyNotFound    = errors.New("api key not found")
+	ErrInvalidTenant     = errors.New("tenantID cannot be empty")
+	ErrInvalidProject    = errors.New("projectID cannot be nil")
+	ErrInvalidUser       = errors.New("userID cannot be nil")
+	ErrEmptyName         = errors.New("name cannot be empty")
+	ErrNameTooLong       = errors.New("name exceeds 100 characters")
+	ErrNoPermissions     = errors.New("api key needs at least one permission")
+	ErrInvalidPermission = errors.New("invalid permission")
+	ErrInvalidAllowedIP  = errors.New("invalid allowed IP: use an IP address or CIDR")
+	ErrTooManyAllowedIPs = errors.New("api key accepts at most 50 allowed IPs")
+	ErrExpiryInPast      = errors.New("expiresAt must be in the future")
+	ErrAlreadyRevoked    = errors.New("api key already revoked")
+	ErrAPIKeyRevoked     = errors.New("api key revoked")
+	ErrAPIKeyExpired     = errors.New("api key expired")
+	ErrIPNotAllowed      = errors.New("request IP not allowed for this api key")
+)
+
+type DomainEvent = shared.DomainEvent
+
+const (
+	// TokenPrefix identifica as chaves novas no header Authorization (as legadas são hex puro)
+	TokenPrefix = "vtr_"
+
+	// DisplayPrefixLength caracteres do token guardados em claro para o usuário reconhecer a chave
+	DisplayPrefixLength = 12
+
+	// MaxAllowedIPs entradas na allow-list
+	MaxAllowedIPs = 50
+
+	maxNameLength = 100
+	secretBytes   = 32
+)
+
+// Details dados informados na criação da chave
+type Details struct {
+	Name        string
+	Permissions []project_member.Permission
+	// AllowedIPs IPs ou CIDRs de onde a chave pode ser usada (vazio = qualquer origem)
+	AllowedIPs []string
+	// ExpiresAt nil = não expira
+	ExpiresAt *time.Time
+}
+
+// APIKey chave de acesso à API de um projeto. O token só existe em claro no momento da
+// criação; persistimos o SHA-256 e um prefixo curto para exibição.
+type APIKey struct {
+	id          uuid.UUID
+	tenantID    string
+	projectID   uuid.UUID
+	userID      uuid.UUID
+	name        string
+	prefix      string
+	keyHash     string
+	permissions []project_member.Permission
+	allowedIPs  []string
+	expiresAt   *time.Time
+	lastUsedAt  *time.Time
+	lastUsedIP  string
+	revokedAt   *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+
+	events []DomainEvent
+}
+
+// NewAPIKey cria a chave e devolve o token em claro, que deve ser mostrado uma única vez
+func NewAPIKey(tenantID string, projectID, userID uuid.UUID, details Details) (*APIKey, string, error) {
+	if tenantID == "" {
+		return nil, "", ErrInvalidTenant
+	}
+	if projectID == uuid.Nil {
+		return nil, "", ErrInvalidProject
+	}
+	if userID == uuid.Nil {
+		return nil, "", ErrInvalidUser
+	}
+	details, err := normalizeDetails(details, time.Now())
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := GenerateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now().UTC()
+	k := &APIKey{
+		id:          uuid.New(),
+		tenantID:    tenantID,
+		projectID:   projectID,
+		userID:      userID,
+		name:        details.Name,
+		prefix:      token[:DisplayPrefixLength],
+		keyHash:     HashToken(token),
+		permissions: details.Permissions,
+		allowedIPs:  details.AllowedIPs,
+		expiresAt:   details.ExpiresAt,
+		createdAt:   now,
+		updatedAt:   now,
+		events:      []DomainEvent{},
+	}
+	k.addEvent(NewAPIKeyCreatedEvent(k))
+	return k, token, nil
+}
+
+// ReconstructAPIKey reconstrói a chave a partir da persistência
+func ReconstructAPIKey(
+	id uuid.UUID,
+	tenantID string,
+	projectID, userID uuid.UUID,
+	name, prefix, keyHash string,
+	permissions []project_member.Permission,
+	allowedIPs []string,
+	expiresAt, lastUsedAt *time.Time,
+	lastUsedIP string,
+	revokedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *APIKey {
+	if permissions == nil {
+		permissions = []project_member.Permission{}
+	}
+	if allowedIPs == nil {
+		allowedIPs = []string{}
+	}
+	return &APIKey{
+		id:          id,
+		tenantID:    tenantID,
+		projectID:   projectID,
+		userID:      userID,
+		name:        name,
+		prefix:      prefix,
+		keyHash:     keyHash,
+		permissions: permissions,
+		allowedIPs:  allowedIPs,
+		expiresAt:   expiresAt,
+		lastUsedAt:  lastUsedAt,
+		lastUsedIP:  lastUsedIP,
+		revokedAt:   revokedAt,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		events:      []DomainEvent{},
+	}
+}
+
+// Revoke invalida a chave imediatamente
+func (k *APIKey) Revoke(revokedBy uuid.UUID) error {
+	if k.revokedAt != nil {
+		return ErrAlreadyRevoked
+	}
+	now := time.Now().UTC()
+	k.revokedAt = &now
+	k.updatedAt = now
+	k.addEvent(NewAPIKeyRevokedEvent(k, revokedBy))
+	return nil
+}
+
+// Validate confere se a chave pode ser usada agora a partir do IP informado
+func (k *APIKey) Validate(now time.Time, ip string) error {
+	if k.revokedAt != nil {
+		return ErrAPIKeyRevoked
+	}
+	if k.IsExpired(now) {
+		return ErrAPIKeyExpired
+	}
+	if !k.AllowsIP(ip) {
+		return ErrIPNotAllowed
+	}
+	return nil
+}
+
+// IsExpired indica se a validade já passou
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.expiresAt != nil && !now.Before(*k.expiresAt)
+}
+
+// IsActive chave não revogada e dentro da validade
+func (k *APIKey) IsActive(now time.Time) bool {
+	return k.revokedAt == nil && !k.IsExpired(now)
+}
+
+// AllowsIP allow-list vazia libera qualquer origem; IP ilegível só passa sem allow-list
+func (k *APIKey) AllowsIP(ip string) bool {
+	if len(k.allowedIPs) == 0 {
+		return true
+	}
+	addr := net.ParseIP(strings.TrimSpace(ip))
+	if addr == nil {
+		return false
+	}
+	for _, allowed := range k.allowedIPs {
+		if _, network, err := net.ParseCIDR(allowed); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if other := net.ParseIP(allowed); other != nil && other.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// HasPermission indica se a permissão foi concedida à chave
+func (k *APIKey) HasPermission(permission project_member.Permission) bool {
+	for _, p := range k.permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateToken token de alta entropia (256 bits) com o prefixo vtr_
+func GenerateToken() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return TokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken SHA-256 hexadecimal do token. Como o token tem 256 bits aleatórios, um hash
+// rápido basta (não há dicionário a atacar) e permite a busca direta pelo índice.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsToken indica se o valor tem o formato das chaves geradas aqui
+func IsToken(token string) bool {
+	return strings.HasPrefix(token, TokenPrefix) && len(token) > DisplayPrefixLength
+}
+
+// NormalizePermissions remove duplicadas e rejeita permissões desconhecidas
+func NormalizePermissions(permissions []project_member.Permission) ([]project_member.Permission, error) {
+	if len(permissions) == 0 {
+		return nil, ErrNoPermissions
+	}
+	seen := make(map[project_member.Permission]bool, len(permissions))
+	normalized := make([]project_member.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return nil, ErrInvalidPermission
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		normalized = append(normalized, p)
+	}
+	return normalized, nil
+}
+
+// NormalizeAllowedIPs valida e padroniza os IPs/CIDRs ("10.0.0.7/24" → "10.0.0.0/24")
+func NormalizeAllowedIPs(ips []string) ([]string, error) {
+	if len(ips) > MaxAllowedIPs {
+		return nil, ErrTooManyAllowedIPs
+	}
+	normalized := make([]string, 0, len(ips))
+	seen := make(map[string]bool, len(ips))
+	for _, raw := range ips {
+		raw = strings.TrimSpace(raw)
+		var value string
+		if strings.Contains(raw, "/") {
+			_, network, err := net.ParseCIDR(raw)
+			if err != nil {
+				return nil, ErrInvalidAllowedIP
+			}
+			value = network.String()
+		} else {
+			ip := net.ParseIP(raw)
+			if ip == nil {
+				return nil, ErrInvalidAllowedIP
+			}
+			value = ip.String()
+		}
+		if !seen[value] {
+			seen[value] = true
+			normalized = append(normalized, value)
+		}
+	}
+	return normalized, nil
+}
+
+func normalizeDetails(details Details, now time.Time) (Details, error) {
+	name := strings.TrimSpace(details.Name)
+	if name == "" {
+		return Details{}, ErrEmptyName
+	}
+	if len([]rune(name)) > maxNameLength {
+		return Details{}, ErrNameTooLong
+	}
+	permissions, err := NormalizePermissions(details.Permissions)
+	if err != nil {
+		return Details{}, err
+	}
+	allowedIPs, err := NormalizeAllowedIPs(details.AllowedIPs)
+	if err != nil {
+		return Details{}, err
+	}
+	var expiresAt *time.Time
+	if details.ExpiresAt != nil {
+		if !details.ExpiresAt.After(now) {
+			return Details{}, ErrExpiryInPast
+		}
+		at := details.ExpiresAt.UTC()
+		expiresAt = &at
+	}
+	return Details{Name: name, Permissions: permissions, AllowedIPs: allowedIPs, ExpiresAt: expiresAt}, nil
+}
+
+func (k *APIKey) addEvent(event DomainEvent) {
+	k.events = append(k.events, event)
+}
+
+func (k *APIKey) ID() uuid.UUID               { return k.id }
+func (k *APIKey) TenantID() string            { return k.tenantID }
+func (k *APIKey) ProjectID() uuid.UUID        { return k.projectID }
+func (k *APIKey) UserID() uuid.UUID           { return k.userID }
+func (k *APIKey) Name() string                { return k.name }
+func (k *APIKey) Prefix() string              { return k.prefix }
+func (k *APIKey) KeyHash() string             { return k.keyHash }
+func (k *APIKey) ExpiresAt() *time.Time       { return k.expiresAt }
+func (k *APIKey) LastUsedAt() *time.Time      { return k.lastUsedAt }
+func (k *APIKey) LastUsedIP() string          { return k.lastUsedIP }
+func (k *APIKey) RevokedAt() *time.Time       { return k.revokedAt }
+func (k *APIKey) CreatedAt() time.Time        { return k.createdAt }
+func (k *APIKey) UpdatedAt() time.Time        { return k.updatedAt }
+func (k *APIKey) DomainEvents() []DomainEvent { return append([]DomainEvent{}, k.events...) }
+func (k *APIKey) ClearEvents()                { k.events = []DomainEvent{} }
+
+func (k *APIKey) Permissions() []project_member.Permission {
+	return append([]project_member.Permission{}, k.permissions...)
+}
+
+func (k *APIKey) AllowedIPs() []string {
+	return append([]string{}, k.allowedIPs...)
+}
@@ -0,0 +1,106 @@
+package apikey
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+func validDetails() Details {
+	return Details{
+		Name:        "  Integração ERP ",
+		Permissions: []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionManageContacts, project_member.PermissionViewContacts},
+	}
+}
+
+func TestNewAPIKey(t *testing.T) {
+	projectID, userID := uuid.New(), uuid.New()
+
+	k, token, err := NewAPIKey("tenant-1", projectID, userID, validDetails())
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, TokenPrefix))
+	assert.Greater(t, len(token), 40, "256 bits of entropy")
+	assert.True(t, IsToken(token))
+	assert.Equal(t, token[:DisplayPrefixLength], k.Prefix())
+	assert.Equal(t, HashToken(token), k.KeyHash())
+	assert.NotContains(t, k.KeyHash(), token, "only the hash is kept")
+	assert.Equal(t, "Integração ERP", k.Name())
+	assert.Equal(t, []project_member.Permission{project_member.PermissionViewContacts, project_member.PermissionManageContacts}, k.Permissions())
+	assert.True(t, k.IsActive(time.Now()))
+	require.Len(t, k.DomainEvents(), 1)
+	assert.Equal(t, "api_key.created", k.DomainEvents()[0].EventName())
+
+	_, other, err := NewAPIKey("tenant-1", projectID, userID, validDetails())
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestNewAPIKey_Validation(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name    string
+		mutate  func(*Details)
+		wantErr error
+	}{
+		{name: "empty name", mutate: func(d *Details) { d.Name = " " }, wantErr: ErrEmptyName},
+		{name: "name too long", mutate: func(d *Details) { d.Name = strings.Repeat("a", 101) }, wantErr: ErrNameTooLong},
+		{name: "no permissions", mutate: func(d *Details) { d.Permissions = nil }, wantErr: ErrNoPermissions},
+		{name: "unknown permission", mutate: func(d *Details) { d.Permissions = []project_member.Permission{"contacts.delete_all"} }, wantErr: ErrInvalidPermission},
+		{name: "invalid ip", mutate: func(d *Details) { d.AllowedIPs = []string{"10.0.0.300"} }, wantErr: ErrInvalidAllowedIP},
+		{name: "invalid cidr", mutate: func(d *Details) { d.AllowedIPs = []string{"10.0.0.0/33"} }, wantErr: ErrInvalidAllowedIP},
+		{name: "expiry in the past", mutate: func(d *Details) { d.ExpiresAt = &past }, wantErr: ErrExpiryInPast},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			details := validDetails()
+			tt.mutate(&details)
+			_, _, err := NewAPIKey("tenant-1", uuid.New(), uuid.New(), details)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	_, _, err := NewAPIKey("", uuid.New(), uuid.New(), validDetails())
+	assert.ErrorIs(t, err, ErrInvalidTenant)
+	_, _, err = NewAPIKey("tenant-1", uuid.Nil, uuid.New(), validDetails())
+	assert.ErrorIs(t, err, ErrInvalidProject)
+}
+
+func TestAPIKey_Validate(t *testing.T) {
+	expiresAt := time.Now().Add(time.Hour)
+	details := validDetails()
+	details.ExpiresAt = &expiresAt
+	details.AllowedIPs = []string{"203.0.113.7", " 10.1.2.3/16 ", "2001:db8::/32"}
+	k, _, err := NewAPIKey("tenant-1", uuid.New(), uuid.New(), details)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"203.0.113.7", "10.1.0.0/16", "2001:db8::/32"}, k.AllowedIPs())
+
+	now := time.Now()
+	assert.NoError(t, k.Validate(now, "203.0.113.7"))
+	assert.NoError(t, k.Validate(now, "10.1.200.9"))
+	assert.NoError(t, k.Validate(now, "2001:db8::1"))
+	assert.ErrorIs(t, k.Validate(now, "203.0.113.8"), ErrIPNotAllowed)
+	assert.ErrorIs(t, k.Validate(now, ""), ErrIPNotAllowed)
+	assert.ErrorIs(t, k.Validate(expiresAt, "203.0.113.7"), ErrAPIKeyExpired)
+
+	require.NoError(t, k.Revoke(uuid.New()))
+	assert.ErrorIs(t, k.Validate(now, "203.0.113.7"), ErrAPIKeyRevoked)
+	assert.False(t, k.IsActive(now))
+	assert.ErrorIs(t, k.Revoke(uuid.New()), ErrAlreadyRevoked)
+	assert.Equal(t, "api_key.revoked", k.DomainEvents()[1].EventName())
+}
+
+func TestAPIKey_HasPermission(t *testing.T) {
+	k, _, err := NewAPIKey("tenant-1", uuid.New(), uuid.New(), validDetails())
+	require.NoError(t, err)
+
+	assert.True(t, k.HasPermission(project_member.PermissionManageContacts))
+	assert.False(t, k.HasPermission(project_member.PermissionSendMessages))
+}
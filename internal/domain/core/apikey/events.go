@@ -0,0 +1,61 @@
+package apikey
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+	"github.com/ventros/crm/internal/domain/crm/project_member"
+)
+
+// APIKeyCreatedEvent chave de API criada (nunca carrega o token nem o hash)
+type APIKeyCreatedEvent struct {
+	shared.BaseEvent
+	APIKeyID    uuid.UUID
+	TenantID    string
+	ProjectID   uuid.UUID
+	UserID      uuid.UUID
+	Name        string
+	Prefix      string
+	Permissions []project_member.Permission
+	AllowedIPs  []string
+	ExpiresAt   *time.Time
+}
+
+func NewAPIKeyCreatedEvent(k *APIKey) APIKeyCreatedEvent {
+	return APIKeyCreatedEvent{
+		BaseEvent:   shared.NewBaseEvent("api_key.created", time.Now()),
+		APIKeyID:    k.id,
+		TenantID:    k.tenantID,
+		ProjectID:   k.projectID,
+		UserID:      k.userID,
+		Name:        k.name,
+		Prefix:      k.prefix,
+		Permissions: k.Permissions(),
+		AllowedIPs:  k.AllowedIPs(),
+		ExpiresAt:   k.expiresAt,
+	}
+}
+
+// APIKeyRevokedEvent chave de API revogada
+type APIKeyRevokedEvent struct {
+	shared.BaseEvent
+	APIKeyID  uuid.UUID
+	TenantID  string
+	ProjectID uuid.UUID
+	Name      string
+	Prefix    string
+	RevokedBy uuid.UUID
+}
+
+func NewAPIKeyRevokedEvent(k *APIKey, revokedBy uuid.UUID) APIKeyRevokedEvent {
+	return APIKeyRevokedEvent{
+		BaseEvent: shared.NewBaseEvent("api_key.revoked", time.Now()),
+		APIKeyID:  k.id,
+		TenantID:  k.tenantID,
+		ProjectID: k.projectID,
+		Name:      k.name,
+		Prefix:    k.prefix,
+		RevokedBy: revokedBy,
+	}
+}
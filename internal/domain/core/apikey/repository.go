@@ -0,0 +1,25 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Usage último uso observado de uma chave (acumulado no Redis e gravado em lote)
+type Usage struct {
+	KeyID uuid.UUID
+	At    time.Time
+	IP    string
+}
+
+type Repository interface {
+	Save(ctx context.Context, k *APIKey) error
+	FindByID(ctx context.Context, id uuid.UUID) (*APIKey, error)
+	FindByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	// ListByProject chaves do projeto, mais recentes primeiro; includeRevoked traz também as revogadas
+	ListByProject(ctx context.Context, projectID uuid.UUID, includeRevoked bool) ([]*APIKey, error)
+	// RecordUsage grava o último uso de várias chaves em uma só operação, sem retroceder last_used
+	RecordUsage(ctx context.Context, usages []Usage) error
+}
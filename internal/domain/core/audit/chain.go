@@ -0,0 +1,63 @@
+package audit
+
+import "fmt"
+
+// Verification resultado da conferência da cadeia de um projeto
+type Verification struct {
+	Valid   bool   `json:"valid"`
+	Checked int64  `json:"checked"`
+	Head    string `json:"head,omitempty"` // hash do último registro conferido
+	// Preenchidos quando a cadeia está quebrada
+	BrokenAtSequence int64  `json:"broken_at_sequence,omitempty"`
+	Reason           string `json:"reason,omitempty"`
+}
+
+// ChainVerifier confere a cadeia em lotes, na ordem crescente de sequência
+type ChainVerifier struct {
+	prev   *Entry
+	result Verification
+}
+
+func NewChainVerifier() *ChainVerifier {
+	return &ChainVerifier{result: Verification{Valid: true}}
+}
+
+// Add confere o próximo lote; devolve false na primeira quebra (os lotes seguintes são ignorados)
+func (v *ChainVerifier) Add(entries []*Entry) bool {
+	if !v.result.Valid {
+		return false
+	}
+	for _, e := range entries {
+		if reason := v.check(e); reason != "" {
+			v.result.Valid = false
+			v.result.BrokenAtSequence = e.sequence
+			v.result.Reason = reason
+			return false
+		}
+		v.prev = e
+		v.result.Checked++
+		v.result.Head = e.hash
+	}
+	return true
+}
+
+func (v *ChainVerifier) check(e *Entry) string {
+	expectedSequence, expectedPrev := int64(1), ""
+	if v.prev != nil {
+		expectedSequence, expectedPrev = v.prev.sequence+1, v.prev.hash
+	}
+	switch {
+	case e.sequence != expectedSequence:
+		return fmt.Sprintf("expected sequence %d, found %d (entry removed)", expectedSequence, e.sequence)
+	case e.prevHash != expectedPrev:
+		return "prev_hash does not match the previous entry"
+	case e.ComputeHash() != e.hash:
+		return "entry content does not match its hash (entry modified)"
+	}
+	return ""
+}
+
+// Result resultado acumulado
+func (v *ChainVerifier) Result() Verification {
+	return v.result
+}
@@ -0,0 +1,85 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// Redacted valor gravado no lugar de campos sensíveis: a trilha registra que mudou, não o valor
+const Redacted = "[redacted]"
+
+var sensitiveKeys = []string{"secret", "password", "token", "key_hash", "ciphertext"}
+
+// ignoredKeys mudam em toda alteração e só poluiriam o diff
+var ignoredKeys = map[string]bool{"updated_at": true}
+
+// Diff campos de primeiro nível que mudaram entre before e after (structs ou mapas, comparados
+// pela representação JSON). before nil registra uma criação; after nil, uma remoção.
+func Diff(before, after interface{}) (map[string]Change, error) {
+	beforeFields, err := toFields(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := toFields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for key, value := range beforeFields {
+		if next, ok := afterFields[key]; !ok || !reflect.DeepEqual(value, next) {
+			changes[key] = Change{Before: value, After: next}
+		}
+	}
+	for key, value := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			changes[key] = Change{After: value}
+		}
+	}
+	for key, change := range changes {
+		if ignoredKeys[key] {
+			delete(changes, key)
+			continue
+		}
+		if isSensitive(key) {
+			changes[key] = redact(change)
+		}
+	}
+	return changes, nil
+}
+
+func toFields(value interface{}) (map[string]interface{}, error) {
+	if value == nil {
+		return map[string]interface{}{}, nil
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func isSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func redact(change Change) Change {
+	if change.Before != nil {
+		change.Before = Redacted
+	}
+	if change.After != nil {
+		change.After = Redacted
+	}
+	return change
+}
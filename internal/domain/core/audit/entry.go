@@ -0,0 +1,254 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidActor  = errors.New("audit actor type is invalid")
+	ErrInvalidAction = errors.New("audit action cannot be empty")
+	ErrAlreadyLinked = errors.New("audit entry is already linked to the chain")
+)
+
+// ActorType quem executou a ação
+type ActorType string
+
+const (
+	ActorUser       ActorType = "user"
+	ActorAPIKey     ActorType = "api_key"
+	ActorAutomation ActorType = "automation"
+	ActorSystem     ActorType = "system"
+)
+
+func (t ActorType) IsValid() bool {
+	switch t {
+	case ActorUser, ActorAPIKey, ActorAutomation, ActorSystem:
+		return true
+	}
+	return false
+}
+
+// Actor autor da ação. ID é o user_id, o id da API key ou o id da automação; Label é legível
+// (email, prefixo da key, nome da automação) e fica congelado no registro.
+type Actor struct {
+	Type  ActorType
+	ID    string
+	Label string
+}
+
+// Change valor antes e depois de um campo alterado
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Record dados de uma ação a registrar
+type Record struct {
+	ProjectID     uuid.UUID // uuid.Nil para ações fora de projeto
+	TenantID      string
+	Actor         Actor
+	Action        string // channels.delete, audit_log.export, webhook-subscriptions.rotate-secret...
+	ResourceType  string
+	ResourceID    string
+	Status        int // status HTTP da resposta (0 fora de requisições)
+	IP            string
+	UserAgent     string
+	CorrelationID string
+	Changes       map[string]Change
+	Metadata      map[string]string
+}
+
+// Entry registro imutável da trilha de auditoria. Os registros de um projeto formam uma cadeia:
+// cada um guarda o hash do anterior, e alterar ou remover qualquer linha quebra todos os seguintes.
+type Entry struct {
+	id            uuid.UUID
+	projectID     uuid.UUID
+	tenantID      string
+	sequence      int64
+	actor         Actor
+	action        string
+	resourceType  string
+	resourceID    string
+	status        int
+	ip            string
+	userAgent     string
+	correlationID string
+	changes       map[string]Change
+	metadata      map[string]string
+	occurredAt    time.Time
+	prevHash      string
+	hash          string
+}
+
+// NewEntry cria o registro ainda fora da cadeia (Link define sequência e hashes)
+func NewEntry(record Record, now time.Time) (*Entry, error) {
+	if !record.Actor.Type.IsValid() {
+		return nil, ErrInvalidActor
+	}
+	if record.Action == "" {
+		return nil, ErrInvalidAction
+	}
+	changes, err := canonicalChanges(record.Changes)
+	if err != nil {
+		return nil, err
+	}
+	metadata := record.Metadata
+	if len(metadata) == 0 {
+		metadata = nil
+	}
+
+	return &Entry{
+		id:            uuid.New(),
+		projectID:     record.ProjectID,
+		tenantID:      record.TenantID,
+		actor:         record.Actor,
+		action:        record.Action,
+		resourceType:  record.ResourceType,
+		resourceID:    record.ResourceID,
+		status:        record.Status,
+		ip:            record.IP,
+		userAgent:     record.UserAgent,
+		correlationID: record.CorrelationID,
+		changes:       changes,
+		metadata:      metadata,
+		// Postgres guarda microssegundos: o hash precisa ser recalculável a partir da linha lida
+		occurredAt: now.UTC().Truncate(time.Microsecond),
+	}, nil
+}
+
+// ReconstructEntry reconstrói um registro a partir da persistência
+func ReconstructEntry(
+	id, projectID uuid.UUID,
+	tenantID string,
+	sequence int64,
+	actor Actor,
+	action, resourceType, resourceID string,
+	status int,
+	ip, userAgent, correlationID string,
+	changes map[string]Change,
+	metadata map[string]string,
+	occurredAt time.Time,
+	prevHash, hash string,
+) *Entry {
+	return &Entry{
+		id:            id,
+		projectID:     projectID,
+		tenantID:      tenantID,
+		sequence:      sequence,
+		actor:         actor,
+		action:        action,
+		resourceType:  resourceType,
+		resourceID:    resourceID,
+		status:        status,
+		ip:            ip,
+		userAgent:     userAgent,
+		correlationID: correlationID,
+		changes:       changes,
+		metadata:      metadata,
+		occurredAt:    occurredAt.UTC(),
+		prevHash:      prevHash,
+		hash:          hash,
+	}
+}
+
+// Link encadeia o registro após prev (nil para o primeiro registro do projeto) e calcula o hash
+func (e *Entry) Link(prev *Entry) error {
+	if e.hash != "" {
+		return ErrAlreadyLinked
+	}
+	e.sequence = 1
+	e.prevHash = ""
+	if prev != nil {
+		e.sequence = prev.sequence + 1
+		e.prevHash = prev.hash
+	}
+	e.hash = e.ComputeHash()
+	return nil
+}
+
+// ComputeHash SHA-256 do conteúdo do registro junto com o hash do anterior
+func (e *Entry) ComputeHash() string {
+	// Campos em ordem fixa; mapas são serializados com as chaves ordenadas pelo encoding/json
+	payload, _ := json.Marshal(struct {
+		Sequence      int64             `json:"sequence"`
+		PrevHash      string            `json:"prev_hash"`
+		ID            string            `json:"id"`
+		ProjectID     string            `json:"project_id"`
+		TenantID      string            `json:"tenant_id"`
+		ActorType     ActorType         `json:"actor_type"`
+		ActorID       string            `json:"actor_id"`
+		ActorLabel    string            `json:"actor_label"`
+		Action        string            `json:"action"`
+		ResourceType  string            `json:"resource_type"`
+		ResourceID    string            `json:"resource_id"`
+		Status        int               `json:"status"`
+		IP            string            `json:"ip"`
+		UserAgent     string            `json:"user_agent"`
+		CorrelationID string            `json:"correlation_id"`
+		Changes       map[string]Change `json:"changes"`
+		Metadata      map[string]string `json:"metadata"`
+		OccurredAt    string            `json:"occurred_at"`
+	}{
+		Sequence:      e.sequence,
+		PrevHash:      e.prevHash,
+		ID:            e.id.String(),
+		ProjectID:     e.projectID.String(),
+		TenantID:      e.tenantID,
+		ActorType:     e.actor.Type,
+		ActorID:       e.actor.ID,
+		ActorLabel:    e.actor.Label,
+		Action:        e.action,
+		ResourceType:  e.resourceType,
+		ResourceID:    e.resourceID,
+		Status:        e.status,
+		IP:            e.ip,
+		UserAgent:     e.userAgent,
+		CorrelationID: e.correlationID,
+		Changes:       e.changes,
+		Metadata:      e.metadata,
+		OccurredAt:    e.occurredAt.Format(time.RFC3339Nano),
+	})
+	sum := sha256.Sum256(payload)
+	return hex.EncodeToString(sum[:])
+}
+
+// canonicalChanges normaliza os valores como voltam do JSONB (números em float64, structs em
+// mapas), para que o hash calculado na gravação bata com o recalculado na verificação
+func canonicalChanges(changes map[string]Change) (map[string]Change, error) {
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	raw, err := json.Marshal(changes)
+	if err != nil {
+		return nil, err
+	}
+	var canonical map[string]Change
+	if err := json.Unmarshal(raw, &canonical); err != nil {
+		return nil, err
+	}
+	return canonical, nil
+}
+
+func (e *Entry) ID() uuid.UUID               { return e.id }
+func (e *Entry) ProjectID() uuid.UUID        { return e.projectID }
+func (e *Entry) TenantID() string            { return e.tenantID }
+func (e *Entry) Sequence() int64             { return e.sequence }
+func (e *Entry) Actor() Actor                { return e.actor }
+func (e *Entry) Action() string              { return e.action }
+func (e *Entry) ResourceType() string        { return e.resourceType }
+func (e *Entry) ResourceID() string          { return e.resourceID }
+func (e *Entry) Status() int                 { return e.status }
+func (e *Entry) IP() string                  { return e.ip }
+func (e *Entry) UserAgent() string           { return e.userAgent }
+func (e *Entry) CorrelationID() string       { return e.correlationID }
+func (e *Entry) Changes() map[string]Change  { return e.changes }
+func (e *Entry) Metadata() map[string]string { return e.metadata }
+func (e *Entry) OccurredAt() time.Time       { return e.occurredAt }
+func (e *Entry) PrevHash() string            { return e.prevHash }
+func (e *Entry) Hash() string                { return e.hash }
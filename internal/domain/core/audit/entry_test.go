@@ -0,0 +1,140 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEntry(t *testing.T, projectID uuid.UUID, action string, prev *Entry) *Entry {
+	t.Helper()
+	e, err := NewEntry(Record{
+		ProjectID:    projectID,
+		TenantID:     "tenant-1",
+		Actor:        Actor{Type: ActorUser, ID: uuid.NewString(), Label: "ana@example.com"},
+		Action:       action,
+		ResourceType: "channels",
+		ResourceID:   uuid.NewString(),
+		Status:       204,
+		IP:           "203.0.113.9",
+		Changes:      map[string]Change{"retry_count": {Before: 3, After: 5}},
+	}, time.Now())
+	require.NoError(t, err)
+	require.NoError(t, e.Link(prev))
+	return e
+}
+
+func TestNewEntry_Validation(t *testing.T) {
+	_, err := NewEntry(Record{Actor: Actor{Type: "robot"}, Action: "channels.delete"}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidActor)
+
+	_, err = NewEntry(Record{Actor: Actor{Type: ActorAPIKey}}, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidAction)
+}
+
+func TestEntry_Link(t *testing.T) {
+	projectID := uuid.New()
+	first := newTestEntry(t, projectID, "channels.delete", nil)
+	second := newTestEntry(t, projectID, "channels.update", first)
+
+	assert.Equal(t, int64(1), first.Sequence())
+	assert.Empty(t, first.PrevHash())
+	assert.Equal(t, int64(2), second.Sequence())
+	assert.Equal(t, first.Hash(), second.PrevHash())
+	assert.NotEqual(t, first.Hash(), second.Hash())
+	assert.ErrorIs(t, second.Link(first), ErrAlreadyLinked)
+}
+
+func TestEntry_HashSurvivesPersistence(t *testing.T) {
+	e := newTestEntry(t, uuid.New(), "channels.update", nil)
+
+	// Como volta do banco: números do JSONB em float64
+	restored := ReconstructEntry(e.ID(), e.ProjectID(), e.TenantID(), e.Sequence(), e.Actor(), e.Action(),
+		e.ResourceType(), e.ResourceID(), e.Status(), e.IP(), e.UserAgent(), e.CorrelationID(),
+		map[string]Change{"retry_count": {Before: float64(3), After: float64(5)}},
+		e.Metadata(), e.OccurredAt().In(time.FixedZone("BRT", -3*3600)), e.PrevHash(), e.Hash())
+
+	assert.Equal(t, e.Hash(), restored.ComputeHash())
+}
+
+func TestChainVerifier(t *testing.T) {
+	projectID := uuid.New()
+	build := func() []*Entry {
+		var chain []*Entry
+		var prev *Entry
+		for _, action := range []string{"channels.create", "channels.update", "channels.delete", "audit_log.export"} {
+			prev = newTestEntry(t, projectID, action, prev)
+			chain = append(chain, prev)
+		}
+		return chain
+	}
+
+	t.Run("intact chain in batches", func(t *testing.T) {
+		chain := build()
+		v := NewChainVerifier()
+		assert.True(t, v.Add(chain[:2]))
+		assert.True(t, v.Add(chain[2:]))
+		result := v.Result()
+		assert.True(t, result.Valid)
+		assert.Equal(t, int64(4), result.Checked)
+		assert.Equal(t, chain[3].Hash(), result.Head)
+	})
+
+	t.Run("modified entry", func(t *testing.T) {
+		chain := build()
+		chain[1].actor.Label = "someone-else@example.com"
+		v := NewChainVerifier()
+		assert.False(t, v.Add(chain))
+		result := v.Result()
+		assert.False(t, result.Valid)
+		assert.Equal(t, int64(2), result.BrokenAtSequence)
+		assert.Equal(t, int64(1), result.Checked)
+		assert.Contains(t, result.Reason, "modified")
+	})
+
+	t.Run("removed entry", func(t *testing.T) {
+		chain := build()
+		v := NewChainVerifier()
+		v.Add(append([]*Entry{chain[0]}, chain[2:]...))
+		result := v.Result()
+		assert.False(t, result.Valid)
+		assert.Equal(t, int64(3), result.BrokenAtSequence)
+		assert.Contains(t, result.Reason, "removed")
+	})
+
+	t.Run("rehashed entry breaks the next link", func(t *testing.T) {
+		chain := build()
+		chain[1].status = 500
+		chain[1].hash = chain[1].ComputeHash()
+		v := NewChainVerifier()
+		v.Add(chain)
+		assert.Equal(t, int64(3), v.Result().BrokenAtSequence)
+	})
+}
+
+func TestDiff(t *testing.T) {
+	type webhook struct {
+		Name    string   `json:"name"`
+		URL     string   `json:"url"`
+		Events  []string `json:"events"`
+		Secret  string   `json:"secret"`
+		Retries int      `json:"retries"`
+	}
+	before := webhook{Name: "CRM", URL: "https://a.example.com", Events: []string{"contact.created"}, Secret: "old", Retries: 3}
+	after := before
+	after.URL = "https://b.example.com"
+	after.Secret = "new"
+
+	changes, err := Diff(before, after)
+	require.NoError(t, err)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, Change{Before: "https://a.example.com", After: "https://b.example.com"}, changes["url"])
+	assert.Equal(t, Change{Before: Redacted, After: Redacted}, changes["secret"], "secrets are never written to the trail")
+
+	created, err := Diff(nil, map[string]interface{}{"name": "CRM"})
+	require.NoError(t, err)
+	assert.Equal(t, Change{After: "CRM"}, created["name"])
+}
@@ -0,0 +1,36 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Filter consulta da trilha de um projeto, mais recentes primeiro
+type Filter struct {
+	ProjectID     uuid.UUID
+	ActorType     ActorType
+	ActorID       string
+	Action        string
+	ResourceType  string
+	ResourceID    string
+	CorrelationID string
+	Since         *time.Time
+	Until         *time.Time
+	// BeforeSequence paginação por cursor (exportação); 0 começa do registro mais recente
+	BeforeSequence int64
+	Limit          int
+	Offset         int
+}
+
+// Repository trilha de auditoria append-only: não há Update nem Delete
+type Repository interface {
+	// Append encadeia o registro após o último do projeto (serializado por projeto) e grava
+	Append(ctx context.Context, entry *Entry) error
+
+	List(ctx context.Context, filter Filter) ([]*Entry, int64, error)
+
+	// Chain registros do projeto em ordem crescente de sequência, a partir de afterSequence
+	Chain(ctx context.Context, projectID uuid.UUID, afterSequence int64, limit int) ([]*Entry, error)
+}
@@ -0,0 +1,18 @@
+package authsession
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Save(ctx context.Context, s *Session) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Session, error)
+	// FindByRefreshHash busca pelo refresh token atual ou pelo anterior (detecção de reuso)
+	FindByRefreshHash(ctx context.Context, tokenHash string) (*Session, error)
+	// ListActiveByUser sessões não revogadas e não expiradas, mais recentes primeiro
+	ListActiveByUser(ctx context.Context, userID uuid.UUID) ([]*Session, error)
+	// RevokeAllByUser revoga as sessões ativas do usuário, menos except (uuid.Nil = todas)
+	RevokeAllByUser(ctx context.Context, userID, except uuid.UUID, reason string) (int64, error)
+}
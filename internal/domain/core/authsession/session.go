@@ -0,0 +1,283 @@
+package authsession
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrSessionNotFound   = errors.New("session not found")
+	ErrInvalidUser       = errors.New("userID cannot be nil")
+	ErrInvalidMethod     = errors.New("invalid login method")
+	ErrMissingProvider   = errors.New("provider is required for oidc sessions")
+	ErrInvalidTTL        = errors.New("session ttl must be positive")
+	ErrAlreadyRevoked    = errors.New("session already revoked")
+	ErrSessionRevoked    = errors.New("session revoked")
+	ErrSessionExpired    = errors.New("session expired")
+	ErrRefreshTokenReuse = errors.New("refresh token already used")
+)
+
+// Method forma de login que abriu a sessão
+type Method string
+
+const (
+	MethodPassword Method = "password"
+	MethodOIDC     Method = "oidc"
+)
+
+// Motivos de revogação gravados em revoke_reason
+const (
+	ReasonLogout       = "logout"
+	ReasonRevoked      = "revoked"
+	ReasonTokenReuse   = "refresh_token_reuse"
+	ReasonRevokeOthers = "revoke_others"
+)
+
+const (
+	// RefreshTokenPrefix identifica os refresh tokens emitidos aqui
+	RefreshTokenPrefix = "vrt_"
+
+	maxUserAgentLength = 512
+	secretBytes        = 32
+)
+
+// Details dados da origem do login
+type Details struct {
+	Method Method
+	// Provider nome do provedor OIDC (ex: google); vazio no login por senha
+	Provider  string
+	UserAgent string
+	IP        string
+	// TwoFactorVerified login concluído com o segundo fator (TOTP ou código de recuperação)
+	TwoFactorVerified bool
+}
+
+// Session sessão de login de um usuário. O access token (JWT curto) carrega o ID da sessão;
+// o refresh token é opaco, rotacionado a cada uso e persistido só como SHA-256.
+// Revogar a sessão invalida os dois.
+type Session struct {
+	id                  uuid.UUID
+	userID              uuid.UUID
+	method              Method
+	provider            string
+	refreshHash         string
+	previousRefreshHash string
+	userAgent           string
+	ip                  string
+	expiresAt           time.Time
+	lastRefreshedAt     *time.Time
+	revokedAt           *time.Time
+	revokeReason        string
+	twoFactorVerifiedAt *time.Time
+	reauthenticatedAt   time.Time
+	createdAt           time.Time
+	updatedAt           time.Time
+}
+
+// NewSession abre a sessão e devolve o refresh token em claro. ttl é a validade absoluta da
+// sessão: a rotação do refresh token não a estende.
+func NewSession(userID uuid.UUID, details Details, ttl time.Duration) (*Session, string, error) {
+	if userID == uuid.Nil {
+		return nil, "", ErrInvalidUser
+	}
+	switch details.Method {
+	case MethodPassword:
+		details.Provider = ""
+	case MethodOIDC:
+		if strings.TrimSpace(details.Provider) == "" {
+			return nil, "", ErrMissingProvider
+		}
+	default:
+		return nil, "", ErrInvalidMethod
+	}
+	if ttl <= 0 {
+		return nil, "", ErrInvalidTTL
+	}
+
+	token, err := GenerateRefreshToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now().UTC()
+	s := &Session{
+		id:                uuid.New(),
+		userID:            userID,
+		method:            details.Method,
+		provider:          strings.TrimSpace(details.Provider),
+		refreshHash:       HashToken(token),
+		userAgent:         truncate(details.UserAgent, maxUserAgentLength),
+		ip:                details.IP,
+		expiresAt:         now.Add(ttl),
+		reauthenticatedAt: now, // o próprio login conta como autenticação recente (step-up)
+		createdAt:         now,
+		updatedAt:         now,
+	}
+	if details.TwoFactorVerified {
+		s.twoFactorVerifiedAt = &now
+	}
+	return s, token, nil
+}
+
+// ReconstructSession reconstrói a sessão a partir da persistência
+func ReconstructSession(
+	id, userID uuid.UUID,
+	method Method,
+	provider, refreshHash, previousRefreshHash, userAgent, ip string,
+	expiresAt time.Time,
+	lastRefreshedAt, revokedAt *time.Time,
+	revokeReason string,
+	twoFactorVerifiedAt *time.Time,
+	reauthenticatedAt time.Time,
+	createdAt, updatedAt time.Time,
+) *Session {
+	if reauthenticatedAt.IsZero() {
+		reauthenticatedAt = createdAt
+	}
+	return &Session{
+		id:                  id,
+		userID:              userID,
+		method:              method,
+		provider:            provider,
+		refreshHash:         refreshHash,
+		previousRefreshHash: previousRefreshHash,
+		userAgent:           userAgent,
+		ip:                  ip,
+		expiresAt:           expiresAt,
+		lastRefreshedAt:     lastRefreshedAt,
+		revokedAt:           revokedAt,
+		revokeReason:        revokeReason,
+		twoFactorVerifiedAt: twoFactorVerifiedAt,
+		reauthenticatedAt:   reauthenticatedAt,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+	}
+}
+
+// Refresh troca o refresh token apresentado por um novo. Apresentar um token já trocado indica
+// que ele vazou: a sessão é revogada e ErrRefreshTokenReuse devolvido (o chamador deve salvar).
+func (s *Session) Refresh(tokenHash, ip string, now time.Time) (string, error) {
+	if s.revokedAt != nil {
+		return "", ErrSessionRevoked
+	}
+	if s.IsExpired(now) {
+		return "", ErrSessionExpired
+	}
+	if tokenHash != s.refreshHash {
+		if tokenHash != "" && tokenHash == s.previousRefreshHash {
+			s.revoke(ReasonTokenReuse, now)
+			return "", ErrRefreshTokenReuse
+		}
+		return "", ErrSessionNotFound
+	}
+
+	token, err := GenerateRefreshToken()
+	if err != nil {
+		return "", err
+	}
+	at := now.UTC()
+	s.previousRefreshHash = s.refreshHash
+	s.refreshHash = HashToken(token)
+	s.lastRefreshedAt = &at
+	if ip != "" {
+		s.ip = ip
+	}
+	s.updatedAt = at
+	return token, nil
+}
+
+// Revoke encerra a sessão; o access token em circulação deixa de valer na próxima requisição
+func (s *Session) Revoke(reason string) error {
+	if s.revokedAt != nil {
+		return ErrAlreadyRevoked
+	}
+	s.revoke(reason, time.Now())
+	return nil
+}
+
+func (s *Session) revoke(reason string, now time.Time) {
+	at := now.UTC()
+	s.revokedAt = &at
+	s.revokeReason = reason
+	s.updatedAt = at
+}
+
+// Reauthenticate registra que o usuário acabou de se autenticar de novo (step-up). Com o segundo
+// fator, a sessão também passa a cumprir a política de 2FA do projeto.
+func (s *Session) Reauthenticate(twoFactor bool, now time.Time) error {
+	if !s.IsActive(now) {
+		return ErrSessionRevoked
+	}
+	at := now.UTC()
+	s.reauthenticatedAt = at
+	if twoFactor {
+		s.twoFactorVerifiedAt = &at
+	}
+	s.updatedAt = at
+	return nil
+}
+
+// ReauthenticatedWithin indica se a última autenticação (login ou step-up) tem no máximo maxAge
+func (s *Session) ReauthenticatedWithin(maxAge time.Duration, now time.Time) bool {
+	return now.Sub(s.reauthenticatedAt) <= maxAge
+}
+
+// IsTwoFactorVerified sessão aberta ou confirmada com o segundo fator
+func (s *Session) IsTwoFactorVerified() bool { return s.twoFactorVerifiedAt != nil }
+
+// IsExpired indica se a validade absoluta já passou
+func (s *Session) IsExpired(now time.Time) bool {
+	return !now.Before(s.expiresAt)
+}
+
+// IsActive sessão não revogada e dentro da validade
+func (s *Session) IsActive(now time.Time) bool {
+	return s.revokedAt == nil && !s.IsExpired(now)
+}
+
+func (s *Session) ID() uuid.UUID                   { return s.id }
+func (s *Session) UserID() uuid.UUID               { return s.userID }
+func (s *Session) Method() Method                  { return s.method }
+func (s *Session) Provider() string                { return s.provider }
+func (s *Session) RefreshHash() string             { return s.refreshHash }
+func (s *Session) PreviousRefreshHash() string     { return s.previousRefreshHash }
+func (s *Session) UserAgent() string               { return s.userAgent }
+func (s *Session) IP() string                      { return s.ip }
+func (s *Session) ExpiresAt() time.Time            { return s.expiresAt }
+func (s *Session) LastRefreshedAt() *time.Time     { return s.lastRefreshedAt }
+func (s *Session) RevokedAt() *time.Time           { return s.revokedAt }
+func (s *Session) RevokeReason() string            { return s.revokeReason }
+func (s *Session) TwoFactorVerifiedAt() *time.Time { return s.twoFactorVerifiedAt }
+func (s *Session) ReauthenticatedAt() time.Time    { return s.reauthenticatedAt }
+func (s *Session) CreatedAt() time.Time            { return s.createdAt }
+func (s *Session) UpdatedAt() time.Time            { return s.updatedAt }
+
+// GenerateRefreshToken token opaco de 256 bits com o prefixo vrt_
+func GenerateRefreshToken() (string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return RefreshTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashToken SHA-256 hexadecimal do refresh token (alta entropia: hash rápido basta)
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func truncate(value string, max int) string {
+	runes := []rune(value)
+	if len(runes) <= max {
+		return value
+	}
+	return string(runes[:max])
+}
@@ -0,0 +1,108 @@
+package authsession
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSession(t *testing.T) {
+	userID := uuid.New()
+
+	s, token, err := NewSession(userID, Details{Method: MethodPassword, Provider: "ignored", UserAgent: "Mozilla/5.0", IP: "203.0.113.9"}, time.Hour)
+
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, RefreshTokenPrefix))
+	assert.Equal(t, HashToken(token), s.RefreshHash())
+	assert.Empty(t, s.Provider(), "password sessions have no provider")
+	assert.Equal(t, userID, s.UserID())
+	assert.WithinDuration(t, time.Now().Add(time.Hour), s.ExpiresAt(), time.Second)
+	assert.True(t, s.IsActive(time.Now()))
+
+	_, _, err = NewSession(uuid.Nil, Details{Method: MethodPassword}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidUser)
+	_, _, err = NewSession(userID, Details{Method: "magic_link"}, time.Hour)
+	assert.ErrorIs(t, err, ErrInvalidMethod)
+	_, _, err = NewSession(userID, Details{Method: MethodOIDC}, time.Hour)
+	assert.ErrorIs(t, err, ErrMissingProvider)
+	_, _, err = NewSession(userID, Details{Method: MethodPassword}, 0)
+	assert.ErrorIs(t, err, ErrInvalidTTL)
+}
+
+func TestSession_Refresh(t *testing.T) {
+	t.Run("rotates the token and keeps the absolute expiry", func(t *testing.T) {
+		s, first, err := NewSession(uuid.New(), Details{Method: MethodOIDC, Provider: "google"}, time.Hour)
+		require.NoError(t, err)
+		expiresAt := s.ExpiresAt()
+
+		second, err := s.Refresh(HashToken(first), "198.51.100.4", time.Now())
+
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+		assert.Equal(t, HashToken(second), s.RefreshHash())
+		assert.Equal(t, HashToken(first), s.PreviousRefreshHash())
+		assert.Equal(t, "198.51.100.4", s.IP())
+		assert.NotNil(t, s.LastRefreshedAt())
+		assert.Equal(t, expiresAt, s.ExpiresAt())
+	})
+
+	t.Run("reusing a rotated token revokes the session", func(t *testing.T) {
+		s, first, err := NewSession(uuid.New(), Details{Method: MethodPassword}, time.Hour)
+		require.NoError(t, err)
+		second, err := s.Refresh(HashToken(first), "", time.Now())
+		require.NoError(t, err)
+
+		_, err = s.Refresh(HashToken(first), "", time.Now())
+
+		assert.ErrorIs(t, err, ErrRefreshTokenReuse)
+		assert.False(t, s.IsActive(time.Now()))
+		assert.Equal(t, ReasonTokenReuse, s.RevokeReason())
+		_, err = s.Refresh(HashToken(second), "", time.Now())
+		assert.ErrorIs(t, err, ErrSessionRevoked, "the legitimate holder is logged out too")
+	})
+
+	t.Run("expired and unknown tokens", func(t *testing.T) {
+		s, token, err := NewSession(uuid.New(), Details{Method: MethodPassword}, time.Hour)
+		require.NoError(t, err)
+
+		_, err = s.Refresh(HashToken("vrt_other"), "", time.Now())
+		assert.ErrorIs(t, err, ErrSessionNotFound)
+		_, err = s.Refresh(HashToken(token), "", s.ExpiresAt())
+		assert.ErrorIs(t, err, ErrSessionExpired)
+		assert.Nil(t, s.RevokedAt(), "expiry is not a revocation")
+	})
+}
+
+func TestSession_Revoke(t *testing.T) {
+	s, token, err := NewSession(uuid.New(), Details{Method: MethodPassword}, time.Hour)
+	require.NoError(t, err)
+
+	require.NoError(t, s.Revoke(ReasonLogout))
+	assert.ErrorIs(t, s.Revoke(ReasonLogout), ErrAlreadyRevoked)
+	assert.Equal(t, ReasonLogout, s.RevokeReason())
+	_, err = s.Refresh(HashToken(token), "", time.Now())
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
+
+func TestSession_Reauthenticate(t *testing.T) {
+	s, token, err := NewSession(uuid.New(), Details{Method: MethodPassword}, time.Hour)
+	require.NoError(t, err)
+	assert.False(t, s.IsTwoFactorVerified())
+	assert.True(t, s.ReauthenticatedWithin(5*time.Minute, time.Now()), "login counts as recent authentication")
+
+	later := time.Now().Add(20 * time.Minute)
+	_, err = s.Refresh(HashToken(token), "", later)
+	require.NoError(t, err)
+	assert.False(t, s.ReauthenticatedWithin(5*time.Minute, later), "refresh is not a re-authentication")
+
+	require.NoError(t, s.Reauthenticate(true, later))
+	assert.True(t, s.ReauthenticatedWithin(5*time.Minute, later))
+	assert.True(t, s.IsTwoFactorVerified())
+
+	require.NoError(t, s.Revoke(ReasonLogout))
+	assert.ErrorIs(t, s.Reauthenticate(false, later), ErrSessionRevoked)
+}
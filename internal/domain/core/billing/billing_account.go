@@ -17,6 +17,16 @@ const (
 	PaymentStatusCanceled  PaymentStatus = "canceled"
 )
 
+// AccountStanding situação da conta perante a cobrança: com fatura em atraso a conta passa a
+// somente leitura no fim da carência e depois é suspensa
+type AccountStanding string
+
+const (
+	StandingGood      AccountStanding = "good"
+	StandingReadOnly  AccountStanding = "read_only"
+	StandingSuspended AccountStanding = "suspended"
+)
+
 type PaymentMethod struct {
 	Type       string
 	LastDigits string
@@ -36,6 +46,7 @@ type BillingAccount struct {
 	suspended        bool
 	suspendedAt      *time.Time
 	suspensionReason string
+	readOnlyAt       *time.Time // Somente leitura por fatura em atraso (régua de cobrança)
 	createdAt        time.Time
 	updatedAt        time.Time
 
@@ -94,6 +105,7 @@ func ReconstructBillingAccount(
 	suspended bool,
 	suspendedAt *time.Time,
 	suspensionReason string,
+	readOnlyAt *time.Time,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *BillingAccount {
@@ -116,6 +128,7 @@ func ReconstructBillingAccount(
 		suspended:        suspended,
 		suspendedAt:      suspendedAt,
 		suspensionReason: suspensionReason,
+		readOnlyAt:       readOnlyAt,
 		createdAt:        createdAt,
 		updatedAt:        updatedAt,
 		events:           []shared.DomainEvent{},
@@ -178,6 +191,55 @@ func (b *BillingAccount) Reactivate() error {
 	return nil
 }
 
+// EnterReadOnly coloca a conta em modo somente leitura (fim da carência de uma fatura vencida).
+// Conta já suspensa ou já somente leitura não muda.
+func (b *BillingAccount) EnterReadOnly(reason string) {
+	if b.suspended || b.readOnlyAt != nil {
+		return
+	}
+
+	now := time.Now()
+	b.readOnlyAt = &now
+	b.updatedAt = now
+
+	b.addEvent(NewBillingAccountReadOnlyEvent(b.id, reason))
+}
+
+// RestoreAfterPayment tira a conta do modo somente leitura e da suspensão depois que as faturas em
+// atraso foram pagas. O pagamento vale como meio de pagamento ativo; conta cancelada não volta.
+// Retorna false quando não havia restrição a remover.
+func (b *BillingAccount) RestoreAfterPayment() bool {
+	if b.paymentStatus == PaymentStatusCanceled {
+		return false
+	}
+	if !b.suspended && b.readOnlyAt == nil {
+		return false
+	}
+
+	b.suspended = false
+	b.suspendedAt = nil
+	b.suspensionReason = ""
+	b.readOnlyAt = nil
+	b.paymentStatus = PaymentStatusActive
+	b.updatedAt = time.Now()
+
+	b.addEvent(NewBillingAccountReactivatedEvent(b.id))
+
+	return true
+}
+
+// Standing situação da conta: suspensa prevalece sobre somente leitura
+func (b *BillingAccount) Standing() AccountStanding {
+	switch {
+	case b.suspended:
+		return StandingSuspended
+	case b.readOnlyAt != nil:
+		return StandingReadOnly
+	default:
+		return StandingGood
+	}
+}
+
 func (b *BillingAccount) Cancel() {
 	now := time.Now()
 	b.paymentStatus = PaymentStatusCanceled
@@ -233,6 +295,8 @@ func (b *BillingAccount) BillingEmail() string     { return b.billingEmail }
 func (b *BillingAccount) IsSuspended() bool        { return b.suspended }
 func (b *BillingAccount) SuspendedAt() *time.Time  { return b.suspendedAt }
 func (b *BillingAccount) SuspensionReason() string { return b.suspensionReason }
+func (b *BillingAccount) ReadOnlyAt() *time.Time   { return b.readOnlyAt }
+func (b *BillingAccount) IsReadOnly() bool         { return b.readOnlyAt != nil }
 func (b *BillingAccount) CreatedAt() time.Time     { return b.createdAt }
 func (b *BillingAccount) UpdatedAt() time.Time     { return b.updatedAt }
 
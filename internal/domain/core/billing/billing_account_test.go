@@ -351,6 +351,7 @@ func TestReconstructBillingAccount(t *testing.T) {
 			false,
 			nil,
 			"",
+			nil, // readOnlyAt
 			createdAt,
 			updatedAt,
 		)
@@ -377,6 +378,7 @@ func TestReconstructBillingAccount(t *testing.T) {
 			true,
 			&suspendedAt,
 			"Payment failed",
+			nil, // readOnlyAt
 			createdAt,
 			updatedAt,
 		)
@@ -399,6 +401,7 @@ func TestReconstructBillingAccount(t *testing.T) {
 			false,
 			nil,
 			"",
+			nil, // readOnlyAt
 			createdAt,
 			updatedAt,
 		)
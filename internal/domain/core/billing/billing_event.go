@@ -0,0 +1,73 @@
+package billing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BillingEvent registro da linha do tempo de cobrança exibida ao cliente: lembretes de fatura em
+// atraso, bloqueios, pagamentos e reativação. O tipo é o nome do evento de domínio que originou
+// o registro (ex: billing.dunning.reminder_sent) e a mensagem é o texto mostrado ao cliente.
+type BillingEvent struct {
+	id               uuid.UUID
+	billingAccountID uuid.UUID
+	invoiceID        *uuid.UUID
+	eventType        string
+	message          string
+	data             map[string]interface{}
+	occurredAt       time.Time
+}
+
+// NewBillingEvent cria um registro da linha do tempo da conta
+func NewBillingEvent(billingAccountID uuid.UUID, invoiceID *uuid.UUID, eventType, message string, data map[string]interface{}, occurredAt time.Time) *BillingEvent {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+	return &BillingEvent{
+		id:               uuid.New(),
+		billingAccountID: billingAccountID,
+		invoiceID:        invoiceID,
+		eventType:        eventType,
+		message:          message,
+		data:             data,
+		occurredAt:       occurredAt,
+	}
+}
+
+// ReconstructBillingEvent reconstrói um registro do banco
+func ReconstructBillingEvent(id, billingAccountID uuid.UUID, invoiceID *uuid.UUID, eventType, message string, data map[string]interface{}, occurredAt time.Time) *BillingEvent {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return &BillingEvent{
+		id:               id,
+		billingAccountID: billingAccountID,
+		invoiceID:        invoiceID,
+		eventType:        eventType,
+		message:          message,
+		data:             data,
+		occurredAt:       occurredAt,
+	}
+}
+
+func (e *BillingEvent) ID() uuid.UUID                { return e.id }
+func (e *BillingEvent) BillingAccountID() uuid.UUID  { return e.billingAccountID }
+func (e *BillingEvent) InvoiceID() *uuid.UUID        { return e.invoiceID }
+func (e *BillingEvent) Type() string                 { return e.eventType }
+func (e *BillingEvent) Message() string              { return e.message }
+func (e *BillingEvent) Data() map[string]interface{} { return e.data }
+func (e *BillingEvent) OccurredAt() time.Time        { return e.occurredAt }
+
+// BillingEventRepository define operações de persistência da linha do tempo de cobrança
+type BillingEventRepository interface {
+	// Append grava um registro
+	Append(ctx context.Context, event *BillingEvent) error
+
+	// ListByAccount lista os registros da conta, mais recentes primeiro, com o total
+	ListByAccount(ctx context.Context, billingAccountID uuid.UUID, limit, offset int) ([]*BillingEvent, int64, error)
+}
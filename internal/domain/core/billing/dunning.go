@@ -0,0 +1,350 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+var (
+	ErrInvalidDunningPolicy = errors.New("invalid dunning policy")
+	ErrDunningCaseNotFound  = errors.New("dunning case not found")
+	ErrDunningCaseResolved  = errors.New("dunning case is already resolved")
+	ErrInvalidInvoiceID     = errors.New("invoice ID cannot be nil")
+)
+
+// DunningPolicy régua de cobrança de faturas em atraso, em dias depois do vencimento
+type DunningPolicy struct {
+	ReminderDays     []int // Lembretes por email (ex: 1, 3, 7)
+	GracePeriodDays  int   // Fim da carência: a conta passa a somente leitura
+	SuspendAfterDays int   // Suspensão: canais pausados e envios bloqueados
+}
+
+// DefaultDunningPolicy lembretes em 1, 3 e 7 dias, somente leitura em 7 e suspensão em 14
+func DefaultDunningPolicy() DunningPolicy {
+	return DunningPolicy{
+		ReminderDays:     []int{1, 3, 7},
+		GracePeriodDays:  7,
+		SuspendAfterDays: 14,
+	}
+}
+
+// Validate exige dias não negativos e suspensão depois (ou junto) do fim da carência
+func (p DunningPolicy) Validate() error {
+	if p.GracePeriodDays < 0 || p.SuspendAfterDays < p.GracePeriodDays {
+		return ErrInvalidDunningPolicy
+	}
+	for _, day := range p.ReminderDays {
+		if day < 0 {
+			return ErrInvalidDunningPolicy
+		}
+	}
+	return nil
+}
+
+// DunningAction ação de um passo da régua
+type DunningAction string
+
+const (
+	DunningActionReminder DunningAction = "reminder"
+	DunningActionReadOnly DunningAction = "read_only"
+	DunningActionSuspend  DunningAction = "suspend"
+)
+
+// rank ordem das ações no mesmo dia: o lembrete sai antes do bloqueio
+func (a DunningAction) rank() int {
+	switch a {
+	case DunningActionReminder:
+		return 0
+	case DunningActionReadOnly:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// DunningStep passo da régua: ação no dia Day depois do vencimento (instante At)
+type DunningStep struct {
+	Action DunningAction
+	Day    int
+	At     time.Time
+}
+
+// Steps passos da régua para o vencimento informado, em ordem cronológica
+func (p DunningPolicy) Steps(dueDate time.Time) []DunningStep {
+	seen := make(map[int]bool, len(p.ReminderDays))
+	steps := make([]DunningStep, 0, len(p.ReminderDays)+2)
+	for _, day := range p.ReminderDays {
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+		steps = append(steps, DunningStep{Action: DunningActionReminder, Day: day})
+	}
+	steps = append(steps,
+		DunningStep{Action: DunningActionReadOnly, Day: p.GracePeriodDays},
+		DunningStep{Action: DunningActionSuspend, Day: p.SuspendAfterDays},
+	)
+
+	sort.SliceStable(steps, func(i, j int) bool {
+		if steps[i].Day != steps[j].Day {
+			return steps[i].Day < steps[j].Day
+		}
+		return steps[i].Action.rank() < steps[j].Action.rank()
+	})
+	for i := range steps {
+		steps[i].At = dueDate.AddDate(0, 0, steps[i].Day)
+	}
+	return steps
+}
+
+// DunningStatus situação de uma régua
+type DunningStatus string
+
+const (
+	DunningStatusOpen     DunningStatus = "open"
+	DunningStatusResolved DunningStatus = "resolved"
+)
+
+const (
+	// DunningResolutionPaid fatura paga (invoice.paid)
+	DunningResolutionPaid = "invoice_paid"
+	// DunningResolutionVoided fatura cancelada ou paga por outro caminho
+	DunningResolutionVoided = "invoice_voided"
+)
+
+// DunningCase régua de cobrança de uma fatura em atraso: registra o que já foi feito (lembretes,
+// somente leitura, suspensão) para que cada passo rode uma única vez, venha o disparo do timer
+// Temporal ou da varredura periódica.
+type DunningCase struct {
+	id               uuid.UUID
+	version          int
+	billingAccountID uuid.UUID
+	invoiceID        uuid.UUID
+	dueDate          time.Time
+	status           DunningStatus
+	lastReminderDay  *int
+	remindersSent    int
+	readOnlyAt       *time.Time
+	suspendedAt      *time.Time
+	resolvedAt       *time.Time
+	resolution       string
+	createdAt        time.Time
+	updatedAt        time.Time
+
+	events []shared.DomainEvent
+}
+
+// NewDunningCase abre a régua de uma fatura vencida em dueDate
+func NewDunningCase(billingAccountID, invoiceID uuid.UUID, dueDate time.Time) (*DunningCase, error) {
+	if billingAccountID == uuid.Nil {
+		return nil, ErrInvalidBillingAccountID
+	}
+	if invoiceID == uuid.Nil {
+		return nil, ErrInvalidInvoiceID
+	}
+	if dueDate.IsZero() {
+		dueDate = time.Now()
+	}
+
+	now := time.Now()
+	c := &DunningCase{
+		id:               uuid.New(),
+		version:          1,
+		billingAccountID: billingAccountID,
+		invoiceID:        invoiceID,
+		dueDate:          dueDate,
+		status:           DunningStatusOpen,
+		createdAt:        now,
+		updatedAt:        now,
+		events:           []shared.DomainEvent{},
+	}
+	c.addEvent(NewDunningStartedEvent(c.id, billingAccountID, invoiceID, dueDate))
+	return c, nil
+}
+
+// ReconstructDunningCase reconstrói uma régua do banco
+func ReconstructDunningCase(
+	id uuid.UUID,
+	version int,
+	billingAccountID uuid.UUID,
+	invoiceID uuid.UUID,
+	dueDate time.Time,
+	status DunningStatus,
+	lastReminderDay *int,
+	remindersSent int,
+	readOnlyAt *time.Time,
+	suspendedAt *time.Time,
+	resolvedAt *time.Time,
+	resolution string,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *DunningCase {
+	if version == 0 {
+		version = 1
+	}
+	return &DunningCase{
+		id:               id,
+		version:          version,
+		billingAccountID: billingAccountID,
+		invoiceID:        invoiceID,
+		dueDate:          dueDate,
+		status:           status,
+		lastReminderDay:  lastReminderDay,
+		remindersSent:    remindersSent,
+		readOnlyAt:       readOnlyAt,
+		suspendedAt:      suspendedAt,
+		resolvedAt:       resolvedAt,
+		resolution:       resolution,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+		events:           []shared.DomainEvent{},
+	}
+}
+
+// PendingSteps passos vencidos em now e ainda não executados. Entre os lembretes só o mais
+// recente é devolvido: quem atrasou (varredura depois de uma queda, fatura descoberta tarde)
+// recebe um lembrete, não todos os perdidos.
+func (c *DunningCase) PendingSteps(policy DunningPolicy, now time.Time) []DunningStep {
+	if c.status != DunningStatusOpen {
+		return nil
+	}
+
+	var reminder *DunningStep
+	var pending []DunningStep
+	for _, step := range policy.Steps(c.dueDate) {
+		if step.At.After(now) {
+			break
+		}
+		switch step.Action {
+		case DunningActionReminder:
+			if c.lastReminderDay == nil || step.Day > *c.lastReminderDay {
+				s := step
+				reminder = &s
+			}
+		case DunningActionReadOnly:
+			if c.readOnlyAt == nil {
+				pending = append(pending, step)
+			}
+		case DunningActionSuspend:
+			if c.suspendedAt == nil {
+				pending = append(pending, step)
+			}
+		}
+	}
+	if reminder != nil {
+		pending = append([]DunningStep{*reminder}, pending...)
+	}
+	return pending
+}
+
+// RecordReminder registra o lembrete do dia day da régua
+func (c *DunningCase) RecordReminder(day int, now time.Time) error {
+	if c.status != DunningStatusOpen {
+		return ErrDunningCaseResolved
+	}
+	c.lastReminderDay = &day
+	c.remindersSent++
+	c.updatedAt = now
+
+	c.addEvent(NewDunningReminderSentEvent(c.id, c.billingAccountID, c.invoiceID, day, c.DaysOverdue(now)))
+	return nil
+}
+
+// RecordReadOnly registra que a conta passou a somente leitura por esta fatura
+func (c *DunningCase) RecordReadOnly(now time.Time) error {
+	if c.status != DunningStatusOpen {
+		return ErrDunningCaseResolved
+	}
+	if c.readOnlyAt == nil {
+		c.readOnlyAt = &now
+		c.updatedAt = now
+	}
+	return nil
+}
+
+// RecordSuspension registra que a conta foi suspensa por esta fatura
+func (c *DunningCase) RecordSuspension(now time.Time) error {
+	if c.status != DunningStatusOpen {
+		return ErrDunningCaseResolved
+	}
+	if c.suspendedAt == nil {
+		c.suspendedAt = &now
+		c.updatedAt = now
+	}
+	return nil
+}
+
+// Resolve encerra a régua
+func (c *DunningCase) Resolve(resolution string, now time.Time) error {
+	if c.status != DunningStatusOpen {
+		return ErrDunningCaseResolved
+	}
+	c.status = DunningStatusResolved
+	c.resolution = resolution
+	c.resolvedAt = &now
+	c.updatedAt = now
+
+	c.addEvent(NewDunningResolvedEvent(c.id, c.billingAccountID, c.invoiceID, resolution))
+	return nil
+}
+
+// DaysOverdue dias completos de atraso em now
+func (c *DunningCase) DaysOverdue(now time.Time) int {
+	if !now.After(c.dueDate) {
+		return 0
+	}
+	return int(now.Sub(c.dueDate) / (24 * time.Hour))
+}
+
+func (c *DunningCase) ID() uuid.UUID               { return c.id }
+func (c *DunningCase) Version() int                { return c.version }
+func (c *DunningCase) BillingAccountID() uuid.UUID { return c.billingAccountID }
+func (c *DunningCase) InvoiceID() uuid.UUID        { return c.invoiceID }
+func (c *DunningCase) DueDate() time.Time          { return c.dueDate }
+func (c *DunningCase) Status() DunningStatus       { return c.status }
+func (c *DunningCase) IsOpen() bool                { return c.status == DunningStatusOpen }
+func (c *DunningCase) LastReminderDay() *int       { return c.lastReminderDay }
+func (c *DunningCase) RemindersSent() int          { return c.remindersSent }
+func (c *DunningCase) ReadOnlyAt() *time.Time      { return c.readOnlyAt }
+func (c *DunningCase) SuspendedAt() *time.Time     { return c.suspendedAt }
+func (c *DunningCase) ResolvedAt() *time.Time      { return c.resolvedAt }
+func (c *DunningCase) Resolution() string          { return c.resolution }
+func (c *DunningCase) CreatedAt() time.Time        { return c.createdAt }
+func (c *DunningCase) UpdatedAt() time.Time        { return c.updatedAt }
+
+func (c *DunningCase) DomainEvents() []shared.DomainEvent {
+	return append([]shared.DomainEvent{}, c.events...)
+}
+
+func (c *DunningCase) ClearEvents() {
+	c.events = []shared.DomainEvent{}
+}
+
+func (c *DunningCase) addEvent(event shared.DomainEvent) {
+	c.events = append(c.events, event)
+}
+
+var _ shared.AggregateRoot = (*DunningCase)(nil)
+
+// DunningCaseRepository define operações de persistência para as réguas de cobrança
+type DunningCaseRepository interface {
+	// Save cria ou atualiza a régua (optimistic locking pela versão)
+	Save(ctx context.Context, c *DunningCase) error
+
+	// FindByID busca uma régua por ID (ErrDunningCaseNotFound)
+	FindByID(ctx context.Context, id uuid.UUID) (*DunningCase, error)
+
+	// FindOpenByInvoice busca a régua aberta da fatura (ErrDunningCaseNotFound)
+	FindOpenByInvoice(ctx context.Context, invoiceID uuid.UUID) (*DunningCase, error)
+
+	// FindOpenByAccount busca as réguas abertas da conta
+	FindOpenByAccount(ctx context.Context, billingAccountID uuid.UUID) ([]*DunningCase, error)
+
+	// FindOpen busca réguas abertas, das mais antigas para as mais novas
+	FindOpen(ctx context.Context, limit int) ([]*DunningCase, error)
+}
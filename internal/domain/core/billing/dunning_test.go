@@ -0,0 +1,141 @@
+package billing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDunningPolicy_Validate(t *testing.T) {
+	assert.NoError(t, DefaultDunningPolicy().Validate())
+	assert.ErrorIs(t, DunningPolicy{GracePeriodDays: 10, SuspendAfterDays: 5}.Validate(), ErrInvalidDunningPolicy)
+	assert.ErrorIs(t, DunningPolicy{ReminderDays: []int{-1}, GracePeriodDays: 1, SuspendAfterDays: 2}.Validate(), ErrInvalidDunningPolicy)
+}
+
+func TestDunningPolicy_Steps(t *testing.T) {
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := DunningPolicy{ReminderDays: []int{7, 1, 3, 7}, GracePeriodDays: 7, SuspendAfterDays: 14}
+
+	steps := policy.Steps(due)
+
+	require.Len(t, steps, 5)
+	assert.Equal(t, DunningStep{Action: DunningActionReminder, Day: 1, At: due.AddDate(0, 0, 1)}, steps[0])
+	assert.Equal(t, DunningStep{Action: DunningActionReminder, Day: 3, At: due.AddDate(0, 0, 3)}, steps[1])
+	// No mesmo dia o lembrete sai antes do bloqueio
+	assert.Equal(t, DunningStep{Action: DunningActionReminder, Day: 7, At: due.AddDate(0, 0, 7)}, steps[2])
+	assert.Equal(t, DunningStep{Action: DunningActionReadOnly, Day: 7, At: due.AddDate(0, 0, 7)}, steps[3])
+	assert.Equal(t, DunningStep{Action: DunningActionSuspend, Day: 14, At: due.AddDate(0, 0, 14)}, steps[4])
+}
+
+func TestNewDunningCase(t *testing.T) {
+	due := time.Now().Add(-time.Hour)
+
+	c, err := NewDunningCase(uuid.New(), uuid.New(), due)
+	require.NoError(t, err)
+	assert.True(t, c.IsOpen())
+	assert.Equal(t, due, c.DueDate())
+	require.Len(t, c.DomainEvents(), 1)
+	assert.Equal(t, "billing.dunning.started", c.DomainEvents()[0].EventName())
+
+	_, err = NewDunningCase(uuid.Nil, uuid.New(), due)
+	assert.ErrorIs(t, err, ErrInvalidBillingAccountID)
+	_, err = NewDunningCase(uuid.New(), uuid.Nil, due)
+	assert.ErrorIs(t, err, ErrInvalidInvoiceID)
+}
+
+func TestDunningCase_PendingSteps(t *testing.T) {
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	policy := DefaultDunningPolicy()
+	c, err := NewDunningCase(uuid.New(), uuid.New(), due)
+	require.NoError(t, err)
+
+	t.Run("nothing before the first reminder", func(t *testing.T) {
+		assert.Empty(t, c.PendingSteps(policy, due.Add(23*time.Hour)))
+	})
+
+	t.Run("only the latest missed reminder is pending", func(t *testing.T) {
+		steps := c.PendingSteps(policy, due.AddDate(0, 0, 4))
+		require.Len(t, steps, 1)
+		assert.Equal(t, DunningActionReminder, steps[0].Action)
+		assert.Equal(t, 3, steps[0].Day)
+
+		require.NoError(t, c.RecordReminder(3, due.AddDate(0, 0, 4)))
+		assert.Empty(t, c.PendingSteps(policy, due.AddDate(0, 0, 4)))
+	})
+
+	t.Run("grace period end brings reminder and read-only", func(t *testing.T) {
+		steps := c.PendingSteps(policy, due.AddDate(0, 0, 7))
+		require.Len(t, steps, 2)
+		assert.Equal(t, DunningActionReminder, steps[0].Action)
+		assert.Equal(t, DunningActionReadOnly, steps[1].Action)
+
+		now := due.AddDate(0, 0, 7)
+		require.NoError(t, c.RecordReminder(7, now))
+		require.NoError(t, c.RecordReadOnly(now))
+		assert.Empty(t, c.PendingSteps(policy, now.Add(time.Hour)))
+	})
+
+	t.Run("suspension after the policy days", func(t *testing.T) {
+		now := due.AddDate(0, 0, 20)
+		steps := c.PendingSteps(policy, now)
+		require.Len(t, steps, 1)
+		assert.Equal(t, DunningActionSuspend, steps[0].Action)
+
+		require.NoError(t, c.RecordSuspension(now))
+		assert.Empty(t, c.PendingSteps(policy, now))
+		assert.Equal(t, 2, c.RemindersSent())
+	})
+
+	t.Run("resolved case has no steps", func(t *testing.T) {
+		require.NoError(t, c.Resolve(DunningResolutionPaid, due.AddDate(0, 0, 21)))
+		assert.False(t, c.IsOpen())
+		assert.Empty(t, c.PendingSteps(policy, due.AddDate(0, 0, 30)))
+		assert.ErrorIs(t, c.Resolve(DunningResolutionPaid, due.AddDate(0, 0, 22)), ErrDunningCaseResolved)
+		assert.ErrorIs(t, c.RecordReminder(20, due.AddDate(0, 0, 22)), ErrDunningCaseResolved)
+	})
+}
+
+func TestDunningCase_DaysOverdue(t *testing.T) {
+	due := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	c, err := NewDunningCase(uuid.New(), uuid.New(), due)
+	require.NoError(t, err)
+
+	assert.Equal(t, 0, c.DaysOverdue(due.Add(-time.Hour)))
+	assert.Equal(t, 0, c.DaysOverdue(due.Add(23*time.Hour)))
+	assert.Equal(t, 3, c.DaysOverdue(due.AddDate(0, 0, 3).Add(time.Hour)))
+}
+
+func TestBillingAccount_ReadOnlyAndRestore(t *testing.T) {
+	account, err := NewBillingAccount(uuid.New(), "Account", "billing@example.com")
+	require.NoError(t, err)
+	account.ClearEvents()
+	assert.Equal(t, StandingGood, account.Standing())
+
+	account.EnterReadOnly("invoice overdue")
+	assert.True(t, account.IsReadOnly())
+	assert.Equal(t, StandingReadOnly, account.Standing())
+	account.EnterReadOnly("again")
+	require.Len(t, account.DomainEvents(), 1)
+	assert.Equal(t, "billing.account.read_only", account.DomainEvents()[0].EventName())
+
+	account.Suspend("invoice overdue")
+	assert.Equal(t, StandingSuspended, account.Standing())
+
+	// Sem meio de pagamento cadastrado: o pagamento da fatura basta para reativar
+	account.ClearEvents()
+	assert.True(t, account.RestoreAfterPayment())
+	assert.Equal(t, StandingGood, account.Standing())
+	assert.False(t, account.IsReadOnly())
+	assert.Equal(t, PaymentStatusActive, account.PaymentStatus())
+	require.Len(t, account.DomainEvents(), 1)
+	assert.Equal(t, "billing.account.reactivated", account.DomainEvents()[0].EventName())
+	assert.False(t, account.RestoreAfterPayment())
+
+	// Conta cancelada não volta
+	account.Cancel()
+	assert.False(t, account.RestoreAfterPayment())
+	assert.True(t, account.IsSuspended())
+}
@@ -68,6 +68,21 @@ func NewBillingAccountReactivatedEvent(accountID uuid.UUID) BillingAccountReacti
 	}
 }
 
+// BillingAccountReadOnlyEvent conta passou a somente leitura (fim da carência de uma fatura vencida)
+type BillingAccountReadOnlyEvent struct {
+	shared.BaseEvent
+	AccountID uuid.UUID
+	Reason    string
+}
+
+func NewBillingAccountReadOnlyEvent(accountID uuid.UUID, reason string) BillingAccountReadOnlyEvent {
+	return BillingAccountReadOnlyEvent{
+		BaseEvent: shared.NewBaseEvent("billing.account.read_only", time.Now()),
+		AccountID: accountID,
+		Reason:    reason,
+	}
+}
+
 type BillingAccountCanceledEvent struct {
 	shared.BaseEvent
 	AccountID uuid.UUID
@@ -363,3 +378,114 @@ func NewUsagePeriodResetEvent(meterID uuid.UUID, metricName string, oldQuantity
 		NewPeriodEnd:   newPeriodEnd,
 	}
 }
+
+// Plan limit events
+
+// UsageSoftLimitReachedEvent consumo da conta atingiu o percentual de aviso do limite do plano
+type UsageSoftLimitReachedEvent struct {
+	shared.BaseEvent
+	BillingAccountID uuid.UUID
+	ProjectID        uuid.UUID
+	PlanKey          string
+	Metric           UsageMetric
+	Usage            int64
+	SoftLimit        int64
+	HardLimit        int64
+}
+
+func NewUsageSoftLimitReachedEvent(billingAccountID, projectID uuid.UUID, planKey string, metric UsageMetric, usage, softLimit, hardLimit int64) UsageSoftLimitReachedEvent {
+	return UsageSoftLimitReachedEvent{
+		BaseEvent:        shared.NewBaseEvent("billing.usage.soft_limit_reached", time.Now()),
+		BillingAccountID: billingAccountID,
+		ProjectID:        projectID,
+		PlanKey:          planKey,
+		Metric:           metric,
+		Usage:            usage,
+		SoftLimit:        softLimit,
+		HardLimit:        hardLimit,
+	}
+}
+
+// UsageLimitReachedEvent consumo da conta atingiu o limite rígido do plano (novas ações são bloqueadas)
+type UsageLimitReachedEvent struct {
+	shared.BaseEvent
+	BillingAccountID uuid.UUID
+	ProjectID        uuid.UUID
+	PlanKey          string
+	Metric           UsageMetric
+	Usage            int64
+	HardLimit        int64
+}
+
+func NewUsageLimitReachedEvent(billingAccountID, projectID uuid.UUID, planKey string, metric UsageMetric, usage, hardLimit int64) UsageLimitReachedEvent {
+	return UsageLimitReachedEvent{
+		BaseEvent:        shared.NewBaseEvent("billing.usage.limit_reached", time.Now()),
+		BillingAccountID: billingAccountID,
+		ProjectID:        projectID,
+		PlanKey:          planKey,
+		Metric:           metric,
+		Usage:            usage,
+		HardLimit:        hardLimit,
+	}
+}
+
+// Dunning events
+
+// DunningStartedEvent régua de cobrança aberta para uma fatura vencida ou com pagamento recusado
+type DunningStartedEvent struct {
+	shared.BaseEvent
+	CaseID           uuid.UUID
+	BillingAccountID uuid.UUID
+	InvoiceID        uuid.UUID
+	DueDate          time.Time
+}
+
+func NewDunningStartedEvent(caseID, billingAccountID, invoiceID uuid.UUID, dueDate time.Time) DunningStartedEvent {
+	return DunningStartedEvent{
+		BaseEvent:        shared.NewBaseEvent("billing.dunning.started", time.Now()),
+		CaseID:           caseID,
+		BillingAccountID: billingAccountID,
+		InvoiceID:        invoiceID,
+		DueDate:          dueDate,
+	}
+}
+
+// DunningReminderSentEvent lembrete de fatura em atraso enviado ao email de cobrança
+type DunningReminderSentEvent struct {
+	shared.BaseEvent
+	CaseID           uuid.UUID
+	BillingAccountID uuid.UUID
+	InvoiceID        uuid.UUID
+	Day              int // Dia da régua (dias depois do vencimento)
+	DaysOverdue      int
+}
+
+func NewDunningReminderSentEvent(caseID, billingAccountID, invoiceID uuid.UUID, day, daysOverdue int) DunningReminderSentEvent {
+	return DunningReminderSentEvent{
+		BaseEvent:        shared.NewBaseEvent("billing.dunning.reminder_sent", time.Now()),
+		CaseID:           caseID,
+		BillingAccountID: billingAccountID,
+		InvoiceID:        invoiceID,
+		Day:              day,
+		DaysOverdue:      daysOverdue,
+	}
+}
+
+// DunningResolvedEvent régua encerrada (fatura paga ou cancelada)
+type DunningResolvedEvent struct {
+	shared.BaseEvent
+	CaseID           uuid.UUID
+	BillingAccountID uuid.UUID
+	InvoiceID        uuid.UUID
+	Resolution       string
+}
+
+func NewDunningResolvedEvent(caseID, billingAccountID, invoiceID uuid.UUID, resolution string) DunningResolvedEvent {
+	return DunningResolvedEvent{
+		BaseEvent:        shared.NewBaseEvent("billing.dunning.resolved", time.Now()),
+		CaseID:           caseID,
+		BillingAccountID: billingAccountID,
+		InvoiceID:        invoiceID,
+		Resolution:       resolution,
+	}
+}
@@ -0,0 +1,306 @@
+package billing
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UsageMetric dimensão de uso limitada pelo plano
+type UsageMetric string
+
+const (
+	MetricChannels   UsageMetric = "channels"      // canais cadastrados
+	MetricContacts   UsageMetric = "contacts"      // contatos cadastrados
+	MetricAgents     UsageMetric = "agents"        // agentes humanos ativos
+	MetricMessages   UsageMetric = "messages_sent" // mensagens enviadas no período de cobrança
+	MetricAIMinutes  UsageMetric = "ai_minutes"    // minutos de processamento de IA no período de cobrança
+	MetricBroadcasts UsageMetric = "broadcasts"    // broadcasts disparados no dia
+)
+
+// UsageWindow janela em que o consumo de uma métrica é acumulado
+type UsageWindow string
+
+const (
+	// WindowNone métricas de estoque: o uso é a contagem atual dos recursos
+	WindowNone UsageWindow = ""
+	// WindowBillingPeriod zera a cada período da assinatura (ou mês civil sem assinatura)
+	WindowBillingPeriod UsageWindow = "billing_period"
+	// WindowDay zera todo dia (UTC)
+	WindowDay UsageWindow = "day"
+)
+
+// AllMetrics métricas na ordem em que aparecem para o cliente
+func AllMetrics() []UsageMetric {
+	return []UsageMetric{MetricChannels, MetricContacts, MetricAgents, MetricMessages, MetricAIMinutes, MetricBroadcasts}
+}
+
+// MeteredMetrics métricas acumuladas em usage meters e reportadas ao Stripe
+func MeteredMetrics() []UsageMetric {
+	var metered []UsageMetric
+	for _, m := range AllMetrics() {
+		if m.IsMetered() {
+			metered = append(metered, m)
+		}
+	}
+	return metered
+}
+
+func (m UsageMetric) IsValid() bool {
+	for _, valid := range AllMetrics() {
+		if m == valid {
+			return true
+		}
+	}
+	return false
+}
+
+func (m UsageMetric) Window() UsageWindow {
+	switch m {
+	case MetricMessages, MetricAIMinutes:
+		return WindowBillingPeriod
+	case MetricBroadcasts:
+		return WindowDay
+	default:
+		return WindowNone
+	}
+}
+
+// IsMetered consumo acumulado por período (as demais são contagens de recursos)
+func (m UsageMetric) IsMetered() bool {
+	return m.Window() != WindowNone
+}
+
+// StripeEventName event_name do Billing Meter do Stripe que recebe o consumo da métrica
+func (m UsageMetric) StripeEventName() string {
+	return "ventros_" + string(m)
+}
+
+// DayWindow janela diária (UTC) que contém o instante
+func DayWindow(at time.Time) (time.Time, time.Time) {
+	start := time.Date(at.UTC().Year(), at.UTC().Month(), at.UTC().Day(), 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 0, 1)
+}
+
+// MonthWindow mês civil (UTC) que contém o instante; período de quem não tem assinatura
+func MonthWindow(at time.Time) (time.Time, time.Time) {
+	start := time.Date(at.UTC().Year(), at.UTC().Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// Unlimited limite sem teto
+const Unlimited int64 = -1
+
+// DefaultSoftLimitPercent percentual do limite em que o aviso de consumo é enviado
+const DefaultSoftLimitPercent = 80
+
+// Entitlements limites do plano. Unlimited (-1) não limita; 0 bloqueia o recurso.
+type Entitlements struct {
+	MaxChannels      int64 `json:"max_channels"`
+	MaxContacts      int64 `json:"max_contacts"`
+	MaxAgents        int64 `json:"max_agents"`
+	MonthlyMessages  int64 `json:"monthly_messages"`
+	AIMinutes        int64 `json:"ai_minutes"`
+	BroadcastsPerDay int64 `json:"broadcasts_per_day"`
+}
+
+// Limit limite rígido da métrica
+func (e Entitlements) Limit(metric UsageMetric) int64 {
+	switch metric {
+	case MetricChannels:
+		return e.MaxChannels
+	case MetricContacts:
+		return e.MaxContacts
+	case MetricAgents:
+		return e.MaxAgents
+	case MetricMessages:
+		return e.MonthlyMessages
+	case MetricAIMinutes:
+		return e.AIMinutes
+	case MetricBroadcasts:
+		return e.BroadcastsPerDay
+	default:
+		return Unlimited
+	}
+}
+
+func (e Entitlements) validate() error {
+	for _, metric := range AllMetrics() {
+		if e.Limit(metric) < Unlimited {
+			return fmt.Errorf("%w: %s", ErrInvalidEntitlement, metric)
+		}
+	}
+	return nil
+}
+
+var (
+	ErrPlanNotFound            = errors.New("plan not found")
+	ErrInvalidPlanKey          = errors.New("plan key must be lowercase letters, digits, '-' or '_'")
+	ErrInvalidPlanName         = errors.New("plan name cannot be empty")
+	ErrInvalidEntitlement      = errors.New("plan limit must be -1 (unlimited) or greater")
+	ErrInvalidSoftLimitPercent = errors.New("soft limit percent must be between 1 and 100")
+)
+
+var planKeyPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,49}$`)
+
+// Plan plano comercial: limites de uso vinculados ao price do Stripe da assinatura.
+// O plano padrão vale para contas sem assinatura ativa ou com price sem plano cadastrado.
+type Plan struct {
+	id               uuid.UUID
+	key              string
+	name             string
+	stripePriceID    string
+	entitlements     Entitlements
+	softLimitPercent int
+	isDefault        bool
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+// NewPlan cria um plano; softLimitPercent 0 usa DefaultSoftLimitPercent
+func NewPlan(key, name, stripePriceID string, entitlements Entitlements, softLimitPercent int) (*Plan, error) {
+	key = strings.TrimSpace(key)
+	name = strings.TrimSpace(name)
+	if !planKeyPattern.MatchString(key) {
+		return nil, ErrInvalidPlanKey
+	}
+	if name == "" {
+		return nil, ErrInvalidPlanName
+	}
+	if err := entitlements.validate(); err != nil {
+		return nil, err
+	}
+	if softLimitPercent == 0 {
+		softLimitPercent = DefaultSoftLimitPercent
+	}
+	if softLimitPercent < 1 || softLimitPercent > 100 {
+		return nil, ErrInvalidSoftLimitPercent
+	}
+
+	now := time.Now()
+	return &Plan{
+		id:               uuid.New(),
+		key:              key,
+		name:             name,
+		stripePriceID:    strings.TrimSpace(stripePriceID),
+		entitlements:     entitlements,
+		softLimitPercent: softLimitPercent,
+		createdAt:        now,
+		updatedAt:        now,
+	}, nil
+}
+
+// ReconstructPlan reconstrói um plano do banco
+func ReconstructPlan(
+	id uuid.UUID,
+	key string,
+	name string,
+	stripePriceID string,
+	entitlements Entitlements,
+	softLimitPercent int,
+	isDefault bool,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Plan {
+	if softLimitPercent == 0 {
+		softLimitPercent = DefaultSoftLimitPercent
+	}
+	return &Plan{
+		id:               id,
+		key:              key,
+		name:             name,
+		stripePriceID:    stripePriceID,
+		entitlements:     entitlements,
+		softLimitPercent: softLimitPercent,
+		isDefault:        isDefault,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+	}
+}
+
+// MarkAsDefault torna o plano o padrão das contas sem assinatura
+func (p *Plan) MarkAsDefault() {
+	p.isDefault = true
+	p.updatedAt = time.Now()
+}
+
+// UpdateEntitlements troca os limites do plano
+func (p *Plan) UpdateEntitlements(entitlements Entitlements) error {
+	if err := entitlements.validate(); err != nil {
+		return err
+	}
+	p.entitlements = entitlements
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// HardLimit limite a partir do qual a ação é bloqueada
+func (p *Plan) HardLimit(metric UsageMetric) int64 {
+	return p.entitlements.Limit(metric)
+}
+
+// SoftLimit consumo a partir do qual a conta é avisada (Unlimited quando a métrica não tem teto)
+func (p *Plan) SoftLimit(metric UsageMetric) int64 {
+	hard := p.HardLimit(metric)
+	if hard == Unlimited {
+		return Unlimited
+	}
+	return hard * int64(p.softLimitPercent) / 100
+}
+
+// CheckQuota verifica se cabem mais requested unidades sobre o uso atual
+func (p *Plan) CheckQuota(metric UsageMetric, current, requested int64) error {
+	hard := p.HardLimit(metric)
+	if hard == Unlimited || current+requested <= hard {
+		return nil
+	}
+	return &QuotaExceededError{
+		Metric:    metric,
+		PlanKey:   p.key,
+		Limit:     hard,
+		Current:   current,
+		Requested: requested,
+	}
+}
+
+// CrossedLimits indica quais limites o consumo atravessou ao passar de before para after
+func (p *Plan) CrossedLimits(metric UsageMetric, before, after int64) (soft, hard bool) {
+	crossed := func(limit int64) bool {
+		return limit != Unlimited && limit > 0 && before < limit && after >= limit
+	}
+	return crossed(p.SoftLimit(metric)), crossed(p.HardLimit(metric))
+}
+
+func (p *Plan) ID() uuid.UUID              { return p.id }
+func (p *Plan) Key() string                { return p.key }
+func (p *Plan) Name() string               { return p.name }
+func (p *Plan) StripePriceID() string      { return p.stripePriceID }
+func (p *Plan) Entitlements() Entitlements { return p.entitlements }
+func (p *Plan) SoftLimitPercent() int      { return p.softLimitPercent }
+func (p *Plan) IsDefault() bool            { return p.isDefault }
+func (p *Plan) CreatedAt() time.Time       { return p.createdAt }
+func (p *Plan) UpdatedAt() time.Time       { return p.updatedAt }
+
+// QuotaExceededError limite rígido do plano atingido
+type QuotaExceededError struct {
+	Metric    UsageMetric
+	PlanKey   string
+	Limit     int64
+	Current   int64
+	Requested int64
+}
+
+// ErrQuotaExceeded permite errors.Is(err, ErrQuotaExceeded) para qualquer métrica
+var ErrQuotaExceeded = errors.New("plan quota exceeded")
+
+func (e *QuotaExceededError) Error() string {
+	return fmt.Sprintf("plan %q allows %d %s (current usage: %d)", e.PlanKey, e.Limit, e.Metric, e.Current)
+}
+
+func (e *QuotaExceededError) Is(target error) bool {
+	return target == ErrQuotaExceeded
+}
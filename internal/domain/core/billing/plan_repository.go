@@ -0,0 +1,18 @@
+package billing
+
+import "context"
+
+// PlanRepository define operações de persistência para o catálogo de planos
+type PlanRepository interface {
+	// Save cria ou atualiza um plano
+	Save(ctx context.Context, plan *Plan) error
+
+	// FindByStripePriceID busca o plano vinculado ao price do Stripe (ErrPlanNotFound se não há)
+	FindByStripePriceID(ctx context.Context, stripePriceID string) (*Plan, error)
+
+	// FindDefault busca o plano das contas sem assinatura (ErrPlanNotFound se não há)
+	FindDefault(ctx context.Context) (*Plan, error)
+
+	// List lista os planos
+	List(ctx context.Context) ([]*Plan, error)
+}
@@ -0,0 +1,126 @@
+package billing
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testEntitlements() Entitlements {
+	return Entitlements{
+		MaxChannels:      2,
+		MaxContacts:      100,
+		MaxAgents:        3,
+		MonthlyMessages:  1000,
+		AIMinutes:        Unlimited,
+		BroadcastsPerDay: 0,
+	}
+}
+
+func TestNewPlan(t *testing.T) {
+	plan, err := NewPlan(" starter ", " Starter ", "price_123", testEntitlements(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "starter", plan.Key())
+	assert.Equal(t, "Starter", plan.Name())
+	assert.Equal(t, DefaultSoftLimitPercent, plan.SoftLimitPercent())
+	assert.False(t, plan.IsDefault())
+
+	cases := []struct {
+		key, name    string
+		entitlements Entitlements
+		percent      int
+		err          error
+	}{
+		{"Starter", "Starter", testEntitlements(), 0, ErrInvalidPlanKey},
+		{"starter", "", testEntitlements(), 0, ErrInvalidPlanName},
+		{"starter", "Starter", Entitlements{MaxChannels: -2}, 0, ErrInvalidEntitlement},
+		{"starter", "Starter", testEntitlements(), 101, ErrInvalidSoftLimitPercent},
+	}
+	for _, tc := range cases {
+		_, err := NewPlan(tc.key, tc.name, "", tc.entitlements, tc.percent)
+		assert.ErrorIs(t, err, tc.err)
+	}
+}
+
+func TestPlan_CheckQuota(t *testing.T) {
+	plan, err := NewPlan("starter", "Starter", "", testEntitlements(), 80)
+	require.NoError(t, err)
+
+	assert.NoError(t, plan.CheckQuota(MetricContacts, 99, 1))
+	assert.NoError(t, plan.CheckQuota(MetricAIMinutes, 1_000_000, 10), "unlimited")
+
+	err = plan.CheckQuota(MetricContacts, 100, 1)
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, ErrQuotaExceeded))
+	var exceeded *QuotaExceededError
+	require.True(t, errors.As(err, &exceeded))
+	assert.Equal(t, MetricContacts, exceeded.Metric)
+	assert.Equal(t, int64(100), exceeded.Limit)
+	assert.Equal(t, "starter", exceeded.PlanKey)
+
+	// Limite 0 bloqueia o recurso
+	assert.ErrorIs(t, plan.CheckQuota(MetricBroadcasts, 0, 1), ErrQuotaExceeded)
+}
+
+func TestPlan_CrossedLimits(t *testing.T) {
+	plan, err := NewPlan("starter", "Starter", "", testEntitlements(), 80)
+	require.NoError(t, err)
+	assert.Equal(t, int64(800), plan.SoftLimit(MetricMessages))
+	assert.Equal(t, Unlimited, plan.SoftLimit(MetricAIMinutes))
+
+	soft, hard := plan.CrossedLimits(MetricMessages, 799, 800)
+	assert.True(t, soft)
+	assert.False(t, hard)
+
+	// Só avisa na travessia, não a cada ação acima do limite
+	soft, hard = plan.CrossedLimits(MetricMessages, 800, 801)
+	assert.False(t, soft)
+	assert.False(t, hard)
+
+	soft, hard = plan.CrossedLimits(MetricMessages, 700, 1000)
+	assert.True(t, soft)
+	assert.True(t, hard)
+
+	soft, hard = plan.CrossedLimits(MetricAIMinutes, 0, 1_000_000)
+	assert.False(t, soft)
+	assert.False(t, hard)
+}
+
+func TestUsageMetric_Window(t *testing.T) {
+	assert.Equal(t, WindowBillingPeriod, MetricMessages.Window())
+	assert.Equal(t, WindowDay, MetricBroadcasts.Window())
+	assert.Equal(t, WindowNone, MetricContacts.Window())
+	assert.Equal(t, []UsageMetric{MetricMessages, MetricAIMinutes, MetricBroadcasts}, MeteredMetrics())
+	assert.Equal(t, "ventros_messages_sent", MetricMessages.StripeEventName())
+
+	at := time.Date(2026, 2, 14, 23, 30, 0, 0, time.FixedZone("BRT", -3*3600))
+	start, end := DayWindow(at)
+	assert.Equal(t, time.Date(2026, 2, 15, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, start.AddDate(0, 0, 1), end)
+
+	start, end = MonthWindow(at)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), end)
+}
+
+func TestNewUsageRecord(t *testing.T) {
+	accountID, projectID := uuid.New(), uuid.New()
+	record, err := NewUsageRecord(accountID, projectID, MetricMessages, "msg-1", 2, time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), record.Quantity())
+	assert.False(t, record.OccurredAt().IsZero())
+	assert.Nil(t, record.ReportedAt())
+
+	_, err = NewUsageRecord(accountID, projectID, "tokens", "msg-1", 1, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidUsageMetric)
+	_, err = NewUsageRecord(accountID, projectID, MetricMessages, " ", 1, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidUsageSource)
+	_, err = NewUsageRecord(accountID, projectID, MetricMessages, "msg-1", 0, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidUsageQuantity)
+	_, err = NewUsageRecord(accountID, uuid.Nil, MetricMessages, "msg-1", 1, time.Now())
+	assert.ErrorIs(t, err, ErrInvalidUsageProject)
+}
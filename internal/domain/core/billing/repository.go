@@ -15,6 +15,9 @@ type Repository interface {
 
 	FindActiveByUserID(ctx context.Context, userID uuid.UUID) (*BillingAccount, error)
 
+	// FindByStripeCustomerID busca a conta vinculada ao customer do Stripe (ErrNotFound)
+	FindByStripeCustomerID(ctx context.Context, stripeCustomerID string) (*BillingAccount, error)
+
 	Update(ctx context.Context, account *BillingAccount) error
 
 	Delete(ctx context.Context, id uuid.UUID) error
@@ -29,14 +29,13 @@ type UsageMeter struct {
 }
 
 var (
-	ErrInvalidStripeCustomerID = errors.New("stripe customer ID cannot be empty")
-	ErrInvalidStripeMeterID    = errors.New("stripe meter ID cannot be empty")
-	ErrInvalidMetricName       = errors.New("metric name cannot be empty")
-	ErrInvalidEventName        = errors.New("event name cannot be empty")
-	ErrNegativeQuantity        = errors.New("quantity cannot be negative")
+	ErrInvalidMetricName = errors.New("metric name cannot be empty")
+	ErrInvalidEventName  = errors.New("event name cannot be empty")
+	ErrNegativeQuantity  = errors.New("quantity cannot be negative")
 )
 
-// NewUsageMeter cria um novo medidor de uso
+// NewUsageMeter cria um novo medidor de uso. Customer e meter do Stripe podem ficar vazios
+// enquanto a conta não está vinculada ao Stripe: o uso conta para os limites do plano do mesmo jeito.
 func NewUsageMeter(
 	billingAccountID uuid.UUID,
 	stripeCustomerID string,
@@ -49,12 +48,6 @@ func NewUsageMeter(
 	if billingAccountID == uuid.Nil {
 		return nil, ErrInvalidBillingAccountID
 	}
-	if stripeCustomerID == "" {
-		return nil, ErrInvalidStripeCustomerID
-	}
-	if stripeMeterID == "" {
-		return nil, ErrInvalidStripeMeterID
-	}
 	if metricName == "" {
 		return nil, ErrInvalidMetricName
 	}
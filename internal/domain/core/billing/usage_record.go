@@ -0,0 +1,114 @@
+package billing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidUsageMetric   = errors.New("invalid usage metric")
+	ErrInvalidUsageSource   = errors.New("usage source cannot be empty")
+	ErrInvalidUsageQuantity = errors.New("usage quantity must be positive")
+	ErrInvalidUsageProject  = errors.New("usage project ID cannot be nil")
+)
+
+// UsageRecord consumo de uma ação medida (mensagem enviada, contato criado, processamento de IA...).
+// A origem identifica a ação (ex: o ID da mensagem) e torna o registro idempotente: reentregas
+// do mesmo evento do outbox não contam duas vezes.
+type UsageRecord struct {
+	id               uuid.UUID
+	billingAccountID uuid.UUID
+	projectID        uuid.UUID
+	metric           UsageMetric
+	sourceID         string
+	quantity         int64
+	occurredAt       time.Time
+	reportedAt       *time.Time
+	createdAt        time.Time
+}
+
+// NewUsageRecord cria o registro de consumo de uma ação
+func NewUsageRecord(billingAccountID, projectID uuid.UUID, metric UsageMetric, sourceID string, quantity int64, occurredAt time.Time) (*UsageRecord, error) {
+	if billingAccountID == uuid.Nil {
+		return nil, ErrInvalidBillingAccountID
+	}
+	if projectID == uuid.Nil {
+		return nil, ErrInvalidUsageProject
+	}
+	if !metric.IsValid() {
+		return nil, ErrInvalidUsageMetric
+	}
+	sourceID = strings.TrimSpace(sourceID)
+	if sourceID == "" {
+		return nil, ErrInvalidUsageSource
+	}
+	if quantity <= 0 {
+		return nil, ErrInvalidUsageQuantity
+	}
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	return &UsageRecord{
+		id:               uuid.New(),
+		billingAccountID: billingAccountID,
+		projectID:        projectID,
+		metric:           metric,
+		sourceID:         sourceID,
+		quantity:         quantity,
+		occurredAt:       occurredAt,
+		createdAt:        time.Now(),
+	}, nil
+}
+
+// ReconstructUsageRecord reconstrói um registro do banco
+func ReconstructUsageRecord(
+	id uuid.UUID,
+	billingAccountID uuid.UUID,
+	projectID uuid.UUID,
+	metric UsageMetric,
+	sourceID string,
+	quantity int64,
+	occurredAt time.Time,
+	reportedAt *time.Time,
+	createdAt time.Time,
+) *UsageRecord {
+	return &UsageRecord{
+		id:               id,
+		billingAccountID: billingAccountID,
+		projectID:        projectID,
+		metric:           metric,
+		sourceID:         sourceID,
+		quantity:         quantity,
+		occurredAt:       occurredAt,
+		reportedAt:       reportedAt,
+		createdAt:        createdAt,
+	}
+}
+
+func (r *UsageRecord) ID() uuid.UUID               { return r.id }
+func (r *UsageRecord) BillingAccountID() uuid.UUID { return r.billingAccountID }
+func (r *UsageRecord) ProjectID() uuid.UUID        { return r.projectID }
+func (r *UsageRecord) Metric() UsageMetric         { return r.metric }
+func (r *UsageRecord) SourceID() string            { return r.sourceID }
+func (r *UsageRecord) Quantity() int64             { return r.quantity }
+func (r *UsageRecord) OccurredAt() time.Time       { return r.occurredAt }
+func (r *UsageRecord) ReportedAt() *time.Time      { return r.reportedAt }
+func (r *UsageRecord) CreatedAt() time.Time        { return r.createdAt }
+
+// UsageRecordRepository define operações de persistência para os registros de consumo
+type UsageRecordRepository interface {
+	// Record grava o registro; false quando a mesma origem já foi registrada para a métrica
+	Record(ctx context.Context, record *UsageRecord) (bool, error)
+
+	// FindUnreported busca registros de métricas medidas ainda não enviados ao Stripe,
+	// só de contas vinculadas a um customer do Stripe, dos mais antigos para os mais novos
+	FindUnreported(ctx context.Context, limit int) ([]*UsageRecord, error)
+
+	// MarkReported marca os registros como enviados ao Stripe
+	MarkReported(ctx context.Context, ids []uuid.UUID, reportedAt time.Time) error
+}
@@ -3,6 +3,7 @@ package project
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -100,18 +101,23 @@ func (r *ReassignmentRule) Validate() error {
 
 // ShouldReassign verifica se deve reatribuir baseado no tempo
 func (r *ReassignmentRule) ShouldReassign(lastActivityAt time.Time, reassignmentCount int) bool {
+	return r.ShouldReassignAt(lastActivityAt, reassignmentCount, time.Now())
+}
+
+// ShouldReassignAt é ShouldReassign avaliado no instante now
+func (r *ReassignmentRule) ShouldReassignAt(lastActivityAt time.Time, reassignmentCount int, now time.Time) bool {
 	if !r.Enabled {
 		return false
 	}
 
 	// Verifica limite de reatribuições
-	if r.MaxReassignments > 0 && reassignmentCount >= r.MaxReassignments {
+	if !r.HasReassignmentsLeft(reassignmentCount) {
 		return false
 	}
 
 	// Para triggers baseados em tempo, verifica inatividade
 	if r.Trigger == TriggerInactivity || r.Trigger == TriggerNoResponse {
-		inactiveDuration := time.Since(lastActivityAt)
+		inactiveDuration := now.Sub(lastActivityAt)
 		timeoutDuration := time.Duration(r.InactivityTimeoutMinutes) * time.Minute
 		return inactiveDuration >= timeoutDuration
 	}
@@ -119,6 +125,19 @@ func (r *ReassignmentRule) ShouldReassign(lastActivityAt time.Time, reassignment
 	return false
 }
 
+// HasReassignmentsLeft verifica se a sessão ainda pode ser reatribuída pela regra
+func (r *ReassignmentRule) HasReassignmentsLeft(reassignmentCount int) bool {
+	return r.MaxReassignments == 0 || reassignmentCount < r.MaxReassignments
+}
+
+// Name identifica a regra no evento de reatribuição (ex: "inactivity_5min")
+func (r *ReassignmentRule) Name() string {
+	if r.Trigger == TriggerInactivity || r.Trigger == TriggerNoResponse {
+		return fmt.Sprintf("%s_%dmin", r.Trigger, r.InactivityTimeoutMinutes)
+	}
+	return string(r.Trigger)
+}
+
 // AgentAssignmentConfig configura como agentes são atribuídos às sessões
 type AgentAssignmentConfig struct {
 	// Enabled - Se a atribuição automática está habilitada
@@ -131,9 +150,6 @@ type AgentAssignmentConfig struct {
 	// Strategy - Estratégia de distribuição
 	Strategy AssignmentStrategy `json:"strategy"`
 
-	// RoundRobinIndex - Índice atual para round-robin (uso interno)
-	RoundRobinIndex int `json:"round_robin_index"`
-
 	// OnlyHumanAgents - Se true, só atribui agentes humanos (exclui virtuais/IA)
 	OnlyHumanAgents bool `json:"only_human_agents"`
 
@@ -147,6 +163,17 @@ type AgentAssignmentConfig struct {
 	// RequireAtLeastOneAgent - Se true, exige pelo menos um agente atribuído
 	// Sessions não podem ser criadas sem agente quando true
 	RequireAtLeastOneAgent bool `json:"require_at_least_one_agent"`
+
+	// DefaultMaxConcurrentSessions - Limite de sessões simultâneas por agente
+	// quando o perfil de roteamento do agente não define um. 0 = ilimitado
+	DefaultMaxConcurrentSessions int `json:"default_max_concurrent_sessions"`
+
+	// MatchContactLanguage - Se true, só atribui agentes que falam o idioma do contato
+	MatchContactLanguage bool `json:"match_contact_language"`
+
+	// SkillTags - Tags de contato que representam skills exigidas do agente
+	// (ex: contato com tag "billing" só vai para agentes com skill "billing")
+	SkillTags []string `json:"skill_tags"`
 }
 
 // NewAgentAssignmentConfig cria uma nova configuração de atribuição
@@ -155,11 +182,11 @@ func NewAgentAssignmentConfig() *AgentAssignmentConfig {
 		Enabled:                false,
 		AgentIDs:               []uuid.UUID{},
 		Strategy:               StrategyManual,
-		RoundRobinIndex:        0,
 		OnlyHumanAgents:        false,
 		ExcludeVirtualAgents:   true, // Default: não atribui a virtuais
 		ReassignmentRules:      []*ReassignmentRule{},
 		RequireAtLeastOneAgent: true, // Default: exige pelo menos um agente
+		SkillTags:              []string{},
 	}
 }
 
@@ -180,6 +207,10 @@ func (c *AgentAssignmentConfig) Validate() error {
 		return fmt.Errorf("invalid assignment strategy: %s", c.Strategy)
 	}
 
+	if c.DefaultMaxConcurrentSessions < 0 {
+		return errors.New("default max concurrent sessions cannot be negative")
+	}
+
 	// Valida regras de reatribuição
 	for i, rule := range c.ReassignmentRules {
 		if err := rule.Validate(); err != nil {
@@ -212,10 +243,6 @@ func (c *AgentAssignmentConfig) RemoveAgent(agentID uuid.UUID) error {
 	for i, id := range c.AgentIDs {
 		if id == agentID {
 			c.AgentIDs = append(c.AgentIDs[:i], c.AgentIDs[i+1:]...)
-			// Reset round-robin index if needed
-			if c.RoundRobinIndex >= len(c.AgentIDs) {
-				c.RoundRobinIndex = 0
-			}
 			return nil
 		}
 	}
@@ -232,22 +259,6 @@ func (c *AgentAssignmentConfig) HasAgent(agentID uuid.UUID) bool {
 	return false
 }
 
-// GetNextAgentRoundRobin retorna o próximo agente usando round-robin
-func (c *AgentAssignmentConfig) GetNextAgentRoundRobin() (uuid.UUID, error) {
-	if len(c.AgentIDs) == 0 {
-		return uuid.Nil, errors.New("no agents configured")
-	}
-
-	if c.RoundRobinIndex >= len(c.AgentIDs) {
-		c.RoundRobinIndex = 0
-	}
-
-	agentID := c.AgentIDs[c.RoundRobinIndex]
-	c.RoundRobinIndex++
-
-	return agentID, nil
-}
-
 // GetAgentCount retorna o número de agentes configurados
 func (c *AgentAssignmentConfig) GetAgentCount() int {
 	return len(c.AgentIDs)
@@ -256,7 +267,6 @@ func (c *AgentAssignmentConfig) GetAgentCount() int {
 // Clear limpa todos os agentes
 func (c *AgentAssignmentConfig) Clear() {
 	c.AgentIDs = []uuid.UUID{}
-	c.RoundRobinIndex = 0
 }
 
 // SetStrategy define a estratégia de atribuição
@@ -290,6 +300,21 @@ func (c *AgentAssignmentConfig) ShouldAutoAssign() bool {
 	return c.Enabled && len(c.AgentIDs) > 0 && c.Strategy != StrategyManual
 }
 
+// RequiredSkills retorna as skills exigidas para atender um contato com as tags informadas.
+// A comparação com SkillTags ignora maiúsculas/minúsculas.
+func (c *AgentAssignmentConfig) RequiredSkills(contactTags []string) []string {
+	required := make([]string, 0)
+	for _, tag := range contactTags {
+		for _, skill := range c.SkillTags {
+			if strings.EqualFold(tag, skill) {
+				required = append(required, strings.ToLower(skill))
+				break
+			}
+		}
+	}
+	return required
+}
+
 // ===== Reassignment Rule Management =====
 
 // AddReassignmentRule adiciona uma regra de reatribuição
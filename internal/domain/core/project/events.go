@@ -26,3 +26,22 @@ func NewProjectCreatedEvent(projectID, customerID, billingAccountID uuid.UUID, t
 		Name:             name,
 	}
 }
+
+// ProjectOwnershipTransferredEvent o projeto passou para outro usuário
+type ProjectOwnershipTransferredEvent struct {
+	shared.BaseEvent
+	ProjectID       uuid.UUID
+	PreviousOwnerID uuid.UUID
+	NewOwnerID      uuid.UUID
+	TransferredBy   uuid.UUID
+}
+
+func NewProjectOwnershipTransferredEvent(projectID, previousOwnerID, newOwnerID, transferredBy uuid.UUID) ProjectOwnershipTransferredEvent {
+	return ProjectOwnershipTransferredEvent{
+		BaseEvent:       shared.NewBaseEvent("project.ownership_transferred", time.Now()),
+		ProjectID:       projectID,
+		PreviousOwnerID: previousOwnerID,
+		NewOwnerID:      newOwnerID,
+		TransferredBy:   transferredBy,
+	}
+}
@@ -9,7 +9,12 @@ import (
 	"github.com/ventros/crm/internal/domain/core/shared"
 )
 
-var ErrProjectNotFound = errors.New("project not found")
+var (
+	ErrProjectNotFound  = errors.New("project not found")
+	ErrProjectNameEmpty = errors.New("name cannot be empty")
+	ErrInvalidOwner     = errors.New("new owner cannot be nil")
+	ErrAlreadyOwner     = errors.New("user already owns this project")
+)
 
 type Project struct {
 	id                    uuid.UUID
@@ -23,6 +28,7 @@ type Project struct {
 	active                bool
 	sessionTimeoutMinutes int
 	agentAssignment       *AgentAssignmentConfig
+	requireTwoFactor      bool
 	createdAt             time.Time
 	updatedAt             time.Time
 
@@ -77,6 +83,7 @@ func ReconstructProject(
 	active bool,
 	sessionTimeoutMinutes int,
 	agentAssignment *AgentAssignmentConfig,
+	requireTwoFactor bool,
 	createdAt time.Time,
 	updatedAt time.Time,
 ) *Project {
@@ -107,6 +114,7 @@ func ReconstructProject(
 		active:                active,
 		sessionTimeoutMinutes: sessionTimeoutMinutes,
 		agentAssignment:       agentAssignment,
+		requireTwoFactor:      requireTwoFactor,
 		createdAt:             createdAt,
 		updatedAt:             updatedAt,
 		events:                []shared.DomainEvent{},
@@ -132,6 +140,32 @@ func (p *Project) UpdateConfiguration(config map[string]interface{}) {
 	p.updatedAt = time.Now()
 }
 
+// Rename troca o nome exibido do projeto
+func (p *Project) Rename(name string) error {
+	if name == "" {
+		return ErrProjectNameEmpty
+	}
+	p.name = name
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// TransferOwnership passa o projeto para outro usuário. A conta de faturamento não muda: a troca
+// de pagador é feita no billing.
+func (p *Project) TransferOwnership(newOwnerID, transferredBy uuid.UUID) error {
+	if newOwnerID == uuid.Nil {
+		return ErrInvalidOwner
+	}
+	if newOwnerID == p.customerID {
+		return ErrAlreadyOwner
+	}
+	previousOwnerID := p.customerID
+	p.customerID = newOwnerID
+	p.updatedAt = time.Now()
+	p.addEvent(NewProjectOwnershipTransferredEvent(p.id, previousOwnerID, newOwnerID, transferredBy))
+	return nil
+}
+
 func (p *Project) UpdateDescription(description string) {
 	p.description = description
 	p.updatedAt = time.Now()
@@ -142,6 +176,32 @@ func (p *Project) GetConfiguration(key string) (interface{}, bool) {
 	return val, ok
 }
 
+// ConfigTimezone é a chave de configuração com o fuso IANA do projeto (ex: "America/Sao_Paulo")
+const ConfigTimezone = "timezone"
+
+// SetTimezone define o fuso do projeto usado em relatórios e horários
+func (p *Project) SetTimezone(name string) error {
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("invalid timezone %q: %w", name, err)
+	}
+	if p.configuration == nil {
+		p.configuration = make(map[string]interface{})
+	}
+	p.configuration[ConfigTimezone] = name
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// Location retorna o fuso do projeto (UTC se não configurado ou inválido)
+func (p *Project) Location() *time.Location {
+	if name, ok := p.configuration[ConfigTimezone].(string); ok && name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return time.UTC
+}
+
 func (p *Project) SetSessionTimeout(minutes int) {
 	if minutes <= 0 {
 		minutes = 30
@@ -217,31 +277,6 @@ func (p *Project) SetAssignmentStrategy(strategy AssignmentStrategy) error {
 	return nil
 }
 
-// GetNextAssignmentAgent retorna o próximo agente baseado na estratégia configurada
-func (p *Project) GetNextAssignmentAgent() (uuid.UUID, error) {
-	config := p.GetAgentAssignment()
-
-	if !config.ShouldAutoAssign() {
-		return uuid.Nil, errors.New("auto-assignment is not enabled")
-	}
-
-	switch config.Strategy {
-	case StrategyRoundRobin:
-		return config.GetNextAgentRoundRobin()
-	case StrategyLeastSessions:
-		// Para least_sessions, precisamos de informação externa
-		// Por enquanto, retorna o primeiro
-		if len(config.AgentIDs) > 0 {
-			return config.AgentIDs[0], nil
-		}
-		return uuid.Nil, errors.New("no agents configured")
-	case StrategyManual:
-		return uuid.Nil, errors.New("manual assignment strategy - no auto-assignment")
-	default:
-		return uuid.Nil, fmt.Errorf("unknown assignment strategy: %s", config.Strategy)
-	}
-}
-
 // ===== Reassignment Rule Management =====
 
 // AddReassignmentRule adiciona uma regra de reatribuição automática ao projeto
@@ -296,6 +331,15 @@ func (p *Project) RequiresAtLeastOneAgent() bool {
 	return config.RequireAtLeastOneAgent
 }
 
+// SetRequireTwoFactor liga/desliga a exigência de 2FA para todos os membros do projeto
+func (p *Project) SetRequireTwoFactor(required bool) {
+	p.requireTwoFactor = required
+	p.updatedAt = time.Now()
+}
+
+// RequiresTwoFactor indica se o projeto exige 2FA de quem o acessa
+func (p *Project) RequiresTwoFactor() bool { return p.requireTwoFactor }
+
 func (p *Project) ID() uuid.UUID               { return p.id }
 func (p *Project) Version() int                { return p.version }
 func (p *Project) CustomerID() uuid.UUID       { return p.customerID }
@@ -43,3 +43,63 @@ func TestNewProject(t *testing.T) {
 		assert.True(t, project.IsActive())
 	})
 }
+
+func TestProject_Timezone(t *testing.T) {
+	project, err := NewProject(uuid.New(), uuid.New(), "tenant", "Project")
+	require.NoError(t, err)
+	assert.Equal(t, "UTC", project.Location().String())
+
+	require.NoError(t, project.SetTimezone("America/Sao_Paulo"))
+	assert.Equal(t, "America/Sao_Paulo", project.Location().String())
+
+	assert.Error(t, project.SetTimezone("Mars/Olympus"))
+	assert.Equal(t, "America/Sao_Paulo", project.Location().String())
+}
+
+func TestAgentAssignmentConfig_RequiredSkills(t *testing.T) {
+	config := NewAgentAssignmentConfig()
+	config.SkillTags = []string{"Billing", "vip"}
+
+	assert.Equal(t, []string{"billing", "vip"}, config.RequiredSkills([]string{"billing", "lead", "VIP"}))
+	assert.Empty(t, config.RequiredSkills([]string{"lead"}))
+
+	config.DefaultMaxConcurrentSessions = -1
+	config.Enabled = true
+	config.AgentIDs = []uuid.UUID{uuid.New()}
+	config.Strategy = StrategyLeastSessions
+	assert.Error(t, config.Validate())
+}
+
+func TestProject_Rename(t *testing.T) {
+	project, err := NewProject(uuid.New(), uuid.New(), "tenant", "Project")
+	require.NoError(t, err)
+
+	require.NoError(t, project.Rename("Support"))
+	assert.Equal(t, "Support", project.Name())
+
+	assert.ErrorIs(t, project.Rename(""), ErrProjectNameEmpty)
+	assert.Equal(t, "Support", project.Name())
+}
+
+func TestProject_TransferOwnership(t *testing.T) {
+	ownerID := uuid.New()
+	project, err := NewProject(ownerID, uuid.New(), "tenant", "Project")
+	require.NoError(t, err)
+	project.ClearEvents()
+
+	assert.ErrorIs(t, project.TransferOwnership(uuid.Nil, ownerID), ErrInvalidOwner)
+	assert.ErrorIs(t, project.TransferOwnership(ownerID, ownerID), ErrAlreadyOwner)
+	assert.Empty(t, project.DomainEvents())
+
+	newOwnerID := uuid.New()
+	require.NoError(t, project.TransferOwnership(newOwnerID, ownerID))
+	assert.Equal(t, newOwnerID, project.CustomerID())
+
+	events := project.DomainEvents()
+	require.Len(t, events, 1)
+	event, ok := events[0].(ProjectOwnershipTransferredEvent)
+	require.True(t, ok)
+	assert.Equal(t, ownerID, event.PreviousOwnerID)
+	assert.Equal(t, newOwnerID, event.NewOwnerID)
+	assert.Equal(t, ownerID, event.TransferredBy)
+}
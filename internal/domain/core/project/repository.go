@@ -22,6 +22,8 @@ type Repository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*Project, error)
 	FindByTenantID(ctx context.Context, tenantID string) (*Project, error)
 	FindByCustomer(ctx context.Context, customerID uuid.UUID) ([]*Project, error)
+	// Delete remove o projeto (soft delete)
+	Delete(ctx context.Context, id uuid.UUID) error
 
 	// Advanced query methods
 	FindByTenantWithFilters(ctx context.Context, filters ProjectFilters) ([]*Project, int64, error)
@@ -0,0 +1,67 @@
+package shared
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrCursorInvalid = errors.New("invalid pagination cursor")
+
+// PageDirection indica o sentido da paginação por cursor a partir de uma posição
+type PageDirection string
+
+const (
+	PageOlder PageDirection = "older" // itens anteriores ao cursor (scroll para cima)
+	PageNewer PageDirection = "newer" // itens posteriores ao cursor (scroll para baixo)
+)
+
+func (d PageDirection) IsValid() bool {
+	return d == PageOlder || d == PageNewer
+}
+
+// Cursor é uma posição de keyset pagination ordenada por (timestamp, id).
+// O id desempata registros com o mesmo timestamp, garantindo ordem total e estável.
+type Cursor struct {
+	Timestamp time.Time
+	ID        uuid.UUID
+}
+
+func NewCursor(timestamp time.Time, id uuid.UUID) Cursor {
+	return Cursor{Timestamp: timestamp.UTC(), ID: id}
+}
+
+// Encode serializa o cursor em um token opaco e seguro para URLs
+func (c Cursor) Encode() string {
+	raw := strconv.FormatInt(c.Timestamp.UnixNano(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor reverte Encode
+func DecodeCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, ErrCursorInvalid
+	}
+
+	parts := strings.SplitN(string(raw), ":", 2)
+	if len(parts) != 2 {
+		return Cursor{}, ErrCursorInvalid
+	}
+
+	nanos, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return Cursor{}, ErrCursorInvalid
+	}
+
+	id, err := uuid.Parse(parts[1])
+	if err != nil {
+		return Cursor{}, ErrCursorInvalid
+	}
+
+	return Cursor{Timestamp: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
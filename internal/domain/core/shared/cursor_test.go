@@ -0,0 +1,44 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_EncodeDecode(t *testing.T) {
+	id := uuid.New()
+	ts := time.Date(2025, 3, 10, 14, 30, 15, 123456000, time.FixedZone("BRT", -3*3600))
+
+	cursor := NewCursor(ts, id)
+	decoded, err := DecodeCursor(cursor.Encode())
+
+	require.NoError(t, err)
+	assert.True(t, decoded.Timestamp.Equal(ts))
+	assert.Equal(t, time.UTC, decoded.Timestamp.Location())
+	assert.Equal(t, id, decoded.ID)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	tests := []string{
+		"",
+		"not-base64!",
+		"MTIzNDU",             // "12345" (sem id)
+		"YWJjOjEyMw",          // "abc:123"
+		"MTIzOm5vdC1hLXV1aWQ", // "123:not-a-uuid"
+	}
+
+	for _, token := range tests {
+		_, err := DecodeCursor(token)
+		assert.ErrorIs(t, err, ErrCursorInvalid, token)
+	}
+}
+
+func TestPageDirection_IsValid(t *testing.T) {
+	assert.True(t, PageOlder.IsValid())
+	assert.True(t, PageNewer.IsValid())
+	assert.False(t, PageDirection("sideways").IsValid())
+}
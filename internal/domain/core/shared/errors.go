@@ -20,6 +20,7 @@ const (
 	ErrorTypeBadRequest         ErrorType = "BAD_REQUEST"
 	ErrorTypePrecondition       ErrorType = "PRECONDITION_FAILED"
 	ErrorTypeInvariantViolation ErrorType = "INVARIANT_VIOLATION"
+	ErrorTypePaymentRequired    ErrorType = "PAYMENT_REQUIRED"
 
 	// Infrastructure Errors
 	ErrorTypeDatabase  ErrorType = "DATABASE_ERROR"
@@ -243,6 +244,24 @@ func NewRateLimitError(message string) *DomainError {
 	}
 }
 
+// NewQuotaExceededError creates a payment required error for a plan limit that was reached
+func NewQuotaExceededError(message string) *DomainError {
+	return &DomainError{
+		Type:    ErrorTypePaymentRequired,
+		Message: message,
+		Code:    "QUOTA_EXCEEDED",
+	}
+}
+
+// NewAccountRestrictedError creates a payment required error for an account restricted by an overdue invoice
+func NewAccountRestrictedError(message string) *DomainError {
+	return &DomainError{
+		Type:    ErrorTypePaymentRequired,
+		Message: message,
+		Code:    "ACCOUNT_RESTRICTED",
+	}
+}
+
 // NewInternalError creates an internal error
 func NewInternalError(message string, err error) *DomainError {
 	return &DomainError{
@@ -334,6 +353,15 @@ func IsOptimisticLockError(err error) bool {
 	return false
 }
 
+// IsPaymentRequiredError checks if error is a payment required (plan limit) error
+func IsPaymentRequiredError(err error) bool {
+	var domainErr *DomainError
+	if errors.As(err, &domainErr) {
+		return domainErr.Type == ErrorTypePaymentRequired
+	}
+	return false
+}
+
 // IsDomainError checks if an error is a DomainError and optionally unwraps it
 func IsDomainError(err error, target **DomainError) bool {
 	return errors.As(err, target)
@@ -0,0 +1,235 @@
+package twofactor
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrEnrollmentNotFound = errors.New("two-factor enrollment not found")
+	ErrInvalidUser        = errors.New("userID cannot be nil")
+	ErrInvalidSecret      = errors.New("invalid two-factor secret")
+	ErrAlreadyActive      = errors.New("two-factor authentication already enabled")
+	ErrNotActive          = errors.New("two-factor authentication not enabled")
+	ErrInvalidCode        = errors.New("invalid two-factor code")
+)
+
+// Status da inscrição: pending até o usuário provar que o app gera códigos válidos
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusActive  Status = "active"
+)
+
+const (
+	// RecoveryCodeCount códigos de recuperação gerados por vez
+	RecoveryCodeCount  = 10
+	recoveryCodeLength = 10
+	// recoveryAlphabet sem caracteres ambíguos (0/o, 1/l/i)
+	recoveryAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+)
+
+// Enrollment 2FA TOTP de um usuário. O segredo é mantido em claro só em memória (a persistência o
+// cifra); os códigos de recuperação são de uso único e guardados apenas como SHA-256.
+type Enrollment struct {
+	id                 uuid.UUID
+	userID             uuid.UUID
+	secret             []byte
+	status             Status
+	lastUsedStep       int64
+	recoveryCodeHashes []string
+	confirmedAt        *time.Time
+	lastUsedAt         *time.Time
+	createdAt          time.Time
+	updatedAt          time.Time
+}
+
+// NewEnrollment inicia a inscrição com um segredo novo (pending)
+func NewEnrollment(userID uuid.UUID) (*Enrollment, error) {
+	if userID == uuid.Nil {
+		return nil, ErrInvalidUser
+	}
+	secret, err := GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	return &Enrollment{
+		id:        uuid.New(),
+		userID:    userID,
+		secret:    secret,
+		status:    StatusPending,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+// ReconstructEnrollment reconstrói a inscrição a partir da persistência
+func ReconstructEnrollment(
+	id, userID uuid.UUID,
+	secret []byte,
+	status Status,
+	lastUsedStep int64,
+	recoveryCodeHashes []string,
+	confirmedAt, lastUsedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *Enrollment {
+	if recoveryCodeHashes == nil {
+		recoveryCodeHashes = []string{}
+	}
+	return &Enrollment{
+		id:                 id,
+		userID:             userID,
+		secret:             secret,
+		status:             status,
+		lastUsedStep:       lastUsedStep,
+		recoveryCodeHashes: recoveryCodeHashes,
+		confirmedAt:        confirmedAt,
+		lastUsedAt:         lastUsedAt,
+		createdAt:          createdAt,
+		updatedAt:          updatedAt,
+	}
+}
+
+// Confirm ativa a inscrição com o primeiro código do app e devolve os códigos de recuperação
+// em claro (exibidos uma única vez)
+func (e *Enrollment) Confirm(code string, now time.Time) ([]string, error) {
+	if e.status == StatusActive {
+		return nil, ErrAlreadyActive
+	}
+	step, ok := MatchStep(e.secret, normalizeCode(code), now)
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, err := e.replaceRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	at := now.UTC()
+	e.status = StatusActive
+	e.lastUsedStep = step
+	e.confirmedAt = &at
+	e.lastUsedAt = &at
+	e.updatedAt = at
+	return codes, nil
+}
+
+// Verify aceita o código TOTP atual ou um código de recuperação (consumido). Um código TOTP já
+// usado não vale de novo, nem outro de passo anterior: quem viu o código por cima do ombro não o reaproveita.
+func (e *Enrollment) Verify(code string, now time.Time) (usedRecoveryCode bool, err error) {
+	if e.status != StatusActive {
+		return false, ErrNotActive
+	}
+	normalized := normalizeCode(code)
+
+	if len(normalized) == recoveryCodeLength {
+		if !e.consumeRecoveryCode(normalized) {
+			return false, ErrInvalidCode
+		}
+		e.touch(now)
+		return true, nil
+	}
+
+	step, ok := MatchStep(e.secret, normalized, now)
+	if !ok || step <= e.lastUsedStep {
+		return false, ErrInvalidCode
+	}
+	e.lastUsedStep = step
+	e.touch(now)
+	return false, nil
+}
+
+// RegenerateRecoveryCodes invalida os códigos anteriores e devolve os novos em claro
+func (e *Enrollment) RegenerateRecoveryCodes() ([]string, error) {
+	if e.status != StatusActive {
+		return nil, ErrNotActive
+	}
+	codes, err := e.replaceRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	e.updatedAt = time.Now().UTC()
+	return codes, nil
+}
+
+// ProvisioningURI URI otpauth:// para o QR code
+func (e *Enrollment) ProvisioningURI(issuer, account string) string {
+	return ProvisioningURI(issuer, account, e.secret)
+}
+
+// IsActive inscrição confirmada
+func (e *Enrollment) IsActive() bool { return e.status == StatusActive }
+
+// RemainingRecoveryCodes códigos de recuperação ainda não usados
+func (e *Enrollment) RemainingRecoveryCodes() int { return len(e.recoveryCodeHashes) }
+
+func (e *Enrollment) ID() uuid.UUID                { return e.id }
+func (e *Enrollment) UserID() uuid.UUID            { return e.userID }
+func (e *Enrollment) Secret() []byte               { return e.secret }
+func (e *Enrollment) Status() Status               { return e.status }
+func (e *Enrollment) LastUsedStep() int64          { return e.lastUsedStep }
+func (e *Enrollment) RecoveryCodeHashes() []string { return e.recoveryCodeHashes }
+func (e *Enrollment) ConfirmedAt() *time.Time      { return e.confirmedAt }
+func (e *Enrollment) LastUsedAt() *time.Time       { return e.lastUsedAt }
+func (e *Enrollment) CreatedAt() time.Time         { return e.createdAt }
+func (e *Enrollment) UpdatedAt() time.Time         { return e.updatedAt }
+
+func (e *Enrollment) touch(now time.Time) {
+	at := now.UTC()
+	e.lastUsedAt = &at
+	e.updatedAt = at
+}
+
+func (e *Enrollment) consumeRecoveryCode(normalized string) bool {
+	hash := HashRecoveryCode(normalized)
+	for i, stored := range e.recoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(stored), []byte(hash)) == 1 {
+			e.recoveryCodeHashes = append(e.recoveryCodeHashes[:i:i], e.recoveryCodeHashes[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Enrollment) replaceRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashes[i] = HashRecoveryCode(code)
+	}
+	e.recoveryCodeHashes = hashes
+	return codes, nil
+}
+
+// HashRecoveryCode SHA-256 hexadecimal do código normalizado (sem hífen, minúsculo)
+func HashRecoveryCode(code string) string {
+	sum := sha256.Sum256([]byte(normalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func generateRecoveryCode() (string, error) {
+	buf := make([]byte, recoveryCodeLength)
+	max := big.NewInt(int64(len(recoveryAlphabet)))
+	for i := range buf {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		buf[i] = recoveryAlphabet[n.Int64()]
+	}
+	return string(buf), nil
+}
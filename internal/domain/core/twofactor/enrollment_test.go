@@ -0,0 +1,121 @@
+package twofactor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	secret := []byte("12345678901234567890")
+
+	// Vetores SHA-1 do apêndice B do RFC 6238, truncados em 6 dígitos
+	assert.Equal(t, "287082", Code(secret, Step(time.Unix(59, 0))))
+	assert.Equal(t, "081804", Code(secret, Step(time.Unix(1111111109, 0))))
+	assert.Equal(t, "005924", Code(secret, Step(time.Unix(1234567890, 0))))
+}
+
+func TestMatchStep_AcceptsClockSkew(t *testing.T) {
+	secret := []byte("12345678901234567890")
+	now := time.Unix(1111111109, 0)
+
+	_, ok := MatchStep(secret, Code(secret, Step(now)-1), now)
+	assert.True(t, ok)
+	_, ok = MatchStep(secret, Code(secret, Step(now)+1), now)
+	assert.True(t, ok)
+	_, ok = MatchStep(secret, Code(secret, Step(now)-2), now)
+	assert.False(t, ok)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Ventros CRM", "ana@example.com", []byte("12345678901234567890"))
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Ventros%20CRM:ana@example.com?"))
+	assert.Contains(t, uri, "secret=GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+	assert.Contains(t, uri, "issuer=Ventros+CRM")
+	assert.Contains(t, uri, "digits=6")
+}
+
+func confirmedEnrollment(t *testing.T, now time.Time) (*Enrollment, []string) {
+	t.Helper()
+	e, err := NewEnrollment(uuid.New())
+	require.NoError(t, err)
+	codes, err := e.Confirm(Code(e.Secret(), Step(now)), now)
+	require.NoError(t, err)
+	return e, codes
+}
+
+func TestEnrollment_Confirm(t *testing.T) {
+	now := time.Now()
+	e, err := NewEnrollment(uuid.New())
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, e.Status())
+
+	_, err = e.Confirm(Code(e.Secret(), Step(now)+5), now)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+	assert.False(t, e.IsActive())
+
+	codes, err := e.Confirm(Code(e.Secret(), Step(now)), now)
+	require.NoError(t, err)
+	assert.True(t, e.IsActive())
+	assert.Len(t, codes, RecoveryCodeCount)
+	assert.Equal(t, RecoveryCodeCount, e.RemainingRecoveryCodes())
+	assert.Regexp(t, `^[a-z2-9]{5}-[a-z2-9]{5}$`, codes[0])
+	assert.NotContains(t, e.RecoveryCodeHashes(), codes[0], "only hashes are kept")
+
+	_, err = e.Confirm(Code(e.Secret(), Step(now)), now)
+	assert.ErrorIs(t, err, ErrAlreadyActive)
+}
+
+func TestEnrollment_VerifyRejectsReplayedCode(t *testing.T) {
+	now := time.Now()
+	e, _ := confirmedEnrollment(t, now)
+
+	// O código usado na confirmação não vale de novo
+	_, err := e.Verify(Code(e.Secret(), Step(now)), now)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	next := now.Add(Period)
+	usedRecovery, err := e.Verify(Code(e.Secret(), Step(next)), next)
+	require.NoError(t, err)
+	assert.False(t, usedRecovery)
+
+	_, err = e.Verify(Code(e.Secret(), Step(next)), next)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+}
+
+func TestEnrollment_RecoveryCodesAreSingleUse(t *testing.T) {
+	now := time.Now()
+	e, codes := confirmedEnrollment(t, now)
+
+	usedRecovery, err := e.Verify(strings.ToUpper(codes[3]), now)
+	require.NoError(t, err)
+	assert.True(t, usedRecovery)
+	assert.Equal(t, RecoveryCodeCount-1, e.RemainingRecoveryCodes())
+
+	_, err = e.Verify(codes[3], now)
+	assert.ErrorIs(t, err, ErrInvalidCode)
+
+	regenerated, err := e.RegenerateRecoveryCodes()
+	require.NoError(t, err)
+	assert.Equal(t, RecoveryCodeCount, e.RemainingRecoveryCodes())
+	_, err = e.Verify(codes[0], now)
+	assert.ErrorIs(t, err, ErrInvalidCode, "old codes are invalidated")
+	_, err = e.Verify(regenerated[0], now)
+	assert.NoError(t, err)
+}
+
+func TestEnrollment_VerifyRequiresActive(t *testing.T) {
+	e, err := NewEnrollment(uuid.New())
+	require.NoError(t, err)
+
+	_, err = e.Verify(Code(e.Secret(), Step(time.Now())), time.Now())
+	assert.ErrorIs(t, err, ErrNotActive)
+
+	_, err = NewEnrollment(uuid.Nil)
+	assert.ErrorIs(t, err, ErrInvalidUser)
+}
@@ -0,0 +1,15 @@
+package twofactor
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository uma inscrição por usuário (pendente ou ativa)
+type Repository interface {
+	Save(ctx context.Context, enrollment *Enrollment) error
+	// FindByUserID devolve ErrEnrollmentNotFound quando o usuário nunca iniciou a inscrição
+	FindByUserID(ctx context.Context, userID uuid.UUID) (*Enrollment, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
@@ -138,7 +138,7 @@ func NewAgent(
 		events:            []DomainEvent{},
 	}
 
-	agent.addEvent(NewAgentCreatedEvent(agent.id, tenantID, name, agent.email, agent.role))
+	agent.addEvent(NewAgentCreatedEvent(agent.id, projectID, tenantID, name, agent.email, agent.role, agent.agentType))
 
 	return agent, nil
 }
@@ -204,7 +204,7 @@ func NewVirtualAgent(
 		events:    []DomainEvent{},
 	}
 
-	agent.addEvent(NewAgentCreatedEvent(agent.id, tenantID, virtualName, agent.email, agent.role))
+	agent.addEvent(NewAgentCreatedEvent(agent.id, projectID, tenantID, virtualName, agent.email, agent.role, agent.agentType))
 
 	return agent, nil
 }
@@ -11,21 +11,25 @@ type DomainEvent = shared.DomainEvent
 
 type AgentCreatedEvent struct {
 	shared.BaseEvent
-	AgentID  uuid.UUID
-	TenantID string
-	Name     string
-	Email    string
-	Role     Role
+	AgentID   uuid.UUID
+	ProjectID uuid.UUID
+	TenantID  string
+	Name      string
+	Email     string
+	Role      Role
+	Type      AgentType
 }
 
-func NewAgentCreatedEvent(agentID uuid.UUID, tenantID, name, email string, role Role) AgentCreatedEvent {
+func NewAgentCreatedEvent(agentID, projectID uuid.UUID, tenantID, name, email string, role Role, agentType AgentType) AgentCreatedEvent {
 	return AgentCreatedEvent{
 		BaseEvent: shared.NewBaseEvent("agent.created", time.Now()),
 		AgentID:   agentID,
+		ProjectID: projectID,
 		TenantID:  tenantID,
 		Name:      name,
 		Email:     email,
 		Role:      role,
+		Type:      agentType,
 	}
 }
 
@@ -106,3 +110,23 @@ func NewAgentPermissionRevokedEvent(agentID uuid.UUID, permission string) AgentP
 		Permission: permission,
 	}
 }
+
+type AgentStatusChangedEvent struct {
+	shared.BaseEvent
+	AgentID        uuid.UUID
+	TenantID       string
+	PreviousStatus AgentStatus
+	Status         AgentStatus
+	Manual         bool // true quando o agente escolheu o status
+}
+
+func NewAgentStatusChangedEvent(agentID uuid.UUID, tenantID string, previous, status AgentStatus, manual bool) AgentStatusChangedEvent {
+	return AgentStatusChangedEvent{
+		BaseEvent:      shared.NewBaseEvent("agent.status_changed", time.Now()),
+		AgentID:        agentID,
+		TenantID:       tenantID,
+		PreviousStatus: previous,
+		Status:         status,
+		Manual:         manual,
+	}
+}
@@ -0,0 +1,88 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LeaderboardSort define a métrica de ordenação do ranking de agentes
+type LeaderboardSort string
+
+const (
+	SortBySessionsHandled   LeaderboardSort = "sessions_handled"
+	SortByMessagesSent      LeaderboardSort = "messages_sent"
+	SortByResolutionRate    LeaderboardSort = "resolution_rate"
+	SortByFirstResponseTime LeaderboardSort = "first_response_time" // mediana, menor primeiro
+)
+
+func (s LeaderboardSort) IsValid() bool {
+	switch s {
+	case SortBySessionsHandled, SortByMessagesSent, SortByResolutionRate, SortByFirstResponseTime:
+		return true
+	default:
+		return false
+	}
+}
+
+// PerformanceFilter delimita o cálculo de métricas de desempenho.
+// As métricas são derivadas de agent_sessions (participações) e do histórico de mensagens,
+// nunca dos contadores do agregado Agent.
+type PerformanceFilter struct {
+	TenantID  string
+	ProjectID *uuid.UUID  // leaderboard: agentes do projeto
+	AgentIDs  []uuid.UUID // vazio = todos os agentes do tenant/projeto
+	StartDate time.Time   // inclusivo
+	EndDate   time.Time   // exclusivo
+	Location  *time.Location
+}
+
+// Validate normaliza e valida o filtro
+func (f *PerformanceFilter) Validate() error {
+	if f.TenantID == "" {
+		return errors.New("tenant_id is required")
+	}
+	if f.StartDate.IsZero() || f.EndDate.IsZero() {
+		return errors.New("start_date and end_date are required")
+	}
+	if !f.EndDate.After(f.StartDate) {
+		return errors.New("end_date must be after start_date")
+	}
+	if f.Location == nil {
+		f.Location = time.UTC
+	}
+	return nil
+}
+
+// PerformanceStats são as métricas de um agente no período
+type PerformanceStats struct {
+	AgentID                    uuid.UUID
+	AgentName                  string
+	SessionsHandled            int64 // sessões em que o agente entrou no período
+	SessionsResolved           int64
+	MessagesSent               int64
+	FirstResponseSamples       int64
+	MedianFirstResponseSeconds *float64
+	P90FirstResponseSeconds    *float64
+	TransfersIn                int64 // assumiu sessão de outro agente
+	TransfersOut               int64 // sessão passou para outro agente
+	AverageSentimentScore      *float64
+	SentimentBreakdown         map[string]int64 // positive, neutral, negative, ...
+	BusyHours                  map[int]int64    // mensagens enviadas por hora do dia (fuso do filtro)
+}
+
+// ResolutionRate retorna a fração (0-1) de sessões atendidas que foram resolvidas
+func (s *PerformanceStats) ResolutionRate() float64 {
+	if s.SessionsHandled == 0 {
+		return 0
+	}
+	return float64(s.SessionsResolved) / float64(s.SessionsHandled)
+}
+
+// PerformanceRepository calcula métricas de desempenho a partir do histórico
+type PerformanceRepository interface {
+	// GetPerformance retorna uma linha por agente do filtro (inclui agentes sem atividade)
+	GetPerformance(ctx context.Context, filter PerformanceFilter) ([]*PerformanceStats, error)
+}
@@ -0,0 +1,112 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Presence sinais de presença de um agente humano, derivados das conexões websocket
+type Presence struct {
+	AgentID  uuid.UUID
+	TenantID string
+
+	// LastSeenAt - Último heartbeat de qualquer conexão do agente (em qualquer servidor)
+	LastSeenAt *time.Time
+
+	// LastActivityAt - Última interação real: conexão, mensagem, digitação, entrada em sessão
+	LastActivityAt *time.Time
+
+	// ManualStatus - Status escolhido pelo agente (busy, away, offline). nil = automático
+	ManualStatus   *AgentStatus
+	ManualStatusAt *time.Time
+}
+
+// PresencePolicy parâmetros da presença automática
+type PresencePolicy struct {
+	// HeartbeatTimeout - Sem heartbeat por esse tempo o agente é considerado desconectado
+	HeartbeatTimeout time.Duration
+
+	// AwayAfter - Conectado e sem atividade por esse tempo o agente fica "away"
+	AwayAfter time.Duration
+}
+
+// DefaultPresencePolicy retorna a política padrão (heartbeats do hub a cada 30s)
+func DefaultPresencePolicy() PresencePolicy {
+	return PresencePolicy{
+		HeartbeatTimeout: 90 * time.Second,
+		AwayAfter:        10 * time.Minute,
+	}
+}
+
+// IsConnected indica se há conexão viva do agente
+func (p Presence) IsConnected(policy PresencePolicy, now time.Time) bool {
+	return p.LastSeenAt != nil && now.Sub(*p.LastSeenAt) <= policy.HeartbeatTimeout
+}
+
+// EffectiveStatus calcula o status do agente:
+// desconectado → offline; status manual → ele mesmo; ocioso → away; senão available.
+func (p Presence) EffectiveStatus(policy PresencePolicy, now time.Time) AgentStatus {
+	if !p.IsConnected(policy, now) {
+		return AgentStatusOffline
+	}
+	if p.ManualStatus != nil {
+		return *p.ManualStatus
+	}
+	if p.LastActivityAt == nil || now.Sub(*p.LastActivityAt) >= policy.AwayAfter {
+		return AgentStatusAway
+	}
+	return AgentStatusAvailable
+}
+
+// ParseManualStatus converte o status escolhido pelo agente.
+// "available" e "auto" (ou vazio) removem o override e voltam à presença automática.
+func ParseManualStatus(value string) (*AgentStatus, error) {
+	switch value {
+	case "", "auto", string(AgentStatusAvailable):
+		return nil, nil
+	case string(AgentStatusBusy), string(AgentStatusAway), string(AgentStatusOffline):
+		status := AgentStatus(value)
+		return &status, nil
+	default:
+		return nil, fmt.Errorf("invalid status %q: use available, busy, away, offline or auto", value)
+	}
+}
+
+// TracksPresence indica se o agente tem presença derivada de conexões (humanos com usuário)
+func (a *Agent) TracksPresence() bool {
+	return a.agentType == AgentTypeHuman && a.userID != nil
+}
+
+// ApplyPresenceStatus atualiza o status a partir da presença e emite agent.status_changed.
+// Retorna false se o status não mudou.
+func (a *Agent) ApplyPresenceStatus(status AgentStatus, manual bool) bool {
+	if a.status == status {
+		return false
+	}
+	previous := a.status
+	a.SetStatus(status)
+	a.addEvent(NewAgentStatusChangedEvent(a.id, a.tenantID, previous, status, manual))
+	return true
+}
+
+// PresenceRepository persiste os sinais de presença dos agentes
+type PresenceRepository interface {
+	// Touch registra heartbeat dos agentes do usuário no tenant; activity também renova a última atividade
+	Touch(ctx context.Context, tenantID string, userID uuid.UUID, at time.Time, activity bool) error
+
+	// SetManualStatus grava (ou remove, com nil) o status manual do agente
+	SetManualStatus(ctx context.Context, agentID uuid.UUID, tenantID string, status *AgentStatus, at time.Time) error
+
+	// FindByAgent retorna a presença do agente (vazia se ele nunca se conectou)
+	FindByAgent(ctx context.Context, agentID uuid.UUID) (*Presence, error)
+
+	// FindByTenant retorna a presença dos agentes que já se conectaram
+	FindByTenant(ctx context.Context, tenantID string) ([]Presence, error)
+
+	// FindSweepCandidates retorna os agentes humanos que não estão offline ou que
+	// enviaram heartbeat desde connectedSince: os únicos cujo status pode mudar
+	FindSweepCandidates(ctx context.Context, connectedSince time.Time, limit int) ([]Presence, error)
+}
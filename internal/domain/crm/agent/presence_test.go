@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPresence_EffectiveStatus(t *testing.T) {
+	policy := DefaultPresencePolicy()
+	now := time.Now()
+	at := func(ago time.Duration) *time.Time {
+		t := now.Add(-ago)
+		return &t
+	}
+	busy := AgentStatusBusy
+	offline := AgentStatusOffline
+
+	tests := []struct {
+		name     string
+		presence Presence
+		want     AgentStatus
+	}{
+		{"never connected", Presence{}, AgentStatusOffline},
+		{"heartbeat expired", Presence{LastSeenAt: at(2 * time.Minute), LastActivityAt: at(2 * time.Minute)}, AgentStatusOffline},
+		{"active", Presence{LastSeenAt: at(10 * time.Second), LastActivityAt: at(time.Minute)}, AgentStatusAvailable},
+		{"idle", Presence{LastSeenAt: at(10 * time.Second), LastActivityAt: at(15 * time.Minute)}, AgentStatusAway},
+		{"manual busy while idle", Presence{LastSeenAt: at(10 * time.Second), LastActivityAt: at(time.Hour), ManualStatus: &busy}, AgentStatusBusy},
+		{"manual offline while connected", Presence{LastSeenAt: at(10 * time.Second), LastActivityAt: at(time.Second), ManualStatus: &offline}, AgentStatusOffline},
+		{"manual status does not survive disconnect", Presence{LastSeenAt: at(time.Hour), ManualStatus: &busy}, AgentStatusOffline},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.presence.EffectiveStatus(policy, now))
+		})
+	}
+}
+
+func TestParseManualStatus(t *testing.T) {
+	for _, value := range []string{"", "auto", "available"} {
+		status, err := ParseManualStatus(value)
+		require.NoError(t, err)
+		assert.Nil(t, status, value)
+	}
+
+	status, err := ParseManualStatus("busy")
+	require.NoError(t, err)
+	assert.Equal(t, AgentStatusBusy, *status)
+
+	_, err = ParseManualStatus("lunch")
+	assert.Error(t, err)
+}
+
+func TestAgent_ApplyPresenceStatus(t *testing.T) {
+	userID := uuid.New()
+	a, err := NewAgent(uuid.New(), "tenant-123", "John Doe", AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	a.ClearEvents()
+
+	assert.True(t, a.TracksPresence())
+	assert.False(t, a.ApplyPresenceStatus(AgentStatusOffline, false), "unchanged status")
+	assert.Empty(t, a.DomainEvents())
+
+	assert.True(t, a.ApplyPresenceStatus(AgentStatusBusy, true))
+	assert.Equal(t, AgentStatusBusy, a.Status())
+	require.Len(t, a.DomainEvents(), 1)
+
+	event, ok := a.DomainEvents()[0].(AgentStatusChangedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "agent.status_changed", event.EventName())
+	assert.Equal(t, AgentStatusOffline, event.PreviousStatus)
+	assert.Equal(t, AgentStatusBusy, event.Status)
+	assert.True(t, event.Manual)
+}
@@ -0,0 +1,136 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ConfigRouting é a chave de configuração com o perfil de roteamento do agente
+const ConfigRouting = "routing"
+
+// RoutingProfile define capacidade e competências usadas pelo roteamento automático de sessões
+type RoutingProfile struct {
+	// MaxConcurrentSessions - Limite de sessões ativas simultâneas. 0 = usa o padrão do projeto
+	MaxConcurrentSessions int `json:"max_concurrent_sessions"`
+
+	// Skills - Competências do agente (comparadas com as skill tags do contato)
+	Skills []string `json:"skills"`
+
+	// Languages - Idiomas atendidos (ex: "pt", "en-US"). Vazio = atende qualquer idioma
+	Languages []string `json:"languages"`
+}
+
+func (p RoutingProfile) Validate() error {
+	if p.MaxConcurrentSessions < 0 {
+		return errors.New("max concurrent sessions cannot be negative")
+	}
+	return nil
+}
+
+// HasSkills retorna true se o agente possui todas as skills exigidas (sem diferenciar maiúsculas)
+func (p RoutingProfile) HasSkills(required []string) bool {
+	for _, skill := range required {
+		found := false
+		for _, own := range p.Skills {
+			if strings.EqualFold(own, skill) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// SpeaksLanguage retorna true se o agente atende o idioma informado.
+// Compara pelo subtag primário, então "pt" atende "pt-BR" e vice-versa.
+func (p RoutingProfile) SpeaksLanguage(language string) bool {
+	if language == "" || len(p.Languages) == 0 {
+		return true
+	}
+	wanted := primaryLanguage(language)
+	for _, lang := range p.Languages {
+		if primaryLanguage(lang) == wanted {
+			return true
+		}
+	}
+	return false
+}
+
+func primaryLanguage(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	return tag
+}
+
+// RoutingProfile retorna o perfil de roteamento salvo na configuração do agente
+func (a *Agent) RoutingProfile() RoutingProfile {
+	var profile RoutingProfile
+	a.decodeConfig(ConfigRouting, &profile)
+	return profile
+}
+
+// SetRoutingProfile grava o perfil de roteamento na configuração do agente
+func (a *Agent) SetRoutingProfile(profile RoutingProfile) error {
+	if err := profile.Validate(); err != nil {
+		return err
+	}
+	return a.encodeConfig(ConfigRouting, profile)
+}
+
+// decodeConfig lê uma chave da configuração para target.
+// A configuração chega do banco como map genérico; o round-trip por JSON normaliza os tipos.
+func (a *Agent) decodeConfig(key string, target interface{}) {
+	raw, ok := a.config[key]
+	if !ok || raw == nil {
+		return
+	}
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(data, target)
+}
+
+// encodeConfig grava value na chave da configuração como map genérico, igual ao que vem do banco
+func (a *Agent) encodeConfig(key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	if a.config == nil {
+		a.config = make(map[string]interface{})
+	}
+	a.config[key] = decoded
+	a.updatedAt = time.Now()
+	return nil
+}
+
+// IsAvailableForRouting indica se o agente pode receber sessões automaticamente agora.
+func (a *Agent) IsAvailableForRouting() bool {
+	return a.IsAvailableForRoutingAt(time.Now())
+}
+
+// IsAvailableForRoutingAt indica se o agente pode receber sessões no instante informado:
+// ativo, com presença "available" e dentro do horário de trabalho.
+// Agentes virtuais e de sistema nunca recebem sessões.
+func (a *Agent) IsAvailableForRoutingAt(t time.Time) bool {
+	if !a.active || a.status != AgentStatusAvailable {
+		return false
+	}
+	if a.agentType == AgentTypeVirtual || a.agentType == AgentTypeSystem {
+		return false
+	}
+	return a.WorkingHours().IsWorkingAt(t)
+}
@@ -0,0 +1,68 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoutingProfile_HasSkills(t *testing.T) {
+	profile := RoutingProfile{Skills: []string{"Billing", "support"}}
+
+	assert.True(t, profile.HasSkills(nil))
+	assert.True(t, profile.HasSkills([]string{"billing"}))
+	assert.True(t, profile.HasSkills([]string{"billing", "SUPPORT"}))
+	assert.False(t, profile.HasSkills([]string{"billing", "sales"}))
+}
+
+func TestRoutingProfile_SpeaksLanguage(t *testing.T) {
+	profile := RoutingProfile{Languages: []string{"pt-BR", "en"}}
+
+	assert.True(t, profile.SpeaksLanguage(""))
+	assert.True(t, profile.SpeaksLanguage("pt"))
+	assert.True(t, profile.SpeaksLanguage("en_US"))
+	assert.False(t, profile.SpeaksLanguage("es"))
+
+	assert.True(t, RoutingProfile{}.SpeaksLanguage("es"), "agent without languages serves everyone")
+}
+
+func TestAgent_RoutingProfile(t *testing.T) {
+	userID := uuid.New()
+	a, err := NewAgent(uuid.New(), "tenant-123", "John Doe", AgentTypeHuman, &userID)
+	require.NoError(t, err)
+
+	assert.Equal(t, RoutingProfile{}, a.RoutingProfile())
+
+	profile := RoutingProfile{MaxConcurrentSessions: 5, Skills: []string{"billing"}, Languages: []string{"pt"}}
+	require.NoError(t, a.SetRoutingProfile(profile))
+	assert.Equal(t, profile, a.RoutingProfile())
+
+	// Configuração vinda do banco (map genérico com float64)
+	a.SetConfig(map[string]interface{}{
+		ConfigRouting: map[string]interface{}{"max_concurrent_sessions": float64(3), "skills": []interface{}{"sales"}},
+	})
+	assert.Equal(t, 3, a.RoutingProfile().MaxConcurrentSessions)
+	assert.Equal(t, []string{"sales"}, a.RoutingProfile().Skills)
+
+	assert.Error(t, a.SetRoutingProfile(RoutingProfile{MaxConcurrentSessions: -1}))
+}
+
+func TestAgent_IsAvailableForRouting(t *testing.T) {
+	userID := uuid.New()
+	a, err := NewAgent(uuid.New(), "tenant-123", "John Doe", AgentTypeHuman, &userID)
+	require.NoError(t, err)
+
+	assert.False(t, a.IsAvailableForRouting(), "new agents start offline")
+
+	a.SetStatus(AgentStatusAvailable)
+	assert.True(t, a.IsAvailableForRouting())
+
+	a.SetStatus(AgentStatusBusy)
+	assert.False(t, a.IsAvailableForRouting())
+
+	a.SetStatus(AgentStatusAvailable)
+	require.NoError(t, a.Deactivate())
+	assert.False(t, a.IsAvailableForRouting())
+}
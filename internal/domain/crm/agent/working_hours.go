@@ -0,0 +1,148 @@
+package agent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ConfigWorkingHours é a chave de configuração com o horário de trabalho do agente
+const ConfigWorkingHours = "working_hours"
+
+const holidayLayout = "2006-01-02"
+
+// TimeRange intervalo de um dia no formato "HH:MM" (fim exclusivo; "24:00" = até o fim do dia)
+type TimeRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// WorkingHours define a jornada semanal do agente no seu fuso horário.
+// Sem jornada configurada o agente é considerado sempre em horário de trabalho.
+type WorkingHours struct {
+	// Timezone - Fuso IANA (ex: "America/Sao_Paulo"). Vazio = UTC
+	Timezone string `json:"timezone"`
+
+	// Weekly - Intervalos por dia da semana ("monday" ... "sunday"). Dia ausente = folga
+	Weekly map[string][]TimeRange `json:"weekly"`
+
+	// Holidays - Datas sem expediente (YYYY-MM-DD, no fuso do agente)
+	Holidays []string `json:"holidays"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func (w WorkingHours) Validate() error {
+	if _, err := w.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q", w.Timezone)
+	}
+
+	for day, ranges := range w.Weekly {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("invalid weekday %q", day)
+		}
+		for _, r := range ranges {
+			start, err := parseClock(r.Start)
+			if err != nil {
+				return fmt.Errorf("%s: invalid start %q", day, r.Start)
+			}
+			end, err := parseClock(r.End)
+			if err != nil {
+				return fmt.Errorf("%s: invalid end %q", day, r.End)
+			}
+			if start >= end {
+				return fmt.Errorf("%s: range %s-%s must end after it starts", day, r.Start, r.End)
+			}
+		}
+	}
+
+	for _, holiday := range w.Holidays {
+		if _, err := time.Parse(holidayLayout, holiday); err != nil {
+			return fmt.Errorf("invalid holiday %q, expected YYYY-MM-DD", holiday)
+		}
+	}
+
+	return nil
+}
+
+// IsConfigured retorna true se há jornada semanal definida
+func (w WorkingHours) IsConfigured() bool {
+	return len(w.Weekly) > 0
+}
+
+// IsWorkingAt indica se o instante cai dentro da jornada do agente
+func (w WorkingHours) IsWorkingAt(t time.Time) bool {
+	if !w.IsConfigured() {
+		return true
+	}
+
+	loc, err := w.location()
+	if err != nil {
+		return true
+	}
+	local := t.In(loc)
+
+	date := local.Format(holidayLayout)
+	for _, holiday := range w.Holidays {
+		if holiday == date {
+			return false
+		}
+	}
+
+	minute := local.Hour()*60 + local.Minute()
+	for day, ranges := range w.Weekly {
+		if weekdays[strings.ToLower(day)] != local.Weekday() {
+			continue
+		}
+		for _, r := range ranges {
+			start, err1 := parseClock(r.Start)
+			end, err2 := parseClock(r.End)
+			if err1 == nil && err2 == nil && minute >= start && minute < end {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (w WorkingHours) location() (*time.Location, error) {
+	if w.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(w.Timezone)
+}
+
+// parseClock converte "HH:MM" em minutos desde a meia-noite (aceita "24:00")
+func parseClock(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// WorkingHours retorna o horário de trabalho salvo na configuração do agente
+func (a *Agent) WorkingHours() WorkingHours {
+	var hours WorkingHours
+	a.decodeConfig(ConfigWorkingHours, &hours)
+	return hours
+}
+
+// SetWorkingHours grava o horário de trabalho na configuração do agente
+func (a *Agent) SetWorkingHours(hours WorkingHours) error {
+	if err := hours.Validate(); err != nil {
+		return err
+	}
+	return a.encodeConfig(ConfigWorkingHours, hours)
+}
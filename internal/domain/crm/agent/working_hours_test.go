@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func businessWeek() WorkingHours {
+	day := []TimeRange{{Start: "09:00", End: "12:00"}, {Start: "13:00", End: "18:00"}}
+	return WorkingHours{
+		Timezone: "America/Sao_Paulo",
+		Weekly: map[string][]TimeRange{
+			"monday": day, "tuesday": day, "wednesday": day, "thursday": day, "friday": day,
+		},
+		Holidays: []string{"2025-12-25"},
+	}
+}
+
+func TestWorkingHours_Validate(t *testing.T) {
+	assert.NoError(t, WorkingHours{}.Validate())
+	assert.NoError(t, businessWeek().Validate())
+	assert.NoError(t, WorkingHours{Weekly: map[string][]TimeRange{"Sunday": {{Start: "18:00", End: "24:00"}}}}.Validate())
+
+	tests := map[string]WorkingHours{
+		"timezone": {Timezone: "Mars/Olympus"},
+		"weekday":  {Weekly: map[string][]TimeRange{"funday": {{Start: "09:00", End: "10:00"}}}},
+		"clock":    {Weekly: map[string][]TimeRange{"monday": {{Start: "9h", End: "10:00"}}}},
+		"order":    {Weekly: map[string][]TimeRange{"monday": {{Start: "18:00", End: "09:00"}}}},
+		"holiday":  {Holidays: []string{"25/12/2025"}},
+	}
+	for name, hours := range tests {
+		assert.Error(t, hours.Validate(), name)
+	}
+}
+
+func TestWorkingHours_IsWorkingAt(t *testing.T) {
+	hours := businessWeek()
+	sp, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	// Segunda-feira, 17/11/2025
+	assert.True(t, hours.IsWorkingAt(time.Date(2025, 11, 17, 9, 0, 0, 0, sp)))
+	assert.False(t, hours.IsWorkingAt(time.Date(2025, 11, 17, 12, 30, 0, 0, sp)), "lunch break")
+	assert.False(t, hours.IsWorkingAt(time.Date(2025, 11, 17, 18, 0, 0, 0, sp)), "end is exclusive")
+	assert.True(t, hours.IsWorkingAt(time.Date(2025, 11, 17, 20, 30, 0, 0, time.UTC)), "17:30 in São Paulo")
+	assert.False(t, hours.IsWorkingAt(time.Date(2025, 11, 22, 10, 0, 0, 0, sp)), "saturday")
+	assert.False(t, hours.IsWorkingAt(time.Date(2025, 12, 25, 10, 0, 0, 0, sp)), "holiday")
+
+	assert.True(t, WorkingHours{}.IsWorkingAt(time.Date(2025, 12, 25, 3, 0, 0, 0, sp)), "no schedule = always working")
+}
+
+func TestAgent_WorkingHours(t *testing.T) {
+	userID := uuid.New()
+	a, err := NewAgent(uuid.New(), "tenant-123", "John Doe", AgentTypeHuman, &userID)
+	require.NoError(t, err)
+
+	assert.False(t, a.WorkingHours().IsConfigured())
+
+	require.NoError(t, a.SetWorkingHours(businessWeek()))
+	assert.Equal(t, businessWeek(), a.WorkingHours())
+
+	assert.Error(t, a.SetWorkingHours(WorkingHours{Timezone: "nowhere"}))
+	assert.Equal(t, businessWeek(), a.WorkingHours(), "invalid hours are not saved")
+}
+
+func TestAgent_IsAvailableForRoutingAt_RespectsWorkingHours(t *testing.T) {
+	userID := uuid.New()
+	a, err := NewAgent(uuid.New(), "tenant-123", "John Doe", AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	a.SetStatus(AgentStatusAvailable)
+	require.NoError(t, a.SetWorkingHours(businessWeek()))
+
+	assert.True(t, a.IsAvailableForRoutingAt(time.Date(2025, 11, 17, 16, 0, 0, 0, time.UTC)), "13:00 in São Paulo")
+	assert.False(t, a.IsAvailableForRoutingAt(time.Date(2025, 11, 17, 23, 0, 0, 0, time.UTC)))
+}
@@ -26,6 +26,17 @@ func NewAgentSession(
 	agentID uuid.UUID,
 	sessionID uuid.UUID,
 	roleInSession *RoleInSession,
+) (*AgentSession, error) {
+	return NewAgentSessionAt(agentID, sessionID, roleInSession, time.Now())
+}
+
+// NewAgentSessionAt registra a participação com o instante real de entrada
+// (ex: AssignedAt do evento de assignment processado de forma assíncrona)
+func NewAgentSessionAt(
+	agentID uuid.UUID,
+	sessionID uuid.UUID,
+	roleInSession *RoleInSession,
+	joinedAt time.Time,
 ) (*AgentSession, error) {
 	if agentID == uuid.Nil {
 		return nil, errors.New("agentID cannot be nil")
@@ -34,13 +45,17 @@ func NewAgentSession(
 		return nil, errors.New("sessionID cannot be nil")
 	}
 
+	if joinedAt.IsZero() {
+		joinedAt = time.Now()
+	}
+
 	now := time.Now()
 	as := &AgentSession{
 		id:            uuid.New(),
 		agentID:       agentID,
 		sessionID:     sessionID,
 		roleInSession: roleInSession,
-		joinedAt:      now,
+		joinedAt:      joinedAt,
 		isActive:      true,
 		metadata:      make(map[string]interface{}),
 		createdAt:     now,
@@ -53,7 +68,7 @@ func NewAgentSession(
 		AgentID:        agentID,
 		SessionID:      sessionID,
 		Role:           roleInSession,
-		JoinedAt:       now,
+		JoinedAt:       joinedAt,
 	})
 
 	return as, nil
@@ -91,20 +106,28 @@ func ReconstructAgentSession(
 }
 
 func (as *AgentSession) Leave() error {
+	return as.LeaveAt(time.Now())
+}
+
+// LeaveAt encerra a participação no instante informado (nunca antes da entrada)
+func (as *AgentSession) LeaveAt(leftAt time.Time) error {
 	if !as.isActive {
 		return errors.New("agent is not active in this session")
 	}
 
-	now := time.Now()
+	if leftAt.Before(as.joinedAt) {
+		leftAt = as.joinedAt
+	}
+
 	as.isActive = false
-	as.leftAt = &now
-	as.updatedAt = now
+	as.leftAt = &leftAt
+	as.updatedAt = time.Now()
 
 	as.addEvent(AgentLeftSessionEvent{
 		AgentSessionID: as.id,
 		AgentID:        as.agentID,
 		SessionID:      as.sessionID,
-		LeftAt:         now,
+		LeftAt:         leftAt,
 	})
 
 	return nil
@@ -0,0 +1,184 @@
+package businesshours
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrCalendarNotFound     = errors.New("business hours calendar not found")
+	ErrInvalidTenant        = errors.New("tenantID cannot be empty")
+	ErrInvalidProject       = errors.New("projectID cannot be nil")
+	ErrEmptyAutoReply       = errors.New("out-of-office message cannot be empty when enabled")
+	ErrNegativeCooldown     = errors.New("out-of-office cooldown cannot be negative")
+	ErrInvalidHolidayFormat = errors.New("invalid holiday, expected YYYY-MM-DD or MM-DD")
+)
+
+// OutOfOffice resposta automática enviada quando o contato escreve fora do expediente.
+// É enviada no máximo uma vez por sessão; Cooldown evita repetir para o mesmo contato
+// em sessões próximas (0 = só o limite por sessão).
+type OutOfOffice struct {
+	Enabled  bool
+	Message  string
+	Cooldown time.Duration
+}
+
+func (o OutOfOffice) validate() error {
+	if o.Cooldown < 0 {
+		return ErrNegativeCooldown
+	}
+	if o.Enabled && strings.TrimSpace(o.Message) == "" {
+		return ErrEmptyAutoReply
+	}
+	return nil
+}
+
+// Calendar expediente do projeto (um por projeto), usado por automações, SLA e auto-resposta
+type Calendar struct {
+	id          uuid.UUID
+	tenantID    string
+	projectID   uuid.UUID
+	schedule    Schedule
+	outOfOffice OutOfOffice
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewCalendar(tenantID string, projectID uuid.UUID, schedule Schedule) (*Calendar, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenant
+	}
+	if projectID == uuid.Nil {
+		return nil, ErrInvalidProject
+	}
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Calendar{
+		id:        uuid.New(),
+		tenantID:  tenantID,
+		projectID: projectID,
+		schedule:  normalize(schedule),
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructCalendar(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	schedule Schedule,
+	outOfOffice OutOfOffice,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Calendar {
+	return &Calendar{
+		id:          id,
+		tenantID:    tenantID,
+		projectID:   projectID,
+		schedule:    schedule,
+		outOfOffice: outOfOffice,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+func (c *Calendar) SetSchedule(schedule Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	c.schedule = normalize(schedule)
+	c.updatedAt = time.Now()
+	return nil
+}
+
+func (c *Calendar) SetOutOfOffice(outOfOffice OutOfOffice) error {
+	if err := outOfOffice.validate(); err != nil {
+		return err
+	}
+	outOfOffice.Message = strings.TrimSpace(outOfOffice.Message)
+	c.outOfOffice = outOfOffice
+	c.updatedAt = time.Now()
+	return nil
+}
+
+// ImportHolidays acrescenta feriados (ex: vindos de um arquivo iCal) sem duplicar.
+// Retorna quantas datas eram novas.
+func (c *Calendar) ImportHolidays(holidays []string) (int, error) {
+	for _, holiday := range holidays {
+		if !IsValidHoliday(holiday) {
+			return 0, ErrInvalidHolidayFormat
+		}
+	}
+
+	before := len(c.schedule.Holidays)
+	c.schedule.Holidays = append(c.schedule.Holidays, holidays...)
+	c.schedule = normalize(c.schedule)
+	added := len(c.schedule.Holidays) - before
+	if added > 0 {
+		c.updatedAt = time.Now()
+	}
+	return added, nil
+}
+
+// IsOpenAt indica se o instante está dentro do expediente
+func (c *Calendar) IsOpenAt(t time.Time) bool {
+	return c.schedule.IsOpenAt(t)
+}
+
+// ShouldAutoReply indica se uma mensagem recebida em at deve receber a resposta de ausência.
+// lastReplyAt é a última resposta de ausência enviada ao contato (nil = nunca).
+func (c *Calendar) ShouldAutoReply(at time.Time, lastReplyAt *time.Time) bool {
+	if !c.outOfOffice.Enabled || c.IsOpenAt(at) {
+		return false
+	}
+	if lastReplyAt != nil && c.outOfOffice.Cooldown > 0 && at.Sub(*lastReplyAt) < c.outOfOffice.Cooldown {
+		return false
+	}
+	return true
+}
+
+// normalize remove feriados duplicados e os ordena
+func normalize(schedule Schedule) Schedule {
+	if len(schedule.Holidays) == 0 {
+		return schedule
+	}
+	seen := make(map[string]bool, len(schedule.Holidays))
+	holidays := make([]string, 0, len(schedule.Holidays))
+	for _, holiday := range schedule.Holidays {
+		if !seen[holiday] {
+			seen[holiday] = true
+			holidays = append(holidays, holiday)
+		}
+	}
+	sort.Strings(holidays)
+	schedule.Holidays = holidays
+	return schedule
+}
+
+func (c *Calendar) ID() uuid.UUID            { return c.id }
+func (c *Calendar) TenantID() string         { return c.tenantID }
+func (c *Calendar) ProjectID() uuid.UUID     { return c.projectID }
+func (c *Calendar) OutOfOffice() OutOfOffice { return c.outOfOffice }
+func (c *Calendar) CreatedAt() time.Time     { return c.createdAt }
+func (c *Calendar) UpdatedAt() time.Time     { return c.updatedAt }
+
+// Schedule retorna uma cópia do expediente (usada como fallback pelas políticas de SLA)
+func (c *Calendar) Schedule() *Schedule {
+	schedule := Schedule{
+		Timezone: c.schedule.Timezone,
+		Weekly:   make(map[string][]Interval, len(c.schedule.Weekly)),
+		Holidays: append([]string(nil), c.schedule.Holidays...),
+	}
+	for day, intervals := range c.schedule.Weekly {
+		schedule.Weekly[day] = append([]Interval(nil), intervals...)
+	}
+	return &schedule
+}
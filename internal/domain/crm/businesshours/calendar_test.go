@@ -0,0 +1,79 @@
+package businesshours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCalendar(t *testing.T) {
+	calendar, err := NewCalendar("tenant-123", uuid.New(), *commercialSchedule())
+	require.NoError(t, err)
+	assert.False(t, calendar.OutOfOffice().Enabled)
+
+	_, err = NewCalendar("", uuid.New(), Schedule{})
+	assert.ErrorIs(t, err, ErrInvalidTenant)
+
+	_, err = NewCalendar("tenant-123", uuid.Nil, Schedule{})
+	assert.ErrorIs(t, err, ErrInvalidProject)
+
+	_, err = NewCalendar("tenant-123", uuid.New(), Schedule{Timezone: "Mars/Olympus"})
+	assert.Error(t, err)
+}
+
+func TestCalendar_SetOutOfOffice(t *testing.T) {
+	calendar, err := NewCalendar("tenant-123", uuid.New(), *commercialSchedule())
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, calendar.SetOutOfOffice(OutOfOffice{Enabled: true, Message: "  "}), ErrEmptyAutoReply)
+	assert.ErrorIs(t, calendar.SetOutOfOffice(OutOfOffice{Cooldown: -time.Minute}), ErrNegativeCooldown)
+
+	require.NoError(t, calendar.SetOutOfOffice(OutOfOffice{Enabled: true, Message: " Voltamos às 9h ", Cooldown: time.Hour}))
+	assert.Equal(t, "Voltamos às 9h", calendar.OutOfOffice().Message)
+}
+
+func TestCalendar_ImportHolidays(t *testing.T) {
+	calendar, err := NewCalendar("tenant-123", uuid.New(), *commercialSchedule())
+	require.NoError(t, err)
+
+	added, err := calendar.ImportHolidays([]string{"12-25", "2025-01-01", "2025-04-18", "12-25"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, added)
+	assert.Equal(t, []string{"12-25", "2025-01-01", "2025-04-18"}, calendar.Schedule().Holidays)
+
+	_, err = calendar.ImportHolidays([]string{"25/12"})
+	assert.ErrorIs(t, err, ErrInvalidHolidayFormat)
+}
+
+func TestCalendar_ShouldAutoReply(t *testing.T) {
+	calendar, err := NewCalendar("tenant-123", uuid.New(), *commercialSchedule())
+	require.NoError(t, err)
+
+	loc, _ := time.LoadLocation("America/Sao_Paulo")
+	night := time.Date(2025, 1, 6, 22, 0, 0, 0, loc)
+	morning := time.Date(2025, 1, 6, 10, 0, 0, 0, loc)
+
+	// Desabilitada
+	assert.False(t, calendar.ShouldAutoReply(night, nil))
+
+	require.NoError(t, calendar.SetOutOfOffice(OutOfOffice{Enabled: true, Message: "Fechado", Cooldown: 2 * time.Hour}))
+	assert.True(t, calendar.ShouldAutoReply(night, nil))
+	assert.False(t, calendar.ShouldAutoReply(morning, nil))
+
+	recent := night.Add(-time.Hour)
+	old := night.Add(-3 * time.Hour)
+	assert.False(t, calendar.ShouldAutoReply(night, &recent))
+	assert.True(t, calendar.ShouldAutoReply(night, &old))
+}
+
+func TestCalendar_ScheduleIsCopy(t *testing.T) {
+	calendar, err := NewCalendar("tenant-123", uuid.New(), *commercialSchedule())
+	require.NoError(t, err)
+
+	calendar.Schedule().Holidays[0] = "2030-01-01"
+
+	assert.Equal(t, "2025-01-01", calendar.Schedule().Holidays[0])
+}
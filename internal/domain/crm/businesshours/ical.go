@@ -0,0 +1,140 @@
+package businesshours
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxHolidaySpanDays limita a expansão de eventos de vários dias (evita calendários de "férias" anuais)
+const maxHolidaySpanDays = 31
+
+var ErrNoHolidays = errors.New("ical file has no all-day events")
+
+// ParseICalHolidays extrai feriados de um arquivo iCalendar (RFC 5545).
+// Cada VEVENT vira um ou mais dias fechados: eventos com RRULE FREQ=YEARLY são recorrentes (MM-DD),
+// os demais são datas únicas (YYYY-MM-DD). DTEND é exclusivo, como no padrão.
+func ParseICalHolidays(r io.Reader) ([]string, error) {
+	lines, err := unfoldICal(r)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{}
+	var event *icalEvent
+	for _, line := range lines {
+		name, value := splitICalLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			event = &icalEvent{}
+		case name == "END" && value == "VEVENT":
+			if event == nil {
+				continue
+			}
+			days, err := event.days()
+			if err != nil {
+				return nil, err
+			}
+			for _, day := range days {
+				seen[day] = true
+			}
+			event = nil
+		case event == nil:
+			continue
+		case name == "DTSTART":
+			event.start = value
+		case name == "DTEND":
+			event.end = value
+		case name == "RRULE":
+			event.yearly = strings.Contains(strings.ToUpper(value), "FREQ=YEARLY")
+		}
+	}
+
+	if len(seen) == 0 {
+		return nil, ErrNoHolidays
+	}
+	holidays := make([]string, 0, len(seen))
+	for day := range seen {
+		holidays = append(holidays, day)
+	}
+	sort.Strings(holidays)
+	return holidays, nil
+}
+
+type icalEvent struct {
+	start  string
+	end    string
+	yearly bool
+}
+
+func (e *icalEvent) days() ([]string, error) {
+	start, err := parseICalDate(e.start)
+	if err != nil {
+		return nil, fmt.Errorf("invalid DTSTART %q: %w", e.start, err)
+	}
+	end := start.AddDate(0, 0, 1)
+	if e.end != "" {
+		if end, err = parseICalDate(e.end); err != nil {
+			return nil, fmt.Errorf("invalid DTEND %q: %w", e.end, err)
+		}
+		if !end.After(start) {
+			end = start.AddDate(0, 0, 1)
+		}
+	}
+
+	layout := dateLayout
+	if e.yearly {
+		layout = recurringLayout
+	}
+
+	var days []string
+	for day := start; day.Before(end) && len(days) < maxHolidaySpanDays; day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format(layout))
+	}
+	return days, nil
+}
+
+// parseICalDate aceita DATE (20250101) e DATE-TIME (20250101T000000Z); usa só a data
+func parseICalDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, errors.New("too short")
+	}
+	return time.Parse("20060102", value[:8])
+}
+
+// unfoldICal junta as linhas dobradas (continuação começa com espaço ou tab)
+func unfoldICal(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read ical file: %w", err)
+	}
+	return lines, nil
+}
+
+// splitICalLine separa "NAME;PARAM=X:VALUE" em nome (sem parâmetros) e valor
+func splitICalLine(line string) (string, string) {
+	idx := strings.Index(line, ":")
+	if idx < 0 {
+		return strings.ToUpper(line), ""
+	}
+	name := line[:idx]
+	if semi := strings.Index(name, ";"); semi >= 0 {
+		name = name[:semi]
+	}
+	return strings.ToUpper(name), strings.TrimSpace(line[idx+1:])
+}
@@ -0,0 +1,57 @@
+package businesshours
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const brazilHolidaysICal = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"PRODID:-//Test//Feriados//PT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:natal@test\r\n" +
+	"DTSTART;VALUE=DATE:20241225\r\n" +
+	"DTEND;VALUE=DATE:20241226\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:Natal\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:carnaval@test\r\n" +
+	"DTSTART;VALUE=DATE:20250303\r\n" +
+	"DTEND;VALUE=DATE:20250305\r\n" +
+	"SUMMARY:Carnaval com uma descrição\r\n" +
+	"  dobrada em duas linhas\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"UID:sexta-santa@test\r\n" +
+	"DTSTART;TZID=America/Sao_Paulo:20250418T000000\r\n" +
+	"SUMMARY:Sexta-feira Santa\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICalHolidays(t *testing.T) {
+	holidays, err := ParseICalHolidays(strings.NewReader(brazilHolidaysICal))
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"12-25", "2025-03-03", "2025-03-04", "2025-04-18"}, holidays)
+}
+
+func TestParseICalHolidays_LongEventIsCapped(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:20250101\nDTEND:20260101\nEND:VEVENT\nEND:VCALENDAR\n"
+
+	holidays, err := ParseICalHolidays(strings.NewReader(ics))
+
+	require.NoError(t, err)
+	assert.Len(t, holidays, maxHolidaySpanDays)
+}
+
+func TestParseICalHolidays_Errors(t *testing.T) {
+	_, err := ParseICalHolidays(strings.NewReader("BEGIN:VCALENDAR\nEND:VCALENDAR\n"))
+	assert.ErrorIs(t, err, ErrNoHolidays)
+
+	_, err = ParseICalHolidays(strings.NewReader("BEGIN:VEVENT\nDTSTART:2025\nEND:VEVENT\n"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,36 @@
+package businesshours
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CalendarRepository interface {
+	Save(ctx context.Context, calendar *Calendar) error
+
+	// FindByProject retorna ErrCalendarNotFound se o projeto não tem expediente configurado
+	FindByProject(ctx context.Context, projectID uuid.UUID) (*Calendar, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// AutoReply registro de uma resposta de ausência enviada
+type AutoReply struct {
+	ID        uuid.UUID
+	TenantID  string
+	ProjectID uuid.UUID
+	ContactID uuid.UUID
+	SessionID uuid.UUID
+	MessageID uuid.UUID
+	SentAt    time.Time
+}
+
+type AutoReplyRepository interface {
+	// TryRecord registra a resposta da sessão. Retorna false se a sessão já recebeu uma
+	// (garante o envio único mesmo com consumidores concorrentes).
+	TryRecord(ctx context.Context, reply AutoReply) (bool, error)
+
+	// LastSentAt retorna a última resposta de ausência enviada ao contato (nil = nunca)
+	LastSentAt(ctx context.Context, contactID uuid.UUID) (*time.Time, error)
+}
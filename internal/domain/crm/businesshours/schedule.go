@@ -0,0 +1,248 @@
+package businesshours
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	recurringLayout = "01-02"
+)
+
+// maxScanDays limita a busca por expediente (agendas sem nenhum intervalo aberto)
+const maxScanDays = 400
+
+// Interval intervalo de um dia no formato "HH:MM" (fim exclusivo; "24:00" = até o fim do dia)
+type Interval struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// Schedule expediente semanal em um fuso horário, com feriados.
+// Agenda sem intervalos semanais funciona 24/7.
+type Schedule struct {
+	// Timezone - Fuso IANA (ex: "America/Sao_Paulo"). Vazio = UTC
+	Timezone string `json:"timezone"`
+
+	// Weekly - Intervalos por dia da semana ("monday" ... "sunday"). Dia ausente = fechado
+	Weekly map[string][]Interval `json:"weekly"`
+
+	// Holidays - Datas fechadas no fuso da agenda: YYYY-MM-DD (data única) ou MM-DD (todo ano)
+	Holidays []string `json:"holidays"`
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+func (s *Schedule) Validate() error {
+	if s == nil {
+		return nil
+	}
+	if _, err := s.location(); err != nil {
+		return fmt.Errorf("invalid timezone %q", s.Timezone)
+	}
+
+	for day, intervals := range s.Weekly {
+		if _, ok := weekdays[strings.ToLower(day)]; !ok {
+			return fmt.Errorf("invalid weekday %q", day)
+		}
+		for _, interval := range intervals {
+			start, err := parseClock(interval.Start)
+			if err != nil {
+				return fmt.Errorf("%s: invalid start %q", day, interval.Start)
+			}
+			end, err := parseClock(interval.End)
+			if err != nil {
+				return fmt.Errorf("%s: invalid end %q", day, interval.End)
+			}
+			if start >= end {
+				return fmt.Errorf("%s: interval %s-%s must end after it starts", day, interval.Start, interval.End)
+			}
+		}
+	}
+
+	for _, holiday := range s.Holidays {
+		if !IsValidHoliday(holiday) {
+			return fmt.Errorf("invalid holiday %q, expected YYYY-MM-DD or MM-DD", holiday)
+		}
+	}
+
+	return nil
+}
+
+// IsAlwaysOpen retorna true se a agenda não restringe horários (nil ou sem intervalos)
+func (s *Schedule) IsAlwaysOpen() bool {
+	if s == nil {
+		return true
+	}
+	for _, intervals := range s.Weekly {
+		if len(intervals) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// IsOpenAt indica se o instante está dentro do expediente
+func (s *Schedule) IsOpenAt(t time.Time) bool {
+	if s.IsAlwaysOpen() {
+		return true
+	}
+	for _, window := range s.windowsOn(s.localDay(t)) {
+		if !t.Before(window.start) && t.Before(window.end) {
+			return true
+		}
+	}
+	return false
+}
+
+// Add retorna o instante em que d de expediente terá decorrido a partir de start.
+// Fora do expediente o relógio fica parado.
+func (s *Schedule) Add(start time.Time, d time.Duration) time.Time {
+	if s.IsAlwaysOpen() || d <= 0 {
+		return start.Add(d)
+	}
+
+	remaining := d
+	day := s.localDay(start)
+	for i := 0; i < maxScanDays; i++ {
+		for _, window := range s.windowsOn(day) {
+			from := window.start
+			if start.After(from) {
+				from = start
+			}
+			if !from.Before(window.end) {
+				continue
+			}
+			available := window.end.Sub(from)
+			if remaining <= available {
+				return from.Add(remaining)
+			}
+			remaining -= available
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	// Agenda sem expediente algum no período: relógio corrido
+	return start.Add(d)
+}
+
+// Between retorna quanto expediente existe entre from e to
+func (s *Schedule) Between(from, to time.Time) time.Duration {
+	if !to.After(from) {
+		return 0
+	}
+	if s.IsAlwaysOpen() {
+		return to.Sub(from)
+	}
+
+	var total time.Duration
+	day := s.localDay(from)
+	for i := 0; i < maxScanDays && day.Before(to); i++ {
+		for _, window := range s.windowsOn(day) {
+			start, end := window.start, window.end
+			if from.After(start) {
+				start = from
+			}
+			if to.Before(end) {
+				end = to
+			}
+			if end.After(start) {
+				total += end.Sub(start)
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+	return total
+}
+
+type window struct {
+	start time.Time
+	end   time.Time
+}
+
+// windowsOn retorna os intervalos abertos do dia (meia-noite local), em ordem
+func (s *Schedule) windowsOn(day time.Time) []window {
+	if s.isHoliday(day) {
+		return nil
+	}
+
+	var windows []window
+	for name, intervals := range s.Weekly {
+		if weekdays[strings.ToLower(name)] != day.Weekday() {
+			continue
+		}
+		for _, interval := range intervals {
+			start, err1 := parseClock(interval.Start)
+			end, err2 := parseClock(interval.End)
+			if err1 != nil || err2 != nil || start >= end {
+				continue
+			}
+			windows = append(windows, window{
+				start: time.Date(day.Year(), day.Month(), day.Day(), start/60, start%60, 0, 0, day.Location()),
+				end:   time.Date(day.Year(), day.Month(), day.Day(), end/60, end%60, 0, 0, day.Location()),
+			})
+		}
+	}
+
+	sort.Slice(windows, func(i, j int) bool { return windows[i].start.Before(windows[j].start) })
+	return windows
+}
+
+func (s *Schedule) isHoliday(day time.Time) bool {
+	date, recurring := day.Format(dateLayout), day.Format(recurringLayout)
+	for _, holiday := range s.Holidays {
+		if holiday == date || holiday == recurring {
+			return true
+		}
+	}
+	return false
+}
+
+// IsValidHoliday aceita YYYY-MM-DD (data única) ou MM-DD (recorrente; 02-29 vale nos anos bissextos)
+func IsValidHoliday(value string) bool {
+	if _, err := time.Parse(dateLayout, value); err == nil {
+		return true
+	}
+	_, err := time.Parse("2006-"+recurringLayout, "2024-"+value)
+	return err == nil && len(value) == len(recurringLayout)
+}
+
+// localDay retorna a meia-noite do dia de t no fuso da agenda
+func (s *Schedule) localDay(t time.Time) time.Time {
+	loc, err := s.location()
+	if err != nil {
+		loc = time.UTC
+	}
+	local := t.In(loc)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+}
+
+func (s *Schedule) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// parseClock converte "HH:MM" em minutos desde a meia-noite (aceita "24:00")
+func parseClock(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
@@ -0,0 +1,105 @@
+package businesshours
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func commercialSchedule() *Schedule {
+	weekday := []Interval{{Start: "09:00", End: "12:00"}, {Start: "13:00", End: "18:00"}}
+	return &Schedule{
+		Timezone: "America/Sao_Paulo",
+		Weekly: map[string][]Interval{
+			"monday":    weekday,
+			"tuesday":   weekday,
+			"wednesday": weekday,
+			"thursday":  weekday,
+			"friday":    weekday,
+		},
+		Holidays: []string{"2025-01-01"},
+	}
+}
+
+func TestSchedule_Validate(t *testing.T) {
+	assert.NoError(t, commercialSchedule().Validate())
+	assert.NoError(t, (*Schedule)(nil).Validate())
+
+	assert.Error(t, (&Schedule{Timezone: "Mars/Olympus"}).Validate())
+	assert.Error(t, (&Schedule{Weekly: map[string][]Interval{"funday": {{Start: "09:00", End: "10:00"}}}}).Validate())
+	assert.Error(t, (&Schedule{Weekly: map[string][]Interval{"monday": {{Start: "10:00", End: "09:00"}}}}).Validate())
+	assert.Error(t, (&Schedule{Holidays: []string{"01/01/2025"}}).Validate())
+	assert.Error(t, (&Schedule{Holidays: []string{"13-01"}}).Validate())
+	assert.NoError(t, (&Schedule{Holidays: []string{"12-25", "02-29"}}).Validate())
+}
+
+func TestSchedule_IsOpenAt(t *testing.T) {
+	s := commercialSchedule()
+
+	// Terça 07/01/2025, São Paulo = UTC-3
+	assert.True(t, s.IsOpenAt(time.Date(2025, 1, 7, 12, 0, 0, 0, time.UTC)))   // 09:00 local
+	assert.False(t, s.IsOpenAt(time.Date(2025, 1, 7, 15, 30, 0, 0, time.UTC))) // 12:30 local, almoço
+	assert.False(t, s.IsOpenAt(time.Date(2025, 1, 7, 21, 0, 0, 0, time.UTC)))  // 18:00 local, fim exclusivo
+	assert.False(t, s.IsOpenAt(time.Date(2025, 1, 4, 15, 0, 0, 0, time.UTC)))  // sábado
+	assert.False(t, s.IsOpenAt(time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC)))  // feriado
+
+	// Feriado recorrente: Natal em qualquer ano
+	s.Holidays = append(s.Holidays, "12-25")
+	assert.False(t, s.IsOpenAt(time.Date(2025, 12, 25, 15, 0, 0, 0, time.UTC)))
+	assert.False(t, s.IsOpenAt(time.Date(2026, 12, 25, 15, 0, 0, 0, time.UTC)))
+
+	assert.True(t, (*Schedule)(nil).IsOpenAt(time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC)))
+	assert.True(t, (&Schedule{}).IsOpenAt(time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC)))
+}
+
+func TestSchedule_Add(t *testing.T) {
+	s := commercialSchedule()
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	t.Run("within the same interval", func(t *testing.T) {
+		start := time.Date(2025, 1, 7, 9, 30, 0, 0, loc)
+		assert.Equal(t, time.Date(2025, 1, 7, 10, 0, 0, 0, loc), s.Add(start, 30*time.Minute))
+	})
+
+	t.Run("skips lunch break", func(t *testing.T) {
+		start := time.Date(2025, 1, 7, 11, 30, 0, 0, loc)
+		assert.Equal(t, time.Date(2025, 1, 7, 13, 30, 0, 0, loc), s.Add(start, time.Hour))
+	})
+
+	t.Run("starting after hours rolls to next business day", func(t *testing.T) {
+		start := time.Date(2025, 1, 7, 20, 0, 0, 0, loc)
+		assert.Equal(t, time.Date(2025, 1, 8, 10, 0, 0, 0, loc), s.Add(start, time.Hour))
+	})
+
+	t.Run("friday evening rolls over the weekend", func(t *testing.T) {
+		start := time.Date(2025, 1, 10, 17, 0, 0, 0, loc)
+		assert.Equal(t, time.Date(2025, 1, 13, 10, 0, 0, 0, loc), s.Add(start, 2*time.Hour))
+	})
+
+	t.Run("skips holidays", func(t *testing.T) {
+		start := time.Date(2024, 12, 31, 17, 30, 0, 0, loc)
+		assert.Equal(t, time.Date(2025, 1, 2, 9, 30, 0, 0, loc), s.Add(start, time.Hour))
+	})
+
+	t.Run("always open schedule uses wall clock", func(t *testing.T) {
+		start := time.Date(2025, 1, 4, 3, 0, 0, 0, time.UTC)
+		assert.Equal(t, start.Add(5*time.Hour), (*Schedule)(nil).Add(start, 5*time.Hour))
+	})
+}
+
+func TestSchedule_Between(t *testing.T) {
+	s := commercialSchedule()
+	loc, err := time.LoadLocation("America/Sao_Paulo")
+	require.NoError(t, err)
+
+	from := time.Date(2025, 1, 10, 17, 0, 0, 0, loc)
+	to := time.Date(2025, 1, 13, 10, 0, 0, 0, loc)
+	assert.Equal(t, 2*time.Hour, s.Between(from, to))
+
+	assert.Equal(t, 8*time.Hour, s.Between(time.Date(2025, 1, 7, 0, 0, 0, 0, loc), time.Date(2025, 1, 8, 0, 0, 0, 0, loc)))
+	assert.Equal(t, time.Duration(0), s.Between(to, from))
+	assert.Equal(t, 3*time.Hour, (*Schedule)(nil).Between(from, from.Add(3*time.Hour)))
+}
@@ -0,0 +1,318 @@
+package cannedresponse
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+var (
+	ErrCannedResponseNotFound = errors.New("canned response not found")
+	ErrAttachmentNotFound     = errors.New("attachment not found")
+	ErrInvalidTenant          = errors.New("tenantID cannot be empty")
+	ErrInvalidProject         = errors.New("projectID cannot be nil")
+	ErrInvalidCreator         = errors.New("createdBy cannot be nil")
+	ErrInvalidShortcut        = errors.New("invalid shortcut: use / followed by up to 32 letters, digits, _ or -")
+	ErrEmptyTitle             = errors.New("title cannot be empty")
+	ErrEmptyContent           = errors.New("content cannot be empty")
+	ErrContentTooLong         = errors.New("content exceeds 4096 characters")
+	ErrInvalidFolder          = errors.New("folder exceeds 100 characters")
+	ErrTooManyAttachments     = errors.New("canned response accepts at most 10 attachments")
+)
+
+type DomainEvent = shared.DomainEvent
+
+const (
+	// MaxContentLength limite do texto (o mesmo das mensagens do WhatsApp)
+	MaxContentLength = 4096
+
+	// MaxAttachments anexos por resposta
+	MaxAttachments = 10
+
+	maxFolderLength = 100
+)
+
+var shortcutPattern = regexp.MustCompile(`^/[\p{Ll}\p{N}][\p{Ll}\p{N}_-]{0,31}$`)
+
+// Attachment arquivo enviado junto com a resposta (guardado no storage)
+type Attachment struct {
+	ID          uuid.UUID `json:"id"`
+	URL         string    `json:"url"`
+	Path        string    `json:"path"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+}
+
+// Details dados editáveis de uma resposta
+type Details struct {
+	Shortcut string
+	Title    string
+	Content  string
+	Folder   string
+}
+
+// CannedResponse resposta pronta do projeto. Com dono (ownerAgentID) é privada do agente;
+// sem dono é compartilhada com o projeto. O atalho (ex: /preco) é único em cada escopo.
+type CannedResponse struct {
+	id           uuid.UUID
+	tenantID     string
+	projectID    uuid.UUID
+	ownerAgentID *uuid.UUID
+	shortcut     string
+	title        string
+	content      string
+	folder       string
+	attachments  []Attachment
+	usageCount   int64
+	lastUsedAt   *time.Time
+	createdBy    uuid.UUID
+	createdAt    time.Time
+	updatedAt    time.Time
+	deletedAt    *time.Time
+
+	events []DomainEvent
+}
+
+// NewCannedResponse cria uma resposta; ownerAgentID != nil a torna privada do agente
+func NewCannedResponse(tenantID string, projectID uuid.UUID, ownerAgentID *uuid.UUID, createdBy uuid.UUID, details Details) (*CannedResponse, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenant
+	}
+	if projectID == uuid.Nil {
+		return nil, ErrInvalidProject
+	}
+	if createdBy == uuid.Nil {
+		return nil, ErrInvalidCreator
+	}
+	details, err := normalizeDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	r := &CannedResponse{
+		id:           uuid.New(),
+		tenantID:     tenantID,
+		projectID:    projectID,
+		ownerAgentID: ownerAgentID,
+		shortcut:     details.Shortcut,
+		title:        details.Title,
+		content:      details.Content,
+		folder:       details.Folder,
+		attachments:  []Attachment{},
+		createdBy:    createdBy,
+		createdAt:    now,
+		updatedAt:    now,
+		events:       []DomainEvent{},
+	}
+	r.addEvent(NewCannedResponseCreatedEvent(r))
+	return r, nil
+}
+
+// ReconstructCannedResponse reconstrói a resposta a partir da persistência
+func ReconstructCannedResponse(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	ownerAgentID *uuid.UUID,
+	shortcut, title, content, folder string,
+	attachments []Attachment,
+	usageCount int64,
+	lastUsedAt *time.Time,
+	createdBy uuid.UUID,
+	createdAt, updatedAt time.Time,
+	deletedAt *time.Time,
+) *CannedResponse {
+	if attachments == nil {
+		attachments = []Attachment{}
+	}
+	return &CannedResponse{
+		id:           id,
+		tenantID:     tenantID,
+		projectID:    projectID,
+		ownerAgentID: ownerAgentID,
+		shortcut:     shortcut,
+		title:        title,
+		content:      content,
+		folder:       folder,
+		attachments:  attachments,
+		usageCount:   usageCount,
+		lastUsedAt:   lastUsedAt,
+		createdBy:    createdBy,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+		deletedAt:    deletedAt,
+		events:       []DomainEvent{},
+	}
+}
+
+// Update substitui atalho, título, texto e pasta; retorna os campos alterados
+func (r *CannedResponse) Update(updatedBy uuid.UUID, details Details) ([]string, error) {
+	details, err := normalizeDetails(details)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	if details.Shortcut != r.shortcut {
+		r.shortcut = details.Shortcut
+		changed = append(changed, "shortcut")
+	}
+	if details.Title != r.title {
+		r.title = details.Title
+		changed = append(changed, "title")
+	}
+	if details.Content != r.content {
+		r.content = details.Content
+		changed = append(changed, "content")
+	}
+	if details.Folder != r.folder {
+		r.folder = details.Folder
+		changed = append(changed, "folder")
+	}
+	if len(changed) > 0 {
+		r.touch(updatedBy, changed)
+	}
+	return changed, nil
+}
+
+// AddAttachment anexa um arquivo já enviado ao storage
+func (r *CannedResponse) AddAttachment(updatedBy uuid.UUID, attachment Attachment) error {
+	if len(r.attachments) >= MaxAttachments {
+		return ErrTooManyAttachments
+	}
+	if attachment.ID == uuid.Nil {
+		attachment.ID = uuid.New()
+	}
+	r.attachments = append(r.attachments, attachment)
+	r.touch(updatedBy, []string{"attachments"})
+	return nil
+}
+
+// RemoveAttachment remove o anexo e o retorna (para apagar o arquivo do storage)
+func (r *CannedResponse) RemoveAttachment(updatedBy, attachmentID uuid.UUID) (Attachment, error) {
+	for i, a := range r.attachments {
+		if a.ID == attachmentID {
+			r.attachments = append(r.attachments[:i:i], r.attachments[i+1:]...)
+			r.touch(updatedBy, []string{"attachments"})
+			return a, nil
+		}
+	}
+	return Attachment{}, ErrAttachmentNotFound
+}
+
+// Delete remove a resposta (soft delete); o atalho fica livre para reuso
+func (r *CannedResponse) Delete(deletedBy uuid.UUID) {
+	if r.deletedAt != nil {
+		return
+	}
+	now := time.Now().UTC()
+	r.deletedAt = &now
+	r.updatedAt = now
+	r.addEvent(NewCannedResponseDeletedEvent(r, deletedBy))
+}
+
+// Render aplica as variáveis ao texto; retorna também as variáveis sem valor (renderizadas vazias)
+func (r *CannedResponse) Render(values map[string]string) (string, []string) {
+	return Render(r.content, values)
+}
+
+// IsPrivate resposta visível só ao agente dono
+func (r *CannedResponse) IsPrivate() bool { return r.ownerAgentID != nil }
+
+// VisibleTo indica se o agente (nil = usuário sem agente) enxerga a resposta
+func (r *CannedResponse) VisibleTo(agentID *uuid.UUID) bool {
+	if r.ownerAgentID == nil {
+		return true
+	}
+	return agentID != nil && *agentID == *r.ownerAgentID
+}
+
+func (r *CannedResponse) IsDeleted() bool { return r.deletedAt != nil }
+
+func (r *CannedResponse) touch(updatedBy uuid.UUID, changed []string) {
+	r.updatedAt = time.Now().UTC()
+	r.addEvent(NewCannedResponseUpdatedEvent(r, updatedBy, changed))
+}
+
+func (r *CannedResponse) addEvent(event DomainEvent) {
+	r.events = append(r.events, event)
+}
+
+func (r *CannedResponse) ID() uuid.UUID               { return r.id }
+func (r *CannedResponse) TenantID() string            { return r.tenantID }
+func (r *CannedResponse) ProjectID() uuid.UUID        { return r.projectID }
+func (r *CannedResponse) OwnerAgentID() *uuid.UUID    { return r.ownerAgentID }
+func (r *CannedResponse) Shortcut() string            { return r.shortcut }
+func (r *CannedResponse) Title() string               { return r.title }
+func (r *CannedResponse) Content() string             { return r.content }
+func (r *CannedResponse) Folder() string              { return r.folder }
+func (r *CannedResponse) UsageCount() int64           { return r.usageCount }
+func (r *CannedResponse) LastUsedAt() *time.Time      { return r.lastUsedAt }
+func (r *CannedResponse) CreatedBy() uuid.UUID        { return r.createdBy }
+func (r *CannedResponse) CreatedAt() time.Time        { return r.createdAt }
+func (r *CannedResponse) UpdatedAt() time.Time        { return r.updatedAt }
+func (r *CannedResponse) DeletedAt() *time.Time       { return r.deletedAt }
+func (r *CannedResponse) DomainEvents() []DomainEvent { return append([]DomainEvent{}, r.events...) }
+func (r *CannedResponse) ClearEvents()                { r.events = []DomainEvent{} }
+
+func (r *CannedResponse) Attachments() []Attachment {
+	return append([]Attachment{}, r.attachments...)
+}
+
+// NormalizeShortcut padroniza o atalho digitado: minúsculas e prefixo "/" (ex: "Preco" → "/preco")
+func NormalizeShortcut(shortcut string) (string, error) {
+	shortcut = strings.ToLower(strings.TrimSpace(shortcut))
+	if !strings.HasPrefix(shortcut, "/") {
+		shortcut = "/" + shortcut
+	}
+	if !shortcutPattern.MatchString(shortcut) {
+		return "", ErrInvalidShortcut
+	}
+	return shortcut, nil
+}
+
+// NormalizeFolder padroniza o caminho da pasta ("Vendas / Preços/" → "Vendas/Preços"); vazio = raiz
+func NormalizeFolder(folder string) (string, error) {
+	var segments []string
+	for _, segment := range strings.Split(folder, "/") {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	folder = strings.Join(segments, "/")
+	if len([]rune(folder)) > maxFolderLength {
+		return "", ErrInvalidFolder
+	}
+	return folder, nil
+}
+
+func normalizeDetails(details Details) (Details, error) {
+	shortcut, err := NormalizeShortcut(details.Shortcut)
+	if err != nil {
+		return Details{}, err
+	}
+	folder, err := NormalizeFolder(details.Folder)
+	if err != nil {
+		return Details{}, err
+	}
+	title := strings.TrimSpace(details.Title)
+	if title == "" {
+		return Details{}, ErrEmptyTitle
+	}
+	if strings.TrimSpace(details.Content) == "" {
+		return Details{}, ErrEmptyContent
+	}
+	if len([]rune(details.Content)) > MaxContentLength {
+		return Details{}, ErrContentTooLong
+	}
+	if err := ValidateVariables(details.Content); err != nil {
+		return Details{}, err
+	}
+	return Details{Shortcut: shortcut, Title: title, Content: details.Content, Folder: folder}, nil
+}
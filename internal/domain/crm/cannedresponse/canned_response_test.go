@@ -0,0 +1,139 @@
+package cannedresponse
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestResponse(t *testing.T, owner *uuid.UUID) *CannedResponse {
+	t.Helper()
+	r, err := NewCannedResponse("tenant-1", uuid.New(), owner, uuid.New(), Details{
+		Shortcut: "Preco",
+		Title:    " Tabela de preços ",
+		Content:  "Olá {{contact.first_name}}, segue a tabela.",
+		Folder:   " Vendas / Preços/ ",
+	})
+	require.NoError(t, err)
+	return r
+}
+
+func TestNewCannedResponse(t *testing.T) {
+	r := newTestResponse(t, nil)
+
+	assert.Equal(t, "/preco", r.Shortcut())
+	assert.Equal(t, "Tabela de preços", r.Title())
+	assert.Equal(t, "Vendas/Preços", r.Folder())
+	assert.False(t, r.IsPrivate())
+	assert.Empty(t, r.Attachments())
+	require.Len(t, r.DomainEvents(), 1)
+	assert.Equal(t, "canned_response.created", r.DomainEvents()[0].EventName())
+}
+
+func TestNewCannedResponse_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		details Details
+		err     error
+	}{
+		{"empty shortcut", Details{Shortcut: "/", Title: "x", Content: "x"}, ErrInvalidShortcut},
+		{"shortcut with space", Details{Shortcut: "/bom dia", Title: "x", Content: "x"}, ErrInvalidShortcut},
+		{"empty title", Details{Shortcut: "/x", Title: " ", Content: "x"}, ErrEmptyTitle},
+		{"empty content", Details{Shortcut: "/x", Title: "x", Content: "  "}, ErrEmptyContent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCannedResponse("tenant-1", uuid.New(), nil, uuid.New(), tt.details)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	t.Run("unknown variable", func(t *testing.T) {
+		_, err := NewCannedResponse("tenant-1", uuid.New(), nil, uuid.New(), Details{
+			Shortcut: "/x", Title: "x", Content: "Oi {{contact.cpf}}",
+		})
+		var unknown *UnknownVariableError
+		require.ErrorAs(t, err, &unknown)
+		assert.Equal(t, "contact.cpf", unknown.Name)
+	})
+
+	t.Run("accented shortcut", func(t *testing.T) {
+		r, err := NewCannedResponse("tenant-1", uuid.New(), nil, uuid.New(), Details{
+			Shortcut: "/Preço", Title: "x", Content: "x",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "/preço", r.Shortcut())
+	})
+}
+
+func TestCannedResponse_Update(t *testing.T) {
+	r := newTestResponse(t, nil)
+	r.ClearEvents()
+
+	changed, err := r.Update(uuid.New(), Details{
+		Shortcut: "/preco",
+		Title:    "Tabela de preços",
+		Content:  "Nova tabela",
+		Folder:   "Vendas/Preços",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"content"}, changed)
+	require.Len(t, r.DomainEvents(), 1)
+	assert.Equal(t, "canned_response.updated", r.DomainEvents()[0].EventName())
+
+	changed, err = r.Update(uuid.New(), Details{Shortcut: "/preco", Title: "Tabela de preços", Content: "Nova tabela", Folder: "Vendas/Preços"})
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Len(t, r.DomainEvents(), 1)
+}
+
+func TestCannedResponse_Attachments(t *testing.T) {
+	r := newTestResponse(t, nil)
+
+	require.NoError(t, r.AddAttachment(uuid.New(), Attachment{URL: "https://cdn/a.pdf", Filename: "a.pdf"}))
+	require.NoError(t, r.AddAttachment(uuid.New(), Attachment{URL: "https://cdn/b.pdf", Filename: "b.pdf"}))
+	first := r.Attachments()[0]
+	assert.NotEqual(t, uuid.Nil, first.ID)
+
+	removed, err := r.RemoveAttachment(uuid.New(), first.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "a.pdf", removed.Filename)
+	require.Len(t, r.Attachments(), 1)
+	assert.Equal(t, "b.pdf", r.Attachments()[0].Filename)
+
+	_, err = r.RemoveAttachment(uuid.New(), first.ID)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+	for len(r.Attachments()) < MaxAttachments {
+		require.NoError(t, r.AddAttachment(uuid.New(), Attachment{URL: "https://cdn/x"}))
+	}
+	assert.ErrorIs(t, r.AddAttachment(uuid.New(), Attachment{URL: "https://cdn/y"}), ErrTooManyAttachments)
+}
+
+func TestCannedResponse_VisibleTo(t *testing.T) {
+	owner := uuid.New()
+	other := uuid.New()
+	private := newTestResponse(t, &owner)
+	shared := newTestResponse(t, nil)
+
+	assert.True(t, private.IsPrivate())
+	assert.True(t, private.VisibleTo(&owner))
+	assert.False(t, private.VisibleTo(&other))
+	assert.False(t, private.VisibleTo(nil))
+	assert.True(t, shared.VisibleTo(nil))
+}
+
+func TestCannedResponse_Delete(t *testing.T) {
+	r := newTestResponse(t, nil)
+	r.ClearEvents()
+
+	r.Delete(uuid.New())
+	r.Delete(uuid.New())
+
+	assert.True(t, r.IsDeleted())
+	require.Len(t, r.DomainEvents(), 1)
+	assert.Equal(t, "canned_response.deleted", r.DomainEvents()[0].EventName())
+}
@@ -0,0 +1,79 @@
+package cannedresponse
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// CannedResponseCreatedEvent resposta pronta criada
+type CannedResponseCreatedEvent struct {
+	shared.BaseEvent
+	CannedResponseID uuid.UUID
+	TenantID         string
+	ProjectID        uuid.UUID
+	OwnerAgentID     *uuid.UUID
+	Shortcut         string
+	Title            string
+	Folder           string
+	CreatedBy        uuid.UUID
+}
+
+func NewCannedResponseCreatedEvent(r *CannedResponse) CannedResponseCreatedEvent {
+	return CannedResponseCreatedEvent{
+		BaseEvent:        shared.NewBaseEvent("canned_response.created", time.Now()),
+		CannedResponseID: r.id,
+		TenantID:         r.tenantID,
+		ProjectID:        r.projectID,
+		OwnerAgentID:     r.ownerAgentID,
+		Shortcut:         r.shortcut,
+		Title:            r.title,
+		Folder:           r.folder,
+		CreatedBy:        r.createdBy,
+	}
+}
+
+// CannedResponseUpdatedEvent resposta pronta editada (inclusive anexos)
+type CannedResponseUpdatedEvent struct {
+	shared.BaseEvent
+	CannedResponseID uuid.UUID
+	TenantID         string
+	ProjectID        uuid.UUID
+	Shortcut         string
+	UpdatedBy        uuid.UUID
+	Changes          []string // campos alterados (shortcut, title, content, folder, attachments)
+}
+
+func NewCannedResponseUpdatedEvent(r *CannedResponse, updatedBy uuid.UUID, changes []string) CannedResponseUpdatedEvent {
+	return CannedResponseUpdatedEvent{
+		BaseEvent:        shared.NewBaseEvent("canned_response.updated", time.Now()),
+		CannedResponseID: r.id,
+		TenantID:         r.tenantID,
+		ProjectID:        r.projectID,
+		Shortcut:         r.shortcut,
+		UpdatedBy:        updatedBy,
+		Changes:          changes,
+	}
+}
+
+// CannedResponseDeletedEvent resposta pronta removida
+type CannedResponseDeletedEvent struct {
+	shared.BaseEvent
+	CannedResponseID uuid.UUID
+	TenantID         string
+	ProjectID        uuid.UUID
+	Shortcut         string
+	DeletedBy        uuid.UUID
+}
+
+func NewCannedResponseDeletedEvent(r *CannedResponse, deletedBy uuid.UUID) CannedResponseDeletedEvent {
+	return CannedResponseDeletedEvent{
+		BaseEvent:        shared.NewBaseEvent("canned_response.deleted", time.Now()),
+		CannedResponseID: r.id,
+		TenantID:         r.tenantID,
+		ProjectID:        r.projectID,
+		Shortcut:         r.shortcut,
+		DeletedBy:        deletedBy,
+	}
+}
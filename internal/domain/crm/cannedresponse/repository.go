@@ -0,0 +1,94 @@
+package cannedresponse
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Scope recorte das respostas visíveis ao agente
+type Scope string
+
+const (
+	ScopeAll     Scope = "all"     // compartilhadas + privadas do agente
+	ScopeShared  Scope = "shared"  // só as do projeto
+	ScopePrivate Scope = "private" // só as do agente
+)
+
+func (s Scope) IsValid() bool {
+	switch s {
+	case ScopeAll, ScopeShared, ScopePrivate:
+		return true
+	}
+	return false
+}
+
+// Filter listagem/busca das respostas que o agente enxerga
+type Filter struct {
+	TenantID  string
+	ProjectID uuid.UUID
+	// ViewerAgentID agente de quem lista (nil = só compartilhadas)
+	ViewerAgentID *uuid.UUID
+	Scope         Scope
+	// Folder pasta exata ("" = raiz); nil = todas
+	Folder *string
+	// Query busca no atalho (prefixo), título e texto; atalhos com o prefixo vêm primeiro
+	Query  string
+	Limit  int
+	Offset int
+}
+
+// FolderCount pasta e quantas respostas visíveis ela tem
+type FolderCount struct {
+	Folder string `json:"folder"`
+	Count  int64  `json:"count"`
+}
+
+// Usage uso de uma resposta por um agente (inserida no compositor)
+type Usage struct {
+	ID               uuid.UUID
+	TenantID         string
+	ProjectID        uuid.UUID
+	CannedResponseID uuid.UUID
+	AgentID          *uuid.UUID
+	UserID           uuid.UUID
+	SessionID        *uuid.UUID
+	UsedAt           time.Time
+}
+
+// UsageQuery período e recorte da análise de uso
+type UsageQuery struct {
+	TenantID  string
+	ProjectID uuid.UUID
+	Since     time.Time
+	Until     time.Time
+	AgentID   *uuid.UUID
+	Limit     int
+}
+
+// UsageStat uso de uma resposta no período
+type UsageStat struct {
+	CannedResponseID uuid.UUID `json:"canned_response_id"`
+	Shortcut         string    `json:"shortcut"`
+	Title            string    `json:"title"`
+	Folder           string    `json:"folder"`
+	Private          bool      `json:"private"`
+	Uses             int64     `json:"uses"`
+	Agents           int64     `json:"agents"`
+	Sessions         int64     `json:"sessions"`
+	LastUsedAt       time.Time `json:"last_used_at"`
+}
+
+type Repository interface {
+	Save(ctx context.Context, r *CannedResponse) error
+	FindByID(ctx context.Context, id uuid.UUID) (*CannedResponse, error)
+	// FindByShortcut busca no escopo exato: ownerAgentID nil = compartilhadas do projeto
+	FindByShortcut(ctx context.Context, projectID uuid.UUID, ownerAgentID *uuid.UUID, shortcut string) (*CannedResponse, error)
+	List(ctx context.Context, filter Filter) ([]*CannedResponse, int64, error)
+	Folders(ctx context.Context, filter Filter) ([]FolderCount, error)
+	// RecordUsage registra o uso e incrementa o contador da resposta
+	RecordUsage(ctx context.Context, usage Usage) error
+	// UsageStats respostas mais usadas no período
+	UsageStats(ctx context.Context, query UsageQuery) ([]UsageStat, error)
+}
@@ -0,0 +1,110 @@
+package cannedresponse
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Variáveis aceitas no texto, no formato {{contact.first_name}}
+const (
+	VarContactName      = "contact.name"
+	VarContactFirstName = "contact.first_name"
+	VarContactEmail     = "contact.email"
+	VarContactPhone     = "contact.phone"
+	VarAgentName        = "agent.name"
+	VarAgentFirstName   = "agent.first_name"
+	VarProjectName      = "project.name"
+	VarSessionStartedAt = "session.started_at"
+)
+
+// Variable variável disponível para o editor de respostas
+type Variable struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Example     string `json:"example"`
+}
+
+// SupportedVariables variáveis renderizadas a partir do contato, da sessão e do agente
+var SupportedVariables = []Variable{
+	{VarContactName, "Nome completo do contato", "Maria Silva"},
+	{VarContactFirstName, "Primeiro nome do contato", "Maria"},
+	{VarContactEmail, "Email do contato", "maria@exemplo.com"},
+	{VarContactPhone, "Telefone do contato", "+5511999998888"},
+	{VarAgentName, "Nome completo do agente", "João Souza"},
+	{VarAgentFirstName, "Primeiro nome do agente", "João"},
+	{VarProjectName, "Nome do projeto", "Loja Centro"},
+	{VarSessionStartedAt, "Início da sessão (fuso do contato)", "10/03/2026 14:30"},
+}
+
+var variablePattern = regexp.MustCompile(`\{\{\s*([a-z_]+(?:\.[a-z_]+)?)\s*\}\}`)
+
+// UnknownVariableError variável não suportada no texto
+type UnknownVariableError struct {
+	Name string
+}
+
+func (e *UnknownVariableError) Error() string {
+	return fmt.Sprintf("unknown variable {{%s}}", e.Name)
+}
+
+// Variables nomes das variáveis usadas no texto, sem repetição e em ordem de aparição
+func Variables(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, match := range variablePattern.FindAllStringSubmatch(content, -1) {
+		if !seen[match[1]] {
+			seen[match[1]] = true
+			names = append(names, match[1])
+		}
+	}
+	return names
+}
+
+// ValidateVariables rejeita variáveis fora de SupportedVariables
+func ValidateVariables(content string) error {
+	for _, name := range Variables(content) {
+		if !isSupported(name) {
+			return &UnknownVariableError{Name: name}
+		}
+	}
+	return nil
+}
+
+// Render substitui as variáveis pelos valores; as que não têm valor ficam vazias e são retornadas
+func Render(content string, values map[string]string) (string, []string) {
+	missing := make(map[string]bool)
+	rendered := variablePattern.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := variablePattern.FindStringSubmatch(placeholder)[1]
+		value := strings.TrimSpace(values[name])
+		if value == "" {
+			missing[name] = true
+		}
+		return value
+	})
+
+	names := make([]string, 0, len(missing))
+	for name := range missing {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return rendered, names
+}
+
+// FirstName primeira palavra do nome
+func FirstName(name string) string {
+	if fields := strings.Fields(name); len(fields) > 0 {
+		return fields[0]
+	}
+	return ""
+}
+
+func isSupported(name string) bool {
+	for _, v := range SupportedVariables {
+		if v.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,37 @@
+package cannedresponse
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVariables(t *testing.T) {
+	content := "Oi {{contact.first_name}}! Sou {{ agent.name }}. {{contact.first_name}} {{invalid"
+
+	assert.Equal(t, []string{"contact.first_name", "agent.name"}, Variables(content))
+	assert.NoError(t, ValidateVariables(content))
+}
+
+func TestRender(t *testing.T) {
+	rendered, missing := Render("Oi {{contact.first_name}}, aqui é {{ agent.first_name }} da {{project.name}}. Email: {{contact.email}}", map[string]string{
+		VarContactFirstName: "Maria",
+		VarAgentFirstName:   "João",
+		VarContactEmail:     " ",
+	})
+
+	assert.Equal(t, "Oi Maria, aqui é João da . Email: ", rendered)
+	assert.Equal(t, []string{VarContactEmail, VarProjectName}, missing)
+}
+
+func TestRender_NoVariables(t *testing.T) {
+	rendered, missing := Render("Obrigado pelo contato!", nil)
+
+	assert.Equal(t, "Obrigado pelo contato!", rendered)
+	assert.Empty(t, missing)
+}
+
+func TestFirstName(t *testing.T) {
+	assert.Equal(t, "Maria", FirstName("  Maria Silva "))
+	assert.Equal(t, "", FirstName(" "))
+}
@@ -42,6 +42,7 @@ const (
 	StatusConnecting   ChannelStatus = "connecting"
 	StatusDisconnected ChannelStatus = "disconnected"
 	StatusError        ChannelStatus = "error"
+	StatusPaused       ChannelStatus = "paused" // Conta de cobrança suspensa: não envia mensagens
 )
 
 type HistoryImportStatus string
@@ -444,6 +445,36 @@ func (c *Channel) Deactivate() {
 	c.addEvent(NewChannelDeactivatedEvent(c.ID))
 }
 
+// Pause pausa um canal ativo (suspensão da conta de cobrança). Canal em outro status não muda:
+// só os canais pausados aqui voltam em Resume.
+func (c *Channel) Pause(reason string) bool {
+	if c.Status != StatusActive {
+		return false
+	}
+	c.Status = StatusPaused
+	c.UpdatedAt = time.Now()
+
+	c.addEvent(NewChannelPausedEvent(c.ID, reason))
+	return true
+}
+
+// Resume reativa um canal pausado
+func (c *Channel) Resume() bool {
+	if c.Status != StatusPaused {
+		return false
+	}
+	c.Status = StatusActive
+	c.UpdatedAt = time.Now()
+
+	c.addEvent(NewChannelResumedEvent(c.ID))
+	return true
+}
+
+// IsPaused indica canal pausado por suspensão da conta
+func (c *Channel) IsPaused() bool {
+	return c.Status == StatusPaused
+}
+
 // RequestActivation marca o canal como "activating" e publica evento para processamento assíncrono
 // O evento channel.activation.requested será consumido por um worker que executará a validação específica do tipo
 func (c *Channel) RequestActivation() {
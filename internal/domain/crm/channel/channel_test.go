@@ -231,6 +231,34 @@ func TestChannel_Deactivate(t *testing.T) {
 	assert.Equal(t, ch.ID, deactivatedEvent.ChannelID)
 }
 
+func TestChannel_PauseAndResume(t *testing.T) {
+	ch := createTestChannel(t)
+	ch.Activate()
+	ch.ClearEvents()
+
+	assert.True(t, ch.Pause("billing account suspended"))
+	assert.True(t, ch.IsPaused())
+	assert.False(t, ch.IsActive())
+	assert.False(t, ch.Pause("again"))
+
+	assert.True(t, ch.Resume())
+	assert.True(t, ch.IsActive())
+	assert.False(t, ch.Resume())
+
+	events := ch.DomainEvents()
+	require.Len(t, events, 2)
+	paused, ok := events[0].(ChannelPausedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "billing account suspended", paused.Reason)
+	_, ok = events[1].(ChannelResumedEvent)
+	assert.True(t, ok)
+
+	// Canal inativo não é pausado (e por isso não volta ativo no Resume)
+	inactive := createTestChannel(t)
+	assert.False(t, inactive.Pause("billing account suspended"))
+	assert.Equal(t, StatusInactive, inactive.Status)
+}
+
 func TestChannel_SetConnecting(t *testing.T) {
 	ch := createTestChannel(t)
 
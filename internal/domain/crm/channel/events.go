@@ -98,6 +98,38 @@ func NewChannelDeactivatedEvent(channelID uuid.UUID) ChannelDeactivatedEvent {
 	}
 }
 
+// ChannelPausedEvent canal pausado por suspensão da conta de cobrança (não envia mensagens)
+type ChannelPausedEvent struct {
+	shared.BaseEvent
+	ChannelID uuid.UUID
+	Reason    string
+	PausedAt  time.Time
+}
+
+func NewChannelPausedEvent(channelID uuid.UUID, reason string) ChannelPausedEvent {
+	return ChannelPausedEvent{
+		BaseEvent: shared.NewBaseEvent("channel.paused", time.Now()),
+		ChannelID: channelID,
+		Reason:    reason,
+		PausedAt:  time.Now(),
+	}
+}
+
+// ChannelResumedEvent canal pausado voltou a ficar ativo (conta reativada)
+type ChannelResumedEvent struct {
+	shared.BaseEvent
+	ChannelID uuid.UUID
+	ResumedAt time.Time
+}
+
+func NewChannelResumedEvent(channelID uuid.UUID) ChannelResumedEvent {
+	return ChannelResumedEvent{
+		BaseEvent: shared.NewBaseEvent("channel.resumed", time.Now()),
+		ChannelID: channelID,
+		ResumedAt: time.Now(),
+	}
+}
+
 type ChannelDeletedEvent struct {
 	shared.BaseEvent
 	ChannelID uuid.UUID
@@ -0,0 +1,33 @@
+package contact
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PipelineStatusSummary é o status atual do contato em um pipeline
+type PipelineStatusSummary struct {
+	PipelineID   uuid.UUID
+	PipelineName string
+	StatusName   string
+}
+
+// Stats agrega a atividade de um contato (mensagens, sessões, tracking e pipelines)
+type Stats struct {
+	TotalMessages          int64
+	TotalSessions          int64
+	ActiveSessions         int64
+	ConvertedSessions      int64
+	AverageSessionDuration time.Duration // apenas sessões encerradas
+	TrackingCount          int64
+	FirstInteractionAt     *time.Time
+	LastInteractionAt      *time.Time
+	PipelineStatuses       []PipelineStatusSummary
+}
+
+// StatsRepository calcula estatísticas agregadas por contato
+type StatsRepository interface {
+	GetStats(ctx context.Context, contactID uuid.UUID) (*Stats, error)
+}
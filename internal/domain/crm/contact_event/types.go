@@ -36,6 +36,7 @@ const (
 	CategorySystem       Category = "system"
 	CategoryNotification Category = "notification"
 	CategoryTracking     Category = "tracking"
+	CategoryTask         Category = "task"
 )
 
 func (c Category) IsValid() bool {
@@ -43,7 +44,8 @@ func (c Category) IsValid() bool {
 	case CategoryGeneral, CategoryStatus, CategoryPipeline,
 		CategoryAssignment, CategoryTag, CategoryNote,
 		CategorySession, CategoryCustomField,
-		CategorySystem, CategoryNotification, CategoryTracking:
+		CategorySystem, CategoryNotification, CategoryTracking,
+		CategoryTask:
 		return true
 	default:
 		return false
@@ -96,6 +98,9 @@ const (
 	EventTypeNoteUpdated = "note_updated"
 	EventTypeNoteDeleted = "note_deleted"
 
+	EventTypeTaskCreated   = "task_created"
+	EventTypeTaskCompleted = "task_completed"
+
 	EventTypeSessionStarted = "session_started"
 	EventTypeSessionEnded   = "session_ended"
 
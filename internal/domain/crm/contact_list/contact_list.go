@@ -28,6 +28,7 @@ type ContactList struct {
 	description      *string
 	filterRules      []*FilterRule
 	logicalOperator  LogicalOperator
+	filterGroup      *FilterGroup // filtros aninhados; quando presente substitui filterRules/logicalOperator
 	isStatic         bool
 	contactCount     int
 	lastCalculatedAt *time.Time
@@ -87,6 +88,7 @@ func ReconstructContactList(
 	description *string,
 	filterRules []*FilterRule,
 	logicalOperator LogicalOperator,
+	filterGroup *FilterGroup,
 	isStatic bool,
 	contactCount int,
 	lastCalculatedAt *time.Time,
@@ -110,6 +112,7 @@ func ReconstructContactList(
 		description:      description,
 		filterRules:      filterRules,
 		logicalOperator:  logicalOperator,
+		filterGroup:      filterGroup,
 		isStatic:         isStatic,
 		contactCount:     contactCount,
 		lastCalculatedAt: lastCalculatedAt,
@@ -187,6 +190,41 @@ func (cl *ContactList) UpdateLogicalOperator(operator LogicalOperator) error {
 	return nil
 }
 
+// SetFilterGroup define (ou remove, com nil) a árvore de filtros aninhados da lista
+func (cl *ContactList) SetFilterGroup(group *FilterGroup) error {
+	if group.IsEmpty() {
+		group = nil
+	}
+	if group != nil {
+		if err := group.Validate(); err != nil {
+			return err
+		}
+	}
+
+	cl.filterGroup = group
+	cl.updatedAt = time.Now()
+
+	cl.addEvent(NewContactListUpdatedEvent(cl.id, []string{"filter_group"}))
+
+	return nil
+}
+
+// EffectiveFilterGroup retorna a árvore de filtros avaliada no cálculo da lista.
+// Listas sem filterGroup são convertidas para um grupo único com as regras planas.
+func (cl *ContactList) EffectiveFilterGroup() *FilterGroup {
+	if cl.filterGroup != nil {
+		return cl.filterGroup
+	}
+	if len(cl.filterRules) == 0 {
+		return nil
+	}
+
+	return &FilterGroup{
+		Logic: cl.logicalOperator,
+		Rules: cl.filterRules,
+	}
+}
+
 func (cl *ContactList) UpdateContactCount(count int) {
 	cl.contactCount = count
 	now := time.Now()
@@ -196,6 +234,29 @@ func (cl *ContactList) UpdateContactCount(count int) {
 	cl.addEvent(NewContactListRecalculatedEvent(cl.id, count))
 }
 
+// RecordContactJoined registra a entrada de um contato na lista (emite list_joined).
+func (cl *ContactList) RecordContactJoined(contactID uuid.UUID) {
+	cl.addEvent(NewContactJoinedListEvent(cl.id, contactID, cl.projectID, cl.tenantID, cl.name, cl.isStatic))
+}
+
+// RecordContactLeft registra a saída de um contato da lista (emite list_left).
+func (cl *ContactList) RecordContactLeft(contactID uuid.UUID) {
+	cl.addEvent(NewContactLeftListEvent(cl.id, contactID, cl.projectID, cl.tenantID, cl.name, cl.isStatic))
+}
+
+// RecordMembershipChanges registra o resultado de um recálculo de lista dinâmica.
+func (cl *ContactList) RecordMembershipChanges(diff *MembershipDiff) {
+	if diff == nil {
+		return
+	}
+	for _, contactID := range diff.Joined {
+		cl.RecordContactJoined(contactID)
+	}
+	for _, contactID := range diff.Left {
+		cl.RecordContactLeft(contactID)
+	}
+}
+
 func (cl *ContactList) Delete() {
 	now := time.Now()
 	cl.deletedAt = &now
@@ -209,7 +270,7 @@ func (cl *ContactList) IsDeleted() bool {
 }
 
 func (cl *ContactList) HasFilterRules() bool {
-	return len(cl.filterRules) > 0
+	return len(cl.filterRules) > 0 || !cl.filterGroup.IsEmpty()
 }
 
 func (cl *ContactList) addEvent(event shared.DomainEvent) {
@@ -232,6 +293,7 @@ func (cl *ContactList) Name() string                     { return cl.name }
 func (cl *ContactList) Description() *string             { return cl.description }
 func (cl *ContactList) FilterRules() []*FilterRule       { return cl.filterRules }
 func (cl *ContactList) LogicalOperator() LogicalOperator { return cl.logicalOperator }
+func (cl *ContactList) FilterGroup() *FilterGroup        { return cl.filterGroup }
 func (cl *ContactList) IsStatic() bool                   { return cl.isStatic }
 func (cl *ContactList) ContactCount() int                { return cl.contactCount }
 func (cl *ContactList) LastCalculatedAt() *time.Time     { return cl.lastCalculatedAt }
@@ -136,3 +136,51 @@ func NewContactRemovedFromListEvent(contactListID, contactID uuid.UUID) ContactR
 		ContactID:     contactID,
 	}
 }
+
+// ContactJoinedListEvent é emitido quando um contato passa a fazer parte da lista,
+// seja por inclusão manual (lista estática) ou por recálculo dos filtros (lista dinâmica).
+// Consumido por sequences com trigger "list_joined".
+type ContactJoinedListEvent struct {
+	shared.BaseEvent
+	ContactListID uuid.UUID
+	ContactID     uuid.UUID
+	ProjectID     uuid.UUID
+	TenantID      string
+	ListName      string
+	IsStatic      bool
+}
+
+func NewContactJoinedListEvent(contactListID, contactID, projectID uuid.UUID, tenantID, listName string, isStatic bool) ContactJoinedListEvent {
+	return ContactJoinedListEvent{
+		BaseEvent:     shared.NewBaseEvent("contact_list.list_joined", time.Now()),
+		ContactListID: contactListID,
+		ContactID:     contactID,
+		ProjectID:     projectID,
+		TenantID:      tenantID,
+		ListName:      listName,
+		IsStatic:      isStatic,
+	}
+}
+
+// ContactLeftListEvent é emitido quando um contato deixa de fazer parte da lista.
+type ContactLeftListEvent struct {
+	shared.BaseEvent
+	ContactListID uuid.UUID
+	ContactID     uuid.UUID
+	ProjectID     uuid.UUID
+	TenantID      string
+	ListName      string
+	IsStatic      bool
+}
+
+func NewContactLeftListEvent(contactListID, contactID, projectID uuid.UUID, tenantID, listName string, isStatic bool) ContactLeftListEvent {
+	return ContactLeftListEvent{
+		BaseEvent:     shared.NewBaseEvent("contact_list.list_left", time.Now()),
+		ContactListID: contactListID,
+		ContactID:     contactID,
+		ProjectID:     projectID,
+		TenantID:      tenantID,
+		ListName:      listName,
+		IsStatic:      isStatic,
+	}
+}
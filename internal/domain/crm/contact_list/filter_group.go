@@ -0,0 +1,74 @@
+package contact_list
+
+import (
+	"errors"
+	"fmt"
+)
+
+// LogicalOperatorNOT nega o resultado de um grupo (combina os filhos com AND e inverte).
+// Só é aceito em FilterGroup; o operador da lista continua sendo AND/OR.
+const LogicalOperatorNOT LogicalOperator = "NOT"
+
+// MaxFilterGroupDepth limita o aninhamento de grupos (e o tamanho da query gerada)
+const MaxFilterGroupDepth = 5
+
+// FilterGroup é uma árvore booleana de regras, no mesmo formato de pipeline.ConditionGroup.
+// Ex.: (tag = vip OR tag = premium) AND NOT (mensagens recebidas nos últimos 30 dias)
+type FilterGroup struct {
+	Logic  LogicalOperator `json:"logic"`
+	Rules  []*FilterRule   `json:"rules"`
+	Groups []FilterGroup   `json:"groups"`
+}
+
+// IsValidGroupOperator indica os operadores aceitos em FilterGroup
+func (lo LogicalOperator) IsValidGroupOperator() bool {
+	return lo.IsValid() || lo == LogicalOperatorNOT
+}
+
+// Validate verifica operadores, profundidade e grupos vazios
+func (g *FilterGroup) Validate() error {
+	return g.validate(1)
+}
+
+func (g *FilterGroup) validate(depth int) error {
+	if depth > MaxFilterGroupDepth {
+		return fmt.Errorf("filter groups cannot be nested more than %d levels", MaxFilterGroupDepth)
+	}
+	if !g.Logic.IsValidGroupOperator() {
+		return fmt.Errorf("invalid group logic: %s", g.Logic)
+	}
+	if g.IsEmpty() {
+		return errors.New("filter group must contain at least one rule or group")
+	}
+
+	for _, rule := range g.Rules {
+		if rule == nil {
+			return errors.New("filter rule cannot be nil")
+		}
+	}
+	for i := range g.Groups {
+		if err := g.Groups[i].validate(depth + 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsEmpty indica se o grupo não tem regras nem subgrupos
+func (g *FilterGroup) IsEmpty() bool {
+	return g == nil || (len(g.Rules) == 0 && len(g.Groups) == 0)
+}
+
+// AllRules retorna todas as regras da árvore (profundidade primeiro)
+func (g *FilterGroup) AllRules() []*FilterRule {
+	if g == nil {
+		return nil
+	}
+
+	rules := append([]*FilterRule{}, g.Rules...)
+	for i := range g.Groups {
+		rules = append(rules, g.Groups[i].AllRules()...)
+	}
+	return rules
+}
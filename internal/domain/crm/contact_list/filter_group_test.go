@@ -0,0 +1,114 @@
+package contact_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterGroup_Validate(t *testing.T) {
+	tagRule, err := NewTagFilterRule(OperatorContains, "vip")
+	require.NoError(t, err)
+
+	t.Run("valid nested group", func(t *testing.T) {
+		group := FilterGroup{
+			Logic: LogicalOperatorAND,
+			Rules: []*FilterRule{tagRule},
+			Groups: []FilterGroup{
+				{Logic: LogicalOperatorNOT, Rules: []*FilterRule{tagRule}},
+			},
+		}
+		assert.NoError(t, group.Validate())
+	})
+
+	t.Run("empty group", func(t *testing.T) {
+		group := FilterGroup{Logic: LogicalOperatorAND}
+		assert.Error(t, group.Validate())
+	})
+
+	t.Run("invalid logic", func(t *testing.T) {
+		group := FilterGroup{Logic: "XOR", Rules: []*FilterRule{tagRule}}
+		assert.Error(t, group.Validate())
+	})
+
+	t.Run("too deep", func(t *testing.T) {
+		group := FilterGroup{Logic: LogicalOperatorAND, Rules: []*FilterRule{tagRule}}
+		for i := 0; i < MaxFilterGroupDepth; i++ {
+			group = FilterGroup{Logic: LogicalOperatorOR, Groups: []FilterGroup{group}}
+		}
+		assert.Error(t, group.Validate())
+	})
+}
+
+func TestFilterGroup_JSONRoundTrip(t *testing.T) {
+	days := 7
+	interactionRule, err := NewInteractionFilterRule(InteractionSessions, OperatorGreaterEqual, 3, &days)
+	require.NoError(t, err)
+	tagRule, err := NewTagFilterRule(OperatorContains, "vip")
+	require.NoError(t, err)
+
+	group := &FilterGroup{
+		Logic:  LogicalOperatorOR,
+		Rules:  []*FilterRule{interactionRule},
+		Groups: []FilterGroup{{Logic: LogicalOperatorNOT, Rules: []*FilterRule{tagRule}}},
+	}
+
+	data, err := json.Marshal(group)
+	require.NoError(t, err)
+
+	var decoded FilterGroup
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	require.Len(t, decoded.Rules, 1)
+	assert.Equal(t, interactionRule.ID(), decoded.Rules[0].ID())
+	assert.Equal(t, FilterTypeInteraction, decoded.Rules[0].FilterType())
+	assert.Equal(t, "sessions", decoded.Rules[0].FieldKey())
+	require.NotNil(t, decoded.Rules[0].WithinDays())
+	assert.Equal(t, 7, *decoded.Rules[0].WithinDays())
+	require.Len(t, decoded.Groups, 1)
+	assert.Equal(t, LogicalOperatorNOT, decoded.Groups[0].Logic)
+	assert.Len(t, decoded.AllRules(), 2)
+}
+
+func TestNewInteractionFilterRule(t *testing.T) {
+	days := 30
+
+	_, err := NewInteractionFilterRule("emails", OperatorGreaterThan, 1, nil)
+	assert.Error(t, err, "invalid source")
+
+	_, err = NewInteractionFilterRule(InteractionMessages, OperatorContains, "x", nil)
+	assert.Error(t, err, "text operators are not supported")
+
+	_, err = NewInteractionFilterRule(InteractionMessages, OperatorWithinLastDays, 7, &days)
+	assert.Error(t, err, "within_days only with count operators")
+
+	_, err = NewInteractionFilterRule(InteractionMessages, OperatorGreaterThan, -1, nil)
+	assert.Error(t, err, "negative count")
+
+	rule, err := NewInteractionFilterRule(InteractionMessages, OperatorEquals, 0, &days)
+	require.NoError(t, err)
+	assert.Equal(t, &days, rule.WithinDays())
+}
+
+func TestContactList_EffectiveFilterGroup(t *testing.T) {
+	list, err := NewContactList(uuid.New(), "tenant-1", "VIPs", LogicalOperatorOR, false)
+	require.NoError(t, err)
+	assert.Nil(t, list.EffectiveFilterGroup())
+
+	tagRule, err := NewTagFilterRule(OperatorContains, "vip")
+	require.NoError(t, err)
+	require.NoError(t, list.AddFilterRule(tagRule))
+
+	flat := list.EffectiveFilterGroup()
+	require.NotNil(t, flat)
+	assert.Equal(t, LogicalOperatorOR, flat.Logic)
+	assert.Len(t, flat.Rules, 1)
+
+	nested := &FilterGroup{Logic: LogicalOperatorNOT, Rules: []*FilterRule{tagRule}}
+	require.NoError(t, list.SetFilterGroup(nested))
+	assert.Same(t, nested, list.EffectiveFilterGroup())
+	assert.True(t, list.HasFilterRules())
+}
@@ -1,6 +1,7 @@
 package contact_list
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -26,6 +27,12 @@ const (
 	OperatorNotIn        FilterOperator = "not_in"
 	OperatorIsNull       FilterOperator = "is_null"
 	OperatorIsNotNull    FilterOperator = "is_not_null"
+
+	// Operadores de data relativa (avaliados no momento do cálculo da lista)
+	OperatorWithinLastDays  FilterOperator = "within_last_days"   // nos últimos N dias
+	OperatorMoreThanDaysAgo FilterOperator = "more_than_days_ago" // há mais de N dias
+	OperatorBefore          FilterOperator = "before"             // antes da data
+	OperatorAfter           FilterOperator = "after"              // depois da data
 )
 
 func (fo FilterOperator) IsValid() bool {
@@ -33,7 +40,29 @@ func (fo FilterOperator) IsValid() bool {
 	case OperatorEquals, OperatorNotEquals, OperatorGreaterThan, OperatorLessThan,
 		OperatorGreaterEqual, OperatorLessEqual, OperatorContains, OperatorNotContains,
 		OperatorStartsWith, OperatorEndsWith, OperatorIn, OperatorNotIn,
-		OperatorIsNull, OperatorIsNotNull:
+		OperatorIsNull, OperatorIsNotNull,
+		OperatorWithinLastDays, OperatorMoreThanDaysAgo, OperatorBefore, OperatorAfter:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRelativeDate indica operadores que recebem um número de dias
+func (fo FilterOperator) IsRelativeDate() bool {
+	return fo == OperatorWithinLastDays || fo == OperatorMoreThanDaysAgo
+}
+
+// IsDateComparison indica operadores que recebem uma data absoluta
+func (fo FilterOperator) IsDateComparison() bool {
+	return fo == OperatorBefore || fo == OperatorAfter
+}
+
+// IsCountComparison indica operadores aceitos na contagem de eventos/interações
+func (fo FilterOperator) IsCountComparison() bool {
+	switch fo {
+	case OperatorEquals, OperatorNotEquals, OperatorGreaterThan, OperatorLessThan,
+		OperatorGreaterEqual, OperatorLessEqual:
 		return true
 	default:
 		return false
@@ -61,6 +90,31 @@ func (ft FilterType) IsValid() bool {
 	}
 }
 
+// InteractionSource identifica o histórico usado por filtros de interação
+type InteractionSource string
+
+const (
+	InteractionMessages         InteractionSource = "messages"          // todas as mensagens
+	InteractionMessagesInbound  InteractionSource = "messages_inbound"  // mensagens enviadas pelo contato
+	InteractionMessagesOutbound InteractionSource = "messages_outbound" // mensagens enviadas ao contato
+	InteractionSessions         InteractionSource = "sessions"
+	InteractionTrackings        InteractionSource = "trackings"
+	InteractionContactEvents    InteractionSource = "contact_events"
+)
+
+func (is InteractionSource) IsValid() bool {
+	switch is {
+	case InteractionMessages, InteractionMessagesInbound, InteractionMessagesOutbound,
+		InteractionSessions, InteractionTrackings, InteractionContactEvents:
+		return true
+	default:
+		return false
+	}
+}
+
+// DateLayout é o formato aceito (além de RFC3339) pelos operadores before/after
+const DateLayout = "2006-01-02"
+
 type FilterRule struct {
 	id         uuid.UUID
 	filterType FilterType
@@ -69,6 +123,7 @@ type FilterRule struct {
 	operator   FilterOperator
 	value      interface{}
 	pipelineID *uuid.UUID
+	withinDays *int // janela opcional para contagem de eventos/interações
 	createdAt  time.Time
 }
 
@@ -97,7 +152,14 @@ func NewFilterRule(
 		if value == nil {
 			return nil, errors.New("value cannot be nil for in/not_in operators")
 		}
-
+	case OperatorWithinLastDays, OperatorMoreThanDaysAgo:
+		if _, err := ParseDays(value); err != nil {
+			return nil, err
+		}
+	case OperatorBefore, OperatorAfter:
+		if _, err := ParseDate(value); err != nil {
+			return nil, err
+		}
 	default:
 		if value == nil && operator != OperatorIsNull && operator != OperatorIsNotNull {
 			return nil, errors.New("value cannot be nil for this operator")
@@ -158,12 +220,65 @@ func NewTagFilterRule(
 	return NewFilterRule(FilterTypeTag, operator, "tag", tagValue)
 }
 
+// NewEventFilterRule filtra pela timeline do contato (contact_events.event_type).
+// Operadores de comparação comparam a quantidade de eventos (opcionalmente nos últimos withinDays dias);
+// operadores de data comparam a ocorrência mais recente; is_null / is_not_null = nunca / já ocorreu.
 func NewEventFilterRule(
 	eventType string,
 	operator FilterOperator,
 	value interface{},
+	withinDays *int,
+) (*FilterRule, error) {
+	return newActivityFilterRule(FilterTypeEvent, eventType, operator, value, withinDays)
+}
+
+// NewInteractionFilterRule filtra pelo histórico de interações (mensagens, sessões, trackings, eventos).
+// Ex.: mensagens recebidas nos últimos 7 dias = (messages_inbound, within_last_days, 7);
+// 3+ sessões = (sessions, gte, 3).
+func NewInteractionFilterRule(
+	source InteractionSource,
+	operator FilterOperator,
+	value interface{},
+	withinDays *int,
+) (*FilterRule, error) {
+	if !source.IsValid() {
+		return nil, fmt.Errorf("invalid interaction source: %s", source)
+	}
+	return newActivityFilterRule(FilterTypeInteraction, string(source), operator, value, withinDays)
+}
+
+func newActivityFilterRule(
+	filterType FilterType,
+	fieldKey string,
+	operator FilterOperator,
+	value interface{},
+	withinDays *int,
 ) (*FilterRule, error) {
-	return NewFilterRule(FilterTypeEvent, operator, eventType, value)
+	switch {
+	case operator.IsCountComparison():
+		if _, err := ParseCount(value); err != nil {
+			return nil, err
+		}
+	case operator.IsRelativeDate(), operator.IsDateComparison(),
+		operator == OperatorIsNull, operator == OperatorIsNotNull:
+		if withinDays != nil {
+			return nil, errors.New("within_days is only allowed with count operators")
+		}
+	default:
+		return nil, fmt.Errorf("operator %s is not supported for %s filters", operator, filterType)
+	}
+
+	if withinDays != nil && *withinDays <= 0 {
+		return nil, errors.New("within_days must be greater than zero")
+	}
+
+	rule, err := NewFilterRule(filterType, operator, fieldKey, value)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.withinDays = withinDays
+	return rule, nil
 }
 
 func NewAttributeFilterRule(
@@ -199,6 +314,7 @@ func ReconstructFilterRule(
 	fieldType *shared.FieldType,
 	value interface{},
 	pipelineID *uuid.UUID,
+	withinDays *int,
 	createdAt time.Time,
 ) *FilterRule {
 	return &FilterRule{
@@ -209,6 +325,7 @@ func ReconstructFilterRule(
 		fieldType:  fieldType,
 		value:      value,
 		pipelineID: pipelineID,
+		withinDays: withinDays,
 		createdAt:  createdAt,
 	}
 }
@@ -220,8 +337,120 @@ func (fr *FilterRule) FieldKey() string             { return fr.fieldKey }
 func (fr *FilterRule) FieldType() *shared.FieldType { return fr.fieldType }
 func (fr *FilterRule) Value() interface{}           { return fr.value }
 func (fr *FilterRule) PipelineID() *uuid.UUID       { return fr.pipelineID }
+func (fr *FilterRule) WithinDays() *int             { return fr.withinDays }
 func (fr *FilterRule) CreatedAt() time.Time         { return fr.createdAt }
 
 func (fr *FilterRule) String() string {
 	return fmt.Sprintf("%s %s %s: %v", fr.filterType, fr.fieldKey, fr.operator, fr.value)
 }
+
+// filterRuleJSON é a forma serializada de uma regra dentro de um FilterGroup
+type filterRuleJSON struct {
+	ID         uuid.UUID         `json:"id"`
+	FilterType FilterType        `json:"filter_type"`
+	Operator   FilterOperator    `json:"operator"`
+	FieldKey   string            `json:"field_key"`
+	FieldType  *shared.FieldType `json:"field_type,omitempty"`
+	Value      interface{}       `json:"value"`
+	PipelineID *uuid.UUID        `json:"pipeline_id,omitempty"`
+	WithinDays *int              `json:"within_days,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+}
+
+func (fr *FilterRule) MarshalJSON() ([]byte, error) {
+	return json.Marshal(filterRuleJSON{
+		ID:         fr.id,
+		FilterType: fr.filterType,
+		Operator:   fr.operator,
+		FieldKey:   fr.fieldKey,
+		FieldType:  fr.fieldType,
+		Value:      fr.value,
+		PipelineID: fr.pipelineID,
+		WithinDays: fr.withinDays,
+		CreatedAt:  fr.createdAt,
+	})
+}
+
+func (fr *FilterRule) UnmarshalJSON(data []byte) error {
+	var raw filterRuleJSON
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*fr = *ReconstructFilterRule(
+		raw.ID,
+		raw.FilterType,
+		raw.Operator,
+		raw.FieldKey,
+		raw.FieldType,
+		raw.Value,
+		raw.PipelineID,
+		raw.WithinDays,
+		raw.CreatedAt,
+	)
+	return nil
+}
+
+// ParseDays converte o valor de operadores relativos (número de dias) vindo de JSON ou código
+func ParseDays(value interface{}) (int, error) {
+	days, err := parseInteger(value)
+	if err != nil {
+		return 0, fmt.Errorf("value must be a number of days: %w", err)
+	}
+	if days <= 0 {
+		return 0, errors.New("number of days must be greater than zero")
+	}
+	return days, nil
+}
+
+// ParseCount converte o valor de filtros de contagem (eventos/interações)
+func ParseCount(value interface{}) (int, error) {
+	count, err := parseInteger(value)
+	if err != nil {
+		return 0, fmt.Errorf("count filters require an integer value: %w", err)
+	}
+	if count < 0 {
+		return 0, errors.New("count cannot be negative")
+	}
+	return count, nil
+}
+
+// ParseDate converte o valor de operadores before/after (RFC3339 ou YYYY-MM-DD)
+func ParseDate(value interface{}) (time.Time, error) {
+	switch v := value.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, nil
+		}
+		if t, err := time.Parse(DateLayout, v); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid date %q (expected RFC3339 or %s)", v, DateLayout)
+	default:
+		return time.Time{}, fmt.Errorf("value must be a date string, got %T", value)
+	}
+}
+
+func parseInteger(value interface{}) (int, error) {
+	switch v := value.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v != float64(int(v)) {
+			return 0, fmt.Errorf("got %v", v)
+		}
+		return int(v), nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return 0, fmt.Errorf("got %v", v)
+		}
+		return int(n), nil
+	default:
+		return 0, fmt.Errorf("got %T", value)
+	}
+}
@@ -2,10 +2,36 @@ package contact_list
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+// MembershipDiff descreve quais contatos entraram e saíram de uma lista após um recálculo.
+type MembershipDiff struct {
+	Joined []uuid.UUID
+	Left   []uuid.UUID
+}
+
+// HasChanges indica se o recálculo alterou a composição da lista.
+func (d *MembershipDiff) HasChanges() bool {
+	return d != nil && (len(d.Joined) > 0 || len(d.Left) > 0)
+}
+
+// ContactSample é um contato retornado na pré-visualização de uma lista
+type ContactSample struct {
+	ID    uuid.UUID
+	Name  string
+	Email string
+	Phone string
+}
+
+// PreviewResult é o resultado da avaliação dos filtros sem persistir a lista
+type PreviewResult struct {
+	Total  int
+	Sample []ContactSample
+}
+
 type Repository interface {
 	Create(ctx context.Context, list *ContactList) error
 
@@ -15,6 +41,10 @@ type Repository interface {
 
 	FindByID(ctx context.Context, id uuid.UUID) (*ContactList, error)
 
+	// FindByIDForUpdate busca a lista bloqueando a linha até o fim da transação do contexto,
+	// para que ajustes concorrentes do contador não se percam.
+	FindByIDForUpdate(ctx context.Context, id uuid.UUID) (*ContactList, error)
+
 	FindByProjectID(ctx context.Context, projectID uuid.UUID) ([]*ContactList, error)
 
 	FindByTenantID(ctx context.Context, tenantID string) ([]*ContactList, error)
@@ -30,4 +60,19 @@ type Repository interface {
 	RemoveContactFromStaticList(ctx context.Context, listID, contactID uuid.UUID) error
 
 	IsContactInList(ctx context.Context, listID, contactID uuid.UUID) (bool, error)
+
+	// FindDynamicListsByContact retorna as listas dinâmicas do projeto ao qual o contato pertence
+	// (inclusive contatos removidos, para que possam sair das listas).
+	FindDynamicListsByContact(ctx context.Context, contactID uuid.UUID) ([]*ContactList, error)
+
+	// FindStaleDynamicLists retorna listas dinâmicas não recalculadas desde calculatedBefore.
+	FindStaleDynamicLists(ctx context.Context, calculatedBefore time.Time, limit int) ([]*ContactList, error)
+
+	// RefreshMembership reavalia os filtros de uma lista dinâmica e sincroniza os membros materializados.
+	// Se contactIDs estiver vazio, toda a lista é recalculada; caso contrário apenas esses contatos.
+	RefreshMembership(ctx context.Context, listID uuid.UUID, contactIDs []uuid.UUID) (*MembershipDiff, error)
+
+	// Preview avalia os filtros de uma lista (não necessariamente persistida) e retorna
+	// o total de contatos e uma amostra de até sampleSize contatos.
+	Preview(ctx context.Context, list *ContactList, sampleSize int) (*PreviewResult, error)
 }
@@ -13,15 +13,17 @@ type MessageCreatedEvent struct {
 	shared.BaseEvent
 	MessageID uuid.UUID
 	ContactID uuid.UUID
+	ProjectID uuid.UUID
 	FromMe    bool
 	CreatedAt time.Time
 }
 
-func NewMessageCreatedEvent(messageID, contactID uuid.UUID, fromMe bool) MessageCreatedEvent {
+func NewMessageCreatedEvent(messageID, contactID, projectID uuid.UUID, fromMe bool) MessageCreatedEvent {
 	return MessageCreatedEvent{
 		BaseEvent: shared.NewBaseEvent("message.created", time.Now()),
 		MessageID: messageID,
 		ContactID: contactID,
+		ProjectID: projectID,
 		FromMe:    fromMe,
 		CreatedAt: time.Now(),
 	}
@@ -88,6 +90,7 @@ func NewMessageFailedEvent(messageID uuid.UUID, failureReason string) MessageFai
 type AIProcessImageRequestedEvent struct {
 	shared.BaseEvent
 	MessageID   uuid.UUID
+	ProjectID   uuid.UUID
 	ChannelID   uuid.UUID
 	ContactID   uuid.UUID
 	SessionID   uuid.UUID
@@ -96,10 +99,11 @@ type AIProcessImageRequestedEvent struct {
 	RequestedAt time.Time
 }
 
-func NewAIProcessImageRequestedEvent(messageID, channelID, contactID, sessionID uuid.UUID, imageURL, mimeType string) AIProcessImageRequestedEvent {
+func NewAIProcessImageRequestedEvent(messageID, projectID, channelID, contactID, sessionID uuid.UUID, imageURL, mimeType string) AIProcessImageRequestedEvent {
 	return AIProcessImageRequestedEvent{
 		BaseEvent:   shared.NewBaseEvent("message.ai.process_image_requested", time.Now()),
 		MessageID:   messageID,
+		ProjectID:   projectID,
 		ChannelID:   channelID,
 		ContactID:   contactID,
 		SessionID:   sessionID,
@@ -112,6 +116,7 @@ func NewAIProcessImageRequestedEvent(messageID, channelID, contactID, sessionID
 type AIProcessVideoRequestedEvent struct {
 	shared.BaseEvent
 	MessageID   uuid.UUID
+	ProjectID   uuid.UUID
 	ChannelID   uuid.UUID
 	ContactID   uuid.UUID
 	SessionID   uuid.UUID
@@ -121,10 +126,11 @@ type AIProcessVideoRequestedEvent struct {
 	RequestedAt time.Time
 }
 
-func NewAIProcessVideoRequestedEvent(messageID, channelID, contactID, sessionID uuid.UUID, videoURL, mimeType string, duration int) AIProcessVideoRequestedEvent {
+func NewAIProcessVideoRequestedEvent(messageID, projectID, channelID, contactID, sessionID uuid.UUID, videoURL, mimeType string, duration int) AIProcessVideoRequestedEvent {
 	return AIProcessVideoRequestedEvent{
 		BaseEvent:   shared.NewBaseEvent("message.ai.process_video_requested", time.Now()),
 		MessageID:   messageID,
+		ProjectID:   projectID,
 		ChannelID:   channelID,
 		ContactID:   contactID,
 		SessionID:   sessionID,
@@ -138,6 +144,7 @@ func NewAIProcessVideoRequestedEvent(messageID, channelID, contactID, sessionID
 type AIProcessAudioRequestedEvent struct {
 	shared.BaseEvent
 	MessageID   uuid.UUID
+	ProjectID   uuid.UUID
 	ChannelID   uuid.UUID
 	ContactID   uuid.UUID
 	SessionID   uuid.UUID
@@ -147,10 +154,11 @@ type AIProcessAudioRequestedEvent struct {
 	RequestedAt time.Time
 }
 
-func NewAIProcessAudioRequestedEvent(messageID, channelID, contactID, sessionID uuid.UUID, audioURL, mimeType string, duration int) AIProcessAudioRequestedEvent {
+func NewAIProcessAudioRequestedEvent(messageID, projectID, channelID, contactID, sessionID uuid.UUID, audioURL, mimeType string, duration int) AIProcessAudioRequestedEvent {
 	return AIProcessAudioRequestedEvent{
 		BaseEvent:   shared.NewBaseEvent("message.ai.process_audio_requested", time.Now()),
 		MessageID:   messageID,
+		ProjectID:   projectID,
 		ChannelID:   channelID,
 		ContactID:   contactID,
 		SessionID:   sessionID,
@@ -164,6 +172,7 @@ func NewAIProcessAudioRequestedEvent(messageID, channelID, contactID, sessionID
 type AIProcessVoiceRequestedEvent struct {
 	shared.BaseEvent
 	MessageID   uuid.UUID
+	ProjectID   uuid.UUID
 	ChannelID   uuid.UUID
 	ContactID   uuid.UUID
 	SessionID   uuid.UUID
@@ -173,10 +182,11 @@ type AIProcessVoiceRequestedEvent struct {
 	RequestedAt time.Time
 }
 
-func NewAIProcessVoiceRequestedEvent(messageID, channelID, contactID, sessionID uuid.UUID, voiceURL, mimeType string, duration int) AIProcessVoiceRequestedEvent {
+func NewAIProcessVoiceRequestedEvent(messageID, projectID, channelID, contactID, sessionID uuid.UUID, voiceURL, mimeType string, duration int) AIProcessVoiceRequestedEvent {
 	return AIProcessVoiceRequestedEvent{
 		BaseEvent:   shared.NewBaseEvent("message.ai.process_voice_requested", time.Now()),
 		MessageID:   messageID,
+		ProjectID:   projectID,
 		ChannelID:   channelID,
 		ContactID:   contactID,
 		SessionID:   sessionID,
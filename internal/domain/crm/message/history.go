@@ -0,0 +1,135 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+const (
+	DefaultHistoryLimit = 50
+	MaxHistoryLimit     = 200
+	MaxReplyThreadSize  = 500
+)
+
+// HistoryFilter define uma página de histórico de mensagens por keyset (timestamp, id).
+// Sem cursor, PageOlder retorna as mensagens mais recentes e PageNewer as mais antigas.
+type HistoryFilter struct {
+	TenantID  string
+	SessionID *uuid.UUID
+	ContactID *uuid.UUID
+	ChannelID *uuid.UUID
+	Cursor    *shared.Cursor
+	Direction shared.PageDirection
+	Limit     int
+}
+
+// Validate normaliza e valida o filtro
+func (f *HistoryFilter) Validate() error {
+	if f.TenantID == "" {
+		return errors.New("tenant_id is required")
+	}
+	if f.SessionID == nil && f.ContactID == nil {
+		return errors.New("session_id or contact_id is required")
+	}
+	if f.Direction == "" {
+		f.Direction = shared.PageOlder
+	}
+	if !f.Direction.IsValid() {
+		return errors.New("direction must be older or newer")
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultHistoryLimit
+	}
+	if f.Limit > MaxHistoryLimit {
+		f.Limit = MaxHistoryLimit
+	}
+	return nil
+}
+
+// HistoryEnrichment é o resultado de processamento de mídia (transcrição, OCR, parsing) inline na mensagem
+type HistoryEnrichment struct {
+	ContentType   string
+	Provider      string
+	Status        string
+	ExtractedText *string
+	ProcessedAt   *time.Time
+}
+
+// ReplyPreview resume a mensagem citada por uma resposta
+type ReplyPreview struct {
+	ID          uuid.UUID
+	FromMe      bool
+	ContentType string
+	Text        *string
+	Timestamp   time.Time
+}
+
+// HistoryMessage é o read model de uma mensagem no histórico
+type HistoryMessage struct {
+	ID            uuid.UUID
+	SessionID     *uuid.UUID
+	ContactID     uuid.UUID
+	ChannelID     uuid.UUID
+	ChannelName   *string
+	FromMe        bool
+	ContentType   string
+	Text          *string
+	MediaURL      *string
+	MediaMimetype *string
+	Status        string
+	AgentID       *uuid.UUID
+	AgentName     *string
+	ReplyToID     *uuid.UUID
+	ReplyTo       *ReplyPreview
+	Metadata      map[string]interface{}
+	Timestamp     time.Time
+	DeliveredAt   *time.Time
+	ReadAt        *time.Time
+	CreatedAt     time.Time
+	Enrichments   []HistoryEnrichment
+}
+
+// Cursor retorna a posição da mensagem para keyset pagination
+func (m *HistoryMessage) Cursor() shared.Cursor {
+	return shared.NewCursor(m.Timestamp, m.ID)
+}
+
+// HistoryPage é uma página do histórico em ordem cronológica (mais antiga primeiro)
+type HistoryPage struct {
+	Messages []*HistoryMessage
+	HasOlder bool
+	HasNewer bool
+}
+
+// OlderCursor retorna o cursor para carregar a página anterior (nil se não houver)
+func (p *HistoryPage) OlderCursor() *shared.Cursor {
+	if !p.HasOlder || len(p.Messages) == 0 {
+		return nil
+	}
+	cursor := p.Messages[0].Cursor()
+	return &cursor
+}
+
+// NewerCursor retorna o cursor da mensagem mais recente da página.
+// É retornado mesmo sem HasNewer para permitir polling de mensagens novas.
+func (p *HistoryPage) NewerCursor() *shared.Cursor {
+	if len(p.Messages) == 0 {
+		return nil
+	}
+	cursor := p.Messages[len(p.Messages)-1].Cursor()
+	return &cursor
+}
+
+// HistoryRepository é o read side do histórico de conversas
+type HistoryRepository interface {
+	// FindHistory retorna uma página de mensagens por keyset, com enriquecimentos e citações inline
+	FindHistory(ctx context.Context, filter HistoryFilter) (*HistoryPage, error)
+
+	// FindReplyThread reconstrói a cadeia de respostas (via reply_to_id) que contém a mensagem,
+	// da raiz até as respostas mais profundas, em ordem cronológica
+	FindReplyThread(ctx context.Context, tenantID string, messageID uuid.UUID) ([]*HistoryMessage, error)
+}
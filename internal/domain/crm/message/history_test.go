@@ -0,0 +1,45 @@
+package message
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+func TestHistoryFilter_Validate(t *testing.T) {
+	sessionID := uuid.New()
+
+	filter := HistoryFilter{TenantID: "tenant-1", SessionID: &sessionID}
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, shared.PageOlder, filter.Direction)
+	assert.Equal(t, DefaultHistoryLimit, filter.Limit)
+
+	capped := HistoryFilter{TenantID: "tenant-1", SessionID: &sessionID, Limit: 10000}
+	assert.NoError(t, capped.Validate())
+	assert.Equal(t, MaxHistoryLimit, capped.Limit)
+
+	noScope := HistoryFilter{TenantID: "tenant-1"}
+	assert.Error(t, noScope.Validate())
+
+	badDirection := HistoryFilter{TenantID: "tenant-1", SessionID: &sessionID, Direction: "up"}
+	assert.Error(t, badDirection.Validate())
+}
+
+func TestHistoryPage_Cursors(t *testing.T) {
+	base := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	first := &HistoryMessage{ID: uuid.New(), Timestamp: base}
+	last := &HistoryMessage{ID: uuid.New(), Timestamp: base.Add(time.Minute)}
+
+	page := &HistoryPage{Messages: []*HistoryMessage{first, last}, HasOlder: true}
+	assert.Equal(t, first.ID, page.OlderCursor().ID)
+	assert.Equal(t, last.ID, page.NewerCursor().ID)
+
+	page.HasOlder = false
+	assert.Nil(t, page.OlderCursor())
+
+	empty := &HistoryPage{}
+	assert.Nil(t, empty.NewerCursor())
+}
@@ -68,7 +68,7 @@ func NewMessage(
 		events:      []DomainEvent{},
 	}
 
-	msg.addEvent(NewMessageCreatedEvent(msg.id, contactID, fromMe))
+	msg.addEvent(NewMessageCreatedEvent(msg.id, contactID, projectID, fromMe))
 
 	return msg, nil
 }
@@ -341,6 +341,7 @@ func (m *Message) RequestAIProcessing(channelConfig AIProcessingConfig) {
 		if channelConfig.ProcessImage {
 			m.addEvent(NewAIProcessImageRequestedEvent(
 				m.id,
+				m.projectID,
 				m.channelID,
 				m.contactID,
 				*m.sessionID,
@@ -354,6 +355,7 @@ func (m *Message) RequestAIProcessing(channelConfig AIProcessingConfig) {
 			duration := 0
 			m.addEvent(NewAIProcessVideoRequestedEvent(
 				m.id,
+				m.projectID,
 				m.channelID,
 				m.contactID,
 				*m.sessionID,
@@ -368,6 +370,7 @@ func (m *Message) RequestAIProcessing(channelConfig AIProcessingConfig) {
 			duration := 0
 			m.addEvent(NewAIProcessAudioRequestedEvent(
 				m.id,
+				m.projectID,
 				m.channelID,
 				m.contactID,
 				*m.sessionID,
@@ -382,6 +385,7 @@ func (m *Message) RequestAIProcessing(channelConfig AIProcessingConfig) {
 			duration := 0
 			m.addEvent(NewAIProcessVoiceRequestedEvent(
 				m.id,
+				m.projectID,
 				m.channelID,
 				m.contactID,
 				*m.sessionID,
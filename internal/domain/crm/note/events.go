@@ -53,9 +53,10 @@ type NoteUpdatedEvent struct {
 	UpdatedBy  uuid.UUID
 	OldContent string
 	NewContent string
+	Changes    []string // campos alterados (content, priority, visible_to_client, tags, mentions)
 }
 
-func NewNoteUpdatedEvent(noteID, contactID uuid.UUID, tenantID string, updatedBy uuid.UUID, oldContent, newContent string) NoteUpdatedEvent {
+func NewNoteUpdatedEvent(noteID, contactID uuid.UUID, tenantID string, updatedBy uuid.UUID, oldContent, newContent string, changes ...string) NoteUpdatedEvent {
 	return NoteUpdatedEvent{
 		BaseEvent:  shared.NewBaseEvent("note.updated", time.Now()),
 		NoteID:     noteID,
@@ -64,6 +65,7 @@ func NewNoteUpdatedEvent(noteID, contactID uuid.UUID, tenantID string, updatedBy
 		UpdatedBy:  updatedBy,
 		OldContent: oldContent,
 		NewContent: newContent,
+		Changes:    changes,
 	}
 }
 
@@ -126,3 +128,29 @@ func (e NotePinnedEvent) EventType() string {
 func (e NotePinnedEvent) AggregateID() uuid.UUID {
 	return e.NoteID
 }
+
+type NoteUnpinnedEvent struct {
+	shared.BaseEvent
+	NoteID     uuid.UUID
+	ContactID  uuid.UUID
+	TenantID   string
+	UnpinnedBy uuid.UUID
+}
+
+func NewNoteUnpinnedEvent(noteID, contactID uuid.UUID, tenantID string, unpinnedBy uuid.UUID) NoteUnpinnedEvent {
+	return NoteUnpinnedEvent{
+		BaseEvent:  shared.NewBaseEvent("note.unpinned", time.Now()),
+		NoteID:     noteID,
+		ContactID:  contactID,
+		TenantID:   tenantID,
+		UnpinnedBy: unpinnedBy,
+	}
+}
+
+func (e NoteUnpinnedEvent) EventType() string {
+	return "note.unpinned"
+}
+
+func (e NoteUnpinnedEvent) AggregateID() uuid.UUID {
+	return e.NoteID
+}
@@ -72,12 +72,31 @@ const (
 )
 
 var (
-	ErrEmptyContent   = errors.New("note content cannot be empty")
-	ErrInvalidContact = errors.New("invalid contact ID")
-	ErrInvalidAuthor  = errors.New("invalid author ID")
-	ErrNoteNotFound   = errors.New("note not found")
+	ErrEmptyContent    = errors.New("note content cannot be empty")
+	ErrInvalidContact  = errors.New("invalid contact ID")
+	ErrInvalidAuthor   = errors.New("invalid author ID")
+	ErrNoteNotFound    = errors.New("note not found")
+	ErrInvalidPriority = errors.New("invalid note priority")
 )
 
+// IsValid indica se a prioridade é conhecida
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// NoteChanges campos editáveis de uma nota; nil mantém o valor atual
+type NoteChanges struct {
+	Content         *string
+	Priority        *Priority
+	VisibleToClient *bool
+	Tags            *[]string
+	Mentions        *[]uuid.UUID
+}
+
 func NewNote(
 	contactID uuid.UUID,
 	tenantID string,
@@ -190,11 +209,74 @@ func (n *Note) UpdateContent(content string, updatedBy uuid.UUID) error {
 	n.content = content
 	n.updatedAt = time.Now()
 
-	n.addEvent(NewNoteUpdatedEvent(n.id, n.contactID, n.tenantID, updatedBy, oldContent, content))
+	n.addEvent(NewNoteUpdatedEvent(n.id, n.contactID, n.tenantID, updatedBy, oldContent, content, "content"))
 
 	return nil
 }
 
+// Update aplica as alterações de uma vez e emite um único NoteUpdatedEvent
+// listando os campos que realmente mudaram. Retorna os agentes mencionados
+// pela primeira vez, que ainda precisam ser notificados.
+func (n *Note) Update(updatedBy uuid.UUID, changes NoteChanges) ([]uuid.UUID, error) {
+	if changes.Content != nil && *changes.Content == "" {
+		return nil, ErrEmptyContent
+	}
+	if changes.Priority != nil && !changes.Priority.IsValid() {
+		return nil, ErrInvalidPriority
+	}
+
+	oldContent := n.content
+	var changed []string
+
+	if changes.Content != nil && *changes.Content != n.content {
+		n.content = *changes.Content
+		changed = append(changed, "content")
+	}
+	if changes.Priority != nil && *changes.Priority != n.priority {
+		n.priority = *changes.Priority
+		changed = append(changed, "priority")
+	}
+	if changes.VisibleToClient != nil && *changes.VisibleToClient != n.visibleToClient {
+		n.visibleToClient = *changes.VisibleToClient
+		changed = append(changed, "visible_to_client")
+	}
+	if changes.Tags != nil && !sameStrings(n.tags, *changes.Tags) {
+		n.tags = nil
+		for _, tag := range *changes.Tags {
+			if tag != "" {
+				n.tags = append(n.tags, tag)
+			}
+		}
+		changed = append(changed, "tags")
+	}
+
+	var newMentions []uuid.UUID
+	if changes.Mentions != nil {
+		previous := n.mentions
+		n.mentions = nil
+		for _, agentID := range *changes.Mentions {
+			n.MentionAgent(agentID)
+		}
+		for _, agentID := range n.mentions {
+			if !containsUUID(previous, agentID) {
+				newMentions = append(newMentions, agentID)
+			}
+		}
+		if len(newMentions) > 0 || len(previous) != len(n.mentions) {
+			changed = append(changed, "mentions")
+		}
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	n.updatedAt = time.Now()
+	n.addEvent(NewNoteUpdatedEvent(n.id, n.contactID, n.tenantID, updatedBy, oldContent, n.content, changed...))
+
+	return newMentions, nil
+}
+
 func (n *Note) SetPriority(priority Priority) {
 	n.priority = priority
 	n.updatedAt = time.Now()
@@ -214,9 +296,13 @@ func (n *Note) Pin(pinnedBy uuid.UUID) {
 	}
 }
 
-func (n *Note) Unpin() {
-	n.pinned = false
-	n.updatedAt = time.Now()
+func (n *Note) Unpin(unpinnedBy uuid.UUID) {
+	if n.pinned {
+		n.pinned = false
+		n.updatedAt = time.Now()
+
+		n.addEvent(NewNoteUnpinnedEvent(n.id, n.contactID, n.tenantID, unpinnedBy))
+	}
 }
 
 func (n *Note) AddTag(tag string) {
@@ -237,12 +323,17 @@ func (n *Note) RemoveTag(tag string) {
 }
 
 func (n *Note) MentionAgent(agentID uuid.UUID) {
-	if agentID != uuid.Nil {
+	if agentID != uuid.Nil && !containsUUID(n.mentions, agentID) {
 		n.mentions = append(n.mentions, agentID)
 		n.updatedAt = time.Now()
 	}
 }
 
+// IsMentioned indica se o agente foi mencionado na nota
+func (n *Note) IsMentioned(agentID uuid.UUID) bool {
+	return containsUUID(n.mentions, agentID)
+}
+
 func (n *Note) AddAttachment(url string) {
 	if url != "" {
 		n.attachments = append(n.attachments, url)
@@ -250,6 +341,15 @@ func (n *Note) AddAttachment(url string) {
 	}
 }
 
+// Attach anexa um arquivo já enviado ao storage e registra a alteração
+func (n *Note) Attach(url string, attachedBy uuid.UUID) {
+	if url == "" {
+		return
+	}
+	n.AddAttachment(url)
+	n.addEvent(NewNoteUpdatedEvent(n.id, n.contactID, n.tenantID, attachedBy, n.content, n.content, "attachments"))
+}
+
 func (n *Note) Delete(deletedBy uuid.UUID) {
 	if n.deletedAt == nil {
 		now := time.Now()
@@ -303,3 +403,24 @@ type DomainEvent interface {
 	AggregateID() uuid.UUID
 	OccurredAt() time.Time
 }
+
+func containsUUID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, existing := range ids {
+		if existing == id {
+			return true
+		}
+	}
+	return false
+}
+
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -254,8 +254,22 @@ func TestNote_PinUnpin(t *testing.T) {
 	})
 
 	t.Run("unpin note", func(t *testing.T) {
-		note.Unpin()
+		note.ClearEvents()
+		note.Unpin(pinnedBy)
 		assert.False(t, note.Pinned())
+
+		events := note.DomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(NoteUnpinnedEvent)
+		require.True(t, ok)
+		assert.Equal(t, pinnedBy, event.UnpinnedBy)
+		assert.Equal(t, "note.unpinned", event.EventType())
+	})
+
+	t.Run("unpin note that is not pinned", func(t *testing.T) {
+		note.ClearEvents()
+		note.Unpin(pinnedBy)
+		assert.Len(t, note.DomainEvents(), 0)
 	})
 }
 
@@ -315,6 +329,91 @@ func TestNote_Mentions(t *testing.T) {
 		note.MentionAgent(uuid.Nil)
 		assert.Len(t, note.Mentions(), initialLen) // Should not add nil
 	})
+
+	t.Run("mention same agent twice", func(t *testing.T) {
+		agent := note.Mentions()[0]
+		initialLen := len(note.Mentions())
+		note.MentionAgent(agent)
+		assert.Len(t, note.Mentions(), initialLen)
+		assert.True(t, note.IsMentioned(agent))
+	})
+}
+
+func TestNote_Update(t *testing.T) {
+	agent1 := uuid.New()
+	agent2 := uuid.New()
+	updatedBy := uuid.New()
+
+	newNote := func(t *testing.T) *Note {
+		n, err := NewNote(uuid.New(), "tenant", uuid.New(), AuthorTypeAgent, "Agent", "Original", NoteTypeGeneral)
+		require.NoError(t, err)
+		n.MentionAgent(agent1)
+		n.ClearEvents()
+		return n
+	}
+
+	t.Run("emits one event with the changed fields", func(t *testing.T) {
+		n := newNote(t)
+		content := "Revised"
+		priority := PriorityHigh
+		visible := false // sem mudança
+
+		newMentions, err := n.Update(updatedBy, NoteChanges{
+			Content:         &content,
+			Priority:        &priority,
+			VisibleToClient: &visible,
+			Mentions:        &[]uuid.UUID{agent1, agent2, agent2},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []uuid.UUID{agent2}, newMentions)
+		assert.Equal(t, []uuid.UUID{agent1, agent2}, n.Mentions())
+		assert.Equal(t, "Revised", n.Content())
+		assert.Equal(t, PriorityHigh, n.Priority())
+
+		events := n.DomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(NoteUpdatedEvent)
+		require.True(t, ok)
+		assert.Equal(t, []string{"content", "priority", "mentions"}, event.Changes)
+		assert.Equal(t, "Original", event.OldContent)
+		assert.Equal(t, "Revised", event.NewContent)
+		assert.Equal(t, updatedBy, event.UpdatedBy)
+	})
+
+	t.Run("removing a mention is a change but notifies nobody", func(t *testing.T) {
+		n := newNote(t)
+
+		newMentions, err := n.Update(updatedBy, NoteChanges{Mentions: &[]uuid.UUID{}})
+		require.NoError(t, err)
+
+		assert.Empty(t, newMentions)
+		assert.Empty(t, n.Mentions())
+		require.Len(t, n.DomainEvents(), 1)
+	})
+
+	t.Run("no changes emits no event", func(t *testing.T) {
+		n := newNote(t)
+		content := "Original"
+
+		_, err := n.Update(updatedBy, NoteChanges{Content: &content, Mentions: &[]uuid.UUID{agent1}})
+		require.NoError(t, err)
+		assert.Len(t, n.DomainEvents(), 0)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		n := newNote(t)
+		empty := ""
+		invalid := Priority("critical")
+
+		_, err := n.Update(updatedBy, NoteChanges{Content: &empty})
+		assert.ErrorIs(t, err, ErrEmptyContent)
+
+		_, err = n.Update(updatedBy, NoteChanges{Priority: &invalid})
+		assert.ErrorIs(t, err, ErrInvalidPriority)
+
+		assert.Equal(t, "Original", n.Content())
+	})
 }
 
 func TestNote_Attachments(t *testing.T) {
@@ -336,6 +435,20 @@ func TestNote_Attachments(t *testing.T) {
 		note.AddAttachment("")
 		assert.Len(t, note.Attachments(), initialLen) // Should not add empty
 	})
+
+	t.Run("attach emits update event", func(t *testing.T) {
+		note.ClearEvents()
+		attachedBy := uuid.New()
+		note.Attach("https://example.com/contract.pdf", attachedBy)
+
+		assert.Contains(t, note.Attachments(), "https://example.com/contract.pdf")
+		events := note.DomainEvents()
+		require.Len(t, events, 1)
+		event, ok := events[0].(NoteUpdatedEvent)
+		require.True(t, ok)
+		assert.Equal(t, []string{"attachments"}, event.Changes)
+		assert.Equal(t, attachedBy, event.UpdatedBy)
+	})
 }
 
 func TestNote_Delete(t *testing.T) {
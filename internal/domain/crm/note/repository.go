@@ -18,6 +18,7 @@ type NoteFilters struct {
 	Priority        *string
 	VisibleToClient *bool
 	Pinned          *bool
+	MentionedAgent  *uuid.UUID // notas que mencionam o agente (caixa de menções)
 	CreatedAfter    *time.Time
 	CreatedBefore   *time.Time
 	Limit           int
@@ -76,6 +76,11 @@ const (
 	TriggerPageVisited    AutomationTrigger = "page.visited"
 	TriggerFormSubmitted  AutomationTrigger = "form.submitted"
 	TriggerFileDownloaded AutomationTrigger = "file.downloaded"
+
+	TriggerSLAWarning  AutomationTrigger = "sla.warning"
+	TriggerSLABreached AutomationTrigger = "sla.breached"
+
+	TriggerTaskOverdue AutomationTrigger = "task.overdue"
 )
 
 type LogicOperator string
@@ -91,6 +96,20 @@ type RuleCondition struct {
 	Value    interface{} `json:"value"`
 }
 
+// ConditionWithinBusinessHours campo embutido: true se o evento ocorreu dentro do expediente do projeto
+// (ex: {"field": "within_business_hours", "operator": "eq", "value": false}). Projeto sem expediente = sempre true.
+const ConditionWithinBusinessHours = "within_business_hours"
+
+// UsesField indica se alguma condição da regra consulta o campo
+func (r *Automation) UsesField(field string) bool {
+	for _, condition := range r.conditions {
+		if condition.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
 type ConditionGroup struct {
 	Logic      LogicOperator    `json:"logic"`
 	Conditions []RuleCondition  `json:"conditions"`
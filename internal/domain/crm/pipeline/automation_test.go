@@ -332,6 +332,19 @@ func TestAutomation_EvaluateConditions(t *testing.T) {
 	})
 }
 
+func TestAutomation_UsesField(t *testing.T) {
+	pipelineID := uuid.New()
+	rule, err := NewAutomation(AutomationTypePipelineBased, "tenant-123", "After hours", TriggerMessageReceived, &pipelineID)
+	require.NoError(t, err)
+	assert.False(t, rule.UsesField(ConditionWithinBusinessHours))
+
+	require.NoError(t, rule.AddCondition(ConditionWithinBusinessHours, "eq", false))
+
+	assert.True(t, rule.UsesField(ConditionWithinBusinessHours))
+	assert.True(t, rule.EvaluateConditions(map[string]interface{}{ConditionWithinBusinessHours: false}))
+	assert.False(t, rule.EvaluateConditions(map[string]interface{}{ConditionWithinBusinessHours: true}))
+}
+
 func TestEvaluateCondition(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -32,6 +32,8 @@ const (
 	CategoryBehavior    TriggerCategory = "behavior"
 	CategoryCustom      TriggerCategory = "custom"
 	CategoryWebhook     TriggerCategory = "webhook"
+	CategorySLA         TriggerCategory = "sla"
+	CategoryTask        TriggerCategory = "task"
 )
 
 type TriggerParameter struct {
@@ -288,6 +290,53 @@ func (r *TriggerRegistry) registerSystemTriggers() {
 				{Name: "file_size_mb", Type: "float"},
 			},
 		},
+
+		// SLA
+		{
+			Code:        string(TriggerSLAWarning),
+			Name:        "SLA Próximo do Vencimento",
+			Description: "Disparado quando um prazo de SLA atinge o percentual de aviso da política",
+			Category:    CategorySLA,
+			IsSystem:    true,
+			Parameters: []TriggerParameter{
+				{Name: "session_id", Type: "uuid"},
+				{Name: "contact_id", Type: "uuid"},
+				{Name: "metric", Type: "string", Description: "first_response, next_response ou resolution"},
+				{Name: "due_at", Type: "timestamp"},
+				{Name: "priority", Type: "string"},
+			},
+		},
+		{
+			Code:        string(TriggerSLABreached),
+			Name:        "SLA Vencido",
+			Description: "Disparado quando um prazo de SLA vence sem ser cumprido",
+			Category:    CategorySLA,
+			IsSystem:    true,
+			Parameters: []TriggerParameter{
+				{Name: "session_id", Type: "uuid"},
+				{Name: "contact_id", Type: "uuid"},
+				{Name: "metric", Type: "string", Description: "first_response, next_response ou resolution"},
+				{Name: "due_at", Type: "timestamp"},
+				{Name: "priority", Type: "string"},
+			},
+		},
+
+		// Tarefas
+		{
+			Code:        string(TriggerTaskOverdue),
+			Name:        "Tarefa Atrasada",
+			Description: "Disparado quando o prazo de uma tarefa aberta vence (tarefa ligada a uma sessão ou negócio do pipeline)",
+			Category:    CategoryTask,
+			IsSystem:    true,
+			Parameters: []TriggerParameter{
+				{Name: "task_id", Type: "uuid"},
+				{Name: "contact_id", Type: "uuid"},
+				{Name: "session_id", Type: "uuid"},
+				{Name: "assignee_id", Type: "uuid"},
+				{Name: "due_at", Type: "timestamp"},
+				{Name: "priority", Type: "string"},
+			},
+		},
 	}
 
 	for _, trigger := range systemTriggers {
@@ -0,0 +1,264 @@
+package project_member
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// CustomRolePrefix identifica os papéis definidos pelo projeto (custom:<slug>) em
+// project_members.role, sem colidir com os papéis padrão
+const CustomRolePrefix = "custom:"
+
+const maxCustomRoleNameLength = 100
+
+var (
+	ErrCustomRoleNameEmpty     = errors.New("custom role name is required")
+	ErrCustomRoleNameTooLong   = errors.New("custom role name is too long")
+	ErrCustomRoleNoPermissions = errors.New("custom role needs at least one permission")
+	ErrInvalidPermission       = errors.New("invalid permission")
+	ErrPermissionNotAssignable = errors.New("permission cannot be granted to project roles")
+	ErrCustomRoleNotFound      = errors.New("custom role not found")
+	ErrCustomRoleExists        = errors.New("a role with this name already exists in the project")
+	ErrCustomRoleInUse         = errors.New("custom role is assigned to members or pending invitations")
+)
+
+var customRoleSlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// CustomRole papel criado pelo projeto a partir das permissões existentes. A chave (custom:<slug>)
+// é fixada na criação: é ela que fica nos membros, então renomear não mexe nas atribuições.
+type CustomRole struct {
+	id          uuid.UUID
+	projectID   uuid.UUID
+	key         ProjectMemberRole
+	name        string
+	description string
+	permissions []Permission
+	// clonedFrom papel de origem quando criado como cópia (padrão ou custom)
+	clonedFrom ProjectMemberRole
+	createdBy  string
+	createdAt  time.Time
+	updatedAt  time.Time
+
+	events []shared.DomainEvent
+}
+
+// NewCustomRole cria o papel com a chave derivada do nome
+func NewCustomRole(projectID uuid.UUID, name, description string, permissions []Permission, clonedFrom ProjectMemberRole, createdBy string) (*CustomRole, error) {
+	if projectID == uuid.Nil {
+		return nil, ErrProjectIDRequired
+	}
+	name, err := normalizeCustomRoleName(name)
+	if err != nil {
+		return nil, err
+	}
+	permissions, err = normalizeRolePermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	key := ProjectMemberRole(CustomRolePrefix + slugify(name))
+	if !IsCustomRole(key) {
+		return nil, ErrInvalidRole
+	}
+
+	now := time.Now().UTC()
+	r := &CustomRole{
+		id:          uuid.New(),
+		projectID:   projectID,
+		key:         key,
+		name:        name,
+		description: strings.TrimSpace(description),
+		permissions: permissions,
+		clonedFrom:  clonedFrom,
+		createdBy:   createdBy,
+		createdAt:   now,
+		updatedAt:   now,
+		events:      []shared.DomainEvent{},
+	}
+	r.events = append(r.events, NewCustomRoleCreatedEvent(r.id, projectID, string(key), permissions, createdBy))
+	return r, nil
+}
+
+// ReconstructCustomRole reconstrói o papel a partir do banco
+func ReconstructCustomRole(
+	id, projectID uuid.UUID,
+	key ProjectMemberRole,
+	name, description string,
+	permissions []Permission,
+	clonedFrom ProjectMemberRole,
+	createdBy string,
+	createdAt, updatedAt time.Time,
+) *CustomRole {
+	return &CustomRole{
+		id:          id,
+		projectID:   projectID,
+		key:         key,
+		name:        name,
+		description: description,
+		permissions: permissions,
+		clonedFrom:  clonedFrom,
+		createdBy:   createdBy,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		events:      []shared.DomainEvent{},
+	}
+}
+
+// Rename troca o nome exibido (a chave continua a mesma)
+func (r *CustomRole) Rename(name string) error {
+	name, err := normalizeCustomRoleName(name)
+	if err != nil {
+		return err
+	}
+	r.name = name
+	r.updatedAt = time.Now().UTC()
+	return nil
+}
+
+// UpdateDescription troca a descrição
+func (r *CustomRole) UpdateDescription(description string) {
+	r.description = strings.TrimSpace(description)
+	r.updatedAt = time.Now().UTC()
+}
+
+// SetPermissions substitui o conjunto de permissões; vale para todos os membros com o papel
+func (r *CustomRole) SetPermissions(permissions []Permission, changedBy string) error {
+	permissions, err := normalizeRolePermissions(permissions)
+	if err != nil {
+		return err
+	}
+	previous := r.permissions
+	r.permissions = permissions
+	r.updatedAt = time.Now().UTC()
+	r.events = append(r.events, NewCustomRolePermissionsChangedEvent(r.id, r.projectID, string(r.key), previous, permissions, changedBy))
+	return nil
+}
+
+// MarkDeleted registra a remoção do papel (a remoção em si é do repositório)
+func (r *CustomRole) MarkDeleted(deletedBy string) {
+	r.events = append(r.events, NewCustomRoleDeletedEvent(r.id, r.projectID, string(r.key), deletedBy))
+}
+
+// HasPermission indica se o papel concede a permissão
+func (r *CustomRole) HasPermission(permission Permission) bool {
+	for _, p := range r.permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCustomRole indica se o papel é uma chave custom:<slug> bem formada
+func IsCustomRole(role ProjectMemberRole) bool {
+	slug, ok := strings.CutPrefix(string(role), CustomRolePrefix)
+	return ok && len(role) <= 50 && customRoleSlug.MatchString(slug)
+}
+
+// IsBuiltInRole indica se o papel é um dos papéis padrão (admin, supervisor, agent, viewer)
+func IsBuiltInRole(role ProjectMemberRole) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
+
+// IsAssignable indica se a permissão pode entrar em um papel de projeto. Billing é da conta do
+// cliente, não do projeto.
+func (p Permission) IsAssignable() bool {
+	return p.IsValid() && p != PermissionViewBilling && p != PermissionManageBilling
+}
+
+// AssignablePermissions permissões disponíveis para compor papéis
+func AssignablePermissions() []Permission {
+	all := AllPermissions()
+	assignable := make([]Permission, 0, len(all))
+	for _, p := range all {
+		if p.IsAssignable() {
+			assignable = append(assignable, p)
+		}
+	}
+	return assignable
+}
+
+func normalizeCustomRoleName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", ErrCustomRoleNameEmpty
+	}
+	if len(name) > maxCustomRoleNameLength {
+		return "", ErrCustomRoleNameTooLong
+	}
+	return name, nil
+}
+
+// normalizeRolePermissions valida, remove duplicadas e ordena
+func normalizeRolePermissions(permissions []Permission) ([]Permission, error) {
+	seen := make(map[Permission]bool, len(permissions))
+	normalized := make([]Permission, 0, len(permissions))
+	for _, p := range permissions {
+		if !p.IsValid() {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPermission, p)
+		}
+		if !p.IsAssignable() {
+			return nil, fmt.Errorf("%w: %s", ErrPermissionNotAssignable, p)
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		normalized = append(normalized, p)
+	}
+	if len(normalized) == 0 {
+		return nil, ErrCustomRoleNoPermissions
+	}
+	sort.Slice(normalized, func(i, j int) bool { return normalized[i] < normalized[j] })
+	return normalized, nil
+}
+
+// slugify "Sales Agent (SP)" → "sales-agent-sp"
+func slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+			dash = false
+		case b.Len() > 0 && !dash:
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if max := 50 - len(CustomRolePrefix); len(slug) > max {
+		slug = strings.TrimSuffix(slug[:max], "-")
+	}
+	return slug
+}
+
+// Getters
+func (r *CustomRole) ID() uuid.UUID                 { return r.id }
+func (r *CustomRole) ProjectID() uuid.UUID          { return r.projectID }
+func (r *CustomRole) Key() ProjectMemberRole        { return r.key }
+func (r *CustomRole) Name() string                  { return r.name }
+func (r *CustomRole) Description() string           { return r.description }
+func (r *CustomRole) ClonedFrom() ProjectMemberRole { return r.clonedFrom }
+func (r *CustomRole) CreatedBy() string             { return r.createdBy }
+func (r *CustomRole) CreatedAt() time.Time          { return r.createdAt }
+func (r *CustomRole) UpdatedAt() time.Time          { return r.updatedAt }
+func (r *CustomRole) Permissions() []Permission {
+	return append([]Permission{}, r.permissions...)
+}
+func (r *CustomRole) DomainEvents() []shared.DomainEvent {
+	return append([]shared.DomainEvent{}, r.events...)
+}
+
+// ClearEvents limpa os eventos de domínio
+func (r *CustomRole) ClearEvents() {
+	r.events = []shared.DomainEvent{}
+}
@@ -0,0 +1,86 @@
+package project_member
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCustomRole(t *testing.T) {
+	t.Run("derives the key and normalizes permissions", func(t *testing.T) {
+		role, err := NewCustomRole(uuid.New(), "  Sales Agent (SP) ", "", []Permission{
+			PermissionViewContacts, PermissionExportContacts, PermissionViewContacts,
+		}, RoleAgent, "owner")
+		require.NoError(t, err)
+
+		assert.Equal(t, ProjectMemberRole("custom:sales-agent-sp"), role.Key())
+		assert.Equal(t, "Sales Agent (SP)", role.Name())
+		assert.Equal(t, []Permission{PermissionExportContacts, PermissionViewContacts}, role.Permissions())
+		assert.Equal(t, RoleAgent, role.ClonedFrom())
+		assert.True(t, role.HasPermission(PermissionExportContacts))
+		assert.False(t, role.HasPermission(PermissionSendMessages))
+
+		require.Len(t, role.DomainEvents(), 1)
+		_, ok := role.DomainEvents()[0].(*CustomRoleCreatedEvent)
+		assert.True(t, ok)
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		cases := []struct {
+			name        string
+			permissions []Permission
+			err         error
+		}{
+			{"", []Permission{PermissionViewContacts}, ErrCustomRoleNameEmpty},
+			{"Empty", nil, ErrCustomRoleNoPermissions},
+			{"Unknown", []Permission{"contacts.delete"}, ErrInvalidPermission},
+			{"Billing", []Permission{PermissionViewBilling}, ErrPermissionNotAssignable},
+			{"!!!", []Permission{PermissionViewContacts}, ErrInvalidRole},
+		}
+		for _, tc := range cases {
+			_, err := NewCustomRole(uuid.New(), tc.name, "", tc.permissions, "", "owner")
+			assert.ErrorIs(t, err, tc.err, tc.name)
+		}
+	})
+}
+
+func TestCustomRole_SetPermissions(t *testing.T) {
+	role, err := NewCustomRole(uuid.New(), "Sales", "", []Permission{PermissionViewContacts}, "", "owner")
+	require.NoError(t, err)
+	role.ClearEvents()
+	key := role.Key()
+
+	require.NoError(t, role.Rename("Inside Sales"))
+	assert.Equal(t, key, role.Key(), "the key stays with the members that use it")
+
+	require.NoError(t, role.SetPermissions([]Permission{PermissionSendMessages, PermissionViewSessions}, "owner"))
+	assert.Equal(t, []Permission{PermissionSendMessages, PermissionViewSessions}, role.Permissions())
+	require.Len(t, role.DomainEvents(), 1)
+	_, ok := role.DomainEvents()[0].(*CustomRolePermissionsChangedEvent)
+	assert.True(t, ok)
+
+	assert.ErrorIs(t, role.SetPermissions(nil, "owner"), ErrCustomRoleNoPermissions)
+	assert.Equal(t, []Permission{PermissionSendMessages, PermissionViewSessions}, role.Permissions())
+
+	// Permissions devolve uma cópia
+	role.Permissions()[0] = PermissionManageBilling
+	assert.False(t, role.HasPermission(PermissionManageBilling))
+}
+
+func TestIsCustomRole(t *testing.T) {
+	assert.True(t, IsCustomRole("custom:sales"))
+	assert.True(t, IsCustomRole("custom:sales-agent-2"))
+	assert.False(t, IsCustomRole("custom:"))
+	assert.False(t, IsCustomRole("custom:Sales"))
+	assert.False(t, IsCustomRole("agent"))
+	assert.True(t, IsBuiltInRole(RoleViewer))
+	assert.False(t, IsBuiltInRole("custom:viewer"))
+
+	// Membros aceitam papéis custom bem formados
+	_, err := NewProjectMember(uuid.New(), uuid.NewString(), "custom:sales", "owner")
+	assert.NoError(t, err)
+	_, err = NewProjectMember(uuid.New(), uuid.NewString(), "custom:Sales!", "owner")
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
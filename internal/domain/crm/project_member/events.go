@@ -89,3 +89,140 @@ func NewProjectMemberRemovedEvent(
 		RemovedAt:       removedAt,
 	}
 }
+
+// InvitationCreatedEvent é disparado quando um convite é enviado
+type InvitationCreatedEvent struct {
+	shared.BaseEvent
+	InvitationID uuid.UUID `json:"invitation_id"`
+	ProjectID    uuid.UUID `json:"project_id"`
+	Email        string    `json:"email"`
+	Role         string    `json:"role"`
+	InvitedBy    string    `json:"invited_by"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func NewInvitationCreatedEvent(
+	invitationID, projectID uuid.UUID,
+	email, role, invitedBy string,
+	expiresAt time.Time,
+) *InvitationCreatedEvent {
+	return &InvitationCreatedEvent{
+		BaseEvent:    shared.NewBaseEvent("project_invitation.created", time.Now()),
+		InvitationID: invitationID,
+		ProjectID:    projectID,
+		Email:        email,
+		Role:         role,
+		InvitedBy:    invitedBy,
+		ExpiresAt:    expiresAt,
+	}
+}
+
+// InvitationAcceptedEvent é disparado quando o convidado aceita (o membro é criado junto)
+type InvitationAcceptedEvent struct {
+	shared.BaseEvent
+	InvitationID uuid.UUID `json:"invitation_id"`
+	ProjectID    uuid.UUID `json:"project_id"`
+	Email        string    `json:"email"`
+	AgentID      string    `json:"agent_id"`
+	Role         string    `json:"role"`
+	AcceptedAt   time.Time `json:"accepted_at"`
+}
+
+func NewInvitationAcceptedEvent(
+	invitationID, projectID uuid.UUID,
+	email, agentID, role string,
+	acceptedAt time.Time,
+) *InvitationAcceptedEvent {
+	return &InvitationAcceptedEvent{
+		BaseEvent:    shared.NewBaseEvent("project_invitation.accepted", acceptedAt),
+		InvitationID: invitationID,
+		ProjectID:    projectID,
+		Email:        email,
+		AgentID:      agentID,
+		Role:         role,
+		AcceptedAt:   acceptedAt,
+	}
+}
+
+// InvitationDeclinedEvent é disparado quando o convidado recusa
+type InvitationDeclinedEvent struct {
+	shared.BaseEvent
+	InvitationID uuid.UUID `json:"invitation_id"`
+	ProjectID    uuid.UUID `json:"project_id"`
+	Email        string    `json:"email"`
+	DeclinedAt   time.Time `json:"declined_at"`
+}
+
+func NewInvitationDeclinedEvent(invitationID, projectID uuid.UUID, email string, declinedAt time.Time) *InvitationDeclinedEvent {
+	return &InvitationDeclinedEvent{
+		BaseEvent:    shared.NewBaseEvent("project_invitation.declined", declinedAt),
+		InvitationID: invitationID,
+		ProjectID:    projectID,
+		Email:        email,
+		DeclinedAt:   declinedAt,
+	}
+}
+
+// CustomRoleCreatedEvent é disparado quando o projeto cria um papel próprio
+type CustomRoleCreatedEvent struct {
+	shared.BaseEvent
+	RoleID      uuid.UUID    `json:"role_id"`
+	ProjectID   uuid.UUID    `json:"project_id"`
+	Key         string       `json:"key"`
+	Permissions []Permission `json:"permissions"`
+	CreatedBy   string       `json:"created_by"`
+}
+
+func NewCustomRoleCreatedEvent(roleID, projectID uuid.UUID, key string, permissions []Permission, createdBy string) *CustomRoleCreatedEvent {
+	return &CustomRoleCreatedEvent{
+		BaseEvent:   shared.NewBaseEvent("project_role.created", time.Now()),
+		RoleID:      roleID,
+		ProjectID:   projectID,
+		Key:         key,
+		Permissions: permissions,
+		CreatedBy:   createdBy,
+	}
+}
+
+// CustomRolePermissionsChangedEvent é disparado quando as permissões do papel mudam (vale na hora
+// para todos os membros com o papel)
+type CustomRolePermissionsChangedEvent struct {
+	shared.BaseEvent
+	RoleID              uuid.UUID    `json:"role_id"`
+	ProjectID           uuid.UUID    `json:"project_id"`
+	Key                 string       `json:"key"`
+	PreviousPermissions []Permission `json:"previous_permissions"`
+	Permissions         []Permission `json:"permissions"`
+	ChangedBy           string       `json:"changed_by"`
+}
+
+func NewCustomRolePermissionsChangedEvent(roleID, projectID uuid.UUID, key string, previous, permissions []Permission, changedBy string) *CustomRolePermissionsChangedEvent {
+	return &CustomRolePermissionsChangedEvent{
+		BaseEvent:           shared.NewBaseEvent("project_role.permissions_changed", time.Now()),
+		RoleID:              roleID,
+		ProjectID:           projectID,
+		Key:                 key,
+		PreviousPermissions: previous,
+		Permissions:         permissions,
+		ChangedBy:           changedBy,
+	}
+}
+
+// CustomRoleDeletedEvent é disparado quando o papel é removido
+type CustomRoleDeletedEvent struct {
+	shared.BaseEvent
+	RoleID    uuid.UUID `json:"role_id"`
+	ProjectID uuid.UUID `json:"project_id"`
+	Key       string    `json:"key"`
+	DeletedBy string    `json:"deleted_by"`
+}
+
+func NewCustomRoleDeletedEvent(roleID, projectID uuid.UUID, key, deletedBy string) *CustomRoleDeletedEvent {
+	return &CustomRoleDeletedEvent{
+		BaseEvent: shared.NewBaseEvent("project_role.deleted", time.Now()),
+		RoleID:    roleID,
+		ProjectID: projectID,
+		Key:       key,
+		DeletedBy: deletedBy,
+	}
+}
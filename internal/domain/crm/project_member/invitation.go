@@ -0,0 +1,244 @@
+package project_member
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// InvitationStatus estado do convite
+type InvitationStatus string
+
+const (
+	InvitationPending  InvitationStatus = "pending"
+	InvitationAccepted InvitationStatus = "accepted"
+	InvitationDeclined InvitationStatus = "declined"
+	InvitationRevoked  InvitationStatus = "revoked"
+)
+
+const (
+	// InvitationTokenPrefix identifica os tokens de convite (enviados por email)
+	InvitationTokenPrefix = "vti_"
+
+	// DefaultInvitationTTL validade do convite quando a configuração não define outra
+	DefaultInvitationTTL = 7 * 24 * time.Hour
+)
+
+var (
+	ErrInvalidInvitationEmail = errors.New("invalid invitation email")
+	ErrInvitationNotFound     = errors.New("invitation not found")
+	ErrInvitationNotPending   = errors.New("invitation is no longer pending")
+	ErrInvitationExpired      = errors.New("invitation expired")
+	ErrInvitationEmailMatch   = errors.New("invitation was sent to a different email")
+	ErrInvitationPending      = errors.New("there is already a pending invitation for this email")
+)
+
+// Invitation convite para entrar em um projeto. Só o hash do token é guardado: o token em claro
+// vai no email e não pode ser recuperado.
+type Invitation struct {
+	id          uuid.UUID
+	projectID   uuid.UUID
+	email       string
+	role        ProjectMemberRole
+	tokenHash   string
+	invitedBy   string
+	status      InvitationStatus
+	expiresAt   time.Time
+	acceptedBy  string
+	respondedAt *time.Time
+	createdAt   time.Time
+	updatedAt   time.Time
+
+	events []shared.DomainEvent
+}
+
+// NewInvitation cria o convite e devolve o token em claro, que só existe no email enviado
+func NewInvitation(projectID uuid.UUID, email string, role ProjectMemberRole, invitedBy string, ttl time.Duration) (*Invitation, string, error) {
+	if projectID == uuid.Nil {
+		return nil, "", ErrProjectIDRequired
+	}
+	email, err := NormalizeInvitationEmail(email)
+	if err != nil {
+		return nil, "", err
+	}
+	if !isValidRole(role) {
+		return nil, "", ErrInvalidRole
+	}
+	if invitedBy == "" {
+		return nil, "", ErrInvitedByRequired
+	}
+	if ttl <= 0 {
+		ttl = DefaultInvitationTTL
+	}
+
+	token, err := GenerateInvitationToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now().UTC()
+	inv := &Invitation{
+		id:        uuid.New(),
+		projectID: projectID,
+		email:     email,
+		role:      role,
+		tokenHash: HashInvitationToken(token),
+		invitedBy: invitedBy,
+		status:    InvitationPending,
+		expiresAt: now.Add(ttl),
+		createdAt: now,
+		updatedAt: now,
+		events:    []shared.DomainEvent{},
+	}
+	inv.events = append(inv.events, NewInvitationCreatedEvent(inv.id, projectID, email, string(role), invitedBy, inv.expiresAt))
+	return inv, token, nil
+}
+
+// ReconstructInvitation reconstrói o convite a partir do banco
+func ReconstructInvitation(
+	id, projectID uuid.UUID,
+	email string,
+	role ProjectMemberRole,
+	tokenHash, invitedBy string,
+	status InvitationStatus,
+	expiresAt time.Time,
+	acceptedBy string,
+	respondedAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *Invitation {
+	return &Invitation{
+		id:          id,
+		projectID:   projectID,
+		email:       email,
+		role:        role,
+		tokenHash:   tokenHash,
+		invitedBy:   invitedBy,
+		status:      status,
+		expiresAt:   expiresAt,
+		acceptedBy:  acceptedBy,
+		respondedAt: respondedAt,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		events:      []shared.DomainEvent{},
+	}
+}
+
+// Accept aceita o convite em nome do usuário logado, que precisa ser o dono do email convidado
+func (i *Invitation) Accept(userID, email string, now time.Time) error {
+	if err := i.ensureRespondable(now); err != nil {
+		return err
+	}
+	if !strings.EqualFold(strings.TrimSpace(email), i.email) {
+		return ErrInvitationEmailMatch
+	}
+	if userID == "" {
+		return ErrAgentIDRequired
+	}
+	i.status = InvitationAccepted
+	i.acceptedBy = userID
+	i.respond(now)
+	i.events = append(i.events, NewInvitationAcceptedEvent(i.id, i.projectID, i.email, userID, string(i.role), now))
+	return nil
+}
+
+// Decline recusa o convite (basta ter o token)
+func (i *Invitation) Decline(now time.Time) error {
+	if err := i.ensureRespondable(now); err != nil {
+		return err
+	}
+	i.status = InvitationDeclined
+	i.respond(now)
+	i.events = append(i.events, NewInvitationDeclinedEvent(i.id, i.projectID, i.email, now))
+	return nil
+}
+
+// Revoke cancela um convite pendente (mesmo expirado)
+func (i *Invitation) Revoke(now time.Time) error {
+	if i.status != InvitationPending {
+		return ErrInvitationNotPending
+	}
+	i.status = InvitationRevoked
+	i.respond(now)
+	return nil
+}
+
+// IsExpired indica se o prazo do convite passou
+func (i *Invitation) IsExpired(now time.Time) bool {
+	return !now.Before(i.expiresAt)
+}
+
+// IsOpen indica se o convite ainda pode ser aceito ou recusado
+func (i *Invitation) IsOpen(now time.Time) bool {
+	return i.status == InvitationPending && !i.IsExpired(now)
+}
+
+func (i *Invitation) ensureRespondable(now time.Time) error {
+	if i.status != InvitationPending {
+		return ErrInvitationNotPending
+	}
+	if i.IsExpired(now) {
+		return ErrInvitationExpired
+	}
+	return nil
+}
+
+func (i *Invitation) respond(now time.Time) {
+	at := now.UTC()
+	i.respondedAt = &at
+	i.updatedAt = at
+}
+
+// GenerateInvitationToken token aleatório de 256 bits com o prefixo vti_
+func GenerateInvitationToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return InvitationTokenPrefix + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// HashInvitationToken hash SHA-256 (hex) usado para localizar o convite pelo token
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// NormalizeInvitationEmail valida o email e o devolve em minúsculas
+func NormalizeInvitationEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", ErrInvalidInvitationEmail
+	}
+	return email, nil
+}
+
+// Getters
+func (i *Invitation) ID() uuid.UUID            { return i.id }
+func (i *Invitation) ProjectID() uuid.UUID     { return i.projectID }
+func (i *Invitation) Email() string            { return i.email }
+func (i *Invitation) Role() ProjectMemberRole  { return i.role }
+func (i *Invitation) TokenHash() string        { return i.tokenHash }
+func (i *Invitation) InvitedBy() string        { return i.invitedBy }
+func (i *Invitation) Status() InvitationStatus { return i.status }
+func (i *Invitation) ExpiresAt() time.Time     { return i.expiresAt }
+func (i *Invitation) AcceptedBy() string       { return i.acceptedBy }
+func (i *Invitation) RespondedAt() *time.Time  { return i.respondedAt }
+func (i *Invitation) CreatedAt() time.Time     { return i.createdAt }
+func (i *Invitation) UpdatedAt() time.Time     { return i.updatedAt }
+func (i *Invitation) DomainEvents() []shared.DomainEvent {
+	return append([]shared.DomainEvent{}, i.events...)
+}
+
+// ClearEvents limpa os eventos de domínio
+func (i *Invitation) ClearEvents() {
+	i.events = []shared.DomainEvent{}
+}
@@ -0,0 +1,113 @@
+package project_member
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInvitation(t *testing.T) (*Invitation, string) {
+	t.Helper()
+	inv, token, err := NewInvitation(uuid.New(), "  Maria@Example.com ", RoleAgent, uuid.NewString(), time.Hour)
+	require.NoError(t, err)
+	return inv, token
+}
+
+func TestNewInvitation(t *testing.T) {
+	t.Run("valid invitation", func(t *testing.T) {
+		inv, token := newTestInvitation(t)
+
+		assert.True(t, strings.HasPrefix(token, InvitationTokenPrefix))
+		assert.Equal(t, HashInvitationToken(token), inv.TokenHash())
+		assert.NotContains(t, inv.TokenHash(), token)
+		assert.Equal(t, "maria@example.com", inv.Email())
+		assert.Equal(t, InvitationPending, inv.Status())
+		assert.True(t, inv.IsOpen(time.Now()))
+
+		require.Len(t, inv.DomainEvents(), 1)
+		_, ok := inv.DomainEvents()[0].(*InvitationCreatedEvent)
+		assert.True(t, ok)
+	})
+
+	t.Run("default ttl", func(t *testing.T) {
+		inv, _, err := NewInvitation(uuid.New(), "a@b.com", RoleViewer, "inviter", 0)
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(DefaultInvitationTTL), inv.ExpiresAt(), time.Minute)
+	})
+
+	t.Run("validation errors", func(t *testing.T) {
+		_, _, err := NewInvitation(uuid.Nil, "a@b.com", RoleAgent, "inviter", time.Hour)
+		assert.ErrorIs(t, err, ErrProjectIDRequired)
+
+		_, _, err = NewInvitation(uuid.New(), "not-an-email", RoleAgent, "inviter", time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidInvitationEmail)
+
+		_, _, err = NewInvitation(uuid.New(), "Maria <a@b.com>", RoleAgent, "inviter", time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidInvitationEmail)
+
+		_, _, err = NewInvitation(uuid.New(), "a@b.com", ProjectMemberRole("owner"), "inviter", time.Hour)
+		assert.ErrorIs(t, err, ErrInvalidRole)
+
+		_, _, err = NewInvitation(uuid.New(), "a@b.com", RoleAgent, "", time.Hour)
+		assert.ErrorIs(t, err, ErrInvitedByRequired)
+	})
+}
+
+func TestInvitation_Accept(t *testing.T) {
+	t.Run("accepts with the invited email", func(t *testing.T) {
+		inv, _ := newTestInvitation(t)
+		inv.ClearEvents()
+		userID := uuid.NewString()
+
+		require.NoError(t, inv.Accept(userID, "MARIA@example.com", time.Now()))
+		assert.Equal(t, InvitationAccepted, inv.Status())
+		assert.Equal(t, userID, inv.AcceptedBy())
+		assert.NotNil(t, inv.RespondedAt())
+
+		require.Len(t, inv.DomainEvents(), 1)
+		_, ok := inv.DomainEvents()[0].(*InvitationAcceptedEvent)
+		assert.True(t, ok)
+
+		assert.ErrorIs(t, inv.Accept(userID, "maria@example.com", time.Now()), ErrInvitationNotPending)
+	})
+
+	t.Run("rejects another email", func(t *testing.T) {
+		inv, _ := newTestInvitation(t)
+		assert.ErrorIs(t, inv.Accept(uuid.NewString(), "joao@example.com", time.Now()), ErrInvitationEmailMatch)
+		assert.Equal(t, InvitationPending, inv.Status())
+	})
+
+	t.Run("rejects expired invitation", func(t *testing.T) {
+		inv, _ := newTestInvitation(t)
+		later := time.Now().Add(2 * time.Hour)
+		assert.False(t, inv.IsOpen(later))
+		assert.ErrorIs(t, inv.Accept(uuid.NewString(), "maria@example.com", later), ErrInvitationExpired)
+	})
+}
+
+func TestInvitation_DeclineAndRevoke(t *testing.T) {
+	inv, _ := newTestInvitation(t)
+	require.NoError(t, inv.Decline(time.Now()))
+	assert.Equal(t, InvitationDeclined, inv.Status())
+	assert.ErrorIs(t, inv.Revoke(time.Now()), ErrInvitationNotPending)
+
+	// Convite expirado ainda pode ser revogado (para dar lugar a um novo)
+	expired, _ := newTestInvitation(t)
+	later := time.Now().Add(2 * time.Hour)
+	assert.ErrorIs(t, expired.Decline(later), ErrInvitationExpired)
+	require.NoError(t, expired.Revoke(later))
+	assert.Equal(t, InvitationRevoked, expired.Status())
+}
+
+func TestNormalizeInvitationEmail(t *testing.T) {
+	email, err := NormalizeInvitationEmail(" Ana@Example.COM ")
+	require.NoError(t, err)
+	assert.Equal(t, "ana@example.com", email)
+
+	_, err = NormalizeInvitationEmail("")
+	assert.ErrorIs(t, err, ErrInvalidInvitationEmail)
+}
@@ -44,6 +44,9 @@ const (
 	// Settings
 	PermissionViewSettings   Permission = "settings.view"
 	PermissionManageSettings Permission = "settings.manage"
+
+	// Audit
+	PermissionViewAuditLog Permission = "audit.view"
 )
 
 // rolePermissions mapeia cada role para suas permissões
@@ -71,6 +74,7 @@ var rolePermissions = map[ProjectMemberRole][]Permission{
 		PermissionManageChannels,
 		PermissionViewSettings,
 		PermissionManageSettings,
+		PermissionViewAuditLog,
 	},
 
 	// Supervisor - Gerenciamento operacional, analytics, campaigns
@@ -175,9 +179,20 @@ func AllPermissions() []Permission {
 		PermissionManageBilling,
 		PermissionViewSettings,
 		PermissionManageSettings,
+		PermissionViewAuditLog,
 	}
 }
 
+// IsValid verifica se a permissão existe no sistema
+func (p Permission) IsValid() bool {
+	for _, known := range AllPermissions() {
+		if p == known {
+			return true
+		}
+	}
+	return false
+}
+
 // PermissionDescription retorna descrição legível de uma permissão
 func PermissionDescription(p Permission) string {
 	descriptions := map[Permission]string{
@@ -204,6 +219,7 @@ func PermissionDescription(p Permission) string {
 		PermissionManageBilling:   "Manage billing and subscriptions",
 		PermissionViewSettings:    "View project settings",
 		PermissionManageSettings:  "Modify project settings",
+		PermissionViewAuditLog:    "View and export the audit log",
 	}
 
 	if desc, exists := descriptions[p]; exists {
@@ -182,7 +182,8 @@ func (pm *ProjectMember) Remove(removedBy string, isLastAdmin bool) error {
 	return nil
 }
 
-// HasPermission verifica se o membro tem permissão para uma ação
+// HasPermission verifica se o membro tem permissão para uma ação. Só conhece os papéis padrão:
+// papéis custom são resolvidos pela aplicação (projectapp.AccessResolver).
 func (pm *ProjectMember) HasPermission(permission Permission) bool {
 	return RoleHasPermission(pm.role, permission)
 }
@@ -235,16 +236,19 @@ func (pm *ProjectMember) AddEvent(event shared.DomainEvent) {
 }
 
 // Helper functions
+
+// isValidRole aceita os papéis padrão e chaves custom:<slug>; a existência do papel custom no
+// projeto é conferida pela aplicação
 func isValidRole(role ProjectMemberRole) bool {
 	switch role {
 	case RoleAdmin, RoleSupervisor, RoleAgent, RoleViewer:
 		return true
 	default:
-		return false
+		return IsCustomRole(role)
 	}
 }
 
-// ValidRoles retorna a lista de roles válidos
+// ValidRoles retorna a lista de roles padrão
 func ValidRoles() []ProjectMemberRole {
 	return []ProjectMemberRole{
 		RoleAdmin,
@@ -35,3 +35,39 @@ type Repository interface {
 	// Delete remove um ProjectMember
 	Delete(ctx context.Context, id uuid.UUID) error
 }
+
+// InvitationRepository persistência dos convites
+type InvitationRepository interface {
+	// Save persiste um convite (create ou update)
+	Save(ctx context.Context, invitation *Invitation) error
+
+	// FindByID busca um convite do projeto
+	FindByID(ctx context.Context, projectID, id uuid.UUID) (*Invitation, error)
+
+	// FindByTokenHash busca o convite pelo hash do token enviado por email
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Invitation, error)
+
+	// FindPendingByEmail busca o convite pendente do email no projeto
+	FindPendingByEmail(ctx context.Context, projectID uuid.UUID, email string) (*Invitation, error)
+
+	// FindByProject lista os convites do projeto (status vazio = todos), mais recentes primeiro
+	FindByProject(ctx context.Context, projectID uuid.UUID, status InvitationStatus) ([]*Invitation, error)
+}
+
+// CustomRoleRepository persistência dos papéis definidos pelos projetos
+type CustomRoleRepository interface {
+	// Save persiste um papel (create ou update)
+	Save(ctx context.Context, role *CustomRole) error
+
+	// FindByID busca um papel do projeto
+	FindByID(ctx context.Context, projectID, id uuid.UUID) (*CustomRole, error)
+
+	// FindByKey busca o papel pela chave custom:<slug>
+	FindByKey(ctx context.Context, projectID uuid.UUID, key ProjectMemberRole) (*CustomRole, error)
+
+	// FindByProject lista os papéis do projeto por nome
+	FindByProject(ctx context.Context, projectID uuid.UUID) ([]*CustomRole, error)
+
+	// Delete remove o papel
+	Delete(ctx context.Context, projectID, id uuid.UUID) error
+}
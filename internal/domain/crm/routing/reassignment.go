@@ -0,0 +1,58 @@
+package routing
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+)
+
+// ActiveAssignment sessão ativa com agente, avaliada pelas regras de reatribuição
+type ActiveAssignment struct {
+	SessionID      uuid.UUID
+	TenantID       string
+	ContactID      uuid.UUID
+	AgentID        uuid.UUID // agente atual (último de agent_ids)
+	LastActivityAt time.Time
+	WaitingSince   *time.Time // mensagem mais antiga do contato ainda sem resposta
+	Transfers      int
+}
+
+// MatchReassignmentRule retorna a primeira regra ativa que manda reatribuir a sessão, ou nil.
+//
+//   - inactivity: nenhuma atividade na sessão desde LastActivityAt pelo tempo da regra
+//   - no_response: contato aguardando resposta desde WaitingSince pelo tempo da regra
+//   - workload_balance: agente atual indisponível (offline, ausente, inativo ou removido
+//     do projeto) ou acima da capacidade
+//
+// Regras manuais nunca disparam automaticamente. current é nil quando o agente atual
+// não está mais entre os candidatos do projeto.
+func MatchReassignmentRule(config *project.AgentAssignmentConfig, assignment ActiveAssignment, current *Candidate, now time.Time) *project.ReassignmentRule {
+	for _, rule := range config.GetActiveReassignmentRules() {
+		switch rule.Trigger {
+		case project.TriggerInactivity:
+			if rule.ShouldReassignAt(assignment.LastActivityAt, assignment.Transfers, now) {
+				return rule
+			}
+		case project.TriggerNoResponse:
+			if assignment.WaitingSince != nil && rule.ShouldReassignAt(*assignment.WaitingSince, assignment.Transfers, now) {
+				return rule
+			}
+		case project.TriggerWorkloadBalance:
+			if rule.HasReassignmentsLeft(assignment.Transfers) && !canKeepSession(config, current, now) {
+				return rule
+			}
+		}
+	}
+	return nil
+}
+
+// canKeepSession indica se o agente atual pode continuar com suas sessões
+// (presente, dentro do horário de trabalho e sem exceder a capacidade)
+func canKeepSession(config *project.AgentAssignmentConfig, current *Candidate, now time.Time) bool {
+	if current == nil || current.Agent == nil || !current.Agent.IsAvailableForRoutingAt(now) {
+		return false
+	}
+	limit := capacity(config, current.Agent.RoutingProfile())
+	return limit == 0 || current.Workload.ActiveSessions <= limit
+}
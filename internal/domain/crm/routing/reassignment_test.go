@@ -0,0 +1,64 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+func TestMatchReassignmentRule(t *testing.T) {
+	now := time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+	current := newAvailableAgent(t, agent.RoutingProfile{MaxConcurrentSessions: 3})
+	config := newConfig(project.StrategyLeastSessions, current)
+
+	inactivity := project.NewReassignmentRule(project.TriggerInactivity, 30)
+	noResponse := project.NewReassignmentRule(project.TriggerNoResponse, 10)
+	workload := project.NewReassignmentRule(project.TriggerWorkloadBalance, 0)
+	config.ReassignmentRules = []*project.ReassignmentRule{inactivity, noResponse, workload}
+
+	candidate := &Candidate{Agent: current, Workload: Workload{ActiveSessions: 2}}
+	waiting := now.Add(-15 * time.Minute)
+
+	t.Run("no rule matches a healthy session", func(t *testing.T) {
+		assert.Nil(t, MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now.Add(-time.Minute)}, candidate, now))
+	})
+
+	t.Run("inactivity", func(t *testing.T) {
+		rule := MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now.Add(-45 * time.Minute)}, candidate, now)
+		assert.Same(t, inactivity, rule)
+		assert.Equal(t, "inactivity_30min", rule.Name())
+	})
+
+	t.Run("no response uses the oldest unanswered message", func(t *testing.T) {
+		rule := MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now.Add(-time.Minute), WaitingSince: &waiting}, candidate, now)
+		assert.Same(t, noResponse, rule)
+	})
+
+	t.Run("max reassignments", func(t *testing.T) {
+		assignment := ActiveAssignment{LastActivityAt: now.Add(-45 * time.Minute), WaitingSince: &waiting, Transfers: 3}
+		assert.Nil(t, MatchReassignmentRule(config, assignment, candidate, now))
+	})
+
+	t.Run("workload balance when agent goes offline", func(t *testing.T) {
+		current.SetStatus(agent.AgentStatusOffline)
+		defer current.SetStatus(agent.AgentStatusAvailable)
+
+		rule := MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now}, candidate, now)
+		assert.Same(t, workload, rule)
+	})
+
+	t.Run("workload balance when agent is over capacity or removed", func(t *testing.T) {
+		over := &Candidate{Agent: current, Workload: Workload{ActiveSessions: 4}}
+		assert.Same(t, workload, MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now}, over, now))
+		assert.Same(t, workload, MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now}, nil, now))
+	})
+
+	t.Run("disabled rules are ignored", func(t *testing.T) {
+		inactivity.Enabled = false
+		defer func() { inactivity.Enabled = true }()
+		assert.Nil(t, MatchReassignmentRule(config, ActiveAssignment{LastActivityAt: now.Add(-45 * time.Minute)}, candidate, now))
+	})
+}
@@ -0,0 +1,45 @@
+package routing
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QueueEntry sessão aguardando um agente elegível
+type QueueEntry struct {
+	SessionID     uuid.UUID
+	TenantID      string
+	ProjectID     uuid.UUID
+	ContactID     uuid.UUID
+	Requirements  Requirements
+	EnqueuedAt    time.Time
+	Attempts      int
+	LastAttemptAt time.Time
+}
+
+// Repository persiste o estado do roteamento: cursor de round-robin, carga dos agentes e fila de espera
+type Repository interface {
+	// LockProject serializa decisões de roteamento do projeto até o fim da transação corrente
+	LockProject(ctx context.Context, projectID uuid.UUID) error
+
+	// GetWorkloads retorna a carga dos agentes informados (agentes sem sessão ativa vêm zerados)
+	GetWorkloads(ctx context.Context, tenantID string, agentIDs []uuid.UUID) (map[uuid.UUID]Workload, error)
+
+	GetRoundRobinCursor(ctx context.Context, projectID uuid.UUID) (*uuid.UUID, error)
+	SaveRoundRobinCursor(ctx context.Context, projectID, agentID uuid.UUID) error
+
+	// Enqueue insere a sessão na fila ou registra mais uma tentativa se ela já estiver lá
+	Enqueue(ctx context.Context, entry QueueEntry) error
+	Dequeue(ctx context.Context, sessionID uuid.UUID) error
+
+	// FindQueued retorna as sessões em espera, mais antigas primeiro
+	FindQueued(ctx context.Context, limit int) ([]QueueEntry, error)
+
+	// FindProjectsWithReassignmentRules retorna os projetos com atribuição automática e regras de reatribuição ativas
+	FindProjectsWithReassignmentRules(ctx context.Context) ([]uuid.UUID, error)
+
+	// FindActiveAssignments retorna sessões ativas com agente do projeto, menos recentes primeiro
+	FindActiveAssignments(ctx context.Context, projectID uuid.UUID, limit int) ([]ActiveAssignment, error)
+}
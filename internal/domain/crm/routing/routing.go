@@ -0,0 +1,163 @@
+package routing
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+var (
+	// ErrNoEligibleAgent nenhum agente do projeto pode receber a sessão agora (sessão vai para a fila)
+	ErrNoEligibleAgent = errors.New("no eligible agent available")
+
+	// ErrManualStrategy o projeto não usa atribuição automática
+	ErrManualStrategy = errors.New("manual assignment strategy - no auto-assignment")
+)
+
+// Workload carga atual de um agente, calculada a partir das sessões ativas
+type Workload struct {
+	ActiveSessions int
+	LastAssignedAt *time.Time
+}
+
+// Candidate agente configurado no projeto com sua carga atual
+type Candidate struct {
+	Agent    *agent.Agent
+	Workload Workload
+}
+
+// Requirements exigências do contato para o agente que vai atendê-lo
+type Requirements struct {
+	Language string   // idioma do contato; vazio = qualquer
+	Skills   []string // skills derivadas das tags do contato
+}
+
+// RequirementsFor deriva as exigências de roteamento a partir do contato e da configuração do projeto
+func RequirementsFor(config *project.AgentAssignmentConfig, contactLanguage string, contactTags []string) Requirements {
+	req := Requirements{Skills: config.RequiredSkills(contactTags)}
+	if config.MatchContactLanguage {
+		req.Language = contactLanguage
+	}
+	return req
+}
+
+// SelectAgent escolhe o agente que deve receber a sessão segundo a estratégia do projeto.
+//
+// Round-robin percorre AgentIDs a partir do agente seguinte a lastAssigned (cursor persistido),
+// pulando quem não é elegível. Least-sessions escolhe o agente elegível com menos sessões ativas;
+// empates vão para quem recebeu sessão há mais tempo e, depois, para a ordem da configuração.
+func SelectAgent(config *project.AgentAssignmentConfig, candidates []Candidate, req Requirements, lastAssigned *uuid.UUID) (uuid.UUID, error) {
+	if !config.ShouldAutoAssign() {
+		return uuid.Nil, ErrManualStrategy
+	}
+
+	byID := make(map[uuid.UUID]Candidate, len(candidates))
+	for _, c := range candidates {
+		if c.Agent != nil {
+			byID[c.Agent.ID()] = c
+		}
+	}
+
+	// Elegíveis na ordem da configuração
+	eligible := make([]Candidate, 0, len(config.AgentIDs))
+	for _, id := range config.AgentIDs {
+		if c, ok := byID[id]; ok && isEligible(config, c, req) {
+			eligible = append(eligible, c)
+		}
+	}
+	if len(eligible) == 0 {
+		return uuid.Nil, ErrNoEligibleAgent
+	}
+
+	switch config.Strategy {
+	case project.StrategyRoundRobin:
+		return nextRoundRobin(config.AgentIDs, eligible, lastAssigned), nil
+	case project.StrategyLeastSessions:
+		return leastSessions(eligible), nil
+	default:
+		return uuid.Nil, ErrManualStrategy
+	}
+}
+
+func isEligible(config *project.AgentAssignmentConfig, c Candidate, req Requirements) bool {
+	a := c.Agent
+	if !a.IsAvailableForRouting() {
+		return false
+	}
+	if config.OnlyHumanAgents && a.Type() != agent.AgentTypeHuman {
+		return false
+	}
+	if config.ExcludeVirtualAgents && a.Type() == agent.AgentTypeVirtual {
+		return false
+	}
+
+	profile := a.RoutingProfile()
+	if limit := capacity(config, profile); limit > 0 && c.Workload.ActiveSessions >= limit {
+		return false
+	}
+	if !profile.SpeaksLanguage(req.Language) {
+		return false
+	}
+	return profile.HasSkills(req.Skills)
+}
+
+// capacity limite de sessões simultâneas do agente (0 = ilimitado)
+func capacity(config *project.AgentAssignmentConfig, profile agent.RoutingProfile) int {
+	if profile.MaxConcurrentSessions > 0 {
+		return profile.MaxConcurrentSessions
+	}
+	return config.DefaultMaxConcurrentSessions
+}
+
+func nextRoundRobin(order []uuid.UUID, eligible []Candidate, lastAssigned *uuid.UUID) uuid.UUID {
+	start := 0
+	if lastAssigned != nil {
+		for i, id := range order {
+			if id == *lastAssigned {
+				start = i + 1
+				break
+			}
+		}
+	}
+
+	eligibleSet := make(map[uuid.UUID]bool, len(eligible))
+	for _, c := range eligible {
+		eligibleSet[c.Agent.ID()] = true
+	}
+
+	for i := 0; i < len(order); i++ {
+		id := order[(start+i)%len(order)]
+		if eligibleSet[id] {
+			return id
+		}
+	}
+	return eligible[0].Agent.ID()
+}
+
+func leastSessions(eligible []Candidate) uuid.UUID {
+	best := eligible[0]
+	for _, c := range eligible[1:] {
+		if c.Workload.ActiveSessions < best.Workload.ActiveSessions {
+			best = c
+			continue
+		}
+		if c.Workload.ActiveSessions == best.Workload.ActiveSessions && assignedEarlier(c.Workload, best.Workload) {
+			best = c
+		}
+	}
+	return best.Agent.ID()
+}
+
+// assignedEarlier true se a recebeu a última sessão antes de b (quem nunca recebeu vem primeiro)
+func assignedEarlier(a, b Workload) bool {
+	if a.LastAssignedAt == nil {
+		return b.LastAssignedAt != nil
+	}
+	if b.LastAssignedAt == nil {
+		return false
+	}
+	return a.LastAssignedAt.Before(*b.LastAssignedAt)
+}
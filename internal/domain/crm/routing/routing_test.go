@@ -0,0 +1,165 @@
+package routing
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/agent"
+)
+
+func newAvailableAgent(t *testing.T, profile agent.RoutingProfile) *agent.Agent {
+	t.Helper()
+	userID := uuid.New()
+	a, err := agent.NewAgent(uuid.New(), "tenant-123", "Agent", agent.AgentTypeHuman, &userID)
+	require.NoError(t, err)
+	a.SetStatus(agent.AgentStatusAvailable)
+	require.NoError(t, a.SetRoutingProfile(profile))
+	return a
+}
+
+func newConfig(strategy project.AssignmentStrategy, agents ...*agent.Agent) *project.AgentAssignmentConfig {
+	config := project.NewAgentAssignmentConfig()
+	config.Enabled = true
+	config.Strategy = strategy
+	for _, a := range agents {
+		config.AgentIDs = append(config.AgentIDs, a.ID())
+	}
+	return config
+}
+
+func TestSelectAgent_RoundRobin(t *testing.T) {
+	a1 := newAvailableAgent(t, agent.RoutingProfile{})
+	a2 := newAvailableAgent(t, agent.RoutingProfile{})
+	a3 := newAvailableAgent(t, agent.RoutingProfile{})
+	config := newConfig(project.StrategyRoundRobin, a1, a2, a3)
+	candidates := []Candidate{{Agent: a1}, {Agent: a2}, {Agent: a3}}
+
+	got, err := SelectAgent(config, candidates, Requirements{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, a1.ID(), got)
+
+	last := a1.ID()
+	got, err = SelectAgent(config, candidates, Requirements{}, &last)
+	require.NoError(t, err)
+	assert.Equal(t, a2.ID(), got)
+
+	last = a3.ID()
+	got, err = SelectAgent(config, candidates, Requirements{}, &last)
+	require.NoError(t, err)
+	assert.Equal(t, a1.ID(), got, "wraps around")
+
+	t.Run("skips unavailable agents", func(t *testing.T) {
+		a2.SetStatus(agent.AgentStatusAway)
+		defer a2.SetStatus(agent.AgentStatusAvailable)
+
+		last := a1.ID()
+		got, err := SelectAgent(config, candidates, Requirements{}, &last)
+		require.NoError(t, err)
+		assert.Equal(t, a3.ID(), got)
+	})
+
+	t.Run("cursor pointing to removed agent restarts", func(t *testing.T) {
+		removed := uuid.New()
+		got, err := SelectAgent(config, candidates, Requirements{}, &removed)
+		require.NoError(t, err)
+		assert.Equal(t, a1.ID(), got)
+	})
+}
+
+func TestSelectAgent_LeastSessions(t *testing.T) {
+	a1 := newAvailableAgent(t, agent.RoutingProfile{})
+	a2 := newAvailableAgent(t, agent.RoutingProfile{})
+	a3 := newAvailableAgent(t, agent.RoutingProfile{})
+	config := newConfig(project.StrategyLeastSessions, a1, a2, a3)
+
+	earlier := time.Now().Add(-time.Hour)
+	later := time.Now()
+
+	got, err := SelectAgent(config, []Candidate{
+		{Agent: a1, Workload: Workload{ActiveSessions: 3}},
+		{Agent: a2, Workload: Workload{ActiveSessions: 1, LastAssignedAt: &later}},
+		{Agent: a3, Workload: Workload{ActiveSessions: 1, LastAssignedAt: &earlier}},
+	}, Requirements{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, a3.ID(), got, "tie goes to the agent idle for longer")
+
+	got, err = SelectAgent(config, []Candidate{
+		{Agent: a1, Workload: Workload{ActiveSessions: 2, LastAssignedAt: &earlier}},
+		{Agent: a2, Workload: Workload{ActiveSessions: 2}},
+		{Agent: a3, Workload: Workload{ActiveSessions: 5}},
+	}, Requirements{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, a2.ID(), got, "agent that never received a session comes first")
+}
+
+func TestSelectAgent_Capacity(t *testing.T) {
+	a1 := newAvailableAgent(t, agent.RoutingProfile{MaxConcurrentSessions: 2})
+	a2 := newAvailableAgent(t, agent.RoutingProfile{})
+	config := newConfig(project.StrategyLeastSessions, a1, a2)
+	config.DefaultMaxConcurrentSessions = 4
+
+	got, err := SelectAgent(config, []Candidate{
+		{Agent: a1, Workload: Workload{ActiveSessions: 2}},
+		{Agent: a2, Workload: Workload{ActiveSessions: 3}},
+	}, Requirements{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, a2.ID(), got, "agent at its own limit is skipped")
+
+	_, err = SelectAgent(config, []Candidate{
+		{Agent: a1, Workload: Workload{ActiveSessions: 2}},
+		{Agent: a2, Workload: Workload{ActiveSessions: 4}},
+	}, Requirements{}, nil)
+	assert.ErrorIs(t, err, ErrNoEligibleAgent, "project default applies when the profile has no limit")
+}
+
+func TestSelectAgent_SkillsAndLanguage(t *testing.T) {
+	a1 := newAvailableAgent(t, agent.RoutingProfile{Languages: []string{"pt"}})
+	a2 := newAvailableAgent(t, agent.RoutingProfile{Languages: []string{"en"}, Skills: []string{"billing"}})
+	config := newConfig(project.StrategyRoundRobin, a1, a2)
+	config.MatchContactLanguage = true
+	config.SkillTags = []string{"billing"}
+	candidates := []Candidate{{Agent: a1}, {Agent: a2}}
+
+	got, err := SelectAgent(config, candidates, RequirementsFor(config, "en-US", nil), nil)
+	require.NoError(t, err)
+	assert.Equal(t, a2.ID(), got)
+
+	got, err = SelectAgent(config, candidates, RequirementsFor(config, "", []string{"Billing"}), nil)
+	require.NoError(t, err)
+	assert.Equal(t, a2.ID(), got)
+
+	_, err = SelectAgent(config, candidates, RequirementsFor(config, "pt-BR", []string{"billing"}), nil)
+	assert.ErrorIs(t, err, ErrNoEligibleAgent)
+
+	config.MatchContactLanguage = false
+	assert.Empty(t, RequirementsFor(config, "pt-BR", nil).Language)
+}
+
+func TestSelectAgent_Filters(t *testing.T) {
+	human := newAvailableAgent(t, agent.RoutingProfile{})
+	bot, err := agent.NewAgent(uuid.New(), "tenant-123", "Bot", agent.AgentTypeBot, nil)
+	require.NoError(t, err)
+	bot.SetStatus(agent.AgentStatusAvailable)
+
+	config := newConfig(project.StrategyRoundRobin, bot, human)
+	config.OnlyHumanAgents = true
+
+	got, err := SelectAgent(config, []Candidate{{Agent: bot}, {Agent: human}}, Requirements{}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, human.ID(), got)
+
+	t.Run("agents missing from candidates are ignored", func(t *testing.T) {
+		_, err := SelectAgent(config, []Candidate{{Agent: bot}}, Requirements{}, nil)
+		assert.ErrorIs(t, err, ErrNoEligibleAgent)
+	})
+
+	t.Run("manual strategy", func(t *testing.T) {
+		manual := newConfig(project.StrategyManual, human)
+		_, err := SelectAgent(manual, []Candidate{{Agent: human}}, Requirements{}, nil)
+		assert.ErrorIs(t, err, ErrManualStrategy)
+	})
+}
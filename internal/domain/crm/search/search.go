@@ -0,0 +1,240 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+
+	// MaxQueryLength limite do texto buscado (websearch_to_tsquery)
+	MaxQueryLength = 256
+
+	// HighlightStart/HighlightEnd delimitam os trechos encontrados nos snippets do repositório.
+	// São convertidos em <mark> por HighlightHTML depois de escapar o conteúdo.
+	HighlightStart = "⟦"
+	HighlightEnd   = "⟧"
+)
+
+var (
+	ErrQueryRequired = errors.New("query is required")
+	ErrQueryTooLong  = fmt.Errorf("query must be at most %d characters", MaxQueryLength)
+	ErrInvalidType   = errors.New("type must be message, note or contact")
+	ErrInvalidRange  = errors.New("from must be before to")
+)
+
+// ResultType origem de um resultado da busca
+type ResultType string
+
+const (
+	TypeMessage ResultType = "message"
+	TypeNote    ResultType = "note"
+	TypeContact ResultType = "contact"
+)
+
+// AllTypes tipos buscados quando o filtro não restringe
+var AllTypes = []ResultType{TypeMessage, TypeNote, TypeContact}
+
+func (t ResultType) IsValid() bool {
+	return t == TypeMessage || t == TypeNote || t == TypeContact
+}
+
+// Configurações de text search do PostgreSQL usadas na indexação (ver crm_search_config na migration 000063)
+const (
+	ConfigPortuguese = "portuguese"
+	ConfigSpanish    = "spanish"
+	ConfigEnglish    = "english"
+	ConfigSimple     = "simple"
+)
+
+// Configs configurações combinadas quando a busca não fixa um idioma
+var Configs = []string{ConfigPortuguese, ConfigSpanish, ConfigEnglish, ConfigSimple}
+
+// ConfigForLanguage configuração de text search para o idioma do contato (pt-BR, es, en_US...).
+// Idiomas sem stemmer configurado usam "simple" (sem stemming nem stopwords).
+func ConfigForLanguage(language string) string {
+	lang := strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(lang, "-_"); i >= 0 {
+		lang = lang[:i]
+	}
+	switch lang {
+	case "pt", "portuguese":
+		return ConfigPortuguese
+	case "es", "spanish":
+		return ConfigSpanish
+	case "en", "english":
+		return ConfigEnglish
+	default:
+		return ConfigSimple
+	}
+}
+
+// Filter busca unificada em mensagens (texto, transcrições e OCR), notas e contatos.
+// Filtros se aplicam às origens que têm o campo: channel_id só a mensagens; agent_id a mensagens
+// (agente que enviou) e notas (autor); contatos ficam de fora quando channel_id ou agent_id são
+// informados. From/To usam o horário da mensagem e a criação da nota/contato.
+type Filter struct {
+	TenantID  string
+	Query     string
+	Types     []ResultType
+	Language  string // opcional: fixa a configuração de text search; vazio = todas (Configs)
+	ChannelID *uuid.UUID
+	AgentID   *uuid.UUID
+	ContactID *uuid.UUID
+	From      *time.Time
+	To        *time.Time
+	Cursor    *Cursor
+	Limit     int
+}
+
+// Validate normaliza e valida o filtro
+func (f *Filter) Validate() error {
+	if f.TenantID == "" {
+		return errors.New("tenant_id is required")
+	}
+	f.Query = strings.TrimSpace(f.Query)
+	if f.Query == "" {
+		return ErrQueryRequired
+	}
+	if len([]rune(f.Query)) > MaxQueryLength {
+		return ErrQueryTooLong
+	}
+	if len(f.Types) == 0 {
+		f.Types = AllTypes
+	}
+	for _, t := range f.Types {
+		if !t.IsValid() {
+			return ErrInvalidType
+		}
+	}
+	if f.From != nil && f.To != nil && !f.From.Before(*f.To) {
+		return ErrInvalidRange
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultLimit
+	}
+	if f.Limit > MaxLimit {
+		f.Limit = MaxLimit
+	}
+	return nil
+}
+
+// Includes indica se a origem entra na busca, considerando tipos e filtros
+func (f *Filter) Includes(t ResultType) bool {
+	wanted := false
+	for _, ft := range f.Types {
+		if ft == t {
+			wanted = true
+			break
+		}
+	}
+	if !wanted {
+		return false
+	}
+	switch t {
+	case TypeNote:
+		return f.ChannelID == nil
+	case TypeContact:
+		return f.ChannelID == nil && f.AgentID == nil
+	}
+	return true
+}
+
+// Cursor posição de keyset pagination ordenada por (rank DESC, occurred_at DESC, id DESC)
+type Cursor struct {
+	Rank       float32
+	OccurredAt time.Time
+	ID         uuid.UUID
+}
+
+// Encode serializa o cursor em um token opaco e seguro para URLs.
+// O rank vai em bits para voltar exatamente igual ao real do PostgreSQL.
+func (c Cursor) Encode() string {
+	raw := strconv.FormatUint(uint64(math.Float32bits(c.Rank)), 16) + ":" +
+		strconv.FormatInt(c.OccurredAt.UnixNano(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor desserializa um token gerado por Cursor.Encode
+func DecodeCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, shared.ErrCursorInvalid
+	}
+	parts := strings.SplitN(string(raw), ":", 3)
+	if len(parts) != 3 {
+		return Cursor{}, shared.ErrCursorInvalid
+	}
+	bits, err := strconv.ParseUint(parts[0], 16, 32)
+	if err != nil {
+		return Cursor{}, shared.ErrCursorInvalid
+	}
+	nanos, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return Cursor{}, shared.ErrCursorInvalid
+	}
+	id, err := uuid.Parse(parts[2])
+	if err != nil {
+		return Cursor{}, shared.ErrCursorInvalid
+	}
+	return Cursor{Rank: math.Float32frombits(uint32(bits)), OccurredAt: time.Unix(0, nanos).UTC(), ID: id}, nil
+}
+
+// Hit resultado da busca. Snippet traz os termos entre HighlightStart/HighlightEnd.
+type Hit struct {
+	Type        ResultType
+	ID          uuid.UUID
+	Rank        float32
+	MatchField  string // text, transcript, ocr, document, content, profile
+	Snippet     string
+	OccurredAt  time.Time
+	ContactID   *uuid.UUID
+	ContactName *string
+	SessionID   *uuid.UUID
+	ChannelID   *uuid.UUID
+	AgentID     *uuid.UUID
+}
+
+// Cursor posição do resultado para keyset pagination
+func (h *Hit) Cursor() Cursor {
+	return Cursor{Rank: h.Rank, OccurredAt: h.OccurredAt.UTC(), ID: h.ID}
+}
+
+// Page página de resultados, mais relevantes primeiro
+type Page struct {
+	Hits    []Hit
+	HasMore bool
+}
+
+// NextCursor cursor da próxima página (nil na última)
+func (p *Page) NextCursor() *Cursor {
+	if !p.HasMore || len(p.Hits) == 0 {
+		return nil
+	}
+	cursor := p.Hits[len(p.Hits)-1].Cursor()
+	return &cursor
+}
+
+// HighlightHTML escapa o snippet e troca os delimitadores de destaque por <mark>
+func HighlightHTML(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, HighlightStart, "<mark>")
+	return strings.ReplaceAll(escaped, HighlightEnd, "</mark>")
+}
+
+// Repository busca full-text (tsvector + GIN) com ranking ts_rank_cd
+type Repository interface {
+	Search(ctx context.Context, filter Filter) (*Page, error)
+}
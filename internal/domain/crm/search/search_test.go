@@ -0,0 +1,101 @@
+package search
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+func TestConfigForLanguage(t *testing.T) {
+	cases := map[string]string{
+		"pt-BR":   ConfigPortuguese,
+		"pt":      ConfigPortuguese,
+		"es_AR":   ConfigSpanish,
+		"EN":      ConfigEnglish,
+		"en-US":   ConfigEnglish,
+		"fr":      ConfigSimple,
+		"":        ConfigSimple,
+		" pt_PT ": ConfigPortuguese,
+	}
+	for language, expected := range cases {
+		assert.Equal(t, expected, ConfigForLanguage(language), language)
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	f := Filter{TenantID: "tenant-1", Query: "  boleto atrasado "}
+	require.NoError(t, f.Validate())
+	assert.Equal(t, "boleto atrasado", f.Query)
+	assert.Equal(t, AllTypes, f.Types)
+	assert.Equal(t, DefaultLimit, f.Limit)
+
+	f = Filter{TenantID: "tenant-1", Query: "x", Limit: 1000}
+	require.NoError(t, f.Validate())
+	assert.Equal(t, MaxLimit, f.Limit)
+
+	f = Filter{TenantID: "tenant-1", Query: "   "}
+	assert.ErrorIs(t, f.Validate(), ErrQueryRequired)
+
+	f = Filter{TenantID: "tenant-1", Query: strings.Repeat("a", MaxQueryLength+1)}
+	assert.ErrorIs(t, f.Validate(), ErrQueryTooLong)
+
+	f = Filter{TenantID: "tenant-1", Query: "x", Types: []ResultType{"session"}}
+	assert.ErrorIs(t, f.Validate(), ErrInvalidType)
+
+	now := time.Now()
+	f = Filter{TenantID: "tenant-1", Query: "x", From: &now, To: &now}
+	assert.ErrorIs(t, f.Validate(), ErrInvalidRange)
+}
+
+func TestFilter_Includes(t *testing.T) {
+	f := Filter{TenantID: "tenant-1", Query: "x"}
+	require.NoError(t, f.Validate())
+	assert.True(t, f.Includes(TypeMessage))
+	assert.True(t, f.Includes(TypeNote))
+	assert.True(t, f.Includes(TypeContact))
+
+	agentID := uuid.New()
+	f.AgentID = &agentID
+	assert.True(t, f.Includes(TypeNote))
+	assert.False(t, f.Includes(TypeContact))
+
+	channelID := uuid.New()
+	f.ChannelID = &channelID
+	assert.True(t, f.Includes(TypeMessage))
+	assert.False(t, f.Includes(TypeNote))
+
+	f = Filter{TenantID: "tenant-1", Query: "x", Types: []ResultType{TypeNote}}
+	require.NoError(t, f.Validate())
+	assert.False(t, f.Includes(TypeMessage))
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{Rank: 0.1, OccurredAt: time.Date(2025, 5, 1, 10, 0, 0, 123, time.UTC), ID: uuid.New()}
+
+	decoded, err := DecodeCursor(cursor.Encode())
+	require.NoError(t, err)
+	assert.Equal(t, cursor, decoded)
+
+	_, err = DecodeCursor("not-a-cursor")
+	assert.ErrorIs(t, err, shared.ErrCursorInvalid)
+}
+
+func TestPage_NextCursor(t *testing.T) {
+	last := Hit{ID: uuid.New(), Rank: 0.5, OccurredAt: time.Now()}
+	page := Page{Hits: []Hit{{ID: uuid.New(), Rank: 0.9}, last}, HasMore: true}
+	require.NotNil(t, page.NextCursor())
+	assert.Equal(t, last.ID, page.NextCursor().ID)
+
+	page.HasMore = false
+	assert.Nil(t, page.NextCursor())
+}
+
+func TestHighlightHTML(t *testing.T) {
+	snippet := "<b>pagar</b> o " + HighlightStart + "boleto" + HighlightEnd + " hoje"
+	assert.Equal(t, "&lt;b&gt;pagar&lt;/b&gt; o <mark>boleto</mark> hoje", HighlightHTML(snippet))
+}
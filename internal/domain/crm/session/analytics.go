@@ -0,0 +1,163 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsGroupBy define a granularidade da série temporal de analytics
+type AnalyticsGroupBy string
+
+const (
+	GroupByDay   AnalyticsGroupBy = "day"
+	GroupByWeek  AnalyticsGroupBy = "week"
+	GroupByMonth AnalyticsGroupBy = "month"
+)
+
+func (g AnalyticsGroupBy) IsValid() bool {
+	return g == GroupByDay || g == GroupByWeek || g == GroupByMonth
+}
+
+// ResponseTimeBuckets são os limites superiores (em segundos, exclusivos) do histograma de
+// tempos de resposta/espera armazenado nos rollups. O último bucket é aberto (>= 24h).
+// Percentis são estimados por interpolação linear dentro do bucket.
+var ResponseTimeBuckets = []int64{10, 30, 60, 120, 300, 600, 1800, 3600, 14400, 86400}
+
+// HistogramSize é o número de buckets (limites + bucket aberto)
+var HistogramSize = len(ResponseTimeBuckets) + 1
+
+// AnalyticsFilter filtra os agregados de sessões de um tenant
+type AnalyticsFilter struct {
+	TenantID      string
+	StartDate     time.Time // inclusivo
+	EndDate       time.Time // exclusivo
+	ChannelTypeID *int
+	AgentID       *uuid.UUID
+	GroupBy       AnalyticsGroupBy
+	Location      *time.Location // fuso do projeto (séries por dia/semana/mês e hora do dia)
+}
+
+// Validate normaliza e valida o filtro
+func (f *AnalyticsFilter) Validate() error {
+	if f.TenantID == "" {
+		return errors.New("tenant_id is required")
+	}
+	if f.StartDate.IsZero() || f.EndDate.IsZero() {
+		return errors.New("start_date and end_date are required")
+	}
+	if !f.EndDate.After(f.StartDate) {
+		return errors.New("end_date must be after start_date")
+	}
+	if f.GroupBy == "" {
+		f.GroupBy = GroupByDay
+	}
+	if !f.GroupBy.IsValid() {
+		return errors.New("group_by must be day, week or month")
+	}
+	if f.Location == nil {
+		f.Location = time.UTC
+	}
+	return nil
+}
+
+// DurationStats agrega uma métrica de tempo (em segundos)
+type DurationStats struct {
+	Count     int64
+	Sum       int64
+	Histogram []int64 // len == HistogramSize
+}
+
+// Average retorna a média em segundos (0 sem amostras)
+func (d DurationStats) Average() float64 {
+	if d.Count == 0 {
+		return 0
+	}
+	return float64(d.Sum) / float64(d.Count)
+}
+
+// Percentile estima o percentil p (0-100) em segundos a partir do histograma
+func (d DurationStats) Percentile(p float64) float64 {
+	return EstimatePercentile(d.Histogram, p)
+}
+
+// AnalyticsTotals são os totais de um recorte (período inteiro ou um ponto da série)
+type AnalyticsTotals struct {
+	Sessions     int64
+	Active       int64
+	Closed       int64
+	Resolved     int64
+	Escalated    int64
+	Converted    int64
+	Messages     int64
+	Duration     DurationStats // duração de sessões encerradas
+	ResponseTime DurationStats // tempo até a primeira resposta do agente
+	WaitTime     DurationStats // tempo de espera do contato
+}
+
+// AnalyticsTimelinePoint é um ponto da série temporal (PeriodStart no fuso do filtro)
+type AnalyticsTimelinePoint struct {
+	PeriodStart time.Time
+	Totals      AnalyticsTotals
+}
+
+// AnalyticsReport é o resultado agregado de sessões
+type AnalyticsReport struct {
+	Totals    AnalyticsTotals
+	ByStatus  map[string]int64
+	ByChannel map[int]int64       // channel_type_id (0 = sem canal)
+	ByAgent   map[uuid.UUID]int64 // primeiro agente atribuído (uuid.Nil = sem agente)
+	ByHour    map[int]int64       // hora do dia (0-23) no fuso do filtro
+	Timeline  []AnalyticsTimelinePoint
+	// RefreshedAt indica até quando os rollups estão atualizados
+	RefreshedAt *time.Time
+}
+
+// AnalyticsRepository serve analytics a partir de tabelas de rollup (pré-agregadas por 15 minutos)
+type AnalyticsRepository interface {
+	GetAnalytics(ctx context.Context, filter AnalyticsFilter) (*AnalyticsReport, error)
+
+	// RefreshRollups recalcula incrementalmente os buckets afetados por sessões alteradas desde
+	// a última execução. Retorna o número de buckets (tenant, 15 minutos) recalculados.
+	RefreshRollups(ctx context.Context) (int, error)
+}
+
+// EstimatePercentile estima o percentil p (0-100) de um histograma com limites ResponseTimeBuckets
+func EstimatePercentile(histogram []int64, p float64) float64 {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	p = math.Max(0, math.Min(100, p))
+	target := p / 100 * float64(total)
+
+	var cumulative int64
+	for i, count := range histogram {
+		if count == 0 {
+			continue
+		}
+		if float64(cumulative+count) >= target {
+			lower := float64(0)
+			if i > 0 {
+				lower = float64(ResponseTimeBuckets[i-1])
+			}
+			// Bucket aberto: sem limite superior, retorna o limite inferior
+			if i >= len(ResponseTimeBuckets) {
+				return lower
+			}
+			upper := float64(ResponseTimeBuckets[i])
+			fraction := (target - float64(cumulative)) / float64(count)
+			return lower + fraction*(upper-lower)
+		}
+		cumulative += count
+	}
+
+	return float64(ResponseTimeBuckets[len(ResponseTimeBuckets)-1])
+}
@@ -0,0 +1,58 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEstimatePercentile(t *testing.T) {
+	t.Run("empty histogram", func(t *testing.T) {
+		assert.Equal(t, float64(0), EstimatePercentile(make([]int64, HistogramSize), 50))
+	})
+
+	t.Run("interpolates inside bucket", func(t *testing.T) {
+		histogram := make([]int64, HistogramSize)
+		histogram[1] = 10 // 10s..30s
+
+		assert.InDelta(t, 20, EstimatePercentile(histogram, 50), 0.001)
+		assert.InDelta(t, 30, EstimatePercentile(histogram, 100), 0.001)
+	})
+
+	t.Run("spans buckets", func(t *testing.T) {
+		histogram := make([]int64, HistogramSize)
+		histogram[0] = 50 // < 10s
+		histogram[4] = 50 // 120s..300s
+
+		assert.InDelta(t, 10, EstimatePercentile(histogram, 50), 0.001)
+		assert.InDelta(t, 264, EstimatePercentile(histogram, 90), 0.001)
+	})
+
+	t.Run("open bucket returns lower bound", func(t *testing.T) {
+		histogram := make([]int64, HistogramSize)
+		histogram[HistogramSize-1] = 3
+
+		assert.Equal(t, float64(86400), EstimatePercentile(histogram, 95))
+	})
+}
+
+func TestDurationStats_Average(t *testing.T) {
+	assert.Equal(t, float64(0), DurationStats{}.Average())
+	assert.Equal(t, 15.0, DurationStats{Count: 2, Sum: 30}.Average())
+}
+
+func TestAnalyticsFilter_Validate(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	filter := AnalyticsFilter{TenantID: "tenant-1", StartDate: start, EndDate: start.AddDate(0, 1, 0)}
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, GroupByDay, filter.GroupBy)
+	assert.Equal(t, time.UTC, filter.Location)
+
+	invalid := AnalyticsFilter{TenantID: "tenant-1", StartDate: start, EndDate: start}
+	assert.Error(t, invalid.Validate())
+
+	badGroup := AnalyticsFilter{TenantID: "tenant-1", StartDate: start, EndDate: start.Add(time.Hour), GroupBy: "year"}
+	assert.Error(t, badGroup.Validate())
+}
@@ -275,3 +275,23 @@ func NewSessionAbandonedEvent(sessionID uuid.UUID, lastAgentMsgAt time.Time, min
 		AbandonedAt:               time.Now(),
 	}
 }
+
+type SessionPriorityChangedEvent struct {
+	shared.BaseEvent
+	SessionID        uuid.UUID
+	TenantID         string
+	PreviousPriority Priority
+	Priority         Priority
+	ChangedAt        time.Time
+}
+
+func NewSessionPriorityChangedEvent(sessionID uuid.UUID, tenantID string, previous, priority Priority) SessionPriorityChangedEvent {
+	return SessionPriorityChangedEvent{
+		BaseEvent:        shared.NewBaseEvent("session.priority_changed", time.Now()),
+		SessionID:        sessionID,
+		TenantID:         tenantID,
+		PreviousPriority: previous,
+		Priority:         priority,
+		ChangedAt:        time.Now(),
+	}
+}
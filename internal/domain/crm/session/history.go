@@ -0,0 +1,145 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+const (
+	DefaultHistoryLimit = 20
+	MaxHistoryLimit     = 100
+	MaxActiveLimit      = 200
+)
+
+// HistoryFilter define uma página do histórico de sessões de um contato,
+// da mais recente para a mais antiga, por keyset (started_at, id)
+type HistoryFilter struct {
+	TenantID  string
+	ContactID uuid.UUID
+	Cursor    *shared.Cursor // sessões iniciadas antes do cursor
+	Limit     int
+}
+
+// Validate normaliza e valida o filtro
+func (f *HistoryFilter) Validate() error {
+	if f.TenantID == "" {
+		return errors.New("tenant_id is required")
+	}
+	if f.ContactID == uuid.Nil {
+		return errors.New("contact_id is required")
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultHistoryLimit
+	}
+	if f.Limit > MaxHistoryLimit {
+		f.Limit = MaxHistoryLimit
+	}
+	return nil
+}
+
+// HistoryEntry é o read model de uma sessão no histórico do contato
+type HistoryEntry struct {
+	ID              uuid.UUID
+	Status          string
+	ChannelID       *uuid.UUID // canal da última mensagem da sessão
+	ChannelName     *string
+	AgentID         *uuid.UUID // agente da participação mais recente
+	AgentName       *string
+	StartedAt       time.Time
+	EndedAt         *time.Time
+	DurationSeconds int
+	MessageCount    int
+	UnansweredCount int // mensagens do contato após a última resposta
+	LastMessageText *string
+	LastMessageAt   *time.Time
+	Summary         *string
+}
+
+// HistoryPage é uma página do histórico de sessões
+type HistoryPage struct {
+	Sessions []*HistoryEntry
+	HasMore  bool
+}
+
+// NextCursor retorna o cursor para a próxima página (sessões mais antigas)
+func (p *HistoryPage) NextCursor() *shared.Cursor {
+	if !p.HasMore || len(p.Sessions) == 0 {
+		return nil
+	}
+	last := p.Sessions[len(p.Sessions)-1]
+	cursor := shared.NewCursor(last.StartedAt, last.ID)
+	return &cursor
+}
+
+// ActiveFilter filtra a fila de sessões ativas
+type ActiveFilter struct {
+	TenantID   string
+	ChannelID  *uuid.UUID
+	AgentID    *uuid.UUID
+	Unassigned bool // apenas sessões sem agente
+	PipelineID *uuid.UUID
+	MinWaiting time.Duration // apenas sessões aguardando resposta há pelo menos esse tempo
+	Limit      int
+	Now        time.Time
+}
+
+// Validate normaliza e valida o filtro
+func (f *ActiveFilter) Validate() error {
+	if f.TenantID == "" {
+		return errors.New("tenant_id is required")
+	}
+	if f.AgentID != nil && f.Unassigned {
+		return errors.New("agent_id and unassigned are mutually exclusive")
+	}
+	if f.MinWaiting < 0 {
+		return errors.New("min_waiting must not be negative")
+	}
+	if f.Limit <= 0 {
+		f.Limit = DefaultHistoryLimit
+	}
+	if f.Limit > MaxActiveLimit {
+		f.Limit = MaxActiveLimit
+	}
+	if f.Now.IsZero() {
+		f.Now = time.Now()
+	}
+	return nil
+}
+
+// ActiveEntry é o read model de uma sessão ativa
+type ActiveEntry struct {
+	ID              uuid.UUID
+	ContactID       uuid.UUID
+	ContactName     *string
+	ContactPhone    *string
+	ChannelID       *uuid.UUID
+	AgentID         *uuid.UUID
+	AgentName       *string
+	PipelineID      *uuid.UUID
+	Status          string
+	StartedAt       time.Time
+	LastMessageAt   *time.Time
+	MessageCount    int
+	UnansweredCount int
+	WaitingSince    *time.Time // primeira mensagem do contato ainda sem resposta
+}
+
+// WaitingTime retorna há quanto tempo o contato aguarda resposta
+func (e *ActiveEntry) WaitingTime(now time.Time) time.Duration {
+	if e.WaitingSince == nil || now.Before(*e.WaitingSince) {
+		return 0
+	}
+	return now.Sub(*e.WaitingSince)
+}
+
+// HistoryRepository é o read side de histórico e fila de sessões
+type HistoryRepository interface {
+	FindContactHistory(ctx context.Context, filter HistoryFilter) (*HistoryPage, error)
+
+	// FindActive retorna sessões ativas ordenadas pela espera (mais antiga primeiro)
+	FindActive(ctx context.Context, filter ActiveFilter) ([]*ActiveEntry, error)
+}
@@ -0,0 +1,53 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistoryFilter_Validate(t *testing.T) {
+	filter := HistoryFilter{TenantID: "tenant-1", ContactID: uuid.New()}
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, DefaultHistoryLimit, filter.Limit)
+
+	missingContact := HistoryFilter{TenantID: "tenant-1"}
+	assert.Error(t, missingContact.Validate())
+}
+
+func TestHistoryPage_NextCursor(t *testing.T) {
+	older := &HistoryEntry{ID: uuid.New(), StartedAt: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	page := &HistoryPage{Sessions: []*HistoryEntry{{ID: uuid.New(), StartedAt: time.Now()}, older}, HasMore: true}
+
+	cursor := page.NextCursor()
+	assert.Equal(t, older.ID, cursor.ID)
+	assert.True(t, cursor.Timestamp.Equal(older.StartedAt))
+
+	page.HasMore = false
+	assert.Nil(t, page.NextCursor())
+}
+
+func TestActiveFilter_Validate(t *testing.T) {
+	agentID := uuid.New()
+
+	filter := ActiveFilter{TenantID: "tenant-1", Limit: 1000}
+	assert.NoError(t, filter.Validate())
+	assert.Equal(t, MaxActiveLimit, filter.Limit)
+	assert.False(t, filter.Now.IsZero())
+
+	conflicting := ActiveFilter{TenantID: "tenant-1", AgentID: &agentID, Unassigned: true}
+	assert.Error(t, conflicting.Validate())
+
+	negative := ActiveFilter{TenantID: "tenant-1", MinWaiting: -time.Minute}
+	assert.Error(t, negative.Validate())
+}
+
+func TestActiveEntry_WaitingTime(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-5 * time.Minute)
+
+	assert.Equal(t, 5*time.Minute, (&ActiveEntry{WaitingSince: &since}).WaitingTime(now))
+	assert.Equal(t, time.Duration(0), (&ActiveEntry{}).WaitingTime(now))
+}
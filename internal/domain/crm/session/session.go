@@ -15,6 +15,7 @@ type Session struct {
 	tenantID        string
 	channelTypeID   *int
 	pipelineID      *uuid.UUID
+	priority        Priority
 	startedAt       time.Time
 	endedAt         *time.Time
 	status          Status
@@ -74,6 +75,7 @@ func NewSession(
 		tenantID:        tenantID,
 		channelTypeID:   channelTypeID,
 		pipelineID:      nil,
+		priority:        PriorityNormal,
 		startedAt:       now,
 		status:          StatusActive,
 		timeoutDuration: timeoutDuration,
@@ -117,6 +119,7 @@ func NewSessionWithTimestamp(
 		tenantID:        tenantID,
 		channelTypeID:   channelTypeID,
 		pipelineID:      nil,
+		priority:        PriorityNormal,
 		startedAt:       startTime, // Use provided timestamp instead of time.Now()
 		status:          StatusActive,
 		timeoutDuration: timeoutDuration,
@@ -187,6 +190,7 @@ func ReconstructSession(
 	escalated bool,
 	converted bool,
 	outcomeTags []string,
+	priority Priority,
 ) *Session {
 	if agentIDs == nil {
 		agentIDs = []uuid.UUID{}
@@ -206,6 +210,9 @@ func ReconstructSession(
 	if version == 0 {
 		version = 1 // Default to version 1 if not set (backwards compatibility)
 	}
+	if !priority.IsValid() {
+		priority = PriorityNormal
+	}
 
 	return &Session{
 		id:                       id,
@@ -214,6 +221,7 @@ func ReconstructSession(
 		tenantID:                 tenantID,
 		channelTypeID:            channelTypeID,
 		pipelineID:               pipelineID,
+		priority:                 priority,
 		startedAt:                startedAt,
 		endedAt:                  endedAt,
 		status:                   status,
@@ -466,6 +474,23 @@ func (s *Session) Escalate() error {
 	return nil
 }
 
+// SetPriority altera a prioridade da sessão (usada na seleção de SLA e nas filas)
+func (s *Session) SetPriority(priority Priority) error {
+	if !priority.IsValid() {
+		return errors.New("invalid priority")
+	}
+	if s.priority == priority {
+		return nil
+	}
+
+	previous := s.priority
+	s.priority = priority
+
+	s.addEvent(NewSessionPriorityChangedEvent(s.id, s.tenantID, previous, priority))
+
+	return nil
+}
+
 func (s *Session) SetSummary(summary string, sentiment Sentiment, score float64, topics, nextSteps []string) {
 	s.summary = &summary
 	s.sentiment = &sentiment
@@ -567,6 +592,7 @@ func (s *Session) ContactID() uuid.UUID           { return s.contactID }
 func (s *Session) TenantID() string               { return s.tenantID }
 func (s *Session) ChannelTypeID() *int            { return s.channelTypeID }
 func (s *Session) PipelineID() *uuid.UUID         { return s.pipelineID }
+func (s *Session) Priority() Priority             { return s.priority }
 func (s *Session) StartedAt() time.Time           { return s.startedAt }
 func (s *Session) EndedAt() *time.Time            { return s.endedAt }
 func (s *Session) Status() Status                 { return s.status }
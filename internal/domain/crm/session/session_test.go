@@ -248,6 +248,26 @@ func TestSession_Escalate(t *testing.T) {
 	assert.Equal(t, "session.escalated", events[0].EventName())
 }
 
+func TestSession_SetPriority(t *testing.T) {
+	sess, _ := session.NewSession(uuid.New(), "tenant-1", nil, 30*time.Minute)
+	sess.ClearEvents()
+	assert.Equal(t, session.PriorityNormal, sess.Priority())
+
+	require.NoError(t, sess.SetPriority(session.PriorityUrgent))
+	assert.Equal(t, session.PriorityUrgent, sess.Priority())
+
+	events := sess.DomainEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, "session.priority_changed", events[0].EventName())
+
+	// Mesma prioridade não gera evento
+	sess.ClearEvents()
+	require.NoError(t, sess.SetPriority(session.PriorityUrgent))
+	assert.Empty(t, sess.DomainEvents())
+
+	assert.Error(t, sess.SetPriority(session.Priority("critical")))
+}
+
 func TestSession_SetSummary(t *testing.T) {
 	// Arrange
 	sess, _ := session.NewSession(uuid.New(), "tenant-1", nil, 30*time.Minute)
@@ -566,6 +586,7 @@ func TestReconstructSession(t *testing.T) {
 		false, // escalated
 		true,  // converted
 		[]string{"tag1"},
+		session.PriorityHigh,
 	)
 
 	// Assert
@@ -581,6 +602,7 @@ func TestReconstructSession(t *testing.T) {
 	assert.True(t, sess.IsResolved())
 	assert.False(t, sess.IsEscalated())
 	assert.True(t, sess.IsConverted())
+	assert.Equal(t, session.PriorityHigh, sess.Priority())
 	assert.NotNil(t, sess.Summary())
 	assert.Equal(t, summary, *sess.Summary())
 
@@ -39,6 +39,37 @@ func (r EndReason) String() string {
 	return string(r)
 }
 
+// Priority prioridade de atendimento da sessão
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+func (p Priority) String() string {
+	return string(p)
+}
+
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return true
+	default:
+		return false
+	}
+}
+
+func ParsePriority(s string) (Priority, error) {
+	priority := Priority(s)
+	if !priority.IsValid() {
+		return "", errors.New("invalid priority: use low, normal, high or urgent")
+	}
+	return priority, nil
+}
+
 type Sentiment string
 
 const (
@@ -0,0 +1,203 @@
+package sla
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+var ErrClockNotFound = errors.New("sla clock not found")
+
+type DomainEvent = shared.DomainEvent
+
+// ClockStatus situação de um relógio de SLA
+type ClockStatus string
+
+const (
+	ClockRunning   ClockStatus = "running"   // meta em andamento
+	ClockMet       ClockStatus = "met"       // parado antes do vencimento
+	ClockBreached  ClockStatus = "breached"  // venceu (mesmo que tenha parado depois)
+	ClockCancelled ClockStatus = "cancelled" // deixou de se aplicar (ex: sessão encerrada antes da resposta)
+)
+
+// Clock relógio de uma métrica de SLA em uma sessão. Os instantes de aviso e
+// vencimento já descontam o tempo fora do expediente da política.
+type Clock struct {
+	id        uuid.UUID
+	tenantID  string
+	projectID uuid.UUID
+	sessionID uuid.UUID
+	policyID  uuid.UUID
+	metric    Metric
+	status    ClockStatus
+
+	startedAt time.Time
+	warnAt    time.Time
+	dueAt     time.Time
+
+	warnedAt   *time.Time
+	breachedAt *time.Time
+	stoppedAt  *time.Time
+
+	// agentID - Agente responsável pela sessão quando o relógio parou ou venceu
+	agentID *uuid.UUID
+
+	// elapsedSeconds - Tempo útil entre o início e a parada
+	elapsedSeconds *int
+
+	events []DomainEvent
+}
+
+func newClock(p *Policy, sessionID uuid.UUID, metric Metric, startedAt, warnAt, dueAt time.Time) *Clock {
+	return &Clock{
+		id:        uuid.New(),
+		tenantID:  p.tenantID,
+		projectID: p.projectID,
+		sessionID: sessionID,
+		policyID:  p.id,
+		metric:    metric,
+		status:    ClockRunning,
+		startedAt: startedAt,
+		warnAt:    warnAt,
+		dueAt:     dueAt,
+		events:    []DomainEvent{},
+	}
+}
+
+func ReconstructClock(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	sessionID uuid.UUID,
+	policyID uuid.UUID,
+	metric Metric,
+	status ClockStatus,
+	startedAt time.Time,
+	warnAt time.Time,
+	dueAt time.Time,
+	warnedAt *time.Time,
+	breachedAt *time.Time,
+	stoppedAt *time.Time,
+	agentID *uuid.UUID,
+	elapsedSeconds *int,
+) *Clock {
+	return &Clock{
+		id:             id,
+		tenantID:       tenantID,
+		projectID:      projectID,
+		sessionID:      sessionID,
+		policyID:       policyID,
+		metric:         metric,
+		status:         status,
+		startedAt:      startedAt,
+		warnAt:         warnAt,
+		dueAt:          dueAt,
+		warnedAt:       warnedAt,
+		breachedAt:     breachedAt,
+		stoppedAt:      stoppedAt,
+		agentID:        agentID,
+		elapsedSeconds: elapsedSeconds,
+		events:         []DomainEvent{},
+	}
+}
+
+// Warn emite sla.warning se o relógio ainda corre e o ponto de aviso passou. Idempotente.
+func (c *Clock) Warn(now time.Time, agentID *uuid.UUID) bool {
+	if c.status != ClockRunning || c.warnedAt != nil || now.Before(c.warnAt) {
+		return false
+	}
+	c.warnedAt = &now
+	c.addEvent(NewWarningEvent(c, agentID, now))
+	return true
+}
+
+// Breach marca o vencimento e emite sla.breached se a meta passou. Idempotente.
+func (c *Clock) Breach(now time.Time, agentID *uuid.UUID) bool {
+	if c.status != ClockRunning || now.Before(c.dueAt) {
+		return false
+	}
+	c.status = ClockBreached
+	c.breachedAt = &now
+	c.agentID = agentID
+	c.addEvent(NewBreachedEvent(c, now))
+	return true
+}
+
+// Stop registra que a métrica foi cumprida (resposta do agente ou encerramento).
+// Se a meta venceu sem o timer ter disparado, o vencimento é registrado agora.
+func (c *Clock) Stop(now time.Time, agentID *uuid.UUID, elapsed time.Duration) {
+	if c.stoppedAt != nil || c.status == ClockCancelled {
+		return
+	}
+
+	if c.status == ClockRunning {
+		if now.Before(c.dueAt) {
+			c.status = ClockMet
+		} else {
+			c.Breach(now, agentID)
+		}
+	}
+
+	seconds := int(elapsed.Seconds())
+	c.stoppedAt = &now
+	c.elapsedSeconds = &seconds
+	if agentID != nil {
+		c.agentID = agentID
+	}
+}
+
+// Cancel descarta um relógio que ainda corre e deixou de fazer sentido
+func (c *Clock) Cancel(now time.Time) bool {
+	if c.status != ClockRunning {
+		return false
+	}
+	c.status = ClockCancelled
+	c.stoppedAt = &now
+	return true
+}
+
+// Reschedule aplica os prazos de outra política a um relógio em andamento
+// (ex: a prioridade da sessão mudou). O aviso pode ser reemitido.
+func (c *Clock) Reschedule(p *Policy) error {
+	if c.status != ClockRunning {
+		return nil
+	}
+	warnAt, dueAt, err := p.Deadlines(c.metric, c.startedAt)
+	if err != nil {
+		return err
+	}
+	c.policyID = p.id
+	c.warnAt = warnAt
+	c.dueAt = dueAt
+	c.warnedAt = nil
+	return nil
+}
+
+func (c *Clock) IsRunning() bool { return c.status == ClockRunning }
+
+func (c *Clock) ID() uuid.UUID               { return c.id }
+func (c *Clock) TenantID() string            { return c.tenantID }
+func (c *Clock) ProjectID() uuid.UUID        { return c.projectID }
+func (c *Clock) SessionID() uuid.UUID        { return c.sessionID }
+func (c *Clock) PolicyID() uuid.UUID         { return c.policyID }
+func (c *Clock) Metric() Metric              { return c.metric }
+func (c *Clock) Status() ClockStatus         { return c.status }
+func (c *Clock) StartedAt() time.Time        { return c.startedAt }
+func (c *Clock) WarnAt() time.Time           { return c.warnAt }
+func (c *Clock) DueAt() time.Time            { return c.dueAt }
+func (c *Clock) WarnedAt() *time.Time        { return c.warnedAt }
+func (c *Clock) BreachedAt() *time.Time      { return c.breachedAt }
+func (c *Clock) StoppedAt() *time.Time       { return c.stoppedAt }
+func (c *Clock) AgentID() *uuid.UUID         { return c.agentID }
+func (c *Clock) ElapsedSeconds() *int        { return c.elapsedSeconds }
+func (c *Clock) DomainEvents() []DomainEvent { return append([]DomainEvent{}, c.events...) }
+
+func (c *Clock) ClearEvents() {
+	c.events = []DomainEvent{}
+}
+
+func (c *Clock) addEvent(event DomainEvent) {
+	c.events = append(c.events, event)
+}
@@ -0,0 +1,112 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func startTestClock(t *testing.T, start time.Time) (*Policy, *Clock) {
+	t.Helper()
+	p := newTestPolicy(t, "p")
+	c, err := p.StartClock(uuid.New(), MetricFirstResponse, start)
+	require.NoError(t, err)
+	return p, c
+}
+
+func TestClock_WarnAndBreach(t *testing.T) {
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	_, c := startTestClock(t, start)
+	agentID := uuid.New()
+
+	assert.Equal(t, start.Add(24*time.Minute), c.WarnAt())
+	assert.Equal(t, start.Add(30*time.Minute), c.DueAt())
+
+	assert.False(t, c.Warn(start.Add(10*time.Minute), nil), "too early")
+	assert.True(t, c.Warn(start.Add(25*time.Minute), &agentID))
+	assert.False(t, c.Warn(start.Add(26*time.Minute), &agentID), "warns once")
+
+	assert.False(t, c.Breach(start.Add(29*time.Minute), &agentID), "not due yet")
+	assert.True(t, c.Breach(start.Add(30*time.Minute), &agentID))
+	assert.False(t, c.Breach(start.Add(31*time.Minute), &agentID), "breaches once")
+
+	assert.Equal(t, ClockBreached, c.Status())
+	assert.Equal(t, &agentID, c.AgentID())
+
+	events := c.DomainEvents()
+	require.Len(t, events, 2)
+	assert.Equal(t, "sla.warning", events[0].EventName())
+	assert.Equal(t, "sla.breached", events[1].EventName())
+
+	// Resposta tardia registra o tempo real sem mudar o resultado
+	c.Stop(start.Add(40*time.Minute), &agentID, 40*time.Minute)
+	assert.Equal(t, ClockBreached, c.Status())
+	require.NotNil(t, c.ElapsedSeconds())
+	assert.Equal(t, 2400, *c.ElapsedSeconds())
+}
+
+func TestClock_StopBeforeDue_Met(t *testing.T) {
+	start := time.Now()
+	_, c := startTestClock(t, start)
+	agentID := uuid.New()
+
+	c.Stop(start.Add(5*time.Minute), &agentID, 5*time.Minute)
+
+	assert.Equal(t, ClockMet, c.Status())
+	assert.Empty(t, c.DomainEvents())
+	assert.False(t, c.Breach(start.Add(time.Hour), nil))
+	assert.False(t, c.Cancel(start.Add(time.Hour)))
+}
+
+func TestClock_StopAfterDue_WithoutTimer_Breaches(t *testing.T) {
+	start := time.Now()
+	_, c := startTestClock(t, start)
+
+	c.Stop(start.Add(time.Hour), nil, time.Hour)
+
+	assert.Equal(t, ClockBreached, c.Status())
+	require.Len(t, c.DomainEvents(), 1)
+	assert.Equal(t, "sla.breached", c.DomainEvents()[0].EventName())
+}
+
+func TestClock_Cancel(t *testing.T) {
+	start := time.Now()
+	_, c := startTestClock(t, start)
+
+	assert.True(t, c.Cancel(start.Add(time.Minute)))
+	assert.Equal(t, ClockCancelled, c.Status())
+	assert.False(t, c.Warn(start.Add(time.Hour), nil))
+
+	c.Stop(start.Add(2*time.Minute), nil, 2*time.Minute)
+	assert.Equal(t, ClockCancelled, c.Status())
+	assert.Nil(t, c.ElapsedSeconds())
+}
+
+func TestClock_Reschedule(t *testing.T) {
+	start := time.Date(2025, 1, 6, 12, 0, 0, 0, time.UTC)
+	_, c := startTestClock(t, start)
+	require.True(t, c.Warn(start.Add(25*time.Minute), nil))
+
+	urgent := session.PriorityUrgent
+	faster, err := NewPolicy("tenant-1", c.ProjectID(), "urgent", Targets{FirstResponse: 10 * time.Minute})
+	require.NoError(t, err)
+	require.NoError(t, faster.SetScope(nil, nil, &urgent))
+
+	require.NoError(t, c.Reschedule(faster))
+	assert.Equal(t, faster.ID(), c.PolicyID())
+	assert.Equal(t, start.Add(10*time.Minute), c.DueAt())
+	assert.Nil(t, c.WarnedAt())
+
+	resolutionOnly, err := NewPolicy("tenant-1", c.ProjectID(), "resolution only", Targets{Resolution: time.Hour})
+	require.NoError(t, err)
+	assert.ErrorIs(t, c.Reschedule(resolutionOnly), ErrMetricNotTracked)
+}
+
+func TestComplianceRow_Rate(t *testing.T) {
+	assert.Equal(t, 100.0, ComplianceRow{}.Rate())
+	assert.Equal(t, 75.0, ComplianceRow{Met: 3, Breached: 1}.Rate())
+}
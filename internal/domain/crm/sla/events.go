@@ -0,0 +1,66 @@
+package sla
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// WarningEvent a meta está perto de vencer (WarningPercent da política)
+type WarningEvent struct {
+	shared.BaseEvent
+	ClockID   uuid.UUID
+	TenantID  string
+	ProjectID uuid.UUID
+	SessionID uuid.UUID
+	PolicyID  uuid.UUID
+	Metric    Metric
+	AgentID   *uuid.UUID
+	DueAt     time.Time
+	WarnedAt  time.Time
+}
+
+func NewWarningEvent(c *Clock, agentID *uuid.UUID, at time.Time) WarningEvent {
+	return WarningEvent{
+		BaseEvent: shared.NewBaseEvent("sla.warning", time.Now()),
+		ClockID:   c.id,
+		TenantID:  c.tenantID,
+		ProjectID: c.projectID,
+		SessionID: c.sessionID,
+		PolicyID:  c.policyID,
+		Metric:    c.metric,
+		AgentID:   agentID,
+		DueAt:     c.dueAt,
+		WarnedAt:  at,
+	}
+}
+
+// BreachedEvent a meta venceu sem ser cumprida
+type BreachedEvent struct {
+	shared.BaseEvent
+	ClockID    uuid.UUID
+	TenantID   string
+	ProjectID  uuid.UUID
+	SessionID  uuid.UUID
+	PolicyID   uuid.UUID
+	Metric     Metric
+	AgentID    *uuid.UUID
+	DueAt      time.Time
+	BreachedAt time.Time
+}
+
+func NewBreachedEvent(c *Clock, at time.Time) BreachedEvent {
+	return BreachedEvent{
+		BaseEvent:  shared.NewBaseEvent("sla.breached", time.Now()),
+		ClockID:    c.id,
+		TenantID:   c.tenantID,
+		ProjectID:  c.projectID,
+		SessionID:  c.sessionID,
+		PolicyID:   c.policyID,
+		Metric:     c.metric,
+		AgentID:    c.agentID,
+		DueAt:      c.dueAt,
+		BreachedAt: at,
+	}
+}
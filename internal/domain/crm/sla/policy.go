@@ -0,0 +1,346 @@
+package sla
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+var (
+	ErrPolicyNotFound   = errors.New("sla policy not found")
+	ErrInvalidTenant    = errors.New("tenantID cannot be empty")
+	ErrInvalidProject   = errors.New("projectID cannot be nil")
+	ErrEmptyName        = errors.New("policy name cannot be empty")
+	ErrNoTargets        = errors.New("policy must define at least one target")
+	ErrNegativeTarget   = errors.New("targets cannot be negative")
+	ErrInvalidWarning   = errors.New("warning percent must be between 1 and 99")
+	ErrInvalidPriority  = errors.New("invalid priority")
+	ErrMetricNotTracked = errors.New("policy has no target for this metric")
+)
+
+// DefaultWarningPercent fração da meta (em %) em que sla.warning é emitido
+const DefaultWarningPercent = 80
+
+// Metric métrica de atendimento medida pelo SLA
+type Metric string
+
+const (
+	// MetricFirstResponse - Do início da sessão até a primeira resposta de um agente
+	MetricFirstResponse Metric = "first_response"
+
+	// MetricNextResponse - De cada mensagem do contato (após a primeira resposta) até a resposta seguinte
+	MetricNextResponse Metric = "next_response"
+
+	// MetricResolution - Do início até o encerramento da sessão
+	MetricResolution Metric = "resolution"
+)
+
+func (m Metric) IsValid() bool {
+	switch m {
+	case MetricFirstResponse, MetricNextResponse, MetricResolution:
+		return true
+	default:
+		return false
+	}
+}
+
+// Targets metas de cada métrica, em horas úteis. Zero = métrica não acompanhada
+type Targets struct {
+	FirstResponse time.Duration
+	NextResponse  time.Duration
+	Resolution    time.Duration
+}
+
+// For retorna a meta da métrica
+func (t Targets) For(metric Metric) time.Duration {
+	switch metric {
+	case MetricFirstResponse:
+		return t.FirstResponse
+	case MetricNextResponse:
+		return t.NextResponse
+	case MetricResolution:
+		return t.Resolution
+	default:
+		return 0
+	}
+}
+
+func (t Targets) validate() error {
+	if t.FirstResponse < 0 || t.NextResponse < 0 || t.Resolution < 0 {
+		return ErrNegativeTarget
+	}
+	if t.FirstResponse == 0 && t.NextResponse == 0 && t.Resolution == 0 {
+		return ErrNoTargets
+	}
+	return nil
+}
+
+// Target características da sessão usadas para escolher a política
+type Target struct {
+	PipelineID    *uuid.UUID
+	ChannelTypeID *int
+	Priority      session.Priority
+}
+
+// TargetFor extrai da sessão os critérios de seleção de política
+func TargetFor(sess *session.Session) Target {
+	return Target{
+		PipelineID:    sess.PipelineID(),
+		ChannelTypeID: sess.ChannelTypeID(),
+		Priority:      sess.Priority(),
+	}
+}
+
+// Policy política de SLA de um projeto. Critérios vazios (pipeline, tipo de canal,
+// prioridade) valem para qualquer sessão; a política mais específica vence.
+type Policy struct {
+	id        uuid.UUID
+	tenantID  string
+	projectID uuid.UUID
+	name      string
+
+	pipelineID    *uuid.UUID
+	channelTypeID *int
+	priority      *session.Priority
+
+	targets        Targets
+	warningPercent int
+	businessHours  *businesshours.Schedule
+	fallbackHours  *businesshours.Schedule // expediente do projeto (não persistido)
+	enabled        bool
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewPolicy(tenantID string, projectID uuid.UUID, name string, targets Targets) (*Policy, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenant
+	}
+	if projectID == uuid.Nil {
+		return nil, ErrInvalidProject
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if err := targets.validate(); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Policy{
+		id:             uuid.New(),
+		tenantID:       tenantID,
+		projectID:      projectID,
+		name:           name,
+		targets:        targets,
+		warningPercent: DefaultWarningPercent,
+		enabled:        true,
+		createdAt:      now,
+		updatedAt:      now,
+	}, nil
+}
+
+func ReconstructPolicy(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	name string,
+	pipelineID *uuid.UUID,
+	channelTypeID *int,
+	priority *session.Priority,
+	targets Targets,
+	warningPercent int,
+	businessHours *businesshours.Schedule,
+	enabled bool,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Policy {
+	if warningPercent <= 0 || warningPercent >= 100 {
+		warningPercent = DefaultWarningPercent
+	}
+	return &Policy{
+		id:             id,
+		tenantID:       tenantID,
+		projectID:      projectID,
+		name:           name,
+		pipelineID:     pipelineID,
+		channelTypeID:  channelTypeID,
+		priority:       priority,
+		targets:        targets,
+		warningPercent: warningPercent,
+		businessHours:  businessHours,
+		enabled:        enabled,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}
+}
+
+func (p *Policy) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	p.name = name
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetScope define a quais sessões a política se aplica (nil = qualquer)
+func (p *Policy) SetScope(pipelineID *uuid.UUID, channelTypeID *int, priority *session.Priority) error {
+	if priority != nil && !priority.IsValid() {
+		return ErrInvalidPriority
+	}
+	p.pipelineID = pipelineID
+	p.channelTypeID = channelTypeID
+	p.priority = priority
+	p.updatedAt = time.Now()
+	return nil
+}
+
+func (p *Policy) SetTargets(targets Targets) error {
+	if err := targets.validate(); err != nil {
+		return err
+	}
+	p.targets = targets
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetWarningPercent define em que fração da meta o aviso sla.warning é emitido
+func (p *Policy) SetWarningPercent(percent int) error {
+	if percent < 1 || percent > 99 {
+		return ErrInvalidWarning
+	}
+	p.warningPercent = percent
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// SetBusinessHours define o expediente em que o relógio corre (nil = 24/7)
+func (p *Policy) SetBusinessHours(schedule *businesshours.Schedule) error {
+	if err := schedule.Validate(); err != nil {
+		return err
+	}
+	p.businessHours = schedule
+	p.updatedAt = time.Now()
+	return nil
+}
+
+// UseProjectHours define o expediente do projeto, usado quando a política não tem expediente próprio
+func (p *Policy) UseProjectHours(schedule *businesshours.Schedule) {
+	p.fallbackHours = schedule
+}
+
+// effectiveHours expediente em que o relógio corre: o da política, senão o do projeto (nil = 24/7)
+func (p *Policy) effectiveHours() *businesshours.Schedule {
+	if p.businessHours != nil {
+		return p.businessHours
+	}
+	return p.fallbackHours
+}
+
+func (p *Policy) Enable() {
+	p.enabled = true
+	p.updatedAt = time.Now()
+}
+
+func (p *Policy) Disable() {
+	p.enabled = false
+	p.updatedAt = time.Now()
+}
+
+// AppliesTo indica se a política cobre a sessão
+func (p *Policy) AppliesTo(target Target) bool {
+	if !p.enabled {
+		return false
+	}
+	if p.pipelineID != nil && (target.PipelineID == nil || *target.PipelineID != *p.pipelineID) {
+		return false
+	}
+	if p.channelTypeID != nil && (target.ChannelTypeID == nil || *target.ChannelTypeID != *p.channelTypeID) {
+		return false
+	}
+	if p.priority != nil && target.Priority != *p.priority {
+		return false
+	}
+	return true
+}
+
+// specificity quantos critérios a política restringe
+func (p *Policy) specificity() int {
+	n := 0
+	if p.pipelineID != nil {
+		n++
+	}
+	if p.channelTypeID != nil {
+		n++
+	}
+	if p.priority != nil {
+		n++
+	}
+	return n
+}
+
+// SelectPolicy escolhe a política mais específica que cobre a sessão.
+// Empates vão para a política criada primeiro. Retorna nil se nenhuma se aplica.
+func SelectPolicy(policies []*Policy, target Target) *Policy {
+	var selected *Policy
+	for _, p := range policies {
+		if !p.AppliesTo(target) {
+			continue
+		}
+		if selected == nil ||
+			p.specificity() > selected.specificity() ||
+			(p.specificity() == selected.specificity() && p.createdAt.Before(selected.createdAt)) {
+			selected = p
+		}
+	}
+	return selected
+}
+
+// Deadlines calcula quando avisar e quando a meta vence, contando apenas horas úteis
+func (p *Policy) Deadlines(metric Metric, startedAt time.Time) (warnAt, dueAt time.Time, err error) {
+	target := p.targets.For(metric)
+	if target <= 0 {
+		return time.Time{}, time.Time{}, ErrMetricNotTracked
+	}
+	warning := target * time.Duration(p.warningPercent) / 100
+	hours := p.effectiveHours()
+	return hours.Add(startedAt, warning), hours.Add(startedAt, target), nil
+}
+
+// Elapsed tempo útil decorrido entre from e to
+func (p *Policy) Elapsed(from, to time.Time) time.Duration {
+	return p.effectiveHours().Between(from, to)
+}
+
+// StartClock abre o relógio da métrica para a sessão
+func (p *Policy) StartClock(sessionID uuid.UUID, metric Metric, startedAt time.Time) (*Clock, error) {
+	warnAt, dueAt, err := p.Deadlines(metric, startedAt)
+	if err != nil {
+		return nil, err
+	}
+	return newClock(p, sessionID, metric, startedAt, warnAt, dueAt), nil
+}
+
+func (p *Policy) Tracks(metric Metric) bool { return p.targets.For(metric) > 0 }
+
+func (p *Policy) ID() uuid.UUID                          { return p.id }
+func (p *Policy) TenantID() string                       { return p.tenantID }
+func (p *Policy) ProjectID() uuid.UUID                   { return p.projectID }
+func (p *Policy) Name() string                           { return p.name }
+func (p *Policy) PipelineID() *uuid.UUID                 { return p.pipelineID }
+func (p *Policy) ChannelTypeID() *int                    { return p.channelTypeID }
+func (p *Policy) Priority() *session.Priority            { return p.priority }
+func (p *Policy) Targets() Targets                       { return p.targets }
+func (p *Policy) WarningPercent() int                    { return p.warningPercent }
+func (p *Policy) BusinessHours() *businesshours.Schedule { return p.businessHours }
+func (p *Policy) IsEnabled() bool                        { return p.enabled }
+func (p *Policy) CreatedAt() time.Time                   { return p.createdAt }
+func (p *Policy) UpdatedAt() time.Time                   { return p.updatedAt }
@@ -0,0 +1,134 @@
+package sla
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/crm/businesshours"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func newTestPolicy(t *testing.T, name string) *Policy {
+	t.Helper()
+	p, err := NewPolicy("tenant-1", uuid.New(), name, Targets{
+		FirstResponse: 30 * time.Minute,
+		NextResponse:  15 * time.Minute,
+		Resolution:    8 * time.Hour,
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestNewPolicy(t *testing.T) {
+	p := newTestPolicy(t, "  Padrão ")
+	assert.Equal(t, "Padrão", p.Name())
+	assert.True(t, p.IsEnabled())
+	assert.Equal(t, DefaultWarningPercent, p.WarningPercent())
+	assert.True(t, p.Tracks(MetricFirstResponse))
+
+	_, err := NewPolicy("", uuid.New(), "x", Targets{FirstResponse: time.Minute})
+	assert.ErrorIs(t, err, ErrInvalidTenant)
+	_, err = NewPolicy("t", uuid.Nil, "x", Targets{FirstResponse: time.Minute})
+	assert.ErrorIs(t, err, ErrInvalidProject)
+	_, err = NewPolicy("t", uuid.New(), " ", Targets{FirstResponse: time.Minute})
+	assert.ErrorIs(t, err, ErrEmptyName)
+	_, err = NewPolicy("t", uuid.New(), "x", Targets{})
+	assert.ErrorIs(t, err, ErrNoTargets)
+	_, err = NewPolicy("t", uuid.New(), "x", Targets{FirstResponse: -time.Minute})
+	assert.ErrorIs(t, err, ErrNegativeTarget)
+}
+
+func TestPolicy_Setters(t *testing.T) {
+	p := newTestPolicy(t, "p")
+
+	assert.ErrorIs(t, p.SetWarningPercent(0), ErrInvalidWarning)
+	assert.ErrorIs(t, p.SetWarningPercent(100), ErrInvalidWarning)
+	require.NoError(t, p.SetWarningPercent(50))
+
+	invalid := session.Priority("critical")
+	assert.ErrorIs(t, p.SetScope(nil, nil, &invalid), ErrInvalidPriority)
+
+	assert.Error(t, p.SetBusinessHours(&businesshours.Schedule{Timezone: "Nowhere/City"}))
+	require.NoError(t, p.SetBusinessHours(nil))
+}
+
+func TestSelectPolicy_MostSpecificWins(t *testing.T) {
+	pipelineID := uuid.New()
+	whatsapp := 1
+	urgent := session.PriorityUrgent
+
+	general := newTestPolicy(t, "general")
+
+	byPipeline := newTestPolicy(t, "pipeline")
+	require.NoError(t, byPipeline.SetScope(&pipelineID, nil, nil))
+
+	urgentWhatsApp := newTestPolicy(t, "urgent whatsapp")
+	require.NoError(t, urgentWhatsApp.SetScope(nil, &whatsapp, &urgent))
+
+	disabled := newTestPolicy(t, "disabled")
+	require.NoError(t, disabled.SetScope(&pipelineID, &whatsapp, &urgent))
+	disabled.Disable()
+
+	policies := []*Policy{general, byPipeline, urgentWhatsApp, disabled}
+
+	assert.Equal(t, general, SelectPolicy(policies, Target{Priority: session.PriorityNormal}))
+	assert.Equal(t, byPipeline, SelectPolicy(policies, Target{PipelineID: &pipelineID, Priority: session.PriorityNormal}))
+	assert.Equal(t, urgentWhatsApp, SelectPolicy(policies, Target{PipelineID: &pipelineID, ChannelTypeID: &whatsapp, Priority: urgent}))
+	assert.Nil(t, SelectPolicy([]*Policy{byPipeline}, Target{Priority: session.PriorityNormal}))
+}
+
+func TestSelectPolicy_TieGoesToOldest(t *testing.T) {
+	older := newTestPolicy(t, "older")
+	newer := ReconstructPolicy(uuid.New(), "tenant-1", older.ProjectID(), "newer", nil, nil, nil,
+		older.Targets(), 80, nil, true, older.CreatedAt().Add(time.Hour), older.CreatedAt().Add(time.Hour))
+
+	assert.Equal(t, older, SelectPolicy([]*Policy{newer, older}, Target{Priority: session.PriorityNormal}))
+}
+
+func TestPolicy_Deadlines_BusinessHours(t *testing.T) {
+	p := newTestPolicy(t, "business")
+	require.NoError(t, p.SetBusinessHours(&businesshours.Schedule{
+		Timezone: "UTC",
+		Weekly: map[string][]businesshours.Interval{
+			"monday":  {{Start: "09:00", End: "18:00"}},
+			"tuesday": {{Start: "09:00", End: "18:00"}},
+		},
+	}))
+
+	// Segunda 17:50 UTC: 10 min hoje, o restante na terça
+	start := time.Date(2025, 1, 6, 17, 50, 0, 0, time.UTC)
+	warnAt, dueAt, err := p.Deadlines(MetricFirstResponse, start)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 7, 9, 14, 0, 0, time.UTC), warnAt)
+	assert.Equal(t, time.Date(2025, 1, 7, 9, 20, 0, 0, time.UTC), dueAt)
+
+	assert.Equal(t, 30*time.Minute, p.Elapsed(start, dueAt))
+
+	unresolved, err := NewPolicy("t", uuid.New(), "first only", Targets{FirstResponse: time.Minute})
+	require.NoError(t, err)
+	_, _, err = unresolved.Deadlines(MetricResolution, start)
+	assert.ErrorIs(t, err, ErrMetricNotTracked)
+}
+
+func TestPolicy_Deadlines_ProjectHoursFallback(t *testing.T) {
+	p := newTestPolicy(t, "fallback")
+	projectHours := &businesshours.Schedule{
+		Timezone: "UTC",
+		Weekly:   map[string][]businesshours.Interval{"monday": {{Start: "09:00", End: "18:00"}}, "tuesday": {{Start: "09:00", End: "18:00"}}},
+	}
+	p.UseProjectHours(projectHours)
+	start := time.Date(2025, 1, 6, 17, 50, 0, 0, time.UTC)
+
+	_, dueAt, err := p.Deadlines(MetricFirstResponse, start)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2025, 1, 7, 9, 20, 0, 0, time.UTC), dueAt)
+
+	// Expediente próprio da política tem precedência
+	require.NoError(t, p.SetBusinessHours(&businesshours.Schedule{Timezone: "UTC"}))
+	_, dueAt, err = p.Deadlines(MetricFirstResponse, start)
+	require.NoError(t, err)
+	assert.Equal(t, start.Add(30*time.Minute), dueAt)
+}
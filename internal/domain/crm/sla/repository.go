@@ -0,0 +1,56 @@
+package sla
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type PolicyRepository interface {
+	Save(ctx context.Context, policy *Policy) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Policy, error)
+
+	// FindByProject retorna as políticas do projeto (habilitadas ou não), mais antigas primeiro
+	FindByProject(ctx context.Context, projectID uuid.UUID) ([]*Policy, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type ClockRepository interface {
+	Save(ctx context.Context, clock *Clock) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Clock, error)
+	FindBySession(ctx context.Context, sessionID uuid.UUID) ([]*Clock, error)
+
+	// FindDue retorna relógios em andamento com aviso ou vencimento já alcançados em now
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*Clock, error)
+
+	// Compliance agrega os relógios encerrados por agente e métrica
+	Compliance(ctx context.Context, filter ComplianceFilter) ([]ComplianceRow, error)
+}
+
+// ComplianceFilter escopo do relatório de cumprimento de SLA (relógios iniciados em [From, To))
+type ComplianceFilter struct {
+	TenantID  string
+	ProjectID *uuid.UUID
+	AgentIDs  []uuid.UUID // vazio = todos os agentes
+	From      time.Time
+	To        time.Time
+}
+
+// ComplianceRow totais de um agente em uma métrica. AgentID nil = sessões sem agente
+type ComplianceRow struct {
+	AgentID           *uuid.UUID
+	Metric            Metric
+	Met               int
+	Breached          int
+	AvgElapsedSeconds float64
+}
+
+// Rate percentual de metas cumpridas (0-100). Sem relógios encerrados = 100
+func (r ComplianceRow) Rate() float64 {
+	total := r.Met + r.Breached
+	if total == 0 {
+		return 100
+	}
+	return float64(r.Met) * 100 / float64(total)
+}
@@ -0,0 +1,169 @@
+package task
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+// TaskCreatedEvent tarefa criada (inclusive a próxima ocorrência de uma recorrente)
+type TaskCreatedEvent struct {
+	shared.BaseEvent
+	TaskID     uuid.UUID
+	TenantID   string
+	Title      string
+	DueAt      *time.Time
+	Timezone   string
+	Priority   Priority
+	Recurrence Recurrence
+	AssigneeID *uuid.UUID
+	ContactID  *uuid.UUID
+	SessionID  *uuid.UUID
+	PipelineID *uuid.UUID
+	CreatedBy  uuid.UUID
+}
+
+func NewTaskCreatedEvent(t *Task) TaskCreatedEvent {
+	return TaskCreatedEvent{
+		BaseEvent:  shared.NewBaseEvent("task.created", time.Now()),
+		TaskID:     t.id,
+		TenantID:   t.tenantID,
+		Title:      t.title,
+		DueAt:      t.dueAt,
+		Timezone:   t.timezone,
+		Priority:   t.priority,
+		Recurrence: t.recurrence,
+		AssigneeID: t.assigneeID,
+		ContactID:  t.contactID,
+		SessionID:  t.sessionID,
+		PipelineID: t.pipelineID,
+		CreatedBy:  t.createdBy,
+	}
+}
+
+// TaskUpdatedEvent tarefa editada
+type TaskUpdatedEvent struct {
+	shared.BaseEvent
+	TaskID     uuid.UUID
+	TenantID   string
+	ContactID  *uuid.UUID
+	AssigneeID *uuid.UUID
+	UpdatedBy  uuid.UUID
+	Changes    []string // campos alterados (title, description, due_at, timezone, priority, recurrence, assignee_id)
+}
+
+func NewTaskUpdatedEvent(t *Task, updatedBy uuid.UUID, changes []string) TaskUpdatedEvent {
+	return TaskUpdatedEvent{
+		BaseEvent:  shared.NewBaseEvent("task.updated", time.Now()),
+		TaskID:     t.id,
+		TenantID:   t.tenantID,
+		ContactID:  t.contactID,
+		AssigneeID: t.assigneeID,
+		UpdatedBy:  updatedBy,
+		Changes:    changes,
+	}
+}
+
+// TaskCompletedEvent tarefa concluída. NextTaskID é a próxima ocorrência de uma recorrente
+type TaskCompletedEvent struct {
+	shared.BaseEvent
+	TaskID      uuid.UUID
+	TenantID    string
+	Title       string
+	ContactID   *uuid.UUID
+	SessionID   *uuid.UUID
+	AssigneeID  *uuid.UUID
+	DueAt       *time.Time
+	CompletedBy uuid.UUID
+	CompletedAt time.Time
+	NextTaskID  *uuid.UUID
+}
+
+func NewTaskCompletedEvent(t *Task, next *Task) TaskCompletedEvent {
+	event := TaskCompletedEvent{
+		BaseEvent:   shared.NewBaseEvent("task.completed", time.Now()),
+		TaskID:      t.id,
+		TenantID:    t.tenantID,
+		Title:       t.title,
+		ContactID:   t.contactID,
+		SessionID:   t.sessionID,
+		AssigneeID:  t.assigneeID,
+		DueAt:       t.dueAt,
+		CompletedBy: *t.completedBy,
+		CompletedAt: *t.completedAt,
+	}
+	if next != nil {
+		event.NextTaskID = &next.id
+	}
+	return event
+}
+
+// TaskCancelledEvent tarefa encerrada sem conclusão
+type TaskCancelledEvent struct {
+	shared.BaseEvent
+	TaskID      uuid.UUID
+	TenantID    string
+	ContactID   *uuid.UUID
+	CancelledBy uuid.UUID
+}
+
+func NewTaskCancelledEvent(t *Task, cancelledBy uuid.UUID) TaskCancelledEvent {
+	return TaskCancelledEvent{
+		BaseEvent:   shared.NewBaseEvent("task.cancelled", time.Now()),
+		TaskID:      t.id,
+		TenantID:    t.tenantID,
+		ContactID:   t.contactID,
+		CancelledBy: cancelledBy,
+	}
+}
+
+// TaskOverdueEvent o prazo de uma tarefa aberta passou
+type TaskOverdueEvent struct {
+	shared.BaseEvent
+	TaskID     uuid.UUID
+	TenantID   string
+	Title      string
+	Priority   Priority
+	AssigneeID *uuid.UUID
+	ContactID  *uuid.UUID
+	SessionID  *uuid.UUID
+	PipelineID *uuid.UUID
+	DueAt      time.Time
+	OverdueAt  time.Time
+}
+
+func NewTaskOverdueEvent(t *Task, at time.Time) TaskOverdueEvent {
+	return TaskOverdueEvent{
+		BaseEvent:  shared.NewBaseEvent("task.overdue", time.Now()),
+		TaskID:     t.id,
+		TenantID:   t.tenantID,
+		Title:      t.title,
+		Priority:   t.priority,
+		AssigneeID: t.assigneeID,
+		ContactID:  t.contactID,
+		SessionID:  t.sessionID,
+		PipelineID: t.pipelineID,
+		DueAt:      *t.dueAt,
+		OverdueAt:  at,
+	}
+}
+
+// TaskDeletedEvent tarefa removida
+type TaskDeletedEvent struct {
+	shared.BaseEvent
+	TaskID    uuid.UUID
+	TenantID  string
+	ContactID *uuid.UUID
+	DeletedBy uuid.UUID
+}
+
+func NewTaskDeletedEvent(t *Task, deletedBy uuid.UUID) TaskDeletedEvent {
+	return TaskDeletedEvent{
+		BaseEvent: shared.NewBaseEvent("task.deleted", time.Now()),
+		TaskID:    t.id,
+		TenantID:  t.tenantID,
+		ContactID: t.contactID,
+		DeletedBy: deletedBy,
+	}
+}
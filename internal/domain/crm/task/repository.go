@@ -0,0 +1,33 @@
+package task
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Filter escopo das listagens de tarefas (tarefas removidas nunca entram)
+type Filter struct {
+	TenantID   string
+	AssigneeID *uuid.UUID
+	ContactID  *uuid.UUID
+	SessionID  *uuid.UUID
+	PipelineID *uuid.UUID
+	Statuses   []Status   // vazio = todos
+	DueBefore  *time.Time // prazo < DueBefore (atrasadas: status open + DueBefore = agora)
+	DueAfter   *time.Time // prazo >= DueAfter
+	Limit      int
+	Offset     int
+}
+
+type Repository interface {
+	Save(ctx context.Context, task *Task) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Task, error)
+
+	// List retorna as tarefas do filtro por prazo (sem prazo por último) e o total
+	List(ctx context.Context, filter Filter) ([]*Task, int64, error)
+
+	// FindDue retorna tarefas abertas com prazo alcançado em now e atraso ainda não registrado
+	FindDue(ctx context.Context, now time.Time, limit int) ([]*Task, error)
+}
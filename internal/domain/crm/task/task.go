@@ -0,0 +1,512 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/shared"
+)
+
+var (
+	ErrTaskNotFound          = errors.New("task not found")
+	ErrInvalidTenant         = errors.New("tenantID cannot be empty")
+	ErrInvalidCreator        = errors.New("createdBy cannot be nil")
+	ErrEmptyTitle            = errors.New("title cannot be empty")
+	ErrInvalidPriority       = errors.New("invalid priority: use low, normal, high or urgent")
+	ErrInvalidTimezone       = errors.New("invalid timezone")
+	ErrInvalidRecurrence     = errors.New("invalid recurrence: use none, daily, weekly or monthly")
+	ErrRecurrenceWithoutDue  = errors.New("recurring task needs a due date")
+	ErrSessionWithoutContact = errors.New("session task needs the session contact")
+	ErrTaskClosed            = errors.New("task is already completed or cancelled")
+)
+
+type DomainEvent = shared.DomainEvent
+
+// Status situação de uma tarefa
+type Status string
+
+const (
+	StatusOpen      Status = "open"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Priority prioridade da tarefa (mesma escala das notas)
+type Priority string
+
+const (
+	PriorityLow    Priority = "low"
+	PriorityNormal Priority = "normal"
+	PriorityHigh   Priority = "high"
+	PriorityUrgent Priority = "urgent"
+)
+
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityNormal, PriorityHigh, PriorityUrgent:
+		return true
+	}
+	return false
+}
+
+// Recurrence repetição da tarefa: ao concluir, a próxima ocorrência é criada
+type Recurrence string
+
+const (
+	RecurrenceNone    Recurrence = "none"
+	RecurrenceDaily   Recurrence = "daily"
+	RecurrenceWeekly  Recurrence = "weekly"
+	RecurrenceMonthly Recurrence = "monthly"
+)
+
+func (r Recurrence) IsValid() bool {
+	switch r {
+	case RecurrenceNone, RecurrenceDaily, RecurrenceWeekly, RecurrenceMonthly:
+		return true
+	}
+	return false
+}
+
+// DefaultTimezone fuso usado quando a tarefa não informa um
+const DefaultTimezone = "UTC"
+
+// Details dados de criação de uma tarefa. A tarefa pode se relacionar a um contato,
+// a uma sessão (e seu contato) e/ou a um negócio (o contato em um pipeline).
+type Details struct {
+	Title       string
+	Description string
+	DueAt       *time.Time
+	Timezone    string // IANA (ex: America/Sao_Paulo); vazio = UTC
+	Priority    Priority
+	Recurrence  Recurrence
+	AssigneeID  *uuid.UUID
+	ContactID   *uuid.UUID
+	SessionID   *uuid.UUID
+	PipelineID  *uuid.UUID
+}
+
+// Changes campos editáveis; nil mantém o valor atual
+type Changes struct {
+	Title       *string
+	Description *string
+	DueAt       *time.Time
+	ClearDueAt  bool // remove o prazo (e a recorrência)
+	Timezone    *string
+	Priority    *Priority
+	Recurrence  *Recurrence
+	AssigneeID  *uuid.UUID
+	Unassign    bool
+}
+
+// Task tarefa de um agente. O prazo é um instante absoluto; o fuso define como ele é
+// exibido e como as ocorrências de uma tarefa recorrente avançam (09:00 continua 09:00
+// na troca de horário de verão). No prazo o responsável recebe o lembrete e a tarefa
+// aberta passa a atrasada (task.overdue).
+type Task struct {
+	id          uuid.UUID
+	tenantID    string
+	title       string
+	description string
+	dueAt       *time.Time
+	timezone    string
+	priority    Priority
+	status      Status
+	recurrence  Recurrence
+
+	assigneeID *uuid.UUID
+	contactID  *uuid.UUID
+	sessionID  *uuid.UUID
+	pipelineID *uuid.UUID
+
+	createdBy   uuid.UUID
+	overdueAt   *time.Time
+	completedAt *time.Time
+	completedBy *uuid.UUID
+
+	createdAt time.Time
+	updatedAt time.Time
+	deletedAt *time.Time
+
+	events []DomainEvent
+}
+
+func NewTask(tenantID string, createdBy uuid.UUID, details Details) (*Task, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenant
+	}
+	if createdBy == uuid.Nil {
+		return nil, ErrInvalidCreator
+	}
+	if details.SessionID != nil && details.ContactID == nil {
+		return nil, ErrSessionWithoutContact
+	}
+
+	now := time.Now()
+	t := &Task{
+		id:         uuid.New(),
+		tenantID:   tenantID,
+		status:     StatusOpen,
+		priority:   PriorityNormal,
+		recurrence: RecurrenceNone,
+		timezone:   DefaultTimezone,
+		assigneeID: details.AssigneeID,
+		contactID:  details.ContactID,
+		sessionID:  details.SessionID,
+		pipelineID: details.PipelineID,
+		createdBy:  createdBy,
+		createdAt:  now,
+		updatedAt:  now,
+		events:     []DomainEvent{},
+	}
+
+	_, err := t.apply(Changes{
+		Title:       &details.Title,
+		Description: &details.Description,
+		DueAt:       details.DueAt,
+		Timezone:    optional(details.Timezone),
+		Priority:    optional(details.Priority),
+		Recurrence:  optional(details.Recurrence),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	t.addEvent(NewTaskCreatedEvent(t))
+	return t, nil
+}
+
+func ReconstructTask(
+	id uuid.UUID,
+	tenantID string,
+	title string,
+	description string,
+	dueAt *time.Time,
+	timezone string,
+	priority Priority,
+	status Status,
+	recurrence Recurrence,
+	assigneeID *uuid.UUID,
+	contactID *uuid.UUID,
+	sessionID *uuid.UUID,
+	pipelineID *uuid.UUID,
+	createdBy uuid.UUID,
+	overdueAt *time.Time,
+	completedAt *time.Time,
+	completedBy *uuid.UUID,
+	createdAt time.Time,
+	updatedAt time.Time,
+	deletedAt *time.Time,
+) *Task {
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	if recurrence == "" {
+		recurrence = RecurrenceNone
+	}
+	return &Task{
+		id:          id,
+		tenantID:    tenantID,
+		title:       title,
+		description: description,
+		dueAt:       dueAt,
+		timezone:    timezone,
+		priority:    priority,
+		status:      status,
+		recurrence:  recurrence,
+		assigneeID:  assigneeID,
+		contactID:   contactID,
+		sessionID:   sessionID,
+		pipelineID:  pipelineID,
+		createdBy:   createdBy,
+		overdueAt:   overdueAt,
+		completedAt: completedAt,
+		completedBy: completedBy,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+		deletedAt:   deletedAt,
+		events:      []DomainEvent{},
+	}
+}
+
+// Update aplica as alterações e emite task.updated com os campos alterados.
+// Retorna os campos alterados (vazio = nada mudou).
+func (t *Task) Update(updatedBy uuid.UUID, changes Changes) ([]string, error) {
+	if t.status != StatusOpen {
+		return nil, ErrTaskClosed
+	}
+	changed, err := t.apply(changes)
+	if err != nil || len(changed) == 0 {
+		return nil, err
+	}
+	t.updatedAt = time.Now()
+	t.addEvent(NewTaskUpdatedEvent(t, updatedBy, changed))
+	return changed, nil
+}
+
+// apply valida tudo antes de alterar qualquer campo
+func (t *Task) apply(c Changes) ([]string, error) {
+	title := t.title
+	if c.Title != nil {
+		title = strings.TrimSpace(*c.Title)
+		if title == "" {
+			return nil, ErrEmptyTitle
+		}
+	}
+	if c.Priority != nil && !c.Priority.IsValid() {
+		return nil, ErrInvalidPriority
+	}
+	if c.Recurrence != nil && !c.Recurrence.IsValid() {
+		return nil, ErrInvalidRecurrence
+	}
+	if c.Timezone != nil {
+		if _, err := time.LoadLocation(*c.Timezone); err != nil || *c.Timezone == "" {
+			return nil, ErrInvalidTimezone
+		}
+	}
+
+	dueAt := t.dueAt
+	if c.ClearDueAt {
+		dueAt = nil
+	} else if c.DueAt != nil {
+		due := c.DueAt.UTC()
+		dueAt = &due
+	}
+	recurrence := t.recurrence
+	if c.Recurrence != nil {
+		recurrence = *c.Recurrence
+	}
+	if c.ClearDueAt && c.Recurrence == nil {
+		recurrence = RecurrenceNone
+	}
+	if dueAt == nil && recurrence != RecurrenceNone {
+		return nil, ErrRecurrenceWithoutDue
+	}
+
+	var changed []string
+	if title != t.title {
+		t.title = title
+		changed = append(changed, "title")
+	}
+	if c.Description != nil && strings.TrimSpace(*c.Description) != t.description {
+		t.description = strings.TrimSpace(*c.Description)
+		changed = append(changed, "description")
+	}
+	if !sameTime(dueAt, t.dueAt) {
+		t.dueAt = dueAt
+		t.overdueAt = nil // novo prazo: lembrete e atraso valem de novo
+		changed = append(changed, "due_at")
+	}
+	if c.Timezone != nil && *c.Timezone != t.timezone {
+		t.timezone = *c.Timezone
+		changed = append(changed, "timezone")
+	}
+	if c.Priority != nil && *c.Priority != t.priority {
+		t.priority = *c.Priority
+		changed = append(changed, "priority")
+	}
+	if recurrence != t.recurrence {
+		t.recurrence = recurrence
+		changed = append(changed, "recurrence")
+	}
+	if c.Unassign && t.assigneeID != nil {
+		t.assigneeID = nil
+		changed = append(changed, "assignee_id")
+	} else if c.AssigneeID != nil && (t.assigneeID == nil || *t.assigneeID != *c.AssigneeID) {
+		assignee := *c.AssigneeID
+		t.assigneeID = &assignee
+		changed = append(changed, "assignee_id")
+	}
+	return changed, nil
+}
+
+// Complete conclui a tarefa. Se ela se repete, retorna a próxima ocorrência
+// (já com task.created pendente), com prazo no primeiro horário após now.
+func (t *Task) Complete(completedBy uuid.UUID, now time.Time) (*Task, error) {
+	if t.status != StatusOpen {
+		return nil, ErrTaskClosed
+	}
+	t.status = StatusCompleted
+	t.completedAt = &now
+	t.completedBy = &completedBy
+	t.updatedAt = now
+
+	var next *Task
+	if t.recurrence != RecurrenceNone && t.dueAt != nil {
+		next = t.nextOccurrence(now)
+	}
+	t.addEvent(NewTaskCompletedEvent(t, next))
+	return next, nil
+}
+
+func (t *Task) nextOccurrence(now time.Time) *Task {
+	due := NextDue(*t.dueAt, t.timezone, t.recurrence)
+	for !due.After(now) {
+		due = NextDue(due, t.timezone, t.recurrence)
+	}
+
+	next := &Task{
+		id:          uuid.New(),
+		tenantID:    t.tenantID,
+		title:       t.title,
+		description: t.description,
+		dueAt:       &due,
+		timezone:    t.timezone,
+		priority:    t.priority,
+		status:      StatusOpen,
+		recurrence:  t.recurrence,
+		assigneeID:  t.assigneeID,
+		contactID:   t.contactID,
+		sessionID:   t.sessionID,
+		pipelineID:  t.pipelineID,
+		createdBy:   t.createdBy,
+		createdAt:   now,
+		updatedAt:   now,
+		events:      []DomainEvent{},
+	}
+	next.addEvent(NewTaskCreatedEvent(next))
+	return next
+}
+
+// Cancel encerra a tarefa sem concluí-la
+func (t *Task) Cancel(cancelledBy uuid.UUID) error {
+	if t.status != StatusOpen {
+		return ErrTaskClosed
+	}
+	t.status = StatusCancelled
+	t.updatedAt = time.Now()
+	t.addEvent(NewTaskCancelledEvent(t, cancelledBy))
+	return nil
+}
+
+// MarkOverdue registra o atraso e emite task.overdue quando o prazo de uma tarefa
+// aberta passou. Idempotente: retorna false se nada mudou.
+func (t *Task) MarkOverdue(now time.Time) bool {
+	if t.status != StatusOpen || t.dueAt == nil || t.overdueAt != nil || t.deletedAt != nil || now.Before(*t.dueAt) {
+		return false
+	}
+	t.overdueAt = &now
+	t.addEvent(NewTaskOverdueEvent(t, now))
+	return true
+}
+
+// Delete remove a tarefa (soft delete)
+func (t *Task) Delete(deletedBy uuid.UUID) {
+	if t.deletedAt != nil {
+		return
+	}
+	now := time.Now()
+	t.deletedAt = &now
+	t.updatedAt = now
+	t.addEvent(NewTaskDeletedEvent(t, deletedBy))
+}
+
+// IsOverdue tarefa aberta com prazo vencido em now
+func (t *Task) IsOverdue(now time.Time) bool {
+	return t.status == StatusOpen && t.dueAt != nil && !now.Before(*t.dueAt)
+}
+
+// Location fuso da tarefa (UTC se inválido)
+func (t *Task) Location() *time.Location {
+	loc, err := time.LoadLocation(t.timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func (t *Task) ID() uuid.UUID               { return t.id }
+func (t *Task) TenantID() string            { return t.tenantID }
+func (t *Task) Title() string               { return t.title }
+func (t *Task) Description() string         { return t.description }
+func (t *Task) DueAt() *time.Time           { return t.dueAt }
+func (t *Task) Timezone() string            { return t.timezone }
+func (t *Task) Priority() Priority          { return t.priority }
+func (t *Task) Status() Status              { return t.status }
+func (t *Task) Recurrence() Recurrence      { return t.recurrence }
+func (t *Task) AssigneeID() *uuid.UUID      { return t.assigneeID }
+func (t *Task) ContactID() *uuid.UUID       { return t.contactID }
+func (t *Task) SessionID() *uuid.UUID       { return t.sessionID }
+func (t *Task) PipelineID() *uuid.UUID      { return t.pipelineID }
+func (t *Task) CreatedBy() uuid.UUID        { return t.createdBy }
+func (t *Task) OverdueAt() *time.Time       { return t.overdueAt }
+func (t *Task) CompletedAt() *time.Time     { return t.completedAt }
+func (t *Task) CompletedBy() *uuid.UUID     { return t.completedBy }
+func (t *Task) CreatedAt() time.Time        { return t.createdAt }
+func (t *Task) UpdatedAt() time.Time        { return t.updatedAt }
+func (t *Task) DeletedAt() *time.Time       { return t.deletedAt }
+func (t *Task) IsDeleted() bool             { return t.deletedAt != nil }
+func (t *Task) IsOpen() bool                { return t.status == StatusOpen }
+func (t *Task) DomainEvents() []DomainEvent { return append([]DomainEvent{}, t.events...) }
+
+func (t *Task) ClearEvents() {
+	t.events = []DomainEvent{}
+}
+
+func (t *Task) addEvent(event DomainEvent) {
+	t.events = append(t.events, event)
+}
+
+// NextDue próxima ocorrência após due, contada no relógio local do fuso.
+// Na recorrência mensal o dia é limitado ao fim do mês (31/01 -> 28/02).
+func NextDue(due time.Time, timezone string, recurrence Recurrence) time.Time {
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	local := due.In(loc)
+	y, m, d := local.Date()
+	h, mi, s := local.Clock()
+
+	switch recurrence {
+	case RecurrenceDaily:
+		d++
+	case RecurrenceWeekly:
+		d += 7
+	case RecurrenceMonthly:
+		m++
+		if last := time.Date(y, m+1, 0, 0, 0, 0, 0, loc).Day(); d > last {
+			d = last
+		}
+	default:
+		return due
+	}
+	return time.Date(y, m, d, h, mi, s, local.Nanosecond(), loc).UTC()
+}
+
+// ParseDue interpreta o prazo: RFC3339 com offset ou horário local sem offset
+// ("2006-01-02T15:04[:05]") no fuso informado.
+func ParseDue(value, timezone string) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at.UTC(), nil
+	}
+	if timezone == "" {
+		timezone = DefaultTimezone
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Time{}, ErrInvalidTimezone
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04"} {
+		if at, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return at.UTC(), nil
+		}
+	}
+	return time.Time{}, errors.New("invalid due date: use RFC3339 or local YYYY-MM-DDTHH:MM")
+}
+
+func optional[T comparable](v T) *T {
+	var zero T
+	if v == zero {
+		return nil
+	}
+	return &v
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.Equal(*b)
+}
@@ -0,0 +1,215 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTask(t *testing.T, details Details) *Task {
+	t.Helper()
+	if details.Title == "" {
+		details.Title = "Ligar para o cliente"
+	}
+	task, err := NewTask("tenant-1", uuid.New(), details)
+	require.NoError(t, err)
+	return task
+}
+
+func TestNewTask(t *testing.T) {
+	contactID := uuid.New()
+	due := time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC)
+
+	task := newTestTask(t, Details{
+		Title:     "  Enviar proposta  ",
+		DueAt:     &due,
+		Timezone:  "America/Sao_Paulo",
+		ContactID: &contactID,
+	})
+
+	assert.Equal(t, "Enviar proposta", task.Title())
+	assert.Equal(t, StatusOpen, task.Status())
+	assert.Equal(t, PriorityNormal, task.Priority())
+	assert.Equal(t, RecurrenceNone, task.Recurrence())
+	assert.Equal(t, "America/Sao_Paulo", task.Timezone())
+	require.Len(t, task.DomainEvents(), 1)
+	assert.Equal(t, "task.created", task.DomainEvents()[0].EventName())
+}
+
+func TestNewTask_Validation(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	sessionID := uuid.New()
+
+	tests := []struct {
+		name    string
+		details Details
+		err     error
+	}{
+		{"empty title", Details{Title: "  "}, ErrEmptyTitle},
+		{"invalid priority", Details{Title: "x", Priority: "critical"}, ErrInvalidPriority},
+		{"invalid timezone", Details{Title: "x", Timezone: "Mars/Olympus"}, ErrInvalidTimezone},
+		{"invalid recurrence", Details{Title: "x", DueAt: &due, Recurrence: "yearly"}, ErrInvalidRecurrence},
+		{"recurrence without due", Details{Title: "x", Recurrence: RecurrenceDaily}, ErrRecurrenceWithoutDue},
+		{"session without contact", Details{Title: "x", SessionID: &sessionID}, ErrSessionWithoutContact},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTask("tenant-1", uuid.New(), tt.details)
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+
+	_, err := NewTask("", uuid.New(), Details{Title: "x"})
+	assert.ErrorIs(t, err, ErrInvalidTenant)
+	_, err = NewTask("tenant-1", uuid.Nil, Details{Title: "x"})
+	assert.ErrorIs(t, err, ErrInvalidCreator)
+}
+
+func TestTask_Update(t *testing.T) {
+	due := time.Now().Add(-time.Hour)
+	task := newTestTask(t, Details{DueAt: &due})
+	require.True(t, task.MarkOverdue(time.Now()))
+	task.ClearEvents()
+
+	newDue := time.Now().Add(24 * time.Hour)
+	high := PriorityHigh
+	agentID := uuid.New()
+	changed, err := task.Update(uuid.New(), Changes{
+		DueAt:      &newDue,
+		Priority:   &high,
+		AssigneeID: &agentID,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"due_at", "priority", "assignee_id"}, changed)
+	assert.Nil(t, task.OverdueAt(), "new due date rearms the reminder")
+	require.Len(t, task.DomainEvents(), 1)
+	assert.Equal(t, "task.updated", task.DomainEvents()[0].EventName())
+
+	changed, err = task.Update(uuid.New(), Changes{Priority: &high})
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+	assert.Len(t, task.DomainEvents(), 1)
+
+	// Remover o prazo encerra a recorrência
+	daily := RecurrenceDaily
+	_, err = task.Update(uuid.New(), Changes{Recurrence: &daily})
+	require.NoError(t, err)
+	_, err = task.Update(uuid.New(), Changes{ClearDueAt: true})
+	require.NoError(t, err)
+	assert.Nil(t, task.DueAt())
+	assert.Equal(t, RecurrenceNone, task.Recurrence())
+
+	_, err = task.Update(uuid.New(), Changes{Recurrence: &daily})
+	assert.ErrorIs(t, err, ErrRecurrenceWithoutDue)
+}
+
+func TestTask_Complete(t *testing.T) {
+	task := newTestTask(t, Details{})
+	task.ClearEvents()
+	userID := uuid.New()
+
+	next, err := task.Complete(userID, time.Now())
+
+	require.NoError(t, err)
+	assert.Nil(t, next)
+	assert.Equal(t, StatusCompleted, task.Status())
+	assert.Equal(t, userID, *task.CompletedBy())
+	require.Len(t, task.DomainEvents(), 1)
+	assert.Equal(t, "task.completed", task.DomainEvents()[0].EventName())
+
+	_, err = task.Complete(userID, time.Now())
+	assert.ErrorIs(t, err, ErrTaskClosed)
+	assert.ErrorIs(t, task.Cancel(userID), ErrTaskClosed)
+	_, err = task.Update(userID, Changes{})
+	assert.ErrorIs(t, err, ErrTaskClosed)
+}
+
+func TestTask_Complete_Recurring(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	// Diária às 09:00 em Nova York, concluída com três dias de atraso
+	due := time.Date(2026, 10, 30, 9, 0, 0, 0, loc)
+	contactID := uuid.New()
+	task := newTestTask(t, Details{DueAt: &due, Timezone: "America/New_York", Recurrence: RecurrenceDaily, ContactID: &contactID})
+	now := time.Date(2026, 11, 2, 10, 0, 0, 0, loc)
+
+	next, err := task.Complete(uuid.New(), now)
+
+	require.NoError(t, err)
+	require.NotNil(t, next)
+	assert.NotEqual(t, task.ID(), next.ID())
+	assert.Equal(t, StatusOpen, next.Status())
+	assert.Equal(t, contactID, *next.ContactID())
+	// 03/11 09:00 local, já depois do fim do horário de verão (UTC-5)
+	assert.Equal(t, time.Date(2026, 11, 3, 14, 0, 0, 0, time.UTC), *next.DueAt())
+	require.Len(t, next.DomainEvents(), 1)
+	assert.Equal(t, "task.created", next.DomainEvents()[0].EventName())
+
+	completed := task.DomainEvents()[len(task.DomainEvents())-1].(TaskCompletedEvent)
+	assert.Equal(t, next.ID(), *completed.NextTaskID)
+}
+
+func TestTask_MarkOverdue(t *testing.T) {
+	due := time.Now().Add(time.Hour)
+	task := newTestTask(t, Details{DueAt: &due})
+	task.ClearEvents()
+
+	assert.False(t, task.MarkOverdue(time.Now()), "not due yet")
+	assert.False(t, task.IsOverdue(time.Now()))
+
+	later := due.Add(time.Minute)
+	assert.True(t, task.IsOverdue(later))
+	assert.True(t, task.MarkOverdue(later))
+	assert.False(t, task.MarkOverdue(later.Add(time.Minute)), "idempotent")
+	require.Len(t, task.DomainEvents(), 1)
+	assert.Equal(t, "task.overdue", task.DomainEvents()[0].EventName())
+
+	withoutDue := newTestTask(t, Details{})
+	assert.False(t, withoutDue.MarkOverdue(time.Now()))
+
+	cancelled := newTestTask(t, Details{DueAt: &due})
+	require.NoError(t, cancelled.Cancel(uuid.New()))
+	assert.False(t, cancelled.MarkOverdue(later))
+}
+
+func TestTask_Delete(t *testing.T) {
+	task := newTestTask(t, Details{})
+	task.ClearEvents()
+
+	task.Delete(uuid.New())
+	task.Delete(uuid.New())
+
+	assert.True(t, task.IsDeleted())
+	require.Len(t, task.DomainEvents(), 1)
+	assert.Equal(t, "task.deleted", task.DomainEvents()[0].EventName())
+}
+
+func TestNextDue(t *testing.T) {
+	jan31 := time.Date(2026, 1, 31, 15, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, time.Date(2026, 2, 1, 15, 0, 0, 0, time.UTC), NextDue(jan31, "UTC", RecurrenceDaily))
+	assert.Equal(t, time.Date(2026, 2, 7, 15, 0, 0, 0, time.UTC), NextDue(jan31, "UTC", RecurrenceWeekly))
+	assert.Equal(t, time.Date(2026, 2, 28, 15, 0, 0, 0, time.UTC), NextDue(jan31, "UTC", RecurrenceMonthly))
+	assert.Equal(t, jan31, NextDue(jan31, "UTC", RecurrenceNone))
+}
+
+func TestParseDue(t *testing.T) {
+	at, err := ParseDue("2026-10-20T09:00:00-03:00", "Asia/Tokyo")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC), at)
+
+	at, err = ParseDue("2026-10-20T09:00", "America/Sao_Paulo")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 20, 12, 0, 0, 0, time.UTC), at)
+
+	_, err = ParseDue("amanhã", "UTC")
+	assert.Error(t, err)
+
+	_, err = ParseDue("2026-10-20T09:00", "Mars/Olympus")
+	assert.ErrorIs(t, err, ErrInvalidTimezone)
+}
@@ -0,0 +1,191 @@
+package team
+
+import (
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+// ItemStatus situação de uma sessão na fila
+type ItemStatus string
+
+const (
+	// ItemWaiting - Sessão aguardando um agente
+	ItemWaiting ItemStatus = "waiting"
+
+	// ItemAssigned - Sessão atribuída a um agente (puxada, distribuída ou atribuída por fora da fila)
+	ItemAssigned ItemStatus = "assigned"
+
+	// ItemAbandoned - Sessão encerrada antes de ser atendida
+	ItemAbandoned ItemStatus = "abandoned"
+)
+
+// QueueItem passagem de uma sessão por uma fila. Uma sessão tem no máximo um item aguardando.
+type QueueItem struct {
+	id         uuid.UUID
+	tenantID   string
+	projectID  uuid.UUID
+	queueID    uuid.UUID
+	sessionID  uuid.UUID
+	contactID  uuid.UUID
+	priority   session.Priority
+	status     ItemStatus
+	agentID    *uuid.UUID
+	enqueuedAt time.Time
+	resolvedAt *time.Time
+}
+
+// NewQueueItem coloca a sessão para aguardar na fila
+func NewQueueItem(q *Queue, sess *session.Session, at time.Time) *QueueItem {
+	priority := sess.Priority()
+	if !priority.IsValid() {
+		priority = session.PriorityNormal
+	}
+	return &QueueItem{
+		id:         uuid.New(),
+		tenantID:   q.TenantID(),
+		projectID:  q.ProjectID(),
+		queueID:    q.ID(),
+		sessionID:  sess.ID(),
+		contactID:  sess.ContactID(),
+		priority:   priority,
+		status:     ItemWaiting,
+		enqueuedAt: at,
+	}
+}
+
+func ReconstructQueueItem(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	queueID uuid.UUID,
+	sessionID uuid.UUID,
+	contactID uuid.UUID,
+	priority session.Priority,
+	status ItemStatus,
+	agentID *uuid.UUID,
+	enqueuedAt time.Time,
+	resolvedAt *time.Time,
+) *QueueItem {
+	return &QueueItem{
+		id:         id,
+		tenantID:   tenantID,
+		projectID:  projectID,
+		queueID:    queueID,
+		sessionID:  sessionID,
+		contactID:  contactID,
+		priority:   priority,
+		status:     status,
+		agentID:    agentID,
+		enqueuedAt: enqueuedAt,
+		resolvedAt: resolvedAt,
+	}
+}
+
+// MoveTo transfere a sessão em espera para outra fila, preservando o tempo de espera do contato
+func (i *QueueItem) MoveTo(q *Queue, priority session.Priority) error {
+	if i.status != ItemWaiting {
+		return ErrItemNotWaiting
+	}
+	i.queueID = q.ID()
+	if priority.IsValid() {
+		i.priority = priority
+	}
+	return nil
+}
+
+// Assign encerra a espera com a sessão atribuída ao agente
+func (i *QueueItem) Assign(agentID uuid.UUID, at time.Time) error {
+	if agentID == uuid.Nil {
+		return ErrInvalidAgent
+	}
+	if i.status != ItemWaiting {
+		return ErrItemNotWaiting
+	}
+	i.status = ItemAssigned
+	i.agentID = &agentID
+	i.resolvedAt = &at
+	return nil
+}
+
+// Abandon encerra a espera porque a sessão terminou sem atendimento
+func (i *QueueItem) Abandon(at time.Time) error {
+	if i.status != ItemWaiting {
+		return ErrItemNotWaiting
+	}
+	i.status = ItemAbandoned
+	i.resolvedAt = &at
+	return nil
+}
+
+// WaitTime tempo de espera até o atendimento/abandono ou, se ainda aguardando, até now
+func (i *QueueItem) WaitTime(now time.Time) time.Duration {
+	end := now
+	if i.resolvedAt != nil {
+		end = *i.resolvedAt
+	}
+	if end.Before(i.enqueuedAt) {
+		return 0
+	}
+	return end.Sub(i.enqueuedAt)
+}
+
+func (i *QueueItem) IsWaiting() bool { return i.status == ItemWaiting }
+
+func (i *QueueItem) ID() uuid.UUID              { return i.id }
+func (i *QueueItem) TenantID() string           { return i.tenantID }
+func (i *QueueItem) ProjectID() uuid.UUID       { return i.projectID }
+func (i *QueueItem) QueueID() uuid.UUID         { return i.queueID }
+func (i *QueueItem) SessionID() uuid.UUID       { return i.sessionID }
+func (i *QueueItem) ContactID() uuid.UUID       { return i.contactID }
+func (i *QueueItem) Priority() session.Priority { return i.priority }
+func (i *QueueItem) Status() ItemStatus         { return i.status }
+func (i *QueueItem) AgentID() *uuid.UUID        { return i.agentID }
+func (i *QueueItem) EnqueuedAt() time.Time      { return i.enqueuedAt }
+func (i *QueueItem) ResolvedAt() *time.Time     { return i.resolvedAt }
+
+// PriorityRank peso da prioridade da sessão na ordem da fila (maior = atendida antes)
+func PriorityRank(p session.Priority) int {
+	switch p {
+	case session.PriorityUrgent:
+		return 3
+	case session.PriorityHigh:
+		return 2
+	case session.PriorityLow:
+		return 0
+	default:
+		return 1
+	}
+}
+
+// SortWaiting ordena itens de uma fila na ordem de atendimento: prioridade da sessão e, depois, quem espera há mais tempo
+func SortWaiting(items []*QueueItem) {
+	sort.SliceStable(items, func(a, b int) bool {
+		ra, rb := PriorityRank(items[a].priority), PriorityRank(items[b].priority)
+		if ra != rb {
+			return ra > rb
+		}
+		return items[a].enqueuedAt.Before(items[b].enqueuedAt)
+	})
+}
+
+// Metrics indicadores de uma fila: espera atual e desfecho das esperas encerradas na janela
+type Metrics struct {
+	QueueID     uuid.UUID
+	Waiting     int
+	LongestWait time.Duration
+	Assigned    int
+	Abandoned   int
+	AvgWait     time.Duration // espera média das sessões atendidas na janela
+}
+
+// AbandonmentRate percentual (0-100) de esperas encerradas por abandono. Sem esperas encerradas = 0
+func (m Metrics) AbandonmentRate() float64 {
+	total := m.Assigned + m.Abandoned
+	if total == 0 {
+		return 0
+	}
+	return float64(m.Abandoned) * 100 / float64(total)
+}
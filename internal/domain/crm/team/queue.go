@@ -0,0 +1,183 @@
+package team
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/project"
+)
+
+// Queue fila de atendimento de um time. Sessões aguardam nela até um membro puxá-las
+// (estratégia manual) ou até serem distribuídas automaticamente entre os membros
+// (round_robin / least_sessions). Filas de prioridade maior são atendidas primeiro.
+type Queue struct {
+	id        uuid.UUID
+	tenantID  string
+	projectID uuid.UUID
+	teamID    uuid.UUID
+	name      string
+	strategy  project.AssignmentStrategy
+	priority  int
+	enabled   bool
+
+	// lastAssignedAgentID cursor do round-robin da fila
+	lastAssignedAgentID *uuid.UUID
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewQueue(tenantID string, projectID, teamID uuid.UUID, name string, strategy project.AssignmentStrategy) (*Queue, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenant
+	}
+	if projectID == uuid.Nil {
+		return nil, ErrInvalidProject
+	}
+	if teamID == uuid.Nil {
+		return nil, ErrInvalidTeam
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+	if !validStrategy(strategy) {
+		return nil, ErrInvalidStrategy
+	}
+
+	now := time.Now()
+	return &Queue{
+		id:        uuid.New(),
+		tenantID:  tenantID,
+		projectID: projectID,
+		teamID:    teamID,
+		name:      name,
+		strategy:  strategy,
+		enabled:   true,
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructQueue(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	teamID uuid.UUID,
+	name string,
+	strategy project.AssignmentStrategy,
+	priority int,
+	enabled bool,
+	lastAssignedAgentID *uuid.UUID,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Queue {
+	return &Queue{
+		id:                  id,
+		tenantID:            tenantID,
+		projectID:           projectID,
+		teamID:              teamID,
+		name:                name,
+		strategy:            strategy,
+		priority:            priority,
+		enabled:             enabled,
+		lastAssignedAgentID: lastAssignedAgentID,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+	}
+}
+
+func validStrategy(strategy project.AssignmentStrategy) bool {
+	switch strategy {
+	case project.StrategyManual, project.StrategyRoundRobin, project.StrategyLeastSessions:
+		return true
+	default:
+		return false
+	}
+}
+
+func (q *Queue) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	q.name = name
+	q.updatedAt = time.Now()
+	return nil
+}
+
+// MoveToTeam passa a fila para outro time
+func (q *Queue) MoveToTeam(teamID uuid.UUID) error {
+	if teamID == uuid.Nil {
+		return ErrInvalidTeam
+	}
+	if q.teamID != teamID {
+		q.teamID = teamID
+		q.lastAssignedAgentID = nil
+		q.updatedAt = time.Now()
+	}
+	return nil
+}
+
+func (q *Queue) SetStrategy(strategy project.AssignmentStrategy) error {
+	if !validStrategy(strategy) {
+		return ErrInvalidStrategy
+	}
+	q.strategy = strategy
+	q.updatedAt = time.Now()
+	return nil
+}
+
+// SetPriority define a ordem de atendimento entre filas (maior = atendida antes)
+func (q *Queue) SetPriority(priority int) {
+	q.priority = priority
+	q.updatedAt = time.Now()
+}
+
+func (q *Queue) Enable() {
+	q.enabled = true
+	q.updatedAt = time.Now()
+}
+
+func (q *Queue) Disable() {
+	q.enabled = false
+	q.updatedAt = time.Now()
+}
+
+// IsAutomatic indica se a fila distribui as sessões sozinha
+func (q *Queue) IsAutomatic() bool {
+	return q.strategy == project.StrategyRoundRobin || q.strategy == project.StrategyLeastSessions
+}
+
+// AssignmentConfig monta a configuração de roteamento da fila: membros do time e estratégia
+// da fila, herdando do projeto capacidade padrão, idioma, skills e filtros de tipo de agente.
+func (q *Queue) AssignmentConfig(t *Team, base *project.AgentAssignmentConfig) *project.AgentAssignmentConfig {
+	config := *project.NewAgentAssignmentConfig()
+	if base != nil {
+		config = *base
+	}
+	config.Enabled = true
+	config.AgentIDs = t.MemberIDs()
+	config.Strategy = q.strategy
+	config.ReassignmentRules = nil
+	return &config
+}
+
+// RecordAssignment avança o cursor do round-robin
+func (q *Queue) RecordAssignment(agentID uuid.UUID) {
+	q.lastAssignedAgentID = &agentID
+	q.updatedAt = time.Now()
+}
+
+func (q *Queue) ID() uuid.UUID                        { return q.id }
+func (q *Queue) TenantID() string                     { return q.tenantID }
+func (q *Queue) ProjectID() uuid.UUID                 { return q.projectID }
+func (q *Queue) TeamID() uuid.UUID                    { return q.teamID }
+func (q *Queue) Name() string                         { return q.name }
+func (q *Queue) Strategy() project.AssignmentStrategy { return q.strategy }
+func (q *Queue) Priority() int                        { return q.priority }
+func (q *Queue) IsEnabled() bool                      { return q.enabled }
+func (q *Queue) LastAssignedAgentID() *uuid.UUID      { return q.lastAssignedAgentID }
+func (q *Queue) CreatedAt() time.Time                 { return q.createdAt }
+func (q *Queue) UpdatedAt() time.Time                 { return q.updatedAt }
@@ -0,0 +1,56 @@
+package team
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TeamRepository interface {
+	Save(ctx context.Context, team *Team) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Team, error)
+
+	// FindByProject retorna os times do projeto, em ordem alfabética
+	FindByProject(ctx context.Context, projectID uuid.UUID) ([]*Team, error)
+
+	// FindByMember retorna os times dos quais o agente é membro
+	FindByMember(ctx context.Context, tenantID string, agentID uuid.UUID) ([]*Team, error)
+
+	// FindBySupervisor retorna os times supervisionados pelo agente
+	FindBySupervisor(ctx context.Context, tenantID string, agentID uuid.UUID) ([]*Team, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type QueueRepository interface {
+	Save(ctx context.Context, queue *Queue) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Queue, error)
+
+	// FindByProject retorna as filas do projeto, maior prioridade primeiro
+	FindByProject(ctx context.Context, projectID uuid.UUID) ([]*Queue, error)
+
+	// FindByTeams retorna as filas dos times informados, maior prioridade primeiro
+	FindByTeams(ctx context.Context, teamIDs []uuid.UUID) ([]*Queue, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type QueueItemRepository interface {
+	Save(ctx context.Context, item *QueueItem) error
+
+	// FindWaitingBySession retorna o item em espera da sessão (ErrQueueItemNotFound se não houver)
+	FindWaitingBySession(ctx context.Context, sessionID uuid.UUID) (*QueueItem, error)
+
+	// FindWaiting retorna os itens em espera das filas, na ordem de atendimento de cada fila
+	FindWaiting(ctx context.Context, queueIDs []uuid.UUID, limit int) ([]*QueueItem, error)
+
+	// ClaimNext bloqueia, até o fim da transação corrente, o próximo item a ser atendido entre as filas
+	// habilitadas informadas: fila de maior prioridade, depois prioridade da sessão, depois o mais antigo.
+	// Itens bloqueados por outra transação são pulados. Retorna ErrQueueItemNotFound se não houver.
+	ClaimNext(ctx context.Context, queueIDs []uuid.UUID) (*QueueItem, error)
+
+	// FindDistributable retorna itens em espera de filas automáticas habilitadas, mais antigos primeiro
+	FindDistributable(ctx context.Context, limit int) ([]*QueueItem, error)
+
+	// Metrics calcula os indicadores das filas; assigned/abandoned consideram esperas encerradas desde since
+	Metrics(ctx context.Context, queueIDs []uuid.UUID, since time.Time, now time.Time) (map[uuid.UUID]Metrics, error)
+}
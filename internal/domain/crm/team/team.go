@@ -0,0 +1,180 @@
+package team
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrTeamNotFound      = errors.New("team not found")
+	ErrQueueNotFound     = errors.New("queue not found")
+	ErrQueueItemNotFound = errors.New("queue item not found")
+	ErrInvalidTenant     = errors.New("tenantID cannot be empty")
+	ErrInvalidProject    = errors.New("projectID cannot be nil")
+	ErrInvalidTeam       = errors.New("teamID cannot be nil")
+	ErrInvalidAgent      = errors.New("agentID cannot be nil")
+	ErrEmptyName         = errors.New("name cannot be empty")
+	ErrInvalidStrategy   = errors.New("invalid strategy: use manual, round_robin or least_sessions")
+	ErrItemNotWaiting    = errors.New("queue item is not waiting")
+)
+
+// Team grupo de agentes de um projeto, com um supervisor opcional.
+// Os membros atendem as filas do time; o supervisor acompanha as filas ao vivo.
+type Team struct {
+	id           uuid.UUID
+	tenantID     string
+	projectID    uuid.UUID
+	name         string
+	description  string
+	supervisorID *uuid.UUID
+	memberIDs    []uuid.UUID
+
+	createdAt time.Time
+	updatedAt time.Time
+}
+
+func NewTeam(tenantID string, projectID uuid.UUID, name string) (*Team, error) {
+	if tenantID == "" {
+		return nil, ErrInvalidTenant
+	}
+	if projectID == uuid.Nil {
+		return nil, ErrInvalidProject
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, ErrEmptyName
+	}
+
+	now := time.Now()
+	return &Team{
+		id:        uuid.New(),
+		tenantID:  tenantID,
+		projectID: projectID,
+		name:      name,
+		memberIDs: []uuid.UUID{},
+		createdAt: now,
+		updatedAt: now,
+	}, nil
+}
+
+func ReconstructTeam(
+	id uuid.UUID,
+	tenantID string,
+	projectID uuid.UUID,
+	name string,
+	description string,
+	supervisorID *uuid.UUID,
+	memberIDs []uuid.UUID,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *Team {
+	if memberIDs == nil {
+		memberIDs = []uuid.UUID{}
+	}
+	return &Team{
+		id:           id,
+		tenantID:     tenantID,
+		projectID:    projectID,
+		name:         name,
+		description:  description,
+		supervisorID: supervisorID,
+		memberIDs:    memberIDs,
+		createdAt:    createdAt,
+		updatedAt:    updatedAt,
+	}
+}
+
+func (t *Team) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrEmptyName
+	}
+	t.name = name
+	t.updatedAt = time.Now()
+	return nil
+}
+
+func (t *Team) SetDescription(description string) {
+	t.description = strings.TrimSpace(description)
+	t.updatedAt = time.Now()
+}
+
+// SetSupervisor define o supervisor do time (nil remove). O supervisor não precisa ser membro.
+func (t *Team) SetSupervisor(agentID *uuid.UUID) error {
+	if agentID != nil && *agentID == uuid.Nil {
+		return ErrInvalidAgent
+	}
+	t.supervisorID = agentID
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// AddMember inclui o agente no time (idempotente)
+func (t *Team) AddMember(agentID uuid.UUID) error {
+	if agentID == uuid.Nil {
+		return ErrInvalidAgent
+	}
+	if t.HasMember(agentID) {
+		return nil
+	}
+	t.memberIDs = append(t.memberIDs, agentID)
+	t.updatedAt = time.Now()
+	return nil
+}
+
+// RemoveMember tira o agente do time (idempotente)
+func (t *Team) RemoveMember(agentID uuid.UUID) {
+	for i, id := range t.memberIDs {
+		if id == agentID {
+			t.memberIDs = append(t.memberIDs[:i], t.memberIDs[i+1:]...)
+			t.updatedAt = time.Now()
+			return
+		}
+	}
+}
+
+// SetMembers substitui os membros, mantendo a ordem informada e ignorando repetidos
+func (t *Team) SetMembers(agentIDs []uuid.UUID) error {
+	members := make([]uuid.UUID, 0, len(agentIDs))
+	seen := make(map[uuid.UUID]bool, len(agentIDs))
+	for _, id := range agentIDs {
+		if id == uuid.Nil {
+			return ErrInvalidAgent
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		members = append(members, id)
+	}
+	t.memberIDs = members
+	t.updatedAt = time.Now()
+	return nil
+}
+
+func (t *Team) HasMember(agentID uuid.UUID) bool {
+	for _, id := range t.memberIDs {
+		if id == agentID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsSupervisor indica se o agente supervisiona o time
+func (t *Team) IsSupervisor(agentID uuid.UUID) bool {
+	return t.supervisorID != nil && *t.supervisorID == agentID
+}
+
+func (t *Team) ID() uuid.UUID            { return t.id }
+func (t *Team) TenantID() string         { return t.tenantID }
+func (t *Team) ProjectID() uuid.UUID     { return t.projectID }
+func (t *Team) Name() string             { return t.name }
+func (t *Team) Description() string      { return t.description }
+func (t *Team) SupervisorID() *uuid.UUID { return t.supervisorID }
+func (t *Team) MemberIDs() []uuid.UUID   { return append([]uuid.UUID{}, t.memberIDs...) }
+func (t *Team) CreatedAt() time.Time     { return t.createdAt }
+func (t *Team) UpdatedAt() time.Time     { return t.updatedAt }
@@ -0,0 +1,187 @@
+package team
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/project"
+	"github.com/ventros/crm/internal/domain/crm/session"
+)
+
+func newTestTeam(t *testing.T) *Team {
+	t.Helper()
+	tm, err := NewTeam("tenant-1", uuid.New(), " Suporte ")
+	require.NoError(t, err)
+	return tm
+}
+
+func newTestQueue(t *testing.T, tm *Team, strategy project.AssignmentStrategy) *Queue {
+	t.Helper()
+	q, err := NewQueue(tm.TenantID(), tm.ProjectID(), tm.ID(), "Nível 1", strategy)
+	require.NoError(t, err)
+	return q
+}
+
+func newTestSession(t *testing.T) *session.Session {
+	t.Helper()
+	sess, err := session.NewSession(uuid.New(), "tenant-1", nil, 30*time.Minute)
+	require.NoError(t, err)
+	return sess
+}
+
+func TestNewTeam(t *testing.T) {
+	tm := newTestTeam(t)
+	assert.Equal(t, "Suporte", tm.Name())
+	assert.Empty(t, tm.MemberIDs())
+
+	_, err := NewTeam("", uuid.New(), "x")
+	assert.ErrorIs(t, err, ErrInvalidTenant)
+	_, err = NewTeam("t", uuid.Nil, "x")
+	assert.ErrorIs(t, err, ErrInvalidProject)
+	_, err = NewTeam("t", uuid.New(), "  ")
+	assert.ErrorIs(t, err, ErrEmptyName)
+}
+
+func TestTeam_Members(t *testing.T) {
+	tm := newTestTeam(t)
+	a, b := uuid.New(), uuid.New()
+
+	require.NoError(t, tm.AddMember(a))
+	require.NoError(t, tm.AddMember(a))
+	require.NoError(t, tm.AddMember(b))
+	assert.Equal(t, []uuid.UUID{a, b}, tm.MemberIDs())
+	assert.ErrorIs(t, tm.AddMember(uuid.Nil), ErrInvalidAgent)
+
+	tm.RemoveMember(a)
+	assert.False(t, tm.HasMember(a))
+	assert.True(t, tm.HasMember(b))
+
+	require.NoError(t, tm.SetMembers([]uuid.UUID{b, a, b}))
+	assert.Equal(t, []uuid.UUID{b, a}, tm.MemberIDs())
+	assert.ErrorIs(t, tm.SetMembers([]uuid.UUID{uuid.Nil}), ErrInvalidAgent)
+}
+
+func TestTeam_Supervisor(t *testing.T) {
+	tm := newTestTeam(t)
+	supervisor := uuid.New()
+
+	require.NoError(t, tm.SetSupervisor(&supervisor))
+	assert.True(t, tm.IsSupervisor(supervisor))
+	assert.False(t, tm.IsSupervisor(uuid.New()))
+
+	nilID := uuid.Nil
+	assert.ErrorIs(t, tm.SetSupervisor(&nilID), ErrInvalidAgent)
+
+	require.NoError(t, tm.SetSupervisor(nil))
+	assert.False(t, tm.IsSupervisor(supervisor))
+}
+
+func TestNewQueue(t *testing.T) {
+	tm := newTestTeam(t)
+	q := newTestQueue(t, tm, project.StrategyManual)
+	assert.True(t, q.IsEnabled())
+	assert.False(t, q.IsAutomatic())
+
+	_, err := NewQueue("t", uuid.New(), uuid.Nil, "x", project.StrategyManual)
+	assert.ErrorIs(t, err, ErrInvalidTeam)
+	_, err = NewQueue("t", uuid.New(), uuid.New(), "x", "random")
+	assert.ErrorIs(t, err, ErrInvalidStrategy)
+	assert.ErrorIs(t, q.SetStrategy("random"), ErrInvalidStrategy)
+}
+
+func TestQueue_AssignmentConfig(t *testing.T) {
+	tm := newTestTeam(t)
+	a, b := uuid.New(), uuid.New()
+	require.NoError(t, tm.SetMembers([]uuid.UUID{a, b}))
+	q := newTestQueue(t, tm, project.StrategyLeastSessions)
+
+	base := project.NewAgentAssignmentConfig()
+	base.AgentIDs = []uuid.UUID{uuid.New()}
+	base.DefaultMaxConcurrentSessions = 5
+	base.MatchContactLanguage = true
+
+	config := q.AssignmentConfig(tm, base)
+	assert.True(t, config.ShouldAutoAssign())
+	assert.Equal(t, []uuid.UUID{a, b}, config.AgentIDs)
+	assert.Equal(t, project.StrategyLeastSessions, config.Strategy)
+	assert.Equal(t, 5, config.DefaultMaxConcurrentSessions)
+	assert.True(t, config.MatchContactLanguage)
+	assert.Len(t, base.AgentIDs, 1, "project config must not change")
+
+	assert.True(t, q.AssignmentConfig(tm, nil).ExcludeVirtualAgents)
+}
+
+func TestQueue_MoveToTeamResetsCursor(t *testing.T) {
+	tm := newTestTeam(t)
+	q := newTestQueue(t, tm, project.StrategyRoundRobin)
+	q.RecordAssignment(uuid.New())
+
+	require.NoError(t, q.MoveToTeam(tm.ID()))
+	assert.NotNil(t, q.LastAssignedAgentID())
+
+	require.NoError(t, q.MoveToTeam(uuid.New()))
+	assert.Nil(t, q.LastAssignedAgentID())
+}
+
+func TestQueueItem_Lifecycle(t *testing.T) {
+	tm := newTestTeam(t)
+	q := newTestQueue(t, tm, project.StrategyManual)
+	sess := newTestSession(t)
+	enqueuedAt := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+
+	item := NewQueueItem(q, sess, enqueuedAt)
+	assert.True(t, item.IsWaiting())
+	assert.Equal(t, session.PriorityNormal, item.Priority())
+	assert.Equal(t, 5*time.Minute, item.WaitTime(enqueuedAt.Add(5*time.Minute)))
+
+	other := newTestQueue(t, tm, project.StrategyManual)
+	require.NoError(t, item.MoveTo(other, session.PriorityHigh))
+	assert.Equal(t, other.ID(), item.QueueID())
+	assert.Equal(t, session.PriorityHigh, item.Priority())
+	assert.Equal(t, enqueuedAt, item.EnqueuedAt())
+
+	agentID := uuid.New()
+	require.NoError(t, item.Assign(agentID, enqueuedAt.Add(2*time.Minute)))
+	assert.Equal(t, ItemAssigned, item.Status())
+	assert.Equal(t, agentID, *item.AgentID())
+	assert.Equal(t, 2*time.Minute, item.WaitTime(enqueuedAt.Add(time.Hour)))
+
+	assert.ErrorIs(t, item.Abandon(enqueuedAt), ErrItemNotWaiting)
+	assert.ErrorIs(t, item.Assign(agentID, enqueuedAt), ErrItemNotWaiting)
+	assert.ErrorIs(t, item.MoveTo(other, session.PriorityLow), ErrItemNotWaiting)
+}
+
+func TestQueueItem_Abandon(t *testing.T) {
+	tm := newTestTeam(t)
+	item := NewQueueItem(newTestQueue(t, tm, project.StrategyManual), newTestSession(t), time.Now())
+
+	require.NoError(t, item.Abandon(time.Now()))
+	assert.Equal(t, ItemAbandoned, item.Status())
+	assert.NotNil(t, item.ResolvedAt())
+	assert.Nil(t, item.AgentID())
+}
+
+func TestSortWaiting(t *testing.T) {
+	base := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	item := func(p session.Priority, minutes int) *QueueItem {
+		return ReconstructQueueItem(uuid.New(), "t", uuid.New(), uuid.New(), uuid.New(), uuid.New(),
+			p, ItemWaiting, nil, base.Add(time.Duration(minutes)*time.Minute), nil)
+	}
+
+	oldNormal := item(session.PriorityNormal, 0)
+	newNormal := item(session.PriorityNormal, 10)
+	urgent := item(session.PriorityUrgent, 20)
+	low := item(session.PriorityLow, -30)
+
+	items := []*QueueItem{newNormal, low, oldNormal, urgent}
+	SortWaiting(items)
+	assert.Equal(t, []*QueueItem{urgent, oldNormal, newNormal, low}, items)
+}
+
+func TestMetrics_AbandonmentRate(t *testing.T) {
+	assert.Equal(t, 0.0, Metrics{}.AbandonmentRate())
+	assert.Equal(t, 25.0, Metrics{Assigned: 3, Abandoned: 1}.AbandonmentRate())
+}
@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+var ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+// MaxLoggedResponseBytes tamanho máximo da resposta guardada no log de entregas
+const MaxLoggedResponseBytes = 4096
+
+// DeliveryTrigger origem da tentativa de entrega
+type DeliveryTrigger string
+
+const (
+	DeliveryTriggerEvent  DeliveryTrigger = "event"  // Evento de domínio (com retries automáticos)
+	DeliveryTriggerReplay DeliveryTrigger = "replay" // Reenvio manual (individual ou em lote)
+)
+
+// sensitiveHeaders headers com credenciais, mascarados no log
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"cookie":              true,
+	"x-api-key":           true,
+	"api-key":             true,
+}
+
+// Delivery uma tentativa de entrega de webhook: o que foi enviado e o que o endpoint respondeu.
+// EventID é o mesmo em todas as tentativas (e reenvios) de uma notificação.
+type Delivery struct {
+	ID             uuid.UUID
+	SubscriptionID uuid.UUID
+	TenantID       string
+	ProjectID      uuid.UUID
+	EventID        uuid.UUID
+	EventType      string
+	Trigger        DeliveryTrigger
+	ReplayOf       *uuid.UUID
+	Attempt        int
+
+	RequestURL     string
+	RequestHeaders map[string]string
+	RequestBody    string
+
+	StatusCode   int // 0 quando não houve resposta (timeout, DNS, conexão recusada)
+	ResponseBody string
+	Latency      time.Duration
+	Error        string
+	Success      bool
+
+	CreatedAt time.Time
+}
+
+// NewDelivery registra uma tentativa de entrega; headers sensíveis são mascarados
+func NewDelivery(sub *WebhookSubscription, eventID uuid.UUID, eventType string, trigger DeliveryTrigger, attempt int, headers http.Header, body []byte) *Delivery {
+	return &Delivery{
+		ID:             uuid.New(),
+		SubscriptionID: sub.ID,
+		TenantID:       sub.TenantID,
+		ProjectID:      sub.ProjectID,
+		EventID:        eventID,
+		EventType:      eventType,
+		Trigger:        trigger,
+		Attempt:        attempt,
+		RequestURL:     sub.URL,
+		RequestHeaders: maskHeaders(headers),
+		RequestBody:    string(body),
+		CreatedAt:      time.Now(),
+	}
+}
+
+// NewReplay tentativa de reenvio manual de uma entrega, com o mesmo corpo e EventID
+func NewReplay(sub *WebhookSubscription, original *Delivery, headers http.Header) *Delivery {
+	d := NewDelivery(sub, original.EventID, original.EventType, DeliveryTriggerReplay, 1, headers, []byte(original.RequestBody))
+	originalID := original.ID
+	d.ReplayOf = &originalID
+	return d
+}
+
+// RecordResponse registra a resposta do endpoint; sucesso = status 2xx
+func (d *Delivery) RecordResponse(statusCode int, body []byte, latency time.Duration) {
+	d.StatusCode = statusCode
+	d.ResponseBody = TruncateResponse(body)
+	d.Latency = latency
+	d.Success = statusCode >= 200 && statusCode < 300
+	if !d.Success {
+		d.Error = fmt.Sprintf("endpoint returned status %d", statusCode)
+	}
+}
+
+// RecordError registra falha sem resposta do endpoint
+func (d *Delivery) RecordError(err error, latency time.Duration) {
+	d.Latency = latency
+	d.Success = false
+	d.Error = err.Error()
+}
+
+// TruncateResponse corta o corpo da resposta em MaxLoggedResponseBytes sem quebrar runas UTF-8
+func TruncateResponse(body []byte) string {
+	if len(body) <= MaxLoggedResponseBytes {
+		return string(body)
+	}
+	cut := MaxLoggedResponseBytes
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+	return string(body[:cut]) + "…[truncated]"
+}
+
+func maskHeaders(headers http.Header) map[string]string {
+	masked := make(map[string]string, len(headers))
+	for key, values := range headers {
+		value := strings.Join(values, ", ")
+		if sensitiveHeaders[strings.ToLower(key)] {
+			value = "[redacted]"
+		}
+		masked[key] = value
+	}
+	return masked
+}
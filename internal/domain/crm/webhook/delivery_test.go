@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDelivery_MasksSensitiveHeaders(t *testing.T) {
+	sub := createTestWebhook(t)
+	headers := http.Header{}
+	headers.Set("Content-Type", "application/json")
+	headers.Set("Authorization", "Bearer secret-token")
+	headers.Set("X-Api-Key", "key-123")
+
+	d := NewDelivery(sub, uuid.New(), "contact.created", DeliveryTriggerEvent, 2, headers, []byte(`{"event":"contact.created"}`))
+
+	assert.Equal(t, sub.ID, d.SubscriptionID)
+	assert.Equal(t, sub.TenantID, d.TenantID)
+	assert.Equal(t, sub.URL, d.RequestURL)
+	assert.Equal(t, 2, d.Attempt)
+	assert.Equal(t, "application/json", d.RequestHeaders["Content-Type"])
+	assert.Equal(t, "[redacted]", d.RequestHeaders["Authorization"])
+	assert.Equal(t, "[redacted]", d.RequestHeaders["X-Api-Key"])
+	assert.Equal(t, `{"event":"contact.created"}`, d.RequestBody)
+}
+
+func TestDelivery_RecordResponse(t *testing.T) {
+	sub := createTestWebhook(t)
+
+	t.Run("2xx is success", func(t *testing.T) {
+		d := NewDelivery(sub, uuid.New(), "contact.created", DeliveryTriggerEvent, 1, nil, nil)
+		d.RecordResponse(http.StatusAccepted, []byte("ok"), 120*time.Millisecond)
+
+		assert.True(t, d.Success)
+		assert.Equal(t, 202, d.StatusCode)
+		assert.Equal(t, "ok", d.ResponseBody)
+		assert.Empty(t, d.Error)
+	})
+
+	t.Run("other status is failure", func(t *testing.T) {
+		d := NewDelivery(sub, uuid.New(), "contact.created", DeliveryTriggerEvent, 1, nil, nil)
+		d.RecordResponse(http.StatusServiceUnavailable, []byte("down"), time.Second)
+
+		assert.False(t, d.Success)
+		assert.Equal(t, "endpoint returned status 503", d.Error)
+	})
+
+	t.Run("no response", func(t *testing.T) {
+		d := NewDelivery(sub, uuid.New(), "contact.created", DeliveryTriggerEvent, 1, nil, nil)
+		d.RecordError(errors.New("dial tcp: connection refused"), 5*time.Millisecond)
+
+		assert.False(t, d.Success)
+		assert.Equal(t, 0, d.StatusCode)
+		assert.Equal(t, "dial tcp: connection refused", d.Error)
+	})
+}
+
+func TestNewReplay_KeepsEventAndBody(t *testing.T) {
+	sub := createTestWebhook(t)
+	original := NewDelivery(sub, uuid.New(), "contact.created", DeliveryTriggerEvent, 3, nil, []byte(`{"id":"1"}`))
+
+	replay := NewReplay(sub, original, nil)
+
+	assert.NotEqual(t, original.ID, replay.ID)
+	assert.Equal(t, original.EventID, replay.EventID)
+	assert.Equal(t, original.RequestBody, replay.RequestBody)
+	assert.Equal(t, DeliveryTriggerReplay, replay.Trigger)
+	assert.Equal(t, 1, replay.Attempt)
+	assert.Equal(t, &original.ID, replay.ReplayOf)
+}
+
+func TestTruncateResponse(t *testing.T) {
+	assert.Equal(t, "short", TruncateResponse([]byte("short")))
+
+	long := strings.Repeat("a", MaxLoggedResponseBytes-1) + "ção"
+	truncated := TruncateResponse([]byte(long))
+	assert.True(t, strings.HasSuffix(truncated, "…[truncated]"))
+	assert.True(t, strings.HasPrefix(truncated, strings.Repeat("a", MaxLoggedResponseBytes-1)))
+	assert.True(t, utf8.ValidString(truncated))
+	assert.LessOrEqual(t, len(truncated), MaxLoggedResponseBytes+len("…[truncated]"))
+}
@@ -12,4 +12,8 @@ var (
 	ErrNotFound = errors.New("webhook subscription not found")
 
 	ErrAlreadyExists = errors.New("webhook subscription already exists")
+
+	ErrInvalidSecret = errors.New("webhook secret must have at least 16 characters")
+
+	ErrInvalidRotationOverlap = errors.New("secret rotation overlap must be between 0 and 7 days")
 )
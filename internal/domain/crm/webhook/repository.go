@@ -2,6 +2,7 @@ package webhook
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -22,4 +23,35 @@ type Repository interface {
 	Delete(ctx context.Context, id uuid.UUID) error
 
 	RecordTrigger(ctx context.Context, id uuid.UUID, success bool) error
+
+	// AutoDisable desativa a inscrição se ainda ativa e com maxConsecutiveFailures falhas seguidas.
+	// Retorna true apenas para quem efetivamente desativou (concorrência entre entregas).
+	AutoDisable(ctx context.Context, id uuid.UUID, maxConsecutiveFailures int) (bool, error)
+}
+
+// DeliveryFilter consulta do log de entregas de uma inscrição
+type DeliveryFilter struct {
+	SubscriptionID uuid.UUID
+	EventType      string
+	Success        *bool
+	Since          *time.Time
+	Until          *time.Time
+	Limit          int
+	Offset         int
+}
+
+// DeliveryRepository log de entregas (uma linha por tentativa)
+type DeliveryRepository interface {
+	Save(ctx context.Context, delivery *Delivery) error
+
+	FindByID(ctx context.Context, id uuid.UUID) (*Delivery, error)
+
+	// List entregas da inscrição, mais recentes primeiro
+	List(ctx context.Context, filter DeliveryFilter) ([]*Delivery, int64, error)
+
+	// FindFailedSince última tentativa de cada notificação desde since que nunca foi entregue com sucesso
+	FindFailedSince(ctx context.Context, subscriptionID uuid.UUID, since time.Time, limit int) ([]*Delivery, error)
+
+	// DeleteBefore remove entregas anteriores a before (retenção)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
 }
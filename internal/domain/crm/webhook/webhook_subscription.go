@@ -1,6 +1,9 @@
 package webhook
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -31,8 +34,26 @@ type WebhookSubscription struct {
 	FailureCount    int
 	CreatedAt       time.Time
 	UpdatedAt       time.Time
+
+	// Falhas seguidas (zera no primeiro sucesso); ao atingir o limite a inscrição é desativada
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	DisabledReason      string
+
+	// Segredo anterior, ainda usado na assinatura até PreviousSecretExpiresAt (rotação sem downtime)
+	PreviousSecret          string
+	PreviousSecretExpiresAt *time.Time
 }
 
+const (
+	// MinSecretLength tamanho mínimo de um segredo informado pelo cliente
+	MinSecretLength = 16
+	// DefaultSecretRotationOverlap período em que o segredo anterior continua assinando
+	DefaultSecretRotationOverlap = 24 * time.Hour
+	// MaxSecretRotationOverlap maior janela de rotação aceita
+	MaxSecretRotationOverlap = 7 * 24 * time.Hour
+)
+
 func NewWebhookSubscription(userID, projectID uuid.UUID, tenantID, name, url string, events []string) (*WebhookSubscription, error) {
 	if name == "" {
 		return nil, ErrInvalidName
@@ -90,8 +111,12 @@ func (w *WebhookSubscription) UpdateEvents(events []string) error {
 	return nil
 }
 
+// SetActive reativa a inscrição, limpando a desativação automática
 func (w *WebhookSubscription) SetActive() {
 	w.Active = true
+	w.ConsecutiveFailures = 0
+	w.DisabledAt = nil
+	w.DisabledReason = ""
 	w.UpdatedAt = time.Now()
 }
 
@@ -100,11 +125,59 @@ func (w *WebhookSubscription) SetInactive() {
 	w.UpdatedAt = time.Now()
 }
 
+// SetSecret troca o segredo imediatamente, sem janela de rotação
 func (w *WebhookSubscription) SetSecret(secret string) {
 	w.Secret = secret
+	w.PreviousSecret = ""
+	w.PreviousSecretExpiresAt = nil
 	w.UpdatedAt = time.Now()
 }
 
+// RotateSecret passa a assinar com newSecret; o segredo atual continua assinando por overlap,
+// para o receptor trocar a configuração sem rejeitar entregas. overlap 0 encerra a janela na hora.
+func (w *WebhookSubscription) RotateSecret(newSecret string, overlap time.Duration) error {
+	if len(newSecret) < MinSecretLength {
+		return ErrInvalidSecret
+	}
+	if overlap < 0 || overlap > MaxSecretRotationOverlap {
+		return ErrInvalidRotationOverlap
+	}
+
+	now := time.Now()
+	if w.Secret != "" && overlap > 0 {
+		expiresAt := now.Add(overlap)
+		w.PreviousSecret = w.Secret
+		w.PreviousSecretExpiresAt = &expiresAt
+	} else {
+		w.PreviousSecret = ""
+		w.PreviousSecretExpiresAt = nil
+	}
+	w.Secret = newSecret
+	w.UpdatedAt = now
+	return nil
+}
+
+// SigningSecrets segredos ativos em now: o atual e, durante a rotação, o anterior
+func (w *WebhookSubscription) SigningSecrets(now time.Time) []string {
+	if w.Secret == "" {
+		return nil
+	}
+	secrets := []string{w.Secret}
+	if w.PreviousSecret != "" && w.PreviousSecretExpiresAt != nil && now.Before(*w.PreviousSecretExpiresAt) {
+		secrets = append(secrets, w.PreviousSecret)
+	}
+	return secrets
+}
+
+// GenerateSecret gera um segredo aleatório (256 bits) para assinatura de webhooks
+func GenerateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
 func (w *WebhookSubscription) SetHeaders(headers map[string]string) {
 	w.Headers = headers
 	w.UpdatedAt = time.Now()
@@ -123,14 +196,39 @@ func (w *WebhookSubscription) RecordTrigger(success bool) {
 	if success {
 		w.LastSuccessAt = &now
 		w.SuccessCount++
+		w.ConsecutiveFailures = 0
 	} else {
 		w.LastFailureAt = &now
 		w.FailureCount++
+		w.ConsecutiveFailures++
 	}
 
 	w.UpdatedAt = now
 }
 
+// ShouldAutoDisable indica se a inscrição ativa atingiu o limite de falhas seguidas (limite <= 0 desliga a regra)
+func (w *WebhookSubscription) ShouldAutoDisable(maxConsecutiveFailures int) bool {
+	return w.Active && maxConsecutiveFailures > 0 && w.ConsecutiveFailures >= maxConsecutiveFailures
+}
+
+// AutoDisable desativa a inscrição por excesso de falhas seguidas
+func (w *WebhookSubscription) AutoDisable(maxConsecutiveFailures int) bool {
+	if !w.ShouldAutoDisable(maxConsecutiveFailures) {
+		return false
+	}
+	now := time.Now()
+	w.Active = false
+	w.DisabledAt = &now
+	w.DisabledReason = AutoDisableReason(maxConsecutiveFailures)
+	w.UpdatedAt = now
+	return true
+}
+
+// AutoDisableReason motivo registrado na desativação automática
+func AutoDisableReason(maxConsecutiveFailures int) string {
+	return fmt.Sprintf("disabled after %d consecutive failed deliveries", maxConsecutiveFailures)
+}
+
 func (w *WebhookSubscription) IsSubscribedTo(eventType string) bool {
 	for _, event := range w.Events {
 		if event == eventType || event == "*" {
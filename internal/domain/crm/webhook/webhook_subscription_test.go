@@ -172,6 +172,42 @@ func TestWebhookSubscription_SetSecret(t *testing.T) {
 	assert.Equal(t, secret, webhook.Secret)
 }
 
+func TestWebhookSubscription_RotateSecret(t *testing.T) {
+	webhook := createTestWebhook(t)
+	webhook.SetSecret("whsec_original_secret")
+
+	assert.ErrorIs(t, webhook.RotateSecret("short", time.Hour), ErrInvalidSecret)
+	assert.ErrorIs(t, webhook.RotateSecret("whsec_rotated_secret", 8*24*time.Hour), ErrInvalidRotationOverlap)
+	assert.Equal(t, "whsec_original_secret", webhook.Secret, "invalid rotation keeps the secret")
+
+	require.NoError(t, webhook.RotateSecret("whsec_rotated_secret", time.Hour))
+	assert.Equal(t, "whsec_rotated_secret", webhook.Secret)
+	assert.Equal(t, "whsec_original_secret", webhook.PreviousSecret)
+	require.NotNil(t, webhook.PreviousSecretExpiresAt)
+
+	now := time.Now()
+	assert.Equal(t, []string{"whsec_rotated_secret", "whsec_original_secret"}, webhook.SigningSecrets(now))
+	assert.Equal(t, []string{"whsec_rotated_secret"}, webhook.SigningSecrets(now.Add(2*time.Hour)), "window expired")
+
+	require.NoError(t, webhook.RotateSecret("whsec_emergency_secret", 0))
+	assert.Empty(t, webhook.PreviousSecret, "zero overlap revokes the old secret immediately")
+	assert.Equal(t, []string{"whsec_emergency_secret"}, webhook.SigningSecrets(now))
+
+	webhook.SetSecret("")
+	assert.Nil(t, webhook.SigningSecrets(now))
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.True(t, len(a) > MinSecretLength)
+	assert.Contains(t, a, "whsec_")
+	assert.NotEqual(t, a, b)
+}
+
 func TestWebhookSubscription_SetHeaders(t *testing.T) {
 	webhook := createTestWebhook(t)
 
@@ -237,6 +273,33 @@ func TestWebhookSubscription_RecordTrigger(t *testing.T) {
 	})
 }
 
+func TestWebhookSubscription_AutoDisable(t *testing.T) {
+	webhook := createTestWebhook(t)
+
+	webhook.RecordTrigger(false)
+	webhook.RecordTrigger(false)
+	webhook.RecordTrigger(true)
+	assert.Equal(t, 0, webhook.ConsecutiveFailures, "success resets the streak")
+
+	webhook.RecordTrigger(false)
+	webhook.RecordTrigger(false)
+	assert.False(t, webhook.AutoDisable(3))
+	assert.False(t, webhook.AutoDisable(0), "threshold 0 disables the rule")
+
+	webhook.RecordTrigger(false)
+	require.True(t, webhook.AutoDisable(3))
+	assert.False(t, webhook.Active)
+	assert.NotNil(t, webhook.DisabledAt)
+	assert.Equal(t, "disabled after 3 consecutive failed deliveries", webhook.DisabledReason)
+	assert.False(t, webhook.AutoDisable(3), "already disabled")
+
+	webhook.SetActive()
+	assert.True(t, webhook.Active)
+	assert.Equal(t, 0, webhook.ConsecutiveFailures)
+	assert.Nil(t, webhook.DisabledAt)
+	assert.Empty(t, webhook.DisabledReason)
+}
+
 func TestWebhookSubscription_IsSubscribedTo(t *testing.T) {
 	tests := []struct {
 		name          string
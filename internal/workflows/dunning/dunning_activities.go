@@ -0,0 +1,33 @@
+package dunning
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdvanceHandler avança a régua no instante do passo (implementado por DunningService)
+type AdvanceHandler interface {
+	Advance(ctx context.Context, caseID uuid.UUID, now time.Time) (bool, error)
+}
+
+// DunningActivities contém as dependências para as activities
+type DunningActivities struct {
+	handler AdvanceHandler
+}
+
+// NewDunningActivities cria as activities da régua de cobrança
+func NewDunningActivities(handler AdvanceHandler) *DunningActivities {
+	return &DunningActivities{handler: handler}
+}
+
+// AdvanceActivity executa os passos vencidos e informa se a régua foi encerrada
+func (a *DunningActivities) AdvanceActivity(ctx context.Context, input DunningAdvanceActivityInput) (bool, error) {
+	caseID, err := uuid.Parse(input.CaseID)
+	if err != nil {
+		return false, fmt.Errorf("invalid dunning case id: %w", err)
+	}
+	return a.handler.Advance(ctx, caseID, time.Now())
+}
@@ -0,0 +1,78 @@
+package dunning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// TemporalTimers agenda um workflow por régua de cobrança
+type TemporalTimers struct {
+	temporalClient client.Client
+}
+
+// NewTemporalTimers cria os timers sobre o Temporal
+func NewTemporalTimers(temporalClient client.Client) *TemporalTimers {
+	return &TemporalTimers{temporalClient: temporalClient}
+}
+
+// Schedule (re)inicia o workflow da régua com os instantes distintos dos passos
+func (t *TemporalTimers) Schedule(ctx context.Context, caseID uuid.UUID, steps []billing.DunningStep) error {
+	options := client.StartWorkflowOptions{
+		ID:                       workflowID(caseID),
+		TaskQueue:                TaskQueue,
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING,
+	}
+	input := DunningWorkflowInput{
+		CaseID:  caseID.String(),
+		StepsAt: stepInstants(steps),
+	}
+
+	if _, err := t.temporalClient.ExecuteWorkflow(ctx, options, DunningWorkflow, input); err != nil {
+		return fmt.Errorf("failed to start dunning workflow: %w", err)
+	}
+	return nil
+}
+
+// Cancel encerra o workflow da régua; workflow inexistente ou já concluído não é erro
+func (t *TemporalTimers) Cancel(ctx context.Context, caseID uuid.UUID) error {
+	err := t.temporalClient.TerminateWorkflow(ctx, workflowID(caseID), "", "dunning case resolved")
+	var notFound *serviceerror.NotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return fmt.Errorf("failed to terminate dunning workflow: %w", err)
+	}
+	return nil
+}
+
+func workflowID(caseID uuid.UUID) string {
+	return fmt.Sprintf("billing-dunning-%s", caseID.String())
+}
+
+// stepInstants instantes dos passos sem repetição (ex: lembrete e somente leitura no mesmo dia)
+func stepInstants(steps []billing.DunningStep) []time.Time {
+	instants := make([]time.Time, 0, len(steps))
+	for _, step := range steps {
+		if n := len(instants); n > 0 && instants[n-1].Equal(step.At) {
+			continue
+		}
+		instants = append(instants, step.At)
+	}
+	return instants
+}
+
+// PollingTimers usado sem Temporal: os passos são disparados apenas pela varredura periódica
+// (DunningService.ProcessDue), com atraso de até um intervalo de varredura.
+type PollingTimers struct{}
+
+func (PollingTimers) Schedule(ctx context.Context, caseID uuid.UUID, steps []billing.DunningStep) error {
+	return nil
+}
+
+func (PollingTimers) Cancel(ctx context.Context, caseID uuid.UUID) error { return nil }
@@ -0,0 +1,55 @@
+package dunning
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TaskQueue fila Temporal da régua de cobrança
+const TaskQueue = "billing-dunning"
+
+// DunningWorkflowInput régua de uma fatura e os instantes dos seus passos
+type DunningWorkflowInput struct {
+	CaseID  string      `json:"case_id"`
+	StepsAt []time.Time `json:"steps_at"`
+}
+
+// DunningAdvanceActivityInput régua a avançar
+type DunningAdvanceActivityInput struct {
+	CaseID string `json:"case_id"`
+}
+
+// DunningWorkflow dorme até cada passo da régua (lembretes, fim da carência, suspensão) e pede
+// o avanço. A activity consulta o estado atual e informa quando a régua foi encerrada (fatura
+// paga ou cancelada); o workflow também é encerrado (terminate) quando chega invoice.paid.
+func DunningWorkflow(ctx workflow.Context, input DunningWorkflowInput) error {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Minute,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 2,
+			MaximumInterval:    10 * time.Minute,
+			MaximumAttempts:    10,
+		},
+	})
+
+	for _, at := range input.StepsAt {
+		if d := at.Sub(workflow.Now(ctx)); d > 0 {
+			if err := workflow.Sleep(ctx, d); err != nil {
+				return err
+			}
+		}
+
+		var closed bool
+		err := workflow.ExecuteActivity(activityCtx, "DunningAdvanceActivity", DunningAdvanceActivityInput{CaseID: input.CaseID}).Get(ctx, &closed)
+		if err != nil {
+			return err
+		}
+		if closed {
+			return nil
+		}
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package dunning
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ventros/crm/internal/domain/core/billing"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestDunningWorkflow_AdvancesAtEachStep(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var firedAt []time.Time
+	env.RegisterActivityWithOptions(func(input DunningAdvanceActivityInput) (bool, error) {
+		firedAt = append(firedAt, env.Now())
+		return false, nil
+	}, activity.RegisterOptions{Name: "DunningAdvanceActivity"})
+
+	due := env.Now()
+	steps := stepInstants(billing.DefaultDunningPolicy().Steps(due))
+	require.Len(t, steps, 4)
+
+	env.ExecuteWorkflow(DunningWorkflow, DunningWorkflowInput{CaseID: "case-1", StepsAt: steps})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	require.Len(t, firedAt, 4)
+	for i, at := range steps {
+		assert.False(t, firedAt[i].Before(at))
+	}
+}
+
+func TestDunningWorkflow_StopsWhenCaseCloses(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	calls := 0
+	env.RegisterActivityWithOptions(func(input DunningAdvanceActivityInput) (bool, error) {
+		calls++
+		return calls == 2, nil
+	}, activity.RegisterOptions{Name: "DunningAdvanceActivity"})
+
+	start := env.Now()
+	env.ExecuteWorkflow(DunningWorkflow, DunningWorkflowInput{
+		CaseID:  "case-1",
+		StepsAt: []time.Time{start.Add(-time.Hour), start.Add(24 * time.Hour), start.Add(48 * time.Hour)},
+	})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, 2, calls)
+}
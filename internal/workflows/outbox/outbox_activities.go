@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/ventros/crm/infrastructure/messaging"
 	"github.com/ventros/crm/internal/domain/core/outbox"
 	"go.temporal.io/sdk/activity"
 )
@@ -201,10 +202,11 @@ func (a *OutboxActivities) ProcessFailedEventsActivity(
 func (a *OutboxActivities) publishEvent(ctx context.Context, event *outbox.OutboxEvent) error {
 	logger := activity.GetLogger(ctx)
 
-	// 1. Publica no RabbitMQ
-	queue := fmt.Sprintf("domain.events.%s", event.EventType)
-	if err := a.eventPublisher.PublishRaw(ctx, queue, event.EventData); err != nil {
-		return fmt.Errorf("failed to publish to RabbitMQ: %w", err)
+	// 1. Publica no RabbitMQ (fila principal + filas de fan-out dos subscribers)
+	for _, queue := range messaging.DomainEventQueues(event.EventType) {
+		if err := a.eventPublisher.PublishRaw(ctx, queue, event.EventData); err != nil {
+			return fmt.Errorf("failed to publish to RabbitMQ: %w", err)
+		}
 	}
 
 	// 2. Notifica webhooks HTTP
@@ -336,6 +338,14 @@ func mapDomainToBusinessEvents(domainEvent string) []string {
 		return []string{"session.summarized"}
 	case "session.abandoned":
 		return []string{"session.abandoned"}
+	case "session.priority_changed":
+		return []string{"session.priority_changed"}
+
+	// Eventos de SLA
+	case "sla.warning":
+		return []string{"sla.warning"}
+	case "sla.breached":
+		return []string{"sla.breached"}
 
 	// Eventos de mensagem
 	case "message.created":
@@ -372,6 +382,8 @@ func mapDomainToBusinessEvents(domainEvent string) []string {
 		return []string{"agent.permission_granted"}
 	case "agent.permission_revoked":
 		return []string{"agent.permission_revoked"}
+	case "agent.status_changed":
+		return []string{"agent.status_changed"}
 
 	// Eventos de canal
 	case "channel.created":
@@ -396,6 +408,36 @@ func mapDomainToBusinessEvents(domainEvent string) []string {
 		return []string{"note.deleted"}
 	case "note.pinned":
 		return []string{"note.pinned"}
+	case "note.unpinned":
+		return []string{"note.unpinned"}
+
+	// Eventos de tarefa
+	case "task.created":
+		return []string{"task.created"}
+	case "task.updated":
+		return []string{"task.updated"}
+	case "task.completed":
+		return []string{"task.completed"}
+	case "task.cancelled":
+		return []string{"task.cancelled"}
+	case "task.overdue":
+		return []string{"task.overdue"}
+	case "task.deleted":
+		return []string{"task.deleted"}
+
+	// Eventos de resposta pronta
+	case "canned_response.created":
+		return []string{"canned_response.created"}
+	case "canned_response.updated":
+		return []string{"canned_response.updated"}
+	case "canned_response.deleted":
+		return []string{"canned_response.deleted"}
+
+	// Eventos de API key (nunca carregam o token)
+	case "api_key.created":
+		return []string{"api_key.created"}
+	case "api_key.revoked":
+		return []string{"api_key.revoked"}
 
 	// Eventos de pipeline
 	case "pipeline.created":
@@ -474,6 +516,10 @@ func mapDomainToBusinessEvents(domainEvent string) []string {
 		return []string{"contact_list.contact_added"}
 	case "contact_list.contact_removed":
 		return []string{"contact_list.contact_removed"}
+	case "contact_list.list_joined":
+		return []string{"contact_list.list_joined"}
+	case "contact_list.list_left":
+		return []string{"contact_list.list_left"}
 
 	// Eventos de automation
 	case "automation.created":
@@ -0,0 +1,43 @@
+package sla
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ClockHandler avalia um relógio no instante do disparo (implementado por ClockService)
+type ClockHandler interface {
+	Warn(ctx context.Context, clockID uuid.UUID, now time.Time) error
+	Breach(ctx context.Context, clockID uuid.UUID, now time.Time) error
+}
+
+// SLAActivities contém as dependências para as activities
+type SLAActivities struct {
+	handler ClockHandler
+}
+
+// NewSLAActivities cria as activities de SLA
+func NewSLAActivities(handler ClockHandler) *SLAActivities {
+	return &SLAActivities{handler: handler}
+}
+
+// WarnActivity emite o aviso de SLA
+func (a *SLAActivities) WarnActivity(ctx context.Context, input SLAClockActivityInput) error {
+	clockID, err := uuid.Parse(input.ClockID)
+	if err != nil {
+		return fmt.Errorf("invalid clock id: %w", err)
+	}
+	return a.handler.Warn(ctx, clockID, time.Now())
+}
+
+// BreachActivity marca o vencimento e escala a sessão
+func (a *SLAActivities) BreachActivity(ctx context.Context, input SLAClockActivityInput) error {
+	clockID, err := uuid.Parse(input.ClockID)
+	if err != nil {
+		return fmt.Errorf("invalid clock id: %w", err)
+	}
+	return a.handler.Breach(ctx, clockID, time.Now())
+}
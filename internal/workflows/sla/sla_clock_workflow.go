@@ -0,0 +1,61 @@
+package sla
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TaskQueue fila Temporal dos timers de SLA
+const TaskQueue = "sla-timers"
+
+// SLAClockWorkflowInput prazos de um relógio de SLA (já calculados em horário útil)
+type SLAClockWorkflowInput struct {
+	ClockID string    `json:"clock_id"`
+	WarnAt  time.Time `json:"warn_at"`
+	DueAt   time.Time `json:"due_at"`
+}
+
+// SLAClockActivityInput relógio a avaliar
+type SLAClockActivityInput struct {
+	ClockID string `json:"clock_id"`
+}
+
+// SLAClockWorkflow dorme até o ponto de aviso, dispara o aviso, dorme até o vencimento e dispara
+// o vencimento. As activities consultam o estado atual do relógio, então um relógio parado entre
+// um disparo e outro resulta em no-op. O workflow é encerrado (terminate) quando o relógio para.
+func SLAClockWorkflow(ctx workflow.Context, input SLAClockWorkflowInput) error {
+	logger := workflow.GetLogger(ctx)
+
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval: 5 * time.Second,
+			MaximumAttempts: 5,
+		},
+	})
+	activityInput := SLAClockActivityInput{ClockID: input.ClockID}
+
+	if err := sleepUntil(ctx, input.WarnAt); err != nil {
+		return err
+	}
+	if input.WarnAt.Before(input.DueAt) {
+		if err := workflow.ExecuteActivity(activityCtx, "SLAWarnActivity", activityInput).Get(ctx, nil); err != nil {
+			// O vencimento ainda deve disparar; o worker de varredura reenvia o aviso perdido
+			logger.Error("SLA warning activity failed", "clock_id", input.ClockID, "error", err.Error())
+		}
+	}
+
+	if err := sleepUntil(ctx, input.DueAt); err != nil {
+		return err
+	}
+	return workflow.ExecuteActivity(activityCtx, "SLABreachActivity", activityInput).Get(ctx, nil)
+}
+
+func sleepUntil(ctx workflow.Context, at time.Time) error {
+	if d := at.Sub(workflow.Now(ctx)); d > 0 {
+		return workflow.Sleep(ctx, d)
+	}
+	return nil
+}
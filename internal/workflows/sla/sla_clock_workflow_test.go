@@ -0,0 +1,67 @@
+package sla
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestSLAClockWorkflow_WarnsThenBreaches(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var calls []string
+	var times []time.Time
+	env.RegisterActivityWithOptions(func(input SLAClockActivityInput) error {
+		calls = append(calls, "warn")
+		times = append(times, env.Now())
+		return nil
+	}, activity.RegisterOptions{Name: "SLAWarnActivity"})
+	env.RegisterActivityWithOptions(func(input SLAClockActivityInput) error {
+		calls = append(calls, "breach")
+		times = append(times, env.Now())
+		return nil
+	}, activity.RegisterOptions{Name: "SLABreachActivity"})
+
+	start := env.Now()
+	env.ExecuteWorkflow(SLAClockWorkflow, SLAClockWorkflowInput{
+		ClockID: "clock-1",
+		WarnAt:  start.Add(8 * time.Minute),
+		DueAt:   start.Add(10 * time.Minute),
+	})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.Equal(t, []string{"warn", "breach"}, calls)
+	assert.False(t, times[0].Before(start.Add(8*time.Minute)))
+	assert.False(t, times[1].Before(start.Add(10*time.Minute)))
+}
+
+func TestSLAClockWorkflow_WarningFailureStillBreaches(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	breached := false
+	env.RegisterActivityWithOptions(func(input SLAClockActivityInput) error {
+		return errors.New("bus unavailable")
+	}, activity.RegisterOptions{Name: "SLAWarnActivity"})
+	env.RegisterActivityWithOptions(func(input SLAClockActivityInput) error {
+		breached = true
+		return nil
+	}, activity.RegisterOptions{Name: "SLABreachActivity"})
+
+	start := env.Now()
+	env.ExecuteWorkflow(SLAClockWorkflow, SLAClockWorkflowInput{
+		ClockID: "clock-1",
+		WarnAt:  start.Add(time.Minute),
+		DueAt:   start.Add(2 * time.Minute),
+	})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.True(t, breached)
+}
@@ -0,0 +1,62 @@
+package sla
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ventros/crm/internal/domain/crm/sla"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// TemporalTimers agenda um workflow por relógio de SLA
+type TemporalTimers struct {
+	temporalClient client.Client
+}
+
+// NewTemporalTimers cria os timers sobre o Temporal
+func NewTemporalTimers(temporalClient client.Client) *TemporalTimers {
+	return &TemporalTimers{temporalClient: temporalClient}
+}
+
+// Schedule (re)inicia o timer do relógio
+func (t *TemporalTimers) Schedule(ctx context.Context, clock *sla.Clock) error {
+	options := client.StartWorkflowOptions{
+		ID:        workflowID(clock.ID()),
+		TaskQueue: TaskQueue,
+	}
+	input := SLAClockWorkflowInput{
+		ClockID: clock.ID().String(),
+		WarnAt:  clock.WarnAt(),
+		DueAt:   clock.DueAt(),
+	}
+
+	if _, err := t.temporalClient.ExecuteWorkflow(ctx, options, SLAClockWorkflow, input); err != nil {
+		return fmt.Errorf("failed to start sla clock workflow: %w", err)
+	}
+	return nil
+}
+
+// Cancel encerra o timer do relógio; timer inexistente ou já concluído não é erro
+func (t *TemporalTimers) Cancel(ctx context.Context, clockID uuid.UUID) error {
+	err := t.temporalClient.TerminateWorkflow(ctx, workflowID(clockID), "", "sla clock stopped")
+	var notFound *serviceerror.NotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return fmt.Errorf("failed to terminate sla clock workflow: %w", err)
+	}
+	return nil
+}
+
+func workflowID(clockID uuid.UUID) string {
+	return fmt.Sprintf("sla-clock-%s", clockID.String())
+}
+
+// PollingTimers usado sem Temporal: os relógios são disparados apenas pela varredura periódica
+// (ClockService.ProcessDue), com atraso de até um intervalo de varredura.
+type PollingTimers struct{}
+
+func (PollingTimers) Schedule(ctx context.Context, clock *sla.Clock) error { return nil }
+
+func (PollingTimers) Cancel(ctx context.Context, clockID uuid.UUID) error { return nil }
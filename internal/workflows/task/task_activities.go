@@ -0,0 +1,33 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DueHandler avalia uma tarefa no instante do prazo (implementado por ReminderService)
+type DueHandler interface {
+	Due(ctx context.Context, taskID uuid.UUID, now time.Time) error
+}
+
+// TaskActivities contém as dependências para as activities
+type TaskActivities struct {
+	handler DueHandler
+}
+
+// NewTaskActivities cria as activities de tarefas
+func NewTaskActivities(handler DueHandler) *TaskActivities {
+	return &TaskActivities{handler: handler}
+}
+
+// DueActivity registra o atraso e entrega o lembrete da tarefa
+func (a *TaskActivities) DueActivity(ctx context.Context, input TaskDueActivityInput) error {
+	taskID, err := uuid.Parse(input.TaskID)
+	if err != nil {
+		return fmt.Errorf("invalid task id: %w", err)
+	}
+	return a.handler.Due(ctx, taskID, time.Now())
+}
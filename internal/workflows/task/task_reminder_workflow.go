@@ -0,0 +1,42 @@
+package task
+
+import (
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TaskQueue fila Temporal dos lembretes de tarefas
+const TaskQueue = "task-reminders"
+
+// TaskReminderWorkflowInput prazo de uma tarefa
+type TaskReminderWorkflowInput struct {
+	TaskID string    `json:"task_id"`
+	DueAt  time.Time `json:"due_at"`
+}
+
+// TaskDueActivityInput tarefa a avaliar
+type TaskDueActivityInput struct {
+	TaskID string `json:"task_id"`
+}
+
+// TaskReminderWorkflow dorme até o prazo e dispara o lembrete/atraso da tarefa. A activity
+// consulta o estado atual, então uma tarefa concluída antes do prazo resulta em no-op. O
+// workflow é substituído quando o prazo muda e encerrado (terminate) quando a tarefa fecha.
+func TaskReminderWorkflow(ctx workflow.Context, input TaskReminderWorkflowInput) error {
+	activityCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval: 5 * time.Second,
+			MaximumAttempts: 5,
+		},
+	})
+
+	if d := input.DueAt.Sub(workflow.Now(ctx)); d > 0 {
+		if err := workflow.Sleep(ctx, d); err != nil {
+			return err
+		}
+	}
+	return workflow.ExecuteActivity(activityCtx, "TaskDueActivity", TaskDueActivityInput{TaskID: input.TaskID}).Get(ctx, nil)
+}
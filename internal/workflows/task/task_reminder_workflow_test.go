@@ -0,0 +1,56 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func TestTaskReminderWorkflow_FiresAtDueTime(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	var firedAt []time.Time
+	var taskIDs []string
+	env.RegisterActivityWithOptions(func(input TaskDueActivityInput) error {
+		firedAt = append(firedAt, env.Now())
+		taskIDs = append(taskIDs, input.TaskID)
+		return nil
+	}, activity.RegisterOptions{Name: "TaskDueActivity"})
+
+	start := env.Now()
+	env.ExecuteWorkflow(TaskReminderWorkflow, TaskReminderWorkflowInput{
+		TaskID: "task-1",
+		DueAt:  start.Add(3 * time.Hour),
+	})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	require.Len(t, firedAt, 1)
+	assert.Equal(t, []string{"task-1"}, taskIDs)
+	assert.False(t, firedAt[0].Before(start.Add(3*time.Hour)))
+}
+
+func TestTaskReminderWorkflow_PastDueFiresImmediately(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	fired := false
+	env.RegisterActivityWithOptions(func(input TaskDueActivityInput) error {
+		fired = true
+		return nil
+	}, activity.RegisterOptions{Name: "TaskDueActivity"})
+
+	env.ExecuteWorkflow(TaskReminderWorkflow, TaskReminderWorkflowInput{
+		TaskID: "task-1",
+		DueAt:  env.Now().Add(-time.Hour),
+	})
+
+	assert.True(t, env.IsWorkflowCompleted())
+	assert.NoError(t, env.GetWorkflowError())
+	assert.True(t, fired)
+}
@@ -0,0 +1,65 @@
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+// TemporalTimers agenda um workflow por tarefa com prazo
+type TemporalTimers struct {
+	temporalClient client.Client
+}
+
+// NewTemporalTimers cria os timers sobre o Temporal
+func NewTemporalTimers(temporalClient client.Client) *TemporalTimers {
+	return &TemporalTimers{temporalClient: temporalClient}
+}
+
+// Schedule (re)inicia o timer da tarefa; um timer anterior (prazo antigo) é encerrado
+func (t *TemporalTimers) Schedule(ctx context.Context, taskID uuid.UUID, dueAt time.Time) error {
+	options := client.StartWorkflowOptions{
+		ID:                       workflowID(taskID),
+		TaskQueue:                TaskQueue,
+		WorkflowIDConflictPolicy: enumspb.WORKFLOW_ID_CONFLICT_POLICY_TERMINATE_EXISTING,
+	}
+	input := TaskReminderWorkflowInput{
+		TaskID: taskID.String(),
+		DueAt:  dueAt,
+	}
+
+	if _, err := t.temporalClient.ExecuteWorkflow(ctx, options, TaskReminderWorkflow, input); err != nil {
+		return fmt.Errorf("failed to start task reminder workflow: %w", err)
+	}
+	return nil
+}
+
+// Cancel encerra o timer da tarefa; timer inexistente ou já concluído não é erro
+func (t *TemporalTimers) Cancel(ctx context.Context, taskID uuid.UUID) error {
+	err := t.temporalClient.TerminateWorkflow(ctx, workflowID(taskID), "", "task closed")
+	var notFound *serviceerror.NotFound
+	if err != nil && !errors.As(err, &notFound) {
+		return fmt.Errorf("failed to terminate task reminder workflow: %w", err)
+	}
+	return nil
+}
+
+func workflowID(taskID uuid.UUID) string {
+	return fmt.Sprintf("task-reminder-%s", taskID.String())
+}
+
+// PollingTimers usado sem Temporal: os prazos são disparados apenas pela varredura periódica
+// (ReminderService.ProcessDue), com atraso de até um intervalo de varredura.
+type PollingTimers struct{}
+
+func (PollingTimers) Schedule(ctx context.Context, taskID uuid.UUID, dueAt time.Time) error {
+	return nil
+}
+
+func (PollingTimers) Cancel(ctx context.Context, taskID uuid.UUID) error { return nil }
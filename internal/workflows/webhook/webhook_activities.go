@@ -4,17 +4,35 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"time"
 
+	"github.com/google/uuid"
+	domainwebhook "github.com/ventros/crm/internal/domain/crm/webhook"
+	"github.com/ventros/crm/pkg/webhooksig"
 	"go.temporal.io/sdk/activity"
 	"go.temporal.io/sdk/temporal"
 )
 
-// DeliverWebhookActivity performs the actual HTTP request to deliver webhook
-func DeliverWebhookActivity(ctx context.Context, input WebhookDeliveryActivity) (*WebhookDeliveryActivityResult, error) {
+// WebhookActivities contém as dependências das activities de entrega e de status
+type WebhookActivities struct {
+	repo       domainwebhook.Repository
+	recorder   DeliveryRecorder
+	httpClient *http.Client
+}
+
+// NewWebhookActivities cria as activities de entrega de webhook
+func NewWebhookActivities(repo domainwebhook.Repository, recorder DeliveryRecorder) *WebhookActivities {
+	return &WebhookActivities{repo: repo, recorder: recorder, httpClient: &http.Client{}}
+}
+
+// DeliverWebhookActivity faz uma única tentativa de entrega. Respostas HTTP (inclusive 4xx/5xx) e
+// falhas de rede voltam no resultado, não como erro, para que o workflow registre cada tentativa
+// no log de entregas e decida o retry.
+func (a *WebhookActivities) DeliverWebhookActivity(ctx context.Context, input WebhookDeliveryActivity) (*WebhookDeliveryActivityResult, error) {
 	logger := activity.GetLogger(ctx)
 	logger.Info("Executing webhook delivery activity", "url", input.URL, "attempt", input.AttemptCount)
 
@@ -24,14 +42,27 @@ func DeliverWebhookActivity(ctx context.Context, input WebhookDeliveryActivity)
 	if input.Payload != nil {
 		requestBody, err = json.Marshal(input.Payload)
 		if err != nil {
-			return nil, temporal.NewApplicationError("failed to marshal payload", "PayloadMarshalError", err)
+			return nil, temporal.NewNonRetryableApplicationError("failed to marshal payload", "PayloadMarshalError", err)
+		}
+	}
+
+	// A assinatura usa os segredos atuais da inscrição (nunca trafegam no histórico do workflow)
+	subscriptionID, err := uuid.Parse(input.WebhookID)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError("invalid webhook id", "InvalidWebhookID", err)
+	}
+	sub, err := a.repo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, domainwebhook.ErrNotFound) {
+			return &WebhookDeliveryActivityResult{Permanent: true, ErrorMessage: "webhook subscription not found"}, nil
 		}
+		return nil, err
 	}
 
-	// Create HTTP request
-	req, err := http.NewRequestWithContext(ctx, input.Method, input.URL, bytes.NewBuffer(requestBody))
+	// Create request
+	req, err := http.NewRequest(input.Method, input.URL, bytes.NewBuffer(requestBody))
 	if err != nil {
-		return nil, temporal.NewApplicationError("failed to create request", "RequestCreationError", err)
+		return nil, temporal.NewNonRetryableApplicationError("failed to create request", "RequestCreationError", err)
 	}
 
 	// Set headers
@@ -39,58 +70,59 @@ func DeliverWebhookActivity(ctx context.Context, input WebhookDeliveryActivity)
 	req.Header.Set("User-Agent", "Ventros-CRM-Webhook/1.0")
 	req.Header.Set("X-Webhook-Attempt", fmt.Sprintf("%d", input.AttemptCount))
 	req.Header.Set("X-Webhook-Timestamp", time.Now().UTC().Format(time.RFC3339))
+	if input.EventID != "" {
+		req.Header.Set("X-Webhook-Event-ID", input.EventID)
+	}
 
 	for key, value := range input.Headers {
 		req.Header.Set(key, value)
 	}
 
-	// Create HTTP client with timeout
-	client := &http.Client{
-		Timeout: time.Duration(input.TimeoutSecs) * time.Second,
+	// Ventros-Signature: HMAC de "t.corpo" com cada segredo ativo, t renovado a cada tentativa
+	signedAt := time.Now()
+	if secrets := sub.SigningSecrets(signedAt); len(secrets) > 0 {
+		req.Header.Set(webhooksig.HeaderName, webhooksig.Sign(requestBody, signedAt, secrets...))
 	}
 
+	result := &WebhookDeliveryActivityResult{
+		RequestHeaders: req.Header.Clone(),
+		RequestBody:    string(requestBody),
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, time.Duration(input.TimeoutSecs)*time.Second)
+	defer cancel()
+
 	// Execute request
-	resp, err := client.Do(req)
+	start := time.Now()
+	resp, err := a.httpClient.Do(req.WithContext(reqCtx))
+	result.LatencyMs = time.Since(start).Milliseconds()
 	if err != nil {
-		logger.Error("HTTP request failed", "error", err.Error())
-		return nil, temporal.NewApplicationError("HTTP request failed", "HTTPRequestError", err)
+		logger.Warn("HTTP request failed", "error", err.Error())
+		result.ErrorMessage = fmt.Sprintf("failed to send request: %v", err)
+		return result, nil
 	}
 	defer resp.Body.Close()
 
-	// Read response body
-	responseBody, err := io.ReadAll(resp.Body)
+	// Read response body (o log guarda no máximo MaxLoggedResponseBytes)
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, domainwebhook.MaxLoggedResponseBytes+1))
 	if err != nil {
 		logger.Warn("Failed to read response body", "error", err.Error())
 		responseBody = []byte("failed to read response")
 	}
+	result.StatusCode = resp.StatusCode
+	result.ResponseBody = string(responseBody)
 
-	result := &WebhookDeliveryActivityResult{
-		StatusCode:   resp.StatusCode,
-		ResponseBody: string(responseBody),
-	}
-
-	// Check status code
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		// Success
 		result.Success = true
 		logger.Info("Webhook delivered successfully", "status_code", resp.StatusCode)
 		return result, nil
 	}
 
-	// Handle different error types
-	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-		// Client error - permanent error, don't retry
-		errorMsg := fmt.Sprintf("permanent webhook error: status %d, body: %s", resp.StatusCode, string(responseBody))
-		result.ErrorMessage = errorMsg
-		logger.Error("Permanent webhook error", "status_code", resp.StatusCode, "response", string(responseBody))
-		return result, temporal.NewApplicationError(errorMsg, "PermanentWebhookError")
-	}
-
-	// Server error (5xx) or other - temporary error, retry
-	errorMsg := fmt.Sprintf("temporary webhook error: status %d, body: %s", resp.StatusCode, string(responseBody))
-	result.ErrorMessage = errorMsg
-	logger.Warn("Temporary webhook error", "status_code", resp.StatusCode, "response", string(responseBody))
-	return result, temporal.NewApplicationError(errorMsg, "TemporaryWebhookError")
+	// 4xx: erro permanente, sem retry; 5xx: erro temporário
+	result.Permanent = resp.StatusCode >= 400 && resp.StatusCode < 500
+	result.ErrorMessage = fmt.Sprintf("endpoint returned status %d", resp.StatusCode)
+	logger.Warn("Webhook delivery failed", "status_code", resp.StatusCode, "permanent", result.Permanent)
+	return result, nil
 }
 
 // CompensateWebhookActivity handles webhook delivery failure compensation
@@ -114,28 +146,77 @@ func CompensateWebhookActivity(ctx context.Context, input WebhookCompensationAct
 	return result, nil
 }
 
-// WebhookStatusUpdateActivity updates webhook status in database
-func WebhookStatusUpdateActivity(ctx context.Context, input WebhookStatusUpdateInput) error {
+// DeliveryRecorder registra tentativas e resultado final das entregas (webhookapp.DeliveryRecorder)
+type DeliveryRecorder interface {
+	Record(ctx context.Context, d *domainwebhook.Delivery)
+	RecordOutcome(ctx context.Context, sub *domainwebhook.WebhookSubscription, success bool, lastError string)
+}
+
+// WebhookStatusUpdateActivity registra a tentativa no log de entregas e, quando o status é final
+// (delivered ou failed), atualiza as estatísticas da inscrição (com desativação automática)
+func (a *WebhookActivities) WebhookStatusUpdateActivity(ctx context.Context, input WebhookStatusUpdateInput) error {
 	logger := activity.GetLogger(ctx)
 	logger.Info("Updating webhook status", "webhook_id", input.WebhookID, "status", input.Status)
 
-	// TODO: Implement database update
-	// This would update the webhook record with:
-	// - Status (pending, delivered, failed)
-	// - Attempt count
-	// - Last attempt timestamp
-	// - Response details
-	// - Error message if failed
+	subscriptionID, err := uuid.Parse(input.WebhookID)
+	if err != nil {
+		return temporal.NewNonRetryableApplicationError("invalid webhook id", "InvalidWebhookID", err)
+	}
+	eventID, err := uuid.Parse(input.EventID)
+	if err != nil {
+		return temporal.NewNonRetryableApplicationError("invalid event id", "InvalidEventID", err)
+	}
 
+	sub, err := a.repo.FindByID(ctx, subscriptionID)
+	if err != nil {
+		if errors.Is(err, domainwebhook.ErrNotFound) {
+			logger.Warn("Webhook subscription not found, skipping status update", "webhook_id", input.WebhookID)
+			return nil
+		}
+		return err
+	}
+
+	delivery := domainwebhook.NewDelivery(sub, eventID, input.EventType, domainwebhook.DeliveryTriggerEvent, input.AttemptCount, input.RequestHeaders, []byte(input.RequestBody))
+	delivery.CreatedAt = input.LastAttemptAt
+	latency := time.Duration(input.LatencyMs) * time.Millisecond
+	switch {
+	case input.StatusCode != nil:
+		body := ""
+		if input.ResponseBody != nil {
+			body = *input.ResponseBody
+		}
+		delivery.RecordResponse(*input.StatusCode, []byte(body), latency)
+	case input.ErrorMessage != nil:
+		delivery.RecordError(errors.New(*input.ErrorMessage), latency)
+	}
+	a.recorder.Record(ctx, delivery)
+
+	switch input.Status {
+	case WebhookStatusDelivered:
+		a.recorder.RecordOutcome(ctx, sub, true, "")
+	case WebhookStatusFailed:
+		a.recorder.RecordOutcome(ctx, sub, false, delivery.Error)
+	}
 	return nil
 }
 
+const (
+	WebhookStatusPending   = "pending" // Tentativa falhou, haverá retry
+	WebhookStatusDelivered = "delivered"
+	WebhookStatusFailed    = "failed" // Retries esgotados
+)
+
 type WebhookStatusUpdateInput struct {
-	WebhookID     string    `json:"webhook_id"`
-	Status        string    `json:"status"` // pending, delivered, failed
-	AttemptCount  int       `json:"attempt_count"`
-	LastAttemptAt time.Time `json:"last_attempt_at"`
-	StatusCode    *int      `json:"status_code,omitempty"`
-	ResponseBody  *string   `json:"response_body,omitempty"`
-	ErrorMessage  *string   `json:"error_message,omitempty"`
+	WebhookID      string      `json:"webhook_id"`
+	EventID        string      `json:"event_id"`
+	EventType      string      `json:"event_type"`
+	Status         string      `json:"status"` // pending, delivered, failed
+	AttemptCount   int         `json:"attempt_count"`
+	LastAttemptAt  time.Time   `json:"last_attempt_at"`
+	RequestHeaders http.Header `json:"request_headers,omitempty"`
+	RequestBody    string      `json:"request_body,omitempty"`
+	LatencyMs      int64       `json:"latency_ms"`
+	StatusCode     *int        `json:"status_code,omitempty"`
+	ResponseBody   *string     `json:"response_body,omitempty"`
+	ErrorMessage   *string     `json:"error_message,omitempty"`
 }
@@ -0,0 +1,86 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	domainwebhook "github.com/ventros/crm/internal/domain/crm/webhook"
+	"github.com/ventros/crm/pkg/webhooksig"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type fakeWebhookRepository struct {
+	domainwebhook.Repository
+	subs map[uuid.UUID]*domainwebhook.WebhookSubscription
+}
+
+func (r *fakeWebhookRepository) FindByID(ctx context.Context, id uuid.UUID) (*domainwebhook.WebhookSubscription, error) {
+	sub, ok := r.subs[id]
+	if !ok {
+		return nil, domainwebhook.ErrNotFound
+	}
+	return sub, nil
+}
+
+func TestDeliverWebhookActivity_SignsWithVentrosSignature(t *testing.T) {
+	sub, err := domainwebhook.NewWebhookSubscription(uuid.New(), uuid.New(), "tenant-1", "CRM", "https://example.com", []string{"contact.created"})
+	require.NoError(t, err)
+	sub.Secret = "whsec_test"
+
+	var received *http.Request
+	var receivedBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		receivedBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(NewWebhookActivities(&fakeWebhookRepository{subs: map[uuid.UUID]*domainwebhook.WebhookSubscription{sub.ID: sub}}, nil))
+
+	value, err := env.ExecuteActivity("DeliverWebhookActivity", WebhookDeliveryActivity{
+		WebhookID:    sub.ID.String(),
+		EventID:      uuid.NewString(),
+		URL:          server.URL,
+		Method:       http.MethodPost,
+		Payload:      map[string]interface{}{"event": "contact.created"},
+		TimeoutSecs:  5,
+		AttemptCount: 1,
+	})
+	require.NoError(t, err)
+
+	var result WebhookDeliveryActivityResult
+	require.NoError(t, value.Get(&result))
+	assert.True(t, result.Success)
+
+	require.NotNil(t, received)
+	assert.Empty(t, received.Header.Get("X-Webhook-Signature"))
+	assert.NoError(t, webhooksig.Verify(receivedBody, received.Header.Get(webhooksig.HeaderName), "whsec_test", webhooksig.DefaultTolerance))
+}
+
+func TestDeliverWebhookActivity_DeletedSubscriptionIsPermanent(t *testing.T) {
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestActivityEnvironment()
+	env.RegisterActivity(NewWebhookActivities(&fakeWebhookRepository{}, nil))
+
+	value, err := env.ExecuteActivity("DeliverWebhookActivity", WebhookDeliveryActivity{
+		WebhookID:   uuid.NewString(),
+		URL:         "http://127.0.0.1:1",
+		Method:      http.MethodPost,
+		TimeoutSecs: 1,
+	})
+	require.NoError(t, err)
+
+	var result WebhookDeliveryActivityResult
+	require.NoError(t, value.Get(&result))
+	assert.False(t, result.Success)
+	assert.True(t, result.Permanent)
+}
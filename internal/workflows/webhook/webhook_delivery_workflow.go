@@ -1,15 +1,26 @@
 package webhook
 
 import (
+	"net/http"
 	"time"
 
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
 )
 
+// TaskQueue fila Temporal das entregas de webhook
+const TaskQueue = "webhook-delivery"
+
+const (
+	deliveryInitialBackoff = time.Second
+	deliveryMaxBackoff     = 5 * time.Minute
+)
+
 // WebhookDeliveryWorkflowInput represents the input for webhook delivery workflow
 type WebhookDeliveryWorkflowInput struct {
 	WebhookID   string                 `json:"webhook_id"`
+	EventID     string                 `json:"event_id"` // Mesmo valor em todas as tentativas (idempotência no receptor)
+	EventType   string                 `json:"event_type"`
 	URL         string                 `json:"url"`
 	Method      string                 `json:"method"`
 	Headers     map[string]string      `json:"headers"`
@@ -31,6 +42,8 @@ type WebhookDeliveryWorkflowResult struct {
 
 // WebhookDeliveryActivity represents the activity input/output
 type WebhookDeliveryActivity struct {
+	WebhookID    string                 `json:"webhook_id"`
+	EventID      string                 `json:"event_id"`
 	URL          string                 `json:"url"`
 	Method       string                 `json:"method"`
 	Headers      map[string]string      `json:"headers"`
@@ -40,13 +53,19 @@ type WebhookDeliveryActivity struct {
 }
 
 type WebhookDeliveryActivityResult struct {
-	Success      bool   `json:"success"`
-	StatusCode   int    `json:"status_code"`
-	ResponseBody string `json:"response_body"`
-	ErrorMessage string `json:"error_message,omitempty"`
+	Success        bool        `json:"success"`
+	Permanent      bool        `json:"permanent"` // 4xx: não adianta tentar de novo
+	StatusCode     int         `json:"status_code"`
+	ResponseBody   string      `json:"response_body"`
+	ErrorMessage   string      `json:"error_message,omitempty"`
+	RequestHeaders http.Header `json:"request_headers,omitempty"`
+	RequestBody    string      `json:"request_body,omitempty"`
+	LatencyMs      int64       `json:"latency_ms"`
 }
 
-// WebhookDeliveryWorkflow implements webhook delivery with exponential backoff retry
+// WebhookDeliveryWorkflow entrega o webhook com backoff exponencial. Cada tentativa é uma execução
+// da activity de entrega (sem retry interno do Temporal), seguida da activity de status, que grava a
+// tentativa no log de entregas e, no resultado final, atualiza as estatísticas da inscrição.
 func WebhookDeliveryWorkflow(ctx workflow.Context, input WebhookDeliveryWorkflowInput) (*WebhookDeliveryWorkflowResult, error) {
 	logger := workflow.GetLogger(ctx)
 	logger.Info("Starting webhook delivery workflow", "webhook_id", input.WebhookID, "url", input.URL)
@@ -56,28 +75,33 @@ func WebhookDeliveryWorkflow(ctx workflow.Context, input WebhookDeliveryWorkflow
 		AttemptCount: 0,
 	}
 
-	// Configure retry policy with exponential backoff
-	retryPolicy := &temporal.RetryPolicy{
-		InitialInterval:        time.Second * 1,
-		BackoffCoefficient:     2.0,
-		MaximumInterval:        time.Minute * 5,
-		MaximumAttempts:        int32(input.MaxRetries),
-		NonRetryableErrorTypes: []string{"PermanentWebhookError"},
+	maxAttempts := input.MaxRetries
+	if maxAttempts < 1 {
+		maxAttempts = 1
 	}
 
-	// Configure activity options
-	activityOptions := workflow.ActivityOptions{
-		StartToCloseTimeout: time.Duration(input.TimeoutSecs) * time.Second,
-		RetryPolicy:         retryPolicy,
-	}
-	ctx = workflow.WithActivityOptions(ctx, activityOptions)
-
-	// Attempt webhook delivery
-	for attempt := 1; attempt <= input.MaxRetries; attempt++ {
+	// Uma tentativa por execução: o retry fica no laço abaixo para que toda tentativa seja registrada
+	deliveryCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: time.Duration(input.TimeoutSecs)*time.Second + 30*time.Second,
+		RetryPolicy:         &temporal.RetryPolicy{MaximumAttempts: 1},
+	})
+	statusCtx := workflow.WithActivityOptions(ctx, workflow.ActivityOptions{
+		StartToCloseTimeout: 30 * time.Second,
+		RetryPolicy: &temporal.RetryPolicy{
+			InitialInterval:    time.Second,
+			BackoffCoefficient: 2.0,
+			MaximumAttempts:    5,
+		},
+	})
+
+	backoff := deliveryInitialBackoff
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
 		result.AttemptCount = attempt
 		result.LastAttemptAt = workflow.Now(ctx)
 
 		activityInput := WebhookDeliveryActivity{
+			WebhookID:    input.WebhookID,
+			EventID:      input.EventID,
 			URL:          input.URL,
 			Method:       input.Method,
 			Headers:      input.Headers,
@@ -87,36 +111,41 @@ func WebhookDeliveryWorkflow(ctx workflow.Context, input WebhookDeliveryWorkflow
 		}
 
 		var activityResult WebhookDeliveryActivityResult
-		err := workflow.ExecuteActivity(ctx, DeliverWebhookActivity, activityInput).Get(ctx, &activityResult)
-
-		if err != nil {
-			logger.Warn("Webhook delivery attempt failed", "attempt", attempt, "error", err.Error())
-			result.ErrorMessage = err.Error()
-
-			// Check if it's a permanent error (4xx status codes)
-			if temporal.IsApplicationError(err) {
-				appErr := err.(*temporal.ApplicationError)
-				if appErr.Type() == "PermanentWebhookError" {
-					logger.Error("Permanent webhook error, stopping retries", "error", err.Error())
-					break
-				}
-			}
-
-			// If this is the last attempt, break
-			if attempt == input.MaxRetries {
-				break
-			}
-
-			// Wait before next attempt (exponential backoff handled by Temporal)
-			continue
+		if err := workflow.ExecuteActivity(deliveryCtx, "DeliverWebhookActivity", activityInput).Get(ctx, &activityResult); err != nil {
+			activityResult = WebhookDeliveryActivityResult{ErrorMessage: err.Error()}
 		}
 
-		// Success!
-		result.Success = true
+		result.Success = activityResult.Success
 		result.StatusCode = activityResult.StatusCode
 		result.ResponseBody = activityResult.ResponseBody
-		logger.Info("Webhook delivered successfully", "attempt", attempt, "status_code", activityResult.StatusCode)
-		break
+		result.ErrorMessage = activityResult.ErrorMessage
+
+		last := activityResult.Success || activityResult.Permanent || attempt == maxAttempts
+		status := WebhookStatusPending
+		switch {
+		case activityResult.Success:
+			status = WebhookStatusDelivered
+		case last:
+			status = WebhookStatusFailed
+		}
+		recordStatus(statusCtx, input, attempt, result.LastAttemptAt, status, activityResult)
+
+		if activityResult.Success {
+			logger.Info("Webhook delivered successfully", "attempt", attempt, "status_code", activityResult.StatusCode)
+			break
+		}
+		logger.Warn("Webhook delivery attempt failed", "attempt", attempt, "error", activityResult.ErrorMessage)
+		if last {
+			break
+		}
+
+		if err := workflow.Sleep(ctx, backoff); err != nil {
+			return result, err
+		}
+		backoff *= 2
+		if backoff > deliveryMaxBackoff {
+			backoff = deliveryMaxBackoff
+		}
 	}
 
 	// If all attempts failed, trigger compensation
@@ -148,6 +177,34 @@ func WebhookDeliveryWorkflow(ctx workflow.Context, input WebhookDeliveryWorkflow
 	return result, nil
 }
 
+// recordStatus grava a tentativa; falha no registro não interrompe a entrega
+func recordStatus(ctx workflow.Context, input WebhookDeliveryWorkflowInput, attempt int, attemptedAt time.Time, status string, delivery WebhookDeliveryActivityResult) {
+	statusInput := WebhookStatusUpdateInput{
+		WebhookID:      input.WebhookID,
+		EventID:        input.EventID,
+		EventType:      input.EventType,
+		Status:         status,
+		AttemptCount:   attempt,
+		LastAttemptAt:  attemptedAt,
+		RequestHeaders: delivery.RequestHeaders,
+		RequestBody:    delivery.RequestBody,
+		LatencyMs:      delivery.LatencyMs,
+	}
+	if delivery.StatusCode != 0 {
+		statusCode := delivery.StatusCode
+		responseBody := delivery.ResponseBody
+		statusInput.StatusCode = &statusCode
+		statusInput.ResponseBody = &responseBody
+	} else if delivery.ErrorMessage != "" {
+		errorMessage := delivery.ErrorMessage
+		statusInput.ErrorMessage = &errorMessage
+	}
+
+	if err := workflow.ExecuteActivity(ctx, "WebhookStatusUpdateActivity", statusInput).Get(ctx, nil); err != nil {
+		workflow.GetLogger(ctx).Error("Failed to record webhook delivery status", "attempt", attempt, "error", err.Error())
+	}
+}
+
 // WebhookCompensationActivity represents compensation activity input
 type WebhookCompensationActivity struct {
 	WebhookID    string `json:"webhook_id"`
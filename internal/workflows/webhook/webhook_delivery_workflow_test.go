@@ -0,0 +1,107 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.temporal.io/sdk/activity"
+	"go.temporal.io/sdk/testsuite"
+)
+
+func newDeliveryEnv(t *testing.T, responses []WebhookDeliveryActivityResult) (*testsuite.TestWorkflowEnvironment, *[]WebhookStatusUpdateInput, *bool) {
+	t.Helper()
+	testSuite := &testsuite.WorkflowTestSuite{}
+	env := testSuite.NewTestWorkflowEnvironment()
+
+	calls := 0
+	env.RegisterActivityWithOptions(func(input WebhookDeliveryActivity) (*WebhookDeliveryActivityResult, error) {
+		result := responses[calls]
+		calls++
+		return &result, nil
+	}, activity.RegisterOptions{Name: "DeliverWebhookActivity"})
+
+	var statuses []WebhookStatusUpdateInput
+	env.RegisterActivityWithOptions(func(input WebhookStatusUpdateInput) error {
+		statuses = append(statuses, input)
+		return nil
+	}, activity.RegisterOptions{Name: "WebhookStatusUpdateActivity"})
+
+	compensated := false
+	env.RegisterActivityWithOptions(func(input WebhookCompensationActivity) (*WebhookCompensationActivityResult, error) {
+		compensated = true
+		return &WebhookCompensationActivityResult{Action: "webhook_marked_as_failed"}, nil
+	}, activity.RegisterOptions{Name: "CompensateWebhookActivity"})
+
+	return env, &statuses, &compensated
+}
+
+func deliveryInput() WebhookDeliveryWorkflowInput {
+	return WebhookDeliveryWorkflowInput{
+		WebhookID:   "3f0d3a57-9d7e-4c61-9a43-2f4f1f0c6a11",
+		EventID:     "b7a4c1e2-6a0e-4c1d-8c55-5a9b3c2e1f00",
+		EventType:   "contact.created",
+		URL:         "https://example.com/hook",
+		Method:      "POST",
+		MaxRetries:  3,
+		TimeoutSecs: 10,
+	}
+}
+
+func TestWebhookDeliveryWorkflow_RecordsEveryAttempt(t *testing.T) {
+	env, statuses, compensated := newDeliveryEnv(t, []WebhookDeliveryActivityResult{
+		{StatusCode: 503, ResponseBody: "unavailable", ErrorMessage: "endpoint returned status 503"},
+		{Success: true, StatusCode: 200, ResponseBody: "ok"},
+	})
+
+	env.ExecuteWorkflow(WebhookDeliveryWorkflow, deliveryInput())
+
+	require.True(t, env.IsWorkflowCompleted())
+	require.NoError(t, env.GetWorkflowError())
+	var result WebhookDeliveryWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.True(t, result.Success)
+	assert.Equal(t, 2, result.AttemptCount)
+	assert.False(t, *compensated)
+
+	require.Len(t, *statuses, 2)
+	assert.Equal(t, WebhookStatusPending, (*statuses)[0].Status)
+	assert.Equal(t, 503, *(*statuses)[0].StatusCode)
+	assert.Equal(t, 1, (*statuses)[0].AttemptCount)
+	assert.Equal(t, WebhookStatusDelivered, (*statuses)[1].Status)
+	assert.Equal(t, 2, (*statuses)[1].AttemptCount)
+	assert.Equal(t, "contact.created", (*statuses)[1].EventType)
+}
+
+func TestWebhookDeliveryWorkflow_PermanentErrorStopsRetries(t *testing.T) {
+	env, statuses, compensated := newDeliveryEnv(t, []WebhookDeliveryActivityResult{
+		{Permanent: true, StatusCode: 410, ErrorMessage: "endpoint returned status 410"},
+	})
+
+	env.ExecuteWorkflow(WebhookDeliveryWorkflow, deliveryInput())
+
+	require.True(t, env.IsWorkflowCompleted())
+	var result WebhookDeliveryWorkflowResult
+	require.NoError(t, env.GetWorkflowResult(&result))
+	assert.False(t, result.Success)
+	assert.Equal(t, 1, result.AttemptCount)
+	assert.True(t, *compensated)
+
+	require.Len(t, *statuses, 1)
+	assert.Equal(t, WebhookStatusFailed, (*statuses)[0].Status)
+}
+
+func TestWebhookDeliveryWorkflow_NetworkErrorsExhaustRetries(t *testing.T) {
+	failure := WebhookDeliveryActivityResult{ErrorMessage: "failed to send request: connection refused"}
+	env, statuses, compensated := newDeliveryEnv(t, []WebhookDeliveryActivityResult{failure, failure, failure})
+
+	env.ExecuteWorkflow(WebhookDeliveryWorkflow, deliveryInput())
+
+	require.True(t, env.IsWorkflowCompleted())
+	assert.True(t, *compensated)
+	require.Len(t, *statuses, 3)
+	assert.Equal(t, WebhookStatusPending, (*statuses)[1].Status)
+	assert.Equal(t, WebhookStatusFailed, (*statuses)[2].Status)
+	assert.Nil(t, (*statuses)[2].StatusCode)
+	assert.Equal(t, failure.ErrorMessage, *(*statuses)[2].ErrorMessage)
+}
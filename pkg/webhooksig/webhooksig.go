@@ -0,0 +1,153 @@
+// Package webhooksig assina e verifica o header Ventros-Signature enviado nos webhooks do Ventros CRM.
+//
+// Formato do header:
+//
+//	Ventros-Signature: t=1714000000,v1=5257a869e7ecebeda32affa62cdca3fa51cad7e77a0e56ff536d0ce8e108d8bd
+//
+// t é o instante do envio (Unix, segundos) e v1 é o HMAC-SHA256 hexadecimal de "t.corpo" com o
+// segredo da inscrição. Durante uma rotação de segredo o header traz um v1 por segredo ativo;
+// basta um deles conferir. Cada tentativa (retry ou reenvio) é assinada com um t novo, então
+// rejeitar timestamps fora da tolerância impede replay de requisições capturadas.
+//
+// Uso típico no receptor:
+//
+//	body, err := webhooksig.VerifyRequest(r, os.Getenv("VENTROS_WEBHOOK_SECRET"), webhooksig.DefaultTolerance)
+//	if err != nil {
+//		http.Error(w, "invalid signature", http.StatusUnauthorized)
+//		return
+//	}
+package webhooksig
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HeaderName header com a assinatura
+const HeaderName = "Ventros-Signature"
+
+// DefaultTolerance diferença máxima aceita entre o timestamp assinado e o relógio do receptor
+const DefaultTolerance = 5 * time.Minute
+
+const schemeV1 = "v1"
+
+var (
+	ErrMissingHeader       = errors.New("webhooksig: missing signature header")
+	ErrInvalidHeader       = errors.New("webhooksig: invalid signature header")
+	ErrNoValidSignature    = errors.New("webhooksig: no signature matches the payload")
+	ErrTimestampOutOfRange = errors.New("webhooksig: timestamp outside the tolerance window")
+)
+
+// Sign monta o valor do header para o corpo no instante informado, com um v1 por segredo
+func Sign(body []byte, at time.Time, secrets ...string) string {
+	timestamp := at.Unix()
+	parts := make([]string, 0, len(secrets)+1)
+	parts = append(parts, "t="+strconv.FormatInt(timestamp, 10))
+	for _, secret := range secrets {
+		if secret == "" {
+			continue
+		}
+		parts = append(parts, schemeV1+"="+ComputeSignature(timestamp, body, secret))
+	}
+	return strings.Join(parts, ",")
+}
+
+// ComputeSignature HMAC-SHA256 hexadecimal de "timestamp.body"
+func ComputeSignature(timestamp int64, body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Parse extrai o timestamp e as assinaturas v1 do header. Esquemas desconhecidos são ignorados
+// para permitir novas versões sem quebrar receptores antigos.
+func Parse(header string) (time.Time, []string, error) {
+	if strings.TrimSpace(header) == "" {
+		return time.Time{}, nil, ErrMissingHeader
+	}
+
+	var timestamp int64
+	var hasTimestamp bool
+	var signatures []string
+	for _, part := range strings.Split(header, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return time.Time{}, nil, ErrInvalidHeader
+		}
+		switch key {
+		case "t":
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return time.Time{}, nil, ErrInvalidHeader
+			}
+			timestamp, hasTimestamp = t, true
+		case schemeV1:
+			signatures = append(signatures, value)
+		}
+	}
+	if !hasTimestamp || len(signatures) == 0 {
+		return time.Time{}, nil, ErrInvalidHeader
+	}
+	return time.Unix(timestamp, 0), signatures, nil
+}
+
+// Verify confere o header contra o corpo recebido e o segredo, usando o relógio atual.
+// tolerance <= 0 usa DefaultTolerance.
+func Verify(body []byte, header, secret string, tolerance time.Duration) error {
+	return VerifyAt(body, header, secret, tolerance, time.Now())
+}
+
+// VerifyAt igual a Verify, com o instante de referência explícito
+func VerifyAt(body []byte, header, secret string, tolerance time.Duration, now time.Time) error {
+	if tolerance <= 0 {
+		tolerance = DefaultTolerance
+	}
+
+	signedAt, signatures, err := Parse(header)
+	if err != nil {
+		return err
+	}
+
+	expected := ComputeSignature(signedAt.Unix(), body, secret)
+	matched := false
+	for _, signature := range signatures {
+		if hmac.Equal([]byte(signature), []byte(expected)) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return ErrNoValidSignature
+	}
+
+	if skew := now.Sub(signedAt); skew > tolerance || skew < -tolerance {
+		return fmt.Errorf("%w: signed at %s", ErrTimestampOutOfRange, signedAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// VerifyRequest lê o corpo da requisição, verifica o header Ventros-Signature e devolve o corpo.
+// r.Body é recolocado para que o handler possa lê-lo novamente.
+func VerifyRequest(r *http.Request, secret string, tolerance time.Duration) ([]byte, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("webhooksig: failed to read body: %w", err)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := Verify(body, r.Header.Get(HeaderName), secret, tolerance); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
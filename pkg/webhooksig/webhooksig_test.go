@@ -0,0 +1,111 @@
+package webhooksig
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var body = []byte(`{"id":"b9a1f6d4-1f5c-4a4e-9f51-0c7a2d3e4f50","event":"contact.created"}`)
+
+func TestSign_Format(t *testing.T) {
+	at := time.Unix(1714000000, 0)
+
+	header := Sign(body, at, "whsec_new", "", "whsec_old")
+
+	parts := strings.Split(header, ",")
+	require.Len(t, parts, 3, "empty secrets are skipped")
+	assert.Equal(t, "t=1714000000", parts[0])
+	assert.Equal(t, "v1="+ComputeSignature(1714000000, body, "whsec_new"), parts[1])
+	assert.Equal(t, "v1="+ComputeSignature(1714000000, body, "whsec_old"), parts[2])
+	assert.NotEqual(t, ComputeSignature(1714000000, body, "whsec_new"), ComputeSignature(1714000001, body, "whsec_new"),
+		"timestamp is part of the signed content")
+}
+
+func TestVerifyAt_ToleranceWindow(t *testing.T) {
+	signedAt := time.Unix(1714000000, 0)
+	header := Sign(body, signedAt, "whsec_test")
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		tolerance time.Duration
+		wantErr   error
+	}{
+		{name: "same instant", now: signedAt, tolerance: time.Minute},
+		{name: "late within tolerance", now: signedAt.Add(59 * time.Second), tolerance: time.Minute},
+		{name: "exactly at the limit", now: signedAt.Add(time.Minute), tolerance: time.Minute},
+		{name: "too old (replay)", now: signedAt.Add(61 * time.Second), tolerance: time.Minute, wantErr: ErrTimestampOutOfRange},
+		{name: "receiver clock behind", now: signedAt.Add(-30 * time.Second), tolerance: time.Minute},
+		{name: "too far in the future", now: signedAt.Add(-2 * time.Minute), tolerance: time.Minute, wantErr: ErrTimestampOutOfRange},
+		{name: "zero tolerance uses default", now: signedAt.Add(DefaultTolerance - time.Second)},
+		{name: "default tolerance exceeded", now: signedAt.Add(DefaultTolerance + time.Second), wantErr: ErrTimestampOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyAt(body, header, "whsec_test", tt.tolerance, tt.now)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVerifyAt_RotationWindow(t *testing.T) {
+	now := time.Now()
+	header := Sign(body, now, "whsec_new", "whsec_old")
+
+	assert.NoError(t, VerifyAt(body, header, "whsec_new", 0, now), "receiver already on the new secret")
+	assert.NoError(t, VerifyAt(body, header, "whsec_old", 0, now), "receiver still on the old secret")
+	assert.ErrorIs(t, VerifyAt(body, header, "whsec_other", 0, now), ErrNoValidSignature)
+}
+
+func TestVerifyAt_Rejects(t *testing.T) {
+	now := time.Now()
+	header := Sign(body, now, "whsec_test")
+
+	t.Run("tampered body", func(t *testing.T) {
+		tampered := bytes.Replace(body, []byte("created"), []byte("deleted"), 1)
+		assert.ErrorIs(t, VerifyAt(tampered, header, "whsec_test", 0, now), ErrNoValidSignature)
+	})
+
+	t.Run("tampered timestamp", func(t *testing.T) {
+		forged := strings.Replace(header, "t=", "t=1", 1)
+		assert.ErrorIs(t, VerifyAt(body, forged, "whsec_test", 0, now), ErrNoValidSignature)
+	})
+
+	t.Run("malformed headers", func(t *testing.T) {
+		assert.ErrorIs(t, VerifyAt(body, "", "whsec_test", 0, now), ErrMissingHeader)
+		assert.ErrorIs(t, VerifyAt(body, "v1=abc", "whsec_test", 0, now), ErrInvalidHeader)
+		assert.ErrorIs(t, VerifyAt(body, "t=abc,v1=abc", "whsec_test", 0, now), ErrInvalidHeader)
+		assert.ErrorIs(t, VerifyAt(body, "t=1714000000", "whsec_test", 0, now), ErrInvalidHeader)
+		assert.ErrorIs(t, VerifyAt(body, "garbage", "whsec_test", 0, now), ErrInvalidHeader)
+	})
+
+	t.Run("unknown schemes are ignored", func(t *testing.T) {
+		assert.NoError(t, VerifyAt(body, header+",v2=future", "whsec_test", 0, now))
+	})
+}
+
+func TestVerifyRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/hooks/ventros", bytes.NewReader(body))
+	req.Header.Set(HeaderName, Sign(body, time.Now(), "whsec_test"))
+
+	got, err := VerifyRequest(req, "whsec_test", DefaultTolerance)
+
+	require.NoError(t, err)
+	assert.Equal(t, body, got)
+	again, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, body, again, "body is restored for the handler")
+}